@@ -0,0 +1,181 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+// Example job worker completing two-task order process end to end
+// Пример воркера заданий, выполняющего процесс заказа из двух задач от начала до конца
+//
+//	go run ./example/jobworker
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"atom-engine/proto/parser/parserpb"
+	"atom-engine/proto/process/processpb"
+	"atom-engine/src/client"
+)
+
+func main() {
+	address := flag.String("address", "localhost:27500", "Engine gRPC address")
+	apiKey := flag.String("api-key", "", "API key, not needed on localhost")
+	bpmnPath := flag.String("bpmn", "example/jobworker/order.bpmn", "Path to order.bpmn readable by engine")
+	flag.Parse()
+
+	// Engine reads file itself, so path must not depend on its working directory
+	// Движок читает файл сам, поэтому путь не должен зависеть от его рабочей директории
+	absPath, err := filepath.Abs(*bpmnPath)
+	if err != nil {
+		log.Fatalf("Invalid BPMN path: %v", err)
+	}
+
+	if err := run(*address, *apiKey, absPath); err != nil {
+		log.Printf("Example failed: %v", err)
+		os.Exit(1)
+	}
+}
+
+// run deploys order process, starts instance and handles its jobs until it completes
+// Развертывает процесс заказа, запускает экземпляр и обрабатывает его задания до завершения
+func run(address, apiKey, bpmnPath string) error {
+	options := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if apiKey != "" {
+		options = append(options, client.WithAPIKey(apiKey))
+	}
+
+	conn, err := grpc.NewClient(address, options...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	deployed, err := parserpb.NewParserServiceClient(conn).ParseBPMNFile(ctx, &parserpb.ParseBPMNFileRequest{
+		FilePath: bpmnPath,
+		Force:    true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to deploy process: %w", err)
+	}
+	if !deployed.Success {
+		return fmt.Errorf("failed to deploy process: %s", deployed.Message)
+	}
+	log.Printf("Deployed process %s", deployed.ProcessId)
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	for jobType, handler := range map[string]client.JobHandler{
+		"example-reserve-stock":  reserveStock,
+		"example-charge-payment": chargePayment,
+	} {
+		worker, err := client.NewJobWorker(conn, client.JobWorkerConfig{
+			JobType:      jobType,
+			WorkerName:   "example-worker",
+			Handler:      handler,
+			Concurrency:  2,
+			PollInterval: 200 * time.Millisecond,
+			JobTimeout:   30 * time.Second,
+			Metrics:      logMetrics{},
+		})
+		if err != nil {
+			stopWorkers()
+			return err
+		}
+
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			_ = worker.Run(workerCtx)
+		}()
+	}
+
+	// Workers finish in-flight jobs before returning
+	// Воркеры завершают выполняющиеся задания перед возвратом
+	defer func() {
+		stopWorkers()
+		workers.Wait()
+	}()
+
+	processClient := processpb.NewProcessServiceClient(conn)
+	started, err := processClient.StartProcessInstance(ctx, &processpb.StartProcessInstanceRequest{
+		ProcessId:     deployed.ProcessId,
+		VariablesJson: `{"orderId":"order-1","amount":42.5}`,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to start process instance: %w", err)
+	}
+	if !started.Success {
+		return fmt.Errorf("failed to start process instance: %s", started.Message)
+	}
+	log.Printf("Started instance %s", started.InstanceId)
+
+	for {
+		status, err := processClient.GetProcessInstanceStatus(ctx, &processpb.GetProcessInstanceStatusRequest{
+			InstanceId: started.InstanceId,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get instance status: %w", err)
+		}
+		if status.Status == "COMPLETED" {
+			log.Printf("Instance %s completed with variables %s", started.InstanceId, status.VariablesJson)
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("instance %s did not complete: %w", started.InstanceId, ctx.Err())
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// reserveStock handles first task
+// Обрабатывает первую задачу
+func reserveStock(ctx context.Context, job *client.Job) (map[string]interface{}, error) {
+	log.Printf("Reserving stock for %v", job.Variables["orderId"])
+	return map[string]interface{}{"reservationId": fmt.Sprintf("res-%s", job.Key)}, nil
+}
+
+// chargePayment handles second task
+// Обрабатывает вторую задачу
+func chargePayment(ctx context.Context, job *client.Job) (map[string]interface{}, error) {
+	amount, ok := job.Variables["amount"].(float64)
+	if !ok || amount <= 0 {
+		return nil, client.NewBPMNError("INVALID_AMOUNT", "order amount must be positive")
+	}
+
+	log.Printf("Charging %.2f for reservation %v", amount, job.Variables["reservationId"])
+	return map[string]interface{}{"paid": true}, nil
+}
+
+// logMetrics prints worker events, replace with Prometheus collectors in production
+// Выводит события воркера, в production замените на коллекторы Prometheus
+type logMetrics struct{}
+
+func (logMetrics) JobHandled(jobType string, latency time.Duration) {
+	log.Printf("Job %s handled in %s", jobType, latency)
+}
+
+func (logMetrics) JobFailed(jobType string, latency time.Duration, err error) {
+	log.Printf("Job %s failed in %s: %v", jobType, latency, err)
+}
+
+func (logMetrics) PollFailed(jobType string, err error) {
+	log.Printf("Polling %s failed: %v", jobType, err)
+}
@@ -0,0 +1,45 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+// Package client provides Go SDK on top of engine gRPC API
+// Пакет client предоставляет Go SDK поверх gRPC API движка
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// apiKeyCredentials sends API key as bearer token of every call
+// Передает API ключ как bearer токен в каждом вызове
+type apiKeyCredentials struct {
+	apiKey string
+}
+
+// WithAPIKey returns dial option authenticating calls with API key
+// Localhost calls are accepted without key
+// Возвращает опцию подключения, аутентифицирующую вызовы API ключом
+// Вызовы с localhost принимаются без ключа
+func WithAPIKey(apiKey string) grpc.DialOption {
+	return grpc.WithPerRPCCredentials(apiKeyCredentials{apiKey: apiKey})
+}
+
+// GetRequestMetadata implements credentials.PerRPCCredentials
+// Реализует credentials.PerRPCCredentials
+func (c apiKeyCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.apiKey}, nil
+}
+
+// RequireTransportSecurity implements credentials.PerRPCCredentials
+// Engine gRPC server listens without TLS
+// Реализует credentials.PerRPCCredentials
+// gRPC сервер движка работает без TLS
+func (c apiKeyCredentials) RequireTransportSecurity() bool {
+	return false
+}
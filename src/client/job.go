@@ -0,0 +1,78 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"atom-engine/proto/jobs/jobspb"
+)
+
+// Job is activated job passed to worker handler
+// Активированное задание, передаваемое обработчику воркера
+type Job struct {
+	Key               string
+	NumericKey        int64
+	Type              string
+	ProcessInstanceID string
+	BPMNProcessID     string
+	ElementID         string
+	CustomHeaders     map[string]string
+	Variables         map[string]interface{}
+	Retries           int
+	Deadline          time.Time
+	TenantID          string
+}
+
+// newJob converts activated job from gRPC response
+// Конвертирует активированное задание из gRPC ответа
+func newJob(activated *jobspb.ActivatedJob) (*Job, error) {
+	job := &Job{
+		Key:               activated.Id,
+		NumericKey:        activated.Key,
+		Type:              activated.Type,
+		ProcessInstanceID: activated.ProcessInstanceId,
+		BPMNProcessID:     activated.BpmnProcessId,
+		ElementID:         activated.ElementId,
+		CustomHeaders:     activated.CustomHeaders,
+		Variables:         make(map[string]interface{}),
+		Retries:           int(activated.Retries),
+		Deadline:          time.UnixMilli(activated.Deadline),
+		TenantID:          activated.TenantId,
+	}
+
+	if activated.Variables != "" {
+		if err := json.Unmarshal([]byte(activated.Variables), &job.Variables); err != nil {
+			return job, fmt.Errorf("failed to decode variables of job %s: %w", job.Key, err)
+		}
+	}
+
+	return job, nil
+}
+
+// BPMNError is returned by handler to throw BPMN error instead of failing job
+// Возвращается обработчиком для выброса BPMN ошибки вместо провала задания
+type BPMNError struct {
+	Code    string
+	Message string
+}
+
+// Error implements error interface
+// Реализует интерфейс error
+func (e *BPMNError) Error() string {
+	return fmt.Sprintf("BPMN error %s: %s", e.Code, e.Message)
+}
+
+// NewBPMNError creates BPMN error caught by error boundary events
+// Создает BPMN ошибку, перехватываемую граничными событиями ошибки
+func NewBPMNError(code, message string) *BPMNError {
+	return &BPMNError{Code: code, Message: message}
+}
@@ -0,0 +1,374 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"atom-engine/proto/jobs/jobspb"
+)
+
+// Job worker defaults
+// Значения по умолчанию воркера заданий
+const (
+	DefaultWorkerName        = "atom-go-worker"
+	DefaultWorkerConcurrency = 4
+	DefaultPollInterval      = time.Second
+	DefaultRequestTimeout    = 10 * time.Second
+	DefaultJobTimeout        = 5 * time.Minute
+
+	// minRenewInterval limits timeout extension rate near deadline and after failed extension
+	// minRenewInterval ограничивает частоту продления таймаута у дедлайна и после ошибки продления
+	minRenewInterval = time.Second
+)
+
+// JobHandler handles activated job and returns variables to complete it with
+// Returned *BPMNError throws BPMN error, any other error fails job
+// Обрабатывает активированное задание и возвращает переменные для его завершения
+// Возвращенная *BPMNError выбрасывает BPMN ошибку, любая другая ошибка проваливает задание
+type JobHandler func(ctx context.Context, job *Job) (map[string]interface{}, error)
+
+// JobWorkerConfig holds job worker configuration
+// Конфигурация воркера заданий
+type JobWorkerConfig struct {
+	JobType           string
+	WorkerName        string
+	Handler           JobHandler
+	Concurrency       int           // Handlers running in parallel
+	MaxJobsToActivate int           // Jobs per activation request, defaults to Concurrency
+	PollInterval      time.Duration // Pause after empty or failed activation
+	RequestTimeout    time.Duration // Deadline of activation long-poll and job update calls
+	JobTimeout        time.Duration // Job lease, extended at 2/3 while handler runs
+	Metrics           WorkerMetrics
+}
+
+// JobWorker polls jobs of one type and runs handler for each with bounded concurrency
+// Опрашивает задания одного типа и запускает обработчик для каждого с ограниченным параллелизмом
+type JobWorker struct {
+	client   jobspb.JobsServiceClient
+	config   JobWorkerConfig
+	slots    chan struct{}
+	handlers sync.WaitGroup
+}
+
+// NewJobWorker creates job worker over gRPC connection to engine
+// Создает воркер заданий поверх gRPC подключения к движку
+func NewJobWorker(conn grpc.ClientConnInterface, config JobWorkerConfig) (*JobWorker, error) {
+	if config.JobType == "" {
+		return nil, fmt.Errorf("job type is required")
+	}
+	if config.Handler == nil {
+		return nil, fmt.Errorf("job handler is required")
+	}
+
+	if config.WorkerName == "" {
+		config.WorkerName = DefaultWorkerName
+	}
+	if config.Concurrency <= 0 {
+		config.Concurrency = DefaultWorkerConcurrency
+	}
+	if config.MaxJobsToActivate <= 0 || config.MaxJobsToActivate > config.Concurrency {
+		config.MaxJobsToActivate = config.Concurrency
+	}
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultPollInterval
+	}
+	if config.RequestTimeout <= 0 {
+		config.RequestTimeout = DefaultRequestTimeout
+	}
+	if config.JobTimeout <= 0 {
+		config.JobTimeout = DefaultJobTimeout
+	}
+	if config.Metrics == nil {
+		config.Metrics = noopWorkerMetrics{}
+	}
+
+	return &JobWorker{
+		client: jobspb.NewJobsServiceClient(conn),
+		config: config,
+		slots:  make(chan struct{}, config.Concurrency),
+	}, nil
+}
+
+// Run polls and handles jobs until ctx is cancelled
+// In-flight handlers are finished before Run returns ctx error
+// Опрашивает и обрабатывает задания до отмены ctx
+// Выполняющиеся обработчики завершаются до возврата ошибки ctx из Run
+func (w *JobWorker) Run(ctx context.Context) error {
+	defer w.handlers.Wait()
+
+	for {
+		// Wait for free handler slot, then take as many free slots as one request may use
+		// Ждем свободный слот обработчика, затем занимаем столько свободных слотов, сколько использует запрос
+		select {
+		case w.slots <- struct{}{}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		capacity := 1 + w.tryAcquire(w.config.MaxJobsToActivate-1)
+
+		jobs, err := w.activate(ctx, capacity)
+		if err != nil && ctx.Err() == nil {
+			w.config.Metrics.PollFailed(w.config.JobType, err)
+		}
+
+		for i := len(jobs); i < capacity; i++ {
+			<-w.slots
+		}
+		for i, activated := range jobs {
+			if i >= capacity {
+				w.slots <- struct{}{}
+			}
+			w.handlers.Add(1)
+			go w.handle(activated)
+		}
+
+		if len(jobs) == 0 {
+			select {
+			case <-time.After(w.config.PollInterval):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// tryAcquire takes up to n free handler slots without waiting
+// Занимает до n свободных слотов обработчиков без ожидания
+func (w *JobWorker) tryAcquire(n int) int {
+	acquired := 0
+	for acquired < n {
+		select {
+		case w.slots <- struct{}{}:
+			acquired++
+		default:
+			return acquired
+		}
+	}
+	return acquired
+}
+
+// activate requests up to maxJobs jobs and reads activation stream
+// Запрашивает до maxJobs заданий и читает поток активации
+func (w *JobWorker) activate(ctx context.Context, maxJobs int) ([]*jobspb.ActivatedJob, error) {
+	requestCtx, cancel := context.WithTimeout(ctx, w.config.RequestTimeout)
+	defer cancel()
+
+	stream, err := w.client.ActivateJobs(requestCtx, &jobspb.ActivateJobsRequest{
+		Type:              w.config.JobType,
+		Worker:            w.config.WorkerName,
+		Timeout:           int32(w.config.JobTimeout.Milliseconds()),
+		MaxJobsToActivate: int32(maxJobs),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to activate jobs: %w", err)
+	}
+
+	var jobs []*jobspb.ActivatedJob
+	for {
+		response, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return jobs, nil
+		}
+		if err != nil {
+			return jobs, fmt.Errorf("failed to receive activated jobs: %w", err)
+		}
+		jobs = append(jobs, response.Jobs...)
+	}
+}
+
+// handle runs handler for activated job and reports result to engine
+// Handler context is not bound to Run context, so shutdown lets handler finish
+// Запускает обработчик активированного задания и сообщает результат движку
+// Контекст обработчика не связан с контекстом Run, поэтому остановка дает обработчику завершиться
+func (w *JobWorker) handle(activated *jobspb.ActivatedJob) {
+	defer w.handlers.Done()
+	defer func() { <-w.slots }()
+
+	started := time.Now()
+	job, err := newJob(activated)
+	if err != nil {
+		w.reportFailure(job, started, err, w.fail(job, err))
+		return
+	}
+
+	handlerCtx, cancel := context.WithCancel(context.Background())
+	go w.keepAlive(handlerCtx, job)
+	variables, err := w.invoke(handlerCtx, job)
+	cancel()
+
+	var bpmnErr *BPMNError
+	switch {
+	case errors.As(err, &bpmnErr):
+		if reportErr := w.throwError(job, bpmnErr); reportErr != nil {
+			w.config.Metrics.JobFailed(job.Type, time.Since(started), reportErr)
+			return
+		}
+		w.config.Metrics.JobHandled(job.Type, time.Since(started))
+	case err != nil:
+		w.reportFailure(job, started, err, w.fail(job, err))
+	default:
+		if reportErr := w.complete(job, variables); reportErr != nil {
+			w.config.Metrics.JobFailed(job.Type, time.Since(started), reportErr)
+			return
+		}
+		w.config.Metrics.JobHandled(job.Type, time.Since(started))
+	}
+}
+
+// invoke calls handler and converts panic into error with stack trace
+// Вызывает обработчик и превращает панику в ошибку со стеком вызовов
+func (w *JobWorker) invoke(ctx context.Context, job *Job) (variables map[string]interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job handler panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+
+	return w.config.Handler(ctx, job)
+}
+
+// keepAlive extends job timeout at 2/3 of remaining lease until ctx is cancelled
+// Продлевает таймаут задания на 2/3 оставшейся аренды до отмены ctx
+func (w *JobWorker) keepAlive(ctx context.Context, job *Job) {
+	deadline := job.Deadline
+	for {
+		wait := time.Until(deadline) * 2 / 3
+		if wait < minRenewInterval {
+			wait = minRenewInterval
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		if err := w.updateTimeout(ctx, job); err != nil {
+			if ctx.Err() == nil {
+				w.config.Metrics.PollFailed(job.Type, err)
+			}
+			continue
+		}
+		deadline = time.Now().Add(w.config.JobTimeout)
+	}
+}
+
+// updateTimeout sets job lease to configured job timeout from now
+// Устанавливает аренду задания на настроенный таймаут от текущего момента
+func (w *JobWorker) updateTimeout(ctx context.Context, job *Job) error {
+	requestCtx, cancel := context.WithTimeout(ctx, w.config.RequestTimeout)
+	defer cancel()
+
+	response, err := w.client.UpdateJobTimeout(requestCtx, &jobspb.UpdateJobTimeoutRequest{
+		JobKey:  job.Key,
+		Timeout: w.config.JobTimeout.Milliseconds(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update timeout of job %s: %w", job.Key, err)
+	}
+	if !response.Success {
+		return fmt.Errorf("failed to update timeout of job %s: %s", job.Key, response.ErrorMessage)
+	}
+	return nil
+}
+
+// complete completes job with handler variables
+// Завершает задание с переменными обработчика
+func (w *JobWorker) complete(job *Job, variables map[string]interface{}) error {
+	var variablesJSON string
+	if len(variables) > 0 {
+		data, err := json.Marshal(variables)
+		if err != nil {
+			err = fmt.Errorf("failed to encode variables of job %s: %w", job.Key, err)
+			return errors.Join(err, w.fail(job, err))
+		}
+		variablesJSON = string(data)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.config.RequestTimeout)
+	defer cancel()
+
+	response, err := w.client.CompleteJob(ctx, &jobspb.CompleteJobRequest{
+		JobKey:    job.Key,
+		Variables: variablesJSON,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete job %s: %w", job.Key, err)
+	}
+	if !response.Success {
+		return fmt.Errorf("failed to complete job %s: %s", job.Key, response.ErrorMessage)
+	}
+	return nil
+}
+
+// throwError throws BPMN error returned by handler
+// Выбрасывает BPMN ошибку, возвращенную обработчиком
+func (w *JobWorker) throwError(job *Job, bpmnErr *BPMNError) error {
+	ctx, cancel := context.WithTimeout(context.Background(), w.config.RequestTimeout)
+	defer cancel()
+
+	response, err := w.client.ThrowError(ctx, &jobspb.ThrowErrorRequest{
+		JobKey:       job.Key,
+		ErrorCode:    bpmnErr.Code,
+		ErrorMessage: bpmnErr.Message,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to throw error for job %s: %w", job.Key, err)
+	}
+	if !response.Success {
+		return fmt.Errorf("failed to throw error for job %s: %s", job.Key, response.ErrorMessage)
+	}
+	return nil
+}
+
+// fail fails job with one retry less, engine applies retry backoff of task
+// Проваливает задание с уменьшением повторов на один, движок применяет задержку повтора задачи
+func (w *JobWorker) fail(job *Job, cause error) error {
+	retries := job.Retries - 1
+	if retries < 0 {
+		retries = 0
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.config.RequestTimeout)
+	defer cancel()
+
+	response, err := w.client.FailJob(ctx, &jobspb.FailJobRequest{
+		JobKey:       job.Key,
+		Retries:      int32(retries),
+		ErrorMessage: cause.Error(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fail job %s: %w", job.Key, err)
+	}
+	if !response.Success {
+		return fmt.Errorf("failed to fail job %s: %s", job.Key, response.ErrorMessage)
+	}
+	return nil
+}
+
+// reportFailure passes handler error and failed report, if any, to metrics
+// Передает в метрики ошибку обработчика и ошибку отчета, если она есть
+func (w *JobWorker) reportFailure(job *Job, started time.Time, cause, reportErr error) {
+	if reportErr != nil {
+		cause = errors.Join(cause, reportErr)
+	}
+	w.config.Metrics.JobFailed(job.Type, time.Since(started), cause)
+}
@@ -0,0 +1,37 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package client
+
+import "time"
+
+// WorkerMetrics receives job worker events, implement it to export Prometheus metrics
+// Calls come from handler goroutines concurrently
+// Получает события воркера заданий, реализуйте для экспорта метрик Prometheus
+// Вызовы приходят конкурентно из горутин обработчиков
+type WorkerMetrics interface {
+	// JobHandled is called after job is completed or BPMN error is thrown
+	// Вызывается после завершения задания или выброса BPMN ошибки
+	JobHandled(jobType string, latency time.Duration)
+
+	// JobFailed is called after handler error, panic or failed completion
+	// Вызывается после ошибки обработчика, паники или неудачного завершения
+	JobFailed(jobType string, latency time.Duration, err error)
+
+	// PollFailed is called when job activation or timeout extension fails
+	// Вызывается при ошибке активации заданий или продления таймаута
+	PollFailed(jobType string, err error)
+}
+
+// noopWorkerMetrics discards worker events
+// Отбрасывает события воркера
+type noopWorkerMetrics struct{}
+
+func (noopWorkerMetrics) JobHandled(string, time.Duration)       {}
+func (noopWorkerMetrics) JobFailed(string, time.Duration, error) {}
+func (noopWorkerMetrics) PollFailed(string, error)               {}
@@ -0,0 +1,90 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"atom-engine/src/core/models"
+	"atom-engine/src/storage"
+)
+
+// FormatVersion is current process archive format version
+// Текущая версия формата архива процесса
+const FormatVersion = 1
+
+// ErrImportDisabled is returned when archive import is not enabled in config
+// Возвращается когда импорт архивов не включен в конфигурации
+var ErrImportDisabled = errors.New("process archive import is disabled")
+
+// ProcessArchive is portable snapshot of single process instance state
+// Переносимый снимок состояния одного экземпляра процесса
+type ProcessArchive struct {
+	FormatVersion int       `json:"format_version"`
+	ExportedAt    time.Time `json:"exported_at"`
+	EngineVersion string    `json:"engine_version,omitempty"`
+	SourceNode    string    `json:"source_node,omitempty"`
+
+	// RedactedKeys lists variable keys whose values were replaced
+	// Список ключей переменных, значения которых были заменены
+	RedactedKeys []string `json:"redacted_keys,omitempty"`
+
+	Instance            *models.ProcessInstance            `json:"instance"`
+	Definition          *Definition                        `json:"definition"`
+	Tokens              []*models.Token                    `json:"tokens"`
+	Jobs                []*models.Job                      `json:"jobs"`
+	Timers              []*storage.TimerRecord             `json:"timers"`
+	BufferedMessages    []*models.BufferedMessage          `json:"buffered_messages"`
+	MessageCorrelations []*models.MessageCorrelationResult `json:"message_correlations"`
+	Incidents           []map[string]interface{}           `json:"incidents"`
+	History             []*storage.SystemEventRecord       `json:"history"`
+}
+
+// Definition holds process definition version the instance runs on
+// Содержит версию определения процесса, на которой выполняется экземпляр
+type Definition struct {
+	ProcessKey     string          `json:"process_key"`
+	ProcessID      string          `json:"process_id"`
+	ProcessName    string          `json:"process_name,omitempty"`
+	ProcessVersion int             `json:"process_version"`
+	BPMNXML        string          `json:"bpmn_xml,omitempty"`
+	Parsed         json.RawMessage `json:"parsed"`
+}
+
+// ImportResult describes outcome of archive import
+// Описывает результат импорта архива
+type ImportResult struct {
+	InstanceID         string            `json:"instance_id"`
+	OriginalInstanceID string            `json:"original_instance_id"`
+	ProcessKey         string            `json:"process_key"`
+	DefinitionImported bool              `json:"definition_imported"`
+	IDMapping          map[string]string `json:"id_mapping"`
+	Counts             map[string]int    `json:"counts"`
+}
+
+// Validate checks archive has data required for import
+// Проверяет наличие в архиве данных, необходимых для импорта
+func (a *ProcessArchive) Validate() error {
+	if a.FormatVersion != FormatVersion {
+		return fmt.Errorf("unsupported archive format version: %d", a.FormatVersion)
+	}
+	if a.Instance == nil || a.Instance.InstanceID == "" {
+		return fmt.Errorf("archive has no process instance")
+	}
+	if a.Definition == nil || a.Definition.ProcessKey == "" {
+		return fmt.Errorf("archive has no process definition")
+	}
+	if len(a.Definition.Parsed) == 0 {
+		return fmt.Errorf("archive definition has no parsed data")
+	}
+	return nil
+}
@@ -0,0 +1,253 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+	"atom-engine/src/storage"
+)
+
+// XMLLoader loads original BPMN XML by process key
+// Загружает оригинальный BPMN XML по ключу процесса
+type XMLLoader func(processKey string) ([]byte, error)
+
+// Exporter collects process instance state into archive
+// Собирает состояние экземпляра процесса в архив
+type Exporter struct {
+	storage   storage.Storage
+	xmlLoader XMLLoader
+	redactor  *Redactor
+}
+
+// NewExporter creates new process archive exporter
+// Nil XML loader exports definition without BPMN XML
+// Создает новый экспортер архивов процессов
+func NewExporter(s storage.Storage, xmlLoader XMLLoader, redactor *Redactor) *Exporter {
+	return &Exporter{
+		storage:   s,
+		xmlLoader: xmlLoader,
+		redactor:  redactor,
+	}
+}
+
+// Export builds archive for process instance
+// Строит архив для экземпляра процесса
+func (e *Exporter) Export(instanceID string) (*ProcessArchive, error) {
+	instance, err := e.storage.LoadProcessInstance(instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load process instance: %w", err)
+	}
+	// Instance may be requested by numeric key
+	// Экземпляр может быть запрошен по числовому ключу
+	instanceID = instance.InstanceID
+
+	archive := &ProcessArchive{
+		FormatVersion: FormatVersion,
+		ExportedAt:    time.Now(),
+		Instance:      instance,
+	}
+
+	archive.Definition, err = e.exportDefinition(instance.ProcessKey)
+	if err != nil {
+		return nil, err
+	}
+
+	archive.Tokens, err = e.storage.LoadTokensByProcessInstance(instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokens: %w", err)
+	}
+
+	archive.Jobs, err = e.exportJobs(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	archive.Timers, err = e.exportTimers(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	archive.MessageCorrelations, err = e.exportCorrelations(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	archive.BufferedMessages, err = e.exportBufferedMessages(archive.Tokens, archive.MessageCorrelations)
+	if err != nil {
+		return nil, err
+	}
+
+	archive.Incidents, err = e.exportIncidents(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	history, _, err := e.storage.QuerySystemEvents(&storage.SystemEventFilter{InstanceID: instanceID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load instance history: %w", err)
+	}
+	archive.History = history
+
+	e.resolveAndRedact(archive)
+
+	return archive, nil
+}
+
+// exportDefinition loads parsed definition and original XML
+// Загружает распарсенное определение и оригинальный XML
+func (e *Exporter) exportDefinition(processKey string) (*Definition, error) {
+	// Process key may be BPMN ID or storage key, same as in parser
+	// Ключ процесса может быть BPMN ID или ключом storage, как и в парсере
+	data, err := e.storage.LoadBPMNProcessByBPMNID(processKey)
+	if err != nil {
+		data, err = e.storage.LoadBPMNProcess(processKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load process definition: %w", err)
+		}
+	}
+
+	var process models.BPMNProcess
+	if err := json.Unmarshal(data, &process); err != nil {
+		return nil, fmt.Errorf("failed to decode process definition: %w", err)
+	}
+
+	definition := &Definition{
+		ProcessKey:     processKey,
+		ProcessID:      process.ProcessID,
+		ProcessName:    process.ProcessName,
+		ProcessVersion: process.ProcessVersion,
+		Parsed:         json.RawMessage(data),
+	}
+
+	if e.xmlLoader != nil {
+		xml, err := e.xmlLoader(processKey)
+		if err != nil {
+			// Parsed definition is enough to restore state, keep exporting
+			// Распарсенного определения достаточно для восстановления, продолжаем
+			logger.Warn("BPMN XML not available for archive",
+				logger.String("process_key", processKey),
+				logger.String("error", err.Error()))
+		} else {
+			definition.BPMNXML = string(xml)
+		}
+	}
+
+	return definition, nil
+}
+
+// exportJobs returns all jobs created by process instance
+// Возвращает все задания, созданные экземпляром процесса
+func (e *Exporter) exportJobs(instanceID string) ([]*models.Job, error) {
+	jobs, err := e.storage.ListJobsByProcessInstance(context.Background(), instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load jobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// exportTimers returns all timers of process instance
+// Возвращает все таймеры экземпляра процесса
+func (e *Exporter) exportTimers(instanceID string) ([]*storage.TimerRecord, error) {
+	timers, err := e.storage.LoadTimersByProcessInstance(instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timers: %w", err)
+	}
+	return timers, nil
+}
+
+// exportCorrelations returns messages correlated to process instance
+// Возвращает сообщения, скоррелированные с экземпляром процесса
+func (e *Exporter) exportCorrelations(instanceID string) ([]*models.MessageCorrelationResult, error) {
+	results, err := e.storage.ListMessageCorrelationResultsByInstance(context.Background(), instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load message correlations: %w", err)
+	}
+	return results, nil
+}
+
+// exportBufferedMessages returns buffered messages instance waits for or received
+// Возвращает буферизованные сообщения, которые экземпляр ожидает или получал
+func (e *Exporter) exportBufferedMessages(
+	tokens []*models.Token,
+	correlations []*models.MessageCorrelationResult,
+) ([]*models.BufferedMessage, error) {
+	names := make(map[string]bool)
+	for _, token := range tokens {
+		if name, ok := strings.CutPrefix(token.WaitingFor, "message:"); ok && token.IsWaiting() {
+			names[name] = true
+		}
+	}
+	for _, result := range correlations {
+		names[result.MessageName] = true
+	}
+
+	messages := make([]*models.BufferedMessage, 0)
+	for _, name := range slices.Sorted(maps.Keys(names)) {
+		named, err := e.storage.ListBufferedMessagesByName(context.Background(), name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load buffered messages: %w", err)
+		}
+		messages = append(messages, named...)
+	}
+	return messages, nil
+}
+
+// exportIncidents returns incidents of process instance
+// Возвращает инциденты экземпляра процесса
+func (e *Exporter) exportIncidents(instanceID string) ([]map[string]interface{}, error) {
+	result, _, err := e.storage.ListIncidents(map[string]interface{}{
+		"process_instance_id": instanceID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load incidents: %w", err)
+	}
+
+	incidents, _ := result.([]map[string]interface{})
+	if incidents == nil {
+		incidents = make([]map[string]interface{}, 0)
+	}
+	return incidents, nil
+}
+
+// resolveAndRedact inlines offloaded blobs and redacts sensitive values
+// Встраивает вынесенные blob'ы и редактирует чувствительные значения
+func (e *Exporter) resolveAndRedact(archive *ProcessArchive) {
+	prepare := func(variables map[string]interface{}) map[string]interface{} {
+		resolved := storage.ResolveVariableBlobs(e.storage, variables)
+		return e.redactor.RedactVariables(resolved)
+	}
+
+	archive.Instance.Variables = prepare(archive.Instance.Variables)
+	for _, token := range archive.Tokens {
+		token.Variables = prepare(token.Variables)
+	}
+	for _, job := range archive.Jobs {
+		job.Variables = prepare(job.Variables)
+	}
+	for _, timer := range archive.Timers {
+		timer.Variables = prepare(timer.Variables)
+	}
+	for _, msg := range archive.BufferedMessages {
+		msg.Variables = prepare(msg.Variables)
+	}
+	for _, result := range archive.MessageCorrelations {
+		result.Variables = prepare(result.Variables)
+	}
+
+	archive.RedactedKeys = e.redactor.RedactedKeys()
+}
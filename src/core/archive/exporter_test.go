@@ -0,0 +1,122 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package archive
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"atom-engine/src/core/models"
+	"atom-engine/src/storage"
+)
+
+// errFullScan is returned by storage listings that read records of all instances
+// Возвращается перечислениями storage, читающими записи всех экземпляров
+var errFullScan = errors.New("full scan not allowed in export")
+
+// noFullScanStorage fails listings that load records of all instances
+// Отклоняет перечисления, загружающие записи всех экземпляров
+type noFullScanStorage struct {
+	storage.Storage
+}
+
+func (s *noFullScanStorage) ListJobsByType(
+	ctx context.Context, jobType string, status models.JobStatus, limit int,
+) ([]*models.Job, error) {
+	return nil, errFullScan
+}
+
+func (s *noFullScanStorage) LoadAllTimers() ([]*storage.TimerRecord, error) {
+	return nil, errFullScan
+}
+
+func (s *noFullScanStorage) ListMessageCorrelationResults(
+	ctx context.Context, tenantID, messageName, processKey string, limit, offset int,
+) ([]*models.MessageCorrelationResult, error) {
+	return nil, errFullScan
+}
+
+func (s *noFullScanStorage) ListBufferedMessages(
+	ctx context.Context, tenantID string, limit, offset int,
+) ([]*models.BufferedMessage, error) {
+	return nil, errFullScan
+}
+
+// newExportStorage starts Badger storage in temporary directory
+// Запускает Badger storage во временной директории
+func newExportStorage(t *testing.T) storage.Storage {
+	t.Helper()
+
+	st := storage.NewStorage(&storage.Config{Path: t.TempDir()})
+	if err := st.Init(); err != nil {
+		t.Fatalf("init storage: %v", err)
+	}
+	if err := st.Start(); err != nil {
+		t.Fatalf("start storage: %v", err)
+	}
+	t.Cleanup(func() { st.Stop() })
+	return st
+}
+
+func TestExporterLoadsInstanceRecordsWithInstanceScopedQueries(t *testing.T) {
+	ctx := context.Background()
+	st := newExportStorage(t)
+
+	for _, instanceID := range []string{"instance-1", "instance-2"} {
+		if err := st.SaveJob(ctx, models.NewJob("work", instanceID, "task")); err != nil {
+			t.Fatalf("save job: %v", err)
+		}
+		if err := st.SaveTimer(&storage.TimerRecord{ID: "timer-" + instanceID, ProcessInstanceID: instanceID,
+			ScheduledAt: time.Now().Add(time.Hour), State: "SCHEDULED"}); err != nil {
+			t.Fatalf("save timer: %v", err)
+		}
+		result := models.NewMessageCorrelationResult("message-1", "", "paid-"+instanceID, "key-1")
+		result.ProcessInstanceID = instanceID
+		if err := st.SaveMessageCorrelationResult(ctx, result); err != nil {
+			t.Fatalf("save correlation result: %v", err)
+		}
+	}
+	for _, name := range []string{"paid-instance-1", "shipped", "other"} {
+		message := models.NewBufferedMessage("", name, "key-1", nil, "no subscription", "")
+		if err := st.SaveBufferedMessage(ctx, message); err != nil {
+			t.Fatalf("save buffered message: %v", err)
+		}
+	}
+
+	exporter := NewExporter(&noFullScanStorage{Storage: st}, nil, nil)
+
+	jobs, err := exporter.exportJobs("instance-1")
+	if err != nil || len(jobs) != 1 || jobs[0].ProcessInstanceID != "instance-1" {
+		t.Fatalf("exported jobs %+v, err %v", jobs, err)
+	}
+	timers, err := exporter.exportTimers("instance-1")
+	if err != nil || len(timers) != 1 || timers[0].ID != "timer-instance-1" {
+		t.Fatalf("exported timers %+v, err %v", timers, err)
+	}
+	correlations, err := exporter.exportCorrelations("instance-1")
+	if err != nil || len(correlations) != 1 || correlations[0].MessageName != "paid-instance-1" {
+		t.Fatalf("exported correlations %+v, err %v", correlations, err)
+	}
+
+	waiting := models.NewToken("instance-1", "process-1", "wait")
+	waiting.SetWaitingFor("message:shipped")
+	messages, err := exporter.exportBufferedMessages([]*models.Token{waiting}, correlations)
+	if err != nil {
+		t.Fatalf("export buffered messages: %v", err)
+	}
+	names := make(map[string]bool)
+	for _, message := range messages {
+		names[message.Name] = true
+	}
+	if len(messages) != 2 || !names["paid-instance-1"] || !names["shipped"] {
+		t.Fatalf("exported buffered messages %+v, want paid-instance-1 and shipped", messages)
+	}
+}
@@ -0,0 +1,279 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package archive
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+	"atom-engine/src/storage"
+)
+
+// DefinitionImporter stores archived definition if it is missing
+// Returns true when definition was stored
+// Сохраняет определение из архива, если оно отсутствует
+type DefinitionImporter func(processKey string, parsed []byte, bpmnXML []byte) (bool, error)
+
+// Importer loads process archive into storage with new IDs
+// Загружает архив процесса в storage с новыми ID
+type Importer struct {
+	storage            storage.Storage
+	definitionImporter DefinitionImporter
+}
+
+// NewImporter creates new process archive importer
+// Создает новый импортер архивов процессов
+func NewImporter(s storage.Storage, definitionImporter DefinitionImporter) *Importer {
+	return &Importer{
+		storage:            s,
+		definitionImporter: definitionImporter,
+	}
+}
+
+// Import stores archive content remapping all entity IDs
+// Сохраняет содержимое архива, переназначая все ID сущностей
+func (i *Importer) Import(archive *ProcessArchive) (*ImportResult, error) {
+	if err := archive.Validate(); err != nil {
+		return nil, err
+	}
+
+	definitionImported, err := i.definitionImporter(
+		archive.Definition.ProcessKey,
+		archive.Definition.Parsed,
+		[]byte(archive.Definition.BPMNXML),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import process definition: %w", err)
+	}
+
+	ids := newIDMapper(archive)
+	ctx := context.Background()
+	now := time.Now()
+
+	instance := archive.Instance
+	originalInstanceID := instance.InstanceID
+	instance.InstanceID = ids.remap(instance.InstanceID)
+	instance.Key = models.GenerateKey()
+	instance.Metadata = ids.remapMap(instance.Metadata)
+	if instance.Metadata == nil {
+		instance.Metadata = make(map[string]interface{})
+	}
+	instance.Metadata["imported_from"] = originalInstanceID
+	instance.Metadata["imported_at"] = now.Format(time.RFC3339)
+	if err := i.storage.SaveProcessInstance(instance); err != nil {
+		return nil, fmt.Errorf("failed to save process instance: %w", err)
+	}
+
+	// Numeric keys are regenerated, old element instance keys are mapped for jobs
+	// Числовые ключи генерируются заново, старые ключи элементов сопоставляются для job'ов
+	elementInstanceKeys := make(map[int64]int64, len(archive.Tokens))
+	for _, token := range archive.Tokens {
+		token.TokenID = ids.remap(token.TokenID)
+		newKey := models.GenerateKey()
+		if token.Key > 0 {
+			elementInstanceKeys[token.Key] = newKey
+		}
+		token.Key = newKey
+		token.ProcessInstanceID = instance.InstanceID
+		token.ParentTokenID = ids.remap(token.ParentTokenID)
+		token.WaitingFor = ids.remap(token.WaitingFor)
+		token.ChildTokenIDs = ids.remapSlice(token.ChildTokenIDs)
+		token.BoundaryTimerIDs = ids.remapSlice(token.BoundaryTimerIDs)
+		token.ExecutionContext = ids.remapMap(token.ExecutionContext)
+		if err := i.storage.SaveToken(token); err != nil {
+			return nil, fmt.Errorf("failed to save token: %w", err)
+		}
+	}
+
+	for _, job := range archive.Jobs {
+		job.ID = ids.remap(job.ID)
+		job.Key = models.GenerateKey()
+		job.ProcessInstanceID = instance.InstanceID
+		job.ProcessInstanceKey = instance.Key
+		job.ElementInstanceKey = elementInstanceKeys[job.ElementInstanceKey]
+		job.ElementInstanceID = ids.remap(job.ElementInstanceID)
+		job.TokenID = ids.remap(job.TokenID)
+		for key, value := range job.Metadata {
+			job.Metadata[key] = ids.remap(value)
+		}
+		if err := i.storage.SaveJob(ctx, job); err != nil {
+			return nil, fmt.Errorf("failed to save job: %w", err)
+		}
+	}
+
+	for _, timer := range archive.Timers {
+		timer.ID = ids.remap(timer.ID)
+		timer.ProcessInstanceID = instance.InstanceID
+		timer.TokenID = ids.remap(timer.TokenID)
+		timer.ProcessContext = ids.remapMap(timer.ProcessContext)
+		if err := i.storage.SaveTimer(timer); err != nil {
+			return nil, fmt.Errorf("failed to save timer: %w", err)
+		}
+	}
+
+	for _, result := range archive.MessageCorrelations {
+		result.ID = ids.remap(result.ID)
+		result.MessageID = ids.remap(result.MessageID)
+		result.ProcessInstanceID = instance.InstanceID
+		if err := i.storage.SaveMessageCorrelationResult(ctx, result); err != nil {
+			return nil, fmt.Errorf("failed to save message correlation: %w", err)
+		}
+	}
+
+	for _, msg := range archive.BufferedMessages {
+		msg.ID = ids.remap(msg.ID)
+		if err := i.storage.SaveBufferedMessage(ctx, msg); err != nil {
+			return nil, fmt.Errorf("failed to save buffered message: %w", err)
+		}
+	}
+
+	for _, incident := range archive.Incidents {
+		remapped := ids.remapMap(incident)
+		remapped["process_instance_id"] = instance.InstanceID
+		if err := i.storage.SaveIncident(remapped); err != nil {
+			return nil, fmt.Errorf("failed to save incident: %w", err)
+		}
+	}
+
+	logger.Info("Process archive imported",
+		logger.String("original_instance_id", originalInstanceID),
+		logger.String("instance_id", instance.InstanceID),
+		logger.String("process_key", archive.Definition.ProcessKey),
+		logger.Bool("definition_imported", definitionImported))
+
+	return &ImportResult{
+		InstanceID:         instance.InstanceID,
+		OriginalInstanceID: originalInstanceID,
+		ProcessKey:         archive.Definition.ProcessKey,
+		DefinitionImported: definitionImported,
+		IDMapping:          ids.mapping,
+		Counts: map[string]int{
+			"tokens":               len(archive.Tokens),
+			"jobs":                 len(archive.Jobs),
+			"timers":               len(archive.Timers),
+			"message_correlations": len(archive.MessageCorrelations),
+			"buffered_messages":    len(archive.BufferedMessages),
+			"incidents":            len(archive.Incidents),
+		},
+	}, nil
+}
+
+// idMapper maps archived entity IDs to newly generated ones
+// Сопоставляет ID сущностей из архива с новыми сгенерированными
+type idMapper struct {
+	mapping map[string]string
+}
+
+// newIDMapper generates new IDs for every entity in archive
+// Генерирует новые ID для каждой сущности архива
+func newIDMapper(archive *ProcessArchive) *idMapper {
+	m := &idMapper{mapping: make(map[string]string)}
+
+	m.add(archive.Instance.InstanceID)
+	for _, token := range archive.Tokens {
+		m.add(token.TokenID)
+	}
+	for _, job := range archive.Jobs {
+		m.add(job.ID)
+	}
+	for _, timer := range archive.Timers {
+		m.add(timer.ID)
+	}
+	for _, result := range archive.MessageCorrelations {
+		m.add(result.ID)
+		m.add(result.MessageID)
+	}
+	for _, msg := range archive.BufferedMessages {
+		m.add(msg.ID)
+	}
+	for _, incident := range archive.Incidents {
+		if id, ok := incident["id"].(string); ok {
+			m.add(id)
+		}
+	}
+
+	return m
+}
+
+// add registers ID for remapping
+// Регистрирует ID для переназначения
+func (m *idMapper) add(id string) {
+	if id == "" {
+		return
+	}
+	if _, exists := m.mapping[id]; !exists {
+		m.mapping[id] = models.GenerateID()
+	}
+}
+
+// remap returns new ID for known ID, also handles "kind:id" references
+// Возвращает новый ID для известного ID, также обрабатывает ссылки "kind:id"
+func (m *idMapper) remap(value string) string {
+	if value == "" {
+		return value
+	}
+	if newID, ok := m.mapping[value]; ok {
+		return newID
+	}
+	if idx := strings.LastIndex(value, ":"); idx >= 0 {
+		if newID, ok := m.mapping[value[idx+1:]]; ok {
+			return value[:idx+1] + newID
+		}
+	}
+	return value
+}
+
+// remapSlice remaps every ID in slice
+// Переназначает каждый ID в срезе
+func (m *idMapper) remapSlice(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	result := make([]string, len(values))
+	for i, value := range values {
+		result[i] = m.remap(value)
+	}
+	return result
+}
+
+// remapMap remaps string values of map recursively
+// Рекурсивно переназначает строковые значения карты
+func (m *idMapper) remapMap(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return nil
+	}
+	result := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		result[key] = m.remapValue(value)
+	}
+	return result
+}
+
+// remapValue remaps nested string values
+// Переназначает вложенные строковые значения
+func (m *idMapper) remapValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return m.remap(v)
+	case map[string]interface{}:
+		return m.remapMap(v)
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = m.remapValue(item)
+		}
+		return items
+	default:
+		return value
+	}
+}
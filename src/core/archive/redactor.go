@@ -0,0 +1,134 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package archive
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// RedactedValue replaces variable values removed from archive
+// Заменяет значения переменных, удаленные из архива
+const RedactedValue = "[REDACTED]"
+
+// RedactionHook decides whether variable value must be replaced
+// Returns replacement value and true when value is redacted
+// Решает, нужно ли заменить значение переменной
+type RedactionHook func(key string, value interface{}) (interface{}, bool)
+
+// Redactor applies redaction hooks to variable maps
+// Применяет хуки редактирования к картам переменных
+type Redactor struct {
+	hooks        []RedactionHook
+	redactedKeys map[string]struct{}
+}
+
+// NewRedactor creates redactor matching keys by case-insensitive patterns
+// Создает редактор, сопоставляющий ключи по регистронезависимым шаблонам
+func NewRedactor(keyPatterns []string) (*Redactor, error) {
+	r := &Redactor{
+		redactedKeys: make(map[string]struct{}),
+	}
+
+	for _, pattern := range keyPatterns {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction key pattern %q: %w", pattern, err)
+		}
+		r.AddHook(KeyPatternHook(re))
+	}
+
+	return r, nil
+}
+
+// KeyPatternHook redacts values whose key matches regexp
+// Редактирует значения, ключ которых соответствует регулярному выражению
+func KeyPatternHook(re *regexp.Regexp) RedactionHook {
+	return func(key string, value interface{}) (interface{}, bool) {
+		if re.MatchString(key) {
+			return RedactedValue, true
+		}
+		return value, false
+	}
+}
+
+// AddHook registers additional redaction hook
+// Регистрирует дополнительный хук редактирования
+func (r *Redactor) AddHook(hook RedactionHook) {
+	r.hooks = append(r.hooks, hook)
+}
+
+// RedactVariables returns copy of variables with redacted values
+// Nested maps and arrays are walked recursively
+// Возвращает копию переменных с отредактированными значениями
+func (r *Redactor) RedactVariables(variables map[string]interface{}) map[string]interface{} {
+	if r == nil || len(r.hooks) == 0 || variables == nil {
+		return variables
+	}
+	return r.redactMap(variables)
+}
+
+// RedactedKeys returns sorted list of keys redacted so far
+// Возвращает отсортированный список отредактированных ключей
+func (r *Redactor) RedactedKeys() []string {
+	if r == nil || len(r.redactedKeys) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(r.redactedKeys))
+	for key := range r.redactedKeys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// redactMap copies map replacing values matched by hooks
+// Копирует карту, заменяя значения, совпавшие с хуками
+func (r *Redactor) redactMap(values map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		if replacement, redacted := r.applyHooks(key, value); redacted {
+			r.redactedKeys[key] = struct{}{}
+			result[key] = replacement
+			continue
+		}
+		result[key] = r.redactValue(value)
+	}
+	return result
+}
+
+// redactValue walks nested containers
+// Обходит вложенные контейнеры
+func (r *Redactor) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return r.redactMap(v)
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = r.redactValue(item)
+		}
+		return items
+	default:
+		return value
+	}
+}
+
+// applyHooks runs hooks until first one redacts value
+// Выполняет хуки до первого, который отредактировал значение
+func (r *Redactor) applyHooks(key string, value interface{}) (interface{}, bool) {
+	for _, hook := range r.hooks {
+		if replacement, redacted := hook(key, value); redacted {
+			return replacement, true
+		}
+	}
+	return value, false
+}
@@ -0,0 +1,344 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package archive
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+	"atom-engine/src/core/objectstore"
+	"atom-engine/src/storage"
+)
+
+// Retention modes
+// Режимы очистки по сроку хранения
+const (
+	RetentionModeDelete  = "delete"
+	RetentionModeArchive = "archive"
+)
+
+// ObjectPrefix is object store key prefix of archived instances
+// Префикс ключей объектного хранилища для архивированных экземпляров
+const ObjectPrefix = "archives/"
+
+// RetentionOptions configures retention sweeper
+// Настройки очистки по сроку хранения
+type RetentionOptions struct {
+	MaxAge    time.Duration // Zero disables sweeper
+	Mode      string
+	BatchSize int
+}
+
+// RetentionStats counts instances processed by sweeper
+// Счетчики экземпляров, обработанных очисткой
+type RetentionStats struct {
+	Expired  int `json:"expired"`
+	Archived int `json:"archived"`
+	Deleted  int `json:"deleted"`
+	Failed   int `json:"failed"`
+}
+
+// RetentionFailure describes instance kept in primary storage because sweep failed
+// Описывает экземпляр, оставленный в основном storage из-за ошибки очистки
+type RetentionFailure struct {
+	InstanceID string    `json:"instance_id"`
+	Stage      string    `json:"stage"` // export, upload, pointer or delete
+	Error      string    `json:"error"`
+	At         time.Time `json:"at"`
+}
+
+// RetentionStatus is snapshot of retention sweeper state
+// Снимок состояния очистки по сроку хранения
+type RetentionStatus struct {
+	Enabled       bool               `json:"enabled"`
+	Mode          string             `json:"mode"`
+	MaxAgeDays    int                `json:"max_age_days"`
+	Destination   string             `json:"destination,omitempty"`
+	Running       bool               `json:"running"`
+	LastRunAt     *time.Time         `json:"last_run_at,omitempty"`
+	LastRunMillis int64              `json:"last_run_duration_ms"`
+	LastRun       RetentionStats     `json:"last_run"`
+	Totals        RetentionStats     `json:"totals"`
+	LastError     string             `json:"last_error,omitempty"`
+	Failures      []RetentionFailure `json:"failures"` // Failures of last run
+}
+
+// RetentionSweeper removes finished instances older than max age, archiving them first in archive mode
+// Удаляет завершенные экземпляры старше срока хранения, в режиме archive сначала архивирует их
+type RetentionSweeper struct {
+	storage  storage.Storage
+	exporter *Exporter
+	store    objectstore.Store
+	options  RetentionOptions
+
+	mu     sync.Mutex
+	status RetentionStatus
+}
+
+// NewRetentionSweeper creates retention sweeper
+// Store is required only in archive mode
+// Создает очистку по сроку хранения
+func NewRetentionSweeper(
+	s storage.Storage,
+	exporter *Exporter,
+	store objectstore.Store,
+	options RetentionOptions,
+) *RetentionSweeper {
+	status := RetentionStatus{
+		Enabled:    options.MaxAge > 0,
+		Mode:       options.Mode,
+		MaxAgeDays: int(options.MaxAge / (24 * time.Hour)),
+		Failures:   make([]RetentionFailure, 0),
+	}
+	if store != nil && options.Mode == RetentionModeArchive {
+		status.Destination = store.Describe()
+	}
+
+	return &RetentionSweeper{
+		storage:  s,
+		exporter: exporter,
+		store:    store,
+		options:  options,
+		status:   status,
+	}
+}
+
+// Status returns copy of current sweeper status
+// Возвращает копию текущего статуса очистки
+func (r *RetentionSweeper) Status() *RetentionStatus {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	status := r.status
+	status.Failures = append([]RetentionFailure(nil), r.status.Failures...)
+	return &status
+}
+
+// Sweep processes one batch of expired instances
+// Обрабатывает одну партию устаревших экземпляров
+func (r *RetentionSweeper) Sweep(ctx context.Context) RetentionStats {
+	var stats RetentionStats
+	if r.options.MaxAge <= 0 {
+		return stats
+	}
+
+	startedAt := time.Now()
+	r.mu.Lock()
+	r.status.Running = true
+	r.mu.Unlock()
+
+	failures := make([]RetentionFailure, 0)
+	expired, err := r.findExpired(startedAt.Add(-r.options.MaxAge))
+	if err == nil {
+		stats.Expired = len(expired)
+		for _, instance := range expired {
+			if ctx.Err() != nil {
+				break
+			}
+			if failure := r.sweepInstance(ctx, instance); failure != nil {
+				stats.Failed++
+				failures = append(failures, *failure)
+				logger.Warn("Retention kept instance in primary storage",
+					logger.String("instance_id", failure.InstanceID),
+					logger.String("stage", failure.Stage),
+					logger.String("error", failure.Error))
+				continue
+			}
+			if r.options.Mode == RetentionModeArchive {
+				stats.Archived++
+			}
+			stats.Deleted++
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.status.Running = false
+	r.status.LastRunAt = &startedAt
+	r.status.LastRunMillis = time.Since(startedAt).Milliseconds()
+	r.status.LastRun = stats
+	r.status.Totals.Expired += stats.Expired
+	r.status.Totals.Archived += stats.Archived
+	r.status.Totals.Deleted += stats.Deleted
+	r.status.Totals.Failed += stats.Failed
+	r.status.Failures = failures
+	r.status.LastError = ""
+	if err != nil {
+		r.status.LastError = err.Error()
+	}
+
+	return stats
+}
+
+// findExpired returns finished instances completed before cutoff, oldest first
+// Возвращает завершенные до cutoff экземпляры, начиная со старых
+func (r *RetentionSweeper) findExpired(cutoff time.Time) ([]*models.ProcessInstance, error) {
+	instances, err := r.storage.LoadAllProcessInstances()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load process instances: %w", err)
+	}
+
+	expired := make([]*models.ProcessInstance, 0)
+	for _, instance := range instances {
+		doneAt := finishedAt(instance)
+		if doneAt != nil && doneAt.Before(cutoff) {
+			expired = append(expired, instance)
+		}
+	}
+
+	sort.Slice(expired, func(i, j int) bool {
+		return finishedAt(expired[i]).Before(*finishedAt(expired[j]))
+	})
+	if r.options.BatchSize > 0 && len(expired) > r.options.BatchSize {
+		expired = expired[:r.options.BatchSize]
+	}
+	return expired, nil
+}
+
+// finishedAt returns completion time of finished instance, nil for running instance
+// Возвращает время завершения экземпляра, nil для выполняющегося экземпляра
+func finishedAt(instance *models.ProcessInstance) *time.Time {
+	switch instance.State {
+	case models.ProcessInstanceStateCompleted,
+		models.ProcessInstanceStateCanceled,
+		models.ProcessInstanceStateFailed:
+	default:
+		return nil
+	}
+	if instance.CompletedAt != nil {
+		return instance.CompletedAt
+	}
+	return &instance.UpdatedAt
+}
+
+// sweepInstance archives instance if configured and removes it from primary storage
+// Any failure before removal keeps instance untouched
+// Архивирует экземпляр при необходимости и удаляет его из основного storage
+func (r *RetentionSweeper) sweepInstance(ctx context.Context, instance *models.ProcessInstance) *RetentionFailure {
+	fail := func(stage string, err error) *RetentionFailure {
+		return &RetentionFailure{
+			InstanceID: instance.InstanceID,
+			Stage:      stage,
+			Error:      err.Error(),
+			At:         time.Now(),
+		}
+	}
+
+	tokens, err := r.storage.LoadTokensByProcessInstance(instance.InstanceID)
+	if err != nil {
+		return fail("export", fmt.Errorf("failed to load tokens: %w", err))
+	}
+	jobs, err := r.exporter.exportJobs(instance.InstanceID)
+	if err != nil {
+		return fail("export", err)
+	}
+	timers, err := r.exporter.exportTimers(instance.InstanceID)
+	if err != nil {
+		return fail("export", err)
+	}
+	correlations, err := r.exporter.exportCorrelations(instance.InstanceID)
+	if err != nil {
+		return fail("export", err)
+	}
+
+	// Collect blob references before export resolves them inline
+	// Собираем ссылки на blob'ы до того, как экспорт встроит их значения
+	blobIDs := collectBlobIDs(instance.Variables)
+	for _, token := range tokens {
+		blobIDs = append(blobIDs, collectBlobIDs(token.Variables)...)
+	}
+
+	// Instance archived by earlier sweep only needs removal to be finished
+	// Экземпляру, заархивированному прошлой очисткой, нужно только завершить удаление
+	alreadyArchived := false
+	if r.options.Mode == RetentionModeArchive {
+		if _, err := r.storage.LoadArchivedInstance(instance.InstanceID); err == nil {
+			alreadyArchived = true
+		}
+	}
+
+	if r.options.Mode == RetentionModeArchive && !alreadyArchived {
+		processArchive, err := r.exporter.Export(instance.InstanceID)
+		if err != nil {
+			return fail("export", err)
+		}
+		data, err := json.Marshal(processArchive)
+		if err != nil {
+			return fail("export", fmt.Errorf("failed to serialize archive: %w", err))
+		}
+
+		key := fmt.Sprintf("%s%s/%s.json", ObjectPrefix, finishedAt(instance).Format("2006/01/02"), instance.InstanceID)
+		object, err := r.store.Put(ctx, key, bytes.NewReader(data))
+		if err != nil {
+			return fail("upload", err)
+		}
+
+		if err := r.storage.SaveArchivedInstance(models.NewArchivedInstance(instance, object.Location)); err != nil {
+			return fail("pointer", err)
+		}
+	}
+
+	// Instance record goes last so partially removed instance is retried on next sweep
+	// Запись экземпляра удаляется последней, чтобы частично удаленный экземпляр повторился
+	for _, token := range tokens {
+		if err := r.storage.DeleteToken(token.TokenID); err != nil {
+			return fail("delete", fmt.Errorf("failed to delete token %s: %w", token.TokenID, err))
+		}
+	}
+	for _, job := range jobs {
+		if err := r.storage.DeleteJob(ctx, job.ID); err != nil {
+			return fail("delete", fmt.Errorf("failed to delete job %s: %w", job.ID, err))
+		}
+	}
+	for _, timer := range timers {
+		if err := r.storage.DeleteTimer(timer.ID); err != nil {
+			return fail("delete", fmt.Errorf("failed to delete timer %s: %w", timer.ID, err))
+		}
+	}
+	for _, result := range correlations {
+		if err := r.storage.DeleteMessageCorrelationResult(ctx, result.ID); err != nil {
+			return fail("delete", fmt.Errorf("failed to delete message correlation %s: %w", result.ID, err))
+		}
+	}
+	for _, blobID := range blobIDs {
+		if err := r.storage.DeleteVariableBlob(blobID); err != nil {
+			return fail("delete", fmt.Errorf("failed to delete variable blob %s: %w", blobID, err))
+		}
+	}
+	if err := r.storage.DeleteProcessInstance(instance.InstanceID); err != nil {
+		return fail("delete", fmt.Errorf("failed to delete process instance: %w", err))
+	}
+
+	logger.Info("Process instance removed by retention",
+		logger.String("instance_id", instance.InstanceID),
+		logger.String("mode", r.options.Mode),
+		logger.Int("tokens", len(tokens)),
+		logger.Int("jobs", len(jobs)))
+
+	return nil
+}
+
+// collectBlobIDs returns IDs of offloaded variable blobs referenced by variables
+// Возвращает ID вынесенных blob'ов, на которые ссылаются переменные
+func collectBlobIDs(variables map[string]interface{}) []string {
+	var ids []string
+	for _, value := range variables {
+		if blobID, ok := models.ParseVariableBlobRef(value); ok {
+			ids = append(ids, blobID)
+		}
+	}
+	return ids
+}
@@ -10,17 +10,32 @@ package auth
 
 import (
 	"encoding/json"
+	"math/rand"
+	"strings"
 	"sync"
+	"sync/atomic"
 
 	"atom-engine/src/core/logger"
 )
 
+// Audit event tiers
+const (
+	AuditTierSecurity = "security" // Failed attempts and blocked IPs, never sampled
+	AuditTierSuccess  = "success"  // Successful authentications, sampled by config
+)
+
 // auditLogger implements AuditLogger interface
 type auditLogger struct {
 	config       AuditConfig
 	recentEvents []AuditEvent
 	mutex        sync.RWMutex
 	maxEvents    int // Maximum number of recent events to keep in memory
+
+	// Volume counters since start
+	recordedSecurity atomic.Int64
+	recordedSuccess  atomic.Int64
+	sampledOut       atomic.Int64
+	excludedReadOnly atomic.Int64
 }
 
 // NewAuditLogger creates a new audit logger
@@ -32,12 +47,31 @@ func NewAuditLogger(config AuditConfig) AuditLogger {
 	}
 }
 
-// LogEvent logs a security audit event
+// LogEvent logs a security audit event.
+// Security tier events are always recorded, success tier events may be excluded or sampled out
 func (al *auditLogger) LogEvent(event AuditEvent) {
-	if !al.config.Enabled {
+	al.mutex.RLock()
+	config := al.config
+	al.mutex.RUnlock()
+
+	if !config.Enabled {
 		return
 	}
 
+	if auditTier(event) == AuditTierSuccess {
+		if config.ExcludeReadOnly && isReadOnlyRequest(event) {
+			al.excludedReadOnly.Add(1)
+			return
+		}
+		if config.SuccessSampleRate != nil && rand.Float64() >= *config.SuccessSampleRate {
+			al.sampledOut.Add(1)
+			return
+		}
+		al.recordedSuccess.Add(1)
+	} else {
+		al.recordedSecurity.Add(1)
+	}
+
 	// Add to recent events (in memory)
 	al.addToRecentEvents(event)
 
@@ -52,12 +86,12 @@ func (al *auditLogger) LogEvent(event AuditEvent) {
 	// Log with appropriate level based on result
 	switch event.Result {
 	case "success":
-		if al.config.LogSuccessfulAuth {
+		if config.LogSuccessfulAuth {
 			logger.Info("Auth success",
 				logger.String("audit_event", string(eventJSON)))
 		}
 	case "failed", "blocked":
-		if al.config.LogFailedAttempts {
+		if config.LogFailedAttempts {
 			logger.Warn("Auth failure",
 				logger.String("audit_event", string(eventJSON)))
 		}
@@ -67,6 +101,28 @@ func (al *auditLogger) LogEvent(event AuditEvent) {
 	}
 }
 
+// auditTier returns tier of audit event, anything but success is security event
+func auditTier(event AuditEvent) string {
+	if event.Result == "success" {
+		return AuditTierSuccess
+	}
+	return AuditTierSecurity
+}
+
+// isReadOnlyRequest reports whether audited request only reads data
+func isReadOnlyRequest(event AuditEvent) bool {
+	if event.Protocol == "grpc" {
+		name := event.Method[strings.LastIndex(event.Method, "/")+1:]
+		return strings.HasPrefix(name, "Get") || strings.HasPrefix(name, "List")
+	}
+
+	switch strings.ToUpper(event.Method) {
+	case "GET", "HEAD", "OPTIONS":
+		return true
+	}
+	return false
+}
+
 // LogAuthSuccess logs successful authentication
 func (al *auditLogger) LogAuthSuccess(ctx AuthContext, result *AuthResult) {
 	event := AuditEvent{
@@ -169,8 +225,13 @@ func (al *auditLogger) GetStats() map[string]interface{} {
 		"enabled":             al.config.Enabled,
 		"log_failed_attempts": al.config.LogFailedAttempts,
 		"log_successful_auth": al.config.LogSuccessfulAuth,
+		"exclude_read_only":   al.config.ExcludeReadOnly,
 		"recent_events_count": len(al.recentEvents),
 		"max_events":          al.maxEvents,
+		"counters":            al.GetCounters(),
+	}
+	if al.config.SuccessSampleRate != nil {
+		stats["success_sample_rate"] = *al.config.SuccessSampleRate
 	}
 
 	// Count events by result type
@@ -185,15 +246,31 @@ func (al *auditLogger) GetStats() map[string]interface{} {
 	return stats
 }
 
-// UpdateConfig updates audit logger configuration
+// GetCounters returns number of audit events recorded and dropped since start
+func (al *auditLogger) GetCounters() AuditCounters {
+	return AuditCounters{
+		RecordedSecurity: al.recordedSecurity.Load(),
+		RecordedSuccess:  al.recordedSuccess.Load(),
+		SampledOut:       al.sampledOut.Load(),
+		ExcludedReadOnly: al.excludedReadOnly.Load(),
+	}
+}
+
+// UpdateConfig updates audit logger configuration, next logged event uses new sampling
 func (al *auditLogger) UpdateConfig(config AuditConfig) {
 	al.mutex.Lock()
 	defer al.mutex.Unlock()
 
 	al.config = config
 
+	sampleRate := 1.0
+	if config.SuccessSampleRate != nil {
+		sampleRate = *config.SuccessSampleRate
+	}
 	logger.Info("Audit logger configuration updated",
 		logger.Bool("enabled", config.Enabled),
 		logger.Bool("log_failed_attempts", config.LogFailedAttempts),
-		logger.Bool("log_successful_auth", config.LogSuccessfulAuth))
+		logger.Bool("log_successful_auth", config.LogSuccessfulAuth),
+		logger.Any("success_sample_rate", sampleRate),
+		logger.Bool("exclude_read_only", config.ExcludeReadOnly))
 }
@@ -222,6 +222,9 @@ func (c *component) UpdateConfig(config *AuthConfig) error {
 	if config == nil {
 		return errors.New("auth config cannot be nil")
 	}
+	if err := config.Audit.Validate(); err != nil {
+		return fmt.Errorf("invalid audit config: %w", err)
+	}
 
 	oldEnabled := c.IsEnabled()
 	c.config = config
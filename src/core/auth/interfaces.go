@@ -86,6 +86,9 @@ type AuditLogger interface {
 
 	// GetRecentEvents returns recent audit events
 	GetRecentEvents(limit int) []AuditEvent
+
+	// GetCounters returns number of audit events recorded and dropped since start
+	GetCounters() AuditCounters
 }
 
 // Component defines the main auth component interface
@@ -95,6 +98,9 @@ type Component interface {
 	// Initialize initializes the auth component with configuration
 	Initialize(config *AuthConfig) error
 
+	// UpdateConfig updates configuration of running component
+	UpdateConfig(config *AuthConfig) error
+
 	// Start starts the auth component
 	Start() error
 
@@ -55,6 +55,14 @@ type AuditEvent struct {
 	Reason      string    `json:"reason,omitempty"`
 }
 
+// AuditCounters is number of audit events recorded and dropped since start
+type AuditCounters struct {
+	RecordedSecurity int64 `json:"recorded_security"`
+	RecordedSuccess  int64 `json:"recorded_success"`
+	SampledOut       int64 `json:"sampled_out"`
+	ExcludedReadOnly int64 `json:"excluded_read_only"`
+}
+
 // Permission constants for common permissions
 const (
 	PermissionAll        = "*"
@@ -69,6 +77,12 @@ const (
 	PermissionIncident   = "incident"
 	PermissionExpression = "expression"
 	PermissionBPMN       = "bpmn"
+
+	// PermissionSensitiveVariables allows reading plaintext of encrypted variables
+	PermissionSensitiveVariables = "sensitive_variables"
+
+	// PermissionCrossTenantMessages allows publishing messages that correlate with subscriptions of any tenant
+	PermissionCrossTenantMessages = "cross_tenant_messages"
 )
 
 // HasPermission checks if the given permissions include the required permission
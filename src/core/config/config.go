@@ -9,6 +9,7 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package config
 
 import (
+	"encoding/base64"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -19,15 +20,30 @@ import (
 // Config holds application configuration
 // Содержит конфигурацию приложения
 type Config struct {
-	InstanceName string         `yaml:"instance_name"` // Instance/deployment name
-	BasePath     string         `yaml:"base_path"`     // Base path for all relative paths
-	Database     DatabaseConfig `yaml:"database"`
-	GRPC         GRPCConfig     `yaml:"grpc"`
-	RestAPI      RestAPIConfig  `yaml:"rest_api"`
-	Logger       LoggerConfig   `yaml:"logger"`
-	Storage      StorageConfig  `yaml:"storage"`
-	BPMN         BPMNConfig     `yaml:"bpmn"`
-	Auth         AuthConfig     `yaml:"auth"`
+	InstanceName   string               `yaml:"instance_name"` // Instance/deployment name
+	BasePath       string               `yaml:"base_path"`     // Base path for all relative paths
+	Database       DatabaseConfig       `yaml:"database"`
+	GRPC           GRPCConfig           `yaml:"grpc"`
+	RestAPI        RestAPIConfig        `yaml:"rest_api"`
+	Logger         LoggerConfig         `yaml:"logger"`
+	Storage        StorageConfig        `yaml:"storage"`
+	BPMN           BPMNConfig           `yaml:"bpmn"`
+	Engine         EngineConfig         `yaml:"engine"`
+	Auth           AuthConfig           `yaml:"auth"`
+	Variables      VariablesConfig      `yaml:"variables"`
+	Expression     ExpressionConfig     `yaml:"expression"`
+	Archive        ArchiveConfig        `yaml:"archive"`
+	CircuitBreaker CircuitBreakerConfig `yaml:"circuit_breaker"`
+	KafkaBridge    KafkaBridgeConfig    `yaml:"kafka_bridge"`
+	NATSBridge     NATSBridgeConfig     `yaml:"nats_bridge"`
+	HistoryExport  HistoryExportConfig  `yaml:"history_export"`
+	EmailConnector EmailConnectorConfig `yaml:"email_connector"`
+	Jobs           JobsConfig           `yaml:"jobs"`
+	Messages       MessagesConfig       `yaml:"messages"`
+	Metrics        MetricsConfig        `yaml:"metrics"`
+	Tracing        TracingConfig        `yaml:"tracing"`
+	ObjectStorage  ObjectStorageConfig  `yaml:"object_storage"`
+	Simulation     SimulationConfig     `yaml:"simulation"`
 }
 
 // DatabaseConfig holds database configuration
@@ -46,8 +62,77 @@ type GRPCConfig struct {
 // RestAPIConfig holds REST API server configuration
 // Конфигурация REST API сервера
 type RestAPIConfig struct {
-	Port int    `yaml:"port"`
-	Host string `yaml:"host"`
+	Port    int           `yaml:"port"`
+	Host    string        `yaml:"host"`
+	Mode    string        `yaml:"mode"` // Gin mode: release, debug or test, default release
+	CORS    CORSConfig    `yaml:"cors"`
+	GRPCWeb GRPCWebConfig `yaml:"grpc_web"`
+	GraphQL GraphQLConfig `yaml:"graphql"`
+	Camunda CamundaConfig `yaml:"camunda_compat"`
+
+	Compression CompressionConfig `yaml:"compression"`
+	Overload    OverloadConfig    `yaml:"overload"`
+	AccessLog   AccessLogConfig   `yaml:"access_log"`
+}
+
+// CORSConfig holds CORS settings of REST API, empty lists use built-in defaults
+// Настройки CORS REST API, пустые списки заменяются встроенными значениями
+type CORSConfig struct {
+	Enabled          bool     `yaml:"enabled"`
+	AllowedOrigins   []string `yaml:"allowed_origins"`
+	AllowedMethods   []string `yaml:"allowed_methods"`
+	AllowedHeaders   []string `yaml:"allowed_headers"`
+	ExposedHeaders   []string `yaml:"exposed_headers"`
+	AllowCredentials bool     `yaml:"allow_credentials"`
+	MaxAge           int      `yaml:"max_age"` // Seconds preflight result is cached
+}
+
+// GRPCWebConfig holds grpc-web proxy settings of REST port for browser clients
+// Настройки grpc-web прокси на порту REST для браузерных клиентов
+type GRPCWebConfig struct {
+	Enabled  bool     `yaml:"enabled"`
+	Services []string `yaml:"services"` // Full gRPC service names, empty exposes expression, messages and process
+}
+
+// GraphQLConfig holds read-only GraphQL endpoint settings, zero limits use defaults
+// Настройки read-only GraphQL endpoint, нулевые лимиты заменяются значениями по умолчанию
+type GraphQLConfig struct {
+	Enabled       bool `yaml:"enabled"`
+	MaxDepth      int  `yaml:"max_depth"`      // Maximum nesting of selections, default 8
+	MaxComplexity int  `yaml:"max_complexity"` // Maximum estimated field count, default 5000
+}
+
+// CamundaConfig holds Camunda 7 compatible external task API settings
+// Настройки совместимого с Camunda 7 API внешних задач
+type CamundaConfig struct {
+	Enabled bool `yaml:"enabled"` // Serves /engine-rest/external-task on REST port
+}
+
+// CompressionConfig holds gzip compression settings of REST responses, zero values use defaults
+// Настройки gzip сжатия ответов REST, нулевые значения заменяются значениями по умолчанию
+type CompressionConfig struct {
+	Enabled       *bool    `yaml:"enabled,omitempty"` // Nil enables compression
+	MinSize       int      `yaml:"min_size"`          // Smaller responses are sent as is, bytes, default 1024
+	Level         int      `yaml:"level"`             // gzip level 1-9, 0 uses default level
+	ContentTypes  []string `yaml:"content_types"`     // Compressed media types, empty uses JSON, XML and text
+	ExcludedPaths []string `yaml:"excluded_paths"`    // Path patterns never compressed, * matches one segment
+}
+
+// OverloadConfig holds load shedding settings of REST API, zero values use defaults
+// Настройки сброса нагрузки REST API, нулевые значения заменяются значениями по умолчанию
+type OverloadConfig struct {
+	Enabled          *bool    `yaml:"enabled,omitempty"`  // Nil enables protection
+	MaxInFlight      int      `yaml:"max_in_flight"`      // Requests handled at once, default 512
+	RequestTimeoutMs int      `yaml:"request_timeout_ms"` // Total handler duration, default 25000
+	ExcludedPaths    []string `yaml:"excluded_paths"`     // Path patterns never limited, * matches one segment
+}
+
+// AccessLogConfig holds structured access log of REST API, one logger line per request
+// Настройки структурированного журнала доступа REST API, одна строка логгера на запрос
+type AccessLogConfig struct {
+	Enabled                *bool    `yaml:"enabled,omitempty"`         // Nil enables access log
+	SkipPaths              []string `yaml:"skip_paths"`                // Exact paths, empty skips probes and /metrics
+	SlowRequestThresholdMs int      `yaml:"slow_request_threshold_ms"` // Logged again as warning, default 1000
 }
 
 // StorageConfig holds storage configuration
@@ -56,6 +141,33 @@ type StorageConfig struct {
 	Directory string               `yaml:"directory"`
 	Type      string               `yaml:"type"` // badger, leveldb, etc
 	Options   StorageOptionsConfig `yaml:"options"`
+	// Negative value keeps events forever
+	SystemEventsRetentionDays int `yaml:"system_events_retention_days"`
+
+	VariableEncryption VariableEncryptionConfig `yaml:"variable_encryption"`
+}
+
+// VariableEncryptionConfig holds encryption of sensitive process variables at rest
+// Key without enabled flag only decrypts previously encrypted values
+// Конфигурация шифрования чувствительных переменных процессов в хранилище
+// Ключ без флага enabled только расшифровывает ранее зашифрованные значения
+type VariableEncryptionConfig struct {
+	Enabled     bool     `yaml:"enabled"`
+	Key         string   `yaml:"key"`          // Base64 encoded 32-byte AES-256 key
+	KeyPatterns []string `yaml:"key_patterns"` // Case-insensitive regexps for variable keys
+}
+
+// DecodeKey decodes base64 encryption key and checks its length
+// Декодирует base64 ключ шифрования и проверяет его длину
+func (c *VariableEncryptionConfig) DecodeKey() ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(c.Key)
+	if err != nil {
+		return nil, fmt.Errorf("variable_encryption.key must be base64 encoded: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("variable_encryption.key must decode to 32 bytes, got %d", len(key))
+	}
+	return key, nil
 }
 
 // StorageOptionsConfig holds storage options
@@ -113,14 +225,299 @@ type LoggerConfig struct {
 	MaxAge        int    `yaml:"max_age"`        // Maximum age in days
 	MaxBackups    int    `yaml:"max_backups"`    // Maximum number of backup files
 	EnableConsole bool   `yaml:"enable_console"` // Enable console output
+
+	// Case-insensitive regexps for variable names whose values are replaced in logs
+	// Регистронезависимые регулярные выражения имен переменных, значения которых заменяются в логах
+	RedactKeyPatterns []string `yaml:"redact_key_patterns"`
+
+	// OmitVariableValues logs process variables by names only
+	// Логировать переменные процесса только именами
+	OmitVariableValues bool `yaml:"omit_variable_values"`
 }
 
 // BPMNConfig holds BPMN parser configuration
 // Конфигурация BPMN парсера
 type BPMNConfig struct {
-	Path            string `yaml:"path"`
-	StorageOriginal bool   `yaml:"storage_original"`
-	Validation      bool   `yaml:"validation"`
+	Path                string `yaml:"path"`
+	StorageOriginal     bool   `yaml:"storage_original"`
+	Validation          bool   `yaml:"validation"`
+	MaxConcurrentParses int    `yaml:"max_concurrent_parses"`  // Parses running at the same time
+	ParseQueueSize      int    `yaml:"parse_queue_size"`       // Parses waiting for free slot
+	ParseQueueTimeoutMs int    `yaml:"parse_queue_timeout_ms"` // Max wait for free slot
+}
+
+// EngineConfig holds process execution engine configuration
+// Конфигурация движка выполнения процессов
+type EngineConfig struct {
+	TokenWorkers   int  `yaml:"token_workers"`    // Tokens executed concurrently by pool
+	TokenQueueSize int  `yaml:"token_queue_size"` // Tokens waiting for free worker
+	NodeID         int  `yaml:"node_id"`          // Node ID in numeric keys, unique per replica (0-1023)
+	ULIDIDs        bool `yaml:"ulid_ids"`         // Generate ULID for new instance, token and message IDs
+
+	BatchStartWorkers  int `yaml:"batch_start_workers"`   // Instances of one batch started concurrently
+	BatchStartMaxItems int `yaml:"batch_start_max_items"` // Max items in one batch start request
+}
+
+// SimulationAllowedEnv is environment variable that must be "true" for simulation mode to start
+// Simulation cannot be enabled by environment alone, config file must enable it as well
+// Переменная окружения, которая должна быть "true" для запуска режима симуляции
+// Симуляцию нельзя включить только окружением, ее также должен включить файл конфигурации
+const SimulationAllowedEnv = "ATOM_SIMULATION_ALLOWED"
+
+// SimulationConfig holds simulation mode for testing time-dependent processes
+// Timewheel runs on simulated clock that moves only by POST /api/v1/sim/advance
+// Конфигурация режима симуляции для тестирования процессов, зависящих от времени
+// Timewheel работает на симулированных часах, которые идут только через POST /api/v1/sim/advance
+type SimulationConfig struct {
+	Enabled   bool   `yaml:"enabled"`
+	StartTime string `yaml:"start_time"` // RFC 3339 time clock starts at, empty starts at engine start
+}
+
+// CircuitBreakerConfig holds component messaging circuit breaker configuration
+// Конфигурация circuit breaker обмена сообщениями с компонентами
+type CircuitBreakerConfig struct {
+	FailureThreshold int `yaml:"failure_threshold"` // Consecutive response timeouts that open breaker
+	CooldownMs       int `yaml:"cooldown_ms"`       // Time breaker stays open before probing component
+}
+
+// JobsConfig holds jobs component configuration
+// Конфигурация jobs компонента
+type JobsConfig struct {
+	RetryBackoff JobRetryBackoffConfig `yaml:"retry_backoff"`
+}
+
+// MessagesConfig holds messages component configuration
+// Конфигурация messages компонента
+type MessagesConfig struct {
+	Buffer MessageBufferConfig `yaml:"buffer"`
+}
+
+// MessageBufferConfig holds capacity of buffer for messages without subscription
+// Capacity is counted per tenant and message name, expired messages are not counted
+// Конфигурация емкости буфера сообщений без подписки
+// Емкость считается по тенанту и имени сообщения, истекшие сообщения не учитываются
+type MessageBufferConfig struct {
+	MaxMessages    int    `yaml:"max_messages"`    // 0 disables limit
+	EvictionPolicy string `yaml:"eviction_policy"` // reject_new or evict_oldest
+}
+
+// JobRetryBackoffConfig holds delay before failed job is activatable again
+// Worker backoff and retryTimeCycle of service task take precedence over it
+// Конфигурация задержки, после которой проваленный job снова доступен для активации
+// Backoff от worker'а и retryTimeCycle сервисной задачи имеют приоритет над ней
+type JobRetryBackoffConfig struct {
+	Strategy   string  `yaml:"strategy"`   // fixed or exponential
+	InitialMs  int64   `yaml:"initial_ms"` // Delay before first retry
+	MaxMs      int64   `yaml:"max_ms"`     // Upper bound of exponential delay
+	Multiplier float64 `yaml:"multiplier"` // Growth of exponential delay per retry
+}
+
+// MetricsConfig holds Prometheus metrics configuration
+// Конфигурация метрик Prometheus
+type MetricsConfig struct {
+	ProcessSeriesLimit int `yaml:"process_series_limit"` // Label sets of per-process metrics, extra go to __overflow__
+}
+
+// TracingConfig holds OpenTelemetry tracing configuration, spans are exported over OTLP
+// Конфигурация трассировки OpenTelemetry, span'ы экспортируются по OTLP
+type TracingConfig struct {
+	Enabled     bool              `yaml:"enabled"`
+	Protocol    string            `yaml:"protocol"`     // grpc or http
+	Endpoint    string            `yaml:"endpoint"`     // Collector host:port, empty uses OTEL_EXPORTER_OTLP_ENDPOINT
+	URLPath     string            `yaml:"url_path"`     // Traces path of http protocol
+	Insecure    bool              `yaml:"insecure"`     // Plain text connection to collector
+	Headers     map[string]string `yaml:"headers"`      // Sent with every export request
+	ServiceName string            `yaml:"service_name"` // service.name resource attribute
+	SampleRatio float64           `yaml:"sample_ratio"` // Share of new traces sampled, incoming decision is kept
+	TimeoutMs   int               `yaml:"timeout_ms"`   // Export request timeout
+}
+
+// KafkaBridgeConfig holds Kafka bridge configuration for jobs and engine events
+// Конфигурация моста Kafka для job'ов и событий движка
+type KafkaBridgeConfig struct {
+	Enabled        bool              `yaml:"enabled"`
+	Brokers        []string          `yaml:"brokers"`
+	ClientID       string            `yaml:"client_id"`
+	ConsumerGroup  string            `yaml:"consumer_group"` // Consumer group of response topic
+	WorkerName     string            `yaml:"worker_name"`    // Worker name jobs are activated with
+	JobTopics      map[string]string `yaml:"job_topics"`     // Job type -> topic activated jobs are published to
+	ResponseTopic  string            `yaml:"response_topic"` // Topic with job completions and failures
+	EventsTopic    string            `yaml:"events_topic"`   // Empty disables engine events mirroring
+	EventTypes     []string          `yaml:"event_types"`    // Mirrored event types, empty mirrors all
+	PollIntervalMs int               `yaml:"poll_interval_ms"`
+	MaxJobs        int               `yaml:"max_jobs"`       // Jobs activated per poll of each type
+	JobTimeoutMs   int               `yaml:"job_timeout_ms"` // Lease of published job before reactivation
+
+	TLS  KafkaTLSConfig  `yaml:"tls"`
+	SASL KafkaSASLConfig `yaml:"sasl"`
+}
+
+// KafkaTLSConfig holds TLS settings of Kafka connections
+// Настройки TLS соединений с Kafka
+type KafkaTLSConfig struct {
+	Enabled            bool   `yaml:"enabled"`
+	CAFile             string `yaml:"ca_file"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+// KafkaSASLConfig holds SASL authentication settings of Kafka connections
+// Настройки SASL аутентификации соединений с Kafka
+type KafkaSASLConfig struct {
+	Mechanism string `yaml:"mechanism"` // Empty, plain, scram-sha-256 or scram-sha-512
+	Username  string `yaml:"username"`
+	Password  string `yaml:"password"`
+}
+
+// NATSBridgeConfig holds NATS bridge configuration for engine events and message publishing
+// Конфигурация моста NATS для событий движка и публикации сообщений
+type NATSBridgeConfig struct {
+	Enabled         bool     `yaml:"enabled"`
+	Servers         []string `yaml:"servers"`          // nats://host:port or tls://host:port, tried in order
+	Name            string   `yaml:"name"`             // Connection name shown by NATS monitoring
+	EventsPrefix    string   `yaml:"events_prefix"`    // Events go to {prefix}.instance.completed and alike
+	EventTypes      []string `yaml:"event_types"`      // Published event types, empty publishes all
+	MessagesSubject string   `yaml:"messages_subject"` // Wildcard subject of incoming messages, empty disables
+	QueueGroup      string   `yaml:"queue_group"`      // Queue group of messages subscription
+	ReconnectWaitMs int      `yaml:"reconnect_wait_ms"`
+
+	TLS  NATSTLSConfig  `yaml:"tls"`
+	Auth NATSAuthConfig `yaml:"auth"`
+}
+
+// NATSTLSConfig holds TLS settings of NATS connection
+// Настройки TLS соединения с NATS
+type NATSTLSConfig struct {
+	Enabled            bool   `yaml:"enabled"`
+	CAFile             string `yaml:"ca_file"`
+	CertFile           string `yaml:"cert_file"`
+	KeyFile            string `yaml:"key_file"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+// NATSAuthConfig holds NATS credentials, at most one method is used
+// Учетные данные NATS, используется не больше одного способа
+type NATSAuthConfig struct {
+	Username        string `yaml:"username"`
+	Password        string `yaml:"password"`
+	Token           string `yaml:"token"`
+	CredentialsFile string `yaml:"credentials_file"` // User JWT and nkey seed file
+}
+
+// HistoryExportConfig holds configuration of history exporter to Elasticsearch compatible endpoint
+// Конфигурация экспортера истории в Elasticsearch совместимый endpoint
+type HistoryExportConfig struct {
+	Enabled            bool   `yaml:"enabled"`
+	URL                string `yaml:"url"` // Base URL, bulk requests go to {url}/_bulk
+	Username           string `yaml:"username"`
+	Password           string `yaml:"password"`
+	APIKey             string `yaml:"api_key"`        // Sent as "Authorization: ApiKey", wins over basic auth
+	IndexTemplate      string `yaml:"index_template"` // Placeholders {kind} and {date}
+	DateFormat         string `yaml:"date_format"`    // Go layout of {date}, UTC
+	BatchSize          int    `yaml:"batch_size"`     // Records per bulk request
+	FlushIntervalMs    int    `yaml:"flush_interval_ms"`
+	RequestTimeoutMs   int    `yaml:"request_timeout_ms"`
+	RetryInitialMs     int    `yaml:"retry_initial_ms"` // First delay after failed bulk request
+	RetryMaxMs         int    `yaml:"retry_max_ms"`     // Upper bound of doubled delay
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+// EmailConnectorConfig holds configuration of built-in worker sending email jobs over SMTP
+// Конфигурация встроенного worker'а, отправляющего email job'ы через SMTP
+type EmailConnectorConfig struct {
+	Enabled        bool   `yaml:"enabled"`
+	JobType        string `yaml:"job_type"`    // Job type handled by connector
+	WorkerName     string `yaml:"worker_name"` // Worker name jobs are activated with
+	PollIntervalMs int    `yaml:"poll_interval_ms"`
+	MaxJobs        int    `yaml:"max_jobs"`       // Jobs activated per poll
+	JobTimeoutMs   int    `yaml:"job_timeout_ms"` // Lease of activated job before reactivation
+	DryRun         bool   `yaml:"dry_run"`        // Log messages instead of sending, for non-production configs
+
+	SMTP EmailSMTPConfig `yaml:"smtp"`
+}
+
+// EmailSMTPConfig holds SMTP server settings of email connector
+// Настройки SMTP сервера email коннектора
+type EmailSMTPConfig struct {
+	Host               string `yaml:"host"`
+	Port               int    `yaml:"port"`
+	Username           string `yaml:"username"` // Empty disables authentication
+	Password           string `yaml:"password"`
+	From               string `yaml:"from"`       // Default sender, "from" header of task wins
+	TLS                string `yaml:"tls"`        // none, starttls or tls
+	TimeoutMs          int    `yaml:"timeout_ms"` // Connect and send timeout
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+// VariablesConfig holds process variable limits configuration
+// Конфигурация ограничений переменных процесса
+type VariablesConfig struct {
+	MaxVariableSize  int64 `yaml:"max_variable_size"` // Maximum JSON size of single variable in bytes
+	MaxPayloadSize   int64 `yaml:"max_payload_size"`  // Maximum JSON size of all variables in bytes
+	OffloadEnabled   bool  `yaml:"offload_enabled"`   // Store large values in blob namespace
+	OffloadThreshold int64 `yaml:"offload_threshold"` // Variable size in bytes to offload from
+
+	// Validation rules of REST variable payloads, violations are rejected with 400
+	// Правила валидации переменных в REST запросах, нарушения отклоняются с 400
+	KeyPattern        string `yaml:"key_pattern"`         // Regexp for top level variable names
+	MaxKeys           int    `yaml:"max_keys"`            // Maximum number of top level variables
+	MaxDepth          int    `yaml:"max_depth"`           // Maximum nesting depth of variable value
+	MaxSerializedSize int64  `yaml:"max_serialized_size"` // Maximum JSON size of variable in bytes
+}
+
+// ExpressionConfig holds expression engine configuration
+// Конфигурация движка выражений
+type ExpressionConfig struct {
+	EvaluationLogLevel string `yaml:"evaluation_log_level"` // debug, info or off
+	LogFullContext     bool   `yaml:"log_full_context"`     // Log variable values, not only names
+}
+
+// ArchiveConfig holds process instance archive export/import configuration
+// Конфигурация экспорта/импорта архивов экземпляров процессов
+type ArchiveConfig struct {
+	RedactKeyPatterns []string `yaml:"redact_key_patterns"` // Case-insensitive regexps for variable keys
+	ImportEnabled     bool     `yaml:"import_enabled"`      // Allow importing archives, never enable in production
+
+	Retention InstanceRetentionConfig `yaml:"retention"`
+
+	// Destination is deprecated, used as object_storage when the latter is not configured
+	// Устарело, используется как object_storage, если он не настроен
+	Destination ObjectStorageConfig `yaml:"destination"`
+}
+
+// InstanceRetentionConfig holds retention sweeper configuration for finished instances
+// Конфигурация очистки завершенных экземпляров процессов по сроку хранения
+type InstanceRetentionConfig struct {
+	CompletedInstanceDays int    `yaml:"completed_instance_days"` // Zero disables sweeper
+	Mode                  string `yaml:"mode"`                    // delete or archive
+	IntervalMinutes       int    `yaml:"interval_minutes"`
+	BatchSize             int    `yaml:"batch_size"` // Max instances removed per sweep
+}
+
+// ObjectStorageConfig holds object store used by exports, backups and archives
+// Конфигурация объектного хранилища для экспортов, резервных копий и архивов
+type ObjectStorageConfig struct {
+	Type     string                `yaml:"type"` // local or s3
+	LocalDir string                `yaml:"local_dir"`
+	S3       ObjectStorageS3Config `yaml:"s3"`
+}
+
+// ObjectStorageS3Config holds S3-compatible bucket settings
+// Credentials are excluded from JSON so they never leak through status output
+// Настройки S3-совместимого bucket
+// Учетные данные исключены из JSON, чтобы не попасть в вывод статуса
+type ObjectStorageS3Config struct {
+	Endpoint       string `yaml:"endpoint"` // e.g. https://s3.amazonaws.com or http://minio:9000
+	Region         string `yaml:"region"`
+	Bucket         string `yaml:"bucket"`
+	Prefix         string `yaml:"prefix"`
+	AccessKey      string `yaml:"access_key" json:"-"`
+	SecretKey      string `yaml:"secret_key" json:"-"`
+	SSE            string `yaml:"sse"`            // Empty, AES256 or aws:kms
+	SSEKMSKeyID    string `yaml:"sse_kms_key_id"` // Only with aws:kms, empty uses bucket default key
+	PartSizeMB     int    `yaml:"part_size_mb"`   // Objects larger than part are uploaded by multipart
+	TimeoutSeconds int    `yaml:"timeout_seconds"`
 }
 
 // AuthConfig holds auth configuration
@@ -147,11 +544,17 @@ type RateLimitConfig struct {
 	RequestsPerMinute int  `yaml:"requests_per_minute"`
 }
 
-// AuditConfig represents audit logging configuration
+// AuditConfig represents audit logging configuration.
+// Security events (failed attempts, blocked IPs) are never sampled,
+// successful authentications are sampled and optionally skipped for read-only requests
 type AuditConfig struct {
 	Enabled           bool `yaml:"enabled"`
 	LogFailedAttempts bool `yaml:"log_failed_attempts"`
 	LogSuccessfulAuth bool `yaml:"log_successful_auth"`
+	// Share of successful authentications recorded, from 0 to 1, all are recorded when not set
+	SuccessSampleRate *float64 `yaml:"success_sample_rate,omitempty"`
+	// Skip successful authentications of read-only requests (GET/HEAD/OPTIONS, gRPC Get*/List*)
+	ExcludeReadOnly bool `yaml:"exclude_read_only"`
 }
 
 // LoadConfig loads configuration from YAML file
@@ -218,6 +621,9 @@ func setDefaults(config *Config) {
 	if config.RestAPI.Port == 0 {
 		config.RestAPI.Port = 27555
 	}
+	if config.RestAPI.Mode == "" {
+		config.RestAPI.Mode = "release"
+	}
 
 	// Database defaults
 	if config.Database.Path == "" {
@@ -231,6 +637,9 @@ func setDefaults(config *Config) {
 	if config.Storage.Type == "" {
 		config.Storage.Type = "badger"
 	}
+	if config.Storage.SystemEventsRetentionDays == 0 {
+		config.Storage.SystemEventsRetentionDays = 30 // 30 days default
+	}
 
 	// Logger defaults
 	if config.Logger.Level == "" {
@@ -251,6 +660,9 @@ func setDefaults(config *Config) {
 	if config.Logger.MaxBackups == 0 {
 		config.Logger.MaxBackups = 10 // 10 backup files default
 	}
+	if config.Logger.RedactKeyPatterns == nil {
+		config.Logger.RedactKeyPatterns = []string{"password", "token", "secret", "ssn"}
+	}
 
 	// BPMN defaults
 	if config.BPMN.Path == "" {
@@ -263,6 +675,228 @@ func setDefaults(config *Config) {
 	if !config.BPMN.Validation {
 		config.BPMN.Validation = true // Default to true
 	}
+	if config.BPMN.MaxConcurrentParses == 0 {
+		config.BPMN.MaxConcurrentParses = 4
+	}
+	if config.BPMN.ParseQueueSize == 0 {
+		config.BPMN.ParseQueueSize = 16
+	}
+	if config.BPMN.ParseQueueTimeoutMs == 0 {
+		config.BPMN.ParseQueueTimeoutMs = 5000 // 5 seconds default
+	}
+
+	// Engine defaults
+	if config.Engine.TokenWorkers == 0 {
+		config.Engine.TokenWorkers = 16
+	}
+	if config.Engine.TokenQueueSize == 0 {
+		config.Engine.TokenQueueSize = 1024
+	}
+	if config.Engine.BatchStartWorkers == 0 {
+		config.Engine.BatchStartWorkers = 8
+	}
+	if config.Engine.BatchStartMaxItems == 0 {
+		config.Engine.BatchStartMaxItems = 50000
+	}
+
+	// Circuit breaker defaults
+	if config.CircuitBreaker.FailureThreshold == 0 {
+		config.CircuitBreaker.FailureThreshold = 5
+	}
+	if config.CircuitBreaker.CooldownMs == 0 {
+		config.CircuitBreaker.CooldownMs = 30000 // 30 seconds default
+	}
+
+	// Job retry backoff defaults
+	if config.Jobs.RetryBackoff.Strategy == "" {
+		config.Jobs.RetryBackoff.Strategy = "fixed"
+	}
+	if config.Jobs.RetryBackoff.InitialMs == 0 {
+		config.Jobs.RetryBackoff.InitialMs = 5000 // 5 seconds default
+	}
+	if config.Jobs.RetryBackoff.MaxMs == 0 {
+		config.Jobs.RetryBackoff.MaxMs = 300000 // 5 minutes default
+	}
+	if config.Jobs.RetryBackoff.Multiplier == 0 {
+		config.Jobs.RetryBackoff.Multiplier = 2
+	}
+
+	// Message buffer defaults
+	if config.Messages.Buffer.MaxMessages == 0 {
+		config.Messages.Buffer.MaxMessages = 10000
+	}
+	if config.Messages.Buffer.EvictionPolicy == "" {
+		config.Messages.Buffer.EvictionPolicy = "reject_new"
+	}
+
+	// Metrics defaults
+	if config.Metrics.ProcessSeriesLimit == 0 {
+		config.Metrics.ProcessSeriesLimit = 500
+	}
+
+	// Kafka bridge defaults
+	if config.KafkaBridge.ClientID == "" {
+		config.KafkaBridge.ClientID = "atom-engine"
+	}
+	if config.KafkaBridge.ConsumerGroup == "" {
+		config.KafkaBridge.ConsumerGroup = "atom-engine-kafka-bridge"
+	}
+	if config.KafkaBridge.WorkerName == "" {
+		config.KafkaBridge.WorkerName = "kafka-bridge"
+	}
+	if config.KafkaBridge.PollIntervalMs == 0 {
+		config.KafkaBridge.PollIntervalMs = 1000
+	}
+	if config.KafkaBridge.MaxJobs == 0 {
+		config.KafkaBridge.MaxJobs = 32
+	}
+	if config.KafkaBridge.JobTimeoutMs == 0 {
+		config.KafkaBridge.JobTimeoutMs = 300000 // 5 minutes default
+	}
+
+	// NATS bridge defaults
+	if config.NATSBridge.Name == "" {
+		config.NATSBridge.Name = "atom-engine"
+	}
+	if config.NATSBridge.EventsPrefix == "" {
+		config.NATSBridge.EventsPrefix = "atom"
+	}
+	if config.NATSBridge.QueueGroup == "" {
+		config.NATSBridge.QueueGroup = "atom-engine"
+	}
+	if config.NATSBridge.ReconnectWaitMs == 0 {
+		config.NATSBridge.ReconnectWaitMs = 2000
+	}
+
+	// Tracing defaults
+	if config.Tracing.Protocol == "" {
+		config.Tracing.Protocol = "grpc"
+	}
+	if config.Tracing.ServiceName == "" {
+		config.Tracing.ServiceName = "atom-engine"
+	}
+	if config.Tracing.SampleRatio == 0 {
+		config.Tracing.SampleRatio = 1
+	}
+	if config.Tracing.TimeoutMs == 0 {
+		config.Tracing.TimeoutMs = 10000
+	}
+
+	// History export defaults
+	if config.HistoryExport.IndexTemplate == "" {
+		config.HistoryExport.IndexTemplate = "atom-{kind}-{date}"
+	}
+	if config.HistoryExport.DateFormat == "" {
+		config.HistoryExport.DateFormat = "2006.01.02"
+	}
+	if config.HistoryExport.BatchSize == 0 {
+		config.HistoryExport.BatchSize = 500
+	}
+	if config.HistoryExport.FlushIntervalMs == 0 {
+		config.HistoryExport.FlushIntervalMs = 1000
+	}
+	if config.HistoryExport.RequestTimeoutMs == 0 {
+		config.HistoryExport.RequestTimeoutMs = 10000
+	}
+	if config.HistoryExport.RetryInitialMs == 0 {
+		config.HistoryExport.RetryInitialMs = 1000
+	}
+	if config.HistoryExport.RetryMaxMs == 0 {
+		config.HistoryExport.RetryMaxMs = 60000
+	}
+
+	// Email connector defaults
+	if config.EmailConnector.JobType == "" {
+		config.EmailConnector.JobType = "email"
+	}
+	if config.EmailConnector.WorkerName == "" {
+		config.EmailConnector.WorkerName = "email-connector"
+	}
+	if config.EmailConnector.PollIntervalMs == 0 {
+		config.EmailConnector.PollIntervalMs = 1000
+	}
+	if config.EmailConnector.MaxJobs == 0 {
+		config.EmailConnector.MaxJobs = 10
+	}
+	if config.EmailConnector.JobTimeoutMs == 0 {
+		config.EmailConnector.JobTimeoutMs = 60000 // 1 minute default
+	}
+	if config.EmailConnector.SMTP.Port == 0 {
+		config.EmailConnector.SMTP.Port = 587
+	}
+	if config.EmailConnector.SMTP.TLS == "" {
+		config.EmailConnector.SMTP.TLS = "starttls"
+	}
+	if config.EmailConnector.SMTP.TimeoutMs == 0 {
+		config.EmailConnector.SMTP.TimeoutMs = 30000 // 30 seconds default
+	}
+
+	// Variables defaults
+	if config.Variables.MaxVariableSize == 0 {
+		config.Variables.MaxVariableSize = 4 * 1024 * 1024 // 4MB default
+	}
+	if config.Variables.MaxPayloadSize == 0 {
+		config.Variables.MaxPayloadSize = 16 * 1024 * 1024 // 16MB default
+	}
+	if config.Variables.OffloadThreshold == 0 {
+		config.Variables.OffloadThreshold = 256 * 1024 // 256KB default
+	}
+	if config.Variables.KeyPattern == "" {
+		config.Variables.KeyPattern = `^[A-Za-z_][A-Za-z0-9_]*$`
+	}
+	if config.Variables.MaxKeys == 0 {
+		config.Variables.MaxKeys = 1000
+	}
+	if config.Variables.MaxDepth == 0 {
+		config.Variables.MaxDepth = 32
+	}
+	if config.Variables.MaxSerializedSize == 0 {
+		config.Variables.MaxSerializedSize = 1024 * 1024 // 1MB default
+	}
+
+	// Expression defaults
+	if config.Expression.EvaluationLogLevel == "" {
+		config.Expression.EvaluationLogLevel = "debug"
+	}
+
+	// Variable encryption defaults
+	if config.Storage.VariableEncryption.KeyPatterns == nil {
+		config.Storage.VariableEncryption.KeyPatterns = []string{"password", "secret", "token"}
+	}
+
+	// Archive defaults
+	if config.Archive.RedactKeyPatterns == nil {
+		config.Archive.RedactKeyPatterns = []string{"password", "secret", "token"}
+	}
+	if config.Archive.Retention.Mode == "" {
+		config.Archive.Retention.Mode = "delete"
+	}
+	if config.Archive.Retention.IntervalMinutes == 0 {
+		config.Archive.Retention.IntervalMinutes = 60
+	}
+	if config.Archive.Retention.BatchSize == 0 {
+		config.Archive.Retention.BatchSize = 100
+	}
+
+	// Object storage defaults, legacy archive destination is taken over when set
+	if config.ObjectStorage.Type == "" && config.Archive.Destination.Type != "" {
+		config.ObjectStorage = config.Archive.Destination
+	}
+	if config.ObjectStorage.Type == "" {
+		config.ObjectStorage.Type = "local"
+	}
+	if config.ObjectStorage.LocalDir == "" {
+		config.ObjectStorage.LocalDir = "objects"
+	}
+	if config.ObjectStorage.S3.Region == "" {
+		config.ObjectStorage.S3.Region = "us-east-1"
+	}
+	if config.ObjectStorage.S3.PartSizeMB == 0 {
+		config.ObjectStorage.S3.PartSizeMB = 16
+	}
+	if config.ObjectStorage.S3.TimeoutSeconds == 0 {
+		config.ObjectStorage.S3.TimeoutSeconds = 60
+	}
 
 	// Auth defaults
 	// Auth is disabled by default for backward compatibility
@@ -294,4 +928,9 @@ func resolvePaths(config *Config) {
 	if !filepath.IsAbs(config.BPMN.Path) {
 		config.BPMN.Path = filepath.Join(config.BasePath, config.BPMN.Path)
 	}
+
+	// Resolve object storage directory
+	if !filepath.IsAbs(config.ObjectStorage.LocalDir) {
+		config.ObjectStorage.LocalDir = filepath.Join(config.BasePath, config.ObjectStorage.LocalDir)
+	}
 }
@@ -49,6 +49,9 @@ func (c *Config) LoadFromEnv() {
 			c.RestAPI.Port = port
 		}
 	}
+	if env := os.Getenv("ATOM_REST_API_MODE"); env != "" {
+		c.RestAPI.Mode = strings.ToLower(env)
+	}
 
 	// Database configuration
 	if env := os.Getenv("ATOM_DATABASE_PATH"); env != "" {
@@ -62,6 +65,92 @@ func (c *Config) LoadFromEnv() {
 	if env := os.Getenv("ATOM_STORAGE_TYPE"); env != "" {
 		c.Storage.Type = env
 	}
+	if env := os.Getenv("ATOM_STORAGE_SYSTEM_EVENTS_RETENTION_DAYS"); env != "" {
+		if days, err := strconv.Atoi(env); err == nil {
+			c.Storage.SystemEventsRetentionDays = days
+		}
+	}
+	if env := os.Getenv("ATOM_STORAGE_VARIABLE_ENCRYPTION_ENABLED"); env != "" {
+		c.Storage.VariableEncryption.Enabled = strings.ToLower(env) == "true"
+	}
+	if env := os.Getenv("ATOM_STORAGE_VARIABLE_ENCRYPTION_KEY"); env != "" {
+		c.Storage.VariableEncryption.Key = env
+	}
+	if env := os.Getenv("ATOM_STORAGE_VARIABLE_ENCRYPTION_KEY_PATTERNS"); env != "" {
+		c.Storage.VariableEncryption.KeyPatterns = splitEnvList(env)
+	}
+
+	// BPMN configuration
+	if env := os.Getenv("ATOM_BPMN_MAX_CONCURRENT_PARSES"); env != "" {
+		if limit, err := strconv.Atoi(env); err == nil {
+			c.BPMN.MaxConcurrentParses = limit
+		}
+	}
+	if env := os.Getenv("ATOM_BPMN_PARSE_QUEUE_SIZE"); env != "" {
+		if size, err := strconv.Atoi(env); err == nil {
+			c.BPMN.ParseQueueSize = size
+		}
+	}
+	if env := os.Getenv("ATOM_BPMN_PARSE_QUEUE_TIMEOUT_MS"); env != "" {
+		if timeout, err := strconv.Atoi(env); err == nil {
+			c.BPMN.ParseQueueTimeoutMs = timeout
+		}
+	}
+
+	// Engine configuration
+	if env := os.Getenv("ATOM_ENGINE_TOKEN_WORKERS"); env != "" {
+		if workers, err := strconv.Atoi(env); err == nil {
+			c.Engine.TokenWorkers = workers
+		}
+	}
+	if env := os.Getenv("ATOM_ENGINE_TOKEN_QUEUE_SIZE"); env != "" {
+		if size, err := strconv.Atoi(env); err == nil {
+			c.Engine.TokenQueueSize = size
+		}
+	}
+	if env := os.Getenv("ATOM_ENGINE_NODE_ID"); env != "" {
+		if nodeID, err := strconv.Atoi(env); err == nil {
+			c.Engine.NodeID = nodeID
+		}
+	}
+	if env := os.Getenv("ATOM_ENGINE_ULID_IDS"); env != "" {
+		c.Engine.ULIDIDs = strings.ToLower(env) == "true"
+	}
+	if env := os.Getenv("ATOM_ENGINE_BATCH_START_WORKERS"); env != "" {
+		if workers, err := strconv.Atoi(env); err == nil {
+			c.Engine.BatchStartWorkers = workers
+		}
+	}
+	if env := os.Getenv("ATOM_ENGINE_BATCH_START_MAX_ITEMS"); env != "" {
+		if maxItems, err := strconv.Atoi(env); err == nil {
+			c.Engine.BatchStartMaxItems = maxItems
+		}
+	}
+
+	// Circuit breaker configuration
+	if env := os.Getenv("ATOM_CIRCUIT_BREAKER_FAILURE_THRESHOLD"); env != "" {
+		if threshold, err := strconv.Atoi(env); err == nil {
+			c.CircuitBreaker.FailureThreshold = threshold
+		}
+	}
+	if env := os.Getenv("ATOM_CIRCUIT_BREAKER_COOLDOWN_MS"); env != "" {
+		if cooldown, err := strconv.Atoi(env); err == nil {
+			c.CircuitBreaker.CooldownMs = cooldown
+		}
+	}
+
+	// Kafka bridge configuration
+	c.loadKafkaBridgeFromEnv()
+	c.loadNATSBridgeFromEnv()
+
+	// Tracing configuration
+	c.loadTracingFromEnv()
+
+	// Object storage configuration
+	c.loadObjectStorageFromEnv()
+
+	// Email connector configuration
+	c.loadEmailConnectorFromEnv()
 
 	// Logger configuration
 	if env := os.Getenv("ATOM_LOGGER_LEVEL"); env != "" {
@@ -91,6 +180,349 @@ func (c *Config) LoadFromEnv() {
 	if env := os.Getenv("ATOM_LOGGER_ENABLE_CONSOLE"); env != "" {
 		c.Logger.EnableConsole = strings.ToLower(env) == "true"
 	}
+	if env := os.Getenv("ATOM_LOGGER_REDACT_KEY_PATTERNS"); env != "" {
+		c.Logger.RedactKeyPatterns = splitEnvList(env)
+	}
+	if env := os.Getenv("ATOM_LOGGER_OMIT_VARIABLE_VALUES"); env != "" {
+		c.Logger.OmitVariableValues = strings.ToLower(env) == "true"
+	}
+
+	// Expression configuration
+	if env := os.Getenv("ATOM_EXPRESSION_EVALUATION_LOG_LEVEL"); env != "" {
+		c.Expression.EvaluationLogLevel = env
+	}
+	if env := os.Getenv("ATOM_EXPRESSION_LOG_FULL_CONTEXT"); env != "" {
+		c.Expression.LogFullContext = strings.ToLower(env) == "true"
+	}
+
+	// Variables configuration
+	if env := os.Getenv("ATOM_VARIABLES_MAX_VARIABLE_SIZE"); env != "" {
+		if size, err := strconv.ParseInt(env, 10, 64); err == nil {
+			c.Variables.MaxVariableSize = size
+		}
+	}
+	if env := os.Getenv("ATOM_VARIABLES_MAX_PAYLOAD_SIZE"); env != "" {
+		if size, err := strconv.ParseInt(env, 10, 64); err == nil {
+			c.Variables.MaxPayloadSize = size
+		}
+	}
+	if env := os.Getenv("ATOM_VARIABLES_OFFLOAD_ENABLED"); env != "" {
+		c.Variables.OffloadEnabled = strings.ToLower(env) == "true"
+	}
+	if env := os.Getenv("ATOM_VARIABLES_OFFLOAD_THRESHOLD"); env != "" {
+		if size, err := strconv.ParseInt(env, 10, 64); err == nil {
+			c.Variables.OffloadThreshold = size
+		}
+	}
+	if env := os.Getenv("ATOM_VARIABLES_KEY_PATTERN"); env != "" {
+		c.Variables.KeyPattern = env
+	}
+	if env := os.Getenv("ATOM_VARIABLES_MAX_KEYS"); env != "" {
+		if count, err := strconv.Atoi(env); err == nil {
+			c.Variables.MaxKeys = count
+		}
+	}
+	if env := os.Getenv("ATOM_VARIABLES_MAX_DEPTH"); env != "" {
+		if depth, err := strconv.Atoi(env); err == nil {
+			c.Variables.MaxDepth = depth
+		}
+	}
+	if env := os.Getenv("ATOM_VARIABLES_MAX_SERIALIZED_SIZE"); env != "" {
+		if size, err := strconv.ParseInt(env, 10, 64); err == nil {
+			c.Variables.MaxSerializedSize = size
+		}
+	}
+
+	// Messages configuration
+	if env := os.Getenv("ATOM_MESSAGES_BUFFER_MAX_MESSAGES"); env != "" {
+		if count, err := strconv.Atoi(env); err == nil {
+			c.Messages.Buffer.MaxMessages = count
+		}
+	}
+	if env := os.Getenv("ATOM_MESSAGES_BUFFER_EVICTION_POLICY"); env != "" {
+		c.Messages.Buffer.EvictionPolicy = env
+	}
+
+	// Archive configuration
+	if env := os.Getenv("ATOM_ARCHIVE_REDACT_KEY_PATTERNS"); env != "" {
+		var patterns []string
+		for _, pattern := range strings.Split(env, ",") {
+			if pattern = strings.TrimSpace(pattern); pattern != "" {
+				patterns = append(patterns, pattern)
+			}
+		}
+		c.Archive.RedactKeyPatterns = patterns
+	}
+	if env := os.Getenv("ATOM_ARCHIVE_IMPORT_ENABLED"); env != "" {
+		c.Archive.ImportEnabled = strings.ToLower(env) == "true"
+	}
+	if env := os.Getenv("ATOM_ARCHIVE_RETENTION_COMPLETED_INSTANCE_DAYS"); env != "" {
+		if days, err := strconv.Atoi(env); err == nil {
+			c.Archive.Retention.CompletedInstanceDays = days
+		}
+	}
+	if env := os.Getenv("ATOM_ARCHIVE_RETENTION_MODE"); env != "" {
+		c.Archive.Retention.Mode = env
+	}
+}
+
+// loadEmailConnectorFromEnv loads email connector configuration from environment variables
+// Загружает конфигурацию email коннектора из переменных окружения
+func (c *Config) loadEmailConnectorFromEnv() {
+	connector := &c.EmailConnector
+	if env := os.Getenv("ATOM_EMAIL_CONNECTOR_ENABLED"); env != "" {
+		connector.Enabled = strings.ToLower(env) == "true"
+	}
+	if env := os.Getenv("ATOM_EMAIL_CONNECTOR_DRY_RUN"); env != "" {
+		connector.DryRun = strings.ToLower(env) == "true"
+	}
+	if env := os.Getenv("ATOM_EMAIL_CONNECTOR_SMTP_HOST"); env != "" {
+		connector.SMTP.Host = env
+	}
+	if env := os.Getenv("ATOM_EMAIL_CONNECTOR_SMTP_PORT"); env != "" {
+		if port, err := strconv.Atoi(env); err == nil {
+			connector.SMTP.Port = port
+		}
+	}
+	if env := os.Getenv("ATOM_EMAIL_CONNECTOR_SMTP_USERNAME"); env != "" {
+		connector.SMTP.Username = env
+	}
+	if env := os.Getenv("ATOM_EMAIL_CONNECTOR_SMTP_PASSWORD"); env != "" {
+		connector.SMTP.Password = env
+	}
+	if env := os.Getenv("ATOM_EMAIL_CONNECTOR_SMTP_FROM"); env != "" {
+		connector.SMTP.From = env
+	}
+	if env := os.Getenv("ATOM_EMAIL_CONNECTOR_SMTP_TLS"); env != "" {
+		connector.SMTP.TLS = strings.ToLower(env)
+	}
+}
+
+// loadKafkaBridgeFromEnv loads Kafka bridge configuration from environment variables
+// Загружает конфигурацию моста Kafka из переменных окружения
+func (c *Config) loadKafkaBridgeFromEnv() {
+	bridge := &c.KafkaBridge
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_ENABLED"); env != "" {
+		bridge.Enabled = strings.ToLower(env) == "true"
+	}
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_BROKERS"); env != "" {
+		bridge.Brokers = splitEnvList(env)
+	}
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_CLIENT_ID"); env != "" {
+		bridge.ClientID = env
+	}
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_CONSUMER_GROUP"); env != "" {
+		bridge.ConsumerGroup = env
+	}
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_WORKER_NAME"); env != "" {
+		bridge.WorkerName = env
+	}
+	// Job topics are given as type=topic pairs separated by commas
+	// Топики job'ов задаются парами type=topic через запятую
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_JOB_TOPICS"); env != "" {
+		topics := make(map[string]string)
+		for _, pair := range splitEnvList(env) {
+			jobType, topic, found := strings.Cut(pair, "=")
+			if found && strings.TrimSpace(jobType) != "" && strings.TrimSpace(topic) != "" {
+				topics[strings.TrimSpace(jobType)] = strings.TrimSpace(topic)
+			}
+		}
+		bridge.JobTopics = topics
+	}
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_RESPONSE_TOPIC"); env != "" {
+		bridge.ResponseTopic = env
+	}
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_EVENTS_TOPIC"); env != "" {
+		bridge.EventsTopic = env
+	}
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_EVENT_TYPES"); env != "" {
+		bridge.EventTypes = splitEnvList(env)
+	}
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_POLL_INTERVAL_MS"); env != "" {
+		if interval, err := strconv.Atoi(env); err == nil {
+			bridge.PollIntervalMs = interval
+		}
+	}
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_MAX_JOBS"); env != "" {
+		if maxJobs, err := strconv.Atoi(env); err == nil {
+			bridge.MaxJobs = maxJobs
+		}
+	}
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_JOB_TIMEOUT_MS"); env != "" {
+		if timeout, err := strconv.Atoi(env); err == nil {
+			bridge.JobTimeoutMs = timeout
+		}
+	}
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_TLS_ENABLED"); env != "" {
+		bridge.TLS.Enabled = strings.ToLower(env) == "true"
+	}
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_TLS_CA_FILE"); env != "" {
+		bridge.TLS.CAFile = env
+	}
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_TLS_CERT_FILE"); env != "" {
+		bridge.TLS.CertFile = env
+	}
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_TLS_KEY_FILE"); env != "" {
+		bridge.TLS.KeyFile = env
+	}
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_TLS_INSECURE_SKIP_VERIFY"); env != "" {
+		bridge.TLS.InsecureSkipVerify = strings.ToLower(env) == "true"
+	}
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_SASL_MECHANISM"); env != "" {
+		bridge.SASL.Mechanism = strings.ToLower(env)
+	}
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_SASL_USERNAME"); env != "" {
+		bridge.SASL.Username = env
+	}
+	if env := os.Getenv("ATOM_KAFKA_BRIDGE_SASL_PASSWORD"); env != "" {
+		bridge.SASL.Password = env
+	}
+}
+
+// loadNATSBridgeFromEnv loads NATS bridge configuration from environment variables
+// Загружает конфигурацию моста NATS из переменных окружения
+func (c *Config) loadNATSBridgeFromEnv() {
+	bridge := &c.NATSBridge
+	if env := os.Getenv("ATOM_NATS_BRIDGE_ENABLED"); env != "" {
+		bridge.Enabled = strings.ToLower(env) == "true"
+	}
+	if env := os.Getenv("ATOM_NATS_BRIDGE_SERVERS"); env != "" {
+		bridge.Servers = splitEnvList(env)
+	}
+	if env := os.Getenv("ATOM_NATS_BRIDGE_NAME"); env != "" {
+		bridge.Name = env
+	}
+	if env := os.Getenv("ATOM_NATS_BRIDGE_EVENTS_PREFIX"); env != "" {
+		bridge.EventsPrefix = env
+	}
+	if env := os.Getenv("ATOM_NATS_BRIDGE_EVENT_TYPES"); env != "" {
+		bridge.EventTypes = splitEnvList(env)
+	}
+	if env := os.Getenv("ATOM_NATS_BRIDGE_MESSAGES_SUBJECT"); env != "" {
+		bridge.MessagesSubject = env
+	}
+	if env := os.Getenv("ATOM_NATS_BRIDGE_QUEUE_GROUP"); env != "" {
+		bridge.QueueGroup = env
+	}
+	if env := os.Getenv("ATOM_NATS_BRIDGE_RECONNECT_WAIT_MS"); env != "" {
+		if wait, err := strconv.Atoi(env); err == nil {
+			bridge.ReconnectWaitMs = wait
+		}
+	}
+	if env := os.Getenv("ATOM_NATS_BRIDGE_TLS_ENABLED"); env != "" {
+		bridge.TLS.Enabled = strings.ToLower(env) == "true"
+	}
+	if env := os.Getenv("ATOM_NATS_BRIDGE_TLS_CA_FILE"); env != "" {
+		bridge.TLS.CAFile = env
+	}
+	if env := os.Getenv("ATOM_NATS_BRIDGE_TLS_CERT_FILE"); env != "" {
+		bridge.TLS.CertFile = env
+	}
+	if env := os.Getenv("ATOM_NATS_BRIDGE_TLS_KEY_FILE"); env != "" {
+		bridge.TLS.KeyFile = env
+	}
+	if env := os.Getenv("ATOM_NATS_BRIDGE_TLS_INSECURE_SKIP_VERIFY"); env != "" {
+		bridge.TLS.InsecureSkipVerify = strings.ToLower(env) == "true"
+	}
+	if env := os.Getenv("ATOM_NATS_BRIDGE_AUTH_USERNAME"); env != "" {
+		bridge.Auth.Username = env
+	}
+	if env := os.Getenv("ATOM_NATS_BRIDGE_AUTH_PASSWORD"); env != "" {
+		bridge.Auth.Password = env
+	}
+	if env := os.Getenv("ATOM_NATS_BRIDGE_AUTH_TOKEN"); env != "" {
+		bridge.Auth.Token = env
+	}
+	if env := os.Getenv("ATOM_NATS_BRIDGE_AUTH_CREDENTIALS_FILE"); env != "" {
+		bridge.Auth.CredentialsFile = env
+	}
+}
+
+// loadTracingFromEnv loads tracing configuration from environment variables
+// Загружает конфигурацию трассировки из переменных окружения
+func (c *Config) loadTracingFromEnv() {
+	tracing := &c.Tracing
+	if env := os.Getenv("ATOM_TRACING_ENABLED"); env != "" {
+		tracing.Enabled = strings.ToLower(env) == "true"
+	}
+	if env := os.Getenv("ATOM_TRACING_PROTOCOL"); env != "" {
+		tracing.Protocol = strings.ToLower(env)
+	}
+	if env := os.Getenv("ATOM_TRACING_ENDPOINT"); env != "" {
+		tracing.Endpoint = env
+	}
+	if env := os.Getenv("ATOM_TRACING_URL_PATH"); env != "" {
+		tracing.URLPath = env
+	}
+	if env := os.Getenv("ATOM_TRACING_INSECURE"); env != "" {
+		tracing.Insecure = strings.ToLower(env) == "true"
+	}
+	if env := os.Getenv("ATOM_TRACING_SERVICE_NAME"); env != "" {
+		tracing.ServiceName = env
+	}
+	if env := os.Getenv("ATOM_TRACING_SAMPLE_RATIO"); env != "" {
+		if ratio, err := strconv.ParseFloat(env, 64); err == nil {
+			tracing.SampleRatio = ratio
+		}
+	}
+	if env := os.Getenv("ATOM_TRACING_TIMEOUT_MS"); env != "" {
+		if timeout, err := strconv.Atoi(env); err == nil {
+			tracing.TimeoutMs = timeout
+		}
+	}
+}
+
+// loadObjectStorageFromEnv loads object storage configuration from environment variables
+// Legacy ATOM_ARCHIVE_* variables are still applied, ATOM_OBJECT_STORAGE_* take precedence
+// Загружает конфигурацию объектного хранилища из переменных окружения
+// Устаревшие переменные ATOM_ARCHIVE_* все еще применяются, ATOM_OBJECT_STORAGE_* имеют приоритет
+func (c *Config) loadObjectStorageFromEnv() {
+	store := &c.ObjectStorage
+	fields := []struct {
+		names  []string
+		target *string
+	}{
+		{[]string{"ATOM_ARCHIVE_DESTINATION_TYPE", "ATOM_OBJECT_STORAGE_TYPE"}, &store.Type},
+		{[]string{"ATOM_ARCHIVE_DESTINATION_LOCAL_DIR", "ATOM_OBJECT_STORAGE_LOCAL_DIR"}, &store.LocalDir},
+		{[]string{"ATOM_ARCHIVE_S3_ENDPOINT", "ATOM_OBJECT_STORAGE_S3_ENDPOINT"}, &store.S3.Endpoint},
+		{[]string{"ATOM_ARCHIVE_S3_REGION", "ATOM_OBJECT_STORAGE_S3_REGION"}, &store.S3.Region},
+		{[]string{"ATOM_ARCHIVE_S3_BUCKET", "ATOM_OBJECT_STORAGE_S3_BUCKET"}, &store.S3.Bucket},
+		{[]string{"ATOM_ARCHIVE_S3_PREFIX", "ATOM_OBJECT_STORAGE_S3_PREFIX"}, &store.S3.Prefix},
+		{[]string{"ATOM_ARCHIVE_S3_ACCESS_KEY", "ATOM_OBJECT_STORAGE_S3_ACCESS_KEY"}, &store.S3.AccessKey},
+		{[]string{"ATOM_ARCHIVE_S3_SECRET_KEY", "ATOM_OBJECT_STORAGE_S3_SECRET_KEY"}, &store.S3.SecretKey},
+		{[]string{"ATOM_OBJECT_STORAGE_S3_SSE"}, &store.S3.SSE},
+		{[]string{"ATOM_OBJECT_STORAGE_S3_SSE_KMS_KEY_ID"}, &store.S3.SSEKMSKeyID},
+	}
+	for _, item := range fields {
+		for _, name := range item.names {
+			if env := os.Getenv(name); env != "" {
+				*item.target = env
+			}
+		}
+	}
+
+	if env := os.Getenv("ATOM_OBJECT_STORAGE_S3_PART_SIZE_MB"); env != "" {
+		if size, err := strconv.Atoi(env); err == nil {
+			store.S3.PartSizeMB = size
+		}
+	}
+	if env := os.Getenv("ATOM_OBJECT_STORAGE_S3_TIMEOUT_SECONDS"); env != "" {
+		if timeout, err := strconv.Atoi(env); err == nil {
+			store.S3.TimeoutSeconds = timeout
+		}
+	}
+}
+
+// splitEnvList splits comma separated environment value skipping empty items
+// Разделяет значение переменной окружения по запятым, пропуская пустые элементы
+func splitEnvList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
 }
 
 // GetConfigPath returns configuration file path from environment or searches in common locations
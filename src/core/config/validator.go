@@ -11,7 +11,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
+	"time"
 )
 
 // Validate validates the configuration
@@ -41,6 +43,58 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("logger validation failed: %w", err)
 	}
 
+	if err := c.validateBPMN(); err != nil {
+		return fmt.Errorf("bpmn validation failed: %w", err)
+	}
+
+	if err := c.validateEngine(); err != nil {
+		return fmt.Errorf("engine validation failed: %w", err)
+	}
+
+	if err := c.validateCircuitBreaker(); err != nil {
+		return fmt.Errorf("circuit breaker validation failed: %w", err)
+	}
+
+	if err := c.validateKafkaBridge(); err != nil {
+		return fmt.Errorf("kafka bridge validation failed: %w", err)
+	}
+
+	if err := c.validateTracing(); err != nil {
+		return fmt.Errorf("tracing validation failed: %w", err)
+	}
+
+	if err := c.validateEmailConnector(); err != nil {
+		return fmt.Errorf("email connector validation failed: %w", err)
+	}
+
+	if err := c.validateExpression(); err != nil {
+		return fmt.Errorf("expression validation failed: %w", err)
+	}
+
+	if err := c.validateVariables(); err != nil {
+		return fmt.Errorf("variables validation failed: %w", err)
+	}
+
+	if err := c.validateMessages(); err != nil {
+		return fmt.Errorf("messages validation failed: %w", err)
+	}
+
+	if err := c.validateArchive(); err != nil {
+		return fmt.Errorf("archive validation failed: %w", err)
+	}
+
+	if err := c.validateObjectStorage(); err != nil {
+		return fmt.Errorf("object storage validation failed: %w", err)
+	}
+
+	if err := c.validateSimulation(); err != nil {
+		return fmt.Errorf("simulation validation failed: %w", err)
+	}
+
+	if err := c.validateAudit(); err != nil {
+		return fmt.Errorf("audit validation failed: %w", err)
+	}
+
 	if err := c.validatePortConflicts(); err != nil {
 		return fmt.Errorf("port conflicts detected: %w", err)
 	}
@@ -90,9 +144,334 @@ func (c *Config) validateRestAPI() error {
 		return fmt.Errorf("rest_api host cannot be empty")
 	}
 
+	switch c.RestAPI.Mode {
+	case "release", "debug", "test":
+	default:
+		return fmt.Errorf("rest_api mode must be release, debug or test, got %q", c.RestAPI.Mode)
+	}
+	if c.RestAPI.AccessLog.SlowRequestThresholdMs < 0 {
+		return fmt.Errorf("rest_api access_log slow_request_threshold_ms cannot be negative, got %d",
+			c.RestAPI.AccessLog.SlowRequestThresholdMs)
+	}
+
+	return nil
+}
+
+// validateBPMN validates BPMN parser configuration
+// Валидирует конфигурацию BPMN парсера
+func (c *Config) validateBPMN() error {
+	if c.BPMN.MaxConcurrentParses < 1 {
+		return fmt.Errorf("max_concurrent_parses must be at least 1, got %d", c.BPMN.MaxConcurrentParses)
+	}
+	if c.BPMN.ParseQueueSize < 1 {
+		return fmt.Errorf("parse_queue_size must be at least 1, got %d", c.BPMN.ParseQueueSize)
+	}
+	if c.BPMN.ParseQueueTimeoutMs < 1 {
+		return fmt.Errorf("parse_queue_timeout_ms must be at least 1, got %d", c.BPMN.ParseQueueTimeoutMs)
+	}
+
 	return nil
 }
 
+// validateEngine validates process execution engine configuration
+// Валидирует конфигурацию движка выполнения процессов
+func (c *Config) validateEngine() error {
+	if c.Engine.TokenWorkers < 1 {
+		return fmt.Errorf("token_workers must be at least 1, got %d", c.Engine.TokenWorkers)
+	}
+	if c.Engine.TokenQueueSize < 1 {
+		return fmt.Errorf("token_queue_size must be at least 1, got %d", c.Engine.TokenQueueSize)
+	}
+	if c.Engine.NodeID < 0 || c.Engine.NodeID > 1023 {
+		return fmt.Errorf("node_id must be between 0 and 1023, got %d", c.Engine.NodeID)
+	}
+	if c.Engine.BatchStartWorkers < 1 {
+		return fmt.Errorf("batch_start_workers must be at least 1, got %d", c.Engine.BatchStartWorkers)
+	}
+	if c.Engine.BatchStartMaxItems < 1 {
+		return fmt.Errorf("batch_start_max_items must be at least 1, got %d", c.Engine.BatchStartMaxItems)
+	}
+
+	return nil
+}
+
+// validateSimulation validates simulation mode, enabled mode also requires explicit permission in environment
+// so that config copied from test setup does not start simulated clock in production
+// Валидирует режим симуляции, включенный режим также требует явного разрешения в окружении,
+// чтобы конфигурация, скопированная из тестовой среды, не запустила симулированные часы в production
+func (c *Config) validateSimulation() error {
+	if !c.Simulation.Enabled {
+		return nil
+	}
+	if os.Getenv(SimulationAllowedEnv) != "true" {
+		return fmt.Errorf("simulation is enabled but %s=true is not set in environment", SimulationAllowedEnv)
+	}
+	if c.Simulation.StartTime != "" {
+		if _, err := time.Parse(time.RFC3339, c.Simulation.StartTime); err != nil {
+			return fmt.Errorf("start_time must be RFC 3339 time: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// validateAudit validates audit sampling of auth configuration
+// Валидирует сэмплирование аудита в конфигурации auth
+func (c *Config) validateAudit() error {
+	return c.Auth.Audit.Validate()
+}
+
+// Validate checks success sample rate of audit configuration
+// Проверяет долю сэмплирования успешных аутентификаций в конфигурации аудита
+func (a AuditConfig) Validate() error {
+	if a.SuccessSampleRate != nil && (*a.SuccessSampleRate < 0 || *a.SuccessSampleRate > 1) {
+		return fmt.Errorf("success_sample_rate must be between 0 and 1, got %g", *a.SuccessSampleRate)
+	}
+
+	return nil
+}
+
+// validateCircuitBreaker validates component circuit breaker configuration
+// Валидирует конфигурацию circuit breaker компонентов
+func (c *Config) validateCircuitBreaker() error {
+	if c.CircuitBreaker.FailureThreshold < 1 {
+		return fmt.Errorf("failure_threshold must be at least 1, got %d",
+			c.CircuitBreaker.FailureThreshold)
+	}
+	if c.CircuitBreaker.CooldownMs < 1 {
+		return fmt.Errorf("cooldown_ms must be at least 1, got %d", c.CircuitBreaker.CooldownMs)
+	}
+
+	return nil
+}
+
+// validateKafkaBridge validates Kafka bridge configuration, disabled bridge is not checked
+// Валидирует конфигурацию моста Kafka, выключенный мост не проверяется
+func (c *Config) validateKafkaBridge() error {
+	bridge := c.KafkaBridge
+	if !bridge.Enabled {
+		return nil
+	}
+
+	if len(bridge.Brokers) == 0 {
+		return fmt.Errorf("brokers cannot be empty")
+	}
+	if len(bridge.JobTopics) == 0 && bridge.EventsTopic == "" {
+		return fmt.Errorf("job_topics or events_topic must be set")
+	}
+	for jobType, topic := range bridge.JobTopics {
+		if jobType == "" || topic == "" {
+			return fmt.Errorf("job_topics entries must have job type and topic")
+		}
+	}
+	if len(bridge.JobTopics) > 0 && bridge.ResponseTopic == "" {
+		return fmt.Errorf("response_topic is required when job_topics is set")
+	}
+	if bridge.PollIntervalMs < 1 {
+		return fmt.Errorf("poll_interval_ms must be at least 1, got %d", bridge.PollIntervalMs)
+	}
+	if bridge.MaxJobs < 1 {
+		return fmt.Errorf("max_jobs must be at least 1, got %d", bridge.MaxJobs)
+	}
+	if bridge.JobTimeoutMs < 1 {
+		return fmt.Errorf("job_timeout_ms must be at least 1, got %d", bridge.JobTimeoutMs)
+	}
+	if (bridge.TLS.CertFile == "") != (bridge.TLS.KeyFile == "") {
+		return fmt.Errorf("tls.cert_file and tls.key_file must be set together")
+	}
+
+	switch bridge.SASL.Mechanism {
+	case "":
+	case "plain", "scram-sha-256", "scram-sha-512":
+		if bridge.SASL.Username == "" {
+			return fmt.Errorf("sasl.username is required for mechanism %s", bridge.SASL.Mechanism)
+		}
+	default:
+		return fmt.Errorf("sasl.mechanism must be plain, scram-sha-256 or scram-sha-512, got %q",
+			bridge.SASL.Mechanism)
+	}
+
+	return nil
+}
+
+// validateExpression validates expression engine configuration
+// Валидирует конфигурацию движка выражений
+func (c *Config) validateExpression() error {
+	validLevels := []string{"debug", "info", "off"}
+	for _, level := range validLevels {
+		if strings.ToLower(c.Expression.EvaluationLogLevel) == level {
+			return nil
+		}
+	}
+	return fmt.Errorf("evaluation_log_level must be one of %v, got %s", validLevels, c.Expression.EvaluationLogLevel)
+}
+
+// validateTracing validates tracing configuration, disabled tracing is not checked
+// Валидирует конфигурацию трассировки, выключенная трассировка не проверяется
+func (c *Config) validateTracing() error {
+	tracing := c.Tracing
+	if !tracing.Enabled {
+		return nil
+	}
+
+	if tracing.Protocol != "grpc" && tracing.Protocol != "http" {
+		return fmt.Errorf("protocol must be grpc or http, got %q", tracing.Protocol)
+	}
+	if tracing.SampleRatio < 0 || tracing.SampleRatio > 1 {
+		return fmt.Errorf("sample_ratio must be between 0 and 1, got %v", tracing.SampleRatio)
+	}
+	if tracing.TimeoutMs < 1 {
+		return fmt.Errorf("timeout_ms must be at least 1, got %d", tracing.TimeoutMs)
+	}
+	if tracing.URLPath != "" && tracing.Protocol != "http" {
+		return fmt.Errorf("url_path is supported by http protocol only")
+	}
+	return nil
+}
+
+// validateEmailConnector validates email connector configuration, disabled connector is not checked
+// Валидирует конфигурацию email коннектора, выключенный коннектор не проверяется
+func (c *Config) validateEmailConnector() error {
+	connector := c.EmailConnector
+	if !connector.Enabled {
+		return nil
+	}
+
+	if connector.JobType == "" {
+		return fmt.Errorf("job_type cannot be empty")
+	}
+	if connector.PollIntervalMs < 1 {
+		return fmt.Errorf("poll_interval_ms must be at least 1, got %d", connector.PollIntervalMs)
+	}
+	if connector.MaxJobs < 1 {
+		return fmt.Errorf("max_jobs must be at least 1, got %d", connector.MaxJobs)
+	}
+	if connector.JobTimeoutMs < 1 {
+		return fmt.Errorf("job_timeout_ms must be at least 1, got %d", connector.JobTimeoutMs)
+	}
+
+	// Dry run never connects, so SMTP server is optional
+	// В режиме dry run соединения нет, поэтому SMTP сервер необязателен
+	if !connector.DryRun && connector.SMTP.Host == "" {
+		return fmt.Errorf("smtp.host is required unless dry_run is enabled")
+	}
+	if connector.SMTP.Port < 1 || connector.SMTP.Port > 65535 {
+		return fmt.Errorf("smtp.port must be between 1 and 65535, got %d", connector.SMTP.Port)
+	}
+	switch connector.SMTP.TLS {
+	case "none", "starttls", "tls":
+	default:
+		return fmt.Errorf("smtp.tls must be none, starttls or tls, got %q", connector.SMTP.TLS)
+	}
+	if connector.SMTP.TimeoutMs < 1 {
+		return fmt.Errorf("smtp.timeout_ms must be at least 1, got %d", connector.SMTP.TimeoutMs)
+	}
+	return nil
+}
+
+// validateArchive validates process archive configuration
+// Валидирует конфигурацию архивов процессов
+func (c *Config) validateArchive() error {
+	for _, pattern := range c.Archive.RedactKeyPatterns {
+		if _, err := regexp.Compile("(?i)" + pattern); err != nil {
+			return fmt.Errorf("invalid redact_key_patterns entry %q: %w", pattern, err)
+		}
+	}
+
+	retention := c.Archive.Retention
+	if retention.CompletedInstanceDays < 0 {
+		return fmt.Errorf("retention.completed_instance_days cannot be negative")
+	}
+	if retention.Mode != "delete" && retention.Mode != "archive" {
+		return fmt.Errorf("retention.mode must be delete or archive, got %q", retention.Mode)
+	}
+	if retention.IntervalMinutes <= 0 {
+		return fmt.Errorf("retention.interval_minutes must be positive")
+	}
+	if retention.BatchSize <= 0 {
+		return fmt.Errorf("retention.batch_size must be positive")
+	}
+
+	return nil
+}
+
+// validateObjectStorage validates object storage configuration
+// Валидирует конфигурацию объектного хранилища
+func (c *Config) validateObjectStorage() error {
+	store := c.ObjectStorage
+	switch store.Type {
+	case "local":
+		if store.LocalDir == "" {
+			return fmt.Errorf("local_dir is required for local object storage")
+		}
+		return nil
+	case "s3":
+	default:
+		return fmt.Errorf("type must be local or s3, got %q", store.Type)
+	}
+
+	s3 := store.S3
+	if s3.Endpoint == "" || s3.Bucket == "" {
+		return fmt.Errorf("s3 endpoint and bucket are required for s3 object storage")
+	}
+	if s3.AccessKey == "" || s3.SecretKey == "" {
+		return fmt.Errorf("s3 access_key and secret_key are required for s3 object storage")
+	}
+	if s3.SSE != "" && s3.SSE != "AES256" && s3.SSE != "aws:kms" {
+		return fmt.Errorf("s3.sse must be empty, AES256 or aws:kms, got %q", s3.SSE)
+	}
+	if s3.SSEKMSKeyID != "" && s3.SSE != "aws:kms" {
+		return fmt.Errorf("s3.sse_kms_key_id requires s3.sse aws:kms")
+	}
+	// S3 rejects multipart parts below 5 MiB except the last one
+	if s3.PartSizeMB < 5 || s3.PartSizeMB > 5120 {
+		return fmt.Errorf("s3.part_size_mb must be between 5 and 5120, got %d", s3.PartSizeMB)
+	}
+	if s3.TimeoutSeconds < 1 {
+		return fmt.Errorf("s3.timeout_seconds must be at least 1, got %d", s3.TimeoutSeconds)
+	}
+	return nil
+}
+
+// validateVariables validates process variable limits configuration
+// Валидирует конфигурацию ограничений переменных процесса
+func (c *Config) validateVariables() error {
+	if c.Variables.MaxVariableSize < 0 || c.Variables.MaxPayloadSize < 0 {
+		return fmt.Errorf("variable size limits cannot be negative")
+	}
+
+	if c.Variables.OffloadEnabled && c.Variables.OffloadThreshold <= 0 {
+		return fmt.Errorf("offload_threshold must be positive when offload is enabled")
+	}
+
+	if c.Variables.MaxKeys < 0 || c.Variables.MaxDepth < 0 || c.Variables.MaxSerializedSize < 0 {
+		return fmt.Errorf("variable validation limits cannot be negative")
+	}
+
+	if _, err := regexp.Compile(c.Variables.KeyPattern); err != nil {
+		return fmt.Errorf("invalid key_pattern %q: %w", c.Variables.KeyPattern, err)
+	}
+
+	return nil
+}
+
+// validateMessages validates message buffer configuration
+// Валидирует конфигурацию буфера сообщений
+func (c *Config) validateMessages() error {
+	if c.Messages.Buffer.MaxMessages < 0 {
+		return fmt.Errorf("buffer max_messages cannot be negative")
+	}
+
+	validPolicies := []string{"reject_new", "evict_oldest"}
+	for _, policy := range validPolicies {
+		if c.Messages.Buffer.EvictionPolicy == policy {
+			return nil
+		}
+	}
+	return fmt.Errorf("buffer eviction_policy must be one of %v, got %s",
+		validPolicies, c.Messages.Buffer.EvictionPolicy)
+}
+
 // validateDatabase validates database configuration
 // Валидирует конфигурацию базы данных
 func (c *Config) validateDatabase() error {
@@ -122,6 +501,21 @@ func (c *Config) validateStorage() error {
 		return fmt.Errorf("storage type must be one of %v, got %s", validTypes, c.Storage.Type)
 	}
 
+	encryption := c.Storage.VariableEncryption
+	if encryption.Enabled && encryption.Key == "" {
+		return fmt.Errorf("variable_encryption.key is required when variable encryption is enabled")
+	}
+	if encryption.Key != "" {
+		if _, err := encryption.DecodeKey(); err != nil {
+			return err
+		}
+	}
+	for _, pattern := range encryption.KeyPatterns {
+		if _, err := regexp.Compile("(?i)" + pattern); err != nil {
+			return fmt.Errorf("invalid variable_encryption.key_patterns entry %q: %w", pattern, err)
+		}
+	}
+
 	return nil
 }
 
@@ -168,6 +562,12 @@ func (c *Config) validateLogger() error {
 		return fmt.Errorf("logger max_backups must be positive, got %d", c.Logger.MaxBackups)
 	}
 
+	for _, pattern := range c.Logger.RedactKeyPatterns {
+		if _, err := regexp.Compile("(?i)" + pattern); err != nil {
+			return fmt.Errorf("invalid logger redact_key_patterns entry %q: %w", pattern, err)
+		}
+	}
+
 	return nil
 }
 
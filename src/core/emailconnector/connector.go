@@ -0,0 +1,225 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package emailconnector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"atom-engine/src/core/config"
+	"atom-engine/src/core/logger"
+	"atom-engine/src/jobs"
+)
+
+// MessageIDVariable is variable job is completed with
+// Переменная, с которой завершается job
+const MessageIDVariable = "messageId"
+
+// JobsClient is subset of jobs component used by connector
+// Подмножество jobs компонента, используемое коннектором
+type JobsClient interface {
+	ActivateJobsWithTimeout(workerName, jobType string, maxJobs int, timeoutMs int32) ([]jobs.JobInfo, error)
+	CompleteJob(jobKey string, variables map[string]interface{}) error
+	FailJob(jobKey string, retries int, errorMessage string) error
+}
+
+// ExpressionEvaluator evaluates FEEL expressions of templates
+// Вычисляет FEEL выражения шаблонов
+type ExpressionEvaluator interface {
+	EvaluateExpressionEngine(expression interface{}, variables map[string]interface{}) (interface{}, error)
+}
+
+// BlobLoader loads offloaded variable values referenced by attachments
+// Загружает вынесенные значения переменных, на которые ссылаются вложения
+type BlobLoader interface {
+	LoadVariableBlob(blobID string) ([]byte, error)
+}
+
+// Connector activates email jobs, sends them over SMTP and completes them with message ID
+// Активирует email job'ы, отправляет их через SMTP и завершает их с ID сообщения
+type Connector struct {
+	cfg         config.EmailConnectorConfig
+	jobs        JobsClient
+	expressions ExpressionEvaluator
+	blobs       BlobLoader
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewConnector creates connector from configuration
+// Создает коннектор из конфигурации
+func NewConnector(
+	cfg config.EmailConnectorConfig,
+	jobsClient JobsClient,
+	expressions ExpressionEvaluator,
+	blobs BlobLoader,
+) (*Connector, error) {
+	if jobsClient == nil {
+		return nil, fmt.Errorf("jobs component is required")
+	}
+	if expressions == nil {
+		return nil, fmt.Errorf("expression component is required")
+	}
+
+	return &Connector{
+		cfg:         cfg,
+		jobs:        jobsClient,
+		expressions: expressions,
+		blobs:       blobs,
+	}, nil
+}
+
+// Start launches job polling loop
+// Запускает цикл опроса job'ов
+func (c *Connector) Start() {
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+
+	c.wg.Add(1)
+	go c.run()
+
+	logger.Info("Email connector started",
+		logger.String("job_type", c.cfg.JobType),
+		logger.String("smtp_host", c.cfg.SMTP.Host),
+		logger.Bool("dry_run", c.cfg.DryRun))
+}
+
+// Stop stops polling loop after current job is handled
+// Jobs activated but not handled stay activated until their lease expires
+// Останавливает цикл опроса после обработки текущего job'а
+// Активированные, но не обработанные job'ы остаются активированными до истечения аренды
+func (c *Connector) Stop() {
+	if c.cancel == nil {
+		return
+	}
+	c.cancel()
+	c.wg.Wait()
+
+	logger.Info("Email connector stopped")
+}
+
+// run activates and handles jobs until connector stops
+// Активирует и обрабатывает job'ы до остановки коннектора
+func (c *Connector) run() {
+	defer c.wg.Done()
+
+	interval := time.Duration(c.cfg.PollIntervalMs) * time.Millisecond
+	for {
+		activated, err := c.jobs.ActivateJobsWithTimeout(
+			c.cfg.WorkerName, c.cfg.JobType, c.cfg.MaxJobs, int32(c.cfg.JobTimeoutMs))
+		if err != nil {
+			logger.Error("Email connector failed to activate jobs",
+				logger.String("job_type", c.cfg.JobType),
+				logger.String("error", err.Error()))
+		}
+
+		for i := range activated {
+			if c.ctx.Err() != nil {
+				return
+			}
+			c.handleJob(&activated[i])
+		}
+
+		// Full batch means more jobs may be waiting, poll again without pause
+		// Полный пакет означает, что могут ожидать еще job'ы, опрашиваем снова без паузы
+		wait := interval
+		if err == nil && len(activated) >= c.cfg.MaxJobs {
+			wait = 0
+		}
+
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// handleJob sends message of job and reports result
+// Transport problems fail job with decremented retries,
+// invalid messages and rejections by server fail it without retries
+// Отправляет сообщение job'а и сообщает результат
+// Проблемы транспорта проваливают job с уменьшением повторов,
+// некорректные сообщения и отказы сервера - без повторов
+func (c *Connector) handleJob(job *jobs.JobInfo) {
+	msg, err := c.buildMessage(job)
+	if err != nil {
+		c.failJob(job, &permanentError{err: err})
+		return
+	}
+
+	if c.cfg.DryRun {
+		logger.Info("Email connector dry run, message not sent",
+			logger.String("job_key", job.Key),
+			logger.String("message_id", msg.ID),
+			logger.String("from", msg.From.String()),
+			logger.Any("to", formatAddresses(msg.To)),
+			logger.Any("cc", formatAddresses(msg.Cc)),
+			logger.Any("bcc", formatAddresses(msg.Bcc)),
+			logger.String("subject", msg.Subject),
+			logger.Int("attachments", len(msg.Attachments)))
+		logger.Debug("Email connector dry run body",
+			logger.String("job_key", job.Key),
+			logger.String("body", msg.Body))
+	} else if err := c.send(msg); err != nil {
+		c.failJob(job, err)
+		return
+	}
+
+	if err := c.jobs.CompleteJob(job.Key, map[string]interface{}{MessageIDVariable: msg.ID}); err != nil {
+		logger.Error("Email connector failed to complete job",
+			logger.String("job_key", job.Key),
+			logger.String("message_id", msg.ID),
+			logger.String("error", err.Error()))
+		return
+	}
+	if c.cfg.DryRun {
+		return
+	}
+
+	logger.Info("Email connector sent message",
+		logger.String("job_key", job.Key),
+		logger.String("message_id", msg.ID),
+		logger.Int("recipients", len(msg.To)+len(msg.Cc)+len(msg.Bcc)))
+}
+
+// failJob fails job, retriable errors decrement retries, permanent errors leave none
+// Проваливает job, повторяемые ошибки уменьшают число повторов, постоянные не оставляют повторов
+func (c *Connector) failJob(job *jobs.JobInfo, cause error) {
+	retries := 0
+	var permanent *permanentError
+	if !errors.As(cause, &permanent) && job.Retries > 0 {
+		retries = job.Retries - 1
+	}
+
+	logger.Warn("Email connector failed to send message",
+		logger.String("job_key", job.Key),
+		logger.Int("retries", retries),
+		logger.Bool("retriable", permanent == nil),
+		logger.String("error", cause.Error()))
+
+	if err := c.jobs.FailJob(job.Key, retries, cause.Error()); err != nil {
+		logger.Error("Email connector failed to fail job",
+			logger.String("job_key", job.Key),
+			logger.String("error", err.Error()))
+	}
+}
+
+// permanentError marks failure retrying cannot fix
+// Помечает ошибку, которую повтор не исправит
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
@@ -0,0 +1,445 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package emailconnector
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"atom-engine/src/core/models"
+	"atom-engine/src/jobs"
+)
+
+// Message fields, read from job variables first and from custom headers of task second
+// Поля сообщения, читаются сначала из переменных job'а, затем из custom headers задачи
+const (
+	FieldFrom        = "from"
+	FieldTo          = "to"
+	FieldCc          = "cc"
+	FieldBcc         = "bcc"
+	FieldSubject     = "subject"
+	FieldBody        = "body"
+	FieldContentType = "contentType" // text or html
+	FieldAttachments = "attachments"
+)
+
+// emailMessage is message resolved from job
+// Сообщение, собранное из job'а
+type emailMessage struct {
+	ID          string
+	From        *mail.Address
+	To          []*mail.Address
+	Cc          []*mail.Address
+	Bcc         []*mail.Address
+	Subject     string
+	Body        string
+	HTML        bool
+	Attachments []attachment
+}
+
+// attachment is file attached to message
+// Файл, прикрепленный к сообщению
+type attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// buildMessage resolves message fields of job
+// Собирает поля сообщения из job'а
+func (c *Connector) buildMessage(job *jobs.JobInfo) (*emailMessage, error) {
+	msg := &emailMessage{}
+
+	from, err := c.field(job, FieldFrom)
+	if err != nil {
+		return nil, err
+	}
+	fromText := formatValue(from)
+	if fromText == "" {
+		fromText = c.cfg.SMTP.From
+	}
+	if fromText == "" {
+		return nil, fmt.Errorf("sender is not set in task and smtp.from")
+	}
+	if msg.From, err = mail.ParseAddress(fromText); err != nil {
+		return nil, fmt.Errorf("invalid sender %q: %w", fromText, err)
+	}
+
+	for _, recipients := range []struct {
+		name   string
+		target *[]*mail.Address
+	}{
+		{FieldTo, &msg.To},
+		{FieldCc, &msg.Cc},
+		{FieldBcc, &msg.Bcc},
+	} {
+		value, err := c.field(job, recipients.name)
+		if err != nil {
+			return nil, err
+		}
+		if *recipients.target, err = parseAddresses(value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", recipients.name, err)
+		}
+	}
+	if len(msg.To)+len(msg.Cc)+len(msg.Bcc) == 0 {
+		return nil, fmt.Errorf("no recipients in to, cc or bcc")
+	}
+
+	subject, err := c.field(job, FieldSubject)
+	if err != nil {
+		return nil, err
+	}
+	msg.Subject = formatValue(subject)
+
+	body, err := c.field(job, FieldBody)
+	if err != nil {
+		return nil, err
+	}
+	msg.Body = formatValue(body)
+
+	contentType, err := c.field(job, FieldContentType)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(formatValue(contentType)) {
+	case "", "text", "plain", "text/plain":
+	case "html", "text/html":
+		msg.HTML = true
+	default:
+		return nil, fmt.Errorf("unsupported content type %q", formatValue(contentType))
+	}
+
+	attachments, err := c.field(job, FieldAttachments)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Attachments, err = c.parseAttachments(attachments); err != nil {
+		return nil, err
+	}
+
+	msg.ID = newMessageID(msg.From.Address)
+	return msg, nil
+}
+
+// field returns message field from job variable or custom header
+// Variable values are used as is, header values are templates evaluated against job variables
+// Возвращает поле сообщения из переменной job'а или custom header
+// Значения переменных используются как есть, значения заголовков - шаблоны, вычисляемые по переменным job'а
+func (c *Connector) field(job *jobs.JobInfo, name string) (interface{}, error) {
+	if value, ok := job.Variables[name]; ok && value != nil {
+		return value, nil
+	}
+
+	header, ok := job.CustomHeaders[name]
+	if !ok || header == "" {
+		return nil, nil
+	}
+
+	value, err := c.render(header, job.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate %s header: %w", name, err)
+	}
+	return value, nil
+}
+
+// render evaluates template
+// Value starting with "=" is single FEEL expression, otherwise ${expression} placeholders are replaced
+// Вычисляет шаблон
+// Значение, начинающееся с "=", - одно FEEL выражение, иначе заменяются подстановки ${expression}
+func (c *Connector) render(template string, variables map[string]interface{}) (interface{}, error) {
+	if strings.HasPrefix(template, "=") {
+		return c.expressions.EvaluateExpressionEngine(template, variables)
+	}
+
+	var builder strings.Builder
+	rest := template
+	for {
+		start := strings.Index(rest, "${")
+		if start < 0 {
+			builder.WriteString(rest)
+			return builder.String(), nil
+		}
+		end := strings.Index(rest[start:], "}")
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated placeholder in %q", template)
+		}
+
+		expression := strings.TrimSpace(rest[start+2 : start+end])
+		value, err := c.expressions.EvaluateExpressionEngine("="+expression, variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to evaluate ${%s}: %w", expression, err)
+		}
+
+		builder.WriteString(rest[:start])
+		builder.WriteString(formatValue(value))
+		rest = rest[start+end+1:]
+	}
+}
+
+// parseAttachments reads list of attachments
+// Item has filename, optional contentType and content given inline or as blob handle
+// Inline content is text unless encoding is "base64"
+// Читает список вложений
+// Элемент содержит filename, необязательный contentType и content, заданный напрямую или ссылкой на blob
+// Содержимое, заданное напрямую, - текст, если encoding не "base64"
+func (c *Connector) parseAttachments(value interface{}) ([]attachment, error) {
+	if value == nil {
+		return nil, nil
+	}
+	items, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("attachments must be list, got %T", value)
+	}
+
+	result := make([]attachment, 0, len(items))
+	for i, item := range items {
+		fields, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("attachment %d must be object, got %T", i, item)
+		}
+
+		filename := formatValue(fields["filename"])
+		if filename == "" {
+			return nil, fmt.Errorf("attachment %d has no filename", i)
+		}
+
+		content := fields["content"]
+		if blobID, ok := models.ParseVariableBlobRef(content); ok {
+			if content, ok = c.loadBlob(blobID); !ok {
+				return nil, fmt.Errorf("attachment %s references missing blob %s", filename, blobID)
+			}
+		}
+
+		var data []byte
+		switch v := content.(type) {
+		case nil:
+			return nil, fmt.Errorf("attachment %s has no content", filename)
+		case string:
+			data = []byte(v)
+			if strings.EqualFold(formatValue(fields["encoding"]), "base64") {
+				decoded, err := base64.StdEncoding.DecodeString(v)
+				if err != nil {
+					return nil, fmt.Errorf("attachment %s has invalid base64 content: %w", filename, err)
+				}
+				data = decoded
+			}
+		default:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("attachment %s has invalid content: %w", filename, err)
+			}
+			data = encoded
+		}
+
+		contentType := formatValue(fields["contentType"])
+		if contentType == "" {
+			contentType = mime.TypeByExtension(filepath.Ext(filename))
+		}
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		result = append(result, attachment{Filename: filename, ContentType: contentType, Data: data})
+	}
+	return result, nil
+}
+
+// loadBlob loads and decodes offloaded value
+// Загружает и декодирует вынесенное значение
+func (c *Connector) loadBlob(blobID string) (interface{}, bool) {
+	if c.blobs == nil {
+		return nil, false
+	}
+	data, err := c.blobs.LoadVariableBlob(blobID)
+	if err != nil {
+		return nil, false
+	}
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// parseAddresses reads addresses from comma or semicolon separated string or list of strings
+// Читает адреса из строки с разделителями запятая или точка с запятой либо из списка строк
+func parseAddresses(value interface{}) ([]*mail.Address, error) {
+	var parts []string
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		for _, item := range v {
+			parts = append(parts, formatValue(item))
+		}
+	default:
+		parts = strings.Split(strings.ReplaceAll(formatValue(v), ";", ","), ",")
+	}
+
+	var addresses []*mail.Address
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		address, err := mail.ParseAddress(part)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", part, err)
+		}
+		addresses = append(addresses, address)
+	}
+	return addresses, nil
+}
+
+// formatAddresses formats addresses for headers and logs
+// Форматирует адреса для заголовков и логов
+func formatAddresses(addresses []*mail.Address) []string {
+	result := make([]string, len(addresses))
+	for i, address := range addresses {
+		result[i] = address.String()
+	}
+	return result
+}
+
+// formatValue formats expression result as text, objects and lists are JSON encoded
+// Форматирует результат выражения как текст, объекты и списки кодируются в JSON
+func formatValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]interface{}, []interface{}:
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(encoded)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// newMessageID generates Message-ID in domain of sender
+// Генерирует Message-ID в домене отправителя
+func newMessageID(sender string) string {
+	domain := "atom-engine"
+	if at := strings.LastIndex(sender, "@"); at >= 0 && at < len(sender)-1 {
+		domain = sender[at+1:]
+	}
+
+	random := make([]byte, 12)
+	_, _ = rand.Read(random)
+	return fmt.Sprintf("<%d.%s@%s>", time.Now().UnixNano(), hex.EncodeToString(random), domain)
+}
+
+// encode renders message as MIME document, bcc recipients are not written to headers
+// Формирует MIME документ сообщения, получатели bcc не попадают в заголовки
+func (m *emailMessage) encode() ([]byte, error) {
+	var buf bytes.Buffer
+
+	writeHeader := func(name, value string) {
+		buf.WriteString(name + ": " + value + "\r\n")
+	}
+	writeHeader("From", m.From.String())
+	if len(m.To) > 0 {
+		writeHeader("To", strings.Join(formatAddresses(m.To), ", "))
+	}
+	if len(m.Cc) > 0 {
+		writeHeader("Cc", strings.Join(formatAddresses(m.Cc), ", "))
+	}
+	writeHeader("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	writeHeader("Date", time.Now().Format(time.RFC1123Z))
+	writeHeader("Message-ID", m.ID)
+	writeHeader("MIME-Version", "1.0")
+
+	bodyType := "text/plain; charset=utf-8"
+	if m.HTML {
+		bodyType = "text/html; charset=utf-8"
+	}
+
+	if len(m.Attachments) == 0 {
+		writeHeader("Content-Type", bodyType)
+		writeHeader("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQuotedPrintable(&buf, m.Body); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	writer := multipart.NewWriter(&buf)
+	writeHeader("Content-Type", mime.FormatMediaType("multipart/mixed", map[string]string{
+		"boundary": writer.Boundary(),
+	}))
+	buf.WriteString("\r\n")
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {bodyType},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := writeQuotedPrintable(part, m.Body); err != nil {
+		return nil, err
+	}
+
+	for _, file := range m.Attachments {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type": {file.ContentType},
+			"Content-Disposition": {mime.FormatMediaType("attachment", map[string]string{
+				"filename": file.Filename,
+			})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		encoded := base64.StdEncoding.EncodeToString(file.Data)
+		for len(encoded) > 76 {
+			if _, err := part.Write([]byte(encoded[:76] + "\r\n")); err != nil {
+				return nil, err
+			}
+			encoded = encoded[76:]
+		}
+		if _, err := part.Write([]byte(encoded + "\r\n")); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeQuotedPrintable writes text in quoted-printable encoding
+// Записывает текст в кодировке quoted-printable
+func writeQuotedPrintable(w io.Writer, text string) error {
+	writer := quotedprintable.NewWriter(w)
+	if _, err := writer.Write([]byte(text)); err != nil {
+		return err
+	}
+	return writer.Close()
+}
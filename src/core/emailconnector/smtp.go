@@ -0,0 +1,139 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package emailconnector
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"time"
+)
+
+// send delivers message to SMTP server
+// Replies with 5xx codes are permanent errors, connection problems and 4xx replies are retriable
+// Доставляет сообщение на SMTP сервер
+// Ответы с кодами 5xx - постоянные ошибки, проблемы соединения и ответы 4xx можно повторить
+func (c *Connector) send(msg *emailMessage) error {
+	data, err := msg.encode()
+	if err != nil {
+		return &permanentError{err: fmt.Errorf("failed to encode message: %w", err)}
+	}
+
+	client, err := c.dial()
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	defer client.Close()
+
+	if err := c.deliver(client, msg, data); err != nil {
+		var reply *textproto.Error
+		if errors.As(err, &reply) && reply.Code >= 500 {
+			return &permanentError{err: err}
+		}
+		return err
+	}
+	return nil
+}
+
+// dial connects to SMTP server with configured TLS mode and authenticates
+// Подключается к SMTP серверу в заданном режиме TLS и проходит аутентификацию
+func (c *Connector) dial() (*smtp.Client, error) {
+	smtpCfg := c.cfg.SMTP
+	timeout := time.Duration(smtpCfg.TimeoutMs) * time.Millisecond
+	addr := net.JoinHostPort(smtpCfg.Host, strconv.Itoa(smtpCfg.Port))
+	tlsConfig := &tls.Config{
+		ServerName:         smtpCfg.Host,
+		InsecureSkipVerify: smtpCfg.InsecureSkipVerify,
+	}
+
+	dialer := &net.Dialer{Timeout: timeout}
+	var conn net.Conn
+	var err error
+	if smtpCfg.TLS == "tls" {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Deadline covers whole session, so stalled server does not block connector
+	// Дедлайн покрывает всю сессию, чтобы зависший сервер не блокировал коннектор
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	client, err := smtp.NewClient(conn, smtpCfg.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	if smtpCfg.TLS == "starttls" {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, fmt.Errorf("server does not support STARTTLS")
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, err
+		}
+	}
+
+	// PlainAuth refuses to send credentials over unencrypted connection to remote host
+	// PlainAuth отказывается передавать учетные данные по незашифрованному соединению к удаленному хосту
+	if smtpCfg.Username != "" {
+		auth := smtp.PlainAuth("", smtpCfg.Username, smtpCfg.Password, smtpCfg.Host)
+		if err := client.Auth(auth); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("authentication failed: %w", err)
+		}
+	}
+
+	return client, nil
+}
+
+// deliver sends envelope and message data over connected client
+// Отправляет конверт и данные сообщения через подключенного клиента
+func (c *Connector) deliver(client *smtp.Client, msg *emailMessage, data []byte) error {
+	if err := client.Mail(msg.From.Address); err != nil {
+		return fmt.Errorf("sender rejected: %w", err)
+	}
+	for _, recipients := range [][]*mail.Address{msg.To, msg.Cc, msg.Bcc} {
+		for _, recipient := range recipients {
+			if err := client.Rcpt(recipient.Address); err != nil {
+				return fmt.Errorf("recipient %s rejected: %w", recipient.Address, err)
+			}
+		}
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("data rejected: %w", err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to write message: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("message rejected: %w", err)
+	}
+
+	// Message is accepted once data is closed, failed QUIT does not make it undelivered
+	// Сообщение принято после закрытия data, неудачный QUIT не делает его недоставленным
+	_ = client.Quit()
+	return nil
+}
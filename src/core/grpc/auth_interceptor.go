@@ -18,6 +18,7 @@ import (
 
 	"atom-engine/src/core/auth"
 	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
 )
 
 // AuthInterceptor provides gRPC authentication interceptor
@@ -234,3 +235,23 @@ func RequirePermission(ctx context.Context, permission string) error {
 
 	return nil
 }
+
+// maskSensitiveVariables hides values of sensitive variables from callers without
+// the sensitive_variables permission, calls without auth result see plaintext
+func maskSensitiveVariables(ctx context.Context, variables map[string]interface{}) map[string]interface{} {
+	authResult, ok := GetAuthResultFromContext(ctx)
+	if !ok || authResult == nil || auth.HasPermission(authResult.Permissions, auth.PermissionSensitiveVariables) {
+		return variables
+	}
+	return models.MaskSensitiveVariables(variables)
+}
+
+// canBroadcastAcrossTenants reports whether caller may publish messages correlating with any tenant
+// and returns caller API key name for audit, calls without auth result are allowed
+func canBroadcastAcrossTenants(ctx context.Context) (bool, string) {
+	authResult, ok := GetAuthResultFromContext(ctx)
+	if !ok || authResult == nil {
+		return true, ""
+	}
+	return auth.HasPermission(authResult.Permissions, auth.PermissionCrossTenantMessages), authResult.APIKeyName
+}
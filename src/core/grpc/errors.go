@@ -0,0 +1,36 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package grpc
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+
+	"atom-engine/src/core/models"
+)
+
+// statusCodeFromError returns gRPC status code of typed component error, fallback for untyped errors
+// Возвращает gRPC код статуса типизированной ошибки компонента, fallback для нетипизированных ошибок
+func statusCodeFromError(err error, fallback codes.Code) codes.Code {
+	switch {
+	case errors.Is(err, models.ErrNotFound):
+		return codes.NotFound
+	case errors.Is(err, models.ErrConflict):
+		return codes.AlreadyExists
+	case errors.Is(err, models.ErrInvalidState):
+		return codes.FailedPrecondition
+	case errors.Is(err, models.ErrTimeout):
+		return codes.DeadlineExceeded
+	case errors.Is(err, models.ErrInvalidArgument):
+		return codes.InvalidArgument
+	default:
+		return fallback
+	}
+}
@@ -46,9 +46,10 @@ func (s *expressionServiceServer) EvaluateExpression(
 	ctx context.Context,
 	req *expressionpb.EvaluateExpressionRequest,
 ) (*expressionpb.EvaluateExpressionResponse, error) {
-	logger.Info("EvaluateExpression request",
-		logger.String("expression", req.Expression),
-		logger.String("context", req.Context))
+	// Evaluation itself is logged by expression component according to config
+	// Само вычисление логируется компонентом выражений согласно конфигурации
+	logger.Debug("EvaluateExpression request",
+		logger.Int("context_size", len(req.Context)))
 
 	// Get expression component
 	expressionComp, err := getExpressionComponent(s.core)
@@ -64,7 +65,6 @@ func (s *expressionServiceServer) EvaluateExpression(
 	if req.Context != "" {
 		if err := json.Unmarshal([]byte(req.Context), &variables); err != nil {
 			logger.Warn("Failed to parse context JSON",
-				logger.String("context", req.Context),
 				logger.String("error", err.Error()))
 			return &expressionpb.EvaluateExpressionResponse{
 				Success:      false,
@@ -112,8 +112,7 @@ func (s *expressionServiceServer) EvaluateExpression(
 		resultType = "null"
 	}
 
-	logger.Info("Expression evaluated successfully",
-		logger.String("expression", req.Expression),
+	logger.Debug("Expression evaluated successfully",
 		logger.String("result_type", resultType))
 
 	return &expressionpb.EvaluateExpressionResponse{
@@ -552,6 +551,53 @@ func (s *expressionServiceServer) EvaluateCondition(
 	}, nil
 }
 
+func (s *expressionServiceServer) EvaluateUnaryTest(
+	ctx context.Context,
+	req *expressionpb.EvaluateUnaryTestRequest,
+) (*expressionpb.EvaluateUnaryTestResponse, error) {
+	expressionComp, err := getExpressionComponent(s.core)
+	if err != nil {
+		return &expressionpb.EvaluateUnaryTestResponse{
+			Success:      false,
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	// Empty input is null
+	var input interface{}
+	if req.Input != "" {
+		if parseErr := json.Unmarshal([]byte(req.Input), &input); parseErr != nil {
+			return &expressionpb.EvaluateUnaryTestResponse{
+				Success:      false,
+				ErrorMessage: "invalid input JSON: " + parseErr.Error(),
+			}, nil
+		}
+	}
+
+	variables := make(map[string]interface{})
+	if req.Context != "" {
+		if parseErr := json.Unmarshal([]byte(req.Context), &variables); parseErr != nil {
+			return &expressionpb.EvaluateUnaryTestResponse{
+				Success:      false,
+				ErrorMessage: "invalid context JSON: " + parseErr.Error(),
+			}, nil
+		}
+	}
+
+	result, evalErr := expressionComp.EvaluateUnaryTest(input, req.Test, variables)
+	if evalErr != nil {
+		return &expressionpb.EvaluateUnaryTestResponse{
+			Success:      false,
+			ErrorMessage: evalErr.Error(),
+		}, nil
+	}
+
+	return &expressionpb.EvaluateUnaryTestResponse{
+		Result:  result,
+		Success: true,
+	}, nil
+}
+
 func (s *expressionServiceServer) ExtractVariables(
 	ctx context.Context,
 	req *expressionpb.ExtractVariablesRequest,
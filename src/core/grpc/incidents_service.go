@@ -88,7 +88,7 @@ func (s *incidentsServiceServer) CreateIncident(
 	}
 
 	// Send JSON message to incidents component through Core
-	if err := s.core.SendMessage("incidents", message); err != nil {
+	if err := s.core.SendMessageWithContext(ctx, "incidents", message); err != nil {
 		logger.Error("Failed to send incident message", logger.String("error", err.Error()))
 		return &incidentspb.CreateIncidentResponse{
 			Incident: nil,
@@ -152,7 +152,7 @@ func (s *incidentsServiceServer) ResolveIncident(
 	}
 
 	// Send JSON message to incidents component through Core
-	if err := s.core.SendMessage("incidents", message); err != nil {
+	if err := s.core.SendMessageWithContext(ctx, "incidents", message); err != nil {
 		logger.Error("Failed to send resolve incident message", logger.String("error", err.Error()))
 		return &incidentspb.ResolveIncidentResponse{
 			Incident: nil,
@@ -196,7 +196,8 @@ func (s *incidentsServiceServer) GetIncident(
 	}
 
 	// Send JSON message to incidents component through Core
-	if err := s.core.SendMessage("incidents", message); err != nil {
+	componentRequestID, err := s.core.SendRequestWithContext(ctx, "incidents", message)
+	if err != nil {
 		logger.Error("Failed to send get incident message", logger.String("error", err.Error()))
 		return &incidentspb.GetIncidentResponse{
 			Incident: nil,
@@ -207,7 +208,7 @@ func (s *incidentsServiceServer) GetIncident(
 
 	// Wait for response from incidents component
 	// Ожидаем ответ от компонента incidents
-	responseJSON, err := s.core.WaitForIncidentsResponse(5000) // 5 second timeout
+	responseJSON, err := s.core.WaitForIncidentsResponse(componentRequestID, 5000) // 5 second timeout
 	if err != nil {
 		logger.Error("Failed to get incidents response", logger.String("error", err.Error()))
 		return &incidentspb.GetIncidentResponse{
@@ -243,6 +244,7 @@ func (s *incidentsServiceServer) GetIncident(
 			ResolvedBy        string                 `json:"resolved_by"`
 			OriginalRetries   int                    `json:"original_retries"`
 			NewRetries        int                    `json:"new_retries"`
+			RootCause         *incidents.RootCause   `json:"root_cause"`
 			Metadata          map[string]interface{} `json:"metadata"`
 		} `json:"data"`
 	}
@@ -280,6 +282,7 @@ func (s *incidentsServiceServer) GetIncident(
 		OriginalRetries:   int32(response.Data.OriginalRetries),
 		NewRetries:        int32(response.Data.NewRetries),
 		ResolvedBy:        response.Data.ResolvedBy,
+		RootCause:         convertRootCauseToProto(response.Data.RootCause),
 	}
 
 	// Convert metadata
@@ -365,7 +368,8 @@ func (s *incidentsServiceServer) ListIncidents(
 	}
 
 	// Send JSON message to incidents component through Core
-	if err := s.core.SendMessage("incidents", message); err != nil {
+	componentRequestID, err := s.core.SendRequestWithContext(ctx, "incidents", message)
+	if err != nil {
 		logger.Error("Failed to send list incidents message", logger.String("error", err.Error()))
 		return &incidentspb.ListIncidentsResponse{
 			Incidents: nil,
@@ -377,7 +381,7 @@ func (s *incidentsServiceServer) ListIncidents(
 
 	// Wait for response from incidents component
 	// Ожидаем ответ от компонента incidents
-	responseJSON, err := s.core.WaitForIncidentsResponse(5000) // 5 second timeout
+	responseJSON, err := s.core.WaitForIncidentsResponse(componentRequestID, 5000) // 5 second timeout
 	if err != nil {
 		logger.Error("Failed to get incidents response", logger.String("error", err.Error()))
 		return &incidentspb.ListIncidentsResponse{
@@ -574,7 +578,8 @@ func (s *incidentsServiceServer) GetIncidentStats(
 	}
 
 	// Send JSON message to incidents component through Core
-	if err := s.core.SendMessage("incidents", message); err != nil {
+	componentRequestID, err := s.core.SendRequestWithContext(ctx, "incidents", message)
+	if err != nil {
 		logger.Error("Failed to send get incident stats message", logger.String("error", err.Error()))
 		return &incidentspb.GetIncidentStatsResponse{
 			Stats: nil,
@@ -585,7 +590,7 @@ func (s *incidentsServiceServer) GetIncidentStats(
 
 	// Wait for response from incidents component
 	// Ожидаем ответ от компонента incidents
-	responseJSON, err := s.core.WaitForIncidentsResponse(5000) // 5 second timeout
+	responseJSON, err := s.core.WaitForIncidentsResponse(componentRequestID, 5000) // 5 second timeout
 	if err != nil {
 		logger.Error("Failed to get incidents response", logger.String("error", err.Error()))
 		return &incidentspb.GetIncidentStatsResponse{
@@ -668,6 +673,8 @@ func convertProtoIncidentType(protoType incidentspb.IncidentType) string {
 		return "MESSAGE_ERROR"
 	case incidentspb.IncidentType_INCIDENT_TYPE_SYSTEM_ERROR:
 		return "SYSTEM_ERROR"
+	case incidentspb.IncidentType_INCIDENT_TYPE_SLA_BREACH:
+		return "SLA_BREACH"
 	default:
 		return "SYSTEM_ERROR"
 	}
@@ -739,11 +746,41 @@ func convertStringToIncidentType(typeStr string) incidentspb.IncidentType {
 		return incidentspb.IncidentType_INCIDENT_TYPE_MESSAGE_ERROR
 	case "SYSTEM_ERROR":
 		return incidentspb.IncidentType_INCIDENT_TYPE_SYSTEM_ERROR
+	case "SLA_BREACH":
+		return incidentspb.IncidentType_INCIDENT_TYPE_SLA_BREACH
 	default:
 		return incidentspb.IncidentType_INCIDENT_TYPE_SYSTEM_ERROR
 	}
 }
 
+// convertRootCauseToProto converts incident root cause to protobuf, nil stays nil
+func convertRootCauseToProto(rootCause *incidents.RootCause) *incidentspb.IncidentRootCause {
+	if rootCause == nil {
+		return nil
+	}
+
+	result := &incidentspb.IncidentRootCause{
+		ElementId:    rootCause.ElementID,
+		ElementType:  rootCause.ElementType,
+		ErrorType:    rootCause.ErrorType,
+		ErrorCode:    rootCause.ErrorCode,
+		ErrorMessage: rootCause.ErrorMessage,
+		JobKey:       rootCause.JobKey,
+		JobType:      rootCause.JobType,
+		WorkerId:     rootCause.WorkerID,
+		Retries:      int32(rootCause.Retries),
+		Expression:   rootCause.Expression,
+		Target:       rootCause.Target,
+	}
+	for _, event := range rootCause.Timeline {
+		result.Timeline = append(result.Timeline, &incidentspb.RootCauseEvent{
+			Event:     event.Event,
+			Timestamp: timestamppb.New(event.Timestamp),
+		})
+	}
+	return result
+}
+
 // convertStringToIncidentStatus converts string to protobuf incident status
 func convertStringToIncidentStatus(statusStr string) incidentspb.IncidentStatus {
 	switch statusStr {
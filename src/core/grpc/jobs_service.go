@@ -13,6 +13,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"time"
 
 	"atom-engine/proto/jobs/jobspb"
 	"atom-engine/src/core/logger"
@@ -68,6 +69,7 @@ func (s *jobsServiceServer) CreateJob(
 		ProcessInstanceID: req.ProcessInstanceId,
 		ElementID:         req.ElementId,
 		Variables:         variables,
+		Retries:           int(req.Retries),
 	}
 
 	message, err := jobs.CreateJobMessage(payload)
@@ -80,7 +82,8 @@ func (s *jobsServiceServer) CreateJob(
 	}
 
 	// Send JSON message to jobs component through Core
-	if err := s.core.SendMessage("jobs", message); err != nil {
+	componentRequestID, err := s.core.SendRequestWithContext(ctx, "jobs", message)
+	if err != nil {
 		logger.Error("Failed to send job message", logger.String("error", err.Error()))
 		return &jobspb.CreateJobResponse{
 			Success:      false,
@@ -92,7 +95,7 @@ func (s *jobsServiceServer) CreateJob(
 
 	// Wait for response from jobs component
 	// Ожидаем ответ от компонента jobs
-	responseJSON, err := s.core.WaitForJobsResponse(5000) // 5 second timeout
+	responseJSON, err := s.core.WaitForJobsResponse(componentRequestID, 5000) // 5 second timeout
 	if err != nil {
 		logger.Error("Failed to get jobs response", logger.String("error", err.Error()))
 		return &jobspb.CreateJobResponse{
@@ -159,14 +162,16 @@ func (s *jobsServiceServer) ActivateJobs(
 	}
 
 	// Send JSON message to jobs component through Core
-	if err := s.core.SendMessage("jobs", message); err != nil {
+	componentRequestID, err := s.core.SendRequestWithContext(stream.Context(), "jobs", message)
+	if err != nil {
 		logger.Error("Failed to send activate jobs message", logger.String("error", err.Error()))
 		return fmt.Errorf("failed to send activate jobs message: %w", err)
 	}
 
 	// Wait for response from jobs component
 	// Ожидаем ответ от компонента jobs
-	responseJSON, err := s.core.WaitForJobsResponse(2000) // 2 second timeout - reduced from 10 seconds
+	// 2 second timeout - reduced from 10 seconds
+	responseJSON, err := s.core.WaitForJobsResponse(componentRequestID, 2000)
 	if err != nil {
 		logger.Error("Failed to get jobs response", logger.String("error", err.Error()))
 		return fmt.Errorf("failed to get jobs response: %w", err)
@@ -174,44 +179,22 @@ func (s *jobsServiceServer) ActivateJobs(
 
 	// Parse JSON response
 	// Парсим JSON ответ
-	var jobsResponse jobs.JobResponse
+	// Result is decoded into typed jobs to keep int64 keys exact
+	// Результат декодируется в типизированные job'ы для точности ключей int64
+	var jobsResponse struct {
+		Success bool           `json:"success"`
+		Result  []jobs.JobInfo `json:"result,omitempty"`
+		Error   string         `json:"error,omitempty"`
+	}
 	if err := json.Unmarshal([]byte(responseJSON), &jobsResponse); err != nil {
 		logger.Error("Failed to parse jobs response", logger.String("error", err.Error()))
 		return fmt.Errorf("failed to parse response JSON: %w", err)
 	}
 
-	var activatedJobs []jobs.JobInfo
+	activatedJobs := jobsResponse.Result
 	if !jobsResponse.Success {
 		logger.Error("Jobs activation failed", logger.String("error", jobsResponse.Error))
 		activatedJobs = []jobs.JobInfo{}
-	} else {
-		// Extract jobs from response
-		if jobsList, ok := jobsResponse.Result.([]interface{}); ok {
-			for _, jobData := range jobsList {
-				if jobMap, ok := jobData.(map[string]interface{}); ok {
-					job := jobs.JobInfo{}
-					if key, ok := jobMap["key"].(string); ok {
-						job.Key = key
-					}
-					if jobType, ok := jobMap["type"].(string); ok {
-						job.Type = jobType
-					}
-					if worker, ok := jobMap["worker"].(string); ok {
-						job.Worker = worker
-					}
-					if processInstanceID, ok := jobMap["process_instance_id"].(string); ok {
-						job.ProcessInstanceID = processInstanceID
-					}
-					if variables, ok := jobMap["variables"].(map[string]interface{}); ok {
-						job.Variables = variables
-					}
-					if retries, ok := jobMap["retries"].(float64); ok {
-						job.Retries = int(retries)
-					}
-					activatedJobs = append(activatedJobs, job)
-				}
-			}
-		}
 	}
 
 	// Stream activated jobs
@@ -225,9 +208,15 @@ func (s *jobsServiceServer) ActivateJobs(
 		}
 
 		activatedJob := &jobspb.ActivatedJob{
-			Key:                job.Key,
+			Id:                 job.Key,
+			Key:                job.NumericKey,
 			Type:               job.Type,
-			ProcessInstanceKey: job.ProcessInstanceID,
+			ProcessInstanceId:  job.ProcessInstanceID,
+			ProcessInstanceKey: job.ProcessInstanceKey,
+			ElementInstanceId:  job.ElementInstanceID,
+			ElementInstanceKey: job.ElementInstanceKey,
+			ElementId:          job.ElementID,
+			CustomHeaders:      job.CustomHeaders,
 			Variables:          variablesJSON,
 			Worker:             job.Worker,
 			Retries:            int32(job.Retries),
@@ -296,7 +285,8 @@ func (s *jobsServiceServer) CompleteJob(
 func (s *jobsServiceServer) FailJob(ctx context.Context, req *jobspb.FailJobRequest) (*jobspb.FailJobResponse, error) {
 	logger.Info("FailJob gRPC request",
 		logger.String("job_key", req.JobKey),
-		logger.Int("retries", int(req.Retries)))
+		logger.Int("retries", int(req.Retries)),
+		logger.String("error_code", req.ErrorCode))
 
 	// Get jobs component from core
 	component, err := getJobsComponent(s.core)
@@ -307,8 +297,11 @@ func (s *jobsServiceServer) FailJob(ctx context.Context, req *jobspb.FailJobRequ
 		}, nil
 	}
 
-	// Fail job through component
-	if err := component.FailJob(req.JobKey, int(req.Retries), req.ErrorMessage); err != nil {
+	// Fail job through component, zero backoff uses retryTimeCycle or configured retry policy
+	// Error code is thrown as BPMN error when no retries are left
+	retryBackoff := time.Duration(req.RetryBackoff) * time.Millisecond
+	err = component.FailJobWithErrorCode(req.JobKey, int(req.Retries), req.ErrorMessage, req.ErrorCode, retryBackoff)
+	if err != nil {
 		logger.Error("Failed to fail job", logger.String("error", err.Error()))
 		return &jobspb.FailJobResponse{
 			Success:      false,
@@ -398,6 +391,8 @@ func (s *jobsServiceServer) GetJobStats(
 			FailedJobs:     componentStats.FailedJobs,
 			ActivatedToday: componentStats.ActivatedToday,
 			CompletedToday: componentStats.CompletedToday,
+
+			OldestActivatableAgeMs: componentStats.OldestActivatableAgeMs,
 		}
 	} else {
 		stats = &jobspb.JobStats{
@@ -488,6 +483,11 @@ func (s *jobsServiceServer) ListJobs(
 				return jobInfos[i].Type < jobInfos[j].Type
 			}
 			return jobInfos[i].Type > jobInfos[j].Type
+		case "priority":
+			if sortOrder == "ASC" {
+				return jobInfos[i].Priority < jobInfos[j].Priority
+			}
+			return jobInfos[i].Priority > jobInfos[j].Priority
 		default:
 			// Default to created_at DESC
 			return jobInfos[i].CreatedAt > jobInfos[j].CreatedAt
@@ -535,12 +535,22 @@ func (s *jobsServiceServer) ListJobs(
 		}
 
 		protoJobs[i] = &jobspb.JobInfo{
-			Key:                job.Key,
+			Id:                 job.Key,
+			Key:                job.NumericKey,
 			Type:               job.Type,
-			ProcessInstanceKey: job.ProcessInstanceID,
+			ProcessInstanceId:  job.ProcessInstanceID,
+			ProcessInstanceKey: job.ProcessInstanceKey,
+			ElementInstanceId:  job.ElementInstanceID,
+			ElementInstanceKey: job.ElementInstanceKey,
+			ElementId:          job.ElementID,
+			CustomHeaders:      job.CustomHeaders,
+			Metadata:           job.Metadata,
 			Variables:          variables,
+			VariablesJson:      encodeVariablesJSON(job.Variables),
 			Worker:             job.Worker,
 			Retries:            int32(job.Retries),
+			Priority:           int32(job.Priority),
+			NextRetryAt:        job.NextRetryAt,
 			CreatedAt:          job.CreatedAt,
 			Status:             job.Status,
 			ErrorMessage:       job.ErrorMessage,
@@ -609,12 +619,22 @@ func (s *jobsServiceServer) GetJob(
 
 	// Convert to protobuf format
 	protoJob := &jobspb.JobInfo{
-		Key:                jobInfo.Key,
+		Id:                 jobInfo.Key,
+		Key:                jobInfo.NumericKey,
 		Type:               jobInfo.Type,
-		ProcessInstanceKey: jobInfo.ProcessInstanceID,
+		ProcessInstanceId:  jobInfo.ProcessInstanceID,
+		ProcessInstanceKey: jobInfo.ProcessInstanceKey,
+		ElementInstanceId:  jobInfo.ElementInstanceID,
+		ElementInstanceKey: jobInfo.ElementInstanceKey,
+		ElementId:          jobInfo.ElementID,
+		CustomHeaders:      jobInfo.CustomHeaders,
+		Metadata:           jobInfo.Metadata,
 		Variables:          variables,
+		VariablesJson:      encodeVariablesJSON(jobInfo.Variables),
 		Worker:             jobInfo.Worker,
 		Retries:            int32(jobInfo.Retries),
+		Priority:           int32(jobInfo.Priority),
+		NextRetryAt:        jobInfo.NextRetryAt,
 		CreatedAt:          jobInfo.CreatedAt,
 		Status:             jobInfo.Status,
 		ErrorMessage:       jobInfo.ErrorMessage,
@@ -661,3 +681,42 @@ func (s *jobsServiceServer) CancelJob(
 		Success: true,
 	}, nil
 }
+
+// UpdateJobTimeout extends lease of activated job
+func (s *jobsServiceServer) UpdateJobTimeout(
+	ctx context.Context,
+	req *jobspb.UpdateJobTimeoutRequest,
+) (*jobspb.UpdateJobTimeoutResponse, error) {
+	logger.Info("UpdateJobTimeout gRPC request",
+		logger.String("job_key", req.JobKey),
+		logger.Int("timeout_ms", int(req.Timeout)))
+
+	if req.Timeout <= 0 {
+		return &jobspb.UpdateJobTimeoutResponse{
+			Success:      false,
+			ErrorMessage: "timeout must be positive",
+		}, nil
+	}
+
+	// Get jobs component from core
+	component, err := getJobsComponent(s.core)
+	if err != nil {
+		return &jobspb.UpdateJobTimeoutResponse{
+			Success:      false,
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	// New lease expiry is counted from now
+	if err := component.UpdateJobTimeout(req.JobKey, time.Duration(req.Timeout)*time.Millisecond); err != nil {
+		logger.Error("Failed to update job timeout", logger.String("error", err.Error()))
+		return &jobspb.UpdateJobTimeoutResponse{
+			Success:      false,
+			ErrorMessage: err.Error(),
+		}, nil
+	}
+
+	return &jobspb.UpdateJobTimeoutResponse{
+		Success: true,
+	}, nil
+}
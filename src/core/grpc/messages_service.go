@@ -14,6 +14,9 @@ import (
 	"fmt"
 	"sort"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	"atom-engine/proto/messages/messagespb"
 	"atom-engine/src/core/logger"
 	"atom-engine/src/messages"
@@ -35,19 +38,48 @@ func (s *messagesServiceServer) PublishMessage(
 		logger.String("message_name", req.MessageName),
 		logger.String("correlation_key", req.CorrelationKey))
 
+	if req.BroadcastAcrossTenants {
+		allowed, apiKeyName := canBroadcastAcrossTenants(ctx)
+		if !allowed {
+			return nil, status.Error(codes.PermissionDenied,
+				"broadcast_across_tenants requires cross_tenant_messages permission")
+		}
+		logger.Warn("Audit: cross-tenant message broadcast requested",
+			logger.String("api_key_name", apiKeyName),
+			logger.String("tenant_id", req.TenantId),
+			logger.String("message_name", req.MessageName),
+			logger.String("correlation_key", req.CorrelationKey))
+	}
+
 	// Convert variables
 	variables := make(map[string]interface{})
 	for k, v := range req.Variables {
 		variables[k] = v
 	}
 
+	// Typed variables from JSON object override string variables with same name
+	// Типизированные переменные из JSON объекта перекрывают строковые с тем же именем
+	if req.VariablesJson != "" {
+		var typed map[string]interface{}
+		if err := json.Unmarshal([]byte(req.VariablesJson), &typed); err != nil {
+			return &messagespb.PublishMessageResponse{
+				Success: false,
+				Message: fmt.Sprintf("variables_json must be JSON object: %v", err),
+			}, nil
+		}
+		for key, value := range typed {
+			variables[key] = value
+		}
+	}
+
 	// Create JSON message for messages component
 	payload := messages.PublishMessagePayload{
-		TenantID:       req.TenantId,
-		MessageName:    req.MessageName,
-		CorrelationKey: req.CorrelationKey,
-		Variables:      variables,
-		TTLSeconds:     int(req.TtlSeconds),
+		TenantID:               req.TenantId,
+		MessageName:            req.MessageName,
+		CorrelationKey:         req.CorrelationKey,
+		Variables:              variables,
+		TTLSeconds:             int(req.TtlSeconds),
+		BroadcastAcrossTenants: req.BroadcastAcrossTenants,
 	}
 
 	message, err := messages.CreatePublishMessageMessage(payload)
@@ -60,7 +92,8 @@ func (s *messagesServiceServer) PublishMessage(
 	}
 
 	// Send JSON message to messages component through Core
-	if err := s.core.SendMessage("messages", message); err != nil {
+	componentRequestID, err := s.core.SendRequestWithContext(ctx, "messages", message)
+	if err != nil {
 		logger.Error("Failed to send publish message", logger.String("error", err.Error()))
 		return &messagespb.PublishMessageResponse{
 			Success: false,
@@ -72,7 +105,7 @@ func (s *messagesServiceServer) PublishMessage(
 
 	// Wait for response from messages component
 	// Ожидаем ответ от компонента messages
-	responseJSON, err := s.core.WaitForMessagesResponse(5000) // 5 second timeout
+	responseJSON, err := s.core.WaitForMessagesResponse(componentRequestID, 5000) // 5 second timeout
 	if err != nil {
 		logger.Error("Failed to get messages response", logger.String("error", err.Error()))
 		return &messagespb.PublishMessageResponse{
@@ -204,6 +237,7 @@ func (s *messagesServiceServer) ListBufferedMessages(
 			ExpiresAt:      expiresAt,
 			Reason:         msg.Reason,
 			ElementId:      msg.ElementID,
+			VariablesJson:  encodeVariablesJSON(msg.Variables),
 		}
 	}
 
@@ -466,17 +500,43 @@ func (s *messagesServiceServer) GetMessageStats(
 
 	return &messagespb.GetMessageStatsResponse{
 		Stats: &messagespb.MessageStats{
-			TotalMessages:         int32(stats.TotalMessages),
-			BufferedMessages:      int32(stats.BufferedMessages),
-			ExpiredMessages:       int32(stats.ExpiredMessages),
-			PublishedToday:        int32(stats.PublishedToday),
-			InstancesCreatedToday: int32(stats.InstancesCreatedToday),
+			TotalMessages:          int32(stats.TotalMessages),
+			BufferedMessages:       int32(stats.BufferedMessages),
+			ExpiredMessages:        int32(stats.ExpiredMessages),
+			PublishedToday:         int32(stats.PublishedToday),
+			InstancesCreatedToday:  int32(stats.InstancesCreatedToday),
+			CorrelatedImmediately:  int32(stats.CorrelatedImmediately),
+			CorrelatedFromBuffer:   int32(stats.CorrelatedFromBuffer),
+			ExpiredUncorrelated:    int32(stats.ExpiredUncorrelated),
+			AvgBufferDwellMs:       stats.AvgBufferDwellMs,
+			CorrelationSuccessRate: stats.CorrelationSuccessRate,
+			BufferCapacity:         int32(stats.BufferCapacity),
+			BufferEvictionPolicy:   stats.BufferEvictionPolicy,
+			BufferEvicted:          stats.BufferEvicted,
+			BufferRejected:         stats.BufferRejected,
+			BufferUtilization:      bufferUtilizationToProto(stats.BufferUtilization),
 		},
 		Success: true,
 		Message: "message statistics retrieved successfully",
 	}, nil
 }
 
+// bufferUtilizationToProto converts buffer fill levels to protobuf
+// Преобразует заполненность буферов в protobuf
+func bufferUtilizationToProto(utilization []messages.BufferUtilization) []*messagespb.BufferUtilization {
+	result := make([]*messagespb.BufferUtilization, 0, len(utilization))
+	for _, item := range utilization {
+		result = append(result, &messagespb.BufferUtilization{
+			TenantId:           item.TenantID,
+			MessageName:        item.MessageName,
+			Buffered:           int32(item.Buffered),
+			Capacity:           int32(item.Capacity),
+			UtilizationPercent: item.UtilizationPercent,
+		})
+	}
+	return result
+}
+
 // CleanupExpiredMessages cleans up expired messages
 func (s *messagesServiceServer) CleanupExpiredMessages(
 	ctx context.Context,
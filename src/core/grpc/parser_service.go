@@ -20,6 +20,7 @@ import (
 
 	"atom-engine/proto/parser/parserpb"
 	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
 	"atom-engine/src/parser"
 )
 
@@ -47,13 +48,42 @@ func (s *ParserService) ParseBPMNFile(
 	logger.Info("Received ParseBPMNFile request",
 		logger.String("file_path", req.FilePath),
 		logger.String("process_id", req.ProcessId),
-		logger.Bool("force", req.Force))
+		logger.Bool("force", req.Force),
+		logger.String("deployment_id", req.DeploymentId))
+
+	// Record who deployed definition from authenticated principal
+	// Записываем, кто развернул определение, из аутентифицированного субъекта
+	source := models.DeploymentSourceGRPC
+	if req.Source == models.DeploymentSourceCLI {
+		source = models.DeploymentSourceCLI
+	}
+	deployedBy := ""
+	if authResult, ok := GetAuthResultFromContext(ctx); ok && authResult != nil {
+		deployedBy = authResult.APIKeyName
+	}
+
+	// Definition metadata and tags are optional, JSON null values remove keys copied from previous version
+	// Метаданные и теги определения необязательны, значения null удаляют ключи, перенесенные из предыдущей версии
+	var metadata *models.DefinitionMetadata
+	if req.MetadataJson != "" || len(req.Tags) > 0 {
+		metadata = &models.DefinitionMetadata{Tags: req.Tags}
+		if req.MetadataJson != "" {
+			if err := json.Unmarshal([]byte(req.MetadataJson), &metadata.Metadata); err != nil {
+				return &parserpb.ParseBPMNFileResponse{
+					Success: false,
+					Message: fmt.Sprintf("metadata_json must be JSON object: %v", err),
+				}, nil
+			}
+		}
+	}
 
 	// Create JSON message for parser component
 	payload := parser.ParseBPMNFilePayload{
-		FilePath:  req.FilePath,
-		ProcessID: req.ProcessId,
-		Force:     req.Force,
+		FilePath:   req.FilePath,
+		ProcessID:  req.ProcessId,
+		Force:      req.Force,
+		Deployment: models.NewDeploymentInfo(req.DeploymentId, deployedBy, source),
+		Metadata:   metadata,
 	}
 
 	message, err := parser.CreateParseBPMNFileMessage(payload)
@@ -66,7 +96,8 @@ func (s *ParserService) ParseBPMNFile(
 	}
 
 	// Send JSON message to parser component through Core
-	if err := s.core.SendMessage("parser", message); err != nil {
+	componentRequestID, err := s.core.SendRequestWithContext(ctx, "parser", message)
+	if err != nil {
 		logger.Error("Failed to send parse BPMN file message", logger.String("error", err.Error()))
 		return &parserpb.ParseBPMNFileResponse{
 			Success: false,
@@ -78,7 +109,7 @@ func (s *ParserService) ParseBPMNFile(
 
 	// Wait for response from parser component
 	// Ожидаем ответ от компонента парсера
-	responseJSON, err := s.core.WaitForParserResponse(10000) // 10 second timeout
+	responseJSON, err := s.core.WaitForParserResponse(componentRequestID, 10000) // 10 second timeout
 	if err != nil {
 		logger.Error("Failed to get parser response", logger.String("error", err.Error()))
 		return &parserpb.ParseBPMNFileResponse{
@@ -107,6 +138,9 @@ func (s *ParserService) ParseBPMNFile(
 
 	if !parserResponse.Success {
 		response.Message = parserResponse.Error
+		if parserResponse.ErrorCode == parser.ErrorCodeParserBusy {
+			return response, status.Error(codes.Unavailable, parserResponse.Error)
+		}
 		return response, nil
 	}
 
@@ -126,6 +160,9 @@ func (s *ParserService) ParseBPMNFile(
 			response.TotalElements = int32(elementsCount)
 			response.SuccessfulElements = int32(elementsCount) // Parser only saves successfully parsed elements
 		}
+		if deploymentID, ok := resultData["deployment_id"].(string); ok {
+			response.DeploymentId = deploymentID
+		}
 	}
 
 	return response, nil
@@ -160,7 +197,9 @@ func (s *ParserService) ListBPMNProcesses(
 		logger.Int("page_size", int(pageSize)),
 		logger.Int("page", int(page)),
 		logger.String("sort_by", sortBy),
-		logger.String("sort_order", sortOrder))
+		logger.String("sort_order", sortOrder),
+		logger.String("deployment_id", req.DeploymentId),
+		logger.Any("tags", req.Tags))
 
 	parserCompInterface := s.core.GetParserComponent()
 	if parserCompInterface == nil {
@@ -188,10 +227,17 @@ func (s *ParserService) ListBPMNProcesses(
 		}, status.Error(codes.Internal, err.Error())
 	}
 
-	// Convert to protobuf format
-	processes := make([]*parserpb.BPMNProcessSummary, len(processList))
-	for i, process := range processList {
-		processes[i] = &parserpb.BPMNProcessSummary{
+	// Convert to protobuf format, filtering by deployment and tags
+	processes := make([]*parserpb.BPMNProcessSummary, 0, len(processList))
+	for _, process := range processList {
+		if req.DeploymentId != "" &&
+			(process.Deployment == nil || process.Deployment.DeploymentID != req.DeploymentId) {
+			continue
+		}
+		if !models.HasAllTags(process.Tags, req.Tags) {
+			continue
+		}
+		processes = append(processes, &parserpb.BPMNProcessSummary{
 			ProcessKey:    process.BPMNID,
 			ProcessId:     process.ProcessID,
 			ProcessName:   process.ProcessName,
@@ -200,7 +246,10 @@ func (s *ParserService) ListBPMNProcesses(
 			TotalElements: int32(process.TotalElements),
 			CreatedAt:     process.CreatedAt.Format(time.RFC3339),
 			UpdatedAt:     process.CreatedAt.Format(time.RFC3339),
-		}
+			Deployment:    deploymentToProto(process.Deployment),
+			MetadataJson:  encodeVariablesJSON(process.Metadata),
+			Tags:          process.Tags,
+		})
 	}
 
 	// Store total count before pagination
@@ -320,7 +369,7 @@ func (s *ParserService) GetBPMNProcess(
 		return &parserpb.GetBPMNProcessResponse{
 			Success: false,
 			Message: fmt.Sprintf("Failed to get BPMN process: %v", err),
-		}, status.Error(codes.Internal, err.Error())
+		}, status.Error(statusCodeFromError(err, codes.Internal), err.Error())
 	}
 
 	details := &parserpb.BPMNProcessDetails{
@@ -337,6 +386,9 @@ func (s *ParserService) GetBPMNProcess(
 		UpdatedAt:      processInfo.UpdatedAt.Format(time.RFC3339),
 		ParsedAt:       processInfo.ParsedAt.Format(time.RFC3339),
 		ElementCounts:  map[string]int32{},
+		Deployment:     deploymentToProto(processInfo.Deployment),
+		MetadataJson:   encodeVariablesJSON(processInfo.Metadata),
+		Tags:           processInfo.Tags,
 	}
 
 	// Element counts populated from process data
@@ -352,6 +404,20 @@ func (s *ParserService) GetBPMNProcess(
 	}, nil
 }
 
+// deploymentToProto converts deployment metadata to protobuf, nil for definitions without metadata
+// Конвертирует метаданные развертывания в protobuf, nil для определений без метаданных
+func deploymentToProto(deployment *models.DeploymentInfo) *parserpb.DeploymentInfo {
+	if deployment == nil {
+		return nil
+	}
+	return &parserpb.DeploymentInfo{
+		DeploymentId: deployment.DeploymentID,
+		DeployedBy:   deployment.DeployedBy,
+		DeployedAt:   deployment.DeployedAt.Format(time.RFC3339),
+		Source:       deployment.Source,
+	}
+}
+
 // DeleteBPMNProcess deletes a BPMN process
 // Удаляет BPMN процесс
 func (s *ParserService) DeleteBPMNProcess(
@@ -385,7 +451,7 @@ func (s *ParserService) DeleteBPMNProcess(
 		return &parserpb.DeleteBPMNProcessResponse{
 			Success: false,
 			Message: fmt.Sprintf("Failed to delete BPMN process: %v", err),
-		}, status.Error(codes.Internal, err.Error())
+		}, status.Error(statusCodeFromError(err, codes.Internal), err.Error())
 	}
 
 	return &parserpb.DeleteBPMNProcessResponse{
@@ -445,6 +511,11 @@ func (s *ParserService) GetBPMNStats(
 		FailedElements:      0,                          // Failed processes are not saved to storage
 		ElementTypeCounts:   make(map[string]int32),
 		LastParsedAt:        time.Now().Format(time.RFC3339),
+		ActiveParses:        int32(stats.Concurrency.Active),
+		MaxConcurrentParses: int32(stats.Concurrency.MaxConcurrent),
+		QueuedParses:        int32(stats.Concurrency.Queued),
+		ParseQueueSize:      int32(stats.Concurrency.QueueSize),
+		RejectedParses:      stats.Concurrency.Rejected,
 	}
 
 	// Add real element type counts from parser statistics
@@ -489,7 +560,7 @@ func (s *ParserService) GetBPMNProcessJSON(
 		return &parserpb.GetBPMNProcessJSONResponse{
 			Success: false,
 			Message: fmt.Sprintf("Failed to get BPMN process JSON: %v", err),
-		}, status.Error(codes.Internal, err.Error())
+		}, status.Error(statusCodeFromError(err, codes.Internal), err.Error())
 	}
 
 	return &parserpb.GetBPMNProcessJSONResponse{
@@ -506,7 +577,15 @@ func (s *ParserService) GetBPMNProcessXML(
 	req *parserpb.GetBPMNProcessXMLRequest,
 ) (*parserpb.GetBPMNProcessXMLResponse, error) {
 	logger.Info("Received GetBPMNProcessXML request",
-		logger.String("process_key", req.ProcessKey))
+		logger.String("process_key", req.ProcessKey),
+		logger.Int("version", int(req.Version)))
+
+	if req.Version < 0 {
+		return &parserpb.GetBPMNProcessXMLResponse{
+			Success: false,
+			Message: "version must not be negative",
+		}, status.Error(codes.InvalidArgument, "version must not be negative")
+	}
 
 	parserCompInterface := s.core.GetParserComponent()
 	if parserCompInterface == nil {
@@ -524,7 +603,7 @@ func (s *ParserService) GetBPMNProcessXML(
 		}, status.Error(codes.Internal, "Invalid parser component type")
 	}
 
-	xmlData, err := parserComp.GetBPMNProcessXML(req.ProcessKey)
+	xmlData, processDetails, err := parserComp.GetBPMNProcessXMLByVersion(req.ProcessKey, int(req.Version))
 	if err != nil {
 		logger.Error("Failed to get BPMN process XML",
 			logger.String("process_key", req.ProcessKey),
@@ -532,22 +611,124 @@ func (s *ParserService) GetBPMNProcessXML(
 		return &parserpb.GetBPMNProcessXMLResponse{
 			Success: false,
 			Message: fmt.Sprintf("Failed to get BPMN process XML: %v", err),
-		}, status.Error(codes.Internal, err.Error())
+		}, status.Error(statusCodeFromError(err, codes.Internal), err.Error())
 	}
 
-	// Extract filename from process key for response
-	// Извлекаем имя файла из ключа процесса для ответа
-	filename := fmt.Sprintf("%s.bpmn", req.ProcessKey)
+	// Filename identifies process version of returned source
+	// Имя файла определяет версию процесса возвращаемого исходника
+	filename := fmt.Sprintf("%s_v%d.bpmn", processDetails.ProcessID, processDetails.ProcessVersion)
 
 	logger.Info("Successfully retrieved BPMN process XML",
 		logger.String("process_key", req.ProcessKey),
 		logger.Int("file_size", len(xmlData)))
 
 	return &parserpb.GetBPMNProcessXMLResponse{
-		Success:  true,
-		Message:  "Successfully retrieved BPMN process XML",
-		XmlData:  string(xmlData),
-		Filename: filename,
-		FileSize: int32(len(xmlData)),
+		Success:        true,
+		Message:        "Successfully retrieved BPMN process XML",
+		XmlData:        string(xmlData),
+		Filename:       filename,
+		FileSize:       int32(len(xmlData)),
+		ProcessVersion: int32(processDetails.ProcessVersion),
+	}, nil
+}
+
+// GetProcessStartEvents returns top-level start events of BPMN process with their triggers
+// Возвращает стартовые события верхнего уровня BPMN процесса с их триггерами
+func (s *ParserService) GetProcessStartEvents(
+	ctx context.Context,
+	req *parserpb.GetProcessStartEventsRequest,
+) (*parserpb.GetProcessStartEventsResponse, error) {
+	logger.Info("Received GetProcessStartEvents request",
+		logger.String("process_key", req.ProcessKey))
+
+	if req.ProcessKey == "" {
+		return nil, status.Error(codes.InvalidArgument, "process key is required")
+	}
+
+	parserCompInterface := s.core.GetParserComponent()
+	if parserCompInterface == nil {
+		return nil, status.Error(codes.Internal, "Parser component not available")
+	}
+
+	parserComp, ok := parserCompInterface.(*parser.Component)
+	if !ok {
+		return nil, status.Error(codes.Internal, "Invalid parser component type")
+	}
+
+	processDetails, startEvents, err := parserComp.GetProcessStartEvents(req.ProcessKey)
+	if err != nil {
+		logger.Warn("Failed to get BPMN process start events",
+			logger.String("process_key", req.ProcessKey),
+			logger.String("error", err.Error()))
+		return nil, status.Errorf(codes.NotFound, "process %s not found", req.ProcessKey)
+	}
+
+	response := &parserpb.GetProcessStartEventsResponse{
+		Success:        true,
+		Message:        "Successfully retrieved process start events",
+		ProcessKey:     req.ProcessKey,
+		ProcessId:      processDetails.ProcessID,
+		ProcessVersion: int32(processDetails.ProcessVersion),
+		StartEvents:    make([]*parserpb.StartEventInfo, 0, len(startEvents)),
+	}
+	for _, startEvent := range startEvents {
+		info := &parserpb.StartEventInfo{
+			ElementId:   startEvent.ElementID,
+			Name:        startEvent.Name,
+			TriggerType: startEvent.TriggerType,
+			MessageRef:  startEvent.MessageRef,
+			MessageName: startEvent.MessageName,
+			SignalRef:   startEvent.SignalRef,
+			SignalName:  startEvent.SignalName,
+			Condition:   startEvent.Condition,
+		}
+		if startEvent.Timer != nil {
+			info.Timer = &parserpb.StartEventTimer{Type: startEvent.Timer.Type, Value: startEvent.Timer.Value}
+		}
+		response.StartEvents = append(response.StartEvents, info)
+	}
+
+	return response, nil
+}
+
+// SetTimerStartEnabled enables or disables scheduling of timer start events of process definition
+// Включает или выключает планирование стартовых событий таймера определения процесса
+func (s *ParserService) SetTimerStartEnabled(
+	ctx context.Context,
+	req *parserpb.SetTimerStartEnabledRequest,
+) (*parserpb.SetTimerStartEnabledResponse, error) {
+	logger.Info("Received SetTimerStartEnabled request",
+		logger.String("process_key", req.ProcessKey),
+		logger.Bool("enabled", req.Enabled))
+
+	if req.ProcessKey == "" {
+		return nil, status.Error(codes.InvalidArgument, "process key is required")
+	}
+
+	parserCompInterface := s.core.GetParserComponent()
+	if parserCompInterface == nil {
+		return nil, status.Error(codes.Internal, "Parser component not available")
+	}
+
+	parserComp, ok := parserCompInterface.(*parser.Component)
+	if !ok {
+		return nil, status.Error(codes.Internal, "Invalid parser component type")
+	}
+
+	processDetails, err := parserComp.SetTimerStartEnabled(req.ProcessKey, req.Enabled)
+	if err != nil {
+		logger.Warn("Failed to toggle timer start events",
+			logger.String("process_key", req.ProcessKey),
+			logger.String("error", err.Error()))
+		return nil, status.Errorf(codes.NotFound, "process %s not found", req.ProcessKey)
+	}
+
+	return &parserpb.SetTimerStartEnabledResponse{
+		Success:        true,
+		Message:        "Timer start events updated",
+		ProcessKey:     req.ProcessKey,
+		ProcessId:      processDetails.ProcessID,
+		ProcessVersion: int32(processDetails.ProcessVersion),
+		Enabled:        !processDetails.TimerStartDisabled,
 	}, nil
 }
@@ -67,7 +67,7 @@ func (s *processServiceServer) StartProcessInstance(
 				variables[key] = parsed
 				logger.Debug("Parsed JSON variable",
 					logger.String("key", key),
-					logger.Any("value", parsed))
+					logger.VariableValue("value", key, parsed))
 			} else {
 				// If parsing failed, keep as string
 				// Если парсинг не удался, оставляем как строку
@@ -80,8 +80,23 @@ func (s *processServiceServer) StartProcessInstance(
 		}
 	}
 
+	// Typed variables from JSON object override string variables with same name
+	// Типизированные переменные из JSON объекта перекрывают строковые с тем же именем
+	if req.VariablesJson != "" {
+		var typed map[string]interface{}
+		if err := json.Unmarshal([]byte(req.VariablesJson), &typed); err != nil {
+			return &processpb.StartProcessInstanceResponse{
+				Success: false,
+				Message: fmt.Sprintf("variables_json must be JSON object: %v", err),
+			}, nil
+		}
+		for key, value := range typed {
+			variables[key] = value
+		}
+	}
+
 	// Start process instance
-	result, err := processComp.StartProcessInstance(req.ProcessId, variables)
+	result, err := processComp.StartProcessInstanceWithContext(ctx, req.ProcessId, variables, int(req.Priority))
 	if err != nil {
 		logger.Error("Failed to start process instance",
 			logger.String("process_id", req.ProcessId),
@@ -98,10 +113,11 @@ func (s *processServiceServer) StartProcessInstance(
 		logger.String("process_id", req.ProcessId))
 
 	return &processpb.StartProcessInstanceResponse{
-		InstanceId: result.InstanceID,
-		Status:     result.State,
-		Success:    true,
-		Message:    "process instance started successfully",
+		InstanceId:  result.InstanceID,
+		InstanceKey: result.InstanceKey,
+		Status:      result.State,
+		Success:     true,
+		Message:     "process instance started successfully",
 	}, nil
 }
 
@@ -132,8 +148,9 @@ func (s *processServiceServer) GetProcessInstanceStatus(
 	}
 
 	// Convert variables to protobuf map
+	resultVariables := maskSensitiveVariables(ctx, result.Variables)
 	variables := make(map[string]string)
-	for key, value := range result.Variables {
+	for key, value := range resultVariables {
 		if strValue, ok := value.(string); ok {
 			variables[key] = strValue
 		} else {
@@ -141,12 +158,18 @@ func (s *processServiceServer) GetProcessInstanceStatus(
 		}
 	}
 
+	variablesJSON, err := json.Marshal(resultVariables)
+	if err != nil {
+		return &processpb.GetProcessInstanceStatusResponse{}, fmt.Errorf("failed to encode variables: %w", err)
+	}
+
 	logger.Info("Process instance status retrieved",
 		logger.String("instance_id", req.InstanceId),
 		logger.String("status", result.State))
 
 	return &processpb.GetProcessInstanceStatusResponse{
 		InstanceId:      result.InstanceID,
+		InstanceKey:     result.InstanceKey,
 		Status:          result.State,
 		CurrentActivity: result.CurrentActivity,
 		Variables:       variables,
@@ -155,6 +178,8 @@ func (s *processServiceServer) GetProcessInstanceStatus(
 		ProcessId:       result.ProcessID,
 		ProcessKey:      result.ProcessKey,
 		ProcessVersion:  int32(extractVersionFromKey(result.ProcessKey)), // Extract version from ProcessKey
+		Priority:        int32(result.Priority),
+		VariablesJson:   string(variablesJSON),
 	}, nil
 }
 
@@ -232,7 +257,8 @@ func (s *processServiceServer) ListProcessInstances(
 		logger.Int("page_size", int(pageSize)),
 		logger.Int("page", int(page)),
 		logger.String("sort_by", sortBy),
-		logger.String("sort_order", sortOrder))
+		logger.String("sort_order", sortOrder),
+		logger.Bool("include_counts", req.IncludeCounts))
 
 	// Get process component
 	processComp := s.core.GetProcessComponent()
@@ -307,12 +333,25 @@ func (s *processServiceServer) ListProcessInstances(
 		}
 	}
 
+	// Counts of returned page come from one storage scan
+	// Счетчики возвращаемой страницы берутся из одного прохода по хранилищу
+	if req.IncludeCounts {
+		if err := s.core.FillProcessInstanceCounts(instances); err != nil {
+			logger.Error("Failed to count process instance activity", logger.String("error", err.Error()))
+			return &processpb.ListProcessInstancesResponse{
+				Success: false,
+				Message: err.Error(),
+			}, nil
+		}
+	}
+
 	// Convert to protobuf format
 	var protoInstances []*processpb.ProcessInstanceInfo
 	for _, instance := range instances {
 		// Convert variables map
+		instanceVariables := maskSensitiveVariables(ctx, instance.Variables)
 		variables := make(map[string]string)
-		for key, value := range instance.Variables {
+		for key, value := range instanceVariables {
 			if strValue, ok := value.(string); ok {
 				variables[key] = strValue
 			} else {
@@ -322,12 +361,19 @@ func (s *processServiceServer) ListProcessInstances(
 
 		protoInstance := &processpb.ProcessInstanceInfo{
 			InstanceId:      instance.InstanceID,
+			InstanceKey:     instance.InstanceKey,
 			ProcessKey:      instance.ProcessID,
 			Status:          instance.State,
 			CurrentActivity: instance.CurrentActivity,
 			StartedAt:       instance.StartedAt,
 			UpdatedAt:       instance.UpdatedAt,
 			Variables:       variables,
+			VariablesJson:   encodeVariablesJSON(instanceVariables),
+		}
+		if instance.ActiveTimers != nil {
+			protoInstance.ActiveTimers = int32(*instance.ActiveTimers)
+			protoInstance.ActiveJobs = int32(*instance.ActiveJobs)
+			protoInstance.OpenIncidents = int32(*instance.OpenIncidents)
 		}
 		protoInstances = append(protoInstances, protoInstance)
 	}
@@ -513,8 +559,9 @@ func (s *processServiceServer) ListTokens(
 	var protoTokens []*processpb.TokenInfo
 	for _, token := range tokens {
 		// Convert variables map
+		tokenVariables := maskSensitiveVariables(ctx, token.Variables)
 		variables := make(map[string]string)
-		for key, value := range token.Variables {
+		for key, value := range tokenVariables {
 			if strValue, ok := value.(string); ok {
 				variables[key] = strValue
 			} else {
@@ -532,6 +579,7 @@ func (s *processServiceServer) ListTokens(
 			CreatedAt:         token.CreatedAt.Unix(),
 			UpdatedAt:         token.UpdatedAt.Unix(),
 			Variables:         variables,
+			VariablesJson:     encodeVariablesJSON(tokenVariables),
 		}
 		protoTokens = append(protoTokens, protoToken)
 	}
@@ -582,8 +630,9 @@ func (s *processServiceServer) GetTokenStatus(
 	}
 
 	// Convert variables map
+	tokenVariables := maskSensitiveVariables(ctx, token.Variables)
 	variables := make(map[string]string)
-	for key, value := range token.Variables {
+	for key, value := range tokenVariables {
 		if strValue, ok := value.(string); ok {
 			variables[key] = strValue
 		} else {
@@ -601,6 +650,7 @@ func (s *processServiceServer) GetTokenStatus(
 		CreatedAt:         token.CreatedAt.Unix(),
 		UpdatedAt:         token.UpdatedAt.Unix(),
 		Variables:         variables,
+		VariablesJson:     encodeVariablesJSON(tokenVariables),
 	}
 
 	logger.Info("Token status retrieved successfully", logger.String("token_id", req.TokenId))
@@ -717,7 +767,7 @@ func (s *processServiceServer) GetProcessInstanceInfo(
 		if err == nil && jobsResp != nil {
 			for _, job := range jobsResp.Jobs {
 				processJob := &processpb.ProcessJobInfo{
-					Key:          job.Key,
+					Key:          job.Id,
 					Type:         job.Type,
 					Worker:       job.Worker,
 					ElementId:    job.ElementId,
@@ -832,6 +882,110 @@ func (s *processServiceServer) GetProcessInstanceInfo(
 	return response, nil
 }
 
+// UpdateProcessVariables applies JSON merge patch to process instance variables
+// Применяет JSON merge patch к переменным экземпляра процесса
+func (s *processServiceServer) UpdateProcessVariables(
+	ctx context.Context,
+	req *processpb.UpdateProcessVariablesRequest,
+) (*processpb.UpdateProcessVariablesResponse, error) {
+	logger.Info("UpdateProcessVariables request",
+		logger.String("instance_id", req.InstanceId))
+
+	processComp := s.core.GetProcessComponent()
+	if processComp == nil {
+		return &processpb.UpdateProcessVariablesResponse{
+			InstanceId: req.InstanceId,
+			Success:    false,
+			Message:    "process component not available",
+		}, nil
+	}
+
+	var patch map[string]interface{}
+	if err := json.Unmarshal([]byte(req.PatchJson), &patch); err != nil || patch == nil {
+		return &processpb.UpdateProcessVariablesResponse{
+			InstanceId: req.InstanceId,
+			Success:    false,
+			Message:    "patch_json must be JSON object",
+		}, nil
+	}
+
+	variables, err := processComp.PatchProcessInstanceVariables(req.InstanceId, patch)
+	if err != nil {
+		logger.Error("Failed to patch process variables",
+			logger.String("instance_id", req.InstanceId),
+			logger.String("error", err.Error()))
+
+		return &processpb.UpdateProcessVariablesResponse{
+			InstanceId: req.InstanceId,
+			Success:    false,
+			Message:    err.Error(),
+		}, nil
+	}
+
+	variablesJSON, err := json.Marshal(variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode variables: %w", err)
+	}
+
+	logger.Info("Process variables patched",
+		logger.String("instance_id", req.InstanceId),
+		logger.Int("keys", len(patch)))
+
+	return &processpb.UpdateProcessVariablesResponse{
+		InstanceId:    req.InstanceId,
+		Success:       true,
+		Message:       "process variables updated successfully",
+		VariablesJson: string(variablesJSON),
+	}, nil
+}
+
+// BulkCancelProcessInstances cancels several process instances with per-instance results
+// Отменяет несколько экземпляров процессов с результатом по каждому экземпляру
+func (s *processServiceServer) BulkCancelProcessInstances(
+	ctx context.Context,
+	req *processpb.BulkCancelProcessInstancesRequest,
+) (*processpb.BulkCancelProcessInstancesResponse, error) {
+	logger.Info("BulkCancelProcessInstances request",
+		logger.Int("instance_count", len(req.InstanceIds)),
+		logger.String("reason", req.Reason))
+
+	processComp := s.core.GetProcessComponent()
+	if processComp == nil {
+		return &processpb.BulkCancelProcessInstancesResponse{Success: false}, nil
+	}
+
+	response := &processpb.BulkCancelProcessInstancesResponse{}
+	for _, instanceID := range req.InstanceIds {
+		if err := processComp.CancelProcessInstance(instanceID, req.Reason); err != nil {
+			logger.Warn("Failed to cancel process instance in bulk",
+				logger.String("instance_id", instanceID),
+				logger.String("error", err.Error()))
+
+			response.Results = append(response.Results, &processpb.CancelProcessInstanceResponse{
+				InstanceId: instanceID,
+				Success:    false,
+				Message:    err.Error(),
+			})
+			response.Failed++
+			continue
+		}
+
+		response.Results = append(response.Results, &processpb.CancelProcessInstanceResponse{
+			InstanceId: instanceID,
+			Success:    true,
+			Message:    "process instance canceled successfully",
+		})
+		response.Succeeded++
+	}
+	response.Success = len(req.InstanceIds) > 0 && response.Failed == 0
+
+	logger.Info("Bulk process cancellation processed",
+		logger.Int("succeeded", int(response.Succeeded)),
+		logger.Int("failed", int(response.Failed)))
+
+	return response, nil
+}
+
 // extractVersionFromKey extracts version from process key
 func extractVersionFromKey(processKey string) int {
 	if strings.Contains(processKey, ":v") {
@@ -844,3 +998,17 @@ func extractVersionFromKey(processKey string) int {
 	}
 	return 1
 }
+
+// encodeVariablesJSON serializes variables as JSON object keeping value types
+// Сериализует переменные в JSON объект с сохранением типов значений
+func encodeVariablesJSON(variables map[string]interface{}) string {
+	if len(variables) == 0 {
+		return "{}"
+	}
+
+	data, err := json.Marshal(variables)
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
@@ -0,0 +1,127 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+	"atom-engine/src/core/tracing"
+)
+
+// RequestIDUnaryServerInterceptor moves request ID from incoming metadata to context
+// Переносит ID запроса из входящих metadata в контекст
+func RequestIDUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx = contextWithIncomingRequestID(ctx, info.FullMethod)
+		return handler(ctx, req)
+	}
+}
+
+// RequestIDStreamServerInterceptor moves request ID from incoming metadata to stream context
+// Переносит ID запроса из входящих metadata в контекст потока
+func RequestIDStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx := contextWithIncomingRequestID(stream.Context(), info.FullMethod)
+		return handler(srv, &requestIDServerStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// RequestIDUnaryClientInterceptor adds request ID from context to outgoing metadata
+// Добавляет ID запроса из контекста в исходящие metadata
+func RequestIDUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		return invoker(contextWithOutgoingRequestID(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// RequestIDStreamClientInterceptor adds request ID from context to outgoing stream metadata
+// Добавляет ID запроса из контекста в исходящие metadata потока
+func RequestIDStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		return streamer(contextWithOutgoingRequestID(ctx), desc, cc, method, opts...)
+	}
+}
+
+// contextWithIncomingRequestID stores request ID from incoming metadata in context
+// Missing ID is generated, component responses are matched to waiting calls by it
+// Сохраняет ID запроса из входящих metadata в контексте
+// Отсутствующий ID генерируется, по нему ответы компонентов сопоставляются с ожидающими вызовами
+func contextWithIncomingRequestID(ctx context.Context, method string) context.Context {
+	requestID := ""
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(models.RequestIDMetadataKey); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	if requestID == "" {
+		requestID = models.GenerateID()
+	}
+
+	logger.Debug("gRPC request",
+		logger.String("method", method),
+		logger.String("request_id", requestID),
+		logger.String("trace_id", tracing.TraceIDFromContext(ctx)))
+
+	return models.ContextWithRequestID(ctx, requestID)
+}
+
+// contextWithOutgoingRequestID appends request ID from context to outgoing metadata
+// Добавляет ID запроса из контекста в исходящие metadata
+func contextWithOutgoingRequestID(ctx context.Context) context.Context {
+	requestID := models.RequestIDFromContext(ctx)
+	if requestID == "" {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(models.RequestIDMetadataKey)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, models.RequestIDMetadataKey, requestID)
+}
+
+// requestIDServerStream wraps grpc.ServerStream with request ID context
+// Оборачивает grpc.ServerStream контекстом с ID запроса
+type requestIDServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns context with request ID
+// Возвращает контекст с ID запроса
+func (s *requestIDServerStream) Context() context.Context {
+	return s.ctx
+}
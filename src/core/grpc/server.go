@@ -24,6 +24,7 @@ import (
 	"atom-engine/proto/parser/parserpb"
 	"atom-engine/proto/process/processpb"
 	"atom-engine/proto/timewheel/timewheelpb"
+	"atom-engine/proto/zeebe/zeebepb"
 	"atom-engine/src/core/auth"
 	"atom-engine/src/core/interfaces"
 	"atom-engine/src/core/logger"
@@ -117,22 +118,30 @@ func (s *Server) Start() error {
 	}
 	s.listener = listener
 
-	// Setup interceptors
-	var opts []grpc.ServerOption
+	// Setup interceptors, tracing and request ID go first so span and ID are available to all of them
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		TracingUnaryServerInterceptor(),
+		RequestIDUnaryServerInterceptor(),
+	}
+	streamInterceptors := []grpc.StreamServerInterceptor{
+		TracingStreamServerInterceptor(),
+		RequestIDStreamServerInterceptor(),
+	}
 
 	// Add auth interceptor if auth component is available
 	if authComp := s.core.GetAuthComponent(); authComp != nil {
 		if authComponent, ok := authComp.(auth.Component); ok {
 			authInterceptor := NewAuthInterceptor(authComponent)
-			opts = append(opts,
-				grpc.UnaryInterceptor(authInterceptor.UnaryInterceptor()),
-				grpc.StreamInterceptor(authInterceptor.StreamInterceptor()),
-			)
+			unaryInterceptors = append(unaryInterceptors, authInterceptor.UnaryInterceptor())
+			streamInterceptors = append(streamInterceptors, authInterceptor.StreamInterceptor())
 			logger.Info("Auth interceptors enabled for gRPC server")
 		}
 	}
 
-	s.grpcServer = grpc.NewServer(opts...)
+	s.grpcServer = grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
+	)
 
 	// Register storage service
 	RegisterStorageServiceServer(s.grpcServer, &storageServiceServer{core: s.core})
@@ -156,6 +165,9 @@ func (s *Server) Start() error {
 	// Register expression service
 	expressionpb.RegisterExpressionServiceServer(s.grpcServer, &expressionServiceServer{core: s.core})
 
+	// Register Zeebe compatible gateway service
+	zeebepb.RegisterGatewayServer(s.grpcServer, &zeebeGatewayServer{core: s.core, port: s.port})
+
 	// Enable reflection for development
 	reflection.Register(s.grpcServer)
 
@@ -199,7 +211,13 @@ func (s *Server) GetLoopbackConnection() (*grpc.ClientConn, error) {
 	// Create a connection to localhost on the server port
 	// Создаем соединение к localhost на порту сервера
 	target := fmt.Sprintf("localhost:%d", s.port)
-	conn, err := grpc.Dial(target, grpc.WithInsecure())
+	// Request ID and trace context of calling REST request are forwarded in metadata
+	// ID и контекст трассировки вызывающего REST запроса передаются в metadata
+	conn, err := grpc.Dial(target,
+		grpc.WithInsecure(),
+		grpc.WithChainUnaryInterceptor(RequestIDUnaryClientInterceptor(), TracingUnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(RequestIDStreamClientInterceptor(), TracingStreamClientInterceptor()),
+	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create loopback connection to %s: %w", target, err)
 	}
@@ -48,6 +48,18 @@ func (s *timewheelServiceServer) AddTimer(
 		}, nil
 	}
 
+	// Interval is validated before scheduling so that client gets cycle syntax error in response
+	// Интервал проверяется до планирования, чтобы клиент получил ошибку синтаксиса цикла в ответе
+	if req.Interval != "" {
+		if _, err := timewheel.NewISO8601DurationParser().ParseTimeCycle(req.Interval); err != nil {
+			return &timewheelpb.AddTimerResponse{
+				TimerId: req.TimerId,
+				Success: false,
+				Message: fmt.Sprintf("invalid interval: %v", err),
+			}, nil
+		}
+	}
+
 	// Create timer request from gRPC request
 	// Создаем запрос таймера из gRPC запроса
 	timerReq := timewheel.TimerRequest{
@@ -133,11 +145,11 @@ func (s *timewheelServiceServer) AddTimer(
 				}
 			}
 		} else if req.Interval != "" {
-			// Parse ISO cycle and get first execution time
-			// Парсим ISO цикл и получаем время первого выполнения
+			// Parse ISO or cron cycle and get first execution time
+			// Парсим ISO или cron цикл и получаем время первого выполнения
 			if parser := timewheel.NewISO8601DurationParser(); parser != nil {
-				if _, interval, err := parser.ParseRepeatingInterval(req.Interval); err == nil {
-					scheduledAt = baseTime.Add(interval).Unix()
+				if cycle, err := parser.ParseTimeCycle(req.Interval); err == nil {
+					scheduledAt = cycle.Next(baseTime).Unix()
 				}
 			}
 		}
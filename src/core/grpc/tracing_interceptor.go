@@ -0,0 +1,159 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package grpc
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"atom-engine/src/core/models"
+	"atom-engine/src/core/tracing"
+)
+
+// TracingUnaryServerInterceptor starts server span continuing trace from incoming metadata
+// Запускает серверный span, продолжающий трассировку из входящих metadata
+func TracingUnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		ctx, span := startServerSpan(ctx, info.FullMethod)
+		resp, err := handler(ctx, req)
+		endServerSpan(span, err)
+		return resp, err
+	}
+}
+
+// TracingStreamServerInterceptor starts server span covering whole stream
+// Запускает серверный span, охватывающий весь поток
+func TracingStreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, span := startServerSpan(stream.Context(), info.FullMethod)
+		err := handler(srv, &requestIDServerStream{ServerStream: stream, ctx: ctx})
+		endServerSpan(span, err)
+		return err
+	}
+}
+
+// TracingUnaryClientInterceptor adds traceparent of context span to outgoing metadata
+// Добавляет traceparent span'а из контекста в исходящие metadata
+func TracingUnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		return invoker(contextWithOutgoingTraceParent(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// TracingStreamClientInterceptor adds traceparent of context span to outgoing stream metadata
+// Добавляет traceparent span'а из контекста в исходящие metadata потока
+func TracingStreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		return streamer(contextWithOutgoingTraceParent(ctx), desc, cc, method, opts...)
+	}
+}
+
+// startServerSpan starts span of gRPC call, span is tagged with request ID from metadata
+// Запускает span gRPC вызова, span помечается ID запроса из metadata
+func startServerSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	attributes := []attribute.KeyValue{
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.method", method),
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		ctx = tracing.Extract(ctx, metadataCarrier(md))
+		if values := md.Get(models.RequestIDMetadataKey); len(values) > 0 && values[0] != "" {
+			attributes = append(attributes, attribute.String("atom.request_id", values[0]))
+		}
+	}
+
+	return tracing.Tracer().Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(attributes...))
+}
+
+// endServerSpan records gRPC status code and ends span
+// Записывает код статуса gRPC и завершает span
+func endServerSpan(span trace.Span, err error) {
+	code := status.Code(err)
+	span.SetAttributes(attribute.String("rpc.grpc.status_code", code.String()))
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// contextWithOutgoingTraceParent appends traceparent of context span to outgoing metadata
+// Добавляет traceparent span'а из контекста в исходящие metadata
+func contextWithOutgoingTraceParent(ctx context.Context) context.Context {
+	traceParent := tracing.TraceParentFromContext(ctx)
+	if traceParent == "" {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(tracing.TraceParentHeader)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, tracing.TraceParentHeader, traceParent)
+}
+
+// metadataCarrier adapts gRPC metadata to OpenTelemetry text map carrier
+// Адаптирует gRPC metadata к text map carrier OpenTelemetry
+type metadataCarrier metadata.MD
+
+// Get returns first value of key
+// Возвращает первое значение ключа
+func (c metadataCarrier) Get(key string) string {
+	values := metadata.MD(c).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// Set replaces values of key
+// Заменяет значения ключа
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys returns all metadata keys
+// Возвращает все ключи metadata
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,678 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"atom-engine/proto/zeebe/zeebepb"
+	"atom-engine/src/core/interfaces"
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+	"atom-engine/src/messages"
+	"atom-engine/src/parser"
+	"atom-engine/src/version"
+)
+
+// Zeebe compatibility defaults
+// Значения по умолчанию совместимости с Zeebe
+const (
+	zeebeDefaultJobTimeoutMs       = 300000 // Zeebe clients always send timeout, used when zero
+	zeebeDefaultResultTimeoutMs    = 10000  // Wait for CreateProcessInstanceWithResult without requestTimeout
+	zeebeResultPollInterval        = 100 * time.Millisecond
+	zeebePartitionID               = 1
+	zeebeProcessDefinitionKeyMask  = 1<<63 - 1
+	zeebeStorageKeyVersionSplitter = ":v"
+)
+
+// zeebeGatewayServer implements subset of Zeebe gateway protocol on top of engine components
+// Реализует подмножество протокола Zeebe gateway поверх компонентов движка
+type zeebeGatewayServer struct {
+	zeebepb.UnimplementedGatewayServer
+	core CoreInterface
+	port int
+}
+
+// ActivateJobs activates jobs and sends them in single response, without long polling
+// Активирует job'ы и отправляет их одним ответом, без long polling
+func (s *zeebeGatewayServer) ActivateJobs(
+	req *zeebepb.ActivateJobsRequest,
+	stream zeebepb.Gateway_ActivateJobsServer,
+) error {
+	logger.Info("Zeebe ActivateJobs request",
+		logger.String("worker", req.Worker),
+		logger.String("type", req.Type),
+		logger.Int("max_jobs", int(req.MaxJobsToActivate)))
+
+	if req.Type == "" {
+		return status.Error(codes.InvalidArgument, "job type is required")
+	}
+	if req.MaxJobsToActivate <= 0 {
+		return status.Error(codes.InvalidArgument, "maxJobsToActivate must be positive")
+	}
+
+	component, err := getJobsComponent(s.core)
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	timeoutMs := req.Timeout
+	if timeoutMs <= 0 {
+		timeoutMs = zeebeDefaultJobTimeoutMs
+	}
+
+	activated, err := component.ActivateJobsWithTimeout(
+		req.Worker, req.Type, int(req.MaxJobsToActivate), int32(timeoutMs))
+	if err != nil {
+		return zeebeStatusError(err)
+	}
+
+	// Process definition data is shared by jobs of same instance
+	// Данные определения процесса общие для job'ов одного экземпляра
+	instances := make(map[string]*models.ProcessInstance)
+	response := &zeebepb.ActivateJobsResponse{Jobs: make([]*zeebepb.ActivatedJob, 0, len(activated))}
+	for _, job := range activated {
+		instance, loaded := instances[job.ProcessInstanceID]
+		if !loaded {
+			instance = s.loadProcessInstance(job.ProcessInstanceID)
+			instances[job.ProcessInstanceID] = instance
+		}
+
+		headers := "{}"
+		if len(job.CustomHeaders) > 0 {
+			if data, err := json.Marshal(job.CustomHeaders); err == nil {
+				headers = string(data)
+			}
+		}
+
+		activatedJob := &zeebepb.ActivatedJob{
+			Key:                job.NumericKey,
+			Type:               job.Type,
+			ProcessInstanceKey: job.ProcessInstanceKey,
+			ElementId:          job.ElementID,
+			ElementInstanceKey: job.ElementInstanceKey,
+			CustomHeaders:      headers,
+			Worker:             job.Worker,
+			Retries:            int32(job.Retries),
+			Deadline:           job.Deadline,
+			Variables:          zeebeVariablesJSON(job.Variables, req.FetchVariable),
+		}
+		if instance != nil {
+			activatedJob.BpmnProcessId = instance.ProcessID
+			activatedJob.ProcessDefinitionVersion = int32(instance.ProcessVersion)
+			activatedJob.ProcessDefinitionKey = zeebeProcessDefinitionKey(instance.ProcessKey)
+		}
+		if activatedJob.Key == 0 {
+			// Jobs created before numeric keys cannot be completed by Zeebe clients
+			// Job'ы, созданные до числовых ключей, не могут быть завершены клиентами Zeebe
+			logger.Warn("Activated job has no numeric key, Zeebe client cannot address it",
+				logger.String("job_id", job.Key))
+		}
+
+		response.Jobs = append(response.Jobs, activatedJob)
+	}
+
+	return stream.Send(response)
+}
+
+// CompleteJob completes activated job
+// Завершает активированный job
+func (s *zeebeGatewayServer) CompleteJob(
+	ctx context.Context,
+	req *zeebepb.CompleteJobRequest,
+) (*zeebepb.CompleteJobResponse, error) {
+	logger.Info("Zeebe CompleteJob request", logger.Any("job_key", req.JobKey))
+
+	variables, err := parseZeebeVariables(req.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	component, err := getJobsComponent(s.core)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	if err := component.CompleteJob(strconv.FormatInt(req.JobKey, 10), variables); err != nil {
+		return nil, zeebeStatusError(err)
+	}
+
+	return &zeebepb.CompleteJobResponse{}, nil
+}
+
+// FailJob fails activated job with explicit remaining retries
+// Проваливает активированный job с явным числом оставшихся повторов
+func (s *zeebeGatewayServer) FailJob(
+	ctx context.Context,
+	req *zeebepb.FailJobRequest,
+) (*zeebepb.FailJobResponse, error) {
+	logger.Info("Zeebe FailJob request",
+		logger.Any("job_key", req.JobKey),
+		logger.Int("retries", int(req.Retries)))
+
+	component, err := getJobsComponent(s.core)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	backoff := time.Duration(req.RetryBackOff) * time.Millisecond
+	err = component.FailJobWithBackoff(
+		strconv.FormatInt(req.JobKey, 10), int(req.Retries), req.ErrorMessage, backoff)
+	if err != nil {
+		return nil, zeebeStatusError(err)
+	}
+
+	return &zeebepb.FailJobResponse{}, nil
+}
+
+// ThrowError throws BPMN error for activated job
+// Выбрасывает BPMN ошибку для активированного job'а
+func (s *zeebeGatewayServer) ThrowError(
+	ctx context.Context,
+	req *zeebepb.ThrowErrorRequest,
+) (*zeebepb.ThrowErrorResponse, error) {
+	logger.Info("Zeebe ThrowError request",
+		logger.Any("job_key", req.JobKey),
+		logger.String("error_code", req.ErrorCode))
+
+	if req.ErrorCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "errorCode is required")
+	}
+
+	variables, err := parseZeebeVariables(req.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	component, err := getJobsComponent(s.core)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+
+	err = component.ThrowErrorWithVariables(
+		strconv.FormatInt(req.JobKey, 10), req.ErrorCode, req.ErrorMessage, variables)
+	if err != nil {
+		return nil, zeebeStatusError(err)
+	}
+
+	return &zeebepb.ThrowErrorResponse{}, nil
+}
+
+// CreateProcessInstance starts process instance by BPMN process ID or process definition key
+// Запускает экземпляр процесса по BPMN ID процесса или ключу определения процесса
+func (s *zeebeGatewayServer) CreateProcessInstance(
+	ctx context.Context,
+	req *zeebepb.CreateProcessInstanceRequest,
+) (*zeebepb.CreateProcessInstanceResponse, error) {
+	instance, err := s.createProcessInstance(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &zeebepb.CreateProcessInstanceResponse{
+		ProcessDefinitionKey: zeebeProcessDefinitionKey(instance.ProcessKey),
+		BpmnProcessId:        instance.ProcessID,
+		Version:              int32(instance.ProcessVersion),
+		ProcessInstanceKey:   instance.Key,
+		TenantId:             req.TenantId,
+	}, nil
+}
+
+// CreateProcessInstanceWithResult starts process instance and polls its state until completion
+// Запускает экземпляр процесса и опрашивает его состояние до завершения
+func (s *zeebeGatewayServer) CreateProcessInstanceWithResult(
+	ctx context.Context,
+	req *zeebepb.CreateProcessInstanceWithResultRequest,
+) (*zeebepb.CreateProcessInstanceWithResultResponse, error) {
+	if req.Request == nil {
+		return nil, status.Error(codes.InvalidArgument, "request is required")
+	}
+
+	instance, err := s.createProcessInstance(ctx, req.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	timeoutMs := req.RequestTimeout
+	if timeoutMs <= 0 {
+		timeoutMs = zeebeDefaultResultTimeoutMs
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, time.Duration(timeoutMs)*time.Millisecond)
+	defer cancel()
+
+	processComponent := s.core.GetProcessComponent()
+	if processComponent == nil {
+		return nil, status.Error(codes.Unavailable, "process component not available")
+	}
+
+	ticker := time.NewTicker(zeebeResultPollInterval)
+	defer ticker.Stop()
+
+	for {
+		instanceStatus, err := processComponent.GetProcessInstanceStatus(instance.InstanceID)
+		if err != nil {
+			return nil, zeebeStatusError(err)
+		}
+
+		switch models.ProcessInstanceState(instanceStatus.State) {
+		case models.ProcessInstanceStateCompleted:
+			return &zeebepb.CreateProcessInstanceWithResultResponse{
+				ProcessDefinitionKey: zeebeProcessDefinitionKey(instance.ProcessKey),
+				BpmnProcessId:        instance.ProcessID,
+				Version:              int32(instance.ProcessVersion),
+				ProcessInstanceKey:   instance.Key,
+				Variables:            zeebeVariablesJSON(s.resultVariables(instanceStatus), req.FetchVariables),
+				TenantId:             req.Request.TenantId,
+			}, nil
+		case models.ProcessInstanceStateCanceled, models.ProcessInstanceStateFailed:
+			return nil, status.Errorf(codes.Aborted,
+				"process instance %d ended in state %s", instance.Key, instanceStatus.State)
+		}
+
+		select {
+		case <-waitCtx.Done():
+			return nil, status.Errorf(codes.DeadlineExceeded,
+				"process instance %d did not complete within %d ms", instance.Key, timeoutMs)
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeployResource deploys BPMN resources through parser component
+// Every deployed resource gets new version, identical content is not deduplicated
+// Развертывает BPMN ресурсы через компонент парсера
+// Каждый развернутый ресурс получает новую версию, одинаковое содержимое не дедуплицируется
+func (s *zeebeGatewayServer) DeployResource(
+	ctx context.Context,
+	req *zeebepb.DeployResourceRequest,
+) (*zeebepb.DeployResourceResponse, error) {
+	if len(req.Resources) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one resource is required")
+	}
+	for _, resource := range req.Resources {
+		if !strings.EqualFold(filepath.Ext(resource.Name), ".bpmn") {
+			return nil, status.Errorf(codes.InvalidArgument,
+				"resource %s is not supported, only .bpmn resources can be deployed", resource.Name)
+		}
+	}
+
+	response := &zeebepb.DeployResourceResponse{
+		Key:      models.GenerateKey(),
+		TenantId: req.TenantId,
+	}
+
+	for _, resource := range req.Resources {
+		logger.Info("Zeebe DeployResource request",
+			logger.String("resource", resource.Name),
+			logger.Int("size", len(resource.Content)))
+
+		message, err := parser.CreateParseBPMNContentMessage(parser.ParseBPMNContentPayload{
+			BPMNContent: string(resource.Content),
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create parse message: %v", err)
+		}
+
+		componentRequestID, err := s.core.SendRequestWithContext(ctx, "parser", message)
+		if err != nil {
+			return nil, zeebeStatusError(err)
+		}
+
+		responseJSON, err := s.core.WaitForParserResponse(componentRequestID, 10000) // 10 second timeout
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "failed to get parser response: %v", err)
+		}
+
+		var parserResponse parser.ParserResponse
+		if err := json.Unmarshal([]byte(responseJSON), &parserResponse); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to parse response JSON: %v", err)
+		}
+		if !parserResponse.Success {
+			if parserResponse.ErrorCode == parser.ErrorCodeParserBusy {
+				return nil, status.Error(codes.ResourceExhausted, parserResponse.Error)
+			}
+			return nil, status.Errorf(codes.InvalidArgument,
+				"failed to deploy resource %s: %s", resource.Name, parserResponse.Error)
+		}
+
+		data, err := json.Marshal(parserResponse.Result)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to encode parse result: %v", err)
+		}
+		var result parser.JSONParseResult
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to parse deployment result: %v", err)
+		}
+
+		storageKey := fmt.Sprintf("%s%s%d", result.ProcessID, zeebeStorageKeyVersionSplitter, result.ProcessVersion)
+		response.Deployments = append(response.Deployments, &zeebepb.Deployment{
+			Metadata: &zeebepb.Deployment_Process{
+				Process: &zeebepb.ProcessMetadata{
+					BpmnProcessId:        result.ProcessID,
+					Version:              int32(result.ProcessVersion),
+					ProcessDefinitionKey: zeebeProcessDefinitionKey(storageKey),
+					ResourceName:         resource.Name,
+					TenantId:             req.TenantId,
+				},
+			},
+		})
+	}
+
+	return response, nil
+}
+
+// PublishMessage publishes message through messages component
+// Returned key is surrogate, engine message IDs are not numeric
+// Публикует сообщение через компонент messages
+// Возвращаемый ключ суррогатный, ID сообщений движка не числовые
+func (s *zeebeGatewayServer) PublishMessage(
+	ctx context.Context,
+	req *zeebepb.PublishMessageRequest,
+) (*zeebepb.PublishMessageResponse, error) {
+	logger.Info("Zeebe PublishMessage request",
+		logger.String("message_name", req.Name),
+		logger.String("correlation_key", req.CorrelationKey))
+
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "message name is required")
+	}
+
+	variables, err := parseZeebeVariables(req.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	// Engine TTL has second precision, non-zero TTL below one second is rounded up
+	// TTL движка имеет точность в секундах, ненулевой TTL меньше секунды округляется вверх
+	ttlSeconds := int((req.TimeToLive + 999) / 1000)
+
+	message, err := messages.CreatePublishMessageMessage(messages.PublishMessagePayload{
+		TenantID:       req.TenantId,
+		MessageName:    req.Name,
+		CorrelationKey: req.CorrelationKey,
+		Variables:      variables,
+		TTLSeconds:     ttlSeconds,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to create publish message: %v", err)
+	}
+
+	componentRequestID, err := s.core.SendRequestWithContext(ctx, "messages", message)
+	if err != nil {
+		return nil, zeebeStatusError(err)
+	}
+
+	responseJSON, err := s.core.WaitForMessagesResponse(componentRequestID, 5000) // 5 second timeout
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "failed to get messages response: %v", err)
+	}
+
+	var messagesResponse messages.MessageResponse
+	if err := json.Unmarshal([]byte(responseJSON), &messagesResponse); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to parse response JSON: %v", err)
+	}
+	if !messagesResponse.Success {
+		return nil, zeebeStatusError(errors.New(messagesResponse.Error))
+	}
+
+	return &zeebepb.PublishMessageResponse{
+		Key:      models.GenerateKey(),
+		TenantId: req.TenantId,
+	}, nil
+}
+
+// Topology reports engine as single broker owning single partition
+// Представляет движок как единственный брокер с единственной партицией
+func (s *zeebeGatewayServer) Topology(
+	ctx context.Context,
+	req *zeebepb.TopologyRequest,
+) (*zeebepb.TopologyResponse, error) {
+	partitionHealth := zeebepb.Partition_HEALTHY
+	if s.core.GetProcessComponent() == nil || s.core.GetStorageTyped() == nil {
+		partitionHealth = zeebepb.Partition_UNHEALTHY
+	}
+
+	return &zeebepb.TopologyResponse{
+		Brokers: []*zeebepb.BrokerInfo{
+			{
+				NodeId:  0,
+				Host:    "localhost",
+				Port:    int32(s.port),
+				Version: version.Version,
+				Partitions: []*zeebepb.Partition{
+					{
+						PartitionId: zeebePartitionID,
+						Role:        zeebepb.Partition_LEADER,
+						Health:      partitionHealth,
+					},
+				},
+			},
+		},
+		ClusterSize:       1,
+		PartitionsCount:   1,
+		ReplicationFactor: 1,
+		GatewayVersion:    version.Version,
+	}, nil
+}
+
+// createProcessInstance starts instance and loads it back for version and keys
+// Запускает экземпляр и загружает его обратно для получения версии и ключей
+func (s *zeebeGatewayServer) createProcessInstance(
+	ctx context.Context,
+	req *zeebepb.CreateProcessInstanceRequest,
+) (*models.ProcessInstance, error) {
+	if len(req.StartInstructions) > 0 {
+		return nil, status.Error(codes.InvalidArgument, "startInstructions are not supported")
+	}
+
+	processKey, err := s.resolveProcessKey(req)
+	if err != nil {
+		return nil, err
+	}
+
+	variables, err := parseZeebeVariables(req.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Zeebe CreateProcessInstance request", logger.String("process_key", processKey))
+
+	processComponent := s.core.GetProcessComponent()
+	if processComponent == nil {
+		return nil, status.Error(codes.Unavailable, "process component not available")
+	}
+
+	result, err := processComponent.StartProcessInstanceWithContext(ctx, processKey, variables, 0)
+	if err != nil {
+		return nil, zeebeStatusError(err)
+	}
+
+	instance := s.loadProcessInstance(result.InstanceID)
+	if instance == nil {
+		return nil, status.Errorf(codes.Internal, "process instance %s not found after start", result.InstanceID)
+	}
+
+	return instance, nil
+}
+
+// resolveProcessKey converts Zeebe process selector into engine process key
+// Преобразует селектор процесса Zeebe в ключ процесса движка
+func (s *zeebeGatewayServer) resolveProcessKey(req *zeebepb.CreateProcessInstanceRequest) (string, error) {
+	if req.ProcessDefinitionKey != 0 {
+		storage := s.core.GetStorageTyped()
+		if storage == nil {
+			return "", status.Error(codes.Unavailable, "storage not available")
+		}
+
+		processes, err := storage.LoadAllBPMNProcesses()
+		if err != nil {
+			return "", status.Errorf(codes.Internal, "failed to load processes: %v", err)
+		}
+		for storageKey := range processes {
+			if zeebeProcessDefinitionKey(storageKey) != req.ProcessDefinitionKey {
+				continue
+			}
+			processID, processVersion, found := strings.Cut(storageKey, zeebeStorageKeyVersionSplitter)
+			if !found {
+				continue
+			}
+			return processID + ":" + processVersion, nil
+		}
+		return "", status.Errorf(codes.NotFound, "process definition %d not found", req.ProcessDefinitionKey)
+	}
+
+	if req.BpmnProcessId == "" {
+		return "", status.Error(codes.InvalidArgument, "bpmnProcessId or processDefinitionKey is required")
+	}
+	if req.Version > 0 {
+		return fmt.Sprintf("%s:%d", req.BpmnProcessId, req.Version), nil
+	}
+	return req.BpmnProcessId, nil
+}
+
+// resultVariables collects final variables of completed instance
+// Instance keeps start variables, later changes live in tokens, latest token wins
+// Собирает итоговые переменные завершенного экземпляра
+// Экземпляр хранит стартовые переменные, последующие изменения находятся в токенах, побеждает последний токен
+func (s *zeebeGatewayServer) resultVariables(instanceStatus *interfaces.ProcessInstanceStatus) map[string]interface{} {
+	variables := make(map[string]interface{}, len(instanceStatus.Variables))
+	for name, value := range instanceStatus.Variables {
+		variables[name] = value
+	}
+
+	tokens, err := s.core.GetProcessComponent().GetTokensByProcessInstance(instanceStatus.InstanceID)
+	if err != nil {
+		logger.Warn("Failed to load tokens for Zeebe result variables",
+			logger.String("instance_id", instanceStatus.InstanceID),
+			logger.String("error", err.Error()))
+		return variables
+	}
+
+	sort.SliceStable(tokens, func(i, j int) bool {
+		return tokens[i].UpdatedAt.Before(tokens[j].UpdatedAt)
+	})
+	for _, token := range tokens {
+		for name, value := range token.Variables {
+			variables[name] = value
+		}
+	}
+	return variables
+}
+
+// loadProcessInstance loads process instance from storage, nil when unavailable
+// Загружает экземпляр процесса из storage, nil если недоступен
+func (s *zeebeGatewayServer) loadProcessInstance(instanceID string) *models.ProcessInstance {
+	storage := s.core.GetStorageTyped()
+	if storage == nil || instanceID == "" {
+		return nil
+	}
+
+	instance, err := storage.LoadProcessInstance(instanceID)
+	if err != nil {
+		logger.Warn("Failed to load process instance for Zeebe response",
+			logger.String("instance_id", instanceID),
+			logger.String("error", err.Error()))
+		return nil
+	}
+	return instance
+}
+
+// zeebeProcessDefinitionKey derives stable positive int64 key from process storage key
+// Вычисляет стабильный положительный ключ int64 из ключа хранения процесса
+func zeebeProcessDefinitionKey(storageKey string) int64 {
+	if storageKey == "" {
+		return 0
+	}
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(storageKey))
+	return int64(hash.Sum64() & zeebeProcessDefinitionKeyMask)
+}
+
+// parseZeebeVariables parses variables JSON object, empty string means no variables
+// Парсит JSON объект переменных, пустая строка означает отсутствие переменных
+func parseZeebeVariables(variablesJSON string) (map[string]interface{}, error) {
+	variables := make(map[string]interface{})
+	if strings.TrimSpace(variablesJSON) == "" {
+		return variables, nil
+	}
+	if err := json.Unmarshal([]byte(variablesJSON), &variables); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "variables must be JSON object: %v", err)
+	}
+	return variables, nil
+}
+
+// zeebeVariablesJSON serializes variables, keeping only fetched names when given
+// Сериализует переменные, оставляя только запрошенные имена если они заданы
+func zeebeVariablesJSON(variables map[string]interface{}, fetch []string) string {
+	selected := variables
+	if len(fetch) > 0 {
+		selected = make(map[string]interface{}, len(fetch))
+		for _, name := range fetch {
+			if value, ok := variables[name]; ok {
+				selected[name] = value
+			}
+		}
+	}
+	if len(selected) == 0 {
+		return "{}"
+	}
+
+	data, err := json.Marshal(selected)
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
+
+// zeebeStatusError maps engine error to gRPC status expected by Zeebe clients
+// Преобразует ошибку движка в gRPC статус, ожидаемый клиентами Zeebe
+func zeebeStatusError(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var notReady *models.ComponentNotReadyError
+	var circuitOpen *models.CircuitOpenError
+	switch {
+	case errors.As(err, &notReady), errors.As(err, &circuitOpen):
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	message := err.Error()
+	if code := statusCodeFromError(err, codes.Unknown); code != codes.Unknown {
+		return status.Error(code, message)
+	}
+
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "not found"):
+		return status.Error(codes.NotFound, message)
+	case strings.Contains(lower, "invalid"), strings.Contains(lower, "required"):
+		return status.Error(codes.InvalidArgument, message)
+	case strings.Contains(lower, "not ready"), strings.Contains(lower, "busy"):
+		return status.Error(codes.Unavailable, message)
+	case strings.Contains(lower, "cannot"), strings.Contains(lower, "not active"),
+		strings.Contains(lower, "status"):
+		return status.Error(codes.FailedPrecondition, message)
+	default:
+		return status.Error(codes.Internal, message)
+	}
+}
@@ -0,0 +1,387 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"os/exec"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"atom-engine/proto/zeebe/zeebepb"
+	"atom-engine/src/core/interfaces"
+	"atom-engine/src/core/models"
+	"atom-engine/src/jobs"
+	"atom-engine/src/storage"
+)
+
+// fakeZeebeCore serves real jobs component and storage, other methods are not implemented
+// Отдает настоящие компонент jobs и storage, остальные методы не реализованы
+type fakeZeebeCore struct {
+	CoreInterface
+	storage storage.Storage
+	jobs    *jobs.Component
+}
+
+func (f *fakeZeebeCore) GetJobsComponent() interface{} { return f.jobs }
+
+func (f *fakeZeebeCore) GetStorageTyped() interfaces.StorageInterface { return f.storage }
+
+func (f *fakeZeebeCore) GetProcessComponent() ProcessComponentInterface {
+	return &fakeZeebeProcessComponent{}
+}
+
+// fakeZeebeProcessComponent only reports process component presence
+// Только сообщает о наличии компонента процессов
+type fakeZeebeProcessComponent struct {
+	ProcessComponentInterface
+}
+
+// zeebeTestGateway is Zeebe gateway on loopback port with its backing components
+// Zeebe gateway на loopback порту с обслуживающими его компонентами
+type zeebeTestGateway struct {
+	address string
+	client  zeebepb.GatewayClient
+	core    *fakeZeebeCore
+}
+
+// startZeebeGateway serves Zeebe gateway over TCP and connects gateway protocol client to it
+// Client speaks same wire protocol as zbctl and official Zeebe clients
+// Обслуживает Zeebe gateway по TCP и подключает к нему клиент протокола gateway
+// Клиент использует тот же протокол, что zbctl и официальные клиенты Zeebe
+func startZeebeGateway(t *testing.T) *zeebeTestGateway {
+	t.Helper()
+
+	st := storage.NewStorage(&storage.Config{Path: t.TempDir()})
+	if err := st.Init(); err != nil {
+		t.Fatalf("init storage: %v", err)
+	}
+	if err := st.Start(); err != nil {
+		t.Fatalf("start storage: %v", err)
+	}
+	t.Cleanup(func() { st.Stop() })
+
+	jobsComponent := jobs.NewComponent(nil, st)
+	if err := jobsComponent.Start(); err != nil {
+		t.Fatalf("start jobs component: %v", err)
+	}
+	t.Cleanup(func() { jobsComponent.Stop() })
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	core := &fakeZeebeCore{storage: st, jobs: jobsComponent}
+	server := grpc.NewServer()
+	zeebepb.RegisterGatewayServer(server, &zeebeGatewayServer{
+		core: core,
+		port: listener.Addr().(*net.TCPAddr).Port,
+	})
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(listener.Addr().String(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial gateway: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &zeebeTestGateway{
+		address: listener.Addr().String(),
+		client:  zeebepb.NewGatewayClient(conn),
+		core:    core,
+	}
+}
+
+// createJob saves process instance and creates job of given type in it
+// Сохраняет экземпляр процесса и создает в нем job заданного типа
+func (g *zeebeTestGateway) createJob(
+	t *testing.T,
+	jobType string,
+	variables map[string]interface{},
+) (*models.ProcessInstance, string) {
+	t.Helper()
+
+	instance := &models.ProcessInstance{
+		InstanceID:     models.GenerateID(),
+		Key:            models.GenerateKey(),
+		ProcessID:      "order-process",
+		ProcessVersion: 3,
+		ProcessKey:     "order-process:v3",
+		State:          models.ProcessInstanceStateActive,
+		StartedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	if err := g.core.storage.SaveProcessInstance(instance); err != nil {
+		t.Fatalf("save process instance: %v", err)
+	}
+
+	jobID, err := g.core.jobs.CreateJobWithDetails(
+		jobType, instance.InstanceID, "charge", map[string]string{"region": "eu"}, nil, variables, 0, 3, "")
+	if err != nil {
+		t.Fatalf("create job: %v", err)
+	}
+	return instance, jobID
+}
+
+// activate activates jobs of type through gateway protocol stream
+// Активирует job'ы типа через поток протокола gateway
+func (g *zeebeTestGateway) activate(t *testing.T, req *zeebepb.ActivateJobsRequest) []*zeebepb.ActivatedJob {
+	t.Helper()
+
+	stream, err := g.client.ActivateJobs(context.Background(), req)
+	if err != nil {
+		t.Fatalf("activate jobs: %v", err)
+	}
+	var activated []*zeebepb.ActivatedJob
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			return activated
+		}
+		if err != nil {
+			t.Fatalf("receive activated jobs: %v", err)
+		}
+		activated = append(activated, response.Jobs...)
+	}
+}
+
+// wantCode fails test unless err carries gRPC code
+// Проваливает тест, если err не несет gRPC код
+func wantCode(t *testing.T, call string, err error, code codes.Code) {
+	t.Helper()
+
+	if status.Code(err) != code {
+		t.Errorf("%s: error %v, want code %s", call, err, code)
+	}
+}
+
+func TestZeebeTopologyReportsSingleBroker(t *testing.T) {
+	gateway := startZeebeGateway(t)
+
+	topology, err := gateway.client.Topology(context.Background(), &zeebepb.TopologyRequest{})
+	if err != nil {
+		t.Fatalf("topology: %v", err)
+	}
+	if topology.ClusterSize != 1 || topology.PartitionsCount != 1 || len(topology.Brokers) != 1 {
+		t.Fatalf("topology %+v, want single broker with single partition", topology)
+	}
+	broker := topology.Brokers[0]
+	if !strings.HasSuffix(gateway.address, ":"+strconv.Itoa(int(broker.Port))) {
+		t.Errorf("broker port %d, gateway listens on %s", broker.Port, gateway.address)
+	}
+	partition := broker.Partitions[0]
+	if partition.PartitionId != zeebePartitionID || partition.Role != zeebepb.Partition_LEADER ||
+		partition.Health != zeebepb.Partition_HEALTHY {
+		t.Errorf("partition %+v, want healthy leader of partition 1", partition)
+	}
+}
+
+func TestZeebeActivatedJobFieldMapping(t *testing.T) {
+	gateway := startZeebeGateway(t)
+	instance, _ := gateway.createJob(t, "charge-card", map[string]interface{}{"amount": 42, "currency": "EUR"})
+
+	activated := gateway.activate(t, &zeebepb.ActivateJobsRequest{
+		Type:              "charge-card",
+		Worker:            "payments",
+		MaxJobsToActivate: 5,
+		FetchVariable:     []string{"amount", "missing"},
+	})
+	if len(activated) != 1 {
+		t.Fatalf("activated %d jobs, want 1", len(activated))
+	}
+	job := activated[0]
+
+	// Keys are int64 on wire, engine string IDs are never exposed
+	// Ключи передаются как int64, строковые ID движка не раскрываются
+	if job.Key <= 0 || job.ProcessInstanceKey != instance.Key {
+		t.Errorf("job key %d, instance key %d, want positive job key and instance key %d",
+			job.Key, job.ProcessInstanceKey, instance.Key)
+	}
+	if job.ProcessDefinitionKey != zeebeProcessDefinitionKey(instance.ProcessKey) || job.ProcessDefinitionKey <= 0 {
+		t.Errorf("process definition key %d, want positive hash of %s", job.ProcessDefinitionKey, instance.ProcessKey)
+	}
+	if job.BpmnProcessId != "order-process" || job.ProcessDefinitionVersion != 3 || job.ElementId != "charge" {
+		t.Errorf("job process fields %+v", job)
+	}
+
+	// Variables and custom headers are JSON object strings, fetchVariable drops other names
+	// Переменные и заголовки - строки JSON объектов, fetchVariable отбрасывает остальные имена
+	var variables map[string]interface{}
+	if err := json.Unmarshal([]byte(job.Variables), &variables); err != nil {
+		t.Fatalf("variables %q are not JSON object: %v", job.Variables, err)
+	}
+	if len(variables) != 1 || variables["amount"] != float64(42) {
+		t.Errorf("variables %v, want only fetched amount", variables)
+	}
+	if job.CustomHeaders != `{"region":"eu"}` {
+		t.Errorf("custom headers %q", job.CustomHeaders)
+	}
+
+	// Zero timeout uses default one, deadline is epoch milliseconds
+	// Нулевой таймаут заменяется значением по умолчанию, deadline в миллисекундах эпохи
+	wantDeadline := time.Now().Add(zeebeDefaultJobTimeoutMs * time.Millisecond).UnixMilli()
+	if job.Deadline < wantDeadline-int64(time.Minute/time.Millisecond) || job.Deadline > wantDeadline {
+		t.Errorf("deadline %d, want about %d", job.Deadline, wantDeadline)
+	}
+	if job.Retries != 3 {
+		t.Errorf("retries %d, want 3", job.Retries)
+	}
+}
+
+func TestZeebeVariablesMustBeJSONObject(t *testing.T) {
+	gateway := startZeebeGateway(t)
+	gateway.createJob(t, "charge-card", nil)
+	job := gateway.activate(t, &zeebepb.ActivateJobsRequest{Type: "charge-card", MaxJobsToActivate: 1})[0]
+
+	if job.Variables != "{}" {
+		t.Errorf("job without variables has variables %q, want {}", job.Variables)
+	}
+
+	ctx := context.Background()
+	for _, variables := range []string{`[1, 2]`, `"paid"`, `{"status":`} {
+		_, err := gateway.client.CompleteJob(ctx, &zeebepb.CompleteJobRequest{JobKey: job.Key, Variables: variables})
+		wantCode(t, "complete job with variables "+variables, err, codes.InvalidArgument)
+	}
+
+	// Empty string means no variables
+	// Пустая строка означает отсутствие переменных
+	if _, err := gateway.client.CompleteJob(ctx, &zeebepb.CompleteJobRequest{JobKey: job.Key}); err != nil {
+		t.Fatalf("complete job without variables: %v", err)
+	}
+	_, err := gateway.client.CompleteJob(ctx, &zeebepb.CompleteJobRequest{JobKey: job.Key + 1})
+	wantCode(t, "complete unknown job", err, codes.NotFound)
+}
+
+func TestZeebeFailJobRetriesAreRemainingRetries(t *testing.T) {
+	gateway := startZeebeGateway(t)
+	_, jobID := gateway.createJob(t, "charge-card", nil)
+	ctx := context.Background()
+
+	// Client sends remaining retries, engine does not decrement them again
+	// Клиент передает оставшиеся повторы, движок не уменьшает их повторно
+	job := gateway.activate(t, &zeebepb.ActivateJobsRequest{Type: "charge-card", MaxJobsToActivate: 1})[0]
+	_, err := gateway.client.FailJob(ctx, &zeebepb.FailJobRequest{
+		JobKey: job.Key, Retries: 2, ErrorMessage: "declined",
+	})
+	if err != nil {
+		t.Fatalf("fail job: %v", err)
+	}
+	retried := gateway.activate(t, &zeebepb.ActivateJobsRequest{Type: "charge-card", MaxJobsToActivate: 1})
+	if len(retried) != 1 || retried[0].Key != job.Key || retried[0].Retries != 2 {
+		t.Fatalf("retried jobs %+v, want same job with 2 retries", retried)
+	}
+
+	// Zero retries fails job for good
+	// Ноль повторов окончательно проваливает job
+	_, err = gateway.client.FailJob(ctx, &zeebepb.FailJobRequest{JobKey: job.Key, Retries: 0, ErrorMessage: "declined"})
+	if err != nil {
+		t.Fatalf("fail job without retries: %v", err)
+	}
+	again := gateway.activate(t, &zeebepb.ActivateJobsRequest{Type: "charge-card", MaxJobsToActivate: 1})
+	if len(again) != 0 {
+		t.Errorf("job without retries activated again: %+v", again)
+	}
+	info, err := gateway.core.jobs.GetJob(jobID)
+	if err != nil {
+		t.Fatalf("get job: %v", err)
+	}
+	if info.Retries != 0 || info.ErrorMessage != "declined" {
+		t.Errorf("failed job retries %d, error %q", info.Retries, info.ErrorMessage)
+	}
+}
+
+func TestZeebeRejectsInvalidRequests(t *testing.T) {
+	gateway := startZeebeGateway(t)
+	ctx := context.Background()
+
+	for _, req := range []*zeebepb.ActivateJobsRequest{
+		{MaxJobsToActivate: 1},
+		{Type: "charge-card"},
+	} {
+		stream, err := gateway.client.ActivateJobs(ctx, req)
+		if err == nil {
+			_, err = stream.Recv()
+		}
+		wantCode(t, "activate jobs "+req.String(), err, codes.InvalidArgument)
+	}
+
+	_, err := gateway.client.DeployResource(ctx, &zeebepb.DeployResourceRequest{
+		Resources: []*zeebepb.Resource{{Name: "rules.dmn", Content: []byte("<definitions/>")}},
+	})
+	wantCode(t, "deploy DMN resource", err, codes.InvalidArgument)
+
+	_, err = gateway.client.ThrowError(ctx, &zeebepb.ThrowErrorRequest{JobKey: 1})
+	wantCode(t, "throw error without code", err, codes.InvalidArgument)
+
+	_, err = gateway.client.PublishMessage(ctx, &zeebepb.PublishMessageRequest{Name: "paid", Variables: "[]"})
+	wantCode(t, "publish message with array variables", err, codes.InvalidArgument)
+}
+
+// fakeDeployCore answers every parse request with fixed parser response
+// Отвечает на каждый запрос разбора фиксированным ответом парсера
+type fakeDeployCore struct {
+	CoreInterface
+	response string
+}
+
+func (f *fakeDeployCore) SendRequestWithContext(
+	ctx context.Context,
+	componentName, messageJSON string,
+) (string, error) {
+	return "parse-1", nil
+}
+
+func (f *fakeDeployCore) WaitForParserResponse(requestID string, timeoutMs int) (string, error) {
+	return f.response, nil
+}
+
+func TestDeployResourceRejectsUndecodableParseResult(t *testing.T) {
+	server := &zeebeGatewayServer{core: &fakeDeployCore{
+		response: `{"type":"parse_bpmn_content_response","success":true,"result":"order.bpmn"}`,
+	}}
+
+	response, err := server.DeployResource(context.Background(), &zeebepb.DeployResourceRequest{
+		Resources: []*zeebepb.Resource{{Name: "order.bpmn", Content: []byte("<definitions/>")}},
+	})
+	wantCode(t, "deploy resource with undecodable parse result", err, codes.Internal)
+	if response != nil {
+		t.Errorf("deployment %+v returned without process metadata", response.Deployments)
+	}
+}
+
+func TestZbctlStatus(t *testing.T) {
+	zbctl, err := exec.LookPath("zbctl")
+	if err != nil {
+		t.Skip("zbctl is not installed")
+	}
+	gateway := startZeebeGateway(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, zbctl,
+		"status", "--address", gateway.address, "--insecure").CombinedOutput()
+	if err != nil {
+		t.Fatalf("zbctl status: %v\n%s", err, output)
+	}
+	if !strings.Contains(string(output), "Cluster size: 1") {
+		t.Errorf("zbctl status output:\n%s", output)
+	}
+}
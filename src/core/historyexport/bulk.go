@@ -0,0 +1,148 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package historyexport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+)
+
+// maxErrorBodySize bounds response body quoted in request errors
+// Ограничивает тело ответа, приводимое в ошибках запроса
+const maxErrorBodySize = 512
+
+// bulkResult splits sent records by outcome
+// Разделяет отправленные записи по результату
+type bulkResult struct {
+	exported   []string // Record IDs accepted by endpoint
+	rejected   []string // Record IDs rejected permanently, e.g. mapping conflict
+	retryable  int      // Records failed with 429 or 5xx, kept in backlog
+	firstError string
+}
+
+// bulkResponse is subset of _bulk API response
+// Подмножество ответа _bulk API
+type bulkResponse struct {
+	Errors bool                         `json:"errors"`
+	Items  []map[string]bulkItemOutcome `json:"items"`
+}
+
+// bulkItemOutcome is outcome of single bulk action
+// Результат одного действия bulk запроса
+type bulkItemOutcome struct {
+	ID     string          `json:"_id"`
+	Status int             `json:"status"`
+	Error  json.RawMessage `json:"error,omitempty"`
+}
+
+// indexName renders index template for record
+// Формирует имя индекса по шаблону для записи
+func (e *Exporter) indexName(record *models.HistoryRecord) string {
+	return strings.NewReplacer(
+		"{kind}", record.Kind,
+		"{date}", record.Timestamp.UTC().Format(e.cfg.DateFormat),
+	).Replace(e.cfg.IndexTemplate)
+}
+
+// sendBulk indexes records with single _bulk request
+// Documents are indexed by their document ID, so resending after partial failure does not duplicate them
+// Индексирует записи одним _bulk запросом
+// Документы индексируются по своему ID, поэтому повторная отправка после частичной ошибки не создает дубликатов
+func (e *Exporter) sendBulk(records []*models.HistoryRecord) (*bulkResult, error) {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, record := range records {
+		action := map[string]interface{}{
+			"index": map[string]string{"_index": e.indexName(record), "_id": record.DocumentID},
+		}
+		if err := encoder.Encode(action); err != nil {
+			return nil, fmt.Errorf("failed to encode bulk action: %w", err)
+		}
+		if err := encoder.Encode(record.Document); err != nil {
+			return nil, fmt.Errorf("failed to encode history document %s: %w", record.DocumentID, err)
+		}
+	}
+
+	request, err := http.NewRequestWithContext(e.ctx, http.MethodPost,
+		strings.TrimRight(e.cfg.URL, "/")+"/_bulk", &body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bulk request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/x-ndjson")
+	switch {
+	case e.cfg.APIKey != "":
+		request.Header.Set("Authorization", "ApiKey "+e.cfg.APIKey)
+	case e.cfg.Username != "":
+		request.SetBasicAuth(e.cfg.Username, e.cfg.Password)
+	}
+
+	response, err := e.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("bulk request failed: %w", err)
+	}
+	defer response.Body.Close()
+
+	data, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bulk response: %w", err)
+	}
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		if len(data) > maxErrorBodySize {
+			data = data[:maxErrorBodySize]
+		}
+		return nil, fmt.Errorf("bulk request returned status %d: %s", response.StatusCode, string(data))
+	}
+
+	var parsed bulkResponse
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to decode bulk response: %w", err)
+	}
+	if len(parsed.Items) != len(records) {
+		return nil, fmt.Errorf("bulk response has %d items for %d records", len(parsed.Items), len(records))
+	}
+
+	return e.splitOutcomes(records, parsed.Items), nil
+}
+
+// splitOutcomes matches bulk items to records by position
+// Сопоставляет элементы ответа bulk с записями по позиции
+func (e *Exporter) splitOutcomes(records []*models.HistoryRecord, items []map[string]bulkItemOutcome) *bulkResult {
+	result := &bulkResult{}
+	for i, record := range records {
+		var outcome bulkItemOutcome
+		for _, value := range items[i] {
+			outcome = value
+		}
+
+		switch {
+		case outcome.Status >= 200 && outcome.Status < 300:
+			result.exported = append(result.exported, record.ID)
+		case outcome.Status == http.StatusTooManyRequests || outcome.Status >= 500:
+			result.retryable++
+			if result.firstError == "" {
+				result.firstError = string(outcome.Error)
+			}
+		default:
+			result.rejected = append(result.rejected, record.ID)
+			logger.Warn("History record rejected by endpoint",
+				logger.String("kind", record.Kind),
+				logger.String("document_id", record.DocumentID),
+				logger.Int("status", outcome.Status),
+				logger.String("error", string(outcome.Error)))
+		}
+	}
+	return result
+}
@@ -0,0 +1,309 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package historyexport
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"atom-engine/src/core/config"
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+	"atom-engine/src/core/types"
+)
+
+// eventsBufferSize bounds engine events waiting for conversion, newer events are dropped when full
+// Ограничивает число событий движка, ожидающих преобразования, при переполнении новые события отбрасываются
+const eventsBufferSize = 1024
+
+// Store is subset of storage used by exporter
+// Подмножество storage, используемое экспортером
+type Store interface {
+	SaveHistoryRecords(records []*models.HistoryRecord) error
+	ListHistoryRecords(limit int) ([]*models.HistoryRecord, error)
+	DeleteHistoryRecords(recordIDs []string) error
+	CountHistoryRecords() (int, error)
+	LoadProcessInstance(instanceID string) (*models.ProcessInstance, error)
+	LoadTokensByProcessInstance(processInstanceID string) ([]*models.Token, error)
+}
+
+// Exporter converts engine events into history documents, keeps them in storage backlog
+// and sends backlog to Elasticsearch compatible endpoint with bulk requests
+// Преобразует события движка в документы истории, хранит их в очереди в storage
+// и отправляет очередь в Elasticsearch совместимый endpoint bulk запросами
+type Exporter struct {
+	cfg    config.HistoryExportConfig
+	store  Store
+	client *http.Client
+	events chan models.EngineEvent
+	wakeup chan struct{}
+
+	backlog        atomic.Int64 // Records waiting in storage
+	oldestQueuedAt atomic.Int64 // Unix nanoseconds of oldest waiting record, 0 when backlog is empty
+	exported       atomic.Uint64
+	requestErrors  atomic.Uint64 // Failed bulk requests and retryable item failures
+	rejected       atomic.Uint64 // Records rejected by endpoint and dropped from backlog
+	droppedEvents  atomic.Uint64 // Events dropped because buffer was full
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewExporter creates exporter from configuration
+// Создает экспортер из конфигурации
+func NewExporter(cfg config.HistoryExportConfig, store Store) (*Exporter, error) {
+	if store == nil {
+		return nil, fmt.Errorf("storage is required")
+	}
+	if _, err := url.ParseRequestURI(cfg.URL); err != nil {
+		return nil, fmt.Errorf("invalid history export url %q: %w", cfg.URL, err)
+	}
+	if !strings.Contains(cfg.IndexTemplate, "{kind}") {
+		return nil, fmt.Errorf("index template %q must contain {kind}", cfg.IndexTemplate)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if cfg.InsecureSkipVerify {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // #nosec G402 -- explicitly configured
+	}
+
+	return &Exporter{
+		cfg:   cfg,
+		store: store,
+		client: &http.Client{
+			Timeout:   time.Duration(cfg.RequestTimeoutMs) * time.Millisecond,
+			Transport: transport,
+		},
+		events: make(chan models.EngineEvent, eventsBufferSize),
+		wakeup: make(chan struct{}, 1),
+	}, nil
+}
+
+// Start restores backlog size and launches collecting and sending loops
+// Восстанавливает размер очереди и запускает циклы сбора и отправки
+func (e *Exporter) Start() {
+	e.ctx, e.cancel = context.WithCancel(context.Background())
+
+	if count, err := e.store.CountHistoryRecords(); err != nil {
+		logger.Error("History exporter failed to count backlog", logger.String("error", err.Error()))
+	} else {
+		e.backlog.Store(int64(count))
+	}
+
+	e.wg.Add(2)
+	go e.runCollector()
+	go e.runSender()
+
+	logger.Info("History exporter started",
+		logger.String("url", e.cfg.URL),
+		logger.String("index_template", e.cfg.IndexTemplate),
+		logger.Int("backlog", int(e.backlog.Load())))
+}
+
+// Stop stops loops, records not sent yet stay in backlog
+// Останавливает циклы, неотправленные записи остаются в очереди
+func (e *Exporter) Stop() {
+	if e.cancel == nil {
+		return
+	}
+	e.cancel()
+	e.wg.Wait()
+	e.client.CloseIdleConnections()
+
+	logger.Info("History exporter stopped", logger.Int("backlog", int(e.backlog.Load())))
+}
+
+// HandleEngineEvent queues engine event for export, never blocks engine
+// Ставит событие движка в очередь экспорта, никогда не блокирует движок
+func (e *Exporter) HandleEngineEvent(event models.EngineEvent) {
+	switch event.Type {
+	case models.EngineEventProcessInstanceCompleted,
+		models.EngineEventProcessInstanceCanceled,
+		models.EngineEventProcessInstanceSLABreached,
+		models.EngineEventIncidentCreated:
+	default:
+		return
+	}
+
+	select {
+	case e.events <- event:
+	default:
+		if dropped := e.droppedEvents.Add(1); dropped == 1 || dropped%1000 == 0 {
+			logger.Warn("History exporter events buffer is full, engine events dropped",
+				logger.Any("dropped_total", dropped))
+		}
+	}
+}
+
+// Stats returns exporter counters
+// Возвращает счетчики экспортера
+func (e *Exporter) Stats() *types.HistoryExportStats {
+	stats := &types.HistoryExportStats{
+		Backlog:       e.backlog.Load(),
+		Exported:      e.exported.Load(),
+		RequestErrors: e.requestErrors.Load(),
+		Rejected:      e.rejected.Load(),
+		DroppedEvents: e.droppedEvents.Load(),
+	}
+	if oldest := e.oldestQueuedAt.Load(); oldest > 0 && stats.Backlog > 0 {
+		stats.LagSeconds = time.Since(time.Unix(0, oldest)).Seconds()
+	}
+	return stats
+}
+
+// runCollector converts queued events into records and appends them to backlog
+// Преобразует события из очереди в записи и добавляет их в очередь в storage
+func (e *Exporter) runCollector() {
+	defer e.wg.Done()
+
+	for {
+		select {
+		case <-e.ctx.Done():
+			return
+		case event := <-e.events:
+			e.collect(event)
+		}
+	}
+}
+
+// collect persists records of single event
+// Сохраняет записи одного события
+func (e *Exporter) collect(event models.EngineEvent) {
+	records, err := e.buildRecords(event)
+	if err != nil {
+		logger.Error("History exporter failed to build records",
+			logger.String("type", event.Type),
+			logger.String("process_instance_id", event.ProcessInstanceID),
+			logger.String("error", err.Error()))
+		return
+	}
+	if len(records) == 0 {
+		return
+	}
+
+	if err := e.store.SaveHistoryRecords(records); err != nil {
+		logger.Error("History exporter failed to save records to backlog",
+			logger.String("type", event.Type),
+			logger.String("process_instance_id", event.ProcessInstanceID),
+			logger.String("error", err.Error()))
+		return
+	}
+
+	e.oldestQueuedAt.CompareAndSwap(0, records[0].QueuedAt.UnixNano())
+	if e.backlog.Add(int64(len(records))) >= int64(e.cfg.BatchSize) {
+		select {
+		case e.wakeup <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// runSender sends backlog on flush interval or when full batch is waiting
+// Failed requests are retried with doubled delay, records stay in backlog meanwhile
+// Отправляет очередь по интервалу или когда накопился полный пакет
+// Неудачные запросы повторяются с удвоенной задержкой, записи остаются в очереди
+func (e *Exporter) runSender() {
+	defer e.wg.Done()
+
+	flushInterval := time.Duration(e.cfg.FlushIntervalMs) * time.Millisecond
+	retryInitial := time.Duration(e.cfg.RetryInitialMs) * time.Millisecond
+	retryMax := time.Duration(e.cfg.RetryMaxMs) * time.Millisecond
+
+	retryDelay := time.Duration(0)
+	for {
+		wait := flushInterval
+		if retryDelay > 0 {
+			wait = retryDelay
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-e.ctx.Done():
+			timer.Stop()
+			return
+		case <-e.wakeup:
+			timer.Stop()
+			if retryDelay > 0 {
+				continue // Keep backing off, endpoint is still failing
+			}
+		case <-timer.C:
+		}
+
+		if err := e.flush(); err != nil {
+			if e.ctx.Err() != nil {
+				return
+			}
+			e.requestErrors.Add(1)
+			retryDelay = nextRetryDelay(retryDelay, retryInitial, retryMax)
+			logger.Warn("History export failed, retrying",
+				logger.String("error", err.Error()),
+				logger.Int("backlog", int(e.backlog.Load())),
+				logger.String("retry_in", retryDelay.String()))
+			continue
+		}
+		retryDelay = 0
+	}
+}
+
+// flush sends backlog batch by batch until it is empty
+// Отправляет очередь пакет за пакетом, пока она не опустеет
+func (e *Exporter) flush() error {
+	for e.ctx.Err() == nil {
+		records, err := e.store.ListHistoryRecords(e.cfg.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to read backlog: %w", err)
+		}
+		if len(records) == 0 {
+			e.oldestQueuedAt.Store(0)
+			return nil
+		}
+		e.oldestQueuedAt.Store(records[0].QueuedAt.UnixNano())
+
+		result, err := e.sendBulk(records)
+		if err != nil {
+			return err
+		}
+
+		done := append(result.exported, result.rejected...)
+		if len(done) > 0 {
+			if err := e.store.DeleteHistoryRecords(done); err != nil {
+				return fmt.Errorf("failed to delete exported records: %w", err)
+			}
+			e.backlog.Add(-int64(len(done)))
+		}
+		e.exported.Add(uint64(len(result.exported)))
+		e.rejected.Add(uint64(len(result.rejected)))
+
+		if result.retryable > 0 {
+			return fmt.Errorf("%d records were not accepted: %s", result.retryable, result.firstError)
+		}
+	}
+	return e.ctx.Err()
+}
+
+// nextRetryDelay doubles delay starting from initial up to max
+// Удваивает задержку, начиная с initial, не больше max
+func nextRetryDelay(current, initial, max time.Duration) time.Duration {
+	if current <= 0 {
+		return initial
+	}
+	next := current * 2
+	if max > 0 && next > max {
+		return max
+	}
+	return next
+}
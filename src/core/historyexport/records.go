@@ -0,0 +1,193 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package historyexport
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"atom-engine/src/core/models"
+)
+
+// buildRecords converts engine event into history records
+// Instance events produce instance document and element document per token
+// Преобразует событие движка в записи истории
+// События экземпляра дают документ экземпляра и документ элемента на каждый токен
+func (e *Exporter) buildRecords(event models.EngineEvent) ([]*models.HistoryRecord, error) {
+	now := time.Now()
+
+	switch event.Type {
+	case models.EngineEventProcessInstanceCompleted, models.EngineEventProcessInstanceCanceled:
+		instance, err := e.store.LoadProcessInstance(event.ProcessInstanceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load process instance: %w", err)
+		}
+		tokens, err := e.store.LoadTokensByProcessInstance(event.ProcessInstanceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tokens: %w", err)
+		}
+
+		records := make([]*models.HistoryRecord, 0, len(tokens)+1)
+		records = append(records, newRecord(models.HistoryKindInstance, instance.InstanceID,
+			eventTime(event), now, instanceDocument(event, instance)))
+		for _, token := range tokens {
+			timestamp := token.UpdatedAt
+			if token.CompletedAt != nil {
+				timestamp = *token.CompletedAt
+			}
+			records = append(records, newRecord(models.HistoryKindElement, token.TokenID,
+				timestamp, now, elementDocument(instance, token)))
+		}
+		return records, nil
+
+	case models.EngineEventProcessInstanceSLABreached:
+		instance, err := e.store.LoadProcessInstance(event.ProcessInstanceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load process instance: %w", err)
+		}
+		return []*models.HistoryRecord{
+			newRecord(models.HistoryKindSLA, instance.InstanceID, eventTime(event), now, slaDocument(event, instance)),
+		}, nil
+
+	case models.EngineEventIncidentCreated:
+		return []*models.HistoryRecord{
+			newRecord(models.HistoryKindIncident, event.IncidentID, eventTime(event), now, e.incidentDocument(event)),
+		}, nil
+	}
+	return nil, nil
+}
+
+// newRecord creates backlog record, document gets @timestamp of event
+// Создает запись очереди, документ получает @timestamp события
+func newRecord(
+	kind, documentID string, timestamp, queuedAt time.Time, document map[string]interface{},
+) *models.HistoryRecord {
+	document["@timestamp"] = timestamp.UTC().Format(time.RFC3339Nano)
+	return &models.HistoryRecord{
+		ID:         models.GenerateULID(),
+		Kind:       kind,
+		DocumentID: documentID,
+		Timestamp:  timestamp,
+		QueuedAt:   queuedAt,
+		Document:   document,
+	}
+}
+
+// instanceDocument describes finished process instance
+// Numeric keys are strings because backlog round trip through JSON loses int64 precision
+// Описывает завершенный экземпляр процесса
+// Числовые ключи передаются строками, так как JSON очереди теряет точность int64
+func instanceDocument(event models.EngineEvent, instance *models.ProcessInstance) map[string]interface{} {
+	endedAt := eventTime(event)
+	if instance.CompletedAt != nil {
+		endedAt = *instance.CompletedAt
+	}
+
+	document := map[string]interface{}{
+		"event_type":      event.Type,
+		"instance_id":     instance.InstanceID,
+		"instance_key":    strconv.FormatInt(instance.Key, 10),
+		"process_id":      instance.ProcessID,
+		"process_key":     instance.ProcessKey,
+		"process_name":    instance.ProcessName,
+		"process_version": instance.ProcessVersion,
+		"state":           string(instance.State),
+		"started_at":      instance.StartedAt.UTC().Format(time.RFC3339Nano),
+		"ended_at":        endedAt.UTC().Format(time.RFC3339Nano),
+		"duration_ms":     endedAt.Sub(instance.StartedAt).Milliseconds(),
+		"variables":       instance.Variables,
+	}
+	if instance.SLADeadline != nil {
+		document["sla_deadline"] = instance.SLADeadline.UTC().Format(time.RFC3339Nano)
+		document["sla_breached"] = instance.SLABreachedAt != nil
+	}
+	return document
+}
+
+// slaDocument describes instance still running at its SLA deadline
+// Описывает экземпляр, все еще выполняющийся в срок SLA
+func slaDocument(event models.EngineEvent, instance *models.ProcessInstance) map[string]interface{} {
+	breachedAt := eventTime(event)
+	if instance.SLABreachedAt != nil {
+		breachedAt = *instance.SLABreachedAt
+	}
+
+	document := map[string]interface{}{
+		"event_type":      event.Type,
+		"instance_id":     instance.InstanceID,
+		"instance_key":    strconv.FormatInt(instance.Key, 10),
+		"process_id":      instance.ProcessID,
+		"process_key":     instance.ProcessKey,
+		"process_version": instance.ProcessVersion,
+		"state":           string(instance.State),
+		"started_at":      instance.StartedAt.UTC().Format(time.RFC3339Nano),
+		"breached_at":     breachedAt.UTC().Format(time.RFC3339Nano),
+		"elapsed_ms":      breachedAt.Sub(instance.StartedAt).Milliseconds(),
+	}
+	if instance.SLADeadline != nil {
+		document["sla_deadline"] = instance.SLADeadline.UTC().Format(time.RFC3339Nano)
+	}
+	return document
+}
+
+// elementDocument describes element passed by token of finished instance
+// Описывает элемент, пройденный токеном завершенного экземпляра
+func elementDocument(instance *models.ProcessInstance, token *models.Token) map[string]interface{} {
+	document := map[string]interface{}{
+		"token_id":             token.TokenID,
+		"element_instance_key": strconv.FormatInt(token.Key, 10),
+		"instance_id":          instance.InstanceID,
+		"process_id":           instance.ProcessID,
+		"process_key":          instance.ProcessKey,
+		"element_id":           token.CurrentElementID,
+		"previous_element_id":  token.PreviousElementID,
+		"state":                string(token.State),
+		"token_type":           string(token.Type),
+		"created_at":           token.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+	if token.CompletedAt != nil {
+		document["completed_at"] = token.CompletedAt.UTC().Format(time.RFC3339Nano)
+		document["duration_ms"] = token.CompletedAt.Sub(token.CreatedAt).Milliseconds()
+	}
+	if token.ParentTokenID != "" {
+		document["parent_token_id"] = token.ParentTokenID
+	}
+	return document
+}
+
+// incidentDocument describes created incident, process ID is resolved from instance when possible
+// Описывает созданный инцидент, ID процесса определяется по экземпляру, если возможно
+func (e *Exporter) incidentDocument(event models.EngineEvent) map[string]interface{} {
+	document := map[string]interface{}{
+		"incident_id": event.IncidentID,
+		"instance_id": event.ProcessInstanceID,
+		"process_key": event.ProcessKey,
+		"process_id":  event.ProcessID,
+	}
+	for key, value := range event.Data {
+		document[key] = value
+	}
+
+	if event.ProcessID == "" && event.ProcessInstanceID != "" {
+		if instance, err := e.store.LoadProcessInstance(event.ProcessInstanceID); err == nil && instance != nil {
+			document["process_id"] = instance.ProcessID
+		}
+	}
+	return document
+}
+
+// eventTime returns event timestamp, current time for events without it
+// Возвращает время события, текущее время для событий без него
+func eventTime(event models.EngineEvent) time.Time {
+	if event.Timestamp.IsZero() {
+		return time.Now()
+	}
+	return event.Timestamp
+}
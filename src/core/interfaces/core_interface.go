@@ -13,7 +13,10 @@ import (
 	"time"
 
 	"atom-engine/proto/timewheel/timewheelpb"
+	"atom-engine/src/core/archive"
 	"atom-engine/src/core/models"
+	"atom-engine/src/core/objectstore"
+	"atom-engine/src/core/triggers"
 	"atom-engine/src/core/types"
 	"atom-engine/src/storage"
 )
@@ -26,6 +29,10 @@ type CoreInterface interface {
 	GetStorageStatus() (*StorageStatusResponse, error)
 	GetStorageInfo() (*StorageInfoResponse, error)
 
+	// Activity counts of listed process instances
+	// Счетчики активности экземпляров процессов в списке
+	FillProcessInstanceCounts(instances []*ProcessInstanceStatus) error
+
 	// Component access - typed interfaces
 	// Доступ к компонентам - типизированные интерфейсы
 	GetProcessComponent() ProcessComponentInterface
@@ -61,13 +68,15 @@ type CoreInterface interface {
 	// JSON Message Routing
 	// Маршрутизация JSON сообщений
 	SendMessage(componentName, messageJSON string) error
-
-	// Response Handling
-	// Обработка ответов
-	WaitForParserResponse(timeoutMs int) (string, error)
-	WaitForJobsResponse(timeoutMs int) (string, error)
-	WaitForMessagesResponse(timeoutMs int) (string, error)
-	WaitForIncidentsResponse(timeoutMs int) (string, error)
+	SendMessageWithContext(ctx context.Context, componentName, messageJSON string) error
+	SendRequestWithContext(ctx context.Context, componentName, messageJSON string) (string, error)
+
+	// Response Handling, responses are matched to requests by request ID
+	// Обработка ответов, ответы сопоставляются с запросами по ID запроса
+	WaitForParserResponse(requestID string, timeoutMs int) (string, error)
+	WaitForJobsResponse(requestID string, timeoutMs int) (string, error)
+	WaitForMessagesResponse(requestID string, timeoutMs int) (string, error)
+	WaitForIncidentsResponse(requestID string, timeoutMs int) (string, error)
 }
 
 // CoreTypedInterface defines strongly typed system-wide methods
@@ -79,19 +88,66 @@ type CoreTypedInterface interface {
 	// Новые строго типизированные системные методы
 	GetSystemStatus() (*types.SystemStatus, error)
 	GetSystemInfo() (*types.SystemInfo, error)
+	GetTokenExecutionStats() (*types.TokenExecutionStats, error)
+	GetComponentLatencyStats() []types.ComponentLatencyStats
+	GetHistoryExportStats() *types.HistoryExportStats
+	GetJobBacklogStats() []types.JobBacklogStats
+	GetAuditStats() *types.AuditStats
 	GetSystemMetrics() (*types.SystemMetrics, error)
 	ListComponents(req *types.ComponentListRequest) (*types.ComponentListResponse, error)
 	GetComponentStatus(componentName string) (*types.ComponentInfo, error)
 	HealthCheck(req *types.ComponentHealthCheckRequest) (*types.ComponentHealthCheckResponse, error)
+	GetProbeStatus() *types.ProbeStatus
 
 	// Strongly typed process operations
 	// Строго типизированные операции с процессами
 	StartProcessTyped(req *types.ProcessStartRequest) (*types.ProcessStartResponse, error)
 	CancelProcessTyped(req *types.ProcessCancelRequest) (*types.ProcessCancelResponse, error)
+	PatchProcessInstanceVariables(instanceID string, patch map[string]interface{}) (map[string]interface{}, error)
+	StartProcessInstanceBatch(
+		ctx context.Context,
+		items []models.BatchStartItem,
+		async bool,
+	) (*models.BatchStart, error)
+	GetProcessInstanceBatch(batchID string) (*models.BatchStart, error)
+	GetProcessStatistics(processID string, days int) (*models.ProcessStatistics, error)
+	DryRunProcess(processKey string, request *models.DryRunRequest) (*models.DryRunResult, error)
+	GetGatewayMetrics(processKey string) (*models.GatewayMetrics, error)
+	SetProcessDebugLogging(processKey string, debug bool, duration time.Duration) (*models.ProcessLogging, error)
+	ListElementInstances(query models.ElementInstanceQuery) ([]*models.ElementInstance, int, error)
+	ListVariableHistory(query models.VariableHistoryQuery) ([]*models.VariableHistoryEntry, int, error)
+
+	// Simulated timewheel clock, available only in simulation mode
+	// Симулированные часы timewheel, доступны только в режиме симуляции
+	SimulationEnabled() bool
+	GetSimulationTime() (time.Time, error)
+	AdvanceSimulationClock(duration time.Duration) (*models.SimulationAdvance, error)
 
 	// REST API adapter methods
 	// Методы адаптера для REST API
-	GetProcessInfoForREST(instanceID string) (map[string]interface{}, error)
+	GetProcessInfoForREST(instanceID string) (*types.ProcessInfo, error)
+
+	// Process instance archive export and import
+	// Экспорт и импорт архивов экземпляров процессов
+	ExportProcessInstance(instanceID string) (*archive.ProcessArchive, error)
+	StoreProcessInstanceExport(ctx context.Context, instanceID string) (*objectstore.ObjectInfo, error)
+	ImportProcessInstance(processArchive *archive.ProcessArchive) (*archive.ImportResult, error)
+
+	// Retention of finished process instances
+	// Очистка завершенных экземпляров процессов по сроку хранения
+	GetRetentionStatus() *archive.RetentionStatus
+	GetArchivedInstance(instanceID string) (*models.ArchivedInstance, error)
+
+	// Storage backups in object storage
+	// Резервные копии storage в объектном хранилище
+	CreateBackup(ctx context.Context) (*models.BackupInfo, error)
+	ListBackups(ctx context.Context) ([]*models.BackupInfo, error)
+	VerifyBackup(ctx context.Context, name string) (*models.BackupInfo, error)
+	DeleteBackup(ctx context.Context, name string) error
+
+	// Inbound webhook triggers
+	// Входящие webhook триггеры
+	GetWebhookTriggers() *triggers.Manager
 
 	// Strongly typed operations results
 	// Строго типизированные результаты операций
@@ -168,11 +224,40 @@ type ProcessComponentInterface interface {
 	// Legacy methods for backward compatibility
 	// Устаревшие методы для обратной совместимости
 	StartProcessInstance(processKey string, variables map[string]interface{}) (*ProcessInstanceResult, error)
+	StartProcessInstanceWithPriority(
+		processKey string,
+		variables map[string]interface{},
+		priority int,
+	) (*ProcessInstanceResult, error)
+	StartProcessInstanceWithContext(
+		ctx context.Context,
+		processKey string,
+		variables map[string]interface{},
+		priority int,
+	) (*ProcessInstanceResult, error)
+	StartProcessInstanceAsync(
+		ctx context.Context,
+		processKey string,
+		variables map[string]interface{},
+		priority int,
+	) (*ProcessInstanceResult, error)
 	GetProcessInstanceStatus(instanceID string) (*ProcessInstanceStatus, error)
 	CancelProcessInstance(instanceID string, reason string) error
+	RestartProcessInstance(instanceID string, elementID string) (*ProcessInstanceResult, error)
+	ModifyProcessInstance(
+		instanceID string,
+		instructions []models.ModificationInstruction,
+	) (*models.ProcessInstanceModification, error)
+	MigrateProcessInstances(
+		plan models.MigrationPlan,
+		filter models.MigrationFilter,
+		dryRun bool,
+	) (*models.ProcessInstanceMigration, error)
 	ListProcessInstances(statusFilter string, processKeyFilter string, limit int) ([]*ProcessInstanceStatus, error)
+	QueryProcessInstances(query models.ProcessInstanceQuery) ([]*ProcessInstanceStatus, int, error)
 	GetTokensByProcessInstance(instanceID string) ([]*models.Token, error)
 	GetActiveTokens(instanceID string) ([]*models.Token, error)
+	PatchProcessInstanceVariables(instanceID string, patch map[string]interface{}) (map[string]interface{}, error)
 }
 
 // ProcessComponentTypedInterface defines strongly typed process methods
@@ -194,10 +279,12 @@ type ProcessComponentTypedInterface interface {
 // Представляет результат создания экземпляра процесса
 type ProcessInstanceResult struct {
 	InstanceID      string                 `json:"instance_id"`
+	InstanceKey     int64                  `json:"instance_key,omitempty"`
 	ProcessKey      string                 `json:"process_key"`
 	ProcessID       string                 `json:"process_id"`
 	ProcessName     string                 `json:"process_name"`
 	Version         int32                  `json:"version"`
+	Priority        int                    `json:"priority"`
 	Variables       map[string]interface{} `json:"variables"`
 	Status          string                 `json:"status"`
 	State           string                 `json:"state"`
@@ -206,23 +293,48 @@ type ProcessInstanceResult struct {
 	StartedAt       int64                  `json:"started_at"`
 	UpdatedAt       int64                  `json:"updated_at"`
 	CompletedAt     int64                  `json:"completed_at,omitempty"`
+
+	// Instance this one was restarted from
+	// Экземпляр, из которого перезапущен данный
+	ParentInstanceID string `json:"parent_instance_id,omitempty"`
+
+	// Time instance must finish by, empty when instance has no SLA
+	// Время, до которого экземпляр должен завершиться, пусто если у экземпляра нет SLA
+	SLADeadline string `json:"sla_deadline,omitempty"`
 }
 
 // ProcessInstanceStatus represents process instance status
 // Представляет статус экземпляра процесса
 type ProcessInstanceStatus struct {
 	InstanceID      string                 `json:"instance_id"`
+	InstanceKey     int64                  `json:"instance_key,omitempty"`
 	ProcessKey      string                 `json:"process_key"`
 	ProcessID       string                 `json:"process_id"`
 	ProcessName     string                 `json:"process_name"`
 	Status          string                 `json:"status"`
 	State           string                 `json:"state"`
 	CurrentActivity string                 `json:"current_activity"`
+	Priority        int                    `json:"priority"`
 	Variables       map[string]interface{} `json:"variables"`
 	CreatedAt       string                 `json:"created_at"`
 	UpdatedAt       int64                  `json:"updated_at"`
 	StartedAt       int64                  `json:"started_at"`
 	CompletedAt     string                 `json:"completed_at,omitempty"`
+
+	// Instance this one was restarted from
+	// Экземпляр, из которого перезапущен данный
+	ParentInstanceID string `json:"parent_instance_id,omitempty"`
+
+	// SLA deadline and time instance was found running at it, empty when not set
+	// Срок SLA и время, когда экземпляр был обнаружен выполняющимся в этот срок, пусто если не заданы
+	SLADeadline   string `json:"sla_deadline,omitempty"`
+	SLABreachedAt string `json:"sla_breached_at,omitempty"`
+
+	// Activity counts, set only when requested
+	// Счетчики активности, заполняются только по запросу
+	ActiveTimers  *int `json:"active_timers,omitempty"`
+	ActiveJobs    *int `json:"active_jobs,omitempty"`
+	OpenIncidents *int `json:"open_incidents,omitempty"`
 }
 
 // ProcessInstanceList represents list of process instances
@@ -345,6 +457,7 @@ type ExpressionComponentInterface interface {
 	// Устаревшие методы для обратной совместимости
 	EvaluateExpression(expression string, variables map[string]interface{}) (interface{}, error)
 	EvaluateCondition(variables map[string]interface{}, condition string) (bool, error)
+	EvaluateUnaryTest(input interface{}, test string, variables map[string]interface{}) (bool, error)
 	EvaluateExpressionEngine(expression interface{}, variables map[string]interface{}) (interface{}, error)
 	ParseRetries(retriesStr string) (int, error)
 }
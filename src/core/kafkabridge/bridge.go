@@ -0,0 +1,169 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package kafkabridge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"atom-engine/src/core/config"
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+	"atom-engine/src/jobs"
+)
+
+// eventsBufferSize bounds engine events waiting for publishing, newer events are dropped when full
+// Ограничивает число событий движка, ожидающих публикации, при переполнении новые события отбрасываются
+const eventsBufferSize = 1024
+
+// writerBatchTimeout limits time producer waits to fill batch
+// Ограничивает время ожидания producer'ом заполнения пакета
+const writerBatchTimeout = 10 * time.Millisecond
+
+// JobsClient is subset of jobs component used by bridge
+// Подмножество jobs компонента, используемое мостом
+type JobsClient interface {
+	ActivateJobsWithTimeout(workerName, jobType string, maxJobs int, timeoutMs int32) ([]jobs.JobInfo, error)
+	GetJob(jobID string) (*jobs.JobInfo, error)
+	CompleteJob(jobKey string, variables map[string]interface{}) error
+	FailJobWithBackoff(jobKey string, retries int, errorMessage string, retryBackoff time.Duration) error
+	ThrowErrorWithVariables(jobKey string, errorCode, errorMessage string, variables map[string]interface{}) error
+}
+
+// Bridge publishes activatable jobs and engine events to Kafka and applies job responses consumed from Kafka
+// Публикует активируемые job'ы и события движка в Kafka и применяет ответы по job'ам, прочитанные из Kafka
+type Bridge struct {
+	cfg        config.KafkaBridgeConfig
+	jobs       JobsClient
+	settings   *connectionSettings
+	eventTypes map[string]bool
+
+	jobWriter   *kafka.Writer
+	eventWriter *kafka.Writer
+	reader      *kafka.Reader
+	events      chan models.EngineEvent
+
+	droppedEvents atomic.Uint64 // Events dropped because buffer was full
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewBridge creates bridge from configuration
+// Создает мост из конфигурации
+func NewBridge(cfg config.KafkaBridgeConfig, jobsClient JobsClient) (*Bridge, error) {
+	if jobsClient == nil && len(cfg.JobTopics) > 0 {
+		return nil, fmt.Errorf("jobs component is required for job topics")
+	}
+
+	settings, err := newConnectionSettings(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	bridge := &Bridge{
+		cfg:      cfg,
+		jobs:     jobsClient,
+		settings: settings,
+	}
+	if len(cfg.EventTypes) > 0 {
+		bridge.eventTypes = make(map[string]bool, len(cfg.EventTypes))
+		for _, eventType := range cfg.EventTypes {
+			bridge.eventTypes[eventType] = true
+		}
+	}
+	return bridge, nil
+}
+
+// Start launches job publishing, response consuming and event mirroring loops
+// Запускает циклы публикации job'ов, чтения ответов и трансляции событий
+func (b *Bridge) Start() {
+	b.ctx, b.cancel = context.WithCancel(context.Background())
+
+	if len(b.cfg.JobTopics) > 0 {
+		b.jobWriter = b.newWriter()
+		b.reader = kafka.NewReader(kafka.ReaderConfig{
+			Brokers:        b.cfg.Brokers,
+			GroupID:        b.cfg.ConsumerGroup,
+			Topic:          b.cfg.ResponseTopic,
+			Dialer:         b.settings.dialer(),
+			CommitInterval: 0, // Offsets are committed synchronously after response is applied
+		})
+
+		for jobType, topic := range b.cfg.JobTopics {
+			b.wg.Add(1)
+			go b.runJobPublisher(jobType, topic)
+		}
+
+		b.wg.Add(1)
+		go b.runResponseConsumer()
+	}
+
+	if b.cfg.EventsTopic != "" {
+		b.eventWriter = b.newWriter()
+		b.events = make(chan models.EngineEvent, eventsBufferSize)
+
+		b.wg.Add(1)
+		go b.runEventPublisher()
+	}
+
+	logger.Info("Kafka bridge started",
+		logger.Any("brokers", b.cfg.Brokers),
+		logger.Int("job_types", len(b.cfg.JobTopics)),
+		logger.String("response_topic", b.cfg.ResponseTopic),
+		logger.String("events_topic", b.cfg.EventsTopic))
+}
+
+// Stop stops loops and closes Kafka connections
+// Jobs published but not answered stay activated until their lease expires
+// Останавливает циклы и закрывает соединения с Kafka
+// Опубликованные job'ы без ответа остаются активированными до истечения аренды
+func (b *Bridge) Stop() error {
+	if b.cancel == nil {
+		return nil
+	}
+	b.cancel()
+	b.wg.Wait()
+
+	var closeErrors []error
+	if b.reader != nil {
+		closeErrors = append(closeErrors, b.reader.Close())
+	}
+	if b.jobWriter != nil {
+		closeErrors = append(closeErrors, b.jobWriter.Close())
+	}
+	if b.eventWriter != nil {
+		closeErrors = append(closeErrors, b.eventWriter.Close())
+	}
+
+	logger.Info("Kafka bridge stopped")
+	if err := errors.Join(closeErrors...); err != nil {
+		return fmt.Errorf("failed to close kafka connections: %w", err)
+	}
+	return nil
+}
+
+// newWriter creates producer, topic is set per message
+// Создает producer, топик задается для каждого сообщения
+func (b *Bridge) newWriter() *kafka.Writer {
+	return &kafka.Writer{
+		Addr:         kafka.TCP(b.cfg.Brokers...),
+		Balancer:     &kafka.Hash{},
+		RequiredAcks: kafka.RequireAll,
+		BatchTimeout: writerBatchTimeout,
+		Transport:    b.settings.transport(),
+	}
+}
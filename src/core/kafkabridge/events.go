@@ -0,0 +1,80 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package kafkabridge
+
+import (
+	"encoding/json"
+
+	"github.com/segmentio/kafka-go"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+)
+
+// HandleEngineEvent queues engine event for mirroring, never blocks engine
+// Ставит событие движка в очередь трансляции, никогда не блокирует движок
+func (b *Bridge) HandleEngineEvent(event models.EngineEvent) {
+	if b.events == nil {
+		return
+	}
+	if b.eventTypes != nil && !b.eventTypes[event.Type] {
+		return
+	}
+
+	select {
+	case b.events <- event:
+	default:
+		if dropped := b.droppedEvents.Add(1); dropped == 1 || dropped%1000 == 0 {
+			logger.Warn("Kafka bridge events buffer is full, engine events dropped",
+				logger.Any("dropped_total", dropped))
+		}
+	}
+}
+
+// runEventPublisher writes queued engine events to events topic until bridge stops
+// Записывает события движка из очереди в топик событий до остановки моста
+func (b *Bridge) runEventPublisher() {
+	defer b.wg.Done()
+
+	for {
+		select {
+		case <-b.ctx.Done():
+			return
+		case event := <-b.events:
+			b.publishEvent(event)
+		}
+	}
+}
+
+// publishEvent writes single event keyed by process instance so events of instance keep order
+// Записывает одно событие с ключом экземпляра процесса, чтобы события экземпляра сохраняли порядок
+func (b *Bridge) publishEvent(event models.EngineEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		logger.Error("Kafka bridge failed to marshal engine event",
+			logger.String("type", event.Type),
+			logger.String("error", err.Error()))
+		return
+	}
+
+	message := kafka.Message{
+		Topic: b.cfg.EventsTopic,
+		Key:   []byte(event.ProcessInstanceID),
+		Value: payload,
+		Headers: []kafka.Header{
+			{Key: "event-type", Value: []byte(event.Type)},
+		},
+	}
+	if err := b.eventWriter.WriteMessages(b.ctx, message); err != nil && b.ctx.Err() == nil {
+		logger.Error("Kafka bridge failed to publish engine event",
+			logger.String("type", event.Type),
+			logger.String("process_instance_id", event.ProcessInstanceID),
+			logger.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,262 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package kafkabridge
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+	"atom-engine/src/jobs"
+)
+
+// Job response actions
+// Действия ответа по job'у
+const (
+	ActionComplete = "complete"
+	ActionFail     = "fail"
+	ActionError    = "error" // Throw BPMN error
+)
+
+// consumerRetryDelay is pause after failed fetch or commit of response
+// Пауза после неудачного чтения или фиксации ответа
+const consumerRetryDelay = time.Second
+
+// JobResponse is message consumed from response topic
+// Сообщение, читаемое из топика ответов
+type JobResponse struct {
+	JobKey         string                 `json:"job_key"` // Falls back to Kafka message key
+	Action         string                 `json:"action"`  // complete, fail or error
+	Variables      map[string]interface{} `json:"variables,omitempty"`
+	Retries        *int                   `json:"retries,omitempty"` // Remaining retries for fail, default decrements
+	ErrorMessage   string                 `json:"error_message,omitempty"`
+	ErrorCode      string                 `json:"error_code,omitempty"` // Required for error
+	RetryBackoffMs int64                  `json:"retry_backoff_ms,omitempty"`
+	// Deadline of published job, rejects stale responses
+	Deadline int64 `json:"deadline,omitempty"`
+}
+
+// runJobPublisher activates jobs of one type and publishes them until bridge stops
+// Активирует job'ы одного типа и публикует их до остановки моста
+func (b *Bridge) runJobPublisher(jobType, topic string) {
+	defer b.wg.Done()
+
+	interval := time.Duration(b.cfg.PollIntervalMs) * time.Millisecond
+	for {
+		published, err := b.publishJobs(jobType, topic)
+		if err != nil {
+			logger.Error("Kafka bridge failed to publish jobs",
+				logger.String("job_type", jobType),
+				logger.String("topic", topic),
+				logger.String("error", err.Error()))
+		}
+
+		// Full batch means more jobs may be waiting, poll again without pause
+		// Полный пакет означает, что могут ожидать еще job'ы, опрашиваем снова без паузы
+		wait := interval
+		if err == nil && published >= b.cfg.MaxJobs {
+			wait = 0
+		}
+
+		if !b.sleep(wait) {
+			return
+		}
+	}
+}
+
+// publishJobs activates batch of jobs and writes them to topic
+// Jobs whose write fails stay activated and are published again after lease expiry
+// Активирует пакет job'ов и записывает их в топик
+// Job'ы, запись которых не удалась, остаются активированными и публикуются снова после истечения аренды
+func (b *Bridge) publishJobs(jobType, topic string) (int, error) {
+	activated, err := b.jobs.ActivateJobsWithTimeout(
+		b.cfg.WorkerName, jobType, b.cfg.MaxJobs, int32(b.cfg.JobTimeoutMs))
+	if err != nil {
+		return 0, fmt.Errorf("failed to activate jobs: %w", err)
+	}
+	if len(activated) == 0 {
+		return 0, nil
+	}
+
+	messages := make([]kafka.Message, 0, len(activated))
+	for _, job := range activated {
+		payload, err := json.Marshal(job)
+		if err != nil {
+			logger.Error("Kafka bridge failed to marshal job",
+				logger.String("job_key", job.Key),
+				logger.String("error", err.Error()))
+			continue
+		}
+		messages = append(messages, kafka.Message{
+			Topic: topic,
+			Key:   []byte(job.Key),
+			Value: payload,
+			Headers: []kafka.Header{
+				{Key: "job-type", Value: []byte(job.Type)},
+			},
+		})
+	}
+
+	if err := b.jobWriter.WriteMessages(b.ctx, messages...); err != nil {
+		return 0, fmt.Errorf("failed to write %d jobs: %w", len(messages), err)
+	}
+
+	logger.Debug("Kafka bridge published jobs",
+		logger.String("job_type", jobType),
+		logger.String("topic", topic),
+		logger.Int("count", len(messages)))
+	return len(activated), nil
+}
+
+// runResponseConsumer applies job responses and commits their offsets until bridge stops
+// Применяет ответы по job'ам и фиксирует их смещения до остановки моста
+func (b *Bridge) runResponseConsumer() {
+	defer b.wg.Done()
+
+	for {
+		message, err := b.reader.FetchMessage(b.ctx)
+		if err != nil {
+			if b.ctx.Err() != nil {
+				return
+			}
+			logger.Error("Kafka bridge failed to fetch job response", logger.String("error", err.Error()))
+			if !b.sleep(consumerRetryDelay) {
+				return
+			}
+			continue
+		}
+
+		b.handleResponseMessage(message)
+
+		// Offset is committed after response is applied, redelivered responses are filtered by job state
+		// Смещение фиксируется после применения ответа, повторно доставленные ответы отсеиваются по состоянию job'а
+		for {
+			err := b.reader.CommitMessages(b.ctx, message)
+			if err == nil || b.ctx.Err() != nil {
+				break
+			}
+			logger.Error("Kafka bridge failed to commit job response", logger.String("error", err.Error()))
+			if !b.sleep(consumerRetryDelay) {
+				return
+			}
+		}
+	}
+}
+
+// handleResponseMessage decodes response and applies it, invalid responses are logged and skipped
+// Декодирует ответ и применяет его, некорректные ответы логируются и пропускаются
+func (b *Bridge) handleResponseMessage(message kafka.Message) {
+	var response JobResponse
+	if err := json.Unmarshal(message.Value, &response); err != nil {
+		logger.Warn("Kafka bridge skipped malformed job response",
+			logger.Int("partition", message.Partition),
+			logger.Any("offset", message.Offset),
+			logger.String("error", err.Error()))
+		return
+	}
+	if response.JobKey == "" {
+		response.JobKey = string(message.Key)
+	}
+
+	applied, err := b.applyResponse(&response)
+	if err != nil {
+		logger.Error("Kafka bridge failed to apply job response",
+			logger.String("job_key", response.JobKey),
+			logger.String("action", response.Action),
+			logger.String("error", err.Error()))
+		return
+	}
+	if applied {
+		logger.Info("Kafka bridge applied job response",
+			logger.String("job_key", response.JobKey),
+			logger.String("action", response.Action))
+	}
+}
+
+// applyResponse applies response to job still activated by bridge
+// Returns false without error when response is duplicate or stale
+// Применяет ответ к job'у, все еще активированному мостом
+// Возвращает false без ошибки, если ответ повторный или устаревший
+func (b *Bridge) applyResponse(response *JobResponse) (bool, error) {
+	if response.JobKey == "" {
+		return false, errors.New("job key is missing")
+	}
+
+	job, err := b.jobs.GetJob(response.JobKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to load job: %w", err)
+	}
+	if skip := b.staleResponseReason(job, response); skip != "" {
+		logger.Info("Kafka bridge skipped job response",
+			logger.String("job_key", response.JobKey),
+			logger.String("action", response.Action),
+			logger.String("reason", skip))
+		return false, nil
+	}
+
+	switch strings.ToLower(response.Action) {
+	case ActionComplete:
+		return true, b.jobs.CompleteJob(job.Key, response.Variables)
+	case ActionFail:
+		retries := job.Retries - 1
+		if response.Retries != nil {
+			retries = *response.Retries
+		}
+		if retries < 0 {
+			retries = 0
+		}
+		backoff := time.Duration(response.RetryBackoffMs) * time.Millisecond
+		return true, b.jobs.FailJobWithBackoff(job.Key, retries, response.ErrorMessage, backoff)
+	case ActionError:
+		if response.ErrorCode == "" {
+			return false, errors.New("error_code is required for error action")
+		}
+		return true, b.jobs.ThrowErrorWithVariables(
+			job.Key, response.ErrorCode, response.ErrorMessage, response.Variables)
+	default:
+		return false, fmt.Errorf("unknown action %q", response.Action)
+	}
+}
+
+// staleResponseReason checks that job is still activated by bridge with same lease
+// Returns reason to skip response or empty string
+// Проверяет, что job все еще активирован мостом с той же арендой
+// Возвращает причину пропуска ответа или пустую строку
+func (b *Bridge) staleResponseReason(job *jobs.JobInfo, response *JobResponse) string {
+	if job == nil {
+		return "job not found"
+	}
+	if job.Status != string(models.JobStatusRunning) {
+		return "job is " + strings.ToLower(job.Status)
+	}
+	if job.Worker != b.cfg.WorkerName {
+		return "job is activated by worker " + job.Worker
+	}
+	if response.Deadline != 0 && job.Deadline != response.Deadline {
+		return "job was reactivated after lease expiry"
+	}
+	return ""
+}
+
+// sleep waits for duration, returns false when bridge stops first
+// Ожидает указанное время, возвращает false если мост остановлен раньше
+func (b *Bridge) sleep(duration time.Duration) bool {
+	select {
+	case <-b.ctx.Done():
+		return false
+	case <-time.After(duration):
+		return true
+	}
+}
@@ -0,0 +1,129 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package kafkabridge
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+
+	"atom-engine/src/core/config"
+)
+
+// dialTimeout limits establishing single broker connection
+// Ограничивает установку одного соединения с брокером
+const dialTimeout = 10 * time.Second
+
+// connectionSettings holds TLS and SASL settings shared by producers and consumer
+// Содержит настройки TLS и SASL, общие для producer'ов и consumer'а
+type connectionSettings struct {
+	clientID string
+	tls      *tls.Config
+	sasl     sasl.Mechanism
+}
+
+// newConnectionSettings builds TLS config and SASL mechanism from bridge configuration
+// Создает TLS конфигурацию и SASL механизм из конфигурации моста
+func newConnectionSettings(cfg config.KafkaBridgeConfig) (*connectionSettings, error) {
+	settings := &connectionSettings{clientID: cfg.ClientID}
+
+	if cfg.TLS.Enabled {
+		tlsConfig, err := newTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		settings.tls = tlsConfig
+	}
+
+	mechanism, err := newSASLMechanism(cfg.SASL)
+	if err != nil {
+		return nil, err
+	}
+	settings.sasl = mechanism
+
+	return settings, nil
+}
+
+// newTLSConfig loads CA and client certificate files
+// Загружает файлы CA и клиентского сертификата
+func newTLSConfig(cfg config.KafkaTLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, // Explicit opt-in for test clusters
+	}
+
+	if cfg.CAFile != "" {
+		caPEM, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read kafka CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("kafka CA file %s contains no certificates", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load kafka client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+// newSASLMechanism creates SASL mechanism, nil when authentication is disabled
+// Создает SASL механизм, nil если аутентификация выключена
+func newSASLMechanism(cfg config.KafkaSASLConfig) (sasl.Mechanism, error) {
+	switch cfg.Mechanism {
+	case "":
+		return nil, nil
+	case "plain":
+		return plain.Mechanism{Username: cfg.Username, Password: cfg.Password}, nil
+	case "scram-sha-256":
+		return scram.Mechanism(scram.SHA256, cfg.Username, cfg.Password)
+	case "scram-sha-512":
+		return scram.Mechanism(scram.SHA512, cfg.Username, cfg.Password)
+	default:
+		return nil, fmt.Errorf("unsupported kafka SASL mechanism: %s", cfg.Mechanism)
+	}
+}
+
+// transport creates producer transport
+// Создает транспорт producer'а
+func (s *connectionSettings) transport() *kafka.Transport {
+	return &kafka.Transport{
+		ClientID:    s.clientID,
+		TLS:         s.tls,
+		SASL:        s.sasl,
+		DialTimeout: dialTimeout,
+	}
+}
+
+// dialer creates consumer dialer
+// Создает dialer consumer'а
+func (s *connectionSettings) dialer() *kafka.Dialer {
+	return &kafka.Dialer{
+		ClientID:      s.clientID,
+		TLS:           s.tls,
+		SASLMechanism: s.sasl,
+		Timeout:       dialTimeout,
+		DualStack:     true,
+	}
+}
@@ -0,0 +1,75 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package logger
+
+// ContextLogger writes entries through global logger with fixed context fields prepended
+// Пишет записи через глобальный логгер, добавляя в начало фиксированные поля контекста
+type ContextLogger struct {
+	fields   []Field
+	elevated bool
+}
+
+// With creates context logger carrying fields in every entry
+// Создает логгер контекста, добавляющий поля в каждую запись
+func With(fields ...Field) *ContextLogger {
+	return &ContextLogger{fields: fields}
+}
+
+// With creates child context logger with additional fields
+// Создает дочерний логгер контекста с дополнительными полями
+func (cl *ContextLogger) With(fields ...Field) *ContextLogger {
+	combined := make([]Field, 0, len(cl.fields)+len(fields))
+	combined = append(combined, cl.fields...)
+	combined = append(combined, fields...)
+	return &ContextLogger{fields: combined, elevated: cl.elevated}
+}
+
+// Elevated returns copy of logger writing debug entries at info level when elevated is set,
+// so troubleshooting of single context does not require debug level for whole daemon
+// Возвращает копию логгера, пишущую debug записи на уровне info при установленном elevated,
+// поэтому диагностика одного контекста не требует debug уровня для всего демона
+func (cl *ContextLogger) Elevated(elevated bool) *ContextLogger {
+	return &ContextLogger{fields: cl.fields, elevated: elevated}
+}
+
+// Debug logs debug message with context fields
+// Логирует debug сообщение с полями контекста
+func (cl *ContextLogger) Debug(msg string, fields ...Field) {
+	if cl.elevated {
+		Info(msg, cl.merge(fields)...)
+		return
+	}
+	Debug(msg, cl.merge(fields)...)
+}
+
+// Info logs info message with context fields
+// Логирует info сообщение с полями контекста
+func (cl *ContextLogger) Info(msg string, fields ...Field) {
+	Info(msg, cl.merge(fields)...)
+}
+
+// Warn logs warning message with context fields
+// Логирует предупреждающее сообщение с полями контекста
+func (cl *ContextLogger) Warn(msg string, fields ...Field) {
+	Warn(msg, cl.merge(fields)...)
+}
+
+// Error logs error message with context fields
+// Логирует сообщение об ошибке с полями контекста
+func (cl *ContextLogger) Error(msg string, fields ...Field) {
+	Error(msg, cl.merge(fields)...)
+}
+
+// merge prepends context fields to entry fields
+// Добавляет поля контекста перед полями записи
+func (cl *ContextLogger) merge(fields []Field) []Field {
+	all := make([]Field, 0, len(cl.fields)+len(fields))
+	all = append(all, cl.fields...)
+	return append(all, fields...)
+}
@@ -75,6 +75,7 @@ type Logger struct {
 	formatter Formatter
 	writer    io.Writer
 	rotator   *Rotator
+	redactor  *Redactor
 	config    *config.LoggerConfig
 	mu        sync.Mutex
 }
@@ -99,11 +100,18 @@ func New(cfg *config.LoggerConfig) (*Logger, error) {
 
 	formatter := NewFormatter(cfg.Format)
 
+	redactor, err := NewRedactor(cfg.RedactKeyPatterns, cfg.OmitVariableValues)
+	if err != nil {
+		rotator.Close()
+		return nil, err
+	}
+
 	logger := &Logger{
 		level:     ParseLogLevel(cfg.Level),
 		formatter: formatter,
 		writer:    writer,
 		rotator:   rotator,
+		redactor:  redactor,
 		config:    cfg,
 	}
 
@@ -152,7 +160,7 @@ func (l *Logger) log(level LogLevel, msg string, fields ...Field) {
 		Timestamp: time.Now(),
 		Level:     level,
 		Message:   msg,
-		Fields:    fields,
+		Fields:    l.redactor.Redact(fields),
 	}
 
 	formatted := l.formatter.Format(entry)
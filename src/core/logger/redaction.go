@@ -0,0 +1,173 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package logger
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// RedactedValue replaces variable values removed from logs
+// Заменяет значения переменных, удаленные из логов
+const RedactedValue = "[REDACTED]"
+
+// variableSet marks field value as process variables
+// Помечает значение поля как переменные процесса
+type variableSet map[string]interface{}
+
+// variableValue marks field value as value of named process variable
+// Помечает значение поля как значение именованной переменной процесса
+type variableValue struct {
+	name  string
+	value interface{}
+}
+
+// Variables creates field with process variables, values are redacted by logger configuration
+// Создает поле с переменными процесса, значения редактируются согласно конфигурации логгера
+func Variables(key string, variables map[string]interface{}) Field {
+	return Field{Key: key, Value: variableSet(variables)}
+}
+
+// VariableValue creates field with value of named process variable, redacted by logger configuration
+// Создает поле со значением именованной переменной процесса, редактируется согласно конфигурации логгера
+func VariableValue(key, name string, value interface{}) Field {
+	return Field{Key: key, Value: variableValue{name: name, value: value}}
+}
+
+// Redactor removes sensitive variable values from log fields
+// Удаляет чувствительные значения переменных из полей лога
+type Redactor struct {
+	patterns   []*regexp.Regexp
+	omitValues bool
+}
+
+// NewRedactor creates redactor matching variable names by case-insensitive patterns
+// When omitValues is set variables are logged by names only
+// Создает редактор, сопоставляющий имена переменных по регистронезависимым шаблонам
+// При omitValues переменные логируются только именами
+func NewRedactor(keyPatterns []string, omitValues bool) (*Redactor, error) {
+	r := &Redactor{omitValues: omitValues}
+	for _, pattern := range keyPatterns {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction key pattern %q: %w", pattern, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// Redact returns fields with variable values redacted, other fields are kept as is
+// Nil redactor only unwraps variable fields
+// Возвращает поля с отредактированными значениями переменных, остальные поля не изменяются
+// Nil редактор только разворачивает поля переменных
+func (r *Redactor) Redact(fields []Field) []Field {
+	var result []Field
+	for i, field := range fields {
+		var value interface{}
+		switch v := field.Value.(type) {
+		case variableSet:
+			value = r.redactVariables(v)
+		case variableValue:
+			value = r.redactVariable(v.name, v.value)
+		default:
+			if result != nil {
+				result = append(result, field)
+			}
+			continue
+		}
+
+		// Fields slice belongs to caller, copied on first change
+		// Срез полей принадлежит вызывающему, копируется при первом изменении
+		if result == nil {
+			result = make([]Field, i, len(fields))
+			copy(result, fields[:i])
+		}
+		result = append(result, Field{Key: field.Key, Value: value})
+	}
+
+	if result == nil {
+		return fields
+	}
+	return result
+}
+
+// redactVariables returns sorted names when values are omitted, otherwise redacted copy
+// Возвращает отсортированные имена, если значения опускаются, иначе отредактированную копию
+func (r *Redactor) redactVariables(variables variableSet) interface{} {
+	if r != nil && r.omitValues {
+		names := make([]string, 0, len(variables))
+		for name := range variables {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+	return r.redactMap(variables)
+}
+
+// redactVariable returns placeholder for omitted or matching variable, otherwise redacted value
+// Возвращает заглушку для опускаемой или совпавшей переменной, иначе отредактированное значение
+func (r *Redactor) redactVariable(name string, value interface{}) interface{} {
+	if r != nil && (r.omitValues || r.matches(name)) {
+		return RedactedValue
+	}
+	return r.redactValue(value)
+}
+
+// redactMap copies map replacing values of matching keys, nested containers are walked
+// Копирует карту, заменяя значения совпавших ключей, вложенные контейнеры обходятся
+func (r *Redactor) redactMap(values map[string]interface{}) map[string]interface{} {
+	if r == nil || len(r.patterns) == 0 || values == nil {
+		return values
+	}
+
+	result := make(map[string]interface{}, len(values))
+	for key, value := range values {
+		if r.matches(key) {
+			result[key] = RedactedValue
+			continue
+		}
+		result[key] = r.redactValue(value)
+	}
+	return result
+}
+
+// redactValue walks nested maps and arrays
+// Обходит вложенные карты и массивы
+func (r *Redactor) redactValue(value interface{}) interface{} {
+	if r == nil || len(r.patterns) == 0 {
+		return value
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return r.redactMap(v)
+	case []interface{}:
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = r.redactValue(item)
+		}
+		return items
+	default:
+		return value
+	}
+}
+
+// matches checks variable name against redaction patterns
+// Проверяет имя переменной по шаблонам редактирования
+func (r *Redactor) matches(name string) bool {
+	for _, re := range r.patterns {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
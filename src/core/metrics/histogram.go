@@ -0,0 +1,82 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package metrics
+
+import (
+	"math"
+	"sort"
+	"sync/atomic"
+)
+
+// DefaultLatencyBuckets are bucket upper bounds in seconds for internal request latency
+// Верхние границы бакетов в секундах для задержки внутренних запросов
+var DefaultLatencyBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Histogram counts observations into fixed buckets using atomic counters only
+// Observe never blocks, snapshot taken during concurrent observations may be off by in-flight values
+// Считает наблюдения по фиксированным бакетам только атомарными счетчиками
+// Observe не блокируется, снимок при конкурентных наблюдениях может не учитывать значения в процессе записи
+type Histogram struct {
+	upperBounds []float64
+	counts      []atomic.Uint64 // per bucket, last one is +Inf
+	count       atomic.Uint64
+	sumBits     atomic.Uint64 // float64 bits of sum
+}
+
+// HistogramSnapshot is point-in-time histogram state with cumulative bucket counts
+// Снимок состояния гистограммы с накопительными счетчиками бакетов
+type HistogramSnapshot struct {
+	UpperBounds []float64
+	Counts      []uint64 // cumulative count per upper bound, +Inf bucket equals Count
+	Count       uint64
+	Sum         float64
+}
+
+// NewHistogram creates histogram with sorted bucket upper bounds
+// Создает гистограмму с отсортированными верхними границами бакетов
+func NewHistogram(upperBounds []float64) *Histogram {
+	bounds := append([]float64(nil), upperBounds...)
+	sort.Float64s(bounds)
+	return &Histogram{
+		upperBounds: bounds,
+		counts:      make([]atomic.Uint64, len(bounds)+1),
+	}
+}
+
+// Observe records single value
+// Записывает одно значение
+func (h *Histogram) Observe(value float64) {
+	h.counts[sort.SearchFloat64s(h.upperBounds, value)].Add(1)
+	h.count.Add(1)
+	for {
+		old := h.sumBits.Load()
+		sum := math.Float64bits(math.Float64frombits(old) + value)
+		if h.sumBits.CompareAndSwap(old, sum) {
+			return
+		}
+	}
+}
+
+// Snapshot returns current state of histogram
+// Возвращает текущее состояние гистограммы
+func (h *Histogram) Snapshot() HistogramSnapshot {
+	snapshot := HistogramSnapshot{
+		UpperBounds: h.upperBounds,
+		Counts:      make([]uint64, len(h.upperBounds)),
+		Sum:         math.Float64frombits(h.sumBits.Load()),
+	}
+
+	var cumulative uint64
+	for i := range h.upperBounds {
+		cumulative += h.counts[i].Load()
+		snapshot.Counts[i] = cumulative
+	}
+	snapshot.Count = cumulative + h.counts[len(h.upperBounds)].Load()
+	return snapshot
+}
@@ -0,0 +1,321 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// DefaultProcessSeriesLimit bounds label sets of per-process metrics
+// Ограничивает число наборов меток метрик по процессам
+const DefaultProcessSeriesLimit = 500
+
+// OverflowProcessKey replaces process_key of series beyond limit
+// Заменяет process_key серий сверх лимита
+const OverflowProcessKey = "__overflow__"
+
+// ProcessDurationBuckets are bucket upper bounds in seconds for process instance duration
+// Верхние границы бакетов в секундах для длительности экземпляра процесса
+var ProcessDurationBuckets = []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 900, 1800, 3600, 14400, 43200, 86400, 604800}
+
+// JobActivationBuckets are bucket upper bounds in seconds for time job waits for activation
+// Верхние границы бакетов в секундах для времени ожидания активации job'а
+var JobActivationBuckets = []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300, 900, 3600}
+
+// Processes collects per-process-definition metrics of engine
+// Метрики по определениям процессов движка
+var Processes = NewProcessMetrics(DefaultProcessSeriesLimit)
+
+// ProcessSeries identifies series of process definition, empty tenant is default tenant
+// Идентифицирует серию определения процесса, пустой tenant - tenant по умолчанию
+type ProcessSeries struct {
+	ProcessKey string
+	Tenant     string
+}
+
+// JobSeries identifies series of job type within process definition
+// Идентифицирует серию типа job'а в определении процесса
+type JobSeries struct {
+	ProcessSeries
+	JobType string
+}
+
+// ProcessStats is snapshot of process definition metrics
+// Снимок метрик определения процесса
+type ProcessStats struct {
+	ProcessSeries
+	Started     uint64
+	Completed   uint64
+	Canceled    uint64
+	Incidents   uint64
+	SLABreaches uint64
+	Active      int64
+	Duration    HistogramSnapshot
+}
+
+// JobStats is snapshot of job type metrics
+// Снимок метрик типа job'а
+type JobStats struct {
+	JobSeries
+	Activations       uint64
+	Failures          uint64
+	ActivationLatency HistogramSnapshot
+}
+
+// processCounters holds metrics of one process series
+// Хранит метрики одной серии процесса
+type processCounters struct {
+	started     uint64
+	completed   uint64
+	canceled    uint64
+	incidents   uint64
+	slaBreaches uint64
+	active      int64
+	duration    *Histogram
+}
+
+// jobCounters holds metrics of one job series
+// Хранит метрики одной серии job'а
+type jobCounters struct {
+	activations uint64
+	failures    uint64
+	latency     *Histogram
+}
+
+// ProcessMetrics counts instance lifecycle, incidents and job activity per process definition
+// Series beyond limit are folded into process_key "__overflow__" so label cardinality stays bounded
+// Считает жизненный цикл экземпляров, инциденты и работу job'ов по определениям процессов
+// Серии сверх лимита объединяются в process_key "__overflow__", чтобы кардинальность меток была ограничена
+type ProcessMetrics struct {
+	mu        sync.Mutex
+	limit     int
+	processes map[ProcessSeries]*processCounters
+	jobs      map[JobSeries]*jobCounters
+}
+
+// NewProcessMetrics creates per-process metrics with series limit, non-positive limit uses default
+// Создает метрики по процессам с лимитом серий, неположительный лимит заменяется значением по умолчанию
+func NewProcessMetrics(limit int) *ProcessMetrics {
+	if limit <= 0 {
+		limit = DefaultProcessSeriesLimit
+	}
+	return &ProcessMetrics{
+		limit:     limit,
+		processes: make(map[ProcessSeries]*processCounters),
+		jobs:      make(map[JobSeries]*jobCounters),
+	}
+}
+
+// SetSeriesLimit changes series limit, existing series are kept
+// Изменяет лимит серий, существующие серии сохраняются
+func (m *ProcessMetrics) SetSeriesLimit(limit int) {
+	if limit <= 0 {
+		limit = DefaultProcessSeriesLimit
+	}
+	m.mu.Lock()
+	m.limit = limit
+	m.mu.Unlock()
+}
+
+// InstanceStarted counts started instance
+// Учитывает запущенный экземпляр
+func (m *ProcessMetrics) InstanceStarted(processKey, tenant string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counters := m.process(ProcessSeries{ProcessKey: processKey, Tenant: tenant})
+	counters.started++
+	counters.active++
+}
+
+// InstanceCompleted counts completed instance and its duration
+// Учитывает завершенный экземпляр и его длительность
+func (m *ProcessMetrics) InstanceCompleted(processKey, tenant string, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counters := m.process(ProcessSeries{ProcessKey: processKey, Tenant: tenant})
+	counters.completed++
+	counters.finish()
+	counters.duration.Observe(duration.Seconds())
+}
+
+// InstanceCanceled counts canceled instance
+// Учитывает отмененный экземпляр
+func (m *ProcessMetrics) InstanceCanceled(processKey, tenant string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counters := m.process(ProcessSeries{ProcessKey: processKey, Tenant: tenant})
+	counters.canceled++
+	counters.finish()
+}
+
+// SetActiveInstances sets active instance gauge, used to restore it from storage on startup
+// Устанавливает gauge активных экземпляров, используется для восстановления из storage при запуске
+func (m *ProcessMetrics) SetActiveInstances(processKey, tenant string, active int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.process(ProcessSeries{ProcessKey: processKey, Tenant: tenant}).active = active
+}
+
+// IncidentOpened counts incident of process definition
+// Учитывает инцидент определения процесса
+func (m *ProcessMetrics) IncidentOpened(processKey, tenant string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.process(ProcessSeries{ProcessKey: processKey, Tenant: tenant}).incidents++
+}
+
+// InstanceSLABreached counts instance still running at its SLA deadline
+// Учитывает экземпляр, все еще выполняющийся в срок SLA
+func (m *ProcessMetrics) InstanceSLABreached(processKey, tenant string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.process(ProcessSeries{ProcessKey: processKey, Tenant: tenant}).slaBreaches++
+}
+
+// JobActivated counts job activation and time job waited since it became activatable
+// Учитывает активацию job'а и время ожидания с момента, когда он стал доступен
+func (m *ProcessMetrics) JobActivated(processKey, tenant, jobType string, waited time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counters := m.job(processKey, tenant, jobType)
+	counters.activations++
+	counters.latency.Observe(waited.Seconds())
+}
+
+// JobFailed counts job failure reported by worker
+// Учитывает провал job'а, переданный worker'ом
+func (m *ProcessMetrics) JobFailed(processKey, tenant, jobType string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.job(processKey, tenant, jobType).failures++
+}
+
+// Snapshot returns process and job series ordered by labels
+// Возвращает серии процессов и job'ов, упорядоченные по меткам
+func (m *ProcessMetrics) Snapshot() ([]ProcessStats, []JobStats) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	processes := make([]ProcessStats, 0, len(m.processes))
+	for series, counters := range m.processes {
+		processes = append(processes, ProcessStats{
+			ProcessSeries: series,
+			Started:       counters.started,
+			Completed:     counters.completed,
+			Canceled:      counters.canceled,
+			Incidents:     counters.incidents,
+			SLABreaches:   counters.slaBreaches,
+			Active:        counters.active,
+			Duration:      counters.duration.Snapshot(),
+		})
+	}
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].ProcessSeries.less(processes[j].ProcessSeries)
+	})
+
+	jobs := make([]JobStats, 0, len(m.jobs))
+	for series, counters := range m.jobs {
+		jobs = append(jobs, JobStats{
+			JobSeries:         series,
+			Activations:       counters.activations,
+			Failures:          counters.failures,
+			ActivationLatency: counters.latency.Snapshot(),
+		})
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		if jobs[i].ProcessSeries != jobs[j].ProcessSeries {
+			return jobs[i].ProcessSeries.less(jobs[j].ProcessSeries)
+		}
+		return jobs[i].JobType < jobs[j].JobType
+	})
+
+	return processes, jobs
+}
+
+// process returns counters of series, folding new series beyond limit into overflow series
+// Возвращает счетчики серии, новые серии сверх лимита объединяются в серию переполнения
+func (m *ProcessMetrics) process(series ProcessSeries) *processCounters {
+	if counters, ok := m.processes[series]; ok {
+		return counters
+	}
+	if len(m.processes) >= m.limit {
+		series = ProcessSeries{ProcessKey: OverflowProcessKey}
+		if counters, ok := m.processes[series]; ok {
+			return counters
+		}
+	}
+
+	counters := &processCounters{duration: NewHistogram(ProcessDurationBuckets)}
+	m.processes[series] = counters
+	return counters
+}
+
+// job returns counters of job series, folding new series beyond limit into overflow series
+// Возвращает счетчики серии job'а, новые серии сверх лимита объединяются в серию переполнения
+func (m *ProcessMetrics) job(processKey, tenant, jobType string) *jobCounters {
+	series := JobSeries{ProcessSeries: ProcessSeries{ProcessKey: processKey, Tenant: tenant}, JobType: jobType}
+	if counters, ok := m.jobs[series]; ok {
+		return counters
+	}
+	if len(m.jobs) >= m.limit {
+		series = JobSeries{ProcessSeries: ProcessSeries{ProcessKey: OverflowProcessKey}, JobType: OverflowProcessKey}
+		if counters, ok := m.jobs[series]; ok {
+			return counters
+		}
+	}
+
+	counters := &jobCounters{latency: NewHistogram(JobActivationBuckets)}
+	m.jobs[series] = counters
+	return counters
+}
+
+// finish decrements active gauge, instances started before restart may not be counted as active
+// Уменьшает gauge активных экземпляров, экземпляры, запущенные до перезапуска, могут быть не учтены
+func (c *processCounters) finish() {
+	if c.active > 0 {
+		c.active--
+	}
+}
+
+// Labels returns Prometheus labels of series, tenant label is added only for non-default tenant
+// Возвращает метки Prometheus серии, метка tenant добавляется только для tenant'а не по умолчанию
+func (s ProcessSeries) Labels() Labels {
+	labels := Labels{"process_key": s.ProcessKey}
+	if s.Tenant != "" {
+		labels["tenant"] = s.Tenant
+	}
+	return labels
+}
+
+// Labels returns Prometheus labels of job series
+// Возвращает метки Prometheus серии job'а
+func (s JobSeries) Labels() Labels {
+	labels := s.ProcessSeries.Labels()
+	labels["job_type"] = s.JobType
+	return labels
+}
+
+// less orders series by process key and tenant
+// Упорядочивает серии по ключу процесса и tenant'у
+func (s ProcessSeries) less(other ProcessSeries) bool {
+	if s.ProcessKey != other.ProcessKey {
+		return s.ProcessKey < other.ProcessKey
+	}
+	return s.Tenant < other.Tenant
+}
@@ -0,0 +1,157 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ContentType is Prometheus text exposition format content type
+// Content type текстового формата Prometheus
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Metric types
+// Типы метрик
+const (
+	TypeGauge     = "gauge"
+	TypeCounter   = "counter"
+	TypeHistogram = "histogram"
+)
+
+// Labels are metric labels
+// Метки метрики
+type Labels map[string]string
+
+// Writer renders metrics in Prometheus text exposition format
+// Формирует метрики в текстовом формате Prometheus
+type Writer struct {
+	buf      bytes.Buffer
+	declared map[string]bool
+}
+
+// NewWriter creates metrics writer
+// Создает writer метрик
+func NewWriter() *Writer {
+	return &Writer{declared: make(map[string]bool)}
+}
+
+// Gauge writes gauge sample
+// Записывает значение gauge
+func (w *Writer) Gauge(name, help string, value float64, labels Labels) {
+	w.sample(name, help, TypeGauge, value, labels)
+}
+
+// Counter writes counter sample
+// Записывает значение counter
+func (w *Writer) Counter(name, help string, value float64, labels Labels) {
+	w.sample(name, help, TypeCounter, value, labels)
+}
+
+// Histogram writes histogram buckets, sum and count samples
+// Записывает бакеты, сумму и количество гистограммы
+func (w *Writer) Histogram(name, help string, snapshot HistogramSnapshot, labels Labels) {
+	w.declare(name, help, TypeHistogram)
+
+	bucketLabels := make(Labels, len(labels)+1)
+	for key, value := range labels {
+		bucketLabels[key] = value
+	}
+	for i, upperBound := range snapshot.UpperBounds {
+		bucketLabels["le"] = formatValue(upperBound)
+		w.line(name+"_bucket", float64(snapshot.Counts[i]), bucketLabels)
+	}
+	bucketLabels["le"] = "+Inf"
+	w.line(name+"_bucket", float64(snapshot.Count), bucketLabels)
+
+	w.line(name+"_sum", snapshot.Sum, labels)
+	w.line(name+"_count", float64(snapshot.Count), labels)
+}
+
+// Bytes returns rendered metrics
+// Возвращает сформированные метрики
+func (w *Writer) Bytes() []byte {
+	return w.buf.Bytes()
+}
+
+// sample writes HELP and TYPE once per metric name followed by sample line
+// Записывает HELP и TYPE один раз на имя метрики и строку значения
+func (w *Writer) sample(name, help, metricType string, value float64, labels Labels) {
+	w.declare(name, help, metricType)
+	w.line(name, value, labels)
+}
+
+// declare writes HELP and TYPE lines once per metric name
+// Записывает строки HELP и TYPE один раз на имя метрики
+func (w *Writer) declare(name, help, metricType string) {
+	if !w.declared[name] {
+		w.declared[name] = true
+		fmt.Fprintf(&w.buf, "# HELP %s %s\n", name, escapeHelp(help))
+		fmt.Fprintf(&w.buf, "# TYPE %s %s\n", name, metricType)
+	}
+}
+
+// line writes single sample line
+// Записывает одну строку значения
+func (w *Writer) line(name string, value float64, labels Labels) {
+	w.buf.WriteString(name)
+	if len(labels) > 0 {
+		keys := make([]string, 0, len(labels))
+		for key := range labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		w.buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				w.buf.WriteByte(',')
+			}
+			fmt.Fprintf(&w.buf, "%s=\"%s\"", key, escapeLabelValue(labels[key]))
+		}
+		w.buf.WriteByte('}')
+	}
+	w.buf.WriteByte(' ')
+	w.buf.WriteString(formatValue(value))
+	w.buf.WriteByte('\n')
+}
+
+// formatValue formats sample value including special float values
+// Форматирует значение, включая специальные значения float
+func formatValue(value float64) string {
+	switch {
+	case math.IsNaN(value):
+		return "NaN"
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
+
+var helpReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+var labelReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+// escapeHelp escapes HELP text
+// Экранирует текст HELP
+func escapeHelp(help string) string {
+	return helpReplacer.Replace(help)
+}
+
+// escapeLabelValue escapes label value
+// Экранирует значение метки
+func escapeLabelValue(value string) string {
+	return labelReplacer.Replace(value)
+}
@@ -0,0 +1,39 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import "time"
+
+// ArchivedInstance points to cold storage location of process instance removed from primary storage
+// Указывает на место в холодном хранилище экземпляра процесса, удаленного из основного storage
+type ArchivedInstance struct {
+	InstanceID     string               `json:"instance_id"`
+	ProcessID      string               `json:"process_id"`
+	ProcessKey     string               `json:"process_key"`
+	ProcessVersion int                  `json:"process_version"`
+	State          ProcessInstanceState `json:"state"`
+	CompletedAt    *time.Time           `json:"completed_at,omitempty"`
+	Location       string               `json:"location"`
+	ArchivedAt     time.Time            `json:"archived_at"`
+}
+
+// NewArchivedInstance creates archive pointer for process instance
+// Создает указатель на архив экземпляра процесса
+func NewArchivedInstance(instance *ProcessInstance, location string) *ArchivedInstance {
+	return &ArchivedInstance{
+		InstanceID:     instance.InstanceID,
+		ProcessID:      instance.ProcessID,
+		ProcessKey:     instance.ProcessKey,
+		ProcessVersion: instance.ProcessVersion,
+		State:          instance.State,
+		CompletedAt:    instance.CompletedAt,
+		Location:       location,
+		ArchivedAt:     time.Now(),
+	}
+}
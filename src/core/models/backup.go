@@ -0,0 +1,36 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrBackupInProgress is returned when backup is requested while another one is running
+// Возвращается, когда резервная копия запрошена во время создания другой
+var ErrBackupInProgress = errors.New("storage backup already in progress")
+
+// BackupInfo describes storage backup kept in object storage
+// Описывает резервную копию storage в объектном хранилище
+type BackupInfo struct {
+	Name      string    `json:"name"`
+	Location  string    `json:"location"`
+	Size      int64     `json:"size"`
+	SHA256    string    `json:"sha256,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Version of newest backed up entry, known only for backup just created
+	// Версия самой новой записи в копии, известна только для только что созданной копии
+	Version uint64 `json:"version,omitempty"`
+
+	// DurationMs is backup or verification duration
+	// Длительность создания или проверки копии
+	DurationMs int64 `json:"duration_ms,omitempty"`
+}
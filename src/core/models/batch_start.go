@@ -0,0 +1,69 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import "time"
+
+// BusinessKeyMetadataKey is instance metadata field holding business key given at start
+// Поле метаданных экземпляра с бизнес-ключом, переданным при запуске
+const BusinessKeyMetadataKey = "business_key"
+
+// BatchStartState is state of process instance batch start
+// Состояние пакетного запуска экземпляров процессов
+type BatchStartState string
+
+const (
+	BatchStartStateRunning   BatchStartState = "RUNNING"
+	BatchStartStateCompleted BatchStartState = "COMPLETED"
+)
+
+// BatchItemStatus is outcome of single batch item
+// Результат отдельного элемента пакета
+type BatchItemStatus string
+
+const (
+	BatchItemStatusPending BatchItemStatus = "PENDING"
+	BatchItemStatusStarted BatchItemStatus = "STARTED"
+	BatchItemStatusFailed  BatchItemStatus = "FAILED"
+)
+
+// BatchStartItem describes one process instance to start in batch
+// Описывает один экземпляр процесса для пакетного запуска
+type BatchStartItem struct {
+	ProcessKey  string                 `json:"process_key"`
+	Variables   map[string]interface{} `json:"variables,omitempty"`
+	BusinessKey string                 `json:"business_key,omitempty"`
+}
+
+// BatchStartItemResult is result of starting one batch item
+// Результат запуска одного элемента пакета
+type BatchStartItemResult struct {
+	Index       int             `json:"index"`
+	ProcessKey  string          `json:"process_key"`
+	BusinessKey string          `json:"business_key,omitempty"`
+	Status      BatchItemStatus `json:"status"`
+	InstanceID  string          `json:"instance_id,omitempty"`
+	Err         error           `json:"-"`
+}
+
+// BatchStart tracks batch start of process instances
+// Started instances are kept when other items fail, batch is never rolled back
+// Отслеживает пакетный запуск экземпляров процессов
+// Запущенные экземпляры сохраняются при ошибках других элементов, пакет не откатывается
+type BatchStart struct {
+	ID          string                 `json:"batch_id"`
+	State       BatchStartState        `json:"state"`
+	Total       int                    `json:"total"`
+	Processed   int                    `json:"processed"`
+	Succeeded   int                    `json:"succeeded"`
+	Failed      int                    `json:"failed"`
+	CreatedAt   time.Time              `json:"created_at"`
+	CompletedAt *time.Time             `json:"completed_at,omitempty"`
+	Results     []BatchStartItemResult `json:"results"`
+}
@@ -38,9 +38,51 @@ type BPMNProcess struct {
 	ParsedAt      time.Time              `json:"parsed_at"`
 	OriginalFile  string                 `json:"original_file"`
 	Metadata      map[string]interface{} `json:"metadata"`
-	Status        string                 `json:"status"` // active, inactive, deployed
+	Tags          []string               `json:"tags,omitempty"` // Deploy-time tags like team:payments
+	Status        string                 `json:"status"`         // active, inactive, deployed
 	CreatedAt     time.Time              `json:"created_at"`
 	UpdatedAt     time.Time              `json:"updated_at"`
+
+	// Timer start events of definition are not scheduled when set
+	// Стартовые события таймера определения не планируются, если установлено
+	TimerStartDisabled bool `json:"timer_start_disabled,omitempty"`
+
+	// Who, when and how deployed definition, nil for definitions deployed before metadata was recorded
+	// Кто, когда и как развернул определение, nil для определений, развернутых до записи метаданных
+	Deployment *DeploymentInfo `json:"deployment,omitempty"`
+}
+
+// Deployment sources of process definitions
+// Источники развертывания определений процессов
+const (
+	DeploymentSourceCLI  = "cli"
+	DeploymentSourceREST = "rest"
+	DeploymentSourceGRPC = "grpc"
+)
+
+// DeploymentInfo describes deployment of process definition
+// Definitions deployed by one request or with same deployment ID share DeploymentID
+// Описывает развертывание определения процесса
+// Определения, развернутые одним запросом или с одним ID развертывания, имеют общий DeploymentID
+type DeploymentInfo struct {
+	DeploymentID string    `json:"deployment_id"`
+	DeployedBy   string    `json:"deployed_by,omitempty"` // API key name, empty when auth is disabled
+	DeployedAt   time.Time `json:"deployed_at"`
+	Source       string    `json:"source"` // cli, rest or grpc
+}
+
+// NewDeploymentInfo creates deployment metadata, empty deployment ID generates new one
+// Создает метаданные развертывания, пустой ID развертывания генерирует новый
+func NewDeploymentInfo(deploymentID, deployedBy, source string) *DeploymentInfo {
+	if deploymentID == "" {
+		deploymentID = GenerateID()
+	}
+	return &DeploymentInfo{
+		DeploymentID: deploymentID,
+		DeployedBy:   deployedBy,
+		DeployedAt:   time.Now(),
+		Source:       source,
+	}
 }
 
 // BPMNElement represents a generic BPMN element
@@ -0,0 +1,76 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CallbackIntentKind represents source component of callback intent
+// Представляет компонент-источник намерения callback
+type CallbackIntentKind string
+
+const (
+	CallbackIntentJob     CallbackIntentKind = "job"
+	CallbackIntentMessage CallbackIntentKind = "message"
+)
+
+// MaxCallbackIntentAttempts is number of failed apply attempts after which intent is dead-lettered
+// Число неудачных попыток применения, после которого намерение переносится в dead letter
+const MaxCallbackIntentAttempts = 5
+
+// CallbackIntent is write-ahead record of callback not yet applied to token
+// Persisted before callback is acknowledged and removed after token has moved on
+// Запись write-ahead лога о callback, еще не примененном к токену
+type CallbackIntent struct {
+	ID                string             `json:"id"`
+	Kind              CallbackIntentKind `json:"kind"`
+	SourceID          string             `json:"source_id"` // Job ID or message ID
+	TokenID           string             `json:"token_id"`
+	ProcessInstanceID string             `json:"process_instance_id,omitempty"`
+	WaitingFor        string             `json:"waiting_for"` // Token condition callback resolves
+	Payload           json.RawMessage    `json:"payload"`
+	Attempts          int                `json:"attempts"`
+	LastError         string             `json:"last_error,omitempty"`
+	DeadLetterReason  string             `json:"dead_letter_reason,omitempty"`
+	CreatedAt         time.Time          `json:"created_at"`
+	UpdatedAt         time.Time          `json:"updated_at"`
+}
+
+// NewCallbackIntent creates new callback intent with generated ID
+// Создает новое намерение callback со сгенерированным ID
+func NewCallbackIntent(kind CallbackIntentKind, sourceID, tokenID, waitingFor string) *CallbackIntent {
+	now := time.Now()
+	return &CallbackIntent{
+		ID:         GenerateID(),
+		Kind:       kind,
+		SourceID:   sourceID,
+		TokenID:    tokenID,
+		WaitingFor: waitingFor,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+}
+
+// RecordAttempt stores result of failed apply attempt
+// Сохраняет результат неудачной попытки применения
+func (ci *CallbackIntent) RecordAttempt(err error) {
+	ci.Attempts++
+	if err != nil {
+		ci.LastError = err.Error()
+	}
+	ci.UpdatedAt = time.Now()
+}
+
+// AttemptsExhausted checks whether intent failed too many times to be replayed again
+// Проверяет, превысило ли намерение число неудачных попыток для нового повтора
+func (ci *CallbackIntent) AttemptsExhausted() bool {
+	return ci.Attempts >= MaxCallbackIntentAttempts
+}
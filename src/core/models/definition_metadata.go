@@ -0,0 +1,132 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+const (
+	// MaxDefinitionMetadataSize is limit of metadata of process definition in bytes of JSON
+	// Лимит метаданных определения процесса в байтах JSON
+	MaxDefinitionMetadataSize = 4096
+	// MaxDefinitionTags is limit of tags of process definition
+	// Лимит тегов определения процесса
+	MaxDefinitionTags = 20
+	// MaxDefinitionTagLength is limit of single tag length
+	// Лимит длины одного тега
+	MaxDefinitionTagLength = 64
+)
+
+// DefinitionMetadata is user metadata and tags attached to process definition at deploy time
+// Metadata of previous version is copied forward, keys of request override copied ones
+// and keys with null value remove them. Tags of request replace copied tags when given
+// Пользовательские метаданные и теги, прикрепляемые к определению процесса при развертывании
+// Метаданные предыдущей версии переносятся, ключи запроса перекрывают перенесенные,
+// а ключи со значением null удаляют их. Теги запроса заменяют перенесенные, если заданы
+type DefinitionMetadata struct {
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
+	Tags     []string               `json:"tags,omitempty"`
+}
+
+// ValidateDefinitionMetadata checks size limits of definition metadata and tags
+// Проверяет лимиты размера метаданных и тегов определения
+func ValidateDefinitionMetadata(metadata map[string]interface{}, tags []string) error {
+	if len(metadata) > 0 {
+		data, err := json.Marshal(metadata)
+		if err != nil {
+			return fmt.Errorf("%w: metadata is not serializable: %v", ErrInvalidArgument, err)
+		}
+		if len(data) > MaxDefinitionMetadataSize {
+			return fmt.Errorf("%w: metadata is %d bytes, limit is %d",
+				ErrInvalidArgument, len(data), MaxDefinitionMetadataSize)
+		}
+	}
+
+	if len(tags) > MaxDefinitionTags {
+		return fmt.Errorf("%w: %d tags given, limit is %d", ErrInvalidArgument, len(tags), MaxDefinitionTags)
+	}
+	for _, tag := range tags {
+		if strings.TrimSpace(tag) == "" {
+			return fmt.Errorf("%w: tag must not be empty", ErrInvalidArgument)
+		}
+		if len(tag) > MaxDefinitionTagLength {
+			return fmt.Errorf("%w: tag %q is longer than %d characters",
+				ErrInvalidArgument, tag, MaxDefinitionTagLength)
+		}
+	}
+	return nil
+}
+
+// Validate checks metadata and tags of deploy request
+// Проверяет метаданные и теги запроса развертывания
+func (dm *DefinitionMetadata) Validate() error {
+	if dm == nil {
+		return nil
+	}
+	return ValidateDefinitionMetadata(dm.Metadata, dm.Tags)
+}
+
+// MergeDefinitionMetadata builds metadata and tags of new definition version from previous version and request
+// Previous version may be nil for first version, request may be nil when deploy sets nothing
+// Строит метаданные и теги новой версии определения из предыдущей версии и запроса
+// Предыдущая версия может быть nil для первой версии, запрос может быть nil, если развертывание ничего не задает
+func MergeDefinitionMetadata(
+	previous *BPMNProcess,
+	request *DefinitionMetadata,
+) (map[string]interface{}, []string) {
+	metadata := make(map[string]interface{})
+	var tags []string
+	if previous != nil {
+		maps.Copy(metadata, previous.Metadata)
+		tags = slices.Clone(previous.Tags)
+	}
+
+	if request != nil {
+		for key, value := range request.Metadata {
+			if value == nil {
+				delete(metadata, key)
+				continue
+			}
+			metadata[key] = value
+		}
+		if len(request.Tags) > 0 {
+			tags = uniqueTags(request.Tags)
+		}
+	}
+
+	return metadata, tags
+}
+
+// HasAllTags reports whether definition tags contain all required tags
+// Сообщает, содержат ли теги определения все требуемые теги
+func HasAllTags(tags, required []string) bool {
+	for _, tag := range required {
+		if !slices.Contains(tags, tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// uniqueTags returns tags without duplicates keeping first occurrence order
+// Возвращает теги без дубликатов, сохраняя порядок первого вхождения
+func uniqueTags(tags []string) []string {
+	result := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if !slices.Contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
@@ -0,0 +1,113 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+const (
+	// DryRunDefaultMaxSteps is step limit of dry run when request does not set one
+	// Лимит шагов пробного прогона, если запрос его не задает
+	DryRunDefaultMaxSteps = 1000
+	// DryRunMaxSteps is upper bound of step limit of dry run
+	// Верхняя граница лимита шагов пробного прогона
+	DryRunMaxSteps = 10000
+)
+
+// DryRunEventOutcome is stubbed outcome of message or timer event in dry run
+// Заглушка исхода события сообщения или таймера в пробном прогоне
+type DryRunEventOutcome string
+
+const (
+	DryRunEventFire  DryRunEventOutcome = "fire"  // Event happens immediately
+	DryRunEventNever DryRunEventOutcome = "never" // Event never happens
+)
+
+// DryRunOutcome is reason dry run stopped
+// Причина остановки пробного прогона
+type DryRunOutcome string
+
+const (
+	DryRunOutcomeCompleted DryRunOutcome = "COMPLETED"  // All paths reached end events
+	DryRunOutcomeWaiting   DryRunOutcome = "WAITING"    // Path waits on unstubbed job or event
+	DryRunOutcomeStepLimit DryRunOutcome = "STEP_LIMIT" // Max steps reached, model probably loops
+	DryRunOutcomeFailed    DryRunOutcome = "FAILED"     // Path hit element walk cannot pass
+)
+
+// DryRunStopReason is reason single path of dry run stopped
+// Причина остановки отдельного пути пробного прогона
+type DryRunStopReason string
+
+const (
+	DryRunStopEndEvent       DryRunStopReason = "END_EVENT"           // End event reached
+	DryRunStopTerminated     DryRunStopReason = "TERMINATED"          // Terminate end event reached on other path
+	DryRunStopWaitingJob     DryRunStopReason = "WAITING_FOR_JOB"     // Job type has no stubbed outcome
+	DryRunStopWaitingEvent   DryRunStopReason = "WAITING_FOR_EVENT"   // Event is not stubbed or never fires
+	DryRunStopWaitingJoin    DryRunStopReason = "WAITING_FOR_JOIN"    // Parallel join misses incoming paths
+	DryRunStopStepLimit      DryRunStopReason = "STEP_LIMIT"          // Max steps reached
+	DryRunStopIncident       DryRunStopReason = "INCIDENT"            // Engine would raise incident on element
+	DryRunStopUnsupported    DryRunStopReason = "UNSUPPORTED_ELEMENT" // Element is not walked by dry run
+	DryRunStopInvalidElement DryRunStopReason = "INVALID_ELEMENT"     // Element or flow is missing in definition
+)
+
+// DryRunRequest describes start variables and stubbed outcomes of dry run
+// Описывает стартовые переменные и заглушки исходов пробного прогона
+type DryRunRequest struct {
+	StartEventID string                 `json:"start_event_id,omitempty"` // Top-level none start event if empty
+	Variables    map[string]interface{} `json:"variables,omitempty"`
+	// Completion variables by job type, job types without entry stop path
+	// Переменные завершения по типу job'а, типы без записи останавливают путь
+	Jobs map[string]map[string]interface{} `json:"jobs,omitempty"`
+	// Outcomes by event element ID or message name, events without entry stop path
+	// Исходы по ID элемента события или имени сообщения, события без записи останавливают путь
+	Events   map[string]DryRunEventOutcome `json:"events,omitempty"`
+	MaxSteps int                           `json:"max_steps,omitempty"`
+}
+
+// DryRunResult is path walked by dry run
+// Путь, пройденный пробным прогоном
+type DryRunResult struct {
+	ProcessKey     string                 `json:"process_key"`
+	ProcessID      string                 `json:"process_id"`
+	ProcessVersion int                    `json:"process_version"`
+	Outcome        DryRunOutcome          `json:"outcome"`
+	Steps          []DryRunStep           `json:"steps"`
+	Conditions     []DryRunCondition      `json:"conditions"`
+	Stops          []DryRunStop           `json:"stops"`
+	Variables      map[string]interface{} `json:"variables"`
+}
+
+// DryRunStep is visit of element by path of dry run
+// Посещение элемента путем пробного прогона
+type DryRunStep struct {
+	Step        int    `json:"step"`
+	Path        int    `json:"path"`
+	ElementID   string `json:"element_id"`
+	ElementType string `json:"element_type"`
+	FlowID      string `json:"flow_id,omitempty"` // Sequence flow path came by
+	Note        string `json:"note,omitempty"`
+}
+
+// DryRunCondition is sequence flow condition evaluated by dry run
+// Условие sequence flow, вычисленное пробным прогоном
+type DryRunCondition struct {
+	Step       int    `json:"step"`
+	ElementID  string `json:"element_id"`
+	FlowID     string `json:"flow_id"`
+	Expression string `json:"expression"`
+	Result     bool   `json:"result"`
+	Error      string `json:"error,omitempty"`
+}
+
+// DryRunStop is element where path of dry run stopped
+// Элемент, на котором остановился путь пробного прогона
+type DryRunStop struct {
+	Path       int              `json:"path"`
+	ElementID  string           `json:"element_id"`
+	Reason     DryRunStopReason `json:"reason"`
+	WaitingFor string           `json:"waiting_for,omitempty"`
+	Detail     string           `json:"detail,omitempty"`
+}
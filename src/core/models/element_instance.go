@@ -0,0 +1,98 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import "time"
+
+// ElementInstanceState represents state of element activation
+// Представляет состояние активации элемента
+type ElementInstanceState string
+
+const (
+	ElementInstanceStateActive     ElementInstanceState = "ACTIVE"     // Token is on element
+	ElementInstanceStateCompleted  ElementInstanceState = "COMPLETED"  // Token left element
+	ElementInstanceStateTerminated ElementInstanceState = "TERMINATED" // Element was canceled or interrupted
+	ElementInstanceStateIncident   ElementInstanceState = "INCIDENT"   // Token failed or has open incident on element
+)
+
+// ElementInstance is single activation of element by token
+// Record is written with every token save, references to waits are joined on read
+// Одна активация элемента токеном
+// Запись пишется при каждом сохранении токена, ссылки на ожидания присоединяются при чтении
+type ElementInstance struct {
+	Key               int64                `json:"key"` // Element instance key of token while on element
+	ProcessInstanceID string               `json:"process_instance_id"`
+	TokenID           string               `json:"token_id"`
+	ElementID         string               `json:"element_id"`
+	ElementType       string               `json:"element_type,omitempty"` // Resolved from definition on read
+	State             ElementInstanceState `json:"state"`
+	WaitingFor        string               `json:"waiting_for,omitempty"`
+	StartedAt         time.Time            `json:"started_at"`
+	EndedAt           *time.Time           `json:"ended_at,omitempty"`
+	TakenFlows        []string             `json:"taken_flows,omitempty"` // Outgoing flows chosen by gateway
+
+	// Waits of activation, filled on read
+	// Ожидания активации, заполняются при чтении
+	Jobs          []ElementInstanceJob          `json:"jobs,omitempty"`
+	Timers        []ElementInstanceTimer        `json:"timers,omitempty"`
+	Subscriptions []ElementInstanceSubscription `json:"subscriptions,omitempty"`
+	Incidents     []ElementInstanceIncident     `json:"incidents,omitempty"`
+}
+
+// IsFinished reports whether token has left element
+// Сообщает, покинул ли токен элемент
+func (ei *ElementInstance) IsFinished() bool {
+	return ei.State == ElementInstanceStateCompleted || ei.State == ElementInstanceStateTerminated
+}
+
+// ElementInstanceJob is job created by element activation
+// Job, созданный активацией элемента
+type ElementInstanceJob struct {
+	ID     string    `json:"id"`
+	Key    int64     `json:"key,omitempty"`
+	Type   string    `json:"type"`
+	Status JobStatus `json:"status"`
+}
+
+// ElementInstanceTimer is timer scheduled for element activation, including boundary timers
+// Таймер, запланированный для активации элемента, включая boundary таймеры
+type ElementInstanceTimer struct {
+	ID        string    `json:"id"`
+	ElementID string    `json:"element_id"` // Boundary event ID for boundary timers
+	State     string    `json:"state"`      // SCHEDULED, FIRED, CANCELLED
+	DueDate   time.Time `json:"due_date"`
+}
+
+// ElementInstanceSubscription is active message subscription of element activation
+// Активная подписка на сообщение активации элемента
+type ElementInstanceSubscription struct {
+	ID             string `json:"id"`
+	MessageName    string `json:"message_name"`
+	CorrelationKey string `json:"correlation_key,omitempty"`
+}
+
+// ElementInstanceIncident is incident raised on element activation
+// Инцидент, возникший на активации элемента
+type ElementInstanceIncident struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// ElementInstanceQuery selects page of element instances of process instance
+// Выбирает страницу экземпляров элементов экземпляра процесса
+type ElementInstanceQuery struct {
+	InstanceID string
+	ElementID  string
+	State      ElementInstanceState // Empty returns all states
+
+	Offset int
+	Limit  int // 0 returns all element instances from offset
+}
@@ -0,0 +1,77 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import "time"
+
+// Engine event types mirrored to external integrations
+// Типы событий движка, транслируемые во внешние интеграции
+const (
+	EngineEventProcessInstanceCompleted   = "process_instance_completed"
+	EngineEventProcessInstanceCanceled    = "process_instance_canceled"
+	EngineEventProcessInstanceSLABreached = "process_instance_sla_breached"
+	EngineEventIncidentCreated            = "incident_created"
+	EngineEventIncidentResolved           = "incident_resolved"
+	EngineEventProcessDeployed            = "process_deployed"
+	EngineEventProcessUpdated             = "process_updated"
+	EngineEventProcessDeleted             = "process_deleted"
+)
+
+// EngineEvent represents notable engine state change
+// Представляет значимое изменение состояния движка
+type EngineEvent struct {
+	Type               string                 `json:"type"`
+	ProcessInstanceID  string                 `json:"process_instance_id,omitempty"`
+	ProcessInstanceKey int64                  `json:"process_instance_key,string,omitempty"`
+	ProcessKey         string                 `json:"process_key,omitempty"`
+	ProcessID          string                 `json:"process_id,omitempty"`
+	IncidentID         string                 `json:"incident_id,omitempty"`
+	Data               map[string]interface{} `json:"data,omitempty"`
+	Timestamp          time.Time              `json:"timestamp"`
+}
+
+// EngineEventListener receives engine events, must not block caller
+// Получает события движка, не должен блокировать вызывающего
+type EngineEventListener func(event EngineEvent)
+
+// NewProcessInstanceEvent creates engine event for process instance state change
+// Создает событие движка для изменения состояния экземпляра процесса
+func NewProcessInstanceEvent(eventType string, instance *ProcessInstance) EngineEvent {
+	event := EngineEvent{
+		Type:      eventType,
+		Timestamp: time.Now(),
+	}
+	if instance != nil {
+		event.ProcessInstanceID = instance.InstanceID
+		event.ProcessInstanceKey = instance.Key
+		event.ProcessKey = instance.ProcessKey
+		event.ProcessID = instance.ProcessID
+	}
+	return event
+}
+
+// NewProcessDefinitionEvent creates engine event for process definition change
+// Process key of event is storage key of definition version
+// Создает событие движка для изменения определения процесса
+// Ключ процесса события - ключ storage версии определения
+func NewProcessDefinitionEvent(eventType, storageKey string, process *BPMNProcess) EngineEvent {
+	event := EngineEvent{
+		Type:       eventType,
+		ProcessKey: storageKey,
+		Timestamp:  time.Now(),
+	}
+	if process != nil {
+		event.ProcessID = process.ProcessID
+		event.Data = map[string]interface{}{
+			"bpmn_id":         process.BPMNID,
+			"process_version": process.ProcessVersion,
+		}
+	}
+	return event
+}
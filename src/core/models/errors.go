@@ -0,0 +1,86 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import "errors"
+
+// Sentinel errors returned by components, callers match them with errors.Is instead of message text
+// Сигнальные ошибки компонентов, вызывающие сверяют их через errors.Is, а не по тексту сообщения
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrConflict        = errors.New("already exists")
+	ErrInvalidState    = errors.New("invalid state")
+	ErrTimeout         = errors.New("timed out")
+	ErrInvalidArgument = errors.New("invalid argument")
+)
+
+// Error codes carried in error_code field of component message responses
+// Коды ошибок, передаваемые в поле error_code ответов компонентов
+const (
+	ErrorCodeNotFound        = "NOT_FOUND"
+	ErrorCodeConflict        = "CONFLICT"
+	ErrorCodeInvalidState    = "INVALID_STATE"
+	ErrorCodeTimeout         = "TIMEOUT"
+	ErrorCodeInvalidArgument = "INVALID_ARGUMENT"
+)
+
+// errorCodes maps error codes to sentinel errors
+// Сопоставляет коды ошибок сигнальным ошибкам
+var errorCodes = map[string]error{
+	ErrorCodeNotFound:        ErrNotFound,
+	ErrorCodeConflict:        ErrConflict,
+	ErrorCodeInvalidState:    ErrInvalidState,
+	ErrorCodeTimeout:         ErrTimeout,
+	ErrorCodeInvalidArgument: ErrInvalidArgument,
+}
+
+// ErrorCodeOf returns error code of sentinel wrapped by err, empty for untyped errors
+// Возвращает код сигнальной ошибки, обернутой в err, пустой для нетипизированных ошибок
+func ErrorCodeOf(err error) string {
+	if err == nil {
+		return ""
+	}
+	for code, sentinel := range errorCodes {
+		if errors.Is(err, sentinel) {
+			return code
+		}
+	}
+	return ""
+}
+
+// ErrorFromCode restores error received in component response
+// Message is kept as is, unknown or empty code gives untyped error
+// Восстанавливает ошибку, полученную в ответе компонента
+// Сообщение сохраняется как есть, неизвестный или пустой код дает нетипизированную ошибку
+func ErrorFromCode(code, message string) error {
+	sentinel, ok := errorCodes[code]
+	if !ok {
+		return errors.New(message)
+	}
+	return &codedError{message: message, sentinel: sentinel}
+}
+
+// codedError is error restored from code and message of component response
+// Ошибка, восстановленная из кода и сообщения ответа компонента
+type codedError struct {
+	message  string
+	sentinel error
+}
+
+// Error implements error interface
+// Реализует интерфейс error
+func (e *codedError) Error() string {
+	return e.message
+}
+
+// Unwrap returns sentinel error matching code
+// Возвращает сигнальную ошибку, соответствующую коду
+func (e *codedError) Unwrap() error {
+	return e.sentinel
+}
@@ -0,0 +1,59 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import "time"
+
+// GatewayDecision is outgoing flows taken by token on exclusive or inclusive gateway
+// Исходящие потоки, выбранные токеном на эксклюзивном или включающем шлюзе
+type GatewayDecision struct {
+	ProcessKey        string    `json:"process_key"` // Storage key of definition version
+	ProcessInstanceID string    `json:"process_instance_id"`
+	TokenKey          int64     `json:"token_key"` // Element instance key of token on gateway
+	GatewayID         string    `json:"gateway_id"`
+	Flows             []string  `json:"flows"`
+	DecidedAt         time.Time `json:"decided_at"`
+}
+
+// GatewayMetrics is branching distribution of conditional gateways of definition version
+// Распределение ветвлений условных шлюзов версии определения
+type GatewayMetrics struct {
+	ProcessKey     string               `json:"process_key"`
+	ProcessID      string               `json:"process_id"`
+	ProcessVersion int                  `json:"process_version"`
+	Gateways       []GatewayFlowMetrics `json:"gateways"`
+}
+
+// GatewayFlowMetrics is how often each outgoing flow of gateway was taken
+// Total counts activations, inclusive gateway may take several flows in one activation
+// Как часто выбирался каждый исходящий поток шлюза
+// Total считает активации, включающий шлюз может выбрать несколько потоков за одну активацию
+type GatewayFlowMetrics struct {
+	GatewayID   string           `json:"gateway_id"`
+	GatewayType string           `json:"gateway_type"`
+	Name        string           `json:"name,omitempty"`
+	Total       int64            `json:"total"`
+	Flows       []FlowTakenCount `json:"flows"`
+}
+
+// FlowTakenCount is number of times outgoing flow was taken and its share of gateway activations
+// Число выборов исходящего потока и его доля от активаций шлюза
+type FlowTakenCount struct {
+	FlowID   string  `json:"flow_id"`
+	TargetID string  `json:"target_id,omitempty"`
+	Name     string  `json:"name,omitempty"`
+	Count    int64   `json:"count"`
+	Ratio    float64 `json:"ratio"`
+}
+
+// GatewayFlowCounters is raw counters of gateway decisions of definition version
+// Keyed by gateway ID, then by flow ID. Activations counter is stored under empty flow ID
+// Сырые счетчики решений шлюзов версии определения
+// Ключ - ID шлюза, затем ID потока. Счетчик активаций хранится под пустым ID потока
+type GatewayFlowCounters map[string]map[string]int64
@@ -0,0 +1,31 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import "time"
+
+// History record kinds, each kind is exported to its own index
+// Виды записей истории, каждый вид экспортируется в свой индекс
+const (
+	HistoryKindInstance = "instance" // Completed or canceled process instance
+	HistoryKindElement  = "element"  // Element passed by token of finished instance
+	HistoryKindIncident = "incident" // Created incident
+	HistoryKindSLA      = "sla"      // Process instance still running at its SLA deadline
+)
+
+// HistoryRecord is document waiting in backlog of history exporter
+// Документ, ожидающий в очереди экспортера истории
+type HistoryRecord struct {
+	ID         string                 `json:"id"` // ULID, orders backlog
+	Kind       string                 `json:"kind"`
+	DocumentID string                 `json:"document_id"` // Index document ID, makes resending idempotent
+	Timestamp  time.Time              `json:"timestamp"`   // Event time, selects dated index
+	QueuedAt   time.Time              `json:"queued_at"`
+	Document   map[string]interface{} `json:"document"`
+}
@@ -0,0 +1,78 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import "errors"
+
+// ErrInvalidMigration is returned when migration plan does not match source or target definition
+// Возвращается, когда план миграции не соответствует исходному или целевому определению
+var ErrInvalidMigration = errors.New("invalid process instance migration")
+
+// MigratedFromMetadataKey is instance metadata field holding process key instance was migrated from
+// Поле метаданных экземпляра с ключом процесса, из которого экземпляр был мигрирован
+const MigratedFromMetadataKey = "migrated_from"
+
+// Per-instance migration statuses
+// Статусы миграции отдельного экземпляра
+const (
+	MigrationStatusMigrated = "migrated"
+	MigrationStatusValid    = "valid"
+	MigrationStatusSkipped  = "skipped"
+)
+
+// MigrationPlan moves running instances of one process definition version to another
+// Elements missing from ElementMappings keep their IDs
+// Переносит выполняющиеся экземпляры одной версии определения процесса на другую
+// Элементы, отсутствующие в ElementMappings, сохраняют свои ID
+type MigrationPlan struct {
+	ProcessID     string `json:"process_id"`
+	SourceVersion int    `json:"source_version"`
+	TargetVersion int    `json:"target_version"`
+
+	// ElementMappings maps source element ID to target element ID for renamed elements
+	// ElementMappings сопоставляет ID исходного элемента с ID целевого для переименованных элементов
+	ElementMappings map[string]string `json:"element_mappings,omitempty"`
+}
+
+// MigrationFilter selects running instances of source version to migrate, empty fields match all
+// Отбирает выполняющиеся экземпляры исходной версии для миграции, пустые поля подходят всем
+type MigrationFilter struct {
+	InstanceIDs []string `json:"instance_ids,omitempty"`
+	BusinessKey string   `json:"business_key,omitempty"`
+
+	// ElementID selects instances holding token at source element
+	// ElementID отбирает экземпляры, у которых есть токен на исходном элементе
+	ElementID string `json:"element_id,omitempty"`
+}
+
+// InstanceMigrationResult is outcome of migration of one instance
+// Результат миграции одного экземпляра
+type InstanceMigrationResult struct {
+	InstanceID string `json:"instance_id"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+
+	// MigratedTokenIDs are tokens moved to target version, RearmedTokenIDs are those of them
+	// whose waits were released and created again from target definition
+	// MigratedTokenIDs - токены, перенесенные на целевую версию, RearmedTokenIDs - те из них,
+	// чьи ожидания сняты и созданы заново по целевому определению
+	MigratedTokenIDs []string `json:"migrated_token_ids,omitempty"`
+	RearmedTokenIDs  []string `json:"rearmed_token_ids,omitempty"`
+}
+
+// ProcessInstanceMigration is report of migration of selected instances
+// Отчет о миграции выбранных экземпляров
+type ProcessInstanceMigration struct {
+	SourceProcessKey string                    `json:"source_process_key"`
+	TargetProcessKey string                    `json:"target_process_key"`
+	DryRun           bool                      `json:"dry_run"`
+	Migrated         int                       `json:"migrated"`
+	Skipped          int                       `json:"skipped"`
+	Instances        []InstanceMigrationResult `json:"instances"`
+}
@@ -0,0 +1,53 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import "errors"
+
+// ErrInvalidModification is returned when modification instructions do not match instance or its definition
+// Возвращается, когда инструкции модификации не соответствуют экземпляру или его определению
+var ErrInvalidModification = errors.New("invalid process instance modification")
+
+// ModificationInstructionType is kind of process instance modification instruction
+// Вид инструкции модификации экземпляра процесса
+type ModificationInstructionType string
+
+const (
+	// ModificationCancelToken cancels token by ID or all tokens at element
+	// Отменяет токен по ID или все токены на элементе
+	ModificationCancelToken ModificationInstructionType = "cancel_token"
+	// ModificationStartToken starts new token at element
+	// Запускает новый токен на элементе
+	ModificationStartToken ModificationInstructionType = "start_token"
+	// ModificationSetVariables applies JSON merge patch to instance variables
+	// Применяет JSON merge patch к переменным экземпляра
+	ModificationSetVariables ModificationInstructionType = "set_variables"
+)
+
+// ModificationInstruction is one change of process instance modification
+// Cancel uses TokenID or ElementID, start uses ElementID and Variables as local variables
+// of new token, set_variables uses Variables as merge patch
+// Одно изменение модификации экземпляра процесса
+// Отмена использует TokenID или ElementID, запуск - ElementID и Variables как локальные
+// переменные нового токена, set_variables - Variables как merge patch
+type ModificationInstruction struct {
+	Type      ModificationInstructionType `json:"type"`
+	ElementID string                      `json:"element_id,omitempty"`
+	TokenID   string                      `json:"token_id,omitempty"`
+	Variables map[string]interface{}      `json:"variables,omitempty"`
+}
+
+// ProcessInstanceModification is result of applied modification
+// Результат примененной модификации
+type ProcessInstanceModification struct {
+	InstanceID       string                 `json:"instance_id"`
+	CanceledTokenIDs []string               `json:"canceled_token_ids"`
+	StartedTokenIDs  []string               `json:"started_token_ids"`
+	Variables        map[string]interface{} `json:"variables"`
+}
@@ -0,0 +1,43 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// InstanceSLAProperty is name of process extension property holding ISO 8601 SLA duration
+// Имя свойства расширения процесса, содержащего ISO 8601 длительность SLA
+const InstanceSLAProperty = "sla"
+
+// instanceSLAContextKey is context key for SLA of started instance
+// Ключ контекста для SLA запускаемого экземпляра
+type instanceSLAContextKey struct{}
+
+// ContextWithInstanceSLA returns context carrying SLA of instance started with it,
+// it overrides SLA declared in process definition
+// Возвращает контекст, содержащий SLA экземпляра, запускаемого с ним,
+// он заменяет SLA, объявленный в определении процесса
+func ContextWithInstanceSLA(ctx context.Context, sla time.Duration) context.Context {
+	if sla <= 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, instanceSLAContextKey{}, sla)
+}
+
+// InstanceSLAFromContext returns SLA stored in context or zero
+// Возвращает SLA из контекста или ноль
+func InstanceSLAFromContext(ctx context.Context) time.Duration {
+	if ctx == nil {
+		return 0
+	}
+	sla, _ := ctx.Value(instanceSLAContextKey{}).(time.Duration)
+	return sla
+}
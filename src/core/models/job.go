@@ -9,6 +9,8 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package models
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -26,10 +28,17 @@ const (
 	JobStatusErrorThrown JobStatus = "ERROR_THROWN"
 )
 
+// Metadata keys of job whose type was resolved from FEEL expression of task definition
+const (
+	JobMetadataTypeExpression = "typeExpression"
+	JobMetadataResolvedType   = "resolvedType"
+)
+
 // Job represents a job in the system
 type Job struct {
 	// Basic fields
 	ID         string    `json:"id"`
+	Key        int64     `json:"key,omitempty"` // Numeric key, zero for jobs created before keys were introduced
 	Type       string    `json:"type"`
 	Status     JobStatus `json:"status"`
 	WorkerID   string    `json:"worker_id"`
@@ -40,7 +49,12 @@ type Job struct {
 	ProcessInstanceID string `json:"process_instance_id"`
 	ElementID         string `json:"element_id"`
 	ElementInstanceID string `json:"element_instance_id"`
-	TokenID           string `json:"token_id"` // Token that created this job
+	TokenID           string `json:"token_id"`             // Token that created this job
+	ProcessID         string `json:"process_id,omitempty"` // BPMN process ID of instance, labels job metrics
+
+	// Numeric keys of related entities
+	ProcessInstanceKey int64 `json:"process_instance_key,omitempty"`
+	ElementInstanceKey int64 `json:"element_instance_key,omitempty"`
 
 	// Job data
 	CustomHeaders map[string]string      `json:"custom_headers"`
@@ -53,12 +67,18 @@ type Job struct {
 	CompletedAt *time.Time `json:"completed_at,omitempty"`
 
 	// Scheduling
-	ScheduledAt *time.Time `json:"scheduled_at,omitempty"`
+	ScheduledAt *time.Time `json:"scheduled_at,omitempty"` // Lease expiry of running job, retry time of deferred job
 	Priority    int        `json:"priority"`
 
+	// Retry schedule from service task, e.g. "R3/PT10S"
+	RetryTimeCycle string `json:"retry_time_cycle,omitempty"`
+
 	// Metadata
 	ErrorMessage string            `json:"error_message,omitempty"`
 	Metadata     map[string]string `json:"metadata,omitempty"`
+
+	// Callbacks sent to process engine so far, numbers each completion for duplicate detection
+	CallbackSeq int `json:"callback_seq,omitempty"`
 }
 
 // NewJob creates a new job
@@ -66,6 +86,7 @@ func NewJob(jobType, processInstanceID, elementID string) *Job {
 	now := time.Now()
 	return &Job{
 		ID:                GenerateID(),
+		Key:               GenerateKey(),
 		Type:              jobType,
 		Status:            JobStatusPending,
 		ProcessInstanceID: processInstanceID,
@@ -145,3 +166,42 @@ func (j *Job) MarkAsErrorThrown(errorCode, errorMessage string) {
 	j.Metadata["errorCode"] = errorCode
 	j.Metadata["completionType"] = "BPMN_ERROR"
 }
+
+// NextCallbackID advances callback sequence and returns ID identifying this completion
+func (j *Job) NextCallbackID() string {
+	j.CallbackSeq++
+	if j.Key != 0 {
+		return fmt.Sprintf("%d:%d", j.Key, j.CallbackSeq)
+	}
+	return fmt.Sprintf("%s:%d", j.ID, j.CallbackSeq)
+}
+
+// ToJSON converts job to storage JSON with typed variables
+func (j *Job) ToJSON() ([]byte, error) {
+	type plain Job
+	return json.Marshal(struct {
+		*plain
+		Variables      map[string]Variable `json:"variables"`
+		VariablesTyped bool                `json:"variables_typed"`
+	}{(*plain)(j), TypedVariables(j.Variables), true})
+}
+
+// FromJSON creates job from storage JSON
+func (j *Job) FromJSON(data []byte) error {
+	type plain Job
+	record := struct {
+		*plain
+		Variables      json.RawMessage `json:"variables"`
+		VariablesTyped bool            `json:"variables_typed"`
+	}{plain: (*plain)(j)}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return err
+	}
+
+	variables, err := DecodeStoredVariables(record.Variables, record.VariablesTyped)
+	if err != nil {
+		return err
+	}
+	j.Variables = variables
+	return nil
+}
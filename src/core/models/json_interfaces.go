@@ -8,7 +8,11 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 
 package models
 
-import "context"
+import (
+	"context"
+	"fmt"
+	"time"
+)
 
 // JSONMessageProcessor interface for components that can process JSON messages
 // Интерфейс для компонентов, которые могут обрабатывать JSON сообщения
@@ -30,3 +34,29 @@ type JSONMessageRouter interface {
 	SendMessage(componentName, messageJSON string) error
 	SendMessageWithResponse(ctx context.Context, componentName, messageJSON string) (*ComponentResponse, error)
 }
+
+// ComponentNotReadyError is returned when message targets component that is not ready
+// Возвращается, когда сообщение адресовано неготовому компоненту
+type ComponentNotReadyError struct {
+	Component string
+}
+
+// Error implements error interface
+// Реализует интерфейс error
+func (e *ComponentNotReadyError) Error() string {
+	return fmt.Sprintf("component not ready: %s", e.Component)
+}
+
+// CircuitOpenError is returned when component circuit breaker rejects request
+// Возвращается, когда circuit breaker компонента отклоняет запрос
+type CircuitOpenError struct {
+	Component  string
+	RetryAfter time.Duration
+}
+
+// Error implements error interface
+// Реализует интерфейс error
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("component unavailable: %s circuit open, retry after %s",
+		e.Component, e.RetryAfter.Round(time.Millisecond))
+}
@@ -0,0 +1,110 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Numeric key layout: 41 bits milliseconds since key epoch, 10 bits node ID, 12 bits sequence
+// Структура числового ключа: 41 бит миллисекунд от эпохи ключей, 10 бит ID узла, 12 бит счетчика
+const (
+	keyNodeBits     = 10
+	keySequenceBits = 12
+
+	// MaxKeyNodeID is largest node ID that fits into numeric key
+	// Максимальный ID узла, помещающийся в числовой ключ
+	MaxKeyNodeID = 1<<keyNodeBits - 1
+
+	maxKeySequence = 1<<keySequenceBits - 1
+)
+
+// keyEpoch is start of numeric key timestamps (2025-01-01 UTC)
+// Начало отсчета времени числовых ключей (2025-01-01 UTC)
+var keyEpoch = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// keyGenerator produces time-ordered numeric keys unique per node
+// Генерирует упорядоченные по времени числовые ключи, уникальные для узла
+type keyGenerator struct {
+	mu            sync.Mutex
+	nodeID        int64
+	lastTimestamp int64
+	sequence      int64
+}
+
+var globalKeyGenerator = &keyGenerator{}
+
+// SetKeyNodeID sets node ID embedded into generated numeric keys
+// Устанавливает ID узла, встраиваемый в генерируемые числовые ключи
+func SetKeyNodeID(nodeID int) error {
+	if nodeID < 0 || nodeID > MaxKeyNodeID {
+		return fmt.Errorf("key node id must be between 0 and %d, got %d", MaxKeyNodeID, nodeID)
+	}
+
+	globalKeyGenerator.mu.Lock()
+	defer globalKeyGenerator.mu.Unlock()
+	globalKeyGenerator.nodeID = int64(nodeID)
+	return nil
+}
+
+// GenerateKey generates unique time-ordered int64 key
+// Генерирует уникальный упорядоченный по времени ключ int64
+func GenerateKey() int64 {
+	return globalKeyGenerator.next()
+}
+
+// next returns next key, never going back when clock moves backwards
+// Возвращает следующий ключ, не уходя назад при переводе часов
+func (g *keyGenerator) next() int64 {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	timestamp := time.Since(keyEpoch).Milliseconds()
+	if timestamp <= g.lastTimestamp {
+		// Same millisecond or clock moved back: continue from last timestamp
+		// Та же миллисекунда или часы ушли назад: продолжаем от последнего времени
+		timestamp = g.lastTimestamp
+		g.sequence++
+		if g.sequence > maxKeySequence {
+			// Sequence exhausted: borrow next millisecond
+			// Счетчик исчерпан: занимаем следующую миллисекунду
+			timestamp++
+			g.sequence = 0
+		}
+	} else {
+		g.sequence = 0
+	}
+	g.lastTimestamp = timestamp
+
+	return timestamp<<(keyNodeBits+keySequenceBits) | g.nodeID<<keySequenceBits | g.sequence
+}
+
+// ParseKey parses numeric key from string
+// Returns false for string IDs, which always contain non-digit characters
+// Разбирает числовой ключ из строки
+// Возвращает false для строковых ID, которые всегда содержат нецифровые символы
+func ParseKey(value string) (int64, bool) {
+	if value == "" {
+		return 0, false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return 0, false
+		}
+	}
+
+	key, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || key <= 0 {
+		return 0, false
+	}
+	return key, true
+}
@@ -0,0 +1,86 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGenerateKeyConcurrentMonotonic(t *testing.T) {
+	const goroutines, perGoroutine = 16, 5000
+
+	results := make([][]int64, goroutines)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			keys := make([]int64, perGoroutine)
+			for i := range keys {
+				keys[i] = GenerateKey()
+			}
+			results[g] = keys
+		}(g)
+	}
+	wg.Wait()
+
+	seen := make(map[int64]bool, goroutines*perGoroutine)
+	for g, keys := range results {
+		for i, key := range keys {
+			if key <= 0 {
+				t.Fatalf("non-positive key %d", key)
+			}
+			if seen[key] {
+				t.Fatalf("duplicate key %d", key)
+			}
+			seen[key] = true
+			if i > 0 && keys[i-1] >= key {
+				t.Fatalf("goroutine %d: key %d not after %d", g, key, keys[i-1])
+			}
+		}
+	}
+}
+
+func TestKeyGeneratorMonotonicWhenClockMovesBack(t *testing.T) {
+	future := time.Since(keyEpoch).Milliseconds() + time.Hour.Milliseconds()
+	g := &keyGenerator{nodeID: 3, lastTimestamp: future, sequence: maxKeySequence - 1}
+	previous := future<<(keyNodeBits+keySequenceBits) | 3<<keySequenceBits | (maxKeySequence - 1)
+
+	// Second key exhausts sequence and borrows next millisecond
+	// Второй ключ исчерпывает счетчик и занимает следующую миллисекунду
+	for i := 0; i < 3; i++ {
+		key := g.next()
+		if key <= previous {
+			t.Fatalf("key %d not after %d", key, previous)
+		}
+		if node := key >> keySequenceBits & MaxKeyNodeID; node != 3 {
+			t.Fatalf("key %d carries node %d, want 3", key, node)
+		}
+		previous = key
+	}
+	if g.lastTimestamp != future+1 {
+		t.Errorf("timestamp %d, want borrowed %d", g.lastTimestamp, future+1)
+	}
+}
+
+func BenchmarkGenerateKey(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		GenerateKey()
+	}
+}
+
+func BenchmarkGenerateKeyParallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			GenerateKey()
+		}
+	})
+}
@@ -12,19 +12,43 @@ import (
 	"time"
 )
 
+// DefaultTenantID is tenant of messages and subscriptions created without explicit tenant
+const DefaultTenantID = ""
+
+// legacyDefaultTenantID is default tenant stored by earlier versions in catch event subscriptions
+const legacyDefaultTenantID = "DEFAULT_TENANT"
+
+// SameTenant reports whether tenant IDs denote same tenant, messages never cross tenants implicitly
+func SameTenant(a, b string) bool {
+	return normalizeTenantID(a) == normalizeTenantID(b)
+}
+
+// normalizeTenantID maps legacy default tenant to DefaultTenantID
+func normalizeTenantID(tenantID string) string {
+	if tenantID == legacyDefaultTenantID {
+		return DefaultTenantID
+	}
+	return tenantID
+}
+
 // ProcessMessageSubscription represents process message subscription
 type ProcessMessageSubscription struct {
-	ID                   string    `json:"id"`
-	TenantID             string    `json:"tenant_id"`
-	ProcessDefinitionKey string    `json:"process_definition_key"`
-	ProcessVersion       int32     `json:"process_version"`
-	StartEventID         string    `json:"start_event_id"`
-	MessageName          string    `json:"message_name"`
-	MessageRef           string    `json:"message_ref"`
-	CorrelationKey       string    `json:"correlation_key,omitempty"`
-	IsActive             bool      `json:"is_active"`
-	CreatedAt            time.Time `json:"created_at"`
-	UpdatedAt            time.Time `json:"updated_at"`
+	ID                   string `json:"id"`
+	TenantID             string `json:"tenant_id"`
+	ProcessDefinitionKey string `json:"process_definition_key"`
+	ProcessVersion       int32  `json:"process_version"`
+	StartEventID         string `json:"start_event_id"`
+	MessageName          string `json:"message_name"`
+	MessageRef           string `json:"message_ref"`
+	CorrelationKey       string `json:"correlation_key,omitempty"`
+	// CorrelationKeyExpression is "=" expression CorrelationKey was resolved from at subscription time
+	CorrelationKeyExpression string `json:"correlation_key_expression,omitempty"`
+	// Process instance and element activation waiting for message, empty for start event subscriptions
+	ProcessInstanceID  string    `json:"process_instance_id,omitempty"`
+	ElementInstanceKey int64     `json:"element_instance_key,omitempty"`
+	IsActive           bool      `json:"is_active"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
 }
 
 // BufferedMessage represents a buffered message
@@ -39,6 +63,8 @@ type BufferedMessage struct {
 	ExpiresAt      *time.Time             `json:"expires_at,omitempty"`
 	Reason         string                 `json:"reason"`
 	ElementID      string                 `json:"element_id,omitempty"`
+	// Set when message may correlate with subscriptions of any tenant
+	BroadcastAcrossTenants bool `json:"broadcast_across_tenants,omitempty"`
 }
 
 // MessageCorrelationResult represents message correlation result
@@ -53,6 +79,24 @@ type MessageCorrelationResult struct {
 	CreatedAt         time.Time              `json:"created_at"`
 	InstanceCreated   bool                   `json:"instance_created"`
 	ErrorMessage      string                 `json:"error_message,omitempty"`
+	BufferedAt        *time.Time             `json:"buffered_at,omitempty"` // Set when correlated from buffer
+	Expired           bool                   `json:"expired,omitempty"`     // Set when message expired uncorrelated
+	// Set when broadcast message correlated with subscription of another tenant
+	CrossTenant          bool   `json:"cross_tenant,omitempty"`
+	SubscriptionTenantID string `json:"subscription_tenant_id,omitempty"`
+}
+
+// IsCorrelated checks if correlation result reached a process instance
+func (r *MessageCorrelationResult) IsCorrelated() bool {
+	return r.ProcessInstanceID != "" && !r.Expired
+}
+
+// BufferDwellTime returns time message spent in buffer before correlation
+func (r *MessageCorrelationResult) BufferDwellTime() time.Duration {
+	if r.BufferedAt == nil {
+		return 0
+	}
+	return r.CreatedAt.Sub(*r.BufferedAt)
 }
 
 // IsExpired checks if buffered message is expired
@@ -86,7 +130,7 @@ func NewBufferedMessage(
 ) *BufferedMessage {
 	now := time.Now()
 	return &BufferedMessage{
-		ID:             GenerateID(),
+		ID:             GenerateSortableID(),
 		TenantID:       tenantID,
 		Name:           name,
 		CorrelationKey: correlationKey,
@@ -10,6 +10,7 @@ package models
 
 import (
 	"encoding/json"
+	"errors"
 	"time"
 )
 
@@ -26,10 +27,19 @@ const (
 	ProcessInstanceStateSuspended ProcessInstanceState = "SUSPENDED"
 )
 
+// ErrProcessInstanceNotFinished is returned when operation requires finished instance
+// Возвращается, когда операции нужен завершенный экземпляр
+var ErrProcessInstanceNotFinished = errors.New("process instance is not finished")
+
+// ErrInvalidRestartElement is returned when instance cannot be restarted from element
+// Возвращается, когда экземпляр нельзя перезапустить с элемента
+var ErrInvalidRestartElement = errors.New("invalid restart element")
+
 // ProcessInstance represents running instance of BPMN process
 // Представляет выполняющийся экземпляр BPMN процесса
 type ProcessInstance struct {
 	InstanceID      string                 `json:"instance_id"`
+	Key             int64                  `json:"key,omitempty"`   // Numeric instance key
 	ProcessID       string                 `json:"process_id"`      // Process definition ID
 	ProcessName     string                 `json:"process_name"`    // Human readable name
 	ProcessVersion  int                    `json:"process_version"` // Version of process definition
@@ -41,17 +51,64 @@ type ProcessInstance struct {
 	UpdatedAt       time.Time              `json:"updated_at"`
 	CompletedAt     *time.Time             `json:"completed_at,omitempty"`
 
+	// Priority is added to priority of every job created in this instance
+	// Приоритет добавляется к приоритету каждого job, созданного в экземпляре
+	Priority int `json:"priority"`
+
+	// ParentInstanceID is instance this one was restarted from
+	// ParentInstanceID - экземпляр, из которого перезапущен данный
+	ParentInstanceID string `json:"parent_instance_id,omitempty"`
+
+	// TraceParent is W3C traceparent of request that started instance, tokens are traced under it
+	// TraceParent - W3C traceparent запроса, запустившего экземпляр, токены трассируются под ним
+	TraceParent string `json:"trace_parent,omitempty"`
+
+	// SLADeadline is time instance must finish by, nil when instance has no SLA
+	// SLADeadline - время, до которого экземпляр должен завершиться, nil если у экземпляра нет SLA
+	SLADeadline *time.Time `json:"sla_deadline,omitempty"`
+
+	// SLATimerID is timewheel timer firing at SLA deadline
+	// SLATimerID - таймер timewheel, срабатывающий в срок SLA
+	SLATimerID string `json:"sla_timer_id,omitempty"`
+
+	// SLABreachedAt is time instance was found running at SLA deadline
+	// SLABreachedAt - время, когда экземпляр был обнаружен выполняющимся в срок SLA
+	SLABreachedAt *time.Time `json:"sla_breached_at,omitempty"`
+
 	// Metadata for process execution
 	// Метаданные для выполнения процесса
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// ProcessInstanceQuery selects sorted page of process instances
+// Выбирает отсортированную страницу экземпляров процессов
+type ProcessInstanceQuery struct {
+	Status     string
+	ProcessKey string
+
+	// SortBy is created_at, updated_at, state or process_key, empty sorts by created_at
+	// SortBy - created_at, updated_at, state или process_key, пустое значение сортирует по created_at
+	SortBy string
+
+	// SortOrder is asc or desc, empty sorts descending
+	// SortOrder - asc или desc, пустое значение сортирует по убыванию
+	SortOrder string
+
+	Offset int
+	Limit  int // 0 returns all instances from offset
+
+	// ExcludeVariables returns instances of page without variables
+	// ExcludeVariables возвращает экземпляры страницы без переменных
+	ExcludeVariables bool
+}
+
 // NewProcessInstance creates new process instance
 // Создает новый экземпляр процесса
 func NewProcessInstance(processID, processName string, processVersion int, processKey string) *ProcessInstance {
 	now := time.Now()
 	return &ProcessInstance{
-		InstanceID:     GenerateID(),
+		InstanceID:     GenerateSortableID(),
+		Key:            GenerateKey(),
 		ProcessID:      processID,
 		ProcessName:    processName,
 		ProcessVersion: processVersion,
@@ -64,16 +121,36 @@ func NewProcessInstance(processID, processName string, processVersion int, proce
 	}
 }
 
-// ToJSON converts process instance to JSON
-// Конвертирует экземпляр процесса в JSON
+// ToJSON converts process instance to storage JSON with typed variables
+// Конвертирует экземпляр процесса в JSON для storage с типизированными переменными
 func (pi *ProcessInstance) ToJSON() ([]byte, error) {
-	return json.Marshal(pi)
+	type plain ProcessInstance
+	return json.Marshal(struct {
+		*plain
+		Variables      map[string]Variable `json:"variables"`
+		VariablesTyped bool                `json:"variables_typed"`
+	}{(*plain)(pi), TypedVariables(pi.Variables), true})
 }
 
-// FromJSON creates process instance from JSON
-// Создает экземпляр процесса из JSON
+// FromJSON creates process instance from storage JSON
+// Создает экземпляр процесса из JSON storage
 func (pi *ProcessInstance) FromJSON(data []byte) error {
-	return json.Unmarshal(data, pi)
+	type plain ProcessInstance
+	record := struct {
+		*plain
+		Variables      json.RawMessage `json:"variables"`
+		VariablesTyped bool            `json:"variables_typed"`
+	}{plain: (*plain)(pi)}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return err
+	}
+
+	variables, err := DecodeStoredVariables(record.Variables, record.VariablesTyped)
+	if err != nil {
+		return err
+	}
+	pi.Variables = variables
+	return nil
 }
 
 // SetVariable sets process variable
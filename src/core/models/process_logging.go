@@ -0,0 +1,23 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import "time"
+
+// ProcessLogging is execution logging state of definition version.
+// While debug is on, step-by-step execution logs of its instances are written at info level
+// Состояние логирования выполнения версии определения
+// Пока debug включен, пошаговые логи выполнения ее экземпляров пишутся на уровне info
+type ProcessLogging struct {
+	ProcessKey     string     `json:"process_key"`
+	ProcessID      string     `json:"process_id"`
+	ProcessVersion int        `json:"process_version"`
+	Debug          bool       `json:"debug"`
+	DebugUntil     *time.Time `json:"debug_until,omitempty"`
+}
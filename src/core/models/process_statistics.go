@@ -0,0 +1,58 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import "time"
+
+// ProcessStatisticsMaxDays is number of days daily instance counts are kept for
+// Число дней, за которые хранятся дневные счетчики экземпляров
+const ProcessStatisticsMaxDays = 90
+
+// ProcessStatistics is instance statistics of process definition and its versions
+// Статистика экземпляров определения процесса и его версий
+type ProcessStatistics struct {
+	ProcessID string `json:"process_id"`
+	Days      int    `json:"days"` // Number of days in daily counts
+
+	// Total aggregates all versions, its Version is 0
+	// Total объединяет все версии, его Version равен 0
+	Total    ProcessVersionStatistics   `json:"total"`
+	Versions []ProcessVersionStatistics `json:"versions"`
+}
+
+// ProcessVersionStatistics is instance statistics of process definition version
+// Статистика экземпляров версии определения процесса
+type ProcessVersionStatistics struct {
+	Version       int   `json:"version"`
+	Active        int64 `json:"active"`
+	Completed     int64 `json:"completed"`
+	Canceled      int64 `json:"canceled"`
+	Failed        int64 `json:"failed"`
+	OpenIncidents int64 `json:"open_incidents"`
+
+	// Durations of completed instances, P95 is computed over most recent completions
+	// Длительности завершенных экземпляров, P95 вычисляется по последним завершениям
+	AvgDuration time.Duration `json:"avg_duration"`
+	P95Duration time.Duration `json:"p95_duration"`
+
+	// CompletionRate is share of completed among instances finished within daily counts window
+	// CompletionRate - доля завершенных среди экземпляров, закончившихся в окне дневных счетчиков
+	CompletionRate float64               `json:"completion_rate"`
+	Daily          []DailyInstanceCounts `json:"daily"`
+}
+
+// DailyInstanceCounts counts instances started and finished during UTC day
+// Считает экземпляры, запущенные и закончившиеся за UTC день
+type DailyInstanceCounts struct {
+	Date      string `json:"date"` // YYYY-MM-DD
+	Started   int64  `json:"started"`
+	Completed int64  `json:"completed"`
+	Canceled  int64  `json:"canceled"`
+	Failed    int64  `json:"failed"`
+}
@@ -0,0 +1,117 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Request ID and trace context propagation keys
+// Ключи распространения ID запроса и контекста трассировки
+const (
+	// RequestIDMetadataKey is gRPC metadata key carrying request ID
+	// Ключ gRPC metadata, содержащий ID запроса
+	RequestIDMetadataKey = "x-request-id"
+	// RequestIDMessageField is JSON field carrying request ID in component messages
+	// JSON поле с ID запроса в сообщениях компонентов
+	RequestIDMessageField = "request_id"
+	// TraceParentMessageField is JSON field carrying W3C traceparent in component messages
+	// JSON поле с W3C traceparent в сообщениях компонентов
+	TraceParentMessageField = "traceparent"
+)
+
+// requestIDContextKey is context key for request ID
+// Ключ контекста для ID запроса
+type requestIDContextKey struct{}
+
+// ContextWithRequestID returns context carrying request ID
+// Возвращает контекст, содержащий ID запроса
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	if requestID == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDContextKey{}, requestID)
+}
+
+// RequestIDFromContext returns request ID stored in context or empty string
+// Возвращает ID запроса из контекста или пустую строку
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	requestID, _ := ctx.Value(requestIDContextKey{}).(string)
+	return requestID
+}
+
+// WithMessageRequestID adds request ID to JSON message object
+// Message is returned unchanged when it already has request ID or is not JSON object
+// Добавляет ID запроса в JSON объект сообщения
+// Сообщение возвращается без изменений, если ID уже задан или это не JSON объект
+func WithMessageRequestID(messageJSON, requestID string) string {
+	return withMessageField(messageJSON, RequestIDMessageField, requestID, false)
+}
+
+// ReplaceMessageRequestID sets request ID of JSON message object, replacing existing one
+// Устанавливает ID запроса в JSON объекте сообщения, заменяя существующий
+func ReplaceMessageRequestID(messageJSON, requestID string) string {
+	return withMessageField(messageJSON, RequestIDMessageField, requestID, true)
+}
+
+// MessageRequestID returns request ID of JSON message object or empty string
+// Возвращает ID запроса JSON объекта сообщения или пустую строку
+func MessageRequestID(messageJSON string) string {
+	var message struct {
+		RequestID string `json:"request_id"`
+	}
+	if err := json.Unmarshal([]byte(messageJSON), &message); err != nil {
+		return ""
+	}
+	return message.RequestID
+}
+
+// WithMessageTraceParent adds W3C traceparent to JSON message object
+// Message is returned unchanged when it already has traceparent or is not JSON object
+// Добавляет W3C traceparent в JSON объект сообщения
+// Сообщение возвращается без изменений, если traceparent уже задан или это не JSON объект
+func WithMessageTraceParent(messageJSON, traceParent string) string {
+	return withMessageField(messageJSON, TraceParentMessageField, traceParent, false)
+}
+
+// withMessageField sets string field of JSON message object unless it is already set and replace is false
+// Устанавливает строковое поле JSON объекта сообщения, если оно еще не задано или replace установлен
+func withMessageField(messageJSON, field, value string, replace bool) string {
+	if value == "" {
+		return messageJSON
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(messageJSON), &fields); err != nil || fields == nil {
+		return messageJSON
+	}
+
+	if existing, ok := fields[field]; ok && !replace {
+		var current string
+		if json.Unmarshal(existing, &current) != nil || current != "" {
+			return messageJSON
+		}
+	}
+
+	encodedValue, err := json.Marshal(value)
+	if err != nil {
+		return messageJSON
+	}
+	fields[field] = encodedValue
+
+	result, err := json.Marshal(fields)
+	if err != nil {
+		return messageJSON
+	}
+	return string(result)
+}
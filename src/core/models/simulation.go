@@ -0,0 +1,29 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import "time"
+
+// SimulationAdvance is result of advancing simulated clock of timewheel
+// Результат продвижения симулированных часов timewheel
+type SimulationAdvance struct {
+	From        time.Time        `json:"from"`
+	To          time.Time        `json:"to"`
+	FiredTimers []SimulatedTimer `json:"fired_timers"` // In firing order
+}
+
+// SimulatedTimer is timer fired while simulated clock was advanced
+// Таймер, сработавший при продвижении симулированных часов
+type SimulatedTimer struct {
+	ID                string    `json:"id"`
+	ElementID         string    `json:"element_id"`
+	ProcessInstanceID string    `json:"process_instance_id,omitempty"`
+	TimerType         TimerType `json:"timer_type"`
+	DueDate           time.Time `json:"due_date"`
+}
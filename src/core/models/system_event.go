@@ -29,6 +29,20 @@ const (
 	EventTypeBPMNParse  = "bpmn_parse"
 	EventTypeBPMNDelete = "bpmn_delete"
 	EventTypeError      = "error"
+
+	EventTypeJobCallback     = "job_callback"
+	EventTypeTimerFired      = "timer_fired"
+	EventTypeMessageCallback = "message_callback"
+)
+
+// System event source components
+// Компоненты-источники системных событий
+const (
+	EventComponentCore      = "core"
+	EventComponentParser    = "parser"
+	EventComponentJobs      = "jobs"
+	EventComponentTimewheel = "timewheel"
+	EventComponentMessages  = "messages"
 )
 
 // System event statuses
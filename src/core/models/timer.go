@@ -17,9 +17,11 @@ import (
 type TimerType string
 
 const (
-	TimerTypeStart    TimerType = "START"    // Start event timer
-	TimerTypeBoundary TimerType = "BOUNDARY" // Boundary event timer
-	TimerTypeEvent    TimerType = "EVENT"    // Intermediate timer event
+	TimerTypeStart    TimerType = "START"     // Start event timer
+	TimerTypeBoundary TimerType = "BOUNDARY"  // Boundary event timer
+	TimerTypeEvent    TimerType = "EVENT"     // Intermediate timer event
+	TimerTypeJobRetry TimerType = "JOB_RETRY" // Reactivation of failed job, element_id holds job ID
+	TimerTypeSLA      TimerType = "SLA"       // Process instance deadline, element_id holds process ID
 )
 
 // TimerState defines state of timer
@@ -45,6 +45,7 @@ const (
 // Представляет токен выполнения движущийся по процессу
 type Token struct {
 	TokenID           string                 `json:"token_id"`
+	Key               int64                  `json:"key,omitempty"` // Numeric element instance key
 	ProcessInstanceID string                 `json:"process_instance_id"`
 	ProcessKey        string                 `json:"process_key"`
 	CurrentElementID  string                 `json:"current_element_id"`
@@ -77,6 +78,10 @@ type Token struct {
 	// Boundary timer IDs attached to this token
 	// ID boundary таймеров прикрепленных к данному токену
 	BoundaryTimerIDs []string `json:"boundary_timer_ids,omitempty"`
+
+	// W3C traceparent execution spans of this token are children of, inherited by derived tokens
+	// W3C traceparent, дочерними которому являются span'ы выполнения токена, наследуется производными токенами
+	TraceParent string `json:"trace_parent,omitempty"`
 }
 
 // NewToken creates new execution token
@@ -84,7 +89,8 @@ type Token struct {
 func NewToken(processInstanceID, processKey, elementID string) *Token {
 	now := time.Now()
 	return &Token{
-		TokenID:           GenerateID(),
+		TokenID:           GenerateSortableID(),
+		Key:               GenerateKey(),
 		ProcessInstanceID: processInstanceID,
 		ProcessKey:        processKey,
 		CurrentElementID:  elementID,
@@ -115,23 +121,44 @@ func NewTimerToken(processInstanceID, processKey, elementID string) *Token {
 	return token
 }
 
-// ToJSON converts token to JSON
-// Конвертирует токен в JSON
+// ToJSON converts token to storage JSON with typed variables
+// Конвертирует токен в JSON для storage с типизированными переменными
 func (t *Token) ToJSON() ([]byte, error) {
-	return json.Marshal(t)
+	type plain Token
+	return json.Marshal(struct {
+		*plain
+		Variables      map[string]Variable `json:"variables"`
+		VariablesTyped bool                `json:"variables_typed"`
+	}{(*plain)(t), TypedVariables(t.Variables), true})
 }
 
-// FromJSON creates token from JSON
-// Создает токен из JSON
+// FromJSON creates token from storage JSON
+// Создает токен из JSON storage
 func (t *Token) FromJSON(data []byte) error {
-	return json.Unmarshal(data, t)
+	type plain Token
+	record := struct {
+		*plain
+		Variables      json.RawMessage `json:"variables"`
+		VariablesTyped bool            `json:"variables_typed"`
+	}{plain: (*plain)(t)}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return err
+	}
+
+	variables, err := DecodeStoredVariables(record.Variables, record.VariablesTyped)
+	if err != nil {
+		return err
+	}
+	t.Variables = variables
+	return nil
 }
 
-// MoveTo moves token to next element
-// Перемещает токен к следующему элементу
+// MoveTo moves token to next element, each element activation gets new element instance key
+// Перемещает токен к следующему элементу, каждая активация элемента получает новый ключ экземпляра элемента
 func (t *Token) MoveTo(elementID string) {
 	t.PreviousElementID = t.CurrentElementID
 	t.CurrentElementID = elementID
+	t.Key = GenerateKey()
 	t.UpdatedAt = time.Now()
 }
 
@@ -210,6 +237,16 @@ func (t *Token) GetExecutionContext(key string) (interface{}, bool) {
 	return value, exists
 }
 
+// DeleteExecutionContext removes execution context field
+// Удаляет поле контекста выполнения
+func (t *Token) DeleteExecutionContext(key string) {
+	if _, exists := t.ExecutionContext[key]; !exists {
+		return
+	}
+	delete(t.ExecutionContext, key)
+	t.UpdatedAt = time.Now()
+}
+
 // SetWaitingFor sets what token is waiting for
 // Устанавливает чего ожидает токен
 func (t *Token) SetWaitingFor(waitingFor string) {
@@ -272,6 +309,17 @@ func (t *Token) RemoveBoundaryTimer(timerID string) {
 	t.UpdatedAt = time.Now()
 }
 
+// HasBoundaryTimer checks if boundary timer ID is registered on token
+// Проверяет, зарегистрирован ли ID boundary таймера в токене
+func (t *Token) HasBoundaryTimer(timerID string) bool {
+	for _, id := range t.BoundaryTimerIDs {
+		if id == timerID {
+			return true
+		}
+	}
+	return false
+}
+
 // HasBoundaryTimers checks if token has boundary timers
 // Проверяет есть ли у токена boundary таймеры
 func (t *Token) HasBoundaryTimers() bool {
@@ -333,7 +381,8 @@ func (t *Token) IsFromTimerCallback() bool {
 func (t *Token) Clone() *Token {
 	now := time.Now()
 	clone := &Token{
-		TokenID:           GenerateID(),
+		TokenID:           GenerateSortableID(),
+		Key:               GenerateKey(),
 		ProcessInstanceID: t.ProcessInstanceID,
 		ProcessKey:        t.ProcessKey,
 		CurrentElementID:  t.CurrentElementID,
@@ -344,6 +393,7 @@ func (t *Token) Clone() *Token {
 		ExecutionContext:  make(map[string]interface{}),
 		ParentTokenID:     t.TokenID, // Set original as parent
 		ChildTokenIDs:     make([]string, 0),
+		TraceParent:       t.TraceParent,
 		CreatedAt:         now,
 		UpdatedAt:         now,
 	}
@@ -0,0 +1,141 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ULID layout: 48 bits Unix milliseconds + 80 bits entropy, Crockford base32 encoded
+// Структура ULID: 48 бит Unix миллисекунд + 80 бит энтропии, кодировка Crockford base32
+const (
+	ulidLength       = 26
+	ulidEncoding     = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	ulidMaxTimestamp = 1<<48 - 1
+)
+
+// ulidGenerator produces monotonic ULIDs
+// Within same millisecond entropy is incremented instead of regenerated
+// Генерирует монотонные ULID
+// В пределах одной миллисекунды энтропия увеличивается, а не генерируется заново
+type ulidGenerator struct {
+	mu            sync.Mutex
+	lastTimestamp uint64
+	entropyHigh   uint16
+	entropyLow    uint64
+}
+
+var (
+	globalULIDGenerator = &ulidGenerator{}
+
+	// ulidIDsEnabled switches entity IDs to ULID format
+	// ulidIDsEnabled переключает ID сущностей на формат ULID
+	ulidIDsEnabled atomic.Bool
+)
+
+// SetULIDIDsEnabled switches new process instance, token and message IDs to ULID format
+// Переключает ID новых экземпляров процессов, токенов и сообщений на формат ULID
+func SetULIDIDsEnabled(enabled bool) {
+	ulidIDsEnabled.Store(enabled)
+}
+
+// GenerateULID generates lexicographically sortable monotonic ULID
+// Генерирует лексикографически сортируемый монотонный ULID
+func GenerateULID() string {
+	return globalULIDGenerator.next()
+}
+
+// GenerateSortableID generates ULID when enabled by configuration, GenerateID format otherwise
+// Генерирует ULID, если включено конфигурацией, иначе ID формата GenerateID
+func GenerateSortableID() string {
+	if ulidIDsEnabled.Load() {
+		return GenerateULID()
+	}
+	return GenerateID()
+}
+
+// next returns next ULID, never going back when clock moves backwards
+// Возвращает следующий ULID, не уходя назад при переводе часов
+func (g *ulidGenerator) next() string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	timestamp := uint64(time.Now().UnixMilli())
+	if timestamp > ulidMaxTimestamp {
+		timestamp = ulidMaxTimestamp
+	}
+
+	if timestamp <= g.lastTimestamp {
+		// Same millisecond or clock moved back: increment entropy of last ULID
+		// Та же миллисекунда или часы ушли назад: увеличиваем энтропию последнего ULID
+		timestamp = g.lastTimestamp
+		g.entropyLow++
+		if g.entropyLow == 0 {
+			g.entropyHigh++
+			if g.entropyHigh == 0 {
+				// Entropy exhausted: borrow next millisecond
+				// Энтропия исчерпана: занимаем следующую миллисекунду
+				timestamp++
+				g.randomizeEntropy()
+			}
+		}
+	} else {
+		g.randomizeEntropy()
+	}
+	g.lastTimestamp = timestamp
+
+	return encodeULID(timestamp, g.entropyHigh, g.entropyLow)
+}
+
+// randomizeEntropy fills entropy with random bits
+// Top bit is cleared so increments within millisecond do not overflow
+// Заполняет энтропию случайными битами
+// Старший бит сбрасывается, чтобы увеличения в пределах миллисекунды не переполнялись
+func (g *ulidGenerator) randomizeEntropy() {
+	var buf [10]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		// Fallback to time-based entropy if crypto/rand fails
+		// Фоллбэк на энтропию от времени если crypto/rand не работает
+		binary.BigEndian.PutUint64(buf[2:], uint64(time.Now().UnixNano()))
+	}
+	g.entropyHigh = binary.BigEndian.Uint16(buf[:2]) & 0x7fff
+	g.entropyLow = binary.BigEndian.Uint64(buf[2:])
+}
+
+// encodeULID encodes timestamp and entropy into 26 character Crockford base32 string
+// Кодирует время и энтропию в строку Crockford base32 из 26 символов
+func encodeULID(timestamp uint64, entropyHigh uint16, entropyLow uint64) string {
+	var id [16]byte
+	id[0] = byte(timestamp >> 40)
+	id[1] = byte(timestamp >> 32)
+	id[2] = byte(timestamp >> 24)
+	id[3] = byte(timestamp >> 16)
+	id[4] = byte(timestamp >> 8)
+	id[5] = byte(timestamp)
+	binary.BigEndian.PutUint16(id[6:8], entropyHigh)
+	binary.BigEndian.PutUint64(id[8:], entropyLow)
+
+	// 128 bits are encoded as 26 groups of 5 bits, first group holds top 3 bits
+	// 128 бит кодируются как 26 групп по 5 бит, первая группа содержит старшие 3 бита
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+
+	var out [ulidLength]byte
+	for i := ulidLength - 1; i >= 0; i-- {
+		out[i] = ulidEncoding[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+
+	return string(out[:])
+}
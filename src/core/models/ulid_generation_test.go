@@ -0,0 +1,123 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGenerateULIDConcurrentMonotonic(t *testing.T) {
+	const goroutines, perGoroutine = 16, 2000
+
+	results := make([][]string, goroutines)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			ids := make([]string, perGoroutine)
+			for i := range ids {
+				ids[i] = GenerateULID()
+			}
+			results[g] = ids
+		}(g)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool, goroutines*perGoroutine)
+	for g, ids := range results {
+		for i, id := range ids {
+			if len(id) != ulidLength || strings.Trim(id, ulidEncoding) != "" {
+				t.Fatalf("malformed ULID %q", id)
+			}
+			if seen[id] {
+				t.Fatalf("duplicate ULID %s", id)
+			}
+			seen[id] = true
+
+			// Generator is shared, so every caller observes increasing IDs
+			// Генератор общий, поэтому каждый вызывающий видит возрастающие ID
+			if i > 0 && ids[i-1] >= id {
+				t.Fatalf("goroutine %d: ULID %s not after %s", g, id, ids[i-1])
+			}
+		}
+	}
+}
+
+func TestULIDGeneratorMonotonicWhenClockMovesBack(t *testing.T) {
+	// Last ULID is an hour ahead of clock, as after clock was set back
+	// Последний ULID на час впереди часов, как после перевода часов назад
+	future := uint64(time.Now().Add(time.Hour).UnixMilli())
+	g := &ulidGenerator{lastTimestamp: future, entropyLow: 41}
+	previous := encodeULID(future, 0, 41)
+
+	for i := 0; i < 100; i++ {
+		id := g.next()
+		if id <= previous {
+			t.Fatalf("ULID %s not after %s", id, previous)
+		}
+		previous = id
+	}
+	if g.lastTimestamp != future {
+		t.Errorf("timestamp moved to %d, want %d", g.lastTimestamp, future)
+	}
+}
+
+func TestULIDGeneratorBorrowsMillisecondOnEntropyOverflow(t *testing.T) {
+	future := uint64(time.Now().Add(time.Hour).UnixMilli())
+	g := &ulidGenerator{lastTimestamp: future, entropyHigh: 0xffff, entropyLow: ^uint64(0)}
+	previous := encodeULID(future, 0xffff, ^uint64(0))
+
+	id := g.next()
+	if id <= previous {
+		t.Fatalf("ULID %s after entropy overflow not after %s", id, previous)
+	}
+	if g.lastTimestamp != future+1 {
+		t.Errorf("timestamp %d, want borrowed %d", g.lastTimestamp, future+1)
+	}
+}
+
+func TestEncodeULID(t *testing.T) {
+	tests := []struct {
+		name        string
+		timestamp   uint64
+		entropyHigh uint16
+		entropyLow  uint64
+		want        string
+	}{
+		{"zero", 0, 0, 0, "00000000000000000000000000"},
+		{"entropy one", 0, 0, 1, "00000000000000000000000001"},
+		{"timestamp one", 1, 0, 0, "00000000010000000000000000"},
+		{"max", ulidMaxTimestamp, 0xffff, ^uint64(0), "7ZZZZZZZZZZZZZZZZZZZZZZZZZ"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := encodeULID(tt.timestamp, tt.entropyHigh, tt.entropyLow); got != tt.want {
+				t.Errorf("encodeULID = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func BenchmarkGenerateULID(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		GenerateULID()
+	}
+}
+
+func BenchmarkGenerateULIDParallel(b *testing.B) {
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			GenerateULID()
+		}
+	})
+}
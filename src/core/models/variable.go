@@ -0,0 +1,281 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+)
+
+// VariableType represents stored variable value type
+// Представляет тип сохраненного значения переменной
+type VariableType string
+
+const (
+	VariableTypeNull    VariableType = "null"
+	VariableTypeString  VariableType = "string"
+	VariableTypeInteger VariableType = "integer"
+	VariableTypeNumber  VariableType = "number"
+	VariableTypeBoolean VariableType = "boolean"
+	VariableTypeObject  VariableType = "object"
+	VariableTypeArray   VariableType = "array"
+)
+
+// Variable is typed representation of variable value used at storage boundary
+// Keeps integers as int64 so they survive JSON round-trip without float64 coercion
+// Типизированное представление значения переменной на границе storage
+type Variable struct {
+	Type      VariableType        `json:"type"`
+	StringVal *string             `json:"string,omitempty"`
+	IntVal    *int64              `json:"int,omitempty"`
+	NumberVal *float64            `json:"number,omitempty"`
+	BoolVal   *bool               `json:"bool,omitempty"`
+	ObjectVal map[string]Variable `json:"object,omitempty"`
+	ArrayVal  []Variable          `json:"array,omitempty"`
+}
+
+// NewVariable converts untyped value into typed variable
+// Конвертирует нетипизированное значение в типизированную переменную
+func NewVariable(value interface{}) Variable {
+	switch v := value.(type) {
+	case nil:
+		return Variable{Type: VariableTypeNull}
+	case string:
+		return Variable{Type: VariableTypeString, StringVal: &v}
+	case bool:
+		return Variable{Type: VariableTypeBoolean, BoolVal: &v}
+	case int:
+		return newIntVariable(int64(v))
+	case int8:
+		return newIntVariable(int64(v))
+	case int16:
+		return newIntVariable(int64(v))
+	case int32:
+		return newIntVariable(int64(v))
+	case int64:
+		return newIntVariable(v)
+	case uint8:
+		return newIntVariable(int64(v))
+	case uint16:
+		return newIntVariable(int64(v))
+	case uint32:
+		return newIntVariable(int64(v))
+	case uint:
+		return newUintVariable(uint64(v))
+	case uint64:
+		return newUintVariable(v)
+	case float32:
+		return newNumberVariable(float64(v))
+	case float64:
+		return newNumberVariable(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return newIntVariable(i)
+		}
+		if f, err := v.Float64(); err == nil {
+			return newNumberVariable(f)
+		}
+		s := v.String()
+		return Variable{Type: VariableTypeString, StringVal: &s}
+	case map[string]interface{}:
+		object := make(map[string]Variable, len(v))
+		for key, item := range v {
+			object[key] = NewVariable(item)
+		}
+		return Variable{Type: VariableTypeObject, ObjectVal: object}
+	case []interface{}:
+		array := make([]Variable, len(v))
+		for i, item := range v {
+			array[i] = NewVariable(item)
+		}
+		return Variable{Type: VariableTypeArray, ArrayVal: array}
+	default:
+		// Named maps, structs and typed slices go through JSON once
+		// Именованные карты, структуры и типизированные срезы проходят через JSON
+		data, err := json.Marshal(v)
+		if err != nil {
+			s := ""
+			return Variable{Type: VariableTypeString, StringVal: &s}
+		}
+		var decoded interface{}
+		if err := decodeUsingNumber(data, &decoded); err != nil {
+			s := string(data)
+			return Variable{Type: VariableTypeString, StringVal: &s}
+		}
+		return NewVariable(decoded)
+	}
+}
+
+// Value converts typed variable back to plain Go value
+// Integers are returned as int64, other numbers as float64
+// Конвертирует типизированную переменную обратно в обычное значение Go
+func (v Variable) Value() interface{} {
+	switch v.Type {
+	case VariableTypeString:
+		if v.StringVal != nil {
+			return *v.StringVal
+		}
+		return ""
+	case VariableTypeInteger:
+		if v.IntVal != nil {
+			return *v.IntVal
+		}
+		return int64(0)
+	case VariableTypeNumber:
+		if v.NumberVal != nil {
+			return *v.NumberVal
+		}
+		return float64(0)
+	case VariableTypeBoolean:
+		return v.BoolVal != nil && *v.BoolVal
+	case VariableTypeObject:
+		object := make(map[string]interface{}, len(v.ObjectVal))
+		for key, item := range v.ObjectVal {
+			object[key] = item.Value()
+		}
+		return object
+	case VariableTypeArray:
+		array := make([]interface{}, len(v.ArrayVal))
+		for i, item := range v.ArrayVal {
+			array[i] = item.Value()
+		}
+		return array
+	default:
+		return nil
+	}
+}
+
+// TypedVariables converts variables map into typed form
+// Конвертирует карту переменных в типизированную форму
+func TypedVariables(variables map[string]interface{}) map[string]Variable {
+	if variables == nil {
+		return nil
+	}
+	typed := make(map[string]Variable, len(variables))
+	for key, value := range variables {
+		typed[key] = NewVariable(value)
+	}
+	return typed
+}
+
+// UntypedVariables converts typed variables back into plain map
+// Конвертирует типизированные переменные обратно в обычную карту
+func UntypedVariables(typed map[string]Variable) map[string]interface{} {
+	if typed == nil {
+		return nil
+	}
+	variables := make(map[string]interface{}, len(typed))
+	for key, value := range typed {
+		variables[key] = value.Value()
+	}
+	return variables
+}
+
+// NormalizeVariables replaces json.Number values with int64 or float64
+// Заменяет значения json.Number на int64 или float64
+func NormalizeVariables(variables map[string]interface{}) map[string]interface{} {
+	return UntypedVariables(TypedVariables(variables))
+}
+
+// NormalizeValue replaces json.Number values in single value with int64 or float64
+// Whole numbers keep integer form in responses, non-finite numbers become strings
+// Заменяет значения json.Number в отдельном значении на int64 или float64
+// Целые числа сохраняют целочисленную форму в ответах, бесконечности и NaN становятся строками
+func NormalizeValue(value interface{}) interface{} {
+	return NewVariable(value).Value()
+}
+
+// DecodeVariables decodes JSON object keeping integer precision
+// Декодирует JSON объект, сохраняя точность целых чисел
+func DecodeVariables(data []byte) (map[string]interface{}, error) {
+	var variables map[string]interface{}
+	if err := decodeUsingNumber(data, &variables); err != nil {
+		return nil, err
+	}
+	return NormalizeVariables(variables), nil
+}
+
+// DecodeStoredVariables decodes variables persisted in typed or legacy form
+// Декодирует переменные, сохраненные в типизированной или старой форме
+func DecodeStoredVariables(data json.RawMessage, typed bool) (map[string]interface{}, error) {
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+	if !typed {
+		return DecodeVariables(data)
+	}
+
+	var typedVariables map[string]Variable
+	if err := json.Unmarshal(data, &typedVariables); err != nil {
+		return nil, err
+	}
+	return UntypedVariables(typedVariables), nil
+}
+
+// UnmarshalPreservingNumbers decodes JSON leaving numbers in interface{} fields as json.Number
+// Used for internal component messages so variables are not coerced to float64 before typed decoding
+// Декодирует JSON, оставляя числа в полях interface{} как json.Number
+func UnmarshalPreservingNumbers(data []byte, target interface{}) error {
+	return decodeUsingNumber(data, target)
+}
+
+// VariableMap is variables map decoded from JSON without float64 coercion
+// Карта переменных, декодируемая из JSON без приведения к float64
+type VariableMap map[string]interface{}
+
+// UnmarshalJSON decodes variables keeping integer precision
+// Декодирует переменные, сохраняя точность целых чисел
+func (m *VariableMap) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*m = nil
+		return nil
+	}
+	variables, err := DecodeVariables(data)
+	if err != nil {
+		return err
+	}
+	*m = variables
+	return nil
+}
+
+// newIntVariable creates integer variable
+// Создает целочисленную переменную
+func newIntVariable(i int64) Variable {
+	return Variable{Type: VariableTypeInteger, IntVal: &i}
+}
+
+// newUintVariable creates integer variable or number if value exceeds int64
+// Создает целочисленную переменную или число, если значение больше int64
+func newUintVariable(u uint64) Variable {
+	if u > math.MaxInt64 {
+		return newNumberVariable(float64(u))
+	}
+	return newIntVariable(int64(u))
+}
+
+// newNumberVariable creates floating point variable
+// Non-finite values are not representable in JSON and stored as strings
+// Создает переменную с плавающей точкой
+func newNumberVariable(f float64) Variable {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		s := strconv.FormatFloat(f, 'g', -1, 64)
+		return Variable{Type: VariableTypeString, StringVal: &s}
+	}
+	return Variable{Type: VariableTypeNumber, NumberVal: &f}
+}
+
+// decodeUsingNumber decodes JSON with UseNumber enabled
+// Декодирует JSON с включенным UseNumber
+func decodeUsingNumber(data []byte, target interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(target)
+}
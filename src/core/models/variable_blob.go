@@ -0,0 +1,45 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+// Variable blob reference keys
+// Ключи ссылки на blob переменной
+const (
+	VariableBlobRefKey  = "$blob_ref"
+	VariableBlobSizeKey = "$blob_size"
+)
+
+// NewVariableBlobRef creates variable value referencing offloaded blob
+// Создает значение переменной, ссылающееся на вынесенный blob
+func NewVariableBlobRef(blobID string, size int64) map[string]interface{} {
+	return map[string]interface{}{
+		VariableBlobRefKey:  blobID,
+		VariableBlobSizeKey: size,
+	}
+}
+
+// ParseVariableBlobRef extracts blob ID if value is blob reference
+// Извлекает ID blob'а если значение является ссылкой на blob
+func ParseVariableBlobRef(value interface{}) (string, bool) {
+	ref, ok := value.(map[string]interface{})
+	if !ok || len(ref) != 2 {
+		return "", false
+	}
+
+	blobID, ok := ref[VariableBlobRefKey].(string)
+	if !ok || blobID == "" {
+		return "", false
+	}
+
+	if _, ok := ref[VariableBlobSizeKey]; !ok {
+		return "", false
+	}
+
+	return blobID, true
+}
@@ -0,0 +1,71 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// VariableHistoryEntry is value variable of process instance got at one moment
+// Entry is written when instance or token save changes variable, empty element means
+// value was set on instance: at start, by variables patch or instance modification
+// Значение, которое переменная экземпляра процесса получила в определенный момент
+// Запись пишется, когда сохранение экземпляра или токена меняет переменную, пустой элемент означает,
+// что значение задано экземпляру: при запуске, патчем переменных или модификацией экземпляра
+type VariableHistoryEntry struct {
+	ProcessInstanceID string      `json:"process_instance_id"`
+	Name              string      `json:"name"`
+	Value             interface{} `json:"-"`                 // Stored in variables field to be encrypted at rest
+	Removed           bool        `json:"removed,omitempty"` // Variable was deleted, value is nil
+	ElementID         string      `json:"element_id,omitempty"`
+	TokenID           string      `json:"token_id,omitempty"`
+	JobID             string      `json:"job_id,omitempty"` // Job whose completion set value
+	SetAt             time.Time   `json:"set_at"`
+}
+
+// ToJSON serializes entry for storage, value is kept under its name in typed variables
+// so sensitive variables are encrypted as in token and instance records
+// Сериализует запись для storage, значение хранится под своим именем в типизированных переменных,
+// поэтому чувствительные переменные шифруются так же, как в записях токенов и экземпляров
+func (e *VariableHistoryEntry) ToJSON() ([]byte, error) {
+	type plain VariableHistoryEntry
+	return json.Marshal(struct {
+		*plain
+		Variables map[string]Variable `json:"variables"`
+	}{(*plain)(e), map[string]Variable{e.Name: NewVariable(e.Value)}})
+}
+
+// FromJSON restores entry from storage
+// Восстанавливает запись из storage
+func (e *VariableHistoryEntry) FromJSON(data []byte) error {
+	type plain VariableHistoryEntry
+	record := struct {
+		*plain
+		Variables map[string]Variable `json:"variables"`
+	}{plain: (*plain)(e)}
+	if err := json.Unmarshal(data, &record); err != nil {
+		return err
+	}
+
+	if value, ok := record.Variables[e.Name]; ok {
+		e.Value = value.Value()
+	}
+	return nil
+}
+
+// VariableHistoryQuery selects page of values of one variable of process instance
+// Выбирает страницу значений одной переменной экземпляра процесса
+type VariableHistoryQuery struct {
+	InstanceID string
+	Name       string
+
+	Offset int
+	Limit  int // 0 returns all values from offset
+}
@@ -0,0 +1,55 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import "errors"
+
+// MergePatchContentType is media type of JSON merge patch (RFC 7386)
+// Media type JSON merge patch (RFC 7386)
+const MergePatchContentType = "application/merge-patch+json"
+
+// ErrProcessInstanceFinished is returned when variables of finished instance are modified
+// Возвращается при попытке изменить переменные завершенного экземпляра
+var ErrProcessInstanceFinished = errors.New("process instance is finished")
+
+// ApplyMergePatch applies JSON merge patch to variables and returns new map
+// Null values delete keys, objects merge recursively, other values replace
+// Source map is not modified
+// Применяет JSON merge patch к переменным и возвращает новую карту
+// Null удаляет ключ, объекты сливаются рекурсивно, остальные значения заменяются
+// Исходная карта не изменяется
+func ApplyMergePatch(target, patch map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(target)+len(patch))
+	for key, value := range target {
+		result[key] = value
+	}
+
+	for key, patchValue := range patch {
+		if patchValue == nil {
+			delete(result, key)
+			continue
+		}
+		result[key] = mergePatchValue(result[key], patchValue)
+	}
+	return result
+}
+
+// mergePatchValue merges single patch value into target value
+// Сливает одно значение патча со значением цели
+func mergePatchValue(target, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	// Non-object target is replaced by empty object before merge
+	// Цель, не являющаяся объектом, заменяется пустым объектом перед слиянием
+	targetObject, _ := target.(map[string]interface{})
+	return ApplyMergePatch(targetObject, patchObject)
+}
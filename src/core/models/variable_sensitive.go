@@ -0,0 +1,93 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// SensitiveVariableMask replaces values of sensitive variables for unauthorized readers
+// Заменяет значения чувствительных переменных для неавторизованных читателей
+const SensitiveVariableMask = "***"
+
+var (
+	// sensitivePatterns holds case-insensitive regexps of sensitive variable names
+	// sensitivePatterns содержит регистронезависимые regexp имен чувствительных переменных
+	sensitivePatterns   []*regexp.Regexp
+	sensitivePatternsMu sync.RWMutex
+)
+
+// SetSensitiveVariablePatterns sets case-insensitive regexps of sensitive variable names
+// Устанавливает регистронезависимые regexp имен чувствительных переменных
+func SetSensitiveVariablePatterns(patterns []string) error {
+	compiled, err := CompileSensitiveVariablePatterns(patterns)
+	if err != nil {
+		return err
+	}
+
+	sensitivePatternsMu.Lock()
+	defer sensitivePatternsMu.Unlock()
+	sensitivePatterns = compiled
+	return nil
+}
+
+// CompileSensitiveVariablePatterns compiles variable name patterns as case-insensitive regexps
+// Компилирует шаблоны имен переменных как регистронезависимые regexp
+func CompileSensitiveVariablePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sensitive variable pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// IsSensitiveVariable reports whether variable name matches sensitive patterns
+// Проверяет, соответствует ли имя переменной шаблонам чувствительных переменных
+func IsSensitiveVariable(name string) bool {
+	sensitivePatternsMu.RLock()
+	defer sensitivePatternsMu.RUnlock()
+
+	for _, re := range sensitivePatterns {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskSensitiveVariables returns copy of variables with sensitive values masked
+// Original map is returned when no variable is sensitive
+// Возвращает копию переменных со скрытыми чувствительными значениями
+// Если чувствительных переменных нет, возвращается исходная карта
+func MaskSensitiveVariables(variables map[string]interface{}) map[string]interface{} {
+	var masked map[string]interface{}
+	for name := range variables {
+		if !IsSensitiveVariable(name) {
+			continue
+		}
+		if masked == nil {
+			masked = make(map[string]interface{}, len(variables))
+			for key, value := range variables {
+				masked[key] = value
+			}
+		}
+		masked[name] = SensitiveVariableMask
+	}
+
+	if masked == nil {
+		return variables
+	}
+	return masked
+}
@@ -0,0 +1,38 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package models
+
+import "time"
+
+// Webhook trigger authentication modes
+// Режимы аутентификации webhook триггера
+const (
+	WebhookAuthHMAC  = "hmac"  // HMAC-SHA256 signature of request body
+	WebhookAuthToken = "token" // Shared secret sent as token
+)
+
+// WebhookTrigger binds inbound webhook URL to process start
+// Связывает входящий URL webhook'а с запуском процесса
+type WebhookTrigger struct {
+	ID          string            `json:"id"` // Last segment of hook URL /hooks/{id}
+	Name        string            `json:"name,omitempty"`
+	ProcessKey  string            `json:"process_key"` // "ProcessID" or "ProcessID:version"
+	AuthType    string            `json:"auth_type"`
+	Secret      string            `json:"secret,omitempty"`    // Returned only on creation
+	Variables   map[string]string `json:"variables,omitempty"` // Variable name to "=" FEEL expression or literal
+	RateLimit   int               `json:"rate_limit_per_minute,omitempty"`
+	Async       bool              `json:"async,omitempty"`
+	Enabled     bool              `json:"enabled"`
+	Invocations int64             `json:"invocation_count"`
+	Failures    int64             `json:"failure_count"`
+	Rejections  int64             `json:"rejected_count"`
+	LastInvoked *time.Time        `json:"last_invoked_at,omitempty"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+}
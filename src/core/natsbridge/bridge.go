@@ -0,0 +1,262 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package natsbridge
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"atom-engine/src/core/config"
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+)
+
+// eventsBufferSize bounds engine events waiting for publishing, newer events are dropped when full
+// Ограничивает число событий движка, ожидающих публикации, при переполнении новые события отбрасываются
+const eventsBufferSize = 1024
+
+// reconnectLogEvery limits logging of failed connection attempts during outage
+// Ограничивает логирование неудачных попыток подключения во время сбоя
+const reconnectLogEvery = 30
+
+// MessagesClient is subset of messages component used by bridge
+// Подмножество messages компонента, используемое мостом
+type MessagesClient interface {
+	PublishMessage(
+		ctx context.Context,
+		tenantID, messageName, correlationKey, elementID string,
+		variables map[string]interface{},
+		ttl *time.Duration,
+	) (*models.MessageCorrelationResult, error)
+}
+
+// Bridge publishes engine events to NATS and turns messages received from NATS into engine messages
+// Публикует события движка в NATS и превращает полученные из NATS сообщения в сообщения движка
+type Bridge struct {
+	cfg           config.NATSBridgeConfig
+	messages      MessagesClient
+	tls           *tls.Config
+	credentials   *userCredentials
+	eventTypes    map[string]bool
+	messagePrefix string // Messages subject without wildcard, stripped to get message name
+
+	events chan models.EngineEvent
+
+	connMu sync.RWMutex
+	conn   *connection // Nil while disconnected
+
+	droppedEvents       atomic.Uint64 // Events dropped because buffer was full
+	droppedDisconnected atomic.Uint64 // Events dropped because NATS was unavailable
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewBridge creates bridge from configuration
+// Создает мост из конфигурации
+func NewBridge(cfg config.NATSBridgeConfig, messagesClient MessagesClient) (*Bridge, error) {
+	if len(cfg.Servers) == 0 {
+		return nil, fmt.Errorf("at least one NATS server is required")
+	}
+	for _, server := range cfg.Servers {
+		if _, _, err := parseServerURL(server); err != nil {
+			return nil, err
+		}
+	}
+	if cfg.MessagesSubject != "" && messagesClient == nil {
+		return nil, fmt.Errorf("messages component is required for messages subject")
+	}
+
+	bridge := &Bridge{
+		cfg:      cfg,
+		messages: messagesClient,
+	}
+
+	if cfg.MessagesSubject != "" {
+		prefix, err := messageSubjectPrefix(cfg.MessagesSubject)
+		if err != nil {
+			return nil, err
+		}
+		bridge.messagePrefix = prefix
+	}
+
+	if cfg.TLS.Enabled {
+		tlsConfig, err := newTLSConfig(cfg.TLS)
+		if err != nil {
+			return nil, err
+		}
+		bridge.tls = tlsConfig
+	}
+
+	if cfg.Auth.CredentialsFile != "" {
+		credentials, err := loadCredentials(cfg.Auth.CredentialsFile)
+		if err != nil {
+			return nil, err
+		}
+		bridge.credentials = credentials
+	}
+
+	if len(cfg.EventTypes) > 0 {
+		bridge.eventTypes = make(map[string]bool, len(cfg.EventTypes))
+		for _, eventType := range cfg.EventTypes {
+			bridge.eventTypes[eventType] = true
+		}
+	}
+	return bridge, nil
+}
+
+// Start launches connection and event publishing loops
+// Engine events are accepted right away, events arriving before connection is up are dropped
+// Запускает циклы соединения и публикации событий
+// События движка принимаются сразу, события до установки соединения отбрасываются
+func (b *Bridge) Start() {
+	b.ctx, b.cancel = context.WithCancel(context.Background())
+	b.events = make(chan models.EngineEvent, eventsBufferSize)
+
+	b.wg.Add(2)
+	go b.runConnectionLoop()
+	go b.runEventPublisher()
+
+	logger.Info("NATS bridge started",
+		logger.Any("servers", b.cfg.Servers),
+		logger.String("events_prefix", b.cfg.EventsPrefix),
+		logger.String("messages_subject", b.cfg.MessagesSubject))
+}
+
+// Stop stops loops and closes NATS connection
+// Останавливает циклы и закрывает соединение с NATS
+func (b *Bridge) Stop() error {
+	if b.cancel == nil {
+		return nil
+	}
+	b.cancel()
+
+	// Closing connection unblocks reader waiting for server data
+	// Закрытие соединения разблокирует чтение, ожидающее данные сервера
+	b.connMu.RLock()
+	if b.conn != nil {
+		b.conn.close()
+	}
+	b.connMu.RUnlock()
+
+	b.wg.Wait()
+
+	logger.Info("NATS bridge stopped",
+		logger.Any("dropped_events", b.droppedEvents.Load()),
+		logger.Any("dropped_disconnected", b.droppedDisconnected.Load()))
+	return nil
+}
+
+// runConnectionLoop keeps connection to one of servers, reconnecting after failures until bridge stops
+// Поддерживает соединение с одним из серверов, переподключаясь после сбоев до остановки моста
+func (b *Bridge) runConnectionLoop() {
+	defer b.wg.Done()
+
+	reconnectWait := time.Duration(b.cfg.ReconnectWaitMs) * time.Millisecond
+	failures := 0
+	for attempt := 0; ; attempt++ {
+		server := b.cfg.Servers[attempt%len(b.cfg.Servers)]
+
+		conn, err := b.connect(server)
+		if err != nil {
+			if b.ctx.Err() != nil {
+				return
+			}
+			// Long outage is logged sparsely, every attempt would flood log
+			// Длительный сбой логируется редко, каждая попытка засорила бы лог
+			if failures++; failures == 1 || failures%reconnectLogEvery == 0 {
+				logger.Warn("NATS bridge failed to connect",
+					logger.String("server", server),
+					logger.Int("attempts", failures),
+					logger.String("error", err.Error()))
+			}
+		} else {
+			failures = 0
+			logger.Info("NATS bridge connected", logger.String("server", server))
+			b.setConnection(conn)
+			if b.ctx.Err() != nil {
+				// Stop could miss connection set after it looked
+				// Stop мог не увидеть соединение, установленное после проверки
+				conn.close()
+			}
+
+			err = conn.readLoop(b.handleMessage)
+
+			b.setConnection(nil)
+			conn.close()
+			if b.ctx.Err() != nil {
+				return
+			}
+			logger.Warn("NATS bridge disconnected",
+				logger.String("server", server),
+				logger.String("error", err.Error()))
+		}
+
+		select {
+		case <-b.ctx.Done():
+			return
+		case <-time.After(reconnectWait):
+		}
+	}
+}
+
+// connect dials server, authenticates and subscribes to messages subject
+// Подключается к серверу, проходит аутентификацию и подписывается на тему сообщений
+func (b *Bridge) connect(server string) (*connection, error) {
+	conn, err := dial(b.ctx, server, connectOptions{
+		name:        b.cfg.Name,
+		tls:         b.tls,
+		auth:        b.cfg.Auth,
+		credentials: b.credentials,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if b.cfg.MessagesSubject != "" {
+		if err := conn.subscribe(b.cfg.MessagesSubject, b.cfg.QueueGroup); err != nil {
+			conn.close()
+			return nil, err
+		}
+	}
+	return conn, nil
+}
+
+// setConnection replaces current connection, nil marks bridge disconnected
+// Заменяет текущее соединение, nil означает отключенный мост
+func (b *Bridge) setConnection(conn *connection) {
+	b.connMu.Lock()
+	b.conn = conn
+	b.connMu.Unlock()
+}
+
+// currentConnection returns current connection, nil while disconnected
+// Возвращает текущее соединение, nil при отключении
+func (b *Bridge) currentConnection() *connection {
+	b.connMu.RLock()
+	defer b.connMu.RUnlock()
+	return b.conn
+}
+
+// messageSubjectPrefix validates messages subject and returns its part before trailing wildcard
+// Проверяет тему сообщений и возвращает ее часть до завершающего wildcard
+func messageSubjectPrefix(subject string) (string, error) {
+	for _, wildcard := range []string{".>", ".*"} {
+		if prefix, found := strings.CutSuffix(subject, wildcard); found && prefix != "" {
+			return prefix + ".", nil
+		}
+	}
+	return "", fmt.Errorf("NATS messages subject %q must end with .> or .* wildcard", subject)
+}
@@ -0,0 +1,421 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package natsbridge
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"atom-engine/src/core/config"
+	"atom-engine/src/core/logger"
+)
+
+// NATS client protocol constants
+// Константы клиентского протокола NATS
+const (
+	dialTimeout   = 10 * time.Second
+	writeTimeout  = 5 * time.Second  // Write stuck longer than this breaks connection
+	pingInterval  = 30 * time.Second // Client PING keeps half-open connections detectable
+	maxPingsOut   = 2                // Unanswered PINGs before connection is considered dead
+	maxLineLength = 64 * 1024        // Longest protocol line accepted from server
+	defaultPort   = "4222"
+	messagesSID   = "1" // Bridge holds single subscription
+)
+
+// serverInfo is subset of INFO sent by server on connect
+// Подмножество INFO, отправляемого сервером при подключении
+type serverInfo struct {
+	ServerID     string `json:"server_id"`
+	TLSRequired  bool   `json:"tls_required"`
+	AuthRequired bool   `json:"auth_required"`
+	MaxPayload   int64  `json:"max_payload"`
+	Nonce        string `json:"nonce"`
+}
+
+// connectRequest is CONNECT sent by client after INFO
+// CONNECT, отправляемый клиентом после INFO
+type connectRequest struct {
+	Verbose      bool   `json:"verbose"`
+	Pedantic     bool   `json:"pedantic"`
+	TLSRequired  bool   `json:"tls_required"`
+	Name         string `json:"name,omitempty"`
+	Lang         string `json:"lang"`
+	Version      string `json:"version"`
+	Protocol     int    `json:"protocol"`
+	User         string `json:"user,omitempty"`
+	Pass         string `json:"pass,omitempty"`
+	AuthToken    string `json:"auth_token,omitempty"`
+	JWT          string `json:"jwt,omitempty"`
+	Signature    string `json:"sig,omitempty"`
+	Headers      bool   `json:"headers"`
+	NoResponders bool   `json:"no_responders"`
+}
+
+// connectOptions holds settings of single connection attempt
+// Настройки одной попытки подключения
+type connectOptions struct {
+	name        string
+	tls         *tls.Config
+	auth        config.NATSAuthConfig
+	credentials *userCredentials
+}
+
+// messageHandler receives message delivered to subscription
+// Получает сообщение, доставленное в подписку
+type messageHandler func(subject, reply string, payload []byte)
+
+// connection is single NATS client connection speaking core protocol
+// Одно клиентское соединение NATS по базовому протоколу
+type connection struct {
+	netConn    net.Conn
+	reader     *bufio.Reader
+	maxPayload int64
+
+	writeMu sync.Mutex // Serializes protocol writes of publisher and reader
+	writer  *bufio.Writer
+
+	pingsOut  atomic.Int32
+	closeOnce sync.Once
+}
+
+// parseServerURL returns host:port and TLS flag of server URL
+// Возвращает host:port и признак TLS адреса сервера
+func parseServerURL(server string) (string, bool, error) {
+	if !strings.Contains(server, "://") {
+		server = "nats://" + server
+	}
+	parsed, err := url.Parse(server)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid NATS server URL %q: %w", server, err)
+	}
+	if parsed.Scheme != "nats" && parsed.Scheme != "tls" {
+		return "", false, fmt.Errorf("unsupported NATS server URL scheme %q", parsed.Scheme)
+	}
+	if parsed.Hostname() == "" {
+		return "", false, fmt.Errorf("NATS server URL %q has no host", server)
+	}
+
+	port := parsed.Port()
+	if port == "" {
+		port = defaultPort
+	}
+	return net.JoinHostPort(parsed.Hostname(), port), parsed.Scheme == "tls", nil
+}
+
+// dial connects to server, upgrades to TLS when needed and completes CONNECT handshake
+// Подключается к серверу, переходит на TLS при необходимости и выполняет CONNECT
+func dial(ctx context.Context, server string, options connectOptions) (*connection, error) {
+	address, tlsScheme, err := parseServerURL(server)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := net.Dialer{Timeout: dialTimeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial NATS server: %w", err)
+	}
+
+	conn, err := handshake(netConn, address, tlsScheme, options)
+	if err != nil {
+		_ = netConn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// handshake reads INFO, sends CONNECT and waits for PONG confirming credentials
+// Читает INFO, отправляет CONNECT и ждет PONG, подтверждающий учетные данные
+func handshake(netConn net.Conn, address string, tlsScheme bool, options connectOptions) (*connection, error) {
+	_ = netConn.SetDeadline(time.Now().Add(dialTimeout))
+
+	reader := bufio.NewReaderSize(netConn, 32*1024)
+	line, err := readLine(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NATS server INFO: %w", err)
+	}
+	infoJSON, found := strings.CutPrefix(line, "INFO ")
+	if !found {
+		return nil, fmt.Errorf("unexpected NATS server greeting: %s", line)
+	}
+	var info serverInfo
+	if err := json.Unmarshal([]byte(infoJSON), &info); err != nil {
+		return nil, fmt.Errorf("failed to parse NATS server INFO: %w", err)
+	}
+
+	useTLS := options.tls != nil || tlsScheme || info.TLSRequired
+	if useTLS {
+		tlsConfig := options.tls
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		tlsConfig = tlsConfig.Clone()
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName, _, _ = net.SplitHostPort(address)
+		}
+
+		tlsConn := tls.Client(netConn, tlsConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			return nil, fmt.Errorf("NATS TLS handshake failed: %w", err)
+		}
+		netConn = tlsConn
+		reader = bufio.NewReaderSize(netConn, 32*1024)
+	}
+
+	request, err := newConnectRequest(options, info, useTLS)
+	if err != nil {
+		return nil, err
+	}
+	requestJSON, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal NATS CONNECT: %w", err)
+	}
+
+	writer := bufio.NewWriter(netConn)
+	if _, err := fmt.Fprintf(writer, "CONNECT %s\r\nPING\r\n", requestJSON); err != nil {
+		return nil, fmt.Errorf("failed to send NATS CONNECT: %w", err)
+	}
+	if err := writer.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to send NATS CONNECT: %w", err)
+	}
+
+	for {
+		line, err := readLine(reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read NATS CONNECT response: %w", err)
+		}
+		switch {
+		case line == "PONG":
+			_ = netConn.SetDeadline(time.Time{})
+			return &connection{
+				netConn:    netConn,
+				reader:     reader,
+				writer:     writer,
+				maxPayload: info.MaxPayload,
+			}, nil
+		case strings.HasPrefix(line, "-ERR"):
+			return nil, fmt.Errorf("NATS server rejected connection: %s", serverError(line))
+		case line == "+OK", strings.HasPrefix(line, "INFO "):
+			continue
+		default:
+			return nil, fmt.Errorf("unexpected NATS CONNECT response: %s", line)
+		}
+	}
+}
+
+// newConnectRequest fills CONNECT with configured credentials, creds file wins over others
+// Заполняет CONNECT настроенными учетными данными, файл creds имеет приоритет
+func newConnectRequest(options connectOptions, info serverInfo, useTLS bool) (*connectRequest, error) {
+	request := &connectRequest{
+		TLSRequired: useTLS,
+		Name:        options.name,
+		Lang:        "go",
+		Version:     "atom-engine",
+		Protocol:    1,
+	}
+
+	switch {
+	case options.credentials != nil:
+		if info.Nonce == "" {
+			return nil, fmt.Errorf("NATS server sent no nonce to sign for credentials file")
+		}
+		request.JWT = options.credentials.jwt
+		request.Signature = options.credentials.sign([]byte(info.Nonce))
+	case options.auth.Token != "":
+		request.AuthToken = options.auth.Token
+	case options.auth.Username != "":
+		request.User = options.auth.Username
+		request.Pass = options.auth.Password
+	}
+	return request, nil
+}
+
+// subscribe subscribes to subject, queue group spreads messages across engine nodes
+// Подписывается на тему, queue group распределяет сообщения между узлами движка
+func (c *connection) subscribe(subject, queueGroup string) error {
+	if queueGroup != "" {
+		return c.write(fmt.Sprintf("SUB %s %s %s\r\n", subject, queueGroup, messagesSID))
+	}
+	return c.write(fmt.Sprintf("SUB %s %s\r\n", subject, messagesSID))
+}
+
+// publish sends payload to subject
+// Отправляет данные в тему
+func (c *connection) publish(subject string, payload []byte) error {
+	if c.maxPayload > 0 && int64(len(payload)) > c.maxPayload {
+		return fmt.Errorf("payload of %d bytes exceeds NATS max payload %d", len(payload), c.maxPayload)
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	_ = c.netConn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := fmt.Fprintf(c.writer, "PUB %s %d\r\n", subject, len(payload)); err != nil {
+		return err
+	}
+	if _, err := c.writer.Write(payload); err != nil {
+		return err
+	}
+	if _, err := c.writer.WriteString("\r\n"); err != nil {
+		return err
+	}
+	return c.writer.Flush()
+}
+
+// write sends raw protocol line
+// Отправляет строку протокола
+func (c *connection) write(line string) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	_ = c.netConn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := c.writer.WriteString(line); err != nil {
+		return err
+	}
+	return c.writer.Flush()
+}
+
+// readLoop reads server protocol until connection fails, messages are passed to handler
+// Читает протокол сервера до сбоя соединения, сообщения передаются обработчику
+func (c *connection) readLoop(handler messageHandler) error {
+	done := make(chan struct{})
+	defer close(done)
+	go c.runPinger(done)
+
+	for {
+		line, err := readLine(c.reader)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case strings.HasPrefix(line, "MSG "):
+			subject, reply, payload, err := c.readMessage(line)
+			if err != nil {
+				return err
+			}
+			handler(subject, reply, payload)
+		case line == "PING":
+			if err := c.write("PONG\r\n"); err != nil {
+				return err
+			}
+		case line == "PONG":
+			c.pingsOut.Store(0)
+		case strings.HasPrefix(line, "-ERR"):
+			// Server closes connection after most errors, permission violations keep it open
+			// Сервер закрывает соединение после большинства ошибок, нарушения прав оставляют его открытым
+			message := serverError(line)
+			if strings.HasPrefix(strings.ToLower(message), "permissions violation") {
+				logger.Warn("NATS server rejected operation", logger.String("error", message))
+				continue
+			}
+			return fmt.Errorf("NATS server error: %s", message)
+		case line == "+OK", strings.HasPrefix(line, "INFO "):
+			continue
+		default:
+			return fmt.Errorf("unexpected NATS protocol line: %s", line)
+		}
+	}
+}
+
+// readMessage parses MSG line and reads payload following it
+// Разбирает строку MSG и читает следующие за ней данные
+func (c *connection) readMessage(line string) (string, string, []byte, error) {
+	// MSG <subject> <sid> [reply-to] <#bytes>
+	fields := strings.Fields(line)
+	var subject, reply, size string
+	switch len(fields) {
+	case 4:
+		subject, size = fields[1], fields[3]
+	case 5:
+		subject, reply, size = fields[1], fields[3], fields[4]
+	default:
+		return "", "", nil, fmt.Errorf("malformed NATS MSG line: %s", line)
+	}
+
+	length, err := strconv.Atoi(size)
+	if err != nil || length < 0 {
+		return "", "", nil, fmt.Errorf("malformed NATS MSG size: %s", line)
+	}
+
+	payload := make([]byte, length+2) // Payload is followed by CRLF
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return "", "", nil, err
+	}
+	return subject, reply, payload[:length], nil
+}
+
+// runPinger sends PING periodically and breaks connection when server stops answering
+// Периодически отправляет PING и разрывает соединение, когда сервер перестает отвечать
+func (c *connection) runPinger(done <-chan struct{}) {
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if c.pingsOut.Add(1) > maxPingsOut {
+				c.close()
+				return
+			}
+			if err := c.write("PING\r\n"); err != nil {
+				c.close()
+				return
+			}
+		}
+	}
+}
+
+// close closes underlying network connection, safe to call repeatedly
+// Закрывает сетевое соединение, допускает повторный вызов
+func (c *connection) close() {
+	c.closeOnce.Do(func() {
+		_ = c.netConn.Close()
+	})
+}
+
+// readLine reads single CRLF terminated protocol line
+// Читает одну строку протокола, завершенную CRLF
+func readLine(reader *bufio.Reader) (string, error) {
+	var line []byte
+	for {
+		chunk, isPrefix, err := reader.ReadLine()
+		if err != nil {
+			return "", err
+		}
+		line = append(line, chunk...)
+		if len(line) > maxLineLength {
+			return "", errors.New("NATS protocol line too long")
+		}
+		if !isPrefix {
+			return string(line), nil
+		}
+	}
+}
+
+// serverError extracts quoted text of -ERR line
+// Извлекает текст в кавычках из строки -ERR
+func serverError(line string) string {
+	message := strings.TrimSpace(strings.TrimPrefix(line, "-ERR"))
+	return strings.Trim(message, "'")
+}
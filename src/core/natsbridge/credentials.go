@@ -0,0 +1,150 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package natsbridge
+
+import (
+	"crypto/ed25519"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"regexp"
+
+	"atom-engine/src/core/config"
+)
+
+// nkey prefix bytes, seed encodes both seed marker and public key type
+// Префиксные байты nkey, seed кодирует маркер seed и тип публичного ключа
+const (
+	nkeyPrefixSeed = 18 << 3 // 'S'
+	nkeyPrefixUser = 20 << 3 // 'U'
+)
+
+// credentialsBlock matches decorated blocks of creds file, first is user JWT, second is nkey seed
+// Находит декорированные блоки файла creds, первый - JWT пользователя, второй - nkey seed
+var credentialsBlock = regexp.MustCompile(
+	`\s*(?:(?:[-]{3,}.*[-]{3,}\r?\n)([\w\-.=]+)(?:\r?\n[-]{3,}.*[-]{3,}(\r?\n|\z)))`)
+
+// nkeyEncoding is base32 alphabet of nkeys without padding
+// Алфавит base32 nkeys без выравнивания
+var nkeyEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// userCredentials holds user JWT and private key signing server nonce
+// Содержит JWT пользователя и закрытый ключ для подписи nonce сервера
+type userCredentials struct {
+	jwt        string
+	privateKey ed25519.PrivateKey
+}
+
+// loadCredentials reads creds file generated by nsc
+// Читает файл creds, созданный nsc
+func loadCredentials(path string) (*userCredentials, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read NATS credentials file: %w", err)
+	}
+
+	blocks := credentialsBlock.FindAllSubmatch(contents, -1)
+	if len(blocks) < 2 {
+		return nil, fmt.Errorf("NATS credentials file %s must contain user JWT and nkey seed", path)
+	}
+
+	privateKey, err := decodeUserSeed(string(blocks[1][1]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid nkey seed in NATS credentials file %s: %w", path, err)
+	}
+	return &userCredentials{
+		jwt:        string(blocks[0][1]),
+		privateKey: privateKey,
+	}, nil
+}
+
+// sign signs server nonce, signature is sent in CONNECT
+// Подписывает nonce сервера, подпись отправляется в CONNECT
+func (c *userCredentials) sign(nonce []byte) string {
+	signature := ed25519.Sign(c.privateKey, nonce)
+	return base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// decodeUserSeed decodes user nkey seed and checks its prefix and checksum
+// Декодирует nkey seed пользователя и проверяет его префикс и контрольную сумму
+func decodeUserSeed(seed string) (ed25519.PrivateKey, error) {
+	raw, err := nkeyEncoding.DecodeString(seed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode seed: %w", err)
+	}
+	if len(raw) != 2+ed25519.SeedSize+2 {
+		return nil, fmt.Errorf("unexpected seed length %d", len(raw))
+	}
+
+	payload, checksum := raw[:len(raw)-2], binary.LittleEndian.Uint16(raw[len(raw)-2:])
+	if crc16(payload) != checksum {
+		return nil, fmt.Errorf("seed checksum mismatch")
+	}
+
+	if raw[0]&0xf8 != nkeyPrefixSeed {
+		return nil, fmt.Errorf("value is not nkey seed")
+	}
+	if keyType := (raw[0]&0x07)<<5 | (raw[1]&0xf8)>>3; keyType != nkeyPrefixUser {
+		return nil, fmt.Errorf("seed is not user seed")
+	}
+
+	return ed25519.NewKeyFromSeed(payload[2:]), nil
+}
+
+// crc16 computes CRC-16/XMODEM checksum used by nkeys
+// Вычисляет контрольную сумму CRC-16/XMODEM, используемую nkeys
+func crc16(data []byte) uint16 {
+	var crc uint16
+	for _, b := range data {
+		crc ^= uint16(b) << 8
+		for i := 0; i < 8; i++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// newTLSConfig loads CA and client certificate files
+// Загружает файлы CA и клиентского сертификата
+func newTLSConfig(cfg config.NATSTLSConfig) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify, // Explicit opt-in for test servers
+	}
+
+	if cfg.CAFile != "" {
+		caPEM, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read NATS CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("NATS CA file %s contains no certificates", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load NATS client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
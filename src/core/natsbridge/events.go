@@ -0,0 +1,101 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package natsbridge
+
+import (
+	"encoding/json"
+	"strings"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+)
+
+// HandleEngineEvent queues engine event for publishing, never blocks engine
+// Ставит событие движка в очередь публикации, никогда не блокирует движок
+func (b *Bridge) HandleEngineEvent(event models.EngineEvent) {
+	if b.events == nil {
+		return
+	}
+	if b.eventTypes != nil && !b.eventTypes[event.Type] {
+		return
+	}
+
+	select {
+	case b.events <- event:
+	default:
+		if dropped := b.droppedEvents.Add(1); dropped == 1 || dropped%1000 == 0 {
+			logger.Warn("NATS bridge events buffer is full, engine events dropped",
+				logger.Any("dropped_total", dropped))
+		}
+	}
+}
+
+// runEventPublisher publishes queued engine events until bridge stops
+// Публикует события движка из очереди до остановки моста
+func (b *Bridge) runEventPublisher() {
+	defer b.wg.Done()
+
+	for {
+		select {
+		case <-b.ctx.Done():
+			return
+		case event := <-b.events:
+			b.publishEvent(event)
+		}
+	}
+}
+
+// publishEvent publishes single event, event is dropped while NATS is unavailable
+// Публикует одно событие, пока NATS недоступен, событие отбрасывается
+func (b *Bridge) publishEvent(event models.EngineEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		logger.Error("NATS bridge failed to marshal engine event",
+			logger.String("type", event.Type),
+			logger.String("error", err.Error()))
+		return
+	}
+
+	conn := b.currentConnection()
+	if conn == nil {
+		b.dropDisconnected(event)
+		return
+	}
+
+	if err := conn.publish(eventSubject(b.cfg.EventsPrefix, event.Type), payload); err != nil {
+		// Broken connection is detected by reader, which reconnects
+		// Разорванное соединение обнаруживает reader, он же переподключается
+		conn.close()
+		logger.Error("NATS bridge failed to publish engine event",
+			logger.String("type", event.Type),
+			logger.String("process_instance_id", event.ProcessInstanceID),
+			logger.String("error", err.Error()))
+		b.dropDisconnected(event)
+	}
+}
+
+// dropDisconnected counts event lost because NATS was unavailable
+// Учитывает событие, потерянное из-за недоступности NATS
+func (b *Bridge) dropDisconnected(event models.EngineEvent) {
+	if dropped := b.droppedDisconnected.Add(1); dropped == 1 || dropped%1000 == 0 {
+		logger.Warn("NATS is unavailable, engine events dropped",
+			logger.String("type", event.Type),
+			logger.Any("dropped_total", dropped))
+	}
+}
+
+// eventSubject maps event type to subject, process_instance_completed goes to {prefix}.instance.completed
+// Преобразует тип события в тему, process_instance_completed уходит в {prefix}.instance.completed
+func eventSubject(prefix, eventType string) string {
+	subject := eventType
+	if rest, found := strings.CutPrefix(eventType, "process_instance_"); found {
+		subject = "instance_" + rest
+	}
+	return prefix + "." + strings.ReplaceAll(subject, "_", ".")
+}
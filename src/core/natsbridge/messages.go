@@ -0,0 +1,124 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package natsbridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"atom-engine/src/core/logger"
+)
+
+// publishTimeout limits single message publishing through messages component
+// Ограничивает публикацию одного сообщения через компонент messages
+const publishTimeout = 10 * time.Second
+
+// messagePayload is JSON body of message received from NATS
+// JSON тело сообщения, полученного из NATS
+type messagePayload struct {
+	CorrelationKey string                 `json:"correlation_key"`
+	Variables      map[string]interface{} `json:"variables"`
+	TenantID       string                 `json:"tenant_id"`
+	TTLSeconds     int                    `json:"ttl_seconds"` // Buffering time when nothing waits for message
+}
+
+// messageReply is sent to reply subject of request, plain publishes get no reply
+// Отправляется в тему ответа запроса, обычные публикации ответа не получают
+type messageReply struct {
+	Success           bool   `json:"success"`
+	MessageName       string `json:"message_name,omitempty"`
+	ProcessInstanceID string `json:"process_instance_id,omitempty"`
+	Error             string `json:"error,omitempty"`
+}
+
+// handleMessage publishes message received from NATS, name is subject suffix after messages subject prefix
+// Публикует полученное из NATS сообщение, имя - суффикс темы после префикса темы сообщений
+func (b *Bridge) handleMessage(subject, reply string, data []byte) {
+	messageName, processInstanceID, err := b.publishMessage(subject, data)
+	result := messageReply{MessageName: messageName, ProcessInstanceID: processInstanceID}
+	if err != nil {
+		logger.Warn("NATS bridge failed to publish message",
+			logger.String("subject", subject),
+			logger.String("error", err.Error()))
+		result.Error = err.Error()
+	} else {
+		result.Success = true
+	}
+
+	if reply == "" {
+		return
+	}
+	b.sendReply(reply, result)
+}
+
+// publishMessage parses payload and publishes message through messages component
+// Returns message name and instance message was correlated with, empty when message was buffered
+// Разбирает данные и публикует сообщение через компонент messages
+// Возвращает имя сообщения и экземпляр, с которым оно скоррелировано, пустой если сообщение буферизовано
+func (b *Bridge) publishMessage(subject string, data []byte) (string, string, error) {
+	messageName, found := strings.CutPrefix(subject, b.messagePrefix)
+	if !found || messageName == "" {
+		return "", "", fmt.Errorf("subject %s has no message name after %s", subject, b.messagePrefix)
+	}
+
+	var payload messagePayload
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return messageName, "", fmt.Errorf("invalid message payload: %w", err)
+		}
+	}
+	if payload.TTLSeconds < 0 {
+		return messageName, "", fmt.Errorf("ttl_seconds must not be negative")
+	}
+
+	var ttl *time.Duration
+	if payload.TTLSeconds > 0 {
+		duration := time.Duration(payload.TTLSeconds) * time.Second
+		ttl = &duration
+	}
+
+	ctx, cancel := context.WithTimeout(b.ctx, publishTimeout)
+	defer cancel()
+
+	result, err := b.messages.PublishMessage(
+		ctx, payload.TenantID, messageName, payload.CorrelationKey, "", payload.Variables, ttl)
+	if err != nil {
+		return messageName, "", err
+	}
+	if result == nil {
+		return messageName, "", nil
+	}
+
+	logger.Debug("NATS bridge published message",
+		logger.String("message_name", messageName),
+		logger.String("correlation_key", payload.CorrelationKey),
+		logger.String("process_instance_id", result.ProcessInstanceID))
+	return messageName, result.ProcessInstanceID, nil
+}
+
+// sendReply answers request, reply is lost if connection broke meanwhile
+// Отвечает на запрос, ответ теряется, если соединение разорвалось
+func (b *Bridge) sendReply(reply string, result messageReply) {
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return
+	}
+	conn := b.currentConnection()
+	if conn == nil {
+		return
+	}
+	if err := conn.publish(reply, payload); err != nil {
+		logger.Warn("NATS bridge failed to send message reply",
+			logger.String("reply", reply),
+			logger.String("error", err.Error()))
+	}
+}
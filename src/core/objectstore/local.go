@@ -0,0 +1,254 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package objectstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// tmpSuffix marks partially written local objects
+// Помечает частично записанные локальные объекты
+const tmpSuffix = ".tmp"
+
+// LocalStore keeps objects as files in local directory with checksum sidecar files
+// Хранит объекты файлами в локальной директории с файлами контрольных сумм рядом
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore creates store writing into directory
+// Создает хранилище, пишущее в директорию
+func NewLocalStore(dir string) *LocalStore {
+	return &LocalStore{dir: dir}
+}
+
+// Put writes object atomically via temporary file
+// Записывает объект атомарно через временный файл
+func (s *LocalStore) Put(ctx context.Context, key string, body io.Reader) (*ObjectInfo, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	filePath := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create object directory: %w", err)
+	}
+
+	tmpPath := filePath + tmpSuffix
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create object file: %w", err)
+	}
+
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hasher), contextReader{ctx: ctx, reader: body})
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return nil, fmt.Errorf("failed to write object file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		os.Remove(tmpPath)
+		return nil, fmt.Errorf("failed to move object file: %w", err)
+	}
+
+	checksum := hex.EncodeToString(hasher.Sum(nil))
+	if err := os.WriteFile(filePath+checksumSuffix, []byte(checksum+"\n"), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write object checksum: %w", err)
+	}
+
+	stat, err := os.Stat(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat object file: %w", err)
+	}
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         size,
+		SHA256:       checksum,
+		LastModified: stat.ModTime().UTC(),
+		Location:     s.location(filePath),
+	}, nil
+}
+
+// Get opens object file with checksum verification
+// Открывает файл объекта с проверкой контрольной суммы
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, nil, err
+	}
+
+	filePath := s.path(key)
+	file, err := os.Open(filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		return nil, nil, fmt.Errorf("failed to open object file: %w", err)
+	}
+
+	info, err := s.stat(key, filePath)
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	return newVerifyingReader(file, info.SHA256), info, nil
+}
+
+// List walks directory returning objects with key prefix
+// Обходит директорию, возвращая объекты с префиксом ключа
+func (s *LocalStore) List(ctx context.Context, prefix string) ([]*ObjectInfo, error) {
+	// Walk only directory containing prefix, not whole store
+	// Обходим только директорию, содержащую префикс, а не все хранилище
+	root := s.dir
+	if dir := path.Dir(prefix); strings.Contains(prefix, "/") && dir != "." {
+		root = s.path(dir)
+	}
+
+	objects := make([]*ObjectInfo, 0)
+	err := filepath.WalkDir(root, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if entry.IsDir() || strings.HasSuffix(filePath, checksumSuffix) || strings.HasSuffix(filePath, tmpSuffix) {
+			return nil
+		}
+
+		relative, err := filepath.Rel(s.dir, filePath)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(relative)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := s.stat(key, filePath)
+		if err != nil {
+			return err
+		}
+		objects = append(objects, info)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// Delete removes object file and its checksum file
+// Удаляет файл объекта и файл его контрольной суммы
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+
+	filePath := s.path(key)
+	if err := os.Remove(filePath); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		return fmt.Errorf("failed to delete object file: %w", err)
+	}
+	if err := os.Remove(filePath + checksumSuffix); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete object checksum: %w", err)
+	}
+	return nil
+}
+
+// Describe returns local destination description
+// Возвращает описание локального назначения
+func (s *LocalStore) Describe() string {
+	return "local:" + s.dir
+}
+
+// stat returns object info with checksum read from sidecar file
+// Возвращает информацию об объекте с суммой из файла рядом
+func (s *LocalStore) stat(key, filePath string) (*ObjectInfo, error) {
+	stat, err := os.Stat(filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+		return nil, fmt.Errorf("failed to stat object file: %w", err)
+	}
+
+	info := &ObjectInfo{
+		Key:          key,
+		Size:         stat.Size(),
+		LastModified: stat.ModTime().UTC(),
+		Location:     s.location(filePath),
+	}
+
+	data, err := os.ReadFile(filePath + checksumSuffix)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return info, nil
+		}
+		return nil, fmt.Errorf("failed to read object checksum: %w", err)
+	}
+	if info.SHA256, err = parseChecksum(data); err != nil {
+		return nil, fmt.Errorf("object %s: %w", key, err)
+	}
+	return info, nil
+}
+
+// path returns file path of key
+// Возвращает путь файла ключа
+func (s *LocalStore) path(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
+
+// location returns file URL of object
+// Возвращает file URL объекта
+func (s *LocalStore) location(filePath string) string {
+	return "file://" + filepath.ToSlash(filePath)
+}
+
+// contextReader stops reading once context is cancelled
+// Прекращает чтение после отмены контекста
+type contextReader struct {
+	ctx    context.Context
+	reader io.Reader
+}
+
+// Read reads from underlying reader unless context is done
+// Читает из исходного reader, если контекст не завершен
+func (r contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.reader.Read(p)
+}
@@ -0,0 +1,565 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"atom-engine/src/core/logger"
+)
+
+// Server-side encryption modes
+// Режимы шифрования на стороне сервера
+const (
+	SSENone = ""
+	SSES3   = "AES256"
+	SSEKMS  = "aws:kms"
+)
+
+// minPartSize is smallest part size S3 accepts for all parts except the last one
+// Минимальный размер части, принимаемый S3 для всех частей кроме последней
+const minPartSize = 5 << 20
+
+// S3Options holds S3-compatible bucket connection settings
+// Настройки подключения к S3-совместимому bucket
+type S3Options struct {
+	Endpoint    string
+	Region      string
+	Bucket      string
+	Prefix      string
+	AccessKey   string
+	SecretKey   string
+	SSE         string
+	SSEKMSKeyID string
+	PartSize    int64         // Objects larger than part are uploaded by multipart
+	Timeout     time.Duration // Per request, time to response headers for downloads
+}
+
+// S3Store keeps objects in S3-compatible bucket using path-style URLs and SigV4
+// Хранит объекты в S3-совместимом bucket через path-style URL и SigV4
+type S3Store struct {
+	options  S3Options
+	endpoint *url.URL
+	client   *http.Client
+}
+
+// NewS3Store creates store for S3-compatible bucket
+// Создает хранилище для S3-совместимого bucket
+func NewS3Store(options S3Options) (*S3Store, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(options.Endpoint, "/"))
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint: %s", options.Endpoint)
+	}
+	if options.PartSize < minPartSize {
+		options.PartSize = minPartSize
+	}
+
+	// Client has no overall timeout so long downloads are not cut, requests are bounded by context
+	// У клиента нет общего таймаута, чтобы не обрывать длинные загрузки, запросы ограничены контекстом
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = options.Timeout
+
+	return &S3Store{
+		options:  options,
+		endpoint: endpoint,
+		client:   &http.Client{Transport: transport},
+	}, nil
+}
+
+// Put uploads object in single request or by multipart when body exceeds part size
+// Only one part is kept in memory at a time
+// Загружает объект одним запросом или по частям, если body больше части
+// В памяти одновременно хранится только одна часть
+func (s *S3Store) Put(ctx context.Context, key string, body io.Reader) (*ObjectInfo, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, err
+	}
+
+	part := make([]byte, s.options.PartSize)
+	n, err := io.ReadFull(body, part)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("failed to read object body: %w", err)
+	}
+
+	hasher := sha256.New()
+	size := int64(n)
+	if err != nil {
+		hasher.Write(part[:n])
+		if err := s.putObject(ctx, key, part[:n]); err != nil {
+			return nil, err
+		}
+	} else {
+		uploaded, err := s.multipartUpload(ctx, key, body, part, hasher)
+		if err != nil {
+			return nil, err
+		}
+		size = uploaded
+	}
+
+	checksum := hex.EncodeToString(hasher.Sum(nil))
+	if err := s.putObject(ctx, key+checksumSuffix, []byte(checksum+"\n")); err != nil {
+		return nil, fmt.Errorf("failed to store object checksum: %w", err)
+	}
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         size,
+		SHA256:       checksum,
+		LastModified: time.Now().UTC(),
+		Location:     s.location(key),
+	}, nil
+}
+
+// Get downloads object with checksum verification
+// Скачивает объект с проверкой контрольной суммы
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error) {
+	if err := ValidateKey(key); err != nil {
+		return nil, nil, err
+	}
+
+	checksum, err := s.loadChecksum(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := s.do(ctx, http.MethodGet, key, nil, nil, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, nil, s.responseError("download", key, resp)
+	}
+
+	info := s.infoFromHeaders(key, resp)
+	info.SHA256 = checksum
+	return newVerifyingReader(resp.Body, checksum), info, nil
+}
+
+// List returns objects with key prefix using ListObjectsV2 pagination
+// Возвращает объекты с префиксом ключа через постраничный ListObjectsV2
+func (s *S3Store) List(ctx context.Context, prefix string) ([]*ObjectInfo, error) {
+	objects := make([]*ObjectInfo, 0)
+	continuationToken := ""
+
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {s.objectKey(prefix)}}
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+
+		var result listBucketResult
+		if err := s.doXML(ctx, http.MethodGet, "", query, nil, &result); err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", err)
+		}
+
+		for _, content := range result.Contents {
+			key := strings.TrimPrefix(content.Key, s.keyPrefix())
+			if strings.HasSuffix(key, checksumSuffix) {
+				continue
+			}
+			objects = append(objects, &ObjectInfo{
+				Key:          key,
+				Size:         content.Size,
+				LastModified: content.LastModified,
+				Location:     s.location(key),
+			})
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			break
+		}
+		continuationToken = result.NextContinuationToken
+	}
+
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+	return objects, nil
+}
+
+// Delete removes object and its checksum, S3 DELETE succeeds for missing keys so HEAD goes first
+// Удаляет объект и его сумму, S3 DELETE успешен для отсутствующих ключей, поэтому сначала HEAD
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	if err := ValidateKey(key); err != nil {
+		return err
+	}
+
+	resp, err := s.do(ctx, http.MethodHead, key, nil, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s.responseError("stat", key, resp)
+	}
+
+	for _, objectKey := range []string{key, key + checksumSuffix} {
+		resp, err := s.do(ctx, http.MethodDelete, objectKey, nil, nil, nil)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+			return s.responseError("delete", objectKey, resp)
+		}
+	}
+	return nil
+}
+
+// Describe returns bucket description without credentials
+// Возвращает описание bucket без учетных данных
+func (s *S3Store) Describe() string {
+	description := fmt.Sprintf("s3:%s/%s", s.endpoint.Host, s.options.Bucket)
+	if prefix := s.keyPrefix(); prefix != "" {
+		description += "/" + strings.TrimSuffix(prefix, "/")
+	}
+	return description
+}
+
+// putObject uploads small object in single request
+// Загружает небольшой объект одним запросом
+func (s *S3Store) putObject(ctx context.Context, key string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, key, nil, data, s.encryptionHeaders())
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return s.responseError("upload", key, resp)
+	}
+	return nil
+}
+
+// multipartUpload uploads first full part and rest of body part by part, aborting upload on failure
+// Загружает первую полную часть и остаток body по частям, прерывая загрузку при ошибке
+func (s *S3Store) multipartUpload(
+	ctx context.Context,
+	key string,
+	body io.Reader,
+	part []byte,
+	hasher io.Writer,
+) (int64, error) {
+	var initiated initiateMultipartUploadResult
+	if err := s.doXML(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil, &initiated,
+		s.encryptionHeaders()); err != nil {
+		return 0, fmt.Errorf("failed to start multipart upload: %w", err)
+	}
+	uploadID := initiated.UploadID
+
+	size, err := s.uploadParts(ctx, key, uploadID, body, part, hasher)
+	if err != nil {
+		s.abortMultipartUpload(key, uploadID)
+		return 0, err
+	}
+	return size, nil
+}
+
+// uploadParts sends parts and completes multipart upload
+// Отправляет части и завершает загрузку по частям
+func (s *S3Store) uploadParts(
+	ctx context.Context,
+	key, uploadID string,
+	body io.Reader,
+	part []byte,
+	hasher io.Writer,
+) (int64, error) {
+	var completed completeMultipartUpload
+	var size int64
+	n := len(part)
+
+	for partNumber := 1; n > 0; partNumber++ {
+		hasher.Write(part[:n])
+		size += int64(n)
+
+		query := url.Values{"partNumber": {strconv.Itoa(partNumber)}, "uploadId": {uploadID}}
+		resp, err := s.do(ctx, http.MethodPut, key, query, part[:n], nil)
+		if err != nil {
+			return 0, err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return 0, s.responseError("upload part", key, resp)
+		}
+		completed.Parts = append(completed.Parts, completedPart{
+			PartNumber: partNumber,
+			ETag:       resp.Header.Get("ETag"),
+		})
+
+		n, err = io.ReadFull(body, part)
+		if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+			return 0, fmt.Errorf("failed to read object body: %w", err)
+		}
+	}
+
+	payload, err := xml.Marshal(completed)
+	if err != nil {
+		return 0, fmt.Errorf("failed to build multipart completion: %w", err)
+	}
+
+	// Completion may fail after 200 OK, error is then reported in response body
+	// Завершение может не удаться после 200 OK, тогда ошибка передается в теле ответа
+	var result completeMultipartUploadResult
+	if err := s.doXML(ctx, http.MethodPost, key, url.Values{"uploadId": {uploadID}}, payload, &result); err != nil {
+		return 0, fmt.Errorf("failed to complete multipart upload: %w", err)
+	}
+	if result.XMLName.Local == "Error" {
+		return 0, fmt.Errorf("failed to complete multipart upload: %s: %s", result.Code, result.Message)
+	}
+
+	logger.Debug("Multipart upload completed",
+		logger.String("key", key),
+		logger.Int("parts", len(completed.Parts)),
+		logger.Any("size", size))
+	return size, nil
+}
+
+// abortMultipartUpload discards uploaded parts, failure only leaves parts for bucket lifecycle rules
+// Удаляет загруженные части, при ошибке части остаются для правил lifecycle bucket
+func (s *S3Store) abortMultipartUpload(key, uploadID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.options.Timeout)
+	defer cancel()
+
+	resp, err := s.do(ctx, http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil, nil)
+	if err == nil {
+		resp.Body.Close()
+		if resp.StatusCode == http.StatusNoContent || resp.StatusCode == http.StatusOK {
+			return
+		}
+		err = fmt.Errorf("status %d", resp.StatusCode)
+	}
+	logger.Warn("Failed to abort multipart upload",
+		logger.String("key", key),
+		logger.String("error", err.Error()))
+}
+
+// loadChecksum reads checksum object, missing checksum returns empty string
+// Читает объект контрольной суммы, отсутствующая сумма возвращает пустую строку
+func (s *S3Store) loadChecksum(ctx context.Context, key string) (string, error) {
+	resp, err := s.do(ctx, http.MethodGet, key+checksumSuffix, nil, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", s.responseError("download checksum", key, resp)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return "", fmt.Errorf("failed to read object checksum: %w", err)
+	}
+	checksum, err := parseChecksum(data)
+	if err != nil {
+		return "", fmt.Errorf("object %s: %w", key, err)
+	}
+	return checksum, nil
+}
+
+// doXML sends request and decodes XML response body
+// Отправляет запрос и декодирует XML тело ответа
+func (s *S3Store) doXML(
+	ctx context.Context,
+	method, key string,
+	query url.Values,
+	payload []byte,
+	result interface{},
+	headers ...map[string]string,
+) error {
+	var extra map[string]string
+	if len(headers) > 0 {
+		extra = headers[0]
+	}
+
+	resp, err := s.do(ctx, method, key, query, payload, extra)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return s.responseError(strings.ToLower(method), key, resp)
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(result); err != nil {
+		return fmt.Errorf("failed to decode s3 response: %w", err)
+	}
+	return nil
+}
+
+// do sends signed request for object key, empty key addresses bucket itself
+// Uploads are bounded by configured timeout, downloads only by time to response headers
+// Отправляет подписанный запрос для ключа объекта, пустой ключ адресует сам bucket
+// Загрузки ограничены настроенным таймаутом, скачивания - только временем до заголовков ответа
+func (s *S3Store) do(
+	ctx context.Context,
+	method, key string,
+	query url.Values,
+	payload []byte,
+	headers map[string]string,
+) (*http.Response, error) {
+	canonicalPath := s.endpoint.Path + "/" + uriEncode(s.options.Bucket, false)
+	if key != "" {
+		canonicalPath += "/" + uriEncode(s.objectKey(key), false)
+	}
+	canonicalQuery := canonicalQueryString(query)
+
+	rawURL := s.endpoint.Scheme + "://" + s.endpoint.Host + canonicalPath
+	if canonicalQuery != "" {
+		rawURL += "?" + canonicalQuery
+	}
+
+	var body io.Reader
+	cancel := context.CancelFunc(func() {})
+	if payload != nil {
+		ctx, cancel = context.WithTimeout(ctx, s.options.Timeout)
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to create s3 request: %w", err)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	s.sign(req, canonicalPath, canonicalQuery, payload, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("s3 %s request failed: %w", strings.ToLower(method), err)
+	}
+	resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// encryptionHeaders returns server-side encryption headers of new objects
+// Возвращает заголовки шифрования на стороне сервера для новых объектов
+func (s *S3Store) encryptionHeaders() map[string]string {
+	if s.options.SSE == SSENone {
+		return nil
+	}
+	headers := map[string]string{"X-Amz-Server-Side-Encryption": s.options.SSE}
+	if s.options.SSE == SSEKMS && s.options.SSEKMSKeyID != "" {
+		headers["X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"] = s.options.SSEKMSKeyID
+	}
+	return headers
+}
+
+// responseError converts unexpected response to error, 404 maps to ErrNotFound
+// Преобразует неожиданный ответ в ошибку, 404 соответствует ErrNotFound
+func (s *S3Store) responseError(operation, key string, resp *http.Response) error {
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%w: %s", ErrNotFound, key)
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return fmt.Errorf("s3 %s of %q failed with status %d: %s",
+		operation, key, resp.StatusCode, strings.TrimSpace(string(body)))
+}
+
+// infoFromHeaders builds object info from download response headers
+// Строит информацию об объекте из заголовков ответа скачивания
+func (s *S3Store) infoFromHeaders(key string, resp *http.Response) *ObjectInfo {
+	info := &ObjectInfo{Key: key, Size: resp.ContentLength, Location: s.location(key)}
+	if modified, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		info.LastModified = modified.UTC()
+	}
+	return info
+}
+
+// keyPrefix returns configured prefix with trailing slash or empty string
+// Возвращает настроенный префикс со слэшем в конце или пустую строку
+func (s *S3Store) keyPrefix() string {
+	if prefix := strings.Trim(s.options.Prefix, "/"); prefix != "" {
+		return prefix + "/"
+	}
+	return ""
+}
+
+// objectKey returns bucket key of store key
+// Возвращает ключ bucket для ключа хранилища
+func (s *S3Store) objectKey(key string) string {
+	return s.keyPrefix() + key
+}
+
+// location returns s3 URL of object
+// Возвращает s3 URL объекта
+func (s *S3Store) location(key string) string {
+	return fmt.Sprintf("s3://%s/%s", s.options.Bucket, s.objectKey(key))
+}
+
+// cancelOnClose releases request context when response body is closed
+// Освобождает контекст запроса при закрытии тела ответа
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes body and cancels context
+// Закрывает тело и отменяет контекст
+func (c cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
+
+// listBucketResult is ListObjectsV2 response
+// Ответ ListObjectsV2
+type listBucketResult struct {
+	Contents []struct {
+		Key          string    `xml:"Key"`
+		Size         int64     `xml:"Size"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// initiateMultipartUploadResult is CreateMultipartUpload response
+// Ответ CreateMultipartUpload
+type initiateMultipartUploadResult struct {
+	UploadID string `xml:"UploadId"`
+}
+
+// completeMultipartUpload is CompleteMultipartUpload request body
+// Тело запроса CompleteMultipartUpload
+type completeMultipartUpload struct {
+	XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+	Parts   []completedPart `xml:"Part"`
+}
+
+// completedPart identifies uploaded part
+// Идентифицирует загруженную часть
+type completedPart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// completeMultipartUploadResult is CompleteMultipartUpload response or embedded error
+// Ответ CompleteMultipartUpload или вложенная ошибка
+type completeMultipartUploadResult struct {
+	XMLName xml.Name
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
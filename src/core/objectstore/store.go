@@ -0,0 +1,156 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package objectstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+	"time"
+)
+
+// checksumSuffix is suffix of sidecar object holding SHA-256 of object content
+// Суффикс сопутствующего объекта с SHA-256 содержимого объекта
+const checksumSuffix = ".sha256"
+
+// ErrNotFound is returned when object does not exist
+// Возвращается, когда объект не существует
+var ErrNotFound = errors.New("object not found")
+
+// ErrChecksumMismatch is returned when read content does not match stored checksum
+// Возвращается, когда прочитанное содержимое не совпадает с сохраненной контрольной суммой
+var ErrChecksumMismatch = errors.New("object checksum mismatch")
+
+// ObjectInfo describes stored object
+// Описывает сохраненный объект
+type ObjectInfo struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	SHA256       string    `json:"sha256,omitempty"`
+	LastModified time.Time `json:"last_modified"`
+	Location     string    `json:"location"`
+}
+
+// Store keeps objects in local directory or S3-compatible bucket
+// Keys are slash separated relative paths, content is streamed in both directions
+// Хранит объекты в локальной директории или S3-совместимом bucket
+// Ключи - относительные пути через слэш, содержимое передается потоком в обе стороны
+type Store interface {
+	// Put streams body into object and stores its SHA-256 next to it
+	// Передает body в объект и сохраняет рядом его SHA-256
+	Put(ctx context.Context, key string, body io.Reader) (*ObjectInfo, error)
+
+	// Get opens object, reader fails with ErrChecksumMismatch at end of corrupted content
+	// Открывает объект, reader завершается ErrChecksumMismatch в конце поврежденного содержимого
+	Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error)
+
+	// List returns objects whose keys start with prefix ordered by key
+	// Возвращает объекты с ключами, начинающимися с prefix, упорядоченные по ключу
+	List(ctx context.Context, prefix string) ([]*ObjectInfo, error)
+
+	// Delete removes object and its checksum
+	// Удаляет объект и его контрольную сумму
+	Delete(ctx context.Context, key string) error
+
+	// Describe returns destination description safe for logs and status output
+	// Возвращает описание назначения, безопасное для логов и статуса
+	Describe() string
+}
+
+// ValidateKey checks key is relative slash separated path without traversal
+// Проверяет, что ключ - относительный путь через слэш без выхода за пределы
+func ValidateKey(key string) error {
+	if key == "" {
+		return fmt.Errorf("object key is empty")
+	}
+	if strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return fmt.Errorf("invalid object key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("invalid object key %q", key)
+		}
+	}
+	if strings.HasSuffix(key, checksumSuffix) {
+		return fmt.Errorf("object key %q uses reserved suffix %s", key, checksumSuffix)
+	}
+	return nil
+}
+
+// Verify reads whole object and checks it against stored checksum
+// Objects stored without checksum are read but reported with empty SHA256
+// Читает объект целиком и сверяет его с сохраненной контрольной суммой
+// Объекты без контрольной суммы читаются, но возвращаются с пустым SHA256
+func Verify(ctx context.Context, store Store, key string) (*ObjectInfo, error) {
+	reader, info, err := store.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	size, err := io.Copy(io.Discard, reader)
+	if err != nil {
+		return nil, err
+	}
+	if info.Size >= 0 && size != info.Size {
+		return nil, fmt.Errorf("%w: read %d bytes, expected %d", ErrChecksumMismatch, size, info.Size)
+	}
+	return info, nil
+}
+
+// verifyingReader hashes content while it is read and compares digest at EOF
+// Считает хеш содержимого при чтении и сравнивает его в конце
+type verifyingReader struct {
+	reader   io.ReadCloser
+	hash     hash.Hash
+	expected string
+}
+
+// newVerifyingReader wraps reader, empty expected checksum disables verification
+// Оборачивает reader, пустая ожидаемая сумма отключает проверку
+func newVerifyingReader(reader io.ReadCloser, expected string) io.ReadCloser {
+	if expected == "" {
+		return reader
+	}
+	return &verifyingReader{reader: reader, hash: sha256.New(), expected: expected}
+}
+
+// Read reads content and verifies checksum on EOF
+// Читает содержимое и проверяет сумму при EOF
+func (r *verifyingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF {
+		if actual := hex.EncodeToString(r.hash.Sum(nil)); actual != r.expected {
+			return n, fmt.Errorf("%w: sha256 %s, expected %s", ErrChecksumMismatch, actual, r.expected)
+		}
+	}
+	return n, err
+}
+
+// Close closes underlying reader
+// Закрывает исходный reader
+func (r *verifyingReader) Close() error {
+	return r.reader.Close()
+}
+
+// parseChecksum returns hex SHA-256 read from checksum object content
+// Возвращает hex SHA-256 из содержимого объекта контрольной суммы
+func parseChecksum(data []byte) (string, error) {
+	checksum := strings.TrimSpace(string(data))
+	if decoded, err := hex.DecodeString(checksum); err != nil || len(decoded) != sha256.Size {
+		return "", fmt.Errorf("invalid checksum content")
+	}
+	return strings.ToLower(checksum), nil
+}
@@ -0,0 +1,373 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package graphqlapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// nestedListSize is estimated length of lists nested in process instance
+const nestedListSize = 10
+
+// listFields maps fields returning lists to argument holding page size and its default
+// Fields without size argument are estimated with nestedListSize
+var listFields = map[string]struct {
+	sizeArg     string
+	defaultSize int
+}{
+	"processInstances":   {sizeArg: "pageSize", defaultSize: 20},
+	"processDefinitions": {sizeArg: "pageSize", defaultSize: 20},
+	"tokens":             {defaultSize: nestedListSize},
+	"jobs":               {defaultSize: nestedListSize},
+	"incidents":          {defaultSize: nestedListSize},
+	"timers":             {defaultSize: nestedListSize},
+	"history":            {defaultSize: nestedListSize},
+}
+
+// selection is field, fragment spread or inline fragment of parsed query
+type selection struct {
+	field    string
+	args     map[string]string // Literal int arguments or "$name" of variables
+	spread   string
+	children []selection
+}
+
+// operation is parsed operation of query document
+type operation struct {
+	name       string
+	selections []selection
+}
+
+// complexity estimates cost of operation before execution
+// Every selected field costs 1, selections of list fields are multiplied by requested page size
+// Query must be validated by schema beforehand, parser relies on valid syntax
+func complexity(query, operationName string, variables map[string]interface{}) (int, error) {
+	p := &queryParser{tokens: tokenize(query)}
+	operations, fragments, err := p.document()
+	if err != nil {
+		return 0, err
+	}
+
+	var op *operation
+	for i := range operations {
+		if operationName == "" || operations[i].name == operationName {
+			op = &operations[i]
+			break
+		}
+	}
+	if op == nil {
+		return 0, fmt.Errorf("operation %q not found", operationName)
+	}
+
+	return selectionsCost(op.selections, fragments, variables, map[string]bool{}), nil
+}
+
+// selectionsCost sums cost of selections, visiting guards against fragment cycles
+func selectionsCost(
+	selections []selection,
+	fragments map[string][]selection,
+	variables map[string]interface{},
+	visiting map[string]bool,
+) int {
+	total := 0
+	for _, sel := range selections {
+		switch {
+		case sel.spread != "":
+			if visiting[sel.spread] {
+				continue
+			}
+			visiting[sel.spread] = true
+			total += selectionsCost(fragments[sel.spread], fragments, variables, visiting)
+			delete(visiting, sel.spread)
+		case sel.field == "":
+			total += selectionsCost(sel.children, fragments, variables, visiting)
+		default:
+			total += 1 + listSize(sel, variables)*selectionsCost(sel.children, fragments, variables, visiting)
+		}
+	}
+	return total
+}
+
+// listSize returns multiplier of field selection, 1 for non-list fields
+func listSize(sel selection, variables map[string]interface{}) int {
+	list, ok := listFields[sel.field]
+	if !ok {
+		return 1
+	}
+
+	size := list.defaultSize
+	if list.sizeArg == "" {
+		return size
+	}
+
+	value, ok := sel.args[list.sizeArg]
+	if !ok {
+		return size
+	}
+	if strings.HasPrefix(value, "$") {
+		switch v := variables[value[1:]].(type) {
+		case float64:
+			size = int(v)
+		case int:
+			size = v
+		case int32:
+			size = int(v)
+		}
+	} else if n, err := strconv.Atoi(value); err == nil {
+		size = n
+	}
+
+	if size < 1 {
+		return 1
+	}
+	return size
+}
+
+// queryToken is lexical token of query, kind is 'n' name, 'v' value literal or punctuator itself
+type queryToken struct {
+	kind  byte
+	value string
+}
+
+// tokenize splits query into names, literals and punctuators, skipping comments and commas
+func tokenize(query string) []queryToken {
+	var tokens []queryToken
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+		case c == '#':
+			for i < len(query) && query[i] != '\n' {
+				i++
+			}
+		case c == '"':
+			start := i
+			if strings.HasPrefix(query[i:], `"""`) {
+				end := strings.Index(query[i+3:], `"""`)
+				if end < 0 {
+					i = len(query)
+				} else {
+					i += end + 6
+				}
+			} else {
+				for i++; i < len(query) && query[i] != '"' && query[i] != '\n'; i++ {
+					if query[i] == '\\' {
+						i++
+					}
+				}
+				i++
+			}
+			tokens = append(tokens, queryToken{kind: 'v', value: query[start:min(i, len(query))]})
+		case c == '.' && strings.HasPrefix(query[i:], "..."):
+			tokens = append(tokens, queryToken{kind: '.', value: "..."})
+			i += 3
+		case c == '-' || (c >= '0' && c <= '9'):
+			start := i
+			for i++; i < len(query) && strings.IndexByte("0123456789.eE+-", query[i]) >= 0; i++ {
+			}
+			tokens = append(tokens, queryToken{kind: 'v', value: query[start:i]})
+		case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			start := i
+			for i++; i < len(query) && isNameChar(query[i]); i++ {
+			}
+			tokens = append(tokens, queryToken{kind: 'n', value: query[start:i]})
+		default:
+			tokens = append(tokens, queryToken{kind: c, value: string(c)})
+			i++
+		}
+	}
+	return tokens
+}
+
+// isNameChar reports whether byte can continue GraphQL name
+func isNameChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// queryParser builds selection tree of operations and fragments from tokens
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+// document parses operations and named fragments
+func (p *queryParser) document() ([]operation, map[string][]selection, error) {
+	var operations []operation
+	fragments := make(map[string][]selection)
+
+	for p.pos < len(p.tokens) {
+		tok := p.tokens[p.pos]
+		switch {
+		case tok.kind == '{':
+			selections, err := p.selectionSet()
+			if err != nil {
+				return nil, nil, err
+			}
+			operations = append(operations, operation{selections: selections})
+		case tok.kind == 'n' && tok.value == "fragment":
+			p.pos++
+			name := p.name()
+			for p.pos < len(p.tokens) && p.tokens[p.pos].kind != '{' {
+				p.pos++
+			}
+			selections, err := p.selectionSet()
+			if err != nil {
+				return nil, nil, err
+			}
+			fragments[name] = selections
+		case tok.kind == 'n':
+			p.pos++
+			op := operation{}
+			if p.peek('n') {
+				op.name = p.name()
+			}
+			if p.peek('(') {
+				p.skipGroup('(', ')')
+			}
+			p.skipDirectives()
+			selections, err := p.selectionSet()
+			if err != nil {
+				return nil, nil, err
+			}
+			op.selections = selections
+			operations = append(operations, op)
+		default:
+			return nil, nil, fmt.Errorf("unexpected %q in query", tok.value)
+		}
+	}
+	return operations, fragments, nil
+}
+
+// selectionSet parses selections between braces
+func (p *queryParser) selectionSet() ([]selection, error) {
+	if !p.peek('{') {
+		return nil, fmt.Errorf("selection set expected")
+	}
+	p.pos++
+
+	var selections []selection
+	for !p.peek('}') {
+		if p.pos >= len(p.tokens) {
+			return nil, fmt.Errorf("unterminated selection set")
+		}
+
+		if p.peek('.') {
+			p.pos++
+			if p.peek('n') && p.tokens[p.pos].value != "on" {
+				selections = append(selections, selection{spread: p.name()})
+				p.skipDirectives()
+				continue
+			}
+			if p.peek('n') {
+				p.pos += 2 // "on" and type condition
+			}
+			p.skipDirectives()
+			children, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			selections = append(selections, selection{children: children})
+			continue
+		}
+
+		if !p.peek('n') {
+			return nil, fmt.Errorf("unexpected %q in selection set", p.tokens[p.pos].value)
+		}
+		sel := selection{field: p.name()}
+		if p.peek(':') {
+			p.pos++
+			sel.field = p.name()
+		}
+		if p.peek('(') {
+			sel.args = p.arguments()
+		}
+		p.skipDirectives()
+		if p.peek('{') {
+			children, err := p.selectionSet()
+			if err != nil {
+				return nil, err
+			}
+			sel.children = children
+		}
+		selections = append(selections, sel)
+	}
+	p.pos++
+	return selections, nil
+}
+
+// arguments parses field arguments keeping scalar literals and variable references
+func (p *queryParser) arguments() map[string]string {
+	args := make(map[string]string)
+	p.pos++ // (
+	for p.pos < len(p.tokens) && !p.peek(')') {
+		name := p.name()
+		if p.peek(':') {
+			p.pos++
+		}
+		switch {
+		case p.peek('$'):
+			p.pos++
+			args[name] = "$" + p.name()
+		case p.peek('['):
+			p.skipGroup('[', ']')
+		case p.peek('{'):
+			p.skipGroup('{', '}')
+		case p.pos < len(p.tokens):
+			args[name] = p.tokens[p.pos].value
+			p.pos++
+		}
+	}
+	p.pos++ // )
+	return args
+}
+
+// skipDirectives skips directives with their arguments
+func (p *queryParser) skipDirectives() {
+	for p.peek('@') {
+		p.pos++
+		p.name()
+		if p.peek('(') {
+			p.skipGroup('(', ')')
+		}
+	}
+}
+
+// skipGroup skips balanced group starting at current token
+func (p *queryParser) skipGroup(open, closing byte) {
+	depth := 0
+	for ; p.pos < len(p.tokens); p.pos++ {
+		switch p.tokens[p.pos].kind {
+		case open:
+			depth++
+		case closing:
+			depth--
+			if depth == 0 {
+				p.pos++
+				return
+			}
+		}
+	}
+}
+
+// name returns current name token and advances
+func (p *queryParser) name() string {
+	if !p.peek('n') {
+		return ""
+	}
+	p.pos++
+	return p.tokens[p.pos-1].value
+}
+
+// peek reports whether current token has kind
+func (p *queryParser) peek(kind byte) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].kind == kind
+}
@@ -0,0 +1,66 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package graphqlapi
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
+	"google.golang.org/grpc"
+)
+
+// Request is GraphQL request body
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// Executor runs read-only queries against component gRPC services
+type Executor struct {
+	schema *graphql.Schema
+	config Config
+}
+
+// NewExecutor parses schema with query limits
+func NewExecutor(config Config) (*Executor, error) {
+	config = config.withDefaults()
+	schema, err := parseSchema(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Executor{schema: schema, config: config}, nil
+}
+
+// Config returns effective query limits
+func (e *Executor) Config() Config {
+	return e.config
+}
+
+// Execute validates query, rejects queries over complexity limit and resolves it over gRPC connection
+// Depth limit is enforced by schema validation
+func (e *Executor) Execute(ctx context.Context, conn grpc.ClientConnInterface, req Request) *graphql.Response {
+	if errs := e.schema.ValidateWithVariables(req.Query, req.Variables); len(errs) > 0 {
+		return &graphql.Response{Errors: errs}
+	}
+
+	cost, err := complexity(req.Query, req.OperationName, req.Variables)
+	if err != nil {
+		return &graphql.Response{Errors: []*errors.QueryError{errors.Errorf("%s", err)}}
+	}
+	if cost > e.config.MaxComplexity {
+		return &graphql.Response{Errors: []*errors.QueryError{
+			errors.Errorf("query complexity %d exceeds limit %d", cost, e.config.MaxComplexity),
+		}}
+	}
+
+	ctx = withLoaders(ctx, newLoaders(conn))
+	return e.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+}
@@ -0,0 +1,232 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package graphqlapi
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"google.golang.org/grpc"
+
+	"atom-engine/proto/incidents/incidentspb"
+	"atom-engine/proto/jobs/jobspb"
+	"atom-engine/proto/messages/messagespb"
+	"atom-engine/proto/parser/parserpb"
+	"atom-engine/proto/process/processpb"
+	"atom-engine/proto/timewheel/timewheelpb"
+)
+
+// batchPageSize is page size of list calls loading children of several instances at once
+const batchPageSize = 10000
+
+// loadersKey is context key of request loaders
+type loadersKey struct{}
+
+// loaders batch component calls of one GraphQL request
+// Instances returned by root fields are primed, first nested field of any primed instance
+// loads that field for all pending instances with one list call
+type loaders struct {
+	process   processpb.ProcessServiceClient
+	jobs      jobspb.JobsServiceClient
+	incidents incidentspb.IncidentsServiceClient
+	timers    timewheelpb.TimeWheelServiceClient
+	parser    parserpb.ParserServiceClient
+	messages  messagespb.MessagesServiceClient
+
+	mu     sync.Mutex
+	primed []string
+	seen   map[string]bool
+
+	tokenBatch    batch[*processpb.TokenInfo]
+	jobBatch      batch[*jobspb.JobInfo]
+	incidentBatch batch[*incidentspb.Incident]
+	timerBatch    batch[*timewheelpb.TimerInfo]
+
+	definitionsOnce sync.Once
+	definitions     []*parserpb.BPMNProcessSummary
+	definitionsErr  error
+}
+
+// newLoaders creates loaders of one request over gRPC connection
+func newLoaders(conn grpc.ClientConnInterface) *loaders {
+	return &loaders{
+		process:   processpb.NewProcessServiceClient(conn),
+		jobs:      jobspb.NewJobsServiceClient(conn),
+		incidents: incidentspb.NewIncidentsServiceClient(conn),
+		timers:    timewheelpb.NewTimeWheelServiceClient(conn),
+		parser:    parserpb.NewParserServiceClient(conn),
+		messages:  messagespb.NewMessagesServiceClient(conn),
+		seen:      make(map[string]bool),
+	}
+}
+
+// withLoaders returns context carrying request loaders
+func withLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+// loadersFrom returns request loaders from context
+func loadersFrom(ctx context.Context) (*loaders, error) {
+	l, ok := ctx.Value(loadersKey{}).(*loaders)
+	if !ok {
+		return nil, fmt.Errorf("GraphQL request loaders not found in context")
+	}
+	return l, nil
+}
+
+// prime registers instances whose children are loaded together
+func (l *loaders) prime(instanceIDs ...string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, id := range instanceIDs {
+		if !l.seen[id] {
+			l.seen[id] = true
+			l.primed = append(l.primed, id)
+		}
+	}
+}
+
+// primedInstances returns copy of primed instance IDs
+func (l *loaders) primedInstances() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return append([]string(nil), l.primed...)
+}
+
+// tokens returns tokens of instance
+func (l *loaders) tokens(ctx context.Context, instanceID string) ([]*processpb.TokenInfo, error) {
+	l.prime(instanceID)
+	return l.tokenBatch.load(instanceID, l.primedInstances(), func(ids []string) ([]*processpb.TokenInfo, error) {
+		req := &processpb.ListTokensRequest{PageSize: batchPageSize, SortBy: "created_at", SortOrder: "ASC"}
+		if len(ids) == 1 {
+			req.InstanceIdFilter = ids[0]
+		}
+		resp, err := l.process.ListTokens(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tokens: %w", err)
+		}
+		return resp.Tokens, nil
+	}, func(token *processpb.TokenInfo) string { return token.ProcessInstanceId })
+}
+
+// instanceJobs returns jobs of instance
+func (l *loaders) instanceJobs(ctx context.Context, instanceID string) ([]*jobspb.JobInfo, error) {
+	l.prime(instanceID)
+	return l.jobBatch.load(instanceID, l.primedInstances(), func(ids []string) ([]*jobspb.JobInfo, error) {
+		req := &jobspb.ListJobsRequest{PageSize: batchPageSize, SortBy: "created_at", SortOrder: "ASC"}
+		if len(ids) == 1 {
+			req.ProcessInstanceId = ids[0]
+		}
+		resp, err := l.jobs.ListJobs(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list jobs: %w", err)
+		}
+		return resp.Jobs, nil
+	}, func(job *jobspb.JobInfo) string { return job.ProcessInstanceId })
+}
+
+// instanceIncidents returns incidents of instance
+func (l *loaders) instanceIncidents(ctx context.Context, instanceID string) ([]*incidentspb.Incident, error) {
+	l.prime(instanceID)
+	return l.incidentBatch.load(instanceID, l.primedInstances(), func(ids []string) ([]*incidentspb.Incident, error) {
+		filter := &incidentspb.IncidentFilter{PageSize: batchPageSize, SortBy: "created_at", SortOrder: "ASC"}
+		if len(ids) == 1 {
+			filter.ProcessInstanceId = ids[0]
+		}
+		resp, err := l.incidents.ListIncidents(ctx, &incidentspb.ListIncidentsRequest{Filter: filter})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list incidents: %w", err)
+		}
+		return resp.Incidents, nil
+	}, func(incident *incidentspb.Incident) string { return incident.ProcessInstanceId })
+}
+
+// instanceTimers returns timers of instance, timer list has no instance filter
+// so timers of all pending instances are always loaded with one call
+func (l *loaders) instanceTimers(ctx context.Context, instanceID string) ([]*timewheelpb.TimerInfo, error) {
+	l.prime(instanceID)
+	return l.timerBatch.load(instanceID, l.primedInstances(), func(ids []string) ([]*timewheelpb.TimerInfo, error) {
+		req := &timewheelpb.ListTimersRequest{PageSize: batchPageSize, SortBy: "scheduled_at", SortOrder: "ASC"}
+		resp, err := l.timers.ListTimers(ctx, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list timers: %w", err)
+		}
+		return resp.Timers, nil
+	}, func(timer *timewheelpb.TimerInfo) string { return timer.ProcessInstanceId })
+}
+
+// allDefinitions returns all deployed process definitions, loaded once per request
+func (l *loaders) allDefinitions(ctx context.Context) ([]*parserpb.BPMNProcessSummary, error) {
+	l.definitionsOnce.Do(func() {
+		resp, err := l.parser.ListBPMNProcesses(ctx, &parserpb.ListBPMNProcessesRequest{PageSize: batchPageSize})
+		if err != nil {
+			l.definitionsErr = fmt.Errorf("failed to list process definitions: %w", err)
+			return
+		}
+		if !resp.Success {
+			l.definitionsErr = fmt.Errorf("failed to list process definitions: %s", resp.Message)
+			return
+		}
+		l.definitions = resp.Processes
+	})
+	return l.definitions, l.definitionsErr
+}
+
+// batch caches children of instances grouped by instance ID
+type batch[T any] struct {
+	mu     sync.Mutex
+	loaded map[string]bool
+	items  map[string][]T
+}
+
+// load returns children of key, loading children of all not yet loaded keys with one fetch call
+// fetch receives pending keys and may return children of other instances, they are dropped
+func (b *batch[T]) load(
+	key string,
+	keys []string,
+	fetch func(pending []string) ([]T, error),
+	owner func(T) string,
+) ([]T, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.loaded[key] {
+		return b.items[key], nil
+	}
+	if b.loaded == nil {
+		b.loaded = make(map[string]bool)
+		b.items = make(map[string][]T)
+	}
+
+	pending := make([]string, 0, len(keys))
+	wanted := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		if !b.loaded[k] && !wanted[k] {
+			pending = append(pending, k)
+			wanted[k] = true
+		}
+	}
+
+	items, err := fetch(pending)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		if id := owner(item); wanted[id] {
+			b.items[id] = append(b.items[id], item)
+		}
+	}
+	for _, k := range pending {
+		b.loaded[k] = true
+	}
+	return b.items[key], nil
+}
@@ -0,0 +1,691 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package graphqlapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"atom-engine/proto/jobs/jobspb"
+	"atom-engine/proto/messages/messagespb"
+	"atom-engine/proto/parser/parserpb"
+	"atom-engine/proto/process/processpb"
+	"atom-engine/proto/timewheel/timewheelpb"
+	"atom-engine/src/core/models"
+)
+
+// maxPageSize bounds page size of root list fields
+const maxPageSize = 1000
+
+// JSON is GraphQL scalar holding arbitrary JSON value
+type JSON struct {
+	Value interface{}
+}
+
+// ImplementsGraphQLType maps JSON to "JSON" scalar of schema
+func (JSON) ImplementsGraphQLType(name string) bool {
+	return name == "JSON"
+}
+
+// UnmarshalGraphQL accepts any input value
+func (j *JSON) UnmarshalGraphQL(input interface{}) error {
+	j.Value = input
+	return nil
+}
+
+// MarshalJSON encodes wrapped value
+func (j JSON) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Value)
+}
+
+// Resolver is root resolver of Query type
+type Resolver struct{}
+
+// processInstanceArgs are arguments of processInstance field
+type processInstanceArgs struct {
+	ID graphql.ID
+}
+
+// ProcessInstance resolves processInstance(id)
+func (r *Resolver) ProcessInstance(ctx context.Context, args processInstanceArgs) (*ProcessInstance, error) {
+	l, err := loadersFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := l.process.GetProcessInstanceStatus(ctx, &processpb.GetProcessInstanceStatusRequest{
+		InstanceId: string(args.ID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get process instance %s: %w", args.ID, err)
+	}
+
+	instance := &ProcessInstance{
+		ID:              graphql.ID(resp.InstanceId),
+		Key:             int64String(resp.InstanceKey),
+		ProcessID:       resp.ProcessId,
+		ProcessKey:      optionalString(resp.ProcessKey),
+		Status:          resp.Status,
+		CurrentActivity: optionalString(resp.CurrentActivity),
+		StartedAt:       unixTime(resp.StartedAt),
+		UpdatedAt:       unixTime(resp.UpdatedAt),
+		Variables:       jsonVariables(resp.VariablesJson, resp.Variables),
+	}
+	l.prime(resp.InstanceId)
+	return instance, nil
+}
+
+// processInstancesArgs are arguments of processInstances field
+type processInstancesArgs struct {
+	Status     *string
+	ProcessKey *string
+	Page       int32
+	PageSize   int32
+}
+
+// ProcessInstances resolves processInstances, returned instances are primed for batch loading
+func (r *Resolver) ProcessInstances(ctx context.Context, args processInstancesArgs) (*ProcessInstancePage, error) {
+	l, err := loadersFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	page, pageSize := pageArgs(args.Page, args.PageSize)
+	req := &processpb.ListProcessInstancesRequest{Page: page, PageSize: pageSize}
+	if args.Status != nil {
+		req.StatusFilter = *args.Status
+	}
+	if args.ProcessKey != nil {
+		req.ProcessKeyFilter = *args.ProcessKey
+	}
+
+	resp, err := l.process.ListProcessInstances(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list process instances: %w", err)
+	}
+
+	result := &ProcessInstancePage{
+		TotalCount: resp.TotalCount,
+		Page:       page,
+		PageSize:   pageSize,
+		Nodes:      make([]*ProcessInstance, 0, len(resp.Instances)),
+	}
+	ids := make([]string, 0, len(resp.Instances))
+	for _, info := range resp.Instances {
+		result.Nodes = append(result.Nodes, &ProcessInstance{
+			ID:              graphql.ID(info.InstanceId),
+			Key:             int64String(info.InstanceKey),
+			ProcessID:       info.ProcessKey, // List carries BPMN process ID in process_key
+			Status:          info.Status,
+			CurrentActivity: optionalString(info.CurrentActivity),
+			StartedAt:       unixTime(info.StartedAt),
+			UpdatedAt:       unixTime(info.UpdatedAt),
+			Variables:       jsonVariables(info.VariablesJson, info.Variables),
+		})
+		ids = append(ids, info.InstanceId)
+	}
+	l.prime(ids...)
+	return result, nil
+}
+
+// processDefinitionsArgs are arguments of processDefinitions field
+type processDefinitionsArgs struct {
+	Page     int32
+	PageSize int32
+}
+
+// ProcessDefinitions resolves processDefinitions
+func (r *Resolver) ProcessDefinitions(
+	ctx context.Context,
+	args processDefinitionsArgs,
+) (*ProcessDefinitionPage, error) {
+	l, err := loadersFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions, err := l.allDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	page, pageSize := pageArgs(args.Page, args.PageSize)
+	result := &ProcessDefinitionPage{
+		TotalCount: int32(len(definitions)),
+		Page:       page,
+		PageSize:   pageSize,
+		Nodes:      []*ProcessDefinition{},
+	}
+	start := int((page - 1) * pageSize)
+	for i := start; i < len(definitions) && i < start+int(pageSize); i++ {
+		result.Nodes = append(result.Nodes, newProcessDefinition(definitions[i]))
+	}
+	return result, nil
+}
+
+// processDefinitionArgs are arguments of processDefinition field
+type processDefinitionArgs struct {
+	Key graphql.ID
+}
+
+// ProcessDefinition resolves processDefinition(key)
+func (r *Resolver) ProcessDefinition(ctx context.Context, args processDefinitionArgs) (*ProcessDefinition, error) {
+	l, err := loadersFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions, err := l.allDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, definition := range definitions {
+		if definition.ProcessKey == string(args.Key) {
+			return newProcessDefinition(definition), nil
+		}
+	}
+	return nil, nil
+}
+
+// JobStats resolves jobStats
+func (r *Resolver) JobStats(ctx context.Context) (*JobStats, error) {
+	l, err := loadersFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := l.jobs.GetJobStats(ctx, &jobspb.GetJobStatsRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job stats: %w", err)
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("failed to get job stats: %s", resp.ErrorMessage)
+	}
+
+	stats := resp.Stats
+	if stats == nil {
+		stats = &jobspb.JobStats{}
+	}
+	return &JobStats{
+		TotalJobs:      stats.TotalJobs,
+		ActiveJobs:     stats.ActiveJobs,
+		CompletedJobs:  stats.CompletedJobs,
+		FailedJobs:     stats.FailedJobs,
+		ActivatedToday: stats.ActivatedToday,
+		CompletedToday: stats.CompletedToday,
+	}, nil
+}
+
+// messageStatsArgs are arguments of messageStats field
+type messageStatsArgs struct {
+	TenantID *string
+}
+
+// MessageStats resolves messageStats
+func (r *Resolver) MessageStats(ctx context.Context, args messageStatsArgs) (*MessageStats, error) {
+	l, err := loadersFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &messagespb.GetMessageStatsRequest{}
+	if args.TenantID != nil {
+		req.TenantId = *args.TenantID
+	}
+	resp, err := l.messages.GetMessageStats(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message stats: %w", err)
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("failed to get message stats: %s", resp.Message)
+	}
+
+	stats := resp.Stats
+	if stats == nil {
+		stats = &messagespb.MessageStats{}
+	}
+	return &MessageStats{
+		TotalMessages:          stats.TotalMessages,
+		BufferedMessages:       stats.BufferedMessages,
+		ExpiredMessages:        stats.ExpiredMessages,
+		PublishedToday:         stats.PublishedToday,
+		InstancesCreatedToday:  stats.InstancesCreatedToday,
+		CorrelatedImmediately:  stats.CorrelatedImmediately,
+		CorrelatedFromBuffer:   stats.CorrelatedFromBuffer,
+		ExpiredUncorrelated:    stats.ExpiredUncorrelated,
+		CorrelationSuccessRate: stats.CorrelationSuccessRate,
+	}, nil
+}
+
+// ProcessInstancePage is page of process instances
+type ProcessInstancePage struct {
+	TotalCount int32
+	Page       int32
+	PageSize   int32
+	Nodes      []*ProcessInstance
+}
+
+// ProcessDefinitionPage is page of process definitions
+type ProcessDefinitionPage struct {
+	TotalCount int32
+	Page       int32
+	PageSize   int32
+	Nodes      []*ProcessDefinition
+}
+
+// ProcessInstance is process instance with nested children resolved through request loaders
+type ProcessInstance struct {
+	ID              graphql.ID
+	Key             *string
+	ProcessID       string
+	ProcessKey      *string
+	Status          string
+	CurrentActivity *string
+	StartedAt       *graphql.Time
+	UpdatedAt       *graphql.Time
+	Variables       *JSON
+}
+
+// stateArgs are optional state filter of nested lists
+type stateArgs struct {
+	State *string
+}
+
+// statusArgs are optional status filter of nested lists
+type statusArgs struct {
+	Status *string
+}
+
+// Definition resolves definition of instance
+// Instances loaded through processInstances have no process key, latest version of process ID is returned
+func (p *ProcessInstance) Definition(ctx context.Context) (*ProcessDefinition, error) {
+	l, err := loadersFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions, err := l.allDefinitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var latest *parserpb.BPMNProcessSummary
+	for _, definition := range definitions {
+		if p.ProcessKey != nil {
+			if definition.ProcessKey == *p.ProcessKey {
+				return newProcessDefinition(definition), nil
+			}
+			continue
+		}
+		if definition.ProcessId == p.ProcessID &&
+			(latest == nil || versionNumber(definition.Version) > versionNumber(latest.Version)) {
+			latest = definition
+		}
+	}
+	if latest == nil {
+		return nil, nil
+	}
+	return newProcessDefinition(latest), nil
+}
+
+// Tokens resolves tokens of instance
+func (p *ProcessInstance) Tokens(ctx context.Context, args stateArgs) ([]*Token, error) {
+	l, err := loadersFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := l.tokens(ctx, string(p.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Token, 0, len(tokens))
+	for _, token := range tokens {
+		if args.State != nil && !strings.EqualFold(token.State, *args.State) {
+			continue
+		}
+		result = append(result, &Token{
+			ID:         graphql.ID(token.TokenId),
+			ElementID:  token.CurrentElementId,
+			State:      token.State,
+			WaitingFor: optionalString(token.WaitingFor),
+			CreatedAt:  unixTime(token.CreatedAt),
+			UpdatedAt:  unixTime(token.UpdatedAt),
+			Variables:  jsonVariables(token.VariablesJson, token.Variables),
+		})
+	}
+	return result, nil
+}
+
+// History resolves elements passed by tokens of instance, ordered by time token entered element
+func (p *ProcessInstance) History(ctx context.Context) ([]*HistoryStep, error) {
+	l, err := loadersFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := l.tokens(ctx, string(p.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	steps := make([]*HistoryStep, 0, len(tokens))
+	for _, token := range tokens {
+		step := &HistoryStep{
+			ElementID: token.CurrentElementId,
+			TokenID:   graphql.ID(token.TokenId),
+			State:     token.State,
+			EnteredAt: unixTime(token.CreatedAt),
+		}
+		if token.State != string(models.TokenStateActive) && token.State != string(models.TokenStateWaiting) {
+			step.LeftAt = unixTime(token.UpdatedAt)
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// Jobs resolves jobs of instance
+func (p *ProcessInstance) Jobs(ctx context.Context, args statusArgs) ([]*Job, error) {
+	l, err := loadersFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs, err := l.instanceJobs(ctx, string(p.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Job, 0, len(jobs))
+	for _, job := range jobs {
+		if args.Status != nil && !strings.EqualFold(job.Status, *args.Status) {
+			continue
+		}
+		item := &Job{
+			ID:            graphql.ID(job.Id),
+			Key:           int64String(job.Key),
+			Type:          job.Type,
+			Status:        job.Status,
+			Worker:        optionalString(job.Worker),
+			Retries:       job.Retries,
+			Priority:      job.Priority,
+			ElementID:     job.ElementId,
+			ErrorMessage:  optionalString(job.ErrorMessage),
+			CreatedAt:     unixTime(job.CreatedAt),
+			CustomHeaders: &JSON{Value: job.CustomHeaders},
+			Variables:     jsonVariables(job.VariablesJson, job.Variables),
+		}
+		if job.NextRetryAt > 0 {
+			item.NextRetryAt = &graphql.Time{Time: time.UnixMilli(job.NextRetryAt)}
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// Incidents resolves incidents of instance, status filter accepts "OPEN" as well as "INCIDENT_STATUS_OPEN"
+func (p *ProcessInstance) Incidents(ctx context.Context, args statusArgs) ([]*Incident, error) {
+	l, err := loadersFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	incidents, err := l.instanceIncidents(ctx, string(p.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Incident, 0, len(incidents))
+	for _, incident := range incidents {
+		status := strings.TrimPrefix(incident.Status.String(), "INCIDENT_STATUS_")
+		if args.Status != nil && !strings.EqualFold(status, strings.TrimPrefix(*args.Status, "INCIDENT_STATUS_")) {
+			continue
+		}
+		result = append(result, &Incident{
+			ID:         graphql.ID(incident.Id),
+			Type:       strings.TrimPrefix(incident.Type.String(), "INCIDENT_TYPE_"),
+			Status:     status,
+			Message:    incident.Message,
+			ErrorCode:  optionalString(incident.ErrorCode),
+			ElementID:  optionalString(incident.ElementId),
+			JobKey:     optionalString(incident.JobKey),
+			TimerID:    optionalString(incident.TimerId),
+			CreatedAt:  timestampTime(incident.CreatedAt),
+			ResolvedAt: timestampTime(incident.ResolvedAt),
+		})
+	}
+	return result, nil
+}
+
+// Timers resolves timers of instance
+func (p *ProcessInstance) Timers(ctx context.Context, args statusArgs) ([]*Timer, error) {
+	l, err := loadersFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	timers, err := l.instanceTimers(ctx, string(p.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*Timer, 0, len(timers))
+	for _, timer := range timers {
+		if args.Status != nil && !strings.EqualFold(timer.Status, *args.Status) {
+			continue
+		}
+		result = append(result, newTimer(timer))
+	}
+	return result, nil
+}
+
+// ProcessDefinition is deployed process definition
+type ProcessDefinition struct {
+	Key           graphql.ID
+	ProcessID     string
+	Name          *string
+	Version       string
+	Status        *string
+	TotalElements int32
+	CreatedAt     *string
+	UpdatedAt     *string
+}
+
+// Token is token of process instance
+type Token struct {
+	ID         graphql.ID
+	ElementID  string
+	State      string
+	WaitingFor *string
+	CreatedAt  *graphql.Time
+	UpdatedAt  *graphql.Time
+	Variables  *JSON
+}
+
+// HistoryStep is element passed by token
+type HistoryStep struct {
+	ElementID string
+	TokenID   graphql.ID
+	State     string
+	EnteredAt *graphql.Time
+	LeftAt    *graphql.Time
+}
+
+// Job is job created by service task of instance
+type Job struct {
+	ID            graphql.ID
+	Key           *string
+	Type          string
+	Status        string
+	Worker        *string
+	Retries       int32
+	Priority      int32
+	ElementID     string
+	ErrorMessage  *string
+	CreatedAt     *graphql.Time
+	NextRetryAt   *graphql.Time
+	CustomHeaders *JSON
+	Variables     *JSON
+}
+
+// Incident is incident of instance
+type Incident struct {
+	ID         graphql.ID
+	Type       string
+	Status     string
+	Message    string
+	ErrorCode  *string
+	ElementID  *string
+	JobKey     *string
+	TimerID    *string
+	CreatedAt  *graphql.Time
+	ResolvedAt *graphql.Time
+}
+
+// Timer is timer of instance
+type Timer struct {
+	ID               graphql.ID
+	ElementID        string
+	Type             string
+	Status           string
+	ScheduledAt      *graphql.Time
+	TimeDuration     *string
+	TimeCycle        *string
+	RemainingSeconds int32
+}
+
+// JobStats is snapshot of job counters
+type JobStats struct {
+	TotalJobs      int32
+	ActiveJobs     int32
+	CompletedJobs  int32
+	FailedJobs     int32
+	ActivatedToday int32
+	CompletedToday int32
+}
+
+// MessageStats is snapshot of message counters
+type MessageStats struct {
+	TotalMessages          int32
+	BufferedMessages       int32
+	ExpiredMessages        int32
+	PublishedToday         int32
+	InstancesCreatedToday  int32
+	CorrelatedImmediately  int32
+	CorrelatedFromBuffer   int32
+	ExpiredUncorrelated    int32
+	CorrelationSuccessRate float64
+}
+
+// newProcessDefinition converts parser summary to GraphQL definition
+func newProcessDefinition(summary *parserpb.BPMNProcessSummary) *ProcessDefinition {
+	return &ProcessDefinition{
+		Key:           graphql.ID(summary.ProcessKey),
+		ProcessID:     summary.ProcessId,
+		Name:          optionalString(summary.ProcessName),
+		Version:       summary.Version,
+		Status:        optionalString(summary.Status),
+		TotalElements: summary.TotalElements,
+		CreatedAt:     optionalString(summary.CreatedAt),
+		UpdatedAt:     optionalString(summary.UpdatedAt),
+	}
+}
+
+// newTimer converts timer info to GraphQL timer
+func newTimer(timer *timewheelpb.TimerInfo) *Timer {
+	remaining := timer.RemainingSeconds
+	if remaining < 0 {
+		remaining = 0
+	}
+	return &Timer{
+		ID:               graphql.ID(timer.TimerId),
+		ElementID:        timer.ElementId,
+		Type:             timer.TimerType,
+		Status:           timer.Status,
+		ScheduledAt:      unixTime(timer.ScheduledAt),
+		TimeDuration:     optionalString(timer.TimeDuration),
+		TimeCycle:        optionalString(timer.TimeCycle),
+		RemainingSeconds: int32(min(remaining, int64(^uint32(0)>>1))),
+	}
+}
+
+// pageArgs normalizes page arguments
+func pageArgs(page, pageSize int32) (int32, int32) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+	return page, pageSize
+}
+
+// jsonVariables returns typed variables from JSON document, falling back to string map
+func jsonVariables(variablesJSON string, variables map[string]string) *JSON {
+	if variablesJSON != "" {
+		if decoded, err := models.DecodeVariables([]byte(variablesJSON)); err == nil {
+			return &JSON{Value: decoded}
+		}
+	}
+	if variables == nil {
+		variables = map[string]string{}
+	}
+	return &JSON{Value: variables}
+}
+
+// unixTime converts Unix seconds to GraphQL time, zero is null
+func unixTime(seconds int64) *graphql.Time {
+	if seconds <= 0 {
+		return nil
+	}
+	return &graphql.Time{Time: time.Unix(seconds, 0)}
+}
+
+// timestampTime converts protobuf timestamp to GraphQL time, unset is null
+func timestampTime(ts *timestamppb.Timestamp) *graphql.Time {
+	if ts == nil || (ts.Seconds == 0 && ts.Nanos == 0) {
+		return nil
+	}
+	return &graphql.Time{Time: ts.AsTime()}
+}
+
+// int64String formats 64-bit key as string, zero is null
+func int64String(value int64) *string {
+	if value == 0 {
+		return nil
+	}
+	s := strconv.FormatInt(value, 10)
+	return &s
+}
+
+// optionalString returns nil for empty string
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
+// versionNumber parses version like "v3" or "3", unparsable version is 0
+func versionNumber(version string) int {
+	n, _ := strconv.Atoi(strings.TrimPrefix(version, "v"))
+	return n
+}
@@ -0,0 +1,205 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package graphqlapi
+
+import (
+	"fmt"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// Default query limits
+const (
+	DefaultMaxDepth       = 8
+	DefaultMaxComplexity  = 5000
+	DefaultMaxParallelism = 10
+)
+
+// Schema is read-only GraphQL schema over operational data of engine
+// Timestamps are RFC 3339 strings, 64-bit keys are strings since GraphQL Int is 32-bit
+const Schema = `
+schema {
+	query: Query
+}
+
+scalar Time
+scalar JSON
+
+type Query {
+	# Process instance by ID or numeric key
+	processInstance(id: ID!): ProcessInstance
+	# Process instances ordered by start time, newest first
+	# processKey filters by storage key of definition version, e.g. "OrderProcess:v1"
+	processInstances(status: String, processKey: String, page: Int = 1, pageSize: Int = 20): ProcessInstancePage!
+	# Deployed process definitions, all versions
+	processDefinitions(page: Int = 1, pageSize: Int = 20): ProcessDefinitionPage!
+	# Process definition by process key
+	processDefinition(key: ID!): ProcessDefinition
+	jobStats: JobStats!
+	messageStats(tenantId: String): MessageStats!
+}
+
+type ProcessInstancePage {
+	totalCount: Int!
+	page: Int!
+	pageSize: Int!
+	nodes: [ProcessInstance!]!
+}
+
+type ProcessDefinitionPage {
+	totalCount: Int!
+	page: Int!
+	pageSize: Int!
+	nodes: [ProcessDefinition!]!
+}
+
+type ProcessInstance {
+	id: ID!
+	key: String
+	processId: String!
+	# Process key of definition, empty for instances loaded through processInstances
+	processKey: String
+	status: String!
+	currentActivity: String
+	startedAt: Time
+	updatedAt: Time
+	variables: JSON
+	definition: ProcessDefinition
+	tokens(state: String): [Token!]!
+	jobs(status: String): [Job!]!
+	incidents(status: String): [Incident!]!
+	timers(status: String): [Timer!]!
+	# Elements passed by tokens of instance in order of entering
+	history: [HistoryStep!]!
+}
+
+type ProcessDefinition {
+	key: ID!
+	processId: String!
+	name: String
+	version: String!
+	status: String
+	totalElements: Int!
+	createdAt: String
+	updatedAt: String
+}
+
+type Token {
+	id: ID!
+	elementId: String!
+	state: String!
+	waitingFor: String
+	createdAt: Time
+	updatedAt: Time
+	variables: JSON
+}
+
+type HistoryStep {
+	elementId: String!
+	tokenId: ID!
+	state: String!
+	enteredAt: Time
+	leftAt: Time
+}
+
+type Job {
+	id: ID!
+	key: String
+	type: String!
+	status: String!
+	worker: String
+	retries: Int!
+	priority: Int!
+	elementId: String!
+	errorMessage: String
+	createdAt: Time
+	nextRetryAt: Time
+	customHeaders: JSON
+	variables: JSON
+}
+
+type Incident {
+	id: ID!
+	type: String!
+	status: String!
+	message: String!
+	errorCode: String
+	elementId: String
+	jobKey: String
+	timerId: String
+	createdAt: Time
+	resolvedAt: Time
+}
+
+type Timer {
+	id: ID!
+	elementId: String!
+	type: String!
+	status: String!
+	scheduledAt: Time
+	timeDuration: String
+	timeCycle: String
+	remainingSeconds: Int!
+}
+
+type JobStats {
+	totalJobs: Int!
+	activeJobs: Int!
+	completedJobs: Int!
+	failedJobs: Int!
+	activatedToday: Int!
+	completedToday: Int!
+}
+
+type MessageStats {
+	totalMessages: Int!
+	bufferedMessages: Int!
+	expiredMessages: Int!
+	publishedToday: Int!
+	instancesCreatedToday: Int!
+	correlatedImmediately: Int!
+	correlatedFromBuffer: Int!
+	expiredUncorrelated: Int!
+	correlationSuccessRate: Float!
+}
+`
+
+// Config holds query limits of GraphQL endpoint, non-positive values use defaults
+type Config struct {
+	MaxDepth       int
+	MaxComplexity  int
+	MaxParallelism int
+}
+
+// withDefaults returns config with non-positive limits replaced by defaults
+func (c Config) withDefaults() Config {
+	if c.MaxDepth <= 0 {
+		c.MaxDepth = DefaultMaxDepth
+	}
+	if c.MaxComplexity <= 0 {
+		c.MaxComplexity = DefaultMaxComplexity
+	}
+	if c.MaxParallelism <= 0 {
+		c.MaxParallelism = DefaultMaxParallelism
+	}
+	return c
+}
+
+// parseSchema parses schema with root resolver and depth limit
+func parseSchema(config Config) (*graphql.Schema, error) {
+	schema, err := graphql.ParseSchema(Schema, &Resolver{},
+		graphql.UseFieldResolvers(),
+		graphql.MaxDepth(config.MaxDepth),
+		graphql.MaxParallelism(config.MaxParallelism),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse GraphQL schema: %w", err)
+	}
+	return schema, nil
+}
@@ -0,0 +1,237 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package restapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/protobuf/proto"
+
+	"atom-engine/proto/expression/expressionpb"
+	"atom-engine/src/core/grpc"
+	"atom-engine/src/core/restapi/handlers"
+	"atom-engine/src/expression"
+)
+
+// consoleOrigin is origin of browser console calling REST port in tests
+const consoleOrigin = "http://console.local"
+
+// evaluateExpressionPath is grpc-web path of EvaluateExpression
+const evaluateExpressionPath = handlers.GRPCWebPathPrefix + "/expression.ExpressionService/EvaluateExpression"
+
+// fetchScript performs browser-like grpc-web-text call with fetch of headless Node.js runtime
+// and prints response status, headers and body as JSON
+const fetchScript = `
+const [url, origin, body] = process.argv.slice(1);
+fetch(url, {
+  method: "POST",
+  headers: {
+    "Origin": origin,
+    "Content-Type": "application/grpc-web-text",
+    "Accept": "application/grpc-web-text",
+    "X-Grpc-Web": "1",
+    "X-User-Agent": "grpc-web-javascript/0.1",
+  },
+  body,
+}).then(async (res) => {
+  console.log(JSON.stringify({
+    status: res.status,
+    contentType: res.headers.get("content-type"),
+    allowOrigin: res.headers.get("access-control-allow-origin"),
+    exposeHeaders: res.headers.get("access-control-expose-headers"),
+    body: await res.text(),
+  }));
+}).catch((err) => {
+  console.error(err);
+  process.exit(1);
+});
+`
+
+// grpcWebCore serves expression component through real gRPC server, other methods are not implemented
+type grpcWebCore struct {
+	CoreInterface
+	expression *expression.Component
+	grpcServer *grpc.Server
+}
+
+func (c *grpcWebCore) GetAuthComponent() interface{} { return nil }
+
+func (c *grpcWebCore) GetStorage() interface{} { return nil }
+
+func (c *grpcWebCore) SimulationEnabled() bool { return false }
+
+func (c *grpcWebCore) GetExpressionComponent() interface{} { return c.expression }
+
+func (c *grpcWebCore) GetGRPCConnection() (interface{}, error) {
+	return c.grpcServer.GetLoopbackConnection()
+}
+
+// startGRPCWebServer starts gRPC server and REST server with grpc-web and CORS enabled
+func startGRPCWebServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	expressionComponent := expression.NewComponent(nil)
+	if err := expressionComponent.Init(); err != nil {
+		t.Fatalf("init expression component: %v", err)
+	}
+	if err := expressionComponent.Start(); err != nil {
+		t.Fatalf("start expression component: %v", err)
+	}
+	t.Cleanup(func() { expressionComponent.Stop() })
+
+	// Loopback connection dials configured port, so free port is reserved up front
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("reserve gRPC port: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	core := &grpcWebCore{expression: expressionComponent}
+	core.grpcServer = grpc.NewServer(&grpc.Config{Port: port}, core)
+	if err := core.grpcServer.Start(); err != nil {
+		t.Fatalf("start gRPC server: %v", err)
+	}
+	t.Cleanup(func() { core.grpcServer.Stop() })
+
+	config := DefaultConfig()
+	config.Mode = gin.TestMode
+	config.CORS.AllowedOrigins = []string{consoleOrigin}
+	config.GRPCWeb = &handlers.GRPCWebConfig{Enabled: true}
+	server := httptest.NewServer(NewServer(config, core).router)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// evaluateExpressionFrame returns grpc-web data frame of EvaluateExpression request
+func evaluateExpressionFrame(t *testing.T, expr, context string) []byte {
+	t.Helper()
+
+	message, err := proto.Marshal(&expressionpb.EvaluateExpressionRequest{Expression: expr, Context: context})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	frame := make([]byte, 5, 5+len(message))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(message)))
+	return append(frame, message...)
+}
+
+// decodeEvaluateExpressionResponse splits grpc-web response body into reply message and trailers
+func decodeEvaluateExpressionResponse(t *testing.T, body []byte) (*expressionpb.EvaluateExpressionResponse, string) {
+	t.Helper()
+
+	reply := &expressionpb.EvaluateExpressionResponse{}
+	var trailers string
+	for len(body) > 0 {
+		if len(body) < 5 {
+			t.Fatalf("truncated grpc-web frame header: %x", body)
+		}
+		flags, length := body[0], binary.BigEndian.Uint32(body[1:5])
+		if uint32(len(body)-5) < length {
+			t.Fatalf("truncated grpc-web frame of %d bytes", length)
+		}
+		payload := body[5 : 5+length]
+		body = body[5+length:]
+
+		if flags&0x80 != 0 {
+			trailers = string(payload)
+			continue
+		}
+		if err := proto.Unmarshal(payload, reply); err != nil {
+			t.Fatalf("unmarshal reply: %v", err)
+		}
+	}
+	return reply, trailers
+}
+
+func TestGRPCWebPreflightAllowsGRPCWebHeaders(t *testing.T) {
+	server := startGRPCWebServer(t)
+
+	request, _ := http.NewRequest(http.MethodOptions, server.URL+evaluateExpressionPath, nil)
+	request.Header.Set("Origin", consoleOrigin)
+	request.Header.Set("Access-Control-Request-Method", http.MethodPost)
+	request.Header.Set("Access-Control-Request-Headers", "content-type,x-grpc-web,x-user-agent,grpc-timeout")
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("preflight: %v", err)
+	}
+	response.Body.Close()
+
+	if response.StatusCode >= 300 {
+		t.Fatalf("preflight status %d", response.StatusCode)
+	}
+	if origin := response.Header.Get("Access-Control-Allow-Origin"); origin != consoleOrigin {
+		t.Errorf("allowed origin %q, want %s", origin, consoleOrigin)
+	}
+	allowed := strings.ToLower(response.Header.Get("Access-Control-Allow-Headers"))
+	for _, header := range []string{"content-type", "x-grpc-web", "x-user-agent", "grpc-timeout"} {
+		if !strings.Contains(allowed, header) {
+			t.Errorf("preflight does not allow %s: %q", header, allowed)
+		}
+	}
+}
+
+func TestGRPCWebEvaluateExpressionWithHeadlessFetch(t *testing.T) {
+	node, err := exec.LookPath("node")
+	if err != nil {
+		t.Skip("node is not installed")
+	}
+	server := startGRPCWebServer(t)
+
+	body := base64.StdEncoding.EncodeToString(
+		evaluateExpressionFrame(t, "= order.total > 100", `{"order":{"total":150}}`))
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	output, err := exec.CommandContext(ctx, node, "-e", fetchScript,
+		server.URL+evaluateExpressionPath, consoleOrigin, body).Output()
+	if err != nil {
+		t.Fatalf("headless fetch: %v", err)
+	}
+
+	var response struct {
+		Status        int    `json:"status"`
+		ContentType   string `json:"contentType"`
+		AllowOrigin   string `json:"allowOrigin"`
+		ExposeHeaders string `json:"exposeHeaders"`
+		Body          string `json:"body"`
+	}
+	if err := json.Unmarshal(output, &response); err != nil {
+		t.Fatalf("decode fetch output %q: %v", output, err)
+	}
+	if response.Status != http.StatusOK || !strings.HasPrefix(response.ContentType, "application/grpc-web-text") {
+		t.Fatalf("fetch response %d %s: %s", response.Status, response.ContentType, response.Body)
+	}
+	if response.AllowOrigin != consoleOrigin || !strings.Contains(response.ExposeHeaders, "Grpc-Status") {
+		t.Errorf("CORS headers do not let browser read response: origin %q, exposed %q",
+			response.AllowOrigin, response.ExposeHeaders)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(response.Body)
+	if err != nil {
+		t.Fatalf("decode grpc-web-text body %q: %v", response.Body, err)
+	}
+	reply, trailers := decodeEvaluateExpressionResponse(t, raw)
+	if !strings.Contains(trailers, "grpc-status:0") {
+		t.Fatalf("trailers %q, want grpc-status 0", trailers)
+	}
+	if !reply.Success || reply.Result != "true" || reply.ResultType != "boolean" {
+		t.Errorf("reply %+v, want successful boolean true", reply)
+	}
+}
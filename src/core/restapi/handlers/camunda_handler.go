@@ -0,0 +1,706 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"atom-engine/src/core/logger"
+	coremodels "atom-engine/src/core/models"
+	"atom-engine/src/core/restapi/middleware"
+	"atom-engine/src/core/restapi/models"
+	"atom-engine/src/core/restapi/utils"
+	"atom-engine/src/jobs"
+)
+
+// CamundaExternalTaskPath is root path of Camunda 7 compatible external task API
+const CamundaExternalTaskPath = "/engine-rest/external-task"
+
+// camundaDateFormat is default date format of Camunda 7 REST API
+const camundaDateFormat = "2006-01-02T15:04:05.000-0700"
+
+// maxCamundaAsyncResponseTimeout is upper limit of long polling, same as in Camunda 7
+const maxCamundaAsyncResponseTimeout = 1800000
+
+// camundaPollInterval is how often long polling fetchAndLock retries activation
+const camundaPollInterval = 500 * time.Millisecond
+
+// CamundaCompatConfig holds Camunda 7 compatibility API configuration
+type CamundaCompatConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// CamundaCoreInterface defines methods needed for Camunda external task API
+type CamundaCoreInterface interface {
+	GetJobsComponent() interface{}
+	PatchProcessInstanceVariables(instanceID string, patch map[string]interface{}) (map[string]interface{}, error)
+}
+
+// CamundaJobsClient is subset of jobs component external tasks are mapped to
+type CamundaJobsClient interface {
+	ActivateJobsWithTimeout(workerName, jobType string, maxJobs int, timeoutMs int32) ([]jobs.JobInfo, error)
+	GetJob(jobID string) (*jobs.JobInfo, error)
+	CompleteJob(jobKey string, variables map[string]interface{}) error
+	FailJobWithBackoff(jobKey string, retries int, errorMessage string, retryBackoff time.Duration) error
+	ThrowErrorWithVariables(jobKey string, errorCode, errorMessage string, variables map[string]interface{}) error
+	UpdateJobTimeout(jobKey string, timeout time.Duration) error
+	UnlockJob(jobKey string) error
+}
+
+// CamundaHandler serves Camunda 7 external task API over jobs, so that unmodified
+// Camunda external task clients can work with engine
+// Topics are job types, external task IDs are job keys
+type CamundaHandler struct {
+	coreInterface   CamundaCoreInterface
+	variableLimiter *utils.VariableLimiter
+}
+
+// CamundaVariable is typed variable value of Camunda REST API
+type CamundaVariable struct {
+	Value     interface{}            `json:"value"`
+	Type      string                 `json:"type,omitempty"`
+	ValueInfo map[string]interface{} `json:"valueInfo"`
+}
+
+// CamundaFetchAndLockRequest is body of POST /engine-rest/external-task/fetchAndLock
+type CamundaFetchAndLockRequest struct {
+	WorkerID             string              `json:"workerId"`
+	MaxTasks             int                 `json:"maxTasks"`
+	UsePriority          bool                `json:"usePriority"`
+	AsyncResponseTimeout int64               `json:"asyncResponseTimeout"`
+	Topics               []CamundaTopicFetch `json:"topics"`
+}
+
+// CamundaTopicFetch describes topic of fetchAndLock request
+// Variables limits returned variables, nil returns all of them
+type CamundaTopicFetch struct {
+	TopicName    string   `json:"topicName"`
+	LockDuration int64    `json:"lockDuration"`
+	Variables    []string `json:"variables"`
+}
+
+// CamundaLockedExternalTask is external task returned by fetchAndLock
+type CamundaLockedExternalTask struct {
+	ID                   string                     `json:"id"`
+	TopicName            string                     `json:"topicName"`
+	WorkerID             string                     `json:"workerId"`
+	LockExpirationTime   string                     `json:"lockExpirationTime"`
+	CreateTime           string                     `json:"createTime"`
+	ProcessInstanceID    string                     `json:"processInstanceId"`
+	ProcessDefinitionID  string                     `json:"processDefinitionId"`
+	ProcessDefinitionKey string                     `json:"processDefinitionKey"`
+	ActivityID           string                     `json:"activityId"`
+	ActivityInstanceID   string                     `json:"activityInstanceId"`
+	ExecutionID          string                     `json:"executionId"`
+	Retries              int                        `json:"retries"`
+	Priority             int                        `json:"priority"`
+	Suspended            bool                       `json:"suspended"`
+	BusinessKey          *string                    `json:"businessKey"`
+	TenantID             *string                    `json:"tenantId"`
+	ErrorMessage         *string                    `json:"errorMessage"`
+	ErrorDetails         *string                    `json:"errorDetails"`
+	ExtensionProperties  map[string]string          `json:"extensionProperties"`
+	Variables            map[string]CamundaVariable `json:"variables"`
+}
+
+// CamundaCompleteRequest is body of POST /engine-rest/external-task/:id/complete
+type CamundaCompleteRequest struct {
+	WorkerID       string                     `json:"workerId"`
+	Variables      map[string]CamundaVariable `json:"variables"`
+	LocalVariables map[string]CamundaVariable `json:"localVariables"`
+}
+
+// CamundaFailureRequest is body of POST /engine-rest/external-task/:id/failure
+type CamundaFailureRequest struct {
+	WorkerID       string                     `json:"workerId"`
+	ErrorMessage   string                     `json:"errorMessage"`
+	ErrorDetails   string                     `json:"errorDetails"`
+	Retries        *int                       `json:"retries"`
+	RetryTimeout   int64                      `json:"retryTimeout"`
+	Variables      map[string]CamundaVariable `json:"variables"`
+	LocalVariables map[string]CamundaVariable `json:"localVariables"`
+}
+
+// CamundaBpmnErrorRequest is body of POST /engine-rest/external-task/:id/bpmnError
+type CamundaBpmnErrorRequest struct {
+	WorkerID     string                     `json:"workerId"`
+	ErrorCode    string                     `json:"errorCode"`
+	ErrorMessage string                     `json:"errorMessage"`
+	Variables    map[string]CamundaVariable `json:"variables"`
+}
+
+// CamundaExtendLockRequest is body of POST /engine-rest/external-task/:id/extendLock
+type CamundaExtendLockRequest struct {
+	WorkerID    string `json:"workerId"`
+	NewDuration int64  `json:"newDuration"`
+}
+
+// CamundaErrorResponse is error body of Camunda REST API
+type CamundaErrorResponse struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// NewCamundaHandler creates new Camunda external task handler
+func NewCamundaHandler(coreInterface CamundaCoreInterface, variableLimiter *utils.VariableLimiter) *CamundaHandler {
+	return &CamundaHandler{
+		coreInterface:   coreInterface,
+		variableLimiter: variableLimiter,
+	}
+}
+
+// RegisterRoutes registers external task routes on root router
+func (h *CamundaHandler) RegisterRoutes(router gin.IRouter, authMiddleware *middleware.AuthMiddleware) {
+	externalTasks := router.Group(CamundaExternalTaskPath)
+
+	// External task operations are job operations
+	if authMiddleware != nil {
+		externalTasks.Use(authMiddleware.RequirePermission("job"))
+	}
+
+	{
+		externalTasks.POST("/fetchAndLock", h.FetchAndLock)
+		externalTasks.POST("/:id/complete", h.Complete)
+		externalTasks.POST("/:id/failure", h.Failure)
+		externalTasks.POST("/:id/bpmnError", h.BpmnError)
+		externalTasks.POST("/:id/extendLock", h.ExtendLock)
+		externalTasks.POST("/:id/unlock", h.Unlock)
+	}
+}
+
+// FetchAndLock handles POST /engine-rest/external-task/fetchAndLock
+// Activates jobs of requested topics with topic lock duration as job timeout.
+// With asyncResponseTimeout request waits until at least one task is locked or timeout passes
+func (h *CamundaHandler) FetchAndLock(c *gin.Context) {
+	var req CamundaFetchAndLockRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.writeError(c, http.StatusBadRequest, "InvalidRequestException",
+			fmt.Sprintf("Invalid fetchAndLock request: %v", err))
+		return
+	}
+
+	if req.WorkerID == "" {
+		h.writeError(c, http.StatusBadRequest, "InvalidRequestException", "workerId is null")
+		return
+	}
+	if req.AsyncResponseTimeout > maxCamundaAsyncResponseTimeout {
+		h.writeError(c, http.StatusBadRequest, "InvalidRequestException", fmt.Sprintf(
+			"The asynchronous response timeout cannot be set to a value greater than %d milliseconds",
+			maxCamundaAsyncResponseTimeout))
+		return
+	}
+	for _, topic := range req.Topics {
+		if topic.TopicName == "" {
+			h.writeError(c, http.StatusBadRequest, "InvalidRequestException", "topicName is null")
+			return
+		}
+		if topic.LockDuration <= 0 || topic.LockDuration > math.MaxInt32 {
+			h.writeError(c, http.StatusBadRequest, "InvalidRequestException", fmt.Sprintf(
+				"lockDuration of topic %s must be between 1 and %d", topic.TopicName, math.MaxInt32))
+			return
+		}
+	}
+
+	jobsClient, ok := h.jobsClient(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	deadline := time.Now().Add(time.Duration(req.AsyncResponseTimeout) * time.Millisecond)
+	for {
+		tasks, err := h.fetchAndLock(jobsClient, &req)
+		if err != nil {
+			logger.Error("Failed to fetch and lock external tasks",
+				logger.String("request_id", utils.GetRequestID(c)),
+				logger.String("worker", req.WorkerID),
+				logger.String("error", err.Error()))
+			h.writeError(c, http.StatusInternalServerError, "ProcessEngineException", err.Error())
+			return
+		}
+
+		wait := time.Until(deadline)
+		if len(tasks) > 0 || wait <= 0 {
+			c.JSON(http.StatusOK, tasks)
+			return
+		}
+		if wait > camundaPollInterval {
+			wait = camundaPollInterval
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// fetchAndLock activates up to maxTasks jobs across topics in request order
+func (h *CamundaHandler) fetchAndLock(
+	jobsClient CamundaJobsClient,
+	req *CamundaFetchAndLockRequest,
+) ([]CamundaLockedExternalTask, error) {
+	tasks := make([]CamundaLockedExternalTask, 0)
+	for _, topic := range req.Topics {
+		remaining := req.MaxTasks - len(tasks)
+		if remaining <= 0 {
+			break
+		}
+
+		activated, err := jobsClient.ActivateJobsWithTimeout(
+			req.WorkerID, topic.TopicName, remaining, int32(topic.LockDuration))
+		if err != nil {
+			return tasks, fmt.Errorf("failed to lock tasks of topic %s: %w", topic.TopicName, err)
+		}
+		for i := range activated {
+			tasks = append(tasks, camundaLockedTask(&activated[i], &topic))
+		}
+	}
+	return tasks, nil
+}
+
+// Complete handles POST /engine-rest/external-task/:id/complete
+// Local variables have no separate scope and are merged over variables
+func (h *CamundaHandler) Complete(c *gin.Context) {
+	var req CamundaCompleteRequest
+	if !h.bindBody(c, &req) {
+		return
+	}
+
+	variables, err := camundaVariablesToValues(req.Variables)
+	if err == nil {
+		var local map[string]interface{}
+		local, err = camundaVariablesToValues(req.LocalVariables)
+		variables = mergeCamundaVariables(variables, local)
+	}
+	if err != nil {
+		h.writeError(c, http.StatusBadRequest, "InvalidRequestException", err.Error())
+		return
+	}
+	variables, ok := h.applyLimits(c, variables)
+	if !ok {
+		return
+	}
+
+	jobsClient, job, ok := h.lockedJob(c, req.WorkerID, "completed")
+	if !ok {
+		return
+	}
+
+	if err := jobsClient.CompleteJob(job.Key, variables); err != nil {
+		h.writeOperationError(c, job.Key, "complete", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Failure handles POST /engine-rest/external-task/:id/failure
+// Missing retries decrements retries of job, retryTimeout is backoff before task can be fetched again.
+// Variables are set on process instance before failure is reported
+func (h *CamundaHandler) Failure(c *gin.Context) {
+	var req CamundaFailureRequest
+	if !h.bindBody(c, &req) {
+		return
+	}
+	if req.Retries != nil && *req.Retries < 0 {
+		h.writeError(c, http.StatusBadRequest, "InvalidRequestException", "retries is negative")
+		return
+	}
+
+	variables, err := camundaVariablesToValues(req.Variables)
+	if err == nil {
+		var local map[string]interface{}
+		local, err = camundaVariablesToValues(req.LocalVariables)
+		variables = mergeCamundaVariables(variables, local)
+	}
+	if err != nil {
+		h.writeError(c, http.StatusBadRequest, "InvalidRequestException", err.Error())
+		return
+	}
+	variables, ok := h.applyLimits(c, variables)
+	if !ok {
+		return
+	}
+
+	jobsClient, job, ok := h.lockedJob(c, req.WorkerID, "failed")
+	if !ok {
+		return
+	}
+
+	retries := job.Retries - 1
+	if req.Retries != nil {
+		retries = *req.Retries
+	}
+	if retries < 0 {
+		retries = 0
+	}
+
+	if len(variables) > 0 {
+		if _, err := h.coreInterface.PatchProcessInstanceVariables(job.ProcessInstanceID, variables); err != nil {
+			h.writeOperationError(c, job.Key, "set variables of", err)
+			return
+		}
+	}
+
+	errorMessage := req.ErrorMessage
+	if errorMessage == "" {
+		errorMessage = req.ErrorDetails
+	}
+	backoff := time.Duration(req.RetryTimeout) * time.Millisecond
+	if err := jobsClient.FailJobWithBackoff(job.Key, retries, errorMessage, backoff); err != nil {
+		h.writeOperationError(c, job.Key, "fail", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// BpmnError handles POST /engine-rest/external-task/:id/bpmnError
+func (h *CamundaHandler) BpmnError(c *gin.Context) {
+	var req CamundaBpmnErrorRequest
+	if !h.bindBody(c, &req) {
+		return
+	}
+	if req.ErrorCode == "" {
+		h.writeError(c, http.StatusBadRequest, "InvalidRequestException", "errorCode is null")
+		return
+	}
+
+	variables, err := camundaVariablesToValues(req.Variables)
+	if err != nil {
+		h.writeError(c, http.StatusBadRequest, "InvalidRequestException", err.Error())
+		return
+	}
+	variables, ok := h.applyLimits(c, variables)
+	if !ok {
+		return
+	}
+
+	jobsClient, job, ok := h.lockedJob(c, req.WorkerID, "reported with a BPMN error")
+	if !ok {
+		return
+	}
+
+	if err := jobsClient.ThrowErrorWithVariables(job.Key, req.ErrorCode, req.ErrorMessage, variables); err != nil {
+		h.writeOperationError(c, job.Key, "throw BPMN error for", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ExtendLock handles POST /engine-rest/external-task/:id/extendLock
+// New lock expires newDuration from now, expired lock cannot be extended
+func (h *CamundaHandler) ExtendLock(c *gin.Context) {
+	var req CamundaExtendLockRequest
+	if !h.bindBody(c, &req) {
+		return
+	}
+	if req.NewDuration <= 0 {
+		h.writeError(c, http.StatusBadRequest, "InvalidRequestException",
+			"lockDuration is not greater than 0")
+		return
+	}
+
+	jobsClient, job, ok := h.lockedJob(c, req.WorkerID, "extended")
+	if !ok {
+		return
+	}
+	if job.Deadline == 0 || job.Deadline < time.Now().UnixMilli() {
+		h.writeError(c, http.StatusBadRequest, "BadUserRequestException", "Cannot extend a lock that expired")
+		return
+	}
+
+	if err := jobsClient.UpdateJobTimeout(job.Key, time.Duration(req.NewDuration)*time.Millisecond); err != nil {
+		h.writeOperationError(c, job.Key, "extend lock of", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Unlock handles POST /engine-rest/external-task/:id/unlock
+// Task can be fetched again by any worker, unlocking task that is not locked does nothing
+func (h *CamundaHandler) Unlock(c *gin.Context) {
+	jobsClient, job, ok := h.existingJob(c)
+	if !ok {
+		return
+	}
+	if job.Status != "RUNNING" {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	if err := jobsClient.UnlockJob(job.Key); err != nil {
+		h.writeOperationError(c, job.Key, "unlock", err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// jobsClient returns jobs component, writes error response when it is unavailable
+func (h *CamundaHandler) jobsClient(c *gin.Context) (CamundaJobsClient, bool) {
+	jobsClient, ok := h.coreInterface.GetJobsComponent().(CamundaJobsClient)
+	if !ok {
+		h.writeError(c, http.StatusInternalServerError, "ProcessEngineException", "jobs component is not available")
+		return nil, false
+	}
+	return jobsClient, true
+}
+
+// existingJob loads job of :id parameter, writes 404 response when job does not exist
+func (h *CamundaHandler) existingJob(c *gin.Context) (CamundaJobsClient, *jobs.JobInfo, bool) {
+	jobsClient, ok := h.jobsClient(c)
+	if !ok {
+		return nil, nil, false
+	}
+
+	id := c.Param("id")
+	job, err := jobsClient.GetJob(id)
+	if err != nil {
+		h.writeOperationError(c, id, "load", err)
+		return nil, nil, false
+	}
+	if job == nil {
+		h.writeError(c, http.StatusNotFound, "RestException",
+			fmt.Sprintf("External task with id %s does not exist", id))
+		return nil, nil, false
+	}
+	return jobsClient, job, true
+}
+
+// lockedJob loads job of :id parameter and checks it is locked by worker
+// Lock that expired is held until jobs component returns job to activatable state
+func (h *CamundaHandler) lockedJob(c *gin.Context, workerID, action string) (CamundaJobsClient, *jobs.JobInfo, bool) {
+	if workerID == "" {
+		h.writeError(c, http.StatusBadRequest, "InvalidRequestException", "workerId is null")
+		return nil, nil, false
+	}
+
+	jobsClient, job, ok := h.existingJob(c)
+	if !ok {
+		return nil, nil, false
+	}
+
+	lockedBy := ""
+	if job.Status == "RUNNING" {
+		lockedBy = job.Worker
+	}
+	if lockedBy != workerID {
+		h.writeError(c, http.StatusBadRequest, "BadUserRequestException", fmt.Sprintf(
+			"External Task %s cannot be %s by worker '%s'. It is locked by worker '%s'.",
+			job.Key, action, workerID, lockedBy))
+		return nil, nil, false
+	}
+	return jobsClient, job, true
+}
+
+// bindBody parses JSON request body, empty body leaves request zero
+func (h *CamundaHandler) bindBody(c *gin.Context, req interface{}) bool {
+	if c.Request.ContentLength == 0 {
+		return true
+	}
+	if err := c.ShouldBindJSON(req); err != nil {
+		h.writeError(c, http.StatusBadRequest, "InvalidRequestException", fmt.Sprintf("Invalid request body: %v", err))
+		return false
+	}
+	return true
+}
+
+// applyLimits enforces variable size limits like other job endpoints
+func (h *CamundaHandler) applyLimits(c *gin.Context, variables map[string]interface{}) (map[string]interface{}, bool) {
+	variables, apiErr := h.variableLimiter.Apply(variables, "variables")
+	if apiErr != nil {
+		h.writeError(c, models.HTTPStatusFromErrorCode(apiErr.Code), "InvalidRequestException", apiErr.Message)
+		return nil, false
+	}
+	return variables, true
+}
+
+// writeOperationError writes error of jobs component call
+func (h *CamundaHandler) writeOperationError(c *gin.Context, id, operation string, err error) {
+	logger.Warn("External task operation failed",
+		logger.String("request_id", utils.GetRequestID(c)),
+		logger.String("external_task_id", id),
+		logger.String("operation", operation),
+		logger.String("error", err.Error()))
+
+	if errors.Is(err, coremodels.ErrNotFound) {
+		h.writeError(c, http.StatusNotFound, "RestException",
+			fmt.Sprintf("External task with id %s does not exist", id))
+		return
+	}
+	h.writeError(c, http.StatusInternalServerError, "ProcessEngineException",
+		fmt.Sprintf("Cannot %s external task %s: %v", operation, id, err))
+}
+
+// writeError writes error in format of Camunda REST API
+func (h *CamundaHandler) writeError(c *gin.Context, status int, errorType, message string) {
+	c.JSON(status, CamundaErrorResponse{Type: errorType, Message: message})
+}
+
+// camundaLockedTask converts activated job to locked external task
+func camundaLockedTask(job *jobs.JobInfo, topic *CamundaTopicFetch) CamundaLockedExternalTask {
+	task := CamundaLockedExternalTask{
+		ID:                   job.Key,
+		TopicName:            job.Type,
+		WorkerID:             job.Worker,
+		LockExpirationTime:   time.UnixMilli(job.Deadline).Format(camundaDateFormat),
+		CreateTime:           time.Unix(job.CreatedAt, 0).Format(camundaDateFormat),
+		ProcessInstanceID:    job.ProcessInstanceID,
+		ProcessDefinitionID:  job.ProcessID,
+		ProcessDefinitionKey: job.ProcessID,
+		ActivityID:           job.ElementID,
+		ActivityInstanceID:   job.ElementInstanceID,
+		ExecutionID:          job.ElementInstanceID,
+		Retries:              job.Retries,
+		Priority:             job.Priority,
+		ExtensionProperties:  job.CustomHeaders,
+		Variables:            make(map[string]CamundaVariable),
+	}
+	if task.ExtensionProperties == nil {
+		task.ExtensionProperties = map[string]string{}
+	}
+
+	if topic.Variables == nil {
+		for name, value := range job.Variables {
+			task.Variables[name] = camundaVariableFromValue(value)
+		}
+		return task
+	}
+	for _, name := range topic.Variables {
+		if value, ok := job.Variables[name]; ok {
+			task.Variables[name] = camundaVariableFromValue(value)
+		}
+	}
+	return task
+}
+
+// camundaVariableFromValue converts engine value to typed Camunda variable
+// Integral numbers are Integer or Long by range, objects and arrays are Json strings
+func camundaVariableFromValue(value interface{}) CamundaVariable {
+	switch v := value.(type) {
+	case nil:
+		return CamundaVariable{Type: "Null", ValueInfo: map[string]interface{}{}}
+	case string:
+		return CamundaVariable{Value: v, Type: "String", ValueInfo: map[string]interface{}{}}
+	case bool:
+		return CamundaVariable{Value: v, Type: "Boolean", ValueInfo: map[string]interface{}{}}
+	case int:
+		return camundaNumberVariable(float64(v))
+	case int32:
+		return camundaNumberVariable(float64(v))
+	case int64:
+		return camundaNumberVariable(float64(v))
+	case float32:
+		return camundaNumberVariable(float64(v))
+	case float64:
+		return camundaNumberVariable(v)
+	case json.Number:
+		if f, err := v.Float64(); err == nil {
+			return camundaNumberVariable(f)
+		}
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return CamundaVariable{Value: fmt.Sprint(value), Type: "String", ValueInfo: map[string]interface{}{}}
+	}
+	return CamundaVariable{Value: string(data), Type: "Json", ValueInfo: map[string]interface{}{}}
+}
+
+// camundaNumberVariable converts number to Integer, Long or Double variable
+func camundaNumberVariable(value float64) CamundaVariable {
+	valueInfo := map[string]interface{}{}
+	if value != math.Trunc(value) || math.IsInf(value, 0) || math.Abs(value) > 1<<53 {
+		return CamundaVariable{Value: value, Type: "Double", ValueInfo: valueInfo}
+	}
+	if value >= math.MinInt32 && value <= math.MaxInt32 {
+		return CamundaVariable{Value: int64(value), Type: "Integer", ValueInfo: valueInfo}
+	}
+	return CamundaVariable{Value: int64(value), Type: "Long", ValueInfo: valueInfo}
+}
+
+// camundaVariablesToValues converts typed Camunda variables to engine values
+func camundaVariablesToValues(variables map[string]CamundaVariable) (map[string]interface{}, error) {
+	if len(variables) == 0 {
+		return nil, nil
+	}
+
+	values := make(map[string]interface{}, len(variables))
+	for name, variable := range variables {
+		value, err := camundaVariableToValue(variable)
+		if err != nil {
+			return nil, fmt.Errorf("cannot convert value of variable %s: %w", name, err)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// camundaVariableToValue converts typed Camunda variable to engine value
+// Type names are case-insensitive. Json values and Object values serialized as
+// application/json are parsed, Date, Bytes and File values are kept as strings
+func camundaVariableToValue(variable CamundaVariable) (interface{}, error) {
+	switch strings.ToLower(variable.Type) {
+	case "null":
+		return nil, nil
+	case "boolean":
+		if s, ok := variable.Value.(string); ok {
+			return strconv.ParseBool(s)
+		}
+	case "integer", "long", "short", "double":
+		if s, ok := variable.Value.(string); ok {
+			return strconv.ParseFloat(s, 64)
+		}
+	case "json":
+		return parseCamundaJSON(variable.Value)
+	case "object":
+		format, _ := variable.ValueInfo["serializationDataFormat"].(string)
+		if strings.HasPrefix(format, "application/json") {
+			return parseCamundaJSON(variable.Value)
+		}
+	}
+	return variable.Value, nil
+}
+
+// parseCamundaJSON parses JSON serialized value, already deserialized values are kept
+func parseCamundaJSON(value interface{}) (interface{}, error) {
+	s, ok := value.(string)
+	if !ok {
+		return value, nil
+	}
+
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(s), &parsed); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	return parsed, nil
+}
+
+// mergeCamundaVariables merges local variables over variables
+func mergeCamundaVariables(variables, local map[string]interface{}) map[string]interface{} {
+	if len(local) == 0 {
+		return variables
+	}
+	if variables == nil {
+		variables = make(map[string]interface{}, len(local))
+	}
+	for name, value := range local {
+		variables[name] = value
+	}
+	return variables
+}
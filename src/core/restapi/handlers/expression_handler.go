@@ -9,6 +9,7 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -17,6 +18,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"atom-engine/src/core/logger"
+	coremodels "atom-engine/src/core/models"
 	"atom-engine/src/core/restapi/middleware"
 	"atom-engine/src/core/restapi/models"
 	"atom-engine/src/core/restapi/utils"
@@ -33,7 +35,7 @@ type ExpressionHandler struct {
 type ExpressionCoreInterface interface {
 	GetExpressionComponent() interface{}
 	// JSON Message Routing would be used if expression component uses async communication
-	SendMessage(componentName, messageJSON string) error
+	SendMessageWithContext(ctx context.Context, componentName, messageJSON string) error
 }
 
 // ExpressionComponent interface for expression evaluation
@@ -41,6 +43,11 @@ type ExpressionComponent interface {
 	EvaluateExpression(expression string, variables map[string]interface{}) (interface{}, error)
 }
 
+// UnaryTestComponent interface for unary test evaluation
+type UnaryTestComponent interface {
+	EvaluateUnaryTest(input interface{}, test string, variables map[string]interface{}) (bool, error)
+}
+
 // Expression data types
 type ExpressionResult struct {
 	Result     interface{} `json:"result"`
@@ -49,6 +56,13 @@ type ExpressionResult struct {
 	Error      string      `json:"error,omitempty"`
 }
 
+// UnaryTestResult reports whether input satisfies unary test
+type UnaryTestResult struct {
+	Input  interface{} `json:"input"`
+	Test   string      `json:"test"`
+	Result bool        `json:"result"`
+}
+
 type BatchExpressionResult struct {
 	Results []ExpressionResult `json:"results"`
 	Success bool               `json:"success"`
@@ -125,6 +139,7 @@ func (h *ExpressionHandler) RegisterRoutes(router *gin.RouterGroup, authMiddlewa
 		expressions.POST("/evaluate", h.EvaluateExpression)
 		expressions.POST("/evaluate/batch", h.EvaluateBatch)
 		expressions.POST("/evaluate/condition", h.EvaluateCondition)
+		expressions.POST("/evaluate/unary-test", h.EvaluateUnaryTest)
 		expressions.POST("/parse", h.ParseExpression)
 		expressions.POST("/validate", h.ValidateExpression)
 		expressions.POST("/test", h.TestExpression)
@@ -148,7 +163,7 @@ func (h *ExpressionHandler) RegisterRoutes(router *gin.RouterGroup, authMiddlewa
 // @Security ApiKeyAuth
 // @Router /api/v1/expressions/evaluate [post]
 func (h *ExpressionHandler) EvaluateExpression(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse request body
 	var req models.EvaluateExpressionRequest
@@ -201,9 +216,8 @@ func (h *ExpressionHandler) EvaluateExpression(c *gin.Context) {
 		return
 	}
 
-	logger.Info("Expression evaluated successfully",
+	logger.Debug("Expression evaluated successfully",
 		logger.String("request_id", requestID),
-		logger.String("expression", req.Expression),
 		logger.String("result_type", result.ResultType))
 
 	c.JSON(http.StatusOK, models.SuccessResponse(result, requestID))
@@ -224,7 +238,7 @@ func (h *ExpressionHandler) EvaluateExpression(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/expressions/evaluate/batch [post]
 func (h *ExpressionHandler) EvaluateBatch(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse request body
 	var reqs []models.EvaluateExpressionRequest
@@ -296,7 +310,7 @@ func (h *ExpressionHandler) EvaluateBatch(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/expressions/evaluate/condition [post]
 func (h *ExpressionHandler) EvaluateCondition(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse request body
 	var req models.EvaluateExpressionRequest
@@ -337,6 +351,70 @@ func (h *ExpressionHandler) EvaluateCondition(c *gin.Context) {
 	c.JSON(http.StatusOK, models.SuccessResponse(result, requestID))
 }
 
+// EvaluateUnaryTest handles POST /api/v1/expressions/evaluate/unary-test
+// @Summary Evaluate unary test
+// @Description Check input value against FEEL unary test with implicit left side: comparison (> 100),
+// @Description range ([1..10], ]1..10[), value, comma separated list ("a","b"), not(...), "-" for any input
+// @Description or boolean expression using ? as input (? > 5 and ? < 10). Context resolves names used in test
+// @Tags expressions
+// @Accept json
+// @Produce json
+// @Param request body models.EvaluateUnaryTestRequest true "Unary test evaluation request"
+// @Success 200 {object} models.APIResponse{data=UnaryTestResult}
+// @Failure 400 {object} models.APIResponse{error=models.APIError}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/expressions/evaluate/unary-test [post]
+func (h *ExpressionHandler) EvaluateUnaryTest(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	var req models.EvaluateUnaryTestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiErr := models.BadRequestError("Invalid request body: " + err.Error())
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	if apiErr := h.validator.ValidateStringLength(req.Test, "test", 1, 10000); apiErr != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(
+			h.validator.CreateValidationError([]models.ValidationError{*apiErr}), requestID))
+		return
+	}
+
+	expressionComp, ok := h.coreInterface.GetExpressionComponent().(UnaryTestComponent)
+	if !ok {
+		apiErr := models.InternalServerError("Expression service not available")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	result, err := expressionComp.EvaluateUnaryTest(req.Input, req.Test, req.Context)
+	if err != nil {
+		logger.Warn("Failed to evaluate unary test",
+			logger.String("request_id", requestID),
+			logger.String("test", req.Test),
+			logger.String("error", err.Error()))
+
+		apiErr := models.NewAPIError(models.ErrorCodeExpressionError, err.Error())
+		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
+		c.JSON(statusCode, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	logger.Debug("Unary test evaluated",
+		logger.String("request_id", requestID),
+		logger.String("test", req.Test),
+		logger.Bool("result", result))
+
+	c.JSON(http.StatusOK, models.SuccessResponse(&UnaryTestResult{
+		Input:  req.Input,
+		Test:   req.Test,
+		Result: result,
+	}, requestID))
+}
+
 // ParseExpression handles POST /api/v1/expressions/parse
 // @Summary Parse expression to AST
 // @Description Parse FEEL expression and return Abstract Syntax Tree
@@ -352,7 +430,7 @@ func (h *ExpressionHandler) EvaluateCondition(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/expressions/parse [post]
 func (h *ExpressionHandler) ParseExpression(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse request body
 	var req models.ParseExpressionRequest
@@ -423,7 +501,7 @@ func (h *ExpressionHandler) ParseExpression(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/expressions/validate [post]
 func (h *ExpressionHandler) ValidateExpression(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse request body
 	var req models.ValidateExpressionRequest
@@ -490,7 +568,7 @@ func (h *ExpressionHandler) ValidateExpression(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/expressions/test [post]
 func (h *ExpressionHandler) TestExpression(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse request body
 	var req models.TestExpressionRequest
@@ -567,7 +645,7 @@ func (h *ExpressionHandler) TestExpression(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/expressions/extract-variables [post]
 func (h *ExpressionHandler) ExtractVariables(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse request body
 	var req models.ParseExpressionRequest
@@ -611,7 +689,7 @@ func (h *ExpressionHandler) ExtractVariables(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/expressions/functions [get]
 func (h *ExpressionHandler) GetSupportedFunctions(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	category := c.Query("category")
 
 	logger.Debug("Getting supported functions",
@@ -633,7 +711,7 @@ func (h *ExpressionHandler) GetSupportedFunctions(c *gin.Context) {
 
 func (h *ExpressionHandler) evaluateExpressionInternal(
 	expression string,
-	context interface{},
+	variables map[string]interface{},
 ) (*ExpressionResult, error) {
 	if expression == "" {
 		return nil, fmt.Errorf("empty expression")
@@ -655,12 +733,8 @@ func (h *ExpressionHandler) evaluateExpressionInternal(
 		return nil, fmt.Errorf("failed to cast expression component")
 	}
 
-	// Convert context to variables map
-	variables := make(map[string]interface{})
-	if context != nil {
-		if contextMap, ok := context.(map[string]interface{}); ok {
-			variables = contextMap
-		}
+	if variables == nil {
+		variables = make(map[string]interface{})
 	}
 
 	// Evaluate expression using real expression component
@@ -669,6 +743,9 @@ func (h *ExpressionHandler) evaluateExpressionInternal(
 		return nil, fmt.Errorf("failed to evaluate expression: %w", err)
 	}
 
+	// Numbers are normalized so integers are rendered without float64 coercion
+	result = coremodels.NormalizeValue(result)
+
 	// Determine result type
 	resultType := "unknown"
 	switch result.(type) {
@@ -849,8 +926,8 @@ func (h *ExpressionHandler) getSupportedFunctions(category string) *SupportedFun
 			Name:        "subtract",
 			Category:    "date",
 			Description: "Subtract duration from datetime",
-			Signature:  "subtract(datetime, duration) -> datetime",
-			ReturnType: "datetime",
+			Signature:   "subtract(datetime, duration) -> datetime",
+			ReturnType:  "datetime",
 			Examples: []string{
 				"subtract(datetime, duration(\"P3D\"))",
 				"subtract(\"2025-12-13T12:18:19.675Z\", duration(\"P3D\"))",
@@ -892,13 +969,6 @@ func (h *ExpressionHandler) getSupportedFunctions(category string) *SupportedFun
 	}
 }
 
-func (h *ExpressionHandler) getRequestID(c *gin.Context) string {
-	if requestID := c.GetHeader("X-Request-ID"); requestID != "" {
-		return requestID
-	}
-	return utils.GenerateSecureRequestID("expression")
-}
-
 // getExpressionComponent returns expression component with proper casting
 func (h *ExpressionHandler) getExpressionComponent() ExpressionComponent {
 	expressionCompInterface := h.coreInterface.GetExpressionComponent()
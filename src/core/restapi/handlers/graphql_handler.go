@@ -0,0 +1,155 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/graph-gophers/graphql-go/errors"
+	"google.golang.org/grpc"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/restapi/graphqlapi"
+	"atom-engine/src/core/restapi/middleware"
+	"atom-engine/src/core/restapi/utils"
+)
+
+// GraphQLPath is path of GraphQL endpoint inside /api/v1
+const GraphQLPath = "/graphql"
+
+// maxGraphQLRequestBytes limits size of GraphQL request body
+const maxGraphQLRequestBytes = 1 << 20
+
+// GraphQLConfig holds GraphQL endpoint configuration, non-positive limits use defaults
+type GraphQLConfig struct {
+	Enabled       bool `yaml:"enabled"`
+	MaxDepth      int  `yaml:"max_depth"`
+	MaxComplexity int  `yaml:"max_complexity"`
+}
+
+// GraphQLCoreInterface defines methods needed for GraphQL queries
+type GraphQLCoreInterface interface {
+	GetGRPCConnection() (interface{}, error)
+}
+
+// GraphQLHandler serves read-only GraphQL queries over operational data
+type GraphQLHandler struct {
+	coreInterface GraphQLCoreInterface
+	executor      *graphqlapi.Executor
+}
+
+// graphQLErrorResponse is response body of request rejected before execution
+type graphQLErrorResponse struct {
+	Errors []*errors.QueryError `json:"errors"`
+}
+
+// NewGraphQLHandler creates new GraphQL handler
+func NewGraphQLHandler(coreInterface GraphQLCoreInterface, config *GraphQLConfig) (*GraphQLHandler, error) {
+	limits := graphqlapi.Config{}
+	if config != nil {
+		limits.MaxDepth = config.MaxDepth
+		limits.MaxComplexity = config.MaxComplexity
+	}
+
+	executor, err := graphqlapi.NewExecutor(limits)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GraphQLHandler{
+		coreInterface: coreInterface,
+		executor:      executor,
+	}, nil
+}
+
+// RegisterRoutes registers GraphQL routes
+func (h *GraphQLHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	graphql := router.Group(GraphQLPath)
+
+	// Queries read process, job, incident and timer data
+	if authMiddleware != nil {
+		graphql.Use(authMiddleware.RequirePermission("process"))
+	}
+
+	{
+		graphql.POST("", h.Query)
+		graphql.GET("", h.Query)
+	}
+}
+
+// Query handles POST and GET /api/v1/graphql
+// POST takes JSON body with query, operationName and variables, GET takes same fields as query parameters
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	req, err := parseGraphQLRequest(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, graphQLErrorResponse{
+			Errors: []*errors.QueryError{errors.Errorf("%s", err)},
+		})
+		return
+	}
+
+	connInterface, err := h.coreInterface.GetGRPCConnection()
+	if err != nil {
+		logger.Error("Failed to get gRPC connection", logger.String("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, graphQLErrorResponse{
+			Errors: []*errors.QueryError{errors.Errorf("internal service error")},
+		})
+		return
+	}
+	conn, ok := connInterface.(*grpc.ClientConn)
+	if !ok {
+		logger.Error("Invalid gRPC connection type")
+		c.JSON(http.StatusInternalServerError, graphQLErrorResponse{
+			Errors: []*errors.QueryError{errors.Errorf("internal service error")},
+		})
+		return
+	}
+	defer conn.Close()
+
+	resp := h.executor.Execute(utils.BackgroundContext(c), conn, req)
+	if len(resp.Errors) > 0 {
+		logger.Debug("GraphQL query returned errors",
+			logger.String("request_id", requestID),
+			logger.String("operation", req.OperationName),
+			logger.Int("errors", len(resp.Errors)),
+			logger.String("first_error", resp.Errors[0].Message))
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// parseGraphQLRequest reads GraphQL request from JSON body or query parameters
+func parseGraphQLRequest(c *gin.Context) (graphqlapi.Request, error) {
+	var req graphqlapi.Request
+
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				return req, fmt.Errorf("invalid variables: %w", err)
+			}
+		}
+	} else {
+		body := http.MaxBytesReader(c.Writer, c.Request.Body, maxGraphQLRequestBytes)
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			return req, fmt.Errorf("invalid request body: %w", err)
+		}
+	}
+
+	if req.Query == "" {
+		return req, fmt.Errorf("query is required")
+	}
+	return req, nil
+}
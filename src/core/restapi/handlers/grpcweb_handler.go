@@ -0,0 +1,385 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package handlers
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/restapi/utils"
+)
+
+// GRPCWebPathPrefix is root path of grpc-web calls, full path is /grpc-web/<package.Service>/<Method>
+const GRPCWebPathPrefix = "/grpc-web"
+
+// maxGRPCWebMessageBytes limits size of grpc-web request body, same as default gRPC receive limit
+const maxGRPCWebMessageBytes = 4 << 20
+
+// grpc-web frame flags
+const (
+	grpcWebFrameCompressed = 0x01
+	grpcWebFrameTrailer    = 0x80
+)
+
+// grpc-web content types
+const (
+	grpcWebContentType     = "application/grpc-web"
+	grpcWebTextContentType = "application/grpc-web-text"
+)
+
+// DefaultGRPCWebServices are gRPC services exposed to browsers when none are configured
+var DefaultGRPCWebServices = []string{
+	"expression.ExpressionService",
+	"messages.MessagesService",
+	"atom.process.v1.ProcessService",
+}
+
+// GRPCWebCORSAllowedHeaders are request headers browsers send with grpc-web calls
+var GRPCWebCORSAllowedHeaders = []string{"X-Grpc-Web", "X-User-Agent", "Grpc-Timeout"}
+
+// GRPCWebCORSExposedHeaders are response headers grpc-web clients read
+var GRPCWebCORSExposedHeaders = []string{"Grpc-Status", "Grpc-Message", "Grpc-Status-Details-Bin"}
+
+// grpcWebForwardedHeaders are HTTP headers passed to gRPC services as metadata
+var grpcWebForwardedHeaders = []string{"authorization", "user-agent", "x-user-agent"}
+
+// GRPCWebConfig holds grpc-web proxy configuration
+type GRPCWebConfig struct {
+	Enabled  bool     `yaml:"enabled"`
+	Services []string `yaml:"services"`
+}
+
+// GRPCWebCoreInterface defines methods needed for grpc-web proxy
+type GRPCWebCoreInterface interface {
+	GetGRPCConnection() (interface{}, error)
+}
+
+// GRPCWebHandler translates grpc-web calls of browser clients into unary calls of gRPC server
+type GRPCWebHandler struct {
+	coreInterface GRPCWebCoreInterface
+	services      map[string]bool
+}
+
+// NewGRPCWebHandler creates new grpc-web handler, empty service list exposes default services
+func NewGRPCWebHandler(coreInterface GRPCWebCoreInterface, config *GRPCWebConfig) *GRPCWebHandler {
+	services := DefaultGRPCWebServices
+	if config != nil && len(config.Services) > 0 {
+		services = config.Services
+	}
+
+	h := &GRPCWebHandler{
+		coreInterface: coreInterface,
+		services:      make(map[string]bool, len(services)),
+	}
+	for _, service := range services {
+		h.services[strings.TrimSpace(service)] = true
+	}
+	return h
+}
+
+// RegisterRoutes registers grpc-web route
+func (h *GRPCWebHandler) RegisterRoutes(router gin.IRouter) {
+	router.POST(GRPCWebPathPrefix+"/:service/:method", h.Invoke)
+}
+
+// Invoke handles POST /grpc-web/:service/:method
+// Only unary methods are supported, message compression is not supported
+func (h *GRPCWebHandler) Invoke(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	service := c.Param("service")
+	fullMethod := "/" + service + "/" + c.Param("method")
+
+	contentType := c.GetHeader("Content-Type")
+	textMode, ok := parseGRPCWebContentType(contentType)
+	if !ok {
+		c.String(http.StatusUnsupportedMediaType, "unsupported content type %q, expected %s", contentType,
+			grpcWebContentType)
+		return
+	}
+
+	if !h.services[service] {
+		h.writeResponse(c, textMode, nil, nil, status.Newf(codes.Unimplemented,
+			"service %s is not exposed over grpc-web", service))
+		return
+	}
+
+	message, err := readGRPCWebRequest(c.Request.Body, textMode)
+	if err != nil {
+		h.writeResponse(c, textMode, nil, nil, status.New(codes.InvalidArgument, err.Error()))
+		return
+	}
+
+	ctx, cancel, err := grpcWebContext(c, requestID)
+	if err != nil {
+		h.writeResponse(c, textMode, nil, nil, status.New(codes.InvalidArgument, err.Error()))
+		return
+	}
+	defer cancel()
+
+	connInterface, err := h.coreInterface.GetGRPCConnection()
+	if err != nil {
+		logger.Error("Failed to get gRPC connection for grpc-web call",
+			logger.String("request_id", requestID),
+			logger.String("method", fullMethod),
+			logger.String("error", err.Error()))
+		h.writeResponse(c, textMode, nil, nil, status.New(codes.Unavailable, "gRPC server is not available"))
+		return
+	}
+	conn, ok := connInterface.(*grpc.ClientConn)
+	if !ok {
+		h.writeResponse(c, textMode, nil, nil, status.New(codes.Internal, "invalid gRPC connection type"))
+		return
+	}
+	defer conn.Close()
+
+	var header, trailer metadata.MD
+	reply := &grpcWebRawMessage{}
+	err = conn.Invoke(ctx, fullMethod, &grpcWebRawMessage{data: message}, reply,
+		grpc.ForceCodec(grpcWebRawCodec{}), grpc.Header(&header), grpc.Trailer(&trailer))
+	if err != nil {
+		st, _ := status.FromError(err)
+		logger.Debug("grpc-web call failed",
+			logger.String("request_id", requestID),
+			logger.String("method", fullMethod),
+			logger.String("code", st.Code().String()),
+			logger.String("error", st.Message()))
+		h.writeResponse(c, textMode, header, trailer, st)
+		return
+	}
+
+	logger.Debug("grpc-web call completed",
+		logger.String("request_id", requestID),
+		logger.String("method", fullMethod))
+
+	h.writeResponse(c, textMode, header, trailer, status.New(codes.OK, ""), reply.data)
+}
+
+// writeResponse writes header metadata, optional message frame and trailer frame with call status
+func (h *GRPCWebHandler) writeResponse(
+	c *gin.Context,
+	textMode bool,
+	header, trailer metadata.MD,
+	st *status.Status,
+	messages ...[]byte,
+) {
+	for key, values := range header {
+		if isReservedGRPCWebHeader(key) {
+			continue
+		}
+		for _, value := range values {
+			c.Writer.Header().Add(key, value)
+		}
+	}
+
+	var body []byte
+	for _, message := range messages {
+		body = appendGRPCWebFrame(body, 0, message)
+	}
+
+	var trailers strings.Builder
+	trailers.WriteString("grpc-status:" + strconv.Itoa(int(st.Code())) + "\r\n")
+	if st.Message() != "" {
+		trailers.WriteString("grpc-message:" + encodeGRPCMessage(st.Message()) + "\r\n")
+	}
+	for key, values := range trailer {
+		if isReservedGRPCWebHeader(key) {
+			continue
+		}
+		for _, value := range values {
+			trailers.WriteString(key + ":" + value + "\r\n")
+		}
+	}
+	body = appendGRPCWebFrame(body, grpcWebFrameTrailer, []byte(trailers.String()))
+
+	contentType := grpcWebContentType + "+proto"
+	if textMode {
+		contentType = grpcWebTextContentType + "+proto"
+		body = []byte(base64.StdEncoding.EncodeToString(body))
+	}
+	c.Data(http.StatusOK, contentType, body)
+}
+
+// parseGRPCWebContentType reports whether content type is grpc-web and whether it is base64 text mode
+func parseGRPCWebContentType(contentType string) (bool, bool) {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	switch mediaType {
+	case grpcWebContentType, grpcWebContentType + "+proto":
+		return false, true
+	case grpcWebTextContentType, grpcWebTextContentType + "+proto":
+		return true, true
+	default:
+		return false, false
+	}
+}
+
+// readGRPCWebRequest returns message of single data frame of request body
+func readGRPCWebRequest(body io.Reader, textMode bool) ([]byte, error) {
+	raw, err := io.ReadAll(io.LimitReader(body, maxGRPCWebMessageBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+	if len(raw) > maxGRPCWebMessageBytes {
+		return nil, fmt.Errorf("request body exceeds %d bytes", maxGRPCWebMessageBytes)
+	}
+
+	if textMode {
+		decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(raw)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 request body: %w", err)
+		}
+		raw = decoded
+	}
+
+	if len(raw) < 5 {
+		return nil, errors.New("request body is not a grpc-web frame")
+	}
+	flags := raw[0]
+	length := binary.BigEndian.Uint32(raw[1:5])
+	if flags&grpcWebFrameTrailer != 0 {
+		return nil, errors.New("request must start with data frame")
+	}
+	if flags&grpcWebFrameCompressed != 0 {
+		return nil, errors.New("compressed grpc-web messages are not supported")
+	}
+	if uint64(len(raw)-5) < uint64(length) {
+		return nil, errors.New("grpc-web frame is truncated")
+	}
+	return raw[5 : 5+length], nil
+}
+
+// grpcWebContext builds call context with forwarded metadata and grpc-timeout deadline
+func grpcWebContext(c *gin.Context, requestID string) (context.Context, context.CancelFunc, error) {
+	ctx := utils.BackgroundContext(c)
+
+	md := metadata.MD{}
+	for _, key := range grpcWebForwardedHeaders {
+		if value := c.GetHeader(key); value != "" {
+			md.Set(key, value)
+		}
+	}
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	timeoutHeader := c.GetHeader("Grpc-Timeout")
+	if timeoutHeader == "" {
+		ctx, cancel := context.WithCancel(ctx)
+		return ctx, cancel, nil
+	}
+	timeout, err := parseGRPCTimeout(timeoutHeader)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, cancel, nil
+}
+
+// parseGRPCTimeout parses grpc-timeout header value like "10S" or "500m"
+func parseGRPCTimeout(value string) (time.Duration, error) {
+	if len(value) < 2 || len(value) > 9 {
+		return 0, fmt.Errorf("invalid grpc-timeout %q", value)
+	}
+
+	amount, err := strconv.ParseInt(value[:len(value)-1], 10, 64)
+	if err != nil || amount < 0 {
+		return 0, fmt.Errorf("invalid grpc-timeout %q", value)
+	}
+
+	units := map[byte]time.Duration{
+		'H': time.Hour,
+		'M': time.Minute,
+		'S': time.Second,
+		'm': time.Millisecond,
+		'u': time.Microsecond,
+		'n': time.Nanosecond,
+	}
+	unit, ok := units[value[len(value)-1]]
+	if !ok {
+		return 0, fmt.Errorf("invalid grpc-timeout unit in %q", value)
+	}
+	return time.Duration(amount) * unit, nil
+}
+
+// appendGRPCWebFrame appends length-prefixed frame with flags
+func appendGRPCWebFrame(dst []byte, flags byte, payload []byte) []byte {
+	var prefix [5]byte
+	prefix[0] = flags
+	binary.BigEndian.PutUint32(prefix[1:], uint32(len(payload)))
+	dst = append(dst, prefix[:]...)
+	return append(dst, payload...)
+}
+
+// encodeGRPCMessage percent-encodes grpc-message value as required by gRPC protocol
+func encodeGRPCMessage(message string) string {
+	var b strings.Builder
+	for i := 0; i < len(message); i++ {
+		ch := message[i]
+		if ch >= 0x20 && ch <= 0x7e && ch != '%' {
+			b.WriteByte(ch)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", ch)
+	}
+	return b.String()
+}
+
+// isReservedGRPCWebHeader reports metadata set by transport that must not be copied to response
+func isReservedGRPCWebHeader(key string) bool {
+	switch strings.ToLower(key) {
+	case "content-type", "content-length", "grpc-status", "grpc-message", "grpc-status-details-bin":
+		return true
+	}
+	return false
+}
+
+// grpcWebRawMessage carries already serialized protobuf message
+type grpcWebRawMessage struct {
+	data []byte
+}
+
+// grpcWebRawCodec passes serialized messages through without decoding them
+// Name is "proto" so gRPC server decodes request with its regular codec
+type grpcWebRawCodec struct{}
+
+// Marshal returns serialized message as is
+func (grpcWebRawCodec) Marshal(v interface{}) ([]byte, error) {
+	message, ok := v.(*grpcWebRawMessage)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+	return message.data, nil
+}
+
+// Unmarshal stores serialized message as is
+func (grpcWebRawCodec) Unmarshal(data []byte, v interface{}) error {
+	message, ok := v.(*grpcWebRawMessage)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+	message.data = append([]byte(nil), data...)
+	return nil
+}
+
+// Name returns codec name
+func (grpcWebRawCodec) Name() string {
+	return "proto"
+}
@@ -9,11 +9,14 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
@@ -52,8 +55,8 @@ type CreateIncidentRequest struct {
 // IncidentsCoreInterface defines methods needed for incidents operations
 type IncidentsCoreInterface interface {
 	// JSON Message Routing to incidents component
-	SendMessage(componentName, messageJSON string) error
-	WaitForIncidentsResponse(timeoutMs int) (string, error)
+	SendRequestWithContext(ctx context.Context, componentName, messageJSON string) (string, error)
+	WaitForIncidentsResponse(requestID string, timeoutMs int) (string, error)
 	GetIncidentsComponent() interface{}
 }
 
@@ -82,9 +85,41 @@ type Incident struct {
 	ResolveComment    string                 `json:"resolve_comment,omitempty"`
 	OriginalRetries   int32                  `json:"original_retries,omitempty"`
 	NewRetries        int32                  `json:"new_retries,omitempty"`
+	RootCause         *IncidentRootCause     `json:"root_cause,omitempty"`
 	Metadata          map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// IncidentRootCause describes failing element, error and timestamps leading to incident
+type IncidentRootCause struct {
+	ElementID    string `json:"element_id,omitempty"`
+	ElementType  string `json:"element_type,omitempty"`
+	ErrorType    string `json:"error_type" example:"JOB_FAILURE"`
+	ErrorCode    string `json:"error_code,omitempty"`
+	ErrorMessage string `json:"error_message"`
+	JobKey       string `json:"job_key,omitempty"`
+	JobType      string `json:"job_type,omitempty"`
+	WorkerID     string `json:"worker_id,omitempty"`
+	Retries      int32  `json:"retries,omitempty"`
+	Expression   string `json:"expression,omitempty"`
+	Target       string `json:"target,omitempty"`
+	// Timestamps in order of occurrence, last one is incident creation
+	Timeline []IncidentRootCauseEvent `json:"timeline,omitempty"`
+}
+
+// IncidentRootCauseEvent is single timestamp of root cause timeline
+type IncidentRootCauseEvent struct {
+	Event     string    `json:"event" example:"job_failed"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// storedIncident is incident as returned by incidents component
+type storedIncident struct {
+	Incident
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	ResolvedAt *time.Time `json:"resolved_at,omitempty"`
+}
+
 type IncidentStats struct {
 	TotalIncidents     int32            `json:"total_incidents"`
 	OpenIncidents      int32            `json:"open_incidents"`
@@ -137,7 +172,7 @@ func (h *IncidentsHandler) RegisterRoutes(router *gin.RouterGroup, authMiddlewar
 // @Security ApiKeyAuth
 // @Router /api/v1/incidents [post]
 func (h *IncidentsHandler) CreateIncident(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse request body
 	var req CreateIncidentRequest
@@ -204,7 +239,7 @@ func (h *IncidentsHandler) CreateIncident(c *gin.Context) {
 	}
 
 	// Send to incidents component
-	response, err := h.sendIncidentsRequest(incidentReq, requestID)
+	response, err := h.sendIncidentsRequest(utils.BackgroundContext(c), incidentReq)
 	if err != nil {
 		apiErr := h.converter.GRPCErrorToAPIError(err)
 		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
@@ -267,7 +302,7 @@ func (h *IncidentsHandler) CreateIncident(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/incidents [get]
 func (h *IncidentsHandler) ListIncidents(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse query parameters
 	pageStr := c.DefaultQuery("page", "1")
@@ -303,7 +338,7 @@ func (h *IncidentsHandler) ListIncidents(c *gin.Context) {
 	if incidentType != "" {
 		validTypes := []string{
 			"job_failure", "bpmn_error", "expression_error",
-			"process_error", "timer_error", "message_error", "system_error",
+			"process_error", "timer_error", "message_error", "system_error", "sla_breach",
 		}
 		if apiErr := h.validator.ValidateStringEnum(incidentType, "type", validTypes); apiErr != nil {
 			c.JSON(http.StatusBadRequest, models.ErrorResponse(
@@ -335,7 +370,7 @@ func (h *IncidentsHandler) ListIncidents(c *gin.Context) {
 	}
 
 	// Send to incidents component and get response
-	response, err := h.sendIncidentsRequest(listReq, requestID)
+	response, err := h.sendIncidentsRequest(utils.BackgroundContext(c), listReq)
 	if err != nil {
 		apiErr := h.converter.GRPCErrorToAPIError(err)
 		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
@@ -378,7 +413,7 @@ func (h *IncidentsHandler) ListIncidents(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/incidents/{id} [get]
 func (h *IncidentsHandler) GetIncident(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	incidentID := c.Param("id")
 
 	if incidentID == "" {
@@ -393,12 +428,15 @@ func (h *IncidentsHandler) GetIncident(c *gin.Context) {
 
 	// Create get request
 	getReq := map[string]interface{}{
-		"operation":   "get",
-		"incident_id": incidentID,
+		"type":    "get_incident",
+		"payload": map[string]interface{}{"incident_id": incidentID},
 	}
 
 	// Send to incidents component and get response
-	response, err := h.sendIncidentsRequest(getReq, requestID)
+	response, err := h.sendIncidentsRequest(utils.BackgroundContext(c), getReq)
+	if err == nil {
+		err = incidentsResponseError(response)
+	}
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			apiErr := models.NewAPIErrorWithDetails(
@@ -453,7 +491,7 @@ func (h *IncidentsHandler) GetIncident(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/incidents/{id}/resolve [put]
 func (h *IncidentsHandler) ResolveIncident(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	incidentID := c.Param("id")
 
 	if incidentID == "" {
@@ -514,7 +552,7 @@ func (h *IncidentsHandler) ResolveIncident(c *gin.Context) {
 	}
 
 	// Send to incidents component and get response
-	response, err := h.sendIncidentsRequest(resolveReq, requestID)
+	response, err := h.sendIncidentsRequest(utils.BackgroundContext(c), resolveReq)
 	if err != nil {
 		if strings.Contains(err.Error(), "not found") {
 			apiErr := models.NewAPIErrorWithDetails(
@@ -569,7 +607,7 @@ func (h *IncidentsHandler) ResolveIncident(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/incidents/stats [get]
 func (h *IncidentsHandler) GetStats(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	logger.Debug("Getting incident statistics",
 		logger.String("request_id", requestID))
@@ -580,7 +618,7 @@ func (h *IncidentsHandler) GetStats(c *gin.Context) {
 	}
 
 	// Send to incidents component and get response
-	response, err := h.sendIncidentsRequest(statsReq, requestID)
+	response, err := h.sendIncidentsRequest(utils.BackgroundContext(c), statsReq)
 	if err != nil {
 		apiErr := h.converter.GRPCErrorToAPIError(err)
 		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
@@ -602,20 +640,20 @@ func (h *IncidentsHandler) GetStats(c *gin.Context) {
 // Helper methods
 
 func (h *IncidentsHandler) sendIncidentsRequest(
+	ctx context.Context,
 	req map[string]interface{},
-	requestID string,
 ) (map[string]interface{}, error) {
 	reqJSON, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	err = h.coreInterface.SendMessage("incidents", string(reqJSON))
+	componentRequestID, err := h.coreInterface.SendRequestWithContext(ctx, "incidents", string(reqJSON))
 	if err != nil {
 		return nil, fmt.Errorf("failed to send message: %w", err)
 	}
 
-	respJSON, err := h.coreInterface.WaitForIncidentsResponse(30000)
+	respJSON, err := h.coreInterface.WaitForIncidentsResponse(componentRequestID, 30000)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get response: %w", err)
 	}
@@ -635,25 +673,47 @@ func (h *IncidentsHandler) parseIncidentsFromResponse(response map[string]interf
 }
 
 func (h *IncidentsHandler) parseIncidentFromResponse(response map[string]interface{}) *Incident {
-	// Parse single incident from response - implementation details
-	return nil
-}
+	data, ok := response["data"].(map[string]interface{})
+	if !ok || len(data) == 0 {
+		return nil
+	}
 
-func (h *IncidentsHandler) parseStatsFromResponse(response map[string]interface{}) *IncidentStats {
-	// Parse stats from response - implementation details
-	return &IncidentStats{}
-}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil
+	}
+	var stored storedIncident
+	if err := json.Unmarshal(raw, &stored); err != nil {
+		logger.Warn("Failed to decode incident from response", logger.String("error", err.Error()))
+		return nil
+	}
+	// Storage returns empty incident for unknown ID
+	if stored.ID == "" {
+		return nil
+	}
 
-func (h *IncidentsHandler) extractTotalCount(response map[string]interface{}) int {
-	if count, ok := response["total_count"].(float64); ok {
-		return int(count)
+	incident := stored.Incident
+	incident.CreatedAt = stored.CreatedAt.Unix()
+	incident.UpdatedAt = stored.UpdatedAt.Unix()
+	if stored.ResolvedAt != nil {
+		incident.ResolvedAt = stored.ResolvedAt.Unix()
 	}
-	return 0
+	return &incident
 }
 
-func (h *IncidentsHandler) getRequestID(c *gin.Context) string {
-	if requestID := c.GetHeader("X-Request-ID"); requestID != "" {
-		return requestID
+// incidentsResponseError returns error of failed incidents component response
+func incidentsResponseError(response map[string]interface{}) error {
+	if success, _ := response["success"].(bool); success {
+		return nil
 	}
-	return utils.GenerateSecureRequestID("incidents")
+	message, _ := response["error"].(string)
+	if message == "" {
+		message = "incidents request failed"
+	}
+	return errors.New(message)
+}
+
+func (h *IncidentsHandler) parseStatsFromResponse(response map[string]interface{}) *IncidentStats {
+	// Parse stats from response - implementation details
+	return &IncidentStats{}
 }
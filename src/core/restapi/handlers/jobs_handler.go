@@ -9,15 +9,17 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
-	"sort"
-	"strings"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
 	"atom-engine/src/core/logger"
+	coremodels "atom-engine/src/core/models"
 	"atom-engine/src/core/restapi/middleware"
 	"atom-engine/src/core/restapi/models"
 	"atom-engine/src/core/restapi/utils"
@@ -25,37 +27,59 @@ import (
 
 // JobsHandler handles job management HTTP requests
 type JobsHandler struct {
-	coreInterface JobsCoreInterface
-	converter     *utils.Converter
-	validator     *utils.Validator
+	coreInterface   JobsCoreInterface
+	converter       *utils.Converter
+	validator       *utils.Validator
+	variableLimiter *utils.VariableLimiter
 }
 
 // JobsCoreInterface defines methods needed for jobs operations
 type JobsCoreInterface interface {
 	// JSON Message Routing to jobs component
-	SendMessage(componentName, messageJSON string) error
-	WaitForJobsResponse(timeoutMs int) (string, error)
+	SendRequestWithContext(ctx context.Context, componentName, messageJSON string) (string, error)
+	WaitForJobsResponse(requestID string, timeoutMs int) (string, error)
 	GetJobsComponent() interface{}
 }
 
 // Job data types
+// ID and numeric key identify same job; lookups accept either form
 type Job struct {
-	Key                 string                 `json:"key"`
+	ID                  string                 `json:"id"`
+	Key                 int64                  `json:"key,omitempty"`
 	Type                string                 `json:"type"`
 	ProcessInstanceID   string                 `json:"process_instance_id"`
+	ProcessInstanceKey  int64                  `json:"process_instance_key,omitempty"`
 	ProcessDefinitionID string                 `json:"process_definition_id"`
 	ElementID           string                 `json:"element_id"`
 	ElementInstanceID   string                 `json:"element_instance_id"`
+	ElementInstanceKey  int64                  `json:"element_instance_key,omitempty"`
 	CustomHeaders       map[string]string      `json:"custom_headers"`
+	Metadata            map[string]string      `json:"metadata,omitempty"` // e.g. typeExpression and resolvedType
 	Variables           map[string]interface{} `json:"variables"`
 	Retries             int32                  `json:"retries"`
+	Priority            int32                  `json:"priority"`
 	Deadline            int64                  `json:"deadline"`
+	NextRetryAt         int64                  `json:"next_retry_at,omitempty"` // Retry time of deferred job, Unix ms
 	Worker              string                 `json:"worker,omitempty"`
 	State               string                 `json:"state"`
 	CreatedAt           int64                  `json:"created_at"`
 	UpdatedAt           int64                  `json:"updated_at"`
 }
 
+// BatchGetJobsResponse lists lookup results in order of requested keys
+type BatchGetJobsResponse struct {
+	Jobs     []BatchJobResult `json:"jobs"`
+	Found    int              `json:"found"`
+	NotFound int              `json:"not_found"`
+}
+
+// BatchJobResult is current state of one requested job, Job is omitted when job is not found
+type BatchJobResult struct {
+	Key   string `json:"key"`
+	Found bool   `json:"found"`
+	Job   *Job   `json:"job,omitempty"`
+}
+
 type JobActivationResponse struct {
 	Jobs []Job `json:"jobs"`
 }
@@ -69,14 +93,25 @@ type JobStats struct {
 	JobsByWorker     map[string]int64 `json:"jobs_by_worker"`
 	AverageLatency   float64          `json:"average_latency_ms"`
 	ThroughputPerMin int64            `json:"throughput_per_minute"`
+
+	// Age of oldest activatable job per job type, growing age means no worker takes jobs of that type
+	OldestActivatableAgeMs map[string]int64 `json:"oldest_activatable_age_ms"`
 }
 
+// jobSortFields are sort_by values of job listing
+var jobSortFields = []string{"created_at", "updated_at", "state", "type"}
+
 // NewJobsHandler creates new jobs handler
-func NewJobsHandler(coreInterface JobsCoreInterface) *JobsHandler {
+func NewJobsHandler(
+	coreInterface JobsCoreInterface,
+	validator *utils.Validator,
+	variableLimiter *utils.VariableLimiter,
+) *JobsHandler {
 	return &JobsHandler{
-		coreInterface: coreInterface,
-		converter:     utils.NewConverter(),
-		validator:     utils.NewValidator(),
+		coreInterface:   coreInterface,
+		converter:       utils.NewConverter(),
+		validator:       validator,
+		variableLimiter: variableLimiter,
 	}
 }
 
@@ -94,6 +129,8 @@ func (h *JobsHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *mi
 		jobs.GET("", h.ListJobs)
 		jobs.GET("/:key", h.GetJob)
 		jobs.POST("/activate", h.ActivateJobs)
+		jobs.POST("/bulk/complete", h.BulkCompleteJobs)
+		jobs.POST("/batch-get", h.BatchGetJobs)
 		jobs.PUT("/:key/complete", h.CompleteJob)
 		jobs.PUT("/:key/fail", h.FailJob)
 		jobs.POST("/:key/throw-error", h.ThrowError)
@@ -119,7 +156,7 @@ func (h *JobsHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *mi
 // @Security ApiKeyAuth
 // @Router /api/v1/jobs [post]
 func (h *JobsHandler) CreateJob(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse request body
 	var req models.CreateJobRequest
@@ -181,7 +218,7 @@ func (h *JobsHandler) CreateJob(c *gin.Context) {
 	}
 
 	// Send to jobs component
-	response, err := h.sendJobsRequest(jobReq, requestID)
+	response, err := h.sendJobsRequest(utils.BackgroundContext(c), jobReq)
 	if err != nil {
 		apiErr := h.converter.GRPCErrorToAPIError(err)
 		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
@@ -220,7 +257,8 @@ func (h *JobsHandler) CreateJob(c *gin.Context) {
 
 // ActivateJobs handles POST /api/v1/jobs/activate
 // @Summary Activate jobs for worker
-// @Description Activate available jobs for a specific worker
+// @Description Activate available jobs for a specific worker. fetch_variables limits returned variables,
+// @Description fields limits returned job fields, dotted names like variables.order.id select nested values
 // @Tags jobs
 // @Accept json
 // @Produce json
@@ -233,7 +271,7 @@ func (h *JobsHandler) CreateJob(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/jobs/activate [post]
 func (h *JobsHandler) ActivateJobs(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse request body
 	var req models.ActivateJobsRequest
@@ -265,6 +303,12 @@ func (h *JobsHandler) ActivateJobs(c *gin.Context) {
 		return
 	}
 
+	selection, apiErr := utils.SelectFields(req.Fields)
+	if apiErr != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
 	logger.Debug("Activating jobs for worker",
 		logger.String("request_id", requestID),
 		logger.String("type", req.Type),
@@ -284,7 +328,7 @@ func (h *JobsHandler) ActivateJobs(c *gin.Context) {
 	}
 
 	// Send to jobs component and get response
-	response, err := h.sendJobsRequest(activateReq, requestID)
+	response, err := h.sendJobsRequest(utils.BackgroundContext(c), activateReq)
 	if err != nil {
 		apiErr := h.converter.GRPCErrorToAPIError(err)
 		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
@@ -294,9 +338,10 @@ func (h *JobsHandler) ActivateJobs(c *gin.Context) {
 
 	// Parse jobs from response
 	jobs := h.parseJobsFromResponse(response)
-
-	activationResp := &JobActivationResponse{
-		Jobs: jobs,
+	if len(req.FetchVariables) > 0 {
+		for i := range jobs {
+			jobs[i].Variables = fetchVariables(jobs[i].Variables, req.FetchVariables)
+		}
 	}
 
 	logger.Info("Jobs activated for worker",
@@ -304,7 +349,32 @@ func (h *JobsHandler) ActivateJobs(c *gin.Context) {
 		logger.String("worker", req.Worker),
 		logger.Int("activated_count", len(jobs)))
 
-	c.JSON(http.StatusOK, models.SuccessResponse(activationResp, requestID))
+	if selection.IsFull() {
+		c.JSON(http.StatusOK, models.SuccessResponse(&JobActivationResponse{Jobs: jobs}, requestID))
+		return
+	}
+
+	// Activation already happened, projection failure must not hide activated jobs from worker
+	data, err := selection.Apply(jobs)
+	if err != nil {
+		logger.Error("Failed to select activated job fields, returning full jobs",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()))
+		c.JSON(http.StatusOK, models.SuccessResponse(&JobActivationResponse{Jobs: jobs}, requestID))
+		return
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse(map[string]interface{}{"jobs": data}, requestID))
+}
+
+// fetchVariables keeps only named top-level variables of activated job
+func fetchVariables(variables map[string]interface{}, names []string) map[string]interface{} {
+	fetched := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		if value, ok := variables[name]; ok {
+			fetched[name] = value
+		}
+	}
+	return fetched
 }
 
 // ListJobs handles GET /api/v1/jobs
@@ -317,14 +387,19 @@ func (h *JobsHandler) ActivateJobs(c *gin.Context) {
 // @Param type query string false "Job type filter"
 // @Param worker query string false "Worker filter"
 // @Param state query string false "State filter (activatable, activated, completed, failed)"
+// @Param include_variables query bool false "Load and return variables of each job" default(true)
+// @Param fields query string false "Comma-separated fields of each job to return, e.g. key,type,state"
+// @Param sort_by query string false "Sort field" Enums(created_at,updated_at,state,type) default(created_at)
+// @Param sort_order query string false "Sort order" Enums(asc,desc) default(desc)
 // @Success 200 {object} models.PaginatedResponse{data=[]Job}
+// @Failure 400 {object} models.APIResponse{error=models.APIError}
 // @Failure 401 {object} models.APIResponse{error=models.APIError}
 // @Failure 403 {object} models.APIResponse{error=models.APIError}
 // @Failure 500 {object} models.APIResponse{error=models.APIError}
 // @Security ApiKeyAuth
 // @Router /api/v1/jobs [get]
 func (h *JobsHandler) ListJobs(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse query parameters
 	pageStr := c.DefaultQuery("page", "1")
@@ -333,6 +408,12 @@ func (h *JobsHandler) ListJobs(c *gin.Context) {
 	worker := c.Query("worker")
 	state := c.Query("state")
 
+	selection, apiErr := utils.ParseFieldSelection(c)
+	if apiErr != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
 	// Parse and validate pagination
 	paginationHelper := utils.NewPaginationHelper()
 	params, apiErr := paginationHelper.ParseAndValidate(pageStr, limitStr)
@@ -341,6 +422,13 @@ func (h *JobsHandler) ListJobs(c *gin.Context) {
 		return
 	}
 
+	sortParams, apiErr := paginationHelper.ParseSort(
+		c.Query("sort_by"), c.Query("sort_order"), jobSortFields, "created_at")
+	if apiErr != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
 	// Validate state filter
 	if state != "" {
 		validStates := []string{"pending", "activatable", "activated", "running", "completed", "failed", "cancelled"}
@@ -360,21 +448,24 @@ func (h *JobsHandler) ListJobs(c *gin.Context) {
 		logger.String("worker", worker),
 		logger.String("state", state))
 
-	// Create list request (load all for sorting)
+	// Jobs component sorts all matching jobs before cutting requested page
 	listReq := map[string]interface{}{
 		"type":       "list_jobs",
 		"request_id": requestID,
 		"payload": map[string]interface{}{
-			"job_type": jobType,
-			"worker":   worker,
-			"state":    state,
-			"limit":    0, // Load all for sorting
-			"offset":   0,
+			"job_type":          jobType,
+			"worker":            worker,
+			"state":             state,
+			"limit":             params.Limit,
+			"offset":            utils.GetOffset(params.Page, params.Limit),
+			"sort_by":           sortParams.By,
+			"sort_order":        sortParams.Order,
+			"exclude_variables": !selection.IncludeVariables,
 		},
 	}
 
 	// Send to jobs component and get response
-	response, err := h.sendJobsRequest(listReq, requestID)
+	response, err := h.sendJobsRequest(utils.BackgroundContext(c), listReq)
 	if err != nil {
 		apiErr := h.converter.GRPCErrorToAPIError(err)
 		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
@@ -382,24 +473,30 @@ func (h *JobsHandler) ListJobs(c *gin.Context) {
 		return
 	}
 
-	// Parse jobs from response
+	// Parse jobs page and total count of matching jobs from response
 	jobs := h.parseJobsFromResponse(response)
-	totalCount := len(jobs)
-
-	// Apply sorting by created_at DESC (consistent with gRPC/CLI behavior)
-	sort.Slice(jobs, func(i, j int) bool {
-		return jobs[i].CreatedAt > jobs[j].CreatedAt // DESC order
-	})
-
-	// Apply client-side pagination after sorting
-	paginatedJobs, paginationInfo := utils.ApplyPagination(jobs, params.Page, params.Limit)
+	totalCount := parseListTotal(response)
+	for i := range jobs {
+		jobs[i].Variables = maskSensitiveVariables(c, jobs[i].Variables)
+	}
 
 	logger.Info("Jobs listed",
 		logger.String("request_id", requestID),
 		logger.Int("count", len(jobs)),
 		logger.Int("total", totalCount))
 
-	paginatedResp := models.PaginatedSuccessResponse(paginatedJobs, paginationInfo, requestID)
+	data, err := selection.Apply(jobs)
+	if err != nil {
+		logger.Error("Failed to select job fields",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()))
+
+		apiErr := models.InternalServerError("Failed to select job fields")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	paginatedResp := paginationHelper.CreateResponse(data, totalCount, params, requestID).WithSort(sortParams)
 	c.JSON(http.StatusOK, paginatedResp)
 }
 
@@ -417,7 +514,7 @@ func (h *JobsHandler) ListJobs(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/jobs/{key} [get]
 func (h *JobsHandler) GetJob(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	jobKey := c.Param("key")
 
 	if jobKey == "" {
@@ -440,9 +537,9 @@ func (h *JobsHandler) GetJob(c *gin.Context) {
 	}
 
 	// Send to jobs component and get response
-	response, err := h.sendJobsRequest(getReq, requestID)
+	response, err := h.sendJobsRequest(utils.BackgroundContext(c), getReq)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, coremodels.ErrNotFound) {
 			apiErr := models.JobNotFoundError(jobKey)
 			c.JSON(http.StatusNotFound, models.ErrorResponse(apiErr, requestID))
 		} else {
@@ -460,6 +557,7 @@ func (h *JobsHandler) GetJob(c *gin.Context) {
 		c.JSON(http.StatusNotFound, models.ErrorResponse(apiErr, requestID))
 		return
 	}
+	job.Variables = maskSensitiveVariables(c, job.Variables)
 
 	logger.Info("Job details retrieved",
 		logger.String("request_id", requestID),
@@ -470,6 +568,76 @@ func (h *JobsHandler) GetJob(c *gin.Context) {
 	c.JSON(http.StatusOK, models.SuccessResponse(job, requestID))
 }
 
+// BatchGetJobs handles POST /api/v1/jobs/batch-get
+// @Summary Get multiple jobs
+// @Description Get current state of several jobs by keys in one request.
+// @Description Results follow order of requested keys, missing jobs are reported with found=false
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Param request body models.BatchGetJobsRequest true "Job keys"
+// @Success 200 {object} models.APIResponse{data=BatchGetJobsResponse}
+// @Failure 400 {object} models.APIResponse{error=models.APIError}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/jobs/batch-get [post]
+func (h *JobsHandler) BatchGetJobs(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	var req models.BatchGetJobsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiErr := models.BadRequestError("Invalid request body: " + err.Error())
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		if apiErr, ok := err.(*models.APIError); ok {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		} else {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(models.BadRequestError(err.Error()), requestID))
+		}
+		return
+	}
+
+	logger.Debug("Getting jobs in batch",
+		logger.String("request_id", requestID),
+		logger.Int("key_count", len(req.Keys)))
+
+	// All keys are looked up by jobs component in one request
+	getReq := map[string]interface{}{
+		"type":       "get_jobs",
+		"request_id": requestID,
+		"payload": map[string]interface{}{
+			"job_ids": req.Keys,
+		},
+	}
+
+	response, err := h.sendJobsRequest(utils.BackgroundContext(c), getReq)
+	if err != nil {
+		apiErr := h.converter.GRPCErrorToAPIError(err)
+		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
+		c.JSON(statusCode, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	batchResp := h.parseBatchJobsFromResponse(response)
+	for _, result := range batchResp.Jobs {
+		if result.Job != nil {
+			result.Job.Variables = maskSensitiveVariables(c, result.Job.Variables)
+		}
+	}
+
+	logger.Info("Jobs retrieved in batch",
+		logger.String("request_id", requestID),
+		logger.Int("found", batchResp.Found),
+		logger.Int("not_found", batchResp.NotFound))
+
+	c.JSON(http.StatusOK, models.SuccessResponse(batchResp, requestID))
+}
+
 // CompleteJob handles PUT /api/v1/jobs/:key/complete
 // @Summary Complete job
 // @Description Mark job as completed with optional variables
@@ -487,7 +655,7 @@ func (h *JobsHandler) GetJob(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/jobs/{key}/complete [put]
 func (h *JobsHandler) CompleteJob(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	jobKey := c.Param("key")
 
 	if jobKey == "" {
@@ -506,6 +674,21 @@ func (h *JobsHandler) CompleteJob(c *gin.Context) {
 		}
 	}
 
+	// Reject malformed variables before they reach expression evaluation and storage
+	if validationErrors := h.validator.ValidateVariables(req.Variables); len(validationErrors) > 0 {
+		apiErr := h.validator.CreateValidationError(validationErrors)
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	// Enforce variable size limits and offload large values
+	variables, apiErr := h.variableLimiter.Apply(req.Variables, "variables")
+	if apiErr != nil {
+		c.JSON(models.HTTPStatusFromErrorCode(apiErr.Code), models.ErrorResponse(apiErr, requestID))
+		return
+	}
+	req.Variables = variables
+
 	logger.Debug("Completing job",
 		logger.String("request_id", requestID),
 		logger.String("job_key", jobKey))
@@ -521,9 +704,9 @@ func (h *JobsHandler) CompleteJob(c *gin.Context) {
 	}
 
 	// Send to jobs component and get response
-	_, err := h.sendJobsRequest(completeReq, requestID)
+	_, err := h.sendJobsRequest(utils.BackgroundContext(c), completeReq)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if errors.Is(err, coremodels.ErrNotFound) {
 			apiErr := models.JobNotFoundError(jobKey)
 			c.JSON(http.StatusNotFound, models.ErrorResponse(apiErr, requestID))
 		} else {
@@ -545,15 +728,106 @@ func (h *JobsHandler) CompleteJob(c *gin.Context) {
 	c.JSON(http.StatusOK, models.SuccessResponse(updateResp, requestID))
 }
 
+// BulkCompleteJobs handles POST /api/v1/jobs/bulk/complete
+// @Summary Complete multiple jobs
+// @Description Complete several jobs in one request with per-job result reporting
+// @Tags jobs
+// @Accept json
+// @Produce json
+// @Param request body models.BulkCompleteJobsRequest true "Bulk job completion request"
+// @Success 200 {object} models.APIResponse{data=models.MultiStatusResponse}
+// @Success 207 {object} models.APIResponse{data=models.MultiStatusResponse}
+// @Failure 400 {object} models.APIResponse{error=models.APIError}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/jobs/bulk/complete [post]
+func (h *JobsHandler) BulkCompleteJobs(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	var req models.BulkCompleteJobsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiErr := models.BadRequestError("Invalid request body: " + err.Error())
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		if apiErr, ok := err.(*models.APIError); ok {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		} else {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(models.BadRequestError(err.Error()), requestID))
+		}
+		return
+	}
+
+	logger.Debug("Completing jobs in bulk",
+		logger.String("request_id", requestID),
+		logger.Int("job_count", len(req.Jobs)))
+
+	// Jobs are completed one by one so each item gets its own outcome
+	result := models.NewMultiStatusResponse()
+	ctx := utils.BackgroundContext(c)
+	for _, item := range req.Jobs {
+		if apiErr := h.completeJobItem(ctx, item, requestID); apiErr != nil {
+			result.AddFailure(item.JobKey, apiErr)
+			continue
+		}
+		result.AddSuccess(item.JobKey, http.StatusOK)
+	}
+
+	logger.Info("Bulk job completion processed",
+		logger.String("request_id", requestID),
+		logger.Int("succeeded", result.Succeeded),
+		logger.Int("failed", result.Failed))
+
+	c.JSON(result.HTTPStatus(), models.SuccessResponse(result, requestID))
+}
+
+// completeJobItem completes single job from bulk request
+func (h *JobsHandler) completeJobItem(
+	ctx context.Context,
+	item models.BulkCompleteJobItem,
+	requestID string,
+) *models.APIError {
+	if validationErrors := h.validator.ValidateVariables(item.Variables); len(validationErrors) > 0 {
+		return h.validator.CreateValidationError(validationErrors)
+	}
+
+	variables, apiErr := h.variableLimiter.Apply(item.Variables, "variables")
+	if apiErr != nil {
+		return apiErr
+	}
+
+	completeReq := map[string]interface{}{
+		"type":       "complete_job",
+		"request_id": requestID,
+		"payload": map[string]interface{}{
+			"job_key":   item.JobKey,
+			"variables": variables,
+		},
+	}
+
+	if _, err := h.sendJobsRequest(ctx, completeReq); err != nil {
+		if errors.Is(err, coremodels.ErrNotFound) {
+			return models.JobNotFoundError(item.JobKey)
+		}
+		return h.converter.GRPCErrorToAPIError(err)
+	}
+
+	return nil
+}
+
 // FailJob handles PUT /api/v1/jobs/:key/fail
 // @Summary Fail job
-// @Description Mark a job as failed with retry information
+// @Description Mark a job as failed with retry information.
+// @Description With error_code and no retries left the failure is thrown as BPMN error to matching error boundary
 // @Tags jobs
 // @Accept json
 // @Produce json
 // @Param key path string true "Job key"
 // @Param request body models.FailJobRequest true "Job failure request"
-// @Success 200 {object} models.APIResponse{data=models.SuccessResponse}
+// @Success 200 {object} models.APIResponse{data=models.UpdateResponse}
 // @Failure 400 {object} models.APIResponse{error=models.APIError}
 // @Failure 401 {object} models.APIResponse{error=models.APIError}
 // @Failure 403 {object} models.APIResponse{error=models.APIError}
@@ -562,7 +836,7 @@ func (h *JobsHandler) CompleteJob(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/jobs/{key}/fail [put]
 func (h *JobsHandler) FailJob(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	jobKey := c.Param("key")
 
 	// Parse request body
@@ -591,7 +865,7 @@ func (h *JobsHandler) FailJob(c *gin.Context) {
 	logger.Debug("Failing job",
 		logger.String("request_id", requestID),
 		logger.String("job_key", jobKey),
-		logger.Int("retries", int(req.Retries)))
+		logger.Int("retries", int(*req.Retries)))
 
 	// Create fail job request
 	failReq := map[string]interface{}{
@@ -599,14 +873,15 @@ func (h *JobsHandler) FailJob(c *gin.Context) {
 		"request_id": requestID,
 		"payload": map[string]interface{}{
 			"job_key":       jobKey,
-			"retries":       req.Retries,
+			"retries":       *req.Retries,
 			"error_message": req.ErrorMessage,
-			"backoff_ms":    req.BackoffMs,
+			"retry_backoff": req.BackoffMs,
+			"error_code":    req.ErrorCode,
 		},
 	}
 
 	// Send to jobs component
-	response, err := h.sendJobsRequest(failReq, requestID)
+	_, err := h.sendJobsRequest(utils.BackgroundContext(c), failReq)
 	if err != nil {
 		apiErr := h.converter.GRPCErrorToAPIError(err)
 		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
@@ -614,17 +889,6 @@ func (h *JobsHandler) FailJob(c *gin.Context) {
 		return
 	}
 
-	// Check if operation succeeded
-	if success, ok := response["success"].(bool); !ok || !success {
-		message := "Job failure operation failed"
-		if msg, exists := response["message"].(string); exists {
-			message = msg
-		}
-		apiErr := models.InternalServerError(message)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse(apiErr, requestID))
-		return
-	}
-
 	logger.Info("Job failed successfully",
 		logger.String("request_id", requestID),
 		logger.String("job_key", jobKey))
@@ -645,7 +909,7 @@ func (h *JobsHandler) FailJob(c *gin.Context) {
 // @Produce json
 // @Param key path string true "Job key"
 // @Param request body models.ThrowErrorRequest true "Error throwing request"
-// @Success 200 {object} models.APIResponse{data=models.SuccessResponse}
+// @Success 200 {object} models.APIResponse{data=models.UpdateResponse}
 // @Failure 400 {object} models.APIResponse{error=models.APIError}
 // @Failure 401 {object} models.APIResponse{error=models.APIError}
 // @Failure 403 {object} models.APIResponse{error=models.APIError}
@@ -654,7 +918,7 @@ func (h *JobsHandler) FailJob(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/jobs/{key}/throw-error [post]
 func (h *JobsHandler) ThrowError(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	jobKey := c.Param("key")
 
 	// Parse request body
@@ -698,7 +962,7 @@ func (h *JobsHandler) ThrowError(c *gin.Context) {
 	}
 
 	// Send to jobs component
-	response, err := h.sendJobsRequest(throwReq, requestID)
+	_, err := h.sendJobsRequest(utils.BackgroundContext(c), throwReq)
 	if err != nil {
 		apiErr := h.converter.GRPCErrorToAPIError(err)
 		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
@@ -706,17 +970,6 @@ func (h *JobsHandler) ThrowError(c *gin.Context) {
 		return
 	}
 
-	// Check if operation succeeded
-	if success, ok := response["success"].(bool); !ok || !success {
-		message := "Error throwing operation failed"
-		if msg, exists := response["message"].(string); exists {
-			message = msg
-		}
-		apiErr := models.InternalServerError(message)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse(apiErr, requestID))
-		return
-	}
-
 	logger.Info("Error thrown for job successfully",
 		logger.String("request_id", requestID),
 		logger.String("job_key", jobKey),
@@ -738,7 +991,7 @@ func (h *JobsHandler) ThrowError(c *gin.Context) {
 // @Produce json
 // @Param key path string true "Job key"
 // @Param request body models.UpdateJobRetriesRequest true "Job retries update request"
-// @Success 200 {object} models.APIResponse{data=models.SuccessResponse}
+// @Success 200 {object} models.APIResponse{data=models.UpdateResponse}
 // @Failure 400 {object} models.APIResponse{error=models.APIError}
 // @Failure 401 {object} models.APIResponse{error=models.APIError}
 // @Failure 403 {object} models.APIResponse{error=models.APIError}
@@ -747,7 +1000,7 @@ func (h *JobsHandler) ThrowError(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/jobs/{key}/retries [put]
 func (h *JobsHandler) UpdateJobRetries(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	jobKey := c.Param("key")
 
 	// Parse request body
@@ -789,7 +1042,7 @@ func (h *JobsHandler) UpdateJobRetries(c *gin.Context) {
 	}
 
 	// Send to jobs component
-	response, err := h.sendJobsRequest(updateReq, requestID)
+	_, err := h.sendJobsRequest(utils.BackgroundContext(c), updateReq)
 	if err != nil {
 		apiErr := h.converter.GRPCErrorToAPIError(err)
 		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
@@ -797,17 +1050,6 @@ func (h *JobsHandler) UpdateJobRetries(c *gin.Context) {
 		return
 	}
 
-	// Check if operation succeeded
-	if success, ok := response["success"].(bool); !ok || !success {
-		message := "Job retries update failed"
-		if msg, exists := response["message"].(string); exists {
-			message = msg
-		}
-		apiErr := models.InternalServerError(message)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse(apiErr, requestID))
-		return
-	}
-
 	logger.Info("Job retries updated successfully",
 		logger.String("request_id", requestID),
 		logger.String("job_key", jobKey))
@@ -828,7 +1070,7 @@ func (h *JobsHandler) UpdateJobRetries(c *gin.Context) {
 // @Produce json
 // @Param key path string true "Job key"
 // @Param request body models.CancelJobRequest false "Job cancellation request"
-// @Success 200 {object} models.APIResponse{data=models.SuccessResponse}
+// @Success 200 {object} models.APIResponse{data=models.DeleteResponse}
 // @Failure 400 {object} models.APIResponse{error=models.APIError}
 // @Failure 401 {object} models.APIResponse{error=models.APIError}
 // @Failure 403 {object} models.APIResponse{error=models.APIError}
@@ -837,7 +1079,7 @@ func (h *JobsHandler) UpdateJobRetries(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/jobs/{key} [delete]
 func (h *JobsHandler) CancelJob(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	jobKey := c.Param("key")
 
 	// Parse optional request body
@@ -863,7 +1105,7 @@ func (h *JobsHandler) CancelJob(c *gin.Context) {
 	}
 
 	// Send to jobs component
-	response, err := h.sendJobsRequest(cancelReq, requestID)
+	_, err := h.sendJobsRequest(utils.BackgroundContext(c), cancelReq)
 	if err != nil {
 		apiErr := h.converter.GRPCErrorToAPIError(err)
 		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
@@ -871,17 +1113,6 @@ func (h *JobsHandler) CancelJob(c *gin.Context) {
 		return
 	}
 
-	// Check if operation succeeded
-	if success, ok := response["success"].(bool); !ok || !success {
-		message := "Job cancellation failed"
-		if msg, exists := response["message"].(string); exists {
-			message = msg
-		}
-		apiErr := models.InternalServerError(message)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse(apiErr, requestID))
-		return
-	}
-
 	logger.Info("Job cancelled successfully",
 		logger.String("request_id", requestID),
 		logger.String("job_key", jobKey))
@@ -902,7 +1133,7 @@ func (h *JobsHandler) CancelJob(c *gin.Context) {
 // @Produce json
 // @Param key path string true "Job key"
 // @Param request body models.UpdateJobTimeoutRequest true "Job timeout update request"
-// @Success 200 {object} models.APIResponse{data=models.SuccessResponse}
+// @Success 200 {object} models.APIResponse{data=models.UpdateResponse}
 // @Failure 400 {object} models.APIResponse{error=models.APIError}
 // @Failure 401 {object} models.APIResponse{error=models.APIError}
 // @Failure 403 {object} models.APIResponse{error=models.APIError}
@@ -911,7 +1142,7 @@ func (h *JobsHandler) CancelJob(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/jobs/{key}/timeout [put]
 func (h *JobsHandler) UpdateJobTimeout(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	jobKey := c.Param("key")
 
 	// Parse request body
@@ -953,7 +1184,7 @@ func (h *JobsHandler) UpdateJobTimeout(c *gin.Context) {
 	}
 
 	// Send to jobs component
-	response, err := h.sendJobsRequest(updateReq, requestID)
+	_, err := h.sendJobsRequest(utils.BackgroundContext(c), updateReq)
 	if err != nil {
 		apiErr := h.converter.GRPCErrorToAPIError(err)
 		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
@@ -961,17 +1192,6 @@ func (h *JobsHandler) UpdateJobTimeout(c *gin.Context) {
 		return
 	}
 
-	// Check if operation succeeded
-	if success, ok := response["success"].(bool); !ok || !success {
-		message := "Job timeout update failed"
-		if msg, exists := response["message"].(string); exists {
-			message = msg
-		}
-		apiErr := models.InternalServerError(message)
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse(apiErr, requestID))
-		return
-	}
-
 	logger.Info("Job timeout updated successfully",
 		logger.String("request_id", requestID),
 		logger.String("job_key", jobKey))
@@ -987,6 +1207,7 @@ func (h *JobsHandler) UpdateJobTimeout(c *gin.Context) {
 // GetJobStats handles GET /api/v1/jobs/stats
 // @Summary Get job statistics
 // @Description Get comprehensive job statistics
+// @Description oldest_activatable_age_ms holds age of oldest activatable job per job type
 // @Tags jobs
 // @Produce json
 // @Success 200 {object} models.APIResponse{data=JobStats}
@@ -996,7 +1217,7 @@ func (h *JobsHandler) UpdateJobTimeout(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/jobs/stats [get]
 func (h *JobsHandler) GetJobStats(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	logger.Debug("Getting job statistics",
 		logger.String("request_id", requestID))
@@ -1009,7 +1230,7 @@ func (h *JobsHandler) GetJobStats(c *gin.Context) {
 	}
 
 	// Send to jobs component
-	response, err := h.sendJobsRequest(statsReq, requestID)
+	response, err := h.sendJobsRequest(utils.BackgroundContext(c), statsReq)
 	if err != nil {
 		apiErr := h.converter.GRPCErrorToAPIError(err)
 		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
@@ -1027,10 +1248,12 @@ func (h *JobsHandler) GetJobStats(c *gin.Context) {
 		JobsByWorker:     make(map[string]int64),
 		AverageLatency:   0.0,
 		ThroughputPerMin: 0,
+
+		OldestActivatableAgeMs: make(map[string]int64),
 	}
 
 	// Extract stats from response
-	if statsData, exists := response["stats"]; exists {
+	if statsData, exists := response["result"]; exists {
 		if statsMap, ok := statsData.(map[string]interface{}); ok {
 			if totalJobs, ok := statsMap["total_jobs"].(float64); ok {
 				stats.TotalJobs = int64(totalJobs)
@@ -1044,6 +1267,13 @@ func (h *JobsHandler) GetJobStats(c *gin.Context) {
 			if failedJobs, ok := statsMap["failed_jobs"].(float64); ok {
 				stats.FailedJobs = int64(failedJobs)
 			}
+			if ages, ok := statsMap["oldest_activatable_age_ms"].(map[string]interface{}); ok {
+				for jobType, age := range ages {
+					if ageMs, ok := age.(float64); ok {
+						stats.OldestActivatableAgeMs[jobType] = int64(ageMs)
+					}
+				}
+			}
 		}
 	}
 
@@ -1056,18 +1286,21 @@ func (h *JobsHandler) GetJobStats(c *gin.Context) {
 
 // Helper methods
 
-func (h *JobsHandler) sendJobsRequest(req map[string]interface{}, requestID string) (map[string]interface{}, error) {
+func (h *JobsHandler) sendJobsRequest(
+	ctx context.Context,
+	req map[string]interface{},
+) (map[string]interface{}, error) {
 	reqJSON, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	err = h.coreInterface.SendMessage("jobs", string(reqJSON))
+	componentRequestID, err := h.coreInterface.SendRequestWithContext(ctx, "jobs", string(reqJSON))
 	if err != nil {
 		return nil, fmt.Errorf("failed to send message: %w", err)
 	}
 
-	respJSON, err := h.coreInterface.WaitForJobsResponse(30000)
+	respJSON, err := h.coreInterface.WaitForJobsResponse(componentRequestID, 30000)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get response: %w", err)
 	}
@@ -1078,26 +1311,37 @@ func (h *JobsHandler) sendJobsRequest(req map[string]interface{}, requestID stri
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
+	// Failed operation is returned as error restored from error_code, message text is not inspected
+	if success, _ := response["success"].(bool); !success {
+		message, _ := response["error"].(string)
+		if message == "" {
+			message = "jobs component request failed"
+		}
+		errorCode, _ := response["error_code"].(string)
+		return nil, coremodels.ErrorFromCode(errorCode, message)
+	}
+
 	return response, nil
 }
 
-func (h *JobsHandler) parseJobsFromResponse(response map[string]interface{}) []Job {
-	var jobs []Job
-
-	// Extract result from response
-	resultData, exists := response["result"]
-	if !exists {
-		return jobs
-	}
-
-	resultMap, ok := resultData.(map[string]interface{})
-	if !ok {
-		return jobs
-	}
+// parseListTotal extracts total count of matching items from component list response
+func parseListTotal(response map[string]interface{}) int {
+	result, _ := response["result"].(map[string]interface{})
+	total, _ := result["total"].(float64)
+	return int(total)
+}
 
-	// Extract jobs array from result
-	jobsData, exists := resultMap["jobs"]
-	if !exists {
+func (h *JobsHandler) parseJobsFromResponse(response map[string]interface{}) []Job {
+	jobs := []Job{}
+
+	// Activation returns bare jobs array, list wraps it into result object with total
+	var jobsData interface{}
+	switch result := response["result"].(type) {
+	case []interface{}:
+		jobsData = result
+	case map[string]interface{}:
+		jobsData = result["jobs"]
+	default:
 		return jobs
 	}
 
@@ -1122,6 +1366,36 @@ func (h *JobsHandler) parseJobsFromResponse(response map[string]interface{}) []J
 	return jobs
 }
 
+// parseBatchJobsFromResponse extracts job lookups of batch get response
+func (h *JobsHandler) parseBatchJobsFromResponse(response map[string]interface{}) *BatchGetJobsResponse {
+	batchResp := &BatchGetJobsResponse{Jobs: []BatchJobResult{}}
+
+	resultMap, _ := response["result"].(map[string]interface{})
+	lookups, _ := resultMap["jobs"].([]interface{})
+	for _, lookupData := range lookups {
+		lookupMap, ok := lookupData.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		item := BatchJobResult{}
+		item.Key, _ = lookupMap["job_id"].(string)
+		if jobMap, ok := lookupMap["job"].(map[string]interface{}); ok {
+			item.Job = h.parseJobFromMap(jobMap)
+		}
+		item.Found = item.Job != nil
+
+		if item.Found {
+			batchResp.Found++
+		} else {
+			batchResp.NotFound++
+		}
+		batchResp.Jobs = append(batchResp.Jobs, item)
+	}
+
+	return batchResp
+}
+
 func (h *JobsHandler) parseJobFromResponse(response map[string]interface{}) *Job {
 	// Extract result from response
 	resultData, exists := response["result"]
@@ -1141,8 +1415,8 @@ func (h *JobsHandler) parseJobFromMap(jobMap map[string]interface{}) *Job {
 	job := &Job{}
 
 	// Parse string fields
-	if key, ok := jobMap["key"].(string); ok {
-		job.Key = key
+	if id, ok := jobMap["key"].(string); ok {
+		job.ID = id
 	}
 	if jobType, ok := jobMap["type"].(string); ok {
 		job.Type = jobType
@@ -1150,6 +1424,9 @@ func (h *JobsHandler) parseJobFromMap(jobMap map[string]interface{}) *Job {
 	if processInstanceID, ok := jobMap["process_instance_id"].(string); ok {
 		job.ProcessInstanceID = processInstanceID
 	}
+	if elementInstanceID, ok := jobMap["element_instance_id"].(string); ok {
+		job.ElementInstanceID = elementInstanceID
+	}
 	if worker, ok := jobMap["worker"].(string); ok {
 		job.Worker = worker
 	}
@@ -1157,19 +1434,50 @@ func (h *JobsHandler) parseJobFromMap(jobMap map[string]interface{}) *Job {
 		job.State = status
 	}
 
+	// Parse numeric keys, encoded as strings by jobs component
+	job.Key = parseNumericKey(jobMap["numeric_key"])
+	job.ProcessInstanceKey = parseNumericKey(jobMap["process_instance_key"])
+	job.ElementInstanceKey = parseNumericKey(jobMap["element_instance_key"])
+
 	// Parse numeric fields
 	if retries, ok := jobMap["retries"].(float64); ok {
 		job.Retries = int32(retries)
 	}
+	if priority, ok := jobMap["priority"].(float64); ok {
+		job.Priority = int32(priority)
+	}
 	if createdAt, ok := jobMap["created_at"].(float64); ok {
 		job.CreatedAt = int64(createdAt)
 	}
+	if nextRetryAt, ok := jobMap["next_retry_at"].(float64); ok {
+		job.NextRetryAt = int64(nextRetryAt)
+	}
 
 	// Parse variables
 	if variables, ok := jobMap["variables"].(map[string]interface{}); ok {
 		job.Variables = variables
 	}
 
+	// Parse custom headers copied from task definition
+	if headers, ok := jobMap["custom_headers"].(map[string]interface{}); ok {
+		job.CustomHeaders = make(map[string]string, len(headers))
+		for key, value := range headers {
+			if str, ok := value.(string); ok {
+				job.CustomHeaders[key] = str
+			}
+		}
+	}
+
+	// Parse engine metadata, e.g. job type expression
+	if metadata, ok := jobMap["metadata"].(map[string]interface{}); ok {
+		job.Metadata = make(map[string]string, len(metadata))
+		for key, value := range metadata {
+			if str, ok := value.(string); ok {
+				job.Metadata[key] = str
+			}
+		}
+	}
+
 	// Initialize empty maps if nil
 	if job.CustomHeaders == nil {
 		job.CustomHeaders = make(map[string]string)
@@ -1181,29 +1489,12 @@ func (h *JobsHandler) parseJobFromMap(jobMap map[string]interface{}) *Job {
 	return job
 }
 
-func (h *JobsHandler) extractTotalCount(response map[string]interface{}) int {
-	// Extract result from response
-	resultData, exists := response["result"]
-	if !exists {
-		return 0
-	}
-
-	resultMap, ok := resultData.(map[string]interface{})
+// parseNumericKey parses numeric key encoded as JSON string, zero when missing
+func parseNumericKey(value interface{}) int64 {
+	str, ok := value.(string)
 	if !ok {
 		return 0
 	}
-
-	// Extract total from result
-	if total, ok := resultMap["total"].(float64); ok {
-		return int(total)
-	}
-
-	return 0
-}
-
-func (h *JobsHandler) getRequestID(c *gin.Context) string {
-	if requestID := c.GetHeader("X-Request-ID"); requestID != "" {
-		return requestID
-	}
-	return utils.GenerateSecureRequestID("jobs")
+	key, _ := strconv.ParseInt(str, 10, 64)
+	return key
 }
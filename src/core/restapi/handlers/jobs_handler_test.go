@@ -0,0 +1,208 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"atom-engine/src/core/restapi/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeJobsCore answers every jobs component request with fixed response
+type fakeJobsCore struct {
+	response map[string]interface{}
+}
+
+func (f *fakeJobsCore) SendRequestWithContext(ctx context.Context, componentName, messageJSON string) (string, error) {
+	return "component-request-1", nil
+}
+
+func (f *fakeJobsCore) WaitForJobsResponse(requestID string, timeoutMs int) (string, error) {
+	data, err := json.Marshal(f.response)
+	return string(data), err
+}
+
+func (f *fakeJobsCore) GetJobsComponent() interface{} {
+	return nil
+}
+
+// getJob serves GET /jobs/:key with jobs handler backed by fixed component response
+func getJob(t *testing.T, response map[string]interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewJobsHandler(&fakeJobsCore{response: response}, nil, nil)
+	router.GET("/jobs/:key", handler.GetJob)
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/jobs/job-1", nil))
+	return recorder
+}
+
+// TestGetJobStatusIgnoresNotFoundInErrorText guards against classifying failures by message text:
+// job variables echoed in error message may contain "not found" without job being missing
+func TestGetJobStatusIgnoresNotFoundInErrorText(t *testing.T) {
+	tests := []struct {
+		name     string
+		response map[string]interface{}
+		want     int
+	}{
+		{
+			name: "untyped failure with variables containing not found",
+			response: map[string]interface{}{
+				"success": false,
+				"error":   `failed to decode job variables {"lookup":"customer not found"}`,
+			},
+			want: http.StatusInternalServerError,
+		},
+		{
+			name: "internal error code with not found in message",
+			response: map[string]interface{}{
+				"success":    false,
+				"error":      `variable "status" = "not found" exceeds limit`,
+				"error_code": "INTERNAL",
+			},
+			want: http.StatusInternalServerError,
+		},
+		{
+			name: "not found error code",
+			response: map[string]interface{}{
+				"success":    false,
+				"error":      "job job-1 does not exist",
+				"error_code": "NOT_FOUND",
+			},
+			want: http.StatusNotFound,
+		},
+		{
+			name: "job with variables containing not found",
+			response: map[string]interface{}{
+				"success": true,
+				"result": map[string]interface{}{
+					"key":       "job-1",
+					"type":      "lookup",
+					"state":     "ACTIVATABLE",
+					"variables": map[string]interface{}{"lookup": "not found"},
+				},
+			},
+			want: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := getJob(t, tt.response)
+			if recorder.Code != tt.want {
+				t.Errorf("status = %d, want %d, body %s", recorder.Code, tt.want, recorder.Body.String())
+			}
+		})
+	}
+}
+
+func TestCompleteJobRejectsInvalidVariables(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	core := &fakeJobsCore{response: map[string]interface{}{"success": true}}
+	router.POST("/jobs/:key/complete", NewJobsHandler(core, strictVariableValidator(t), nil).CompleteJob)
+
+	fields := postValidationFields(t, router, "/jobs/job-1/complete", map[string]interface{}{
+		"variables": invalidVariables(),
+	})
+	if strings.Join(fields, ",") != strings.Join(wantInvalidVariableFields, ",") {
+		t.Errorf("offending fields %q, want %q", fields, wantInvalidVariableFields)
+	}
+}
+
+// activateJobs posts activation request to jobs handler backed by fixed component response
+func activateJobs(t *testing.T, response, body map[string]interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewJobsHandler(&fakeJobsCore{response: response}, utils.NewValidator(), nil)
+	router.POST("/jobs/activate", handler.ActivateJobs)
+
+	data, _ := json.Marshal(body)
+	request := httptest.NewRequest(http.MethodPost, "/jobs/activate", strings.NewReader(string(data)))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestActivateJobsProjectsRequestedFields(t *testing.T) {
+	response := map[string]interface{}{
+		"success": true,
+		"result": []interface{}{map[string]interface{}{
+			"key":         "job-1",
+			"numeric_key": "42",
+			"type":        "ship",
+			"status":      "ACTIVATED",
+			"variables": map[string]interface{}{
+				"order":  map[string]interface{}{"id": "o-1", "total": 120},
+				"secret": "token",
+			},
+		}},
+	}
+
+	tests := []struct {
+		name   string
+		fields []string
+		fetch  []string
+		want   string
+	}{
+		{
+			name:   "key and nested variable",
+			fields: []string{"key", "variables.order.id"},
+			want:   `{"jobs":[{"key":42,"variables":{"order":{"id":"o-1"}}}]}`,
+		},
+		{
+			name:   "projection after fetched variables",
+			fields: []string{"id", "variables.secret", "variables.order.total"},
+			fetch:  []string{"order"},
+			want:   `{"jobs":[{"id":"job-1","variables":{"order":{"total":120}}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := activateJobs(t, response, map[string]interface{}{
+				"type": "ship", "worker": "w1", "fields": tt.fields, "fetch_variables": tt.fetch,
+			})
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("status %d, body %s", recorder.Code, recorder.Body.String())
+			}
+			var body struct {
+				Data json.RawMessage `json:"data"`
+			}
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if string(body.Data) != tt.want {
+				t.Errorf("data %s, want %s", body.Data, tt.want)
+			}
+		})
+	}
+}
+
+func TestActivateJobsRejectsEmptyFieldPath(t *testing.T) {
+	recorder := activateJobs(t, map[string]interface{}{"success": true}, map[string]interface{}{
+		"type": "ship", "worker": "w1", "fields": []string{"variables..id"},
+	})
+	if recorder.Code != http.StatusBadRequest {
+		t.Errorf("status %d, want 400, body %s", recorder.Code, recorder.Body.String())
+	}
+}
@@ -9,12 +9,14 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
+	"atom-engine/src/core/auth"
 	"atom-engine/src/core/logger"
 	"atom-engine/src/core/restapi/middleware"
 	"atom-engine/src/core/restapi/models"
@@ -23,16 +25,17 @@ import (
 
 // MessagesHandler handles message management HTTP requests
 type MessagesHandler struct {
-	coreInterface MessagesCoreInterface
-	converter     *utils.Converter
-	validator     *utils.Validator
+	coreInterface   MessagesCoreInterface
+	converter       *utils.Converter
+	validator       *utils.Validator
+	variableLimiter *utils.VariableLimiter
 }
 
 // MessagesCoreInterface defines methods needed for messages operations
 type MessagesCoreInterface interface {
 	// JSON Message Routing to messages component
-	SendMessage(componentName, messageJSON string) error
-	WaitForMessagesResponse(timeoutMs int) (string, error)
+	SendRequestWithContext(ctx context.Context, componentName, messageJSON string) (string, error)
+	WaitForMessagesResponse(requestID string, timeoutMs int) (string, error)
 	GetMessagesComponent() interface{}
 }
 
@@ -64,11 +67,31 @@ type MessageSubscription struct {
 }
 
 type MessageStats struct {
-	TotalMessages         int32 `json:"total_messages"`
-	BufferedMessages      int32 `json:"buffered_messages"`
-	ExpiredMessages       int32 `json:"expired_messages"`
-	PublishedToday        int32 `json:"published_today"`
-	InstancesCreatedToday int32 `json:"instances_created_today"`
+	TotalMessages          int32   `json:"total_messages"`
+	BufferedMessages       int32   `json:"buffered_messages"`
+	ExpiredMessages        int32   `json:"expired_messages"`
+	PublishedToday         int32   `json:"published_today"`
+	InstancesCreatedToday  int32   `json:"instances_created_today"`
+	CorrelatedImmediately  int32   `json:"correlated_immediately"`
+	CorrelatedFromBuffer   int32   `json:"correlated_from_buffer"`
+	ExpiredUncorrelated    int32   `json:"expired_uncorrelated"`
+	AvgBufferDwellMs       int64   `json:"avg_buffer_dwell_ms"`
+	CorrelationSuccessRate float64 `json:"correlation_success_rate"`
+
+	BufferCapacity       int32               `json:"buffer_capacity"`
+	BufferEvictionPolicy string              `json:"buffer_eviction_policy"`
+	BufferEvicted        int64               `json:"buffer_evicted"`
+	BufferRejected       int64               `json:"buffer_rejected"`
+	BufferUtilization    []BufferUtilization `json:"buffer_utilization"`
+}
+
+// BufferUtilization describes fill level of buffer of one tenant and message name
+type BufferUtilization struct {
+	TenantID           string  `json:"tenant_id"`
+	MessageName        string  `json:"message_name"`
+	Buffered           int32   `json:"buffered"`
+	Capacity           int32   `json:"capacity"`
+	UtilizationPercent float64 `json:"utilization_percent"`
 }
 
 type PublishMessageResponse struct {
@@ -83,11 +106,16 @@ type CleanupResponse struct {
 }
 
 // NewMessagesHandler creates new messages handler
-func NewMessagesHandler(coreInterface MessagesCoreInterface) *MessagesHandler {
+func NewMessagesHandler(
+	coreInterface MessagesCoreInterface,
+	validator *utils.Validator,
+	variableLimiter *utils.VariableLimiter,
+) *MessagesHandler {
 	return &MessagesHandler{
-		coreInterface: coreInterface,
-		converter:     utils.NewConverter(),
-		validator:     utils.NewValidator(),
+		coreInterface:   coreInterface,
+		converter:       utils.NewConverter(),
+		validator:       validator,
+		variableLimiter: variableLimiter,
 	}
 }
 
@@ -112,7 +140,8 @@ func (h *MessagesHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware
 
 // PublishMessage handles POST /api/v1/messages/publish
 // @Summary Publish message
-// @Description Publish a message for process correlation
+// @Description Publish a message for process correlation. Message correlates only with subscriptions of its tenant,
+// @Description broadcast_across_tenants lets it correlate with any tenant and requires cross_tenant_messages permission
 // @Tags messages
 // @Accept json
 // @Produce json
@@ -121,11 +150,12 @@ func (h *MessagesHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware
 // @Failure 400 {object} models.APIResponse{error=models.APIError}
 // @Failure 401 {object} models.APIResponse{error=models.APIError}
 // @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 429 {object} models.APIResponse{error=models.APIError} "Message buffer is full"
 // @Failure 500 {object} models.APIResponse{error=models.APIError}
 // @Security ApiKeyAuth
 // @Router /api/v1/messages/publish [post]
 func (h *MessagesHandler) PublishMessage(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse request body
 	var req models.PublishMessageRequest
@@ -174,6 +204,7 @@ func (h *MessagesHandler) PublishMessage(c *gin.Context) {
 			return nil
 		},
 	)
+	validationErrors = append(validationErrors, h.validator.ValidateVariables(req.Variables)...)
 
 	if len(validationErrors) > 0 {
 		apiErr := h.validator.CreateValidationError(validationErrors)
@@ -181,6 +212,34 @@ func (h *MessagesHandler) PublishMessage(c *gin.Context) {
 		return
 	}
 
+	// Enforce variable size limits and offload large values
+	variables, apiErr := h.variableLimiter.Apply(req.Variables, "variables")
+	if apiErr != nil {
+		c.JSON(models.HTTPStatusFromErrorCode(apiErr.Code), models.ErrorResponse(apiErr, requestID))
+		return
+	}
+	req.Variables = variables
+
+	if req.BroadcastAcrossTenants {
+		authResult, ok := middleware.GetAuthResult(c)
+		if ok && authResult != nil && !auth.HasPermission(authResult.Permissions, auth.PermissionCrossTenantMessages) {
+			apiErr := models.ForbiddenError("broadcast_across_tenants requires cross_tenant_messages permission")
+			c.JSON(http.StatusForbidden, models.ErrorResponse(apiErr, requestID))
+			return
+		}
+
+		apiKeyName := ""
+		if authResult != nil {
+			apiKeyName = authResult.APIKeyName
+		}
+		logger.Warn("Audit: cross-tenant message broadcast requested",
+			logger.String("request_id", requestID),
+			logger.String("api_key_name", apiKeyName),
+			logger.String("tenant_id", req.TenantID),
+			logger.String("message_name", req.MessageName),
+			logger.String("correlation_key", req.CorrelationKey))
+	}
+
 	logger.Debug("Publishing message",
 		logger.String("request_id", requestID),
 		logger.String("message_name", req.MessageName),
@@ -192,16 +251,17 @@ func (h *MessagesHandler) PublishMessage(c *gin.Context) {
 		"type":       "publish_message",
 		"request_id": requestID,
 		"payload": map[string]interface{}{
-			"tenant_id":       req.TenantID,
-			"message_name":    req.MessageName,
-			"correlation_key": req.CorrelationKey,
-			"variables":       req.Variables,
-			"ttl_seconds":     req.TTLSeconds,
+			"tenant_id":                req.TenantID,
+			"message_name":             req.MessageName,
+			"correlation_key":          req.CorrelationKey,
+			"variables":                req.Variables,
+			"ttl_seconds":              req.TTLSeconds,
+			"broadcast_across_tenants": req.BroadcastAcrossTenants,
 		},
 	}
 
 	// Send to messages component
-	response, err := h.sendMessagesRequest(publishReq, requestID)
+	response, err := h.sendMessagesRequest(utils.BackgroundContext(c), publishReq)
 	if err != nil {
 		apiErr := h.converter.GRPCErrorToAPIError(err)
 		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
@@ -222,16 +282,23 @@ func (h *MessagesHandler) PublishMessage(c *gin.Context) {
 			logger.String("message_name", req.MessageName),
 			logger.String("error", errorMsg))
 
-		apiErr := models.NewAPIError(models.ErrorCodeMessageFailed, errorMsg)
+		// Full buffer is reported with own code so clients can back off instead of failing
+		errorCode, _ := response["error_code"].(string)
+		if errorCode != models.ErrorCodeMessageBufferFull {
+			errorCode = models.ErrorCodeMessageFailed
+		}
+		apiErr := models.NewAPIError(errorCode, errorMsg)
 		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
 		c.JSON(statusCode, models.ErrorResponse(apiErr, requestID))
 		return
 	}
 
 	// Extract message information from response
-	messageID, _ := response["message_id"].(string)
-	matched, _ := response["matched"].(bool)
-	message, _ := response["message"].(string)
+	result, _ := response["result"].(map[string]interface{})
+	messageID, _ := result["message_id"].(string)
+	processInstanceID, _ := result["process_instance_id"].(string)
+	matched := processInstanceID != ""
+	message, _ := result["message"].(string)
 
 	publishResp := &PublishMessageResponse{
 		MessageID: messageID,
@@ -263,7 +330,7 @@ func (h *MessagesHandler) PublishMessage(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/messages [get]
 func (h *MessagesHandler) ListBufferedMessages(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse query parameters
 	pageStr := c.DefaultQuery("page", "1")
@@ -296,7 +363,7 @@ func (h *MessagesHandler) ListBufferedMessages(c *gin.Context) {
 	}
 
 	// Send to messages component and get response
-	response, err := h.sendMessagesRequest(listReq, requestID)
+	response, err := h.sendMessagesRequest(utils.BackgroundContext(c), listReq)
 	if err != nil {
 		apiErr := h.converter.GRPCErrorToAPIError(err)
 		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
@@ -306,7 +373,7 @@ func (h *MessagesHandler) ListBufferedMessages(c *gin.Context) {
 
 	// Parse messages and total count from response
 	messages := h.parseBufferedMessagesFromResponse(response)
-	totalCount := h.extractTotalCount(response)
+	totalCount := utils.ExtractTotalCount(response, "total_count")
 
 	logger.Info("Buffered messages listed",
 		logger.String("request_id", requestID),
@@ -332,7 +399,7 @@ func (h *MessagesHandler) ListBufferedMessages(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/messages/subscriptions [get]
 func (h *MessagesHandler) ListSubscriptions(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse query parameters
 	pageStr := c.DefaultQuery("page", "1")
@@ -365,7 +432,7 @@ func (h *MessagesHandler) ListSubscriptions(c *gin.Context) {
 	}
 
 	// Send to messages component and get response
-	response, err := h.sendMessagesRequest(listReq, requestID)
+	response, err := h.sendMessagesRequest(utils.BackgroundContext(c), listReq)
 	if err != nil {
 		apiErr := h.converter.GRPCErrorToAPIError(err)
 		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
@@ -375,7 +442,7 @@ func (h *MessagesHandler) ListSubscriptions(c *gin.Context) {
 
 	// Parse subscriptions and total count from response
 	subscriptions := h.parseSubscriptionsFromResponse(response)
-	totalCount := h.extractTotalCount(response)
+	totalCount := utils.ExtractTotalCount(response, "total_count")
 
 	logger.Info("Message subscriptions listed",
 		logger.String("request_id", requestID),
@@ -399,7 +466,7 @@ func (h *MessagesHandler) ListSubscriptions(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/messages/stats [get]
 func (h *MessagesHandler) GetStats(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	tenantID := c.Query("tenant_id")
 
 	logger.Debug("Getting message statistics",
@@ -416,7 +483,7 @@ func (h *MessagesHandler) GetStats(c *gin.Context) {
 	}
 
 	// Send to messages component and get response
-	response, err := h.sendMessagesRequest(statsReq, requestID)
+	response, err := h.sendMessagesRequest(utils.BackgroundContext(c), statsReq)
 	if err != nil {
 		apiErr := h.converter.GRPCErrorToAPIError(err)
 		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
@@ -448,7 +515,7 @@ func (h *MessagesHandler) GetStats(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/messages/expired [delete]
 func (h *MessagesHandler) CleanupExpired(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	tenantID := c.Query("tenant_id")
 
 	logger.Debug("Cleaning up expired messages",
@@ -465,7 +532,7 @@ func (h *MessagesHandler) CleanupExpired(c *gin.Context) {
 	}
 
 	// Send to messages component and get response
-	response, err := h.sendMessagesRequest(cleanupReq, requestID)
+	response, err := h.sendMessagesRequest(utils.BackgroundContext(c), cleanupReq)
 	if err != nil {
 		apiErr := h.converter.GRPCErrorToAPIError(err)
 		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
@@ -507,7 +574,7 @@ func (h *MessagesHandler) CleanupExpired(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/messages/test [post]
 func (h *MessagesHandler) TestMessage(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse request body
 	var req models.PublishMessageRequest
@@ -548,20 +615,20 @@ func (h *MessagesHandler) TestMessage(c *gin.Context) {
 // Helper methods
 
 func (h *MessagesHandler) sendMessagesRequest(
+	ctx context.Context,
 	req map[string]interface{},
-	requestID string,
 ) (map[string]interface{}, error) {
 	reqJSON, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	err = h.coreInterface.SendMessage("messages", string(reqJSON))
+	componentRequestID, err := h.coreInterface.SendRequestWithContext(ctx, "messages", string(reqJSON))
 	if err != nil {
 		return nil, fmt.Errorf("failed to send message: %w", err)
 	}
 
-	respJSON, err := h.coreInterface.WaitForMessagesResponse(30000)
+	respJSON, err := h.coreInterface.WaitForMessagesResponse(componentRequestID, 30000)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get response: %w", err)
 	}
@@ -586,20 +653,81 @@ func (h *MessagesHandler) parseSubscriptionsFromResponse(response map[string]int
 }
 
 func (h *MessagesHandler) parseStatsFromResponse(response map[string]interface{}) *MessageStats {
-	// Parse stats from response - implementation details
-	return &MessageStats{}
-}
+	stats := &MessageStats{}
 
-func (h *MessagesHandler) extractTotalCount(response map[string]interface{}) int {
-	if count, ok := response["total_count"].(float64); ok {
-		return int(count)
+	// Extract result from response
+	statsMap, ok := response["result"].(map[string]interface{})
+	if !ok {
+		return stats
 	}
-	return 0
-}
 
-func (h *MessagesHandler) getRequestID(c *gin.Context) string {
-	if requestID := c.GetHeader("X-Request-ID"); requestID != "" {
-		return requestID
+	// Parse counters
+	if v, ok := statsMap["total_messages"].(float64); ok {
+		stats.TotalMessages = int32(v)
+	}
+	if v, ok := statsMap["buffered_messages"].(float64); ok {
+		stats.BufferedMessages = int32(v)
+	}
+	if v, ok := statsMap["expired_messages"].(float64); ok {
+		stats.ExpiredMessages = int32(v)
+	}
+	if v, ok := statsMap["published_today"].(float64); ok {
+		stats.PublishedToday = int32(v)
 	}
-	return utils.GenerateSecureRequestID("messages")
+	if v, ok := statsMap["instances_created_today"].(float64); ok {
+		stats.InstancesCreatedToday = int32(v)
+	}
+
+	// Parse correlation breakdown
+	if v, ok := statsMap["correlated_immediately"].(float64); ok {
+		stats.CorrelatedImmediately = int32(v)
+	}
+	if v, ok := statsMap["correlated_from_buffer"].(float64); ok {
+		stats.CorrelatedFromBuffer = int32(v)
+	}
+	if v, ok := statsMap["expired_uncorrelated"].(float64); ok {
+		stats.ExpiredUncorrelated = int32(v)
+	}
+	if v, ok := statsMap["avg_buffer_dwell_ms"].(float64); ok {
+		stats.AvgBufferDwellMs = int64(v)
+	}
+	if v, ok := statsMap["correlation_success_rate"].(float64); ok {
+		stats.CorrelationSuccessRate = v
+	}
+
+	// Parse buffer capacity and utilization
+	if v, ok := statsMap["buffer_capacity"].(float64); ok {
+		stats.BufferCapacity = int32(v)
+	}
+	if v, ok := statsMap["buffer_eviction_policy"].(string); ok {
+		stats.BufferEvictionPolicy = v
+	}
+	if v, ok := statsMap["buffer_evicted"].(float64); ok {
+		stats.BufferEvicted = int64(v)
+	}
+	if v, ok := statsMap["buffer_rejected"].(float64); ok {
+		stats.BufferRejected = int64(v)
+	}
+	if items, ok := statsMap["buffer_utilization"].([]interface{}); ok {
+		stats.BufferUtilization = make([]BufferUtilization, 0, len(items))
+		for _, item := range items {
+			entry, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			utilization := BufferUtilization{}
+			utilization.TenantID, _ = entry["tenant_id"].(string)
+			utilization.MessageName, _ = entry["message_name"].(string)
+			if v, ok := entry["buffered"].(float64); ok {
+				utilization.Buffered = int32(v)
+			}
+			if v, ok := entry["capacity"].(float64); ok {
+				utilization.Capacity = int32(v)
+			}
+			utilization.UtilizationPercent, _ = entry["utilization_percent"].(float64)
+			stats.BufferUtilization = append(stats.BufferUtilization, utilization)
+		}
+	}
+
+	return stats
 }
@@ -0,0 +1,39 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package handlers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeMessagesCore does not implement any method, request reaching messages component panics
+type fakeMessagesCore struct {
+	MessagesCoreInterface
+}
+
+func TestPublishMessageRejectsInvalidVariables(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/messages/publish",
+		NewMessagesHandler(&fakeMessagesCore{}, strictVariableValidator(t), nil).PublishMessage)
+
+	// Variable errors are reported together with other field errors of request
+	fields := postValidationFields(t, router, "/messages/publish", map[string]interface{}{
+		"message_name": "payment-received",
+		"ttl_seconds":  -1,
+		"variables":    invalidVariables(),
+	})
+	want := append([]string{"ttl_seconds"}, wantInvalidVariableFields...)
+	if strings.Join(fields, ",") != strings.Join(want, ",") {
+		t.Errorf("offending fields %q, want %q", fields, want)
+	}
+}
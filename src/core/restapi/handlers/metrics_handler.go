@@ -0,0 +1,238 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/metrics"
+	"atom-engine/src/core/types"
+)
+
+// MetricsHandler serves engine metrics in Prometheus text format
+type MetricsHandler struct {
+	coreInterface MetricsCoreInterface
+}
+
+// MetricsCoreInterface defines methods needed for metrics export
+type MetricsCoreInterface interface {
+	GetSystemInfo() (*types.SystemInfo, error)
+	GetTokenExecutionStats() (*types.TokenExecutionStats, error)
+	GetComponentLatencyStats() []types.ComponentLatencyStats
+	GetHistoryExportStats() *types.HistoryExportStats
+	GetJobBacklogStats() []types.JobBacklogStats
+	GetAuditStats() *types.AuditStats
+}
+
+// NewMetricsHandler creates new metrics handler
+func NewMetricsHandler(coreInterface MetricsCoreInterface) *MetricsHandler {
+	return &MetricsHandler{
+		coreInterface: coreInterface,
+	}
+}
+
+// RegisterRoutes registers metrics route on root router, outside of API versioning
+func (h *MetricsHandler) RegisterRoutes(router gin.IRouter) {
+	router.GET("/metrics", h.GetMetrics)
+}
+
+// GetMetrics handles GET /metrics
+// @Summary Prometheus metrics
+// @Description Export engine process metrics in Prometheus text exposition format
+// @Tags system
+// @Produce plain
+// @Success 200 {string} string "Prometheus metrics"
+// @Router /metrics [get]
+func (h *MetricsHandler) GetMetrics(c *gin.Context) {
+	w := metrics.NewWriter()
+
+	info, err := h.coreInterface.GetSystemInfo()
+	if err != nil {
+		logger.Warn("Failed to collect system info for metrics", logger.String("error", err.Error()))
+	} else {
+		writeSystemMetrics(w, info)
+	}
+
+	tokenStats, err := h.coreInterface.GetTokenExecutionStats()
+	if err != nil {
+		logger.Warn("Failed to collect token execution stats for metrics", logger.String("error", err.Error()))
+	} else {
+		writeTokenExecutionMetrics(w, tokenStats)
+	}
+
+	writeComponentLatencyMetrics(w, h.coreInterface.GetComponentLatencyStats())
+	writeProcessMetrics(w, metrics.Processes)
+	writeJobBacklogMetrics(w, h.coreInterface.GetJobBacklogStats())
+	if stats := h.coreInterface.GetHistoryExportStats(); stats != nil {
+		writeHistoryExportMetrics(w, stats)
+	}
+	if stats := h.coreInterface.GetAuditStats(); stats != nil {
+		writeAuditMetrics(w, stats)
+	}
+
+	c.Data(http.StatusOK, metrics.ContentType, w.Bytes())
+}
+
+// writeSystemMetrics writes host, CPU and Go runtime gauges
+func writeSystemMetrics(w *metrics.Writer, info *types.SystemInfo) {
+	w.Gauge("atom_uptime_seconds", "Engine uptime in seconds.", info.Uptime.Seconds(), nil)
+	w.Gauge("atom_host_memory_total_bytes", "Total physical memory of host.", float64(info.HostInfo.MemoryTotal), nil)
+	w.Gauge("atom_host_disk_total_bytes", "Total size of system disk.", float64(info.HostInfo.DiskTotal), nil)
+
+	cpu := info.CPU
+	if cpu == nil {
+		return
+	}
+
+	w.Gauge("atom_cpu_cores", "Number of logical CPU cores.", float64(cpu.Cores), nil)
+	w.Gauge("atom_gomaxprocs", "Go GOMAXPROCS setting.", float64(cpu.GOMAXPROCS), nil)
+	if cpu.ProcessPercent >= 0 {
+		w.Gauge("atom_process_cpu_usage_percent",
+			"CPU used by engine process as percentage of all cores, sampled over short interval.",
+			cpu.ProcessPercent, nil)
+	}
+	if cpu.LoadAverage != nil {
+		help := "System load average."
+		w.Gauge("atom_load_average", help, cpu.LoadAverage.Load1, metrics.Labels{"period": "1m"})
+		w.Gauge("atom_load_average", help, cpu.LoadAverage.Load5, metrics.Labels{"period": "5m"})
+		w.Gauge("atom_load_average", help, cpu.LoadAverage.Load15, metrics.Labels{"period": "15m"})
+	}
+	w.Gauge("atom_goroutines", "Number of goroutines.", float64(cpu.Goroutines), nil)
+	w.Counter("atom_gc_cycles_total", "Completed garbage collection cycles.", float64(cpu.GC.Cycles), nil)
+	w.Counter("atom_gc_pause_seconds_total", "Total garbage collection pause time.",
+		float64(cpu.GC.PauseTotalNs)/1e9, nil)
+	w.Gauge("atom_gc_last_pause_seconds", "Duration of most recent garbage collection pause.",
+		float64(cpu.GC.LastPauseNs)/1e9, nil)
+	w.Gauge("atom_gc_max_recent_pause_seconds", "Longest garbage collection pause over last 256 cycles.",
+		float64(cpu.GC.MaxRecentPause)/1e9, nil)
+}
+
+// writeTokenExecutionMetrics writes token execution pool gauges and counters
+func writeTokenExecutionMetrics(w *metrics.Writer, stats *types.TokenExecutionStats) {
+	w.Gauge("atom_token_execution_workers", "Configured token execution workers.", float64(stats.Workers), nil)
+	w.Gauge("atom_token_execution_in_flight", "Tokens currently executing in worker pool.",
+		float64(stats.InFlight), nil)
+	w.Gauge("atom_token_execution_queue_depth", "Tokens waiting for free execution worker.",
+		float64(stats.QueueDepth), nil)
+	w.Gauge("atom_token_execution_queue_capacity", "Capacity of token execution queue.",
+		float64(stats.QueueCapacity), nil)
+	w.Counter("atom_token_executions_total", "Tokens executed by worker pool, including caller runs.",
+		float64(stats.Executed), nil)
+	w.Counter("atom_token_execution_caller_runs_total",
+		"Tokens executed in spawning goroutine because execution queue was full.",
+		float64(stats.CallerRuns), nil)
+}
+
+// writeComponentLatencyMetrics writes request/response latency histograms of internal components
+func writeComponentLatencyMetrics(w *metrics.Writer, stats []types.ComponentLatencyStats) {
+	for _, stat := range stats {
+		w.Histogram("atom_component_response_duration_seconds",
+			"Round trip from sending request to internal component until its response is received.",
+			metrics.HistogramSnapshot{
+				UpperBounds: stat.UpperBounds,
+				Counts:      stat.Counts,
+				Count:       stat.Count,
+				Sum:         stat.SumSeconds,
+			},
+			metrics.Labels{"component": stat.Component, "operation": stat.Operation})
+	}
+}
+
+// writeProcessMetrics writes per-process-definition instance, incident and job metrics
+// Metric names are part of public contract, see docs/API/REST_API/system/prometheus-metrics.md
+func writeProcessMetrics(w *metrics.Writer, processMetrics *metrics.ProcessMetrics) {
+	processes, jobs := processMetrics.Snapshot()
+
+	// Samples of one metric must be contiguous, so every metric is written in its own pass
+	for _, stat := range processes {
+		w.Counter("atom_process_instances_started_total", "Process instances started.",
+			float64(stat.Started), stat.Labels())
+	}
+	for _, stat := range processes {
+		w.Counter("atom_process_instances_completed_total", "Process instances completed.",
+			float64(stat.Completed), stat.Labels())
+	}
+	for _, stat := range processes {
+		w.Counter("atom_process_instances_canceled_total", "Process instances canceled.",
+			float64(stat.Canceled), stat.Labels())
+	}
+	for _, stat := range processes {
+		w.Gauge("atom_process_instances_active", "Process instances not yet completed or canceled.",
+			float64(stat.Active), stat.Labels())
+	}
+	for _, stat := range processes {
+		w.Histogram("atom_process_instance_duration_seconds",
+			"Time from start to completion of completed process instances.",
+			stat.Duration, stat.Labels())
+	}
+	for _, stat := range processes {
+		w.Counter("atom_process_incidents_opened_total", "Incidents created for process instances.",
+			float64(stat.Incidents), stat.Labels())
+	}
+	for _, stat := range processes {
+		w.Counter("atom_process_sla_breaches_total", "Process instances still running at their SLA deadline.",
+			float64(stat.SLABreaches), stat.Labels())
+	}
+
+	for _, stat := range jobs {
+		w.Counter("atom_job_activations_total", "Jobs activated by workers.", float64(stat.Activations), stat.Labels())
+	}
+	for _, stat := range jobs {
+		w.Counter("atom_job_failures_total", "Job failures reported by workers.", float64(stat.Failures), stat.Labels())
+	}
+	for _, stat := range jobs {
+		w.Histogram("atom_job_activation_latency_seconds",
+			"Time job waited for activation since it became activatable.",
+			stat.ActivationLatency, stat.Labels())
+	}
+}
+
+// writeJobBacklogMetrics writes age of oldest activatable job per job type
+// Job types without activatable jobs have no sample
+func writeJobBacklogMetrics(w *metrics.Writer, stats []types.JobBacklogStats) {
+	for _, stat := range stats {
+		w.Gauge("atom_job_oldest_activatable_age_seconds",
+			"Age of oldest job waiting for activation by worker, by job type.",
+			stat.OldestActivatableAge.Seconds(), metrics.Labels{"job_type": stat.JobType})
+	}
+}
+
+// writeHistoryExportMetrics writes history exporter backlog, lag and error counters
+func writeHistoryExportMetrics(w *metrics.Writer, stats *types.HistoryExportStats) {
+	w.Gauge("atom_history_export_backlog_records", "History records waiting in storage for export.",
+		float64(stats.Backlog), nil)
+	w.Gauge("atom_history_export_lag_seconds", "Age of oldest history record waiting for export.",
+		stats.LagSeconds, nil)
+	w.Counter("atom_history_export_records_total", "History records accepted by export endpoint.",
+		float64(stats.Exported), nil)
+	w.Counter("atom_history_export_request_errors_total", "Failed history export bulk requests.",
+		float64(stats.RequestErrors), nil)
+	w.Counter("atom_history_export_rejected_records_total",
+		"History records rejected by export endpoint and dropped from backlog.", float64(stats.Rejected), nil)
+	w.Counter("atom_history_export_dropped_events_total",
+		"Engine events dropped because history exporter buffer was full.", float64(stats.DroppedEvents), nil)
+}
+
+// writeAuditMetrics writes audit entries recorded by tier and dropped by reason
+func writeAuditMetrics(w *metrics.Writer, stats *types.AuditStats) {
+	recordedHelp := "Auth audit entries recorded by tier."
+	w.Counter("atom_audit_entries_recorded_total", recordedHelp,
+		float64(stats.RecordedSecurity), metrics.Labels{"tier": "security"})
+	w.Counter("atom_audit_entries_recorded_total", recordedHelp,
+		float64(stats.RecordedSuccess), metrics.Labels{"tier": "success"})
+
+	droppedHelp := "Successful authentication audit entries not recorded by reason."
+	w.Counter("atom_audit_entries_dropped_total", droppedHelp,
+		float64(stats.SampledOut), metrics.Labels{"reason": "sampled"})
+	w.Counter("atom_audit_entries_dropped_total", droppedHelp,
+		float64(stats.ExcludedReadOnly), metrics.Labels{"reason": "read_only"})
+}
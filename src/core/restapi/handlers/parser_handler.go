@@ -24,6 +24,7 @@ import (
 
 	"atom-engine/proto/parser/parserpb"
 	"atom-engine/src/core/logger"
+	coremodels "atom-engine/src/core/models"
 	"atom-engine/src/core/restapi/middleware"
 	"atom-engine/src/core/restapi/models"
 	"atom-engine/src/core/restapi/utils"
@@ -39,10 +40,18 @@ type ParserHandler struct {
 // ParserCoreInterface defines methods needed for BPMN operations
 type ParserCoreInterface interface {
 	// JSON Message Routing to parser component
-	SendMessage(componentName, messageJSON string) error
-	WaitForParserResponse(timeoutMs int) (string, error)
+	SendRequestWithContext(ctx context.Context, componentName, messageJSON string) (string, error)
+	WaitForParserResponse(requestID string, timeoutMs int) (string, error)
 	// gRPC connection for direct calls
 	GetGRPCConnection() (interface{}, error)
+	// Instance statistics kept by process component
+	GetProcessStatistics(processID string, days int) (*coremodels.ProcessStatistics, error)
+	// Side-effect free walk of process definition
+	DryRunProcess(processKey string, request *coremodels.DryRunRequest) (*coremodels.DryRunResult, error)
+	// Counters of flows taken by conditional gateways
+	GetGatewayMetrics(processKey string) (*coremodels.GatewayMetrics, error)
+	// Temporary elevation of execution logs of definition version
+	SetProcessDebugLogging(processKey string, debug bool, duration time.Duration) (*coremodels.ProcessLogging, error)
 }
 
 // BPMN response types
@@ -56,7 +65,25 @@ type BPMNProcess struct {
 	UpdatedAt    int64                  `json:"updated_at"`
 	ElementCount int32                  `json:"element_count"`
 	IsDeployable bool                   `json:"is_deployable"`
-	Metadata     map[string]interface{} `json:"metadata"`
+	Metadata     map[string]interface{} `json:"metadata"` // Deploy-time metadata and built-in status keys
+	Tags         []string               `json:"tags,omitempty"`
+	Deployment   *BPMNDeployment        `json:"deployment,omitempty"`
+}
+
+// BPMNDeployment describes who, when and how deployed process definition.
+// Definitions deployed by one request or with same deployment_id share deployment ID
+type BPMNDeployment struct {
+	DeploymentID string `json:"deployment_id"`
+	DeployedBy   string `json:"deployed_by,omitempty"` // API key name, empty when auth is disabled
+	DeployedAt   string `json:"deployed_at"`
+	Source       string `json:"source"` // cli, rest or grpc
+}
+
+// BPMNParseResponse is result of BPMN file deployment
+type BPMNParseResponse struct {
+	ID           string `json:"id"`
+	DeploymentID string `json:"deployment_id"`
+	Message      string `json:"message,omitempty"`
 }
 
 type BPMNProcessDetails struct {
@@ -73,6 +100,10 @@ type BPMNProcessDetails struct {
 	CreatedAt      string           `json:"created_at"`
 	UpdatedAt      string           `json:"updated_at"`
 	ParsedAt       string           `json:"parsed_at"`
+	Deployment     *BPMNDeployment  `json:"deployment,omitempty"`
+	// Deploy-time metadata like team or git commit, copied forward to new versions
+	Metadata map[string]interface{} `json:"metadata"`
+	Tags     []string               `json:"tags,omitempty"`
 }
 
 type BPMNStats struct {
@@ -83,8 +114,94 @@ type BPMNStats struct {
 	ElementsByType   map[string]int32 `json:"elements_by_type"`
 	LastParsed       int64            `json:"last_parsed"`
 	ParseSuccessRate float64          `json:"parse_success_rate"`
+	ParseConcurrency ParseConcurrency `json:"parse_concurrency"`
+}
+
+// ParseConcurrency shows parser load against configured limits
+type ParseConcurrency struct {
+	Active        int32 `json:"active"`
+	MaxConcurrent int32 `json:"max_concurrent"`
+	Queued        int32 `json:"queued"`
+	QueueSize     int32 `json:"queue_size"`
+	Rejected      int64 `json:"rejected"`
+}
+
+// BPMNStartEventTimer describes timer of timer start event
+type BPMNStartEventTimer struct {
+	Type  string `json:"type"`  // duration, date or cycle
+	Value string `json:"value"` // ISO 8601 value or cron expression
+}
+
+// BPMNStartEvent describes top-level start event and its trigger
+type BPMNStartEvent struct {
+	ElementID   string               `json:"element_id"`
+	Name        string               `json:"name,omitempty"`
+	TriggerType string               `json:"trigger_type"` // none, timer, message, signal, conditional
+	Timer       *BPMNStartEventTimer `json:"timer,omitempty"`
+	MessageRef  string               `json:"message_ref,omitempty"`
+	MessageName string               `json:"message_name,omitempty"`
+	SignalRef   string               `json:"signal_ref,omitempty"`
+	SignalName  string               `json:"signal_name,omitempty"`
+	Condition   string               `json:"condition,omitempty"`
+}
+
+// BPMNProcessStartEvents lists start events of process definition
+type BPMNProcessStartEvents struct {
+	ProcessKey     string           `json:"process_key"`
+	ProcessID      string           `json:"process_id"`
+	ProcessVersion int32            `json:"process_version"`
+	StartEvents    []BPMNStartEvent `json:"start_events"`
+}
+
+// SetTimerStartEnabledRequest toggles scheduling of timer start events
+type SetTimerStartEnabledRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// BPMNTimerStartState reports whether timer start events of process definition are scheduled
+type BPMNTimerStartState struct {
+	ProcessKey     string `json:"process_key"`
+	ProcessID      string `json:"process_id"`
+	ProcessVersion int32  `json:"process_version"`
+	Enabled        bool   `json:"enabled"`
+}
+
+// SetProcessLoggingRequest turns debug logging of process definition on for duration or off.
+// Duration is Go duration string like "30m", one hour by default, at most 24 hours
+type SetProcessLoggingRequest struct {
+	Debug    *bool  `json:"debug" binding:"required"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// BPMNProcessVersionStatistics is instance statistics of process definition version.
+// Version is 0 for totals of all versions
+type BPMNProcessVersionStatistics struct {
+	Version        int                              `json:"version"`
+	Active         int64                            `json:"active"`
+	Completed      int64                            `json:"completed"`
+	Canceled       int64                            `json:"canceled"`
+	Failed         int64                            `json:"failed"`
+	OpenIncidents  int64                            `json:"open_incidents"`
+	AvgDurationMs  int64                            `json:"avg_duration_ms"`
+	P95DurationMs  int64                            `json:"p95_duration_ms"` // Over last 1000 completions
+	CompletionRate float64                          `json:"completion_rate"` // Within daily window
+	Daily          []coremodels.DailyInstanceCounts `json:"daily"`
 }
 
+// BPMNProcessStatistics is instance statistics of process definition and its versions
+type BPMNProcessStatistics struct {
+	ProcessID string                         `json:"process_id"`
+	Days      int                            `json:"days"`
+	Total     BPMNProcessVersionStatistics   `json:"total"`
+	Versions  []BPMNProcessVersionStatistics `json:"versions"`
+}
+
+// defaultStatisticsDays is number of days of daily counts when days parameter is omitted
+const defaultStatisticsDays = 7
+
+// bpmnProcessSortFields are sort_by values of BPMN process listing
+var bpmnProcessSortFields = []string{"created_at", "updated_at", "process_key", "process_name"}
+
 // NewParserHandler creates new parser handler
 func NewParserHandler(coreInterface ParserCoreInterface) *ParserHandler {
 	return &ParserHandler{
@@ -110,6 +227,12 @@ func (h *ParserHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *
 		bpmn.DELETE("/processes/:id", h.DeleteBPMNProcess)
 		bpmn.GET("/processes/:key/json", h.GetBPMNProcessJSON)
 		bpmn.GET("/processes/:key/xml", h.GetBPMNProcessXML)
+		bpmn.GET("/processes/:key/start-events", h.GetProcessStartEvents)
+		bpmn.PUT("/processes/:key/timer-start", h.SetTimerStartEnabled)
+		bpmn.GET("/processes/:key/statistics", h.GetProcessStatistics)
+		bpmn.POST("/processes/:key/simulate", h.SimulateProcess)
+		bpmn.GET("/processes/:key/gateway-metrics", h.GetGatewayMetrics)
+		bpmn.PUT("/processes/:key/logging", h.SetProcessLogging)
 		bpmn.GET("/stats", h.GetBPMNStats)
 	}
 }
@@ -123,16 +246,20 @@ func (h *ParserHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *
 // @Param file formData file true "BPMN file"
 // @Param process_id formData string false "Process ID"
 // @Param force formData boolean false "Force overwrite existing process"
-// @Success 201 {object} models.APIResponse{data=models.CreateResponse}
+// @Param deployment_id formData string false "Deployment ID grouping deployed definitions"
+// @Param metadata formData string false "JSON object of definition metadata, null values remove copied keys"
+// @Param tag formData []string false "Definition tag like team:payments, repeatable" collectionFormat(multi)
+// @Success 201 {object} models.APIResponse{data=BPMNParseResponse}
 // @Failure 400 {object} models.APIResponse{error=models.APIError}
 // @Failure 401 {object} models.APIResponse{error=models.APIError}
 // @Failure 403 {object} models.APIResponse{error=models.APIError}
 // @Failure 409 {object} models.APIResponse{error=models.APIError}
 // @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Failure 503 {object} models.APIResponse{error=models.APIError}
 // @Security ApiKeyAuth
 // @Router /api/v1/bpmn/parse [post]
 func (h *ParserHandler) ParseBPMN(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	logger.Debug("Parsing BPMN file",
 		logger.String("request_id", requestID),
@@ -187,6 +314,28 @@ func (h *ParserHandler) ParseBPMN(c *gin.Context) {
 	forceStr := c.Request.FormValue("force")
 	force, _ := strconv.ParseBool(forceStr)
 
+	deploymentID := c.Request.FormValue("deployment_id")
+	if deploymentID != "" {
+		if validationErr := h.validator.ValidateProcessKey(deploymentID, "deployment_id"); validationErr != nil {
+			apiErr := models.NewValidationError("Invalid deployment ID", []models.ValidationError{*validationErr})
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+			return
+		}
+	}
+
+	definitionMetadata, apiErr := parseDefinitionMetadataForm(c)
+	if apiErr != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	// Record who deployed definition, empty when auth is disabled
+	deployedBy := ""
+	if authResult, ok := middleware.GetAuthResult(c); ok && authResult != nil {
+		deployedBy = authResult.APIKeyName
+	}
+	deployment := coremodels.NewDeploymentInfo(deploymentID, deployedBy, coremodels.DeploymentSourceREST)
+
 	// Create parse request
 	parseReq := map[string]interface{}{
 		"type":       "parse_bpmn_content",
@@ -195,6 +344,8 @@ func (h *ParserHandler) ParseBPMN(c *gin.Context) {
 			"bpmn_content": bpmnContent,
 			"process_id":   processID,
 			"force":        force,
+			"deployment":   deployment,
+			"metadata":     definitionMetadata,
 		},
 	}
 
@@ -210,7 +361,8 @@ func (h *ParserHandler) ParseBPMN(c *gin.Context) {
 		return
 	}
 
-	err = h.coreInterface.SendMessage("parser", string(reqJSON))
+	ctx := utils.BackgroundContext(c)
+	parserRequestID, err := h.coreInterface.SendRequestWithContext(ctx, "parser", string(reqJSON))
 	if err != nil {
 		logger.Error("Failed to send message to parser",
 			logger.String("request_id", requestID),
@@ -222,7 +374,7 @@ func (h *ParserHandler) ParseBPMN(c *gin.Context) {
 	}
 
 	// Wait for response
-	respJSON, err := h.coreInterface.WaitForParserResponse(30000) // 30 seconds timeout
+	respJSON, err := h.coreInterface.WaitForParserResponse(parserRequestID, 30000) // 30 seconds timeout
 	if err != nil {
 		logger.Error("Failed to get parser response",
 			logger.String("request_id", requestID),
@@ -259,8 +411,11 @@ func (h *ParserHandler) ParseBPMN(c *gin.Context) {
 			logger.String("error", errorMsg))
 
 		// Determine appropriate error type
+		errorCode, _ := parseResp["error_code"].(string)
 		var apiErr *models.APIError
-		if strings.Contains(strings.ToLower(errorMsg), "already exists") {
+		if errorCode == models.ErrorCodeParserBusy {
+			apiErr = models.ParserBusyError(errorMsg)
+		} else if errorCode == coremodels.ErrorCodeConflict {
 			apiErr = models.ConflictError(errorMsg)
 		} else if strings.Contains(strings.ToLower(errorMsg), "invalid") ||
 			strings.Contains(strings.ToLower(errorMsg), "validation") {
@@ -274,21 +429,24 @@ func (h *ParserHandler) ParseBPMN(c *gin.Context) {
 		return
 	}
 
-	// Extract process information from response
-	processKey, _ := parseResp["process_key"].(string)
-	processName, _ := parseResp["process_name"].(string)
+	// Extract process information from parse result
+	result, _ := parseResp["result"].(map[string]interface{})
+	processKey, _ := result["process_key"].(string)
+	processName, _ := result["process_name"].(string)
 	if processKey == "" {
 		processKey = processID
 	}
 
-	response := &models.CreateResponse{
-		ID:      processKey,
-		Message: fmt.Sprintf("BPMN process '%s' parsed successfully", processName),
+	response := &BPMNParseResponse{
+		ID:           processKey,
+		DeploymentID: deployment.DeploymentID,
+		Message:      fmt.Sprintf("BPMN process '%s' parsed successfully", processName),
 	}
 
 	logger.Info("BPMN file parsed successfully",
 		logger.String("request_id", requestID),
 		logger.String("process_key", processKey),
+		logger.String("deployment_id", deployment.DeploymentID),
 		logger.String("file_name", header.Filename))
 
 	c.JSON(http.StatusCreated, models.SuccessResponse(response, requestID))
@@ -302,14 +460,19 @@ func (h *ParserHandler) ParseBPMN(c *gin.Context) {
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(20)
 // @Param tenant_id query string false "Tenant ID filter"
+// @Param deployment_id query string false "Deployment ID filter"
+// @Param tag query []string false "Only processes having all tags, repeatable" collectionFormat(multi)
+// @Param sort_by query string false "Sort field (created_at)" Enums(created_at,updated_at,process_key,process_name)
+// @Param sort_order query string false "Sort order" Enums(asc,desc) default(desc)
 // @Success 200 {object} models.PaginatedResponse{data=[]BPMNProcess}
+// @Failure 400 {object} models.APIResponse{error=models.APIError}
 // @Failure 401 {object} models.APIResponse{error=models.APIError}
 // @Failure 403 {object} models.APIResponse{error=models.APIError}
 // @Failure 500 {object} models.APIResponse{error=models.APIError}
 // @Security ApiKeyAuth
 // @Router /api/v1/bpmn/processes [get]
 func (h *ParserHandler) ListProcesses(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse pagination parameters
 	pageStr := c.DefaultQuery("page", "1")
@@ -322,6 +485,13 @@ func (h *ParserHandler) ListProcesses(c *gin.Context) {
 		return
 	}
 
+	sortParams, apiErr := paginationHelper.ParseSort(
+		c.Query("sort_by"), c.Query("sort_order"), bpmnProcessSortFields, "created_at")
+	if apiErr != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
 	logger.Debug("Listing BPMN processes",
 		logger.String("request_id", requestID),
 		logger.Int("page", params.Page),
@@ -341,16 +511,18 @@ func (h *ParserHandler) ListProcesses(c *gin.Context) {
 	defer conn.Close()
 
 	// Create gRPC context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(utils.BackgroundContext(c), 10*time.Second)
 	defer cancel()
 
 	// Call gRPC ListBPMNProcesses method (same as CLI)
 	grpcReq := &parserpb.ListBPMNProcessesRequest{
-		Limit:     0, // Use pagination instead
-		PageSize:  int32(params.Limit),
-		Page:      int32(params.Page),
-		SortBy:    "created_at",
-		SortOrder: "DESC",
+		Limit:        0, // Use pagination instead
+		PageSize:     int32(params.Limit),
+		Page:         int32(params.Page),
+		SortBy:       sortParams.By,
+		SortOrder:    strings.ToUpper(sortParams.Order),
+		DeploymentId: c.Query("deployment_id"),
+		Tags:         c.QueryArray("tag"),
 	}
 
 	resp, err := client.ListBPMNProcesses(ctx, grpcReq)
@@ -394,13 +566,26 @@ func (h *ParserHandler) ListProcesses(c *gin.Context) {
 		HasPrev: resp.Page > 1,
 	}
 
-	paginatedResp := models.PaginatedSuccessResponse(processes, paginationInfo, requestID)
+	paginatedResp := models.PaginatedSuccessResponse(processes, paginationInfo, requestID).WithSort(sortParams)
 	c.JSON(http.StatusOK, paginatedResp)
 }
 
 // GetProcess handles GET /api/v1/bpmn/processes/:key
+// @Summary Get BPMN process
+// @Description Get details of a deployed BPMN process by key
+// @Tags bpmn
+// @Produce json
+// @Param key path string true "Process key"
+// @Success 200 {object} models.APIResponse{data=BPMNProcessDetails}
+// @Failure 400 {object} models.APIResponse{error=models.APIError}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 404 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/bpmn/processes/{key} [get]
 func (h *ParserHandler) GetProcess(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	processKey := c.Param("key")
 
 	if processKey == "" {
@@ -432,7 +617,7 @@ func (h *ParserHandler) GetProcess(c *gin.Context) {
 
 	// Create gRPC client and call GetBPMNProcess
 	client := parserpb.NewParserServiceClient(conn)
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(utils.BackgroundContext(c), 10*time.Second)
 	defer cancel()
 
 	resp, err := client.GetBPMNProcess(ctx, &parserpb.GetBPMNProcessRequest{
@@ -485,20 +670,20 @@ func (h *ParserHandler) readFileContent(file multipart.File) (string, error) {
 }
 
 func (h *ParserHandler) sendParserRequest(
+	ctx context.Context,
 	req map[string]interface{},
-	requestID string,
 ) (map[string]interface{}, error) {
 	reqJSON, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	err = h.coreInterface.SendMessage("parser", string(reqJSON))
+	componentRequestID, err := h.coreInterface.SendRequestWithContext(ctx, "parser", string(reqJSON))
 	if err != nil {
 		return nil, fmt.Errorf("failed to send message: %w", err)
 	}
 
-	respJSON, err := h.coreInterface.WaitForParserResponse(30000)
+	respJSON, err := h.coreInterface.WaitForParserResponse(componentRequestID, 30000)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get response: %w", err)
 	}
@@ -535,6 +720,12 @@ func (h *ParserHandler) convertGRPCProcessesToREST(grpcProcesses []*parserpb.BPM
 			version = int32(v)
 		}
 
+		// Built-in keys are set last so deploy-time metadata cannot shadow them
+		metadata := decodeDefinitionMetadata(grpcProcess.MetadataJson)
+		metadata["status"] = grpcProcess.Status
+		metadata["version_string"] = grpcProcess.Version
+		metadata["total_elements"] = grpcProcess.TotalElements
+
 		processes[i] = BPMNProcess{
 			ID:           grpcProcess.ProcessId,
 			Key:          grpcProcess.ProcessKey,
@@ -545,22 +736,63 @@ func (h *ParserHandler) convertGRPCProcessesToREST(grpcProcesses []*parserpb.BPM
 			UpdatedAt:    updatedAt,
 			ElementCount: grpcProcess.TotalElements,
 			IsDeployable: grpcProcess.Status == "active",
-			Metadata: map[string]interface{}{
-				"status":         grpcProcess.Status,
-				"version_string": grpcProcess.Version,
-				"total_elements": grpcProcess.TotalElements,
-			},
+			Metadata:     metadata,
+			Tags:         grpcProcess.Tags,
+			Deployment:   convertGRPCDeploymentToREST(grpcProcess.Deployment),
 		}
 	}
 
 	return processes
 }
 
-func (h *ParserHandler) getRequestID(c *gin.Context) string {
-	if requestID := c.GetHeader("X-Request-ID"); requestID != "" {
-		return requestID
+// parseDefinitionMetadataForm reads optional metadata JSON object and repeated tag fields of deploy form,
+// nil when neither is given
+func parseDefinitionMetadataForm(c *gin.Context) (*coremodels.DefinitionMetadata, *models.APIError) {
+	metadataJSON := c.Request.FormValue("metadata")
+	var tags []string
+	if c.Request.MultipartForm != nil {
+		tags = c.Request.MultipartForm.Value["tag"]
+	}
+	if metadataJSON == "" && len(tags) == 0 {
+		return nil, nil
+	}
+
+	definitionMetadata := &coremodels.DefinitionMetadata{Tags: tags}
+	if metadataJSON != "" {
+		if err := json.Unmarshal([]byte(metadataJSON), &definitionMetadata.Metadata); err != nil ||
+			definitionMetadata.Metadata == nil {
+			return nil, models.BadRequestError("metadata must be JSON object")
+		}
+	}
+	if err := definitionMetadata.Validate(); err != nil {
+		return nil, models.BadRequestError(err.Error())
+	}
+	return definitionMetadata, nil
+}
+
+// decodeDefinitionMetadata decodes metadata JSON of gRPC response, empty map when there is none
+func decodeDefinitionMetadata(metadataJSON string) map[string]interface{} {
+	var metadata map[string]interface{}
+	if metadataJSON != "" {
+		_ = json.Unmarshal([]byte(metadataJSON), &metadata)
+	}
+	if metadata == nil {
+		metadata = make(map[string]interface{})
+	}
+	return metadata
+}
+
+// convertGRPCDeploymentToREST converts gRPC DeploymentInfo, nil for definitions without deployment metadata
+func convertGRPCDeploymentToREST(grpcDeployment *parserpb.DeploymentInfo) *BPMNDeployment {
+	if grpcDeployment == nil {
+		return nil
+	}
+	return &BPMNDeployment{
+		DeploymentID: grpcDeployment.DeploymentId,
+		DeployedBy:   grpcDeployment.DeployedBy,
+		DeployedAt:   grpcDeployment.DeployedAt,
+		Source:       grpcDeployment.Source,
 	}
-	return utils.GenerateSecureRequestID("parser")
 }
 
 // DeleteBPMNProcess handles DELETE /api/v1/bpmn/processes/:id
@@ -578,7 +810,7 @@ func (h *ParserHandler) getRequestID(c *gin.Context) string {
 // @Security ApiKeyAuth
 // @Router /api/v1/bpmn/processes/{id} [delete]
 func (h *ParserHandler) DeleteBPMNProcess(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	processID := c.Param("id")
 
 	if processID == "" {
@@ -605,7 +837,7 @@ func (h *ParserHandler) DeleteBPMNProcess(c *gin.Context) {
 	defer conn.Close()
 
 	// Create gRPC context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(utils.BackgroundContext(c), 30*time.Second)
 	defer cancel()
 
 	// Call gRPC DeleteBPMNProcess method
@@ -661,7 +893,7 @@ func (h *ParserHandler) DeleteBPMNProcess(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/bpmn/stats [get]
 func (h *ParserHandler) GetBPMNStats(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	logger.Debug("Getting BPMN stats",
 		logger.String("request_id", requestID))
@@ -680,7 +912,7 @@ func (h *ParserHandler) GetBPMNStats(c *gin.Context) {
 	defer conn.Close()
 
 	// Create gRPC context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(utils.BackgroundContext(c), 10*time.Second)
 	defer cancel()
 
 	// Call gRPC GetBPMNStats method
@@ -716,6 +948,13 @@ func (h *ParserHandler) GetBPMNStats(c *gin.Context) {
 		TotalElements:    resp.TotalElementsParsed,
 		ParseSuccessRate: float64(resp.SuccessfulElements) / float64(resp.TotalElementsParsed) * 100,
 		LastParsed:       0, // Convert from string if needed
+		ParseConcurrency: ParseConcurrency{
+			Active:        resp.ActiveParses,
+			MaxConcurrent: resp.MaxConcurrentParses,
+			Queued:        resp.QueuedParses,
+			QueueSize:     resp.ParseQueueSize,
+			Rejected:      resp.RejectedParses,
+		},
 	}
 
 	// Convert element counts from map[string]int32 to map[string]int32
@@ -744,7 +983,9 @@ func (h *ParserHandler) GetBPMNStats(c *gin.Context) {
 // @Tags bpmn
 // @Produce json
 // @Param key path string true "Process Key"
+// @Param If-None-Match header string false "ETag of cached response"
 // @Success 200 {object} models.APIResponse{data=object}
+// @Success 304 "Process definition not modified"
 // @Failure 400 {object} models.APIResponse{error=models.APIError}
 // @Failure 401 {object} models.APIResponse{error=models.APIError}
 // @Failure 403 {object} models.APIResponse{error=models.APIError}
@@ -753,7 +994,7 @@ func (h *ParserHandler) GetBPMNStats(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/bpmn/processes/{key}/json [get]
 func (h *ParserHandler) GetBPMNProcessJSON(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	processKey := c.Param("key")
 
 	if processKey == "" {
@@ -780,7 +1021,7 @@ func (h *ParserHandler) GetBPMNProcessJSON(c *gin.Context) {
 	defer conn.Close()
 
 	// Create gRPC context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(utils.BackgroundContext(c), 10*time.Second)
 	defer cancel()
 
 	// Call gRPC GetBPMNProcessJSON method
@@ -812,6 +1053,11 @@ func (h *ParserHandler) GetBPMNProcessJSON(c *gin.Context) {
 		return
 	}
 
+	// Definition content is hashed before decoding, unchanged definition is answered with 304
+	if utils.CheckNotModified(c, utils.WeakETag([]byte(resp.JsonData))) {
+		return
+	}
+
 	// Parse JSON data
 	var jsonData interface{}
 	if err := json.Unmarshal([]byte(resp.JsonData), &jsonData); err != nil {
@@ -834,11 +1080,14 @@ func (h *ParserHandler) GetBPMNProcessJSON(c *gin.Context) {
 
 // GetBPMNProcessXML handles GET /api/v1/bpmn/processes/:key/xml
 // @Summary Get BPMN process original XML
-// @Description Get original XML content of a BPMN process by process key
+// @Description Get original XML content of a BPMN process by process key, optionally for another version
 // @Tags bpmn
-// @Produce text/xml
+// @Produce application/xml
 // @Param key path string true "Process Key"
+// @Param version query int false "Process version (defaults to version of process key)"
+// @Param If-None-Match header string false "ETag of cached response"
 // @Success 200 {string} string "Original BPMN XML content"
+// @Success 304 "Process XML not modified"
 // @Failure 400 {object} models.APIResponse{error=models.APIError}
 // @Failure 401 {object} models.APIResponse{error=models.APIError}
 // @Failure 403 {object} models.APIResponse{error=models.APIError}
@@ -847,7 +1096,7 @@ func (h *ParserHandler) GetBPMNProcessJSON(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/bpmn/processes/{key}/xml [get]
 func (h *ParserHandler) GetBPMNProcessXML(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	processKey := c.Param("key")
 
 	if processKey == "" {
@@ -856,9 +1105,21 @@ func (h *ParserHandler) GetBPMNProcessXML(c *gin.Context) {
 		return
 	}
 
+	version := 0
+	if versionStr := c.Query("version"); versionStr != "" {
+		parsed, err := strconv.Atoi(versionStr)
+		if err != nil || parsed < 1 {
+			apiErr := models.BadRequestError("Version must be a positive integer")
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+			return
+		}
+		version = parsed
+	}
+
 	logger.Debug("Getting BPMN process XML",
 		logger.String("request_id", requestID),
-		logger.String("process_key", processKey))
+		logger.String("process_key", processKey),
+		logger.Int("version", version))
 
 	// Get gRPC client
 	client, conn, err := h.getParserGRPCClient()
@@ -874,12 +1135,13 @@ func (h *ParserHandler) GetBPMNProcessXML(c *gin.Context) {
 	defer conn.Close()
 
 	// Create gRPC context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(utils.BackgroundContext(c), 10*time.Second)
 	defer cancel()
 
 	// Call gRPC GetBPMNProcessXML method
 	grpcReq := &parserpb.GetBPMNProcessXMLRequest{
 		ProcessKey: processKey,
+		Version:    int32(version),
 	}
 
 	resp, err := client.GetBPMNProcessXML(ctx, grpcReq)
@@ -909,15 +1171,415 @@ func (h *ParserHandler) GetBPMNProcessXML(c *gin.Context) {
 	logger.Info("BPMN process XML retrieved",
 		logger.String("request_id", requestID),
 		logger.String("process_key", processKey),
+		logger.Int("process_version", int(resp.ProcessVersion)),
 		logger.Int("file_size", int(resp.FileSize)))
 
 	// Set appropriate headers for XML content
-	c.Header("Content-Type", "application/xml; charset=utf-8")
 	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"%s\"", resp.Filename))
-	c.Header("Content-Length", fmt.Sprintf("%d", resp.FileSize))
+	c.Header("X-Process-Version", strconv.Itoa(int(resp.ProcessVersion)))
+	if utils.CheckNotModified(c, utils.ContentETag([]byte(resp.XmlData))) {
+		return
+	}
 
 	// Return raw XML content
-	c.String(http.StatusOK, resp.XmlData)
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", []byte(resp.XmlData))
+}
+
+// GetProcessStartEvents handles GET /api/v1/bpmn/processes/:key/start-events
+// @Summary List BPMN process start events
+// @Description List top-level start events of a process definition with their trigger details
+// @Tags bpmn
+// @Produce json
+// @Param key path string true "Process Key"
+// @Success 200 {object} models.APIResponse{data=BPMNProcessStartEvents}
+// @Failure 400 {object} models.APIResponse{error=models.APIError}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 404 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/bpmn/processes/{key}/start-events [get]
+func (h *ParserHandler) GetProcessStartEvents(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	processKey := c.Param("key")
+
+	if processKey == "" {
+		apiErr := models.BadRequestError("Process key is required")
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	logger.Debug("Getting BPMN process start events",
+		logger.String("request_id", requestID),
+		logger.String("process_key", processKey))
+
+	// Get gRPC client
+	client, conn, err := h.getParserGRPCClient()
+	if err != nil {
+		logger.Error("Failed to get Parser gRPC client",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()))
+
+		apiErr := models.InternalServerError("Parser service not available")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+	defer conn.Close()
+
+	// Create gRPC context with timeout
+	ctx, cancel := context.WithTimeout(utils.BackgroundContext(c), 10*time.Second)
+	defer cancel()
+
+	resp, err := client.GetProcessStartEvents(ctx, &parserpb.GetProcessStartEventsRequest{
+		ProcessKey: processKey,
+	})
+	if err != nil {
+		logger.Error("Failed to get BPMN process start events via gRPC",
+			logger.String("request_id", requestID),
+			logger.String("process_key", processKey),
+			logger.String("error", err.Error()))
+
+		apiErr := h.converter.GRPCErrorToAPIError(err)
+		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
+		c.JSON(statusCode, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	// Check if operation succeeded
+	if !resp.Success {
+		message := "BPMN process not found"
+		if resp.Message != "" {
+			message = resp.Message
+		}
+		apiErr := models.NotFoundError(message)
+		c.JSON(http.StatusNotFound, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	result := BPMNProcessStartEvents{
+		ProcessKey:     resp.ProcessKey,
+		ProcessID:      resp.ProcessId,
+		ProcessVersion: resp.ProcessVersion,
+		StartEvents:    make([]BPMNStartEvent, 0, len(resp.StartEvents)),
+	}
+	for _, startEvent := range resp.StartEvents {
+		event := BPMNStartEvent{
+			ElementID:   startEvent.ElementId,
+			Name:        startEvent.Name,
+			TriggerType: startEvent.TriggerType,
+			MessageRef:  startEvent.MessageRef,
+			MessageName: startEvent.MessageName,
+			SignalRef:   startEvent.SignalRef,
+			SignalName:  startEvent.SignalName,
+			Condition:   startEvent.Condition,
+		}
+		if startEvent.Timer != nil {
+			event.Timer = &BPMNStartEventTimer{Type: startEvent.Timer.Type, Value: startEvent.Timer.Value}
+		}
+		result.StartEvents = append(result.StartEvents, event)
+	}
+
+	logger.Info("BPMN process start events retrieved",
+		logger.String("request_id", requestID),
+		logger.String("process_key", processKey),
+		logger.Int("start_events", len(result.StartEvents)))
+
+	c.JSON(http.StatusOK, models.SuccessResponse(result, requestID))
+}
+
+// SetTimerStartEnabled handles PUT /api/v1/bpmn/processes/:key/timer-start
+// @Summary Enable or disable BPMN process timer start events
+// @Description Enable or disable automatic scheduling of timer start events of a process definition
+// @Tags bpmn
+// @Accept json
+// @Produce json
+// @Param key path string true "Process Key"
+// @Param request body SetTimerStartEnabledRequest true "Timer start state"
+// @Success 200 {object} models.APIResponse{data=BPMNTimerStartState}
+// @Failure 400 {object} models.APIResponse{error=models.APIError}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 404 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/bpmn/processes/{key}/timer-start [put]
+func (h *ParserHandler) SetTimerStartEnabled(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	processKey := c.Param("key")
+
+	if processKey == "" {
+		apiErr := models.BadRequestError("Process key is required")
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	var req SetTimerStartEnabledRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiErr := models.BadRequestError("Invalid request body: " + err.Error())
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	// Get gRPC client
+	client, conn, err := h.getParserGRPCClient()
+	if err != nil {
+		logger.Error("Failed to get Parser gRPC client",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()))
+
+		apiErr := models.InternalServerError("Parser service not available")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+	defer conn.Close()
+
+	// Create gRPC context with timeout
+	ctx, cancel := context.WithTimeout(utils.BackgroundContext(c), 10*time.Second)
+	defer cancel()
+
+	resp, err := client.SetTimerStartEnabled(ctx, &parserpb.SetTimerStartEnabledRequest{
+		ProcessKey: processKey,
+		Enabled:    *req.Enabled,
+	})
+	if err != nil {
+		logger.Error("Failed to toggle BPMN process timer start events via gRPC",
+			logger.String("request_id", requestID),
+			logger.String("process_key", processKey),
+			logger.String("error", err.Error()))
+
+		apiErr := h.converter.GRPCErrorToAPIError(err)
+		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
+		c.JSON(statusCode, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	logger.Info("BPMN process timer start events toggled",
+		logger.String("request_id", requestID),
+		logger.String("process_key", processKey),
+		logger.Bool("enabled", resp.Enabled))
+
+	c.JSON(http.StatusOK, models.SuccessResponse(BPMNTimerStartState{
+		ProcessKey:     resp.ProcessKey,
+		ProcessID:      resp.ProcessId,
+		ProcessVersion: resp.ProcessVersion,
+		Enabled:        resp.Enabled,
+	}, requestID))
+}
+
+// GetProcessStatistics handles GET /api/v1/bpmn/processes/:key/statistics
+// @Summary Get BPMN process instance statistics
+// @Description Get per-version instance counts, durations and daily starts of a process definition.
+// @Description Statistics cover all versions of process the key belongs to
+// @Tags bpmn
+// @Produce json
+// @Param key path string true "Process Key"
+// @Param days query int false "Number of days of daily counts (1-90, default 7)"
+// @Success 200 {object} models.APIResponse{data=BPMNProcessStatistics}
+// @Failure 400 {object} models.APIResponse{error=models.APIError}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 404 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/bpmn/processes/{key}/statistics [get]
+func (h *ParserHandler) GetProcessStatistics(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	processKey := c.Param("key")
+
+	if processKey == "" {
+		apiErr := models.BadRequestError("Process key is required")
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	days := defaultStatisticsDays
+	if daysStr := c.Query("days"); daysStr != "" {
+		parsed, err := strconv.Atoi(daysStr)
+		if err != nil || parsed < 1 || parsed > coremodels.ProcessStatisticsMaxDays {
+			apiErr := models.BadRequestError(
+				fmt.Sprintf("Days must be an integer between 1 and %d", coremodels.ProcessStatisticsMaxDays))
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+			return
+		}
+		days = parsed
+	}
+
+	// Get gRPC client
+	client, conn, err := h.getParserGRPCClient()
+	if err != nil {
+		logger.Error("Failed to get Parser gRPC client",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()))
+
+		apiErr := models.InternalServerError("Parser service not available")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+	defer conn.Close()
+
+	// Create gRPC context with timeout
+	ctx, cancel := context.WithTimeout(utils.BackgroundContext(c), 10*time.Second)
+	defer cancel()
+
+	// Process key names one version, statistics are collected for its process ID
+	resp, err := client.GetBPMNProcess(ctx, &parserpb.GetBPMNProcessRequest{ProcessKey: processKey})
+	if err != nil {
+		logger.Warn("Failed to resolve BPMN process for statistics",
+			logger.String("request_id", requestID),
+			logger.String("process_key", processKey),
+			logger.String("error", err.Error()))
+
+		apiErr := h.converter.GRPCErrorToAPIError(err)
+		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
+		c.JSON(statusCode, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	statistics, err := h.coreInterface.GetProcessStatistics(resp.Process.ProcessId, days)
+	if err != nil {
+		logger.Error("Failed to get process statistics",
+			logger.String("request_id", requestID),
+			logger.String("process_id", resp.Process.ProcessId),
+			logger.String("error", err.Error()))
+
+		apiErr := models.InternalServerError("Process statistics not available")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(convertProcessStatisticsToREST(statistics), requestID))
+}
+
+// SimulateProcess handles POST /api/v1/bpmn/processes/:key/simulate
+// @Summary Simulate BPMN process path
+// @Description Walk process definition with start variables using real condition evaluation.
+// @Description Service tasks complete with stubbed variables by job type, message, signal and timer events
+// @Description fire or never fire by element ID or name. No instances, jobs, timers or subscriptions are created
+// @Tags bpmn
+// @Accept json
+// @Produce json
+// @Param key path string true "Process Key or process ID with optional :version"
+// @Param request body coremodels.DryRunRequest false "Start variables and stubbed outcomes"
+// @Success 200 {object} models.APIResponse{data=coremodels.DryRunResult}
+// @Failure 400 {object} models.APIResponse{error=models.APIError}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 404 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/bpmn/processes/{key}/simulate [post]
+func (h *ParserHandler) SimulateProcess(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	processKey := c.Param("key")
+
+	if processKey == "" {
+		apiErr := models.BadRequestError("Process key is required")
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	var req coremodels.DryRunRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apiErr := models.BadRequestError("Invalid request body: " + err.Error())
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+			return
+		}
+	}
+
+	result, err := h.coreInterface.DryRunProcess(processKey, &req)
+	if err != nil {
+		logger.Warn("Failed to simulate BPMN process",
+			logger.String("request_id", requestID),
+			logger.String("process_key", processKey),
+			logger.String("error", err.Error()))
+
+		apiErr := h.converter.GRPCErrorToAPIError(err)
+		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
+		c.JSON(statusCode, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	logger.Info("BPMN process simulated",
+		logger.String("request_id", requestID),
+		logger.String("process_key", result.ProcessKey),
+		logger.String("outcome", string(result.Outcome)),
+		logger.Int("steps", len(result.Steps)))
+
+	c.JSON(http.StatusOK, models.SuccessResponse(result, requestID))
+}
+
+// GetGatewayMetrics handles GET /api/v1/bpmn/processes/:key/gateway-metrics
+// @Summary Get gateway routing metrics
+// @Description Get how often each outgoing flow of exclusive and inclusive gateways was taken
+// @Description across all instances of process definition version. Flows never taken have zero count
+// @Tags bpmn
+// @Produce json
+// @Param key path string true "Process Key or process ID with optional :version"
+// @Success 200 {object} models.APIResponse{data=coremodels.GatewayMetrics}
+// @Failure 400 {object} models.APIResponse{error=models.APIError}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 404 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/bpmn/processes/{key}/gateway-metrics [get]
+func (h *ParserHandler) GetGatewayMetrics(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	processKey := c.Param("key")
+
+	if processKey == "" {
+		apiErr := models.BadRequestError("Process key is required")
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	metrics, err := h.coreInterface.GetGatewayMetrics(processKey)
+	if err != nil {
+		logger.Warn("Failed to get gateway metrics",
+			logger.String("request_id", requestID),
+			logger.String("process_key", processKey),
+			logger.String("error", err.Error()))
+
+		apiErr := h.converter.GRPCErrorToAPIError(err)
+		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
+		c.JSON(statusCode, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(metrics, requestID))
+}
+
+// convertProcessStatisticsToREST converts process statistics to REST format with durations in milliseconds
+func convertProcessStatisticsToREST(statistics *coremodels.ProcessStatistics) *BPMNProcessStatistics {
+	result := &BPMNProcessStatistics{
+		ProcessID: statistics.ProcessID,
+		Days:      statistics.Days,
+		Total:     convertProcessVersionStatisticsToREST(statistics.Total),
+		Versions:  make([]BPMNProcessVersionStatistics, len(statistics.Versions)),
+	}
+	for i, version := range statistics.Versions {
+		result.Versions[i] = convertProcessVersionStatisticsToREST(version)
+	}
+	return result
+}
+
+// convertProcessVersionStatisticsToREST converts statistics of process definition version to REST format
+func convertProcessVersionStatisticsToREST(
+	statistics coremodels.ProcessVersionStatistics,
+) BPMNProcessVersionStatistics {
+	return BPMNProcessVersionStatistics{
+		Version:        statistics.Version,
+		Active:         statistics.Active,
+		Completed:      statistics.Completed,
+		Canceled:       statistics.Canceled,
+		Failed:         statistics.Failed,
+		OpenIncidents:  statistics.OpenIncidents,
+		AvgDurationMs:  statistics.AvgDuration.Milliseconds(),
+		P95DurationMs:  statistics.P95Duration.Milliseconds(),
+		CompletionRate: statistics.CompletionRate,
+		Daily:          statistics.Daily,
+	}
 }
 
 // Helper method to get Parser gRPC client
@@ -956,5 +1618,69 @@ func (h *ParserHandler) convertGRPCProcessDetailsToREST(grpcDetails *parserpb.BP
 		CreatedAt:      grpcDetails.CreatedAt,
 		UpdatedAt:      grpcDetails.UpdatedAt,
 		ParsedAt:       grpcDetails.ParsedAt,
+		Deployment:     convertGRPCDeploymentToREST(grpcDetails.Deployment),
+		Metadata:       decodeDefinitionMetadata(grpcDetails.MetadataJson),
+		Tags:           grpcDetails.Tags,
 	}
 }
+
+// SetProcessLogging handles PUT /api/v1/bpmn/processes/:key/logging
+// @Summary Set process execution logging
+// @Description Temporarily write step-by-step execution logs of process definition version at info level
+// @Description for live troubleshooting. Debug logging ends after duration or on daemon restart
+// @Tags bpmn
+// @Accept json
+// @Produce json
+// @Param key path string true "Process Key or process ID with optional :version"
+// @Param request body SetProcessLoggingRequest true "Debug logging state"
+// @Success 200 {object} models.APIResponse{data=coremodels.ProcessLogging}
+// @Failure 400 {object} models.APIResponse{error=models.APIError}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 404 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/bpmn/processes/{key}/logging [put]
+func (h *ParserHandler) SetProcessLogging(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	processKey := c.Param("key")
+
+	if processKey == "" {
+		apiErr := models.BadRequestError("Process key is required")
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	var req SetProcessLoggingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiErr := models.BadRequestError("Invalid request body: " + err.Error())
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	var duration time.Duration
+	if req.Duration != "" {
+		parsed, err := time.ParseDuration(req.Duration)
+		if err != nil {
+			apiErr := models.BadRequestError("Invalid duration: " + err.Error())
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+			return
+		}
+		duration = parsed
+	}
+
+	state, err := h.coreInterface.SetProcessDebugLogging(processKey, *req.Debug, duration)
+	if err != nil {
+		logger.Warn("Failed to set process execution logging",
+			logger.String("request_id", requestID),
+			logger.String("process_key", processKey),
+			logger.String("error", err.Error()))
+
+		apiErr := h.converter.GRPCErrorToAPIError(err)
+		statusCode := models.HTTPStatusFromErrorCode(apiErr.Code)
+		c.JSON(statusCode, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(state, requestID))
+}
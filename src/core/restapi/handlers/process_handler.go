@@ -9,17 +9,22 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package handlers
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
-	"sort"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"atom-engine/src/core/archive"
 	"atom-engine/src/core/grpc"
 	"atom-engine/src/core/interfaces"
 	"atom-engine/src/core/logger"
 	"atom-engine/src/core/models"
+	"atom-engine/src/core/objectstore"
 	"atom-engine/src/core/restapi/middleware"
 	restmodels "atom-engine/src/core/restapi/models"
 	"atom-engine/src/core/restapi/utils"
@@ -28,9 +33,10 @@ import (
 
 // ProcessHandler handles process management HTTP requests
 type ProcessHandler struct {
-	coreInterface ProcessCoreInterface
-	converter     *utils.Converter
-	validator     *utils.Validator
+	coreInterface   ProcessCoreInterface
+	converter       *utils.Converter
+	validator       *utils.Validator
+	variableLimiter *utils.VariableLimiter
 }
 
 // ProcessCoreInterface defines methods needed for process operations
@@ -44,8 +50,24 @@ type ProcessCoreInterface interface {
 	// Core typed methods for process operations
 	StartProcessTyped(req *types.ProcessStartRequest) (*types.ProcessStartResponse, error)
 	CancelProcessTyped(req *types.ProcessCancelRequest) (*types.ProcessCancelResponse, error)
+	PatchProcessInstanceVariables(instanceID string, patch map[string]interface{}) (map[string]interface{}, error)
+	StartProcessInstanceBatch(
+		ctx context.Context,
+		items []models.BatchStartItem,
+		async bool,
+	) (*models.BatchStart, error)
+	GetProcessInstanceBatch(batchID string) (*models.BatchStart, error)
+	FillProcessInstanceCounts(instances []*interfaces.ProcessInstanceStatus) error
+	ListElementInstances(query models.ElementInstanceQuery) ([]*models.ElementInstance, int, error)
+	ListVariableHistory(query models.VariableHistoryQuery) ([]*models.VariableHistoryEntry, int, error)
 	GetSystemStatus() (*types.SystemStatus, error)
 	GetSystemMetrics() (*types.SystemMetrics, error)
+
+	// Process instance archive
+	ExportProcessInstance(instanceID string) (*archive.ProcessArchive, error)
+	StoreProcessInstanceExport(ctx context.Context, instanceID string) (*objectstore.ObjectInfo, error)
+	ImportProcessInstance(processArchive *archive.ProcessArchive) (*archive.ImportResult, error)
+	GetArchivedInstance(instanceID string) (*models.ArchivedInstance, error)
 }
 
 // ProcessComponentInterface defines process component interface
@@ -69,6 +91,18 @@ type ProcessInstanceResult struct {
 	UpdatedAt       int64                  `json:"updated_at"`
 	CompletedAt     int64                  `json:"completed_at,omitempty"`
 	Variables       map[string]interface{} `json:"variables"`
+
+	// Instance this one was restarted from
+	ParentInstanceID string `json:"parent_instance_id,omitempty"`
+
+	// Time instance must finish by and time it was found running at that deadline, RFC 3339
+	SLADeadline   string `json:"sla_deadline,omitempty"`
+	SLABreachedAt string `json:"sla_breached_at,omitempty"`
+
+	// Set only when listing with include_counts=true
+	ActiveTimers  *int `json:"active_timers,omitempty"`
+	ActiveJobs    *int `json:"active_jobs,omitempty"`
+	OpenIncidents *int `json:"open_incidents,omitempty"`
 }
 
 type Token struct {
@@ -89,12 +123,91 @@ const (
 	TokenStateCancelled TokenState = "CANCELLED"
 )
 
+// ElementInstance is single activation of element by token.
+// JobKey and TimerID point to current job and timer, full lists are in Jobs and Timers
+type ElementInstance struct {
+	Key           int64                         `json:"key"`
+	ElementID     string                        `json:"element_id"`
+	ElementType   string                        `json:"element_type"`
+	State         string                        `json:"state"`
+	TokenID       string                        `json:"token_id"`
+	WaitingFor    string                        `json:"waiting_for,omitempty"`
+	StartedAt     int64                         `json:"started_at"`
+	EndedAt       int64                         `json:"ended_at,omitempty"`
+	JobKey        int64                         `json:"job_key,omitempty"`
+	TimerID       string                        `json:"timer_id,omitempty"`
+	TakenFlows    []string                      `json:"taken_flows,omitempty"`
+	Jobs          []ElementInstanceJob          `json:"jobs,omitempty"`
+	Timers        []ElementInstanceTimer        `json:"timers,omitempty"`
+	Subscriptions []ElementInstanceSubscription `json:"subscriptions,omitempty"`
+	Incidents     []ElementInstanceIncident     `json:"incidents,omitempty"`
+}
+
+// ElementInstanceJob is job created by element activation
+type ElementInstanceJob struct {
+	ID     string `json:"id"`
+	Key    int64  `json:"key,omitempty"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+}
+
+// ElementInstanceTimer is timer of element activation, boundary timers carry boundary event ID
+type ElementInstanceTimer struct {
+	ID        string `json:"id"`
+	ElementID string `json:"element_id"`
+	State     string `json:"state"`
+	DueDate   int64  `json:"due_date"`
+}
+
+// ElementInstanceSubscription is active message subscription of element activation
+type ElementInstanceSubscription struct {
+	ID             string `json:"id"`
+	MessageName    string `json:"message_name"`
+	CorrelationKey string `json:"correlation_key,omitempty"`
+}
+
+// ElementInstanceIncident is incident raised on element activation
+type ElementInstanceIncident struct {
+	ID      string `json:"id"`
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// VariableHistoryEntry is value process instance variable got at one moment.
+// Value set on instance (start, variables patch, modification) has no element and token
+type VariableHistoryEntry struct {
+	Name      string      `json:"name"`
+	Value     interface{} `json:"value"`
+	Removed   bool        `json:"removed,omitempty"`
+	ElementID string      `json:"element_id,omitempty"`
+	TokenID   string      `json:"token_id,omitempty"`
+	JobID     string      `json:"job_id,omitempty"`
+	SetAt     int64       `json:"set_at"`
+}
+
+// elementInstanceStates are state filter values of element instance listing
+var elementInstanceStates = []string{
+	string(models.ElementInstanceStateActive),
+	string(models.ElementInstanceStateCompleted),
+	string(models.ElementInstanceStateTerminated),
+	string(models.ElementInstanceStateIncident),
+}
+
+// processInstanceSortFields are sort_by values of process instance listing
+var processInstanceSortFields = []string{"created_at", "updated_at", "state", "process_key"}
+
 // NewProcessHandler creates new process handler
-func NewProcessHandler(coreInterface ProcessCoreInterface) *ProcessHandler {
+func NewProcessHandler(
+	coreInterface ProcessCoreInterface,
+	validator *utils.Validator,
+	variableLimiter *utils.VariableLimiter,
+) *ProcessHandler {
 	return &ProcessHandler{
-		coreInterface: coreInterface,
-		converter:     utils.NewConverter(),
-		validator:     utils.NewValidator(),
+		coreInterface:   coreInterface,
+		converter:       utils.NewConverter(),
+		validator:       validator,
+		variableLimiter: variableLimiter,
 	}
 }
 
@@ -113,9 +226,20 @@ func (h *ProcessHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware
 		processes.GET("", h.ListProcesses)
 		processes.GET("/:id", h.GetProcessStatus)
 		processes.GET("/:id/info", h.GetProcessInfo)
+		processes.GET("/:id/export", h.ExportProcess)
+		processes.POST("/:id/export", h.StoreProcessExport)
 		processes.DELETE("/:id", h.CancelProcess)
+		processes.PATCH("/:id/variables", h.PatchProcessVariables)
+		processes.POST("/:id/restart", h.RestartProcess)
+		processes.POST("/:id/modify", h.ModifyProcess)
+		processes.POST("/migrate", h.MigrateProcesses)
+		processes.POST("/bulk/cancel", h.BulkCancelProcesses)
+		processes.POST("/batch", h.StartProcessBatch)
+		processes.GET("/batch/:batch_id", h.GetProcessBatch)
 		processes.GET("/:id/tokens", h.GetProcessTokens)
 		processes.GET("/:id/tokens/trace", h.GetTokenTrace)
+		processes.GET("/:id/element-instances", h.GetElementInstances)
+		processes.GET("/:id/variables/:name/history", h.GetVariableHistory)
 
 		// New typed endpoints for enhanced functionality
 		processes.POST("/typed", h.StartProcessTyped)
@@ -126,16 +250,28 @@ func (h *ProcessHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware
 		processes.GET("/:id/trace/typed", h.TraceProcessExecutionTyped)
 		processes.GET("/stats", h.GetProcessStatsHandler)
 	}
+
+	// Archive import overwrites engine state, admin permission is required on top of process
+	if authMiddleware != nil {
+		processes.POST("/import", authMiddleware.RequirePermission("admin"), h.ImportProcess)
+	} else {
+		processes.POST("/import", h.ImportProcess)
+	}
 }
 
 // StartProcess handles POST /api/v1/processes
 // @Summary Start process instance
-// @Description Start a new process instance with optional variables
+// @Description Start a new process instance with optional variables.
+// @Description With async=true or header Prefer: respond-async the instance is created and
+// @Description 202 is returned at once, execution continues in background. Poll GET /processes/{id} for state.
 // @Tags processes
 // @Accept json
 // @Produce json
 // @Param request body restmodels.StartProcessRequest true "Process start request"
+// @Param async query bool false "Return 202 after instance is created and execute it in background"
+// @Param Prefer header string false "respond-async has same effect as async=true"
 // @Success 201 {object} restmodels.APIResponse{data=ProcessInstanceResult}
+// @Success 202 {object} restmodels.APIResponse{data=ProcessInstanceResult}
 // @Failure 400 {object} restmodels.APIResponse{error=restmodels.APIError}
 // @Failure 401 {object} restmodels.APIResponse{error=restmodels.APIError}
 // @Failure 403 {object} restmodels.APIResponse{error=restmodels.APIError}
@@ -144,7 +280,13 @@ func (h *ProcessHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware
 // @Security ApiKeyAuth
 // @Router /api/v1/processes [post]
 func (h *ProcessHandler) StartProcess(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
+
+	async, apiErr := parseAsyncPreference(c)
+	if apiErr != nil {
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
 
 	// Parse request body
 	var req restmodels.StartProcessRequest
@@ -168,9 +310,26 @@ func (h *ProcessHandler) StartProcess(c *gin.Context) {
 		return
 	}
 
+	// Reject malformed variables before they reach expression evaluation and storage
+	if validationErrors := h.validator.ValidateVariables(req.Variables); len(validationErrors) > 0 {
+		apiErr := h.validator.CreateValidationError(validationErrors)
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	// Enforce variable size limits and offload large values
+	variables, apiErr := h.variableLimiter.Apply(req.Variables, "variables")
+	if apiErr != nil {
+		c.JSON(restmodels.HTTPStatusFromErrorCode(apiErr.Code), restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+	req.Variables = variables
+
 	logger.Debug("Starting process instance",
 		logger.String("request_id", requestID),
 		logger.String("process_key", req.ProcessKey),
+		logger.Int("priority", req.Priority),
+		logger.Bool("async", async),
 		logger.String("client_ip", c.ClientIP()))
 
 	// Get process component
@@ -184,8 +343,13 @@ func (h *ProcessHandler) StartProcess(c *gin.Context) {
 		return
 	}
 
-	// Start process instance
-	result, err := processComp.StartProcessInstance(req.ProcessKey, req.Variables)
+	// Start process instance, async start returns once instance is saved
+	start := processComp.StartProcessInstanceWithContext
+	if async {
+		start = processComp.StartProcessInstanceAsync
+	}
+	ctx := models.ContextWithInstanceSLA(utils.BackgroundContext(c), time.Duration(req.SLAMs)*time.Millisecond)
+	result, err := start(ctx, req.ProcessKey, req.Variables, req.Priority)
 	if err != nil {
 		logger.Error("Failed to start process instance",
 			logger.String("request_id", requestID),
@@ -201,11 +365,38 @@ func (h *ProcessHandler) StartProcess(c *gin.Context) {
 	logger.Info("Process instance started",
 		logger.String("request_id", requestID),
 		logger.String("process_key", req.ProcessKey),
-		logger.String("instance_id", result.InstanceID))
+		logger.String("instance_id", result.InstanceID),
+		logger.Bool("async", async))
 
+	if async {
+		c.Header("Preference-Applied", "respond-async")
+		c.Header("Location", "/api/v1/processes/"+result.InstanceID)
+		c.JSON(http.StatusAccepted, restmodels.SuccessResponse(result, requestID))
+		return
+	}
 	c.JSON(http.StatusCreated, restmodels.SuccessResponse(result, requestID))
 }
 
+// parseAsyncPreference reports whether client asked for async start by query or Prefer header (RFC 7240)
+func parseAsyncPreference(c *gin.Context) (bool, *restmodels.APIError) {
+	if value := c.Query("async"); value != "" {
+		async, err := strconv.ParseBool(value)
+		if err != nil {
+			return false, restmodels.BadRequestError("async must be true or false")
+		}
+		return async, nil
+	}
+
+	for _, header := range c.Request.Header.Values("Prefer") {
+		for _, preference := range strings.Split(header, ",") {
+			if strings.EqualFold(strings.TrimSpace(preference), "respond-async") {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
 // ListProcesses handles GET /api/v1/processes
 // @Summary List process instances
 // @Description Get list of process instances with filtering and pagination
@@ -216,14 +407,20 @@ func (h *ProcessHandler) StartProcess(c *gin.Context) {
 // @Param status query string false "Status filter (active, completed, cancelled)"
 // @Param process_key query string false "Process key filter"
 // @Param tenant_id query string false "Tenant ID filter"
+// @Param include_counts query bool false "Include active_timers, active_jobs and open_incidents of each instance"
+// @Param include_variables query bool false "Load and return variables of each instance" default(true)
+// @Param fields query string false "Comma-separated fields of each instance to return, e.g. instance_id,state"
+// @Param sort_by query string false "Sort field" Enums(created_at,updated_at,state,process_key) default(created_at)
+// @Param sort_order query string false "Sort order" Enums(asc,desc) default(desc)
 // @Success 200 {object} restmodels.PaginatedResponse{data=[]ProcessInstanceResult}
+// @Failure 400 {object} restmodels.APIResponse{error=restmodels.APIError}
 // @Failure 401 {object} restmodels.APIResponse{error=restmodels.APIError}
 // @Failure 403 {object} restmodels.APIResponse{error=restmodels.APIError}
 // @Failure 500 {object} restmodels.APIResponse{error=restmodels.APIError}
 // @Security ApiKeyAuth
 // @Router /api/v1/processes [get]
 func (h *ProcessHandler) ListProcesses(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse query parameters
 	pageStr := c.DefaultQuery("page", "1")
@@ -231,6 +428,23 @@ func (h *ProcessHandler) ListProcesses(c *gin.Context) {
 	status := c.Query("status")
 	processKey := c.Query("process_key")
 
+	includeCounts := false
+	if value := c.Query("include_counts"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			apiErr := restmodels.BadRequestError("include_counts must be true or false")
+			c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+			return
+		}
+		includeCounts = parsed
+	}
+
+	selection, apiErr := utils.ParseFieldSelection(c)
+	if apiErr != nil {
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
 	// Parse and validate pagination
 	paginationHelper := utils.NewPaginationHelper()
 	params, apiErr := paginationHelper.ParseAndValidate(pageStr, limitStr)
@@ -239,6 +453,13 @@ func (h *ProcessHandler) ListProcesses(c *gin.Context) {
 		return
 	}
 
+	sortParams, apiErr := paginationHelper.ParseSort(
+		c.Query("sort_by"), c.Query("sort_order"), processInstanceSortFields, "created_at")
+	if apiErr != nil {
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
 	// Validate status filter
 	if status != "" {
 		validStatuses := []string{"active", "completed", "cancelled"}
@@ -265,8 +486,16 @@ func (h *ProcessHandler) ListProcesses(c *gin.Context) {
 		return
 	}
 
-	// List process instances (load all for sorting)
-	instances, err := processComp.ListProcessInstances(status, processKey, 0)
+	// Sorted page comes from component, so sorting applies to all instances, not one page
+	instances, total, err := processComp.QueryProcessInstances(models.ProcessInstanceQuery{
+		Status:           status,
+		ProcessKey:       processKey,
+		SortBy:           sortParams.By,
+		SortOrder:        sortParams.Order,
+		Offset:           utils.GetOffset(params.Page, params.Limit),
+		Limit:            params.Limit,
+		ExcludeVariables: !selection.IncludeVariables,
+	})
 	if err != nil {
 		logger.Error("Failed to list process instances",
 			logger.String("request_id", requestID),
@@ -278,20 +507,41 @@ func (h *ProcessHandler) ListProcesses(c *gin.Context) {
 		return
 	}
 
-	// Apply sorting by started_at DESC (consistent with gRPC/CLI behavior)
-	sort.Slice(instances, func(i, j int) bool {
-		return instances[i].StartedAt > instances[j].StartedAt
-	})
+	// Counts of all instances come from one storage scan, not lookup per instance
+	if includeCounts {
+		if err := h.coreInterface.FillProcessInstanceCounts(instances); err != nil {
+			logger.Error("Failed to count process instance activity",
+				logger.String("request_id", requestID),
+				logger.String("error", err.Error()))
 
-	// Apply client-side pagination after sorting
-	paginatedInstances, paginationInfo := utils.ApplyPagination(instances, params.Page, params.Limit)
+			apiErr := restmodels.InternalServerError("Failed to count process instance activity")
+			c.JSON(http.StatusInternalServerError, restmodels.ErrorResponse(apiErr, requestID))
+			return
+		}
+	}
 
 	logger.Info("Process instances listed",
 		logger.String("request_id", requestID),
 		logger.Int("count", len(instances)),
+		logger.Int("total", total),
 		logger.Int("page", params.Page))
 
-	paginatedResp := restmodels.PaginatedSuccessResponse(paginatedInstances, paginationInfo, requestID)
+	for _, instance := range instances {
+		instance.Variables = maskSensitiveVariables(c, instance.Variables)
+	}
+
+	data, err := selection.Apply(instances)
+	if err != nil {
+		logger.Error("Failed to select process instance fields",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()))
+
+		apiErr := restmodels.InternalServerError("Failed to select process instance fields")
+		c.JSON(http.StatusInternalServerError, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	paginatedResp := paginationHelper.CreateResponse(data, total, params, requestID).WithSort(sortParams)
 	c.JSON(http.StatusOK, paginatedResp)
 }
 
@@ -301,15 +551,18 @@ func (h *ProcessHandler) ListProcesses(c *gin.Context) {
 // @Tags processes
 // @Produce json
 // @Param id path string true "Process instance ID"
+// @Param If-None-Match header string false "ETag of cached response"
 // @Success 200 {object} restmodels.APIResponse{data=ProcessInstanceResult}
+// @Success 304 "Instance status not modified"
 // @Failure 401 {object} restmodels.APIResponse{error=restmodels.APIError}
 // @Failure 403 {object} restmodels.APIResponse{error=restmodels.APIError}
 // @Failure 404 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 410 {object} restmodels.APIResponse{error=restmodels.APIError}
 // @Failure 500 {object} restmodels.APIResponse{error=restmodels.APIError}
 // @Security ApiKeyAuth
 // @Router /api/v1/processes/{id} [get]
 func (h *ProcessHandler) GetProcessStatus(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	instanceID := c.Param("id")
 
 	if instanceID == "" {
@@ -351,153 +604,958 @@ func (h *ProcessHandler) GetProcessStatus(c *gin.Context) {
 			apiErr = restmodels.ProcessNotFoundError(instanceID)
 		}
 		statusCode := restmodels.HTTPStatusFromErrorCode(apiErr.Code)
+
+		// Instance removed by retention sweeper is reported as gone with archive location
+		// Экземпляр, удаленный очисткой, возвращается как удаленный с расположением архива
+		if statusCode == http.StatusNotFound {
+			if pointer, archiveErr := h.coreInterface.GetArchivedInstance(instanceID); archiveErr == nil {
+				apiErr = restmodels.InstanceArchivedError(instanceID, pointer.Location, pointer.ArchivedAt)
+				statusCode = http.StatusGone
+			}
+		}
+
+		c.JSON(statusCode, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	logger.Info("Process instance status retrieved",
+		logger.String("request_id", requestID),
+		logger.String("instance_id", instanceID),
+		logger.String("state", result.State))
+
+	result.Variables = maskSensitiveVariables(c, result.Variables)
+
+	// Polling clients revalidate by ETag and get 304 while instance is unchanged
+	if etag, err := utils.DataETag(result); err == nil && utils.CheckNotModified(c, etag) {
+		return
+	}
+
+	c.JSON(http.StatusOK, restmodels.SuccessResponse(result, requestID))
+}
+
+// GetProcessInfo handles GET /api/v1/processes/:id/info
+// @Summary Get complete process instance information
+// @Description Get detailed information about a process instance including
+// tokens, timers, jobs, messages, and incidents
+// @Tags processes
+// @Produce json
+// @Param id path string true "Process instance ID"
+// @Success 200 {object} restmodels.APIResponse{data=types.ProcessInfo}
+// @Failure 400 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 401 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 403 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 404 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 500 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/processes/{id}/info [get]
+func (h *ProcessHandler) GetProcessInfo(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	instanceID := c.Param("id")
+
+	if instanceID == "" {
+		apiErr := restmodels.BadRequestError("Process instance ID is required")
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	// Validate instance ID format
+	if apiErr := h.validator.ValidateID(instanceID, "instance_id"); apiErr != nil {
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(
+			restmodels.NewValidationError("Invalid instance ID format", []restmodels.ValidationError{*apiErr}),
+			requestID))
+		return
+	}
+
+	logger.Debug("Getting complete process instance information",
+		logger.String("request_id", requestID),
+		logger.String("instance_id", instanceID))
+
+	// Use adapter to get complete process info
+	coreInterface, ok := h.coreInterface.(interfaces.CoreTypedInterface)
+	if !ok {
+		logger.Error("Core typed interface not available",
+			logger.String("request_id", requestID))
+		apiErr := restmodels.InternalServerError("Service not available")
+		c.JSON(http.StatusInternalServerError, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	// Get complete process info through adapter
+	processInfo, err := coreInterface.GetProcessInfoForREST(instanceID)
+	if err != nil {
+		logger.Error("Failed to get complete process information",
+			logger.String("request_id", requestID),
+			logger.String("instance_id", instanceID),
+			logger.String("error", err.Error()))
+
+		apiErr := h.converter.GRPCErrorToAPIError(err)
+		statusCode := restmodels.HTTPStatusFromErrorCode(apiErr.Code)
+		c.JSON(statusCode, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	logger.Info("Process information retrieved successfully",
+		logger.String("request_id", requestID),
+		logger.String("instance_id", instanceID))
+
+	processInfo.Variables = maskSensitiveVariables(c, processInfo.Variables)
+
+	c.JSON(http.StatusOK, restmodels.SuccessResponse(processInfo, requestID))
+}
+
+// ExportProcess handles GET /api/v1/processes/:id/export
+// @Summary Export process instance archive
+// @Description Export process instance with its definition, tokens, jobs, timers,
+// messages, incidents and history as single JSON document. Values of variables
+// matching configured key patterns are redacted
+// @Tags processes
+// @Produce json
+// @Param id path string true "Process instance ID"
+// @Success 200 {object} restmodels.APIResponse{data=archive.ProcessArchive}
+// @Failure 400 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 401 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 403 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 404 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 500 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/processes/{id}/export [get]
+func (h *ProcessHandler) ExportProcess(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	instanceID := c.Param("id")
+
+	if apiErr := h.validator.ValidateID(instanceID, "instance_id"); apiErr != nil {
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(
+			restmodels.NewValidationError("Invalid instance ID format", []restmodels.ValidationError{*apiErr}),
+			requestID))
+		return
+	}
+
+	processArchive, err := h.coreInterface.ExportProcessInstance(instanceID)
+	if err != nil {
+		logger.Error("Failed to export process instance",
+			logger.String("request_id", requestID),
+			logger.String("instance_id", instanceID),
+			logger.String("error", err.Error()))
+
+		apiErr := h.converter.GRPCErrorToAPIError(err)
+		statusCode := restmodels.HTTPStatusFromErrorCode(apiErr.Code)
+		c.JSON(statusCode, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	c.JSON(http.StatusOK, restmodels.SuccessResponse(processArchive, requestID))
+}
+
+// StoreProcessExport handles POST /api/v1/processes/:id/export
+// @Summary Export process instance archive to object storage
+// @Description Build the same archive as GET export and write it into configured object storage
+// under exports/{id}/. Returns location and SHA-256 of stored object
+// @Tags processes
+// @Produce json
+// @Param id path string true "Process instance ID"
+// @Success 201 {object} restmodels.APIResponse{data=objectstore.ObjectInfo}
+// @Failure 400 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 401 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 403 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 404 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 500 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/processes/{id}/export [post]
+func (h *ProcessHandler) StoreProcessExport(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	instanceID := c.Param("id")
+
+	if apiErr := h.validator.ValidateID(instanceID, "instance_id"); apiErr != nil {
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(
+			restmodels.NewValidationError("Invalid instance ID format", []restmodels.ValidationError{*apiErr}),
+			requestID))
+		return
+	}
+
+	object, err := h.coreInterface.StoreProcessInstanceExport(c.Request.Context(), instanceID)
+	if err != nil {
+		logger.Error("Failed to store process instance export",
+			logger.String("request_id", requestID),
+			logger.String("instance_id", instanceID),
+			logger.String("error", err.Error()))
+
+		apiErr := h.converter.GRPCErrorToAPIError(err)
+		statusCode := restmodels.HTTPStatusFromErrorCode(apiErr.Code)
 		c.JSON(statusCode, restmodels.ErrorResponse(apiErr, requestID))
 		return
 	}
 
-	logger.Info("Process instance status retrieved",
+	c.JSON(http.StatusCreated, restmodels.SuccessResponse(object, requestID))
+}
+
+// ImportProcess handles POST /api/v1/processes/import
+// @Summary Import process instance archive
+// @Description Load archive produced by export endpoint with newly generated IDs.
+// Requires admin permission and archive.import_enabled, intended for test engines only
+// @Tags processes
+// @Accept json
+// @Produce json
+// @Param request body archive.ProcessArchive true "Process archive"
+// @Success 201 {object} restmodels.APIResponse{data=archive.ImportResult}
+// @Failure 400 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 401 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 403 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 500 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/processes/import [post]
+func (h *ProcessHandler) ImportProcess(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	var processArchive archive.ProcessArchive
+	if err := c.ShouldBindJSON(&processArchive); err != nil {
+		apiErr := restmodels.BadRequestError("Invalid request body: " + err.Error())
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	if err := processArchive.Validate(); err != nil {
+		apiErr := restmodels.BadRequestError("Invalid process archive: " + err.Error())
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	result, err := h.coreInterface.ImportProcessInstance(&processArchive)
+	if err != nil {
+		if errors.Is(err, archive.ErrImportDisabled) {
+			apiErr := restmodels.ForbiddenError("Process archive import is disabled")
+			c.JSON(http.StatusForbidden, restmodels.ErrorResponse(apiErr, requestID))
+			return
+		}
+
+		logger.Error("Failed to import process archive",
+			logger.String("request_id", requestID),
+			logger.String("original_instance_id", processArchive.Instance.InstanceID),
+			logger.String("error", err.Error()))
+
+		apiErr := restmodels.InternalServerError("Failed to import process archive: " + err.Error())
+		c.JSON(http.StatusInternalServerError, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	c.JSON(http.StatusCreated, restmodels.SuccessResponse(result, requestID))
+}
+
+// CancelProcess handles DELETE /api/v1/processes/:id
+// @Summary Cancel process instance
+// @Description Cancel a running process instance with optional reason
+// @Tags processes
+// @Accept json
+// @Produce json
+// @Param id path string true "Process instance ID"
+// @Param request body restmodels.CancelProcessRequest false "Process cancellation request"
+// @Success 200 {object} restmodels.APIResponse{data=restmodels.DeleteResponse}
+// @Failure 400 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 401 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 403 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 404 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 500 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/processes/{id} [delete]
+func (h *ProcessHandler) CancelProcess(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	instanceID := c.Param("id")
+
+	if instanceID == "" {
+		apiErr := restmodels.BadRequestError("Process instance ID is required")
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	// Parse optional request body
+	var req restmodels.CancelProcessRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logger.Warn("Failed to parse cancel request body, using defaults",
+				logger.String("request_id", requestID),
+				logger.String("error", err.Error()))
+		}
+	}
+
+	logger.Debug("Cancelling process instance",
+		logger.String("request_id", requestID),
+		logger.String("instance_id", instanceID),
+		logger.String("reason", req.Reason))
+
+	// Get process component
+	processComp := h.coreInterface.GetProcessComponent()
+	if processComp == nil {
+		apiErr := restmodels.InternalServerError("Process service not available")
+		c.JSON(http.StatusInternalServerError, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	// Cancel process instance
+	err := processComp.CancelProcessInstance(instanceID, req.Reason)
+	if err != nil {
+		logger.Error("Failed to cancel process instance",
+			logger.String("request_id", requestID),
+			logger.String("instance_id", instanceID),
+			logger.String("error", err.Error()))
+
+		apiErr := h.converter.GRPCErrorToAPIError(err)
+		if apiErr.Code == restmodels.ErrorCodeResourceNotFound {
+			apiErr = restmodels.ProcessNotFoundError(instanceID)
+		}
+		statusCode := restmodels.HTTPStatusFromErrorCode(apiErr.Code)
+		c.JSON(statusCode, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	response := &restmodels.DeleteResponse{
+		ID:      instanceID,
+		Message: "Process instance cancelled successfully",
+	}
+
+	logger.Info("Process instance cancelled",
+		logger.String("request_id", requestID),
+		logger.String("instance_id", instanceID))
+
+	c.JSON(http.StatusOK, restmodels.SuccessResponse(response, requestID))
+}
+
+// PatchProcessVariables handles PATCH /api/v1/processes/:id/variables
+// @Summary Patch process instance variables
+// @Description Apply JSON merge patch (RFC 7386) to process instance variables.
+// Null values delete keys, nested objects are merged, other values replace existing ones.
+// Patch is applied to active tokens as well. Returns resulting variable set
+// @Tags processes
+// @Accept json
+// @Accept application/merge-patch+json
+// @Produce json
+// @Param id path string true "Process instance ID"
+// @Param patch body object true "JSON merge patch"
+// @Success 200 {object} restmodels.APIResponse{data=object}
+// @Failure 400 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 401 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 403 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 404 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 409 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 413 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 415 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 500 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/processes/{id}/variables [patch]
+func (h *ProcessHandler) PatchProcessVariables(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	instanceID := c.Param("id")
+
+	if apiErr := h.validator.ValidateID(instanceID, "instance_id"); apiErr != nil {
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(
+			restmodels.NewValidationError("Invalid instance ID format", []restmodels.ValidationError{*apiErr}),
+			requestID))
+		return
+	}
+
+	contentType := c.ContentType()
+	if contentType != models.MergePatchContentType && contentType != gin.MIMEJSON {
+		apiErr := restmodels.BadRequestError("Unsupported content type, expected " + models.MergePatchContentType)
+		c.JSON(http.StatusUnsupportedMediaType, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	var patch map[string]interface{}
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		apiErr := restmodels.BadRequestError("Merge patch must be JSON object: " + err.Error())
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	// Enforce variable size limits and offload large values
+	patch, apiErr := h.variableLimiter.Apply(patch, "variables")
+	if apiErr != nil {
+		c.JSON(restmodels.HTTPStatusFromErrorCode(apiErr.Code), restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	variables, err := h.coreInterface.PatchProcessInstanceVariables(instanceID, patch)
+	if err != nil {
+		logger.Error("Failed to patch process variables",
+			logger.String("request_id", requestID),
+			logger.String("instance_id", instanceID),
+			logger.String("error", err.Error()))
+
+		if errors.Is(err, models.ErrProcessInstanceFinished) {
+			apiErr := restmodels.ConflictError(err.Error())
+			c.JSON(http.StatusConflict, restmodels.ErrorResponse(apiErr, requestID))
+			return
+		}
+
+		apiErr := h.converter.GRPCErrorToAPIError(err)
+		if apiErr.Code == restmodels.ErrorCodeNotFound {
+			apiErr = restmodels.ProcessNotFoundError(instanceID)
+		}
+		statusCode := restmodels.HTTPStatusFromErrorCode(apiErr.Code)
+		c.JSON(statusCode, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	logger.Info("Process variables patched",
+		logger.String("request_id", requestID),
+		logger.String("instance_id", instanceID),
+		logger.Int("keys", len(patch)))
+
+	c.JSON(http.StatusOK, restmodels.SuccessResponse(variables, requestID))
+}
+
+// RestartProcess handles POST /api/v1/processes/:id/restart
+// @Summary Restart finished process instance from element
+// @Description Create new instance of the same definition version as a completed, canceled or failed instance.
+// Variables are copied and the initial token is placed at the given element instead of the start event.
+// The new instance references the original through parent_instance_id
+// @Tags processes
+// @Accept json
+// @Produce json
+// @Param id path string true "Process instance ID"
+// @Param request body restmodels.RestartProcessRequest true "Process restart request"
+// @Success 201 {object} restmodels.APIResponse{data=ProcessInstanceResult}
+// @Failure 400 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 401 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 403 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 404 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 409 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 500 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/processes/{id}/restart [post]
+func (h *ProcessHandler) RestartProcess(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	instanceID := c.Param("id")
+
+	if apiErr := h.validator.ValidateID(instanceID, "instance_id"); apiErr != nil {
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(
+			restmodels.NewValidationError("Invalid instance ID format", []restmodels.ValidationError{*apiErr}),
+			requestID))
+		return
+	}
+
+	var req restmodels.RestartProcessRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiErr := restmodels.BadRequestError("Invalid request body: " + err.Error())
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	processComp := h.coreInterface.GetProcessComponent()
+	if processComp == nil {
+		apiErr := restmodels.InternalServerError("Process service not available")
+		c.JSON(http.StatusInternalServerError, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	result, err := processComp.RestartProcessInstance(instanceID, req.ElementID)
+	if err != nil {
+		logger.Error("Failed to restart process instance",
+			logger.String("request_id", requestID),
+			logger.String("instance_id", instanceID),
+			logger.String("element_id", req.ElementID),
+			logger.String("error", err.Error()))
+
+		switch {
+		case errors.Is(err, models.ErrProcessInstanceNotFinished):
+			apiErr := restmodels.ConflictError(err.Error())
+			c.JSON(http.StatusConflict, restmodels.ErrorResponse(apiErr, requestID))
+			return
+		case errors.Is(err, models.ErrInvalidRestartElement):
+			apiErr := restmodels.BadRequestError(err.Error())
+			c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+			return
+		}
+
+		apiErr := h.converter.GRPCErrorToAPIError(err)
+		if apiErr.Code == restmodels.ErrorCodeNotFound {
+			apiErr = restmodels.ProcessNotFoundError(instanceID)
+		}
+		statusCode := restmodels.HTTPStatusFromErrorCode(apiErr.Code)
+		c.JSON(statusCode, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	logger.Info("Process instance restarted",
+		logger.String("request_id", requestID),
+		logger.String("instance_id", instanceID),
+		logger.String("new_instance_id", result.InstanceID),
+		logger.String("element_id", req.ElementID))
+
+	c.JSON(http.StatusCreated, restmodels.SuccessResponse(result, requestID))
+}
+
+// ModifyProcess handles POST /api/v1/processes/:id/modify
+// @Summary Modify running process instance
+// @Description Apply batch of instructions to running instance atomically: cancel tokens by token_id or element_id,
+// @Description start tokens at elements and set variables with JSON merge patch. Instructions are validated against
+// @Description instance tokens and process definition before any change, invalid batch changes nothing.
+// @Description Variables are set first, so started tokens see them
+// @Tags processes
+// @Accept json
+// @Produce json
+// @Param id path string true "Process instance ID"
+// @Param request body restmodels.ModifyProcessRequest true "Modification instructions"
+// @Success 200 {object} restmodels.APIResponse{data=models.ProcessInstanceModification}
+// @Failure 400 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 401 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 403 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 404 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 409 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 413 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 500 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/processes/{id}/modify [post]
+func (h *ProcessHandler) ModifyProcess(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	instanceID := c.Param("id")
+
+	if apiErr := h.validator.ValidateID(instanceID, "instance_id"); apiErr != nil {
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(
+			restmodels.NewValidationError("Invalid instance ID format", []restmodels.ValidationError{*apiErr}),
+			requestID))
+		return
+	}
+
+	var req restmodels.ModifyProcessRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiErr := restmodels.BadRequestError("Invalid request body: " + err.Error())
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	instructions := make([]models.ModificationInstruction, 0, len(req.Instructions))
+	for i, instruction := range req.Instructions {
+		// Enforce variable size limits and offload large values
+		fieldName := fmt.Sprintf("instructions[%d].variables", i)
+		variables, apiErr := h.variableLimiter.Apply(instruction.Variables, fieldName)
+		if apiErr != nil {
+			c.JSON(restmodels.HTTPStatusFromErrorCode(apiErr.Code), restmodels.ErrorResponse(apiErr, requestID))
+			return
+		}
+		instructions = append(instructions, models.ModificationInstruction{
+			Type:      models.ModificationInstructionType(instruction.Type),
+			ElementID: instruction.ElementID,
+			TokenID:   instruction.TokenID,
+			Variables: variables,
+		})
+	}
+
+	processComp := h.coreInterface.GetProcessComponent()
+	if processComp == nil {
+		apiErr := restmodels.InternalServerError("Process service not available")
+		c.JSON(http.StatusInternalServerError, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	result, err := processComp.ModifyProcessInstance(instanceID, instructions)
+	if err != nil {
+		logger.Error("Failed to modify process instance",
+			logger.String("request_id", requestID),
+			logger.String("instance_id", instanceID),
+			logger.String("error", err.Error()))
+
+		switch {
+		case errors.Is(err, models.ErrProcessInstanceFinished):
+			apiErr := restmodels.ConflictError(err.Error())
+			c.JSON(http.StatusConflict, restmodels.ErrorResponse(apiErr, requestID))
+			return
+		case errors.Is(err, models.ErrInvalidModification):
+			apiErr := restmodels.BadRequestError(err.Error())
+			c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+			return
+		}
+
+		apiErr := h.converter.GRPCErrorToAPIError(err)
+		if apiErr.Code == restmodels.ErrorCodeNotFound {
+			apiErr = restmodels.ProcessNotFoundError(instanceID)
+		}
+		statusCode := restmodels.HTTPStatusFromErrorCode(apiErr.Code)
+		c.JSON(statusCode, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	logger.Info("Process instance modified",
+		logger.String("request_id", requestID),
+		logger.String("instance_id", instanceID),
+		logger.Int("instructions", len(instructions)),
+		logger.Int("canceled_tokens", len(result.CanceledTokenIDs)),
+		logger.Int("started_tokens", len(result.StartedTokenIDs)))
+
+	c.JSON(http.StatusOK, restmodels.SuccessResponse(result, requestID))
+}
+
+// MigrateProcesses handles POST /api/v1/processes/migrate
+// @Summary Migrate running process instances to another definition version
+// @Description Move running instances of source_version to target_version. Plan is validated against both
+// @Description definitions: mapped elements must exist with same type, every active token position must map.
+// @Description Each instance is validated and migrated on its own, failing instances are skipped and reported.
+// @Description Waiting tokens whose element changed get their jobs, timers and subscriptions created again.
+// @Description With dry_run nothing is changed, report shows which instances would migrate
+// @Tags processes
+// @Accept json
+// @Produce json
+// @Param request body restmodels.MigrateProcessesRequest true "Migration plan"
+// @Success 200 {object} restmodels.APIResponse{data=models.ProcessInstanceMigration}
+// @Failure 400 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 401 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 403 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 404 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 500 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/processes/migrate [post]
+func (h *ProcessHandler) MigrateProcesses(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	var req restmodels.MigrateProcessesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiErr := restmodels.BadRequestError("Invalid request body: " + err.Error())
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		if apiErr, ok := err.(*restmodels.APIError); ok {
+			c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		} else {
+			c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(restmodels.BadRequestError(err.Error()), requestID))
+		}
+		return
+	}
+
+	processComp := h.coreInterface.GetProcessComponent()
+	if processComp == nil {
+		apiErr := restmodels.InternalServerError("Process service not available")
+		c.JSON(http.StatusInternalServerError, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	plan := models.MigrationPlan{
+		ProcessID:       req.ProcessID,
+		SourceVersion:   req.SourceVersion,
+		TargetVersion:   req.TargetVersion,
+		ElementMappings: req.ElementMappings,
+	}
+	filter := models.MigrationFilter{
+		InstanceIDs: req.Filter.InstanceIDs,
+		BusinessKey: req.Filter.BusinessKey,
+		ElementID:   req.Filter.ElementID,
+	}
+
+	report, err := processComp.MigrateProcessInstances(plan, filter, req.DryRun)
+	if err != nil {
+		logger.Error("Failed to migrate process instances",
+			logger.String("request_id", requestID),
+			logger.String("process_id", req.ProcessID),
+			logger.Int("source_version", req.SourceVersion),
+			logger.Int("target_version", req.TargetVersion),
+			logger.String("error", err.Error()))
+
+		switch {
+		case errors.Is(err, models.ErrInvalidMigration):
+			apiErr := restmodels.BadRequestError(err.Error())
+			c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+			return
+		case errors.Is(err, models.ErrNotFound):
+			apiErr := restmodels.NotFoundError(err.Error())
+			c.JSON(http.StatusNotFound, restmodels.ErrorResponse(apiErr, requestID))
+			return
+		}
+
+		apiErr := h.converter.GRPCErrorToAPIError(err)
+		statusCode := restmodels.HTTPStatusFromErrorCode(apiErr.Code)
+		c.JSON(statusCode, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	logger.Info("Process instances migration finished",
+		logger.String("request_id", requestID),
+		logger.String("source_process_key", report.SourceProcessKey),
+		logger.String("target_process_key", report.TargetProcessKey),
+		logger.Bool("dry_run", report.DryRun),
+		logger.Int("migrated", report.Migrated),
+		logger.Int("skipped", report.Skipped))
+
+	c.JSON(http.StatusOK, restmodels.SuccessResponse(report, requestID))
+}
+
+// BulkCancelProcesses handles POST /api/v1/processes/bulk/cancel
+// @Summary Cancel multiple process instances
+// @Description Cancel several process instances in one request with per-instance result reporting
+// @Tags processes
+// @Accept json
+// @Produce json
+// @Param request body models.BulkCancelProcessesRequest true "Bulk cancellation request"
+// @Success 200 {object} models.APIResponse{data=models.MultiStatusResponse}
+// @Success 207 {object} models.APIResponse{data=models.MultiStatusResponse}
+// @Failure 400 {object} models.APIResponse{error=models.APIError}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/processes/bulk/cancel [post]
+func (h *ProcessHandler) BulkCancelProcesses(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	var req restmodels.BulkCancelProcessesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiErr := restmodels.BadRequestError("Invalid request body: " + err.Error())
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		if apiErr, ok := err.(*restmodels.APIError); ok {
+			c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		} else {
+			c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(restmodels.BadRequestError(err.Error()), requestID))
+		}
+		return
+	}
+
+	processComp := h.coreInterface.GetProcessComponent()
+	if processComp == nil {
+		apiErr := restmodels.InternalServerError("Process service not available")
+		c.JSON(http.StatusInternalServerError, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	logger.Debug("Cancelling process instances in bulk",
+		logger.String("request_id", requestID),
+		logger.Int("instance_count", len(req.InstanceIDs)),
+		logger.String("reason", req.Reason))
+
+	result := restmodels.NewMultiStatusResponse()
+	for _, instanceID := range req.InstanceIDs {
+		if err := processComp.CancelProcessInstance(instanceID, req.Reason); err != nil {
+			logger.Warn("Failed to cancel process instance in bulk",
+				logger.String("request_id", requestID),
+				logger.String("instance_id", instanceID),
+				logger.String("error", err.Error()))
+
+			apiErr := h.converter.GRPCErrorToAPIError(err)
+			if apiErr.Code == restmodels.ErrorCodeResourceNotFound {
+				apiErr = restmodels.ProcessNotFoundError(instanceID)
+			}
+			result.AddFailure(instanceID, apiErr)
+			continue
+		}
+		result.AddSuccess(instanceID, http.StatusOK)
+	}
+
+	logger.Info("Bulk process cancellation processed",
 		logger.String("request_id", requestID),
-		logger.String("instance_id", instanceID),
-		logger.String("state", result.State))
+		logger.Int("succeeded", result.Succeeded),
+		logger.Int("failed", result.Failed))
 
-	c.JSON(http.StatusOK, restmodels.SuccessResponse(result, requestID))
+	c.JSON(result.HTTPStatus(), restmodels.SuccessResponse(result, requestID))
 }
 
-// GetProcessInfo handles GET /api/v1/processes/:id/info
-// @Summary Get complete process instance information
-// @Description Get detailed information about a process instance including
-// tokens, timers, jobs, messages, and incidents
+// StartProcessBatch handles POST /api/v1/processes/batch
+// @Summary Start process instances in batch
+// @Description Start many process instances in one request, items are started concurrently by process component.
+// @Description Number of items is limited by engine.batch_start_max_items. Batch is not atomic: instances
+// @Description started before failure of other items are kept, failed items are reported and can be resent.
+// @Description With async=true or header Prefer: respond-async 202 is returned at once with batch ID,
+// @Description poll GET /processes/batch/{batch_id} for progress.
 // @Tags processes
+// @Accept json
 // @Produce json
-// @Param id path string true "Process instance ID"
-// @Success 200 {object} restmodels.APIResponse{data=map[string]interface{}}
+// @Param request body restmodels.BatchStartProcessesRequest true "Batch start request"
+// @Param async query bool false "Return 202 at once and start instances in background"
+// @Param Prefer header string false "respond-async has same effect as async=true"
+// @Success 200 {object} restmodels.APIResponse{data=restmodels.BatchStartResponse}
+// @Success 202 {object} restmodels.APIResponse{data=restmodels.BatchStartResponse}
+// @Success 207 {object} restmodels.APIResponse{data=restmodels.BatchStartResponse}
 // @Failure 400 {object} restmodels.APIResponse{error=restmodels.APIError}
 // @Failure 401 {object} restmodels.APIResponse{error=restmodels.APIError}
 // @Failure 403 {object} restmodels.APIResponse{error=restmodels.APIError}
-// @Failure 404 {object} restmodels.APIResponse{error=restmodels.APIError}
 // @Failure 500 {object} restmodels.APIResponse{error=restmodels.APIError}
 // @Security ApiKeyAuth
-// @Router /api/v1/processes/{id}/info [get]
-func (h *ProcessHandler) GetProcessInfo(c *gin.Context) {
-	requestID := h.getRequestID(c)
-	instanceID := c.Param("id")
+// @Router /api/v1/processes/batch [post]
+func (h *ProcessHandler) StartProcessBatch(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
 
-	if instanceID == "" {
-		apiErr := restmodels.BadRequestError("Process instance ID is required")
+	async, apiErr := parseAsyncPreference(c)
+	if apiErr != nil {
 		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
 		return
 	}
 
-	// Validate instance ID format
-	if apiErr := h.validator.ValidateID(instanceID, "instance_id"); apiErr != nil {
-		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(
-			restmodels.NewValidationError("Invalid instance ID format", []restmodels.ValidationError{*apiErr}),
-			requestID))
+	var req restmodels.BatchStartProcessesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiErr := restmodels.BadRequestError("Invalid request body: " + err.Error())
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
 		return
 	}
 
-	logger.Debug("Getting complete process instance information",
-		logger.String("request_id", requestID),
-		logger.String("instance_id", instanceID))
-
-	// Use adapter to get complete process info
-	coreInterface, ok := h.coreInterface.(interfaces.CoreTypedInterface)
-	if !ok {
-		logger.Error("Core typed interface not available",
-			logger.String("request_id", requestID))
-		apiErr := restmodels.InternalServerError("Service not available")
-		c.JSON(http.StatusInternalServerError, restmodels.ErrorResponse(apiErr, requestID))
+	if err := req.Validate(); err != nil {
+		if apiErr, ok := err.(*restmodels.APIError); ok {
+			c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		} else {
+			c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(restmodels.BadRequestError(err.Error()), requestID))
+		}
 		return
 	}
 
-	// Get complete process info through adapter
-	processInfo, err := coreInterface.GetProcessInfoForREST(instanceID)
+	// Variables of all items are checked before any instance is started
+	items := make([]models.BatchStartItem, len(req.Items))
+	for i, item := range req.Items {
+		if validationErrors := h.validator.ValidateVariables(item.Variables); len(validationErrors) > 0 {
+			apiErr := h.validator.CreateValidationError(validationErrors)
+			apiErr.Message = fmt.Sprintf("items[%d]: %s", i, apiErr.Message)
+			c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+			return
+		}
+
+		variables, apiErr := h.variableLimiter.Apply(item.Variables, fmt.Sprintf("items[%d].variables", i))
+		if apiErr != nil {
+			c.JSON(restmodels.HTTPStatusFromErrorCode(apiErr.Code), restmodels.ErrorResponse(apiErr, requestID))
+			return
+		}
+
+		items[i] = models.BatchStartItem{
+			ProcessKey:  item.ProcessKey,
+			Variables:   variables,
+			BusinessKey: item.BusinessKey,
+		}
+	}
+
+	logger.Debug("Starting process instances in batch",
+		logger.String("request_id", requestID),
+		logger.Int("item_count", len(items)),
+		logger.Bool("async", async))
+
+	batch, err := h.coreInterface.StartProcessInstanceBatch(utils.BackgroundContext(c), items, async)
 	if err != nil {
-		logger.Error("Failed to get complete process information",
+		logger.Error("Failed to start process batch",
 			logger.String("request_id", requestID),
-			logger.String("instance_id", instanceID),
 			logger.String("error", err.Error()))
 
 		apiErr := h.converter.GRPCErrorToAPIError(err)
-		statusCode := restmodels.HTTPStatusFromErrorCode(apiErr.Code)
-		c.JSON(statusCode, restmodels.ErrorResponse(apiErr, requestID))
+		c.JSON(restmodels.HTTPStatusFromErrorCode(apiErr.Code), restmodels.ErrorResponse(apiErr, requestID))
 		return
 	}
 
-	logger.Info("Process information retrieved successfully",
-		logger.String("request_id", requestID),
-		logger.String("instance_id", instanceID))
-
-	c.JSON(http.StatusOK, restmodels.SuccessResponse(processInfo, requestID))
-}
-
-func (h *ProcessHandler) CancelProcess(c *gin.Context) {
-	requestID := h.getRequestID(c)
-	instanceID := c.Param("id")
-
-	if instanceID == "" {
-		apiErr := restmodels.BadRequestError("Process instance ID is required")
-		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+	if async {
+		c.Header("Preference-Applied", "respond-async")
+		c.Header("Location", "/api/v1/processes/batch/"+batch.ID)
+		c.JSON(http.StatusAccepted, restmodels.SuccessResponse(h.newBatchStartResponse(batch, "none"), requestID))
 		return
 	}
 
-	// Parse optional request body
-	var req restmodels.CancelProcessRequest
-	if c.Request.ContentLength > 0 {
-		if err := c.ShouldBindJSON(&req); err != nil {
-			logger.Warn("Failed to parse cancel request body, using defaults",
-				logger.String("request_id", requestID),
-				logger.String("error", err.Error()))
-		}
+	logger.Info("Process batch processed",
+		logger.String("request_id", requestID),
+		logger.String("batch_id", batch.ID),
+		logger.Int("succeeded", batch.Succeeded),
+		logger.Int("failed", batch.Failed))
+
+	statusCode := http.StatusOK
+	if batch.Failed > 0 {
+		statusCode = http.StatusMultiStatus
 	}
+	c.JSON(statusCode, restmodels.SuccessResponse(h.newBatchStartResponse(batch, "all"), requestID))
+}
 
-	logger.Debug("Cancelling process instance",
-		logger.String("request_id", requestID),
-		logger.String("instance_id", instanceID),
-		logger.String("reason", req.Reason))
+// GetProcessBatch handles GET /api/v1/processes/batch/:batch_id
+// @Summary Get batch start progress
+// @Description Progress of batch started with async=true. Finished batches are kept for one hour
+// @Description and are lost on engine restart. By default only failed items are listed in results.
+// @Tags processes
+// @Produce json
+// @Param batch_id path string true "Batch ID"
+// @Param results query string false "Items to list in results: all, failed or none" default(failed)
+// @Success 200 {object} restmodels.APIResponse{data=restmodels.BatchStartResponse}
+// @Failure 400 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 401 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 403 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 404 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/processes/batch/{batch_id} [get]
+func (h *ProcessHandler) GetProcessBatch(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
 
-	// Get process component
-	processComp := h.coreInterface.GetProcessComponent()
-	if processComp == nil {
-		apiErr := restmodels.InternalServerError("Process service not available")
-		c.JSON(http.StatusInternalServerError, restmodels.ErrorResponse(apiErr, requestID))
+	results := c.DefaultQuery("results", "failed")
+	if results != "all" && results != "failed" && results != "none" {
+		apiErr := restmodels.BadRequestError("results must be all, failed or none")
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
 		return
 	}
 
-	// Cancel process instance
-	err := processComp.CancelProcessInstance(instanceID, req.Reason)
+	batch, err := h.coreInterface.GetProcessInstanceBatch(c.Param("batch_id"))
 	if err != nil {
-		logger.Error("Failed to cancel process instance",
-			logger.String("request_id", requestID),
-			logger.String("instance_id", instanceID),
-			logger.String("error", err.Error()))
-
 		apiErr := h.converter.GRPCErrorToAPIError(err)
-		if apiErr.Code == restmodels.ErrorCodeResourceNotFound {
-			apiErr = restmodels.ProcessNotFoundError(instanceID)
-		}
-		statusCode := restmodels.HTTPStatusFromErrorCode(apiErr.Code)
-		c.JSON(statusCode, restmodels.ErrorResponse(apiErr, requestID))
+		c.JSON(restmodels.HTTPStatusFromErrorCode(apiErr.Code), restmodels.ErrorResponse(apiErr, requestID))
 		return
 	}
 
-	response := &restmodels.DeleteResponse{
-		ID:      instanceID,
-		Message: "Process instance cancelled successfully",
-	}
+	c.JSON(http.StatusOK, restmodels.SuccessResponse(h.newBatchStartResponse(batch, results), requestID))
+}
 
-	logger.Info("Process instance cancelled",
-		logger.String("request_id", requestID),
-		logger.String("instance_id", instanceID))
+// newBatchStartResponse converts batch to response listing all, failed or none of its items
+func (h *ProcessHandler) newBatchStartResponse(
+	batch *models.BatchStart,
+	results string,
+) *restmodels.BatchStartResponse {
+	response := &restmodels.BatchStartResponse{
+		BatchID:   batch.ID,
+		State:     string(batch.State),
+		Total:     batch.Total,
+		Processed: batch.Processed,
+		Succeeded: batch.Succeeded,
+		Failed:    batch.Failed,
+		CreatedAt: batch.CreatedAt.Unix(),
+		Results:   []restmodels.BatchStartItemResponse{},
+	}
+	if batch.CompletedAt != nil {
+		response.CompletedAt = batch.CompletedAt.Unix()
+	}
+
+	for _, result := range batch.Results {
+		failed := result.Status == models.BatchItemStatusFailed
+		if results == "none" || (results == "failed" && !failed) {
+			continue
+		}
 
-	c.JSON(http.StatusOK, restmodels.SuccessResponse(response, requestID))
+		item := restmodels.BatchStartItemResponse{
+			Index:       result.Index,
+			ProcessKey:  result.ProcessKey,
+			BusinessKey: result.BusinessKey,
+			Status:      string(result.Status),
+			InstanceID:  result.InstanceID,
+		}
+		if result.Err != nil {
+			item.Error = h.converter.GRPCErrorToAPIError(result.Err)
+		}
+		response.Results = append(response.Results, item)
+	}
+	return response
 }
 
 // GetProcessTokens handles GET /api/v1/processes/:id/tokens
+// @Summary Get process instance tokens
+// @Description Get all tokens of a process instance
+// @Tags processes
+// @Produce json
+// @Param id path string true "Process instance ID"
+// @Param include_variables query bool false "Return variables of each token" default(true)
+// @Param fields query string false "Comma-separated fields of each token to return, e.g. id,element_id"
+// @Success 200 {object} restmodels.PaginatedResponse{data=[]Token}
+// @Failure 400 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 401 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 403 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 404 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 500 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/processes/{id}/tokens [get]
 func (h *ProcessHandler) GetProcessTokens(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	instanceID := c.Param("id")
 
+	selection, apiErr := utils.ParseFieldSelection(c)
+	if apiErr != nil {
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
 	logger.Debug("Getting process tokens",
 		logger.String("request_id", requestID),
 		logger.String("instance_id", instanceID))
@@ -540,7 +1598,9 @@ func (h *ProcessHandler) GetProcessTokens(c *gin.Context) {
 			ProcessInstanceID: token.ProcessInstanceID,
 			CreatedAt:         token.CreatedAt.Unix(),
 			UpdatedAt:         token.UpdatedAt.Unix(),
-			Variables:         token.Variables,
+		}
+		if selection.IncludeVariables {
+			restTokens[i].Variables = maskSensitiveVariables(c, token.Variables)
 		}
 	}
 
@@ -558,12 +1618,35 @@ func (h *ProcessHandler) GetProcessTokens(c *gin.Context) {
 		HasPrev: false,
 	}
 
-	c.JSON(http.StatusOK, restmodels.PaginatedSuccessResponse(restTokens, pagination, requestID))
+	data, err := selection.Apply(restTokens)
+	if err != nil {
+		logger.Error("Failed to select process token fields",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()))
+
+		apiErr := restmodels.InternalServerError("Failed to select process token fields")
+		c.JSON(http.StatusInternalServerError, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	c.JSON(http.StatusOK, restmodels.PaginatedSuccessResponse(data, pagination, requestID))
 }
 
 // GetTokenTrace handles GET /api/v1/processes/:id/tokens/trace
+// @Summary Get process instance token trace
+// @Description Get token execution trace of a process instance
+// @Tags processes
+// @Produce json
+// @Param id path string true "Process instance ID"
+// @Success 200 {object} restmodels.PaginatedResponse{data=[]Token}
+// @Failure 401 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 403 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 404 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 500 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/processes/{id}/tokens/trace [get]
 func (h *ProcessHandler) GetTokenTrace(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	instanceID := c.Param("id")
 
 	logger.Debug("Getting token trace",
@@ -608,7 +1691,7 @@ func (h *ProcessHandler) GetTokenTrace(c *gin.Context) {
 			ProcessInstanceID: token.ProcessInstanceID,
 			CreatedAt:         token.CreatedAt.Unix(),
 			UpdatedAt:         token.UpdatedAt.Unix(),
-			Variables:         token.Variables,
+			Variables:         maskSensitiveVariables(c, token.Variables),
 		}
 	}
 
@@ -629,13 +1712,223 @@ func (h *ProcessHandler) GetTokenTrace(c *gin.Context) {
 	c.JSON(http.StatusOK, restmodels.PaginatedSuccessResponse(restTokens, pagination, requestID))
 }
 
-// Helper methods
+// GetElementInstances handles GET /api/v1/processes/:id/element-instances
+// @Summary List element instances of process instance
+// @Description One row per element activation in activation order, with jobs, timers, message subscriptions
+// @Description and incidents of activation. Activation whose token failed or has open incident is in INCIDENT state.
+// @Description Activations recorded before element instance tracking was enabled are not listed
+// @Tags processes
+// @Produce json
+// @Param id path string true "Process instance ID or key"
+// @Param element_id query string false "Element ID filter"
+// @Param state query string false "State filter" Enums(active,completed,terminated,incident)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} restmodels.PaginatedResponse{data=[]ElementInstance}
+// @Failure 400 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 401 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 403 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 404 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 500 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/processes/{id}/element-instances [get]
+func (h *ProcessHandler) GetElementInstances(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	instanceID := c.Param("id")
+	elementID := c.Query("element_id")
+	state := strings.ToUpper(c.Query("state"))
+
+	paginationHelper := utils.NewPaginationHelper()
+	params, apiErr := paginationHelper.ParseAndValidate(c.DefaultQuery("page", "1"), c.DefaultQuery("limit", "20"))
+	if apiErr != nil {
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	if validationErr := h.validator.ValidateStringEnum(state, "state", elementInstanceStates); validationErr != nil {
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(
+			restmodels.NewValidationError("Invalid state filter", []restmodels.ValidationError{*validationErr}),
+			requestID))
+		return
+	}
+
+	logger.Debug("Listing element instances",
+		logger.String("request_id", requestID),
+		logger.String("instance_id", instanceID),
+		logger.String("element_id", elementID),
+		logger.String("state", state))
+
+	records, total, err := h.coreInterface.ListElementInstances(models.ElementInstanceQuery{
+		InstanceID: instanceID,
+		ElementID:  elementID,
+		State:      models.ElementInstanceState(state),
+		Offset:     utils.GetOffset(params.Page, params.Limit),
+		Limit:      params.Limit,
+	})
+	if err != nil {
+		logger.Error("Failed to list element instances",
+			logger.String("request_id", requestID),
+			logger.String("instance_id", instanceID),
+			logger.String("error", err.Error()))
+
+		apiErr := h.converter.GRPCErrorToAPIError(err)
+		if apiErr.Code == restmodels.ErrorCodeNotFound {
+			apiErr = restmodels.ProcessNotFoundError(instanceID)
+		}
+		c.JSON(restmodels.HTTPStatusFromErrorCode(apiErr.Code), restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	data := make([]*ElementInstance, len(records))
+	for i, record := range records {
+		data[i] = toRESTElementInstance(record)
+	}
+
+	logger.Info("Element instances listed",
+		logger.String("request_id", requestID),
+		logger.String("instance_id", instanceID),
+		logger.Int("count", len(data)),
+		logger.Int("total", total))
+
+	c.JSON(http.StatusOK, paginationHelper.CreateResponse(data, total, params, requestID))
+}
+
+// toRESTElementInstance converts element instance to REST format.
+// Current job is most recent one, current timer is scheduled one or most recent when none is scheduled
+func toRESTElementInstance(record *models.ElementInstance) *ElementInstance {
+	result := &ElementInstance{
+		Key:         record.Key,
+		ElementID:   record.ElementID,
+		ElementType: record.ElementType,
+		State:       string(record.State),
+		TokenID:     record.TokenID,
+		WaitingFor:  record.WaitingFor,
+		StartedAt:   record.StartedAt.Unix(),
+		TakenFlows:  record.TakenFlows,
+	}
+	if record.EndedAt != nil {
+		result.EndedAt = record.EndedAt.Unix()
+	}
+
+	for _, job := range record.Jobs {
+		result.Jobs = append(result.Jobs, ElementInstanceJob{
+			ID:     job.ID,
+			Key:    job.Key,
+			Type:   job.Type,
+			Status: string(job.Status),
+		})
+		result.JobKey = max(result.JobKey, job.Key)
+	}
+
+	var scheduled string
+	for _, timer := range record.Timers {
+		result.Timers = append(result.Timers, ElementInstanceTimer{
+			ID:        timer.ID,
+			ElementID: timer.ElementID,
+			State:     timer.State,
+			DueDate:   timer.DueDate.Unix(),
+		})
+		result.TimerID = timer.ID
+		if timer.State == "SCHEDULED" && scheduled == "" {
+			scheduled = timer.ID
+		}
+	}
+	if scheduled != "" {
+		result.TimerID = scheduled
+	}
+
+	for _, subscription := range record.Subscriptions {
+		result.Subscriptions = append(result.Subscriptions, ElementInstanceSubscription(subscription))
+	}
+	for _, incident := range record.Incidents {
+		result.Incidents = append(result.Incidents, ElementInstanceIncident(incident))
+	}
+	return result
+}
+
+// GetVariableHistory handles GET /api/v1/processes/:id/variables/:name/history
+// @Summary Get history of process instance variable
+// @Description Values variable held over instance life in order they were set, with element, token and job
+// @Description that set each value. Values set on instance at start, by variables patch or modification
+// @Description have no element. Values set before variable history was enabled are not listed
+// @Tags processes
+// @Produce json
+// @Param id path string true "Process instance ID or key"
+// @Param name path string true "Variable name"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} restmodels.PaginatedResponse{data=[]VariableHistoryEntry}
+// @Failure 400 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 401 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 403 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 404 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 500 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/processes/{id}/variables/{name}/history [get]
+func (h *ProcessHandler) GetVariableHistory(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	instanceID := c.Param("id")
+	name := c.Param("name")
+
+	paginationHelper := utils.NewPaginationHelper()
+	params, apiErr := paginationHelper.ParseAndValidate(c.DefaultQuery("page", "1"), c.DefaultQuery("limit", "20"))
+	if apiErr != nil {
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	logger.Debug("Getting variable history",
+		logger.String("request_id", requestID),
+		logger.String("instance_id", instanceID),
+		logger.String("variable", name))
+
+	entries, total, err := h.coreInterface.ListVariableHistory(models.VariableHistoryQuery{
+		InstanceID: instanceID,
+		Name:       name,
+		Offset:     utils.GetOffset(params.Page, params.Limit),
+		Limit:      params.Limit,
+	})
+	if err != nil {
+		logger.Error("Failed to get variable history",
+			logger.String("request_id", requestID),
+			logger.String("instance_id", instanceID),
+			logger.String("variable", name),
+			logger.String("error", err.Error()))
+
+		if errors.Is(err, models.ErrNotFound) {
+			c.JSON(http.StatusNotFound, restmodels.ErrorResponse(restmodels.NotFoundError(err.Error()), requestID))
+			return
+		}
+		apiErr := h.converter.GRPCErrorToAPIError(err)
+		c.JSON(restmodels.HTTPStatusFromErrorCode(apiErr.Code), restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
 
-func (h *ProcessHandler) getRequestID(c *gin.Context) string {
-	if requestID := c.GetHeader("X-Request-ID"); requestID != "" {
-		return requestID
+	masked := !canReadSensitiveVariables(c) && models.IsSensitiveVariable(name)
+	data := make([]*VariableHistoryEntry, len(entries))
+	for i, entry := range entries {
+		data[i] = &VariableHistoryEntry{
+			Name:      entry.Name,
+			Value:     entry.Value,
+			Removed:   entry.Removed,
+			ElementID: entry.ElementID,
+			TokenID:   entry.TokenID,
+			JobID:     entry.JobID,
+			SetAt:     entry.SetAt.Unix(),
+		}
+		if masked && !entry.Removed {
+			data[i].Value = models.SensitiveVariableMask
+		}
 	}
-	return utils.GenerateSecureRequestID("process")
+
+	logger.Info("Variable history retrieved",
+		logger.String("request_id", requestID),
+		logger.String("instance_id", instanceID),
+		logger.String("variable", name),
+		logger.Int("count", len(data)),
+		logger.Int("total", total))
+
+	c.JSON(http.StatusOK, paginationHelper.CreateResponse(data, total, params, requestID))
 }
 
 // ProcessStats provides process statistics
@@ -702,7 +1995,7 @@ func (h *ProcessHandler) GetProcessStats() (*ProcessStats, error) {
 // @Security ApiKeyAuth
 // @Router /api/v1/processes/typed [post]
 func (h *ProcessHandler) StartProcessTyped(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse typed request body
 	var req types.ProcessStartRequest
@@ -759,7 +2052,7 @@ func (h *ProcessHandler) StartProcessTyped(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/processes/typed [get]
 func (h *ProcessHandler) ListProcessesTyped(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse query parameters into typed request
 	req := &types.ProcessListRequest{
@@ -821,6 +2114,10 @@ func (h *ProcessHandler) ListProcessesTyped(c *gin.Context) {
 		logger.String("request_id", requestID),
 		logger.Int("count", int(result.TotalCount)))
 
+	for i := range result.Instances {
+		result.Instances[i].Variables = maskSensitiveVariables(c, result.Instances[i].Variables)
+	}
+
 	c.JSON(http.StatusOK, restmodels.SuccessResponse(result, requestID))
 }
 
@@ -836,7 +2133,7 @@ func (h *ProcessHandler) ListProcessesTyped(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/processes/{id}/typed [get]
 func (h *ProcessHandler) GetProcessStatusTyped(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	instanceID := c.Param("id")
 
 	if instanceID == "" {
@@ -876,6 +2173,8 @@ func (h *ProcessHandler) GetProcessStatusTyped(c *gin.Context) {
 		logger.String("instance_id", instanceID),
 		logger.String("status", string(result.Status)))
 
+	result.Variables = maskSensitiveVariables(c, result.Variables)
+
 	c.JSON(http.StatusOK, restmodels.SuccessResponse(result, requestID))
 }
 
@@ -894,7 +2193,7 @@ func (h *ProcessHandler) GetProcessStatusTyped(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/processes/{id}/typed [delete]
 func (h *ProcessHandler) CancelProcessTyped(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	instanceID := c.Param("id")
 
 	if instanceID == "" {
@@ -954,7 +2253,7 @@ func (h *ProcessHandler) CancelProcessTyped(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/processes/{id}/tokens/typed [get]
 func (h *ProcessHandler) GetProcessTokensTyped(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	instanceID := c.Param("id")
 
 	if instanceID == "" {
@@ -1015,6 +2314,10 @@ func (h *ProcessHandler) GetProcessTokensTyped(c *gin.Context) {
 		logger.String("instance_id", instanceID),
 		logger.Int("count", int(result.TotalCount)))
 
+	for i := range result.Tokens {
+		result.Tokens[i].Variables = maskSensitiveVariables(c, result.Tokens[i].Variables)
+	}
+
 	c.JSON(http.StatusOK, restmodels.SuccessResponse(result, requestID))
 }
 
@@ -1032,7 +2335,7 @@ func (h *ProcessHandler) GetProcessTokensTyped(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/processes/{id}/trace/typed [get]
 func (h *ProcessHandler) TraceProcessExecutionTyped(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	instanceID := c.Param("id")
 
 	if instanceID == "" {
@@ -1081,6 +2384,10 @@ func (h *ProcessHandler) TraceProcessExecutionTyped(c *gin.Context) {
 		logger.String("instance_id", instanceID),
 		logger.Int("total_tokens", int(result.TotalTokens)))
 
+	for i := range result.Tokens {
+		result.Tokens[i].Variables = maskSensitiveVariables(c, result.Tokens[i].Variables)
+	}
+
 	c.JSON(http.StatusOK, restmodels.SuccessResponse(result, requestID))
 }
 
@@ -1094,7 +2401,7 @@ func (h *ProcessHandler) TraceProcessExecutionTyped(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/processes/stats [get]
 func (h *ProcessHandler) GetProcessStatsHandler(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	logger.Debug("Getting process statistics with typed API",
 		logger.String("request_id", requestID))
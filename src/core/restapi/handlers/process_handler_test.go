@@ -0,0 +1,321 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"atom-engine/src/core/grpc"
+	"atom-engine/src/core/interfaces"
+	"atom-engine/src/core/models"
+	"atom-engine/src/core/restapi/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeProcessComponent serves status of one process instance, instance list and tokens,
+// other methods are not implemented
+type fakeProcessComponent struct {
+	interfaces.ProcessComponentInterface
+	status    *interfaces.ProcessInstanceStatus
+	instances []*interfaces.ProcessInstanceStatus
+	tokens    []*models.Token
+	lastQuery models.ProcessInstanceQuery
+}
+
+// QueryProcessInstances records query and, like process component, leaves variables out when excluded
+func (f *fakeProcessComponent) QueryProcessInstances(
+	query models.ProcessInstanceQuery,
+) ([]*interfaces.ProcessInstanceStatus, int, error) {
+	f.lastQuery = query
+	page := make([]*interfaces.ProcessInstanceStatus, len(f.instances))
+	for i, instance := range f.instances {
+		copied := *instance
+		if query.ExcludeVariables {
+			copied.Variables = nil
+		}
+		page[i] = &copied
+	}
+	return page, len(f.instances), nil
+}
+
+func (f *fakeProcessComponent) GetActiveTokens(instanceID string) ([]*models.Token, error) {
+	return f.tokens, nil
+}
+
+func (f *fakeProcessComponent) GetProcessInstanceStatus(instanceID string) (*interfaces.ProcessInstanceStatus, error) {
+	copied := *f.status
+	return &copied, nil
+}
+
+// fakeProcessCore exposes fake process component, other methods are not implemented
+type fakeProcessCore struct {
+	ProcessCoreInterface
+	component *fakeProcessComponent
+}
+
+func (f *fakeProcessCore) GetProcessComponent() grpc.ProcessComponentInterface {
+	return f.component
+}
+
+func TestGetProcessStatusConditionalGet(t *testing.T) {
+	const instanceID = "srv1-aBcDeFgHiJkLmNoPqR"
+
+	status := &interfaces.ProcessInstanceStatus{
+		InstanceID:      instanceID,
+		ProcessID:       "order",
+		State:           "ACTIVE",
+		CurrentActivity: "review",
+		Variables:       map[string]interface{}{"amount": 100},
+		UpdatedAt:       1000,
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	handler := NewProcessHandler(&fakeProcessCore{component: &fakeProcessComponent{status: status}},
+		utils.NewValidator(), nil)
+	router.GET("/processes/:id", handler.GetProcessStatus)
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/processes/"+instanceID, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	first := get("")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" {
+		t.Fatalf("first GET: status %d, ETag %q", first.Code, etag)
+	}
+	if cacheControl := first.Header().Get("Cache-Control"); cacheControl != utils.CacheControlRevalidate {
+		t.Errorf("Cache-Control = %q, want %q", cacheControl, utils.CacheControlRevalidate)
+	}
+
+	cached := get(etag)
+	if cached.Code != http.StatusNotModified {
+		t.Fatalf("GET with matching ETag: status %d, want 304", cached.Code)
+	}
+	if cached.Body.Len() != 0 {
+		t.Errorf("304 response has body %q", cached.Body.String())
+	}
+	if cached.Header().Get("ETag") != etag {
+		t.Errorf("304 ETag = %q, want %q", cached.Header().Get("ETag"), etag)
+	}
+
+	// Instance moves on, cached ETag no longer matches
+	status.CurrentActivity = "ship"
+	status.UpdatedAt = 2000
+
+	changed := get(etag)
+	if changed.Code != http.StatusOK {
+		t.Fatalf("GET after change: status %d, want 200", changed.Code)
+	}
+	if newETag := changed.Header().Get("ETag"); newETag == "" || newETag == etag {
+		t.Errorf("ETag after change = %q, previous %q", newETag, etag)
+	}
+	if changed.Body.Len() == 0 {
+		t.Error("200 response after change has no body")
+	}
+}
+
+// documentVariables returns variables with large document, as carried by real instances
+func documentVariables() map[string]interface{} {
+	return map[string]interface{}{"document": strings.Repeat("x", 10000), "amount": 100}
+}
+
+// getJSON serves GET request and returns decoded data items and raw body size
+func getJSON(t *testing.T, router *gin.Engine, url string) ([]map[string]interface{}, int) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, url, nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET %s: status %d, body %s", url, recorder.Code, recorder.Body.String())
+	}
+
+	var response struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode GET %s: %v", url, err)
+	}
+	return response.Data, recorder.Body.Len()
+}
+
+func TestListProcessesFieldSelectionShrinksPayload(t *testing.T) {
+	component := &fakeProcessComponent{}
+	for i := 0; i < 20; i++ {
+		component.instances = append(component.instances, &interfaces.ProcessInstanceStatus{
+			InstanceID: fmt.Sprintf("instance-%02d", i),
+			ProcessID:  "order",
+			State:      "ACTIVE",
+			Variables:  documentVariables(),
+		})
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/processes",
+		NewProcessHandler(&fakeProcessCore{component: component}, utils.NewValidator(), nil).ListProcesses)
+
+	full, fullSize := getJSON(t, router, "/processes?limit=20")
+	if component.lastQuery.ExcludeVariables {
+		t.Error("default listing asked component to exclude variables")
+	}
+	if len(full) != 20 || full[0]["variables"] == nil {
+		t.Fatalf("default listing must keep variables: %d items, first %v", len(full), full[0])
+	}
+
+	lean, leanSize := getJSON(t, router, "/processes?limit=20&include_variables=false")
+	if !component.lastQuery.ExcludeVariables {
+		t.Error("include_variables=false did not ask component to exclude variables")
+	}
+	for _, item := range lean {
+		if _, ok := item["variables"]; ok {
+			t.Fatalf("instance listed with variables: %v", item)
+		}
+	}
+
+	projected, projectedSize := getJSON(t, router, "/processes?limit=20&fields=instance_id,state")
+	for _, item := range projected {
+		if len(item) != 2 || item["instance_id"] == nil || item["state"] != "ACTIVE" {
+			t.Fatalf("projected instance %v, want only instance_id and state", item)
+		}
+	}
+
+	if fullSize < 20*10000 || leanSize*10 > fullSize || projectedSize >= leanSize {
+		t.Errorf("payload is %d bytes in full, %d without variables and %d with two fields",
+			fullSize, leanSize, projectedSize)
+	}
+}
+
+func TestGetProcessTokensWithoutVariablesShrinksPayload(t *testing.T) {
+	const instanceID = "srv1-aBcDeFgHiJkLmNoPqR"
+
+	component := &fakeProcessComponent{}
+	for i := 0; i < 20; i++ {
+		component.tokens = append(component.tokens, &models.Token{
+			TokenID:           fmt.Sprintf("token-%02d", i),
+			ProcessInstanceID: instanceID,
+			CurrentElementID:  "review",
+			State:             models.TokenStateWaiting,
+			Variables:         documentVariables(),
+			CreatedAt:         time.Now(),
+			UpdatedAt:         time.Now(),
+		})
+	}
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/processes/:id/tokens",
+		NewProcessHandler(&fakeProcessCore{component: component}, utils.NewValidator(), nil).GetProcessTokens)
+
+	full, fullSize := getJSON(t, router, "/processes/"+instanceID+"/tokens")
+	if len(full) != 20 || full[0]["variables"] == nil {
+		t.Fatalf("default tokens must keep variables: %d items, first %v", len(full), full[0])
+	}
+
+	lean, leanSize := getJSON(t, router,
+		"/processes/"+instanceID+"/tokens?include_variables=false&fields=id,element_id")
+	for _, item := range lean {
+		if len(item) != 2 || item["element_id"] != "review" {
+			t.Fatalf("token %v, want only id and element_id", item)
+		}
+	}
+	if fullSize < 20*10000 || leanSize*10 > fullSize {
+		t.Errorf("payload is %d bytes in full and %d without variables", fullSize, leanSize)
+	}
+}
+
+// strictVariableValidator returns validator with small variable limits, as configured by operator
+func strictVariableValidator(t *testing.T) *utils.Validator {
+	t.Helper()
+
+	validator, err := utils.NewVariableValidator(&utils.VariableLimitsConfig{
+		KeyPattern:        utils.DefaultVariableKeyPattern,
+		MaxKeys:           10,
+		MaxDepth:          3,
+		MaxSerializedSize: 1024,
+	})
+	if err != nil {
+		t.Fatalf("create variable validator: %v", err)
+	}
+	return validator
+}
+
+// invalidVariables breaks key pattern, nesting depth and serialized size rules with one key each
+func invalidVariables() map[string]interface{} {
+	return map[string]interface{}{
+		"amount":   100,
+		"order.id": 7,
+		"tree":     map[string]interface{}{"a": map[string]interface{}{"b": map[string]interface{}{"c": 1}}},
+		"document": strings.Repeat("x", 2048),
+	}
+}
+
+// postValidationFields posts JSON body, expects validation error and returns offending fields
+func postValidationFields(t *testing.T, router *gin.Engine, url string, body interface{}) []string {
+	t.Helper()
+
+	data, _ := json.Marshal(body)
+	request := httptest.NewRequest(http.MethodPost, url, strings.NewReader(string(data)))
+	request.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	if recorder.Code != http.StatusBadRequest {
+		t.Fatalf("POST %s: status %d, want 400, body %s", url, recorder.Code, recorder.Body.String())
+	}
+
+	var response struct {
+		Error struct {
+			Code    string `json:"code"`
+			Details struct {
+				ValidationErrors []struct {
+					Field string `json:"field"`
+				} `json:"validation_errors"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode POST %s: %v", url, err)
+	}
+	if response.Error.Code != "VALIDATION_ERROR" {
+		t.Errorf("POST %s: error code %q, want VALIDATION_ERROR", url, response.Error.Code)
+	}
+	var fields []string
+	for _, validationError := range response.Error.Details.ValidationErrors {
+		fields = append(fields, validationError.Field)
+	}
+	return fields
+}
+
+// wantInvalidVariableFields are offending keys of invalidVariables in reported order
+var wantInvalidVariableFields = []string{"variables.document", "variables.order.id", "variables.tree"}
+
+func TestStartProcessRejectsInvalidVariables(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	// Process component is not set, so request reaching it would panic
+	router.POST("/processes", NewProcessHandler(&fakeProcessCore{}, strictVariableValidator(t), nil).StartProcess)
+
+	fields := postValidationFields(t, router, "/processes", map[string]interface{}{
+		"process_key": "order-process",
+		"variables":   invalidVariables(),
+	})
+	if strings.Join(fields, ",") != strings.Join(wantInvalidVariableFields, ",") {
+		t.Errorf("offending fields %q, want %q", fields, wantInvalidVariableFields)
+	}
+}
@@ -0,0 +1,198 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/restapi/middleware"
+	"atom-engine/src/core/restapi/models"
+	"atom-engine/src/core/restapi/utils"
+)
+
+// RateLimitAdminPath is path of rate limit management, never rate limited so limits can be loosened
+const RateLimitAdminPath = "/api/v1/admin/ratelimit"
+
+// Bounds of rate limit settings accepted at runtime
+const (
+	maxRequestsPerMinute = 1000000
+	minRateLimitWindow   = time.Second
+	maxRateLimitWindow   = time.Hour
+)
+
+// RateLimitHandler handles runtime management of HTTP rate limiting
+type RateLimitHandler struct {
+	rateLimiter *middleware.RateLimitMiddleware
+	validator   *utils.Validator
+}
+
+// UpdateRateLimitRequest changes rate limit settings, omitted fields keep current values,
+// skip_paths replaces current list
+type UpdateRateLimitRequest struct {
+	Enabled            *bool    `json:"enabled,omitempty"`
+	RequestsPerMinute  *int     `json:"requests_per_minute,omitempty"`
+	BurstSize          *int     `json:"burst_size,omitempty"`
+	WindowSize         string   `json:"window_size,omitempty" example:"1m"`
+	SkipPaths          []string `json:"skip_paths,omitempty"`
+	UseAuthRateLimiter *bool    `json:"use_auth_rate_limiter,omitempty"`
+}
+
+// RateLimitConfigResponse is effective rate limit configuration
+type RateLimitConfigResponse struct {
+	Enabled            bool     `json:"enabled"`
+	RequestsPerMinute  int      `json:"requests_per_minute"`
+	BurstSize          int      `json:"burst_size"`
+	WindowSize         string   `json:"window_size" example:"1m0s"`
+	SkipPaths          []string `json:"skip_paths"`
+	UseAuthRateLimiter bool     `json:"use_auth_rate_limiter"`
+}
+
+// NewRateLimitHandler creates new rate limit handler
+func NewRateLimitHandler(rateLimiter *middleware.RateLimitMiddleware) *RateLimitHandler {
+	return &RateLimitHandler{
+		rateLimiter: rateLimiter,
+		validator:   utils.NewValidator(),
+	}
+}
+
+// RegisterRoutes registers rate limit management routes
+func (h *RateLimitHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	admin := router.Group("/admin")
+
+	// Apply auth middleware with required permissions
+	if authMiddleware != nil {
+		admin.Use(authMiddleware.RequirePermission("admin"))
+	}
+
+	{
+		admin.GET("/ratelimit", h.GetRateLimit)
+		admin.PUT("/ratelimit", h.UpdateRateLimit)
+	}
+}
+
+// GetRateLimit handles GET /api/v1/admin/ratelimit
+// @Summary Get rate limit configuration
+// @Description Get effective HTTP rate limit configuration
+// @Tags admin
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=RateLimitConfigResponse}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/admin/ratelimit [get]
+func (h *RateLimitHandler) GetRateLimit(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	c.JSON(http.StatusOK, models.SuccessResponse(newRateLimitConfigResponse(h.rateLimiter.GetConfig()), requestID))
+}
+
+// UpdateRateLimit handles PUT /api/v1/admin/ratelimit
+// @Summary Update rate limit configuration
+// @Description Change HTTP rate limit configuration without daemon restart, omitted fields keep current values.
+// @Description skip_paths replaces current list, this endpoint itself is never rate limited.
+// @Description Limits of requests_per_minute and window_size apply only when use_auth_rate_limiter is false
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body UpdateRateLimitRequest true "Rate limit settings"
+// @Success 200 {object} models.APIResponse{data=RateLimitConfigResponse}
+// @Failure 400 {object} models.APIResponse{error=models.APIError}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/admin/ratelimit [put]
+func (h *RateLimitHandler) UpdateRateLimit(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	var req UpdateRateLimitRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiErr := models.BadRequestError("Invalid request body: " + err.Error())
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	config := h.rateLimiter.GetConfig()
+	if req.Enabled != nil {
+		config.Enabled = *req.Enabled
+	}
+	if req.RequestsPerMinute != nil {
+		config.RequestsPerMinute = *req.RequestsPerMinute
+	}
+	if req.BurstSize != nil {
+		config.BurstSize = *req.BurstSize
+	}
+	if req.SkipPaths != nil {
+		config.SkipPaths = append([]string{RateLimitAdminPath}, req.SkipPaths...)
+	}
+	if req.UseAuthRateLimiter != nil {
+		config.UseAuthRateLimiter = *req.UseAuthRateLimiter
+	}
+
+	validationErrors := h.validator.ValidateMultiple(
+		func() *models.ValidationError {
+			return h.validator.ValidateRange(config.RequestsPerMinute, "requests_per_minute", 1, maxRequestsPerMinute)
+		},
+		func() *models.ValidationError {
+			return h.validator.ValidateRange(config.BurstSize, "burst_size", 0, maxRequestsPerMinute)
+		},
+		func() *models.ValidationError {
+			if req.WindowSize == "" {
+				return nil
+			}
+			windowSize, err := time.ParseDuration(req.WindowSize)
+			if err != nil || windowSize < minRateLimitWindow || windowSize > maxRateLimitWindow {
+				return &models.ValidationError{
+					Field: "window_size",
+					Value: req.WindowSize,
+					Message: fmt.Sprintf("window_size must be duration between %s and %s",
+						minRateLimitWindow, maxRateLimitWindow),
+				}
+			}
+			config.WindowSize = windowSize
+			return nil
+		},
+	)
+
+	if len(validationErrors) > 0 {
+		apiErr := h.validator.CreateValidationError(validationErrors)
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	h.rateLimiter.UpdateConfig(config)
+
+	logger.Info("Rate limit configuration changed via API",
+		logger.String("request_id", requestID),
+		logger.Int("requests_per_minute", config.RequestsPerMinute),
+		logger.String("window_size", config.WindowSize.String()))
+
+	c.JSON(http.StatusOK, models.SuccessResponse(newRateLimitConfigResponse(h.rateLimiter.GetConfig()), requestID))
+}
+
+// newRateLimitConfigResponse converts middleware configuration to API response
+func newRateLimitConfigResponse(config *middleware.RateLimitConfig) RateLimitConfigResponse {
+	skipPaths := config.SkipPaths
+	if skipPaths == nil {
+		skipPaths = []string{}
+	}
+
+	return RateLimitConfigResponse{
+		Enabled:            config.Enabled,
+		RequestsPerMinute:  config.RequestsPerMinute,
+		BurstSize:          config.BurstSize,
+		WindowSize:         config.WindowSize.String(),
+		SkipPaths:          skipPaths,
+		UseAuthRateLimiter: config.UseAuthRateLimiter,
+	}
+}
@@ -0,0 +1,153 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"atom-engine/src/core/logger"
+	coremodels "atom-engine/src/core/models"
+	"atom-engine/src/core/restapi/middleware"
+	"atom-engine/src/core/restapi/models"
+	"atom-engine/src/core/restapi/utils"
+	"atom-engine/src/timewheel"
+)
+
+// SimulationHandler handles simulated clock of timewheel.
+// Routes exist only in simulation mode and are left out of OpenAPI specification like other optional APIs
+type SimulationHandler struct {
+	coreInterface SimulationCoreInterface
+}
+
+// SimulationCoreInterface defines methods needed for simulated clock
+type SimulationCoreInterface interface {
+	GetSimulationTime() (time.Time, error)
+	AdvanceSimulationClock(duration time.Duration) (*coremodels.SimulationAdvance, error)
+}
+
+// SimulationClockResponse is current time of simulated clock
+type SimulationClockResponse struct {
+	Now int64 `json:"now"`
+}
+
+// SimulationAdvanceResponse is result of advancing simulated clock
+type SimulationAdvanceResponse struct {
+	From        int64            `json:"from"`
+	To          int64            `json:"to"`
+	FiredCount  int              `json:"fired_count"`
+	FiredTimers []SimulatedTimer `json:"fired_timers"`
+}
+
+// SimulatedTimer is timer fired while simulated clock was advanced
+type SimulatedTimer struct {
+	TimerID           string `json:"timer_id"`
+	ElementID         string `json:"element_id"`
+	ProcessInstanceID string `json:"process_instance_id,omitempty"`
+	TimerType         string `json:"timer_type"`
+	DueDate           int64  `json:"due_date"`
+}
+
+// NewSimulationHandler creates new simulation handler
+func NewSimulationHandler(coreInterface SimulationCoreInterface) *SimulationHandler {
+	return &SimulationHandler{
+		coreInterface: coreInterface,
+	}
+}
+
+// RegisterRoutes registers simulated clock routes
+func (h *SimulationHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	sim := router.Group("/sim")
+
+	// Moving clock fires timers of all instances, admin permission is required
+	if authMiddleware != nil {
+		sim.Use(authMiddleware.RequirePermission("admin"))
+	}
+
+	{
+		sim.GET("/clock", h.GetClock)
+		sim.POST("/advance", h.Advance)
+	}
+}
+
+// GetClock handles GET /api/v1/sim/clock
+// Returns current time of simulated timewheel clock
+func (h *SimulationHandler) GetClock(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	now, err := h.coreInterface.GetSimulationTime()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(models.InternalServerError(err.Error()), requestID))
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(SimulationClockResponse{Now: now.Unix()}, requestID))
+}
+
+// Advance handles POST /api/v1/sim/advance?duration=
+// Moves simulated clock forward and fires timers due within advanced period in due date order.
+// Timer callbacks are processed before response, timers they schedule fire in same call when due
+func (h *SimulationHandler) Advance(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	duration, apiErr := parseSimulationDuration(c.Query("duration"))
+	if apiErr != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	result, err := h.coreInterface.AdvanceSimulationClock(duration)
+	if err != nil {
+		logger.Error("Failed to advance simulated clock",
+			logger.String("request_id", requestID),
+			logger.String("duration", duration.String()),
+			logger.String("error", err.Error()))
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(models.InternalServerError(err.Error()), requestID))
+		return
+	}
+
+	response := SimulationAdvanceResponse{
+		From:        result.From.Unix(),
+		To:          result.To.Unix(),
+		FiredCount:  len(result.FiredTimers),
+		FiredTimers: make([]SimulatedTimer, 0, len(result.FiredTimers)),
+	}
+	for _, timer := range result.FiredTimers {
+		response.FiredTimers = append(response.FiredTimers, SimulatedTimer{
+			TimerID:           timer.ID,
+			ElementID:         timer.ElementID,
+			ProcessInstanceID: timer.ProcessInstanceID,
+			TimerType:         string(timer.TimerType),
+			DueDate:           timer.DueDate.Unix(),
+		})
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(response, requestID))
+}
+
+// parseSimulationDuration parses Go or ISO 8601 duration, ISO form allows days for week-long processes
+func parseSimulationDuration(value string) (time.Duration, *models.APIError) {
+	if value == "" {
+		return 0, models.BadRequestError("duration query parameter is required")
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		duration, err = timewheel.NewISO8601DurationParser().ParseDuration(value)
+		if err != nil {
+			return 0, models.BadRequestError("Invalid duration: use Go (1h30m) or ISO 8601 (P7D) format")
+		}
+	}
+	if duration < 0 {
+		return 0, models.BadRequestError("duration must not be negative")
+	}
+	return duration, nil
+}
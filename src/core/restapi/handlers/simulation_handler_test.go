@@ -0,0 +1,122 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	coremodels "atom-engine/src/core/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeSimulationCore advances fixed clock and fires boundary timer once clock passes its due date
+type fakeSimulationCore struct {
+	now      time.Time
+	timerDue time.Time
+	advanced []time.Duration
+}
+
+func (f *fakeSimulationCore) GetSimulationTime() (time.Time, error) {
+	return f.now, nil
+}
+
+func (f *fakeSimulationCore) AdvanceSimulationClock(duration time.Duration) (*coremodels.SimulationAdvance, error) {
+	f.advanced = append(f.advanced, duration)
+	result := &coremodels.SimulationAdvance{From: f.now, To: f.now.Add(duration)}
+	if f.now.Before(f.timerDue) && !result.To.Before(f.timerDue) {
+		result.FiredTimers = append(result.FiredTimers, coremodels.SimulatedTimer{
+			ID:                "timer-1",
+			ElementID:         "timeout",
+			ProcessInstanceID: "instance-1",
+			TimerType:         coremodels.TimerTypeBoundary,
+			DueDate:           f.timerDue,
+		})
+	}
+	f.now = result.To
+	return result, nil
+}
+
+// advanceClock posts to /sim/advance and returns status with decoded response data
+func advanceClock(t *testing.T, router *gin.Engine, duration string) (int, SimulationAdvanceResponse) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/sim/advance?duration="+duration, nil))
+
+	var response struct {
+		Data SimulationAdvanceResponse `json:"data"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	return recorder.Code, response.Data
+}
+
+func TestAdvanceSimulationClockPastBoundaryTimer(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	core := &fakeSimulationCore{now: start, timerDue: start.Add(time.Hour)}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/sim/advance", NewSimulationHandler(core).Advance)
+
+	status, result := advanceClock(t, router, "59m")
+	if status != http.StatusOK || result.FiredCount != 0 {
+		t.Fatalf("advance before due date: status %d, %d timers fired", status, result.FiredCount)
+	}
+
+	status, result = advanceClock(t, router, "PT2M")
+	if status != http.StatusOK {
+		t.Fatalf("advance past due date: status %d", status)
+	}
+	if result.From != start.Add(59*time.Minute).Unix() || result.To != start.Add(61*time.Minute).Unix() {
+		t.Errorf("advanced from %d to %d", result.From, result.To)
+	}
+	if result.FiredCount != 1 || len(result.FiredTimers) != 1 {
+		t.Fatalf("%d timers fired, want boundary timer", result.FiredCount)
+	}
+	want := SimulatedTimer{
+		TimerID:           "timer-1",
+		ElementID:         "timeout",
+		ProcessInstanceID: "instance-1",
+		TimerType:         string(coremodels.TimerTypeBoundary),
+		DueDate:           start.Add(time.Hour).Unix(),
+	}
+	if result.FiredTimers[0] != want {
+		t.Errorf("fired timer %+v, want %+v", result.FiredTimers[0], want)
+	}
+}
+
+func TestAdvanceSimulationClockRejectsInvalidDuration(t *testing.T) {
+	core := &fakeSimulationCore{}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/sim/advance", NewSimulationHandler(core).Advance)
+
+	for _, duration := range []string{"", "soon", "-1h"} {
+		if status, _ := advanceClock(t, router, duration); status != http.StatusBadRequest {
+			t.Errorf("duration %q: status %d, want 400", duration, status)
+		}
+	}
+	if len(core.advanced) != 0 {
+		t.Errorf("clock advanced by invalid durations %v", core.advanced)
+	}
+
+	// ISO 8601 days let week-long processes be advanced in one call
+	if status, _ := advanceClock(t, router, "P7D"); status != http.StatusOK ||
+		len(core.advanced) != 1 || core.advanced[0] != 7*24*time.Hour {
+		t.Errorf("advance by P7D: status %d, advanced %v", status, core.advanced)
+	}
+}
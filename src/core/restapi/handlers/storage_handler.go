@@ -9,12 +9,17 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 
+	"atom-engine/src/core/archive"
 	"atom-engine/src/core/interfaces"
 	"atom-engine/src/core/logger"
+	coremodels "atom-engine/src/core/models"
+	"atom-engine/src/core/objectstore"
 	"atom-engine/src/core/restapi/middleware"
 	"atom-engine/src/core/restapi/models"
 	"atom-engine/src/core/restapi/utils"
@@ -30,6 +35,11 @@ type StorageHandler struct {
 type CoreInterface interface {
 	GetStorageStatus() (*interfaces.StorageStatusResponse, error)
 	GetStorageInfo() (*interfaces.StorageInfoResponse, error)
+	GetRetentionStatus() *archive.RetentionStatus
+	CreateBackup(ctx context.Context) (*coremodels.BackupInfo, error)
+	ListBackups(ctx context.Context) ([]*coremodels.BackupInfo, error)
+	VerifyBackup(ctx context.Context, name string) (*coremodels.BackupInfo, error)
+	DeleteBackup(ctx context.Context, name string) error
 }
 
 // Response types for storage operations
@@ -69,6 +79,19 @@ func (h *StorageHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware
 	{
 		storage.GET("/status", h.GetStatus)
 		storage.GET("/info", h.GetInfo)
+		storage.GET("/retention", h.GetRetentionStatus)
+		storage.GET("/backups", h.ListBackups)
+		storage.POST("/backups/:name/verify", h.VerifyBackup)
+	}
+
+	// Backups copy whole engine state, admin permission is required on top of storage
+	backups := storage.Group("/backups")
+	if authMiddleware != nil {
+		backups.Use(authMiddleware.RequirePermission("admin"))
+	}
+	{
+		backups.POST("", h.CreateBackup)
+		backups.DELETE("/:name", h.DeleteBackup)
 	}
 }
 
@@ -84,7 +107,7 @@ func (h *StorageHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware
 // @Security ApiKeyAuth
 // @Router /api/v1/storage/status [get]
 func (h *StorageHandler) GetStatus(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	logger.Debug("Getting storage status",
 		logger.String("request_id", requestID),
@@ -118,6 +141,29 @@ func (h *StorageHandler) GetStatus(c *gin.Context) {
 	c.JSON(http.StatusOK, models.SuccessResponse(response, requestID))
 }
 
+// GetRetentionStatus handles GET /api/v1/storage/retention
+// @Summary Get process instance retention status
+// @Description Get retention sweeper mode, last run counters and instances kept in primary storage after failures
+// @Tags storage
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=archive.RetentionStatus}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/storage/retention [get]
+func (h *StorageHandler) GetRetentionStatus(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	status := h.coreInterface.GetRetentionStatus()
+
+	logger.Debug("Retention status retrieved",
+		logger.String("request_id", requestID),
+		logger.Bool("enabled", status.Enabled),
+		logger.Int("failures", len(status.Failures)))
+
+	c.JSON(http.StatusOK, models.SuccessResponse(status, requestID))
+}
+
 // GetInfo handles GET /api/v1/storage/info
 // @Summary Get storage information
 // @Description Get detailed storage information including size and statistics
@@ -130,7 +176,7 @@ func (h *StorageHandler) GetStatus(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/storage/info [get]
 func (h *StorageHandler) GetInfo(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	logger.Debug("Getting storage info",
 		logger.String("request_id", requestID),
@@ -161,21 +207,6 @@ func (h *StorageHandler) GetInfo(c *gin.Context) {
 	c.JSON(http.StatusOK, models.SuccessResponse(response, requestID))
 }
 
-// getRequestID extracts request ID from context
-func (h *StorageHandler) getRequestID(c *gin.Context) string {
-	if requestID := c.GetHeader("X-Request-ID"); requestID != "" {
-		return requestID
-	}
-	return h.generateRequestID()
-}
-
-// generateRequestID generates a simple request ID
-func (h *StorageHandler) generateRequestID() string {
-	return utils.GenerateSecureRequestID("storage")
-}
-
-// generateRandomString generates random string of given length
-
 // GetHealthStatus provides storage health information for health checks
 func (h *StorageHandler) GetHealthStatus() map[string]interface{} {
 	status, err := h.coreInterface.GetStorageStatus()
@@ -268,3 +299,133 @@ func (h *StorageHandler) GetStorageStats() (*StorageStats, error) {
 
 	return stats, nil
 }
+
+// CreateBackup handles POST /api/v1/storage/backups
+// @Summary Create storage backup
+// @Description Stream gzip compressed database backup into configured object storage.
+// Returns name, location and SHA-256 of stored backup. Requires admin permission
+// @Tags storage
+// @Produce json
+// @Success 201 {object} models.APIResponse{data=coremodels.BackupInfo}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 409 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/storage/backups [post]
+func (h *StorageHandler) CreateBackup(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	backup, err := h.coreInterface.CreateBackup(c.Request.Context())
+	if err != nil {
+		h.respondBackupError(c, requestID, "create", "", err)
+		return
+	}
+
+	logger.Info("Storage backup created via REST",
+		logger.String("request_id", requestID),
+		logger.String("name", backup.Name))
+
+	c.JSON(http.StatusCreated, models.SuccessResponse(backup, requestID))
+}
+
+// ListBackups handles GET /api/v1/storage/backups
+// @Summary List storage backups
+// @Description List backups kept in configured object storage ordered by name, oldest first
+// @Tags storage
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=[]coremodels.BackupInfo}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/storage/backups [get]
+func (h *StorageHandler) ListBackups(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	backups, err := h.coreInterface.ListBackups(c.Request.Context())
+	if err != nil {
+		h.respondBackupError(c, requestID, "list", "", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(backups, requestID))
+}
+
+// VerifyBackup handles POST /api/v1/storage/backups/:name/verify
+// @Summary Verify storage backup
+// @Description Download backup from object storage and check it against SHA-256 stored at upload
+// @Tags storage
+// @Produce json
+// @Param name path string true "Backup name"
+// @Success 200 {object} models.APIResponse{data=coremodels.BackupInfo}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 404 {object} models.APIResponse{error=models.APIError}
+// @Failure 422 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/storage/backups/{name}/verify [post]
+func (h *StorageHandler) VerifyBackup(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	name := c.Param("name")
+
+	backup, err := h.coreInterface.VerifyBackup(c.Request.Context(), name)
+	if err != nil {
+		h.respondBackupError(c, requestID, "verify", name, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(backup, requestID))
+}
+
+// DeleteBackup handles DELETE /api/v1/storage/backups/:name
+// @Summary Delete storage backup
+// @Description Remove backup and its checksum from object storage. Requires admin permission
+// @Tags storage
+// @Produce json
+// @Param name path string true "Backup name"
+// @Success 200 {object} models.APIResponse{data=map[string]string}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 404 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/storage/backups/{name} [delete]
+func (h *StorageHandler) DeleteBackup(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	name := c.Param("name")
+
+	if err := h.coreInterface.DeleteBackup(c.Request.Context(), name); err != nil {
+		h.respondBackupError(c, requestID, "delete", name, err)
+		return
+	}
+
+	logger.Info("Storage backup deleted via REST",
+		logger.String("request_id", requestID),
+		logger.String("name", name))
+
+	c.JSON(http.StatusOK, models.SuccessResponse(map[string]string{"name": name, "status": "deleted"}, requestID))
+}
+
+// respondBackupError maps backup operation error to API error response
+func (h *StorageHandler) respondBackupError(c *gin.Context, requestID, operation, name string, err error) {
+	var apiErr *models.APIError
+	switch {
+	case errors.Is(err, objectstore.ErrNotFound):
+		apiErr = models.NotFoundError("Backup not found: " + name)
+	case errors.Is(err, coremodels.ErrBackupInProgress):
+		apiErr = models.ConflictError(err.Error())
+	case errors.Is(err, objectstore.ErrChecksumMismatch):
+		apiErr = models.NewAPIError(models.ErrorCodeChecksumMismatch, "Backup is corrupted: "+err.Error())
+	default:
+		logger.Error("Storage backup operation failed",
+			logger.String("request_id", requestID),
+			logger.String("operation", operation),
+			logger.String("name", name),
+			logger.String("error", err.Error()))
+		apiErr = models.NewAPIError(models.ErrorCodeStorageError, "Failed to "+operation+" backup: "+err.Error())
+	}
+
+	c.JSON(models.HTTPStatusFromErrorCode(apiErr.Code), models.ErrorResponse(apiErr, requestID))
+}
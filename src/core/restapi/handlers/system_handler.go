@@ -15,11 +15,13 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"atom-engine/src/core/interfaces"
 	"atom-engine/src/core/logger"
 	"atom-engine/src/core/restapi/middleware"
 	restmodels "atom-engine/src/core/restapi/models"
 	"atom-engine/src/core/restapi/utils"
 	"atom-engine/src/core/types"
+	"atom-engine/src/storage"
 )
 
 // SystemHandler handles system monitoring and management HTTP requests
@@ -38,6 +40,21 @@ type SystemCoreInterface interface {
 	ListComponents(req *types.ComponentListRequest) (*types.ComponentListResponse, error)
 	GetComponentStatus(componentName string) (*types.ComponentInfo, error)
 	HealthCheck(req *types.ComponentHealthCheckRequest) (*types.ComponentHealthCheckResponse, error)
+
+	// Storage access for system events log
+	GetStorageTyped() interfaces.StorageInterface
+}
+
+// SystemEvent represents system event log entry
+type SystemEvent struct {
+	ID         string    `json:"id"`
+	EventType  string    `json:"event_type"`
+	Status     string    `json:"status"`
+	Message    string    `json:"message"`
+	Component  string    `json:"component,omitempty"`
+	InstanceID string    `json:"instance_id,omitempty"`
+	JobID      string    `json:"job_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
 }
 
 // NewSystemHandler creates new system handler
@@ -64,6 +81,7 @@ func (h *SystemHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *
 		system.GET("/info", h.GetSystemInfo)
 		system.GET("/metrics", h.GetSystemMetrics)
 		system.GET("/health", h.SystemHealthCheck)
+		system.GET("/events", h.ListSystemEvents)
 
 		// Component management endpoints
 		system.GET("/components", h.ListComponents)
@@ -82,7 +100,7 @@ func (h *SystemHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *
 // @Security ApiKeyAuth
 // @Router /api/v1/system/status [get]
 func (h *SystemHandler) GetSystemStatus(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	logger.Debug("Getting system status",
 		logger.String("request_id", requestID),
@@ -119,7 +137,7 @@ func (h *SystemHandler) GetSystemStatus(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/system/info [get]
 func (h *SystemHandler) GetSystemInfo(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	logger.Debug("Getting system info",
 		logger.String("request_id", requestID),
@@ -156,7 +174,7 @@ func (h *SystemHandler) GetSystemInfo(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/system/metrics [get]
 func (h *SystemHandler) GetSystemMetrics(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	logger.Debug("Getting system metrics",
 		logger.String("request_id", requestID),
@@ -194,7 +212,7 @@ func (h *SystemHandler) GetSystemMetrics(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/system/health [get]
 func (h *SystemHandler) SystemHealthCheck(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse query parameters
 	deepCheck := c.Query("deep") == "true"
@@ -245,6 +263,114 @@ func (h *SystemHandler) SystemHealthCheck(c *gin.Context) {
 	c.JSON(httpStatus, restmodels.SuccessResponse(result, requestID))
 }
 
+// ListSystemEvents handles GET /api/v1/system/events
+// @Summary List system events
+// @Description Query system event log with filters, sorted newest first
+// @Tags system
+// @Produce json
+// @Param event_type query string false "Event type filter"
+// @Param status query string false "Event status filter"
+// @Param component query string false "Source component filter"
+// @Param instance_id query string false "Process instance ID filter"
+// @Param job_id query string false "Job ID filter"
+// @Param since query string false "Lower time bound (RFC3339)"
+// @Param until query string false "Upper time bound (RFC3339)"
+// @Param q query string false "Case-insensitive text match on message"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} restmodels.PaginatedResponse{data=[]SystemEvent}
+// @Failure 400 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Failure 500 {object} restmodels.APIResponse{error=restmodels.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/system/events [get]
+func (h *SystemHandler) ListSystemEvents(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	params := utils.ParsePaginationParams(c.Query("page"), c.Query("limit"))
+	if apiErr := utils.ValidatePaginationParams(params); apiErr != nil {
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	since, err := h.converter.ParseTimestamp(c.Query("since"))
+	if err != nil {
+		apiErr := restmodels.BadRequestError("Invalid since: " + err.Error())
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	until, err := h.converter.ParseTimestamp(c.Query("until"))
+	if err != nil {
+		apiErr := restmodels.BadRequestError("Invalid until: " + err.Error())
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	if !since.IsZero() && !until.IsZero() && until.Before(since) {
+		apiErr := restmodels.BadRequestError("until must not be before since")
+		c.JSON(http.StatusBadRequest, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	storageComp := h.coreInterface.GetStorageTyped()
+	if storageComp == nil {
+		apiErr := restmodels.InternalServerError("Storage component not available")
+		c.JSON(http.StatusInternalServerError, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	filter := &storage.SystemEventFilter{
+		EventType:  c.Query("event_type"),
+		Status:     c.Query("status"),
+		Component:  c.Query("component"),
+		InstanceID: c.Query("instance_id"),
+		JobID:      c.Query("job_id"),
+		Since:      since,
+		Until:      until,
+		Text:       c.Query("q"),
+		Offset:     utils.GetOffset(params.Page, params.Limit),
+		Limit:      params.Limit,
+	}
+
+	logger.Debug("Querying system events",
+		logger.String("request_id", requestID),
+		logger.Any("filter", filter))
+
+	records, total, err := storageComp.QuerySystemEvents(filter)
+	if err != nil {
+		logger.Error("Failed to query system events",
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()))
+
+		apiErr := restmodels.InternalServerError("Failed to load system events")
+		c.JSON(http.StatusInternalServerError, restmodels.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	events := make([]SystemEvent, 0, len(records))
+	for _, record := range records {
+		events = append(events, SystemEvent{
+			ID:         record.ID,
+			EventType:  record.EventType,
+			Status:     record.Status,
+			Message:    record.Message,
+			Component:  record.Component,
+			InstanceID: record.InstanceID,
+			JobID:      record.JobID,
+			CreatedAt:  record.CreatedAt,
+		})
+	}
+
+	pagination := utils.CalculatePaginationInfo(params.Page, params.Limit, total)
+
+	logger.Debug("Queried system events",
+		logger.String("request_id", requestID),
+		logger.Int("count", len(events)),
+		logger.Int("total", total))
+
+	c.JSON(http.StatusOK, restmodels.PaginatedSuccessResponse(events, pagination, requestID))
+}
+
 // ListComponents handles GET /api/v1/system/components
 // @Summary List system components
 // @Description Get list of system components with filtering options
@@ -261,7 +387,7 @@ func (h *SystemHandler) SystemHealthCheck(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/system/components [get]
 func (h *SystemHandler) ListComponents(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse query parameters into typed request
 	req := &types.ComponentListRequest{
@@ -321,7 +447,7 @@ func (h *SystemHandler) ListComponents(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/system/components/{name} [get]
 func (h *SystemHandler) GetComponentStatus(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	componentName := c.Param("name")
 
 	if componentName == "" {
@@ -372,7 +498,7 @@ func (h *SystemHandler) GetComponentStatus(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/system/components/{name}/health [get]
 func (h *SystemHandler) ComponentHealthCheck(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	componentName := c.Param("name")
 
 	if componentName == "" {
@@ -439,15 +565,3 @@ func (h *SystemHandler) ComponentHealthCheck(c *gin.Context) {
 
 	c.JSON(httpStatus, restmodels.SuccessResponse(result, requestID))
 }
-
-// Helper methods
-
-// getRequestID extracts request ID from gin context
-func (h *SystemHandler) getRequestID(c *gin.Context) string {
-	if id, exists := c.Get("request_id"); exists {
-		if requestID, ok := id.(string); ok {
-			return requestID
-		}
-	}
-	return "unknown"
-}
@@ -125,7 +125,7 @@ func (h *TimerHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *m
 
 // CreateTimer handles POST /api/v1/timers
 // @Summary Create timer
-// @Description Create a new timer with ISO 8601 duration format
+// @Description Create a new timer with ISO 8601 duration format, repeating interval may be cron expression
 // @Tags timers
 // @Accept json
 // @Produce json
@@ -139,7 +139,7 @@ func (h *TimerHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *m
 // @Security ApiKeyAuth
 // @Router /api/v1/timers [post]
 func (h *TimerHandler) CreateTimer(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse request body
 	var req models.AddTimerRequest
@@ -173,7 +173,7 @@ func (h *TimerHandler) CreateTimer(c *gin.Context) {
 		},
 		func() *models.ValidationError {
 			if req.Repeating && req.Interval != "" {
-				return h.validator.ValidateISO8601Duration(req.Interval, "interval")
+				return h.validator.ValidateTimeCycle(req.Interval, "interval")
 			}
 			return nil
 		},
@@ -242,7 +242,7 @@ func (h *TimerHandler) CreateTimer(c *gin.Context) {
 		logger.String("json_message", string(reqJSON)))
 
 	// Send timer creation request
-	err = timewheelComp.ProcessMessage(context.Background(), string(reqJSON))
+	err = timewheelComp.ProcessMessage(utils.BackgroundContext(c), string(reqJSON))
 	if err != nil {
 		logger.Error("Failed to send timer request",
 			logger.String("request_id", requestID),
@@ -292,7 +292,7 @@ func (h *TimerHandler) CreateTimer(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/timers [get]
 func (h *TimerHandler) ListTimers(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Parse query parameters
 	pageStr := c.DefaultQuery("page", "1")
@@ -324,8 +324,9 @@ func (h *TimerHandler) ListTimers(c *gin.Context) {
 		logger.Int("limit", params.Limit),
 		logger.String("status", status))
 
-	// Get timers list from core
-	timersResp, err := h.coreInterface.GetTimersList(status, int32(params.Limit*params.Page))
+	// Get timers list from core. Storage returns all timers of status at once,
+	// so whole list is paginated here and total counts every matching timer
+	timersResp, err := h.coreInterface.GetTimersList(status, 0)
 	if err != nil {
 		logger.Error("Failed to get timers list",
 			logger.String("request_id", requestID),
@@ -337,16 +338,13 @@ func (h *TimerHandler) ListTimers(c *gin.Context) {
 		return
 	}
 
-	// Apply client-side pagination
-	timers := timersResp.Timers
-	totalCount := int(timersResp.TotalCount)
-
-	paginatedTimers, paginationInfo := utils.ApplyPagination(timers, params.Page, params.Limit)
+	// Apply in-memory pagination
+	paginatedTimers, paginationInfo := utils.ApplyPagination(timersResp.Timers, params.Page, params.Limit)
 
 	logger.Info("Timers listed",
 		logger.String("request_id", requestID),
-		logger.Int("count", len(timers)),
-		logger.Int("total", totalCount))
+		logger.Int("count", len(paginatedTimers)),
+		logger.Int("total", paginationInfo.Total))
 
 	paginatedResp := models.PaginatedSuccessResponse(paginatedTimers, paginationInfo, requestID)
 	c.JSON(http.StatusOK, paginatedResp)
@@ -366,7 +364,7 @@ func (h *TimerHandler) ListTimers(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/timers/{id} [get]
 func (h *TimerHandler) GetTimer(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	timerID := c.Param("id")
 
 	if timerID == "" {
@@ -429,7 +427,7 @@ func (h *TimerHandler) GetTimer(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/timers/{id} [delete]
 func (h *TimerHandler) DeleteTimer(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	timerID := c.Param("id")
 
 	if timerID == "" {
@@ -471,7 +469,7 @@ func (h *TimerHandler) DeleteTimer(c *gin.Context) {
 	}
 
 	// Send timer removal request
-	err = timewheelComp.ProcessMessage(context.Background(), string(reqJSON))
+	err = timewheelComp.ProcessMessage(utils.BackgroundContext(c), string(reqJSON))
 	if err != nil {
 		logger.Error("Failed to send timer removal request",
 			logger.String("request_id", requestID),
@@ -516,7 +514,7 @@ func (h *TimerHandler) DeleteTimer(c *gin.Context) {
 // @Security ApiKeyAuth
 // @Router /api/v1/timers/stats [get]
 func (h *TimerHandler) GetStats(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	logger.Debug("Getting timer statistics",
 		logger.String("request_id", requestID))
@@ -541,12 +539,3 @@ func (h *TimerHandler) GetStats(c *gin.Context) {
 
 	c.JSON(http.StatusOK, models.SuccessResponse(stats, requestID))
 }
-
-// Helper methods
-
-func (h *TimerHandler) getRequestID(c *gin.Context) string {
-	if requestID := c.GetHeader("X-Request-ID"); requestID != "" {
-		return requestID
-	}
-	return utils.GenerateSecureRequestID("timer")
-}
@@ -0,0 +1,105 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"atom-engine/proto/timewheel/timewheelpb"
+	"atom-engine/src/core/restapi/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeTimerCore lists fixed timers and records requested limit, other methods are not implemented
+type fakeTimerCore struct {
+	TimerCoreInterface
+	timers         []*timewheelpb.TimerInfo
+	requestedLimit int32
+}
+
+func (f *fakeTimerCore) GetTimersList(statusFilter string, limit int32) (*timewheelpb.ListTimersResponse, error) {
+	f.requestedLimit = limit
+	return &timewheelpb.ListTimersResponse{Timers: f.timers, TotalCount: int32(len(f.timers))}, nil
+}
+
+// listTimersPage requests page of GET /timers and decodes timer IDs and pagination
+func listTimersPage(
+	t *testing.T,
+	router *gin.Engine,
+	query string,
+) ([]string, models.PaginationInfo) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/timers?"+query, nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET /timers?%s: status %d, body %s", query, recorder.Code, recorder.Body.String())
+	}
+
+	var response struct {
+		Data []struct {
+			TimerID string `json:"timer_id"`
+		} `json:"data"`
+		Pagination models.PaginationInfo `json:"pagination"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	ids := make([]string, 0, len(response.Data))
+	for _, timer := range response.Data {
+		ids = append(ids, timer.TimerID)
+	}
+	return ids, response.Pagination
+}
+
+func TestListTimersPaginatesWholeList(t *testing.T) {
+	core := &fakeTimerCore{}
+	for i := 0; i < 45; i++ {
+		core.timers = append(core.timers, &timewheelpb.TimerInfo{TimerId: fmt.Sprintf("timer-%02d", i)})
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/timers", NewTimerHandler(core).ListTimers)
+
+	tests := []struct {
+		query     string
+		wantFirst string
+		wantCount int
+		wantInfo  models.PaginationInfo
+	}{
+		{"", "timer-00", 20, models.PaginationInfo{Page: 1, Limit: 20, Total: 45, Pages: 3, HasNext: true}},
+		{"page=2&limit=20", "timer-20", 20,
+			models.PaginationInfo{Page: 2, Limit: 20, Total: 45, Pages: 3, HasNext: true, HasPrev: true}},
+		{"page=3&limit=20", "timer-40", 5,
+			models.PaginationInfo{Page: 3, Limit: 20, Total: 45, Pages: 3, HasPrev: true}},
+		{"page=5&limit=20", "", 0, models.PaginationInfo{Page: 5, Limit: 20, Total: 45, Pages: 3, HasPrev: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			ids, info := listTimersPage(t, router, tt.query)
+			if len(ids) != tt.wantCount || (tt.wantCount > 0 && ids[0] != tt.wantFirst) {
+				t.Errorf("page %v, want %d timers from %s", ids, tt.wantCount, tt.wantFirst)
+			}
+			if info != tt.wantInfo {
+				t.Errorf("pagination %+v, want %+v", info, tt.wantInfo)
+			}
+			// Whole list is requested, so total counts every timer whatever page is asked
+			if core.requestedLimit != 0 {
+				t.Errorf("timers requested with limit %d, want whole list", core.requestedLimit)
+			}
+		})
+	}
+}
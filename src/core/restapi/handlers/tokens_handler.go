@@ -88,7 +88,7 @@ func (h *TokensHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *
 // @Security ApiKeyAuth
 // @Router /api/v1/tokens/{id} [get]
 func (h *TokensHandler) GetTokenStatus(c *gin.Context) {
-	requestID := h.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 	tokenID := c.Param("id")
 
 	if tokenID == "" {
@@ -115,7 +115,7 @@ func (h *TokensHandler) GetTokenStatus(c *gin.Context) {
 	defer conn.Close()
 
 	// Create gRPC context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(utils.BackgroundContext(c), 10*time.Second)
 	defer cancel()
 
 	// Call gRPC GetTokenStatus method
@@ -165,6 +165,7 @@ func (h *TokensHandler) GetTokenStatus(c *gin.Context) {
 		for k, v := range resp.Token.Variables {
 			token.Variables[k] = v
 		}
+		token.Variables = maskSensitiveVariables(c, token.Variables)
 	}
 
 	logger.Info("Token status retrieved",
@@ -191,10 +192,3 @@ func (h *TokensHandler) getProcessGRPCClient() (processpb.ProcessServiceClient,
 	client := processpb.NewProcessServiceClient(grpcConn)
 	return client, grpcConn, nil
 }
-
-func (h *TokensHandler) getRequestID(c *gin.Context) string {
-	if requestID := c.GetHeader("X-Request-ID"); requestID != "" {
-		return requestID
-	}
-	return utils.GenerateSecureRequestID("tokens")
-}
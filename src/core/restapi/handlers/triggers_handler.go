@@ -0,0 +1,332 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"atom-engine/src/core/logger"
+	coremodels "atom-engine/src/core/models"
+	"atom-engine/src/core/restapi/middleware"
+	"atom-engine/src/core/restapi/models"
+	"atom-engine/src/core/restapi/utils"
+	"atom-engine/src/core/triggers"
+)
+
+// HookPathPrefix is root path of inbound webhook URLs, served without API key
+const HookPathPrefix = "/hooks/"
+
+// maxHookBodyBytes limits size of inbound webhook body
+const maxHookBodyBytes = 1 << 20
+
+// TriggersHandler handles webhook trigger management and inbound webhook calls
+type TriggersHandler struct {
+	coreInterface TriggersCoreInterface
+}
+
+// TriggersCoreInterface defines methods needed for webhook triggers
+type TriggersCoreInterface interface {
+	GetWebhookTriggers() *triggers.Manager
+}
+
+// CreateWebhookTriggerRequest describes new webhook trigger
+type CreateWebhookTriggerRequest struct {
+	Name       string            `json:"name,omitempty"`
+	ProcessKey string            `json:"process_key" binding:"required"`
+	AuthType   string            `json:"auth_type,omitempty" enums:"hmac,token"`
+	Secret     string            `json:"secret,omitempty"`
+	Variables  map[string]string `json:"variables,omitempty"`
+	RateLimit  int               `json:"rate_limit_per_minute,omitempty"`
+	Async      bool              `json:"async,omitempty"`
+}
+
+// SetWebhookTriggerEnabledRequest enables or disables webhook trigger
+type SetWebhookTriggerEnabledRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// WebhookTriggerResponse is webhook trigger with its hook URL path
+type WebhookTriggerResponse struct {
+	*coremodels.WebhookTrigger
+	URL string `json:"url"`
+}
+
+// WebhookTriggerListResponse is list of webhook triggers
+type WebhookTriggerListResponse struct {
+	Triggers []WebhookTriggerResponse `json:"triggers"`
+	Count    int                      `json:"count"`
+}
+
+// NewTriggersHandler creates new webhook triggers handler
+func NewTriggersHandler(coreInterface TriggersCoreInterface) *TriggersHandler {
+	return &TriggersHandler{
+		coreInterface: coreInterface,
+	}
+}
+
+// RegisterRoutes registers webhook trigger management routes
+func (h *TriggersHandler) RegisterRoutes(router *gin.RouterGroup, authMiddleware *middleware.AuthMiddleware) {
+	triggersGroup := router.Group("/triggers")
+
+	// Apply auth middleware with required permissions
+	if authMiddleware != nil {
+		triggersGroup.Use(authMiddleware.RequirePermission("admin"))
+	}
+
+	{
+		triggersGroup.POST("", h.CreateTrigger)
+		triggersGroup.GET("", h.ListTriggers)
+		triggersGroup.GET("/:id", h.GetTrigger)
+		triggersGroup.PUT("/:id/enabled", h.SetTriggerEnabled)
+		triggersGroup.DELETE("/:id", h.DeleteTrigger)
+	}
+}
+
+// RegisterHookRoutes registers inbound webhook route on root router, authenticated by trigger secret
+func (h *TriggersHandler) RegisterHookRoutes(router gin.IRouter) {
+	router.POST(HookPathPrefix+":id", h.InvokeHook)
+}
+
+// CreateTrigger handles POST /api/v1/triggers
+// @Summary Create webhook trigger
+// @Description Create webhook trigger starting process instances on calls of its URL, the secret is returned only once
+// @Tags triggers
+// @Accept json
+// @Produce json
+// @Param request body CreateWebhookTriggerRequest true "Webhook trigger"
+// @Success 201 {object} models.APIResponse{data=WebhookTriggerResponse}
+// @Failure 400 {object} models.APIResponse{error=models.APIError}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/triggers [post]
+func (h *TriggersHandler) CreateTrigger(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	var req CreateWebhookTriggerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiErr := models.BadRequestError("Invalid request body: " + err.Error())
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	trigger, err := h.coreInterface.GetWebhookTriggers().Create(triggers.CreateRequest{
+		Name:       req.Name,
+		ProcessKey: req.ProcessKey,
+		AuthType:   req.AuthType,
+		Secret:     req.Secret,
+		Variables:  req.Variables,
+		RateLimit:  req.RateLimit,
+		Async:      req.Async,
+	})
+	if err != nil {
+		h.respondTriggerError(c, requestID, "Failed to create webhook trigger", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, models.SuccessResponse(newWebhookTriggerResponse(trigger), requestID))
+}
+
+// ListTriggers handles GET /api/v1/triggers
+// @Summary List webhook triggers
+// @Description List webhook triggers with invocation counters, secrets are not returned
+// @Tags triggers
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=WebhookTriggerListResponse}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/triggers [get]
+func (h *TriggersHandler) ListTriggers(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	list := h.coreInterface.GetWebhookTriggers().List()
+	response := WebhookTriggerListResponse{
+		Triggers: make([]WebhookTriggerResponse, 0, len(list)),
+		Count:    len(list),
+	}
+	for _, trigger := range list {
+		response.Triggers = append(response.Triggers, newWebhookTriggerResponse(trigger))
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(response, requestID))
+}
+
+// GetTrigger handles GET /api/v1/triggers/:id
+// @Summary Get webhook trigger
+// @Description Get webhook trigger with invocation counters, the secret is not returned
+// @Tags triggers
+// @Produce json
+// @Param id path string true "Trigger ID"
+// @Success 200 {object} models.APIResponse{data=WebhookTriggerResponse}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 404 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/triggers/{id} [get]
+func (h *TriggersHandler) GetTrigger(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	trigger, err := h.coreInterface.GetWebhookTriggers().Get(c.Param("id"))
+	if err != nil {
+		h.respondTriggerError(c, requestID, "Failed to get webhook trigger", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(newWebhookTriggerResponse(trigger), requestID))
+}
+
+// SetTriggerEnabled handles PUT /api/v1/triggers/:id/enabled
+// @Summary Enable or disable webhook trigger
+// @Description Enable or disable webhook trigger, calls of disabled trigger are rejected with 403
+// @Tags triggers
+// @Accept json
+// @Produce json
+// @Param id path string true "Trigger ID"
+// @Param request body SetWebhookTriggerEnabledRequest true "Trigger state"
+// @Success 200 {object} models.APIResponse{data=WebhookTriggerResponse}
+// @Failure 400 {object} models.APIResponse{error=models.APIError}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 404 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/triggers/{id}/enabled [put]
+func (h *TriggersHandler) SetTriggerEnabled(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	var req SetWebhookTriggerEnabledRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiErr := models.BadRequestError("Invalid request body: " + err.Error())
+		c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	trigger, err := h.coreInterface.GetWebhookTriggers().SetEnabled(c.Param("id"), *req.Enabled)
+	if err != nil {
+		h.respondTriggerError(c, requestID, "Failed to toggle webhook trigger", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(newWebhookTriggerResponse(trigger), requestID))
+}
+
+// DeleteTrigger handles DELETE /api/v1/triggers/:id
+// @Summary Delete webhook trigger
+// @Description Delete webhook trigger, its URL stops accepting calls
+// @Tags triggers
+// @Produce json
+// @Param id path string true "Trigger ID"
+// @Success 200 {object} models.APIResponse{data=models.DeleteResponse}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 404 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/triggers/{id} [delete]
+func (h *TriggersHandler) DeleteTrigger(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+	triggerID := c.Param("id")
+
+	if err := h.coreInterface.GetWebhookTriggers().Delete(triggerID); err != nil {
+		h.respondTriggerError(c, requestID, "Failed to delete webhook trigger", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(&models.DeleteResponse{
+		ID:      triggerID,
+		Message: "Webhook trigger deleted",
+	}, requestID))
+}
+
+// InvokeHook handles POST /hooks/:id
+// @Summary Invoke webhook trigger
+// @Description Start process instance of webhook trigger, authenticated by X-Webhook-Signature HMAC or X-Webhook-Token
+// @Tags triggers
+// @Accept json
+// @Produce json
+// @Param id path string true "Trigger ID"
+// @Param X-Webhook-Signature header string false "sha256=<hex HMAC-SHA256 of body>"
+// @Param X-Webhook-Token header string false "Trigger token"
+// @Success 200 {object} models.APIResponse{data=triggers.InvocationResult}
+// @Success 202 {object} models.APIResponse{data=triggers.InvocationResult}
+// @Failure 400 {object} models.APIResponse{error=models.APIError}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 403 {object} models.APIResponse{error=models.APIError}
+// @Failure 404 {object} models.APIResponse{error=models.APIError}
+// @Failure 413 {object} models.APIResponse{error=models.APIError}
+// @Failure 429 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Router /hooks/{id} [post]
+func (h *TriggersHandler) InvokeHook(c *gin.Context) {
+	requestID := utils.GetRequestID(c)
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxHookBodyBytes))
+	if err != nil {
+		apiErr := models.PayloadTooLargeError("Webhook body is too large", map[string]interface{}{
+			"max_bytes": maxHookBodyBytes,
+		})
+		c.JSON(http.StatusRequestEntityTooLarge, models.ErrorResponse(apiErr, requestID))
+		return
+	}
+
+	result, err := h.coreInterface.GetWebhookTriggers().Invoke(c.Param("id"), &triggers.Invocation{
+		Body:    body,
+		Headers: c.Request.Header,
+		Query:   c.Request.URL.Query(),
+	})
+	if err != nil {
+		h.respondTriggerError(c, requestID, "Webhook invocation failed", err)
+		return
+	}
+
+	status := http.StatusOK
+	if result.Async {
+		status = http.StatusAccepted
+	}
+	c.JSON(status, models.SuccessResponse(result, requestID))
+}
+
+// respondTriggerError maps trigger manager errors to HTTP responses
+func (h *TriggersHandler) respondTriggerError(c *gin.Context, requestID, message string, err error) {
+	var apiErr *models.APIError
+	var status int
+
+	switch {
+	case errors.Is(err, triggers.ErrTriggerNotFound):
+		apiErr, status = models.NotFoundError(err.Error()), http.StatusNotFound
+	case errors.Is(err, triggers.ErrUnauthorized):
+		apiErr, status = models.UnauthorizedError(err.Error()), http.StatusUnauthorized
+	case errors.Is(err, triggers.ErrTriggerDisabled):
+		apiErr, status = models.ForbiddenError(err.Error()), http.StatusForbidden
+	case errors.Is(err, triggers.ErrRateLimited):
+		apiErr, status = models.RateLimitedError(err.Error()), http.StatusTooManyRequests
+	case errors.Is(err, triggers.ErrInvalidTrigger), errors.Is(err, triggers.ErrInvalidRequest):
+		apiErr, status = models.BadRequestError(err.Error()), http.StatusBadRequest
+	default:
+		logger.Error(message,
+			logger.String("request_id", requestID),
+			logger.String("error", err.Error()))
+		apiErr, status = models.InternalServerError(err.Error()), http.StatusInternalServerError
+	}
+
+	c.JSON(status, models.ErrorResponse(apiErr, requestID))
+}
+
+// newWebhookTriggerResponse adds hook URL path to trigger
+func newWebhookTriggerResponse(trigger *coremodels.WebhookTrigger) WebhookTriggerResponse {
+	return WebhookTriggerResponse{
+		WebhookTrigger: trigger,
+		URL:            HookPathPrefix + trigger.ID,
+	}
+}
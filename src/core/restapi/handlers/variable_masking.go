@@ -0,0 +1,36 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package handlers
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"atom-engine/src/core/auth"
+	coremodels "atom-engine/src/core/models"
+	"atom-engine/src/core/restapi/middleware"
+)
+
+// maskSensitiveVariables hides values of sensitive variables from callers
+// without the sensitive_variables permission. Requests without auth result
+// (auth disabled) see plaintext.
+func maskSensitiveVariables(c *gin.Context, variables map[string]interface{}) map[string]interface{} {
+	if len(variables) == 0 || canReadSensitiveVariables(c) {
+		return variables
+	}
+	return coremodels.MaskSensitiveVariables(variables)
+}
+
+// canReadSensitiveVariables reports whether caller may read sensitive variable values
+func canReadSensitiveVariables(c *gin.Context) bool {
+	authResult, ok := middleware.GetAuthResult(c)
+	if !ok || authResult == nil {
+		return true
+	}
+	return auth.HasPermission(authResult.Permissions, auth.PermissionSensitiveVariables)
+}
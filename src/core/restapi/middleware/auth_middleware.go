@@ -84,7 +84,7 @@ func (am *AuthMiddleware) Authenticate() gin.HandlerFunc {
 				logger.String("error", err.Error()))
 
 			apiErr := models.BadRequestError("Invalid request context")
-			c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, getRequestID(c)))
+			c.JSON(http.StatusBadRequest, models.ErrorResponse(apiErr, utils.GetRequestID(c)))
 			c.Abort()
 			return
 		}
@@ -99,7 +99,7 @@ func (am *AuthMiddleware) Authenticate() gin.HandlerFunc {
 				logger.String("error", err.Error()))
 
 			apiErr := models.InternalServerError("Authentication failed")
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse(apiErr, getRequestID(c)))
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(apiErr, utils.GetRequestID(c)))
 			c.Abort()
 			return
 		}
@@ -130,7 +130,7 @@ func (am *AuthMiddleware) Authenticate() gin.HandlerFunc {
 				statusCode = http.StatusUnauthorized
 			}
 
-			c.JSON(statusCode, models.ErrorResponse(apiErr, getRequestID(c)))
+			c.JSON(statusCode, models.ErrorResponse(apiErr, utils.GetRequestID(c)))
 			c.Abort()
 			return
 		}
@@ -156,7 +156,7 @@ func (am *AuthMiddleware) RequirePermission(permission string) gin.HandlerFunc {
 		authResult, exists := c.Get("auth_result")
 		if !exists {
 			apiErr := models.InternalServerError("Authentication context not found")
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse(apiErr, getRequestID(c)))
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(apiErr, utils.GetRequestID(c)))
 			c.Abort()
 			return
 		}
@@ -164,7 +164,7 @@ func (am *AuthMiddleware) RequirePermission(permission string) gin.HandlerFunc {
 		result, ok := authResult.(*auth.AuthResult)
 		if !ok {
 			apiErr := models.InternalServerError("Invalid authentication context")
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse(apiErr, getRequestID(c)))
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse(apiErr, utils.GetRequestID(c)))
 			c.Abort()
 			return
 		}
@@ -177,7 +177,7 @@ func (am *AuthMiddleware) RequirePermission(permission string) gin.HandlerFunc {
 				logger.Any("user_permissions", result.Permissions))
 
 			apiErr := models.ForbiddenError("Insufficient permissions")
-			c.JSON(http.StatusForbidden, models.ErrorResponse(apiErr, getRequestID(c)))
+			c.JSON(http.StatusForbidden, models.ErrorResponse(apiErr, utils.GetRequestID(c)))
 			c.Abort()
 			return
 		}
@@ -241,21 +241,6 @@ func GetAuthContext(c *gin.Context) (*auth.AuthContext, bool) {
 	return nil, false
 }
 
-// getRequestID gets or generates request ID
-func getRequestID(c *gin.Context) string {
-	if requestID := c.GetHeader("X-Request-ID"); requestID != "" {
-		return requestID
-	}
-
-	// Generate simple request ID if not provided
-	return generateRequestID()
-}
-
-// generateRequestID generates a cryptographically secure request ID
-func generateRequestID() string {
-	return utils.GenerateSecureRequestID("req")
-}
-
 // AuthContextKey is the context key for auth result
 type AuthContextKey string
 
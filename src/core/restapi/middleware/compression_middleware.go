@@ -0,0 +1,289 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package middleware
+
+import (
+	"compress/gzip"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CompressionConfig holds response compression configuration
+type CompressionConfig struct {
+	Enabled       bool     `yaml:"enabled"`
+	MinSize       int      `yaml:"min_size"`       // Smaller responses are sent as is, bytes
+	Level         int      `yaml:"level"`          // gzip level 1-9, 0 uses default level
+	ContentTypes  []string `yaml:"content_types"`  // Media types that are compressed
+	ExcludedPaths []string `yaml:"excluded_paths"` // Path patterns never compressed, * matches one segment
+}
+
+// DefaultCompressionConfig returns default compression configuration
+func DefaultCompressionConfig() *CompressionConfig {
+	return &CompressionConfig{
+		Enabled: true,
+		MinSize: 1024,
+		Level:   gzip.DefaultCompression,
+		ContentTypes: []string{
+			"application/json", "application/xml", "application/problem+json",
+			"text/plain", "text/html", "text/xml", "text/csv",
+		},
+	}
+}
+
+// CompressionMiddleware compresses responses with gzip when client accepts it
+type CompressionMiddleware struct {
+	config       *CompressionConfig
+	contentTypes map[string]bool
+	writers      sync.Pool
+}
+
+// NewCompressionMiddleware creates new compression middleware
+func NewCompressionMiddleware(config *CompressionConfig) *CompressionMiddleware {
+	if config == nil {
+		config = DefaultCompressionConfig()
+	}
+
+	// Set defaults for empty fields
+	if config.MinSize <= 0 {
+		config.MinSize = DefaultCompressionConfig().MinSize
+	}
+	if config.Level < gzip.HuffmanOnly || config.Level > gzip.BestCompression || config.Level == gzip.NoCompression {
+		config.Level = gzip.DefaultCompression
+	}
+	if len(config.ContentTypes) == 0 {
+		config.ContentTypes = DefaultCompressionConfig().ContentTypes
+	}
+
+	cm := &CompressionMiddleware{
+		config:       config,
+		contentTypes: make(map[string]bool, len(config.ContentTypes)),
+	}
+	for _, contentType := range config.ContentTypes {
+		cm.contentTypes[strings.ToLower(contentType)] = true
+	}
+	cm.writers.New = func() interface{} {
+		// Level is validated above, so writer creation cannot fail
+		writer, _ := gzip.NewWriterLevel(nil, config.Level)
+		return writer
+	}
+
+	return cm
+}
+
+// Handler provides Gin middleware for response compression.
+// It must be registered before logging middleware, so logging sees uncompressed body and size
+func (cm *CompressionMiddleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !cm.config.Enabled || !cm.shouldCompressRequest(c.Request) {
+			c.Next()
+			return
+		}
+
+		// Responses to clients without gzip support are not compressed, but still vary by Accept-Encoding
+		writer := &compressWriter{
+			ResponseWriter: c.Writer,
+			middleware:     cm,
+			identity:       !acceptsGzip(c.Request.Header.Get("Accept-Encoding")),
+		}
+		c.Writer = writer
+		defer writer.finish()
+
+		c.Next()
+	}
+}
+
+// shouldCompressRequest checks request properties that rule out compression before handler runs
+func (cm *CompressionMiddleware) shouldCompressRequest(req *http.Request) bool {
+	if req.Method == http.MethodHead || req.Header.Get("Range") != "" || req.Header.Get("Upgrade") != "" {
+		return false
+	}
+	for _, pattern := range cm.config.ExcludedPaths {
+		if matched, err := path.Match(pattern, req.URL.Path); err == nil && matched {
+			return false
+		}
+	}
+	return true
+}
+
+// isCompressibleType checks Content-Type header against allowlist.
+// Event streams are never compressed, so every event reaches client on flush
+func (cm *CompressionMiddleware) isCompressibleType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType == "text/event-stream" {
+		return false
+	}
+	return cm.contentTypes[mediaType]
+}
+
+// acceptsGzip checks Accept-Encoding header, gzip;q=0 rejects gzip explicitly
+func acceptsGzip(header string) bool {
+	accepted := false
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		coding = strings.ToLower(strings.TrimSpace(coding))
+		if coding != "gzip" && coding != "*" {
+			continue
+		}
+
+		quality := 1.0
+		if name, value, ok := strings.Cut(strings.TrimSpace(params), "="); ok && strings.TrimSpace(name) == "q" {
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				quality = parsed
+			}
+		}
+		if coding == "gzip" {
+			return quality > 0
+		}
+		accepted = quality > 0
+	}
+	return accepted
+}
+
+// compressWriter buffers start of response until MinSize bytes are written or handler finishes,
+// then sends it compressed or as is. Size reports uncompressed bytes written by handler.
+// Identity writer serves client without gzip support, it sends body as is without buffering
+type compressWriter struct {
+	gin.ResponseWriter
+	middleware *CompressionMiddleware
+	identity   bool
+	buffer     []byte
+	gzip       *gzip.Writer
+	decided    bool
+	size       int
+}
+
+// Write buffers or compresses response body
+func (w *compressWriter) Write(data []byte) (int, error) {
+	w.size += len(data)
+
+	if !w.decided {
+		w.buffer = append(w.buffer, data...)
+		if !w.identity && len(w.buffer) < w.middleware.config.MinSize {
+			return len(data), nil
+		}
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	}
+
+	if w.gzip != nil {
+		return w.gzip.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+// WriteString buffers or compresses response body
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow sends buffered headers, body written so far decides compression
+func (w *compressWriter) WriteHeaderNow() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Size returns number of uncompressed body bytes, -1 until body is written
+func (w *compressWriter) Size() int {
+	if w.size == 0 {
+		return w.ResponseWriter.Size()
+	}
+	return w.size
+}
+
+// Written reports whether handler has written headers or body
+func (w *compressWriter) Written() bool {
+	return w.size > 0 || w.ResponseWriter.Written()
+}
+
+// Flush sends buffered and compressed data to client, used by streaming responses
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if w.gzip != nil {
+		_ = w.gzip.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide chooses compression by status, headers and buffered size and writes out buffered body
+func (w *compressWriter) decide() error {
+	w.decided = true
+	header := w.Header()
+
+	if w.shouldCompressResponse() {
+		header.Set("Content-Encoding", "gzip")
+		header.Del("Content-Length")
+		header.Add("Vary", "Accept-Encoding")
+
+		w.gzip = w.middleware.writers.Get().(*gzip.Writer)
+		w.gzip.Reset(w.ResponseWriter)
+	} else if w.middleware.isCompressibleType(header.Get("Content-Type")) {
+		// Response still depends on Accept-Encoding for caches
+		header.Add("Vary", "Accept-Encoding")
+	}
+
+	buffered := w.buffer
+	w.buffer = nil
+	if len(buffered) == 0 {
+		return nil
+	}
+	if w.gzip != nil {
+		_, err := w.gzip.Write(buffered)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buffered)
+	return err
+}
+
+// shouldCompressResponse checks response properties known when body starts
+func (w *compressWriter) shouldCompressResponse() bool {
+	if w.identity {
+		return false
+	}
+	status := w.Status()
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		return false
+	}
+	if len(w.buffer) < w.middleware.config.MinSize {
+		return false
+	}
+
+	header := w.Header()
+	// Already encoded downloads are sent as is
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+	return w.middleware.isCompressibleType(header.Get("Content-Type"))
+}
+
+// finish writes rest of response after handler returns and releases gzip writer
+func (w *compressWriter) finish() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if w.gzip == nil {
+		return
+	}
+
+	_ = w.gzip.Close()
+	w.gzip.Reset(nil)
+	w.middleware.writers.Put(w.gzip)
+	w.gzip = nil
+}
@@ -0,0 +1,271 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package middleware
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// largeJSON is response body well above default compression threshold
+var largeJSON = `{"items":"` + strings.Repeat("token-", 4000) + `"}`
+
+// observedSizes records response sizes seen by access log and by middleware in rate limit position
+type observedSizes struct {
+	mu        sync.Mutex
+	accessLog *ResponseInfo
+	rateLimit int
+}
+
+// newCompressionServer serves routes through compression, access log and rate limit middleware
+// in order the REST server registers them, so real HTTP framing headers can be checked
+func newCompressionServer(t *testing.T) (*httptest.Server, *observedSizes) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	sizes := &observedSizes{}
+
+	router.Use(NewCompressionMiddleware(DefaultCompressionConfig()).Handler())
+
+	logging := NewLoggingMiddleware(&LoggingConfig{Enabled: true, LogBodies: true, MaxBodySize: 1 << 20})
+	router.Use(logging.Handler())
+	// Response is captured by access log code right after handler, same as logging middleware does
+	router.Use(func(c *gin.Context) {
+		writer := c.Writer.(*responseWriter)
+		c.Next()
+		info := logging.captureResponse(c, writer, 0)
+		sizes.mu.Lock()
+		sizes.accessLog = info
+		sizes.mu.Unlock()
+	})
+
+	router.Use(NewRateLimitMiddleware(&RateLimitConfig{
+		Enabled:           true,
+		RequestsPerMinute: 1000,
+		BurstSize:         1000,
+		WindowSize:        time.Minute,
+	}, nil).Handler())
+	router.Use(func(c *gin.Context) {
+		c.Next()
+		sizes.mu.Lock()
+		sizes.rateLimit = c.Writer.Size()
+		sizes.mu.Unlock()
+	})
+
+	router.GET("/large", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", []byte(largeJSON))
+	})
+	router.GET("/large-with-length", func(c *gin.Context) {
+		c.DataFromReader(http.StatusOK, int64(len(largeJSON)), "application/json",
+			strings.NewReader(largeJSON), nil)
+	})
+	router.GET("/small", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	router.GET("/archive", func(c *gin.Context) {
+		var archive bytes.Buffer
+		writer := gzip.NewWriter(&archive)
+		writer.Write([]byte(largeJSON))
+		writer.Close()
+		c.Header("Content-Encoding", "gzip")
+		c.Header("Content-Length", strconv.Itoa(archive.Len()))
+		c.Data(http.StatusOK, "application/json", archive.Bytes())
+	})
+
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server, sizes
+}
+
+// rawGet sends GET without transparent decompression and returns response with raw body
+func rawGet(t *testing.T, url, acceptEncoding string) (*http.Response, []byte) {
+	t.Helper()
+
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	request, _ := http.NewRequest(http.MethodGet, url, nil)
+	if acceptEncoding != "" {
+		request.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("read %s: %v", url, err)
+	}
+	return response, body
+}
+
+// checkFraming fails test unless Content-Length and Transfer-Encoding agree with raw body
+func checkFraming(t *testing.T, response *http.Response, body []byte) {
+	t.Helper()
+
+	header := response.Header.Get("Content-Length")
+	chunked := len(response.TransferEncoding) > 0 && response.TransferEncoding[0] == "chunked"
+	switch {
+	case header != "" && chunked:
+		t.Errorf("response has both Content-Length %s and chunked Transfer-Encoding", header)
+	case header != "" && header != strconv.Itoa(len(body)):
+		t.Errorf("Content-Length %s, body has %d bytes", header, len(body))
+	case header == "" && !chunked:
+		t.Errorf("response has neither Content-Length nor chunked Transfer-Encoding")
+	}
+}
+
+func TestCompressionHeadersMatchSentBody(t *testing.T) {
+	server, sizes := newCompressionServer(t)
+
+	tests := []struct {
+		name           string
+		path           string
+		acceptEncoding string
+		wantEncoding   string
+		wantBody       string
+	}{
+		{"compressed large body", "/large", "gzip", "gzip", largeJSON},
+		{"compressed body with handler length", "/large-with-length", "gzip, deflate", "gzip", largeJSON},
+		{"large body without gzip support", "/large", "", "", largeJSON},
+		{"gzip rejected by quality", "/large", "gzip;q=0, identity", "", largeJSON},
+		{"small body below threshold", "/small", "gzip", "", `{"status":"ok"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, raw := rawGet(t, server.URL+tt.path, tt.acceptEncoding)
+			if response.StatusCode != http.StatusOK {
+				t.Fatalf("status %d", response.StatusCode)
+			}
+			checkFraming(t, response, raw)
+
+			if encoding := response.Header.Get("Content-Encoding"); encoding != tt.wantEncoding {
+				t.Fatalf("Content-Encoding %q, want %q", encoding, tt.wantEncoding)
+			}
+			body := raw
+			if tt.wantEncoding == "gzip" {
+				if len(raw) >= len(tt.wantBody) {
+					t.Errorf("compressed body of %d bytes is not smaller than %d", len(raw), len(tt.wantBody))
+				}
+				reader, err := gzip.NewReader(bytes.NewReader(raw))
+				if err != nil {
+					t.Fatalf("gzip reader: %v", err)
+				}
+				if body, err = io.ReadAll(reader); err != nil {
+					t.Fatalf("decompress body: %v", err)
+				}
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("body of %d bytes differs from handler body of %d bytes", len(body), len(tt.wantBody))
+			}
+			if !strings.Contains(response.Header.Get("Vary"), "Accept-Encoding") {
+				t.Errorf("Vary %q does not list Accept-Encoding", response.Header.Get("Vary"))
+			}
+
+			// Access log and rate limit see body as written by handler, not compressed bytes
+			sizes.mu.Lock()
+			defer sizes.mu.Unlock()
+			if sizes.accessLog.Size != len(tt.wantBody) || sizes.accessLog.Body != tt.wantBody {
+				t.Errorf("access log size %d, body of %d bytes, want uncompressed %d",
+					sizes.accessLog.Size, len(sizes.accessLog.Body), len(tt.wantBody))
+			}
+			if sizes.rateLimit != len(tt.wantBody) {
+				t.Errorf("rate limit position sees size %d, want uncompressed %d", sizes.rateLimit, len(tt.wantBody))
+			}
+		})
+	}
+}
+
+func TestCompressionKeepsEncodedDownloadAsIs(t *testing.T) {
+	server, _ := newCompressionServer(t)
+
+	response, raw := rawGet(t, server.URL+"/archive", "gzip")
+	checkFraming(t, response, raw)
+	if response.Header.Get("Content-Length") != strconv.Itoa(len(raw)) {
+		t.Errorf("Content-Length %q of download not kept, body has %d bytes",
+			response.Header.Get("Content-Length"), len(raw))
+	}
+
+	// Body is gzip of handler once, not compressed twice
+	reader, err := gzip.NewReader(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("gzip reader: %v", err)
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil || string(body) != largeJSON {
+		t.Errorf("download decompressed once is not handler body: %v", err)
+	}
+}
+
+func TestCompressionFlushesEventStream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(NewCompressionMiddleware(DefaultCompressionConfig()).Handler())
+
+	received := make(chan struct{})
+	router.GET("/events", func(c *gin.Context) {
+		c.Header("Content-Type", "text/event-stream")
+		c.Status(http.StatusOK)
+		c.Writer.WriteString("data: " + strings.Repeat("x", 2048) + "\n\n")
+		c.Writer.Flush()
+
+		// Stream stays open until client has read first event
+		select {
+		case <-received:
+		case <-time.After(5 * time.Second):
+		}
+		c.Writer.WriteString("data: done\n\n")
+	})
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+	request, _ := http.NewRequest(http.MethodGet, server.URL+"/events", nil)
+	request.Header.Set("Accept-Encoding", "gzip")
+	response, err := client.Do(request)
+	if err != nil {
+		t.Fatalf("GET /events: %v", err)
+	}
+	defer response.Body.Close()
+
+	if encoding := response.Header.Get("Content-Encoding"); encoding != "" {
+		t.Errorf("event stream sent with Content-Encoding %q", encoding)
+	}
+	if response.Header.Get("Content-Length") != "" {
+		t.Errorf("event stream has Content-Length %q", response.Header.Get("Content-Length"))
+	}
+
+	events := make(chan string)
+	go func() {
+		line, _ := bufio.NewReader(response.Body).ReadString('\n')
+		events <- line
+	}()
+	select {
+	case line := <-events:
+		if !strings.HasPrefix(line, "data: xxx") {
+			t.Errorf("first event line %q", line)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("first event not flushed while stream is open")
+	}
+	close(received)
+}
@@ -10,6 +10,7 @@ package middleware
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -38,10 +39,11 @@ func DefaultCORSConfig() *CORSConfig {
 		},
 		AllowedHeaders: []string{
 			"Origin", "Content-Type", "Accept", "Authorization",
-			"X-Request-ID", "X-API-Key", "User-Agent",
+			"X-Request-ID", "X-API-Key", "User-Agent", "traceparent", "tracestate", "If-None-Match",
 		},
 		ExposedHeaders: []string{
-			"X-Request-ID", "X-Rate-Limit-Remaining", "X-Rate-Limit-Reset",
+			"X-Request-ID", "X-Rate-Limit-Remaining", "X-Rate-Limit-Reset", "ETag",
+			HeaderTotalCount, HeaderPage, HeaderPageLimit,
 		},
 		AllowCredentials: false,
 		MaxAge:           3600, // 1 hour
@@ -223,7 +225,7 @@ func (cm *CORSMiddleware) setCredentialsHeader(c *gin.Context) {
 // setMaxAgeHeader sets Access-Control-Max-Age header
 func (cm *CORSMiddleware) setMaxAgeHeader(c *gin.Context) {
 	if cm.config.MaxAge > 0 {
-		c.Header("Access-Control-Max-Age", strings.Join([]string{string(rune(cm.config.MaxAge + '0'))}, ""))
+		c.Header("Access-Control-Max-Age", strconv.Itoa(cm.config.MaxAge))
 	}
 }
 
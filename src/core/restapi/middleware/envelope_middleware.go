@@ -0,0 +1,158 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"atom-engine/src/core/restapi/models"
+)
+
+// EnvelopeQueryParam is query parameter that turns off response envelope when set to false
+const EnvelopeQueryParam = "envelope"
+
+// Pagination of unwrapped list responses is moved to these headers
+const (
+	HeaderTotalCount = "X-Total-Count"
+	HeaderPage       = "X-Page"
+	HeaderPageLimit  = "X-Page-Limit"
+)
+
+// EnvelopeMiddleware returns bare data object of successful GET responses when client asks
+// for it with ?envelope=false. Enveloped form stays default and errors are always enveloped
+type EnvelopeMiddleware struct{}
+
+// NewEnvelopeMiddleware creates new envelope middleware
+func NewEnvelopeMiddleware() *EnvelopeMiddleware {
+	return &EnvelopeMiddleware{}
+}
+
+// Handler returns gin handler that unwraps response envelope on request.
+// It must be registered after compression middleware, so it rewrites uncompressed body
+func (em *EnvelopeMiddleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet || c.Query(EnvelopeQueryParam) != "false" {
+			c.Next()
+			return
+		}
+
+		writer := &envelopeWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		defer writer.finish()
+
+		c.Next()
+	}
+}
+
+// envelopeWriter buffers response body until handler returns, then writes it unwrapped
+// when it is successful enveloped JSON. Streaming responses are passed through on first flush
+type envelopeWriter struct {
+	gin.ResponseWriter
+	buffer      bytes.Buffer
+	passthrough bool
+}
+
+// Write buffers response body
+func (w *envelopeWriter) Write(data []byte) (int, error) {
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.buffer.Write(data)
+}
+
+// WriteString buffers response body
+func (w *envelopeWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow is deferred until body is known, headers may still change
+func (w *envelopeWriter) WriteHeaderNow() {
+	if w.passthrough {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+// Size returns number of body bytes written by handler, -1 until body is written
+func (w *envelopeWriter) Size() int {
+	if w.passthrough || w.buffer.Len() == 0 {
+		return w.ResponseWriter.Size()
+	}
+	return w.buffer.Len()
+}
+
+// Written reports whether handler has written body
+func (w *envelopeWriter) Written() bool {
+	return w.buffer.Len() > 0 || w.ResponseWriter.Written()
+}
+
+// Flush switches to passthrough, streamed responses are never unwrapped
+func (w *envelopeWriter) Flush() {
+	if !w.passthrough {
+		w.passthrough = true
+		_, _ = w.ResponseWriter.Write(w.buffer.Bytes())
+		w.buffer.Reset()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// finish writes buffered body, unwrapped when it is successful enveloped JSON
+func (w *envelopeWriter) finish() {
+	if w.passthrough {
+		return
+	}
+
+	body := w.buffer.Bytes()
+	if data, ok := w.unwrap(body); ok {
+		body = data
+	}
+	if len(body) == 0 {
+		w.ResponseWriter.WriteHeaderNow()
+		return
+	}
+	_, _ = w.ResponseWriter.Write(body)
+}
+
+// unwrap extracts data field of successful response and moves pagination to headers
+func (w *envelopeWriter) unwrap(body []byte) ([]byte, bool) {
+	status := w.Status()
+	if status < http.StatusOK || status >= http.StatusMultipleChoices {
+		return nil, false
+	}
+	mediaType, _, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return nil, false
+	}
+
+	var envelope struct {
+		Success    *bool                  `json:"success"`
+		Data       json.RawMessage        `json:"data"`
+		Pagination *models.PaginationInfo `json:"pagination"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil || envelope.Success == nil || !*envelope.Success {
+		return nil, false
+	}
+
+	header := w.Header()
+	header.Del("Content-Length")
+	if envelope.Pagination != nil {
+		header.Set(HeaderTotalCount, strconv.Itoa(envelope.Pagination.Total))
+		header.Set(HeaderPage, strconv.Itoa(envelope.Pagination.Page))
+		header.Set(HeaderPageLimit, strconv.Itoa(envelope.Pagination.Limit))
+	}
+	if len(envelope.Data) == 0 {
+		return []byte("null"), true
+	}
+	return envelope.Data, true
+}
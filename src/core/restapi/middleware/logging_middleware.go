@@ -16,6 +16,8 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"atom-engine/src/core/logger"
+	"atom-engine/src/core/restapi/utils"
+	"atom-engine/src/core/tracing"
 )
 
 // LoggingConfig holds logging middleware configuration
@@ -38,7 +40,7 @@ func DefaultLoggingConfig() *LoggingConfig {
 		LogResponses:         true,
 		LogBodies:            false, // Disabled by default for security
 		MaxBodySize:          1024,  // 1KB max body logging
-		SkipPaths:            []string{"/health", "/metrics"},
+		SkipPaths:            []string{"/health", "/health/live", "/health/ready", "/health/startup", "/metrics"},
 		LogSlowRequests:      true,
 		SlowRequestThreshold: 1 * time.Second,
 	}
@@ -123,6 +125,7 @@ type RequestInfo struct {
 	ClientIP  string            `json:"client_ip"`
 	UserAgent string            `json:"user_agent"`
 	RequestID string            `json:"request_id"`
+	TraceID   string            `json:"trace_id,omitempty"`
 	Timestamp time.Time         `json:"timestamp"`
 }
 
@@ -144,7 +147,8 @@ func (lm *LoggingMiddleware) captureRequest(c *gin.Context) *RequestInfo {
 		Headers:   make(map[string]string),
 		ClientIP:  c.ClientIP(),
 		UserAgent: c.GetHeader("User-Agent"),
-		RequestID: c.GetHeader("X-Request-ID"),
+		RequestID: utils.GetRequestID(c),
+		TraceID:   tracing.TraceIDFromContext(c.Request.Context()),
 		Timestamp: time.Now(),
 	}
 
@@ -207,6 +211,7 @@ func (lm *LoggingMiddleware) logRequest(reqInfo *RequestInfo) {
 		logger.String("user_agent", reqInfo.UserAgent),
 		logger.String("request_id", reqInfo.RequestID),
 	}
+	fields = appendTraceID(fields, reqInfo)
 
 	if reqInfo.Query != "" {
 		fields = append(fields, logger.String("query", reqInfo.Query))
@@ -235,10 +240,11 @@ func (lm *LoggingMiddleware) logResponse(reqInfo *RequestInfo, respInfo *Respons
 		logger.String("path", reqInfo.Path),
 		logger.Int("status_code", respInfo.StatusCode),
 		logger.Int("response_size", respInfo.Size),
-		logger.Any("duration", respInfo.Duration),
+		logger.Float64("latency_ms", latencyMilliseconds(respInfo.Duration)),
 		logger.String("client_ip", reqInfo.ClientIP),
 		logger.String("request_id", reqInfo.RequestID),
 	}
+	fields = appendTraceID(fields, reqInfo)
 
 	if respInfo.Body != "" {
 		fields = append(fields, logger.String("response_body", respInfo.Body))
@@ -249,15 +255,30 @@ func (lm *LoggingMiddleware) logResponse(reqInfo *RequestInfo, respInfo *Respons
 
 // logSlowRequest logs slow HTTP requests
 func (lm *LoggingMiddleware) logSlowRequest(reqInfo *RequestInfo, respInfo *ResponseInfo) {
-	logger.Warn("Slow HTTP Request",
+	fields := []logger.Field{
 		logger.String("type", "slow_request"),
 		logger.String("method", reqInfo.Method),
 		logger.String("path", reqInfo.Path),
 		logger.Int("status_code", respInfo.StatusCode),
-		logger.Any("duration", respInfo.Duration),
+		logger.Float64("latency_ms", latencyMilliseconds(respInfo.Duration)),
 		logger.Any("threshold", lm.config.SlowRequestThreshold),
 		logger.String("client_ip", reqInfo.ClientIP),
-		logger.String("request_id", reqInfo.RequestID))
+		logger.String("request_id", reqInfo.RequestID),
+	}
+	logger.Warn("Slow HTTP Request", appendTraceID(fields, reqInfo)...)
+}
+
+// appendTraceID adds trace ID so request log lines can be found from trace and back
+func appendTraceID(fields []logger.Field, reqInfo *RequestInfo) []logger.Field {
+	if reqInfo.TraceID == "" {
+		return fields
+	}
+	return append(fields, logger.String("trace_id", reqInfo.TraceID))
+}
+
+// latencyMilliseconds returns duration in milliseconds with microsecond precision
+func latencyMilliseconds(duration time.Duration) float64 {
+	return float64(duration.Microseconds()) / 1000
 }
 
 // shouldSkipPath checks if path should be skipped from logging
@@ -331,6 +352,11 @@ func (rw *responseWriter) Write(data []byte) (int, error) {
 	return n, err
 }
 
+// WriteString captures response body written as string, e.g. by c.String or io.Copy from strings.Reader
+func (rw *responseWriter) WriteString(s string) (int, error) {
+	return rw.Write([]byte(s))
+}
+
 // GetConfig returns logging configuration
 func (lm *LoggingMiddleware) GetConfig() *LoggingConfig {
 	return lm.config
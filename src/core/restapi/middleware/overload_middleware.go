@@ -0,0 +1,248 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/restapi/models"
+	"atom-engine/src/core/restapi/utils"
+)
+
+// overloadRetryAfter is delay suggested to clients rejected because of overload
+const overloadRetryAfter = time.Second
+
+// OverloadConfig holds load shedding configuration
+type OverloadConfig struct {
+	Enabled        bool          `yaml:"enabled"`
+	MaxInFlight    int           `yaml:"max_in_flight"`   // Requests handled at once, more are rejected with 503
+	RequestTimeout time.Duration `yaml:"request_timeout"` // Longer requests get 503, handler result is discarded
+	ExcludedPaths  []string      `yaml:"excluded_paths"`  // Path patterns never limited, * matches one segment
+}
+
+// DefaultOverloadConfig returns default load shedding configuration
+func DefaultOverloadConfig() *OverloadConfig {
+	return &OverloadConfig{
+		Enabled:        true,
+		MaxInFlight:    512,
+		RequestTimeout: 25 * time.Second,
+	}
+}
+
+// OverloadMiddleware sheds load when server is saturated and limits total handler duration.
+// It wraps whole router instead of being gin middleware: handler runs in own goroutine with
+// own gin context, so timed out handler never touches context gin already reused
+type OverloadMiddleware struct {
+	config    *OverloadConfig
+	slots     chan struct{}
+	skipPaths map[string]bool
+}
+
+// NewOverloadMiddleware creates new overload middleware
+func NewOverloadMiddleware(config *OverloadConfig) *OverloadMiddleware {
+	if config == nil {
+		config = DefaultOverloadConfig()
+	}
+
+	// Set defaults for empty fields
+	if config.MaxInFlight <= 0 {
+		config.MaxInFlight = DefaultOverloadConfig().MaxInFlight
+	}
+	if config.RequestTimeout <= 0 {
+		config.RequestTimeout = DefaultOverloadConfig().RequestTimeout
+	}
+
+	return &OverloadMiddleware{
+		config:    config,
+		slots:     make(chan struct{}, config.MaxInFlight),
+		skipPaths: make(map[string]bool),
+	}
+}
+
+// AddSkipPath exempts exact path from limits, used for probes and streaming endpoints
+func (om *OverloadMiddleware) AddSkipPath(path string) {
+	om.skipPaths[path] = true
+}
+
+// Wrap returns handler enforcing in-flight limit and request timeout on next handler.
+// Slot is released when handler really finishes, so timed out work still counts against limit
+func (om *OverloadMiddleware) Wrap(next http.Handler) http.Handler {
+	if !om.config.Enabled {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if om.isExempt(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		// ID is resolved before router, so 503 responses and handler share it
+		requestID := utils.RequestIDFromHeader(r.Header.Get(utils.RequestIDHeader))
+		r.Header.Set(utils.RequestIDHeader, requestID)
+
+		select {
+		case om.slots <- struct{}{}:
+		default:
+			w.Header().Set("Retry-After", strconv.Itoa(int(overloadRetryAfter.Seconds())))
+			writeOverloadError(w, requestID, models.ServerOverloadedError(om.config.MaxInFlight, overloadRetryAfter))
+			return
+		}
+
+		om.serveWithTimeout(w, r, next, requestID)
+	})
+}
+
+// serveWithTimeout runs handler with deadline and buffered response.
+// Response is sent when handler finishes in time, otherwise 503 is sent and handler output is dropped
+func (om *OverloadMiddleware) serveWithTimeout(
+	w http.ResponseWriter,
+	r *http.Request,
+	next http.Handler,
+	requestID string,
+) {
+	ctx, cancel := context.WithTimeout(r.Context(), om.config.RequestTimeout)
+	tw := &timeoutWriter{header: make(http.Header)}
+	done := make(chan struct{})
+	panicChan := make(chan interface{}, 1)
+
+	go func() {
+		defer func() {
+			cancel()
+			<-om.slots
+		}()
+		defer func() {
+			if p := recover(); p != nil {
+				panicChan <- p
+			}
+		}()
+		next.ServeHTTP(tw, r.WithContext(ctx))
+		close(done)
+	}()
+
+	select {
+	case p := <-panicChan:
+		panic(p)
+
+	case <-done:
+		tw.mu.Lock()
+		defer tw.mu.Unlock()
+		dst := w.Header()
+		for key, values := range tw.header {
+			dst[key] = values
+		}
+		if !tw.wroteHeader {
+			tw.status = http.StatusOK
+		}
+		w.WriteHeader(tw.status)
+		_, _ = w.Write(tw.buf.Bytes())
+
+	case <-ctx.Done():
+		tw.mu.Lock()
+		defer tw.mu.Unlock()
+		tw.timedOut = true
+
+		// Client went away, nobody reads response
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return
+		}
+
+		logger.Warn("REST request timed out",
+			logger.String("request_id", requestID),
+			logger.String("method", r.Method),
+			logger.String("path", r.URL.Path),
+			logger.String("timeout", om.config.RequestTimeout.String()))
+		writeOverloadError(w, requestID, models.RequestTimeoutError(om.config.RequestTimeout))
+	}
+}
+
+// isExempt checks whether request is streaming, long polling or excluded from limits
+func (om *OverloadMiddleware) isExempt(r *http.Request) bool {
+	if r.Header.Get("Upgrade") != "" || strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+		return true
+	}
+	if om.skipPaths[r.URL.Path] {
+		return true
+	}
+	for _, pattern := range om.config.ExcludedPaths {
+		if matched, err := path.Match(pattern, r.URL.Path); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// writeOverloadError writes 503 error response in API envelope
+func writeOverloadError(w http.ResponseWriter, requestID string, apiErr *models.APIError) {
+	data, err := json.Marshal(models.ErrorResponse(apiErr, requestID))
+	if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set(utils.RequestIDHeader, requestID)
+	w.WriteHeader(http.StatusServiceUnavailable)
+	_, _ = w.Write(data)
+}
+
+// timeoutWriter buffers handler response until handler finishes or times out
+type timeoutWriter struct {
+	mu          sync.Mutex
+	header      http.Header
+	buf         bytes.Buffer
+	status      int
+	wroteHeader bool
+	timedOut    bool
+}
+
+// Header returns buffered response headers
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+// Write buffers response body, fails after timeout
+func (tw *timeoutWriter) Write(data []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if !tw.wroteHeader {
+		tw.writeHeaderLocked(http.StatusOK)
+	}
+	return tw.buf.Write(data)
+}
+
+// WriteHeader records response status
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+	tw.writeHeaderLocked(code)
+}
+
+// Flush is no-op, buffered response is sent when handler finishes
+func (tw *timeoutWriter) Flush() {}
+
+func (tw *timeoutWriter) writeHeaderLocked(code int) {
+	tw.wroteHeader = true
+	tw.status = code
+}
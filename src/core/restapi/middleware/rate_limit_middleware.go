@@ -19,6 +19,7 @@ import (
 	"atom-engine/src/core/auth"
 	"atom-engine/src/core/logger"
 	"atom-engine/src/core/restapi/models"
+	"atom-engine/src/core/restapi/utils"
 )
 
 // RateLimitConfig holds rate limiting configuration
@@ -50,9 +51,18 @@ type clientInfo struct {
 	mutex     sync.Mutex
 }
 
+// clone returns deep copy of configuration, so published snapshot is never mutated
+func (c *RateLimitConfig) clone() *RateLimitConfig {
+	cloned := *c
+	cloned.SkipPaths = append([]string(nil), c.SkipPaths...)
+	return &cloned
+}
+
 // RateLimitMiddleware provides HTTP rate limiting
 type RateLimitMiddleware struct {
+	// config is immutable snapshot replaced as whole under configMutex on update
 	config        *RateLimitConfig
+	configMutex   sync.RWMutex
 	authComponent auth.Component
 	clients       map[string]*clientInfo
 	clientsMutex  sync.RWMutex
@@ -65,7 +75,7 @@ func NewRateLimitMiddleware(config *RateLimitConfig, authComponent auth.Componen
 	}
 
 	return &RateLimitMiddleware{
-		config:        config,
+		config:        config.clone(),
 		authComponent: authComponent,
 		clients:       make(map[string]*clientInfo),
 	}
@@ -74,13 +84,15 @@ func NewRateLimitMiddleware(config *RateLimitConfig, authComponent auth.Componen
 // Handler provides Gin middleware for rate limiting
 func (rlm *RateLimitMiddleware) Handler() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		if !rlm.config.Enabled {
+		// Whole request is checked against one snapshot even if config is updated meanwhile
+		config := rlm.currentConfig()
+		if !config.Enabled {
 			c.Next()
 			return
 		}
 
 		// Skip rate limiting for configured paths
-		if rlm.shouldSkipPath(c.Request.URL.Path) {
+		if shouldSkipPath(config, c.Request.URL.Path) {
 			c.Next()
 			return
 		}
@@ -89,20 +101,20 @@ func (rlm *RateLimitMiddleware) Handler() gin.HandlerFunc {
 		clientID := rlm.getClientIdentifier(c)
 
 		// Check rate limit
-		if rlm.config.UseAuthRateLimiter && rlm.authComponent != nil {
+		if config.UseAuthRateLimiter && rlm.authComponent != nil {
 			// Use auth component's rate limiter
 			if !rlm.checkAuthRateLimit(c, clientID) {
 				return
 			}
 		} else {
 			// Use built-in rate limiter
-			if !rlm.checkBuiltinRateLimit(c, clientID) {
+			if !rlm.checkBuiltinRateLimit(c, clientID, config) {
 				return
 			}
 		}
 
 		// Add rate limit headers
-		rlm.addRateLimitHeaders(c, clientID)
+		rlm.addRateLimitHeaders(c, clientID, config)
 
 		c.Next()
 	}
@@ -144,7 +156,7 @@ func (rlm *RateLimitMiddleware) checkAuthRateLimit(c *gin.Context, clientID stri
 		}
 
 		apiErr := models.RateLimitedError("Rate limit exceeded")
-		c.JSON(http.StatusTooManyRequests, models.ErrorResponse(apiErr, getRequestID(c)))
+		c.JSON(http.StatusTooManyRequests, models.ErrorResponse(apiErr, utils.GetRequestID(c)))
 		c.Abort()
 		return false
 	}
@@ -156,7 +168,11 @@ func (rlm *RateLimitMiddleware) checkAuthRateLimit(c *gin.Context, clientID stri
 }
 
 // checkBuiltinRateLimit checks rate limit using built-in limiter
-func (rlm *RateLimitMiddleware) checkBuiltinRateLimit(c *gin.Context, clientID string) bool {
+func (rlm *RateLimitMiddleware) checkBuiltinRateLimit(
+	c *gin.Context,
+	clientID string,
+	config *RateLimitConfig,
+) bool {
 	now := time.Now()
 
 	// Get or create client info
@@ -180,7 +196,7 @@ func (rlm *RateLimitMiddleware) checkBuiltinRateLimit(c *gin.Context, clientID s
 	defer client.mutex.Unlock()
 
 	// Clean old requests (sliding window)
-	cutoff := now.Add(-rlm.config.WindowSize)
+	cutoff := now.Add(-config.WindowSize)
 	validRequests := make([]time.Time, 0)
 	for _, reqTime := range client.requests {
 		if reqTime.After(cutoff) {
@@ -190,12 +206,16 @@ func (rlm *RateLimitMiddleware) checkBuiltinRateLimit(c *gin.Context, clientID s
 	client.requests = validRequests
 
 	// Check if limit exceeded
-	if len(client.requests) >= rlm.config.RequestsPerMinute {
+	if len(client.requests) >= config.RequestsPerMinute {
 		logger.Warn("Rate limit exceeded",
 			logger.String("client_id", clientID),
 			logger.String("path", c.Request.URL.Path),
 			logger.Int("requests_count", len(client.requests)),
-			logger.Int("limit", rlm.config.RequestsPerMinute))
+			logger.Int("limit", config.RequestsPerMinute))
+
+		apiErr := models.RateLimitedError("Rate limit exceeded")
+		c.JSON(http.StatusTooManyRequests, models.ErrorResponse(apiErr, utils.GetRequestID(c)))
+		c.Abort()
 		return false
 	}
 
@@ -206,35 +226,35 @@ func (rlm *RateLimitMiddleware) checkBuiltinRateLimit(c *gin.Context, clientID s
 		logger.String("client_id", clientID),
 		logger.String("path", c.Request.URL.Path),
 		logger.Int("requests_count", len(client.requests)),
-		logger.Int("limit", rlm.config.RequestsPerMinute))
+		logger.Int("limit", config.RequestsPerMinute))
 
 	return true
 }
 
 // addRateLimitHeaders adds rate limit information to response headers
-func (rlm *RateLimitMiddleware) addRateLimitHeaders(c *gin.Context, clientID string) {
+func (rlm *RateLimitMiddleware) addRateLimitHeaders(c *gin.Context, clientID string, config *RateLimitConfig) {
 	// Add standard rate limit headers
-	c.Header("X-RateLimit-Limit", strconv.Itoa(rlm.config.RequestsPerMinute))
+	c.Header("X-RateLimit-Limit", strconv.Itoa(config.RequestsPerMinute))
 
 	// Calculate remaining requests for built-in limiter
-	remaining := rlm.config.RequestsPerMinute
-	resetTime := time.Now().Add(rlm.config.WindowSize)
+	remaining := config.RequestsPerMinute
+	resetTime := time.Now().Add(config.WindowSize)
 
-	if !rlm.config.UseAuthRateLimiter {
+	if !config.UseAuthRateLimiter {
 		// Get current request count for built-in limiter
 		rlm.clientsMutex.RLock()
 		if client, exists := rlm.clients[clientID]; exists {
 			client.mutex.Lock()
 			// Clean old requests for accurate count
 			now := time.Now()
-			cutoff := now.Add(-rlm.config.WindowSize)
+			cutoff := now.Add(-config.WindowSize)
 			validCount := 0
 			for _, reqTime := range client.requests {
 				if reqTime.After(cutoff) {
 					validCount++
 				}
 			}
-			remaining = rlm.config.RequestsPerMinute - validCount
+			remaining = config.RequestsPerMinute - validCount
 			if remaining < 0 {
 				remaining = 0
 			}
@@ -290,8 +310,8 @@ func (rlm *RateLimitMiddleware) extractAPIKey(c *gin.Context) string {
 }
 
 // shouldSkipPath checks if path should be skipped from rate limiting
-func (rlm *RateLimitMiddleware) shouldSkipPath(path string) bool {
-	for _, skipPath := range rlm.config.SkipPaths {
+func shouldSkipPath(config *RateLimitConfig, path string) bool {
+	for _, skipPath := range config.SkipPaths {
 		if path == skipPath {
 			return true
 		}
@@ -312,15 +332,25 @@ func maskAPIKey(apiKey string) string {
 	return apiKey[:4] + "***" + apiKey[len(apiKey)-4:]
 }
 
-// GetConfig returns rate limit configuration
-func (rlm *RateLimitMiddleware) GetConfig() *RateLimitConfig {
+// currentConfig returns current configuration snapshot, callers must not modify it
+func (rlm *RateLimitMiddleware) currentConfig() *RateLimitConfig {
+	rlm.configMutex.RLock()
+	defer rlm.configMutex.RUnlock()
 	return rlm.config
 }
 
-// UpdateConfig updates rate limit configuration
+// GetConfig returns copy of rate limit configuration
+func (rlm *RateLimitMiddleware) GetConfig() *RateLimitConfig {
+	return rlm.currentConfig().clone()
+}
+
+// UpdateConfig replaces rate limit configuration, safe to call while requests are served
 func (rlm *RateLimitMiddleware) UpdateConfig(config *RateLimitConfig) {
 	if config != nil {
-		rlm.config = config
+		rlm.configMutex.Lock()
+		rlm.config = config.clone()
+		rlm.configMutex.Unlock()
+
 		logger.Info("Rate limit middleware configuration updated",
 			logger.Bool("enabled", config.Enabled),
 			logger.Int("requests_per_minute", config.RequestsPerMinute),
@@ -330,7 +360,12 @@ func (rlm *RateLimitMiddleware) UpdateConfig(config *RateLimitConfig) {
 
 // AddSkipPath adds a path to skip rate limiting
 func (rlm *RateLimitMiddleware) AddSkipPath(path string) {
-	rlm.config.SkipPaths = append(rlm.config.SkipPaths, path)
+	rlm.configMutex.Lock()
+	defer rlm.configMutex.Unlock()
+
+	config := rlm.config.clone()
+	config.SkipPaths = append(config.SkipPaths, path)
+	rlm.config = config
 }
 
 // RateLimitInfo provides rate limit information for clients
@@ -343,14 +378,15 @@ type RateLimitInfo struct {
 
 // GetRateLimitInfo returns current rate limit information for client
 func (rlm *RateLimitMiddleware) GetRateLimitInfo(clientID string) *RateLimitInfo {
+	config := rlm.currentConfig()
 	info := &RateLimitInfo{
-		Limit:  rlm.config.RequestsPerMinute,
-		Window: rlm.config.WindowSize,
-		Reset:  time.Now().Add(rlm.config.WindowSize),
+		Limit:  config.RequestsPerMinute,
+		Window: config.WindowSize,
+		Reset:  time.Now().Add(config.WindowSize),
 	}
 
 	// If using auth rate limiter, try to get actual remaining count
-	if rlm.config.UseAuthRateLimiter && rlm.authComponent != nil {
+	if config.UseAuthRateLimiter && rlm.authComponent != nil {
 		if rateLimiter := rlm.authComponent.GetRateLimiter(); rateLimiter != nil {
 			// This would require extending the RateLimiter interface to get remaining count
 			// For now, set to unknown
@@ -364,7 +400,7 @@ func (rlm *RateLimitMiddleware) GetRateLimitInfo(clientID string) *RateLimitInfo
 // CleanupOldClients removes inactive clients to prevent memory leaks
 func (rlm *RateLimitMiddleware) CleanupOldClients() {
 	now := time.Now()
-	cleanupCutoff := now.Add(-rlm.config.WindowSize * 2) // Clean clients with no activity for 2 windows
+	cleanupCutoff := now.Add(-rlm.currentConfig().WindowSize * 2) // Clean clients with no activity for 2 windows
 
 	rlm.clientsMutex.Lock()
 	defer rlm.clientsMutex.Unlock()
@@ -393,7 +429,7 @@ func (rlm *RateLimitMiddleware) CleanupOldClients() {
 // StartCleanupWorker starts a background worker to clean up old clients
 func (rlm *RateLimitMiddleware) StartCleanupWorker() {
 	go func() {
-		ticker := time.NewTicker(rlm.config.WindowSize)
+		ticker := time.NewTicker(rlm.currentConfig().WindowSize)
 		defer ticker.Stop()
 
 		for range ticker.C {
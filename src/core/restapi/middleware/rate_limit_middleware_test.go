@@ -0,0 +1,146 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// newRateLimitedRouter creates router with rate limit middleware and one open route
+func newRateLimitedRouter(rlm *RateLimitMiddleware) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(rlm.Handler())
+	router.GET("/api/v1/jobs", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+// TestRateLimitUpdateConfigDuringRequests must be run with -race to detect unsynchronized config access
+func TestRateLimitUpdateConfigDuringRequests(t *testing.T) {
+	rlm := NewRateLimitMiddleware(&RateLimitConfig{
+		Enabled:           true,
+		RequestsPerMinute: 1000000,
+		WindowSize:        time.Minute,
+	}, nil)
+	router := newRateLimitedRouter(rlm)
+
+	stop := make(chan struct{})
+	var writers sync.WaitGroup
+
+	writers.Add(2)
+	go func() {
+		defer writers.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			rlm.UpdateConfig(&RateLimitConfig{
+				Enabled:           i%2 == 0,
+				RequestsPerMinute: 1000000 + i,
+				WindowSize:        time.Minute,
+				SkipPaths:         []string{"/health"},
+			})
+		}
+	}()
+	go func() {
+		defer writers.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			rlm.AddSkipPath(fmt.Sprintf("/skip/%d", i))
+		}
+	}()
+
+	var requests sync.WaitGroup
+	for worker := 0; worker < 8; worker++ {
+		requests.Add(1)
+		go func(worker int) {
+			defer requests.Done()
+			for i := 0; i < 200; i++ {
+				req := httptest.NewRequest(http.MethodGet, "/api/v1/jobs", nil)
+				req.RemoteAddr = fmt.Sprintf("10.0.0.%d:1234", worker)
+				rec := httptest.NewRecorder()
+				router.ServeHTTP(rec, req)
+				if rec.Code != http.StatusOK {
+					t.Errorf("unexpected status %d", rec.Code)
+					return
+				}
+				_ = rlm.GetConfig()
+			}
+		}(worker)
+	}
+
+	requests.Wait()
+	close(stop)
+	writers.Wait()
+}
+
+func TestRateLimitUpdateConfigAppliesToNextRequest(t *testing.T) {
+	rlm := NewRateLimitMiddleware(&RateLimitConfig{
+		Enabled:           true,
+		RequestsPerMinute: 1,
+		WindowSize:        time.Minute,
+	}, nil)
+	router := newRateLimitedRouter(rlm)
+
+	serve := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/jobs", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	if code := serve(); code != http.StatusOK {
+		t.Fatalf("first request: status %d, want 200", code)
+	}
+	if code := serve(); code != http.StatusTooManyRequests {
+		t.Fatalf("second request: status %d, want 429", code)
+	}
+
+	rlm.AddSkipPath("/api/v1/jobs")
+	if code := serve(); code != http.StatusOK {
+		t.Fatalf("skipped path: status %d, want 200", code)
+	}
+
+	config := rlm.GetConfig()
+	config.SkipPaths = nil
+	config.Enabled = false
+	rlm.UpdateConfig(config)
+	if code := serve(); code != http.StatusOK {
+		t.Fatalf("disabled limiter: status %d, want 200", code)
+	}
+}
+
+func TestRateLimitGetConfigReturnsCopy(t *testing.T) {
+	rlm := NewRateLimitMiddleware(DefaultRateLimitConfig(), nil)
+
+	config := rlm.GetConfig()
+	config.SkipPaths[0] = "/changed"
+	config.RequestsPerMinute = 1
+
+	current := rlm.GetConfig()
+	if current.SkipPaths[0] != "/health" || current.RequestsPerMinute != 100 {
+		t.Fatalf("published config was mutated through copy: %+v", current)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/restapi/models"
+	"atom-engine/src/core/restapi/utils"
+)
+
+// RecoveryMiddleware recovers panics of request handling, logs them through project logger
+// with request ID and stack and answers 500 in standard error format
+type RecoveryMiddleware struct{}
+
+// NewRecoveryMiddleware creates new recovery middleware
+func NewRecoveryMiddleware() *RecoveryMiddleware {
+	return &RecoveryMiddleware{}
+}
+
+// Handler returns gin handler that recovers panics of later handlers.
+// http.ErrAbortHandler is passed on, net/http uses it to abort response silently
+func (rm *RecoveryMiddleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+			rm.handlePanic(c, recovered)
+		}()
+		c.Next()
+	}
+}
+
+// handlePanic logs recovered panic and writes 500 response unless client is gone or response has started
+func (rm *RecoveryMiddleware) handlePanic(c *gin.Context, recovered interface{}) {
+	requestID := utils.GetRequestID(c)
+	fields := []logger.Field{
+		logger.String("type", "panic"),
+		logger.String("method", c.Request.Method),
+		logger.String("path", c.Request.URL.Path),
+		logger.String("client_ip", c.ClientIP()),
+		logger.String("request_id", requestID),
+		logger.String("panic", fmt.Sprint(recovered)),
+	}
+
+	if err, ok := recovered.(error); ok && isBrokenConnection(err) {
+		logger.Warn("HTTP client connection lost while handling request", fields...)
+		c.Abort()
+		return
+	}
+
+	logger.Error("Panic recovered in HTTP handler", append(fields, logger.String("stack", string(debug.Stack())))...)
+
+	if c.Writer.Written() {
+		c.Abort()
+		return
+	}
+	c.AbortWithStatusJSON(http.StatusInternalServerError,
+		models.ErrorResponse(models.InternalServerError("Internal server error"), requestID))
+}
+
+// isBrokenConnection reports whether panic was caused by client closing connection
+func isBrokenConnection(err error) bool {
+	return errors.Is(err, syscall.EPIPE) || errors.Is(err, syscall.ECONNRESET)
+}
@@ -0,0 +1,33 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"atom-engine/src/core/restapi/utils"
+)
+
+// RequestIDMiddleware assigns request ID to every request
+type RequestIDMiddleware struct{}
+
+// NewRequestIDMiddleware creates new request ID middleware
+func NewRequestIDMiddleware() *RequestIDMiddleware {
+	return &RequestIDMiddleware{}
+}
+
+// Handler returns gin handler that accepts or generates X-Request-ID,
+// stores it in gin and request context and returns it in response header.
+// Request context carries ID to component messages and gRPC metadata
+func (rm *RequestIDMiddleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		utils.AssignRequestID(c)
+		c.Next()
+	}
+}
@@ -0,0 +1,84 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	coremodels "atom-engine/src/core/models"
+	"atom-engine/src/core/restapi/utils"
+)
+
+// newRequestIDRouter creates router echoing request ID seen by handler in response body
+func newRequestIDRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(NewRequestIDMiddleware().Handler())
+	router.GET("/api/v1/jobs", func(c *gin.Context) {
+		contextID := coremodels.RequestIDFromContext(c.Request.Context())
+		if contextID != utils.GetRequestID(c) {
+			c.String(http.StatusInternalServerError, "request context carries %s", contextID)
+			return
+		}
+		c.String(http.StatusOK, utils.GetRequestID(c))
+	})
+	return router
+}
+
+func TestRequestIDMiddlewareAssignsUniqueIDsToConcurrentRequests(t *testing.T) {
+	router := newRequestIDRouter()
+
+	const requests = 500
+	ids := make([]string, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/jobs", nil))
+			if w.Code != http.StatusOK {
+				t.Errorf("status %d: %s", w.Code, w.Body.String())
+				return
+			}
+			if header := w.Header().Get(utils.RequestIDHeader); header != w.Body.String() {
+				t.Errorf("response header %q differs from handler request ID %q", header, w.Body.String())
+			}
+			ids[i] = w.Body.String()
+		}(i)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool, requests)
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			t.Fatalf("request ID %q empty or reused", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestRequestIDMiddlewareEchoesClientID(t *testing.T) {
+	router := newRequestIDRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/jobs", nil)
+	req.Header.Set(utils.RequestIDHeader, "client-42")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Body.String() != "client-42" || w.Header().Get(utils.RequestIDHeader) != "client-42" {
+		t.Fatalf("client request ID not kept: body %q, header %q",
+			w.Body.String(), w.Header().Get(utils.RequestIDHeader))
+	}
+}
@@ -0,0 +1,70 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/restapi/utils"
+	"atom-engine/src/core/tracing"
+)
+
+// TracingMiddleware starts server span for every request
+type TracingMiddleware struct{}
+
+// NewTracingMiddleware creates new tracing middleware
+func NewTracingMiddleware() *TracingMiddleware {
+	return &TracingMiddleware{}
+}
+
+// Handler returns gin handler that continues trace from traceparent header or starts new one.
+// Span is stored in request context, so component messages and gRPC calls become its children.
+// Must run after request ID middleware, span is tagged with request ID
+func (tm *TracingMiddleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := tracing.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		ctx, span := tracing.Tracer().Start(ctx, c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Request.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", c.Request.URL.Path),
+				attribute.String("client.address", c.ClientIP()),
+				attribute.String("atom.request_id", utils.GetRequestID(c)),
+			))
+		defer span.End()
+
+		logger.Debug("HTTP request traced",
+			logger.String("method", c.Request.Method),
+			logger.String("path", c.Request.URL.Path),
+			logger.String("request_id", utils.GetRequestID(c)),
+			logger.String("trace_id", tracing.TraceIDFromContext(ctx)))
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
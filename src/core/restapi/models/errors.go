@@ -11,6 +11,7 @@ package models
 import (
 	"fmt"
 	"net/http"
+	"time"
 )
 
 // Error codes for API responses
@@ -20,7 +21,17 @@ const (
 	ErrorCodeBadRequest      = "BAD_REQUEST"
 	ErrorCodeNotFound        = "NOT_FOUND"
 	ErrorCodeConflict        = "CONFLICT"
+	ErrorCodeInvalidState    = "INVALID_STATE"
+	ErrorCodeTimeout         = "TIMEOUT"
 	ErrorCodeValidationError = "VALIDATION_ERROR"
+	ErrorCodePayloadTooLarge = "PAYLOAD_TOO_LARGE"
+
+	// Availability errors
+	ErrorCodeComponentNotReady    = "COMPONENT_NOT_READY"
+	ErrorCodeComponentUnavailable = "COMPONENT_UNAVAILABLE"
+	ErrorCodeParserBusy           = "PARSER_BUSY"
+	ErrorCodeServerOverloaded     = "SERVER_OVERLOADED"
+	ErrorCodeRequestTimeout       = "REQUEST_TIMEOUT"
 
 	// Authentication errors
 	ErrorCodeUnauthorized            = "UNAUTHORIZED"
@@ -42,6 +53,7 @@ const (
 	ErrorCodeProcessNotFound  = "PROCESS_NOT_FOUND"
 	ErrorCodeProcessFailed    = "PROCESS_FAILED"
 	ErrorCodeInstanceNotFound = "INSTANCE_NOT_FOUND"
+	ErrorCodeInstanceArchived = "INSTANCE_ARCHIVED"
 
 	// Job errors
 	ErrorCodeJobNotFound    = "JOB_NOT_FOUND"
@@ -56,14 +68,16 @@ const (
 	// Message errors
 	ErrorCodeMessageFailed     = "MESSAGE_FAILED"
 	ErrorCodeCorrelationFailed = "CORRELATION_FAILED"
+	ErrorCodeMessageBufferFull = "MESSAGE_BUFFER_FULL"
 
 	// Expression errors
 	ErrorCodeExpressionError = "EXPRESSION_ERROR"
 	ErrorCodeSyntaxError     = "SYNTAX_ERROR"
 
 	// Storage errors
-	ErrorCodeStorageError  = "STORAGE_ERROR"
-	ErrorCodeDatabaseError = "DATABASE_ERROR"
+	ErrorCodeStorageError     = "STORAGE_ERROR"
+	ErrorCodeDatabaseError    = "DATABASE_ERROR"
+	ErrorCodeChecksumMismatch = "CHECKSUM_MISMATCH"
 
 	// BPMN errors
 	ErrorCodeBPMNParseError      = "BPMN_PARSE_ERROR"
@@ -136,15 +150,31 @@ func HTTPStatusFromErrorCode(code string) int {
 		ErrorCodeWorkerNotFound:
 		return http.StatusNotFound
 
-	case ErrorCodeConflict, ErrorCodeResourceConflict:
+	case ErrorCodeConflict, ErrorCodeResourceConflict, ErrorCodeInvalidState:
 		return http.StatusConflict
 
+	case ErrorCodeTimeout:
+		return http.StatusGatewayTimeout
+
+	case ErrorCodeInstanceArchived:
+		return http.StatusGone
+
+	case ErrorCodeChecksumMismatch:
+		return http.StatusUnprocessableEntity
+
 	case ErrorCodeResourceLocked:
 		return http.StatusLocked
 
-	case ErrorCodeRateLimited:
+	case ErrorCodeRateLimited, ErrorCodeMessageBufferFull:
 		return http.StatusTooManyRequests
 
+	case ErrorCodePayloadTooLarge:
+		return http.StatusRequestEntityTooLarge
+
+	case ErrorCodeComponentNotReady, ErrorCodeComponentUnavailable, ErrorCodeParserBusy,
+		ErrorCodeServerOverloaded, ErrorCodeRequestTimeout:
+		return http.StatusServiceUnavailable
+
 	case ErrorCodeInternalError, ErrorCodeProcessFailed, ErrorCodeJobFailed,
 		ErrorCodeTimerFailed, ErrorCodeMessageFailed, ErrorCodeCorrelationFailed,
 		ErrorCodeExpressionError, ErrorCodeStorageError, ErrorCodeDatabaseError:
@@ -180,10 +210,64 @@ func ConflictError(message string) *APIError {
 	return NewAPIError(ErrorCodeConflict, message)
 }
 
+func InvalidStateError(message string) *APIError {
+	return NewAPIError(ErrorCodeInvalidState, message)
+}
+
+func TimeoutError(message string) *APIError {
+	return NewAPIError(ErrorCodeTimeout, message)
+}
+
 func RateLimitedError(message string) *APIError {
 	return NewAPIError(ErrorCodeRateLimited, message)
 }
 
+func PayloadTooLargeError(message string, details map[string]interface{}) *APIError {
+	return NewAPIErrorWithDetails(ErrorCodePayloadTooLarge, message, details)
+}
+
+func ComponentNotReadyError(component string) *APIError {
+	return NewAPIErrorWithDetails(
+		ErrorCodeComponentNotReady,
+		fmt.Sprintf("Component not ready: %s", component),
+		map[string]interface{}{"component": component},
+	)
+}
+
+func ComponentUnavailableError(component string, retryAfter time.Duration) *APIError {
+	return NewAPIErrorWithDetails(
+		ErrorCodeComponentUnavailable,
+		fmt.Sprintf("Component temporarily unavailable: %s", component),
+		map[string]interface{}{
+			"component":      component,
+			"retry_after_ms": retryAfter.Milliseconds(),
+		},
+	)
+}
+
+func ServerOverloadedError(maxInFlight int, retryAfter time.Duration) *APIError {
+	return NewAPIErrorWithDetails(
+		ErrorCodeServerOverloaded,
+		"Server is overloaded, too many requests in flight",
+		map[string]interface{}{
+			"max_in_flight":  maxInFlight,
+			"retry_after_ms": retryAfter.Milliseconds(),
+		},
+	)
+}
+
+func RequestTimeoutError(timeout time.Duration) *APIError {
+	return NewAPIErrorWithDetails(
+		ErrorCodeRequestTimeout,
+		fmt.Sprintf("Request was not handled within %s", timeout),
+		map[string]interface{}{"timeout_ms": timeout.Milliseconds()},
+	)
+}
+
+func ParserBusyError(message string) *APIError {
+	return NewAPIError(ErrorCodeParserBusy, message)
+}
+
 func ProcessNotFoundError(processID string) *APIError {
 	return NewAPIErrorWithDetails(
 		ErrorCodeProcessNotFound,
@@ -192,6 +276,18 @@ func ProcessNotFoundError(processID string) *APIError {
 	)
 }
 
+func InstanceArchivedError(instanceID, location string, archivedAt time.Time) *APIError {
+	return NewAPIErrorWithDetails(
+		ErrorCodeInstanceArchived,
+		"Process instance has been archived",
+		map[string]interface{}{
+			"instance_id":      instanceID,
+			"archive_location": location,
+			"archived_at":      archivedAt.Format(time.RFC3339),
+		},
+	)
+}
+
 func JobNotFoundError(jobKey string) *APIError {
 	return NewAPIErrorWithDetails(
 		ErrorCodeJobNotFound,
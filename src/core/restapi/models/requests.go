@@ -10,7 +10,10 @@ package models
 
 import (
 	"encoding/json"
+	"fmt"
 	"time"
+
+	coremodels "atom-engine/src/core/models"
 )
 
 // PaginationParams represents pagination parameters
@@ -27,14 +30,35 @@ func GetDefaultPagination() PaginationParams {
 	}
 }
 
+// Sort orders accepted by sort_order query parameter
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// SortParams represents sorting parameters of list endpoints
+type SortParams struct {
+	By    string `json:"by"`
+	Order string `json:"order" enums:"asc,desc"`
+}
+
+// Descending reports whether items are sorted in descending order
+func (s SortParams) Descending() bool {
+	return s.Order == SortOrderDesc
+}
+
 // Process Management Requests
 
 // StartProcessRequest represents process start request
 type StartProcessRequest struct {
 	ProcessKey string                 `json:"process_key" binding:"required"`
 	Version    *int32                 `json:"version,omitempty"`
-	Variables  map[string]interface{} `json:"variables,omitempty"`
+	Variables  coremodels.VariableMap `json:"variables,omitempty"`
 	TenantID   string                 `json:"tenant_id,omitempty"`
+	// Priority is added to priority of jobs created in instance, higher is activated first
+	Priority int `json:"priority,omitempty"`
+	// SLAMs is time in milliseconds instance must finish in, overrides SLA of process definition
+	SLAMs int64 `json:"sla_ms,omitempty"`
 }
 
 // ListProcessInstancesRequest represents process instances list request
@@ -50,6 +74,64 @@ type CancelProcessRequest struct {
 	Reason string `json:"reason,omitempty"`
 }
 
+// RestartProcessRequest represents process instance restart request
+type RestartProcessRequest struct {
+	// ElementID is element initial token of new instance is placed at
+	ElementID string `json:"element_id" binding:"required"`
+}
+
+// ModifyProcessRequest represents process instance modification request
+type ModifyProcessRequest struct {
+	Instructions []ModificationInstruction `json:"instructions" binding:"required"`
+}
+
+// ModificationInstruction represents one change of process instance modification.
+// cancel_token uses token_id or element_id, start_token uses element_id and variables
+// local to new token, set_variables uses variables as JSON merge patch of instance variables
+type ModificationInstruction struct {
+	Type      string                 `json:"type" binding:"required" enums:"cancel_token,start_token,set_variables"`
+	ElementID string                 `json:"element_id,omitempty"`
+	TokenID   string                 `json:"token_id,omitempty"`
+	Variables coremodels.VariableMap `json:"variables,omitempty"`
+}
+
+// MigrateProcessesRequest represents migration of running instances to another process definition version.
+// Elements missing from element_mappings keep their IDs
+type MigrateProcessesRequest struct {
+	ProcessID       string            `json:"process_id" binding:"required"`
+	SourceVersion   int               `json:"source_version" binding:"required"`
+	TargetVersion   int               `json:"target_version" binding:"required"`
+	ElementMappings map[string]string `json:"element_mappings,omitempty"`
+	Filter          MigrationFilter   `json:"filter,omitempty"`
+	DryRun          bool              `json:"dry_run,omitempty"`
+}
+
+// MigrationFilter selects instances of source version to migrate, empty fields match all running instances
+type MigrationFilter struct {
+	InstanceIDs []string `json:"instance_ids,omitempty"`
+	BusinessKey string   `json:"business_key,omitempty"`
+	// ElementID selects instances holding token at source element
+	ElementID string `json:"element_id,omitempty"`
+}
+
+// BatchStartProcessesRequest represents batch process instance start request
+type BatchStartProcessesRequest struct {
+	Items []BatchStartProcessItem `json:"items" binding:"required"`
+}
+
+// BatchStartProcessItem represents one process instance of batch start request
+type BatchStartProcessItem struct {
+	ProcessKey  string                 `json:"process_key"`
+	Variables   coremodels.VariableMap `json:"variables,omitempty"`
+	BusinessKey string                 `json:"business_key,omitempty"`
+}
+
+// BulkCancelProcessesRequest represents bulk process cancellation request
+type BulkCancelProcessesRequest struct {
+	InstanceIDs []string `json:"instance_ids" binding:"required"`
+	Reason      string   `json:"reason,omitempty"`
+}
+
 // Timer Management Requests
 
 // AddTimerRequest represents timer creation request
@@ -58,7 +140,7 @@ type AddTimerRequest struct {
 	Duration     string `json:"duration" binding:"required"`
 	CallbackData string `json:"callback_data,omitempty"`
 	Repeating    bool   `json:"repeating,omitempty"`
-	Interval     string `json:"interval,omitempty"`
+	Interval     string `json:"interval,omitempty"` // ISO 8601 repeating interval or cron expression
 }
 
 // ListTimersRequest represents timers list request
@@ -76,7 +158,7 @@ type CreateJobRequest struct {
 	ElementID         string                 `json:"element_id" binding:"required"`
 	ElementInstanceID string                 `json:"element_instance_id,omitempty"`
 	CustomHeaders     map[string]string      `json:"custom_headers,omitempty"`
-	Variables         map[string]interface{} `json:"variables,omitempty"`
+	Variables         coremodels.VariableMap `json:"variables,omitempty"`
 	Retries           int32                  `json:"retries,omitempty"`
 	TimeoutMs         int64                  `json:"timeout_ms,omitempty"`
 }
@@ -88,25 +170,46 @@ type ActivateJobsRequest struct {
 	MaxJobs        int32    `json:"max_jobs,omitempty"`
 	TimeoutMs      int64    `json:"timeout_ms,omitempty"`
 	FetchVariables []string `json:"fetch_variables,omitempty"`
+	// Fields of each activated job to return, dotted names select nested values, e.g. variables.order.id
+	Fields []string `json:"fields,omitempty"`
 }
 
 // CompleteJobRequest represents job completion request
 type CompleteJobRequest struct {
-	Variables map[string]interface{} `json:"variables,omitempty"`
+	Variables coremodels.VariableMap `json:"variables,omitempty"`
+}
+
+// BulkCompleteJobsRequest represents bulk job completion request
+type BulkCompleteJobsRequest struct {
+	Jobs []BulkCompleteJobItem `json:"jobs" binding:"required"`
+}
+
+// BulkCompleteJobItem represents single job in bulk completion request
+type BulkCompleteJobItem struct {
+	JobKey    string                 `json:"job_key"`
+	Variables coremodels.VariableMap `json:"variables,omitempty"`
+}
+
+// BatchGetJobsRequest represents request for current state of several jobs
+type BatchGetJobsRequest struct {
+	Keys []string `json:"keys" binding:"required"` // Job IDs or numeric keys
 }
 
 // FailJobRequest represents job failure request
 type FailJobRequest struct {
-	Retries      int32  `json:"retries" binding:"required"`
+	// Retries is a pointer so that required check accepts zero, which means final failure
+	Retries      *int32 `json:"retries" binding:"required"`
 	ErrorMessage string `json:"error_message,omitempty"`
 	BackoffMs    int64  `json:"backoff_ms,omitempty"`
+	// ErrorCode is thrown as BPMN error when no retries are left, so error boundary may catch the failure
+	ErrorCode string `json:"error_code,omitempty"`
 }
 
 // ThrowErrorRequest represents job error throwing request
 type ThrowErrorRequest struct {
 	ErrorCode    string                 `json:"error_code" binding:"required"`
 	ErrorMessage string                 `json:"error_message,omitempty"`
-	Variables    map[string]interface{} `json:"variables,omitempty"`
+	Variables    coremodels.VariableMap `json:"variables,omitempty"`
 }
 
 // ListJobsRequest represents jobs list request
@@ -139,8 +242,11 @@ type PublishMessageRequest struct {
 	TenantID       string                 `json:"tenant_id,omitempty"`
 	MessageName    string                 `json:"message_name" binding:"required"`
 	CorrelationKey string                 `json:"correlation_key,omitempty"`
-	Variables      map[string]interface{} `json:"variables,omitempty"`
+	Variables      coremodels.VariableMap `json:"variables,omitempty"`
 	TTLSeconds     int64                  `json:"ttl_seconds,omitempty"`
+	// BroadcastAcrossTenants lets message correlate with subscriptions of any tenant,
+	// requires cross_tenant_messages permission
+	BroadcastAcrossTenants bool `json:"broadcast_across_tenants,omitempty"`
 }
 
 // ListMessagesRequest represents messages list request
@@ -154,10 +260,19 @@ type ListMessagesRequest struct {
 // EvaluateExpressionRequest represents expression evaluation request
 type EvaluateExpressionRequest struct {
 	Expression string                 `json:"expression" binding:"required"`
-	Context    map[string]interface{} `json:"context,omitempty"`
+	Context    coremodels.VariableMap `json:"context,omitempty"`
 	TenantID   string                 `json:"tenant_id,omitempty"`
 }
 
+// EvaluateUnaryTestRequest represents FEEL unary test evaluation request.
+// Test like "> 100", "[1..10]" or "\"a\",\"b\"" is checked against input, context resolves names used in test
+type EvaluateUnaryTestRequest struct {
+	Input    interface{}            `json:"input"`
+	Test     string                 `json:"test" binding:"required"`
+	Context  coremodels.VariableMap `json:"context,omitempty"`
+	TenantID string                 `json:"tenant_id,omitempty"`
+}
+
 // ValidateExpressionRequest represents expression validation request
 type ValidateExpressionRequest struct {
 	Expression string `json:"expression" binding:"required"`
@@ -244,6 +359,9 @@ func (r *StartProcessRequest) Validate() error {
 	if r.ProcessKey == "" {
 		return BadRequestError("process_key is required")
 	}
+	if r.SLAMs < 0 {
+		return BadRequestError("sla_ms cannot be negative")
+	}
 	return nil
 }
 
@@ -265,7 +383,7 @@ func (r *PublishMessageRequest) Validate() error {
 }
 
 func (r *FailJobRequest) Validate() error {
-	if r.Retries < 0 {
+	if *r.Retries < 0 {
 		return BadRequestError("retries cannot be negative")
 	}
 	return nil
@@ -297,3 +415,86 @@ func (r *UpdateJobTimeoutRequest) Validate() error {
 	}
 	return nil
 }
+
+// MaxBulkItems limits number of items in single bulk request
+const MaxBulkItems = 100
+
+func (r *BulkCancelProcessesRequest) Validate() error {
+	if len(r.InstanceIDs) == 0 {
+		return BadRequestError("instance_ids cannot be empty")
+	}
+	if len(r.InstanceIDs) > MaxBulkItems {
+		return BadRequestError(fmt.Sprintf("maximum %d instances allowed in bulk request", MaxBulkItems))
+	}
+	for i, id := range r.InstanceIDs {
+		if id == "" {
+			return BadRequestError(fmt.Sprintf("instance_ids[%d] cannot be empty", i))
+		}
+	}
+	return nil
+}
+
+func (r *MigrateProcessesRequest) Validate() error {
+	if r.SourceVersion <= 0 || r.TargetVersion <= 0 {
+		return BadRequestError("source_version and target_version must be positive")
+	}
+	if r.SourceVersion == r.TargetVersion {
+		return BadRequestError("source_version and target_version must differ")
+	}
+	for sourceID, targetID := range r.ElementMappings {
+		if sourceID == "" || targetID == "" {
+			return BadRequestError("element_mappings cannot contain empty element IDs")
+		}
+	}
+	if len(r.Filter.InstanceIDs) > MaxBulkItems {
+		return BadRequestError(fmt.Sprintf("maximum %d instances allowed in migration filter", MaxBulkItems))
+	}
+	for i, id := range r.Filter.InstanceIDs {
+		if id == "" {
+			return BadRequestError(fmt.Sprintf("filter.instance_ids[%d] cannot be empty", i))
+		}
+	}
+	return nil
+}
+
+func (r *BatchStartProcessesRequest) Validate() error {
+	if len(r.Items) == 0 {
+		return BadRequestError("items cannot be empty")
+	}
+	for i, item := range r.Items {
+		if item.ProcessKey == "" {
+			return BadRequestError(fmt.Sprintf("items[%d].process_key is required", i))
+		}
+	}
+	return nil
+}
+
+func (r *BulkCompleteJobsRequest) Validate() error {
+	if len(r.Jobs) == 0 {
+		return BadRequestError("jobs cannot be empty")
+	}
+	if len(r.Jobs) > MaxBulkItems {
+		return BadRequestError(fmt.Sprintf("maximum %d jobs allowed in bulk request", MaxBulkItems))
+	}
+	for i, job := range r.Jobs {
+		if job.JobKey == "" {
+			return BadRequestError(fmt.Sprintf("jobs[%d].job_key is required", i))
+		}
+	}
+	return nil
+}
+
+func (r *BatchGetJobsRequest) Validate() error {
+	if len(r.Keys) == 0 {
+		return BadRequestError("keys cannot be empty")
+	}
+	if len(r.Keys) > MaxBulkItems {
+		return BadRequestError(fmt.Sprintf("maximum %d keys allowed in batch request", MaxBulkItems))
+	}
+	for i, key := range r.Keys {
+		if key == "" {
+			return BadRequestError(fmt.Sprintf("keys[%d] cannot be empty", i))
+		}
+	}
+	return nil
+}
@@ -9,6 +9,7 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package models
 
 import (
+	"net/http"
 	"time"
 )
 
@@ -32,6 +33,7 @@ type PaginatedResponse struct {
 	Data       interface{}     `json:"data,omitempty"`
 	Error      *APIError       `json:"error,omitempty"`
 	Pagination *PaginationInfo `json:"pagination,omitempty"`
+	Sort       *SortParams     `json:"sort,omitempty"` // Effective sort of sortable list endpoints
 	Meta       ResponseMeta    `json:"meta"`
 }
 
@@ -82,6 +84,12 @@ func PaginatedSuccessResponse(data interface{}, pagination *PaginationInfo, requ
 	}
 }
 
+// WithSort sets effective sort echoed to client
+func (r *PaginatedResponse) WithSort(sort SortParams) *PaginatedResponse {
+	r.Sort = &sort
+	return r
+}
+
 // PaginatedErrorResponse creates error paginated API response
 func PaginatedErrorResponse(err *APIError, requestID string) *PaginatedResponse {
 	return &PaginatedResponse{
@@ -103,6 +111,16 @@ type StatusResponse struct {
 	IsHealthy bool      `json:"is_healthy"`
 }
 
+// VersionResponse represents build metadata of running engine
+type VersionResponse struct {
+	Version           string `json:"version"`
+	GitCommit         string `json:"git_commit"`
+	BuildTime         string `json:"build_time"`
+	GoVersion         string `json:"go_version"`
+	Platform          string `json:"platform"`
+	FeatureSetVersion string `json:"feature_set_version"`
+}
+
 // StatsResponse represents generic statistics response
 type StatsResponse struct {
 	TotalCount int                    `json:"total_count"`
@@ -134,6 +152,80 @@ type DeleteResponse struct {
 	Message string `json:"message,omitempty"`
 }
 
+// MultiStatusItem represents result of single item in bulk operation
+type MultiStatusItem struct {
+	ID     string    `json:"id"`
+	Status int       `json:"status"`
+	Error  *APIError `json:"error,omitempty"`
+}
+
+// MultiStatusResponse represents bulk operation result with per-item statuses
+type MultiStatusResponse struct {
+	Results   []MultiStatusItem `json:"results"`
+	Total     int               `json:"total"`
+	Succeeded int               `json:"succeeded"`
+	Failed    int               `json:"failed"`
+}
+
+// NewMultiStatusResponse creates empty multi-status response
+func NewMultiStatusResponse() *MultiStatusResponse {
+	return &MultiStatusResponse{
+		Results: []MultiStatusItem{},
+	}
+}
+
+// AddSuccess records successfully processed item
+func (r *MultiStatusResponse) AddSuccess(id string, status int) {
+	r.Results = append(r.Results, MultiStatusItem{ID: id, Status: status})
+	r.Total++
+	r.Succeeded++
+}
+
+// AddFailure records failed item with its error
+func (r *MultiStatusResponse) AddFailure(id string, err *APIError) {
+	r.Results = append(r.Results, MultiStatusItem{
+		ID:     id,
+		Status: HTTPStatusFromErrorCode(err.Code),
+		Error:  err,
+	})
+	r.Total++
+	r.Failed++
+}
+
+// HTTPStatus returns 200 when all items succeeded and 207 otherwise
+func (r *MultiStatusResponse) HTTPStatus() int {
+	if r.Failed == 0 {
+		return http.StatusOK
+	}
+	return http.StatusMultiStatus
+}
+
+// BatchStartResponse represents batch process instance start result
+// Instances started before failure of other items are kept, batch is never rolled back
+type BatchStartResponse struct {
+	BatchID     string                   `json:"batch_id"`
+	State       string                   `json:"state" example:"COMPLETED"`
+	Total       int                      `json:"total"`
+	Processed   int                      `json:"processed"`
+	Succeeded   int                      `json:"succeeded"`
+	Failed      int                      `json:"failed"`
+	Atomic      bool                     `json:"atomic"` // Always false, failed items do not roll back started ones
+	CreatedAt   int64                    `json:"created_at"`
+	CompletedAt int64                    `json:"completed_at,omitempty"`
+	Results     []BatchStartItemResponse `json:"results"`
+}
+
+// BatchStartItemResponse represents result of single batch start item
+type BatchStartItemResponse struct {
+	Index       int    `json:"index"`
+	ProcessKey  string `json:"process_key"`
+	BusinessKey string `json:"business_key,omitempty"`
+	Status      string `json:"status" example:"STARTED"`
+	// Set for started items and for failed items whose instance was created before failure
+	InstanceID string    `json:"instance_id,omitempty"`
+	Error      *APIError `json:"error,omitempty"`
+}
+
 // HealthResponse represents health check response
 type HealthResponse struct {
 	Status    string                 `json:"status"`
@@ -0,0 +1,502 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// operation is single annotated handler
+type operation struct {
+	method      string
+	path        string
+	funcName    string
+	summary     string
+	description string
+	tags        []string
+	accept      []string
+	produce     []string
+	params      []paramAnnotation
+	responses   []responseAnnotation
+	security    []string
+	file        *sourceFile
+}
+
+type paramAnnotation struct {
+	name        string
+	in          string
+	dataType    string
+	required    bool
+	description string
+	defaultVal  string
+}
+
+type responseAnnotation struct {
+	code        string
+	kind        string
+	dataType    string
+	description string
+}
+
+// generator collects annotated operations and referenced schemas
+type generator struct {
+	moduleRoot string
+	modulePath string
+	operations []*operation
+	resolver   *typeResolver
+}
+
+func newGenerator(dir string) (*generator, error) {
+	moduleRoot, modulePath, err := findModuleRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	gen := &generator{
+		moduleRoot: moduleRoot,
+		modulePath: modulePath,
+		resolver:   newTypeResolver(moduleRoot, modulePath),
+	}
+
+	err = filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == "openapi" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		return gen.parseFile(path)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(gen.operations, func(i, j int) bool {
+		if gen.operations[i].path != gen.operations[j].path {
+			return gen.operations[i].path < gen.operations[j].path
+		}
+		return gen.operations[i].method < gen.operations[j].method
+	})
+	return gen, nil
+}
+
+// parseFile extracts operations from function doc comments of file
+func (g *generator) parseFile(path string) error {
+	fileSet := token.NewFileSet()
+	file, err := parser.ParseFile(fileSet, path, nil, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	source := g.resolver.sourceFile(filepath.Dir(path), file)
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Doc == nil {
+			continue
+		}
+		op, err := parseOperation(fn.Doc.List)
+		if err != nil {
+			return fmt.Errorf("%s: %s: %w", path, fn.Name.Name, err)
+		}
+		if op == nil {
+			continue
+		}
+		op.funcName = fn.Name.Name
+		op.file = source
+		g.operations = append(g.operations, op)
+	}
+	return nil
+}
+
+// parseOperation parses swagger annotations, nil when function has no @Router
+func parseOperation(comments []*ast.Comment) (*operation, error) {
+	op := &operation{}
+	for _, comment := range comments {
+		line := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+		name, rest, _ := strings.Cut(line, " ")
+		rest = strings.TrimSpace(rest)
+
+		switch name {
+		case "@Summary":
+			op.summary = rest
+		case "@Description":
+			op.description = joinText(op.description, rest)
+		case "@Tags":
+			op.tags = splitList(rest)
+		case "@Accept":
+			op.accept = append(op.accept, splitList(rest)...)
+		case "@Produce":
+			op.produce = append(op.produce, splitList(rest)...)
+		case "@Security":
+			op.security = append(op.security, rest)
+		case "@Param":
+			param, err := parseParam(rest)
+			if err != nil {
+				return nil, err
+			}
+			op.params = append(op.params, param)
+		case "@Success", "@Failure":
+			response, err := parseResponse(rest)
+			if err != nil {
+				return nil, err
+			}
+			op.responses = append(op.responses, response)
+		case "@Router":
+			fields := strings.Fields(rest)
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("invalid @Router annotation %q", rest)
+			}
+			op.path = fields[0]
+			op.method = strings.ToUpper(strings.Trim(fields[1], "[]"))
+		}
+	}
+
+	if op.path == "" {
+		return nil, nil
+	}
+	return op, nil
+}
+
+// parseParam parses "name in type required "description" default(value)"
+func parseParam(text string) (paramAnnotation, error) {
+	head, description, attributes := splitQuoted(text)
+	fields := strings.Fields(head)
+	if len(fields) != 4 {
+		return paramAnnotation{}, fmt.Errorf("invalid @Param annotation %q", text)
+	}
+
+	param := paramAnnotation{
+		name:        fields[0],
+		in:          fields[1],
+		dataType:    fields[2],
+		required:    fields[3] == "true",
+		description: description,
+	}
+	if start := strings.Index(attributes, "default("); start >= 0 {
+		value := attributes[start+len("default("):]
+		if end := strings.Index(value, ")"); end >= 0 {
+			param.defaultVal = value[:end]
+		}
+	}
+	return param, nil
+}
+
+// parseResponse parses "code {kind} type "description""
+func parseResponse(text string) (responseAnnotation, error) {
+	head, description, _ := splitQuoted(text)
+	fields := strings.Fields(head)
+	if len(fields) < 1 {
+		return responseAnnotation{}, fmt.Errorf("invalid response annotation %q", text)
+	}
+
+	response := responseAnnotation{code: fields[0], description: description}
+	if len(fields) >= 3 {
+		response.kind = strings.Trim(fields[1], "{}")
+		response.dataType = strings.Join(fields[2:], "")
+	}
+	return response, nil
+}
+
+// splitQuoted splits text into part before quoted description, description and remainder
+func splitQuoted(text string) (string, string, string) {
+	start := strings.Index(text, `"`)
+	if start < 0 {
+		return text, "", ""
+	}
+	end := strings.Index(text[start+1:], `"`)
+	if end < 0 {
+		return text[:start], text[start+1:], ""
+	}
+	end += start + 1
+	return text[:start], text[start+1 : end], text[end+1:]
+}
+
+func splitList(text string) []string {
+	var items []string
+	for _, item := range strings.Split(text, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func joinText(current, next string) string {
+	if current == "" {
+		return next
+	}
+	return current + "\n" + next
+}
+
+func (g *generator) operationKeys() []string {
+	keys := make([]string, 0, len(g.operations))
+	for _, op := range g.operations {
+		keys = append(keys, operationKey(op.method, op.path))
+	}
+	return keys
+}
+
+// build renders OpenAPI 3 document
+// Server URL, security requirements and version are set by engine at runtime
+func (g *generator) build() ([]byte, error) {
+	paths := make(map[string]map[string]interface{})
+	operationIDs := make(map[string]bool)
+
+	for _, op := range g.operations {
+		rendered, err := g.renderOperation(op)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", op.method, op.path, err)
+		}
+
+		operationID := lowerFirst(op.funcName)
+		if operationIDs[operationID] {
+			operationID = lowerFirst(op.file.pkgName) + op.funcName
+		}
+		for suffix := 2; operationIDs[operationID]; suffix++ {
+			operationID = lowerFirst(op.funcName) + strconv.Itoa(suffix)
+		}
+		operationIDs[operationID] = true
+		rendered["operationId"] = operationID
+
+		if paths[op.path] == nil {
+			paths[op.path] = make(map[string]interface{})
+		}
+		key := strings.ToLower(op.method)
+		if _, exists := paths[op.path][key]; exists {
+			return nil, fmt.Errorf("duplicate annotation for %s %s", op.method, op.path)
+		}
+		paths[op.path][key] = rendered
+	}
+
+	document := map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "Atom Engine REST API",
+			"description": "REST API of Atom Engine BPMN process engine",
+			"version":     "dev",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": g.resolver.schemas,
+			"securitySchemes": map[string]interface{}{
+				"ApiKeyAuth": map[string]interface{}{
+					"type":        "http",
+					"scheme":      "bearer",
+					"description": "API key passed as `Authorization: Bearer <api-key>`",
+				},
+			},
+		},
+	}
+
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func (g *generator) renderOperation(op *operation) (map[string]interface{}, error) {
+	rendered := map[string]interface{}{}
+	if op.summary != "" {
+		rendered["summary"] = op.summary
+	}
+	if op.description != "" {
+		rendered["description"] = op.description
+	}
+	if len(op.tags) > 0 {
+		rendered["tags"] = op.tags
+	}
+	if len(op.security) > 0 {
+		var security []map[string][]string
+		for _, name := range op.security {
+			security = append(security, map[string][]string{name: {}})
+		}
+		rendered["security"] = security
+	}
+
+	var parameters []map[string]interface{}
+	formProperties := map[string]interface{}{}
+	var formRequired []string
+	for _, param := range op.params {
+		switch param.in {
+		case "body":
+			schema, err := g.resolver.schemaFor(op.file, param.dataType)
+			if err != nil {
+				return nil, err
+			}
+			content := map[string]interface{}{}
+			for _, mediaType := range mediaTypes(op.accept) {
+				content[mediaType] = map[string]interface{}{"schema": schema}
+			}
+			body := map[string]interface{}{"content": content, "required": param.required}
+			if param.description != "" {
+				body["description"] = param.description
+			}
+			rendered["requestBody"] = body
+		case "formData":
+			schema := primitiveSchema(param.dataType)
+			if param.description != "" {
+				schema["description"] = param.description
+			}
+			formProperties[param.name] = schema
+			if param.required {
+				formRequired = append(formRequired, param.name)
+			}
+		default:
+			schema := primitiveSchema(param.dataType)
+			if param.defaultVal != "" {
+				schema["default"] = typedDefault(schema, param.defaultVal)
+			}
+			parameter := map[string]interface{}{
+				"name":     param.name,
+				"in":       param.in,
+				"required": param.required || param.in == "path",
+				"schema":   schema,
+			}
+			if param.description != "" {
+				parameter["description"] = param.description
+			}
+			parameters = append(parameters, parameter)
+		}
+	}
+	if len(parameters) > 0 {
+		rendered["parameters"] = parameters
+	}
+	if len(formProperties) > 0 {
+		schema := map[string]interface{}{"type": "object", "properties": formProperties}
+		if len(formRequired) > 0 {
+			schema["required"] = formRequired
+		}
+		rendered["requestBody"] = map[string]interface{}{
+			"required": len(formRequired) > 0,
+			"content": map[string]interface{}{
+				"multipart/form-data": map[string]interface{}{"schema": schema},
+			},
+		}
+	}
+
+	responses := map[string]interface{}{}
+	for _, response := range op.responses {
+		code, err := strconv.Atoi(response.code)
+		if err != nil {
+			return nil, fmt.Errorf("invalid response code %q", response.code)
+		}
+		description := response.description
+		if description == "" {
+			description = http.StatusText(code)
+		}
+		rendered := map[string]interface{}{"description": description}
+		if response.dataType != "" {
+			schema, err := g.resolver.schemaFor(op.file, response.dataType)
+			if err != nil {
+				return nil, err
+			}
+			if response.kind == "array" {
+				schema = map[string]interface{}{"type": "array", "items": schema}
+			}
+			content := map[string]interface{}{}
+			for _, mediaType := range mediaTypes(op.produce) {
+				content[mediaType] = map[string]interface{}{"schema": schema}
+			}
+			rendered["content"] = content
+		}
+		responses[response.code] = rendered
+	}
+	if len(responses) == 0 {
+		responses["200"] = map[string]interface{}{"description": "OK"}
+	}
+	rendered["responses"] = responses
+
+	return rendered, nil
+}
+
+// mediaTypes expands swag short names into MIME types
+func mediaTypes(names []string) []string {
+	if len(names) == 0 {
+		return []string{"application/json"}
+	}
+	types := make([]string, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case "json":
+			types = append(types, "application/json")
+		case "xml":
+			types = append(types, "application/xml")
+		case "plain":
+			types = append(types, "text/plain")
+		case "html":
+			types = append(types, "text/html")
+		case "mpfd":
+			types = append(types, "multipart/form-data")
+		default:
+			types = append(types, name)
+		}
+	}
+	return types
+}
+
+// primitiveSchema maps annotation type of non-body parameter
+func primitiveSchema(dataType string) map[string]interface{} {
+	switch dataType {
+	case "int", "integer", "int32", "int64":
+		return map[string]interface{}{"type": "integer"}
+	case "bool", "boolean":
+		return map[string]interface{}{"type": "boolean"}
+	case "number", "float", "float64":
+		return map[string]interface{}{"type": "number"}
+	case "file":
+		return map[string]interface{}{"type": "string", "format": "binary"}
+	default:
+		return map[string]interface{}{"type": "string"}
+	}
+}
+
+func typedDefault(schema map[string]interface{}, value string) interface{} {
+	switch schema["type"] {
+	case "integer":
+		if parsed, err := strconv.Atoi(value); err == nil {
+			return parsed
+		}
+	case "boolean":
+		if parsed, err := strconv.ParseBool(value); err == nil {
+			return parsed
+		}
+	case "number":
+		if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+			return parsed
+		}
+	}
+	return value
+}
+
+func lowerFirst(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
@@ -0,0 +1,159 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+// Command gen builds OpenAPI specification from swagger annotations of REST handlers
+// With -check it fails when registered routes and annotations diverge or spec is stale
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"atom-engine/src/core/interfaces"
+	"atom-engine/src/core/restapi"
+)
+
+func main() {
+	dir := flag.String("dir", "..", "REST API source directory with annotated handlers")
+	out := flag.String("out", "openapi.json", "Output specification file")
+	check := flag.Bool("check", false, "Verify routes are annotated and output file is up to date")
+	flag.Parse()
+
+	gen, err := newGenerator(*dir)
+	if err != nil {
+		fail(err)
+	}
+
+	spec, err := gen.build()
+	if err != nil {
+		fail(err)
+	}
+
+	if *check {
+		problems := diffRoutes(registeredRoutes(), gen.operationKeys())
+		current, err := os.ReadFile(*out)
+		if err != nil || !bytes.Equal(current, spec) {
+			problems = append(problems, fmt.Sprintf("%s is stale, run `make openapi`", *out))
+		}
+		if len(problems) > 0 {
+			for _, problem := range problems {
+				fmt.Fprintln(os.Stderr, problem)
+			}
+			os.Exit(1)
+		}
+		fmt.Printf("OpenAPI check passed: %d operations\n", len(gen.operations))
+		return
+	}
+
+	if err := os.WriteFile(*out, spec, 0o644); err != nil {
+		fail(err)
+	}
+	fmt.Printf("OpenAPI specification written to %s: %d operations\n", *out, len(gen.operations))
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "openapi gen:", err)
+	os.Exit(1)
+}
+
+// routeCore satisfies core interface for building router without running engine
+// Methods used while registering routes return nil, others are never called
+type routeCore struct {
+	interfaces.CoreTypedInterface
+}
+
+func (routeCore) GetAuthComponent() interface{} { return nil }
+func (routeCore) GetStorage() interface{}       { return nil }
+func (routeCore) SimulationEnabled() bool       { return false }
+
+// registeredRoutes returns method and OpenAPI style path of every route registered by REST server
+func registeredRoutes() []string {
+	gin.SetMode(gin.ReleaseMode)
+	server := restapi.NewServer(restapi.DefaultConfig(), routeCore{})
+
+	var routes []string
+	for _, route := range server.Routes() {
+		if route.Method == "HEAD" {
+			continue
+		}
+		routes = append(routes, operationKey(route.Method, ginPathToOpenAPI(route.Path)))
+	}
+	return routes
+}
+
+// diffRoutes reports routes without annotations and annotations without routes
+func diffRoutes(routes, annotated []string) []string {
+	registered := make(map[string]bool, len(routes))
+	for _, route := range routes {
+		registered[route] = true
+	}
+	documented := make(map[string]bool, len(annotated))
+	for _, key := range annotated {
+		documented[key] = true
+	}
+
+	var problems []string
+	for route := range registered {
+		if !documented[route] {
+			problems = append(problems, "route registered but not annotated: "+route)
+		}
+	}
+	for key := range documented {
+		if !registered[key] {
+			problems = append(problems, "annotation without registered route: "+key)
+		}
+	}
+	sort.Strings(problems)
+	return problems
+}
+
+// ginPathToOpenAPI converts :param and *param segments into {param}
+func ginPathToOpenAPI(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func operationKey(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
+
+// findModuleRoot walks up from dir to directory containing go.mod
+func findModuleRoot(dir string) (string, string, error) {
+	current, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", err
+	}
+	for {
+		data, err := os.ReadFile(filepath.Join(current, "go.mod"))
+		if err == nil {
+			for _, line := range strings.Split(string(data), "\n") {
+				if strings.HasPrefix(line, "module ") {
+					return current, strings.TrimSpace(strings.TrimPrefix(line, "module ")), nil
+				}
+			}
+			return "", "", fmt.Errorf("module directive not found in %s/go.mod", current)
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", "", fmt.Errorf("go.mod not found above %s", dir)
+		}
+		current = parent
+	}
+}
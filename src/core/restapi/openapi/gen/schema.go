@@ -0,0 +1,456 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// sourceFile keeps package and imports needed to resolve type names used in file
+type sourceFile struct {
+	dir        string
+	pkgName    string
+	importPath string
+	imports    map[string]string // alias -> import path
+}
+
+// typeDecl is named type declared in module package
+type typeDecl struct {
+	spec *ast.TypeSpec
+	file *sourceFile
+}
+
+// packageTypes holds type declarations of single package directory
+type packageTypes struct {
+	name  string
+	types map[string]typeDecl
+}
+
+// typeResolver converts Go types referenced by annotations into OpenAPI schemas
+type typeResolver struct {
+	moduleRoot string
+	modulePath string
+	packages   map[string]*packageTypes // import path -> types
+	schemas    map[string]interface{}   // component name -> schema
+	names      map[string]string        // qualified type -> component name
+	owners     map[string]string        // component name -> qualified type
+}
+
+func newTypeResolver(moduleRoot, modulePath string) *typeResolver {
+	return &typeResolver{
+		moduleRoot: moduleRoot,
+		modulePath: modulePath,
+		packages:   make(map[string]*packageTypes),
+		schemas:    make(map[string]interface{}),
+		names:      make(map[string]string),
+		owners:     make(map[string]string),
+	}
+}
+
+// sourceFile describes parsed file for later type resolution
+func (r *typeResolver) sourceFile(dir string, file *ast.File) *sourceFile {
+	source := &sourceFile{
+		dir:        dir,
+		pkgName:    file.Name.Name,
+		importPath: r.importPathOf(dir),
+		imports:    make(map[string]string),
+	}
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		alias := filepath.Base(path)
+		if spec.Name != nil {
+			alias = spec.Name.Name
+		}
+		source.imports[alias] = path
+	}
+	return source
+}
+
+func (r *typeResolver) importPathOf(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return dir
+	}
+	rel, err := filepath.Rel(r.moduleRoot, abs)
+	if err != nil || rel == "." {
+		return r.modulePath
+	}
+	return r.modulePath + "/" + filepath.ToSlash(rel)
+}
+
+// loadPackage parses type declarations of module package
+func (r *typeResolver) loadPackage(importPath string) (*packageTypes, error) {
+	if pkg, ok := r.packages[importPath]; ok {
+		return pkg, nil
+	}
+	if importPath != r.modulePath && !strings.HasPrefix(importPath, r.modulePath+"/") {
+		return nil, nil
+	}
+
+	dir := filepath.Join(r.moduleRoot, strings.TrimPrefix(strings.TrimPrefix(importPath, r.modulePath), "/"))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package %s: %w", importPath, err)
+	}
+
+	pkg := &packageTypes{types: make(map[string]typeDecl)}
+	fileSet := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fileSet, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		source := r.sourceFile(dir, file)
+		pkg.name = file.Name.Name
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				pkg.types[typeSpec.Name.Name] = typeDecl{spec: typeSpec, file: source}
+			}
+		}
+	}
+
+	r.packages[importPath] = pkg
+	return pkg, nil
+}
+
+// schemaFor converts annotation type expression, e.g. models.APIResponse{data=[]Job}
+func (r *typeResolver) schemaFor(file *sourceFile, expr string) (map[string]interface{}, error) {
+	expr = strings.TrimSpace(expr)
+
+	if base, overrides, ok := splitOverrides(expr); ok {
+		baseSchema, err := r.schemaFor(file, base)
+		if err != nil {
+			return nil, err
+		}
+		properties := map[string]interface{}{}
+		for _, override := range splitTopLevel(overrides) {
+			name, fieldType, found := strings.Cut(override, "=")
+			if !found {
+				return nil, fmt.Errorf("invalid field override %q", override)
+			}
+			fieldSchema, err := r.schemaFor(file, fieldType)
+			if err != nil {
+				return nil, err
+			}
+			properties[strings.TrimSpace(name)] = fieldSchema
+		}
+		return map[string]interface{}{
+			"allOf": []interface{}{
+				baseSchema,
+				map[string]interface{}{"type": "object", "properties": properties},
+			},
+		}, nil
+	}
+
+	switch {
+	case strings.HasPrefix(expr, "[]"):
+		items, err := r.schemaFor(file, expr[2:])
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	case strings.HasPrefix(expr, "map["):
+		end := strings.Index(expr, "]")
+		values, err := r.schemaFor(file, expr[end+1:])
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case expr == "object":
+		return map[string]interface{}{"type": "object"}, nil
+	case expr == "interface{}" || expr == "any":
+		return map[string]interface{}{}, nil
+	}
+
+	if schema := builtinSchema(expr); schema != nil {
+		return schema, nil
+	}
+
+	importPath := file.importPath
+	typeName := expr
+	if alias, name, found := strings.Cut(expr, "."); found {
+		path, err := r.qualifierPath(file, alias, name)
+		if err != nil {
+			return nil, fmt.Errorf("%w in type %s", err, expr)
+		}
+		importPath, typeName = path, name
+	}
+	return r.namedSchema(importPath, typeName)
+}
+
+// qualifierPath resolves package qualifier of annotation type
+// Like swag, qualifier may be package name of aliased import, e.g. models for restmodels
+func (r *typeResolver) qualifierPath(file *sourceFile, qualifier, typeName string) (string, error) {
+	var candidates []string
+	if path, ok := file.imports[qualifier]; ok {
+		candidates = append(candidates, path)
+	}
+	aliases := make([]string, 0, len(file.imports))
+	for alias := range file.imports {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	for _, alias := range aliases {
+		path := file.imports[alias]
+		if alias != qualifier && filepath.Base(path) == qualifier {
+			candidates = append(candidates, path)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("unknown package %q", qualifier)
+	}
+
+	for _, path := range candidates {
+		pkg, err := r.loadPackage(path)
+		if err != nil {
+			return "", err
+		}
+		if pkg == nil {
+			return path, nil
+		}
+		if _, ok := pkg.types[typeName]; ok {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("type %s not found in package %q", typeName, qualifier)
+}
+
+// namedSchema registers component schema for named type and returns reference to it
+func (r *typeResolver) namedSchema(importPath, typeName string) (map[string]interface{}, error) {
+	qualified := importPath + "." + typeName
+	if name, ok := r.names[qualified]; ok {
+		return ref(name), nil
+	}
+
+	pkg, err := r.loadPackage(importPath)
+	if err != nil {
+		return nil, err
+	}
+	if pkg == nil {
+		return map[string]interface{}{"type": "object"}, nil
+	}
+	decl, ok := pkg.types[typeName]
+	if !ok {
+		return nil, fmt.Errorf("type %s not found in %s", typeName, importPath)
+	}
+
+	name := pkg.name + "." + typeName
+	if owner, taken := r.owners[name]; taken && owner != qualified {
+		name = filepath.Base(filepath.Dir(importPath)) + "_" + name
+	}
+	r.names[qualified] = name
+	r.owners[name] = qualified
+	r.schemas[name] = map[string]interface{}{} // placeholder breaks recursive types
+
+	schema, err := r.exprSchema(decl.file, decl.spec.Type)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", qualified, err)
+	}
+	r.schemas[name] = schema
+	return ref(name), nil
+}
+
+// exprSchema converts Go type expression into schema
+func (r *typeResolver) exprSchema(file *sourceFile, expr ast.Expr) (map[string]interface{}, error) {
+	switch typed := expr.(type) {
+	case *ast.Ident:
+		if schema := builtinSchema(typed.Name); schema != nil {
+			return schema, nil
+		}
+		if typed.Name == "any" {
+			return map[string]interface{}{}, nil
+		}
+		return r.namedSchema(file.importPath, typed.Name)
+	case *ast.SelectorExpr:
+		alias, ok := typed.X.(*ast.Ident)
+		if !ok {
+			return map[string]interface{}{}, nil
+		}
+		importPath := file.imports[alias.Name]
+		switch importPath + "." + typed.Sel.Name {
+		case "time.Time":
+			return map[string]interface{}{"type": "string", "format": "date-time"}, nil
+		case "time.Duration":
+			return map[string]interface{}{"type": "integer", "format": "int64"}, nil
+		case "encoding/json.RawMessage":
+			return map[string]interface{}{}, nil
+		}
+		return r.namedSchema(importPath, typed.Sel.Name)
+	case *ast.StarExpr:
+		return r.exprSchema(file, typed.X)
+	case *ast.ArrayType:
+		if ident, ok := typed.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			return map[string]interface{}{"type": "string", "format": "byte"}, nil
+		}
+		items, err := r.exprSchema(file, typed.Elt)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	case *ast.MapType:
+		values, err := r.exprSchema(file, typed.Value)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case *ast.StructType:
+		return r.structSchema(file, typed)
+	default:
+		// Interfaces, generics, functions and channels have no JSON shape to describe
+		return map[string]interface{}{}, nil
+	}
+}
+
+// structSchema converts struct fields using their json tags
+func (r *typeResolver) structSchema(file *sourceFile, structType *ast.StructType) (map[string]interface{}, error) {
+	properties := map[string]interface{}{}
+	var required []string
+	var embedded []interface{}
+
+	for _, field := range structType.Fields.List {
+		if isFuncOrChan(field.Type) {
+			continue
+		}
+
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			value, _ := strconv.Unquote(field.Tag.Value)
+			tag = reflect.StructTag(value)
+		}
+		jsonName, jsonOptions, _ := strings.Cut(tag.Get("json"), ",")
+		if jsonName == "-" && jsonOptions == "" {
+			continue
+		}
+
+		if len(field.Names) == 0 && jsonName == "" {
+			// Embedded struct fields are flattened by encoding/json
+			schema, err := r.exprSchema(file, field.Type)
+			if err != nil {
+				return nil, err
+			}
+			embedded = append(embedded, schema)
+			continue
+		}
+
+		names := []string{jsonName}
+		if jsonName == "" {
+			names = names[:0]
+			for _, name := range field.Names {
+				if ast.IsExported(name.Name) {
+					names = append(names, name.Name)
+				}
+			}
+		}
+		if len(names) == 0 {
+			continue
+		}
+
+		schema, err := r.exprSchema(file, field.Type)
+		if err != nil {
+			return nil, err
+		}
+		if strings.Contains(jsonOptions, "string") {
+			schema = map[string]interface{}{"type": "string"}
+		}
+		for _, name := range names {
+			properties[name] = schema
+			if strings.Contains(tag.Get("binding"), "required") {
+				required = append(required, name)
+			}
+		}
+	}
+
+	schema := map[string]interface{}{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	if len(embedded) > 0 {
+		return map[string]interface{}{"allOf": append(embedded, schema)}, nil
+	}
+	return schema, nil
+}
+
+func isFuncOrChan(expr ast.Expr) bool {
+	switch expr.(type) {
+	case *ast.FuncType, *ast.ChanType:
+		return true
+	}
+	return false
+}
+
+// builtinSchema maps Go and swag primitive type names
+func builtinSchema(name string) map[string]interface{} {
+	switch name {
+	case "string", "error":
+		return map[string]interface{}{"type": "string"}
+	case "bool", "boolean":
+		return map[string]interface{}{"type": "boolean"}
+	case "int", "int8", "int16", "uint", "uint8", "uint16", "byte", "rune", "integer":
+		return map[string]interface{}{"type": "integer"}
+	case "int32", "uint32":
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case "int64", "uint64":
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case "float32", "float64", "number":
+		return map[string]interface{}{"type": "number"}
+	}
+	return nil
+}
+
+// splitOverrides splits "Type{field=Other}" into type and field overrides
+func splitOverrides(expr string) (string, string, bool) {
+	start := strings.Index(expr, "{")
+	if start <= 0 || !strings.HasSuffix(expr, "}") || strings.HasSuffix(expr[:start], "interface") {
+		return "", "", false
+	}
+	return expr[:start], expr[start+1 : len(expr)-1], true
+}
+
+// splitTopLevel splits by commas outside of braces and brackets
+func splitTopLevel(text string) []string {
+	var parts []string
+	depth := 0
+	start := 0
+	for i, char := range text {
+		switch char {
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, text[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, text[start:])
+}
+
+func ref(name string) map[string]interface{} {
+	return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+}
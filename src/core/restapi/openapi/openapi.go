@@ -0,0 +1,99 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+// Package openapi embeds OpenAPI specification generated from REST handler annotations
+package openapi
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+//go:generate go run ./gen -dir .. -out openapi.json
+
+//go:embed openapi.json
+var specJSON []byte
+
+// RuntimeOptions holds values known only to running engine
+type RuntimeOptions struct {
+	ServerURL   string
+	Version     string
+	AuthEnabled bool
+}
+
+// Spec returns embedded specification with server URL, version and security from runtime options
+func Spec(options RuntimeOptions) ([]byte, error) {
+	var document map[string]interface{}
+	if err := json.Unmarshal(specJSON, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse embedded OpenAPI spec: %w", err)
+	}
+
+	if options.ServerURL != "" {
+		document["servers"] = []map[string]string{{"url": options.ServerURL}}
+	}
+	if info, ok := document["info"].(map[string]interface{}); ok && options.Version != "" {
+		info["version"] = options.Version
+	}
+
+	if !options.AuthEnabled {
+		// Without auth requests carry no credentials, drop scheme and requirements
+		if components, ok := document["components"].(map[string]interface{}); ok {
+			delete(components, "securitySchemes")
+		}
+		if paths, ok := document["paths"].(map[string]interface{}); ok {
+			for _, item := range paths {
+				operations, _ := item.(map[string]interface{})
+				for _, operation := range operations {
+					if op, ok := operation.(map[string]interface{}); ok {
+						delete(op, "security")
+					}
+				}
+			}
+		}
+	}
+
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render OpenAPI spec: %w", err)
+	}
+	return data, nil
+}
+
+// docsTemplate renders Swagger UI loaded from CDN, spec itself is served by engine
+var docsTemplate = template.Must(template.New("docs").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>{{.Title}}</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({url: "{{.SpecURL}}", dom_id: "#swagger-ui"});
+  </script>
+</body>
+</html>
+`))
+
+// DocsHTML renders Swagger UI page pointing to specification URL
+func DocsHTML(title, specURL string) ([]byte, error) {
+	var page strings.Builder
+	err := docsTemplate.Execute(&page, struct {
+		Title   string
+		SpecURL string
+	}{title, specURL})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render docs page: %w", err)
+	}
+	return []byte(page.String()), nil
+}
@@ -11,8 +11,10 @@ package restapi
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -24,20 +26,43 @@ import (
 	"atom-engine/src/core/restapi/handlers"
 	"atom-engine/src/core/restapi/middleware"
 	"atom-engine/src/core/restapi/models"
+	"atom-engine/src/core/restapi/openapi"
 	"atom-engine/src/core/restapi/utils"
+	"atom-engine/src/version"
 )
 
+// OpenAPIPath is path of OpenAPI specification endpoint
+const OpenAPIPath = "/api/v1/openapi.json"
+
+// Probe paths for container orchestration, served without auth and rate limiting
+const (
+	LivenessPath  = "/health/live"
+	ReadinessPath = "/health/ready"
+	StartupPath   = "/health/startup"
+)
+
+// VersionPath is path of build metadata endpoint, served without auth for health dashboards
+const VersionPath = "/api/v1/version"
+
 // Config holds REST API server configuration
 type Config struct {
-	Host      string                      `yaml:"host"`
-	Port      int                         `yaml:"port"`
-	CORS      *middleware.CORSConfig      `yaml:"cors"`
-	Logging   *middleware.LoggingConfig   `yaml:"logging"`
-	RateLimit *middleware.RateLimitConfig `yaml:"rate_limit"`
-	Swagger   *SwaggerConfig              `yaml:"swagger"`
+	Host        string                        `yaml:"host"`
+	Port        int                           `yaml:"port"`
+	Mode        string                        `yaml:"mode"` // Gin mode: release (default), debug or test
+	CORS        *middleware.CORSConfig        `yaml:"cors"`
+	Compression *middleware.CompressionConfig `yaml:"compression"`
+	Overload    *middleware.OverloadConfig    `yaml:"overload"`
+	Logging     *middleware.LoggingConfig     `yaml:"logging"`
+	RateLimit   *middleware.RateLimitConfig   `yaml:"rate_limit"`
+	Swagger     *SwaggerConfig                `yaml:"swagger"`
+	Variables   *utils.VariableLimitsConfig   `yaml:"variables"`
+	GRPCWeb     *handlers.GRPCWebConfig       `yaml:"grpc_web"`
+	GraphQL     *handlers.GraphQLConfig       `yaml:"graphql"`
+	Camunda     *handlers.CamundaCompatConfig `yaml:"camunda_compat"`
 }
 
 // SwaggerConfig holds Swagger documentation configuration
+// Path is Swagger UI page, specification is always served at OpenAPIPath
 type SwaggerConfig struct {
 	Enabled bool   `yaml:"enabled"`
 	Path    string `yaml:"path"`
@@ -48,14 +73,17 @@ type SwaggerConfig struct {
 // DefaultConfig returns default REST API configuration
 func DefaultConfig() *Config {
 	return &Config{
-		Host:      "localhost",
-		Port:      27555,
-		CORS:      middleware.DefaultCORSConfig(),
-		Logging:   middleware.DefaultLoggingConfig(),
-		RateLimit: middleware.DefaultRateLimitConfig(),
+		Host:        "localhost",
+		Port:        27555,
+		Mode:        gin.ReleaseMode,
+		CORS:        middleware.DefaultCORSConfig(),
+		Compression: middleware.DefaultCompressionConfig(),
+		Overload:    middleware.DefaultOverloadConfig(),
+		Logging:     middleware.DefaultLoggingConfig(),
+		RateLimit:   middleware.DefaultRateLimitConfig(),
 		Swagger: &SwaggerConfig{
 			Enabled: true,
-			Path:    "/api/docs",
+			Path:    "/docs",
 			Title:   "Atom Engine REST API",
 			Version: "1.0.0",
 		},
@@ -73,8 +101,10 @@ type Server struct {
 	// Middleware instances
 	authMiddleware      *middleware.AuthMiddleware
 	corsMiddleware      *middleware.CORSMiddleware
+	compressMiddleware  *middleware.CompressionMiddleware
 	loggingMiddleware   *middleware.LoggingMiddleware
 	rateLimitMiddleware *middleware.RateLimitMiddleware
+	overloadMiddleware  *middleware.OverloadMiddleware
 
 	// Handler instances
 	storageHandler    *handlers.StorageHandler
@@ -87,6 +117,13 @@ type Server struct {
 	expressionHandler *handlers.ExpressionHandler
 	incidentsHandler  *handlers.IncidentsHandler
 	systemHandler     *handlers.SystemHandler
+	metricsHandler    *handlers.MetricsHandler
+	triggersHandler   *handlers.TriggersHandler
+	rateLimitHandler  *handlers.RateLimitHandler
+	grpcWebHandler    *handlers.GRPCWebHandler
+	graphQLHandler    *handlers.GraphQLHandler
+	camundaHandler    *handlers.CamundaHandler
+	simulationHandler *handlers.SimulationHandler
 }
 
 // Import the unified core interface (with typed support)
@@ -199,22 +236,67 @@ func NewServer(config *Config, coreInterface CoreInterface) *Server {
 
 // setupHandlers initializes all request handlers
 func (s *Server) setupHandlers() {
+	// Offloaded variable values are stored through storage blob namespace
+	var blobStore utils.VariableBlobStore
+	if store, ok := s.coreInterface.GetStorage().(utils.VariableBlobStore); ok {
+		blobStore = store
+	}
+	variableLimiter := utils.NewVariableLimiter(s.config.Variables, blobStore)
+
+	// Variable payloads are validated with configured rules before limits are applied
+	variableValidator, err := utils.NewVariableValidator(s.config.Variables)
+	if err != nil {
+		logger.Error("Invalid variable validation rules, variable validation disabled",
+			logger.String("error", err.Error()))
+		variableValidator = utils.NewValidator()
+	}
+
 	s.storageHandler = handlers.NewStorageHandler(s.coreInterface)
 	s.parserHandler = handlers.NewParserHandler(s.coreInterface)
-	s.processHandler = handlers.NewProcessHandler(s.coreInterface)
+	s.processHandler = handlers.NewProcessHandler(s.coreInterface, variableValidator, variableLimiter)
 	s.tokensHandler = handlers.NewTokensHandler(s.coreInterface)
 	s.timerHandler = handlers.NewTimerHandler(s.coreInterface)
-	s.jobsHandler = handlers.NewJobsHandler(s.coreInterface)
-	s.messagesHandler = handlers.NewMessagesHandler(s.coreInterface)
+	s.jobsHandler = handlers.NewJobsHandler(s.coreInterface, variableValidator, variableLimiter)
+	s.messagesHandler = handlers.NewMessagesHandler(s.coreInterface, variableValidator, variableLimiter)
 	s.expressionHandler = handlers.NewExpressionHandler(s.coreInterface)
 	s.incidentsHandler = handlers.NewIncidentsHandler(s.coreInterface)
 	s.systemHandler = handlers.NewSystemHandler(s.coreInterface)
+	s.metricsHandler = handlers.NewMetricsHandler(s.coreInterface)
+	s.triggersHandler = handlers.NewTriggersHandler(s.coreInterface)
+	if s.grpcWebEnabled() {
+		s.grpcWebHandler = handlers.NewGRPCWebHandler(s.coreInterface, s.config.GRPCWeb)
+	}
+	if s.config.GraphQL != nil && s.config.GraphQL.Enabled {
+		graphQLHandler, err := handlers.NewGraphQLHandler(s.coreInterface, s.config.GraphQL)
+		if err != nil {
+			logger.Error("Failed to create GraphQL handler, endpoint disabled", logger.String("error", err.Error()))
+		} else {
+			s.graphQLHandler = graphQLHandler
+		}
+	}
+	if s.config.Camunda != nil && s.config.Camunda.Enabled {
+		s.camundaHandler = handlers.NewCamundaHandler(s.coreInterface, variableLimiter)
+	}
+	// Simulated clock routes are never registered outside simulation mode
+	if s.coreInterface.SimulationEnabled() {
+		s.simulationHandler = handlers.NewSimulationHandler(s.coreInterface)
+	}
 }
 
 // setupRouter configures Gin router and middleware
 func (s *Server) setupRouter() {
-	// Set Gin mode based on log level
-	gin.SetMode(gin.ReleaseMode) // Default to release mode
+	mode := s.config.Mode
+	if mode == "" {
+		mode = gin.ReleaseMode
+	}
+	gin.SetMode(mode)
+
+	// Gin writes nothing to stdout or stderr, debug mode output goes through project logger
+	gin.DefaultWriter = io.Discard
+	gin.DefaultErrorWriter = io.Discard
+	gin.DebugPrintFunc = func(format string, values ...interface{}) {
+		logger.Debug(strings.TrimSpace(fmt.Sprintf(format, values...)), logger.String("type", "gin"))
+	}
 
 	// Create router
 	s.router = gin.New()
@@ -228,39 +310,134 @@ func (s *Server) setupRouter() {
 
 // setupMiddleware configures all middleware
 func (s *Server) setupMiddleware() {
-	// Recovery middleware (built-in)
-	s.router.Use(gin.Recovery())
+	// Request ID middleware, runs before others so they share the ID
+	s.router.Use(middleware.NewRequestIDMiddleware().Handler())
+
+	// Recovery middleware catches panics of middleware below, handler panics are caught by second
+	// recovery registered after logging, so their 500 response passes compression and access log
+	recovery := middleware.NewRecoveryMiddleware()
+	s.router.Use(recovery.Handler())
+
+	// Tracing middleware, span is tagged with request ID and wraps all later middleware
+	s.router.Use(middleware.NewTracingMiddleware().Handler())
 
 	// CORS middleware
 	if s.config.CORS != nil {
+		if s.grpcWebEnabled() {
+			defaults := middleware.DefaultCORSConfig()
+			s.config.CORS.AllowedHeaders = appendMissingHeaders(s.config.CORS.AllowedHeaders,
+				defaults.AllowedHeaders, handlers.GRPCWebCORSAllowedHeaders)
+			s.config.CORS.ExposedHeaders = appendMissingHeaders(s.config.CORS.ExposedHeaders,
+				defaults.ExposedHeaders, handlers.GRPCWebCORSExposedHeaders)
+		}
 		s.corsMiddleware = middleware.NewCORSMiddleware(s.config.CORS)
 		s.router.Use(s.corsMiddleware.Handler())
 	}
 
+	// Compression middleware, registered before logging so logging sees uncompressed body and size
+	if s.config.Compression != nil {
+		s.compressMiddleware = middleware.NewCompressionMiddleware(s.config.Compression)
+		s.router.Use(s.compressMiddleware.Handler())
+	}
+
+	// Envelope middleware, registered after compression so it rewrites uncompressed body
+	s.router.Use(middleware.NewEnvelopeMiddleware().Handler())
+
 	// Logging middleware
 	if s.config.Logging != nil {
 		s.loggingMiddleware = middleware.NewLoggingMiddleware(s.config.Logging)
 		s.router.Use(s.loggingMiddleware.Handler())
 	}
 
+	// Handler panics are recovered here, inside access log
+	s.router.Use(recovery.Handler())
+
 	// Rate limiting middleware
 	if s.config.RateLimit != nil {
 		s.rateLimitMiddleware = middleware.NewRateLimitMiddleware(s.config.RateLimit, s.authComponent)
+		skipPaths := append(s.docsPaths(), LivenessPath, ReadinessPath, StartupPath, handlers.RateLimitAdminPath)
+		for _, path := range skipPaths {
+			s.rateLimitMiddleware.AddSkipPath(path)
+		}
 		s.router.Use(s.rateLimitMiddleware.Handler())
+		s.rateLimitHandler = handlers.NewRateLimitHandler(s.rateLimitMiddleware)
 	}
 
 	// Auth middleware
 	if s.authComponent != nil {
 		s.authMiddleware = middleware.NewAuthMiddleware(s.authComponent)
+		for _, path := range s.docsPaths() {
+			s.authMiddleware.AddBypassPath(path)
+		}
+		// Webhook calls are authenticated by trigger secret instead of API key
+		s.authMiddleware.AddBypassPath(handlers.HookPathPrefix)
+		s.authMiddleware.AddBypassPath(VersionPath)
 		s.router.Use(s.authMiddleware.Authenticate())
 	}
 }
 
+// grpcWebEnabled reports whether grpc-web proxy is configured
+func (s *Server) grpcWebEnabled() bool {
+	return s.config.GRPCWeb != nil && s.config.GRPCWeb.Enabled
+}
+
+// appendMissingHeaders appends headers not yet present in list, empty list starts from defaults
+func appendMissingHeaders(list, defaults, headers []string) []string {
+	if len(list) == 0 {
+		list = append(list, defaults...)
+	}
+	for _, header := range headers {
+		found := false
+		for _, existing := range list {
+			if strings.EqualFold(existing, header) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			list = append(list, header)
+		}
+	}
+	return list
+}
+
+// docsPaths returns documentation paths served without auth and rate limiting
+func (s *Server) docsPaths() []string {
+	if s.config.Swagger == nil || !s.config.Swagger.Enabled {
+		return nil
+	}
+	return []string{OpenAPIPath, s.config.Swagger.Path}
+}
+
 // setupRoutes configures all API routes
 func (s *Server) setupRoutes() {
 	// Health check endpoint (no auth required)
 	s.router.GET("/health", s.healthHandler)
 
+	// Liveness, readiness and startup probes (no auth required)
+	s.router.GET(LivenessPath, s.livenessHandler)
+	s.router.GET(ReadinessPath, s.readinessHandler)
+	s.router.GET(StartupPath, s.startupHandler)
+
+	// Build metadata (no auth required)
+	s.router.GET(VersionPath, s.versionHandler)
+
+	// Prometheus metrics endpoint (no auth required)
+	s.metricsHandler.RegisterRoutes(s.router)
+
+	// Inbound webhook triggers (authenticated by trigger secret)
+	s.triggersHandler.RegisterHookRoutes(s.router)
+
+	// grpc-web calls of browser clients (authenticated by API key like REST routes)
+	if s.grpcWebHandler != nil {
+		s.grpcWebHandler.RegisterRoutes(s.router)
+	}
+
+	// Camunda 7 external task clients use their own root path
+	if s.camundaHandler != nil {
+		s.camundaHandler.RegisterRoutes(s.router, s.authMiddleware)
+	}
+
 	// API v1 routes
 	v1 := s.router.Group("/api/v1")
 	{
@@ -284,24 +461,56 @@ func (s *Server) setupRoutes() {
 		s.expressionHandler.RegisterRoutes(v1, s.authMiddleware)
 		s.incidentsHandler.RegisterRoutes(v1, s.authMiddleware)
 		s.systemHandler.RegisterRoutes(v1, s.authMiddleware)
+		s.triggersHandler.RegisterRoutes(v1, s.authMiddleware)
+		if s.rateLimitHandler != nil {
+			s.rateLimitHandler.RegisterRoutes(v1, s.authMiddleware)
+		}
+		if s.graphQLHandler != nil {
+			s.graphQLHandler.RegisterRoutes(v1, s.authMiddleware)
+		}
+		if s.simulationHandler != nil {
+			s.simulationHandler.RegisterRoutes(v1, s.authMiddleware)
+		}
 	}
 
-	// Swagger documentation
+	// OpenAPI specification and Swagger UI (no auth required)
 	if s.config.Swagger != nil && s.config.Swagger.Enabled {
+		s.router.GET(OpenAPIPath, s.openAPIHandler)
 		s.router.GET(s.config.Swagger.Path, s.swaggerHandler)
-		s.router.Static(s.config.Swagger.Path+"/static", "./docs/swagger")
 	}
 }
 
+// Routes returns registered routes
+func (s *Server) Routes() gin.RoutesInfo {
+	return s.router.Routes()
+}
+
 // Start starts the REST API server
 func (s *Server) Start() error {
 	addr := fmt.Sprintf("%s:%d", s.config.Host, s.config.Port)
 
+	handler := http.Handler(s.router)
+	writeTimeout := 30 * time.Second
+	if s.config.Overload != nil {
+		s.overloadMiddleware = middleware.NewOverloadMiddleware(s.config.Overload)
+		// Probes and metrics must answer under overload, long polling waits longer than timeout by design
+		for _, path := range []string{"/health", "/metrics", LivenessPath, ReadinessPath, StartupPath,
+			handlers.CamundaExternalTaskPath + "/fetchAndLock"} {
+			s.overloadMiddleware.AddSkipPath(path)
+		}
+		handler = s.overloadMiddleware.Wrap(handler)
+
+		// Write deadline must outlive request timeout, otherwise 503 cannot be delivered
+		if s.config.Overload.Enabled && s.config.Overload.RequestTimeout >= writeTimeout {
+			writeTimeout = s.config.Overload.RequestTimeout + 5*time.Second
+		}
+	}
+
 	s.httpServer = &http.Server{
 		Addr:         addr,
-		Handler:      s.router,
+		Handler:      handler,
 		ReadTimeout:  30 * time.Second,
-		WriteTimeout: 30 * time.Second,
+		WriteTimeout: writeTimeout,
 		IdleTimeout:  120 * time.Second,
 	}
 
@@ -340,6 +549,12 @@ func (s *Server) IsReady() bool {
 // Basic handlers (more will be in separate handler files)
 
 // healthHandler handles health check requests
+// @Summary Liveness check
+// @Description Check that REST API server is running
+// @Tags system
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=models.HealthResponse}
+// @Router /health [get]
 func (s *Server) healthHandler(c *gin.Context) {
 	response := models.HealthResponse{
 		Status:    "healthy",
@@ -352,17 +567,182 @@ func (s *Server) healthHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, models.SuccessResponse(response, "health"))
 }
 
-// swaggerHandler serves Swagger documentation
-func (s *Server) swaggerHandler(c *gin.Context) {
-	c.HTML(http.StatusOK, "swagger.html", gin.H{
-		"title":   s.config.Swagger.Title,
-		"version": s.config.Swagger.Version,
+// livenessHandler handles liveness probe requests
+// @Summary Liveness probe
+// @Description Check that engine process is up. Answers 200 whenever HTTP server is able to serve requests,
+// @Description including startup recovery, so restart is triggered only by hung process
+// @Tags system
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=models.HealthResponse}
+// @Router /health/live [get]
+func (s *Server) livenessHandler(c *gin.Context) {
+	response := models.HealthResponse{
+		Status:    "alive",
+		Timestamp: time.Now(),
+		Checks: map[string]interface{}{
+			"server": "ok",
+		},
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(response, "health"))
+}
+
+// readinessHandler handles readiness probe requests
+// @Summary Readiness probe
+// @Description Check that engine accepts traffic: storage is connected, all components are ready
+// @Description and in-flight state of previous run is recovered. Answers 503 with failed checks otherwise
+// @Tags system
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=models.HealthResponse}
+// @Failure 503 {object} models.APIResponse{error=models.APIError}
+// @Router /health/ready [get]
+func (s *Server) readinessHandler(c *gin.Context) {
+	status := s.coreInterface.GetProbeStatus()
+
+	checks := map[string]interface{}{
+		"recovery": probeCheck(status.Recovered),
+	}
+	for name, ready := range status.Components {
+		checks[name] = probeCheck(ready)
+	}
+
+	writeProbe(c, status.Ready(), "ready", checks)
+}
+
+// startupHandler handles startup probe requests
+// @Summary Startup probe
+// @Description Check that engine has finished startup recovery of in-flight tokens, timers and callbacks.
+// @Description Answers 503 while recovery is running, so slow recovery is not mistaken for hung process
+// @Tags system
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=models.HealthResponse}
+// @Failure 503 {object} models.APIResponse{error=models.APIError}
+// @Router /health/startup [get]
+func (s *Server) startupHandler(c *gin.Context) {
+	recovered := s.coreInterface.GetProbeStatus().Recovered
+
+	writeProbe(c, recovered, "started", map[string]interface{}{
+		"recovery": probeCheck(recovered),
 	})
 }
 
+// probeCheck returns probe check value of component or recovery state
+func probeCheck(ok bool) string {
+	if ok {
+		return "ok"
+	}
+	return "pending"
+}
+
+// writeProbe answers 200 with checks when probe passes, otherwise 503 with checks in error details
+func writeProbe(c *gin.Context, ok bool, status string, checks map[string]interface{}) {
+	if !ok {
+		apiErr := models.NewAPIErrorWithDetails(models.ErrorCodeComponentNotReady, "Engine is not "+status,
+			map[string]interface{}{"checks": checks})
+		c.JSON(http.StatusServiceUnavailable, models.ErrorResponse(apiErr, "health"))
+		return
+	}
+
+	response := models.HealthResponse{
+		Status:    status,
+		Timestamp: time.Now(),
+		Checks:    checks,
+	}
+	c.JSON(http.StatusOK, models.SuccessResponse(response, "health"))
+}
+
+// versionHandler returns build metadata of running engine
+// @Summary Engine version
+// @Description Get engine version, git commit, build time, Go runtime and supported BPMN/FEEL feature set version
+// @Tags system
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=models.VersionResponse}
+// @Router /api/v1/version [get]
+func (s *Server) versionHandler(c *gin.Context) {
+	response := models.VersionResponse{
+		Version:           version.Version,
+		GitCommit:         version.GitCommit,
+		BuildTime:         version.BuildTime,
+		GoVersion:         version.GoVersion,
+		Platform:          version.Platform,
+		FeatureSetVersion: version.FeatureSetVersion,
+	}
+
+	c.JSON(http.StatusOK, models.SuccessResponse(response, utils.GetRequestID(c)))
+}
+
+// openAPIHandler serves embedded OpenAPI specification
+// @Summary OpenAPI specification
+// @Description Get OpenAPI 3 specification of REST API with server URL and auth scheme of this engine
+// @Tags system
+// @Produce json
+// @Success 200 {object} object "OpenAPI document"
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Router /api/v1/openapi.json [get]
+func (s *Server) openAPIHandler(c *gin.Context) {
+	spec, err := openapi.Spec(openapi.RuntimeOptions{
+		ServerURL:   s.serverURL(c),
+		Version:     version.Version,
+		AuthEnabled: s.authComponent != nil && s.authComponent.IsEnabled(),
+	})
+	if err != nil {
+		logger.Error("Failed to render OpenAPI spec", logger.String("error", err.Error()))
+		apiErr := models.InternalServerError("Failed to render OpenAPI specification")
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse(apiErr, utils.GetRequestID(c)))
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", spec)
+}
+
+// serverURL returns base URL of this server for OpenAPI clients
+// Wildcard listen addresses are replaced with host requested by client
+func (s *Server) serverURL(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+
+	switch s.config.Host {
+	case "", "0.0.0.0", "::", "[::]":
+		return scheme + "://" + c.Request.Host
+	default:
+		return fmt.Sprintf("%s://%s:%d", scheme, s.config.Host, s.config.Port)
+	}
+}
+
+// swaggerHandler serves Swagger UI for embedded specification
+// @Summary API documentation
+// @Description Swagger UI for OpenAPI specification of REST API
+// @Tags system
+// @Produce html
+// @Success 200 {string} string "Swagger UI page"
+// @Router /docs [get]
+func (s *Server) swaggerHandler(c *gin.Context) {
+	page, err := openapi.DocsHTML(s.config.Swagger.Title, OpenAPIPath)
+	if err != nil {
+		logger.Error("Failed to render docs page", logger.String("error", err.Error()))
+		c.String(http.StatusInternalServerError, "failed to render docs page")
+		return
+	}
+
+	c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+}
+
 // Daemon handlers - implemented REST endpoints
+
+// daemonStatusHandler handles GET /api/v1/daemon/status
+// @Summary Daemon status
+// @Description Get daemon status, health, uptime and version
+// @Tags daemon
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/daemon/status [get]
 func (s *Server) daemonStatusHandler(c *gin.Context) {
-	requestID := s.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Get system status from core
 	status, err := s.coreInterface.GetSystemStatus()
@@ -385,8 +765,17 @@ func (s *Server) daemonStatusHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, models.SuccessResponse(response, requestID))
 }
 
+// daemonStartHandler handles POST /api/v1/daemon/start
+// @Summary Start daemon
+// @Description Informational endpoint, daemon is started through CLI
+// @Tags daemon
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/daemon/start [post]
 func (s *Server) daemonStartHandler(c *gin.Context) {
-	requestID := s.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Daemon start/stop operations are managed at system level, not through REST API
 	// These operations affect the entire system and should be done through CLI
@@ -399,8 +788,17 @@ func (s *Server) daemonStartHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, models.SuccessResponse(response, requestID))
 }
 
+// daemonStopHandler handles POST /api/v1/daemon/stop
+// @Summary Stop daemon
+// @Description Informational endpoint, daemon is stopped through CLI or system signals
+// @Tags daemon
+// @Produce json
+// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/daemon/stop [post]
 func (s *Server) daemonStopHandler(c *gin.Context) {
-	requestID := s.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Graceful shutdown would stop this endpoint itself, so we can't implement it here
 	// Direct shutdown should be done through CLI or system signals
@@ -413,8 +811,19 @@ func (s *Server) daemonStopHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, models.SuccessResponse(response, requestID))
 }
 
+// daemonEventsHandler handles GET /api/v1/daemon/events
+// @Summary Recent system events
+// @Description Get recent system events from storage
+// @Tags daemon
+// @Produce json
+// @Param limit query int false "Number of events (max 100)" default(50)
+// @Success 200 {object} models.APIResponse{data=map[string]interface{}}
+// @Failure 401 {object} models.APIResponse{error=models.APIError}
+// @Failure 500 {object} models.APIResponse{error=models.APIError}
+// @Security ApiKeyAuth
+// @Router /api/v1/daemon/events [get]
 func (s *Server) daemonEventsHandler(c *gin.Context) {
-	requestID := s.getRequestID(c)
+	requestID := utils.GetRequestID(c)
 
 	// Get system events from storage through core
 	storageComp := s.coreInterface.GetStorageTyped()
@@ -464,11 +873,3 @@ func (s *Server) daemonEventsHandler(c *gin.Context) {
 
 	c.JSON(http.StatusOK, models.SuccessResponse(response, requestID))
 }
-
-// getRequestID extracts request ID from context or generates one
-func (s *Server) getRequestID(c *gin.Context) string {
-	if requestID := c.GetHeader("X-Request-ID"); requestID != "" {
-		return requestID
-	}
-	return utils.GenerateSecureRequestID("req")
-}
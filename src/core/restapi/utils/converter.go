@@ -10,10 +10,15 @@ package utils
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	coremodels "atom-engine/src/core/models"
 	"atom-engine/src/core/restapi/models"
 )
 
@@ -158,50 +163,72 @@ func (c *Converter) HTTPStatusFromGRPCError(err error) int {
 	if err == nil {
 		return 200
 	}
-
-	errMsg := err.Error()
-
-	switch {
-	case contains(errMsg, "not found"):
-		return 404
-	case contains(errMsg, "already exists"):
-		return 409
-	case contains(errMsg, "invalid"):
-		return 400
-	case contains(errMsg, "unauthorized"):
-		return 401
-	case contains(errMsg, "forbidden"):
-		return 403
-	case contains(errMsg, "rate limit"):
-		return 429
-	default:
-		return 500
-	}
+	return models.HTTPStatusFromErrorCode(c.GRPCErrorToAPIError(err).Code)
 }
 
-// GRPCErrorToAPIError converts gRPC error to API error
+// GRPCErrorToAPIError converts gRPC error to API error.
+// Error is classified by typed component errors and gRPC status codes, message text is never inspected
 func (c *Converter) GRPCErrorToAPIError(err error) *models.APIError {
 	if err == nil {
 		return nil
 	}
 
+	var notReady *coremodels.ComponentNotReadyError
+	if errors.As(err, &notReady) {
+		return models.ComponentNotReadyError(notReady.Component)
+	}
+
+	var circuitOpen *coremodels.CircuitOpenError
+	if errors.As(err, &circuitOpen) {
+		return models.ComponentUnavailableError(circuitOpen.Component, circuitOpen.RetryAfter)
+	}
+
 	errMsg := err.Error()
 
 	switch {
-	case contains(errMsg, "not found"):
+	case errors.Is(err, coremodels.ErrNotFound):
 		return models.NotFoundError(errMsg)
-	case contains(errMsg, "already exists"):
+	case errors.Is(err, coremodels.ErrConflict):
 		return models.ConflictError(errMsg)
-	case contains(errMsg, "invalid"):
+	case errors.Is(err, coremodels.ErrInvalidState):
+		return models.InvalidStateError(errMsg)
+	case errors.Is(err, coremodels.ErrTimeout):
+		return models.TimeoutError(errMsg)
+	case errors.Is(err, coremodels.ErrInvalidArgument):
 		return models.BadRequestError(errMsg)
-	case contains(errMsg, "unauthorized"):
-		return models.UnauthorizedError(errMsg)
-	case contains(errMsg, "forbidden"):
-		return models.ForbiddenError(errMsg)
-	case contains(errMsg, "rate limit"):
-		return models.RateLimitedError(errMsg)
+	}
+
+	if st, ok := status.FromError(err); ok {
+		return apiErrorFromStatus(st)
+	}
+
+	return models.InternalServerError(errMsg)
+}
+
+// apiErrorFromStatus converts gRPC status to API error by status code
+func apiErrorFromStatus(st *status.Status) *models.APIError {
+	message := st.Message()
+	switch st.Code() {
+	case codes.NotFound:
+		return models.NotFoundError(message)
+	case codes.AlreadyExists:
+		return models.ConflictError(message)
+	case codes.FailedPrecondition:
+		return models.InvalidStateError(message)
+	case codes.InvalidArgument, codes.OutOfRange:
+		return models.BadRequestError(message)
+	case codes.Unauthenticated:
+		return models.UnauthorizedError(message)
+	case codes.PermissionDenied:
+		return models.ForbiddenError(message)
+	case codes.ResourceExhausted:
+		return models.RateLimitedError(message)
+	case codes.DeadlineExceeded:
+		return models.TimeoutError(message)
+	case codes.Unavailable:
+		return models.NewAPIError(models.ErrorCodeComponentUnavailable, message)
 	default:
-		return models.InternalServerError(errMsg)
+		return models.InternalServerError(message)
 	}
 }
 
@@ -248,6 +275,7 @@ func (c *Converter) ConvertIncidentType(incidentType string) string {
 		"INCIDENT_TYPE_TIMER_ERROR":      "timer_error",
 		"INCIDENT_TYPE_MESSAGE_ERROR":    "message_error",
 		"INCIDENT_TYPE_SYSTEM_ERROR":     "system_error",
+		"INCIDENT_TYPE_SLA_BREACH":       "sla_breach",
 	}
 
 	if converted, exists := typeMap[incidentType]; exists {
@@ -278,42 +306,6 @@ func IsValidJSON(str string) bool {
 	return json.Unmarshal([]byte(str), &js) == nil
 }
 
-// contains checks if string contains substring (case-insensitive)
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) &&
-		(s == substr ||
-			len(s) > len(substr) &&
-				(s[:len(substr)] == substr ||
-					s[len(s)-len(substr):] == substr ||
-					containsIgnoreCase(s, substr)))
-}
-
-// containsIgnoreCase performs case-insensitive substring search
-func containsIgnoreCase(s, substr string) bool {
-	s = toLower(s)
-	substr = toLower(substr)
-
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return true
-		}
-	}
-	return false
-}
-
-// toLower converts string to lowercase
-func toLower(s string) string {
-	result := make([]byte, len(s))
-	for i := 0; i < len(s); i++ {
-		c := s[i]
-		if c >= 'A' && c <= 'Z' {
-			c = c + ('a' - 'A')
-		}
-		result[i] = c
-	}
-	return string(result)
-}
-
 // ConvertArrayToStringSlice converts []interface{} to []string
 func (c *Converter) ConvertArrayToStringSlice(arr []interface{}) []string {
 	result := make([]string, len(arr))
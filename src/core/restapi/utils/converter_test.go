@@ -0,0 +1,52 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	coremodels "atom-engine/src/core/models"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHTTPStatusFromGRPCErrorUsesErrorTypeNotText(t *testing.T) {
+	const variables = `{"lookup":"customer not found","note":"Not Found"}`
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"plain error with not found text",
+			errors.New("failed to store variables " + variables), http.StatusInternalServerError},
+		{"uncoded component error",
+			coremodels.ErrorFromCode("", "invalid variables "+variables), http.StatusInternalServerError},
+		{"unknown code",
+			coremodels.ErrorFromCode("INTERNAL", "job not found in "+variables), http.StatusInternalServerError},
+		{"grpc internal status", status.Error(codes.Internal, "variables "+variables), http.StatusInternalServerError},
+		{"not found code",
+			coremodels.ErrorFromCode(coremodels.ErrorCodeNotFound, "job job-1 missing"), http.StatusNotFound},
+		{"wrapped sentinel", fmt.Errorf("load job: %w", coremodels.ErrNotFound), http.StatusNotFound},
+		{"grpc not found status", status.Error(codes.NotFound, "job job-1"), http.StatusNotFound},
+	}
+
+	converter := NewConverter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := converter.HTTPStatusFromGRPCError(tt.err); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
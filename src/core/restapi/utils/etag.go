@@ -0,0 +1,74 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheControlRevalidate lets clients cache response but requires revalidation by ETag before reuse
+const CacheControlRevalidate = "private, no-cache"
+
+// ContentETag returns strong entity tag of response body served as is
+func ContentETag(content []byte) string {
+	sum := sha256.Sum256(content)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// WeakETag returns weak entity tag of content wrapped into response envelope.
+// Envelope meta differs between calls, so bodies are equivalent but not byte identical
+func WeakETag(content []byte) string {
+	return "W/" + ContentETag(content)
+}
+
+// DataETag returns weak entity tag of JSON response data.
+// Map keys are marshaled sorted, so equal data always gives equal tag
+func DataETag(data interface{}) (string, error) {
+	content, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal response data: %w", err)
+	}
+	return WeakETag(content), nil
+}
+
+// CheckNotModified sets ETag and Cache-Control headers and answers 304 when If-None-Match matches etag.
+// Returns true when response is complete and handler must not write body
+func CheckNotModified(c *gin.Context, etag string) bool {
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", CacheControlRevalidate)
+
+	if !etagMatches(c.GetHeader("If-None-Match"), etag) {
+		return false
+	}
+	c.Status(http.StatusNotModified)
+	c.Abort()
+	return true
+}
+
+// etagMatches checks If-None-Match header with weak comparison as required for conditional GET
+func etagMatches(header, etag string) bool {
+	if header == "" {
+		return false
+	}
+	target := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == target {
+			return true
+		}
+	}
+	return false
+}
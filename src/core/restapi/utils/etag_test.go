@@ -0,0 +1,97 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package utils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDataETagIsStableForEqualData(t *testing.T) {
+	first, err := DataETag(map[string]interface{}{"a": 1, "b": "x", "c": []int{1, 2}})
+	if err != nil {
+		t.Fatalf("data etag: %v", err)
+	}
+	second, _ := DataETag(map[string]interface{}{"c": []int{1, 2}, "b": "x", "a": 1})
+	changed, _ := DataETag(map[string]interface{}{"a": 2, "b": "x", "c": []int{1, 2}})
+
+	if first != second {
+		t.Errorf("equal data got different tags %s and %s", first, second)
+	}
+	if first == changed {
+		t.Errorf("changed data kept tag %s", first)
+	}
+}
+
+// TestCheckNotModifiedSequence serves 200, then 304 for cached tag, then 200 after content change
+func TestCheckNotModifiedSequence(t *testing.T) {
+	content := []byte("<definitions>v1</definitions>")
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/xml", func(c *gin.Context) {
+		if CheckNotModified(c, ContentETag(content)) {
+			return
+		}
+		c.Data(http.StatusOK, "application/xml", content)
+	})
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/xml", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, req)
+		return recorder
+	}
+
+	first := get("")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || first.Body.String() != string(content) {
+		t.Fatalf("first GET: status %d, ETag %q, body %q", first.Code, etag, first.Body.String())
+	}
+
+	if cached := get(etag); cached.Code != http.StatusNotModified || cached.Body.Len() != 0 {
+		t.Fatalf("GET with matching ETag: status %d, body %q", cached.Code, cached.Body.String())
+	}
+
+	content = []byte("<definitions>v2</definitions>")
+	changed := get(etag)
+	if changed.Code != http.StatusOK || changed.Body.String() != string(content) {
+		t.Fatalf("GET after change: status %d, body %q", changed.Code, changed.Body.String())
+	}
+	if changed.Header().Get("ETag") == etag {
+		t.Errorf("ETag not changed after content change: %s", etag)
+	}
+}
+
+func TestETagMatches(t *testing.T) {
+	const etag = `W/"abc"`
+
+	tests := []struct {
+		header string
+		want   bool
+	}{
+		{"", false},
+		{`W/"abc"`, true},
+		{`"abc"`, true},
+		{`"other", W/"abc"`, true},
+		{"*", true},
+		{`"other"`, false},
+		{`"abcd"`, false},
+	}
+	for _, tt := range tests {
+		if got := etagMatches(tt.header, etag); got != tt.want {
+			t.Errorf("etagMatches(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"atom-engine/src/core/restapi/models"
+)
+
+// variablesField is JSON field of list items removed when variables are not included
+const variablesField = "variables"
+
+// FieldSelection is projection of list items requested by ?fields= and ?include_variables=
+type FieldSelection struct {
+	Fields           []string // Whitelist of JSON fields, nil keeps all fields
+	IncludeVariables bool     // False means variables are neither loaded nor returned
+}
+
+// ParseFieldSelection parses comma-separated ?fields= whitelist and ?include_variables= flag.
+// Without parameters all fields are returned, including variables
+func ParseFieldSelection(c *gin.Context) (FieldSelection, *models.APIError) {
+	selection := FieldSelection{IncludeVariables: true}
+
+	if value := c.Query("include_variables"); value != "" {
+		include, err := strconv.ParseBool(value)
+		if err != nil {
+			return selection, models.BadRequestError("include_variables must be true or false")
+		}
+		selection.IncludeVariables = include
+	}
+
+	if value := c.Query("fields"); value != "" {
+		fields, apiErr := SelectFields(strings.Split(value, ","))
+		if apiErr != nil {
+			return selection, apiErr
+		}
+		selection.Fields = fields.Fields
+	}
+
+	return selection, nil
+}
+
+// SelectFields creates selection keeping listed fields, empty list keeps all fields.
+// Dotted name selects nested value, e.g. variables.order.id keeps only id of order variable
+func SelectFields(fields []string) (FieldSelection, *models.APIError) {
+	selection := FieldSelection{IncludeVariables: true}
+	if len(fields) == 0 {
+		return selection, nil
+	}
+
+	for _, field := range fields {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		for _, segment := range strings.Split(field, ".") {
+			if segment == "" {
+				return selection, models.BadRequestError(fmt.Sprintf("field %q has empty path segment", field))
+			}
+		}
+		selection.Fields = append(selection.Fields, field)
+	}
+	if len(selection.Fields) == 0 {
+		return selection, models.BadRequestError("fields must list at least one field")
+	}
+
+	return selection, nil
+}
+
+// IsFull reports whether items are returned as is
+func (s FieldSelection) IsFull() bool {
+	return s.Fields == nil && s.IncludeVariables
+}
+
+// Apply keeps requested fields of each list item and drops variables when they are not included.
+// Field names are JSON names of items, unknown names and missing nested paths are ignored
+func (s FieldSelection) Apply(items interface{}) (interface{}, error) {
+	if s.IsFull() {
+		return items, nil
+	}
+
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal list items: %w", err)
+	}
+	var objects []map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&objects); err != nil {
+		return nil, fmt.Errorf("list items are not objects: %w", err)
+	}
+
+	for i, object := range objects {
+		if s.Fields != nil {
+			selected := make(map[string]interface{}, len(s.Fields))
+			for _, field := range s.Fields {
+				selectPath(selected, object, strings.Split(field, "."))
+			}
+			object = selected
+		}
+		if !s.IncludeVariables {
+			delete(object, variablesField)
+		}
+		objects[i] = object
+	}
+	return objects, nil
+}
+
+// selectPath copies value at path from source into target, creating intermediate objects.
+// Whole value selected by shorter path is kept when longer path into it is also requested
+func selectPath(target, source map[string]interface{}, path []string) {
+	value, ok := source[path[0]]
+	if !ok {
+		return
+	}
+	if len(path) == 1 {
+		target[path[0]] = value
+		return
+	}
+
+	nestedSource, ok := value.(map[string]interface{})
+	if !ok {
+		return
+	}
+	// Target already holding whole source object stays unchanged, copying into it is no-op
+	if existing, exists := target[path[0]]; exists {
+		if nestedTarget, ok := existing.(map[string]interface{}); ok {
+			selectPath(nestedTarget, nestedSource, path[1:])
+		}
+		return
+	}
+
+	nestedTarget := make(map[string]interface{})
+	selectPath(nestedTarget, nestedSource, path[1:])
+	if len(nestedTarget) > 0 {
+		target[path[0]] = nestedTarget
+	}
+}
@@ -0,0 +1,105 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// orderItem is list item with nested order variable
+type orderItem struct {
+	Key       string                 `json:"key"`
+	State     string                 `json:"state"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// projected applies selection of fields to order item and returns JSON of result
+func projected(t *testing.T, fields ...string) string {
+	t.Helper()
+
+	selection, apiErr := SelectFields(fields)
+	if apiErr != nil {
+		t.Fatalf("select fields %q: %v", fields, apiErr.Message)
+	}
+	items := []orderItem{{
+		Key:   "job-1",
+		State: "ACTIVATED",
+		Variables: map[string]interface{}{
+			"order": map[string]interface{}{
+				"id": "o-1", "total": 120, "customer": map[string]interface{}{"name": "Ann"},
+			},
+			"priority": "high",
+		},
+	}}
+	result, err := selection.Apply(items)
+	if err != nil {
+		t.Fatalf("apply selection: %v", err)
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatalf("encode result: %v", err)
+	}
+	return string(data)
+}
+
+func TestFieldSelectionKeepsNestedVariablePaths(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields []string
+		want   string
+	}{
+		{
+			name:   "top-level fields",
+			fields: []string{"key", "state"},
+			want:   `[{"key":"job-1","state":"ACTIVATED"}]`,
+		},
+		{
+			name:   "nested variable",
+			fields: []string{"key", "variables.order.id"},
+			want:   `[{"key":"job-1","variables":{"order":{"id":"o-1"}}}]`,
+		},
+		{
+			name:   "sibling nested paths",
+			fields: []string{"variables.order.id", "variables.order.customer.name", "variables.priority"},
+			want:   `[{"variables":{"order":{"customer":{"name":"Ann"},"id":"o-1"},"priority":"high"}}]`,
+		},
+		{
+			name:   "whole object kept with path into it",
+			fields: []string{"variables.order", "variables.order.id"},
+			want:   `[{"variables":{"order":{"customer":{"name":"Ann"},"id":"o-1","total":120}}}]`,
+		},
+		{
+			name:   "unknown field and missing path ignored",
+			fields: []string{"key", "worker", "variables.order.missing", "variables.priority.level"},
+			want:   `[{"key":"job-1"}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := projected(t, tt.fields...); got != tt.want {
+				t.Errorf("projection %q = %s, want %s", tt.fields, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectFieldsRejectsEmptyPathSegments(t *testing.T) {
+	for _, fields := range [][]string{{"variables..id"}, {".key"}, {"variables."}, {" ", ""}} {
+		if _, apiErr := SelectFields(fields); apiErr == nil {
+			t.Errorf("fields %q accepted", fields)
+		}
+	}
+
+	selection, apiErr := SelectFields(nil)
+	if apiErr != nil || !selection.IsFull() {
+		t.Errorf("empty field list: selection %+v, error %v, want all fields", selection, apiErr)
+	}
+}
@@ -14,6 +14,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"atom-engine/src/core/models"
 )
 
 // GenerateSecureRequestID generates a cryptographically secure request ID using UUID v4 standard
@@ -28,6 +30,11 @@ func GenerateSecureRequestID(prefix string) string {
 	return fmt.Sprintf("%s_%s", prefix, uuid)
 }
 
+// GenerateULID generates a lexicographically sortable ULID, monotonic within the same millisecond
+func GenerateULID() string {
+	return models.GenerateULID()
+}
+
 // generateUUIDv4 generates a UUID v4 (random) compliant with RFC 4122
 func generateUUIDv4() string {
 	// UUID v4 requires 16 random bytes
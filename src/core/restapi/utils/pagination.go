@@ -11,6 +11,7 @@ package utils
 import (
 	"math"
 	"strconv"
+	"strings"
 
 	"atom-engine/src/core/restapi/models"
 )
@@ -84,49 +85,15 @@ func ValidatePaginationParams(params models.PaginationParams) *models.APIError {
 	return nil
 }
 
-// ApplyPagination applies pagination to a slice
-func ApplyPagination(items interface{}, page, limit int) (interface{}, *models.PaginationInfo) {
-	switch v := items.(type) {
-	case []interface{}:
-		return applySlicePagination(v, page, limit)
-	case []string:
-		return applyStringSlicePagination(v, page, limit)
-	case []map[string]interface{}:
-		return applyMapSlicePagination(v, page, limit)
-	default:
-		// Return original if type not supported
-		return items, CalculatePaginationInfo(page, limit, 0)
-	}
-}
-
-// applySlicePagination applies pagination to []interface{}
-func applySlicePagination(items []interface{}, page, limit int) ([]interface{}, *models.PaginationInfo) {
+// ApplyPagination returns requested page of collection that is already in memory.
+// Lists stored by components are paginated by components with limit and offset instead of loading them whole
+func ApplyPagination[T any](items []T, page, limit int) ([]T, *models.PaginationInfo) {
 	totalCount := len(items)
 	offset := GetOffset(page, limit)
 
 	// Handle empty slice or offset beyond bounds
 	if totalCount == 0 || offset >= totalCount {
-		return []interface{}{}, CalculatePaginationInfo(page, limit, totalCount)
-	}
-
-	end := offset + limit
-	if end > totalCount {
-		end = totalCount
-	}
-
-	paginatedItems := items[offset:end]
-	paginationInfo := CalculatePaginationInfo(page, limit, totalCount)
-
-	return paginatedItems, paginationInfo
-}
-
-// applyStringSlicePagination applies pagination to []string
-func applyStringSlicePagination(items []string, page, limit int) ([]string, *models.PaginationInfo) {
-	totalCount := len(items)
-	offset := GetOffset(page, limit)
-
-	if totalCount == 0 || offset >= totalCount {
-		return []string{}, CalculatePaginationInfo(page, limit, totalCount)
+		return []T{}, CalculatePaginationInfo(page, limit, totalCount)
 	}
 
 	end := offset + limit
@@ -134,33 +101,26 @@ func applyStringSlicePagination(items []string, page, limit int) ([]string, *mod
 		end = totalCount
 	}
 
-	paginatedItems := items[offset:end]
-	paginationInfo := CalculatePaginationInfo(page, limit, totalCount)
-
-	return paginatedItems, paginationInfo
+	return items[offset:end], CalculatePaginationInfo(page, limit, totalCount)
 }
 
-// applyMapSlicePagination applies pagination to []map[string]interface{}
-func applyMapSlicePagination(
-	items []map[string]interface{},
-	page, limit int,
-) ([]map[string]interface{}, *models.PaginationInfo) {
-	totalCount := len(items)
-	offset := GetOffset(page, limit)
-
-	if totalCount == 0 || offset >= totalCount {
-		return []map[string]interface{}{}, CalculatePaginationInfo(page, limit, totalCount)
+// ExtractTotalCount reads total count of list response of component by path of JSON keys,
+// e.g. "result", "total". Missing or non-numeric value gives 0
+func ExtractTotalCount(response map[string]interface{}, path ...string) int {
+	var value interface{} = response
+	for _, key := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return 0
+		}
+		value = object[key]
 	}
 
-	end := offset + limit
-	if end > totalCount {
-		end = totalCount
+	count, ok := value.(float64)
+	if !ok {
+		return 0
 	}
-
-	paginatedItems := items[offset:end]
-	paginationInfo := CalculatePaginationInfo(page, limit, totalCount)
-
-	return paginatedItems, paginationInfo
+	return int(count)
 }
 
 // PaginationHelper provides pagination utilities
@@ -182,6 +142,37 @@ func (h *PaginationHelper) ParseAndValidate(pageStr, limitStr string) (models.Pa
 	return params, nil
 }
 
+// ParseSort parses sort_by and sort_order against allowed sort fields of endpoint
+// Empty sort_by selects defaultField, empty sort_order selects descending order
+func (h *PaginationHelper) ParseSort(
+	sortBy, sortOrder string,
+	allowedFields []string,
+	defaultField string,
+) (models.SortParams, *models.APIError) {
+	sort := models.SortParams{By: defaultField, Order: models.SortOrderDesc}
+	if sortBy != "" {
+		sort.By = sortBy
+	}
+	if sortOrder != "" {
+		sort.Order = strings.ToLower(sortOrder)
+	}
+
+	validator := NewValidator()
+	var errors []models.ValidationError
+	if err := validator.ValidateStringEnum(sort.By, "sort_by", allowedFields); err != nil {
+		errors = append(errors, *err)
+	}
+	orders := []string{models.SortOrderAsc, models.SortOrderDesc}
+	if err := validator.ValidateStringEnum(sort.Order, "sort_order", orders); err != nil {
+		errors = append(errors, *err)
+	}
+	if len(errors) > 0 {
+		return sort, models.NewValidationError("Invalid sort parameters", errors)
+	}
+
+	return sort, nil
+}
+
 // CreateResponse creates paginated response
 func (h *PaginationHelper) CreateResponse(
 	items interface{},
@@ -217,5 +208,5 @@ func FilterAndPaginate(
 	}
 
 	// Apply pagination
-	return applyMapSlicePagination(filteredItems, page, limit)
+	return ApplyPagination(filteredItems, page, limit)
 }
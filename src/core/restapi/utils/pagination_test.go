@@ -0,0 +1,141 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package utils
+
+import (
+	"fmt"
+	"testing"
+
+	"atom-engine/src/core/restapi/models"
+)
+
+// timerItem stands for typed in-memory list element
+type timerItem struct {
+	ID string
+}
+
+// timerItems returns count items with IDs timer-0 .. timer-(count-1)
+func timerItems(count int) []timerItem {
+	items := make([]timerItem, count)
+	for i := range items {
+		items[i] = timerItem{ID: fmt.Sprintf("timer-%d", i)}
+	}
+	return items
+}
+
+func TestApplyPagination(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       int
+		page, limit int
+		wantFirst   string
+		wantCount   int
+		wantInfo    models.PaginationInfo
+	}{
+		{"first page", 45, 1, 20, "timer-0", 20,
+			models.PaginationInfo{Page: 1, Limit: 20, Total: 45, Pages: 3, HasNext: true}},
+		{"middle page", 45, 2, 20, "timer-20", 20,
+			models.PaginationInfo{Page: 2, Limit: 20, Total: 45, Pages: 3, HasNext: true, HasPrev: true}},
+		{"partial last page", 45, 3, 20, "timer-40", 5,
+			models.PaginationInfo{Page: 3, Limit: 20, Total: 45, Pages: 3, HasPrev: true}},
+		{"exact last page", 40, 2, 20, "timer-20", 20,
+			models.PaginationInfo{Page: 2, Limit: 20, Total: 40, Pages: 2, HasPrev: true}},
+		{"page beyond end", 45, 4, 20, "", 0,
+			models.PaginationInfo{Page: 4, Limit: 20, Total: 45, Pages: 3, HasPrev: true}},
+		{"limit above total", 3, 1, 100, "timer-0", 3,
+			models.PaginationInfo{Page: 1, Limit: 100, Total: 3, Pages: 1}},
+		{"empty list", 0, 1, 20, "", 0,
+			models.PaginationInfo{Page: 1, Limit: 20}},
+		{"page zero is first page", 5, 0, 2, "timer-0", 2,
+			models.PaginationInfo{Page: 0, Limit: 2, Total: 5, Pages: 3, HasNext: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, info := ApplyPagination(timerItems(tt.total), tt.page, tt.limit)
+			if page == nil {
+				t.Fatal("page must be empty slice, not nil, to be encoded as []")
+			}
+			if len(page) != tt.wantCount {
+				t.Fatalf("page has %d items, want %d", len(page), tt.wantCount)
+			}
+			if tt.wantCount > 0 && page[0].ID != tt.wantFirst {
+				t.Errorf("first item %s, want %s", page[0].ID, tt.wantFirst)
+			}
+			if *info != tt.wantInfo {
+				t.Errorf("pagination %+v, want %+v", *info, tt.wantInfo)
+			}
+		})
+	}
+}
+
+func TestApplyPaginationPagesCoverListOnce(t *testing.T) {
+	items := timerItems(47)
+
+	seen := make(map[string]bool)
+	for page := 1; ; page++ {
+		pageItems, info := ApplyPagination(items, page, 10)
+		for _, item := range pageItems {
+			if seen[item.ID] {
+				t.Fatalf("item %s returned twice", item.ID)
+			}
+			seen[item.ID] = true
+		}
+		if !info.HasNext {
+			break
+		}
+	}
+	if len(seen) != len(items) {
+		t.Errorf("pages returned %d of %d items", len(seen), len(items))
+	}
+}
+
+func TestExtractTotalCount(t *testing.T) {
+	response := map[string]interface{}{
+		"total_count": float64(12),
+		"result":      map[string]interface{}{"total": float64(7), "items": []interface{}{}},
+		"text":        "5",
+	}
+
+	tests := []struct {
+		path []string
+		want int
+	}{
+		{[]string{"total_count"}, 12},
+		{[]string{"result", "total"}, 7},
+		{[]string{"result", "missing"}, 0},
+		{[]string{"total_count", "nested"}, 0},
+		{[]string{"text"}, 0},
+		{[]string{"missing"}, 0},
+	}
+	for _, tt := range tests {
+		if got := ExtractTotalCount(response, tt.path...); got != tt.want {
+			t.Errorf("ExtractTotalCount(%v) = %d, want %d", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestFilterAndPaginate(t *testing.T) {
+	var items []map[string]interface{}
+	for i := 0; i < 9; i++ {
+		status := "active"
+		if i%3 == 0 {
+			status = "done"
+		}
+		items = append(items, map[string]interface{}{"id": i, "status": status})
+	}
+
+	page, info := FilterAndPaginate(items, map[string]interface{}{"status": "active"}, 2, 4)
+	if info.Total != 6 || info.Pages != 2 || info.HasNext {
+		t.Fatalf("pagination %+v, want 6 active items on 2 pages", *info)
+	}
+	if len(page) != 2 || page[0]["id"] != 7 || page[1]["id"] != 8 {
+		t.Fatalf("second page %v, want items 7 and 8", page)
+	}
+}
@@ -0,0 +1,81 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package utils
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+
+	coremodels "atom-engine/src/core/models"
+	"atom-engine/src/core/tracing"
+)
+
+// RequestIDHeader is HTTP header carrying request ID
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDContextKey is gin context key holding request ID
+const RequestIDContextKey = "request_id"
+
+// maxRequestIDLength limits client supplied request ID
+const maxRequestIDLength = 128
+
+// AssignRequestID resolves request ID for current request.
+// Accepts valid X-Request-ID header, otherwise generates new one.
+// ID is stored in gin context and request context and echoed in X-Request-ID response header
+func AssignRequestID(c *gin.Context) string {
+	requestID := RequestIDFromHeader(c.GetHeader(RequestIDHeader))
+
+	c.Set(RequestIDContextKey, requestID)
+	c.Header(RequestIDHeader, requestID)
+	c.Request = c.Request.WithContext(coremodels.ContextWithRequestID(c.Request.Context(), requestID))
+	return requestID
+}
+
+// GetRequestID returns request ID stored in context by request ID middleware.
+// Assigns ID when middleware did not run so all handlers of one request share the same ID
+func GetRequestID(c *gin.Context) string {
+	if requestID := c.GetString(RequestIDContextKey); requestID != "" {
+		return requestID
+	}
+	return AssignRequestID(c)
+}
+
+// BackgroundContext returns context carrying request ID and request span that is not cancelled
+// when client disconnects, for component and gRPC calls that must run to completion
+func BackgroundContext(c *gin.Context) context.Context {
+	ctx := coremodels.ContextWithRequestID(context.Background(), GetRequestID(c))
+	return tracing.ContextWithSpan(ctx, c.Request.Context())
+}
+
+// RequestIDFromHeader returns X-Request-ID header value when it is valid, otherwise generates new ID.
+// Used by code running outside of gin before request ID middleware
+func RequestIDFromHeader(value string) string {
+	if isValidRequestID(value) {
+		return value
+	}
+	return GenerateSecureRequestID("req")
+}
+
+// isValidRequestID accepts non-empty IDs of printable safe characters only,
+// so client input cannot break log lines or response headers
+func isValidRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range requestID {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
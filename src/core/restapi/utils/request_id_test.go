@@ -0,0 +1,82 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// generatedRequestID matches request IDs generated for requests without valid X-Request-ID
+var generatedRequestID = regexp.MustCompile(`^req_[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+
+func TestGenerateSecureRequestIDUniqueUnderConcurrency(t *testing.T) {
+	const goroutines, perGoroutine = 16, 5000
+
+	results := make([][]string, goroutines)
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			ids := make([]string, perGoroutine)
+			for i := range ids {
+				ids[i] = RequestIDFromHeader("")
+			}
+			results[g] = ids
+		}(g)
+	}
+	wg.Wait()
+
+	seen := make(map[string]bool, goroutines*perGoroutine)
+	for _, ids := range results {
+		for _, id := range ids {
+			if !generatedRequestID.MatchString(id) {
+				t.Fatalf("generated request ID %q is not prefixed UUID v4", id)
+			}
+			if seen[id] {
+				t.Fatalf("duplicate request ID %s", id)
+			}
+			seen[id] = true
+		}
+	}
+}
+
+func TestRequestIDFromHeader(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		echoed bool
+	}{
+		{"client ID", "client-42", true},
+		{"all allowed characters", "Az09-_.:", true},
+		{"max length", strings.Repeat("a", maxRequestIDLength), true},
+		{"empty", "", false},
+		{"too long", strings.Repeat("a", maxRequestIDLength+1), false},
+		{"space", "client 42", false},
+		{"header injection", "client\r\nX-Other: 1", false},
+		{"non ascii", "запрос", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RequestIDFromHeader(tt.header)
+			if tt.echoed {
+				if got != tt.header {
+					t.Errorf("valid request ID replaced with %q", got)
+				}
+				return
+			}
+			if !generatedRequestID.MatchString(got) {
+				t.Errorf("invalid request ID %q not replaced by generated one, got %q", tt.header, got)
+			}
+		})
+	}
+}
@@ -9,24 +9,52 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package utils
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 	"unicode/utf8"
 
 	"atom-engine/src/core/restapi/models"
+	"atom-engine/src/timewheel"
 )
 
+// DefaultVariableKeyPattern allows variable names addressable from expressions
+const DefaultVariableKeyPattern = `^[A-Za-z_][A-Za-z0-9_]*$`
+
 // Validator provides request validation utilities
-type Validator struct{}
+type Validator struct {
+	variableKeyPattern *regexp.Regexp
+	variableLimits     VariableLimitsConfig
+}
 
 // NewValidator creates new validator instance
 func NewValidator() *Validator {
 	return &Validator{}
 }
 
+// NewVariableValidator creates validator enforcing variable rules from config
+// Nil config and zero limits disable corresponding checks
+func NewVariableValidator(config *VariableLimitsConfig) (*Validator, error) {
+	v := NewValidator()
+	if config == nil {
+		return v, nil
+	}
+
+	v.variableLimits = *config
+	if config.KeyPattern != "" {
+		pattern, err := regexp.Compile(config.KeyPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid variable key pattern %q: %w", config.KeyPattern, err)
+		}
+		v.variableKeyPattern = pattern
+	}
+	return v, nil
+}
+
 // ValidateRequired validates required fields
 func (v *Validator) ValidateRequired(value interface{}, fieldName string) *models.ValidationError {
 	if value == nil {
@@ -112,10 +140,10 @@ func (v *Validator) ValidatePattern(value, fieldName, pattern, patternName strin
 	return nil
 }
 
-// ValidateID validates ID format (NanoID)
+// ValidateID validates ID format (NanoID or ULID) or numeric key
 func (v *Validator) ValidateID(value, fieldName string) *models.ValidationError {
-	// NanoID pattern: 4-char prefix + hyphen + 18-char NanoID
-	pattern := `^[a-zA-Z0-9]{4}-[a-zA-Z0-9_-]{18}$`
+	// NanoID pattern: 4-char prefix + hyphen + 18-char NanoID, 26-char ULID, or positive int64 key
+	pattern := `^([a-zA-Z0-9]{4}-[a-zA-Z0-9_-]{18}|[0-7][0-9A-HJKMNP-TV-Z]{25}|[1-9][0-9]{0,18})$`
 	return v.ValidatePattern(value, fieldName, pattern, "ID")
 }
 
@@ -160,6 +188,22 @@ func (v *Validator) ValidateISO8601Duration(value, fieldName string) *models.Val
 	}
 }
 
+// ValidateTimeCycle validates timer cycle given as ISO 8601 repeating interval or cron expression
+func (v *Validator) ValidateTimeCycle(value, fieldName string) *models.ValidationError {
+	if !timewheel.IsCronExpression(value) {
+		return v.ValidateISO8601Duration(value, fieldName)
+	}
+
+	if _, err := timewheel.ParseCron(value); err != nil {
+		return &models.ValidationError{
+			Field:   fieldName,
+			Value:   value,
+			Message: fmt.Sprintf("%s must be valid cron expression: %v", fieldName, err),
+		}
+	}
+	return nil
+}
+
 // ValidateEmail validates email format
 func (v *Validator) ValidateEmail(value, fieldName string) *models.ValidationError {
 	pattern := `^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`
@@ -309,6 +353,92 @@ func (v *Validator) ValidateJSON(value, fieldName string) *models.ValidationErro
 	return nil
 }
 
+// ValidateVariables validates variable names, count, nesting depth and serialized size
+// Returns one error per offending key so clients can fix all of them at once
+func (v *Validator) ValidateVariables(variables map[string]interface{}) []models.ValidationError {
+	limits := v.variableLimits
+	if limits.MaxKeys > 0 && len(variables) > limits.MaxKeys {
+		return []models.ValidationError{{
+			Field:   "variables",
+			Value:   len(variables),
+			Message: fmt.Sprintf("variables must contain at most %d keys", limits.MaxKeys),
+		}}
+	}
+
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errors []models.ValidationError
+	for _, name := range names {
+		field := "variables." + name
+		if v.variableKeyPattern != nil && !v.variableKeyPattern.MatchString(name) {
+			errors = append(errors, models.ValidationError{
+				Field:   field,
+				Value:   name,
+				Message: fmt.Sprintf("variable name must match pattern %s", v.variableKeyPattern.String()),
+			})
+			continue
+		}
+
+		value := variables[name]
+		if limits.MaxDepth > 0 && exceedsDepth(value, 1, limits.MaxDepth) {
+			errors = append(errors, models.ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("variable nesting depth must be at most %d", limits.MaxDepth),
+			})
+			continue
+		}
+
+		if limits.MaxSerializedSize > 0 {
+			data, err := json.Marshal(value)
+			if err != nil {
+				errors = append(errors, models.ValidationError{
+					Field:   field,
+					Message: "variable value is not serializable",
+				})
+				continue
+			}
+			if size := int64(len(data)); size > limits.MaxSerializedSize {
+				errors = append(errors, models.ValidationError{
+					Field: field,
+					Value: size,
+					Message: fmt.Sprintf("variable serialized size must be at most %d bytes",
+						limits.MaxSerializedSize),
+				})
+			}
+		}
+	}
+
+	return errors
+}
+
+// exceedsDepth reports whether value nests objects or arrays deeper than maxDepth
+// Walk stops at limit, so hostile payloads are not traversed completely
+func exceedsDepth(value interface{}, depth, maxDepth int) bool {
+	if depth > maxDepth {
+		return true
+	}
+
+	switch val := value.(type) {
+	case map[string]interface{}:
+		for _, item := range val {
+			if exceedsDepth(item, depth+1, maxDepth) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, item := range val {
+			if exceedsDepth(item, depth+1, maxDepth) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // ValidateMultiple validates multiple constraints and returns all errors
 func (v *Validator) ValidateMultiple(validations ...func() *models.ValidationError) []models.ValidationError {
 	var errors []models.ValidationError
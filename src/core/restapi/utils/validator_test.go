@@ -0,0 +1,97 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// nested returns value wrapped into depth levels of objects
+func nested(depth int) interface{} {
+	var value interface{} = "leaf"
+	for i := 0; i < depth; i++ {
+		value = map[string]interface{}{"child": value}
+	}
+	return value
+}
+
+func TestValidateVariablesLimits(t *testing.T) {
+	validator, err := NewVariableValidator(&VariableLimitsConfig{
+		KeyPattern:        DefaultVariableKeyPattern,
+		MaxKeys:           3,
+		MaxDepth:          3,
+		MaxSerializedSize: 64,
+	})
+	if err != nil {
+		t.Fatalf("create validator: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		variables  map[string]interface{}
+		wantFields []string
+	}{
+		{"valid variables", map[string]interface{}{"orderId": 7, "_meta": nested(2)}, nil},
+		{"no variables", nil, nil},
+		{"key with dot", map[string]interface{}{"order.id": 7}, []string{"variables.order.id"}},
+		{"key with space", map[string]interface{}{"order id": 7}, []string{"variables.order id"}},
+		{"key starting with digit", map[string]interface{}{"1st": 7}, []string{"variables.1st"}},
+		{"too many keys", map[string]interface{}{"a": 1, "b": 2, "c": 3, "d": 4}, []string{"variables"}},
+		{"object nested too deep", map[string]interface{}{"tree": nested(3)}, []string{"variables.tree"}},
+		{"array nested too deep", map[string]interface{}{
+			"matrix": []interface{}{[]interface{}{[]interface{}{1}}},
+		}, []string{"variables.matrix"}},
+		{"depth at limit", map[string]interface{}{"tree": nested(2)}, nil},
+		{"serialized value too large", map[string]interface{}{"note": strings.Repeat("x", 100)},
+			[]string{"variables.note"}},
+		{"all offending keys listed in order", map[string]interface{}{
+			"ok":      1,
+			"bad key": 2,
+			"big":     strings.Repeat("x", 100),
+		}, []string{"variables.bad key", "variables.big"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fields []string
+			for _, validationError := range validator.ValidateVariables(tt.variables) {
+				fields = append(fields, validationError.Field)
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("offending fields %q, want %q", fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestValidateVariablesZeroLimitsDisableRules(t *testing.T) {
+	validator, err := NewVariableValidator(&VariableLimitsConfig{})
+	if err != nil {
+		t.Fatalf("create validator: %v", err)
+	}
+
+	variables := map[string]interface{}{
+		"order.id": nested(100),
+		"note":     strings.Repeat("x", 1<<20),
+	}
+	if validationErrors := validator.ValidateVariables(variables); len(validationErrors) != 0 {
+		t.Errorf("validator without rules reported %v", validationErrors)
+	}
+	if validationErrors := NewValidator().ValidateVariables(variables); len(validationErrors) != 0 {
+		t.Errorf("default validator reported %v", validationErrors)
+	}
+}
+
+func TestNewVariableValidatorRejectsInvalidKeyPattern(t *testing.T) {
+	if _, err := NewVariableValidator(&VariableLimitsConfig{KeyPattern: "[a-z"}); err == nil {
+		t.Error("invalid key pattern accepted")
+	}
+}
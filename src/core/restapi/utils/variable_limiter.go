@@ -0,0 +1,120 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+
+	coremodels "atom-engine/src/core/models"
+	"atom-engine/src/core/restapi/models"
+)
+
+// VariableLimitsConfig holds variable size limits, validation rules and offloading settings
+type VariableLimitsConfig struct {
+	MaxVariableSize  int64 `yaml:"max_variable_size"`
+	MaxPayloadSize   int64 `yaml:"max_payload_size"`
+	OffloadEnabled   bool  `yaml:"offload_enabled"`
+	OffloadThreshold int64 `yaml:"offload_threshold"`
+
+	// Validation rules enforced by Validator.ValidateVariables, zero disables a rule
+	KeyPattern        string `yaml:"key_pattern"`
+	MaxKeys           int    `yaml:"max_keys"`
+	MaxDepth          int    `yaml:"max_depth"`
+	MaxSerializedSize int64  `yaml:"max_serialized_size"`
+}
+
+// VariableBlobStore stores offloaded variable values
+type VariableBlobStore interface {
+	SaveVariableBlob(blobID string, data []byte) error
+}
+
+// VariableLimiter enforces variable size limits and offloads large values
+type VariableLimiter struct {
+	config    *VariableLimitsConfig
+	blobStore VariableBlobStore
+}
+
+// NewVariableLimiter creates new variable limiter
+// Nil config disables all limits, nil blob store disables offloading
+func NewVariableLimiter(config *VariableLimitsConfig, blobStore VariableBlobStore) *VariableLimiter {
+	return &VariableLimiter{
+		config:    config,
+		blobStore: blobStore,
+	}
+}
+
+// Apply validates variables against limits and offloads oversized values
+// Returns variables that should be passed to engine instead of original map
+func (l *VariableLimiter) Apply(
+	variables map[string]interface{},
+	fieldName string,
+) (map[string]interface{}, *models.APIError) {
+	if l == nil || l.config == nil || len(variables) == 0 {
+		return variables, nil
+	}
+
+	sizes := make(map[string]int64, len(variables))
+	var totalSize int64
+
+	for name, value := range variables {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, models.BadRequestError(fmt.Sprintf("%s.%s is not serializable: %v", fieldName, name, err))
+		}
+
+		size := int64(len(data))
+		if l.config.MaxVariableSize > 0 && size > l.config.MaxVariableSize {
+			return nil, models.PayloadTooLargeError(
+				fmt.Sprintf("Variable %s.%s exceeds maximum size", fieldName, name),
+				map[string]interface{}{
+					"variable":   name,
+					"size_bytes": size,
+					"limit":      l.config.MaxVariableSize,
+				},
+			)
+		}
+
+		sizes[name] = size
+		totalSize += size
+	}
+
+	if l.config.MaxPayloadSize > 0 && totalSize > l.config.MaxPayloadSize {
+		return nil, models.PayloadTooLargeError(
+			fmt.Sprintf("%s exceed maximum payload size", fieldName),
+			map[string]interface{}{
+				"size_bytes": totalSize,
+				"limit":      l.config.MaxPayloadSize,
+			},
+		)
+	}
+
+	if !l.config.OffloadEnabled || l.blobStore == nil || l.config.OffloadThreshold <= 0 {
+		return variables, nil
+	}
+
+	result := make(map[string]interface{}, len(variables))
+	for name, value := range variables {
+		size := sizes[name]
+		if size < l.config.OffloadThreshold {
+			result[name] = value
+			continue
+		}
+
+		data, _ := json.Marshal(value)
+		blobID := coremodels.GenerateID()
+		if err := l.blobStore.SaveVariableBlob(blobID, data); err != nil {
+			return nil, models.NewAPIError(models.ErrorCodeStorageError,
+				fmt.Sprintf("Failed to offload variable %s.%s: %v", fieldName, name, err))
+		}
+		result[name] = coremodels.NewVariableBlobRef(blobID, size)
+	}
+
+	return result, nil
+}
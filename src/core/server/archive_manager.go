@@ -0,0 +1,127 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"atom-engine/src/core/archive"
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+	"atom-engine/src/core/objectstore"
+	"atom-engine/src/version"
+)
+
+// ExportProcessInstance builds portable archive of process instance state
+// Строит переносимый архив состояния экземпляра процесса
+func (c *Core) ExportProcessInstance(instanceID string) (*archive.ProcessArchive, error) {
+	if c.storage == nil {
+		return nil, fmt.Errorf("storage not available")
+	}
+
+	exporter, err := c.newArchiveExporter()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := exporter.Export(instanceID)
+	if err != nil {
+		return nil, err
+	}
+
+	result.EngineVersion = version.Version
+	result.SourceNode = c.config.InstanceName
+
+	logger.Info("Process instance exported",
+		logger.String("instance_id", instanceID),
+		logger.Int("tokens", len(result.Tokens)),
+		logger.Int("jobs", len(result.Jobs)),
+		logger.Int("redacted_keys", len(result.RedactedKeys)))
+
+	return result, nil
+}
+
+// exportPrefix is object store key prefix of instance exports
+// Префикс ключей объектного хранилища для экспортов экземпляров
+const exportPrefix = "exports/"
+
+// StoreProcessInstanceExport writes process instance archive into object storage
+// Записывает архив экземпляра процесса в объектное хранилище
+func (c *Core) StoreProcessInstanceExport(ctx context.Context, instanceID string) (*objectstore.ObjectInfo, error) {
+	if c.objectStore == nil {
+		return nil, fmt.Errorf("object storage not available")
+	}
+
+	processArchive, err := c.ExportProcessInstance(instanceID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(processArchive)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize archive: %w", err)
+	}
+
+	key := fmt.Sprintf("%s%s/%s.json", exportPrefix, processArchive.Instance.InstanceID,
+		processArchive.ExportedAt.UTC().Format("20060102T150405.000Z"))
+	object, err := c.objectStore.Put(ctx, key, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to store process instance export: %w", err)
+	}
+
+	logger.Info("Process instance export stored",
+		logger.String("instance_id", instanceID),
+		logger.String("location", object.Location),
+		logger.String("sha256", object.SHA256))
+	return object, nil
+}
+
+// ImportProcessInstance loads process archive with remapped IDs
+// Allowed only when archive import is enabled in configuration
+// Загружает архив процесса с переназначенными ID
+func (c *Core) ImportProcessInstance(processArchive *archive.ProcessArchive) (*archive.ImportResult, error) {
+	if !c.config.Archive.ImportEnabled {
+		return nil, archive.ErrImportDisabled
+	}
+	if c.storage == nil {
+		return nil, fmt.Errorf("storage not available")
+	}
+	if c.parserComp == nil {
+		return nil, fmt.Errorf("parser component not available")
+	}
+
+	return archive.NewImporter(c.storage, c.parserComp.ImportBPMNProcess).Import(processArchive)
+}
+
+// GetArchivedInstance returns archive pointer of instance removed by retention
+// Возвращает указатель на архив экземпляра, удаленного очисткой
+func (c *Core) GetArchivedInstance(instanceID string) (*models.ArchivedInstance, error) {
+	if c.storage == nil {
+		return nil, fmt.Errorf("storage not available")
+	}
+	return c.storage.LoadArchivedInstance(instanceID)
+}
+
+// newArchiveExporter creates exporter with configured redaction
+// Создает экспортер с настроенным скрытием значений
+func (c *Core) newArchiveExporter() (*archive.Exporter, error) {
+	redactor, err := archive.NewRedactor(c.config.Archive.RedactKeyPatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	var xmlLoader archive.XMLLoader
+	if c.parserComp != nil {
+		xmlLoader = c.parserComp.GetBPMNProcessXML
+	}
+
+	return archive.NewExporter(c.storage, xmlLoader, redactor), nil
+}
@@ -0,0 +1,51 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"errors"
+	"fmt"
+
+	"atom-engine/src/core/config"
+	"atom-engine/src/core/types"
+)
+
+// ReloadAuthConfig applies auth section of reloaded configuration to running auth component.
+// API keys, allowed hosts, rate limit and audit sampling take effect for next request
+// Применяет секцию auth перечитанной конфигурации к работающему auth компоненту.
+// API ключи, разрешенные хосты, лимит запросов и сэмплирование аудита действуют со следующего запроса
+func (c *Core) ReloadAuthConfig(authConfig *config.AuthConfig) error {
+	if c.authComp == nil {
+		return errors.New("auth component is not initialized")
+	}
+	if err := c.authComp.UpdateConfig(authConfig); err != nil {
+		return fmt.Errorf("failed to update auth config: %w", err)
+	}
+	return nil
+}
+
+// GetAuditStats returns audit logger counters, nil when auth component is not initialized
+// Возвращает счетчики аудита, nil если auth компонент не инициализирован
+func (c *Core) GetAuditStats() *types.AuditStats {
+	if c.authComp == nil {
+		return nil
+	}
+	auditLogger := c.authComp.GetAuditLogger()
+	if auditLogger == nil {
+		return nil
+	}
+
+	counters := auditLogger.GetCounters()
+	return &types.AuditStats{
+		RecordedSecurity: counters.RecordedSecurity,
+		RecordedSuccess:  counters.RecordedSuccess,
+		SampledOut:       counters.SampledOut,
+		ExcludedReadOnly: counters.ExcludedReadOnly,
+	}
+}
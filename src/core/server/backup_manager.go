@@ -0,0 +1,202 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+	"atom-engine/src/core/objectstore"
+)
+
+// backupPrefix is object store key prefix of storage backups
+// Префикс ключей объектного хранилища для резервных копий storage
+const backupPrefix = "backups/"
+
+// initObjectStore creates object store from configuration
+// Создает объектное хранилище из конфигурации
+func (c *Core) initObjectStore() error {
+	store, err := c.newObjectStore()
+	if err != nil {
+		return err
+	}
+	c.objectStore = store
+
+	logger.Info("Object storage initialized", logger.String("destination", store.Describe()))
+	return nil
+}
+
+// newObjectStore creates store for configured destination
+// Создает хранилище для настроенного назначения
+func (c *Core) newObjectStore() (objectstore.Store, error) {
+	cfg := c.config.ObjectStorage
+	switch cfg.Type {
+	case "local":
+		return objectstore.NewLocalStore(cfg.LocalDir), nil
+	case "s3":
+		return objectstore.NewS3Store(objectstore.S3Options{
+			Endpoint:    cfg.S3.Endpoint,
+			Region:      cfg.S3.Region,
+			Bucket:      cfg.S3.Bucket,
+			Prefix:      cfg.S3.Prefix,
+			AccessKey:   cfg.S3.AccessKey,
+			SecretKey:   cfg.S3.SecretKey,
+			SSE:         cfg.S3.SSE,
+			SSEKMSKeyID: cfg.S3.SSEKMSKeyID,
+			PartSize:    int64(cfg.S3.PartSizeMB) << 20,
+			Timeout:     time.Duration(cfg.S3.TimeoutSeconds) * time.Second,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported object storage type: %s", cfg.Type)
+	}
+}
+
+// CreateBackup streams gzip compressed database backup into object storage
+// Backup is never buffered whole, large backups are uploaded by multipart
+// Передает сжатую gzip резервную копию базы в объектное хранилище
+// Копия не буферизуется целиком, большие копии загружаются по частям
+func (c *Core) CreateBackup(ctx context.Context) (*models.BackupInfo, error) {
+	if c.storage == nil || c.objectStore == nil {
+		return nil, fmt.Errorf("storage not available")
+	}
+	if !c.backupMu.TryLock() {
+		return nil, models.ErrBackupInProgress
+	}
+	defer c.backupMu.Unlock()
+
+	startedAt := time.Now().UTC()
+	name := fmt.Sprintf("%s-%s.badger.gz", startedAt.Format("20060102T150405Z"), c.config.InstanceName)
+
+	reader, writer := io.Pipe()
+	versions := make(chan uint64, 1)
+	go func() {
+		compressor := gzip.NewWriter(writer)
+		version, err := c.storage.Backup(compressor)
+		if closeErr := compressor.Close(); err == nil {
+			err = closeErr
+		}
+		versions <- version
+		writer.CloseWithError(err)
+	}()
+
+	object, err := c.objectStore.Put(ctx, backupPrefix+name, reader)
+	// Unblocks backup goroutine when upload stopped reading early
+	// Разблокирует горутину копии, если загрузка прекратила чтение раньше
+	reader.CloseWithError(fmt.Errorf("backup upload stopped"))
+	version := <-versions
+	if err != nil {
+		logger.Error("Storage backup failed",
+			logger.String("name", name),
+			logger.String("destination", c.objectStore.Describe()),
+			logger.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to create backup: %w", err)
+	}
+
+	info := backupInfo(object)
+	info.Version = version
+	info.DurationMs = time.Since(startedAt).Milliseconds()
+
+	logger.Info("Storage backup created",
+		logger.String("name", info.Name),
+		logger.String("location", info.Location),
+		logger.Any("size", info.Size),
+		logger.String("sha256", info.SHA256),
+		logger.Any("duration_ms", info.DurationMs))
+	return info, nil
+}
+
+// ListBackups returns backups kept in object storage ordered by name, oldest first
+// Возвращает резервные копии из объектного хранилища по имени, старые первыми
+func (c *Core) ListBackups(ctx context.Context) ([]*models.BackupInfo, error) {
+	if c.objectStore == nil {
+		return nil, fmt.Errorf("object storage not available")
+	}
+
+	objects, err := c.objectStore.List(ctx, backupPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	backups := make([]*models.BackupInfo, 0, len(objects))
+	for _, object := range objects {
+		backups = append(backups, backupInfo(object))
+	}
+	return backups, nil
+}
+
+// VerifyBackup downloads backup and checks it against checksum stored at upload
+// Скачивает резервную копию и сверяет ее с контрольной суммой, сохраненной при загрузке
+func (c *Core) VerifyBackup(ctx context.Context, name string) (*models.BackupInfo, error) {
+	if c.objectStore == nil {
+		return nil, fmt.Errorf("object storage not available")
+	}
+	if err := validateBackupName(name); err != nil {
+		return nil, err
+	}
+
+	startedAt := time.Now()
+	object, err := objectstore.Verify(ctx, c.objectStore, backupPrefix+name)
+	if err != nil {
+		return nil, err
+	}
+	if object.SHA256 == "" {
+		return nil, fmt.Errorf("backup %s has no stored checksum", name)
+	}
+
+	info := backupInfo(object)
+	info.DurationMs = time.Since(startedAt).Milliseconds()
+	logger.Info("Storage backup verified",
+		logger.String("name", name),
+		logger.String("sha256", info.SHA256))
+	return info, nil
+}
+
+// DeleteBackup removes backup from object storage
+// Удаляет резервную копию из объектного хранилища
+func (c *Core) DeleteBackup(ctx context.Context, name string) error {
+	if c.objectStore == nil {
+		return fmt.Errorf("object storage not available")
+	}
+	if err := validateBackupName(name); err != nil {
+		return err
+	}
+
+	if err := c.objectStore.Delete(ctx, backupPrefix+name); err != nil {
+		return err
+	}
+	logger.Info("Storage backup deleted", logger.String("name", name))
+	return nil
+}
+
+// validateBackupName rejects names addressing objects outside of backups, such backup cannot exist
+// Отклоняет имена, адресующие объекты вне резервных копий, такой копии не может существовать
+func validateBackupName(name string) error {
+	if strings.Contains(name, "/") || objectstore.ValidateKey(name) != nil {
+		return fmt.Errorf("%w: invalid backup name %q", objectstore.ErrNotFound, name)
+	}
+	return nil
+}
+
+// backupInfo converts stored object to backup description
+// Преобразует сохраненный объект в описание резервной копии
+func backupInfo(object *objectstore.ObjectInfo) *models.BackupInfo {
+	return &models.BackupInfo{
+		Name:      strings.TrimPrefix(object.Key, backupPrefix),
+		Location:  object.Location,
+		Size:      object.Size,
+		SHA256:    object.SHA256,
+		CreatedAt: object.LastModified,
+	}
+}
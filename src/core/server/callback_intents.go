@@ -0,0 +1,167 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+	"atom-engine/src/process"
+)
+
+// acknowledgeCallbackIntent marks callback intent done after process component handled callback
+// Token that has already moved on means callback was applied earlier, so it is treated as success
+// Отмечает намерение callback выполненным после обработки callback в process компоненте
+func (c *Core) acknowledgeCallbackIntent(intentID string, callbackErr error) {
+	if intentID == "" || c.storage == nil {
+		return
+	}
+
+	if callbackErr == nil || errors.Is(callbackErr, process.ErrTokenNotWaiting) {
+		if err := c.storage.DeleteCallbackIntent(intentID); err != nil {
+			logger.Warn("Failed to mark callback intent done",
+				logger.String("intent_id", intentID),
+				logger.String("error", err.Error()))
+		}
+		return
+	}
+
+	// Keep intent pending for replay on next startup
+	// Оставляем намерение ожидающим для повтора при следующем запуске
+	intent, err := c.storage.LoadCallbackIntent(intentID)
+	if err != nil {
+		logger.Warn("Failed to load callback intent",
+			logger.String("intent_id", intentID),
+			logger.String("error", err.Error()))
+		return
+	}
+	intent.RecordAttempt(callbackErr)
+	if intent.AttemptsExhausted() {
+		c.deadLetterCallbackIntent(intent, fmt.Sprintf("gave up after %d attempts", intent.Attempts))
+		return
+	}
+	if err := c.storage.SaveCallbackIntent(intent); err != nil {
+		logger.Warn("Failed to update callback intent",
+			logger.String("intent_id", intentID),
+			logger.String("error", err.Error()))
+	}
+}
+
+// replayCallbackIntents re-applies callbacks persisted but not acknowledged before shutdown
+// Повторно применяет callbacks, сохраненные, но не подтвержденные до остановки
+func (c *Core) replayCallbackIntents() {
+	if c.storage == nil || c.processComp == nil {
+		return
+	}
+
+	intents, err := c.storage.ListCallbackIntents()
+	if err != nil {
+		logger.Error("Failed to list callback intents", logger.String("error", err.Error()))
+		return
+	}
+	if len(intents) == 0 {
+		return
+	}
+
+	sort.Slice(intents, func(i, j int) bool {
+		return intents[i].CreatedAt.Before(intents[j].CreatedAt)
+	})
+
+	logger.Info("Replaying pending callback intents", logger.Int("count", len(intents)))
+
+	replayed := 0
+	for _, intent := range intents {
+		if c.replayCallbackIntent(intent) {
+			replayed++
+		}
+	}
+
+	logger.Info("Callback intents replay completed",
+		logger.Int("pending", len(intents)),
+		logger.Int("replayed", replayed))
+}
+
+// replayCallbackIntent re-applies single callback intent
+// Returns true when callback was dispatched to process component
+// Повторно применяет одно намерение callback
+func (c *Core) replayCallbackIntent(intent *models.CallbackIntent) bool {
+	if intent.AttemptsExhausted() {
+		c.deadLetterCallbackIntent(intent, fmt.Sprintf("gave up after %d attempts", intent.Attempts))
+		return false
+	}
+
+	token, err := c.storage.LoadToken(intent.TokenID)
+	if errors.Is(err, models.ErrNotFound) {
+		// Token was removed with its instance, callback can never be applied
+		// Токен удален вместе с экземпляром, callback уже не может быть применен
+		c.deadLetterCallbackIntent(intent, "token no longer exists")
+		return false
+	}
+	if err != nil {
+		logger.Warn("Token for callback intent not available, retrying on next startup",
+			logger.String("intent_id", intent.ID),
+			logger.String("token_id", intent.TokenID),
+			logger.String("error", err.Error()))
+		c.acknowledgeCallbackIntent(intent.ID, err)
+		return false
+	}
+
+	// Duplicate replay is idempotent - token that moved on has already seen this callback
+	// Повторный replay идемпотентен - продвинувшийся токен уже получил этот callback
+	if !token.IsWaiting() || token.WaitingFor != intent.WaitingFor {
+		logger.Info("Callback intent already applied",
+			logger.String("intent_id", intent.ID),
+			logger.String("token_id", intent.TokenID),
+			logger.String("waiting_for", intent.WaitingFor))
+		c.acknowledgeCallbackIntent(intent.ID, nil)
+		return false
+	}
+
+	logger.Info("Replaying callback intent",
+		logger.String("intent_id", intent.ID),
+		logger.String("kind", string(intent.Kind)),
+		logger.String("source_id", intent.SourceID),
+		logger.String("token_id", intent.TokenID),
+		logger.Int("attempts", intent.Attempts))
+
+	// Handlers acknowledge intent by intent_id from payload
+	// Обработчики подтверждают намерение по intent_id из payload
+	switch intent.Kind {
+	case models.CallbackIntentJob:
+		c.handleJobsResponse(string(intent.Payload))
+	case models.CallbackIntentMessage:
+		c.handleMessagesResponse(string(intent.Payload))
+	default:
+		c.deadLetterCallbackIntent(intent, fmt.Sprintf("unknown callback intent kind %q", intent.Kind))
+		return false
+	}
+
+	return true
+}
+
+// deadLetterCallbackIntent stops replaying callback intent and keeps it in dead letter log for inspection
+// Прекращает повтор намерения callback и сохраняет его в dead letter логе для анализа
+func (c *Core) deadLetterCallbackIntent(intent *models.CallbackIntent, reason string) {
+	logger.Warn("Callback intent moved to dead letter log",
+		logger.String("intent_id", intent.ID),
+		logger.String("kind", string(intent.Kind)),
+		logger.String("token_id", intent.TokenID),
+		logger.Int("attempts", intent.Attempts),
+		logger.String("last_error", intent.LastError),
+		logger.String("reason", reason))
+
+	if err := c.storage.DeadLetterCallbackIntent(intent, reason); err != nil {
+		logger.Warn("Failed to dead-letter callback intent",
+			logger.String("intent_id", intent.ID),
+			logger.String("error", err.Error()))
+	}
+}
@@ -0,0 +1,154 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"atom-engine/src/core/models"
+	"atom-engine/src/storage"
+)
+
+// newStorageCore creates core with real storage only
+// Создает core только с реальным storage
+func newStorageCore(t *testing.T) *Core {
+	t.Helper()
+
+	st := storage.NewStorage(&storage.Config{Path: t.TempDir()})
+	if err := st.Init(); err != nil {
+		t.Fatalf("init storage: %v", err)
+	}
+	if err := st.Start(); err != nil {
+		t.Fatalf("start storage: %v", err)
+	}
+	t.Cleanup(func() { st.Stop() })
+	return &Core{storage: st}
+}
+
+// savedIntent persists job callback intent of token
+// Сохраняет намерение job callback токена
+func savedIntent(t *testing.T, c *Core, tokenID string, attempts int) *models.CallbackIntent {
+	t.Helper()
+
+	intent := models.NewCallbackIntent(models.CallbackIntentJob, "job-1", tokenID, "job:job-1")
+	intent.Attempts = attempts
+	if err := c.storage.SaveCallbackIntent(intent); err != nil {
+		t.Fatalf("save callback intent: %v", err)
+	}
+	return intent
+}
+
+// intentState reports whether intent is pending replay and whether it is dead-lettered
+// Сообщает, ожидает ли намерение повтора и перенесено ли оно в dead letter
+func intentState(t *testing.T, c *Core, intentID string) (pending bool, deadLetter *models.CallbackIntent) {
+	t.Helper()
+
+	_, err := c.storage.LoadCallbackIntent(intentID)
+	pending = err == nil
+
+	deadLetters, err := c.storage.ListDeadLetterCallbackIntents()
+	if err != nil {
+		t.Fatalf("list dead letter intents: %v", err)
+	}
+	for _, intent := range deadLetters {
+		if intent.ID == intentID {
+			deadLetter = intent
+		}
+	}
+	return pending, deadLetter
+}
+
+func TestReplayCallbackIntentDeadLettersIntentOfMissingToken(t *testing.T) {
+	c := newStorageCore(t)
+	intent := savedIntent(t, c, "gone-token", 0)
+
+	if c.replayCallbackIntent(intent) {
+		t.Fatal("intent of missing token must not be dispatched")
+	}
+
+	pending, deadLetter := intentState(t, c, intent.ID)
+	if pending {
+		t.Error("intent of missing token kept for replay")
+	}
+	if deadLetter == nil || deadLetter.DeadLetterReason == "" {
+		t.Fatalf("intent of missing token not dead-lettered: %+v", deadLetter)
+	}
+}
+
+func TestReplayCallbackIntentDeadLettersExhaustedIntent(t *testing.T) {
+	c := newStorageCore(t)
+
+	token := models.NewToken("instance-1", "process-1", "task")
+	token.SetWaitingFor("job:job-1")
+	if err := c.storage.SaveToken(token); err != nil {
+		t.Fatalf("save token: %v", err)
+	}
+	intent := savedIntent(t, c, token.TokenID, models.MaxCallbackIntentAttempts)
+
+	if c.replayCallbackIntent(intent) {
+		t.Fatal("exhausted intent must not be dispatched")
+	}
+
+	pending, deadLetter := intentState(t, c, intent.ID)
+	if pending || deadLetter == nil {
+		t.Fatalf("exhausted intent not dead-lettered: pending %v, dead letter %+v", pending, deadLetter)
+	}
+	if deadLetter.Attempts != models.MaxCallbackIntentAttempts {
+		t.Errorf("dead letter lost attempts: %d", deadLetter.Attempts)
+	}
+}
+
+func TestReplayCallbackIntentDropsAppliedIntent(t *testing.T) {
+	c := newStorageCore(t)
+
+	token := models.NewToken("instance-1", "process-1", "next-task")
+	token.SetWaitingFor("job:job-2")
+	if err := c.storage.SaveToken(token); err != nil {
+		t.Fatalf("save token: %v", err)
+	}
+	intent := savedIntent(t, c, token.TokenID, 0)
+
+	if c.replayCallbackIntent(intent) {
+		t.Fatal("intent of token that moved on must not be dispatched")
+	}
+
+	pending, deadLetter := intentState(t, c, intent.ID)
+	if pending || deadLetter != nil {
+		t.Fatalf("applied intent must be deleted: pending %v, dead letter %+v", pending, deadLetter)
+	}
+}
+
+func TestAcknowledgeCallbackIntentDeadLettersAfterMaxAttempts(t *testing.T) {
+	c := newStorageCore(t)
+	intent := savedIntent(t, c, "token-1", 0)
+	callbackErr := errors.New("process component unavailable")
+
+	for attempt := 1; attempt < models.MaxCallbackIntentAttempts; attempt++ {
+		c.acknowledgeCallbackIntent(intent.ID, callbackErr)
+
+		stored, err := c.storage.LoadCallbackIntent(intent.ID)
+		if err != nil {
+			t.Fatalf("attempt %d: intent not kept for replay: %v", attempt, err)
+		}
+		if stored.Attempts != attempt || stored.LastError != callbackErr.Error() {
+			t.Fatalf("attempt %d: unexpected intent %+v", attempt, stored)
+		}
+	}
+
+	c.acknowledgeCallbackIntent(intent.ID, callbackErr)
+
+	pending, deadLetter := intentState(t, c, intent.ID)
+	if pending || deadLetter == nil {
+		t.Fatalf("intent not dead-lettered at max attempts: pending %v, dead letter %+v", pending, deadLetter)
+	}
+	if deadLetter.LastError != callbackErr.Error() {
+		t.Errorf("dead letter lost last error: %+v", deadLetter)
+	}
+}
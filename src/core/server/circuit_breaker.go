@@ -0,0 +1,243 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"sync"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+	"atom-engine/src/core/types"
+)
+
+// Circuit breaker states
+// Состояния circuit breaker
+const (
+	CircuitStateClosed   = "closed"
+	CircuitStateOpen     = "open"
+	CircuitStateHalfOpen = "half_open"
+)
+
+// breakerComponents lists components whose request/response messaging is guarded by breaker
+// Компоненты, обмен запросами/ответами с которыми защищен circuit breaker
+var breakerComponents = []string{"parser", "jobs", "messages", "incidents"}
+
+// CircuitBreaker fails component requests fast after consecutive response timeouts
+// After cooldown one probe request is let through, its outcome closes or reopens breaker
+// Быстро отклоняет запросы к компоненту после подряд идущих таймаутов ответа
+// После паузы пропускается один пробный запрос, его результат закрывает или снова открывает breaker
+type CircuitBreaker struct {
+	component        string
+	failureThreshold int
+	cooldown         time.Duration
+
+	mu                  sync.Mutex
+	state               string
+	consecutiveFailures int
+	openedAt            time.Time
+	probeStartedAt      time.Time
+}
+
+// NewCircuitBreaker creates circuit breaker for component
+// Создает circuit breaker для компонента
+func NewCircuitBreaker(component string, failureThreshold int, cooldown time.Duration) *CircuitBreaker {
+	if failureThreshold < 1 {
+		failureThreshold = 1
+	}
+
+	return &CircuitBreaker{
+		component:        component,
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		state:            CircuitStateClosed,
+	}
+}
+
+// Allow checks whether request may be sent to component
+// Проверяет, можно ли отправить запрос компоненту
+func (cb *CircuitBreaker) Allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := time.Now()
+	switch cb.state {
+	case CircuitStateOpen:
+		if elapsed := now.Sub(cb.openedAt); elapsed < cb.cooldown {
+			return &models.CircuitOpenError{Component: cb.component, RetryAfter: cb.cooldown - elapsed}
+		}
+		cb.state = CircuitStateHalfOpen
+		cb.probeStartedAt = now
+		logger.Info("Circuit breaker half-open, probing component",
+			logger.String("component", cb.component))
+		return nil
+	case CircuitStateHalfOpen:
+		// Single probe at a time; probe that never reported back expires after cooldown
+		// Один пробный запрос за раз; пробный запрос без результата истекает через паузу
+		if elapsed := now.Sub(cb.probeStartedAt); elapsed < cb.cooldown {
+			return &models.CircuitOpenError{Component: cb.component, RetryAfter: cb.cooldown - elapsed}
+		}
+		cb.probeStartedAt = now
+		return nil
+	default:
+		return nil
+	}
+}
+
+// RecordSuccess records received component response
+// Фиксирует полученный ответ компонента
+func (cb *CircuitBreaker) RecordSuccess() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state != CircuitStateClosed {
+		logger.Info("Circuit breaker closed, component recovered",
+			logger.String("component", cb.component))
+	}
+	cb.state = CircuitStateClosed
+	cb.consecutiveFailures = 0
+}
+
+// RecordTimeout records component response timeout
+// Фиксирует таймаут ответа компонента
+func (cb *CircuitBreaker) RecordTimeout() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.consecutiveFailures++
+	if cb.state == CircuitStateHalfOpen || cb.consecutiveFailures >= cb.failureThreshold {
+		if cb.state != CircuitStateOpen {
+			logger.Warn("Circuit breaker opened, failing component requests fast",
+				logger.String("component", cb.component),
+				logger.Int("consecutive_failures", cb.consecutiveFailures),
+				logger.String("cooldown", cb.cooldown.String()))
+		}
+		cb.state = CircuitStateOpen
+		cb.openedAt = time.Now()
+	}
+}
+
+// HealthCheck returns breaker state as health check entry
+// Возвращает состояние breaker как запись проверки здоровья
+func (cb *CircuitBreaker) HealthCheck() types.HealthCheck {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	check := types.HealthCheck{
+		Name: "circuit_breaker:" + cb.component,
+		Metadata: map[string]interface{}{
+			"state":                cb.state,
+			"consecutive_failures": cb.consecutiveFailures,
+			"failure_threshold":    cb.failureThreshold,
+		},
+	}
+
+	switch cb.state {
+	case CircuitStateOpen:
+		check.Status = types.ComponentHealthUnhealthy
+		check.Message = "component requests fail fast until cooldown ends"
+		check.Metadata["opened_at"] = cb.openedAt
+		if retryAfter := cb.cooldown - time.Since(cb.openedAt); retryAfter > 0 {
+			check.Metadata["retry_after_ms"] = retryAfter.Milliseconds()
+		}
+	case CircuitStateHalfOpen:
+		check.Status = types.ComponentHealthDegraded
+		check.Message = "probing component recovery"
+	default:
+		check.Status = types.ComponentHealthHealthy
+	}
+
+	return check
+}
+
+// newComponentBreakers creates breakers for guarded components
+// Создает breaker'ы для защищаемых компонентов
+func newComponentBreakers(failureThreshold int, cooldown time.Duration) map[string]*CircuitBreaker {
+	breakers := make(map[string]*CircuitBreaker, len(breakerComponents))
+	for _, name := range breakerComponents {
+		breakers[name] = NewCircuitBreaker(name, failureThreshold, cooldown)
+	}
+	return breakers
+}
+
+// allowComponentRequest checks component breaker before sending request
+// Проверяет breaker компонента перед отправкой запроса
+func (c *Core) allowComponentRequest(componentName string) error {
+	if breaker := c.breakers[componentName]; breaker != nil {
+		return breaker.Allow()
+	}
+	return nil
+}
+
+// recordComponentResponse records outcome and latency of waiting for component response
+// Фиксирует результат и задержку ожидания ответа компонента
+func (c *Core) recordComponentResponse(componentName, requestID string, waitStart time.Time, timedOut bool) {
+	c.latency.observeResponse(componentName, requestID, waitStart, timedOut)
+
+	breaker := c.breakers[componentName]
+	if breaker == nil {
+		return
+	}
+	if timedOut {
+		breaker.RecordTimeout()
+	} else {
+		breaker.RecordSuccess()
+	}
+}
+
+// breakerHealthChecks returns health checks of component breakers
+// Empty component name returns checks of all breakers
+// Возвращает проверки здоровья breaker'ов компонентов
+// Пустое имя компонента возвращает проверки всех breaker'ов
+func (c *Core) breakerHealthChecks(componentName string) []types.HealthCheck {
+	var checks []types.HealthCheck
+	for _, name := range breakerComponents {
+		if componentName != "" && componentName != name {
+			continue
+		}
+		if breaker := c.breakers[name]; breaker != nil {
+			checks = append(checks, breaker.HealthCheck())
+		}
+	}
+	return checks
+}
+
+// applyBreakerHealth lowers health to worst breaker state, capped at limit
+// Понижает здоровье до худшего состояния breaker'ов, но не ниже limit
+func applyBreakerHealth(
+	health types.ComponentHealth,
+	checks []types.HealthCheck,
+	limit types.ComponentHealth,
+) types.ComponentHealth {
+	for _, check := range checks {
+		status := check.Status
+		if healthRank(status) > healthRank(limit) {
+			status = limit
+		}
+		if healthRank(status) > healthRank(health) {
+			health = status
+		}
+	}
+	return health
+}
+
+// healthRank orders health values from best to worst
+// Упорядочивает значения здоровья от лучшего к худшему
+func healthRank(health types.ComponentHealth) int {
+	switch health {
+	case types.ComponentHealthHealthy:
+		return 0
+	case types.ComponentHealthDegraded:
+		return 1
+	case types.ComponentHealthUnhealthy:
+		return 2
+	default:
+		return 1
+	}
+}
@@ -0,0 +1,197 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"atom-engine/src/core/metrics"
+	"atom-engine/src/core/types"
+)
+
+// pendingRequestsCapacity bounds requests awaiting response per component, extra requests are not timed
+// Ограничивает число запросов, ожидающих ответа, на компонент, лишние запросы не замеряются
+const pendingRequestsCapacity = 1024
+
+// unknownOperation tags responses waited for without tracked request
+// Метка ответов, ожидаемых без отслеженного запроса
+const unknownOperation = "unknown"
+
+// pendingRequest is request sent to component whose response is not received yet
+// Запрос, отправленный компоненту, ответ на который еще не получен
+type pendingRequest struct {
+	operation string
+	sentAt    time.Time
+}
+
+// latencyKey identifies histogram of component operation
+// Идентифицирует гистограмму операции компонента
+type latencyKey struct {
+	component string
+	operation string
+}
+
+// componentLatency measures round trip from sending JSON request to receiving component response
+// Responses are matched to requests by request ID, same as response dispatcher delivers them
+// Измеряет время от отправки JSON запроса до получения ответа компонента
+// Ответы сопоставляются с запросами по ID запроса, так же как их доставляет диспетчер ответов
+type componentLatency struct {
+	mu         sync.Mutex
+	pending    map[string]map[string]pendingRequest // component -> request ID, components fixed at creation
+	histograms sync.Map                             // latencyKey -> *metrics.Histogram
+}
+
+// newComponentLatency creates latency tracking for request/response components
+// Создает отслеживание задержки для компонентов с обменом запросами/ответами
+func newComponentLatency() *componentLatency {
+	latency := &componentLatency{pending: make(map[string]map[string]pendingRequest, len(breakerComponents))}
+	for _, name := range breakerComponents {
+		latency.pending[name] = make(map[string]pendingRequest)
+	}
+	return latency
+}
+
+// trackRequest remembers operation and send time of request sent to component
+// Запоминает операцию и время отправки запроса компоненту
+func (l *componentLatency) trackRequest(componentName, requestID, messageJSON string, sentAt time.Time) {
+	if l == nil || requestID == "" {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	requests, ok := l.pending[componentName]
+	if !ok {
+		return
+	}
+	if len(requests) >= pendingRequestsCapacity {
+		pruneAbandonedRequests(requests, sentAt)
+		if len(requests) >= pendingRequestsCapacity {
+			return
+		}
+	}
+	requests[requestID] = pendingRequest{operation: messageOperation(messageJSON), sentAt: sentAt}
+}
+
+// observeResponse records latency of request with given ID
+// Timed out waits drop pending request without observation
+// Записывает задержку запроса с указанным ID
+// Ожидание с таймаутом удаляет ожидающий запрос без записи
+func (l *componentLatency) observeResponse(componentName, requestID string, waitStart time.Time, timedOut bool) {
+	if l == nil {
+		return
+	}
+
+	request := pendingRequest{operation: unknownOperation, sentAt: waitStart}
+	l.mu.Lock()
+	requests, ok := l.pending[componentName]
+	if ok {
+		if tracked, found := requests[requestID]; found {
+			delete(requests, requestID)
+			request = tracked
+		}
+	}
+	l.mu.Unlock()
+
+	if !ok || timedOut {
+		return
+	}
+
+	l.histogram(componentName, request.operation).Observe(time.Since(request.sentAt).Seconds())
+}
+
+// forgetRequest drops request not accepted by component
+// Удаляет запрос, не принятый компонентом
+func (l *componentLatency) forgetRequest(componentName, requestID string) {
+	if l == nil {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if requests, ok := l.pending[componentName]; ok {
+		delete(requests, requestID)
+	}
+}
+
+// pruneAbandonedRequests drops requests whose caller never waited for response
+// Удаляет запросы, ответ на которые вызывающий так и не ожидал
+func pruneAbandonedRequests(requests map[string]pendingRequest, now time.Time) {
+	for requestID, request := range requests {
+		if now.Sub(request.sentAt) > pendingResponseTTL {
+			delete(requests, requestID)
+		}
+	}
+}
+
+// histogram returns histogram of component operation, creating it on first use
+// Возвращает гистограмму операции компонента, создавая ее при первом использовании
+func (l *componentLatency) histogram(componentName, operation string) *metrics.Histogram {
+	key := latencyKey{component: componentName, operation: operation}
+	if existing, ok := l.histograms.Load(key); ok {
+		return existing.(*metrics.Histogram)
+	}
+	existing, _ := l.histograms.LoadOrStore(key, metrics.NewHistogram(metrics.DefaultLatencyBuckets))
+	return existing.(*metrics.Histogram)
+}
+
+// stats returns snapshots of all histograms ordered by component and operation
+// Возвращает снимки всех гистограмм, упорядоченные по компоненту и операции
+func (l *componentLatency) stats() []types.ComponentLatencyStats {
+	if l == nil {
+		return nil
+	}
+
+	var stats []types.ComponentLatencyStats
+	l.histograms.Range(func(key, value interface{}) bool {
+		latencyKey := key.(latencyKey)
+		snapshot := value.(*metrics.Histogram).Snapshot()
+		stats = append(stats, types.ComponentLatencyStats{
+			Component:   latencyKey.component,
+			Operation:   latencyKey.operation,
+			UpperBounds: snapshot.UpperBounds,
+			Counts:      snapshot.Counts,
+			Count:       snapshot.Count,
+			SumSeconds:  snapshot.Sum,
+		})
+		return true
+	})
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Component != stats[j].Component {
+			return stats[i].Component < stats[j].Component
+		}
+		return stats[i].Operation < stats[j].Operation
+	})
+	return stats
+}
+
+// messageOperation derives operation tag from message type verb, e.g. list_jobs -> list
+// Получает метку операции из глагола типа сообщения, например list_jobs -> list
+func messageOperation(messageJSON string) string {
+	var message struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal([]byte(messageJSON), &message); err != nil || message.Type == "" {
+		return unknownOperation
+	}
+	operation, _, _ := strings.Cut(message.Type, "_")
+	return operation
+}
+
+// GetComponentLatencyStats returns response latency histograms of component operations
+// Возвращает гистограммы задержки ответов операций компонентов
+func (c *Core) GetComponentLatencyStats() []types.ComponentLatencyStats {
+	return c.latency.stats()
+}
@@ -11,6 +11,7 @@ package server
 import (
 	"bufio"
 	"context"
+	"encoding/json"
 	"fmt"
 	"math"
 	"os"
@@ -18,17 +19,30 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"atom-engine/src/core/archive"
 	"atom-engine/src/core/auth"
 	"atom-engine/src/core/config"
+	"atom-engine/src/core/emailconnector"
 	"atom-engine/src/core/grpc"
+	"atom-engine/src/core/historyexport"
 	"atom-engine/src/core/interfaces"
+	"atom-engine/src/core/kafkabridge"
 	"atom-engine/src/core/logger"
+	"atom-engine/src/core/metrics"
 	"atom-engine/src/core/models"
+	"atom-engine/src/core/natsbridge"
+	"atom-engine/src/core/objectstore"
 	"atom-engine/src/core/restapi"
 	"atom-engine/src/core/restapi/handlers"
 	"atom-engine/src/core/system"
+	"atom-engine/src/core/tracing"
+	"atom-engine/src/core/triggers"
 	"atom-engine/src/core/types"
 	"atom-engine/src/expression"
 	"atom-engine/src/incidents"
@@ -41,6 +55,10 @@ import (
 	"atom-engine/src/version"
 )
 
+// cpuSampleInterval is window over which process CPU usage is measured for system info
+// Окно, за которое измеряется загрузка CPU процессом для информации о системе
+const cpuSampleInterval = 250 * time.Millisecond
+
 // Core manages all system components
 // Управляет всеми компонентами системы
 type Core struct {
@@ -66,10 +84,67 @@ type Core struct {
 	startTime      time.Time
 	isShuttingDown bool
 
+	// Set once in-flight state left by previous run is recovered, read by startup and readiness probes
+	// Устанавливается после восстановления состояния предыдущего запуска, читается startup и readiness probe
+	recovered atomic.Bool
+
 	// Message Multiplexer for jobs component
 	// Message Multiplexer для jobs компонента
 	jobsMultiplexer MessageMultiplexerInterface
 
+	// Circuit breakers guarding component request/response messaging
+	// Circuit breaker'ы, защищающие обмен запросами/ответами с компонентами
+	breakers map[string]*CircuitBreaker
+
+	// Round-trip latency of component request/response messaging
+	// Задержка обмена запросами/ответами с компонентами
+	latency *componentLatency
+
+	// Delivers component responses to callers by request ID
+	// Доставляет ответы компонентов вызывающим по ID запроса
+	responses *responseDispatcher
+
+	// Listeners of engine events published by components
+	// Слушатели событий движка, публикуемых компонентами
+	engineEvents engineEventHub
+
+	// Optional Kafka bridge for jobs and engine events
+	// Необязательный мост Kafka для job'ов и событий движка
+	kafkaBridge *kafkabridge.Bridge
+
+	// Optional NATS bridge for engine events and message publishing
+	// Необязательный мост NATS для событий движка и публикации сообщений
+	natsBridge *natsbridge.Bridge
+
+	// Optional exporter of history to Elasticsearch compatible endpoint
+	// Необязательный экспортер истории в Elasticsearch совместимый endpoint
+	historyExporter *historyexport.Exporter
+
+	// Optional built-in worker sending email jobs over SMTP
+	// Необязательный встроенный worker, отправляющий email job'ы через SMTP
+	emailConnector *emailconnector.Connector
+
+	// Object store of instance exports, storage backups and retention archives
+	// Объектное хранилище экспортов экземпляров, резервных копий и архивов очистки
+	objectStore objectstore.Store
+
+	// Serializes storage backups, concurrent backup request is rejected
+	// Сериализует резервные копии storage, параллельный запрос копии отклоняется
+	backupMu sync.Mutex
+
+	// Retention sweeper for finished process instances
+	// Очистка завершенных экземпляров процессов по сроку хранения
+	retentionSweeper *archive.RetentionSweeper
+
+	// Closed on shutdown to stop background loops, which are awaited before storage closes
+	// Закрывается при остановке для завершения фоновых циклов, которые ожидаются до закрытия storage
+	backgroundStop chan struct{}
+	background     sync.WaitGroup
+
+	// Inbound webhook triggers starting process instances
+	// Входящие webhook триггеры, запускающие экземпляры процессов
+	webhookTriggers *triggers.Manager
+
 	// CPU monitoring fields for sophisticated calculation
 	// Поля мониторинга CPU для более точных вычислений
 	lastCPUUpdate    time.Time
@@ -97,9 +172,25 @@ func NewCoreWithConfig(cfg *config.Config) (*Core, error) {
 	// Устанавливаем имя инстанса для генерации ID
 	models.SetInstanceName(cfg.InstanceName)
 
+	// Set node ID for numeric key generation
+	// Устанавливаем ID узла для генерации числовых ключей
+	if err := models.SetKeyNodeID(cfg.Engine.NodeID); err != nil {
+		return nil, fmt.Errorf("failed to configure key generator: %w", err)
+	}
+
+	// Select ID format for new instances, tokens and messages
+	// Выбираем формат ID для новых экземпляров, токенов и сообщений
+	models.SetULIDIDsEnabled(cfg.Engine.ULIDIDs)
+
+	variableEncryption, err := convertVariableEncryption(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure variable encryption: %w", err)
+	}
+
 	storageConfig := &storage.Config{
-		Path:    cfg.Database.Path,
-		Options: convertStorageOptions(&cfg.Storage.Options),
+		Path:               cfg.Database.Path,
+		Options:            convertStorageOptions(&cfg.Storage.Options),
+		VariableEncryption: variableEncryption,
 	}
 
 	storageInstance := storage.NewStorage(storageConfig)
@@ -108,9 +199,28 @@ func NewCoreWithConfig(cfg *config.Config) (*Core, error) {
 	// Инициализируем timewheel компонент с storage
 	timewheelComp := timewheel.NewComponentWithStorage(storageInstance)
 
+	// Simulated clock is checked again here, config may be built without validation
+	// Симулированные часы проверяются еще раз здесь, конфигурация может быть создана без валидации
+	if cfg.Simulation.Enabled {
+		if os.Getenv(config.SimulationAllowedEnv) != "true" {
+			return nil, fmt.Errorf("simulation is enabled but %s=true is not set in environment",
+				config.SimulationAllowedEnv)
+		}
+		start := time.Now()
+		if cfg.Simulation.StartTime != "" {
+			start, err = time.Parse(time.RFC3339, cfg.Simulation.StartTime)
+			if err != nil {
+				return nil, fmt.Errorf("invalid simulation start_time: %w", err)
+			}
+		}
+		if err := timewheelComp.EnableSimulation(start); err != nil {
+			return nil, fmt.Errorf("failed to enable simulation: %w", err)
+		}
+	}
+
 	// Initialize process component with storage
 	// Инициализируем process компонент с storage
-	processComp := process.NewComponent(storageInstance)
+	processComp := process.NewComponent(cfg, storageInstance)
 
 	// Initialize parser component with config and storage
 	// Инициализируем parser компонент с конфигурацией и storage
@@ -126,7 +236,7 @@ func NewCoreWithConfig(cfg *config.Config) (*Core, error) {
 
 	// Initialize expression component
 	// Инициализируем expression компонент
-	expressionComp := expression.NewComponent()
+	expressionComp := expression.NewComponent(cfg)
 
 	// Initialize incidents component with storage
 	// Инициализируем incidents компонент с storage
@@ -136,6 +246,8 @@ func NewCoreWithConfig(cfg *config.Config) (*Core, error) {
 	// Инициализируем auth компонент
 	authComp := auth.NewComponent()
 
+	metrics.Processes.SetSeriesLimit(cfg.Metrics.ProcessSeriesLimit)
+
 	return &Core{
 		config:        cfg,
 		storage:       storageInstance,
@@ -156,6 +268,13 @@ func NewCoreWithConfig(cfg *config.Config) (*Core, error) {
 		startTime:        time.Now(),
 		isShuttingDown:   false,
 		cpuCacheDuration: 5 * time.Second, // Cache CPU metrics for 5 seconds
+		breakers: newComponentBreakers(
+			cfg.CircuitBreaker.FailureThreshold,
+			time.Duration(cfg.CircuitBreaker.CooldownMs)*time.Millisecond,
+		),
+		latency:         newComponentLatency(),
+		responses:       newResponseDispatcher(),
+		webhookTriggers: triggers.NewManager(storageInstance, processComp, expressionComp),
 	}, nil
 }
 
@@ -248,6 +367,14 @@ func (c *Core) GetStorageComponent() grpc.StorageComponentInterface {
 // SendMessage sends JSON message to specified component
 // Отправляет JSON сообщение указанному компоненту
 func (c *Core) SendMessage(componentName, messageJSON string) error {
+	return c.SendMessageWithContext(context.Background(), componentName, messageJSON)
+}
+
+// SendMessageWithContext sends JSON message to component with request context
+// Request ID from context is added to message so component logs can be correlated
+// Отправляет JSON сообщение компоненту с контекстом запроса
+// ID запроса из контекста добавляется в сообщение для корреляции логов компонента
+func (c *Core) SendMessageWithContext(ctx context.Context, componentName, messageJSON string) error {
 	component := c.getComponentByName(componentName)
 	if component == nil {
 		return fmt.Errorf("component not found: %s", componentName)
@@ -258,7 +385,68 @@ func (c *Core) SendMessage(componentName, messageJSON string) error {
 		return fmt.Errorf("component %s does not support JSON messages", componentName)
 	}
 
-	return processor.ProcessMessage(context.Background(), messageJSON)
+	// Fail fast instead of sending into channel nobody reads
+	// Быстро возвращаем ошибку вместо отправки в канал, который никто не читает
+	if !isComponentReady(component) {
+		return &models.ComponentNotReadyError{Component: componentName}
+	}
+
+	// Fail fast while component breaker is open after repeated timeouts
+	// Быстро возвращаем ошибку, пока breaker компонента открыт после повторных таймаутов
+	if err := c.allowComponentRequest(componentName); err != nil {
+		return err
+	}
+
+	ctx, span := startComponentSpan(ctx, componentName, messageJSON)
+	requestID := models.RequestIDFromContext(ctx)
+	messageJSON = models.WithMessageRequestID(messageJSON, requestID)
+	messageJSON = models.WithMessageTraceParent(messageJSON, tracing.TraceParentFromContext(ctx))
+
+	// Component processing outlives request, only request ID and span are carried over
+	// Обработка компонентом переживает запрос, переносятся только ID запроса и span
+	componentCtx := tracing.ContextWithSpan(models.ContextWithRequestID(context.Background(), requestID), ctx)
+	err := processor.ProcessMessage(componentCtx, messageJSON)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// startComponentSpan starts span of message sent to component, child of request span in ctx
+// Message type is read only for sampled spans, unsampled messages are not parsed
+// Запускает span сообщения компоненту, дочерний span'у запроса из ctx
+// Тип сообщения читается только для сэмплированных span'ов, остальные сообщения не разбираются
+func startComponentSpan(ctx context.Context, componentName, messageJSON string) (context.Context, trace.Span) {
+	ctx, span := tracing.Tracer().Start(ctx, "component "+componentName,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attribute.String("atom.component", componentName)))
+	if !span.IsRecording() {
+		return ctx, span
+	}
+
+	if requestID := models.RequestIDFromContext(ctx); requestID != "" {
+		span.SetAttributes(attribute.String("atom.request_id", requestID))
+	}
+	var message struct {
+		Type string `json:"type"`
+	}
+	if json.Unmarshal([]byte(messageJSON), &message) == nil && message.Type != "" {
+		span.SetName("component " + componentName + " " + message.Type)
+		span.SetAttributes(attribute.String("atom.message_type", message.Type))
+	}
+	return ctx, span
+}
+
+// isComponentReady checks component readiness using IsReady or IsRunning
+// Components without readiness tracking are considered ready
+// Проверяет готовность компонента через IsReady или IsRunning
+func isComponentReady(component interface{}) bool {
+	switch c := component.(type) {
+	case interface{ IsReady() bool }:
+		return c.IsReady()
+	case interface{ IsRunning() bool }:
+		return c.IsRunning()
+	default:
+		return true
+	}
 }
 
 // GetGRPCConnection returns gRPC connection for direct calls
@@ -319,6 +507,41 @@ func (c *Core) GetStorageInfoForREST() (*handlers.StorageInfoResponse, error) {
 	}, nil
 }
 
+// convertVariableEncryption converts variable encryption config to storage package format
+// Encrypted variables are also masked for unauthorized API readers and redacted in logs and exports
+// Конвертирует настройки шифрования переменных в формат пакета storage
+// Зашифрованные переменные также скрываются от неавторизованных читателей API, в логах и экспорте
+func convertVariableEncryption(cfg *config.Config) (*storage.VariableEncryptionConfig, error) {
+	encryption := cfg.Storage.VariableEncryption
+	if encryption.Key == "" {
+		return nil, nil
+	}
+
+	key, err := encryption.DecodeKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// Key without enabled flag keeps previously encrypted values readable
+	// Ключ без флага enabled сохраняет читаемость ранее зашифрованных значений
+	if !encryption.Enabled {
+		return &storage.VariableEncryptionConfig{Key: key}, nil
+	}
+
+	if err := models.SetSensitiveVariablePatterns(encryption.KeyPatterns); err != nil {
+		return nil, err
+	}
+	cfg.Logger.RedactKeyPatterns = append(cfg.Logger.RedactKeyPatterns, encryption.KeyPatterns...)
+	// Exporter reads decrypted variables, so exports and cold-storage archives redact them too
+	// Экспортер читает расшифрованные переменные, поэтому экспорт и архивы тоже их скрывают
+	cfg.Archive.RedactKeyPatterns = append(cfg.Archive.RedactKeyPatterns, encryption.KeyPatterns...)
+
+	return &storage.VariableEncryptionConfig{
+		Key:         key,
+		KeyPatterns: encryption.KeyPatterns,
+	}, nil
+}
+
 // convertStorageOptions converts config storage options to storage package format
 // Конвертирует настройки storage из config в формат пакета storage
 func convertStorageOptions(configOptions *config.StorageOptionsConfig) *storage.StorageOptionsConfig {
@@ -386,131 +609,98 @@ func (c *Core) getComponentByName(componentName string) interface{} {
 	}
 }
 
-// WaitForParserResponse waits for parser response with timeout
-// Ожидает ответ от парсера с таймаутом
-func (c *Core) WaitForParserResponse(timeoutMs int) (string, error) {
-	if c.parserComp == nil {
-		return "", fmt.Errorf("parser component not available")
-	}
-
-	responseChannel := c.parserComp.GetResponseChannel()
-	if responseChannel == nil {
-		return "", fmt.Errorf("parser response channel not available")
-	}
-
-	logger.Debug("Waiting for parser response", logger.Int("timeout_ms", timeoutMs))
-	timeout := time.Duration(timeoutMs) * time.Millisecond
-	select {
-	case response := <-responseChannel:
-		logger.Debug("Received parser response", logger.String("response_length", fmt.Sprintf("%d", len(response))))
-		return response, nil
-	case <-time.After(timeout):
-		logger.Warn("Parser response timeout", logger.Int("timeout_ms", timeoutMs))
-		return "", fmt.Errorf("timeout waiting for parser response after %dms", timeoutMs)
-	}
+// SendRequestWithContext sends JSON request to component under request ID unique within daemon
+// and returns that ID, response to request is received by WaitFor*Response of component
+// Отправляет JSON запрос компоненту под ID запроса, уникальным в пределах демона,
+// и возвращает этот ID, ответ на запрос получается через WaitFor*Response компонента
+func (c *Core) SendRequestWithContext(ctx context.Context, componentName, messageJSON string) (string, error) {
+	base := models.MessageRequestID(messageJSON)
+	if base == "" {
+		base = models.RequestIDFromContext(ctx)
+	}
+	requestID := c.responses.nextRequestID(componentName, base)
+	messageJSON = models.ReplaceMessageRequestID(messageJSON, requestID)
+
+	// Latency is tracked under same request ID the response is dispatched by
+	// Задержка отслеживается под тем же ID запроса, по которому доставляется ответ
+	// Tracked before sending, since response may be dispatched before send returns
+	// Отслеживается до отправки, так как ответ может прийти раньше возврата из отправки
+	c.latency.trackRequest(componentName, requestID, messageJSON, time.Now())
+	if err := c.SendMessageWithContext(ctx, componentName, messageJSON); err != nil {
+		c.latency.forgetRequest(componentName, requestID)
+		return "", err
+	}
+	return requestID, nil
 }
 
-// WaitForJobsResponse waits for jobs response with timeout
-// Ожидает ответ от jobs компонента с таймаутом
-func (c *Core) WaitForJobsResponse(timeoutMs int) (string, error) {
-	// Use Message Multiplexer if available
-	// Используем Message Multiplexer если доступен
-	if c.jobsMultiplexer != nil && c.jobsMultiplexer.IsRunning() {
-		responseChannel := c.jobsMultiplexer.GetAPIResponseChannel()
-		if responseChannel == nil {
-			return "", fmt.Errorf("jobs API response channel not available")
-		}
-
-		logger.Debug("Waiting for jobs API response via multiplexer", logger.Int("timeout_ms", timeoutMs))
-		timeout := time.Duration(timeoutMs) * time.Millisecond
-		select {
-		case response := <-responseChannel:
-			logger.Debug("Received jobs API response via multiplexer",
-				logger.String("response_length", fmt.Sprintf("%d", len(response))))
-			return response, nil
-		case <-time.After(timeout):
-			logger.Warn("Jobs API response timeout via multiplexer", logger.Int("timeout_ms", timeoutMs))
-			return "", fmt.Errorf("timeout waiting for jobs API response after %dms", timeoutMs)
-		}
-	}
+// WaitForParserResponse waits for parser response to request with timeout
+// Ожидает ответ парсера на запрос с таймаутом
+func (c *Core) WaitForParserResponse(requestID string, timeoutMs int) (string, error) {
+	return c.waitForComponentResponse("parser", requestID, timeoutMs)
+}
 
-	// Fallback to direct channel access (for backwards compatibility)
-	// Резервный прямой доступ к каналу (для обратной совместимости)
-	if c.jobsComp == nil {
-		return "", fmt.Errorf("jobs component not available")
-	}
+// WaitForJobsResponse waits for jobs response to request with timeout
+// Ожидает ответ jobs компонента на запрос с таймаутом
+func (c *Core) WaitForJobsResponse(requestID string, timeoutMs int) (string, error) {
+	return c.waitForComponentResponse("jobs", requestID, timeoutMs)
+}
 
-	responseChannel := c.jobsComp.GetResponseChannel()
-	if responseChannel == nil {
-		return "", fmt.Errorf("jobs response channel not available")
-	}
+// WaitForMessagesResponse waits for messages response to request with timeout
+// Ожидает ответ messages компонента на запрос с таймаутом
+func (c *Core) WaitForMessagesResponse(requestID string, timeoutMs int) (string, error) {
+	return c.waitForComponentResponse("messages", requestID, timeoutMs)
+}
 
-	logger.Debug("Waiting for jobs response (direct channel)", logger.Int("timeout_ms", timeoutMs))
-	timeout := time.Duration(timeoutMs) * time.Millisecond
-	select {
-	case response := <-responseChannel:
-		logger.Debug("Received jobs response (direct channel)",
-			logger.String("response_length", fmt.Sprintf("%d", len(response))))
-		return response, nil
-	case <-time.After(timeout):
-		logger.Warn("Jobs response timeout (direct channel)", logger.Int("timeout_ms", timeoutMs))
-		return "", fmt.Errorf("timeout waiting for jobs response after %dms", timeoutMs)
-	}
+// WaitForIncidentsResponse waits for incidents response to request with timeout
+// Ожидает ответ incidents компонента на запрос с таймаутом
+func (c *Core) WaitForIncidentsResponse(requestID string, timeoutMs int) (string, error) {
+	return c.waitForComponentResponse("incidents", requestID, timeoutMs)
 }
 
-// WaitForMessagesResponse waits for messages response with timeout
-// Ожидает ответ от messages компонента с таймаутом
-func (c *Core) WaitForMessagesResponse(timeoutMs int) (string, error) {
-	if c.messagesComp == nil {
-		return "", fmt.Errorf("messages component not available")
+// waitForComponentResponse waits for response dispatched to request ID and records its latency
+// Ожидает ответ, доставленный по ID запроса, и записывает его задержку
+func (c *Core) waitForComponentResponse(componentName, requestID string, timeoutMs int) (string, error) {
+	if requestID == "" {
+		return "", fmt.Errorf("request ID is required to wait for %s response", componentName)
 	}
 
-	responseChannel := c.messagesComp.GetResponseChannel()
-	if responseChannel == nil {
-		return "", fmt.Errorf("messages response channel not available")
-	}
+	waitStart := time.Now()
+	logger.Debug("Waiting for component response",
+		logger.String("component", componentName),
+		logger.String("request_id", requestID),
+		logger.Int("timeout_ms", timeoutMs))
 
-	timeout := time.Duration(timeoutMs) * time.Millisecond
-	select {
-	case response := <-responseChannel:
-		return response, nil
-	case <-time.After(timeout):
-		return "", fmt.Errorf("timeout waiting for messages response after %dms", timeoutMs)
+	response, err := c.responses.wait(componentName, requestID, time.Duration(timeoutMs)*time.Millisecond)
+	if err != nil {
+		logger.Warn("Component response timeout",
+			logger.String("component", componentName),
+			logger.String("request_id", requestID),
+			logger.Int("timeout_ms", timeoutMs))
+		c.recordComponentResponse(componentName, requestID, waitStart, true)
+		return "", err
 	}
-}
 
-// WaitForIncidentsResponse waits for incidents response with timeout
-// Ожидает ответ от incidents компонента с таймаутом
-func (c *Core) WaitForIncidentsResponse(timeoutMs int) (string, error) {
-	if c.incidentsComp == nil {
-		return "", fmt.Errorf("incidents component not available")
-	}
+	c.recordComponentResponse(componentName, requestID, waitStart, false)
+	return response, nil
+}
 
-	responseChannel := c.incidentsComp.GetResponseChannel()
-	if responseChannel == nil {
-		return "", fmt.Errorf("incidents response channel not available")
+// registerComponentResponses routes responses of request/response components through dispatcher
+// Jobs API responses come from multiplexer, messages callbacks without request ID keep their handler
+// Направляет ответы компонентов с обменом запросами/ответами через диспетчер
+// API ответы jobs приходят из multiplexer, callback'и messages без ID запроса сохраняют свой обработчик
+func (c *Core) registerComponentResponses() error {
+	if err := c.responses.register("parser", c.parserComp.GetResponseChannel(), nil); err != nil {
+		return err
 	}
-
-	// Clear any old responses from channel (non-blocking)
-	// Очищаем старые ответы из канала (неблокирующе)
-	for {
-		select {
-		case <-responseChannel:
-			// Discard old response
-		default:
-			// Channel is empty, proceed to wait for new response
-			goto waitForResponse
-		}
+	if err := c.responses.register("jobs", c.jobsMultiplexer.GetAPIResponseChannel(), nil); err != nil {
+		return err
 	}
-
-waitForResponse:
-	timeout := time.Duration(timeoutMs) * time.Millisecond
-	select {
-	case response := <-responseChannel:
-		return response, nil
-	case <-time.After(timeout):
-		return "", fmt.Errorf("timeout waiting for incidents response after %dms", timeoutMs)
+	if err := c.responses.register(
+		"messages", c.messagesComp.GetResponseChannel(), c.handleMessagesResponse,
+	); err != nil {
+		return err
 	}
+	return c.responses.register("incidents", c.incidentsComp.GetResponseChannel(), nil)
 }
 
 // CoreTypedInterface implementation
@@ -595,6 +785,7 @@ func (c *Core) GetSystemInfo() (*types.SystemInfo, error) {
 		StartedAt:     c.startTime,
 		Uptime:        time.Since(c.startTime),
 		HostInfo:      hostInfo,
+		CPU:           system.GetCPUInfo(cpuSampleInterval),
 		Configuration: c.getSystemConfiguration(),
 	}, nil
 }
@@ -699,11 +890,13 @@ func (c *Core) HealthCheck(req *types.ComponentHealthCheckRequest) (*types.Compo
 			}, nil
 		}
 
+		checks := c.breakerHealthChecks(req.ComponentName)
 		return &types.ComponentHealthCheckResponse{
 			ComponentName: req.ComponentName,
-			Health:        comp.Health,
+			Health:        applyBreakerHealth(comp.Health, checks, types.ComponentHealthUnhealthy),
 			Status:        comp.Status,
 			Message:       "Component health check completed",
+			Checks:        checks,
 			CheckedAt:     time.Now(),
 			Duration:      time.Since(start),
 		}, nil
@@ -721,10 +914,14 @@ func (c *Core) HealthCheck(req *types.ComponentHealthCheckRequest) (*types.Compo
 		}, nil
 	}
 
+	// Open breaker degrades system but does not make it unavailable
+	// Открытый breaker ухудшает состояние системы, но не делает ее недоступной
+	checks := c.breakerHealthChecks("")
 	return &types.ComponentHealthCheckResponse{
-		Health:    systemStatus.Health,
+		Health:    applyBreakerHealth(systemStatus.Health, checks, types.ComponentHealthDegraded),
 		Status:    systemStatus.Status,
 		Message:   "System health check completed",
+		Checks:    checks,
 		CheckedAt: time.Now(),
 		Duration:  time.Since(start),
 	}, nil
@@ -743,7 +940,7 @@ func (c *Core) StartProcessTyped(req *types.ProcessStartRequest) (*types.Process
 		variables[k] = v
 	}
 
-	result, err := c.processComp.StartProcessInstance(req.ProcessKey, variables)
+	result, err := c.processComp.StartProcessInstanceWithPriority(req.ProcessKey, variables, req.Priority)
 	if err != nil {
 		return &types.ProcessStartResponse{
 			ProcessKey: req.ProcessKey,
@@ -754,14 +951,15 @@ func (c *Core) StartProcessTyped(req *types.ProcessStartRequest) (*types.Process
 	}
 
 	return &types.ProcessStartResponse{
-		InstanceID: result.InstanceID,
-		ProcessKey: result.ProcessKey,
-		Version:    int32(result.ProcessVersion),
-		Status:     convertProcessInstanceState(result.State),
-		Success:    true,
-		Message:    "process started successfully",
-		StartedAt:  result.StartedAt,
-		Variables:  req.Variables,
+		InstanceID:  result.InstanceID,
+		InstanceKey: result.Key,
+		ProcessKey:  result.ProcessKey,
+		Version:     int32(result.ProcessVersion),
+		Status:      convertProcessInstanceState(result.State),
+		Success:     true,
+		Message:     "process started successfully",
+		StartedAt:   result.StartedAt,
+		Variables:   req.Variables,
 	}, nil
 }
 
@@ -0,0 +1,56 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"atom-engine/src/core/archive"
+	"atom-engine/src/core/config"
+)
+
+func TestConvertVariableEncryptionRedactsArchiveExports(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Storage.VariableEncryption = config.VariableEncryptionConfig{
+		Enabled:     true,
+		Key:         base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))),
+		KeyPatterns: []string{"^card"},
+	}
+	cfg.Archive.RedactKeyPatterns = []string{"password"}
+
+	encryption, err := convertVariableEncryption(cfg)
+	if err != nil {
+		t.Fatalf("convert variable encryption: %v", err)
+	}
+	if encryption == nil || len(encryption.KeyPatterns) != 1 {
+		t.Fatalf("unexpected storage encryption config: %+v", encryption)
+	}
+
+	redactor, err := archive.NewRedactor(cfg.Archive.RedactKeyPatterns)
+	if err != nil {
+		t.Fatalf("create redactor: %v", err)
+	}
+	redacted := redactor.RedactVariables(map[string]interface{}{
+		"cardNumber": "4111111111111111",
+		"password":   "secret",
+		"amount":     100,
+	})
+
+	if redacted["cardNumber"] != archive.RedactedValue {
+		t.Errorf("encrypted variable exported as %v, want redacted", redacted["cardNumber"])
+	}
+	if redacted["password"] != archive.RedactedValue {
+		t.Errorf("archive pattern no longer applied: %v", redacted["password"])
+	}
+	if redacted["amount"] != 100 {
+		t.Errorf("non-sensitive variable changed: %v", redacted["amount"])
+	}
+}
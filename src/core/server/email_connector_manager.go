@@ -0,0 +1,46 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"fmt"
+
+	"atom-engine/src/core/emailconnector"
+)
+
+// startEmailConnector starts email connector when enabled in configuration
+// Запускает email коннектор, если он включен в конфигурации
+func (c *Core) startEmailConnector() error {
+	if !c.config.EmailConnector.Enabled {
+		return nil
+	}
+	if c.jobsComp == nil || c.expressionComp == nil {
+		return fmt.Errorf("email connector requires jobs and expression components")
+	}
+
+	connector, err := emailconnector.NewConnector(c.config.EmailConnector, c.jobsComp, c.expressionComp, c.storage)
+	if err != nil {
+		return fmt.Errorf("failed to create email connector: %w", err)
+	}
+
+	connector.Start()
+	c.emailConnector = connector
+	return nil
+}
+
+// stopEmailConnector stops email connector if it was started
+// Останавливает email коннектор, если он был запущен
+func (c *Core) stopEmailConnector() {
+	if c.emailConnector == nil {
+		return
+	}
+
+	c.emailConnector.Stop()
+	c.emailConnector = nil
+}
@@ -0,0 +1,52 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"sync"
+
+	"atom-engine/src/core/models"
+)
+
+// engineEventHub fans out engine events published by components to registered listeners
+// Раздает события движка, опубликованные компонентами, зарегистрированным слушателям
+type engineEventHub struct {
+	mu        sync.RWMutex
+	listeners []models.EngineEventListener
+}
+
+// add registers listener
+// Регистрирует слушателя
+func (h *engineEventHub) add(listener models.EngineEventListener) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.listeners = append(h.listeners, listener)
+}
+
+// publish delivers event to every listener synchronously, listeners must not block
+// Синхронно доставляет событие каждому слушателю, слушатели не должны блокироваться
+func (h *engineEventHub) publish(event models.EngineEvent) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, listener := range h.listeners {
+		listener(event)
+	}
+}
+
+// AddEngineEventListener registers listener of engine events
+// Регистрирует слушателя событий движка
+func (c *Core) AddEngineEventListener(listener models.EngineEventListener) {
+	c.engineEvents.add(listener)
+}
+
+// PublishEngineEvent delivers engine event from component to registered listeners
+// Доставляет событие движка от компонента зарегистрированным слушателям
+func (c *Core) PublishEngineEvent(event models.EngineEvent) {
+	c.engineEvents.publish(event)
+}
@@ -0,0 +1,55 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"fmt"
+
+	"atom-engine/src/core/historyexport"
+	"atom-engine/src/core/types"
+)
+
+// startHistoryExporter starts history exporter when enabled in configuration
+// Запускает экспортер истории, если он включен в конфигурации
+func (c *Core) startHistoryExporter() error {
+	if !c.config.HistoryExport.Enabled {
+		return nil
+	}
+
+	exporter, err := historyexport.NewExporter(c.config.HistoryExport, c.storage)
+	if err != nil {
+		return fmt.Errorf("failed to create history exporter: %w", err)
+	}
+
+	exporter.Start()
+	c.AddEngineEventListener(exporter.HandleEngineEvent)
+	c.historyExporter = exporter
+	return nil
+}
+
+// stopHistoryExporter stops history exporter if it was started
+// Останавливает экспортер истории, если он был запущен
+func (c *Core) stopHistoryExporter() {
+	if c.historyExporter == nil {
+		return
+	}
+
+	c.historyExporter.Stop()
+	c.historyExporter = nil
+}
+
+// GetHistoryExportStats returns history exporter counters, nil when exporter is disabled
+// Возвращает счетчики экспортера истории, nil если экспортер выключен
+func (c *Core) GetHistoryExportStats() *types.HistoryExportStats {
+	exporter := c.historyExporter
+	if exporter == nil {
+		return nil
+	}
+	return exporter.Stats()
+}
@@ -11,9 +11,13 @@ package server
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"time"
 
 	"atom-engine/src/core/logger"
 	"atom-engine/src/core/models"
+	"atom-engine/src/core/types"
+	"atom-engine/src/storage"
 )
 
 // processJobsResponses processes jobs responses in background
@@ -60,13 +64,15 @@ func (c *Core) handleJobsResponse(response string) {
 
 		// Parse full callback for variables
 		var fullCallback struct {
-			JobID             string                 `json:"job_id"`
-			ElementID         string                 `json:"element_id"`
-			TokenID           string                 `json:"token_id"`
-			ProcessInstanceID string                 `json:"process_instance_id"`
-			Status            string                 `json:"status"`
-			Variables         map[string]interface{} `json:"variables"`
-			ErrorMessage      string                 `json:"error_message"`
+			JobID             string             `json:"job_id"`
+			ElementID         string             `json:"element_id"`
+			TokenID           string             `json:"token_id"`
+			ProcessInstanceID string             `json:"process_instance_id"`
+			Status            string             `json:"status"`
+			Variables         models.VariableMap `json:"variables"`
+			ErrorMessage      string             `json:"error_message"`
+			IntentID          string             `json:"intent_id"`
+			CallbackID        string             `json:"callback_id"`
 		}
 
 		json.Unmarshal([]byte(response), &fullCallback)
@@ -74,14 +80,17 @@ func (c *Core) handleJobsResponse(response string) {
 		// Forward job callback to process component
 		// Передаем job callback в process component
 		if c.processComp != nil {
-			if err := c.processComp.HandleJobCallback(
+			err := c.processComp.HandleJobCallback(
+				fullCallback.CallbackID,
 				fullCallback.JobID,
 				fullCallback.ElementID,
 				fullCallback.TokenID,
 				fullCallback.Status,
 				fullCallback.ErrorMessage,
 				fullCallback.Variables,
-			); err != nil {
+			)
+			c.acknowledgeCallbackIntent(fullCallback.IntentID, err)
+			if err != nil {
 				logger.Error("Failed to handle job callback in process component",
 					logger.String("job_id", fullCallback.JobID),
 					logger.String("element_id", fullCallback.ElementID),
@@ -104,12 +113,49 @@ func (c *Core) handleJobsResponse(response string) {
 
 	// Log job response to storage
 	// Логируем ответ job'а в storage
-	err := c.storage.LogSystemEvent(
-		models.EventTypeReady,
-		models.StatusSuccess,
-		fmt.Sprintf("Job completed: %s", response),
+	eventStatus := models.StatusSuccess
+	if jobResp.Status == "failed" {
+		eventStatus = models.StatusFailed
+	}
+	err := c.storage.LogSystemEventWithFields(
+		models.EventTypeJobCallback,
+		eventStatus,
+		fmt.Sprintf("Job %s: element %s", jobResp.Status, jobResp.ElementID),
+		storage.SystemEventFields{
+			Component:  models.EventComponentJobs,
+			InstanceID: jobResp.ProcessInstanceID,
+			JobID:      jobResp.JobID,
+		},
 	)
 	if err != nil {
 		logger.Warn("Failed to log job response to storage", logger.String("error", err.Error()))
 	}
 }
+
+// GetJobBacklogStats returns age of oldest activatable job per job type ordered by type
+// Returns nil when jobs component is not initialized or backlog cannot be read
+// Возвращает возраст самого старого доступного для активации job'а по типам, упорядоченно по типу
+// Возвращает nil, если jobs компонент не инициализирован или backlog не удалось прочитать
+func (c *Core) GetJobBacklogStats() []types.JobBacklogStats {
+	if c.jobsComp == nil {
+		return nil
+	}
+
+	ages, err := c.jobsComp.OldestActivatableAges()
+	if err != nil {
+		logger.Warn("Failed to collect job backlog stats", logger.String("error", err.Error()))
+		return nil
+	}
+
+	stats := make([]types.JobBacklogStats, 0, len(ages))
+	for jobType, ageMs := range ages {
+		stats = append(stats, types.JobBacklogStats{
+			JobType:              jobType,
+			OldestActivatableAge: time.Duration(ageMs) * time.Millisecond,
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].JobType < stats[j].JobType
+	})
+	return stats
+}
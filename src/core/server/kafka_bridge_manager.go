@@ -0,0 +1,47 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"fmt"
+
+	"atom-engine/src/core/kafkabridge"
+	"atom-engine/src/core/logger"
+)
+
+// startKafkaBridge starts Kafka bridge when enabled in configuration
+// Запускает мост Kafka, если он включен в конфигурации
+func (c *Core) startKafkaBridge() error {
+	if !c.config.KafkaBridge.Enabled {
+		return nil
+	}
+
+	bridge, err := kafkabridge.NewBridge(c.config.KafkaBridge, c.jobsComp)
+	if err != nil {
+		return fmt.Errorf("failed to create kafka bridge: %w", err)
+	}
+
+	bridge.Start()
+	c.AddEngineEventListener(bridge.HandleEngineEvent)
+	c.kafkaBridge = bridge
+	return nil
+}
+
+// stopKafkaBridge stops Kafka bridge if it was started
+// Останавливает мост Kafka, если он был запущен
+func (c *Core) stopKafkaBridge() {
+	if c.kafkaBridge == nil {
+		return
+	}
+
+	if err := c.kafkaBridge.Stop(); err != nil {
+		logger.Error("Failed to stop kafka bridge", logger.String("error", err.Error()))
+	}
+	c.kafkaBridge = nil
+}
@@ -9,12 +9,20 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package server
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"atom-engine/src/core/logger"
 	"atom-engine/src/core/models"
+	"atom-engine/src/core/tracing"
+	"atom-engine/src/storage"
 )
 
+// tracingShutdownTimeout limits flushing of buffered spans on shutdown
+// Ограничивает отправку буферизованных span'ов при остановке
+const tracingShutdownTimeout = 5 * time.Second
+
 // Start initializes and starts all components
 // Инициализирует и запускает все компоненты
 func (c *Core) Start() error {
@@ -33,6 +41,13 @@ func (c *Core) Start() error {
 	c.loggerReady = true
 	logger.Info("Logger initialized successfully")
 
+	// Initialize tracing before components, so their first spans are exported
+	// Инициализируем трассировку до компонентов, чтобы их первые span'ы экспортировались
+	if err := tracing.Init(c.config.Tracing, c.config.InstanceName); err != nil {
+		logger.Error("Failed to initialize tracing", logger.String("error", err.Error()))
+		return fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+
 	// Create PID file
 	err = c.createPIDFile()
 	if err != nil {
@@ -73,6 +88,10 @@ func (c *Core) Start() error {
 		logger.Error("Failed to start timewheel", logger.String("error", err.Error()))
 		return fmt.Errorf("failed to start timewheel: %w", err)
 	}
+	if c.timewheelComp.IsSimulated() {
+		logger.Warn("SIMULATION MODE: timers fire only when clock is advanced via POST /api/v1/sim/advance",
+			logger.String("clock", c.timewheelComp.Now().Format(time.RFC3339)))
+	}
 
 	// Initialize and start expression component
 	// Инициализируем и запускаем expression компонент
@@ -109,6 +128,10 @@ func (c *Core) Start() error {
 		return fmt.Errorf("failed to start process component: %w", err)
 	}
 
+	// Open incidents of instance statistics follow incident events
+	// Открытые инциденты статистики экземпляров отслеживаются по событиям инцидентов
+	c.AddEngineEventListener(c.processComp.GetInstanceStatistics().HandleEngineEvent)
+
 	// Initialize and start parser component
 	// Инициализируем и запускаем parser компонент
 	err = c.parserComp.Init()
@@ -193,11 +216,26 @@ func (c *Core) Start() error {
 	}
 
 	// Log startup event
-	err = c.storage.LogSystemEvent(models.EventTypeStartup, models.StatusInProgress, "Starting Atom Engine")
+	err = c.storage.LogSystemEventWithFields(models.EventTypeStartup, models.StatusInProgress, "Starting Atom Engine",
+		storage.SystemEventFields{Component: models.EventComponentCore})
 	if err != nil {
 		logger.Warn("Failed to log startup event to storage", logger.String("error", err.Error()))
 	}
 
+	// Load webhook triggers before REST API starts accepting hook calls
+	// Загружаем webhook триггеры до того, как REST API начнет принимать вызовы
+	if err := c.webhookTriggers.Load(); err != nil {
+		logger.Error("Failed to load webhook triggers", logger.String("error", err.Error()))
+		return err
+	}
+
+	// Route component responses by request ID before API servers accept requests
+	// Направляем ответы компонентов по ID запроса до того, как API серверы начнут принимать запросы
+	if err := c.registerComponentResponses(); err != nil {
+		logger.Error("Failed to register component responses", logger.String("error", err.Error()))
+		return fmt.Errorf("failed to register component responses: %w", err)
+	}
+
 	// Start gRPC server
 	err = c.startGRPCServer()
 	if err != nil {
@@ -227,24 +265,90 @@ func (c *Core) Start() error {
 		go c.processJobCallbacks()
 	}
 
-	// Start messages response processor
-	// Запускаем обработчик ответов messages
-	go c.processMessagesResponses()
+	// Start Kafka bridge after jobs component and callback processing are ready
+	// Запускаем мост Kafka после готовности jobs компонента и обработки callback'ов
+	if err := c.startKafkaBridge(); err != nil {
+		logger.Error("Failed to start kafka bridge", logger.String("error", err.Error()))
+		return err
+	}
+
+	// Start NATS bridge after messages component is ready to publish incoming messages
+	// Запускаем мост NATS после готовности компонента messages публиковать входящие сообщения
+	if err := c.startNATSBridge(); err != nil {
+		logger.Error("Failed to start nats bridge", logger.String("error", err.Error()))
+		return err
+	}
+
+	// Start email connector once jobs component accepts activations
+	// Запускаем email коннектор, когда jobs компонент принимает активации
+	if err := c.startEmailConnector(); err != nil {
+		logger.Error("Failed to start email connector", logger.String("error", err.Error()))
+		return err
+	}
+
+	// Start history exporter, records left in backlog from previous run are sent first
+	// Запускаем экспортер истории, записи, оставшиеся в очереди с прошлого запуска, отправляются первыми
+	if err := c.startHistoryExporter(); err != nil {
+		logger.Error("Failed to start history exporter", logger.String("error", err.Error()))
+		return err
+	}
+
+	// Object store shared by instance exports, storage backups and retention archives
+	// Объектное хранилище для экспорта экземпляров, резервных копий и архивов очистки
+	if err := c.initObjectStore(); err != nil {
+		logger.Error("Failed to initialize object storage", logger.String("error", err.Error()))
+		return err
+	}
 
 	c.running = true
+	c.backgroundStop = make(chan struct{})
+
+	// Start system events retention cleanup
+	// Запускаем очистку системных событий по сроку хранения
+	c.startBackground(c.runSystemEventsRetention)
+
+	// Start process instance retention sweeper
+	// Запускаем очистку экземпляров процессов по сроку хранения
+	if err := c.initRetentionSweeper(); err != nil {
+		logger.Error("Failed to initialize process instance retention", logger.String("error", err.Error()))
+	} else {
+		c.startBackground(c.runInstanceRetention)
+	}
 	logger.Info("Atom Engine started successfully")
 
 	// Log successful startup
-	err = c.storage.LogSystemEvent(models.EventTypeStartup, models.StatusSuccess, "Atom Engine started successfully")
+	err = c.storage.LogSystemEventWithFields(
+		models.EventTypeStartup, models.StatusSuccess, "Atom Engine started successfully",
+		storage.SystemEventFields{Component: models.EventComponentCore},
+	)
 	if err != nil {
 		logger.Warn("Failed to log startup success to storage", logger.String("error", err.Error()))
 	}
 
+	// Schedule timer start events of deployed processes
+	// Планируем стартовые события таймера развернутых процессов
+	c.startTimerStartScheduling()
+
+	// Recover in-flight state in background, startup probe reports engine started once it completes
+	// Core lock is held here, recovery runs after Start returns
+	// Восстанавливаем выполняющееся состояние в фоне, startup probe сообщает о запуске после его завершения
+	go c.recoverState()
+
+	return nil
+}
+
+// recoverState restores process instances, timers and pending callbacks left by previous run
+// Восстанавливает экземпляры процессов, таймеры и ожидающие callbacks, оставшиеся от предыдущего запуска
+func (c *Core) recoverState() {
+	started := time.Now()
+	logger.Info("Recovering engine state from storage")
+
+	c.processComp.Recover()
+
 	// Restore timers from storage after everything is initialized
 	// Восстанавливаем таймеры из storage после полной инициализации
 	logger.Info("Restoring timers from storage")
-	err = c.timewheelComp.RestoreTimers()
-	if err != nil {
+	if err := c.timewheelComp.RestoreTimers(); err != nil {
 		logger.Error("Failed to restore timers", logger.String("error", err.Error()))
 		// Don't fail startup - just warn about timer restoration
 		logger.Warn("Timer restoration failed, continuing without restored timers")
@@ -252,7 +356,13 @@ func (c *Core) Start() error {
 		logger.Info("Timer restoration completed")
 	}
 
-	return nil
+	// Replay callback intents left pending by previous run
+	// Повторно применяем намерения callback, оставшиеся от предыдущего запуска
+	c.replayCallbackIntents()
+
+	c.recovered.Store(true)
+	logger.Info("Engine state recovery completed",
+		logger.String("duration", time.Since(started).String()))
 }
 
 // Stop gracefully stops all components
@@ -267,18 +377,45 @@ func (c *Core) Stop() error {
 
 	logger.Info("Shutting down Atom Engine")
 
+	// Readiness probe fails from now on, so traffic is drained before servers stop
+	// С этого момента readiness probe не проходит, чтобы трафик ушел до остановки серверов
+	c.recovered.Store(false)
+
 	// Log shutdown event
-	err := c.storage.LogSystemEvent(models.EventTypeShutdown, models.StatusInProgress, "Shutting down Atom Engine")
+	err := c.storage.LogSystemEventWithFields(
+		models.EventTypeShutdown, models.StatusInProgress, "Shutting down Atom Engine",
+		storage.SystemEventFields{Component: models.EventComponentCore},
+	)
 	if err != nil {
 		logger.Warn("Failed to log shutdown event to storage", logger.String("error", err.Error()))
 	}
 
+	// Stop background loops while storage is still open
+	// Останавливаем фоновые циклы, пока storage открыт
+	c.stopBackground()
+
 	// Stop gRPC server
 	c.stopGRPCServer()
 
 	// Stop REST API server
 	c.stopRESTServer()
 
+	// Stop Kafka bridge before components it activates and completes jobs through
+	// Останавливаем мост Kafka до компонентов, через которые он активирует и завершает job'ы
+	c.stopKafkaBridge()
+
+	// Stop NATS bridge before messages component it publishes messages through
+	// Останавливаем мост NATS до компонента messages, через который он публикует сообщения
+	c.stopNATSBridge()
+
+	// Stop email connector before jobs component it completes jobs through
+	// Останавливаем email коннектор до jobs компонента, через который он завершает job'ы
+	c.stopEmailConnector()
+
+	// Stop history exporter while storage is still open, unsent records stay in backlog
+	// Останавливаем экспортер истории, пока storage открыт, неотправленные записи остаются в очереди
+	c.stopHistoryExporter()
+
 	// Stop expression component
 	// Останавливаем expression компонент
 	if c.expressionComp != nil {
@@ -323,6 +460,10 @@ func (c *Core) Stop() error {
 		}
 	}
 
+	// Stop reading component responses before their channels close
+	// Прекращаем чтение ответов компонентов до закрытия их каналов
+	c.responses.stop()
+
 	// Stop jobs message multiplexer first
 	// Сначала останавливаем jobs message multiplexer
 	err = c.stopJobsMultiplexer()
@@ -374,6 +515,10 @@ func (c *Core) Stop() error {
 		}
 	}
 
+	// Flush spans after components stopped producing them
+	// Отправляем span'ы после остановки компонентов, которые их создают
+	c.shutdownTracing()
+
 	// Stop storage
 	err = c.storage.Stop()
 	if err != nil {
@@ -396,6 +541,37 @@ func (c *Core) Stop() error {
 	return nil
 }
 
+// shutdownTracing flushes buffered spans, spans not sent within timeout are lost
+// Отправляет буферизованные span'ы, не отправленные за таймаут span'ы теряются
+func (c *Core) shutdownTracing() {
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	if err := tracing.Shutdown(ctx); err != nil {
+		logger.Warn("Failed to flush traces", logger.String("error", err.Error()))
+	}
+}
+
+// startBackground runs loop in goroutine tracked until stopBackground
+// Запускает цикл в горутине, отслеживаемой до stopBackground
+func (c *Core) startBackground(loop func(stop <-chan struct{})) {
+	stop := c.backgroundStop
+	c.background.Add(1)
+	go func() {
+		defer c.background.Done()
+		loop(stop)
+	}()
+}
+
+// stopBackground signals background loops to stop and waits for them to return
+// Сигнализирует фоновым циклам об остановке и ожидает их завершения
+func (c *Core) stopBackground() {
+	if c.backgroundStop != nil {
+		close(c.backgroundStop)
+		c.backgroundStop = nil
+	}
+	c.background.Wait()
+}
+
 // IsRunning returns core running status
 // Возвращает статус работы core
 func (c *Core) IsRunning() bool {
@@ -408,7 +584,8 @@ func (c *Core) IsRunning() bool {
 // Логирует системное событие (вспомогательный метод)
 func (c *Core) logEvent(eventType, status, message string) error {
 	if c.storage != nil && c.storage.IsReady() {
-		return c.storage.LogSystemEvent(eventType, status, message)
+		return c.storage.LogSystemEventWithFields(eventType, status, message,
+			storage.SystemEventFields{Component: models.EventComponentCore})
 	}
 	return fmt.Errorf("storage not available")
 }
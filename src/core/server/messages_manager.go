@@ -14,33 +14,9 @@ import (
 
 	"atom-engine/src/core/logger"
 	"atom-engine/src/core/models"
+	"atom-engine/src/storage"
 )
 
-// processMessagesResponses processes messages responses in background
-// Обрабатывает ответы messages в фоне
-func (c *Core) processMessagesResponses() {
-	if c.messagesComp == nil {
-		logger.Warn("Messages component is nil in processMessagesResponses")
-		return
-	}
-
-	responseChannel := c.messagesComp.GetResponseChannel()
-	if responseChannel == nil {
-		logger.Warn("Response channel is nil from messages component")
-		return
-	}
-
-	logger.Info("Messages response processor started")
-
-	for {
-		select {
-		case response := <-responseChannel:
-			logger.Info("Received message response", logger.String("response", response))
-			c.handleMessagesResponse(response)
-		}
-	}
-}
-
 // handleMessagesResponse handles single messages response
 // Обрабатывает один ответ messages
 func (c *Core) handleMessagesResponse(response string) {
@@ -55,6 +31,7 @@ func (c *Core) handleMessagesResponse(response string) {
 		Variables         map[string]interface{} `json:"variables"`
 		CorrelatedAt      string                 `json:"correlated_at"`
 		EventType         string                 `json:"event_type"`
+		IntentID          string                 `json:"intent_id"`
 	}
 
 	if err := json.Unmarshal([]byte(response), &messageResp); err == nil {
@@ -84,13 +61,15 @@ func (c *Core) handleMessagesResponse(response string) {
 				logger.String("message_id", messageResp.MessageID),
 				logger.String("token_id", messageResp.TokenID))
 
-			if err := c.processComp.HandleMessageCallback(
+			err := c.processComp.HandleMessageCallback(
 				messageResp.MessageID,
 				messageResp.MessageName,
 				messageResp.CorrelationKey,
 				messageResp.TokenID,
 				messageResp.Variables,
-			); err != nil {
+			)
+			c.acknowledgeCallbackIntent(messageResp.IntentID, err)
+			if err != nil {
 				logger.Error("Failed to handle message callback in process component",
 					logger.String("message_id", messageResp.MessageID),
 					logger.String("message_name", messageResp.MessageName),
@@ -119,8 +98,13 @@ func (c *Core) handleMessagesResponse(response string) {
 
 	// Log message response to storage
 	// Логируем ответ сообщения в storage
-	err := c.storage.LogSystemEvent(models.EventTypeReady, models.StatusSuccess,
-		fmt.Sprintf("Message callback: %s", response))
+	err := c.storage.LogSystemEventWithFields(models.EventTypeMessageCallback, models.StatusSuccess,
+		fmt.Sprintf("Message %s: %s (correlation key %s)",
+			messageResp.EventType, messageResp.MessageName, messageResp.CorrelationKey),
+		storage.SystemEventFields{
+			Component:  models.EventComponentMessages,
+			InstanceID: messageResp.ProcessInstanceID,
+		})
 	if err != nil {
 		logger.Warn("Failed to log message callback to storage", logger.String("error", err.Error()))
 	}
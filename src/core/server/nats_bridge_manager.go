@@ -0,0 +1,47 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"fmt"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/natsbridge"
+)
+
+// startNATSBridge starts NATS bridge when enabled in configuration
+// Запускает мост NATS, если он включен в конфигурации
+func (c *Core) startNATSBridge() error {
+	if !c.config.NATSBridge.Enabled {
+		return nil
+	}
+
+	bridge, err := natsbridge.NewBridge(c.config.NATSBridge, c.messagesComp)
+	if err != nil {
+		return fmt.Errorf("failed to create nats bridge: %w", err)
+	}
+
+	bridge.Start()
+	c.AddEngineEventListener(bridge.HandleEngineEvent)
+	c.natsBridge = bridge
+	return nil
+}
+
+// stopNATSBridge stops NATS bridge if it was started
+// Останавливает мост NATS, если он был запущен
+func (c *Core) stopNATSBridge() {
+	if c.natsBridge == nil {
+		return
+	}
+
+	if err := c.natsBridge.Stop(); err != nil {
+		logger.Error("Failed to stop nats bridge", logger.String("error", err.Error()))
+	}
+	c.natsBridge = nil
+}
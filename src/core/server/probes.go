@@ -0,0 +1,32 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import "atom-engine/src/core/types"
+
+// GetProbeStatus returns recovery flag and readiness of components for readiness and startup probes
+// Core lock is not taken, so probes answer while Start holds it
+// Возвращает флаг восстановления и готовность компонентов для readiness и startup probe
+// Блокировка core не захватывается, чтобы probe отвечали, пока ее держит Start
+func (c *Core) GetProbeStatus() *types.ProbeStatus {
+	return &types.ProbeStatus{
+		Recovered: c.recovered.Load(),
+		Components: map[string]bool{
+			"storage":    c.storage != nil && c.storage.IsReady(),
+			"timewheel":  c.timewheelComp != nil && c.timewheelComp.IsReady(),
+			"expression": c.expressionComp != nil && c.expressionComp.IsReady(),
+			"process":    c.processComp != nil && c.processComp.IsReady(),
+			"parser":     c.parserComp != nil && c.parserComp.IsReady(),
+			"jobs":       c.jobsComp != nil && c.jobsComp.IsReady(),
+			"messages":   c.messagesComp != nil && c.messagesComp.IsRunning(),
+			"incidents":  c.incidentsComp != nil && c.incidentsComp.IsReady(),
+			"auth":       c.authComp != nil && c.authComp.IsReady(),
+		},
+	}
+}
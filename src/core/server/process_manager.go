@@ -9,6 +9,7 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package server
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -40,19 +41,96 @@ func (a *processComponentAdapter) StartProcessInstance(
 	processKey string,
 	variables map[string]interface{},
 ) (*interfaces.ProcessInstanceResult, error) {
-	instance, err := a.comp.StartProcessInstance(processKey, variables)
+	return a.StartProcessInstanceWithPriority(processKey, variables, 0)
+}
+
+// StartProcessInstanceWithPriority starts new process instance with given priority
+// Запускает новый экземпляр процесса с заданным приоритетом
+func (a *processComponentAdapter) StartProcessInstanceWithPriority(
+	processKey string,
+	variables map[string]interface{},
+	priority int,
+) (*interfaces.ProcessInstanceResult, error) {
+	return a.StartProcessInstanceWithContext(context.Background(), processKey, variables, priority)
+}
+
+// StartProcessInstanceWithContext starts new process instance traced under span in ctx
+// Запускает новый экземпляр процесса, трассируемый под span'ом из ctx
+func (a *processComponentAdapter) StartProcessInstanceWithContext(
+	ctx context.Context,
+	processKey string,
+	variables map[string]interface{},
+	priority int,
+) (*interfaces.ProcessInstanceResult, error) {
+	instance, err := a.comp.StartProcessInstanceWithContext(ctx, processKey, variables, priority)
 	if err != nil {
 		return nil, err
 	}
+	return newProcessInstanceResult(instance), nil
+}
+
+// StartProcessInstanceAsync starts new process instance, execution continues in background
+// Запускает новый экземпляр процесса, выполнение продолжается в фоне
+func (a *processComponentAdapter) StartProcessInstanceAsync(
+	ctx context.Context,
+	processKey string,
+	variables map[string]interface{},
+	priority int,
+) (*interfaces.ProcessInstanceResult, error) {
+	instance, err := a.comp.StartProcessInstanceAsync(ctx, processKey, variables, priority)
+	if err != nil {
+		return nil, err
+	}
+	return newProcessInstanceResult(instance), nil
+}
+
+// RestartProcessInstance starts new instance from element of finished instance
+// Запускает новый экземпляр с элемента завершенного экземпляра
+func (a *processComponentAdapter) RestartProcessInstance(
+	instanceID string,
+	elementID string,
+) (*interfaces.ProcessInstanceResult, error) {
+	instance, err := a.comp.RestartProcessInstance(instanceID, elementID)
+	if err != nil {
+		return nil, err
+	}
+	return newProcessInstanceResult(instance), nil
+}
+
+// ModifyProcessInstance applies batch of modification instructions to running instance
+// Применяет пакет инструкций модификации к выполняющемуся экземпляру
+func (a *processComponentAdapter) ModifyProcessInstance(
+	instanceID string,
+	instructions []models.ModificationInstruction,
+) (*models.ProcessInstanceModification, error) {
+	return a.comp.ModifyProcessInstance(instanceID, instructions)
+}
+
+// MigrateProcessInstances moves running instances to another version of their process definition
+// Переносит выполняющиеся экземпляры на другую версию их определения процесса
+func (a *processComponentAdapter) MigrateProcessInstances(
+	plan models.MigrationPlan,
+	filter models.MigrationFilter,
+	dryRun bool,
+) (*models.ProcessInstanceMigration, error) {
+	return a.comp.MigrateProcessInstances(plan, filter, dryRun)
+}
 
+// newProcessInstanceResult converts started instance to result
+// Конвертирует запущенный экземпляр в результат
+func newProcessInstanceResult(instance *models.ProcessInstance) *interfaces.ProcessInstanceResult {
 	return &grpc.ProcessInstanceResult{
-		InstanceID:  instance.InstanceID,
-		ProcessID:   instance.ProcessID,
-		ProcessName: instance.ProcessName,
-		State:       string(instance.State),
-		StartedAt:   instance.StartedAt.Unix(),
-		Variables:   instance.Variables,
-	}, nil
+		InstanceID:       instance.InstanceID,
+		InstanceKey:      instance.Key,
+		ProcessID:        instance.ProcessID,
+		ProcessName:      instance.ProcessName,
+		State:            string(instance.State),
+		Priority:         instance.Priority,
+		StartedAt:        instance.StartedAt.Unix(),
+		Variables:        instance.Variables,
+		ParentInstanceID: instance.ParentInstanceID,
+		SLADeadline:      formatOptionalTime(instance.SLADeadline),
+	}
 }
 
 // GetProcessInstanceStatus gets process instance status
@@ -64,25 +142,7 @@ func (a *processComponentAdapter) GetProcessInstanceStatus(
 	if err != nil {
 		return nil, err
 	}
-
-	var completedAtStr string
-	if instance.CompletedAt != nil {
-		completedAtStr = instance.CompletedAt.Format("2006-01-02T15:04:05Z07:00")
-	}
-
-	return &interfaces.ProcessInstanceStatus{
-		InstanceID:      instance.InstanceID,
-		ProcessID:       instance.ProcessID,
-		ProcessName:     instance.ProcessName,
-		Status:          string(instance.State),
-		State:           string(instance.State),
-		CurrentActivity: instance.CurrentActivity,
-		StartedAt:       instance.StartedAt.Unix(),
-		UpdatedAt:       instance.UpdatedAt.Unix(),
-		CompletedAt:     completedAtStr,
-		Variables:       instance.Variables,
-		CreatedAt:       instance.StartedAt.Format("2006-01-02T15:04:05Z07:00"), // Use StartedAt as CreatedAt
-	}, nil
+	return newProcessInstanceStatus(instance), nil
 }
 
 // CancelProcessInstance cancels process instance
@@ -105,30 +165,66 @@ func (a *processComponentAdapter) ListProcessInstances(
 
 	var results []*interfaces.ProcessInstanceStatus
 	for _, instance := range instances {
-		var completedAtStr string
-		if instance.CompletedAt != nil {
-			completedAtStr = instance.CompletedAt.Format("2006-01-02T15:04:05Z07:00")
-		}
-
-		result := &interfaces.ProcessInstanceStatus{
-			InstanceID:      instance.InstanceID,
-			ProcessID:       instance.ProcessID,
-			ProcessName:     instance.ProcessName,
-			Status:          string(instance.State),
-			State:           string(instance.State),
-			CurrentActivity: instance.CurrentActivity,
-			StartedAt:       instance.StartedAt.Unix(),
-			UpdatedAt:       instance.UpdatedAt.Unix(),
-			CompletedAt:     completedAtStr,
-			Variables:       instance.Variables,
-			CreatedAt:       instance.StartedAt.Format("2006-01-02T15:04:05Z07:00"), // Use StartedAt as CreatedAt
-		}
-		results = append(results, result)
+		results = append(results, newProcessInstanceStatus(instance))
 	}
 
 	return results, nil
 }
 
+// QueryProcessInstances returns sorted page of process instances and total count matching filters
+// Возвращает отсортированную страницу экземпляров процессов и общее количество подходящих под фильтры
+func (a *processComponentAdapter) QueryProcessInstances(
+	query models.ProcessInstanceQuery,
+) ([]*interfaces.ProcessInstanceStatus, int, error) {
+	instances, total, err := a.comp.QueryProcessInstances(query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	results := make([]*interfaces.ProcessInstanceStatus, 0, len(instances))
+	for _, instance := range instances {
+		results = append(results, newProcessInstanceStatus(instance))
+	}
+	return results, total, nil
+}
+
+// newProcessInstanceStatus converts process instance to status
+// Конвертирует экземпляр процесса в статус
+func newProcessInstanceStatus(instance *models.ProcessInstance) *interfaces.ProcessInstanceStatus {
+	var completedAtStr string
+	if instance.CompletedAt != nil {
+		completedAtStr = instance.CompletedAt.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return &interfaces.ProcessInstanceStatus{
+		InstanceID:       instance.InstanceID,
+		InstanceKey:      instance.Key,
+		ProcessID:        instance.ProcessID,
+		ProcessName:      instance.ProcessName,
+		Status:           string(instance.State),
+		State:            string(instance.State),
+		CurrentActivity:  instance.CurrentActivity,
+		Priority:         instance.Priority,
+		StartedAt:        instance.StartedAt.Unix(),
+		UpdatedAt:        instance.UpdatedAt.Unix(),
+		CompletedAt:      completedAtStr,
+		Variables:        instance.Variables,
+		CreatedAt:        instance.StartedAt.Format("2006-01-02T15:04:05Z07:00"), // Use StartedAt as CreatedAt
+		ParentInstanceID: instance.ParentInstanceID,
+		SLADeadline:      formatOptionalTime(instance.SLADeadline),
+		SLABreachedAt:    formatOptionalTime(instance.SLABreachedAt),
+	}
+}
+
+// formatOptionalTime formats time as RFC 3339, nil gives empty string
+// Форматирует время в RFC 3339, nil дает пустую строку
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
 // GetTokensByProcessInstance gets tokens for process instance
 // Получает токены для экземпляра процесса
 func (a *processComponentAdapter) GetTokensByProcessInstance(instanceID string) ([]*models.Token, error) {
@@ -141,6 +237,15 @@ func (a *processComponentAdapter) GetActiveTokens(instanceID string) ([]*models.
 	return a.comp.GetActiveTokens(instanceID)
 }
 
+// PatchProcessInstanceVariables applies JSON merge patch to process instance variables
+// Применяет JSON merge patch к переменным экземпляра процесса
+func (a *processComponentAdapter) PatchProcessInstanceVariables(
+	instanceID string,
+	patch map[string]interface{},
+) (map[string]interface{}, error) {
+	return a.comp.PatchProcessInstanceVariables(instanceID, patch)
+}
+
 // ProcessComponentTypedInterface implementation
 // Реализация ProcessComponentTypedInterface
 
@@ -191,6 +296,7 @@ func (a *processComponentAdapter) StartProcessInstanceTyped(
 
 	return &types.ProcessInstanceDetails{
 		InstanceID:          instance.InstanceID,
+		InstanceKey:         instance.Key,
 		ProcessKey:          instance.ProcessKey, // Use actual process key from instance
 		ProcessDefinitionID: instance.ProcessID,
 		Version:             int32(instance.ProcessVersion), // Use actual version from instance
@@ -255,6 +361,7 @@ func (a *processComponentAdapter) GetProcessInstanceStatusTyped(
 
 	return &types.ProcessInstanceDetails{
 		InstanceID:          instance.InstanceID,
+		InstanceKey:         instance.Key,
 		ProcessKey:          instance.ProcessKey, // Use actual process key from instance
 		ProcessDefinitionID: instance.ProcessID,
 		Version:             int32(instance.ProcessVersion), // Use actual version from instance
@@ -337,6 +444,7 @@ func (a *processComponentAdapter) ListProcessInstancesTyped(
 
 		typedInstance := types.ProcessInstanceDetails{
 			InstanceID:          instance.InstanceID,
+			InstanceKey:         instance.Key,
 			ProcessKey:          instance.ProcessKey, // Use actual process key from instance
 			ProcessDefinitionID: instance.ProcessID,
 			Version:             int32(instance.ProcessVersion), // Use actual version from instance
@@ -634,3 +742,152 @@ func (a *processComponentAdapter) TraceProcessExecution(
 		CompletedTokens:   completedCount,
 	}, nil
 }
+
+// FillProcessInstanceCounts sets active timer, active job and open incident counts of instances
+// Counts of all instances are computed by single storage scan
+// Заполняет число активных таймеров, активных job'ов и открытых инцидентов экземпляров
+// Количества всех экземпляров вычисляются одним проходом по хранилищу
+func (c *Core) FillProcessInstanceCounts(instances []*interfaces.ProcessInstanceStatus) error {
+	if len(instances) == 0 {
+		return nil
+	}
+	if c.storage == nil {
+		return fmt.Errorf("storage not available")
+	}
+
+	instanceIDs := make([]string, len(instances))
+	for i, instance := range instances {
+		instanceIDs[i] = instance.InstanceID
+	}
+
+	counts, err := c.storage.CountInstanceActivity(instanceIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, instance := range instances {
+		instanceCounts, ok := counts[instance.InstanceID]
+		if !ok {
+			continue
+		}
+		activeTimers := instanceCounts.ActiveTimers
+		activeJobs := instanceCounts.ActiveJobs
+		openIncidents := instanceCounts.OpenIncidents
+		instance.ActiveTimers = &activeTimers
+		instance.ActiveJobs = &activeJobs
+		instance.OpenIncidents = &openIncidents
+	}
+	return nil
+}
+
+// PatchProcessInstanceVariables applies JSON merge patch to process instance variables
+// Применяет JSON merge patch к переменным экземпляра процесса
+func (c *Core) PatchProcessInstanceVariables(
+	instanceID string,
+	patch map[string]interface{},
+) (map[string]interface{}, error) {
+	if c.processComp == nil {
+		return nil, fmt.Errorf("process component not available")
+	}
+	return c.processComp.PatchProcessInstanceVariables(instanceID, patch)
+}
+
+// StartProcessInstanceBatch starts instances of batch items, async returns at once with running batch
+// Запускает экземпляры элементов пакета, при async сразу возвращает выполняющийся пакет
+func (c *Core) StartProcessInstanceBatch(
+	ctx context.Context,
+	items []models.BatchStartItem,
+	async bool,
+) (*models.BatchStart, error) {
+	if c.processComp == nil {
+		return nil, fmt.Errorf("process component not available")
+	}
+	if async {
+		return c.processComp.StartProcessInstanceBatchAsync(ctx, items)
+	}
+	return c.processComp.StartProcessInstanceBatch(ctx, items)
+}
+
+// GetProcessInstanceBatch returns progress of background batch start
+// Возвращает прогресс фонового пакетного запуска
+func (c *Core) GetProcessInstanceBatch(batchID string) (*models.BatchStart, error) {
+	if c.processComp == nil {
+		return nil, fmt.Errorf("process component not available")
+	}
+	return c.processComp.GetProcessInstanceBatch(batchID)
+}
+
+// GetProcessStatistics returns instance statistics of process definition versions
+// Возвращает статистику экземпляров версий определения процесса
+func (c *Core) GetProcessStatistics(processID string, days int) (*models.ProcessStatistics, error) {
+	if c.processComp == nil {
+		return nil, fmt.Errorf("process component not available")
+	}
+	return c.processComp.GetProcessStatistics(processID, days), nil
+}
+
+// DryRunProcess walks process definition with stubbed outcomes without creating instance
+// Проходит определение процесса с заглушками исходов, не создавая экземпляр
+func (c *Core) DryRunProcess(processKey string, request *models.DryRunRequest) (*models.DryRunResult, error) {
+	if c.processComp == nil {
+		return nil, fmt.Errorf("process component not available")
+	}
+	return c.processComp.DryRunProcess(processKey, request)
+}
+
+// GetGatewayMetrics returns branching distribution of conditional gateways of process definition version
+// Возвращает распределение ветвлений условных шлюзов версии определения процесса
+func (c *Core) GetGatewayMetrics(processKey string) (*models.GatewayMetrics, error) {
+	if c.processComp == nil {
+		return nil, fmt.Errorf("process component not available")
+	}
+	return c.processComp.GetGatewayMetrics(processKey)
+}
+
+// SetProcessDebugLogging temporarily elevates execution logs of process definition version to info
+// Временно поднимает логи выполнения версии определения процесса до уровня info
+func (c *Core) SetProcessDebugLogging(
+	processKey string,
+	debug bool,
+	duration time.Duration,
+) (*models.ProcessLogging, error) {
+	if c.processComp == nil {
+		return nil, fmt.Errorf("process component not available")
+	}
+	return c.processComp.SetProcessDebugLogging(processKey, debug, duration)
+}
+
+// ListElementInstances returns page of element activations of process instance and total count
+// Возвращает страницу активаций элементов экземпляра процесса и общее количество
+func (c *Core) ListElementInstances(query models.ElementInstanceQuery) ([]*models.ElementInstance, int, error) {
+	if c.processComp == nil {
+		return nil, 0, fmt.Errorf("process component not available")
+	}
+	return c.processComp.ListElementInstances(query)
+}
+
+// ListVariableHistory returns page of values of process instance variable and total count
+// Возвращает страницу значений переменной экземпляра процесса и общее количество
+func (c *Core) ListVariableHistory(query models.VariableHistoryQuery) ([]*models.VariableHistoryEntry, int, error) {
+	if c.processComp == nil {
+		return nil, 0, fmt.Errorf("process component not available")
+	}
+	return c.processComp.ListVariableHistory(query)
+}
+
+// GetTokenExecutionStats returns token execution pool state
+// Возвращает состояние пула выполнения токенов
+func (c *Core) GetTokenExecutionStats() (*types.TokenExecutionStats, error) {
+	if c.processComp == nil {
+		return nil, fmt.Errorf("process component not available")
+	}
+	stats := c.processComp.GetTokenExecutionStats()
+	return &types.TokenExecutionStats{
+		Workers:       stats.Workers,
+		InFlight:      stats.InFlight,
+		QueueDepth:    stats.QueueDepth,
+		QueueCapacity: stats.QueueCapacity,
+		Executed:      stats.Executed,
+		CallerRuns:    stats.CallerRuns,
+	}, nil
+}
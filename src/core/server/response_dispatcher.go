@@ -0,0 +1,191 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+)
+
+// pendingResponseTTL bounds how long response waits for its caller, longer than any response wait timeout
+// Ограничивает время ожидания ответом своего вызывающего, больше любого таймаута ожидания ответа
+const pendingResponseTTL = time.Minute
+
+// pendingResponse is response received before its caller started waiting
+// Ответ, полученный до того, как вызывающий начал его ожидать
+type pendingResponse struct {
+	response   string
+	receivedAt time.Time
+}
+
+// responseDispatcher delivers component responses to callers by request ID,
+// so concurrent requests to one component never receive each other's responses
+// Доставляет ответы компонентов вызывающим по ID запроса,
+// поэтому параллельные запросы к одному компоненту не получают чужие ответы
+type responseDispatcher struct {
+	mu         sync.Mutex
+	waiters    map[string]chan string
+	pending    map[string]pendingResponse
+	lastPruned time.Time
+	sequence   atomic.Uint64
+	stopChan   chan struct{}
+	stopOnce   sync.Once
+}
+
+// newResponseDispatcher creates dispatcher without registered components
+// Создает диспетчер без зарегистрированных компонентов
+func newResponseDispatcher() *responseDispatcher {
+	return &responseDispatcher{
+		waiters:  make(map[string]chan string),
+		pending:  make(map[string]pendingResponse),
+		stopChan: make(chan struct{}),
+	}
+}
+
+// register starts reading responses of component from source channel
+// Messages without request ID are passed to unsolicited handler, dropped when handler is nil
+// Запускает чтение ответов компонента из исходного канала
+// Сообщения без ID запроса передаются обработчику unsolicited, отбрасываются при nil обработчике
+func (d *responseDispatcher) register(component string, source <-chan string, unsolicited func(string)) error {
+	if source == nil {
+		return fmt.Errorf("%s response channel not available", component)
+	}
+
+	go func() {
+		for {
+			select {
+			case response, ok := <-source:
+				if !ok {
+					logger.Debug("Component response channel closed", logger.String("component", component))
+					return
+				}
+				d.dispatch(component, response, unsolicited)
+			case <-d.stopChan:
+				return
+			}
+		}
+	}()
+
+	logger.Info("Component responses registered with dispatcher", logger.String("component", component))
+	return nil
+}
+
+// stop ends reading of all registered components
+// Останавливает чтение всех зарегистрированных компонентов
+func (d *responseDispatcher) stop() {
+	d.stopOnce.Do(func() { close(d.stopChan) })
+}
+
+// nextRequestID returns request ID unique within daemon, base keeps link to caller's request ID in logs
+// Возвращает ID запроса, уникальный в пределах демона, base сохраняет связь с ID запроса вызывающего в логах
+func (d *responseDispatcher) nextRequestID(component, base string) string {
+	if base == "" {
+		base = component
+	}
+	return fmt.Sprintf("%s#%d", base, d.sequence.Add(1))
+}
+
+// dispatch hands response to caller waiting for its request ID or keeps it until caller starts waiting
+// Передает ответ вызывающему, ожидающему его ID запроса, или хранит до начала ожидания
+func (d *responseDispatcher) dispatch(component, response string, unsolicited func(string)) {
+	requestID := models.MessageRequestID(response)
+	if requestID == "" {
+		if unsolicited != nil {
+			unsolicited(response)
+			return
+		}
+		logger.Debug("Dropping component response without request ID",
+			logger.String("component", component),
+			logger.Int("response_length", len(response)))
+		return
+	}
+
+	key := responseKey(component, requestID)
+	now := time.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if waiter, ok := d.waiters[key]; ok {
+		delete(d.waiters, key)
+		waiter <- response
+		return
+	}
+
+	d.pruneLocked(now)
+	d.pending[key] = pendingResponse{response: response, receivedAt: now}
+}
+
+// wait returns response of component to request ID or error after timeout
+// Возвращает ответ компонента на ID запроса или ошибку по таймауту
+func (d *responseDispatcher) wait(component, requestID string, timeout time.Duration) (string, error) {
+	key := responseKey(component, requestID)
+
+	d.mu.Lock()
+	if pending, ok := d.pending[key]; ok {
+		delete(d.pending, key)
+		d.mu.Unlock()
+		return pending.response, nil
+	}
+	if _, ok := d.waiters[key]; ok {
+		d.mu.Unlock()
+		return "", fmt.Errorf("%s response for request %s is already awaited", component, requestID)
+	}
+	waiter := make(chan string, 1)
+	d.waiters[key] = waiter
+	d.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case response := <-waiter:
+		return response, nil
+	case <-timer.C:
+	}
+
+	// Response may be dispatched between timeout and removal of waiter
+	// Ответ может быть доставлен между таймаутом и удалением ожидающего
+	d.mu.Lock()
+	delete(d.waiters, key)
+	d.mu.Unlock()
+
+	select {
+	case response := <-waiter:
+		return response, nil
+	default:
+		return "", fmt.Errorf("timeout waiting for %s response after %dms", component, timeout.Milliseconds())
+	}
+}
+
+// pruneLocked drops responses nobody waited for, such as responses to fire-and-forget messages
+// Удаляет ответы, которые никто не ожидал, например ответы на сообщения без ожидания
+func (d *responseDispatcher) pruneLocked(now time.Time) {
+	if now.Sub(d.lastPruned) < pendingResponseTTL/4 {
+		return
+	}
+	d.lastPruned = now
+
+	for key, pending := range d.pending {
+		if now.Sub(pending.receivedAt) > pendingResponseTTL {
+			delete(d.pending, key)
+		}
+	}
+}
+
+// responseKey identifies response of component to request
+// Идентифицирует ответ компонента на запрос
+func responseKey(component, requestID string) string {
+	return component + "/" + requestID
+}
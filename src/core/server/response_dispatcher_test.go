@@ -0,0 +1,251 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"atom-engine/src/core/config"
+	"atom-engine/src/core/models"
+	"atom-engine/src/parser"
+	"atom-engine/src/storage"
+)
+
+// newParserCore creates core with real storage and parser wired through response dispatcher
+// Создает core с реальным storage и парсером, подключенными через диспетчер ответов
+func newParserCore(t *testing.T) *Core {
+	t.Helper()
+
+	st := storage.NewStorage(&storage.Config{Path: t.TempDir()})
+	if err := st.Init(); err != nil {
+		t.Fatalf("init storage: %v", err)
+	}
+	if err := st.Start(); err != nil {
+		t.Fatalf("start storage: %v", err)
+	}
+
+	cfg := &config.Config{}
+	cfg.BPMN.Path = t.TempDir()
+	cfg.BPMN.MaxConcurrentParses = 4
+	cfg.BPMN.ParseQueueSize = 64
+	cfg.BPMN.ParseQueueTimeoutMs = 10000
+
+	parserComp := parser.NewComponent(cfg, st)
+	if err := parserComp.Init(); err != nil {
+		t.Fatalf("init parser: %v", err)
+	}
+
+	c := &Core{
+		storage:    st,
+		parserComp: parserComp,
+		responses:  newResponseDispatcher(),
+		latency:    newComponentLatency(),
+		breakers:   newComponentBreakers(5, time.Second),
+	}
+	if err := c.responses.register("parser", parserComp.GetResponseChannel(), nil); err != nil {
+		t.Fatalf("register parser responses: %v", err)
+	}
+	t.Cleanup(func() {
+		c.responses.stop()
+		st.Stop()
+	})
+	return c
+}
+
+// uploadBPMN sends BPMN content to parser through core and waits for its response
+// Отправляет содержимое BPMN парсеру через core и ожидает ответ
+func uploadBPMN(c *Core, processID string) (parser.ParserResponse, string, error) {
+	message, err := parser.CreateParseBPMNContentMessage(parser.ParseBPMNContentPayload{
+		BPMNContent: `<?xml version="1.0" encoding="UTF-8"?>
+<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL"
+  id="Definitions_` + processID + `" targetNamespace="http://bpmn.io/schema/bpmn">
+  <bpmn:process id="` + processID + `" isExecutable="true">
+    <bpmn:startEvent id="start"><bpmn:outgoing>f1</bpmn:outgoing></bpmn:startEvent>
+    <bpmn:sequenceFlow id="f1" sourceRef="start" targetRef="end" />
+    <bpmn:endEvent id="end"><bpmn:incoming>f1</bpmn:incoming></bpmn:endEvent>
+  </bpmn:process>
+</bpmn:definitions>`,
+	})
+	if err != nil {
+		return parser.ParserResponse{}, "", err
+	}
+
+	ctx := models.ContextWithRequestID(context.Background(), "upload-"+processID)
+	requestID, err := c.SendRequestWithContext(ctx, "parser", message)
+	if err != nil {
+		return parser.ParserResponse{}, "", err
+	}
+
+	responseJSON, err := c.WaitForParserResponse(requestID, 10000)
+	if err != nil {
+		return parser.ParserResponse{}, requestID, err
+	}
+
+	var response parser.ParserResponse
+	err = json.Unmarshal([]byte(responseJSON), &response)
+	return response, requestID, err
+}
+
+func TestConcurrentBPMNUploadsReceiveOwnResponses(t *testing.T) {
+	c := newParserCore(t)
+
+	const uploads = 20
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	errs := make(chan error, uploads)
+
+	for i := 0; i < uploads; i++ {
+		wg.Add(1)
+		go func(processID string) {
+			defer wg.Done()
+			<-start
+
+			response, requestID, err := uploadBPMN(c, processID)
+			if err != nil {
+				errs <- fmt.Errorf("%s: %w", processID, err)
+				return
+			}
+			if response.RequestID != requestID {
+				errs <- fmt.Errorf("%s: got response to request %s, want %s", processID, response.RequestID, requestID)
+				return
+			}
+			if !response.Success {
+				errs <- fmt.Errorf("%s: parse failed: %s", processID, response.Error)
+				return
+			}
+
+			var result parser.JSONParseResult
+			resultJSON, _ := json.Marshal(response.Result)
+			if err := json.Unmarshal(resultJSON, &result); err != nil {
+				errs <- fmt.Errorf("%s: parse result: %w", processID, err)
+				return
+			}
+			if result.ProcessID != processID {
+				errs <- fmt.Errorf("%s: got result of process %s", processID, result.ProcessID)
+			}
+		}(fmt.Sprintf("upload-process-%02d", i))
+	}
+
+	close(start)
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	var parseCount uint64
+	for _, stats := range c.GetComponentLatencyStats() {
+		if stats.Component == "parser" && stats.Operation == "parse" {
+			parseCount = stats.Count
+		}
+	}
+	if parseCount != uploads {
+		t.Errorf("parser parse latency observed %d times, want %d", parseCount, uploads)
+	}
+}
+
+func TestResponseDispatcherDeliversOutOfOrderResponses(t *testing.T) {
+	d := newResponseDispatcher()
+	defer d.stop()
+
+	source := make(chan string)
+	if err := d.register("parser", source, nil); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	const requests = 20
+	requestIDs := make([]string, requests)
+	for i := range requestIDs {
+		requestIDs[i] = d.nextRequestID("parser", "")
+	}
+
+	// First half responds before callers wait, second half after
+	// Первая половина отвечает до начала ожидания, вторая после
+	for i := requests/2 - 1; i >= 0; i-- {
+		source <- fmt.Sprintf(`{"request_id":%q,"result":%d}`, requestIDs[i], i)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, requests)
+	for i, requestID := range requestIDs {
+		wg.Add(1)
+		go func(i int, requestID string) {
+			defer wg.Done()
+
+			response, err := d.wait("parser", requestID, 5*time.Second)
+			if err != nil {
+				errs <- err
+				return
+			}
+			var message struct {
+				RequestID string `json:"request_id"`
+				Result    int    `json:"result"`
+			}
+			if err := json.Unmarshal([]byte(response), &message); err != nil {
+				errs <- err
+				return
+			}
+			if message.RequestID != requestID || message.Result != i {
+				errs <- fmt.Errorf("request %s got response %s", requestID, response)
+			}
+		}(i, requestID)
+	}
+
+	for i := requests - 1; i >= requests/2; i-- {
+		source <- fmt.Sprintf(`{"request_id":%q,"result":%d}`, requestIDs[i], i)
+	}
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
+
+func TestResponseDispatcherWaitTimeout(t *testing.T) {
+	d := newResponseDispatcher()
+	defer d.stop()
+
+	if _, err := d.wait("jobs", "missing#1", 20*time.Millisecond); err == nil {
+		t.Fatal("expected timeout error")
+	}
+}
+
+func TestComponentLatencyMatchesResponsesByRequestID(t *testing.T) {
+	latency := newComponentLatency()
+	sentAt := time.Now().Add(-time.Second)
+
+	latency.trackRequest("parser", "slow#1", `{"type":"parse_bpmn_content"}`, sentAt)
+	latency.trackRequest("parser", "fast#2", `{"type":"list_processes"}`, sentAt)
+
+	// Response to later request arrives first and must not take latency of earlier one
+	// Ответ на более поздний запрос приходит первым и не должен забирать задержку предыдущего
+	latency.observeResponse("parser", "fast#2", time.Now(), false)
+
+	counts := map[string]uint64{}
+	for _, stats := range latency.stats() {
+		counts[stats.Operation] = stats.Count
+	}
+	if counts["list"] != 1 || counts["parse"] != 0 {
+		t.Fatalf("latency recorded under wrong operation: %v", counts)
+	}
+
+	latency.observeResponse("parser", "slow#1", time.Now(), true)
+	latency.observeResponse("parser", "slow#1", time.Now(), false)
+	for _, stats := range latency.stats() {
+		if stats.Operation == "parse" {
+			t.Fatalf("timed out request must not be observed, got %+v", stats)
+		}
+	}
+}
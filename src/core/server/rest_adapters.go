@@ -11,7 +11,9 @@ package server
 import (
 	"fmt"
 
+	"atom-engine/src/core/models"
 	"atom-engine/src/core/restapi/handlers"
+	"atom-engine/src/core/types"
 	"atom-engine/src/jobs"
 	"atom-engine/src/parser"
 )
@@ -69,7 +71,7 @@ func (c *Core) GetTimersListForREST(statusFilter string, limit int32) (*handlers
 }
 
 // GetProcessInfoForREST returns complete process information adapted for REST API
-func (c *Core) GetProcessInfoForREST(instanceID string) (map[string]interface{}, error) {
+func (c *Core) GetProcessInfoForREST(instanceID string) (*types.ProcessInfo, error) {
 	// Get process status
 	processComp := c.GetProcessComponent()
 	if processComp == nil {
@@ -116,70 +118,64 @@ func (c *Core) GetProcessInfoForREST(instanceID string) (map[string]interface{},
 	}
 
 	// Build complete process info including external services
-	processInfo := map[string]interface{}{
-		"instance_id":       processStatus.InstanceID,
-		"process_key":       processKey,
-		"bpmn_process_key":  bpmnProcessKey,
-		"process_name":      processStatus.ProcessName,
-		"state":             processStatus.State,
-		"created_at":        processStatus.CreatedAt,
-		"updated_at":        processStatus.UpdatedAt,
-		"variables":         processStatus.Variables,
-		"external_services": c.buildExternalServicesForREST(instanceID, processStatus.ProcessKey),
+	processInfo := &types.ProcessInfo{
+		InstanceID:       processStatus.InstanceID,
+		ProcessKey:       processKey,
+		BPMNProcessKey:   bpmnProcessKey,
+		ProcessName:      processStatus.ProcessName,
+		State:            processStatus.State,
+		CreatedAt:        processStatus.CreatedAt,
+		UpdatedAt:        processStatus.UpdatedAt,
+		Variables:        models.NormalizeVariables(processStatus.Variables),
+		ExternalServices: c.buildExternalServicesForREST(instanceID),
 	}
 
 	return processInfo, nil
 }
 
 // buildExternalServicesForREST builds external services info for REST API
-func (c *Core) buildExternalServicesForREST(instanceID, processKey string) map[string]interface{} {
-	externalServices := map[string]interface{}{
-		"timers":                []map[string]interface{}{},
-		"jobs":                  []map[string]interface{}{},
-		"message_subscriptions": []map[string]interface{}{},
-		"buffered_messages":     []map[string]interface{}{},
-		"incidents":             []map[string]interface{}{},
+func (c *Core) buildExternalServicesForREST(instanceID string) types.ProcessExternalServices {
+	externalServices := types.ProcessExternalServices{
+		Timers:               []types.ProcessTimerInfo{},
+		Jobs:                 []types.ProcessJobInfo{},
+		MessageSubscriptions: []interface{}{},
+		BufferedMessages:     []interface{}{},
+		Incidents:            []interface{}{},
 	}
 
 	// Get timers using existing method
 	if timersResp, err := c.GetTimersList("", 1000); err == nil {
-		var timers []map[string]interface{}
 		for _, timer := range timersResp.Timers {
 			if timer.ProcessInstanceId == instanceID {
-				timerInfo := map[string]interface{}{
-					"timer_id":          timer.TimerId,
-					"element_id":        timer.ElementId,
-					"timer_type":        timer.TimerType,
-					"status":            timer.Status,
-					"scheduled_at":      timer.ScheduledAt,
-					"remaining_seconds": timer.RemainingSeconds,
-					"time_duration":     timer.TimeDuration,
-					"time_cycle":        timer.TimeCycle,
-				}
-				timers = append(timers, timerInfo)
+				externalServices.Timers = append(externalServices.Timers, types.ProcessTimerInfo{
+					TimerID:          timer.TimerId,
+					ElementID:        timer.ElementId,
+					TimerType:        timer.TimerType,
+					Status:           timer.Status,
+					ScheduledAt:      timer.ScheduledAt,
+					RemainingSeconds: timer.RemainingSeconds,
+					TimeDuration:     timer.TimeDuration,
+					TimeCycle:        timer.TimeCycle,
+				})
 			}
 		}
-		externalServices["timers"] = timers
 	}
 
 	// Get jobs using jobs component - cast to jobs.Component
 	if jobsComp, ok := c.GetJobsComponent().(*jobs.Component); jobsComp != nil && ok {
 		if jobInfos, _, err := jobsComp.ListJobs("", "", instanceID, "", 1000, 0); err == nil {
-			var jobsList []map[string]interface{}
 			for _, jobInfo := range jobInfos {
-				jobMap := map[string]interface{}{
-					"key":           jobInfo.Key,
-					"type":          jobInfo.Type,
-					"worker":        jobInfo.Worker,
-					"element_id":    "", // Not available in JobInfo
-					"status":        jobInfo.Status,
-					"retries":       jobInfo.Retries,
-					"created_at":    jobInfo.CreatedAt,
-					"error_message": jobInfo.ErrorMessage,
-				}
-				jobsList = append(jobsList, jobMap)
+				externalServices.Jobs = append(externalServices.Jobs, types.ProcessJobInfo{
+					Key:          jobInfo.Key,
+					Type:         jobInfo.Type,
+					Worker:       jobInfo.Worker,
+					ElementID:    jobInfo.ElementID,
+					Status:       jobInfo.Status,
+					Retries:      jobInfo.Retries,
+					CreatedAt:    jobInfo.CreatedAt,
+					ErrorMessage: jobInfo.ErrorMessage,
+				})
 			}
-			externalServices["jobs"] = jobsList
 		}
 	}
 
@@ -10,9 +10,13 @@ package server
 
 import (
 	"fmt"
+	"time"
 
 	"atom-engine/src/core/logger"
 	"atom-engine/src/core/restapi"
+	"atom-engine/src/core/restapi/handlers"
+	"atom-engine/src/core/restapi/middleware"
+	"atom-engine/src/core/restapi/utils"
 )
 
 // startRESTServer starts REST API server
@@ -21,8 +25,94 @@ func (c *Core) startRESTServer() error {
 	restConfig := &restapi.Config{
 		Host: c.config.RestAPI.Host,
 		Port: c.config.RestAPI.Port,
+		Mode: c.config.RestAPI.Mode,
+		Variables: &utils.VariableLimitsConfig{
+			MaxVariableSize:  c.config.Variables.MaxVariableSize,
+			MaxPayloadSize:   c.config.Variables.MaxPayloadSize,
+			OffloadEnabled:   c.config.Variables.OffloadEnabled,
+			OffloadThreshold: c.config.Variables.OffloadThreshold,
+
+			KeyPattern:        c.config.Variables.KeyPattern,
+			MaxKeys:           c.config.Variables.MaxKeys,
+			MaxDepth:          c.config.Variables.MaxDepth,
+			MaxSerializedSize: c.config.Variables.MaxSerializedSize,
+		},
+		Swagger: restapi.DefaultConfig().Swagger,
+		GRPCWeb: &handlers.GRPCWebConfig{
+			Enabled:  c.config.RestAPI.GRPCWeb.Enabled,
+			Services: c.config.RestAPI.GRPCWeb.Services,
+		},
+		GraphQL: &handlers.GraphQLConfig{
+			Enabled:       c.config.RestAPI.GraphQL.Enabled,
+			MaxDepth:      c.config.RestAPI.GraphQL.MaxDepth,
+			MaxComplexity: c.config.RestAPI.GraphQL.MaxComplexity,
+		},
+		Camunda: &handlers.CamundaCompatConfig{
+			Enabled: c.config.RestAPI.Camunda.Enabled,
+		},
+	}
+
+	// CORS headers are added only when enabled in configuration
+	// Заголовки CORS добавляются только при включении в конфигурации
+	if cors := c.config.RestAPI.CORS; cors.Enabled {
+		restConfig.CORS = &middleware.CORSConfig{
+			Enabled:          true,
+			AllowedOrigins:   cors.AllowedOrigins,
+			AllowedMethods:   cors.AllowedMethods,
+			AllowedHeaders:   cors.AllowedHeaders,
+			ExposedHeaders:   cors.ExposedHeaders,
+			AllowCredentials: cors.AllowCredentials,
+			MaxAge:           cors.MaxAge,
+		}
+		if len(restConfig.CORS.AllowedOrigins) == 0 {
+			restConfig.CORS.AllowedOrigins = middleware.DefaultCORSConfig().AllowedOrigins
+		}
+	}
+
+	// Compression is on unless disabled explicitly
+	// Сжатие включено, если не отключено явно
+	if compression := c.config.RestAPI.Compression; compression.Enabled == nil || *compression.Enabled {
+		restConfig.Compression = &middleware.CompressionConfig{
+			Enabled:       true,
+			MinSize:       compression.MinSize,
+			Level:         compression.Level,
+			ContentTypes:  compression.ContentTypes,
+			ExcludedPaths: compression.ExcludedPaths,
+		}
 	}
 
+	// Load shedding is on unless disabled explicitly
+	// Сброс нагрузки включен, если не отключен явно
+	if overload := c.config.RestAPI.Overload; overload.Enabled == nil || *overload.Enabled {
+		restConfig.Overload = &middleware.OverloadConfig{
+			Enabled:        true,
+			MaxInFlight:    overload.MaxInFlight,
+			RequestTimeout: time.Duration(overload.RequestTimeoutMs) * time.Millisecond,
+			ExcludedPaths:  overload.ExcludedPaths,
+		}
+	}
+
+	// Access log is on unless disabled explicitly, one line per request with status and latency
+	// Журнал доступа включен, если не отключен явно, одна строка на запрос со статусом и задержкой
+	if accessLog := c.config.RestAPI.AccessLog; accessLog.Enabled == nil || *accessLog.Enabled {
+		restConfig.Logging = middleware.DefaultLoggingConfig()
+		restConfig.Logging.LogRequests = false
+		if len(accessLog.SkipPaths) > 0 {
+			restConfig.Logging.SkipPaths = accessLog.SkipPaths
+		}
+		if accessLog.SlowRequestThresholdMs > 0 {
+			restConfig.Logging.SlowRequestThreshold = time.Duration(accessLog.SlowRequestThresholdMs) * time.Millisecond
+		}
+	}
+
+	// HTTP rate limiter is installed switched off, admin API enables it at runtime during incidents
+	// Per-key limits are already enforced by auth component, so auth limiter is not used second time
+	// HTTP ограничитель устанавливается выключенным, admin API включает его во время инцидентов
+	// Лимиты по ключам уже применяются компонентом auth, поэтому его ограничитель повторно не используется
+	restConfig.RateLimit = middleware.DefaultRateLimitConfig()
+	restConfig.RateLimit.Enabled = false
+	restConfig.RateLimit.UseAuthRateLimiter = false
+
 	if restConfig.Port == 0 {
 		restConfig.Port = 27555 // Default port
 	}
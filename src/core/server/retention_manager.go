@@ -0,0 +1,93 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"atom-engine/src/core/archive"
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/objectstore"
+)
+
+// initRetentionSweeper creates retention sweeper from archive configuration
+// Создает очистку по сроку хранения из конфигурации архивов
+func (c *Core) initRetentionSweeper() error {
+	retention := c.config.Archive.Retention
+
+	exporter, err := c.newArchiveExporter()
+	if err != nil {
+		return err
+	}
+
+	var store objectstore.Store
+	if retention.Mode == archive.RetentionModeArchive {
+		if c.objectStore == nil {
+			return fmt.Errorf("object storage not available")
+		}
+		store = c.objectStore
+	}
+
+	c.retentionSweeper = archive.NewRetentionSweeper(c.storage, exporter, store, archive.RetentionOptions{
+		MaxAge:    time.Duration(retention.CompletedInstanceDays) * 24 * time.Hour,
+		Mode:      retention.Mode,
+		BatchSize: retention.BatchSize,
+	})
+	return nil
+}
+
+// runInstanceRetention periodically removes or archives expired finished instances
+// Периодически удаляет или архивирует устаревшие завершенные экземпляры
+func (c *Core) runInstanceRetention(stop <-chan struct{}) {
+	if c.retentionSweeper == nil || !c.retentionSweeper.Status().Enabled {
+		logger.Info("Process instance retention disabled")
+		return
+	}
+
+	interval := time.Duration(c.config.Archive.Retention.IntervalMinutes) * time.Minute
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	logger.Info("Process instance retention started",
+		logger.String("mode", c.config.Archive.Retention.Mode),
+		logger.Int("completed_instance_days", c.config.Archive.Retention.CompletedInstanceDays))
+
+	for {
+		if c.storage != nil && c.storage.IsReady() {
+			stats := c.retentionSweeper.Sweep(context.Background())
+			if stats.Expired > 0 {
+				logger.Info("Process instance retention sweep completed",
+					logger.Int("expired", stats.Expired),
+					logger.Int("archived", stats.Archived),
+					logger.Int("deleted", stats.Deleted),
+					logger.Int("failed", stats.Failed))
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// GetRetentionStatus returns process instance retention sweeper status
+// Возвращает статус очистки экземпляров процессов по сроку хранения
+func (c *Core) GetRetentionStatus() *archive.RetentionStatus {
+	if c.retentionSweeper == nil {
+		return &archive.RetentionStatus{
+			Mode:     c.config.Archive.Retention.Mode,
+			Failures: make([]archive.RetentionFailure, 0),
+		}
+	}
+	return c.retentionSweeper.Status()
+}
@@ -0,0 +1,70 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"fmt"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+)
+
+// SimulationEnabled reports whether timewheel runs on simulated clock
+// Сообщает, работает ли timewheel на симулированных часах
+func (c *Core) SimulationEnabled() bool {
+	return c.timewheelComp != nil && c.timewheelComp.IsSimulated()
+}
+
+// GetSimulationTime returns current time of simulated clock
+// Возвращает текущее время симулированных часов
+func (c *Core) GetSimulationTime() (time.Time, error) {
+	if !c.SimulationEnabled() {
+		return time.Time{}, fmt.Errorf("simulation mode is not enabled")
+	}
+	return c.timewheelComp.Now(), nil
+}
+
+// AdvanceSimulationClock moves simulated clock forward and fires timers due within advanced period
+// Timer callbacks are processed before call returns, so process state reflects advanced clock
+// Продвигает симулированные часы и запускает таймеры, срок которых наступил в этом периоде
+// Колбеки таймеров обрабатываются до возврата, поэтому состояние процессов отражает продвинутые часы
+func (c *Core) AdvanceSimulationClock(duration time.Duration) (*models.SimulationAdvance, error) {
+	if !c.SimulationEnabled() {
+		return nil, fmt.Errorf("simulation mode is not enabled")
+	}
+
+	from := c.timewheelComp.Now()
+	timers, err := c.timewheelComp.AdvanceClock(duration, c.handleTimewheelResponse)
+	if err != nil {
+		return nil, fmt.Errorf("failed to advance simulated clock: %w", err)
+	}
+
+	result := &models.SimulationAdvance{
+		From:        from,
+		To:          c.timewheelComp.Now(),
+		FiredTimers: make([]models.SimulatedTimer, 0, len(timers)),
+	}
+	for _, timer := range timers {
+		result.FiredTimers = append(result.FiredTimers, models.SimulatedTimer{
+			ID:                timer.ID,
+			ElementID:         timer.ElementID,
+			ProcessInstanceID: timer.ProcessInstanceID,
+			TimerType:         timer.Type,
+			DueDate:           timer.DueDate,
+		})
+	}
+
+	logger.Info("Simulated clock advanced",
+		logger.String("from", result.From.Format(time.RFC3339)),
+		logger.String("to", result.To.Format(time.RFC3339)),
+		logger.Int("fired_timers", len(result.FiredTimers)))
+
+	return result, nil
+}
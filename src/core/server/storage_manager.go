@@ -11,8 +11,10 @@ package server
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"atom-engine/src/core/grpc"
+	"atom-engine/src/core/logger"
 )
 
 // GetStorageStatus returns storage status for gRPC
@@ -72,3 +74,40 @@ func (c *Core) GetStorageInfo() (*grpc.StorageInfoResponse, error) {
 		Statistics:     statistics,
 	}, nil
 }
+
+// systemEventsRetentionInterval defines how often expired system events are removed
+// Определяет, как часто удаляются устаревшие системные события
+const systemEventsRetentionInterval = time.Hour
+
+// runSystemEventsRetention periodically removes system events older than retention period until stop is closed
+// Периодически удаляет системные события старше периода хранения, пока stop не закрыт
+func (c *Core) runSystemEventsRetention(stop <-chan struct{}) {
+	retentionDays := c.config.Storage.SystemEventsRetentionDays
+	if retentionDays <= 0 {
+		logger.Info("System events retention disabled")
+		return
+	}
+	retention := time.Duration(retentionDays) * 24 * time.Hour
+
+	ticker := time.NewTicker(systemEventsRetentionInterval)
+	defer ticker.Stop()
+
+	for {
+		if c.storage != nil && c.storage.IsReady() {
+			deleted, err := c.storage.DeleteSystemEventsBefore(time.Now().Add(-retention))
+			if err != nil {
+				logger.Warn("Failed to clean up system events", logger.String("error", err.Error()))
+			} else if deleted > 0 {
+				logger.Info("Expired system events removed",
+					logger.Int("deleted", deleted),
+					logger.Int("retention_days", retentionDays))
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,54 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"atom-engine/src/core/config"
+)
+
+func TestSystemEventsRetentionStopsOnShutdown(t *testing.T) {
+	c := newStorageCore(t)
+	c.config = &config.Config{}
+	c.config.Storage.SystemEventsRetentionDays = 1
+	c.backgroundStop = make(chan struct{})
+
+	returned := make(chan struct{})
+	c.startBackground(func(stop <-chan struct{}) {
+		defer close(returned)
+		c.runSystemEventsRetention(stop)
+	})
+
+	// Loop is parked on hourly ticker, only stop signal can end it
+	// Цикл ожидает часового тикера, завершить его может только сигнал остановки
+	select {
+	case <-returned:
+		t.Fatal("retention loop returned before shutdown")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		c.stopBackground()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("shutdown did not stop retention loop")
+	}
+	select {
+	case <-returned:
+	default:
+		t.Fatal("stopBackground returned before retention loop")
+	}
+}
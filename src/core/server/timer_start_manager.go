@@ -0,0 +1,54 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import (
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+)
+
+// startTimerStartScheduling schedules timer start events of deployed processes and follows definition changes
+// Must run after timers are restored so that restored start timers are not scheduled twice
+// Планирует стартовые события таймера развернутых процессов и отслеживает изменения определений
+// Должен выполняться после восстановления таймеров, чтобы восстановленные стартовые таймеры не планировались повторно
+func (c *Core) startTimerStartScheduling() {
+	if c.parserComp == nil || c.processComp == nil {
+		return
+	}
+
+	c.parserComp.SetEventPublisher(c.PublishEngineEvent)
+	c.AddEngineEventListener(c.handleProcessDefinitionEvent)
+
+	go func() {
+		if err := c.processComp.SyncAllTimerStartEvents(); err != nil {
+			logger.Error("Failed to schedule timer start events", logger.String("error", err.Error()))
+		}
+	}()
+}
+
+// handleProcessDefinitionEvent reschedules timer start events when definition is deployed, updated or deleted
+// Listener must not block event publisher, so sync runs in background
+// Перепланирует стартовые события таймера при развертывании, изменении или удалении определения
+// Слушатель не должен блокировать издателя событий, поэтому синхронизация выполняется в фоне
+func (c *Core) handleProcessDefinitionEvent(event models.EngineEvent) {
+	switch event.Type {
+	case models.EngineEventProcessDeployed, models.EngineEventProcessUpdated, models.EngineEventProcessDeleted:
+	default:
+		return
+	}
+
+	go func() {
+		if err := c.processComp.SyncTimerStartEvents(event.ProcessID); err != nil {
+			logger.Error("Failed to sync timer start events",
+				logger.String("process_id", event.ProcessID),
+				logger.String("event_type", event.Type),
+				logger.String("error", err.Error()))
+		}
+	}()
+}
@@ -216,11 +216,11 @@ func (c *Core) calculateTimerDueTime(timer *storage.TimerRecord) (time.Time, err
 		// Cycle-based timer - get first execution time
 		// Циклический таймер - получаем время первого выполнения
 		parser := timewheel.NewISO8601DurationParser()
-		_, interval, err := parser.ParseRepeatingInterval(*timer.TimeCycle)
+		cycle, err := parser.ParseTimeCycle(*timer.TimeCycle)
 		if err != nil {
 			return time.Time{}, err
 		}
-		return baseTime.Add(interval), nil
+		return cycle.Next(baseTime), nil
 	}
 
 	return time.Time{}, fmt.Errorf("no timer definition found")
@@ -253,6 +253,7 @@ func (c *Core) handleTimewheelResponse(response string) {
 		ElementID         string `json:"element_id"`
 		TokenID           string `json:"token_id"`
 		ProcessInstanceID string `json:"process_instance_id"`
+		TimerType         string `json:"timer_type"`
 		FiredAt           string `json:"fired_at"`
 	}
 
@@ -264,9 +265,20 @@ func (c *Core) handleTimewheelResponse(response string) {
 			logger.String("process_instance_id", timerResp.ProcessInstanceID),
 			logger.String("fired_at", timerResp.FiredAt))
 
-		// Forward timer callback to process component with token ID
-		// Передаем timer callback в process component с token ID
-		if c.processComp != nil {
+		// Job retry timers make deferred job activatable again, element ID holds job ID
+		// Таймеры повтора job'а снова делают отложенный job доступным, element ID содержит ID job'а
+		if timerResp.TimerType == string(models.TimerTypeJobRetry) {
+			if c.jobsComp != nil {
+				if err := c.jobsComp.HandleRetryTimer(timerResp.ElementID); err != nil {
+					logger.Error("Failed to reactivate deferred job",
+						logger.String("timer_id", timerResp.TimerID),
+						logger.String("job_id", timerResp.ElementID),
+						logger.String("error", err.Error()))
+				}
+			}
+		} else if c.processComp != nil {
+			// Forward timer callback to process component with token ID
+			// Передаем timer callback в process component с token ID
 			if err := c.processComp.HandleTimerCallback(timerResp.TimerID, timerResp.ElementID, timerResp.TokenID); err != nil {
 				logger.Error("Failed to handle timer callback in process component",
 					logger.String("timer_id", timerResp.TimerID),
@@ -288,7 +300,12 @@ func (c *Core) handleTimewheelResponse(response string) {
 
 	// Log timer response to storage
 	// Логируем ответ таймера в storage
-	err := c.storage.LogSystemEvent(models.EventTypeReady, models.StatusSuccess, fmt.Sprintf("Timer fired: %s", response))
+	err := c.storage.LogSystemEventWithFields(models.EventTypeTimerFired, models.StatusSuccess,
+		fmt.Sprintf("Timer fired: %s at element %s", timerResp.TimerID, timerResp.ElementID),
+		storage.SystemEventFields{
+			Component:  models.EventComponentTimewheel,
+			InstanceID: timerResp.ProcessInstanceID,
+		})
 	if err != nil {
 		logger.Warn("Failed to log timer response to storage", logger.String("error", err.Error()))
 	}
@@ -0,0 +1,17 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package server
+
+import "atom-engine/src/core/triggers"
+
+// GetWebhookTriggers returns inbound webhook trigger manager
+// Возвращает менеджер входящих webhook триггеров
+func (c *Core) GetWebhookTriggers() *triggers.Manager {
+	return c.webhookTriggers
+}
@@ -0,0 +1,132 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package system
+
+import (
+	"runtime"
+	"time"
+)
+
+// CPUInfo describes CPU load of engine process and Go runtime scheduler state
+// Описывает нагрузку CPU процесса движка и состояние планировщика Go runtime
+type CPUInfo struct {
+	Cores          int          `json:"cores"`
+	GOMAXPROCS     int          `json:"gomaxprocs"`
+	ProcessPercent float64      `json:"process_cpu_percent"` // Share of all cores used by process, 0-100; -1 if unknown
+	SampleMillis   int64        `json:"sample_interval_ms"`
+	LoadAverage    *LoadAverage `json:"load_average,omitempty"` // Absent where OS does not provide it
+	Goroutines     int          `json:"goroutines"`
+	GC             GCStats      `json:"gc"`
+}
+
+// LoadAverage holds system load averages
+// Средняя загрузка системы
+type LoadAverage struct {
+	Load1  float64 `json:"load1"`
+	Load5  float64 `json:"load5"`
+	Load15 float64 `json:"load15"`
+}
+
+// GCStats holds garbage collector pause statistics
+// Статистика пауз сборщика мусора
+type GCStats struct {
+	Cycles         uint32     `json:"cycles"`
+	PauseTotalNs   uint64     `json:"pause_total_ns"`
+	LastPauseNs    uint64     `json:"last_pause_ns"`
+	MaxRecentPause uint64     `json:"max_recent_pause_ns"` // Max over last 256 cycles
+	LastGCAt       *time.Time `json:"last_gc_at,omitempty"`
+}
+
+// GetCPUInfo samples process CPU time over interval and collects runtime statistics
+// Blocks for interval; zero interval skips sampling and reports ProcessPercent -1
+// Замеряет время CPU процесса за интервал и собирает статистику runtime
+func GetCPUInfo(interval time.Duration) *CPUInfo {
+	info := &CPUInfo{
+		Cores:          runtime.NumCPU(),
+		GOMAXPROCS:     runtime.GOMAXPROCS(0),
+		ProcessPercent: -1,
+		SampleMillis:   interval.Milliseconds(),
+	}
+
+	if interval > 0 {
+		if percent, err := sampleProcessCPU(interval, info.Cores); err == nil {
+			info.ProcessPercent = percent
+		}
+	}
+
+	if load, err := platformLoadAverage(); err == nil {
+		info.LoadAverage = load
+	}
+
+	info.Goroutines = runtime.NumGoroutine()
+	info.GC = readGCStats()
+
+	return info
+}
+
+// sampleProcessCPU measures process CPU time spent during interval as share of all cores
+// Измеряет долю времени CPU процесса за интервал от всех ядер
+func sampleProcessCPU(interval time.Duration, cores int) (float64, error) {
+	before, err := platformProcessCPUTime()
+	if err != nil {
+		return 0, err
+	}
+	startedAt := time.Now()
+
+	time.Sleep(interval)
+
+	after, err := platformProcessCPUTime()
+	if err != nil {
+		return 0, err
+	}
+	elapsed := time.Since(startedAt)
+
+	if elapsed <= 0 || cores < 1 {
+		return 0, nil
+	}
+	percent := float64(after-before) / float64(elapsed) / float64(cores) * 100
+	if percent < 0 {
+		percent = 0
+	} else if percent > 100 {
+		percent = 100
+	}
+	return percent, nil
+}
+
+// readGCStats collects GC pause statistics from runtime
+// Собирает статистику пауз GC из runtime
+func readGCStats() GCStats {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+
+	stats := GCStats{
+		Cycles:       m.NumGC,
+		PauseTotalNs: m.PauseTotalNs,
+	}
+	if m.NumGC == 0 {
+		return stats
+	}
+
+	// PauseNs is circular buffer, most recent pause is at (NumGC+255)%256
+	// PauseNs - кольцевой буфер, последняя пауза находится в (NumGC+255)%256
+	stats.LastPauseNs = m.PauseNs[(m.NumGC+255)%256]
+	recent := int(m.NumGC)
+	if recent > len(m.PauseNs) {
+		recent = len(m.PauseNs)
+	}
+	for i := 0; i < recent; i++ {
+		if m.PauseNs[i] > stats.MaxRecentPause {
+			stats.MaxRecentPause = m.PauseNs[i]
+		}
+	}
+	lastGC := time.Unix(0, int64(m.LastGC))
+	stats.LastGCAt = &lastGC
+
+	return stats
+}
@@ -0,0 +1,50 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package system
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// platformTotalMemory reads physical memory size from sysctl hw.memsize
+// Читает размер физической памяти из sysctl hw.memsize
+func platformTotalMemory() (int64, error) {
+	memSize, err := unix.SysctlUint64("hw.memsize")
+	if err != nil {
+		return 0, fmt.Errorf("sysctl hw.memsize failed: %w", err)
+	}
+	return int64(memSize), nil
+}
+
+// platformLoadAverage reads load averages from sysctl vm.loadavg
+// Читает среднюю загрузку из sysctl vm.loadavg
+func platformLoadAverage() (*LoadAverage, error) {
+	raw, err := unix.SysctlRaw("vm.loadavg")
+	if err != nil {
+		return nil, fmt.Errorf("sysctl vm.loadavg failed: %w", err)
+	}
+
+	// struct loadavg { fixpt_t ldavg[3]; long fscale; } with padding before fscale on 64-bit
+	// struct loadavg { fixpt_t ldavg[3]; long fscale; } с выравниванием перед fscale на 64-bit
+	if len(raw) < 24 {
+		return nil, fmt.Errorf("unexpected vm.loadavg size: %d", len(raw))
+	}
+	scale := float64(binary.LittleEndian.Uint64(raw[16:24]))
+	if scale == 0 {
+		return nil, fmt.Errorf("invalid vm.loadavg scale")
+	}
+	return &LoadAverage{
+		Load1:  float64(binary.LittleEndian.Uint32(raw[0:4])) / scale,
+		Load5:  float64(binary.LittleEndian.Uint32(raw[4:8])) / scale,
+		Load15: float64(binary.LittleEndian.Uint32(raw[8:12])) / scale,
+	}, nil
+}
@@ -0,0 +1,88 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package system
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// platformTotalMemory reads total memory from /proc/meminfo, falling back to sysinfo syscall
+// Читает общую память из /proc/meminfo, при неудаче использует syscall sysinfo
+func platformTotalMemory() (int64, error) {
+	if memTotal, err := procMemTotal(); err == nil {
+		return memTotal, nil
+	}
+
+	var info syscall.Sysinfo_t
+	if err := syscall.Sysinfo(&info); err != nil {
+		return 0, fmt.Errorf("sysinfo failed: %w", err)
+	}
+
+	unit := int64(info.Unit)
+	if unit == 0 {
+		unit = 1 // Kernels before 2.3.23 report bytes
+	}
+	return int64(info.Totalram) * unit, nil
+}
+
+// procMemTotal parses MemTotal line of /proc/meminfo
+// Разбирает строку MemTotal из /proc/meminfo
+func procMemTotal() (int64, error) {
+	file, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "MemTotal:") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			break
+		}
+		kbValue, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid MemTotal value: %w", err)
+		}
+		// Convert from KB to bytes
+		return kbValue * 1024, nil
+	}
+	return 0, fmt.Errorf("MemTotal not found in /proc/meminfo")
+}
+
+// platformLoadAverage reads load averages from /proc/loadavg
+// Читает среднюю загрузку из /proc/loadavg
+func platformLoadAverage() (*LoadAverage, error) {
+	data, err := os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return nil, err
+	}
+
+	fields := strings.Fields(string(data))
+	if len(fields) < 3 {
+		return nil, fmt.Errorf("unexpected /proc/loadavg format")
+	}
+
+	var values [3]float64
+	for i := range values {
+		if values[i], err = strconv.ParseFloat(fields[i], 64); err != nil {
+			return nil, fmt.Errorf("invalid load average value: %w", err)
+		}
+	}
+	return &LoadAverage{Load1: values[0], Load5: values[1], Load15: values[2]}, nil
+}
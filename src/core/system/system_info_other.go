@@ -0,0 +1,43 @@
+//go:build !linux && !darwin && !windows
+
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package system
+
+import "time"
+
+// platformTotalMemory has no implementation on this platform
+// Не реализовано для этой платформы
+func platformTotalMemory() (int64, error) {
+	return 0, ErrUnsupported
+}
+
+// GetDiskSpace has no implementation on this platform
+// Не реализовано для этой платформы
+func GetDiskSpace(path string) (total int64, free int64, err error) {
+	return 0, 0, ErrUnsupported
+}
+
+// systemRootPath returns path of filesystem holding the system
+// Возвращает путь файловой системы, на которой находится система
+func systemRootPath() string {
+	return "/"
+}
+
+// platformProcessCPUTime has no implementation on this platform
+// Не реализовано для этой платформы
+func platformProcessCPUTime() (time.Duration, error) {
+	return 0, ErrUnsupported
+}
+
+// platformLoadAverage has no implementation on this platform
+// Не реализовано для этой платформы
+func platformLoadAverage() (*LoadAverage, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,49 @@
+//go:build linux || darwin
+
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package system
+
+import (
+	"syscall"
+	"time"
+)
+
+// GetDiskSpace returns disk space information for given path
+// Возвращает информацию о дисковом пространстве для указанного пути
+func GetDiskSpace(path string) (total int64, free int64, err error) {
+	var stat syscall.Statfs_t
+
+	err = syscall.Statfs(path, &stat)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Calculate total and free space
+	total = int64(stat.Blocks) * int64(stat.Bsize)
+	free = int64(stat.Bavail) * int64(stat.Bsize)
+
+	return total, free, nil
+}
+
+// systemRootPath returns path of filesystem holding the system
+// Возвращает путь файловой системы, на которой находится система
+func systemRootPath() string {
+	return "/"
+}
+
+// platformProcessCPUTime returns user plus system CPU time consumed by process
+// Возвращает суммарное user и system время CPU, потребленное процессом
+func platformProcessCPUTime() (time.Duration, error) {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0, err
+	}
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano()), nil
+}
@@ -0,0 +1,97 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package system
+
+import (
+	"fmt"
+	"os"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var procGlobalMemoryStatusEx = windows.NewLazySystemDLL("kernel32.dll").NewProc("GlobalMemoryStatusEx")
+
+// memoryStatusEx mirrors MEMORYSTATUSEX structure
+// Повторяет структуру MEMORYSTATUSEX
+type memoryStatusEx struct {
+	Length               uint32
+	MemoryLoad           uint32
+	TotalPhys            uint64
+	AvailPhys            uint64
+	TotalPageFile        uint64
+	AvailPageFile        uint64
+	TotalVirtual         uint64
+	AvailVirtual         uint64
+	AvailExtendedVirtual uint64
+}
+
+// platformTotalMemory reads physical memory size via GlobalMemoryStatusEx
+// Читает размер физической памяти через GlobalMemoryStatusEx
+func platformTotalMemory() (int64, error) {
+	if err := procGlobalMemoryStatusEx.Find(); err != nil {
+		return 0, fmt.Errorf("GlobalMemoryStatusEx not available: %w", err)
+	}
+
+	status := memoryStatusEx{}
+	status.Length = uint32(unsafe.Sizeof(status))
+	ret, _, callErr := procGlobalMemoryStatusEx.Call(uintptr(unsafe.Pointer(&status)))
+	if ret == 0 {
+		return 0, fmt.Errorf("GlobalMemoryStatusEx failed: %w", callErr)
+	}
+	return int64(status.TotalPhys), nil
+}
+
+// GetDiskSpace returns disk space information for given path
+// Возвращает информацию о дисковом пространстве для указанного пути
+func GetDiskSpace(path string) (total int64, free int64, err error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var freeAvailable, totalBytes, totalFree uint64
+	if err := windows.GetDiskFreeSpaceEx(pathPtr, &freeAvailable, &totalBytes, &totalFree); err != nil {
+		return 0, 0, err
+	}
+
+	// Free space available to caller respects user quotas like Bavail on Unix
+	// Свободное место для вызывающего учитывает квоты пользователя, как Bavail в Unix
+	return int64(totalBytes), int64(freeAvailable), nil
+}
+
+// systemRootPath returns root of system drive
+// Возвращает корень системного диска
+func systemRootPath() string {
+	if drive := os.Getenv("SystemDrive"); drive != "" {
+		return drive + `\`
+	}
+	return `C:\`
+}
+
+// platformProcessCPUTime returns kernel plus user CPU time consumed by process
+// Возвращает суммарное kernel и user время CPU, потребленное процессом
+func platformProcessCPUTime() (time.Duration, error) {
+	var creation, exit, kernel, user windows.Filetime
+	if err := windows.GetProcessTimes(windows.CurrentProcess(), &creation, &exit, &kernel, &user); err != nil {
+		return 0, err
+	}
+	// Filetime counts 100-nanosecond intervals
+	// Filetime считает интервалы по 100 наносекунд
+	ticks := (int64(kernel.HighDateTime)<<32 | int64(kernel.LowDateTime)) +
+		(int64(user.HighDateTime)<<32 | int64(user.LowDateTime))
+	return time.Duration(ticks * 100), nil
+}
+
+// platformLoadAverage is not provided by Windows
+// Windows не предоставляет среднюю загрузку
+func platformLoadAverage() (*LoadAverage, error) {
+	return nil, ErrUnsupported
+}
@@ -0,0 +1,90 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceParentHeader is W3C header and gRPC metadata key carrying trace context
+// Заголовок W3C и ключ gRPC metadata с контекстом трассировки
+const TraceParentHeader = "traceparent"
+
+// Extract returns context with remote span context read from carrier
+// Возвращает контекст с удаленным span контекстом, прочитанным из carrier
+func Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return propagator.Extract(ctx, carrier)
+}
+
+// Inject writes span context of ctx to carrier
+// Записывает span контекст из ctx в carrier
+func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	propagator.Inject(ctx, carrier)
+}
+
+// TraceParentFromContext returns traceparent value of span in context or empty string
+// Возвращает значение traceparent span'а из контекста или пустую строку
+func TraceParentFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	return carrier.Get(TraceParentHeader)
+}
+
+// ContextWithTraceParent returns context with remote span context parsed from traceparent
+// Invalid or empty value leaves context unchanged
+// Возвращает контекст с удаленным span контекстом из traceparent
+// Некорректное или пустое значение оставляет контекст без изменений
+func ContextWithTraceParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+	return propagator.Extract(ctx, propagation.MapCarrier{TraceParentHeader: traceParent})
+}
+
+// ContextWithSpan returns ctx carrying span context of source
+// Used when work outlives request, cancellation of source must not apply
+// Возвращает ctx со span контекстом из source
+// Используется, когда работа переживает запрос и отмена source не должна применяться
+func ContextWithSpan(ctx, source context.Context) context.Context {
+	spanContext := trace.SpanContextFromContext(source)
+	if !spanContext.IsValid() {
+		return ctx
+	}
+	return trace.ContextWithSpanContext(ctx, spanContext)
+}
+
+// TraceIDFromContext returns trace ID of span in context or empty string
+// Возвращает ID трассировки span'а из контекста или пустую строку
+func TraceIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.HasTraceID() {
+		return ""
+	}
+	return spanContext.TraceID().String()
+}
+
+// EndSpan records error when present and ends span
+// Записывает ошибку, если она есть, и завершает span
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,144 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"atom-engine/src/core/config"
+	"atom-engine/src/core/logger"
+	"atom-engine/src/version"
+)
+
+// instrumentationName is name of tracer all engine spans are created with
+// Имя tracer, которым создаются все span'ы движка
+const instrumentationName = "atom-engine"
+
+// propagator reads and writes W3C traceparent, used even when export is disabled
+// Читает и записывает W3C traceparent, используется и при выключенном экспорте
+var propagator = propagation.TraceContext{}
+
+var (
+	providerMu sync.Mutex
+	provider   *sdktrace.TracerProvider
+)
+
+// Init installs OTLP exporting tracer provider when tracing is enabled
+// Disabled tracing keeps OpenTelemetry no-op provider, incoming trace context is still passed on
+// Устанавливает tracer provider с экспортом по OTLP, если трассировка включена
+// Выключенная трассировка оставляет no-op provider OpenTelemetry, входящий контекст трассировки все равно передается
+func Init(cfg config.TracingConfig, instanceName string) error {
+	otel.SetTextMapPropagator(propagator)
+	if !cfg.Enabled {
+		logger.Info("Tracing disabled")
+		return nil
+	}
+
+	exporter, err := newExporter(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	attributes := resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+		semconv.ServiceVersion(version.Version),
+		semconv.ServiceInstanceID(instanceName))
+	res, err := resource.Merge(resource.Default(), attributes)
+	if err != nil {
+		res = attributes
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))))
+	otel.SetTracerProvider(tracerProvider)
+
+	providerMu.Lock()
+	provider = tracerProvider
+	providerMu.Unlock()
+
+	logger.Info("Tracing enabled",
+		logger.String("protocol", cfg.Protocol),
+		logger.String("endpoint", cfg.Endpoint),
+		logger.String("service_name", cfg.ServiceName),
+		logger.Any("sample_ratio", cfg.SampleRatio))
+	return nil
+}
+
+// Shutdown flushes buffered spans and stops exporter
+// Отправляет буферизованные span'ы и останавливает экспортер
+func Shutdown(ctx context.Context) error {
+	providerMu.Lock()
+	tracerProvider := provider
+	provider = nil
+	providerMu.Unlock()
+
+	if tracerProvider == nil {
+		return nil
+	}
+	if err := tracerProvider.Shutdown(ctx); err != nil {
+		return fmt.Errorf("failed to shutdown tracer provider: %w", err)
+	}
+	return nil
+}
+
+// newExporter creates OTLP exporter, options not set in config fall back to OTEL_EXPORTER_OTLP_* variables
+// Создает OTLP экспортер, не заданные в конфигурации опции берутся из переменных OTEL_EXPORTER_OTLP_*
+func newExporter(cfg config.TracingConfig) (*otlptrace.Exporter, error) {
+	timeout := time.Duration(cfg.TimeoutMs) * time.Millisecond
+
+	if cfg.Protocol == "http" {
+		options := []otlptracehttp.Option{otlptracehttp.WithTimeout(timeout)}
+		if cfg.Endpoint != "" {
+			options = append(options, otlptracehttp.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.URLPath != "" {
+			options = append(options, otlptracehttp.WithURLPath(cfg.URLPath))
+		}
+		if cfg.Insecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		}
+		if len(cfg.Headers) > 0 {
+			options = append(options, otlptracehttp.WithHeaders(cfg.Headers))
+		}
+		return otlptracehttp.New(context.Background(), options...)
+	}
+
+	options := []otlptracegrpc.Option{otlptracegrpc.WithTimeout(timeout)}
+	if cfg.Endpoint != "" {
+		options = append(options, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+	}
+	if cfg.Insecure {
+		options = append(options, otlptracegrpc.WithInsecure())
+	}
+	if len(cfg.Headers) > 0 {
+		options = append(options, otlptracegrpc.WithHeaders(cfg.Headers))
+	}
+	return otlptracegrpc.New(context.Background(), options...)
+}
+
+// Tracer returns tracer of engine spans
+// Возвращает tracer span'ов движка
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
@@ -0,0 +1,281 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package triggers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+)
+
+// Headers carrying webhook credentials
+// Заголовки с учетными данными webhook'а
+const (
+	SignatureHeader = "X-Webhook-Signature" // "sha256=<hex>" HMAC of raw body
+	TokenHeader     = "X-Webhook-Token"
+)
+
+// Invocation is inbound webhook call
+// Входящий вызов webhook'а
+type Invocation struct {
+	Body    []byte
+	Headers http.Header
+	Query   url.Values
+}
+
+// InvocationResult describes started or accepted process instance
+// Описывает запущенный или принятый к запуску экземпляр процесса
+type InvocationResult struct {
+	TriggerID  string `json:"trigger_id"`
+	ProcessKey string `json:"process_key"`
+	InstanceID string `json:"instance_id,omitempty"` // Empty for async invocation
+	Async      bool   `json:"async"`
+}
+
+// Invoke authenticates webhook call and starts process instance of trigger
+// Async triggers return immediately, start failures are then only counted and logged
+// Аутентифицирует вызов webhook'а и запускает экземпляр процесса триггера
+// Асинхронные триггеры возвращают ответ сразу, ошибки запуска тогда только учитываются и логируются
+func (m *Manager) Invoke(triggerID string, invocation *Invocation) (*InvocationResult, error) {
+	m.mu.Lock()
+	stored, ok := m.triggers[triggerID]
+	m.mu.Unlock()
+	if !ok {
+		return nil, ErrTriggerNotFound
+	}
+	trigger := *stored
+
+	if err := m.admit(&trigger, invocation); err != nil {
+		m.recordStats(triggerID, func(t *models.WebhookTrigger) { t.Rejections++ })
+		logger.Warn("Webhook invocation rejected",
+			logger.String("trigger_id", triggerID),
+			logger.String("reason", err.Error()))
+		return nil, err
+	}
+
+	now := time.Now()
+	m.recordStats(triggerID, func(t *models.WebhookTrigger) {
+		t.Invocations++
+		t.LastInvoked = &now
+	})
+
+	variables, err := m.buildVariables(&trigger, invocation)
+	if err != nil {
+		m.recordStats(triggerID, func(t *models.WebhookTrigger) { t.Failures++ })
+		return nil, err
+	}
+
+	result := &InvocationResult{TriggerID: triggerID, ProcessKey: trigger.ProcessKey, Async: trigger.Async}
+	if trigger.Async {
+		go func() {
+			_, _ = m.startInstance(&trigger, variables)
+		}()
+		return result, nil
+	}
+
+	instance, err := m.startInstance(&trigger, variables)
+	if err != nil {
+		return nil, err
+	}
+	result.InstanceID = instance.InstanceID
+	return result, nil
+}
+
+// admit checks credentials, enabled state and rate limit of invocation
+// Проверяет учетные данные, включенность и лимит частоты вызова
+func (m *Manager) admit(trigger *models.WebhookTrigger, invocation *Invocation) error {
+	if !authenticate(trigger, invocation) {
+		return ErrUnauthorized
+	}
+	if !trigger.Enabled {
+		return ErrTriggerDisabled
+	}
+	if trigger.RateLimit <= 0 {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	window, ok := m.limiters[trigger.ID]
+	if !ok {
+		window = &rateWindow{}
+		m.limiters[trigger.ID] = window
+	}
+	if !window.allow(time.Now(), trigger.RateLimit) {
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// startInstance starts process instance and counts failure
+// Запускает экземпляр процесса и учитывает ошибку
+func (m *Manager) startInstance(
+	trigger *models.WebhookTrigger,
+	variables map[string]interface{},
+) (*models.ProcessInstance, error) {
+	instance, err := m.processes.StartProcessInstance(trigger.ProcessKey, variables)
+	if err != nil {
+		m.recordStats(trigger.ID, func(t *models.WebhookTrigger) { t.Failures++ })
+		logger.Error("Webhook trigger failed to start process instance",
+			logger.String("trigger_id", trigger.ID),
+			logger.String("process_key", trigger.ProcessKey),
+			logger.String("error", err.Error()))
+		return nil, fmt.Errorf("failed to start process instance: %w", err)
+	}
+
+	logger.Info("Process instance started by webhook trigger",
+		logger.String("trigger_id", trigger.ID),
+		logger.String("process_key", trigger.ProcessKey),
+		logger.String("instance_id", instance.InstanceID))
+	return instance, nil
+}
+
+// recordStats updates counters of trigger and writes them to storage
+// Обновляет счетчики триггера и записывает их в storage
+func (m *Manager) recordStats(triggerID string, update func(trigger *models.WebhookTrigger)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	trigger, ok := m.triggers[triggerID]
+	if !ok {
+		return // Deleted while invocation was in progress
+	}
+
+	updated := *trigger
+	update(&updated)
+	if err := m.store.SaveWebhookTrigger(&updated); err != nil {
+		logger.Warn("Failed to save webhook trigger counters",
+			logger.String("trigger_id", triggerID),
+			logger.String("error", err.Error()))
+	}
+	m.triggers[triggerID] = &updated
+}
+
+// authenticate verifies HMAC signature of body or shared token
+// Проверяет HMAC подпись тела или общий токен
+func authenticate(trigger *models.WebhookTrigger, invocation *Invocation) bool {
+	switch trigger.AuthType {
+	case models.WebhookAuthToken:
+		token := invocation.Headers.Get(TokenHeader)
+		if token == "" {
+			token = strings.TrimPrefix(invocation.Headers.Get("Authorization"), "Bearer ")
+		}
+		return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(trigger.Secret)) == 1
+	case models.WebhookAuthHMAC:
+		signature := strings.TrimPrefix(invocation.Headers.Get(SignatureHeader), "sha256=")
+		provided, err := hex.DecodeString(signature)
+		if err != nil || len(provided) == 0 {
+			return false
+		}
+		mac := hmac.New(sha256.New, []byte(trigger.Secret))
+		mac.Write(invocation.Body)
+		return hmac.Equal(provided, mac.Sum(nil))
+	default:
+		return false
+	}
+}
+
+// buildVariables maps invocation to start variables
+// Without mapping top-level fields of JSON object body become variables
+// Отображает вызов в стартовые переменные
+// Без маппинга переменными становятся поля верхнего уровня JSON объекта из тела
+func (m *Manager) buildVariables(
+	trigger *models.WebhookTrigger,
+	invocation *Invocation,
+) (map[string]interface{}, error) {
+	var body interface{}
+	if len(bytes.TrimSpace(invocation.Body)) > 0 {
+		if err := json.Unmarshal(invocation.Body, &body); err != nil {
+			return nil, fmt.Errorf("%w: body is not valid JSON: %v", ErrInvalidRequest, err)
+		}
+	}
+
+	if len(trigger.Variables) == 0 {
+		variables, _ := body.(map[string]interface{})
+		return variables, nil
+	}
+
+	scope := map[string]interface{}{
+		"body":    body,
+		"headers": flattenValues(invocation.Headers, true),
+		"query":   flattenValues(invocation.Query, false),
+	}
+
+	variables := make(map[string]interface{}, len(trigger.Variables))
+	for name, source := range trigger.Variables {
+		if !strings.HasPrefix(source, "=") {
+			variables[name] = source
+			continue
+		}
+		if m.evaluator == nil {
+			return nil, fmt.Errorf("expression component not available for variable %s", name)
+		}
+		value, err := m.evaluator.EvaluateExpressionEngine(source, scope)
+		if err != nil {
+			return nil, fmt.Errorf("%w: failed to evaluate variable %s: %v", ErrInvalidRequest, name, err)
+		}
+		variables[name] = value
+	}
+	return variables, nil
+}
+
+// flattenValues keeps first value of each key
+// Header names are lowercased with dashes replaced by underscores to be usable in expressions
+// Оставляет первое значение каждого ключа
+// Имена заголовков приводятся к нижнему регистру с заменой дефисов на подчеркивания для использования в выражениях
+func flattenValues(values map[string][]string, header bool) map[string]interface{} {
+	result := make(map[string]interface{}, len(values))
+	for key, list := range values {
+		if len(list) == 0 {
+			continue
+		}
+		if header {
+			key = strings.ReplaceAll(strings.ToLower(key), "-", "_")
+		}
+		result[key] = list[0]
+	}
+	return result
+}
+
+// rateWindow is sliding one-minute window of invocations
+// Скользящее минутное окно вызовов
+type rateWindow struct {
+	requests []time.Time
+}
+
+// allow records invocation if fewer than limit invocations happened during last minute
+// Учитывает вызов, если за последнюю минуту было меньше limit вызовов
+func (w *rateWindow) allow(now time.Time, limit int) bool {
+	cutoff := now.Add(-time.Minute)
+	valid := w.requests[:0]
+	for _, requestTime := range w.requests {
+		if requestTime.After(cutoff) {
+			valid = append(valid, requestTime)
+		}
+	}
+	w.requests = valid
+
+	if len(w.requests) >= limit {
+		return false
+	}
+	w.requests = append(w.requests, now)
+	return true
+}
@@ -0,0 +1,273 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package triggers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+)
+
+// Errors returned by trigger manager, mapped to HTTP statuses by REST handler
+// Ошибки менеджера триггеров, отображаемые REST обработчиком в HTTP статусы
+var (
+	ErrTriggerNotFound = errors.New("webhook trigger not found")
+	ErrTriggerDisabled = errors.New("webhook trigger is disabled")
+	ErrUnauthorized    = errors.New("webhook signature or token is invalid")
+	ErrRateLimited     = errors.New("webhook trigger rate limit exceeded")
+	ErrInvalidTrigger  = errors.New("invalid webhook trigger")
+	ErrInvalidRequest  = errors.New("invalid webhook request")
+)
+
+// secretBytes is length of generated trigger secret
+// Длина генерируемого секрета триггера
+const secretBytes = 32
+
+// Store persists webhook triggers
+// Хранит webhook триггеры
+type Store interface {
+	SaveWebhookTrigger(trigger *models.WebhookTrigger) error
+	DeleteWebhookTrigger(triggerID string) error
+	ListWebhookTriggers() ([]*models.WebhookTrigger, error)
+}
+
+// ProcessStarter starts process instances
+// Запускает экземпляры процессов
+type ProcessStarter interface {
+	StartProcessInstance(processKey string, variables map[string]interface{}) (*models.ProcessInstance, error)
+}
+
+// ExpressionEvaluator evaluates FEEL expressions of variable mapping
+// Вычисляет FEEL выражения маппинга переменных
+type ExpressionEvaluator interface {
+	EvaluateExpressionEngine(expression interface{}, variables map[string]interface{}) (interface{}, error)
+}
+
+// CreateRequest describes new webhook trigger
+// Описывает новый webhook триггер
+type CreateRequest struct {
+	Name       string
+	ProcessKey string
+	AuthType   string // hmac by default
+	Secret     string // generated when empty
+	Variables  map[string]string
+	RateLimit  int // Invocations per minute, zero is unlimited
+	Async      bool
+}
+
+// Manager keeps webhook triggers and starts processes on their invocation
+// Triggers are cached in memory, every change is written through to storage
+// Хранит webhook триггеры и запускает процессы при их вызове
+// Триггеры кэшируются в памяти, каждое изменение сразу записывается в storage
+type Manager struct {
+	store     Store
+	processes ProcessStarter
+	evaluator ExpressionEvaluator
+
+	mu       sync.Mutex
+	triggers map[string]*models.WebhookTrigger
+	limiters map[string]*rateWindow
+}
+
+// NewManager creates webhook trigger manager
+// Создает менеджер webhook триггеров
+func NewManager(store Store, processes ProcessStarter, evaluator ExpressionEvaluator) *Manager {
+	return &Manager{
+		store:     store,
+		processes: processes,
+		evaluator: evaluator,
+		triggers:  make(map[string]*models.WebhookTrigger),
+		limiters:  make(map[string]*rateWindow),
+	}
+}
+
+// Load reads triggers from storage, must be called after storage is started
+// Читает триггеры из storage, вызывается после запуска storage
+func (m *Manager) Load() error {
+	triggers, err := m.store.ListWebhookTriggers()
+	if err != nil {
+		return fmt.Errorf("failed to load webhook triggers: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, trigger := range triggers {
+		m.triggers[trigger.ID] = trigger
+	}
+
+	logger.Info("Webhook triggers loaded", logger.Int("count", len(triggers)))
+	return nil
+}
+
+// Create validates and stores new trigger, returned copy contains secret
+// Проверяет и сохраняет новый триггер, возвращаемая копия содержит секрет
+func (m *Manager) Create(req CreateRequest) (*models.WebhookTrigger, error) {
+	if strings.TrimSpace(req.ProcessKey) == "" {
+		return nil, fmt.Errorf("%w: process_key is required", ErrInvalidTrigger)
+	}
+	if req.AuthType == "" {
+		req.AuthType = models.WebhookAuthHMAC
+	}
+	if req.AuthType != models.WebhookAuthHMAC && req.AuthType != models.WebhookAuthToken {
+		return nil, fmt.Errorf("%w: auth_type must be %s or %s",
+			ErrInvalidTrigger, models.WebhookAuthHMAC, models.WebhookAuthToken)
+	}
+	if req.RateLimit < 0 {
+		return nil, fmt.Errorf("%w: rate_limit_per_minute must not be negative", ErrInvalidTrigger)
+	}
+	for name := range req.Variables {
+		if strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("%w: variable name must not be empty", ErrInvalidTrigger)
+		}
+	}
+
+	secret := req.Secret
+	if secret == "" {
+		generated, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	now := time.Now()
+	trigger := &models.WebhookTrigger{
+		ID:         models.GenerateID(),
+		Name:       req.Name,
+		ProcessKey: strings.TrimSpace(req.ProcessKey),
+		AuthType:   req.AuthType,
+		Secret:     secret,
+		Variables:  req.Variables,
+		RateLimit:  req.RateLimit,
+		Async:      req.Async,
+		Enabled:    true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.store.SaveWebhookTrigger(trigger); err != nil {
+		return nil, err
+	}
+	m.triggers[trigger.ID] = trigger
+
+	logger.Info("Webhook trigger created",
+		logger.String("trigger_id", trigger.ID),
+		logger.String("process_key", trigger.ProcessKey),
+		logger.String("auth_type", trigger.AuthType))
+
+	created := *trigger
+	return &created, nil
+}
+
+// List returns triggers ordered by creation time without secrets
+// Возвращает триггеры в порядке создания без секретов
+func (m *Manager) List() []*models.WebhookTrigger {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	result := make([]*models.WebhookTrigger, 0, len(m.triggers))
+	for _, trigger := range m.triggers {
+		result = append(result, redacted(trigger))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].CreatedAt.Equal(result[j].CreatedAt) {
+			return result[i].ID < result[j].ID
+		}
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+	return result
+}
+
+// Get returns trigger without secret
+// Возвращает триггер без секрета
+func (m *Manager) Get(triggerID string) (*models.WebhookTrigger, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	trigger, ok := m.triggers[triggerID]
+	if !ok {
+		return nil, ErrTriggerNotFound
+	}
+	return redacted(trigger), nil
+}
+
+// SetEnabled enables or disables trigger
+// Включает или выключает триггер
+func (m *Manager) SetEnabled(triggerID string, enabled bool) (*models.WebhookTrigger, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	trigger, ok := m.triggers[triggerID]
+	if !ok {
+		return nil, ErrTriggerNotFound
+	}
+
+	updated := *trigger
+	updated.Enabled = enabled
+	updated.UpdatedAt = time.Now()
+	if err := m.store.SaveWebhookTrigger(&updated); err != nil {
+		return nil, err
+	}
+	m.triggers[triggerID] = &updated
+
+	logger.Info("Webhook trigger toggled",
+		logger.String("trigger_id", triggerID),
+		logger.Bool("enabled", enabled))
+
+	return redacted(&updated), nil
+}
+
+// Delete removes trigger, its URL stops accepting calls
+// Удаляет триггер, его URL перестает принимать вызовы
+func (m *Manager) Delete(triggerID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.triggers[triggerID]; !ok {
+		return ErrTriggerNotFound
+	}
+	if err := m.store.DeleteWebhookTrigger(triggerID); err != nil {
+		return fmt.Errorf("failed to delete webhook trigger: %w", err)
+	}
+	delete(m.triggers, triggerID)
+	delete(m.limiters, triggerID)
+
+	logger.Info("Webhook trigger deleted", logger.String("trigger_id", triggerID))
+	return nil
+}
+
+// redacted returns copy of trigger without secret
+// Возвращает копию триггера без секрета
+func redacted(trigger *models.WebhookTrigger) *models.WebhookTrigger {
+	result := *trigger
+	result.Secret = ""
+	return &result
+}
+
+// generateSecret returns random hex secret
+// Возвращает случайный секрет в hex
+func generateSecret() (string, error) {
+	buf := make([]byte, secretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate trigger secret: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -215,6 +215,59 @@ type ComponentSummary struct {
 	HasErrors int32                     `json:"has_errors"`
 }
 
+// ComponentLatencyStats represents request/response latency histogram of component operation
+type ComponentLatencyStats struct {
+	Component   string    `json:"component"`
+	Operation   string    `json:"operation"`
+	UpperBounds []float64 `json:"upper_bounds"` // bucket upper bounds in seconds
+	Counts      []uint64  `json:"counts"`       // cumulative counts per upper bound
+	Count       uint64    `json:"count"`
+	SumSeconds  float64   `json:"sum_seconds"`
+}
+
+// HistoryExportStats represents counters of history exporter
+type HistoryExportStats struct {
+	Backlog       int64   `json:"backlog"`     // records waiting in storage
+	LagSeconds    float64 `json:"lag_seconds"` // age of oldest waiting record
+	Exported      uint64  `json:"exported"`
+	RequestErrors uint64  `json:"request_errors"`
+	Rejected      uint64  `json:"rejected"`
+	DroppedEvents uint64  `json:"dropped_events"`
+}
+
+// JobBacklogStats represents activation backlog of job type
+type JobBacklogStats struct {
+	JobType              string        `json:"job_type"`
+	OldestActivatableAge time.Duration `json:"oldest_activatable_age"` // age of oldest job waiting for worker
+}
+
+// AuditStats represents counters of auth audit logger
+type AuditStats struct {
+	RecordedSecurity int64 `json:"recorded_security"` // failed attempts and blocked IPs, never sampled
+	RecordedSuccess  int64 `json:"recorded_success"`
+	SampledOut       int64 `json:"sampled_out"`        // successful authentications dropped by sampling
+	ExcludedReadOnly int64 `json:"excluded_read_only"` // successful read-only requests skipped
+}
+
+// ProbeStatus represents engine state checked by readiness and startup probes
+type ProbeStatus struct {
+	Recovered  bool            `json:"recovered"`  // in-flight state of previous run is restored
+	Components map[string]bool `json:"components"` // readiness of storage and engine components
+}
+
+// Ready checks that state is recovered and every component is ready
+func (p *ProbeStatus) Ready() bool {
+	if !p.Recovered {
+		return false
+	}
+	for _, ready := range p.Components {
+		if !ready {
+			return false
+		}
+	}
+	return true
+}
+
 // Helper methods for ComponentInfo
 func (ci *ComponentInfo) IsStarting() bool {
 	return ci.Status == ComponentStatusStarting
@@ -1,7 +1,10 @@
 package types
 
 import (
+	"bytes"
 	"time"
+
+	"atom-engine/src/core/models"
 )
 
 // ProcessStatus represents the status of a process instance
@@ -58,6 +61,7 @@ type ProcessElementInfo struct {
 // ProcessInstanceDetails represents detailed information about a process instance
 type ProcessInstanceDetails struct {
 	InstanceID          string           `json:"instance_id"`
+	InstanceKey         int64            `json:"instance_key,omitempty"`
 	ProcessKey          string           `json:"process_key"`
 	ProcessDefinitionID string           `json:"process_definition_id"`
 	Version             int32            `json:"version"`
@@ -77,6 +81,54 @@ type ProcessInstanceDetails struct {
 	ErrorMessage        string           `json:"error_message,omitempty"`
 }
 
+// ProcessInfo represents complete process instance information with related external services.
+// Fields are serialized in declaration order so responses are stable between calls
+type ProcessInfo struct {
+	InstanceID       string                  `json:"instance_id"`
+	ProcessKey       string                  `json:"process_key"`
+	BPMNProcessKey   string                  `json:"bpmn_process_key"`
+	ProcessName      string                  `json:"process_name"`
+	State            string                  `json:"state"`
+	CreatedAt        string                  `json:"created_at"`
+	UpdatedAt        int64                   `json:"updated_at"`
+	Variables        ProcessVariables        `json:"variables"`
+	ExternalServices ProcessExternalServices `json:"external_services"`
+}
+
+// ProcessExternalServices represents timers, jobs and other services waiting on a process instance.
+// Lists are always present and empty when nothing is found
+type ProcessExternalServices struct {
+	Timers               []ProcessTimerInfo `json:"timers"`
+	Jobs                 []ProcessJobInfo   `json:"jobs"`
+	MessageSubscriptions []interface{}      `json:"message_subscriptions"`
+	BufferedMessages     []interface{}      `json:"buffered_messages"`
+	Incidents            []interface{}      `json:"incidents"`
+}
+
+// ProcessTimerInfo represents a timer scheduled for a process instance
+type ProcessTimerInfo struct {
+	TimerID          string `json:"timer_id"`
+	ElementID        string `json:"element_id"`
+	TimerType        string `json:"timer_type"`
+	Status           string `json:"status"`
+	ScheduledAt      int64  `json:"scheduled_at"`
+	RemainingSeconds int64  `json:"remaining_seconds"`
+	TimeDuration     string `json:"time_duration"`
+	TimeCycle        string `json:"time_cycle"`
+}
+
+// ProcessJobInfo represents a job created for a process instance
+type ProcessJobInfo struct {
+	Key          string `json:"key"`
+	Type         string `json:"type"`
+	Worker       string `json:"worker"`
+	ElementID    string `json:"element_id"`
+	Status       string `json:"status"`
+	Retries      int    `json:"retries"`
+	CreatedAt    int64  `json:"created_at"`
+	ErrorMessage string `json:"error_message"`
+}
+
 // ProcessDefinitionInfo represents information about a process definition
 type ProcessDefinitionInfo struct {
 	ProcessKey          string               `json:"process_key"`
@@ -109,6 +161,16 @@ type TokenInfo struct {
 	IsActive          bool             `json:"is_active"`
 }
 
+// TokenExecutionStats represents state of token execution worker pool
+type TokenExecutionStats struct {
+	Workers       int   `json:"workers"`
+	InFlight      int64 `json:"in_flight"`
+	QueueDepth    int   `json:"queue_depth"`
+	QueueCapacity int   `json:"queue_capacity"`
+	Executed      int64 `json:"executed"`
+	CallerRuns    int64 `json:"caller_runs"`
+}
+
 // ProcessStats represents statistics about processes in the system
 type ProcessStats struct {
 	TotalInstances        int64                   `json:"total_instances"`
@@ -138,18 +200,20 @@ type ProcessStartRequest struct {
 	TenantID          string           `json:"tenant_id,omitempty"`
 	BusinessKey       string           `json:"business_key,omitempty"`
 	StartInstructions []string         `json:"start_instructions,omitempty"`
+	Priority          int              `json:"priority,omitempty"`
 }
 
 // ProcessStartResponse represents the response from starting a process
 type ProcessStartResponse struct {
-	InstanceID string           `json:"instance_id"`
-	ProcessKey string           `json:"process_key"`
-	Version    int32            `json:"version"`
-	Status     ProcessStatus    `json:"status"`
-	Success    bool             `json:"success"`
-	Message    string           `json:"message"`
-	StartedAt  time.Time        `json:"started_at"`
-	Variables  ProcessVariables `json:"variables,omitempty"`
+	InstanceID  string           `json:"instance_id"`
+	InstanceKey int64            `json:"instance_key,omitempty"`
+	ProcessKey  string           `json:"process_key"`
+	Version     int32            `json:"version"`
+	Status      ProcessStatus    `json:"status"`
+	Success     bool             `json:"success"`
+	Message     string           `json:"message"`
+	StartedAt   time.Time        `json:"started_at"`
+	Variables   ProcessVariables `json:"variables,omitempty"`
 }
 
 // ProcessCancelRequest represents a request to cancel a process instance
@@ -242,6 +306,20 @@ type ProcessTraceResponse struct {
 	CompletedTokens   int32          `json:"completed_tokens"`
 }
 
+// UnmarshalJSON decodes variables keeping integers as int64 instead of float64
+func (pv *ProcessVariables) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*pv = nil
+		return nil
+	}
+	variables, err := models.DecodeVariables(data)
+	if err != nil {
+		return err
+	}
+	*pv = variables
+	return nil
+}
+
 // Helper methods for ProcessVariables
 func (pv ProcessVariables) GetString(key string) (string, bool) {
 	if val, exists := pv[key]; exists {
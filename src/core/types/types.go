@@ -16,6 +16,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"atom-engine/src/core/system"
 )
 
 // Common type aliases for convenience
@@ -150,6 +152,7 @@ type SystemInfo struct {
 	StartedAt     time.Time              `json:"started_at"`
 	Uptime        time.Duration          `json:"uptime"`
 	HostInfo      HostInfo               `json:"host_info"`
+	CPU           *system.CPUInfo        `json:"cpu,omitempty"`
 	Configuration map[string]interface{} `json:"configuration,omitempty"`
 }
 
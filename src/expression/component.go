@@ -12,6 +12,7 @@ import (
 	"context"
 	"fmt"
 
+	"atom-engine/src/core/config"
 	"atom-engine/src/core/logger"
 )
 
@@ -20,6 +21,7 @@ import (
 type Component struct {
 	evaluator        *ExpressionEvaluator
 	evaluationHelper *EvaluationHelper
+	evaluationLogger *EvaluationLogger
 	logger           logger.ComponentLogger
 	ready            bool
 	ctx              context.Context
@@ -38,6 +40,7 @@ type ComponentInterface interface {
 	// Основные методы оценки
 	EvaluateExpression(expression string, variables map[string]interface{}) (interface{}, error)
 	EvaluateCondition(variables map[string]interface{}, condition string) (bool, error)
+	EvaluateUnaryTest(input interface{}, test string, variables map[string]interface{}) (bool, error)
 	EvaluateExpressionEngine(expression interface{}, variables map[string]interface{}) (interface{}, error)
 	ParseRetries(retriesStr string) (int, error)
 
@@ -48,14 +51,22 @@ type ComponentInterface interface {
 
 // NewComponent creates new expression component
 // Создает новый компонент выражений
-func NewComponent() *Component {
+func NewComponent(cfg *config.Config) *Component {
 	ctx, cancel := context.WithCancel(context.Background())
 
+	componentLogger := logger.NewComponentLogger("expression")
+
+	var expressionConfig config.ExpressionConfig
+	if cfg != nil {
+		expressionConfig = cfg.Expression
+	}
+
 	return &Component{
-		logger: logger.NewComponentLogger("expression"),
-		ctx:    ctx,
-		cancel: cancel,
-		ready:  false,
+		evaluationLogger: NewEvaluationLogger(expressionConfig, componentLogger),
+		logger:           componentLogger,
+		ctx:              ctx,
+		cancel:           cancel,
+		ready:            false,
 	}
 }
 
@@ -124,7 +135,10 @@ func (c *Component) EvaluateExpression(expression string, variables map[string]i
 		return nil, fmt.Errorf("expression component not ready")
 	}
 
-	return c.evaluator.EvaluateExpression(expression, variables)
+	result, err := c.evaluator.EvaluateExpression(expression, variables)
+	c.evaluationLogger.LogEvaluation(expression, variables, result, err)
+
+	return result, err
 }
 
 // EvaluateCondition evaluates conditional expression
@@ -137,6 +151,25 @@ func (c *Component) EvaluateCondition(variables map[string]interface{}, conditio
 	return c.evaluator.EvaluateCondition(variables, condition)
 }
 
+// EvaluateUnaryTest evaluates FEEL unary test like "> 100", "[1..10]" or "\"a\",\"b\"" against input value
+// Unlike EvaluateCondition test has implicit left side, variables resolve names used in test
+// Вычисляет FEEL unary test вида "> 100", "[1..10]" или "\"a\",\"b\"" относительно входного значения
+// В отличие от EvaluateCondition левая часть теста неявная, variables разрешают имена в тесте
+func (c *Component) EvaluateUnaryTest(
+	input interface{},
+	test string,
+	variables map[string]interface{},
+) (bool, error) {
+	if !c.IsReady() {
+		return false, fmt.Errorf("expression component not ready")
+	}
+
+	result, err := c.evaluator.EvaluateUnaryTest(input, test, variables)
+	c.evaluationLogger.LogEvaluation(test, variables, result, err)
+
+	return result, err
+}
+
 // EvaluateExpressionEngine full expression engine
 // Полноценный движок выражений
 func (c *Component) EvaluateExpressionEngine(
@@ -118,7 +118,7 @@ func (cee *ConnectorExpressionEvaluator) evaluateFeelExpression(
 	if value, exists := variables[varName]; exists {
 		cee.logger.Debug("FEEL variable resolved",
 			logger.String("variable", varName),
-			logger.Any("value", value))
+			logger.VariableValue("value", varName, value))
 		return value
 	}
 
@@ -0,0 +1,96 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package expression
+
+import (
+	"sort"
+	"strings"
+
+	"atom-engine/src/core/config"
+	"atom-engine/src/core/logger"
+)
+
+// Evaluation log levels
+// Уровни логирования вычислений
+const (
+	EvaluationLogLevelDebug = "debug"
+	EvaluationLogLevelInfo  = "info"
+	EvaluationLogLevelOff   = "off"
+)
+
+// EvaluationLogger logs expression evaluations according to configuration
+// Логирует вычисления выражений согласно конфигурации
+type EvaluationLogger struct {
+	level          string
+	logFullContext bool
+	logger         logger.ComponentLogger
+}
+
+// NewEvaluationLogger creates evaluation logger
+// Создает логгер вычислений
+func NewEvaluationLogger(cfg config.ExpressionConfig, componentLogger logger.ComponentLogger) *EvaluationLogger {
+	level := strings.ToLower(cfg.EvaluationLogLevel)
+	if level == "" {
+		level = EvaluationLogLevelDebug
+	}
+
+	return &EvaluationLogger{
+		level:          level,
+		logFullContext: cfg.LogFullContext,
+		logger:         componentLogger,
+	}
+}
+
+// LogEvaluation logs single expression evaluation with its outcome
+// Логирует одно вычисление выражения и его результат
+func (el *EvaluationLogger) LogEvaluation(
+	expression string,
+	variables map[string]interface{},
+	result interface{},
+	err error,
+) {
+	if el == nil || el.level == EvaluationLogLevelOff {
+		return
+	}
+
+	fields := []logger.Field{
+		logger.String("expression", expression),
+	}
+
+	// Context values may contain sensitive data, log only names unless enabled
+	// Значения контекста могут содержать чувствительные данные, логируем только имена если не включено
+	if el.logFullContext {
+		fields = append(fields, logger.Variables("context", variables))
+	} else {
+		fields = append(fields, logger.Any("context_variables", variableNames(variables)))
+	}
+
+	if err != nil {
+		fields = append(fields, logger.String("error", err.Error()))
+	} else if el.logFullContext {
+		fields = append(fields, logger.Any("result", result))
+	}
+
+	if el.level == EvaluationLogLevelInfo {
+		el.logger.Info("Expression evaluated", fields...)
+		return
+	}
+	el.logger.Debug("Expression evaluated", fields...)
+}
+
+// variableNames returns sorted variable names of context
+// Возвращает отсортированные имена переменных контекста
+func variableNames(variables map[string]interface{}) []string {
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
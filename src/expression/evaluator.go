@@ -18,6 +18,7 @@ type ExpressionEvaluator struct {
 	logger             logger.ComponentLogger
 	variableEvaluator  *VariableEvaluator
 	conditionEvaluator *ConditionEvaluator
+	unaryTestEvaluator *UnaryTestEvaluator
 	retriesParser      *RetriesParser
 	engineEvaluator    *EngineEvaluator
 	connectorEvaluator *ConnectorExpressionEvaluator
@@ -33,11 +34,13 @@ func NewExpressionEvaluator() *ExpressionEvaluator {
 	// Создаем общий VariableEvaluator и FunctionEvaluator
 	variableEvaluator := NewVariableEvaluator(logger)
 	functionEvaluator := NewFunctionEvaluator(logger)
+	conditionEvaluator := NewConditionEvaluatorWithVariableEvaluator(logger, variableEvaluator)
 
 	return &ExpressionEvaluator{
 		logger:             logger,
 		variableEvaluator:  variableEvaluator,
-		conditionEvaluator: NewConditionEvaluatorWithVariableEvaluator(logger, variableEvaluator),
+		conditionEvaluator: conditionEvaluator,
+		unaryTestEvaluator: NewUnaryTestEvaluatorWithEvaluators(logger, variableEvaluator, conditionEvaluator),
 		retriesParser:      NewRetriesParserWithVariableEvaluator(logger, variableEvaluator),
 		engineEvaluator:    NewEngineEvaluatorWithEvaluators(logger, variableEvaluator, functionEvaluator),
 		connectorEvaluator: NewConnectorExpressionEvaluator(logger),
@@ -60,6 +63,16 @@ func (ee *ExpressionEvaluator) EvaluateCondition(variables map[string]interface{
 	return ee.conditionEvaluator.EvaluateCondition(variables, condition)
 }
 
+// EvaluateUnaryTest evaluates FEEL unary test against input value
+// Вычисляет FEEL unary test относительно входного значения
+func (ee *ExpressionEvaluator) EvaluateUnaryTest(
+	input interface{},
+	test string,
+	variables map[string]interface{},
+) (bool, error) {
+	return ee.unaryTestEvaluator.EvaluateUnaryTest(input, test, variables)
+}
+
 // EvaluateExpressionEngine full expression engine
 // Полноценный движок выражений
 func (ee *ExpressionEvaluator) EvaluateExpressionEngine(
@@ -93,6 +106,12 @@ func (ee *ExpressionEvaluator) GetConditionEvaluator() *ConditionEvaluator {
 	return ee.conditionEvaluator
 }
 
+// GetUnaryTestEvaluator returns unary test evaluator
+// Возвращает обработчик unary tests
+func (ee *ExpressionEvaluator) GetUnaryTestEvaluator() *UnaryTestEvaluator {
+	return ee.unaryTestEvaluator
+}
+
 // GetEngineEvaluator returns engine evaluator
 // Возвращает движок выражений
 func (ee *ExpressionEvaluator) GetEngineEvaluator() *EngineEvaluator {
@@ -122,7 +122,7 @@ func (pn *PathNavigator) NavigatePath(
 
 	pn.logger.Debug("Path navigation successful",
 		logger.String("path", path),
-		logger.Any("result", current),
+		logger.VariableValue("result", path, current),
 		logger.String("result_type", fmt.Sprintf("%T", current)))
 
 	return current, nil
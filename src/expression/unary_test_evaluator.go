@@ -0,0 +1,422 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package expression
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"atom-engine/src/core/logger"
+)
+
+// unaryTestInputVariable is name input value is bound to when test uses ? as expression
+// Имя, к которому привязывается входное значение, когда тест использует ? как выражение
+const unaryTestInputVariable = "__unary_test_input"
+
+// UnaryTestEvaluator evaluates FEEL unary tests against implicit input value
+// Supported tests: "-", comparisons (< 10, >= x, != "a"), ranges ([1..10], ]a..b[), values,
+// comma separated lists of tests, not(tests) and boolean expressions using ? as input
+// Вычисляет FEEL unary tests относительно неявного входного значения
+// Поддерживаемые тесты: "-", сравнения (< 10, >= x, != "a"), интервалы ([1..10], ]a..b[), значения,
+// списки тестов через запятую, not(tests) и логические выражения с ? в роли входного значения
+type UnaryTestEvaluator struct {
+	logger             logger.ComponentLogger
+	variableEvaluator  *VariableEvaluator
+	conditionEvaluator *ConditionEvaluator
+}
+
+// NewUnaryTestEvaluatorWithEvaluators creates new unary test evaluator with shared evaluators
+// Создает новый обработчик unary tests с общими обработчиками
+func NewUnaryTestEvaluatorWithEvaluators(
+	logger logger.ComponentLogger,
+	variableEvaluator *VariableEvaluator,
+	conditionEvaluator *ConditionEvaluator,
+) *UnaryTestEvaluator {
+	return &UnaryTestEvaluator{
+		logger:             logger,
+		variableEvaluator:  variableEvaluator,
+		conditionEvaluator: conditionEvaluator,
+	}
+}
+
+// EvaluateUnaryTest reports whether input satisfies unary test, variables resolve names used in test
+// Leading "=" is allowed and ignored, empty test and "-" match any input
+// Сообщает, удовлетворяет ли входное значение unary test, variables разрешают имена в тесте
+// Ведущий "=" допускается и игнорируется, пустой тест и "-" подходят любому значению
+func (ute *UnaryTestEvaluator) EvaluateUnaryTest(
+	input interface{},
+	test string,
+	variables map[string]interface{},
+) (bool, error) {
+	test = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(test), "="))
+	if variables == nil {
+		variables = make(map[string]interface{})
+	}
+
+	result, err := ute.evaluateTests(input, test, variables)
+	if err != nil {
+		ute.logger.Debug("Unary test evaluation failed",
+			logger.String("test", test),
+			logger.String("error", err.Error()))
+		return false, err
+	}
+
+	ute.logger.Debug("Unary test evaluated",
+		logger.String("test", test),
+		logger.Bool("result", result))
+	return result, nil
+}
+
+// evaluateTests evaluates negated or comma separated list of tests, list matches when any test matches
+// Вычисляет отрицание или список тестов через запятую, список подходит, если подходит любой тест
+func (ute *UnaryTestEvaluator) evaluateTests(
+	input interface{},
+	test string,
+	variables map[string]interface{},
+) (bool, error) {
+	if test == "" || test == "-" {
+		return true, nil
+	}
+
+	if inner, ok := negatedTests(test); ok {
+		result, err := ute.evaluateTests(input, strings.TrimSpace(inner), variables)
+		return !result, err
+	}
+
+	tests, err := splitUnaryTests(test)
+	if err != nil {
+		return false, err
+	}
+	for _, single := range tests {
+		matched, err := ute.evaluateSingleTest(input, single, variables)
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// evaluateSingleTest evaluates one comparison, range, expression or value test
+// Вычисляет один тест: сравнение, интервал, выражение или значение
+func (ute *UnaryTestEvaluator) evaluateSingleTest(
+	input interface{},
+	test string,
+	variables map[string]interface{},
+) (bool, error) {
+	if test == "" {
+		return false, fmt.Errorf("empty test in unary tests list")
+	}
+
+	for _, operator := range []string{"<=", ">=", "!=", "<", ">", "="} {
+		if strings.HasPrefix(test, operator) {
+			endpoint, err := ute.evaluateValue(strings.TrimSpace(test[len(operator):]), variables)
+			if err != nil {
+				return false, err
+			}
+			return ute.compare(input, endpoint, operator)
+		}
+	}
+
+	if isRangeTest(test) {
+		return ute.evaluateRange(input, test, variables)
+	}
+
+	if containsInputReference(test) {
+		scope := make(map[string]interface{}, len(variables)+1)
+		for name, value := range variables {
+			scope[name] = value
+		}
+		scope[unaryTestInputVariable] = input
+		return ute.conditionEvaluator.EvaluateFeelExpression(replaceInputReference(test), scope)
+	}
+
+	value, err := ute.evaluateValue(test, variables)
+	if err != nil {
+		return false, err
+	}
+	if list, ok := value.([]interface{}); ok {
+		for _, item := range list {
+			if ute.equal(input, item) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+	return ute.equal(input, value), nil
+}
+
+// evaluateRange checks input lies in interval, [ and ] include endpoint, ( ) and outward brackets exclude it
+// Проверяет, что значение лежит в интервале, [ и ] включают границу, ( ) и обратные скобки исключают
+func (ute *UnaryTestEvaluator) evaluateRange(
+	input interface{},
+	test string,
+	variables map[string]interface{},
+) (bool, error) {
+	body := test[1 : len(test)-1]
+	separator := indexOutsideQuotes(body, "..")
+	if separator < 0 {
+		return false, fmt.Errorf("invalid range %s", test)
+	}
+
+	low, err := ute.evaluateValue(strings.TrimSpace(body[:separator]), variables)
+	if err != nil {
+		return false, err
+	}
+	high, err := ute.evaluateValue(strings.TrimSpace(body[separator+2:]), variables)
+	if err != nil {
+		return false, err
+	}
+
+	lowOperator := ">"
+	if test[0] == '[' {
+		lowOperator = ">="
+	}
+	highOperator := "<"
+	if test[len(test)-1] == ']' {
+		highOperator = "<="
+	}
+
+	aboveLow, err := ute.compare(input, low, lowOperator)
+	if err != nil || !aboveLow {
+		return false, err
+	}
+	return ute.compare(input, high, highOperator)
+}
+
+// evaluateValue evaluates literal or variable path used as endpoint or value of test
+// Вычисляет литерал или путь переменной, используемые как граница или значение теста
+func (ute *UnaryTestEvaluator) evaluateValue(expr string, variables map[string]interface{}) (interface{}, error) {
+	switch {
+	case expr == "":
+		return nil, fmt.Errorf("missing value in unary test")
+	case expr == "null":
+		return nil, nil
+	case expr == "true":
+		return true, nil
+	case expr == "false":
+		return false, nil
+	case len(expr) >= 2 && expr[0] == '"' && expr[len(expr)-1] == '"':
+		value, err := strconv.Unquote(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string literal %s: %w", expr, err)
+		}
+		return value, nil
+	}
+
+	if number, err := strconv.ParseFloat(expr, 64); err == nil {
+		return number, nil
+	}
+
+	if ute.variableEvaluator.isVarStartChar(expr[0]) && ute.variableEvaluator.scanVariablePath(expr, 0) == expr {
+		if value, found := ute.variableEvaluator.resolveVariablePath(expr, variables); found {
+			return value, nil
+		}
+		return nil, fmt.Errorf("variable %s not found", expr)
+	}
+	return nil, fmt.Errorf("unsupported value in unary test: %s", expr)
+}
+
+// compare compares input with endpoint, null input matches only equality with null
+// Сравнивает входное значение с границей, null подходит только под равенство с null
+func (ute *UnaryTestEvaluator) compare(input, endpoint interface{}, operator string) (bool, error) {
+	switch operator {
+	case "=":
+		return ute.equal(input, endpoint), nil
+	case "!=":
+		return !ute.equal(input, endpoint), nil
+	}
+
+	if input == nil || endpoint == nil {
+		return false, nil
+	}
+
+	var order int
+	inputNumber, inputIsNumber := numberValue(input)
+	endpointNumber, endpointIsNumber := numberValue(endpoint)
+	inputString, inputIsString := input.(string)
+	endpointString, endpointIsString := endpoint.(string)
+	switch {
+	case inputIsNumber && endpointIsNumber:
+		switch {
+		case inputNumber < endpointNumber:
+			order = -1
+		case inputNumber > endpointNumber:
+			order = 1
+		}
+	case inputIsString && endpointIsString:
+		order = strings.Compare(inputString, endpointString)
+	default:
+		return false, fmt.Errorf("cannot compare %T with %T", input, endpoint)
+	}
+
+	switch operator {
+	case "<":
+		return order < 0, nil
+	case "<=":
+		return order <= 0, nil
+	case ">":
+		return order > 0, nil
+	default:
+		return order >= 0, nil
+	}
+}
+
+// equal compares numbers by value and other values as VariableEvaluator does
+// Сравнивает числа по значению, остальные значения как VariableEvaluator
+func (ute *UnaryTestEvaluator) equal(input, value interface{}) bool {
+	inputNumber, inputIsNumber := numberValue(input)
+	valueNumber, valueIsNumber := numberValue(value)
+	if inputIsNumber || valueIsNumber {
+		return inputIsNumber && valueIsNumber && inputNumber == valueNumber
+	}
+	return ute.variableEvaluator.compareEqual(input, value)
+}
+
+// numberValue returns numeric value as float64, strings are not numbers
+// Возвращает числовое значение как float64, строки не считаются числами
+func numberValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// negatedTests returns tests inside not(...) when whole test is negation
+// Возвращает тесты внутри not(...), когда весь тест является отрицанием
+func negatedTests(test string) (string, bool) {
+	if !strings.HasPrefix(test, "not(") || !strings.HasSuffix(test, ")") {
+		return "", false
+	}
+	if closing := matchingParenthesis(test, len("not")); closing != len(test)-1 {
+		return "", false
+	}
+	return test[len("not(") : len(test)-1], true
+}
+
+// matchingParenthesis returns index of parenthesis closing one at open, -1 when unbalanced
+// Возвращает индекс скобки, закрывающей скобку open, -1 при несбалансированных скобках
+func matchingParenthesis(expr string, open int) int {
+	depth := 0
+	inString := false
+	for i := open; i < len(expr); i++ {
+		switch {
+		case expr[i] == '"' && (i == 0 || expr[i-1] != '\\'):
+			inString = !inString
+		case inString:
+		case expr[i] == '(':
+			depth++
+		case expr[i] == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// splitUnaryTests splits tests by commas outside string literals, ranges and parentheses
+// Range brackets are not balanced in FEEL (]1..5[), so range is tracked until its closing bracket
+// Разбивает тесты по запятым вне строковых литералов, интервалов и скобок
+// Скобки интервалов в FEEL не сбалансированы (]1..5[), поэтому интервал отслеживается до закрывающей скобки
+func splitUnaryTests(test string) ([]string, error) {
+	var tests []string
+	depth := 0
+	inString := false
+	inRange := false
+	start := 0
+	for i := 0; i < len(test); i++ {
+		char := test[i]
+		switch {
+		case char == '"' && (i == 0 || test[i-1] != '\\'):
+			inString = !inString
+		case inString:
+		case inRange:
+			if char == ']' || char == ')' || char == '[' {
+				inRange = false
+			}
+		case strings.ContainsRune("[(]", rune(char)) && depth == 0 && strings.TrimSpace(test[start:i]) == "":
+			inRange = true
+		case char == '(':
+			depth++
+		case char == ')':
+			depth--
+		case char == ',' && depth == 0:
+			tests = append(tests, strings.TrimSpace(test[start:i]))
+			start = i + 1
+		}
+	}
+	if inString || depth != 0 {
+		return nil, fmt.Errorf("unbalanced unary tests: %s", test)
+	}
+	return append(tests, strings.TrimSpace(test[start:])), nil
+}
+
+// isRangeTest reports whether test is interval like [1..10], (a..b] or ]1..5[
+// Сообщает, является ли тест интервалом вида [1..10], (a..b] или ]1..5[
+func isRangeTest(test string) bool {
+	if len(test) < 5 || !strings.ContainsRune("[(]", rune(test[0])) {
+		return false
+	}
+	return strings.ContainsRune("])[", rune(test[len(test)-1])) && indexOutsideQuotes(test, "..") > 0
+}
+
+// indexOutsideQuotes returns index of first occurrence of substr outside string literals, -1 when absent
+// Возвращает индекс первого вхождения substr вне строковых литералов, -1 если его нет
+func indexOutsideQuotes(expr, substr string) int {
+	inString := false
+	for i := 0; i < len(expr); i++ {
+		if expr[i] == '"' && (i == 0 || expr[i-1] != '\\') {
+			inString = !inString
+			continue
+		}
+		if !inString && strings.HasPrefix(expr[i:], substr) {
+			return i
+		}
+	}
+	return -1
+}
+
+// containsInputReference reports whether test uses ? outside string literals
+// Сообщает, использует ли тест ? вне строковых литералов
+func containsInputReference(test string) bool {
+	return indexOutsideQuotes(test, "?") >= 0
+}
+
+// replaceInputReference replaces ? outside string literals with input variable name
+// Заменяет ? вне строковых литералов на имя переменной входного значения
+func replaceInputReference(test string) string {
+	var builder strings.Builder
+	inString := false
+	for i := 0; i < len(test); i++ {
+		if test[i] == '"' && (i == 0 || test[i-1] != '\\') {
+			inString = !inString
+		}
+		if !inString && test[i] == '?' {
+			builder.WriteString(unaryTestInputVariable)
+			continue
+		}
+		builder.WriteByte(test[i])
+	}
+	return builder.String()
+}
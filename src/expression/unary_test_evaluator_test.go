@@ -0,0 +1,158 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package expression
+
+import (
+	"testing"
+)
+
+// unaryTestCase is input checked by unary test and expected outcome
+// Входное значение, проверяемое unary test, и ожидаемый результат
+type unaryTestCase struct {
+	name  string
+	input interface{}
+	test  string
+	want  bool
+}
+
+// runUnaryTests evaluates cases with shared variables
+// Вычисляет случаи с общими переменными
+func runUnaryTests(t *testing.T, cases []unaryTestCase, variables map[string]interface{}) {
+	t.Helper()
+
+	evaluator := NewExpressionEvaluator()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := evaluator.EvaluateUnaryTest(tc.input, tc.test, variables)
+			if err != nil {
+				t.Fatalf("%v against %q: %v", tc.input, tc.test, err)
+			}
+			if got != tc.want {
+				t.Errorf("%v against %q = %v, want %v", tc.input, tc.test, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUnaryTestAnyInput(t *testing.T) {
+	runUnaryTests(t, []unaryTestCase{
+		{"dash matches number", 42, "-", true},
+		{"dash matches null", nil, "-", true},
+		{"dash matches string", "gold", " - ", true},
+		{"empty test", "gold", "", true},
+		{"leading equals sign", 42, "= -", true},
+	}, nil)
+}
+
+func TestUnaryTestRanges(t *testing.T) {
+	variables := map[string]interface{}{
+		"limits": map[string]interface{}{"low": 10, "high": 20},
+	}
+
+	runUnaryTests(t, []unaryTestCase{
+		{"closed range inside", 5, "[1..10]", true},
+		{"closed range low endpoint", 1, "[1..10]", true},
+		{"closed range high endpoint", 10, "[1..10]", true},
+		{"closed range outside", 11, "[1..10]", false},
+		{"open range low endpoint", 1, "(1..10)", false},
+		{"open range high endpoint", 10, "(1..10)", false},
+		{"open range inside", 1.5, "(1..10)", true},
+		{"outward brackets exclude low", 1, "]1..10]", false},
+		{"outward brackets include high", 10, "]1..10]", true},
+		{"outward brackets exclude high", 10, "[1..10[", false},
+		{"outward brackets include low", 1, "[1..10[", true},
+		{"negative endpoints", -3, "[-5..-1]", true},
+		{"float input", 9.99, "[0..10)", true},
+		{"string range", "m", `["a".."n"]`, true},
+		{"string range outside", "z", `["a".."n"]`, false},
+		{"variable endpoints", 15, "[limits.low..limits.high]", true},
+		{"variable endpoints outside", 25, "[limits.low..limits.high]", false},
+		{"null input", nil, "[1..10]", false},
+	}, variables)
+}
+
+func TestUnaryTestComparisons(t *testing.T) {
+	variables := map[string]interface{}{"threshold": 100, "tier": "gold"}
+
+	runUnaryTests(t, []unaryTestCase{
+		{"less than", 5, "< 10", true},
+		{"less than endpoint", 10, "< 10", false},
+		{"less or equal endpoint", 10, "<= 10", true},
+		{"greater than variable", 150, "> threshold", true},
+		{"greater or equal variable", 99, ">= threshold", false},
+		{"not equal", "silver", `!= "gold"`, true},
+		{"equal variable", "gold", "= tier", true},
+		{"string value", "gold", `"gold"`, true},
+		{"other string value", "silver", `"gold"`, false},
+		{"number value matches int input", 3, "3", true},
+		{"boolean value", true, "true", true},
+		{"null value", nil, "null", true},
+		{"null input does not compare", nil, "< 10", false},
+	}, variables)
+}
+
+func TestUnaryTestLists(t *testing.T) {
+	variables := map[string]interface{}{
+		"allowed": []interface{}{"DE", "FR"},
+		"vip":     "VIP",
+	}
+
+	runUnaryTests(t, []unaryTestCase{
+		{"first value", "a", `"a","b","c"`, true},
+		{"last value", "c", `"a", "b", "c"`, true},
+		{"no value", "d", `"a","b","c"`, false},
+		{"comma inside string", "x,y", `"x,y","z"`, true},
+		{"mixed tests", 50, "< 10, [40..60], > 100", true},
+		{"mixed tests no match", 20, "< 10, [40..60], > 100", false},
+		{"ranges with outward brackets", 3, "]0..5[, ]10..20[", true},
+		{"ranges with outward brackets gap", 7, "]0..5[, ]10..20[", false},
+		{"list variable", "FR", "allowed", true},
+		{"list variable no match", "US", "allowed", false},
+		{"variable value in list", "VIP", `"REGULAR", vip`, true},
+	}, variables)
+}
+
+func TestUnaryTestNegation(t *testing.T) {
+	runUnaryTests(t, []unaryTestCase{
+		{"negated value", "b", `not("a")`, true},
+		{"negated matching value", "a", `not("a")`, false},
+		{"negated list", "c", `not("a", "b")`, true},
+		{"negated list match", "b", `not("a", "b")`, false},
+		{"negated range", 15, "not([1..10])", true},
+		{"negated range match", 5, "not([1..10])", false},
+		{"negated comparison", 5, "not(> 10)", true},
+		{"negated mixed list", 50, "not(< 10, [40..60])", false},
+		{"negated string with parenthesis", "a)", `not("a)")`, false},
+	}, nil)
+}
+
+func TestUnaryTestInputReference(t *testing.T) {
+	runUnaryTests(t, []unaryTestCase{
+		{"input in expression", 15, "? > 10", true},
+		{"input in expression false", 5, "? > 10", false},
+		{"question mark in string is literal", "?", `"?"`, true},
+	}, nil)
+}
+
+func TestUnaryTestErrors(t *testing.T) {
+	evaluator := NewExpressionEvaluator()
+
+	for _, test := range []string{
+		"> missing",
+		"[1..missing]",
+		`"a",`,
+		`not("a"`,
+		"[1..10] extra(",
+		"> \"x\", < 10",
+	} {
+		if result, err := evaluator.EvaluateUnaryTest(5, test, nil); err == nil {
+			t.Errorf("test %q: expected error, got %v", test, result)
+		}
+	}
+}
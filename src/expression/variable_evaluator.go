@@ -47,7 +47,7 @@ func (ve *VariableEvaluator) EvaluateVariable(
 		if value, exists := variables[varName]; exists {
 			ve.logger.Debug("Variable found",
 				logger.String("variable", varName),
-				logger.Any("value", value))
+				logger.VariableValue("value", varName, value))
 			return value, nil
 		}
 		ve.logger.Warn("Variable not found",
@@ -62,7 +62,7 @@ func (ve *VariableEvaluator) EvaluateVariable(
 		if value, exists := variables[varName]; exists {
 			ve.logger.Debug("Camunda variable found",
 				logger.String("variable", varName),
-				logger.Any("value", value))
+				logger.VariableValue("value", varName, value))
 			return value, nil
 		}
 		ve.logger.Warn("Camunda variable not found",
@@ -84,11 +84,29 @@ func (ve *VariableEvaluator) EvaluateVariable(
 			if value, exists := variables[trimmedExpr]; exists {
 				ve.logger.Debug("FEEL simple variable found",
 					logger.String("variable", trimmedExpr),
-					logger.Any("value", value))
+					logger.VariableValue("value", trimmedExpr, value))
 				return value, nil
 			}
 		}
 		
+		// Whole variable path keeps type of its value, replacement below would turn it into string
+		// Целый путь переменной сохраняет тип значения, замена ниже превратила бы его в строку
+		if trimmedExpr != "" && ve.isVarStartChar(trimmedExpr[0]) && strings.Contains(trimmedExpr, ".") &&
+			ve.scanVariablePath(trimmedExpr, 0) == trimmedExpr {
+			if value, found := ve.resolveVariablePath(trimmedExpr, variables); found {
+				ve.logger.Debug("FEEL variable path found",
+					logger.String("path", trimmedExpr),
+					logger.VariableValue("value", trimmedExpr, value))
+				return value, nil
+			}
+		}
+
+		// String concatenation like "handler-" + region, operands are string literals and variable paths
+		// Конкатенация строк вида "handler-" + region, операнды - строковые литералы и пути переменных
+		if operands, ok := ve.splitStringConcatenation(trimmedExpr); ok {
+			return ve.evaluateStringConcatenation(trimmedExpr, operands, variables)
+		}
+
 		// First, replace all variables in the expression (works for paths, JSON, strings, etc.)
 		// Сначала заменяем все переменные в выражении (работает для путей, JSON, строк и т.д.)
 		replaced := ve.replaceVariablesInString(feelExpr, variables)
@@ -181,7 +199,7 @@ func (ve *VariableEvaluator) EvaluateVariable(
 			} else {
 				ve.logger.Debug("Path navigation successful",
 					logger.String("path", exprToCheck),
-					logger.Any("result", result),
+					logger.VariableValue("result", exprToCheck, result),
 					logger.String("result_type", fmt.Sprintf("%T", result)))
 				return result, nil
 			}
@@ -192,7 +210,7 @@ func (ve *VariableEvaluator) EvaluateVariable(
 		if value, exists := variables[exprToCheck]; exists {
 			ve.logger.Debug("FEEL variable found",
 				logger.String("variable", exprToCheck),
-				logger.Any("value", value))
+				logger.VariableValue("value", exprToCheck, value))
 			return value, nil
 		}
 		
@@ -216,7 +234,7 @@ func (ve *VariableEvaluator) EvaluateVariable(
 		if value, exists := variables[expression]; exists {
 			ve.logger.Debug("Simple variable found",
 				logger.String("variable", expression),
-				logger.Any("value", value))
+				logger.VariableValue("value", expression, value))
 			return value, nil
 		}
 		ve.logger.Debug("Simple variable not found, returning as literal",
@@ -303,7 +321,7 @@ func (ve *VariableEvaluator) replaceVariablesInString(
 					result += ve.formatValueForString(value)
 					ve.logger.Debug("Variable path replaced in string",
 						logger.String("path", path),
-						logger.Any("value", value),
+						logger.VariableValue("value", path, value),
 						logger.String("position", fmt.Sprintf("%d-%d", pathStart, pathEnd)))
 					i = pathEnd
 					continue
@@ -472,7 +490,7 @@ func (ve *VariableEvaluator) evaluateComparison(
 				return false, fmt.Errorf("comparison failed: %w", err)
 			}
 
-			ve.logger.Info("Comparison result",
+			ve.logger.Debug("Comparison result",
 				logger.String("expression", expr),
 				logger.String("operator", op),
 				logger.Any("left", leftValue),
@@ -753,6 +771,84 @@ func (ve *VariableEvaluator) formatValueForString(value interface{}) string {
 	}
 }
 
+// splitStringConcatenation splits expression on + outside string literals.
+// Expression is concatenation only if it has string literal operand and every operand is literal or variable path
+// Разбивает выражение по + вне строковых литералов
+// Выражение считается конкатенацией, только если есть строковый литерал и каждый операнд - литерал или путь переменной
+func (ve *VariableEvaluator) splitStringConcatenation(expr string) ([]string, bool) {
+	var operands []string
+	inString := false
+	start := 0
+	for i := 0; i < len(expr); i++ {
+		switch {
+		case expr[i] == '\\' && inString:
+			i++
+		case expr[i] == '"':
+			inString = !inString
+		case expr[i] == '+' && !inString:
+			operands = append(operands, strings.TrimSpace(expr[start:i]))
+			start = i + 1
+		}
+	}
+	if inString || len(operands) == 0 {
+		return nil, false
+	}
+	operands = append(operands, strings.TrimSpace(expr[start:]))
+
+	hasLiteral := false
+	for _, operand := range operands {
+		if ve.isStringLiteral(operand) {
+			hasLiteral = true
+			continue
+		}
+		if operand == "" || !ve.isVarStartChar(operand[0]) || ve.scanVariablePath(operand, 0) != operand {
+			return nil, false
+		}
+	}
+	return operands, hasLiteral
+}
+
+// evaluateStringConcatenation joins operands, every variable operand must exist and be string
+// Соединяет операнды, каждая переменная-операнд должна существовать и быть строкой
+func (ve *VariableEvaluator) evaluateStringConcatenation(
+	expr string,
+	operands []string,
+	variables map[string]interface{},
+) (interface{}, error) {
+	var result strings.Builder
+	for _, operand := range operands {
+		if ve.isStringLiteral(operand) {
+			literal, err := strconv.Unquote(operand)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string literal %s: %w", operand, err)
+			}
+			result.WriteString(literal)
+			continue
+		}
+
+		value, found := ve.resolveVariablePath(operand, variables)
+		if !found {
+			return nil, fmt.Errorf("variable %s not found in %s", operand, expr)
+		}
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("cannot concatenate %T variable %s in %s, string expected", value, operand, expr)
+		}
+		result.WriteString(str)
+	}
+
+	ve.logger.Debug("String concatenation evaluated",
+		logger.String("expression", expr),
+		logger.String("result", result.String()))
+	return result.String(), nil
+}
+
+// isStringLiteral checks if operand is double quoted string literal
+// Проверяет, является ли операнд строковым литералом в двойных кавычках
+func (ve *VariableEvaluator) isStringLiteral(operand string) bool {
+	return len(operand) >= 2 && operand[0] == '"' && operand[len(operand)-1] == '"'
+}
+
 // tokenType represents type of token in logical expression
 // Тип токена в логическом выражении
 type tokenType int
@@ -15,6 +15,7 @@ import (
 
 	"atom-engine/src/core/config"
 	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
 	"atom-engine/src/storage"
 )
 
@@ -66,6 +67,7 @@ type ComponentInterface interface {
 // Определяет методы core необходимые incidents компоненту
 type CoreInterface interface {
 	SendMessage(componentName, messageJSON string) error
+	PublishEngineEvent(event models.EngineEvent)
 }
 
 // Component represents the incidents component
@@ -104,6 +104,7 @@ func (c *Component) handleCreateIncident(ctx context.Context, request IncidentRe
 		MessageName:       payload.MessageName,
 		CorrelationKey:    payload.CorrelationKey,
 		OriginalRetries:   payload.OriginalRetries,
+		RootCause:         payload.RootCause,
 		Metadata:          payload.Metadata,
 	}
 
@@ -114,7 +115,7 @@ func (c *Component) handleCreateIncident(ctx context.Context, request IncidentRe
 		return
 	}
 
-	response := CreateIncidentSuccessResponse("create_incident_response", incident)
+	response := CreateIncidentSuccessResponse("create_incident_response", request.RequestID, incident)
 	c.sendResponse(response)
 }
 
@@ -148,7 +149,7 @@ func (c *Component) handleResolveIncident(ctx context.Context, request IncidentR
 		return
 	}
 
-	response := CreateIncidentSuccessResponse("resolve_incident_response", incident)
+	response := CreateIncidentSuccessResponse("resolve_incident_response", request.RequestID, incident)
 	c.sendResponse(response)
 }
 
@@ -173,7 +174,7 @@ func (c *Component) handleGetIncident(ctx context.Context, request IncidentReque
 		return
 	}
 
-	response := CreateIncidentSuccessResponse("get_incident_response", incident)
+	response := CreateIncidentSuccessResponse("get_incident_response", request.RequestID, incident)
 	c.sendResponse(response)
 }
 
@@ -217,7 +218,7 @@ func (c *Component) handleListIncidents(ctx context.Context, request IncidentReq
 		return
 	}
 
-	response := CreateIncidentListResponse(incidents, total)
+	response := CreateIncidentListResponse(request.RequestID, incidents, total)
 	c.sendResponse(response)
 }
 
@@ -231,7 +232,7 @@ func (c *Component) handleGetIncidentStats(ctx context.Context, request Incident
 		return
 	}
 
-	response := CreateIncidentStatsResponse(stats)
+	response := CreateIncidentStatsResponse(request.RequestID, stats)
 	c.sendResponse(response)
 }
 
@@ -48,6 +48,7 @@ type CreateIncidentPayload struct {
 	MessageName       string                 `json:"message_name,omitempty"`
 	CorrelationKey    string                 `json:"correlation_key,omitempty"`
 	OriginalRetries   int                    `json:"original_retries,omitempty"`
+	RootCause         *RootCause             `json:"root_cause,omitempty"`
 	Metadata          map[string]interface{} `json:"metadata,omitempty"`
 }
 
@@ -133,11 +134,12 @@ func CreateGetIncidentStatsMessage() (string, error) {
 
 // CreateIncidentSuccessResponse creates successful incident response
 // Создает успешный ответ об инциденте
-func CreateIncidentSuccessResponse(responseType string, incident *Incident) string {
+func CreateIncidentSuccessResponse(responseType, requestID string, incident *Incident) string {
 	response := IncidentResponse{
-		Type:    responseType,
-		Success: true,
-		Data:    structToMap(incident),
+		Type:      responseType,
+		Success:   true,
+		Data:      structToMap(incident),
+		RequestID: requestID,
 	}
 
 	if data, err := json.Marshal(response); err == nil {
@@ -148,7 +150,7 @@ func CreateIncidentSuccessResponse(responseType string, incident *Incident) stri
 
 // CreateIncidentListResponse creates incident list response
 // Создает ответ со списком инцидентов
-func CreateIncidentListResponse(incidents []*Incident, total int) string {
+func CreateIncidentListResponse(requestID string, incidents []*Incident, total int) string {
 	response := IncidentResponse{
 		Type:    "list_incidents_response",
 		Success: true,
@@ -156,6 +158,7 @@ func CreateIncidentListResponse(incidents []*Incident, total int) string {
 			"incidents": incidents,
 			"total":     total,
 		},
+		RequestID: requestID,
 	}
 
 	if data, err := json.Marshal(response); err == nil {
@@ -166,11 +169,12 @@ func CreateIncidentListResponse(incidents []*Incident, total int) string {
 
 // CreateIncidentStatsResponse creates incident stats response
 // Создает ответ со статистикой инцидентов
-func CreateIncidentStatsResponse(stats *IncidentStats) string {
+func CreateIncidentStatsResponse(requestID string, stats *IncidentStats) string {
 	response := IncidentResponse{
-		Type:    "get_incident_stats_response",
-		Success: true,
-		Data:    structToMap(stats),
+		Type:      "get_incident_stats_response",
+		Success:   true,
+		Data:      structToMap(stats),
+		RequestID: requestID,
 	}
 
 	if data, err := json.Marshal(response); err == nil {
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"atom-engine/src/core/logger"
+	"atom-engine/src/core/metrics"
 	"atom-engine/src/core/models"
 	"atom-engine/src/storage"
 )
@@ -156,6 +157,14 @@ func (im *IncidentManager) CreateIncident(ctx context.Context, request *CreateIn
 	incident.CorrelationKey = request.CorrelationKey
 	incident.OriginalRetries = request.OriginalRetries
 
+	// Root cause timeline ends with incident creation
+	if request.RootCause != nil {
+		rootCause := *request.RootCause
+		rootCause.Timeline = append([]RootCauseEvent(nil), request.RootCause.Timeline...)
+		rootCause.AddEvent("incident_created", incident.CreatedAt)
+		incident.RootCause = &rootCause
+	}
+
 	// Copy metadata
 	if request.Metadata != nil {
 		incident.Metadata = make(map[string]interface{})
@@ -182,9 +191,71 @@ func (im *IncidentManager) CreateIncident(ctx context.Context, request *CreateIn
 		logger.String("incident_id", incidentID),
 		logger.String("type", string(request.Type)))
 
+	instance := im.incidentInstance(incident)
+	metrics.Processes.IncidentOpened(incidentProcessID(incident, instance), "")
+	im.publishIncidentEvent(models.EngineEventIncidentCreated, incident, instance, map[string]interface{}{
+		"incident_type": string(incident.Type),
+		"message":       incident.Message,
+		"error_code":    incident.ErrorCode,
+		"element_id":    incident.ElementID,
+		"job_key":       incident.JobKey,
+		"job_type":      incident.JobType,
+	})
+
 	return incident, nil
 }
 
+// incidentInstance returns process instance of incident, nil when incident has none or it is not stored
+// Возвращает экземпляр процесса инцидента, nil если его нет или он не сохранен
+func (im *IncidentManager) incidentInstance(incident *Incident) *models.ProcessInstance {
+	if incident.ProcessInstanceID == "" || im.storage == nil {
+		return nil
+	}
+	instance, err := im.storage.LoadProcessInstance(incident.ProcessInstanceID)
+	if err != nil {
+		return nil
+	}
+	return instance
+}
+
+// incidentProcessID returns BPMN process ID of incident instance, falling back to process key of incident
+// Возвращает BPMN ID процесса экземпляра инцидента, при его отсутствии - ключ процесса инцидента
+func incidentProcessID(incident *Incident, instance *models.ProcessInstance) string {
+	if instance != nil {
+		return instance.ProcessID
+	}
+	return incident.ProcessKey
+}
+
+// publishIncidentEvent notifies core listeners about incident change
+// Process ID and version of instance are included so listeners need no storage lookup
+// Уведомляет слушателей core об изменении инцидента
+// ID и версия процесса экземпляра включаются, чтобы слушателям не нужно было обращаться к storage
+func (im *IncidentManager) publishIncidentEvent(
+	eventType string,
+	incident *Incident,
+	instance *models.ProcessInstance,
+	data map[string]interface{},
+) {
+	if im.core == nil {
+		return
+	}
+
+	event := models.EngineEvent{
+		Type:              eventType,
+		ProcessInstanceID: incident.ProcessInstanceID,
+		ProcessKey:        incident.ProcessKey,
+		IncidentID:        incident.ID,
+		Data:              data,
+		Timestamp:         incident.UpdatedAt,
+	}
+	if instance != nil {
+		event.ProcessID = instance.ProcessID
+		data["process_version"] = instance.ProcessVersion
+	}
+	im.core.PublishEngineEvent(event)
+}
+
 // ResolveIncident resolves an incident
 // Разрешает инцидент
 func (im *IncidentManager) ResolveIncident(ctx context.Context, request *ResolveIncidentRequest) (*Incident, error) {
@@ -251,6 +322,12 @@ func (im *IncidentManager) ResolveIncident(ctx context.Context, request *Resolve
 		logger.String("incident_id", request.IncidentID),
 		logger.String("action", string(request.Action)))
 
+	im.publishIncidentEvent(models.EngineEventIncidentResolved, incident, im.incidentInstance(incident),
+		map[string]interface{}{
+			"resolve_action": string(incident.ResolveAction),
+			"resolved_by":    incident.ResolvedBy,
+		})
+
 	return incident, nil
 }
 
@@ -241,6 +241,10 @@ func (im *IncidentManager) sanitizeIncidentData(incident *Incident) {
 		incident.Message = incident.Message[:maxMessageLength] + "... [truncated]"
 	}
 
+	if incident.RootCause != nil && len(incident.RootCause.ErrorMessage) > maxMessageLength {
+		incident.RootCause.ErrorMessage = incident.RootCause.ErrorMessage[:maxMessageLength] + "... [truncated]"
+	}
+
 	// Sanitize metadata
 	if incident.Metadata != nil {
 		for key, value := range incident.Metadata {
@@ -37,6 +37,9 @@ const (
 
 	// General system incidents
 	IncidentTypeSystemError IncidentType = "SYSTEM_ERROR"
+
+	// Process instance did not finish before its SLA deadline
+	IncidentTypeSLABreach IncidentType = "SLA_BREACH"
 )
 
 // IncidentStatus represents the status of an incident
@@ -98,10 +101,61 @@ type Incident struct {
 	OriginalRetries int `json:"original_retries,omitempty"`
 	NewRetries      int `json:"new_retries,omitempty"`
 
+	// Structured root cause captured when incident was created
+	RootCause *RootCause `json:"root_cause,omitempty"`
+
 	// Additional metadata
 	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
 
+// Root cause error types
+const (
+	RootCauseJobFailure    = "JOB_FAILURE"    // Worker failed job without retries left
+	RootCauseOutputMapping = "OUTPUT_MAPPING" // Output mapping of completed job failed
+	RootCauseJobType       = "JOB_TYPE"       // Job type expression of service task did not give job type
+	RootCauseSLABreach     = "SLA_BREACH"     // Process instance was still running at its SLA deadline
+)
+
+// RootCause describes where and why incident originated so operators do not need logs
+// Описывает, где и почему возник инцидент, чтобы операторам не нужно было искать в логах
+type RootCause struct {
+	ElementID    string `json:"element_id,omitempty"`
+	ElementType  string `json:"element_type,omitempty"`
+	ErrorType    string `json:"error_type"`
+	ErrorCode    string `json:"error_code,omitempty"`
+	ErrorMessage string `json:"error_message"`
+
+	// Job context, set when incident comes from job
+	JobKey   string `json:"job_key,omitempty"`
+	JobType  string `json:"job_type,omitempty"`
+	WorkerID string `json:"worker_id,omitempty"`
+	Retries  int    `json:"retries,omitempty"`
+
+	// Expression context, set when incident comes from expression evaluation
+	Expression string `json:"expression,omitempty"`
+	Target     string `json:"target,omitempty"`
+
+	// Timestamps leading to incident in order of occurrence
+	// Метки времени, приведшие к инциденту, в порядке возникновения
+	Timeline []RootCauseEvent `json:"timeline,omitempty"`
+}
+
+// RootCauseEvent is single timestamp of root cause timeline
+// Отдельная метка времени в хронологии первопричины
+type RootCauseEvent struct {
+	Event     string    `json:"event"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// AddEvent appends event to timeline, zero timestamps are skipped
+// Добавляет событие в хронологию, нулевые метки времени пропускаются
+func (rc *RootCause) AddEvent(event string, timestamp time.Time) {
+	if timestamp.IsZero() {
+		return
+	}
+	rc.Timeline = append(rc.Timeline, RootCauseEvent{Event: event, Timestamp: timestamp})
+}
+
 // IncidentFilter represents filters for incident queries
 // Представляет фильтры для запросов инцидентов
 type IncidentFilter struct {
@@ -147,6 +201,7 @@ type CreateIncidentRequest struct {
 	MessageName       string                 `json:"message_name,omitempty"`
 	CorrelationKey    string                 `json:"correlation_key,omitempty"`
 	OriginalRetries   int                    `json:"original_retries,omitempty"`
+	RootCause         *RootCause             `json:"root_cause,omitempty"`
 	Metadata          map[string]interface{} `json:"metadata,omitempty"`
 }
 
@@ -252,6 +307,8 @@ func (i *Incident) GetDisplayName() string {
 		return "Message Error"
 	case IncidentTypeSystemError:
 		return "System Error"
+	case IncidentTypeSLABreach:
+		return "SLA Breach"
 	default:
 		return string(i.Type)
 	}
@@ -13,6 +13,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"atom-engine/proto/parser/parserpb"
@@ -26,11 +28,13 @@ func (d *DaemonCommand) BPMNParse() error {
 
 	if len(os.Args) < 4 {
 		logger.Error("Invalid BPMN parse arguments", logger.Int("args_count", len(os.Args)))
-		return fmt.Errorf("usage: atomd bpmn parse <file.bpmn> [process_id] [--force|-f]")
+		return fmt.Errorf("usage: atomd bpmn parse <file.bpmn> [process_id] [--force|-f] [--deployment <id>] " +
+			"[--metadata <json>] [--tag <tag>]...")
 	}
 
 	filename := os.Args[3]
-	var processID string
+	var processID, deploymentID, metadataJSON string
+	var tags []string
 	var force bool
 
 	// Parse optional arguments
@@ -38,6 +42,24 @@ func (d *DaemonCommand) BPMNParse() error {
 		arg := os.Args[i]
 		if arg == "--force" || arg == "-f" {
 			force = true
+		} else if arg == "--deployment" {
+			if i+1 >= len(os.Args) {
+				return fmt.Errorf("--deployment requires deployment ID")
+			}
+			deploymentID = os.Args[i+1]
+			i++
+		} else if arg == "--metadata" {
+			if i+1 >= len(os.Args) {
+				return fmt.Errorf("--metadata requires JSON object")
+			}
+			metadataJSON = os.Args[i+1]
+			i++
+		} else if arg == "--tag" {
+			if i+1 >= len(os.Args) {
+				return fmt.Errorf("--tag requires tag value")
+			}
+			tags = append(tags, os.Args[i+1])
+			i++
 		} else if processID == "" {
 			processID = arg
 		}
@@ -46,7 +68,18 @@ func (d *DaemonCommand) BPMNParse() error {
 	logger.Debug("BPMN parse request",
 		logger.String("filename", filename),
 		logger.String("process_id", processID),
-		logger.Bool("force", force))
+		logger.Bool("force", force),
+		logger.String("deployment_id", deploymentID),
+		logger.Any("tags", tags))
+
+	// Metadata is sent as JSON object, check it before contacting daemon
+	// Метаданные передаются JSON объектом, проверяем их до обращения к демону
+	if metadataJSON != "" {
+		var metadata map[string]interface{}
+		if err := json.Unmarshal([]byte(metadataJSON), &metadata); err != nil {
+			return fmt.Errorf("invalid JSON metadata: %w", err)
+		}
+	}
 
 	conn, err := d.grpcClient.Connect()
 	if err != nil {
@@ -61,9 +94,13 @@ func (d *DaemonCommand) BPMNParse() error {
 	defer cancel()
 
 	resp, err := client.ParseBPMNFile(ctx, &parserpb.ParseBPMNFileRequest{
-		FilePath:  filename,
-		ProcessId: processID,
-		Force:     force,
+		FilePath:     filename,
+		ProcessId:    processID,
+		Force:        force,
+		DeploymentId: deploymentID,
+		Source:       "cli",
+		MetadataJson: metadataJSON,
+		Tags:         tags,
 	})
 	if err != nil {
 		logger.Error("Failed to parse BPMN file", logger.String("error", err.Error()))
@@ -84,6 +121,7 @@ func (d *DaemonCommand) BPMNParse() error {
 		fmt.Printf("BPMN ID: %s\n", resp.BpmnId)
 		fmt.Printf("Process ID: %s\n", resp.ProcessId)
 		fmt.Printf("Process Name: %s\n", resp.ProcessName)
+		fmt.Printf("Deployment ID: %s\n", resp.DeploymentId)
 		fmt.Printf("Total Elements: %d\n", resp.TotalElements)
 		fmt.Printf("Successful: %d\n", resp.SuccessfulElements)
 		fmt.Printf("Generic: %d\n", resp.GenericElements)
@@ -100,6 +138,8 @@ func (d *DaemonCommand) BPMNList() error {
 
 	// Parse arguments for pagination
 	var pageSize, page int32 = 20, 1 // Default values
+	var deploymentID string
+	var tags []string
 
 	args := os.Args[3:] // Skip "atomd bpmn list"
 
@@ -121,13 +161,27 @@ func (d *DaemonCommand) BPMNList() error {
 					continue
 				}
 			}
+		} else if arg == "--deployment" {
+			if i+1 < len(args) {
+				deploymentID = args[i+1]
+				i++
+				continue
+			}
+		} else if arg == "--tag" {
+			if i+1 < len(args) {
+				tags = append(tags, args[i+1])
+				i++
+				continue
+			}
 		}
 		// Note: No positional arguments for BPMN list currently
 	}
 
 	logger.Debug("BPMN list request",
 		logger.Int("page_size", int(pageSize)),
-		logger.Int("page", int(page)))
+		logger.Int("page", int(page)),
+		logger.String("deployment_id", deploymentID),
+		logger.Any("tags", tags))
 
 	conn, err := d.grpcClient.Connect()
 	if err != nil {
@@ -142,11 +196,13 @@ func (d *DaemonCommand) BPMNList() error {
 	defer cancel()
 
 	resp, err := client.ListBPMNProcesses(ctx, &parserpb.ListBPMNProcessesRequest{
-		Limit:     0, // Use pagination instead
-		PageSize:  pageSize,
-		Page:      page,
-		SortBy:    "created_at",
-		SortOrder: "DESC",
+		Limit:        0, // Use pagination instead
+		PageSize:     pageSize,
+		Page:         page,
+		SortBy:       "created_at",
+		SortOrder:    "DESC",
+		DeploymentId: deploymentID,
+		Tags:         tags,
 	})
 	if err != nil {
 		logger.Error("Failed to list BPMN processes", logger.String("error", err.Error()))
@@ -245,6 +301,18 @@ func (d *DaemonCommand) BPMNShow() error {
 	fmt.Printf("Created At: %s\n", process.CreatedAt)
 	fmt.Printf("Updated At: %s\n", process.UpdatedAt)
 	fmt.Printf("Parsed At: %s\n", process.ParsedAt)
+	if deployment := process.Deployment; deployment != nil {
+		fmt.Printf("Deployment ID: %s\n", deployment.DeploymentId)
+		fmt.Printf("Deployed At: %s\n", deployment.DeployedAt)
+		fmt.Printf("Deployed By: %s\n", deployment.DeployedBy)
+		fmt.Printf("Deployment Source: %s\n", deployment.Source)
+	}
+	if len(process.Tags) > 0 {
+		fmt.Printf("Tags: %s\n", strings.Join(process.Tags, ", "))
+	}
+	if process.MetadataJson != "" && process.MetadataJson != "{}" {
+		fmt.Printf("Metadata: %s\n", process.MetadataJson)
+	}
 
 	if len(process.ElementCounts) > 0 {
 		fmt.Printf("\nElement Counts:\n")
@@ -341,6 +409,11 @@ func (d *DaemonCommand) BPMNStats() error {
 		fmt.Printf("Last Parsed At: %s\n", resp.LastParsedAt)
 	}
 
+	fmt.Printf("\nParse Concurrency:\n")
+	fmt.Printf("  Active: %d/%d\n", resp.ActiveParses, resp.MaxConcurrentParses)
+	fmt.Printf("  Queued: %d/%d\n", resp.QueuedParses, resp.ParseQueueSize)
+	fmt.Printf("  Rejected: %d\n", resp.RejectedParses)
+
 	if len(resp.ElementTypeCounts) > 0 {
 		fmt.Printf("\nElement Type Counts:\n")
 		for elementType, count := range resp.ElementTypeCounts {
@@ -427,11 +500,24 @@ func (d *DaemonCommand) BPMNXml() error {
 
 	if len(os.Args) < 4 {
 		logger.Error("Invalid BPMN xml arguments", logger.Int("args_count", len(os.Args)))
-		return fmt.Errorf("usage: atomd bpmn xml <process_key>")
+		return fmt.Errorf("usage: atomd bpmn xml <process_key> [--version N]")
 	}
 
 	processKey := os.Args[3]
-	logger.Debug("BPMN xml request", logger.String("process_key", processKey))
+	version := 0
+	args := os.Args[4:]
+	for i, arg := range args {
+		if (arg == "-v" || arg == "--version") && i+1 < len(args) {
+			value, err := strconv.Atoi(args[i+1])
+			if err != nil || value < 1 {
+				return fmt.Errorf("invalid version %q: must be positive integer", args[i+1])
+			}
+			version = value
+		}
+	}
+	logger.Debug("BPMN xml request",
+		logger.String("process_key", processKey),
+		logger.Int("version", version))
 
 	conn, err := d.grpcClient.Connect()
 	if err != nil {
@@ -447,6 +533,7 @@ func (d *DaemonCommand) BPMNXml() error {
 
 	resp, err := client.GetBPMNProcessXML(ctx, &parserpb.GetBPMNProcessXMLRequest{
 		ProcessKey: processKey,
+		Version:    int32(version),
 	})
 	if err != nil {
 		logger.Error("Failed to get BPMN process XML", logger.String("error", err.Error()))
@@ -467,6 +554,7 @@ func (d *DaemonCommand) BPMNXml() error {
 	fmt.Printf("BPMN Process XML\n")
 	fmt.Printf("================\n")
 	fmt.Printf("Process Key: %s\n", processKey)
+	fmt.Printf("Version: %d\n", resp.ProcessVersion)
 	fmt.Printf("Filename: %s\n", resp.Filename)
 	fmt.Printf("File Size: %d bytes\n", resp.FileSize)
 	fmt.Printf("\nXML Content:\n")
@@ -82,7 +82,7 @@ func (d *DaemonCommand) Run() error {
 
 	// Set up signal handling
 	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGINT)
+	signal.Notify(sigChan, syscall.SIGTERM, syscall.SIGINT, syscall.SIGHUP)
 
 	// Start core system
 	err := d.startCore()
@@ -93,8 +93,10 @@ func (d *DaemonCommand) Run() error {
 
 	fmt.Println(ColorizeMessage("Atom Engine daemon is running"))
 
-	// Wait for shutdown signal
-	<-sigChan
+	// Wait for shutdown signal, SIGHUP reloads auth configuration without restart
+	for sig := <-sigChan; sig == syscall.SIGHUP; sig = <-sigChan {
+		d.reloadAuthConfig()
+	}
 	fmt.Println("Shutting down Atom Engine daemon...")
 
 	// Stop core system
@@ -110,6 +112,29 @@ func (d *DaemonCommand) Run() error {
 	return nil
 }
 
+// reloadAuthConfig reloads configuration and applies its auth section to running core.
+// Other sections still require restart
+// Перечитывает конфигурацию и применяет ее секцию auth к работающему ядру.
+// Остальные секции по-прежнему требуют перезапуска
+func (d *DaemonCommand) reloadAuthConfig() {
+	logger.Info("Reloading auth configuration on SIGHUP")
+
+	cfg, err := config.LoadConfigWithEnv()
+	if err != nil {
+		logger.Error("Failed to reload configuration, keeping current auth settings",
+			logger.String("error", err.Error()))
+		return
+	}
+
+	if err := d.core.ReloadAuthConfig(&cfg.Auth); err != nil {
+		logger.Error("Failed to apply reloaded auth configuration",
+			logger.String("error", err.Error()))
+		return
+	}
+
+	logger.Info("Auth configuration reloaded")
+}
+
 // Stop stops running daemon
 // Останавливает работающий демон
 func (d *DaemonCommand) Stop() error {
@@ -9,11 +9,15 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
 	"time"
 
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
 	"atom-engine/proto/jobs/jobspb"
 	"atom-engine/proto/messages/messagespb"
 	"atom-engine/proto/parser/parserpb"
@@ -21,6 +25,52 @@ import (
 	"atom-engine/proto/timewheel/timewheelpb"
 )
 
+// Output formats of -o flag
+// Форматы вывода флага -o
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// parseOutputFormat extracts -o/--output flag from arguments and returns remaining arguments
+// Извлекает флаг -o/--output из аргументов и возвращает оставшиеся аргументы
+func parseOutputFormat(args []string) (string, []string, error) {
+	format := outputTable
+	rest := make([]string, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg != "-o" && arg != "--output" {
+			rest = append(rest, arg)
+			continue
+		}
+		if i+1 >= len(args) {
+			return "", nil, fmt.Errorf("%s requires value: table or json", arg)
+		}
+		format = strings.ToLower(args[i+1])
+		if format != outputTable && format != outputJSON {
+			return "", nil, fmt.Errorf("unsupported output format %q: use table or json", args[i+1])
+		}
+		i++
+	}
+	return format, rest, nil
+}
+
+// printProtoJSON prints gRPC response as indented JSON with proto field names
+// Выводит gRPC ответ как JSON с отступами и именами полей из proto
+func printProtoJSON(message proto.Message) error {
+	data, err := protojson.MarshalOptions{
+		Multiline:       true,
+		Indent:          "  ",
+		UseProtoNames:   true,
+		EmitUnpopulated: true,
+	}.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to encode JSON output: %w", err)
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
 // formatBytes formats byte size to human readable format
 // Форматирует размер в байтах в удобочитаемый формат
 func formatBytes(bytes int64) string {
@@ -264,13 +314,14 @@ func printJobsTable(jobs []*jobspb.JobInfo, totalCount int32) {
 	})
 
 	// Table headers
-	fmt.Printf("%-25s %-15s %-15s %-8s %-12s %-25s %-20s %-20s\n",
-		"JOB KEY", "TYPE", "WORKER", "RETRIES", "STATUS", "PROCESS INSTANCE", "ELEMENT ID", "CREATED")
-	fmt.Printf("%-25s %-15s %-15s %-8s %-12s %-25s %-20s %-20s\n",
+	fmt.Printf("%-25s %-15s %-15s %-8s %-8s %-12s %-25s %-20s %-20s\n",
+		"JOB ID", "TYPE", "WORKER", "RETRIES", "PRIORITY", "STATUS", "PROCESS INSTANCE", "ELEMENT ID", "CREATED")
+	fmt.Printf("%-25s %-15s %-15s %-8s %-8s %-12s %-25s %-20s %-20s\n",
 		strings.Repeat("-", 25),
 		strings.Repeat("-", 15),
 		strings.Repeat("-", 15),
 		strings.Repeat("-", 8),
+		strings.Repeat("-", 8),
 		strings.Repeat("-", 12),
 		strings.Repeat("-", 25),
 		strings.Repeat("-", 20),
@@ -289,13 +340,14 @@ func printJobsTable(jobs []*jobspb.JobInfo, totalCount int32) {
 		// Format retries with color
 		retriesInfo := colorizeRetries(job.Retries, job.MaxRetries)
 
-		fmt.Printf("%-25s %-15s %-15s %-8s %-12s %-25s %-20s %-20s\n",
-			job.Key,
+		fmt.Printf("%-25s %-15s %-15s %-8s %-8d %-12s %-25s %-20s %-20s\n",
+			job.Id,
 			job.Type,
 			job.Worker,
 			retriesInfo,
+			job.Priority,
 			colorizeJobStatus(job.Status),
-			job.ProcessInstanceKey,
+			job.ProcessInstanceId,
 			job.ElementId,
 			createdTime)
 	}
@@ -387,9 +439,10 @@ func printMessagesTable(messages []*messagespb.BufferedMessage, totalCount int32
 	})
 
 	// Table headers
-	fmt.Printf("%-25s %-20s %-20s %-15s %-20s %-20s %-20s %-30s\n",
-		"MESSAGE ID", "NAME", "CORRELATION KEY", "TENANT ID", "PUBLISHED", "EXPIRES", "ELEMENT ID", "REASON")
-	fmt.Printf("%-25s %-20s %-20s %-15s %-20s %-20s %-20s %-30s\n",
+	fmt.Printf("%-25s %-20s %-20s %-15s %-20s %-20s %-20s %-30s %s\n",
+		"MESSAGE ID", "NAME", "CORRELATION KEY", "TENANT ID", "PUBLISHED", "EXPIRES", "ELEMENT ID", "REASON",
+		"VARIABLES")
+	fmt.Printf("%-25s %-20s %-20s %-15s %-20s %-20s %-20s %-30s %s\n",
 		strings.Repeat("-", 25),
 		strings.Repeat("-", 20),
 		strings.Repeat("-", 20),
@@ -397,7 +450,8 @@ func printMessagesTable(messages []*messagespb.BufferedMessage, totalCount int32
 		strings.Repeat("-", 20),
 		strings.Repeat("-", 20),
 		strings.Repeat("-", 20),
-		strings.Repeat("-", 30))
+		strings.Repeat("-", 30),
+		strings.Repeat("-", 9))
 
 	// Print message rows
 	for _, msg := range messages {
@@ -436,7 +490,7 @@ func printMessagesTable(messages []*messagespb.BufferedMessage, totalCount int32
 			elementID = "<none>"
 		}
 
-		fmt.Printf("%-25s %-20s %-20s %-15s %-20s %-20s %-20s %-30s\n",
+		fmt.Printf("%-25s %-20s %-20s %-15s %-20s %-20s %-20s %-30s %s\n",
 			msg.Id,
 			msg.Name,
 			correlationKey,
@@ -444,12 +498,30 @@ func printMessagesTable(messages []*messagespb.BufferedMessage, totalCount int32
 			publishedTime,
 			expiresTime,
 			elementID,
-			reason)
+			reason,
+			formatMessageVariables(msg))
 	}
 
 	fmt.Println()
 }
 
+// formatMessageVariables formats message variables as compact JSON keeping lists and nested objects
+// Falls back to string variables when server does not send typed JSON
+// Форматирует переменные сообщения компактным JSON с сохранением списков и вложенных объектов
+// Использует строковые переменные, если сервер не передает типизированный JSON
+func formatMessageVariables(msg *messagespb.BufferedMessage) string {
+	variablesJSON := msg.VariablesJson
+	if variablesJSON == "" && len(msg.Variables) > 0 {
+		if data, err := json.Marshal(msg.Variables); err == nil {
+			variablesJSON = string(data)
+		}
+	}
+	if variablesJSON == "" || variablesJSON == "{}" || variablesJSON == "null" {
+		return "<none>"
+	}
+	return truncateString(variablesJSON, 60)
+}
+
 // printMessageSubscriptionsTable prints message subscriptions in a formatted table
 // sorted by creation time (newest first)
 // Выводит подписки на сообщения в форматированной таблице, отсортированной по времени создания (новые первыми)
@@ -69,11 +69,11 @@ func showHelp() {
 	fmt.Println("  atomd bpmn delete <process_id>            Delete process")
 	fmt.Println("  atomd bpmn stats                          Show parser statistics")
 	fmt.Println("  atomd bpmn json <process_key>             Show process JSON (use PROCESS KEY)")
-	fmt.Println("  atomd bpmn xml <process_key>              Show original XML (use PROCESS KEY)")
+	fmt.Println("  atomd bpmn xml <process_key> [-v ver]     Show original XML (use PROCESS KEY)")
 	fmt.Println("")
 
 	fmt.Println("Process:")
-	fmt.Println("  atomd process start <key> [-v ver] [-d vars]  Start process instance")
+	fmt.Println("  atomd process start <key> [vars] [-v ver]     Start process instance")
 	fmt.Println("  atomd process status <instance_id>           Get instance status")
 	fmt.Println("  atomd process info <instance_id>             Get complete instance information")
 	fmt.Println("  atomd process cancel <instance_id> [reason]  Cancel instance")
@@ -188,7 +188,7 @@ func showProcessHelp() {
 	fmt.Println("Process management commands:")
 	fmt.Println("")
 	fmt.Println("Usage:")
-	fmt.Println("  atomd process start <process_key> [-v version] [-d variables]              - Start process instance")
+	fmt.Println("  atomd process start <process_key> [variables] [-v version] [-d variables]  - Start process instance")
 	fmt.Println("  atomd process status <instance_id>                                         - Get process instance status")
 	fmt.Println("  atomd process info <instance_id>                                           - Get complete process instance information")
 	fmt.Println("  atomd process cancel <instance_id> [reason]                                - Cancel process instance")
@@ -198,21 +198,28 @@ func showProcessHelp() {
 	fmt.Println("Start options:")
 	fmt.Println("  -v, --version <version>                                                    - Specific version to start")
 	fmt.Println("  -d, --data <json>                                                          - Process variables as JSON")
+	fmt.Println("  --priority <N>                                                             - Instance priority added to job priority")
 	fmt.Println("")
 	fmt.Println("List options:")
 	fmt.Println("  --page, -p <N>         Page number (default: 1)")
 	fmt.Println("  --page-size, -s <N>    Number of instances per page (default: 20)")
 	fmt.Println("")
+	fmt.Println("Output options (start, status, cancel, list):")
+	fmt.Println("  -o, --output <format>  Output format: table (default) or json")
+	fmt.Println("")
 	fmt.Println("Examples:")
 	fmt.Println("  atomd process start Process_Big_Process_ID                                 - Start latest version")
 	fmt.Println("  atomd process start Process_Big_Process_ID -v 3                            - Start version 3")
 	fmt.Println("  atomd process start Process_Big_Process_ID -d '{\"data\": \"value\"}'          - Start with variables")
+	fmt.Println("  atomd process start Process_Big_Process_ID --priority 10                   - Start with high priority")
+	fmt.Println("  atomd process start Process_Big_Process_ID '{\"amount\": 250}' -o json      - Start and print JSON result")
 	fmt.Println("  atomd process status srv1-aB3dEf9hK2mN5pQ8uV                              - Get instance status")
 	fmt.Println("  atomd process info srv1-aB3dEf9hK2mN5pQ8uV                                - Get complete instance info")
 	fmt.Println("  atomd process cancel srv1-aB3dEf9hK2mN5pQ8uV \"user requested\"              - Cancel with reason")
 	fmt.Println("  atomd process list                                                         - List first 20 instances")
 	fmt.Println("  atomd process list --page 2                                                - List page 2 (instances 21-40)")
 	fmt.Println("  atomd process list ACTIVE --page-size 50                                   - List active instances, 50 per page")
+	fmt.Println("  atomd process list --page 2 -o json                                        - List page 2 as JSON")
 	fmt.Println("  atomd process list \"\" ProcessKey --page 1 --page-size 10                   - List instances with pagination")
 }
 
@@ -251,7 +258,7 @@ func showJobHelp() {
 	fmt.Println("  atomd job show <job_key>                                                                               - Show job details")
 	fmt.Println("  atomd job activate <type> <worker> [-j max_jobs] [-t timeout]                                          - Activate jobs for worker")
 	fmt.Println("  atomd job complete <job_key> [variables]                                                               - Complete job")
-	fmt.Println("  atomd job fail <job_key> <retries> [error] [backoff]                                                   - Fail job")
+	fmt.Println("  atomd job fail <job_key> <retries> [error] [--error-code <code>]                                       - Fail job")
 	fmt.Println("  atomd job throw-error <job_key> <error_code> [error_message]                                            - Throw BPMN error")
 	fmt.Println("  atomd job cancel <job_key>                                                                             - Cancel job")
 	fmt.Println("  atomd job help                                                                                         - Show this help")
@@ -272,6 +279,7 @@ func showJobHelp() {
 	fmt.Println("  atomd job activate service-task worker1 -j 3 -t 10000                                                  - Activate 3 jobs with 10s timeout")
 	fmt.Println("  atomd job complete atom-jobkey12345 '{\"result\": \"success\"}'                                           - Complete with variables")
 	fmt.Println("  atomd job fail atom-jobkey12345 2 \"Connection failed\"                                                  - Fail with 2 retries left")
+	fmt.Println("  atomd job fail atom-jobkey12345 0 \"Card declined\" --error-code PAYMENT_FAILED                          - Final failure as BPMN error")
 	fmt.Println("  atomd job throw-error atom-jobkey12345 404 \"Not Found\"                                                 - Throw BPMN error 404")
 	fmt.Println("  atomd job cancel atom-jobkey12345                                                                      - Cancel job")
 }
@@ -338,32 +346,42 @@ func showBPMNHelp() {
 	fmt.Println("BPMN management commands:")
 	fmt.Println("")
 	fmt.Println("Usage:")
-	fmt.Println("  atomd bpmn parse <file.bpmn> [process_id] [--force|-f]                     - Parse BPMN file")
-	fmt.Println("  atomd bpmn list [--page N] [--page-size N]                                 - List all BPMN processes")
+	fmt.Println("  atomd bpmn parse <file.bpmn> [process_id] [--force|-f] [--deployment <id>] - Parse BPMN file")
+	fmt.Println("  atomd bpmn list [--page N] [--page-size N] [--deployment <id>] [--tag <t>] - List BPMN processes")
 	fmt.Println("  atomd bpmn show <process_key>                                               - Show BPMN process details (use PROCESS KEY from list)")
 	fmt.Println("  atomd bpmn delete <process_id>                                              - Delete BPMN process")
 	fmt.Println("  atomd bpmn stats                                                            - Show BPMN statistics")
 	fmt.Println("  atomd bpmn json <process_key>                                               - Show process JSON data (use PROCESS KEY from list)")
-	fmt.Println("  atomd bpmn xml <process_key>                                                - Show original BPMN XML (use PROCESS KEY from list)")
+	fmt.Println("  atomd bpmn xml <process_key> [--version N]                                  - Show original BPMN XML (use PROCESS KEY from list)")
 	fmt.Println("  atomd bpmn help                                                             - Show this help")
 	fmt.Println("")
 	fmt.Println("List options:")
 	fmt.Println("  --page, -p <N>         Page number (default: 1)")
 	fmt.Println("  --page-size, -s <N>    Number of processes per page (default: 20)")
+	fmt.Println("  --deployment <id>      Only processes of deployment")
+	fmt.Println("  --tag <tag>            Only processes having tag, repeatable")
+	fmt.Println("")
+	fmt.Println("Parse options:")
+	fmt.Println("  --metadata <json>      Definition metadata, copied to next versions, null removes key")
+	fmt.Println("  --tag <tag>            Definition tag, repeatable, replaces tags of previous version")
 	fmt.Println("")
 	fmt.Println("Examples:")
 	fmt.Println("  atomd bpmn parse process.bpmn                                               - Parse process.bpmn")
 	fmt.Println("  atomd bpmn parse process.bpmn my-process-1                                  - Parse with specified ID")
 	fmt.Println("  atomd bpmn parse process.bpmn --force                                       - Force import")
 	fmt.Println("  atomd bpmn parse process.bpmn my-process-1 -f                               - Force with ID")
+	fmt.Println("  atomd bpmn parse order.bpmn --deployment release-42                         - Group into deployment")
+	fmt.Println("  atomd bpmn parse order.bpmn --metadata '{\"team\":\"payments\"}' --tag team:payments")
 	fmt.Println("  atomd bpmn list                                                             - List first 20 processes")
 	fmt.Println("  atomd bpmn list --page 2                                                    - List page 2 (processes 21-40)")
 	fmt.Println("  atomd bpmn list --page-size 50                                              - List 50 processes per page")
+	fmt.Println("  atomd bpmn list --tag team:payments                                         - Filter by tag")
 	fmt.Println("  atomd bpmn show atom-7-1k2-PVn4Y9j-CF5M                                     - Show details (PROCESS KEY)")
 	fmt.Println("  atomd bpmn delete my-process-1                                              - Delete process")
 	fmt.Println("  atomd bpmn stats                                                            - Show parser statistics")
 	fmt.Println("  atomd bpmn json atom-7-1k2-PVn4Y9j-CF5M                                     - Show JSON data (PROCESS KEY)")
 	fmt.Println("  atomd bpmn xml atom-7-1k2-PVn4Y9j-CF5M                                      - Show original XML (PROCESS KEY)")
+	fmt.Println("  atomd bpmn xml atom-7-1k2-PVn4Y9j-CF5M --version 2                          - Show original XML of version 2")
 }
 
 // showIncidentHelp displays incident help information
@@ -301,9 +301,44 @@ func (d *DaemonCommand) IncidentShow() error {
 		}
 	}
 
+	printIncidentRootCause(incident.RootCause)
+
 	return nil
 }
 
+// printIncidentRootCause prints root cause section of incident details
+// Выводит секцию первопричины в деталях инцидента
+func printIncidentRootCause(rootCause *incidentspb.IncidentRootCause) {
+	if rootCause == nil {
+		return
+	}
+
+	fmt.Printf("\nRoot Cause:\n")
+	fmt.Printf("  Error Type:   %s\n", rootCause.ErrorType)
+	fmt.Printf("  Error:        %s\n", rootCause.ErrorMessage)
+	if rootCause.ErrorCode != "" {
+		fmt.Printf("  Error Code:   %s\n", rootCause.ErrorCode)
+	}
+	if rootCause.ElementId != "" {
+		fmt.Printf("  Element:      %s (%s)\n", rootCause.ElementId, rootCause.ElementType)
+	}
+	if rootCause.JobType != "" {
+		fmt.Printf("  Job:          %s (%s)\n", rootCause.JobKey, rootCause.JobType)
+	}
+	if rootCause.WorkerId != "" {
+		fmt.Printf("  Worker:       %s\n", rootCause.WorkerId)
+	}
+	if rootCause.Expression != "" {
+		fmt.Printf("  Expression:   %s -> %s\n", rootCause.Expression, rootCause.Target)
+	}
+	for _, event := range rootCause.Timeline {
+		if event.Timestamp == nil {
+			continue
+		}
+		fmt.Printf("  %-22s %s\n", event.Event, event.Timestamp.AsTime().Format("2006-01-02 15:04:05.000"))
+	}
+}
+
 // IncidentResolve resolves an incident with retry or dismiss action
 // Разрешает инцидент с действием retry или dismiss
 func (d *DaemonCommand) IncidentResolve() error {
@@ -491,6 +526,8 @@ func parseIncidentType(incidentType string) incidentspb.IncidentType {
 		return incidentspb.IncidentType_INCIDENT_TYPE_MESSAGE_ERROR
 	case "SYSTEM_ERROR", "SYSTEM":
 		return incidentspb.IncidentType_INCIDENT_TYPE_SYSTEM_ERROR
+	case "SLA_BREACH", "SLA":
+		return incidentspb.IncidentType_INCIDENT_TYPE_SLA_BREACH
 	default:
 		return incidentspb.IncidentType_INCIDENT_TYPE_UNSPECIFIED
 	}
@@ -513,6 +550,8 @@ func formatIncidentType(incidentType incidentspb.IncidentType) string {
 		return "MESSAGE_ERROR"
 	case incidentspb.IncidentType_INCIDENT_TYPE_SYSTEM_ERROR:
 		return "SYSTEM_ERROR"
+	case incidentspb.IncidentType_INCIDENT_TYPE_SLA_BREACH:
+		return "SLA_BREACH"
 	default:
 		return "UNKNOWN"
 	}
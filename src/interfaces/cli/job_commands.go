@@ -12,6 +12,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
 
@@ -194,9 +195,15 @@ func (d *DaemonCommand) JobShow() error {
 	}
 
 	job := resp.Job
-	fmt.Printf("Job Key: %s\n", job.Key)
+	fmt.Printf("Job ID: %s\n", job.Id)
+	if job.Key > 0 {
+		fmt.Printf("Job Key: %d\n", job.Key)
+	}
 	fmt.Printf("Type: %s\n", job.Type)
-	fmt.Printf("Process Instance: %s\n", job.ProcessInstanceKey)
+	fmt.Printf("Process Instance: %s\n", job.ProcessInstanceId)
+	if job.ProcessInstanceKey > 0 {
+		fmt.Printf("Process Instance Key: %d\n", job.ProcessInstanceKey)
+	}
 	fmt.Printf("Worker: %s\n", job.Worker)
 	fmt.Printf("Status: %s\n", colorizeJobStatus(job.Status))
 	fmt.Printf("Retries: %d\n", job.Retries)
@@ -256,5 +263,19 @@ func (d *DaemonCommand) JobStats() error {
 	fmt.Printf("Activated Today: %d\n", stats.ActivatedToday)
 	fmt.Printf("Completed Today: %d\n", stats.CompletedToday)
 
+	if len(stats.OldestActivatableAgeMs) > 0 {
+		jobTypes := make([]string, 0, len(stats.OldestActivatableAgeMs))
+		for jobType := range stats.OldestActivatableAgeMs {
+			jobTypes = append(jobTypes, jobType)
+		}
+		sort.Strings(jobTypes)
+
+		fmt.Printf("\nOldest Activatable Job Age\n")
+		for _, jobType := range jobTypes {
+			age := time.Duration(stats.OldestActivatableAgeMs[jobType]) * time.Millisecond
+			fmt.Printf("  %s: %s\n", jobType, age)
+		}
+	}
+
 	return nil
 }
@@ -107,11 +107,17 @@ func (d *DaemonCommand) JobActivate() error {
 
 		for _, job := range resp.Jobs {
 			fmt.Printf("Activated Job:\n")
-			fmt.Printf("  Key: %s\n", job.Key)
+			fmt.Printf("  ID: %s\n", job.Id)
+			if job.Key > 0 {
+				fmt.Printf("  Key: %d\n", job.Key)
+			}
 			fmt.Printf("  Type: %s\n", job.Type)
-			fmt.Printf("  Process Instance: %s\n", job.ProcessInstanceKey)
+			fmt.Printf("  Process Instance: %s\n", job.ProcessInstanceId)
 			fmt.Printf("  Worker: %s\n", job.Worker)
 			fmt.Printf("  Retries: %d\n", job.Retries)
+			if len(job.CustomHeaders) > 0 {
+				fmt.Printf("  Custom Headers: %v\n", job.CustomHeaders)
+			}
 			fmt.Printf("  Variables: %s\n", job.Variables)
 			fmt.Printf("\n")
 			activatedCount++
@@ -179,7 +185,7 @@ func (d *DaemonCommand) JobFail() error {
 
 	if len(os.Args) < 5 {
 		logger.Error("Invalid job fail arguments", logger.Int("args_count", len(os.Args)))
-		return fmt.Errorf("usage: atomd job fail <job_key> <retries> [error] [backoff]")
+		return fmt.Errorf("usage: atomd job fail <job_key> <retries> [error] [--error-code <code>]")
 	}
 
 	jobKey := os.Args[3]
@@ -189,9 +195,19 @@ func (d *DaemonCommand) JobFail() error {
 		return fmt.Errorf("invalid retries value: %s", retriesStr)
 	}
 
-	var errorMessage string
-	if len(os.Args) > 5 {
-		errorMessage = os.Args[5]
+	// Error code thrown as BPMN error when no retries are left is passed with --error-code option
+	// Код ошибки, выбрасываемый как BPMN ошибка при отсутствии повторов, передается опцией --error-code
+	var errorMessage, errorCode string
+	args := os.Args[5:]
+	for i := 0; i < len(args); i++ {
+		if args[i] == "--error-code" && i+1 < len(args) {
+			errorCode = args[i+1]
+			i++
+			continue
+		}
+		if errorMessage == "" {
+			errorMessage = args[i]
+		}
 	}
 
 	conn, err := d.grpcClient.Connect()
@@ -210,6 +226,7 @@ func (d *DaemonCommand) JobFail() error {
 		JobKey:       jobKey,
 		Retries:      int32(retries),
 		ErrorMessage: errorMessage,
+		ErrorCode:    errorCode,
 	})
 	if err != nil {
 		logger.Error("Failed to fail job", logger.String("error", err.Error()))
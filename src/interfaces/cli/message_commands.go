@@ -10,6 +10,7 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -65,12 +66,15 @@ func (d *DaemonCommand) MessagePublish() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Parse variables if provided
-	variablesMap := make(map[string]string)
+	// Variables are sent as JSON object so lists, nested objects and numbers keep their types
+	// Переменные передаются JSON объектом, чтобы списки, вложенные объекты и числа сохранили типы
 	if variables != "" {
-		// Simple implementation - store as single variable
-		// Простая реализация - сохраняем как одну переменную
-		variablesMap["data"] = variables
+		var jsonVars map[string]interface{}
+		if err := json.Unmarshal([]byte(variables), &jsonVars); err != nil {
+			logger.Error("Failed to parse JSON variables", logger.String("error", err.Error()))
+			return fmt.Errorf("invalid JSON variables: %w", err)
+		}
+		logger.Debug("Parsed variables", logger.Int("var_count", len(jsonVars)))
 	}
 
 	// Make gRPC request
@@ -78,7 +82,7 @@ func (d *DaemonCommand) MessagePublish() error {
 		TenantId:       "", // Default tenant
 		MessageName:    name,
 		CorrelationKey: correlationKey,
-		Variables:      variablesMap,
+		VariablesJson:  variables,
 		TtlSeconds:     ttlSeconds,
 	}
 
@@ -429,6 +433,30 @@ func (d *DaemonCommand) MessageStats() error {
 	fmt.Printf("Expired Messages: %d\n", stats.ExpiredMessages)
 	fmt.Printf("Published Today: %d\n", stats.PublishedToday)
 	fmt.Printf("Instances Created Today: %d\n", stats.InstancesCreatedToday)
+	fmt.Printf("\nCorrelation\n")
+	fmt.Printf("-----------\n")
+	fmt.Printf("Correlated Immediately: %d\n", stats.CorrelatedImmediately)
+	fmt.Printf("Correlated From Buffer: %d\n", stats.CorrelatedFromBuffer)
+	fmt.Printf("Expired Uncorrelated: %d\n", stats.ExpiredUncorrelated)
+	fmt.Printf("Avg Buffer Dwell Time: %s\n", (time.Duration(stats.AvgBufferDwellMs) * time.Millisecond).String())
+	fmt.Printf("Correlation Success Rate: %.1f%%\n", stats.CorrelationSuccessRate)
+	fmt.Printf("\nBuffer\n")
+	fmt.Printf("------\n")
+	if stats.BufferCapacity > 0 {
+		fmt.Printf("Capacity: %d per tenant and message name\n", stats.BufferCapacity)
+	} else {
+		fmt.Printf("Capacity: unlimited\n")
+	}
+	fmt.Printf("Eviction Policy: %s\n", stats.BufferEvictionPolicy)
+	fmt.Printf("Evicted: %d\n", stats.BufferEvicted)
+	fmt.Printf("Rejected: %d\n", stats.BufferRejected)
+	for _, item := range stats.BufferUtilization {
+		fmt.Printf("  %s [tenant %q]: %d buffered", item.MessageName, item.TenantId, item.Buffered)
+		if item.Capacity > 0 {
+			fmt.Printf(" (%.1f%%)", item.UtilizationPercent)
+		}
+		fmt.Printf("\n")
+	}
 
 	return nil
 }
@@ -13,6 +13,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -25,34 +27,57 @@ import (
 func (d *DaemonCommand) ProcessStart() error {
 	logger.Debug("Starting process instance")
 
+	const usage = "usage: atomd process start <process_key> [variables] " +
+		"[-v version] [-d variables] [--priority N] [-o json]"
 	if len(os.Args) < 4 {
 		logger.Error("Invalid process start arguments", logger.Int("args_count", len(os.Args)))
-		return fmt.Errorf("usage: atomd process start <process_key> [-v version] [-d variables]")
+		return fmt.Errorf(usage)
+	}
+
+	outputFormat, args, err := parseOutputFormat(os.Args[3:]) // Skip "atomd process start"
+	if err != nil {
+		return err
 	}
 
 	// Parse arguments and flags
 	var processKey string
 	var version string
 	var variables string
+	var priority int
 
-	args := os.Args[3:] // Skip "atomd process start"
-	for i, arg := range args {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
 		if arg == "-v" || arg == "--version" {
 			if i+1 < len(args) {
 				version = args[i+1]
+				i++
 			}
 		} else if arg == "-d" || arg == "--data" {
 			if i+1 < len(args) {
 				variables = args[i+1]
+				i++
+			}
+		} else if arg == "--priority" {
+			if i+1 < len(args) {
+				value, err := strconv.Atoi(args[i+1])
+				if err != nil {
+					return fmt.Errorf("invalid priority %q: must be integer", args[i+1])
+				}
+				priority = value
+				i++
 			}
 		} else if processKey == "" && !strings.HasPrefix(arg, "-") {
 			processKey = arg
+		} else if variables == "" && !strings.HasPrefix(arg, "-") {
+			// Second positional argument is variables JSON
+			// Второй позиционный аргумент - JSON переменных
+			variables = arg
 		}
 	}
 
 	if processKey == "" {
 		logger.Error("Process key not provided")
-		return fmt.Errorf("usage: atomd process start <process_key> [-v version] [-d variables]")
+		return fmt.Errorf(usage)
 	}
 
 	logger.Debug("Process start request",
@@ -60,6 +85,19 @@ func (d *DaemonCommand) ProcessStart() error {
 		logger.String("version", version),
 		logger.String("variables", variables))
 
+	// Variables are sent as JSON object so numbers, booleans and objects keep their types
+	// Переменные передаются JSON объектом, чтобы числа, boolean и объекты сохранили типы
+	if variables != "" {
+		var jsonVars map[string]interface{}
+		if err := json.Unmarshal([]byte(variables), &jsonVars); err != nil {
+			logger.Error("Failed to parse JSON variables",
+				logger.String("variables", variables),
+				logger.String("error", err.Error()))
+			return fmt.Errorf("invalid JSON variables: %w", err)
+		}
+		logger.Debug("Parsed variables", logger.Int("var_count", len(jsonVars)))
+	}
+
 	conn, err := d.grpcClient.Connect()
 	if err != nil {
 		logger.Error("Failed to connect for process start", logger.String("error", err.Error()))
@@ -73,41 +111,6 @@ func (d *DaemonCommand) ProcessStart() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
 
-	// Parse variables if provided
-	variablesMap := make(map[string]string)
-	if variables != "" {
-		var jsonVars map[string]interface{}
-		if err := json.Unmarshal([]byte(variables), &jsonVars); err != nil {
-			logger.Error("Failed to parse JSON variables",
-				logger.String("variables", variables),
-				logger.String("error", err.Error()))
-			return fmt.Errorf("invalid JSON variables: %w", err)
-		}
-
-		// Convert to string map for protobuf, preserving JSON structure
-		for key, value := range jsonVars {
-			// For complex objects, serialize back to JSON
-			// Для сложных объектов сериализуем обратно в JSON
-			switch v := value.(type) {
-			case map[string]interface{}, []interface{}, map[string]string, []string:
-				// Serialize complex types back to JSON
-				jsonBytes, err := json.Marshal(v)
-				if err != nil {
-					logger.Warn("Failed to serialize variable to JSON, using string representation",
-						logger.String("key", key),
-						logger.String("error", err.Error()))
-					variablesMap[key] = fmt.Sprintf("%v", value)
-				} else {
-					variablesMap[key] = string(jsonBytes)
-				}
-			default:
-				// For simple types, use string representation
-				variablesMap[key] = fmt.Sprintf("%v", value)
-			}
-		}
-		logger.Debug("Parsed variables", logger.Int("var_count", len(variablesMap)))
-	}
-
 	// Construct final process key with version if specified
 	finalProcessKey := processKey
 	if version != "" {
@@ -117,8 +120,9 @@ func (d *DaemonCommand) ProcessStart() error {
 	logger.Debug("Starting process with final key", logger.String("final_process_key", finalProcessKey))
 
 	response, err := client.StartProcessInstance(ctx, &processpb.StartProcessInstanceRequest{
-		ProcessId: finalProcessKey,
-		Variables: variablesMap,
+		ProcessId:     finalProcessKey,
+		VariablesJson: variables,
+		Priority:      int32(priority),
 	})
 	if err != nil {
 		logger.Error("Failed to start process instance via gRPC",
@@ -138,8 +142,15 @@ func (d *DaemonCommand) ProcessStart() error {
 		logger.String("instance_id", response.InstanceId),
 		logger.String("status", response.Status))
 
+	if outputFormat == outputJSON {
+		return printProtoJSON(response)
+	}
+
 	fmt.Printf("Process instance started successfully\n")
 	fmt.Printf("Instance ID: %s\n", response.InstanceId)
+	if response.InstanceKey > 0 {
+		fmt.Printf("Instance Key: %d\n", response.InstanceKey)
+	}
 	fmt.Printf("Status: %s\n", colorizeStatus(response.Status))
 	fmt.Printf("Message: %s\n", response.Message)
 
@@ -151,12 +162,16 @@ func (d *DaemonCommand) ProcessStart() error {
 func (d *DaemonCommand) ProcessStatus() error {
 	logger.Debug("Getting process status")
 
-	if len(os.Args) < 4 {
+	outputFormat, args, err := parseOutputFormat(os.Args[3:])
+	if err != nil {
+		return err
+	}
+	if len(args) < 1 {
 		logger.Error("Invalid process status arguments", logger.Int("args_count", len(os.Args)))
-		return fmt.Errorf("usage: atomd process status <instance_id>")
+		return fmt.Errorf("usage: atomd process status <instance_id> [-o json]")
 	}
 
-	instanceID := os.Args[3]
+	instanceID := args[0]
 	logger.Debug("Process status request", logger.String("instance_id", instanceID))
 
 	conn, err := d.grpcClient.Connect()
@@ -185,22 +200,54 @@ func (d *DaemonCommand) ProcessStatus() error {
 		logger.String("instance_id", response.InstanceId),
 		logger.String("status", response.Status))
 
+	if outputFormat == outputJSON {
+		return printProtoJSON(response)
+	}
+
 	fmt.Printf("Process Instance Status\n")
 	fmt.Printf("=======================\n")
 	fmt.Printf("Instance ID:      %s\n", response.InstanceId)
+	if response.InstanceKey > 0 {
+		fmt.Printf("Instance Key:     %d\n", response.InstanceKey)
+	}
 	fmt.Printf("Status:           %s\n", colorizeStatus(response.Status))
 	fmt.Printf("Current Activity: %s\n", response.CurrentActivity)
 	fmt.Printf("Started At:       %s\n", time.Unix(response.StartedAt, 0).Format("2006-01-02 15:04:05"))
 	fmt.Printf("Updated At:       %s\n", time.Unix(response.UpdatedAt, 0).Format("2006-01-02 15:04:05"))
 
-	if len(response.Variables) > 0 {
-		fmt.Printf("\nVariables:\n")
-		for key, value := range response.Variables {
-			fmt.Printf("  %s: %s\n", key, value)
+	printProcessVariables(response.VariablesJson, response.Variables)
+
+	return nil
+}
+
+// printProcessVariables prints variables sorted by name, typed JSON values are preferred over string map
+// Выводит переменные, отсортированные по имени, типизированные JSON значения предпочтительнее строковых
+func printProcessVariables(variablesJSON string, variables map[string]string) {
+	values := make(map[string]string, len(variables))
+	var typed map[string]json.RawMessage
+	if variablesJSON != "" && json.Unmarshal([]byte(variablesJSON), &typed) == nil {
+		for key, value := range typed {
+			values[key] = string(value)
 		}
+	} else {
+		for key, value := range variables {
+			values[key] = value
+		}
+	}
+	if len(values) == 0 {
+		return
 	}
 
-	return nil
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Printf("\nVariables:\n")
+	for _, key := range keys {
+		fmt.Printf("  %s: %s\n", key, values[key])
+	}
 }
 
 // ProcessCancel cancels process instance via gRPC
@@ -208,15 +255,19 @@ func (d *DaemonCommand) ProcessStatus() error {
 func (d *DaemonCommand) ProcessCancel() error {
 	logger.Debug("Cancelling process instance")
 
-	if len(os.Args) < 4 {
+	outputFormat, args, err := parseOutputFormat(os.Args[3:])
+	if err != nil {
+		return err
+	}
+	if len(args) < 1 {
 		logger.Error("Invalid process cancel arguments", logger.Int("args_count", len(os.Args)))
-		return fmt.Errorf("usage: atomd process cancel <instance_id> [reason]")
+		return fmt.Errorf("usage: atomd process cancel <instance_id> [reason] [-o json]")
 	}
 
-	instanceID := os.Args[3]
+	instanceID := args[0]
 	reason := "Canceled by user"
-	if len(os.Args) >= 5 {
-		reason = os.Args[4]
+	if len(args) >= 2 {
+		reason = args[1]
 	}
 
 	logger.Debug("Process cancel request",
@@ -257,6 +308,10 @@ func (d *DaemonCommand) ProcessCancel() error {
 		logger.String("instance_id", response.InstanceId),
 		logger.String("reason", reason))
 
+	if outputFormat == outputJSON {
+		return printProtoJSON(response)
+	}
+
 	fmt.Printf("Process instance canceled successfully\n")
 	fmt.Printf("Instance ID: %s\n", response.InstanceId)
 	fmt.Printf("Message: %s\n", response.Message)
@@ -274,7 +329,10 @@ func (d *DaemonCommand) ProcessList() error {
 	var processKeyFilter string
 	var pageSize, page int32 = 20, 1 // Default values
 
-	args := os.Args[3:] // Skip "atomd process list"
+	outputFormat, args, err := parseOutputFormat(os.Args[3:]) // Skip "atomd process list"
+	if err != nil {
+		return err
+	}
 
 	// Parse arguments: handle flags and positional arguments
 	for i := 0; i < len(args); i++ {
@@ -345,6 +403,10 @@ func (d *DaemonCommand) ProcessList() error {
 	logger.Debug("Process list retrieved",
 		logger.Int("instances_count", len(response.Instances)))
 
+	if outputFormat == outputJSON {
+		return printProtoJSON(response)
+	}
+
 	fmt.Printf("Process Instance List\n")
 	fmt.Printf("====================\n")
 
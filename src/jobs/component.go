@@ -19,6 +19,7 @@ import (
 	"atom-engine/src/core/models"
 	"atom-engine/src/incidents"
 	"atom-engine/src/storage"
+	"atom-engine/src/timewheel"
 )
 
 // CoreInterface defines core methods needed by jobs component
@@ -28,6 +29,10 @@ type CoreInterface interface {
 	SendMessage(componentName, messageJSON string) error
 }
 
+// defaultJobRetries is number of retries of job without retries setting
+// Количество попыток job'а без явной настройки
+const defaultJobRetries = 3
+
 // Component handles job management operations
 type Component struct {
 	config          *config.Config
@@ -48,6 +53,9 @@ func NewComponent(cfg *config.Config, storage storage.Storage) *Component {
 		responseChannel: make(chan string, 100), // Buffered channel for job callbacks
 	}
 	comp.manager = NewJobManager(storage, logger.NewComponentLogger("job-manager"), comp)
+	if cfg != nil {
+		comp.manager.retryPolicy = NewRetryPolicy(cfg.Jobs.RetryBackoff)
+	}
 	return comp
 }
 
@@ -203,19 +211,26 @@ func (c *Component) IsReady() bool {
 
 // CreateJob creates a new job
 func (c *Component) CreateJob(jobType, processInstanceID string, variables map[string]interface{}) (string, error) {
-	return c.CreateJobWithDetails(jobType, processInstanceID, "", nil, variables)
+	return c.CreateJobWithDetails(jobType, processInstanceID, "", nil, nil, variables, 0, 0, "")
 }
 
-// CreateJobWithDetails creates a new job with custom headers and element ID
+// CreateJobWithDetails creates a new job with custom headers, metadata, element ID, priority and retry settings
+// Jobs with higher priority are activated first
+// Non-positive retries use default, repeat count of retryTimeCycle like "R3/PT10S" overrides retries
 func (c *Component) CreateJobWithDetails(
 	jobType, processInstanceID, elementID string,
 	customHeaders map[string]string,
+	metadata map[string]string,
 	variables map[string]interface{},
+	priority int,
+	retries int,
+	retryTimeCycle string,
 ) (string, error) {
 	c.logger.Info("Creating job",
 		logger.String("type", jobType),
 		logger.String("processInstanceId", processInstanceID),
-		logger.String("elementId", elementID))
+		logger.String("elementId", elementID),
+		logger.Int("priority", priority))
 
 	// Extract token ID from variables if available
 	var tokenID string
@@ -225,18 +240,36 @@ func (c *Component) CreateJobWithDetails(
 		}
 	}
 
+	if retries <= 0 {
+		retries = defaultJobRetries
+	}
+	if retryTimeCycle != "" {
+		repeatCount, _, err := ParseRetryTimeCycle(retryTimeCycle)
+		if err != nil {
+			return "", err
+		}
+		if repeatCount > 0 {
+			retries = repeatCount
+		}
+	}
+
 	// Create job model
 	job := &models.Job{
 		ID:                models.GenerateID(),
+		Key:               models.GenerateKey(),
 		Type:              jobType,
 		ProcessInstanceID: processInstanceID,
 		ElementID:         elementID,
+		ElementInstanceID: tokenID,
 		TokenID:           tokenID,
 		CustomHeaders:     customHeaders,
+		Metadata:          metadata,
 		Variables:         variables,
 		Status:            models.JobStatusPending,
-		Retries:           3,
-		MaxRetries:        3,
+		Retries:           retries,
+		MaxRetries:        retries,
+		Priority:          priority,
+		RetryTimeCycle:    retryTimeCycle,
 		CreatedAt:         time.Now(),
 		UpdatedAt:         time.Now(),
 	}
@@ -244,6 +277,7 @@ func (c *Component) CreateJobWithDetails(
 	if customHeaders == nil {
 		job.CustomHeaders = make(map[string]string)
 	}
+	c.fillRelatedKeys(job)
 
 	// Delegate to job manager
 	if err := c.manager.CreateJob(context.Background(), job); err != nil {
@@ -258,6 +292,29 @@ func (c *Component) CreateJobWithDetails(
 	return job.ID, nil
 }
 
+// fillRelatedKeys sets numeric keys of process instance and token that created job
+// Entities created before numeric keys were introduced have no key and are skipped
+// Process ID of instance is kept to label job metrics
+// Устанавливает числовые ключи экземпляра процесса и токена, создавших job
+// Сущности, созданные до появления числовых ключей, не имеют ключа и пропускаются
+// ID процесса экземпляра сохраняется для меток метрик job'а
+func (c *Component) fillRelatedKeys(job *models.Job) {
+	if c.storage == nil {
+		return
+	}
+	if job.ProcessInstanceID != "" {
+		if instance, err := c.storage.LoadProcessInstance(job.ProcessInstanceID); err == nil {
+			job.ProcessInstanceKey = instance.Key
+			job.ProcessID = instance.ProcessID
+		}
+	}
+	if job.TokenID != "" {
+		if token, err := c.storage.LoadToken(job.TokenID); err == nil {
+			job.ElementInstanceKey = token.Key
+		}
+	}
+}
+
 // ActivateJobs activates jobs for worker
 func (c *Component) ActivateJobs(workerName, jobType string, maxJobs int) ([]JobInfo, error) {
 	c.logger.Info("Activating jobs",
@@ -277,13 +334,25 @@ func (c *Component) ActivateJobs(workerName, jobType string, maxJobs int) ([]Job
 	jobInfos := make([]JobInfo, len(jobs))
 	for i, job := range jobs {
 		jobInfos[i] = JobInfo{
-			Key:               job.ID,
-			Type:              job.Type,
-			ProcessInstanceID: job.ProcessInstanceID,
-			Variables:         job.Variables,
-			Worker:            job.WorkerID,
-			Retries:           job.Retries,
-			CreatedAt:         job.CreatedAt.Unix(),
+			Key:                job.ID,
+			NumericKey:         job.Key,
+			Type:               job.Type,
+			ProcessInstanceID:  job.ProcessInstanceID,
+			ProcessInstanceKey: job.ProcessInstanceKey,
+			ProcessID:          job.ProcessID,
+			ElementID:          job.ElementID,
+			ElementInstanceID:  job.ElementInstanceID,
+			ElementInstanceKey: job.ElementInstanceKey,
+			CustomHeaders:      job.CustomHeaders,
+			Metadata:           job.Metadata,
+			Variables:          job.Variables,
+			Worker:             job.WorkerID,
+			Retries:            job.Retries,
+			Priority:           job.Priority,
+			CreatedAt:          job.CreatedAt.Unix(),
+		}
+		if job.ScheduledAt != nil {
+			jobInfos[i].Deadline = job.ScheduledAt.UnixMilli()
 		}
 	}
 
@@ -313,13 +382,25 @@ func (c *Component) ActivateJobsWithTimeout(
 	jobInfos := make([]JobInfo, len(jobs))
 	for i, job := range jobs {
 		jobInfos[i] = JobInfo{
-			Key:               job.ID,
-			Type:              job.Type,
-			ProcessInstanceID: job.ProcessInstanceID,
-			Variables:         job.Variables,
-			Worker:            job.WorkerID,
-			Retries:           job.Retries,
-			CreatedAt:         job.CreatedAt.Unix(),
+			Key:                job.ID,
+			NumericKey:         job.Key,
+			Type:               job.Type,
+			ProcessInstanceID:  job.ProcessInstanceID,
+			ProcessInstanceKey: job.ProcessInstanceKey,
+			ProcessID:          job.ProcessID,
+			ElementID:          job.ElementID,
+			ElementInstanceID:  job.ElementInstanceID,
+			ElementInstanceKey: job.ElementInstanceKey,
+			CustomHeaders:      job.CustomHeaders,
+			Metadata:           job.Metadata,
+			Variables:          job.Variables,
+			Worker:             job.WorkerID,
+			Retries:            job.Retries,
+			Priority:           job.Priority,
+			CreatedAt:          job.CreatedAt.Unix(),
+		}
+		if job.ScheduledAt != nil {
+			jobInfos[i].Deadline = job.ScheduledAt.UnixMilli()
 		}
 	}
 
@@ -338,9 +419,56 @@ func (c *Component) CompleteJob(jobKey string, variables map[string]interface{})
 func (c *Component) FailJob(jobKey string, retries int, errorMessage string) error {
 	c.logger.Info("Failing job", logger.String("jobKey", jobKey), logger.Int("retries", retries))
 
-	// Delegate to job manager
-	retryBackoff := 5 * time.Second
-	return c.manager.FailJob(context.Background(), jobKey, retries, errorMessage, retryBackoff)
+	// Delegate to job manager, backoff comes from retryTimeCycle or retry policy
+	return c.manager.FailJob(context.Background(), jobKey, retries, errorMessage, "", 0)
+}
+
+// FailJobWithBackoff fails a job with explicit retry backoff
+// Non-positive backoff falls back to retryTimeCycle of job or configured retry policy
+// Проваливает job с явной задержкой повтора
+// Неположительная задержка заменяется retryTimeCycle job'а или политикой повторов из конфигурации
+func (c *Component) FailJobWithBackoff(
+	jobKey string,
+	retries int,
+	errorMessage string,
+	retryBackoff time.Duration,
+) error {
+	if retryBackoff <= 0 {
+		return c.FailJob(jobKey, retries, errorMessage)
+	}
+
+	c.logger.Info("Failing job with backoff",
+		logger.String("jobKey", jobKey),
+		logger.Int("retries", retries),
+		logger.String("retryBackoff", retryBackoff.String()))
+
+	return c.manager.FailJob(context.Background(), jobKey, retries, errorMessage, "", retryBackoff)
+}
+
+// FailJobWithErrorCode fails a job with BPMN error code applied when no retries are left
+// Such final failure is caught by matching error boundary, unmatched one becomes UNHANDLED_BPMN_ERROR incident
+// Empty error code keeps behavior of FailJobWithBackoff
+// Проваливает job с кодом BPMN ошибки, применяемым когда повторов не осталось
+// Такой финальный провал перехватывается подходящим граничным событием ошибки,
+// без него создается инцидент UNHANDLED_BPMN_ERROR
+// Пустой код ошибки сохраняет поведение FailJobWithBackoff
+func (c *Component) FailJobWithErrorCode(
+	jobKey string,
+	retries int,
+	errorMessage string,
+	errorCode string,
+	retryBackoff time.Duration,
+) error {
+	if errorCode == "" {
+		return c.FailJobWithBackoff(jobKey, retries, errorMessage, retryBackoff)
+	}
+
+	c.logger.Info("Failing job with error code",
+		logger.String("jobKey", jobKey),
+		logger.Int("retries", retries),
+		logger.String("errorCode", errorCode))
+
+	return c.manager.FailJob(context.Background(), jobKey, retries, errorMessage, errorCode, retryBackoff)
 }
 
 // ThrowError throws BPMN error for job
@@ -354,6 +482,39 @@ func (c *Component) ThrowError(jobKey string, errorCode, errorMessage string) er
 	return c.manager.ThrowError(context.Background(), jobKey, errorCode, errorMessage, nil)
 }
 
+// ThrowErrorWithVariables throws BPMN error for job passing variables to error scope
+// Выбрасывает BPMN ошибку для job'а, передавая переменные в область обработки ошибки
+func (c *Component) ThrowErrorWithVariables(
+	jobKey string,
+	errorCode, errorMessage string,
+	variables map[string]interface{},
+) error {
+	c.logger.Info("Throwing error with variables for job",
+		logger.String("jobKey", jobKey),
+		logger.String("errorCode", errorCode),
+		logger.Int("variables", len(variables)))
+
+	return c.manager.ThrowError(context.Background(), jobKey, errorCode, errorMessage, variables)
+}
+
+// UpdateJobTimeout sets lease of running job to expire timeout from now
+// Устанавливает истечение аренды выполняющегося job'а через timeout от текущего момента
+func (c *Component) UpdateJobTimeout(jobKey string, timeout time.Duration) error {
+	c.logger.Info("Updating job timeout",
+		logger.String("jobKey", jobKey),
+		logger.String("timeout", timeout.String()))
+
+	return c.manager.UpdateJobTimeout(context.Background(), jobKey, timeout)
+}
+
+// UnlockJob releases lease of running job, job becomes activatable again
+// Снимает аренду выполняющегося job'а, job снова становится доступным для активации
+func (c *Component) UnlockJob(jobKey string) error {
+	c.logger.Info("Unlocking job", logger.String("jobKey", jobKey))
+
+	return c.manager.UnlockJob(context.Background(), jobKey)
+}
+
 // CompleteJobWithBPMNError completes job with BPMN error status
 func (c *Component) CompleteJobWithBPMNError(jobKey, errorCode, errorMessage string) error {
 	c.logger.Info("Completing job with BPMN error",
@@ -381,6 +542,11 @@ func (c *Component) GetJobStats() (interface{}, error) {
 		return &JobStats{TotalJobs: 0, ActiveJobs: 0, CompletedJobs: 0, FailedJobs: 0}, nil
 	}
 
+	oldestAges, err := c.OldestActivatableAges()
+	if err != nil {
+		c.logger.Error("Failed to get activatable job ages for stats", logger.String("error", err.Error()))
+	}
+
 	// Get today's date for comparison
 	today := time.Now().Format("2006-01-02")
 
@@ -413,9 +579,29 @@ func (c *Component) GetJobStats() (interface{}, error) {
 		FailedJobs:     failedJobs,
 		ActivatedToday: activatedToday, // Use real activated today count
 		CompletedToday: completedToday, // Use real completed today count
+
+		OldestActivatableAgeMs: oldestAges,
 	}, nil
 }
 
+// OldestActivatableAges returns age in milliseconds of oldest activatable job per job type
+// Growing age means no worker activates jobs of that type
+// Возвращает возраст в миллисекундах самого старого доступного для активации job'а по типам
+// Растущий возраст означает, что ни один worker не активирует job'ы этого типа
+func (c *Component) OldestActivatableAges() (map[string]int64, error) {
+	oldest, err := c.manager.OldestActivatableJobs(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ages := make(map[string]int64, len(oldest))
+	for jobType, createdAt := range oldest {
+		ages[jobType] = now.Sub(createdAt).Milliseconds()
+	}
+	return ages, nil
+}
+
 // ListJobs lists jobs with filtering
 func (c *Component) ListJobs(
 	jobType, worker, processInstanceID, state string,
@@ -423,14 +609,23 @@ func (c *Component) ListJobs(
 ) ([]JobInfo, int, error) {
 
 	// Create filter - ListJobsFilter is defined in manager.go
-	filter := &ListJobsFilter{
+	return c.listJobs(&ListJobsFilter{
 		Type:              jobType,
 		Worker:            worker,
 		ProcessInstanceID: processInstanceID,
 		State:             state,
+		IncludeVariables:  true,
 		Limit:             limit,
 		Offset:            offset,
-		IncludeVariables:  true,
+	})
+}
+
+// listJobs lists jobs matching filter, variables are set only when filter includes them
+func (c *Component) listJobs(filter *ListJobsFilter) ([]JobInfo, int, error) {
+	// Process instance may be filtered by numeric key
+	// Экземпляр процесса может фильтроваться по числовому ключу
+	if resolvedID, err := c.storage.ResolveProcessInstanceID(filter.ProcessInstanceID); err == nil {
+		filter.ProcessInstanceID = resolvedID
 	}
 
 	// Delegate to job manager
@@ -443,15 +638,27 @@ func (c *Component) ListJobs(
 	jobInfos := make([]JobInfo, len(jobs))
 	for i, job := range jobs {
 		jobInfos[i] = JobInfo{
-			Key:               job.ID,
-			Type:              job.Type,
-			ProcessInstanceID: job.ProcessInstanceID,
-			Variables:         job.Variables,
-			Worker:            job.WorkerID,
-			Retries:           job.Retries,
-			CreatedAt:         job.CreatedAt.Unix(),
-			Status:            string(job.Status),
-			ErrorMessage:      job.ErrorMessage,
+			Key:                job.ID,
+			NumericKey:         job.Key,
+			Type:               job.Type,
+			ProcessInstanceID:  job.ProcessInstanceID,
+			ProcessInstanceKey: job.ProcessInstanceKey,
+			ProcessID:          job.ProcessID,
+			ElementID:          job.ElementID,
+			ElementInstanceID:  job.ElementInstanceID,
+			ElementInstanceKey: job.ElementInstanceKey,
+			CustomHeaders:      job.CustomHeaders,
+			Metadata:           job.Metadata,
+			Worker:             job.WorkerID,
+			Retries:            job.Retries,
+			Priority:           job.Priority,
+			CreatedAt:          job.CreatedAt.Unix(),
+			Status:             string(job.Status),
+			ErrorMessage:       job.ErrorMessage,
+			NextRetryAt:        nextRetryAt(job),
+		}
+		if filter.IncludeVariables {
+			jobInfos[i].Variables = job.Variables
 		}
 	}
 
@@ -471,20 +678,113 @@ func (c *Component) GetJob(jobID string) (*JobInfo, error) {
 		return nil, nil // Job not found
 	}
 
-	// Convert to JobInfo
+	return newJobInfo(job), nil
+}
+
+// MaxBatchJobs limits number of keys in single batch job lookup
+// Ограничивает количество ключей в одном пакетном поиске job'ов
+const MaxBatchJobs = 100
+
+// GetJobs gets several jobs by IDs or numeric keys in order of requested keys
+// Missing job gives lookup with Found false instead of error
+// Получает несколько job'ов по ID или числовым ключам в порядке запрошенных ключей
+// Отсутствующий job дает результат с Found false вместо ошибки
+func (c *Component) GetJobs(jobIDs []string) ([]JobLookup, error) {
+	if len(jobIDs) > MaxBatchJobs {
+		return nil, fmt.Errorf("too many job keys: %d, maximum %d", len(jobIDs), MaxBatchJobs)
+	}
+
+	ctx := context.Background()
+	lookups := make([]JobLookup, len(jobIDs))
+	for i, jobID := range jobIDs {
+		job, err := c.manager.GetJob(ctx, jobID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job %s: %w", jobID, err)
+		}
+
+		lookups[i] = JobLookup{JobID: jobID, Found: job != nil}
+		if job != nil {
+			lookups[i].Job = newJobInfo(job)
+		}
+	}
+
+	return lookups, nil
+}
+
+// newJobInfo converts stored job to job information returned to callers
+// Преобразует сохраненный job в информацию о job'е, возвращаемую вызывающим
+func newJobInfo(job *models.Job) *JobInfo {
 	jobInfo := &JobInfo{
-		Key:               job.ID,
-		Type:              job.Type,
+		Key:                job.ID,
+		NumericKey:         job.Key,
+		Type:               job.Type,
+		ProcessInstanceID:  job.ProcessInstanceID,
+		ProcessInstanceKey: job.ProcessInstanceKey,
+		ProcessID:          job.ProcessID,
+		ElementID:          job.ElementID,
+		ElementInstanceID:  job.ElementInstanceID,
+		ElementInstanceKey: job.ElementInstanceKey,
+		CustomHeaders:      job.CustomHeaders,
+		Metadata:           job.Metadata,
+		Variables:          job.Variables,
+		Worker:             job.WorkerID,
+		Retries:            job.Retries,
+		Priority:           job.Priority,
+		CreatedAt:          job.CreatedAt.Unix(),
+		Status:             string(job.Status),
+		ErrorMessage:       job.ErrorMessage,
+		NextRetryAt:        nextRetryAt(job),
+	}
+	if job.Status == models.JobStatusRunning && job.ScheduledAt != nil {
+		jobInfo.Deadline = job.ScheduledAt.UnixMilli()
+	}
+
+	return jobInfo
+}
+
+// nextRetryAt returns retry time of deferred job in Unix milliseconds, zero for other jobs
+// Возвращает время повтора отложенного job'а в Unix миллисекундах, ноль для остальных job'ов
+func nextRetryAt(job *models.Job) int64 {
+	if job.Status != models.JobStatusDeferred || job.ScheduledAt == nil {
+		return 0
+	}
+	return job.ScheduledAt.UnixMilli()
+}
+
+// ScheduleJobRetry registers timer in timewheel that makes deferred job activatable again
+// Регистрирует в timewheel таймер, который снова делает отложенный job доступным для активации
+func (c *Component) ScheduleJobRetry(job *models.Job) error {
+	if c.core == nil {
+		return fmt.Errorf("core interface not set")
+	}
+	if job.ScheduledAt == nil {
+		return fmt.Errorf("job %s has no retry time", job.ID)
+	}
+
+	delay := time.Until(*job.ScheduledAt)
+	if delay < time.Millisecond {
+		delay = time.Millisecond
+	}
+	duration := isoDuration(delay)
+
+	messageJSON, err := timewheel.CreateScheduleTimerMessage(timewheel.TimerRequest{
+		ElementID:         job.ID,
+		TokenID:           job.TokenID,
 		ProcessInstanceID: job.ProcessInstanceID,
-		Variables:         job.Variables,
-		Worker:            job.WorkerID,
-		Retries:           job.Retries,
-		CreatedAt:         job.CreatedAt.Unix(),
-		Status:            string(job.Status),
-		ErrorMessage:      job.ErrorMessage,
+		TimerType:         models.TimerTypeJobRetry,
+		TimeDuration:      &duration,
+		ProcessContext:    &models.TimerProcessContext{ComponentSource: "jobs"},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create job retry timer message: %w", err)
 	}
+	return c.core.SendMessage("timewheel", messageJSON)
+}
 
-	return jobInfo, nil
+// HandleRetryTimer handles fired JOB_RETRY timer of deferred job
+// Обрабатывает сработавший JOB_RETRY таймер отложенного job'а
+func (c *Component) HandleRetryTimer(jobID string) error {
+	return c.manager.ReactivateJob(context.Background(), jobID)
 }
 
 // GetResponseChannel returns response channel for job callbacks
@@ -514,16 +814,32 @@ func (c *Component) CancelJob(jobID, reason string) error {
 }
 
 // JobInfo represents job information
+// Numeric keys are encoded as JSON strings to stay exact in generic JSON decoding
 type JobInfo struct {
-	Key               string                 `json:"key"`
-	Type              string                 `json:"type"`
-	ProcessInstanceID string                 `json:"process_instance_id"`
-	Variables         map[string]interface{} `json:"variables"`
-	Worker            string                 `json:"worker"`
-	Retries           int                    `json:"retries"`
-	CreatedAt         int64                  `json:"created_at"`
-	Status            string                 `json:"status"`
-	ErrorMessage      string                 `json:"error_message"`
+	Key                string                 `json:"key"`
+	NumericKey         int64                  `json:"numeric_key,string,omitempty"`
+	Type               string                 `json:"type"`
+	ProcessInstanceID  string                 `json:"process_instance_id"`
+	ProcessInstanceKey int64                  `json:"process_instance_key,string,omitempty"`
+	ProcessID          string                 `json:"process_id,omitempty"`
+	ElementID          string                 `json:"element_id,omitempty"`
+	ElementInstanceID  string                 `json:"element_instance_id,omitempty"`
+	ElementInstanceKey int64                  `json:"element_instance_key,string,omitempty"`
+	CustomHeaders      map[string]string      `json:"custom_headers,omitempty"`
+	Metadata           map[string]string      `json:"metadata,omitempty"`
+	Variables          map[string]interface{} `json:"variables"`
+	Worker             string                 `json:"worker"`
+	Retries            int                    `json:"retries"`
+	Priority           int                    `json:"priority"`
+	CreatedAt          int64                  `json:"created_at"`
+	Status             string                 `json:"status"`
+	ErrorMessage       string                 `json:"error_message"`
+
+	// Lease expiry of activated job, Unix milliseconds
+	Deadline int64 `json:"deadline,omitempty"`
+
+	// Retry time of deferred job, Unix milliseconds
+	NextRetryAt int64 `json:"next_retry_at,omitempty"`
 }
 
 // JobStats represents job statistics
@@ -534,6 +850,9 @@ type JobStats struct {
 	FailedJobs     int32 `json:"failed_jobs"`
 	ActivatedToday int32 `json:"activated_today"`
 	CompletedToday int32 `json:"completed_today"`
+
+	// Age of oldest activatable job per job type
+	OldestActivatableAgeMs map[string]int64 `json:"oldest_activatable_age_ms"`
 }
 
 // ProcessMessage processes JSON message from core engine
@@ -546,10 +865,16 @@ func (c *Component) ProcessMessage(ctx context.Context, messageJSON string) erro
 	// Parse message to determine type
 	// Парсим сообщение для определения типа
 	var request JobRequest
-	if err := json.Unmarshal([]byte(messageJSON), &request); err != nil {
+	// Keep numbers as json.Number so payload variables are not coerced to float64
+	// Сохраняем числа как json.Number, чтобы переменные payload не приводились к float64
+	if err := models.UnmarshalPreservingNumbers([]byte(messageJSON), &request); err != nil {
 		return fmt.Errorf("failed to parse job message: %w", err)
 	}
 
+	logger.Debug("Processing job request",
+		logger.String("type", request.Type),
+		logger.String("request_id", request.RequestID))
+
 	switch request.Type {
 	case "create_job":
 		return c.handleCreateJob(ctx, request)
@@ -571,6 +896,8 @@ func (c *Component) ProcessMessage(ctx context.Context, messageJSON string) erro
 		return c.handleListJobs(ctx, request)
 	case "get_job":
 		return c.handleGetJob(ctx, request)
+	case "get_jobs":
+		return c.handleGetJobs(ctx, request)
 	case "get_stats":
 		return c.handleGetStats(ctx, request)
 	default:
@@ -592,11 +919,15 @@ func (c *Component) handleCreateJob(ctx context.Context, request JobRequest) err
 		payload.ProcessInstanceID,
 		payload.ElementID,
 		payload.CustomHeaders,
-		payload.Variables)
+		nil,
+		payload.Variables,
+		payload.Priority,
+		payload.Retries,
+		payload.RetryTimeCycle)
 
 	var response JobResponse
 	if err != nil {
-		response = CreateJobErrorResponse("create_job_response", request.RequestID, err.Error())
+		response = CreateJobErrorResponseFromError("create_job_response", request.RequestID, err)
 	} else {
 		result := JobResult{
 			JobID:     jobID,
@@ -633,7 +964,7 @@ func (c *Component) handleActivateJobs(ctx context.Context, request JobRequest)
 
 	var response JobResponse
 	if err != nil {
-		response = CreateJobErrorResponse("activate_jobs_response", request.RequestID, err.Error())
+		response = CreateJobErrorResponseFromError("activate_jobs_response", request.RequestID, err)
 	} else {
 		response = CreateJobResponse("activate_jobs_response", request.RequestID, jobs)
 	}
@@ -658,7 +989,7 @@ func (c *Component) handleCompleteJob(ctx context.Context, request JobRequest) e
 
 	var response JobResponse
 	if err != nil {
-		response = CreateJobErrorResponse("complete_job_response", request.RequestID, err.Error())
+		response = CreateJobErrorResponseFromError("complete_job_response", request.RequestID, err)
 	} else {
 		result := JobResult{
 			JobKey:    payload.JobKey,
@@ -681,11 +1012,13 @@ func (c *Component) handleFailJob(ctx context.Context, request JobRequest) error
 		return c.sendResponse(response)
 	}
 
-	err := c.FailJob(payload.JobKey, payload.Retries, payload.ErrorMessage)
+	retryBackoff := time.Duration(payload.RetryBackoff) * time.Millisecond
+	err := c.FailJobWithErrorCode(
+		payload.JobKey, payload.Retries, payload.ErrorMessage, payload.ErrorCode, retryBackoff)
 
 	var response JobResponse
 	if err != nil {
-		response = CreateJobErrorResponse("fail_job_response", request.RequestID, err.Error())
+		response = CreateJobErrorResponseFromError("fail_job_response", request.RequestID, err)
 	} else {
 		result := JobResult{
 			JobKey:    payload.JobKey,
@@ -716,7 +1049,7 @@ func (c *Component) handleThrowError(ctx context.Context, request JobRequest) er
 
 	var response JobResponse
 	if err != nil {
-		response = CreateJobErrorResponse("throw_error_response", request.RequestID, err.Error())
+		response = CreateJobErrorResponseFromError("throw_error_response", request.RequestID, err)
 	} else {
 		result := JobResult{
 			JobKey:    payload.JobKey,
@@ -743,7 +1076,7 @@ func (c *Component) handleCancelJob(ctx context.Context, request JobRequest) err
 
 	var response JobResponse
 	if err != nil {
-		response = CreateJobErrorResponse("cancel_job_response", request.RequestID, err.Error())
+		response = CreateJobErrorResponseFromError("cancel_job_response", request.RequestID, err)
 	} else {
 		result := JobResult{
 			JobKey:    payload.JobKey,
@@ -778,7 +1111,7 @@ func (c *Component) handleUpdateJobRetries(ctx context.Context, request JobReque
 
 	var response JobResponse
 	if err != nil {
-		response = CreateJobErrorResponse("update_job_retries_response", request.RequestID, err.Error())
+		response = CreateJobErrorResponseFromError("update_job_retries_response", request.RequestID, err)
 	} else {
 		result := JobResult{
 			JobKey:    payload.JobKey,
@@ -814,7 +1147,7 @@ func (c *Component) handleUpdateJobTimeout(ctx context.Context, request JobReque
 
 	var response JobResponse
 	if err != nil {
-		response = CreateJobErrorResponse("update_job_timeout_response", request.RequestID, err.Error())
+		response = CreateJobErrorResponseFromError("update_job_timeout_response", request.RequestID, err)
 	} else {
 		result := JobResult{
 			JobKey:    payload.JobKey,
@@ -837,17 +1170,21 @@ func (c *Component) handleListJobs(ctx context.Context, request JobRequest) erro
 		return c.sendResponse(response)
 	}
 
-	jobs, total, err := c.ListJobs(
-		payload.JobType,
-		payload.Worker,
-		payload.ProcessInstanceID,
-		payload.State,
-		payload.Limit,
-		payload.Offset)
+	jobs, total, err := c.listJobs(&ListJobsFilter{
+		Type:              payload.JobType,
+		Worker:            payload.Worker,
+		ProcessInstanceID: payload.ProcessInstanceID,
+		State:             payload.State,
+		Limit:             payload.Limit,
+		Offset:            payload.Offset,
+		SortBy:            payload.SortBy,
+		SortOrder:         payload.SortOrder,
+		IncludeVariables:  !payload.ExcludeVariables,
+	})
 
 	var response JobResponse
 	if err != nil {
-		response = CreateJobErrorResponse("list_jobs_response", request.RequestID, err.Error())
+		response = CreateJobErrorResponseFromError("list_jobs_response", request.RequestID, err)
 	} else {
 		result := JobListResult{
 			Jobs:   jobs,
@@ -871,10 +1208,13 @@ func (c *Component) handleGetJob(ctx context.Context, request JobRequest) error
 	}
 
 	job, err := c.GetJob(payload.JobID)
+	if err == nil && job == nil {
+		err = fmt.Errorf("job %w: %s", models.ErrNotFound, payload.JobID)
+	}
 
 	var response JobResponse
 	if err != nil {
-		response = CreateJobErrorResponse("get_job_response", request.RequestID, err.Error())
+		response = CreateJobErrorResponseFromError("get_job_response", request.RequestID, err)
 	} else {
 		response = CreateJobResponse("get_job_response", request.RequestID, job)
 	}
@@ -882,6 +1222,28 @@ func (c *Component) handleGetJob(ctx context.Context, request JobRequest) error
 	return c.sendResponse(response)
 }
 
+// handleGetJobs handles batch get jobs request
+// Обрабатывает запрос пакетного получения job'ов
+func (c *Component) handleGetJobs(ctx context.Context, request JobRequest) error {
+	var payload GetJobsPayload
+	if err := mapToStruct(request.Payload, &payload); err != nil {
+		response := CreateJobErrorResponse(
+			"get_jobs_response", request.RequestID, fmt.Sprintf("invalid payload: %v", err))
+		return c.sendResponse(response)
+	}
+
+	lookups, err := c.GetJobs(payload.JobIDs)
+
+	var response JobResponse
+	if err != nil {
+		response = CreateJobErrorResponseFromError("get_jobs_response", request.RequestID, err)
+	} else {
+		response = CreateJobResponse("get_jobs_response", request.RequestID, JobBatchResult{Jobs: lookups})
+	}
+
+	return c.sendResponse(response)
+}
+
 // handleGetStats handles get statistics request
 // Обрабатывает запрос получения статистики
 func (c *Component) handleGetStats(ctx context.Context, request JobRequest) error {
@@ -911,6 +1273,11 @@ func (c *Component) handleGetStats(ctx context.Context, request JobRequest) erro
 		}
 	}
 
+	oldestAges, err := c.OldestActivatableAges()
+	if err != nil {
+		c.logger.Error("Failed to get activatable job ages for stats", logger.String("error", err.Error()))
+	}
+
 	stats := JobStatsResult{
 		TotalJobs:     totalJobs,
 		PendingJobs:   pendingJobs,
@@ -918,6 +1285,8 @@ func (c *Component) handleGetStats(ctx context.Context, request JobRequest) erro
 		CompletedJobs: completedJobs,
 		FailedJobs:    failedJobs,
 		CanceledJobs:  canceledJobs,
+
+		OldestActivatableAgeMs: oldestAges,
 	}
 
 	response := CreateJobResponse("get_stats_response", request.RequestID, stats)
@@ -0,0 +1,83 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// listJobsResponse sends list_jobs request to component and returns raw response
+// Отправляет компоненту запрос list_jobs и возвращает сырой ответ
+func listJobsResponse(t *testing.T, c *Component, payload map[string]interface{}) string {
+	t.Helper()
+
+	request, err := json.Marshal(JobRequest{Type: "list_jobs", RequestID: "list", Payload: payload})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	if err := c.ProcessMessage(context.Background(), string(request)); err != nil {
+		t.Fatalf("process list_jobs: %v", err)
+	}
+
+	select {
+	case response := <-c.GetResponseChannel():
+		return response
+	case <-time.After(5 * time.Second):
+		t.Fatal("no list_jobs response")
+		return ""
+	}
+}
+
+func TestListJobsExcludeVariablesShrinksResponse(t *testing.T) {
+	st := openJobStorage(t, t.TempDir())
+	defer st.Stop()
+
+	c := NewComponent(nil, st)
+	if err := c.Start(); err != nil {
+		t.Fatalf("start component: %v", err)
+	}
+	defer c.Stop()
+
+	document := strings.Repeat("x", 10000)
+	for i := 0; i < 5; i++ {
+		if _, err := c.CreateJob("archive", "instance-1", map[string]interface{}{"document": document}); err != nil {
+			t.Fatalf("create job: %v", err)
+		}
+	}
+
+	full := listJobsResponse(t, c, map[string]interface{}{"job_type": "archive"})
+	lean := listJobsResponse(t, c, map[string]interface{}{"job_type": "archive", "exclude_variables": true})
+
+	var response struct {
+		Success bool          `json:"success"`
+		Result  JobListResult `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(lean), &response); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if !response.Success || response.Result.Total != 5 || len(response.Result.Jobs) != 5 {
+		t.Fatalf("response without variables %s, want 5 jobs", lean)
+	}
+	for _, job := range response.Result.Jobs {
+		if job.Variables != nil {
+			t.Errorf("job %s listed with variables", job.Key)
+		}
+	}
+
+	// Variables are not serialized at all, so response does not carry documents
+	// Переменные вообще не сериализуются, поэтому ответ не несет документов
+	if len(full) < 5*len(document) || len(lean)*10 > len(full) {
+		t.Errorf("response is %d bytes with variables and %d bytes without, want at least tenfold reduction",
+			len(full), len(lean))
+	}
+}
@@ -11,6 +11,8 @@ package jobs
 import (
 	"encoding/json"
 	"fmt"
+
+	"atom-engine/src/core/models"
 )
 
 // CreateJobMessage creates JSON message for job creation
@@ -93,6 +95,16 @@ func CreateGetJobMessage(payload GetJobPayload) (string, error) {
 	return marshalRequest(request)
 }
 
+// CreateGetJobsMessage creates JSON message for getting several jobs
+// Создает JSON сообщение для получения нескольких job'ов
+func CreateGetJobsMessage(payload GetJobsPayload) (string, error) {
+	request := JobRequest{
+		Type:    "get_jobs",
+		Payload: structToMap(payload),
+	}
+	return marshalRequest(request)
+}
+
 // CreateGetStatsMessage creates JSON message for getting job statistics
 // Создает JSON сообщение для получения статистики job'ов
 func CreateGetStatsMessage() (string, error) {
@@ -168,3 +180,11 @@ func CreateJobErrorResponse(responseType, requestID, errorMsg string) JobRespons
 		Error:     errorMsg,
 	}
 }
+
+// CreateJobErrorResponseFromError creates error job response with error code derived from err
+// Создает ответ job'а с ошибкой и кодом ошибки, определенным по err
+func CreateJobErrorResponseFromError(responseType, requestID string, err error) JobResponse {
+	response := CreateJobErrorResponse(responseType, requestID, err.Error())
+	response.ErrorCode = models.ErrorCodeOf(err)
+	return response
+}
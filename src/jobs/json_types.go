@@ -8,6 +8,8 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 
 package jobs
 
+import "atom-engine/src/core/models"
+
 // JobRequest base structure for all job requests
 // Базовая структура для всех запросов job'ов
 type JobRequest struct {
@@ -24,16 +26,20 @@ type JobResponse struct {
 	Success   bool        `json:"success"`
 	Result    interface{} `json:"result,omitempty"`
 	Error     string      `json:"error,omitempty"`
+	ErrorCode string      `json:"error_code,omitempty"` // Machine readable reason, e.g. NOT_FOUND
 }
 
 // CreateJobPayload payload for creating a job
 // Payload для создания job'а
 type CreateJobPayload struct {
-	JobType           string                 `json:"job_type"`
-	ProcessInstanceID string                 `json:"process_instance_id"`
-	ElementID         string                 `json:"element_id,omitempty"`
-	CustomHeaders     map[string]string      `json:"custom_headers,omitempty"`
-	Variables         map[string]interface{} `json:"variables,omitempty"`
+	JobType           string             `json:"job_type"`
+	ProcessInstanceID string             `json:"process_instance_id"`
+	ElementID         string             `json:"element_id,omitempty"`
+	CustomHeaders     map[string]string  `json:"custom_headers,omitempty"`
+	Variables         models.VariableMap `json:"variables,omitempty"`
+	Priority          int                `json:"priority,omitempty"`
+	Retries           int                `json:"retries,omitempty"`
+	RetryTimeCycle    string             `json:"retry_time_cycle,omitempty"`
 }
 
 // ActivateJobsPayload payload for activating jobs
@@ -48,8 +54,8 @@ type ActivateJobsPayload struct {
 // CompleteJobPayload payload for completing a job
 // Payload для завершения job'а
 type CompleteJobPayload struct {
-	JobKey    string                 `json:"job_key"`
-	Variables map[string]interface{} `json:"variables,omitempty"`
+	JobKey    string             `json:"job_key"`
+	Variables models.VariableMap `json:"variables,omitempty"`
 }
 
 // FailJobPayload payload for failing a job
@@ -58,16 +64,17 @@ type FailJobPayload struct {
 	JobKey       string `json:"job_key"`
 	Retries      int    `json:"retries"`
 	ErrorMessage string `json:"error_message,omitempty"`
-	RetryBackoff int64  `json:"retry_backoff,omitempty"`
+	RetryBackoff int64  `json:"retry_backoff,omitempty"` // Milliseconds, zero uses retryTimeCycle or retry policy
+	ErrorCode    string `json:"error_code,omitempty"`    // BPMN error code thrown when no retries are left
 }
 
 // ThrowErrorPayload payload for throwing BPMN error for a job
 // Payload для выброса BPMN ошибки для job'а
 type ThrowErrorPayload struct {
-	JobKey       string                 `json:"job_key"`
-	ErrorCode    string                 `json:"error_code"`
-	ErrorMessage string                 `json:"error_message,omitempty"`
-	Variables    map[string]interface{} `json:"variables,omitempty"`
+	JobKey       string             `json:"job_key"`
+	ErrorCode    string             `json:"error_code"`
+	ErrorMessage string             `json:"error_message,omitempty"`
+	Variables    models.VariableMap `json:"variables,omitempty"`
 }
 
 // CancelJobPayload payload for canceling a job
@@ -85,6 +92,9 @@ type ListJobsPayload struct {
 	State             string `json:"state,omitempty"`
 	Limit             int    `json:"limit,omitempty"`
 	Offset            int    `json:"offset,omitempty"`
+	SortBy            string `json:"sort_by,omitempty"`    // created_at, updated_at, state or type
+	SortOrder         string `json:"sort_order,omitempty"` // asc or desc
+	ExcludeVariables  bool   `json:"exclude_variables,omitempty"`
 }
 
 // GetJobPayload payload for getting a specific job
@@ -93,6 +103,12 @@ type GetJobPayload struct {
 	JobID string `json:"job_id"`
 }
 
+// GetJobsPayload payload for getting several jobs by keys
+// Payload для получения нескольких job'ов по ключам
+type GetJobsPayload struct {
+	JobIDs []string `json:"job_ids"` // String IDs or numeric keys
+}
+
 // UpdateJobRetriesPayload payload for updating job retries
 // Payload для обновления retries job'а
 type UpdateJobRetriesPayload struct {
@@ -126,6 +142,20 @@ type JobListResult struct {
 	Offset int       `json:"offset"`
 }
 
+// JobLookup is result of looking up one job of batch, Job is nil when job is not found
+// Результат поиска одного job'а из пакета, Job равен nil, если job не найден
+type JobLookup struct {
+	JobID string   `json:"job_id"`
+	Found bool     `json:"found"`
+	Job   *JobInfo `json:"job,omitempty"`
+}
+
+// JobBatchResult result structure for batch job lookup, lookups follow order of requested keys
+// Структура результата пакетного поиска job'ов, результаты идут в порядке запрошенных ключей
+type JobBatchResult struct {
+	Jobs []JobLookup `json:"jobs"`
+}
+
 // JobStatsResult result structure for job statistics
 // Структура результата для статистики job'ов
 type JobStatsResult struct {
@@ -135,4 +165,8 @@ type JobStatsResult struct {
 	CompletedJobs int `json:"completed_jobs"`
 	FailedJobs    int `json:"failed_jobs"`
 	CanceledJobs  int `json:"canceled_jobs"`
+
+	// Age in milliseconds of oldest activatable job per job type
+	// Возраст в миллисекундах самого старого доступного для активации job'а по типам
+	OldestActivatableAgeMs map[string]int64 `json:"oldest_activatable_age_ms"`
 }
@@ -12,11 +12,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"atom-engine/src/core/logger"
+	"atom-engine/src/core/metrics"
 	"atom-engine/src/core/models"
 	"atom-engine/src/storage"
 )
@@ -33,6 +36,8 @@ type JobCallback struct {
 	ErrorMessage      string                 `json:"error_message,omitempty"`
 	ErrorCode         string                 `json:"error_code,omitempty"` // For BPMN errors
 	CompletedAt       time.Time              `json:"completed_at"`
+	IntentID          string                 `json:"intent_id,omitempty"`   // Write-ahead callback intent
+	CallbackID        string                 `json:"callback_id,omitempty"` // Job key and callback sequence
 }
 
 // JobManager manages job lifecycle and operations
@@ -44,8 +49,19 @@ type JobManager struct {
 	isRunning bool
 	stopChan  chan struct{}
 	component JobsComponentInterface
+
+	// Backoff of failed jobs without worker backoff or retryTimeCycle
+	// Задержка повтора проваленных job'ов без backoff от worker'а и retryTimeCycle
+	retryPolicy RetryPolicy
+
+	// Serialize complete, fail and throw of same job so retried request cannot emit second callback
+	// Сериализуют complete, fail и throw одного job'а, чтобы повторный запрос не отправил второй callback
+	jobLocks [jobLockStripes]sync.Mutex
 }
 
+// jobLockStripes is number of locks jobs are hashed to
+const jobLockStripes = 64
+
 // JobsComponentInterface defines interface for job callback handling
 // Определяет интерфейс для обработки callback'ов job'ов
 type JobsComponentInterface interface {
@@ -54,6 +70,7 @@ type JobsComponentInterface interface {
 		incidentType, elementID, processInstanceID, jobKey, jobType, workerID, errorMessage string,
 		retries int,
 	) error
+	ScheduleJobRetry(job *models.Job) error
 }
 
 // WorkerInfo contains information about job worker
@@ -78,6 +95,11 @@ type ListJobsFilter struct {
 	IncludeVariables  bool
 	Limit             int
 	Offset            int
+
+	// SortBy is created_at, updated_at, state or type, empty keeps storage order
+	SortBy string
+	// SortOrder is asc or desc, empty sorts descending
+	SortOrder string
 }
 
 // NewJobManager creates new job manager
@@ -151,100 +173,118 @@ func (jm *JobManager) ActivateJobs(
 	// Register or update worker info
 	jm.registerWorker(workerID, jobType, maxJobs, timeout)
 
-	// Get available jobs
-	jobs, err := jm.storage.ListJobsByType(ctx, jobType, models.JobStatusPending, maxJobs)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	if maxJobs <= 0 {
+		maxJobs = 1
 	}
 
-	jm.logger.Debug("Found jobs for activation",
-		logger.String("jobType", jobType),
-		logger.String("status", string(models.JobStatusPending)),
-		logger.Int("count", len(jobs)))
-
+	// Jobs are handed out in activation queue order: higher priority first, FIFO by creation time
+	// within same priority. Queue is read again while jobs are taken by concurrent activations
 	var activatedJobs []*models.Job
-	for _, job := range jobs {
-		jm.logger.Debug("Processing job for activation",
-			logger.String("jobID", job.ID),
-			logger.String("currentStatus", string(job.Status)),
-			logger.String("currentWorker", job.WorkerID))
-
-		// Double-check job is actually pending (race condition protection)
-		if job.Status != models.JobStatusPending {
-			jm.logger.Warn("Skipping job - not pending",
-				logger.String("jobID", job.ID),
-				logger.String("status", string(job.Status)))
-			continue
+	for len(activatedJobs) < maxJobs {
+		jobs, err := jm.storage.ListActivatableJobs(ctx, jobType, maxJobs-len(activatedJobs))
+		if err != nil && len(activatedJobs) == 0 {
+			return nil, fmt.Errorf("failed to list jobs: %w", err)
 		}
-
-		// Re-read job from storage to check if still pending (avoid race condition)
-		freshJob, err := jm.storage.GetJob(ctx, job.ID)
 		if err != nil {
-			jm.logger.Error("Failed to re-read job", logger.String("error", err.Error()))
-			continue
+			jm.logger.Error("Failed to list more jobs for activation", logger.String("error", err.Error()))
+			break
 		}
 
-		if freshJob == nil || freshJob.Status != models.JobStatusPending {
-			jm.logger.Warn("Job no longer pending - skipping",
-				logger.String("jobID", job.ID),
-				logger.String("freshStatus", string(freshJob.Status)))
-			continue
+		jm.logger.Debug("Found jobs for activation",
+			logger.String("jobType", jobType),
+			logger.Int("count", len(jobs)))
+
+		activatedBefore := len(activatedJobs)
+		for _, job := range jobs {
+			if activated := jm.activateJob(ctx, job.ID, workerID, timeout); activated != nil {
+				activatedJobs = append(activatedJobs, activated)
+			}
+		}
+		if len(activatedJobs) == activatedBefore {
+			break
 		}
+	}
+
+	jm.logger.Info("Jobs activated", logger.String("worker", workerID), logger.Int("count", len(activatedJobs)))
+	return activatedJobs, nil
+}
+
+// activateJob marks pending job as started by worker, returns nil when job was not activated
+func (jm *JobManager) activateJob(
+	ctx context.Context,
+	jobID, workerID string,
+	timeout time.Duration,
+) *models.Job {
+	unlock := jm.lockJob(jobID)
+	defer unlock()
 
-		// Mark job as running
-		freshJob.MarkAsStarted(workerID)
+	// Re-read job from storage to check if still pending (avoid race condition)
+	freshJob, err := jm.storage.GetJob(ctx, jobID)
+	if err != nil {
+		jm.logger.Error("Failed to re-read job", logger.String("error", err.Error()))
+		return nil
+	}
 
-		// Set lease expiry
-		leaseExpiry := time.Now().Add(timeout)
-		freshJob.ScheduledAt = &leaseExpiry
+	if freshJob == nil || freshJob.Status != models.JobStatusPending {
+		jm.logger.Debug("Job no longer pending - skipping", logger.String("jobID", jobID))
+		return nil
+	}
 
-		jm.logger.Debug("Marking job as started",
-			logger.String("jobID", freshJob.ID),
-			logger.String("newWorker", workerID),
-			logger.String("newStatus", string(freshJob.Status)),
-			logger.String("timeout", timeout.String()),
-			logger.String("scheduledAt", leaseExpiry.Format("15:04:05.000")))
+	// Pending job was last updated when it became activatable
+	// Ожидающий job последний раз обновлялся, когда стал доступен для активации
+	waited := time.Since(freshJob.UpdatedAt)
 
-		if err := jm.storage.SaveJob(ctx, freshJob); err != nil {
-			jm.logger.Error("Failed to save activated job", logger.String("error", err.Error()))
-			continue
-		}
+	// Mark job as running
+	freshJob.MarkAsStarted(workerID)
 
-		// Verify job was saved correctly
-		savedJob, err := jm.storage.GetJob(ctx, freshJob.ID)
-		if err == nil && savedJob != nil {
-			jm.logger.Debug("Job saved verification",
-				logger.String("jobID", savedJob.ID),
-				logger.String("savedStatus", string(savedJob.Status)),
-				logger.String("savedWorker", savedJob.WorkerID))
-		}
+	// Set lease expiry
+	leaseExpiry := time.Now().Add(timeout)
+	freshJob.ScheduledAt = &leaseExpiry
 
-		activatedJobs = append(activatedJobs, freshJob)
+	jm.logger.Debug("Marking job as started",
+		logger.String("jobID", freshJob.ID),
+		logger.String("newWorker", workerID),
+		logger.String("newStatus", string(freshJob.Status)),
+		logger.String("timeout", timeout.String()),
+		logger.String("scheduledAt", leaseExpiry.Format("15:04:05.000")))
 
-		if len(activatedJobs) >= maxJobs {
-			break
-		}
+	if err := jm.storage.SaveJob(ctx, freshJob); err != nil {
+		jm.logger.Error("Failed to save activated job", logger.String("error", err.Error()))
+		return nil
 	}
 
-	jm.logger.Info("Jobs activated", logger.String("worker", workerID), logger.Int("count", len(activatedJobs)))
-	return activatedJobs, nil
+	metrics.Processes.JobActivated(freshJob.ProcessID, "", freshJob.Type, waited)
+
+	// Dereference offloaded variables for worker payload, stored job keeps references
+	freshJob.Variables = storage.ResolveVariableBlobs(jm.storage, freshJob.Variables)
+
+	return freshJob
 }
 
 // CompleteJob completes a job
 func (jm *JobManager) CompleteJob(ctx context.Context, jobID string, variables map[string]interface{}) error {
 	jm.logger.Info("Completing job", logger.String("jobID", jobID))
 
+	unlock := jm.lockJob(jobID)
+	defer unlock()
+
 	job, err := jm.storage.GetJob(ctx, jobID)
 	if err != nil {
 		return fmt.Errorf("failed to get job: %w", err)
 	}
 
 	if job == nil {
-		return fmt.Errorf("job not found: %s", jobID)
+		return fmt.Errorf("job %w: %s", models.ErrNotFound, jobID)
+	}
+
+	// Worker retrying completion after timeout gets success, callback was already sent once
+	if job.Status == models.JobStatusCompleted {
+		jm.logger.Info("Job already completed, duplicate completion ignored", logger.String("jobID", jobID))
+		return nil
 	}
 
 	if job.Status != models.JobStatusRunning {
-		return fmt.Errorf("job is not running: %s", jobID)
+		return fmt.Errorf("%w: job is not running: %s", models.ErrInvalidState, jobID)
 	}
 
 	// Update job variables if provided
@@ -259,14 +299,8 @@ func (jm *JobManager) CompleteJob(ctx context.Context, jobID string, variables m
 
 	job.MarkAsCompleted()
 
-	if err := jm.storage.SaveJob(ctx, job); err != nil {
-		return fmt.Errorf("failed to save completed job: %w", err)
-	}
-
-	// Update worker info
-	jm.updateWorkerActiveJobs(job.WorkerID, -1)
-
-	// Send job completion callback
+	// Persist callback intent before completion is acknowledged to worker
+	// Сохраняем намерение callback до подтверждения завершения worker'у
 	callback := JobCallback{
 		JobID:             job.ID,
 		ElementID:         job.ElementID,
@@ -276,14 +310,25 @@ func (jm *JobManager) CompleteJob(ctx context.Context, jobID string, variables m
 		Variables:         variables,
 		CompletedAt:       time.Now(),
 	}
+	callbackJSON, err := jm.persistCallbackIntent(job, &callback)
+	if err != nil {
+		return err
+	}
 
+	if err := jm.storage.SaveJob(ctx, job); err != nil {
+		jm.discardCallbackIntent(callback.IntentID)
+		return fmt.Errorf("failed to save completed job: %w", err)
+	}
+
+	// Update worker info
+	jm.updateWorkerActiveJobs(job.WorkerID, -1)
+
+	// Send job completion callback
 	if jm.component != nil {
-		if callbackJSON, err := json.Marshal(callback); err == nil {
-			jm.component.SendJobCallback(string(callbackJSON))
-			jm.logger.Info("Job completion callback sent",
-				logger.String("jobID", job.ID),
-				logger.String("elementID", job.ElementID))
-		}
+		jm.component.SendJobCallback(callbackJSON)
+		jm.logger.Info("Job completion callback sent",
+			logger.String("jobID", job.ID),
+			logger.String("elementID", job.ElementID))
 	}
 
 	jm.logger.Info("Job completed successfully")
@@ -303,7 +348,7 @@ func (jm *JobManager) CompleteJobWithBPMNError(ctx context.Context, jobID, error
 	}
 
 	if job == nil {
-		return fmt.Errorf("job not found: %s", jobID)
+		return fmt.Errorf("job %w: %s", models.ErrNotFound, jobID)
 	}
 
 	// Job should be RUNNING when BPMN error is thrown
@@ -329,27 +374,41 @@ func (jm *JobManager) CompleteJobWithBPMNError(ctx context.Context, jobID, error
 	return nil
 }
 
-// FailJob fails a job
+// FailJob fails a job.
+// Failure without retries left and with error code is thrown as BPMN error instead of incident
 func (jm *JobManager) FailJob(
 	ctx context.Context,
 	jobID string,
 	retries int,
 	errorMessage string,
+	errorCode string,
 	retryBackoff time.Duration,
 ) error {
 	jm.logger.Info("Failing job",
 		logger.String("jobID", jobID),
 		logger.Int("retries", retries),
 		logger.String("error", errorMessage),
+		logger.String("errorCode", errorCode),
 	)
 
+	unlock := jm.lockJob(jobID)
+	defer unlock()
+
 	job, err := jm.storage.GetJob(ctx, jobID)
 	if err != nil {
 		return fmt.Errorf("failed to get job: %w", err)
 	}
 
 	if job == nil {
-		return fmt.Errorf("job not found: %s", jobID)
+		return fmt.Errorf("job %w: %s", models.ErrNotFound, jobID)
+	}
+
+	// Final failure with error code goes to error boundary like thrown error, incident only if none matches
+	if errorCode != "" && retries <= 0 {
+		job.Retries = retries
+		job.ErrorMessage = errorMessage
+		metrics.Processes.JobFailed(job.ProcessID, "", job.Type)
+		return jm.throwError(ctx, job, errorCode, errorMessage, nil)
 	}
 
 	// Update retries and mark as failed
@@ -362,24 +421,28 @@ func (jm *JobManager) FailJob(
 
 	// Check if can retry BEFORE changing status to DEFERRED
 	canRetry := job.CanRetry()
-
-	// Schedule retry if retries available
-	if canRetry && retryBackoff > 0 {
-		retryTime := time.Now().Add(retryBackoff)
-		job.Status = models.JobStatusDeferred
-		job.ScheduledAt = &retryTime
-	}
-
-	if err := jm.storage.SaveJob(ctx, job); err != nil {
-		return fmt.Errorf("failed to save failed job: %w", err)
+	metrics.Processes.JobFailed(job.ProcessID, "", job.Type)
+
+	// Job with retries left is deferred until its backoff elapses, zero backoff makes it activatable at once
+	// Job с оставшимися попытками откладывается до истечения задержки, нулевая задержка сразу делает его доступным
+	if canRetry {
+		if delay := retryDelay(job, retryBackoff, jm.retryPolicy); delay > 0 {
+			retryTime := now.Add(delay)
+			job.Status = models.JobStatusDeferred
+			job.ScheduledAt = &retryTime
+		} else {
+			job.Status = models.JobStatusPending
+			job.ScheduledAt = nil
+			job.CompletedAt = nil
+		}
 	}
 
-	// Update worker info
-	jm.updateWorkerActiveJobs(job.WorkerID, -1)
-
-	// Send job failure callback only if cannot retry anymore
+	// Failure callback is sent only if job cannot retry anymore, persist its intent first
+	// Callback провала отправляется только если retry невозможен, сначала сохраняем намерение
+	var callback JobCallback
+	var callbackJSON string
 	if !canRetry {
-		callback := JobCallback{
+		callback = JobCallback{
 			JobID:             job.ID,
 			ElementID:         job.ElementID,
 			TokenID:           job.TokenID,
@@ -388,14 +451,31 @@ func (jm *JobManager) FailJob(
 			ErrorMessage:      errorMessage,
 			CompletedAt:       time.Now(),
 		}
+		callbackJSON, err = jm.persistCallbackIntent(job, &callback)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := jm.storage.SaveJob(ctx, job); err != nil {
+		jm.discardCallbackIntent(callback.IntentID)
+		return fmt.Errorf("failed to save failed job: %w", err)
+	}
+
+	// Update worker info
+	jm.updateWorkerActiveJobs(job.WorkerID, -1)
 
+	if job.Status == models.JobStatusDeferred {
+		jm.scheduleRetry(ctx, job)
+	}
+
+	// Send job failure callback only if cannot retry anymore
+	if !canRetry {
 		if jm.component != nil {
-			if callbackJSON, err := json.Marshal(callback); err == nil {
-				jm.component.SendJobCallback(string(callbackJSON))
-				jm.logger.Info("Job failure callback sent",
-					logger.String("jobID", job.ID),
-					logger.String("elementID", job.ElementID))
-			}
+			jm.component.SendJobCallback(callbackJSON)
+			jm.logger.Info("Job failure callback sent",
+				logger.String("jobID", job.ID),
+				logger.String("elementID", job.ElementID))
 
 			// Incident creation is handled by process component through job callback
 			// Создание инцидента обрабатывается process компонентом через job callback
@@ -405,7 +485,109 @@ func (jm *JobManager) FailJob(
 		}
 	}
 
-	jm.logger.Info("Job failed", logger.String("jobID", jobID), logger.Bool("canRetry", job.CanRetry()))
+	jm.logger.Info("Job failed",
+		logger.String("jobID", jobID),
+		logger.Bool("canRetry", canRetry),
+		logger.String("status", string(job.Status)))
+	return nil
+}
+
+// scheduleRetry registers retry timer of deferred job in timewheel
+// Job is made activatable at once when timer cannot be scheduled, so it is never stuck deferred
+// Регистрирует таймер повтора отложенного job'а в timewheel
+// Если таймер запланировать не удалось, job сразу становится доступным, чтобы не зависнуть отложенным
+func (jm *JobManager) scheduleRetry(ctx context.Context, job *models.Job) {
+	err := fmt.Errorf("jobs component not set")
+	if jm.component != nil {
+		err = jm.component.ScheduleJobRetry(job)
+	}
+	if err == nil {
+		jm.logger.Info("Job retry scheduled",
+			logger.String("jobID", job.ID),
+			logger.Int("retries", job.Retries),
+			logger.String("retryAt", job.ScheduledAt.Format(time.RFC3339Nano)))
+		return
+	}
+
+	jm.logger.Error("Failed to schedule job retry, job is activatable immediately",
+		logger.String("jobID", job.ID),
+		logger.String("error", err.Error()))
+	if err := jm.ReactivateJob(ctx, job.ID); err != nil {
+		jm.logger.Error("Failed to reactivate job", logger.String("jobID", job.ID), logger.String("error", err.Error()))
+	}
+}
+
+// ReactivateJob makes deferred job activatable again when its retry time comes
+// Jobs that left DEFERRED state meanwhile (canceled, retries updated) are not touched
+// Делает отложенный job снова доступным для активации при наступлении времени повтора
+// Job'ы, покинувшие состояние DEFERRED за это время (отменены, обновлены попытки), не затрагиваются
+func (jm *JobManager) ReactivateJob(ctx context.Context, jobID string) error {
+	job, err := jm.storage.GetJob(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+	if job == nil {
+		return fmt.Errorf("job %w: %s", models.ErrNotFound, jobID)
+	}
+
+	if job.Status != models.JobStatusDeferred {
+		jm.logger.Debug("Job is not deferred, retry skipped",
+			logger.String("jobID", jobID),
+			logger.String("status", string(job.Status)))
+		return nil
+	}
+
+	job.Status = models.JobStatusPending
+	job.WorkerID = ""
+	job.ScheduledAt = nil
+	job.CompletedAt = nil
+	job.UpdatedAt = time.Now()
+
+	if err := jm.storage.SaveJob(ctx, job); err != nil {
+		return fmt.Errorf("failed to save reactivated job: %w", err)
+	}
+
+	jm.logger.Info("Deferred job is activatable again",
+		logger.String("jobID", jobID),
+		logger.String("type", job.Type),
+		logger.Int("retries", job.Retries))
+	return nil
+}
+
+// UnlockJob releases lease of running job so any worker can activate it again
+// Retries are not changed, same as when lease expires
+// Снимает аренду выполняющегося job'а, чтобы любой worker мог снова его активировать
+// Число повторов не меняется, как и при истечении аренды
+func (jm *JobManager) UnlockJob(ctx context.Context, jobID string) error {
+	job, err := jm.storage.GetJob(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+	if job == nil {
+		return fmt.Errorf("job %w: %s", models.ErrNotFound, jobID)
+	}
+
+	if job.Status != models.JobStatusRunning {
+		return fmt.Errorf("%w: job is not running: %s", models.ErrInvalidState, jobID)
+	}
+
+	workerID := job.WorkerID
+	job.Status = models.JobStatusPending
+	job.WorkerID = ""
+	job.ScheduledAt = nil
+	job.UpdatedAt = time.Now()
+
+	if err := jm.storage.SaveJob(ctx, job); err != nil {
+		return fmt.Errorf("failed to save unlocked job: %w", err)
+	}
+
+	if workerID != "" {
+		jm.updateWorkerActiveJobs(workerID, -1)
+	}
+
+	jm.logger.Info("Job unlocked",
+		logger.String("jobID", jobID),
+		logger.String("worker", workerID))
 	return nil
 }
 
@@ -417,15 +599,28 @@ func (jm *JobManager) ThrowError(
 ) error {
 	jm.logger.Info("Throwing error for job", logger.String("jobID", jobID), logger.String("errorCode", errorCode))
 
+	unlock := jm.lockJob(jobID)
+	defer unlock()
+
 	job, err := jm.storage.GetJob(ctx, jobID)
 	if err != nil {
 		return fmt.Errorf("failed to get job: %w", err)
 	}
 
 	if job == nil {
-		return fmt.Errorf("job not found: %s", jobID)
+		return fmt.Errorf("job %w: %s", models.ErrNotFound, jobID)
 	}
 
+	return jm.throwError(ctx, job, errorCode, errorMessage, variables)
+}
+
+// throwError sends BPMN error callback for loaded job
+func (jm *JobManager) throwError(
+	ctx context.Context,
+	job *models.Job,
+	errorCode, errorMessage string,
+	variables map[string]interface{},
+) error {
 	// Initialize job variables if needed
 	if job.Variables == nil {
 		job.Variables = make(map[string]interface{})
@@ -450,7 +645,24 @@ func (jm *JobManager) ThrowError(
 	// Do not mark job as failed yet - let process engine decide after checking boundary events
 	// Не помечаем job как failed сразу - пусть process engine решает после проверки boundary events
 
+	callback := JobCallback{
+		JobID:             job.ID,
+		ElementID:         job.ElementID,
+		TokenID:           job.TokenID,
+		ProcessInstanceID: job.ProcessInstanceID,
+		Status:            "ERROR_THROWN", // Different from "FAILED"
+		ErrorMessage:      errorMessage,
+		ErrorCode:         errorCode,
+		Variables:         job.Variables,
+		CompletedAt:       time.Now(),
+	}
+	callbackJSON, err := jm.persistCallbackIntent(job, &callback)
+	if err != nil {
+		return err
+	}
+
 	if err := jm.storage.SaveJob(ctx, job); err != nil {
+		jm.discardCallbackIntent(callback.IntentID)
 		return fmt.Errorf("failed to save job with error metadata: %w", err)
 	}
 
@@ -459,25 +671,12 @@ func (jm *JobManager) ThrowError(
 
 	// Send error callback to process component
 	// Отправляем error callback в process компонент
-	callback := JobCallback{
-		JobID:        job.ID,
-		ElementID:    job.ElementID,
-		TokenID:      job.TokenID,
-		Status:       "ERROR_THROWN", // Different from "FAILED"
-		ErrorMessage: errorMessage,
-		ErrorCode:    errorCode,
-		Variables:    job.Variables,
-		CompletedAt:  time.Now(),
-	}
-
 	if jm.component != nil {
-		if callbackJSON, err := json.Marshal(callback); err == nil {
-			jm.component.SendJobCallback(string(callbackJSON))
-			jm.logger.Info("Job error callback sent",
-				logger.String("jobID", job.ID),
-				logger.String("elementID", job.ElementID),
-				logger.String("errorCode", errorCode))
-		}
+		jm.component.SendJobCallback(callbackJSON)
+		jm.logger.Info("Job error callback sent",
+			logger.String("jobID", job.ID),
+			logger.String("elementID", job.ElementID),
+			logger.String("errorCode", errorCode))
 
 		// Do not create incident here - let process engine create incident only if no boundary event found
 		// Не создаем инцидент здесь - пусть process engine создает инцидент только если boundary event не найден
@@ -497,7 +696,7 @@ func (jm *JobManager) UpdateJobRetries(ctx context.Context, jobID string, retrie
 	}
 
 	if job == nil {
-		return fmt.Errorf("job not found: %s", jobID)
+		return fmt.Errorf("job %w: %s", models.ErrNotFound, jobID)
 	}
 
 	job.Retries = retries
@@ -528,11 +727,11 @@ func (jm *JobManager) CancelJob(ctx context.Context, jobID string) error {
 	}
 
 	if job == nil {
-		return fmt.Errorf("job not found: %s", jobID)
+		return fmt.Errorf("job %w: %s", models.ErrNotFound, jobID)
 	}
 
 	if job.IsCompleted() {
-		return fmt.Errorf("job is already completed: %s", jobID)
+		return fmt.Errorf("%w: job is already completed: %s", models.ErrInvalidState, jobID)
 	}
 
 	job.Status = models.JobStatusCanceled
@@ -553,6 +752,23 @@ func (jm *JobManager) CancelJob(ctx context.Context, jobID string) error {
 	return nil
 }
 
+// OldestActivatableJobs returns creation time of oldest activatable job per job type
+// Types without activatable jobs are absent, deferred jobs waiting for retry are not activatable
+func (jm *JobManager) OldestActivatableJobs(ctx context.Context) (map[string]time.Time, error) {
+	jobs, err := jm.storage.ListJobsByType(ctx, "", models.JobStatusPending, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list activatable jobs: %w", err)
+	}
+
+	oldest := make(map[string]time.Time)
+	for _, job := range jobs {
+		if createdAt, ok := oldest[job.Type]; !ok || job.CreatedAt.Before(createdAt) {
+			oldest[job.Type] = job.CreatedAt
+		}
+	}
+	return oldest, nil
+}
+
 // ListJobs lists jobs with filtering
 func (jm *JobManager) ListJobs(ctx context.Context, filter *ListJobsFilter) ([]*models.Job, int, error) {
 	jm.logger.Debug("Listing jobs with filter", logger.String("worker", filter.Worker))
@@ -573,7 +789,18 @@ func (jm *JobManager) ListJobs(ctx context.Context, filter *ListJobsFilter) ([]*
 		}
 	}
 
-	jobs, err := jm.storage.ListJobsByType(ctx, filter.Type, status, filter.Limit)
+	less, err := jobLess(filter.SortBy)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Sorted listing needs all matching jobs, unsorted one only jobs up to end of page
+	scanLimit := 0
+	if less == nil && filter.Limit > 0 {
+		scanLimit = filter.Offset + filter.Limit
+	}
+
+	jobs, err := jm.storage.ListJobsByType(ctx, filter.Type, status, scanLimit)
 	if err != nil {
 		return nil, 0, fmt.Errorf("failed to list jobs: %w", err)
 	}
@@ -612,12 +839,22 @@ func (jm *JobManager) ListJobs(ctx context.Context, filter *ListJobsFilter) ([]*
 
 		filteredJobs = append(filteredJobs, job)
 
-		// Apply limit if specified (0 means no limit)
-		if filter.Limit > 0 && len(filteredJobs) >= filter.Limit {
+		// Stop at end of page unless all jobs are needed for sorting (0 limit means no limit)
+		if scanLimit > 0 && len(filteredJobs) >= scanLimit {
 			break
 		}
 	}
 
+	if less != nil {
+		descending := !strings.EqualFold(filter.SortOrder, "asc")
+		sort.SliceStable(filteredJobs, func(i, j int) bool {
+			if descending {
+				return less(filteredJobs[j], filteredJobs[i])
+			}
+			return less(filteredJobs[i], filteredJobs[j])
+		})
+	}
+
 	// Apply offset and limit for pagination
 	start := filter.Offset
 	if start > len(filteredJobs) {
@@ -642,6 +879,32 @@ func (jm *JobManager) ListJobs(ctx context.Context, filter *ListJobsFilter) ([]*
 	return result, total, nil
 }
 
+// jobLess returns ascending order of sort field with ties ordered by job ID, nil for empty field
+func jobLess(sortBy string) (func(a, b *models.Job) bool, error) {
+	var compare func(a, b *models.Job) int
+	switch sortBy {
+	case "":
+		return nil, nil
+	case "created_at":
+		compare = func(a, b *models.Job) int { return a.CreatedAt.Compare(b.CreatedAt) }
+	case "updated_at":
+		compare = func(a, b *models.Job) int { return a.UpdatedAt.Compare(b.UpdatedAt) }
+	case "state":
+		compare = func(a, b *models.Job) int { return strings.Compare(string(a.Status), string(b.Status)) }
+	case "type":
+		compare = func(a, b *models.Job) int { return strings.Compare(a.Type, b.Type) }
+	default:
+		return nil, fmt.Errorf("unsupported job sort field: %s", sortBy)
+	}
+
+	return func(a, b *models.Job) bool {
+		if c := compare(a, b); c != 0 {
+			return c < 0
+		}
+		return a.ID < b.ID
+	}, nil
+}
+
 // GetJob gets job by ID
 func (jm *JobManager) GetJob(ctx context.Context, jobID string) (*models.Job, error) {
 	return jm.storage.GetJob(ctx, jobID)
@@ -657,7 +920,7 @@ func (jm *JobManager) UpdateJobTimeout(ctx context.Context, jobID string, timeou
 	}
 
 	if job == nil {
-		return fmt.Errorf("job not found: %s", jobID)
+		return fmt.Errorf("job %w: %s", models.ErrNotFound, jobID)
 	}
 
 	if job.Status == models.JobStatusRunning && job.ScheduledAt != nil {
@@ -691,7 +954,7 @@ func (jm *JobManager) ThrowJobError(
 	}
 
 	if job == nil {
-		return fmt.Errorf("job not found: %s", jobID)
+		return fmt.Errorf("job %w: %s", models.ErrNotFound, jobID)
 	}
 
 	// Update job status to ERROR_THROWN
@@ -738,6 +1001,57 @@ func (jm *JobManager) ThrowJobError(
 	return nil
 }
 
+// persistCallbackIntent records callback in write-ahead log and returns callback JSON
+// Intent is marked done by core once token has moved on, pending intents are replayed on startup.
+// Callback gets next ID from job sequence, caller saves job afterwards
+// Записывает callback в write-ahead лог и возвращает JSON callback
+func (jm *JobManager) persistCallbackIntent(job *models.Job, callback *JobCallback) (string, error) {
+	callback.CallbackID = job.NextCallbackID()
+
+	intent := models.NewCallbackIntent(
+		models.CallbackIntentJob,
+		callback.JobID,
+		callback.TokenID,
+		fmt.Sprintf("job:%s", callback.JobID),
+	)
+	intent.ProcessInstanceID = callback.ProcessInstanceID
+	callback.IntentID = intent.ID
+
+	callbackJSON, err := json.Marshal(callback)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal job callback: %w", err)
+	}
+	intent.Payload = callbackJSON
+
+	if err := jm.storage.SaveCallbackIntent(intent); err != nil {
+		return "", fmt.Errorf("failed to persist job callback intent: %w", err)
+	}
+
+	return string(callbackJSON), nil
+}
+
+// lockJob locks stripe of job and returns unlock function
+func (jm *JobManager) lockJob(jobID string) func() {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(jobID))
+	mu := &jm.jobLocks[h.Sum32()%jobLockStripes]
+	mu.Lock()
+	return mu.Unlock
+}
+
+// discardCallbackIntent removes intent of callback that will not be sent
+// Удаляет намерение callback, который не будет отправлен
+func (jm *JobManager) discardCallbackIntent(intentID string) {
+	if intentID == "" {
+		return
+	}
+	if err := jm.storage.DeleteCallbackIntent(intentID); err != nil {
+		jm.logger.Warn("Failed to discard job callback intent",
+			logger.String("intentID", intentID),
+			logger.String("error", err.Error()))
+	}
+}
+
 // Helper method to marshal variables safely
 func (jm *JobManager) marshalVariables(variables map[string]interface{}) string {
 	if variables == nil {
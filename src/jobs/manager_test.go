@@ -0,0 +1,159 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+	"atom-engine/src/storage"
+)
+
+// openJobStorage opens started storage in dir, caller stops it
+// Открывает запущенный storage в директории, останавливает вызывающий
+func openJobStorage(t *testing.T, dir string) storage.Storage {
+	t.Helper()
+
+	st := storage.NewStorage(&storage.Config{Path: dir})
+	if err := st.Init(); err != nil {
+		t.Fatalf("init storage: %v", err)
+	}
+	if err := st.Start(); err != nil {
+		t.Fatalf("start storage: %v", err)
+	}
+	return st
+}
+
+// queuedJobs creates pending jobs of type with priorities cycling through 0, 10 and -5
+// Creation times are distinct and increasing, so FIFO order within priority is defined
+// Создает ожидающие job'ы типа с приоритетами по кругу 0, 10 и -5
+// Времена создания различны и возрастают, поэтому порядок FIFO внутри приоритета определен
+func queuedJobs(jobType string, count int) []*models.Job {
+	priorities := []int{0, 10, -5}
+	base := time.Now().Add(-time.Hour)
+
+	jobs := make([]*models.Job, count)
+	for i := range jobs {
+		job := models.NewJob(jobType, "instance-1", fmt.Sprintf("task-%d", i))
+		job.Priority = priorities[i%len(priorities)]
+		job.CreatedAt = base.Add(time.Duration(i) * time.Millisecond)
+		job.UpdatedAt = job.CreatedAt
+		jobs[i] = job
+	}
+	return jobs
+}
+
+// activationOrder returns job IDs in expected activation order: priority descending, then oldest first
+// Возвращает ID job'ов в ожидаемом порядке активации: по убыванию приоритета, затем от старых к новым
+func activationOrder(jobs []*models.Job) []string {
+	sorted := append([]*models.Job(nil), jobs...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Priority != sorted[j].Priority {
+			return sorted[i].Priority > sorted[j].Priority
+		}
+		return sorted[i].CreatedAt.Before(sorted[j].CreatedAt)
+	})
+
+	ids := make([]string, len(sorted))
+	for i, job := range sorted {
+		ids[i] = job.ID
+	}
+	return ids
+}
+
+func TestActivateJobsQueueOrderAcrossCallsAndRestart(t *testing.T) {
+	const (
+		jobType   = "queue-order"
+		jobCount  = 1000
+		batchSize = 37
+	)
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	st := openJobStorage(t, dir)
+
+	jobs := queuedJobs(jobType, jobCount)
+	// Saved in shuffled order, so activation order comes from index and not from insertion
+	// Сохраняются в перемешанном порядке, чтобы порядок активации шел из индекса, а не из вставки
+	for _, i := range shuffledIndexes(jobCount) {
+		if err := st.SaveJob(ctx, jobs[i]); err != nil {
+			t.Fatalf("save job: %v", err)
+		}
+	}
+	// Job of type sharing prefix must not leak into queue
+	// Job типа с общим префиксом не должен попасть в очередь
+	if err := st.SaveJob(ctx, models.NewJob(jobType+":other", "instance-2", "task")); err != nil {
+		t.Fatalf("save job: %v", err)
+	}
+
+	activate := func(st storage.Storage, until int, activated []string) []string {
+		manager := NewJobManager(st, logger.NewComponentLogger("job-manager"), nil)
+		for len(activated) < until {
+			batch, err := manager.ActivateJobs(ctx, jobType, "worker-1", batchSize, time.Minute)
+			if err != nil {
+				t.Fatalf("activate jobs: %v", err)
+			}
+			if len(batch) == 0 {
+				t.Fatalf("queue empty after %d of %d jobs", len(activated), jobCount)
+			}
+			for _, job := range batch {
+				activated = append(activated, job.ID)
+			}
+		}
+		return activated
+	}
+
+	activated := activate(st, jobCount/2, nil)
+
+	// Restart storage in same directory, queue index survives restart
+	// Перезапуск storage в той же директории, индекс очереди переживает перезапуск
+	if err := st.Stop(); err != nil {
+		t.Fatalf("stop storage: %v", err)
+	}
+	st = openJobStorage(t, dir)
+	defer st.Stop()
+
+	activated = activate(st, jobCount, activated)
+
+	expected := activationOrder(jobs)
+	if len(activated) != len(expected) {
+		t.Fatalf("activated %d jobs, want %d", len(activated), len(expected))
+	}
+	for i := range expected {
+		if activated[i] != expected[i] {
+			t.Fatalf("activation %d: got job %s, want %s", i, activated[i], expected[i])
+		}
+	}
+
+	manager := NewJobManager(st, logger.NewComponentLogger("job-manager"), nil)
+	rest, err := manager.ActivateJobs(ctx, jobType, "worker-1", batchSize, time.Minute)
+	if err != nil {
+		t.Fatalf("activate jobs: %v", err)
+	}
+	if len(rest) != 0 {
+		t.Fatalf("activated %d jobs after queue drained", len(rest))
+	}
+}
+
+// shuffledIndexes returns deterministic permutation of 0..n-1
+// Возвращает детерминированную перестановку 0..n-1
+func shuffledIndexes(n int) []int {
+	indexes := make([]int, n)
+	for i := range indexes {
+		// 7919 is prime and does not divide n, so i*7919 mod n is permutation
+		// 7919 простое и не делит n, поэтому i*7919 mod n является перестановкой
+		indexes[i] = i * 7919 % n
+	}
+	return indexes
+}
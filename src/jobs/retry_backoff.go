@@ -0,0 +1,110 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package jobs
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"atom-engine/src/core/config"
+	"atom-engine/src/core/models"
+	"atom-engine/src/timewheel"
+)
+
+// Retry backoff strategies
+// Стратегии задержки повтора
+const (
+	RetryStrategyFixed       = "fixed"
+	RetryStrategyExponential = "exponential"
+)
+
+// RetryPolicy computes delay before failed job is activatable again
+// Вычисляет задержку, после которой проваленный job снова доступен для активации
+type RetryPolicy struct {
+	Strategy   string
+	Initial    time.Duration
+	Max        time.Duration
+	Multiplier float64
+}
+
+// NewRetryPolicy creates retry policy from configuration
+// Создает политику повторов из конфигурации
+func NewRetryPolicy(cfg config.JobRetryBackoffConfig) RetryPolicy {
+	return RetryPolicy{
+		Strategy:   strings.ToLower(strings.TrimSpace(cfg.Strategy)),
+		Initial:    time.Duration(cfg.InitialMs) * time.Millisecond,
+		Max:        time.Duration(cfg.MaxMs) * time.Millisecond,
+		Multiplier: cfg.Multiplier,
+	}
+}
+
+// Delay returns backoff before retry attempt, attempts are numbered from 1
+// Unknown strategy is treated as fixed
+// Возвращает задержку перед попыткой повтора, попытки нумеруются с 1
+// Неизвестная стратегия считается fixed
+func (p RetryPolicy) Delay(attempt int) time.Duration {
+	if p.Strategy != RetryStrategyExponential || attempt <= 1 || p.Multiplier <= 1 {
+		return p.Initial
+	}
+
+	delay := float64(p.Initial) * math.Pow(p.Multiplier, float64(attempt-1))
+	if p.Max > 0 && delay > float64(p.Max) {
+		return p.Max
+	}
+	return time.Duration(delay)
+}
+
+// ParseRetryTimeCycle parses retryTimeCycle like "R3/PT10S" of service task
+// Repeat count is zero for unbounded cycle "R/PT10S"
+// Парсит retryTimeCycle вида "R3/PT10S" сервисной задачи
+// Количество повторов равно нулю для неограниченного цикла "R/PT10S"
+func ParseRetryTimeCycle(cycle string) (int, time.Duration, error) {
+	repeatCount, interval, err := timewheel.NewISO8601DurationParser().ParseRepeatingInterval(strings.TrimSpace(cycle))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid retry time cycle %q: %w", cycle, err)
+	}
+	if interval <= 0 {
+		return 0, 0, fmt.Errorf("invalid retry time cycle %q: interval must be positive", cycle)
+	}
+	if repeatCount < 0 {
+		repeatCount = 0
+	}
+	return repeatCount, interval, nil
+}
+
+// retryDelay selects backoff of failed job
+// Backoff from worker wins, then retryTimeCycle of service task, then configured policy
+// Выбирает задержку повтора проваленного job'а
+// Приоритет: backoff от worker'а, затем retryTimeCycle сервисной задачи, затем политика из конфигурации
+func retryDelay(job *models.Job, explicit time.Duration, policy RetryPolicy) time.Duration {
+	if explicit > 0 {
+		return explicit
+	}
+
+	if job.RetryTimeCycle != "" {
+		if _, interval, err := ParseRetryTimeCycle(job.RetryTimeCycle); err == nil {
+			return interval
+		}
+	}
+
+	attempt := job.MaxRetries - job.Retries
+	if attempt < 1 {
+		attempt = 1
+	}
+	return policy.Delay(attempt)
+}
+
+// isoDuration formats duration as ISO 8601 seconds for timewheel, e.g. "PT2.5S"
+// Форматирует длительность как ISO 8601 секунды для timewheel, например "PT2.5S"
+func isoDuration(d time.Duration) string {
+	return "PT" + strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "S"
+}
@@ -0,0 +1,181 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"atom-engine/src/core/config"
+	"atom-engine/src/core/models"
+)
+
+// delaySchedule returns delays of attempts 1..attempts
+// Возвращает задержки попыток 1..attempts
+func delaySchedule(policy RetryPolicy, attempts int) []time.Duration {
+	delays := make([]time.Duration, attempts)
+	for i := range delays {
+		delays[i] = policy.Delay(i + 1)
+	}
+	return delays
+}
+
+func TestRetryPolicyDelaySchedule(t *testing.T) {
+	tests := []struct {
+		name   string
+		config config.JobRetryBackoffConfig
+		want   []time.Duration
+	}{
+		{
+			name:   "fixed",
+			config: config.JobRetryBackoffConfig{Strategy: "fixed", InitialMs: 500, MaxMs: 1000, Multiplier: 2},
+			want: []time.Duration{
+				500 * time.Millisecond, 500 * time.Millisecond, 500 * time.Millisecond, 500 * time.Millisecond,
+			},
+		},
+		{
+			name:   "exponential",
+			config: config.JobRetryBackoffConfig{Strategy: "exponential", InitialMs: 1000, MaxMs: 60000, Multiplier: 2},
+			want:   []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second},
+		},
+		{
+			name:   "exponential capped by max",
+			config: config.JobRetryBackoffConfig{Strategy: "exponential", InitialMs: 1000, MaxMs: 5000, Multiplier: 3},
+			want:   []time.Duration{time.Second, 3 * time.Second, 5 * time.Second, 5 * time.Second},
+		},
+		{
+			name:   "exponential without max",
+			config: config.JobRetryBackoffConfig{Strategy: "exponential", InitialMs: 100, Multiplier: 10},
+			want:   []time.Duration{100 * time.Millisecond, time.Second, 10 * time.Second, 100 * time.Second},
+		},
+		{
+			name: "fractional multiplier",
+			config: config.JobRetryBackoffConfig{
+				Strategy: "Exponential ", InitialMs: 1000, MaxMs: 60000, Multiplier: 1.5,
+			},
+			want: []time.Duration{time.Second, 1500 * time.Millisecond, 2250 * time.Millisecond},
+		},
+		{
+			name:   "multiplier not above one stays fixed",
+			config: config.JobRetryBackoffConfig{Strategy: "exponential", InitialMs: 200, MaxMs: 1000, Multiplier: 1},
+			want:   []time.Duration{200 * time.Millisecond, 200 * time.Millisecond, 200 * time.Millisecond},
+		},
+		{
+			name:   "unknown strategy is fixed",
+			config: config.JobRetryBackoffConfig{Strategy: "linear", InitialMs: 300, Multiplier: 2},
+			want:   []time.Duration{300 * time.Millisecond, 300 * time.Millisecond, 300 * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := delaySchedule(NewRetryPolicy(tt.config), len(tt.want))
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Fatalf("schedule = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelayNeverExceedsMax(t *testing.T) {
+	policy := RetryPolicy{Strategy: RetryStrategyExponential, Initial: time.Second,
+		Max: 30 * time.Second, Multiplier: 2}
+
+	// Large attempt numbers overflow float growth into +Inf, cap must still hold
+	// Большие номера попыток доводят рост до +Inf, ограничение все равно должно действовать
+	for _, attempt := range []int{6, 10, 100, 5000} {
+		if delay := policy.Delay(attempt); delay != policy.Max {
+			t.Errorf("attempt %d: delay %v, want max %v", attempt, delay, policy.Max)
+		}
+	}
+	if delay := policy.Delay(0); delay != policy.Initial {
+		t.Errorf("attempt 0: delay %v, want initial %v", delay, policy.Initial)
+	}
+}
+
+func TestParseRetryTimeCycle(t *testing.T) {
+	tests := []struct {
+		cycle    string
+		repeats  int
+		interval time.Duration
+		wantErr  bool
+	}{
+		{cycle: "R3/PT10S", repeats: 3, interval: 10 * time.Second},
+		{cycle: " R5/PT1M ", repeats: 5, interval: time.Minute},
+		{cycle: "R/PT30S", repeats: 0, interval: 30 * time.Second},
+		{cycle: "R2/PT0S", wantErr: true},
+		{cycle: "PT10S", wantErr: true},
+		{cycle: "R3/soon", wantErr: true},
+		{cycle: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		repeats, interval, err := ParseRetryTimeCycle(tt.cycle)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected error, got %d x %v", tt.cycle, repeats, interval)
+			}
+			continue
+		}
+		if err != nil || repeats != tt.repeats || interval != tt.interval {
+			t.Errorf("%q: got %d x %v, err %v, want %d x %v", tt.cycle, repeats, interval, err,
+				tt.repeats, tt.interval)
+		}
+	}
+}
+
+func TestRetryDelayPrecedence(t *testing.T) {
+	policy := RetryPolicy{Strategy: RetryStrategyExponential, Initial: time.Second,
+		Max: time.Minute, Multiplier: 2}
+
+	failedJob := func(retriesLeft int, cycle string) *models.Job {
+		job := models.NewJob("work", "instance-1", "task")
+		job.MaxRetries = 3
+		job.Retries = retriesLeft
+		job.RetryTimeCycle = cycle
+		return job
+	}
+
+	tests := []struct {
+		name     string
+		job      *models.Job
+		explicit time.Duration
+		want     time.Duration
+	}{
+		{"worker backoff wins over cycle", failedJob(2, "R3/PT10S"), 7 * time.Second, 7 * time.Second},
+		{"cycle wins over policy", failedJob(1, "R3/PT10S"), 0, 10 * time.Second},
+		{"invalid cycle falls back to policy", failedJob(2, "R3/never"), 0, time.Second},
+		{"first retry uses initial delay", failedJob(2, ""), 0, time.Second},
+		{"second retry grows", failedJob(1, ""), 0, 2 * time.Second},
+		{"retries above max use first attempt", failedJob(5, ""), 0, time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryDelay(tt.job, tt.explicit, policy); got != tt.want {
+				t.Errorf("delay = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestISODuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		10 * time.Second:        "PT10S",
+		2500 * time.Millisecond: "PT2.5S",
+		time.Minute:             "PT60S",
+	}
+	for duration, want := range tests {
+		if got := isoDuration(duration); got != want {
+			t.Errorf("isoDuration(%v) = %s, want %s", duration, got, want)
+		}
+	}
+}
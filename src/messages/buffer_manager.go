@@ -10,14 +10,34 @@ package messages
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"atom-engine/src/core/config"
 	"atom-engine/src/core/logger"
 	"atom-engine/src/core/models"
 	"atom-engine/src/storage"
 )
 
+// Buffer eviction policies applied when buffer reached capacity
+// Политики вытеснения, применяемые при заполнении буфера
+const (
+	EvictionPolicyRejectNew   = "reject_new"
+	EvictionPolicyEvictOldest = "evict_oldest"
+)
+
+// ErrorCodeMessageBufferFull is set in messages response when message was rejected by full buffer
+// Код ошибки в ответе messages, когда сообщение отклонено заполненным буфером
+const ErrorCodeMessageBufferFull = "MESSAGE_BUFFER_FULL"
+
+// ErrBufferFull is returned when buffer reached capacity and policy rejects new messages
+// Возвращается, когда буфер заполнен и политика отклоняет новые сообщения
+var ErrBufferFull = errors.New("message buffer is full")
+
 // BufferManager manages message buffering
 type BufferManager struct {
 	storage        storage.Storage
@@ -25,14 +45,42 @@ type BufferManager struct {
 	correlationMgr *CorrelationManager
 	isRunning      bool
 	stopChan       chan struct{}
+
+	// Capacity per tenant and message name, check and save are serialized by bufferMu
+	// Емкость на тенант и имя сообщения, проверка и сохранение сериализуются bufferMu
+	maxMessages    int
+	evictionPolicy string
+	bufferMu       sync.Mutex
+	evictedCount   atomic.Int64
+	rejectedCount  atomic.Int64
+}
+
+// BufferUtilization describes fill level of buffer of one tenant and message name
+// Описывает заполненность буфера одного тенанта и имени сообщения
+type BufferUtilization struct {
+	TenantID           string  `json:"tenant_id"`
+	MessageName        string  `json:"message_name"`
+	Buffered           int     `json:"buffered"`
+	Capacity           int     `json:"capacity"`
+	UtilizationPercent float64 `json:"utilization_percent"`
 }
 
 // NewBufferManager creates new buffer manager
-func NewBufferManager(storage storage.Storage, logger logger.ComponentLogger) *BufferManager {
+func NewBufferManager(
+	storage storage.Storage,
+	logger logger.ComponentLogger,
+	bufferConfig config.MessageBufferConfig,
+) *BufferManager {
+	policy := bufferConfig.EvictionPolicy
+	if policy == "" {
+		policy = EvictionPolicyRejectNew
+	}
 	return &BufferManager{
-		storage:  storage,
-		logger:   logger,
-		stopChan: make(chan struct{}),
+		storage:        storage,
+		logger:         logger,
+		stopChan:       make(chan struct{}),
+		maxMessages:    bufferConfig.MaxMessages,
+		evictionPolicy: policy,
 	}
 }
 
@@ -61,10 +109,20 @@ func (bm *BufferManager) Stop() {
 	bm.logger.Info("Buffer manager stopped")
 }
 
-// BufferMessage buffers a message
+// BufferMessage buffers a message enforcing buffer capacity
+// Full buffer returns ErrBufferFull or evicts oldest messages depending on policy
+// Буферизует сообщение с учетом емкости буфера
+// Заполненный буфер возвращает ErrBufferFull или вытесняет старые сообщения в зависимости от политики
 func (bm *BufferManager) BufferMessage(ctx context.Context, message *models.BufferedMessage) error {
 	bm.logger.Info("Buffering message", logger.String("name", message.Name), logger.String("reason", message.Reason))
 
+	bm.bufferMu.Lock()
+	defer bm.bufferMu.Unlock()
+
+	if err := bm.ensureCapacity(ctx, message); err != nil {
+		return err
+	}
+
 	if err := bm.storage.SaveBufferedMessage(ctx, message); err != nil {
 		return fmt.Errorf("failed to buffer message: %w", err)
 	}
@@ -73,6 +131,115 @@ func (bm *BufferManager) BufferMessage(ctx context.Context, message *models.Buff
 	return nil
 }
 
+// ensureCapacity makes room for message in buffer of its tenant and name
+// Освобождает место для сообщения в буфере его тенанта и имени
+func (bm *BufferManager) ensureCapacity(ctx context.Context, message *models.BufferedMessage) error {
+	if bm.maxMessages <= 0 {
+		return nil
+	}
+
+	buffered, err := bm.listActive(ctx, message.TenantID, message.Name)
+	if err != nil {
+		return err
+	}
+	if len(buffered) < bm.maxMessages {
+		return nil
+	}
+
+	if bm.evictionPolicy != EvictionPolicyEvictOldest {
+		bm.rejectedCount.Add(1)
+		bm.logger.Warn("Message buffer full, message rejected",
+			logger.String("tenant_id", message.TenantID),
+			logger.String("name", message.Name),
+			logger.Int("capacity", bm.maxMessages))
+		return fmt.Errorf("%w: %d messages %q buffered for tenant %q",
+			ErrBufferFull, len(buffered), message.Name, message.TenantID)
+	}
+
+	sort.Slice(buffered, func(i, j int) bool {
+		return buffered[i].BufferedAt.Before(buffered[j].BufferedAt)
+	})
+	for _, oldest := range buffered[:len(buffered)-bm.maxMessages+1] {
+		if err := bm.storage.DeleteBufferedMessage(ctx, oldest.ID); err != nil {
+			return fmt.Errorf("failed to evict buffered message %s: %w", oldest.ID, err)
+		}
+		bm.evictedCount.Add(1)
+		bm.logger.Warn("Message buffer full, oldest message evicted",
+			logger.String("tenant_id", oldest.TenantID),
+			logger.String("name", oldest.Name),
+			logger.String("message_id", oldest.ID),
+			logger.String("correlation_key", oldest.CorrelationKey),
+			logger.Int("capacity", bm.maxMessages))
+	}
+	return nil
+}
+
+// listActive lists not expired buffered messages of tenant with given name
+// Возвращает не истекшие буферизованные сообщения тенанта с заданным именем
+func (bm *BufferManager) listActive(
+	ctx context.Context,
+	tenantID, messageName string,
+) ([]*models.BufferedMessage, error) {
+	messages, err := bm.storage.ListBufferedMessages(ctx, tenantID, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list buffered messages: %w", err)
+	}
+
+	var result []*models.BufferedMessage
+	for _, message := range messages {
+		if message.TenantID == tenantID && message.Name == messageName && !message.IsExpired() {
+			result = append(result, message)
+		}
+	}
+	return result, nil
+}
+
+// Utilization returns fill level of buffers of given messages ordered by tenant and name
+// Возвращает заполненность буферов для переданных сообщений по тенанту и имени
+func (bm *BufferManager) Utilization(messages []*models.BufferedMessage) []BufferUtilization {
+	type bufferKey struct{ tenantID, name string }
+	counts := make(map[bufferKey]int)
+	for _, message := range messages {
+		if !message.IsExpired() {
+			counts[bufferKey{message.TenantID, message.Name}]++
+		}
+	}
+
+	result := make([]BufferUtilization, 0, len(counts))
+	for key, count := range counts {
+		utilization := BufferUtilization{
+			TenantID:    key.tenantID,
+			MessageName: key.name,
+			Buffered:    count,
+			Capacity:    bm.maxMessages,
+		}
+		if bm.maxMessages > 0 {
+			utilization.UtilizationPercent = float64(count) / float64(bm.maxMessages) * 100
+		}
+		result = append(result, utilization)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].TenantID != result[j].TenantID {
+			return result[i].TenantID < result[j].TenantID
+		}
+		return result[i].MessageName < result[j].MessageName
+	})
+	return result
+}
+
+// Capacity returns max buffered messages per tenant and name and eviction policy
+// Возвращает максимум буферизованных сообщений на тенант и имя и политику вытеснения
+func (bm *BufferManager) Capacity() (int, string) {
+	return bm.maxMessages, bm.evictionPolicy
+}
+
+// EvictionCounts returns numbers of evicted and rejected messages since start
+// Возвращает количество вытесненных и отклоненных сообщений с момента запуска
+func (bm *BufferManager) EvictionCounts() (evicted, rejected int64) {
+	return bm.evictedCount.Load(), bm.rejectedCount.Load()
+}
+
 // ListBufferedMessages lists buffered messages
 func (bm *BufferManager) ListBufferedMessages(
 	ctx context.Context,
@@ -157,6 +324,24 @@ func (bm *BufferManager) CleanupExpiredMessages(ctx context.Context) (int, error
 			}
 			cleanedCount++
 			bm.logger.Debug("Deleted expired message", logger.String("name", message.Name))
+
+			// Keep record of expired message for correlation statistics
+			// Сохраняем запись о просроченном сообщении для статистики корреляции
+			bufferedAt := message.BufferedAt
+			expiredResult := &models.MessageCorrelationResult{
+				ID:             models.GenerateID(),
+				MessageID:      message.ID,
+				TenantID:       message.TenantID,
+				MessageName:    message.Name,
+				CorrelationKey: message.CorrelationKey,
+				CreatedAt:      time.Now(),
+				BufferedAt:     &bufferedAt,
+				Expired:        true,
+				ErrorMessage:   "message expired without correlation",
+			}
+			if err := bm.storage.SaveMessageCorrelationResult(ctx, expiredResult); err != nil {
+				bm.logger.Warn("Failed to record expired message", logger.String("error", err.Error()))
+			}
 		}
 	}
 
@@ -171,7 +356,9 @@ func (bm *BufferManager) ProcessBufferedMessages(
 ) (int, error) {
 	bm.logger.Info("Processing buffered messages for subscription", logger.String("messageName", subscription.MessageName))
 
-	messages, err := bm.storage.ListBufferedMessages(ctx, subscription.TenantID, 1000, 0)
+	// Broadcast messages of other tenants are candidates too, so buffer of all tenants is listed
+	// Широковещательные сообщения других арендаторов тоже подходят, поэтому читается буфер всех арендаторов
+	messages, err := bm.storage.ListBufferedMessages(ctx, "", 1000, 0)
 	if err != nil {
 		return 0, fmt.Errorf("failed to list buffered messages: %w", err)
 	}
@@ -183,6 +370,12 @@ func (bm *BufferManager) ProcessBufferedMessages(
 			continue
 		}
 
+		// Messages of other tenants match only when broadcast across tenants
+		// Сообщения других арендаторов совпадают только при широковещательной публикации
+		if !models.SameTenant(message.TenantID, subscription.TenantID) && !message.BroadcastAcrossTenants {
+			continue
+		}
+
 		// Check correlation key match if specified
 		if subscription.CorrelationKey != "" && message.CorrelationKey != subscription.CorrelationKey {
 			continue
@@ -196,15 +389,7 @@ func (bm *BufferManager) ProcessBufferedMessages(
 		// For intermediate catch events, trigger message correlation through correlation manager
 		// Для intermediate catch events запускаем корреляцию сообщений через correlation manager
 		if bm.correlationMgr != nil {
-			correlationResult, err := bm.correlationMgr.PublishMessage(
-				ctx,
-				message.TenantID,
-				message.Name,
-				message.CorrelationKey,
-				message.ElementID,
-				message.Variables,
-				nil,
-			)
+			correlationResult, err := bm.correlationMgr.PublishBufferedMessage(ctx, message)
 			if err != nil {
 				bm.logger.Error("Failed to correlate buffered message",
 					logger.String("message_id", message.ID),
@@ -0,0 +1,168 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package messages
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"atom-engine/src/core/config"
+)
+
+// bufferConfig returns config with buffer capacity of three messages and given policy
+// Возвращает конфигурацию с емкостью буфера в три сообщения и заданной политикой
+func bufferConfig(policy string) *config.Config {
+	return &config.Config{Messages: config.MessagesConfig{
+		Buffer: config.MessageBufferConfig{MaxMessages: 3, EvictionPolicy: policy},
+	}}
+}
+
+// publishKeys publishes uncorrelated messages with correlation keys and returns first error
+// Публикует некоррелируемые сообщения с ключами корреляции и возвращает первую ошибку
+func publishKeys(c *Component, tenantID, name string, keys ...string) error {
+	for _, key := range keys {
+		if _, err := c.PublishMessage(context.Background(), tenantID, name, key, "", nil, nil); err != nil {
+			return err
+		}
+		// Buffering time orders messages for eviction
+		// Время буферизации упорядочивает сообщения для вытеснения
+		time.Sleep(2 * time.Millisecond)
+	}
+	return nil
+}
+
+// bufferedKeys returns sorted correlation keys of buffered messages with name
+// Возвращает отсортированные ключи корреляции буферизованных сообщений с именем
+func bufferedKeys(t *testing.T, c *Component, name string) []string {
+	t.Helper()
+
+	messages, err := c.storage.ListBufferedMessagesByName(context.Background(), name)
+	if err != nil {
+		t.Fatalf("list buffered messages: %v", err)
+	}
+	keys := make([]string, 0, len(messages))
+	for _, message := range messages {
+		keys = append(keys, message.CorrelationKey)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestBufferRejectNewAtCapacity(t *testing.T) {
+	ctx := context.Background()
+	c := newMessagesComponent(t, bufferConfig(EvictionPolicyRejectNew))
+
+	if err := publishKeys(c, "tenant-a", "order-paid", "k1", "k2", "k3"); err != nil {
+		t.Fatalf("publish up to capacity: %v", err)
+	}
+	err := publishKeys(c, "tenant-a", "order-paid", "k4")
+	if !errors.Is(err, ErrBufferFull) {
+		t.Fatalf("publish to full buffer: %v, want ErrBufferFull", err)
+	}
+	if keys := fmt.Sprint(bufferedKeys(t, c, "order-paid")); keys != "[k1 k2 k3]" {
+		t.Errorf("buffered keys %s, want messages buffered before capacity was reached", keys)
+	}
+
+	// Capacity is counted per tenant and message name
+	// Емкость считается по тенанту и имени сообщения
+	if err := publishKeys(c, "tenant-b", "order-paid", "b1"); err != nil {
+		t.Errorf("publish for other tenant: %v", err)
+	}
+	if err := publishKeys(c, "tenant-a", "order-shipped", "s1"); err != nil {
+		t.Errorf("publish other message name: %v", err)
+	}
+
+	stats, err := c.GetMessageStats(ctx, "tenant-a")
+	if err != nil {
+		t.Fatalf("message stats: %v", err)
+	}
+	if stats.BufferCapacity != 3 || stats.BufferEvictionPolicy != EvictionPolicyRejectNew ||
+		stats.BufferRejected != 1 || stats.BufferEvicted != 0 {
+		t.Errorf("stats capacity %d, policy %s, rejected %d, evicted %d",
+			stats.BufferCapacity, stats.BufferEvictionPolicy, stats.BufferRejected, stats.BufferEvicted)
+	}
+	for _, utilization := range stats.BufferUtilization {
+		if utilization.MessageName == "order-paid" && utilization.UtilizationPercent != 100 {
+			t.Errorf("utilization of full buffer %+v", utilization)
+		}
+	}
+}
+
+func TestBufferRejectNewReportsBufferFullErrorCode(t *testing.T) {
+	c := newMessagesComponent(t, bufferConfig(EvictionPolicyRejectNew))
+	if err := publishKeys(c, "", "order-paid", "k1", "k2", "k3"); err != nil {
+		t.Fatalf("publish up to capacity: %v", err)
+	}
+
+	request, _ := json.Marshal(MessageRequest{
+		Type:      "publish_message",
+		RequestID: "publish-4",
+		Payload:   map[string]interface{}{"message_name": "order-paid", "correlation_key": "k4"},
+	})
+	if err := c.ProcessMessage(context.Background(), string(request)); err != nil {
+		t.Fatalf("process publish request: %v", err)
+	}
+
+	select {
+	case raw := <-c.GetResponseChannel():
+		var response MessageResponse
+		if err := json.Unmarshal([]byte(raw), &response); err != nil {
+			t.Fatalf("decode response: %v", err)
+		}
+		if response.Success || response.ErrorCode != ErrorCodeMessageBufferFull {
+			t.Errorf("response %s, want %s error", raw, ErrorCodeMessageBufferFull)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no publish response")
+	}
+}
+
+func TestBufferEvictOldestAtCapacity(t *testing.T) {
+	ctx := context.Background()
+	c := newMessagesComponent(t, bufferConfig(EvictionPolicyEvictOldest))
+
+	if err := publishKeys(c, "tenant-a", "order-paid", "k1", "k2", "k3", "k4", "k5"); err != nil {
+		t.Fatalf("publish beyond capacity: %v", err)
+	}
+	if keys := fmt.Sprint(bufferedKeys(t, c, "order-paid")); keys != "[k3 k4 k5]" {
+		t.Errorf("buffered keys %s, want three newest messages", keys)
+	}
+
+	stats, err := c.GetMessageStats(ctx, "tenant-a")
+	if err != nil {
+		t.Fatalf("message stats: %v", err)
+	}
+	if stats.BufferEvictionPolicy != EvictionPolicyEvictOldest ||
+		stats.BufferEvicted != 2 || stats.BufferRejected != 0 {
+		t.Errorf("stats policy %s, evicted %d, rejected %d, want 2 evicted",
+			stats.BufferEvictionPolicy, stats.BufferEvicted, stats.BufferRejected)
+	}
+}
+
+func TestBufferCapacityIgnoresExpiredMessages(t *testing.T) {
+	ctx := context.Background()
+	c := newMessagesComponent(t, bufferConfig(EvictionPolicyRejectNew))
+
+	ttl := time.Millisecond
+	for _, key := range []string{"k1", "k2", "k3"} {
+		if _, err := c.PublishMessage(ctx, "tenant-a", "order-paid", key, "", nil, &ttl); err != nil {
+			t.Fatalf("publish short-lived message: %v", err)
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	if err := publishKeys(c, "tenant-a", "order-paid", "k4"); err != nil {
+		t.Errorf("publish after buffered messages expired: %v", err)
+	}
+}
@@ -11,6 +11,7 @@ package messages
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -67,11 +68,16 @@ func (c *Component) Start() error {
 	// Initialize managers
 	c.correlationMgr = NewCorrelationManager(c.storage, c.logger, c.responseChannel)
 	c.subscriptionMgr = NewSubscriptionManager(c.storage, c.logger)
-	c.bufferMgr = NewBufferManager(c.storage, c.logger)
+	var bufferConfig config.MessageBufferConfig
+	if c.config != nil {
+		bufferConfig = c.config.Messages.Buffer
+	}
+	c.bufferMgr = NewBufferManager(c.storage, c.logger, bufferConfig)
 
 	// Set correlation manager reference in buffer manager
 	// Устанавливаем ссылку на correlation manager в buffer manager
 	c.bufferMgr.SetCorrelationManager(c.correlationMgr)
+	c.correlationMgr.SetBufferManager(c.bufferMgr)
 
 	// Start managers
 	if err := c.correlationMgr.Start(); err != nil {
@@ -140,6 +146,25 @@ func (c *Component) PublishMessage(
 	return c.correlationMgr.PublishMessage(ctx, tenantID, messageName, correlationKey, elementID, variables, ttl)
 }
 
+// PublishMessageAcrossTenants publishes message that may correlate with subscription of any tenant
+// Публикует сообщение, которое может коррелировать с подпиской любого арендатора
+func (c *Component) PublishMessageAcrossTenants(
+	ctx context.Context,
+	tenantID, messageName, correlationKey, elementID string,
+	variables map[string]interface{},
+	ttl *time.Duration,
+) (*models.MessageCorrelationResult, error) {
+	c.logger.Info("Publishing message across tenants",
+		logger.String("messageName", messageName),
+		logger.String("correlationKey", correlationKey),
+		logger.String("tenantID", tenantID),
+	)
+
+	return c.correlationMgr.PublishMessageAcrossTenants(
+		ctx, tenantID, messageName, correlationKey, elementID, variables, ttl,
+	)
+}
+
 func (c *Component) PublishMessageWithElementID(
 	ctx context.Context,
 	tenantID, messageName, correlationKey, elementID string,
@@ -302,6 +327,22 @@ type MessageStats struct {
 	ExpiredMessages       int `json:"expired_messages"`
 	PublishedToday        int `json:"published_today"`
 	InstancesCreatedToday int `json:"instances_created_today"`
+
+	// Correlation outcome breakdown
+	// Разбивка результатов корреляции
+	CorrelatedImmediately  int     `json:"correlated_immediately"`
+	CorrelatedFromBuffer   int     `json:"correlated_from_buffer"`
+	ExpiredUncorrelated    int     `json:"expired_uncorrelated"`
+	AvgBufferDwellMs       int64   `json:"avg_buffer_dwell_ms"`
+	CorrelationSuccessRate float64 `json:"correlation_success_rate"`
+
+	// Buffer capacity and fill level per tenant and message name
+	// Емкость буфера и заполненность по тенанту и имени сообщения
+	BufferCapacity       int                 `json:"buffer_capacity"`
+	BufferEvictionPolicy string              `json:"buffer_eviction_policy"`
+	BufferEvicted        int64               `json:"buffer_evicted"`
+	BufferRejected       int64               `json:"buffer_rejected"`
+	BufferUtilization    []BufferUtilization `json:"buffer_utilization"`
 }
 
 // ProcessMessage processes JSON message from core engine
@@ -314,7 +355,9 @@ func (c *Component) ProcessMessage(ctx context.Context, messageJSON string) erro
 	// Parse message to determine type
 	// Парсим сообщение для определения типа
 	var request MessageRequest
-	if err := json.Unmarshal([]byte(messageJSON), &request); err != nil {
+	// Keep numbers as json.Number so payload variables are not coerced to float64
+	// Сохраняем числа как json.Number, чтобы переменные payload не приводились к float64
+	if err := models.UnmarshalPreservingNumbers([]byte(messageJSON), &request); err != nil {
 		return fmt.Errorf("failed to parse message request: %w", err)
 	}
 
@@ -365,7 +408,11 @@ func (c *Component) handlePublishMessage(ctx context.Context, request MessageReq
 		ttl = &duration
 	}
 
-	result, err := c.PublishMessage(
+	publish := c.PublishMessage
+	if payload.BroadcastAcrossTenants {
+		publish = c.PublishMessageAcrossTenants
+	}
+	result, err := publish(
 		ctx,
 		payload.TenantID,
 		payload.MessageName,
@@ -378,6 +425,9 @@ func (c *Component) handlePublishMessage(ctx context.Context, request MessageReq
 	var response MessageResponse
 	if err != nil {
 		response = CreateMessageErrorResponse("publish_message_response", request.RequestID, err.Error())
+		if errors.Is(err, ErrBufferFull) {
+			response.ErrorCode = ErrorCodeMessageBufferFull
+		}
 	} else {
 		messageResult := MessageResult{
 			MessageID:         result.MessageID,
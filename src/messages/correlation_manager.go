@@ -11,6 +11,7 @@ package messages
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -25,6 +26,7 @@ type CorrelationManager struct {
 	storage         storage.Storage
 	logger          logger.ComponentLogger
 	responseChannel chan string
+	bufferMgr       *BufferManager
 	isRunning       bool
 	stopChan        chan struct{}
 }
@@ -43,6 +45,12 @@ func NewCorrelationManager(
 	}
 }
 
+// SetBufferManager sets buffer manager enforcing buffer capacity
+// Устанавливает buffer manager, соблюдающий емкость буфера
+func (cm *CorrelationManager) SetBufferManager(bm *BufferManager) {
+	cm.bufferMgr = bm
+}
+
 // Start starts the correlation manager
 func (cm *CorrelationManager) Start() error {
 	cm.logger.Info("Starting correlation manager")
@@ -69,6 +77,54 @@ func (cm *CorrelationManager) PublishMessage(
 	tenantID, messageName, correlationKey, elementID string,
 	variables map[string]interface{},
 	ttl *time.Duration,
+) (*models.MessageCorrelationResult, error) {
+	return cm.publishMessage(ctx, tenantID, messageName, correlationKey, elementID, variables, ttl, nil, false)
+}
+
+// PublishMessageAcrossTenants publishes message that may correlate with subscription of any tenant
+// Caller is responsible for checking that publisher is allowed to cross tenant boundaries
+// Публикует сообщение, которое может коррелировать с подпиской любого арендатора
+// Вызывающий отвечает за проверку права издателя пересекать границы арендаторов
+func (cm *CorrelationManager) PublishMessageAcrossTenants(
+	ctx context.Context,
+	tenantID, messageName, correlationKey, elementID string,
+	variables map[string]interface{},
+	ttl *time.Duration,
+) (*models.MessageCorrelationResult, error) {
+	return cm.publishMessage(ctx, tenantID, messageName, correlationKey, elementID, variables, ttl, nil, true)
+}
+
+// PublishBufferedMessage re-publishes buffered message keeping its buffering time
+// Повторно публикует буферизованное сообщение сохраняя время буферизации
+func (cm *CorrelationManager) PublishBufferedMessage(
+	ctx context.Context,
+	message *models.BufferedMessage,
+) (*models.MessageCorrelationResult, error) {
+	bufferedAt := message.BufferedAt
+	return cm.publishMessage(
+		ctx,
+		message.TenantID,
+		message.Name,
+		message.CorrelationKey,
+		message.ElementID,
+		message.Variables,
+		nil,
+		&bufferedAt,
+		message.BroadcastAcrossTenants,
+	)
+}
+
+// publishMessage publishes message, bufferedAt is set for messages coming from buffer
+// Message correlates only with subscriptions of its tenant unless acrossTenants is set
+// Публикует сообщение, bufferedAt задается для сообщений из буфера
+// Сообщение коррелирует только с подписками своего арендатора, если не установлен acrossTenants
+func (cm *CorrelationManager) publishMessage(
+	ctx context.Context,
+	tenantID, messageName, correlationKey, elementID string,
+	variables map[string]interface{},
+	ttl *time.Duration,
+	bufferedAt *time.Time,
+	acrossTenants bool,
 ) (*models.MessageCorrelationResult, error) {
 	cm.logger.Info("Publishing message for correlation",
 		logger.String("messageName", messageName),
@@ -77,17 +133,37 @@ func (cm *CorrelationManager) PublishMessage(
 	)
 
 	// Create message ID
-	messageID := models.GenerateID()
+	messageID := models.GenerateSortableID()
 
-	// Try to find active subscription
-	subscriptions, err := cm.storage.ListProcessMessageSubscriptions(ctx, tenantID, 100, 0)
+	// Try to find active subscription, storage treats empty tenant as any tenant
+	// Ищем активную подписку, storage считает пустой арендатор любым арендатором
+	subscriptionTenantFilter := tenantID
+	if acrossTenants {
+		subscriptionTenantFilter = ""
+	}
+	subscriptions, err := cm.storage.ListProcessMessageSubscriptions(ctx, subscriptionTenantFilter, 100, 0)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
 	}
 
 	var targetSubscription *models.ProcessMessageSubscription
 	for _, sub := range subscriptions {
+		// Subscriptions of other tenants are never matched by regular messages
+		// Подписки других арендаторов никогда не совпадают с обычными сообщениями
+		if !acrossTenants && !models.SameTenant(sub.TenantID, tenantID) {
+			continue
+		}
 		if sub.MessageName == messageName && sub.IsActive {
+			// Key resolved from instance variables must match exactly, message without key does not correlate
+			// Ключ, вычисленный из переменных экземпляра, должен совпадать точно, сообщение без ключа не коррелирует
+			if sub.CorrelationKeyExpression != "" {
+				if sub.CorrelationKey != correlationKey {
+					continue
+				}
+				targetSubscription = sub
+				break
+			}
+
 			// Check correlation key match if specified
 			if correlationKey != "" && sub.CorrelationKey != "" {
 				// Handle FEEL expressions in subscription correlation key
@@ -138,6 +214,8 @@ func (cm *CorrelationManager) PublishMessage(
 	}
 
 	if targetSubscription != nil {
+		result.BufferedAt = bufferedAt
+
 		// Check if this is intermediate catch event or start event
 		// Проверяем является ли это intermediate catch event или start event
 		isIntermediateCatchEvent := cm.isIntermediateCatchEvent(targetSubscription.StartEventID)
@@ -167,7 +245,7 @@ func (cm *CorrelationManager) PublishMessage(
 		} else {
 			// For start events, create new process instance
 			// Для start events создаем новый экземпляр процесса
-			processInstanceID := models.GenerateID()
+			processInstanceID := models.GenerateSortableID()
 
 			// NOTE: Process instance creation should be integrated with process engine
 			// For now, just set the ID
@@ -180,6 +258,10 @@ func (cm *CorrelationManager) PublishMessage(
 			)
 		}
 
+		if !models.SameTenant(targetSubscription.TenantID, tenantID) {
+			cm.auditCrossTenantCorrelation(ctx, result, targetSubscription)
+		}
+
 		// Send correlation callback if response channel is available
 		// Отправляем correlation callback если канал ответов доступен
 		if cm.responseChannel != nil {
@@ -205,7 +287,30 @@ func (cm *CorrelationManager) PublishMessage(
 				}
 			}
 
+			// Persist intent for token callbacks so they survive crash before token moves on
+			// Сохраняем намерение для callback токена, чтобы он пережил падение до перехода токена
+			var intent *models.CallbackIntent
+			if tokenID, ok := callback["token_id"].(string); ok && tokenID != "" {
+				intent = models.NewCallbackIntent(
+					models.CallbackIntentMessage,
+					messageID,
+					tokenID,
+					fmt.Sprintf("message:%s", messageName),
+				)
+				intent.ProcessInstanceID = result.ProcessInstanceID
+				callback["intent_id"] = intent.ID
+			}
+
 			if callbackJSON, err := json.Marshal(callback); err == nil {
+				if intent != nil {
+					intent.Payload = callbackJSON
+					if err := cm.storage.SaveCallbackIntent(intent); err != nil {
+						cm.logger.Error("Failed to persist message callback intent",
+							logger.String("message_name", messageName),
+							logger.String("error", err.Error()))
+					}
+				}
+
 				cm.logger.Info("Sending callback to response channel",
 					logger.String("message_name", messageName),
 					logger.String("callback_json", string(callbackJSON)))
@@ -248,6 +353,8 @@ func (cm *CorrelationManager) PublishMessage(
 			BufferedAt:     time.Now(),
 			Reason:         "No active subscription found",
 			ElementID:      elementID,
+
+			BroadcastAcrossTenants: acrossTenants,
 		}
 
 		if ttl != nil {
@@ -255,7 +362,18 @@ func (cm *CorrelationManager) PublishMessage(
 			bufferedMessage.ExpiresAt = &expiresAt
 		}
 
-		if err := cm.storage.SaveBufferedMessage(ctx, bufferedMessage); err != nil {
+		var err error
+		if cm.bufferMgr != nil {
+			err = cm.bufferMgr.BufferMessage(ctx, bufferedMessage)
+		} else {
+			err = cm.storage.SaveBufferedMessage(ctx, bufferedMessage)
+		}
+		if errors.Is(err, ErrBufferFull) {
+			// Rejected message is not published, correlation result is not recorded
+			// Отклоненное сообщение не опубликовано, результат корреляции не сохраняется
+			return nil, err
+		}
+		if err != nil {
 			cm.logger.Error("Failed to buffer message", logger.String("error", err.Error()))
 			result.ErrorMessage = fmt.Sprintf("failed to buffer message: %v", err)
 		} else {
@@ -271,6 +389,29 @@ func (cm *CorrelationManager) PublishMessage(
 	return result, nil
 }
 
+// auditCrossTenantCorrelation marks correlation result as cross-tenant and writes audit entry,
+// entry is logged at warn level so it is not lost with info logs disabled
+// Помечает результат корреляции как межарендаторный и пишет запись аудита,
+// запись логируется на уровне warn, чтобы не теряться при отключенных info логах
+func (cm *CorrelationManager) auditCrossTenantCorrelation(
+	ctx context.Context,
+	result *models.MessageCorrelationResult,
+	subscription *models.ProcessMessageSubscription,
+) {
+	result.CrossTenant = true
+	result.SubscriptionTenantID = subscription.TenantID
+
+	cm.logger.Warn("Audit: cross-tenant message correlation",
+		logger.String("request_id", models.RequestIDFromContext(ctx)),
+		logger.String("message_id", result.MessageID),
+		logger.String("message_name", result.MessageName),
+		logger.String("correlation_key", result.CorrelationKey),
+		logger.String("publisher_tenant_id", result.TenantID),
+		logger.String("subscription_tenant_id", subscription.TenantID),
+		logger.String("subscription_id", subscription.ID),
+		logger.String("process_instance_id", result.ProcessInstanceID))
+}
+
 // CorrelateMessage correlates message with specific process instance
 func (cm *CorrelationManager) CorrelateMessage(
 	ctx context.Context,
@@ -282,7 +423,7 @@ func (cm *CorrelationManager) CorrelateMessage(
 		logger.String("processInstanceID", processInstanceID),
 	)
 
-	messageID := models.GenerateID()
+	messageID := models.GenerateSortableID()
 
 	result := &models.MessageCorrelationResult{
 		ID:                messageID,
@@ -347,9 +488,31 @@ func (cm *CorrelationManager) GetStats(ctx context.Context, tenantID string) (*M
 		correlationResults = []*models.MessageCorrelationResult{}
 	}
 
+	totalMessages := 0
 	publishedToday := 0
 	instancesCreatedToday := 0
+	correlatedImmediately := 0
+	correlatedFromBuffer := 0
+	expiredUncorrelated := 0
+	var totalDwell time.Duration
 	for _, result := range correlationResults {
+		// Expiry records are written on cleanup, not on publish
+		// Записи об истечении создаются при очистке, а не при публикации
+		if result.Expired {
+			expiredUncorrelated++
+			continue
+		}
+		totalMessages++
+
+		if result.IsCorrelated() {
+			if result.BufferedAt != nil {
+				correlatedFromBuffer++
+				totalDwell += result.BufferDwellTime()
+			} else {
+				correlatedImmediately++
+			}
+		}
+
 		if result.CreatedAt.Format("2006-01-02") == today {
 			publishedToday++
 			if result.InstanceCreated {
@@ -358,17 +521,40 @@ func (cm *CorrelationManager) GetStats(ctx context.Context, tenantID string) (*M
 		}
 	}
 
+	// Messages still in buffer past their TTL are not correlated either
+	// Сообщения в буфере с истекшим TTL также не коррелированы
+	expiredUncorrelated += expiredMessages
+
 	stats := &MessageStats{
-		TotalMessages:         len(correlationResults),
+		TotalMessages:         totalMessages,
 		BufferedMessages:      len(bufferedMessages),
 		ExpiredMessages:       expiredMessages,
 		PublishedToday:        publishedToday,
 		InstancesCreatedToday: instancesCreatedToday,
+		CorrelatedImmediately: correlatedImmediately,
+		CorrelatedFromBuffer:  correlatedFromBuffer,
+		ExpiredUncorrelated:   expiredUncorrelated,
+	}
+
+	if cm.bufferMgr != nil {
+		stats.BufferCapacity, stats.BufferEvictionPolicy = cm.bufferMgr.Capacity()
+		stats.BufferEvicted, stats.BufferRejected = cm.bufferMgr.EvictionCounts()
+		stats.BufferUtilization = cm.bufferMgr.Utilization(bufferedMessages)
+	}
+
+	if correlatedFromBuffer > 0 {
+		stats.AvgBufferDwellMs = totalDwell.Milliseconds() / int64(correlatedFromBuffer)
+	}
+
+	if finished := correlatedImmediately + correlatedFromBuffer + expiredUncorrelated; finished > 0 {
+		stats.CorrelationSuccessRate = float64(correlatedImmediately+correlatedFromBuffer) / float64(finished) * 100
 	}
 
 	cm.logger.Debug("Message stats calculated",
 		logger.Int("bufferedMessages", stats.BufferedMessages),
 		logger.Int("publishedToday", stats.PublishedToday),
+		logger.Int("correlatedFromBuffer", stats.CorrelatedFromBuffer),
+		logger.Int("expiredUncorrelated", stats.ExpiredUncorrelated),
 	)
 
 	return stats, nil
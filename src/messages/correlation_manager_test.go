@@ -0,0 +1,188 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package messages
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"atom-engine/src/core/config"
+	"atom-engine/src/core/models"
+	"atom-engine/src/storage"
+)
+
+// newMessagesComponent starts messages component on Badger storage in temporary directory
+// Запускает компонент сообщений на Badger storage во временной директории
+func newMessagesComponent(t *testing.T, cfg *config.Config) *Component {
+	t.Helper()
+
+	st := storage.NewStorage(&storage.Config{Path: t.TempDir()})
+	if err := st.Init(); err != nil {
+		t.Fatalf("init storage: %v", err)
+	}
+	if err := st.Start(); err != nil {
+		t.Fatalf("start storage: %v", err)
+	}
+	t.Cleanup(func() { st.Stop() })
+
+	c := NewComponent(cfg, st)
+	if err := c.Start(); err != nil {
+		t.Fatalf("start messages component: %v", err)
+	}
+	t.Cleanup(func() { c.Stop() })
+	return c
+}
+
+// subscribe saves active start event subscription of tenant to message
+// Сохраняет активную подписку start event арендатора на сообщение
+func subscribe(t *testing.T, c *Component, tenantID, messageName string) *models.ProcessMessageSubscription {
+	t.Helper()
+
+	now := time.Now()
+	subscription := &models.ProcessMessageSubscription{
+		ID:                   models.GenerateID(),
+		TenantID:             tenantID,
+		ProcessDefinitionKey: "process-" + tenantID,
+		StartEventID:         "start-" + tenantID,
+		MessageName:          messageName,
+		IsActive:             true,
+		CreatedAt:            now,
+		UpdatedAt:            now,
+	}
+	if err := c.storage.SaveProcessMessageSubscription(context.Background(), subscription); err != nil {
+		t.Fatalf("save subscription: %v", err)
+	}
+	return subscription
+}
+
+// bufferedCount returns number of buffered messages with name
+// Возвращает количество буферизованных сообщений с именем
+func bufferedCount(t *testing.T, c *Component, name string) int {
+	t.Helper()
+
+	messages, err := c.storage.ListBufferedMessagesByName(context.Background(), name)
+	if err != nil {
+		t.Fatalf("list buffered messages: %v", err)
+	}
+	return len(messages)
+}
+
+func TestPublishMessageIsolatesTenantsByDefault(t *testing.T) {
+	ctx := context.Background()
+	c := newMessagesComponent(t, nil)
+	subscribe(t, c, "tenant-b", "order-paid")
+
+	result, err := c.PublishMessage(ctx, "tenant-a", "order-paid", "", "", nil, nil)
+	if err != nil {
+		t.Fatalf("publish message: %v", err)
+	}
+	if result.ProcessInstanceID != "" || result.CrossTenant {
+		t.Fatalf("message of tenant-a correlated with tenant-b subscription: %+v", result)
+	}
+	if count := bufferedCount(t, c, "order-paid"); count != 1 {
+		t.Fatalf("uncorrelated message not buffered, buffered %d", count)
+	}
+
+	// Same tenant still correlates, legacy default tenant ID is default tenant
+	// Тот же арендатор по-прежнему коррелирует, устаревший ID арендатора по умолчанию равен арендатору по умолчанию
+	subscribe(t, c, "tenant-a", "order-shipped")
+	subscribe(t, c, "DEFAULT_TENANT", "order-closed")
+	for _, publish := range []struct{ tenantID, name string }{
+		{"tenant-a", "order-shipped"},
+		{models.DefaultTenantID, "order-closed"},
+	} {
+		result, err := c.PublishMessage(ctx, publish.tenantID, publish.name, "", "", nil, nil)
+		if err != nil {
+			t.Fatalf("publish %s: %v", publish.name, err)
+		}
+		if result.ProcessInstanceID == "" || result.CrossTenant {
+			t.Errorf("%s of own tenant not correlated as same tenant: %+v", publish.name, result)
+		}
+	}
+}
+
+func TestPublishMessageAcrossTenantsCorrelatesWithOtherTenant(t *testing.T) {
+	ctx := context.Background()
+	c := newMessagesComponent(t, nil)
+	subscription := subscribe(t, c, "tenant-b", "order-paid")
+
+	result, err := c.PublishMessageAcrossTenants(ctx, "tenant-a", "order-paid", "", "", nil, nil)
+	if err != nil {
+		t.Fatalf("publish message across tenants: %v", err)
+	}
+	if result.ProcessInstanceID == "" {
+		t.Fatalf("broadcast message not correlated: %+v", result)
+	}
+	if !result.CrossTenant || result.SubscriptionTenantID != subscription.TenantID || result.TenantID != "tenant-a" {
+		t.Errorf("cross-tenant correlation not recorded: %+v", result)
+	}
+	if count := bufferedCount(t, c, "order-paid"); count != 0 {
+		t.Errorf("correlated broadcast message buffered, buffered %d", count)
+	}
+
+	// Broadcast to own tenant is not cross-tenant correlation
+	// Рассылка своему арендатору не является межарендаторной корреляцией
+	subscribe(t, c, "tenant-a", "order-shipped")
+	result, err = c.PublishMessageAcrossTenants(ctx, "tenant-a", "order-shipped", "", "", nil, nil)
+	if err != nil {
+		t.Fatalf("publish message across tenants: %v", err)
+	}
+	if result.ProcessInstanceID == "" || result.CrossTenant {
+		t.Errorf("broadcast within tenant: %+v", result)
+	}
+}
+
+func TestBufferedMessagesCrossTenantsOnlyWhenBroadcast(t *testing.T) {
+	ctx := context.Background()
+	c := newMessagesComponent(t, nil)
+
+	if _, err := c.PublishMessage(ctx, "tenant-a", "order-paid", "", "", nil, nil); err != nil {
+		t.Fatalf("publish message: %v", err)
+	}
+	if _, err := c.PublishMessageAcrossTenants(ctx, "tenant-a", "order-paid", "", "", nil, nil); err != nil {
+		t.Fatalf("publish message across tenants: %v", err)
+	}
+	if count := bufferedCount(t, c, "order-paid"); count != 2 {
+		t.Fatalf("buffered %d messages, want 2", count)
+	}
+
+	processed, err := c.bufferMgr.ProcessBufferedMessages(ctx, subscribe(t, c, "tenant-b", "order-paid"))
+	if err != nil {
+		t.Fatalf("process buffered messages: %v", err)
+	}
+	if processed != 1 {
+		t.Fatalf("tenant-b subscription took %d buffered messages, want only broadcast one", processed)
+	}
+
+	remaining, err := c.storage.ListBufferedMessagesByName(ctx, "order-paid")
+	if err != nil {
+		t.Fatalf("list buffered messages: %v", err)
+	}
+	if len(remaining) != 1 || remaining[0].BroadcastAcrossTenants || remaining[0].TenantID != "tenant-a" {
+		t.Fatalf("regular message of tenant-a must stay buffered: %+v", remaining)
+	}
+
+	results, err := c.storage.ListMessageCorrelationResults(ctx, "tenant-a", "order-paid", "", 0, 0)
+	if err != nil {
+		t.Fatalf("list correlation results: %v", err)
+	}
+	crossTenant := 0
+	for _, result := range results {
+		if result.CrossTenant {
+			crossTenant++
+			if result.SubscriptionTenantID != "tenant-b" {
+				t.Errorf("cross-tenant result of wrong subscription tenant: %+v", result)
+			}
+		}
+	}
+	if crossTenant != 1 {
+		t.Errorf("cross-tenant correlation results %d, want 1", crossTenant)
+	}
+}
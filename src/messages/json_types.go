@@ -8,6 +8,8 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 
 package messages
 
+import "atom-engine/src/core/models"
+
 // MessageRequest base structure for all message requests
 // Базовая структура для всех запросов сообщений
 type MessageRequest struct {
@@ -24,41 +26,45 @@ type MessageResponse struct {
 	Success   bool        `json:"success"`
 	Result    interface{} `json:"result,omitempty"`
 	Error     string      `json:"error,omitempty"`
+	ErrorCode string      `json:"error_code,omitempty"` // Machine readable reason, e.g. MESSAGE_BUFFER_FULL
 }
 
 // PublishMessagePayload payload for publishing a message
 // Payload для публикации сообщения
 type PublishMessagePayload struct {
-	TenantID       string                 `json:"tenant_id,omitempty"`
-	MessageName    string                 `json:"message_name"`
-	CorrelationKey string                 `json:"correlation_key,omitempty"`
-	Variables      map[string]interface{} `json:"variables,omitempty"`
-	TTLSeconds     int                    `json:"ttl_seconds,omitempty"`
+	TenantID       string             `json:"tenant_id,omitempty"`
+	MessageName    string             `json:"message_name"`
+	CorrelationKey string             `json:"correlation_key,omitempty"`
+	Variables      models.VariableMap `json:"variables,omitempty"`
+	TTLSeconds     int                `json:"ttl_seconds,omitempty"`
+	// Allows correlation with subscriptions of any tenant, publisher permission is checked by API layer
+	// Разрешает корреляцию с подписками любого арендатора, право издателя проверяется слоем API
+	BroadcastAcrossTenants bool `json:"broadcast_across_tenants,omitempty"`
 }
 
 // CorrelateMessagePayload payload for correlating a message
 // Payload для корреляции сообщения
 type CorrelateMessagePayload struct {
-	TenantID          string                 `json:"tenant_id,omitempty"`
-	MessageName       string                 `json:"message_name"`
-	CorrelationKey    string                 `json:"correlation_key,omitempty"`
-	ProcessInstanceID string                 `json:"process_instance_id"`
-	Variables         map[string]interface{} `json:"variables,omitempty"`
+	TenantID          string             `json:"tenant_id,omitempty"`
+	MessageName       string             `json:"message_name"`
+	CorrelationKey    string             `json:"correlation_key,omitempty"`
+	ProcessInstanceID string             `json:"process_instance_id"`
+	Variables         models.VariableMap `json:"variables,omitempty"`
 }
 
 // CreateSubscriptionPayload payload for creating a message subscription
 // Payload для создания подписки на сообщение
 type CreateSubscriptionPayload struct {
-	TenantID          string                 `json:"tenant_id,omitempty"`
-	MessageName       string                 `json:"message_name"`
-	ProcessKey        string                 `json:"process_key,omitempty"`
-	ProcessInstanceID string                 `json:"process_instance_id,omitempty"`
-	ElementID         string                 `json:"element_id"`
-	TokenID           string                 `json:"token_id,omitempty"`
-	CorrelationKey    string                 `json:"correlation_key,omitempty"`
-	SubscriptionType  string                 `json:"subscription_type"` // PERMANENT or TEMPORARY
-	Variables         map[string]interface{} `json:"variables,omitempty"`
-	IsInterrupting    bool                   `json:"is_interrupting,omitempty"`
+	TenantID          string             `json:"tenant_id,omitempty"`
+	MessageName       string             `json:"message_name"`
+	ProcessKey        string             `json:"process_key,omitempty"`
+	ProcessInstanceID string             `json:"process_instance_id,omitempty"`
+	ElementID         string             `json:"element_id"`
+	TokenID           string             `json:"token_id,omitempty"`
+	CorrelationKey    string             `json:"correlation_key,omitempty"`
+	SubscriptionType  string             `json:"subscription_type"` // PERMANENT or TEMPORARY
+	Variables         models.VariableMap `json:"variables,omitempty"`
+	IsInterrupting    bool               `json:"is_interrupting,omitempty"`
 }
 
 // DeleteSubscriptionPayload payload for deleting a message subscription
@@ -11,6 +11,7 @@ package parser
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -29,17 +30,27 @@ type Component struct {
 	config          *config.Config
 	storage         storage.Storage
 	parser          *BPMNParser
+	limiter         *ParseLimiter
 	ready           bool
 	responseChannel chan string
+	eventPublisher  models.EngineEventListener
 }
 
 // NewComponent creates new parser component
 // Создает новый компонент парсера
 func NewComponent(cfg *config.Config, storage storage.Storage) *Component {
+	maxConcurrent, queueSize, queueTimeout := 4, 16, 5*time.Second
+	if cfg != nil {
+		maxConcurrent = cfg.BPMN.MaxConcurrentParses
+		queueSize = cfg.BPMN.ParseQueueSize
+		queueTimeout = time.Duration(cfg.BPMN.ParseQueueTimeoutMs) * time.Millisecond
+	}
+
 	return &Component{
 		config:          cfg,
 		storage:         storage,
 		parser:          NewBPMNParser(),
+		limiter:         NewParseLimiter(maxConcurrent, queueSize, queueTimeout),
 		ready:           false,
 		responseChannel: make(chan string, 100), // Buffered channel for parser responses
 	}
@@ -84,12 +95,26 @@ func (c *Component) IsReady() bool {
 	return c.ready
 }
 
-// ParseBPMNContent parses BPMN content and saves to storage
-// Парсит содержимое BPMN и сохраняет в storage
-func (c *Component) ParseBPMNContent(bpmnContent, processID string, force bool) (*ParseResult, error) {
+// ParseBPMNContent parses BPMN content and saves to storage with deployment metadata
+// Парсит содержимое BPMN и сохраняет в storage с метаданными развертывания
+func (c *Component) ParseBPMNContent(
+	bpmnContent, processID string,
+	force bool,
+	deployment *models.DeploymentInfo,
+	metadata *models.DefinitionMetadata,
+) (*ParseResult, error) {
 	if !c.ready {
 		return nil, fmt.Errorf("parser component not ready")
 	}
+	if err := metadata.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid definition metadata: %w", err)
+	}
+
+	if err := c.limiter.Acquire(); err != nil {
+		logger.Warn("BPMN parse rejected", logger.String("error", err.Error()))
+		return nil, err
+	}
+	defer c.limiter.Release()
 
 	logger.Info("Parsing BPMN content",
 		logger.String("content_length", fmt.Sprintf("%d", len(bpmnContent))),
@@ -105,6 +130,7 @@ func (c *Component) ParseBPMNContent(bpmnContent, processID string, force bool)
 	// Set additional metadata like in ParseBPMNFile
 	bpmnProcess.ParsedAt = time.Now()
 	bpmnProcess.Status = "active"
+	bpmnProcess.Deployment = deploymentOrNew(deployment)
 
 	// Determine correct version number - prefer XML version if available
 	// Определяем правильный номер версии - предпочитаем версию из XML если доступна
@@ -134,6 +160,11 @@ func (c *Component) ParseBPMNContent(bpmnContent, processID string, force bool)
 			logger.Int("previous_max_version", maxVersion))
 	}
 
+	// Copy metadata forward from previous version and apply metadata of request
+	if err := c.applyDefinitionMetadata(bpmnProcess, metadata); err != nil {
+		return nil, err
+	}
+
 	// Convert to JSON for storage
 	jsonData, err := bpmnProcess.ToJSON()
 	if err != nil {
@@ -147,6 +178,12 @@ func (c *Component) ParseBPMNContent(bpmnContent, processID string, force bool)
 		return nil, fmt.Errorf("failed to save BPMN process to storage: %w", err)
 	}
 
+	// Retain original source alongside parsed process
+	if err := c.saveOriginalSource(storageKey, bpmnProcess, []byte(bpmnContent)); err != nil {
+		return nil, err
+	}
+	c.publishDefinitionEvent(models.EngineEventProcessDeployed, storageKey, bpmnProcess)
+
 	// Save original content to filesystem (configured directory)
 	err = c.saveOriginalFile(bpmnProcess, []byte(bpmnContent))
 	if err != nil {
@@ -171,6 +208,7 @@ func (c *Component) ParseBPMNContent(bpmnContent, processID string, force bool)
 		ElementCounts:  bpmnProcess.ElementCounts,
 		Success:        true,
 		ParsedAt:       bpmnProcess.ParsedAt,
+		DeploymentID:   bpmnProcess.Deployment.DeploymentID,
 	}
 
 	logger.Info("BPMN content parsed successfully",
@@ -181,12 +219,20 @@ func (c *Component) ParseBPMNContent(bpmnContent, processID string, force bool)
 	return result, nil
 }
 
-// ParseBPMNFile parses BPMN file and saves to storage
-// Парсит BPMN файл и сохраняет в storage
-func (c *Component) ParseBPMNFile(filePath, processID string, force bool) (*ParseResult, error) {
+// ParseBPMNFile parses BPMN file and saves to storage with deployment metadata
+// Парсит BPMN файл и сохраняет в storage с метаданными развертывания
+func (c *Component) ParseBPMNFile(
+	filePath, processID string,
+	force bool,
+	deployment *models.DeploymentInfo,
+	metadata *models.DefinitionMetadata,
+) (*ParseResult, error) {
 	if !c.ready {
 		return nil, fmt.Errorf("parser component not ready")
 	}
+	if err := metadata.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid definition metadata: %w", err)
+	}
 
 	// Check if file exists
 	// Проверка существования файла
@@ -194,6 +240,12 @@ func (c *Component) ParseBPMNFile(filePath, processID string, force bool) (*Pars
 		return nil, fmt.Errorf("file not found: %s", filePath)
 	}
 
+	if err := c.limiter.Acquire(); err != nil {
+		logger.Warn("BPMN parse rejected", logger.String("error", err.Error()))
+		return nil, err
+	}
+	defer c.limiter.Release()
+
 	logger.Info("Parsing BPMN file",
 		logger.String("file", filePath),
 		logger.String("process_id", processID),
@@ -217,6 +269,7 @@ func (c *Component) ParseBPMNFile(filePath, processID string, force bool) (*Pars
 	// Установка дополнительных метаданных
 	bpmnProcess.ParsedAt = time.Now()
 	bpmnProcess.Status = "active"
+	bpmnProcess.Deployment = deploymentOrNew(deployment)
 
 	// Determine correct version number - prefer XML version if available
 	// Определяем правильный номер версии - предпочитаем версию из XML если доступна
@@ -246,6 +299,12 @@ func (c *Component) ParseBPMNFile(filePath, processID string, force bool) (*Pars
 			logger.Int("previous_max_version", maxVersion))
 	}
 
+	// Copy metadata forward from previous version and apply metadata of request
+	// Переносим метаданные предыдущей версии и применяем метаданные запроса
+	if err := c.applyDefinitionMetadata(bpmnProcess, metadata); err != nil {
+		return nil, err
+	}
+
 	// Convert to JSON for storage
 	// Конвертация в JSON для хранения
 	jsonData, err := bpmnProcess.ToJSON()
@@ -261,6 +320,13 @@ func (c *Component) ParseBPMNFile(filePath, processID string, force bool) (*Pars
 		return nil, fmt.Errorf("failed to save BPMN process to storage: %w", err)
 	}
 
+	// Retain original source alongside parsed process
+	// Сохраняем оригинальный исходник рядом с распарсенным процессом
+	if err := c.saveOriginalSource(storageKey, bpmnProcess, originalContent); err != nil {
+		return nil, err
+	}
+	c.publishDefinitionEvent(models.EngineEventProcessDeployed, storageKey, bpmnProcess)
+
 	// Save original file to filesystem (configured directory)
 	// Сохранение оригинального файла в файловую систему (настроенная директория)
 	err = c.saveOriginalFile(bpmnProcess, originalContent)
@@ -281,8 +347,9 @@ func (c *Component) ParseBPMNFile(filePath, processID string, force bool) (*Pars
 
 	// Log successful parsing
 	// Логирование успешного парсинга
-	err = c.storage.LogSystemEvent(models.EventTypeBPMNParse, models.StatusSuccess,
-		fmt.Sprintf("Successfully parsed BPMN file: %s -> %s", filePath, bpmnProcess.BPMNID))
+	err = c.storage.LogSystemEventWithFields(models.EventTypeBPMNParse, models.StatusSuccess,
+		fmt.Sprintf("Successfully parsed BPMN file: %s -> %s", filePath, bpmnProcess.BPMNID),
+		storage.SystemEventFields{Component: models.EventComponentParser})
 	if err != nil {
 		logger.Warn("Failed to log parse event", logger.String("error", err.Error()))
 	}
@@ -301,9 +368,54 @@ func (c *Component) ParseBPMNFile(filePath, processID string, force bool) (*Pars
 		ElementCounts:  bpmnProcess.ElementCounts,
 		ParsedAt:       bpmnProcess.ParsedAt,
 		Success:        true,
+		DeploymentID:   bpmnProcess.Deployment.DeploymentID,
 	}, nil
 }
 
+// deploymentOrNew returns deployment metadata of request or new deployment of unknown source
+// Возвращает метаданные развертывания запроса или новое развертывание неизвестного источника
+func deploymentOrNew(deployment *models.DeploymentInfo) *models.DeploymentInfo {
+	if deployment == nil {
+		return models.NewDeploymentInfo("", "", "")
+	}
+	if deployment.DeploymentID == "" {
+		deployment.DeploymentID = models.GenerateID()
+	}
+	if deployment.DeployedAt.IsZero() {
+		deployment.DeployedAt = time.Now()
+	}
+	return deployment
+}
+
+// applyDefinitionMetadata sets metadata and tags of new definition version
+// Metadata and tags are copied from latest stored version of same process unless request overrides them
+// Устанавливает метаданные и теги новой версии определения
+// Метаданные и теги переносятся из последней сохраненной версии того же процесса, если запрос их не перекрывает
+func (c *Component) applyDefinitionMetadata(
+	bpmnProcess *models.BPMNProcess,
+	request *models.DefinitionMetadata,
+) error {
+	var previous *models.BPMNProcess
+	maxVersion, err := c.storage.GetMaxProcessVersionByProcessID(bpmnProcess.ProcessID)
+	if err == nil && maxVersion > 0 {
+		storageKey := fmt.Sprintf("%s:v%d", bpmnProcess.ProcessID, maxVersion)
+		if jsonData, err := c.storage.LoadBPMNProcess(storageKey); err == nil {
+			var stored models.BPMNProcess
+			if err := stored.FromJSON(jsonData); err == nil {
+				previous = &stored
+			}
+		}
+	}
+
+	metadata, tags := models.MergeDefinitionMetadata(previous, request)
+	if err := models.ValidateDefinitionMetadata(metadata, tags); err != nil {
+		return fmt.Errorf("invalid definition metadata: %w", err)
+	}
+	bpmnProcess.Metadata = metadata
+	bpmnProcess.Tags = tags
+	return nil
+}
+
 // ListBPMNProcesses returns list of all BPMN processes
 // Возвращает список всех BPMN процессов
 func (c *Component) ListBPMNProcesses(limit int) ([]*ProcessInfo, error) {
@@ -349,6 +461,9 @@ func (c *Component) ListBPMNProcesses(limit int) ([]*ProcessInfo, error) {
 			TotalElements:  bpmnProcess.GetTotalElements(),
 			ParsedAt:       bpmnProcess.ParsedAt,
 			CreatedAt:      bpmnProcess.CreatedAt,
+			Deployment:     bpmnProcess.Deployment,
+			Metadata:       bpmnProcess.Metadata,
+			Tags:           bpmnProcess.Tags,
 		})
 
 		count++
@@ -406,6 +521,48 @@ func (c *Component) GetBPMNProcessJSON(processKey string) ([]byte, error) {
 	return jsonData, nil
 }
 
+// ImportBPMNProcess stores already parsed BPMN process if it does not exist
+// Returns false when process with same key is already deployed
+// Сохраняет уже распарсенный BPMN процесс, если он не существует
+func (c *Component) ImportBPMNProcess(processKey string, jsonData, bpmnXML []byte) (bool, error) {
+	if !c.ready {
+		return false, fmt.Errorf("parser component not ready")
+	}
+
+	if _, err := c.GetBPMNProcessJSON(processKey); err == nil {
+		return false, nil
+	}
+
+	var bpmnProcess models.BPMNProcess
+	if err := bpmnProcess.FromJSON(jsonData); err != nil {
+		return false, fmt.Errorf("failed to parse BPMN process data: %w", err)
+	}
+
+	storageKey := fmt.Sprintf("%s:v%d", bpmnProcess.ProcessID, bpmnProcess.ProcessVersion)
+	if err := c.storage.SaveBPMNProcess(storageKey, jsonData); err != nil {
+		return false, fmt.Errorf("failed to save BPMN process to storage: %w", err)
+	}
+
+	if len(bpmnXML) > 0 {
+		if err := c.saveOriginalSource(storageKey, &bpmnProcess, bpmnXML); err != nil {
+			return false, err
+		}
+		if err := c.saveOriginalFile(&bpmnProcess, bpmnXML); err != nil {
+			logger.Warn("Failed to save imported BPMN XML to filesystem",
+				logger.String("process_id", bpmnProcess.ProcessID),
+				logger.String("error", err.Error()))
+		}
+	}
+
+	c.publishDefinitionEvent(models.EngineEventProcessDeployed, storageKey, &bpmnProcess)
+
+	logger.Info("BPMN process imported",
+		logger.String("process_key", processKey),
+		logger.String("storage_key", storageKey))
+
+	return true, nil
+}
+
 // DeleteBPMNProcess deletes BPMN process
 // Удаляет BPMN процесс
 func (c *Component) DeleteBPMNProcess(processID string) error {
@@ -413,17 +570,31 @@ func (c *Component) DeleteBPMNProcess(processID string) error {
 		return fmt.Errorf("parser component not ready")
 	}
 
+	// Definition is loaded before deletion to announce what was deleted
+	// Process key is resolved to storage key the same way as in GetBPMNProcessDetails
+	// Определение загружается до удаления, чтобы сообщить что было удалено
+	// Ключ процесса приводится к ключу storage так же, как в GetBPMNProcessDetails
+	var deleted *models.BPMNProcess
+	if details, err := c.GetBPMNProcessDetails(processID); err == nil {
+		deleted = details
+		processID = fmt.Sprintf("%s:v%d", details.ProcessID, details.ProcessVersion)
+	}
+
 	// Delete from storage
 	// Удаление из storage
 	err := c.storage.DeleteBPMNProcess(processID)
 	if err != nil {
 		return fmt.Errorf("failed to delete BPMN process: %w", err)
 	}
+	if deleted != nil {
+		c.publishDefinitionEvent(models.EngineEventProcessDeleted, processID, deleted)
+	}
 
 	// Log deletion
 	// Логирование удаления
-	err = c.storage.LogSystemEvent(models.EventTypeBPMNDelete, models.StatusSuccess,
-		fmt.Sprintf("Successfully deleted BPMN process: %s", processID))
+	err = c.storage.LogSystemEventWithFields(models.EventTypeBPMNDelete, models.StatusSuccess,
+		fmt.Sprintf("Successfully deleted BPMN process: %s", processID),
+		storage.SystemEventFields{Component: models.EventComponentParser})
 	if err != nil {
 		logger.Warn("Failed to log delete event", logger.String("error", err.Error()))
 	}
@@ -451,6 +622,7 @@ func (c *Component) GetBPMNStats() (*BPMNStats, error) {
 		ElementCounts:  make(map[string]int),
 		StatusCounts:   make(map[string]int),
 		ParsedToday:    0,
+		Concurrency:    c.limiter.Stats(),
 	}
 
 	// Get today's date for comparison
@@ -503,6 +675,21 @@ func (c *Component) getBPMNPath() string {
 	return "bpmn_test"
 }
 
+// saveOriginalSource stores original BPMN source under process storage key
+// Сохраняет оригинальный исходник BPMN под ключом процесса в storage
+func (c *Component) saveOriginalSource(storageKey string, bpmnProcess *models.BPMNProcess, content []byte) error {
+	if err := c.storage.SaveBPMNFile(storageKey, originalFilename(bpmnProcess), content); err != nil {
+		return fmt.Errorf("failed to save original BPMN source to storage: %w", err)
+	}
+	return nil
+}
+
+// originalFilename returns file name of original BPMN source
+// Возвращает имя файла оригинального исходника BPMN
+func originalFilename(bpmnProcess *models.BPMNProcess) string {
+	return fmt.Sprintf("%s_v%d.bpmn", bpmnProcess.ProcessID, bpmnProcess.ProcessVersion)
+}
+
 // saveOriginalFile saves original BPMN file to configured directory
 // Сохраняет оригинальный BPMN файл в настроенную директорию
 func (c *Component) saveOriginalFile(bpmnProcess *models.BPMNProcess, content []byte) error {
@@ -517,7 +704,7 @@ func (c *Component) saveOriginalFile(bpmnProcess *models.BPMNProcess, content []
 
 	// Generate filename
 	// Генерация имени файла
-	filename := fmt.Sprintf("%s_v%d.bpmn", bpmnProcess.ProcessID, bpmnProcess.ProcessVersion)
+	filename := originalFilename(bpmnProcess)
 	filePath := filepath.Join(bpmnPath, filename)
 
 	// Write file
@@ -570,6 +757,7 @@ type ParseResult struct {
 	ElementCounts  map[string]int `json:"element_counts"`
 	ParsedAt       time.Time      `json:"parsed_at"`
 	Success        bool           `json:"success"`
+	DeploymentID   string         `json:"deployment_id"`
 }
 
 // ProcessInfo represents brief information about BPMN process
@@ -584,6 +772,10 @@ type ProcessInfo struct {
 	TotalElements  int       `json:"total_elements"`
 	ParsedAt       time.Time `json:"parsed_at"`
 	CreatedAt      time.Time `json:"created_at"`
+
+	Deployment *models.DeploymentInfo `json:"deployment,omitempty"`
+	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	Tags       []string               `json:"tags,omitempty"`
 }
 
 // BPMNStats represents BPMN parser statistics
@@ -594,6 +786,8 @@ type BPMNStats struct {
 	ElementCounts  map[string]int `json:"element_counts"`
 	StatusCounts   map[string]int `json:"status_counts"`
 	ParsedToday    int            `json:"parsed_today"`
+
+	Concurrency ParseConcurrencyStats `json:"concurrency"`
 }
 
 // ProcessMessage processes JSON message from core engine
@@ -648,11 +842,12 @@ func (c *Component) handleParseBPMNFile(ctx context.Context, request ParserReque
 		return c.sendResponse(response)
 	}
 
-	result, err := c.ParseBPMNFile(payload.FilePath, payload.ProcessID, payload.Force)
+	result, err := c.ParseBPMNFile(
+		payload.FilePath, payload.ProcessID, payload.Force, payload.Deployment, payload.Metadata)
 
 	var response ParserResponse
 	if err != nil {
-		response = CreateParserErrorResponse("parse_bpmn_file_response", request.RequestID, err.Error())
+		response = CreateParserErrorResponseFromError("parse_bpmn_file_response", request.RequestID, err)
 	} else {
 		parseResult := JSONParseResult{
 			ProcessKey:     result.BPMNID,
@@ -662,6 +857,7 @@ func (c *Component) handleParseBPMNFile(ctx context.Context, request ParserReque
 			ElementsCount:  result.TotalElements,
 			Success:        result.Success,
 			Message:        "BPMN file parsed successfully",
+			DeploymentID:   result.DeploymentID,
 			ProcessData:    map[string]interface{}{"element_counts": result.ElementCounts},
 			Timestamp:      result.ParsedAt.Unix(),
 		}
@@ -684,11 +880,12 @@ func (c *Component) handleParseBPMNContent(ctx context.Context, request ParserRe
 		return c.sendResponse(response)
 	}
 
-	result, err := c.ParseBPMNContent(payload.BPMNContent, payload.ProcessID, payload.Force)
+	result, err := c.ParseBPMNContent(
+		payload.BPMNContent, payload.ProcessID, payload.Force, payload.Deployment, payload.Metadata)
 
 	var response ParserResponse
 	if err != nil {
-		response = CreateParserErrorResponse("parse_bpmn_content_response", request.RequestID, err.Error())
+		response = CreateParserErrorResponseFromError("parse_bpmn_content_response", request.RequestID, err)
 	} else {
 		parseResult := JSONParseResult{
 			ProcessKey:     result.BPMNID,
@@ -697,6 +894,7 @@ func (c *Component) handleParseBPMNContent(ctx context.Context, request ParserRe
 			ElementsCount:  result.TotalElements,
 			Success:        result.Success,
 			Message:        "BPMN content parsed successfully",
+			DeploymentID:   result.DeploymentID,
 			ProcessData:    map[string]interface{}{"element_counts": result.ElementCounts},
 			Timestamp:      result.ParsedAt.Unix(),
 		}
@@ -722,14 +920,20 @@ func (c *Component) handleValidateBPMN(ctx context.Context, request ParserReques
 	// Validation by parsing - validates XML structure and BPMN elements
 	var err error
 	if payload.FilePath != "" {
-		_, err = c.ParseBPMNFile(payload.FilePath, "", false)
+		_, err = c.ParseBPMNFile(payload.FilePath, "", false, nil, nil)
 	} else if payload.BPMNContent != "" {
 		// Use existing ParseBPMNContent for content validation
-		_, err = c.ParseBPMNContent(payload.BPMNContent, "", false)
+		_, err = c.ParseBPMNContent(payload.BPMNContent, "", false, nil, nil)
 	} else {
 		err = fmt.Errorf("neither file path nor content provided for validation")
 	}
 
+	// Busy parser says nothing about diagram, so it is reported as error, not as invalid BPMN
+	// Занятый парсер ничего не говорит о диаграмме, поэтому это ошибка, а не невалидный BPMN
+	if errors.Is(err, ErrParserBusy) {
+		return c.sendResponse(CreateParserErrorResponseFromError("validate_bpmn_response", request.RequestID, err))
+	}
+
 	validationResult := ValidationResult{
 		Valid:   err == nil,
 		Message: "BPMN validation completed",
@@ -760,7 +964,7 @@ func (c *Component) handleGetProcessInfo(ctx context.Context, request ParserRequ
 
 	var response ParserResponse
 	if err != nil {
-		response = CreateParserErrorResponse("get_process_info_response", request.RequestID, err.Error())
+		response = CreateParserErrorResponseFromError("get_process_info_response", request.RequestID, err)
 	} else {
 		processInfo := ProcessInfoResult{
 			ProcessKey:     bpmnProcess.BPMNID,
@@ -796,7 +1000,7 @@ func (c *Component) handleListProcesses(ctx context.Context, request ParserReque
 
 	var response ParserResponse
 	if err != nil {
-		response = CreateParserErrorResponse("list_processes_response", request.RequestID, err.Error())
+		response = CreateParserErrorResponseFromError("list_processes_response", request.RequestID, err)
 	} else {
 		// Convert to ProcessInfoResult slice
 		processInfoResults := make([]ProcessInfoResult, len(processes))
@@ -842,7 +1046,7 @@ func (c *Component) handleDeleteProcess(ctx context.Context, request ParserReque
 
 	var response ParserResponse
 	if err != nil {
-		response = CreateParserErrorResponse("delete_process_response", request.RequestID, err.Error())
+		response = CreateParserErrorResponseFromError("delete_process_response", request.RequestID, err)
 	} else {
 		deleteResult := DeleteResult{
 			ProcessID: payload.ProcessID,
@@ -863,7 +1067,7 @@ func (c *Component) handleGetStats(ctx context.Context, request ParserRequest) e
 
 	var response ParserResponse
 	if err != nil {
-		response = CreateParserErrorResponse("get_stats_response", request.RequestID, err.Error())
+		response = CreateParserErrorResponseFromError("get_stats_response", request.RequestID, err)
 	} else {
 		statsResult := ParserStatsResult{
 			TotalProcesses:  stats.TotalProcesses,
@@ -913,39 +1117,70 @@ func (c *Component) GetResponseChannel() <-chan string {
 // GetBPMNProcessXML returns original BPMN XML content for BPMN process
 // Возвращает оригинальное BPMN XML содержимое для BPMN процесса
 func (c *Component) GetBPMNProcessXML(processKey string) ([]byte, error) {
+	xmlContent, _, err := c.GetBPMNProcessXMLByVersion(processKey, 0)
+	return xmlContent, err
+}
+
+// GetBPMNProcessXMLByVersion returns original BPMN XML of process version
+// Version 0 selects version identified by process key
+// Возвращает оригинальный BPMN XML указанной версии процесса
+// Версия 0 выбирает версию, определяемую ключом процесса
+func (c *Component) GetBPMNProcessXMLByVersion(processKey string, version int) ([]byte, *models.BPMNProcess, error) {
 	if !c.ready {
-		return nil, fmt.Errorf("parser component not ready")
+		return nil, nil, fmt.Errorf("parser component not ready")
 	}
 
 	logger.Debug("Getting BPMN process XML",
-		logger.String("process_key", processKey))
+		logger.String("process_key", processKey),
+		logger.Int("version", version))
 
 	// First, get the process details to extract ProcessID and Version
 	// Сначала получаем детали процесса для извлечения ProcessID и Version
 	processDetails, err := c.GetBPMNProcessDetails(processKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get BPMN process details: %w", err)
+		return nil, nil, fmt.Errorf("failed to get BPMN process details: %w", err)
 	}
 
-	// Build file path using ProcessID and Version
-	// Строим путь к файлу используя ProcessID и Version
-	bpmnPath := c.getBPMNPath()
-	filename := fmt.Sprintf("%s_v%d.bpmn", processDetails.ProcessID, processDetails.ProcessVersion)
-	filePath := filepath.Join(bpmnPath, filename)
+	// Switch to requested version of same process
+	// Переключаемся на запрошенную версию того же процесса
+	if version > 0 && version != processDetails.ProcessVersion {
+		jsonData, _, err := c.storage.LoadBPMNProcessByProcessID(processDetails.ProcessID, version)
+		if err != nil {
+			return nil, nil, fmt.Errorf("BPMN process %s version %d not found: %w",
+				processDetails.ProcessID, version, err)
+		}
+		versionDetails := &models.BPMNProcess{}
+		if err := versionDetails.FromJSON(jsonData); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse BPMN process data: %w", err)
+		}
+		processDetails = versionDetails
+	}
+
+	// Original source retained in storage
+	// Оригинальный исходник, сохраненный в storage
+	storageKey := fmt.Sprintf("%s:v%d", processDetails.ProcessID, processDetails.ProcessVersion)
+	xmlContent, err := c.storage.LoadBPMNFile(storageKey)
+	if err == nil {
+		return xmlContent, processDetails, nil
+	}
+
+	// Processes parsed before source retention keep XML on filesystem only
+	// Процессы, распарсенные до хранения исходников, имеют XML только в файловой системе
+	filename := originalFilename(processDetails)
+	filePath := filepath.Join(c.getBPMNPath(), filename)
 
 	logger.Debug("Reading BPMN XML file",
 		logger.String("file_path", filePath),
 		logger.String("process_id", processDetails.ProcessID),
 		logger.Int("version", processDetails.ProcessVersion))
 
-	// Read the original BPMN file from filesystem
-	// Читаем оригинальный BPMN файл из файловой системы
-	xmlContent, err := ioutil.ReadFile(filePath)
+	xmlContent, err = ioutil.ReadFile(filePath)
 	if err != nil {
 		if os.IsNotExist(err) {
-			return nil, fmt.Errorf("BPMN XML file not found: %s (file may have been moved or deleted after parsing)", filename)
+			return nil, nil, fmt.Errorf(
+				"BPMN XML file not found: %s (file may have been moved or deleted after parsing)", filename)
 		}
-		return nil, fmt.Errorf("failed to read BPMN XML file: %w", err)
+		return nil, nil, fmt.Errorf("failed to read BPMN XML file: %w", err)
 	}
 
 	logger.Info("Successfully read BPMN XML file",
@@ -954,5 +1189,5 @@ func (c *Component) GetBPMNProcessXML(processKey string) ([]byte, error) {
 		logger.Int("version", processDetails.ProcessVersion),
 		logger.Int("file_size", len(xmlContent)))
 
-	return xmlContent, nil
+	return xmlContent, processDetails, nil
 }
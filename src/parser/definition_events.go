@@ -0,0 +1,61 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package parser
+
+import (
+	"fmt"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+)
+
+// SetEventPublisher sets publisher of process definition events
+// Устанавливает издателя событий определений процессов
+func (c *Component) SetEventPublisher(publisher models.EngineEventListener) {
+	c.eventPublisher = publisher
+}
+
+// publishDefinitionEvent announces deployed, updated or deleted process definition
+// Сообщает о развернутом, измененном или удаленном определении процесса
+func (c *Component) publishDefinitionEvent(eventType, storageKey string, bpmnProcess *models.BPMNProcess) {
+	if c.eventPublisher == nil {
+		return
+	}
+	c.eventPublisher(models.NewProcessDefinitionEvent(eventType, storageKey, bpmnProcess))
+}
+
+// SetTimerStartEnabled enables or disables scheduling of timer start events of process definition
+// Включает или выключает планирование стартовых событий таймера определения процесса
+func (c *Component) SetTimerStartEnabled(processKey string, enabled bool) (*models.BPMNProcess, error) {
+	bpmnProcess, err := c.GetBPMNProcessDetails(processKey)
+	if err != nil {
+		return nil, err
+	}
+
+	bpmnProcess.TimerStartDisabled = !enabled
+	bpmnProcess.UpdatedAt = time.Now()
+
+	jsonData, err := bpmnProcess.ToJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert to JSON: %w", err)
+	}
+
+	storageKey := fmt.Sprintf("%s:v%d", bpmnProcess.ProcessID, bpmnProcess.ProcessVersion)
+	if err := c.storage.SaveBPMNProcess(storageKey, jsonData); err != nil {
+		return nil, fmt.Errorf("failed to save BPMN process to storage: %w", err)
+	}
+	c.publishDefinitionEvent(models.EngineEventProcessUpdated, storageKey, bpmnProcess)
+
+	logger.Info("Timer start events toggled",
+		logger.String("process_key", storageKey),
+		logger.Bool("enabled", enabled))
+
+	return bpmnProcess, nil
+}
@@ -10,7 +10,10 @@ package parser
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+
+	"atom-engine/src/core/models"
 )
 
 // CreateParseBPMNFileMessage creates JSON message for BPMN file parsing
@@ -148,3 +151,15 @@ func CreateParserErrorResponse(responseType, requestID, errorMsg string) ParserR
 		Error:     errorMsg,
 	}
 }
+
+// CreateParserErrorResponseFromError creates error parser response with error code derived from err
+// Создает ответ парсера с ошибкой и кодом ошибки, определенным по err
+func CreateParserErrorResponseFromError(responseType, requestID string, err error) ParserResponse {
+	response := CreateParserErrorResponse(responseType, requestID, err.Error())
+	if errors.Is(err, ErrParserBusy) {
+		response.ErrorCode = ErrorCodeParserBusy
+	} else {
+		response.ErrorCode = models.ErrorCodeOf(err)
+	}
+	return response
+}
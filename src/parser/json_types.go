@@ -8,6 +8,8 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 
 package parser
 
+import "atom-engine/src/core/models"
+
 // ParserRequest base structure for all parser requests
 // Базовая структура для всех запросов парсера
 type ParserRequest struct {
@@ -24,22 +26,29 @@ type ParserResponse struct {
 	Success   bool        `json:"success"`
 	Result    interface{} `json:"result,omitempty"`
 	Error     string      `json:"error,omitempty"`
+	ErrorCode string      `json:"error_code,omitempty"` // Machine readable reason, e.g. PARSER_BUSY or NOT_FOUND
 }
 
 // ParseBPMNFilePayload payload for parsing BPMN file
 // Payload для парсинга BPMN файла
 type ParseBPMNFilePayload struct {
-	FilePath  string `json:"file_path"`
-	ProcessID string `json:"process_id,omitempty"`
-	Force     bool   `json:"force,omitempty"`
+	FilePath   string                 `json:"file_path"`
+	ProcessID  string                 `json:"process_id,omitempty"`
+	Force      bool                   `json:"force,omitempty"`
+	Deployment *models.DeploymentInfo `json:"deployment,omitempty"`
+
+	Metadata *models.DefinitionMetadata `json:"metadata,omitempty"`
 }
 
 // ParseBPMNContentPayload payload for parsing BPMN content
 // Payload для парсинга содержимого BPMN
 type ParseBPMNContentPayload struct {
-	BPMNContent string `json:"bpmn_content"`
-	ProcessID   string `json:"process_id,omitempty"`
-	Force       bool   `json:"force,omitempty"`
+	BPMNContent string                 `json:"bpmn_content"`
+	ProcessID   string                 `json:"process_id,omitempty"`
+	Force       bool                   `json:"force,omitempty"`
+	Deployment  *models.DeploymentInfo `json:"deployment,omitempty"`
+
+	Metadata *models.DefinitionMetadata `json:"metadata,omitempty"`
 }
 
 // ValidateBPMNPayload payload for validating BPMN
@@ -83,6 +92,7 @@ type JSONParseResult struct {
 	ValidationErrors []string               `json:"validation_errors,omitempty"`
 	ProcessData      map[string]interface{} `json:"process_data,omitempty"`
 	Timestamp        int64                  `json:"timestamp,omitempty"`
+	DeploymentID     string                 `json:"deployment_id,omitempty"`
 }
 
 // ValidationResult result structure for validation operations
@@ -203,6 +203,14 @@ func (p *MetadataParser) parseTaskDefinition(element *XMLElement) map[string]int
 			} else {
 				taskDef["retries"] = attr.Value
 			}
+		case "priority":
+			if priority, err := strconv.Atoi(attr.Value); err == nil {
+				taskDef["priority"] = priority
+			} else {
+				taskDef["priority"] = attr.Value
+			}
+		case "retryTimeCycle":
+			taskDef["retryTimeCycle"] = attr.Value
 		}
 	}
 
@@ -0,0 +1,122 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrorCodeParserBusy is set in parser response when parse was rejected by limiter
+// Код ошибки в ответе парсера, когда парсинг отклонен ограничителем
+const ErrorCodeParserBusy = "PARSER_BUSY"
+
+// ErrParserBusy is returned when all parse slots are taken and queue is full or wait timed out
+// Возвращается, когда все слоты парсинга заняты и очередь заполнена или истекло ожидание
+var ErrParserBusy = errors.New("parser busy")
+
+// ParseConcurrencyStats is snapshot of parse limiter state
+// Снимок состояния ограничителя парсинга
+type ParseConcurrencyStats struct {
+	Active        int   `json:"active"`
+	MaxConcurrent int   `json:"max_concurrent"`
+	Queued        int   `json:"queued"`
+	QueueSize     int   `json:"queue_size"`
+	Rejected      int64 `json:"rejected"`
+}
+
+// ParseLimiter bounds number of concurrent parse operations
+// Excess parses wait in bounded queue for limited time
+// Ограничивает количество одновременных операций парсинга
+type ParseLimiter struct {
+	slots     chan struct{}
+	queueSize int
+	timeout   time.Duration
+
+	mu       sync.Mutex
+	queued   int
+	rejected int64
+}
+
+// NewParseLimiter creates parse limiter
+// Создает ограничитель парсинга
+func NewParseLimiter(maxConcurrent, queueSize int, timeout time.Duration) *ParseLimiter {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	return &ParseLimiter{
+		slots:     make(chan struct{}, maxConcurrent),
+		queueSize: queueSize,
+		timeout:   timeout,
+	}
+}
+
+// Acquire takes parse slot, waiting in queue if all slots are busy
+// Every successful Acquire must be paired with Release
+// Занимает слот парсинга, ожидая в очереди если все слоты заняты
+func (l *ParseLimiter) Acquire() error {
+	select {
+	case l.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	l.mu.Lock()
+	if l.queued >= l.queueSize {
+		l.rejected++
+		l.mu.Unlock()
+		return fmt.Errorf("%w: %d parses running and %d queued", ErrParserBusy, cap(l.slots), l.queueSize)
+	}
+	l.queued++
+	l.mu.Unlock()
+
+	timer := time.NewTimer(l.timeout)
+	defer timer.Stop()
+
+	select {
+	case l.slots <- struct{}{}:
+		l.mu.Lock()
+		l.queued--
+		l.mu.Unlock()
+		return nil
+	case <-timer.C:
+		l.mu.Lock()
+		l.queued--
+		l.rejected++
+		l.mu.Unlock()
+		return fmt.Errorf("%w: no parse slot freed within %s", ErrParserBusy, l.timeout)
+	}
+}
+
+// Release frees parse slot taken by Acquire
+// Освобождает слот парсинга, занятый Acquire
+func (l *ParseLimiter) Release() {
+	<-l.slots
+}
+
+// Stats returns current limiter state
+// Возвращает текущее состояние ограничителя
+func (l *ParseLimiter) Stats() ParseConcurrencyStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return ParseConcurrencyStats{
+		Active:        len(l.slots),
+		MaxConcurrent: cap(l.slots),
+		Queued:        l.queued,
+		QueueSize:     l.queueSize,
+		Rejected:      l.rejected,
+	}
+}
@@ -207,6 +207,13 @@ func (p *BPMNParser) ParseBPMNContent(bpmnContent, processID string, force bool)
 		return nil, fmt.Errorf("failed to parse XML structure: %w", err)
 	}
 
+	// Validate timer cycles
+	if err := p.validateTimeCycles(xmlRoot, ""); err != nil {
+		logger.Error("Invalid timer definition",
+			logger.String("error", err.Error()))
+		return nil, err
+	}
+
 	// Create process data model
 	if processID == "" {
 		processID = p.extractProcessIDFromXML(xmlRoot)
@@ -296,6 +303,15 @@ func (p *BPMNParser) ParseBPMNFile(filePath, processID string, force bool) (*mod
 		return nil, fmt.Errorf("failed to parse XML structure: %w", err)
 	}
 
+	// Validate timer cycles
+	// Проверка циклов таймеров
+	if err := p.validateTimeCycles(xmlRoot, ""); err != nil {
+		logger.Error("Invalid timer definition",
+			logger.String("file", filePath),
+			logger.String("error", err.Error()))
+		return nil, err
+	}
+
 	// Create process data model
 	// Создание модели данных процесса
 	if processID == "" {
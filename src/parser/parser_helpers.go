@@ -11,8 +11,10 @@ package parser
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"atom-engine/src/core/models"
+	"atom-engine/src/timewheel"
 )
 
 // isDiagramElement checks if element is a diagram element
@@ -129,6 +131,32 @@ func (p *BPMNParser) parseGenericElement(element *XMLElement) map[string]interfa
 	return result
 }
 
+// validateTimeCycles checks cron timeCycle definitions so that invalid schedule fails deployment
+// ISO 8601 cycles and expressions are checked when timer is scheduled
+// Проверяет cron определения timeCycle, чтобы неверное расписание не прошло развертывание
+// ISO 8601 циклы и выражения проверяются при планировании таймера
+func (p *BPMNParser) validateTimeCycles(element *XMLElement, ownerID string) error {
+	if id := p.getElementID(element); id != "" && !strings.HasSuffix(element.XMLName.Local, "EventDefinition") {
+		ownerID = id
+	}
+
+	if element.XMLName.Local == "timeCycle" {
+		cycle := strings.TrimSpace(element.Text)
+		if timewheel.IsCronExpression(cycle) {
+			if _, err := timewheel.ParseCron(cycle); err != nil {
+				return fmt.Errorf("invalid timeCycle of element %s: %w", ownerID, err)
+			}
+		}
+	}
+
+	for _, child := range element.Children {
+		if err := p.validateTimeCycles(child, ownerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // getElementID extracts ID attribute from element
 // Извлекает ID атрибут из элемента
 func (p *BPMNParser) getElementID(element *XMLElement) string {
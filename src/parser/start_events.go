@@ -0,0 +1,155 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package parser
+
+import (
+	"sort"
+	"strings"
+
+	"atom-engine/src/core/models"
+)
+
+// Start event trigger types
+// Типы триггеров стартовых событий
+const (
+	StartTriggerNone        = "none"
+	StartTriggerTimer       = "timer"
+	StartTriggerMessage     = "message"
+	StartTriggerSignal      = "signal"
+	StartTriggerConditional = "conditional"
+)
+
+// StartEventTimer describes timer of timer start event
+// Описывает таймер стартового события таймера
+type StartEventTimer struct {
+	Type  string `json:"type"`  // duration, date or cycle
+	Value string `json:"value"` // ISO 8601 value, cron expression or FEEL expression
+}
+
+// StartEventInfo describes top-level start event of process and how it is triggered
+// Описывает стартовое событие верхнего уровня процесса и способ его запуска
+type StartEventInfo struct {
+	ElementID   string           `json:"element_id"`
+	Name        string           `json:"name,omitempty"`
+	TriggerType string           `json:"trigger_type"`
+	Timer       *StartEventTimer `json:"timer,omitempty"`
+	MessageRef  string           `json:"message_ref,omitempty"`
+	MessageName string           `json:"message_name,omitempty"`
+	SignalRef   string           `json:"signal_ref,omitempty"`
+	SignalName  string           `json:"signal_name,omitempty"`
+	Condition   string           `json:"condition,omitempty"`
+}
+
+// GetProcessStartEvents returns process definition and its top-level start events
+// Возвращает определение процесса и его стартовые события верхнего уровня
+func (c *Component) GetProcessStartEvents(processKey string) (*models.BPMNProcess, []StartEventInfo, error) {
+	bpmnProcess, err := c.GetBPMNProcessDetails(processKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bpmnProcess, ExtractStartEvents(bpmnProcess.Elements), nil
+}
+
+// ExtractStartEvents derives start events from parsed element graph
+// Start events nested in subprocesses and event subprocesses do not start process and are skipped
+// Получает стартовые события из графа распарсенных элементов
+// Стартовые события вложенных подпроцессов и событийных подпроцессов не запускают процесс и пропускаются
+func ExtractStartEvents(elements map[string]interface{}) []StartEventInfo {
+	startEvents := make([]StartEventInfo, 0)
+	for elementID, item := range elements {
+		element, ok := item.(map[string]interface{})
+		if !ok || element["type"] != "startEvent" {
+			continue
+		}
+		if scope, _ := element["parent_scope"].(string); scope != "" {
+			continue
+		}
+
+		info := StartEventInfo{ElementID: elementID, TriggerType: StartTriggerNone}
+		info.Name, _ = element["name"].(string)
+		if definition, ok := firstEventDefinition(element); ok {
+			fillStartTrigger(&info, definition, elements)
+		}
+		startEvents = append(startEvents, info)
+	}
+
+	sort.Slice(startEvents, func(i, j int) bool {
+		return startEvents[i].ElementID < startEvents[j].ElementID
+	})
+	return startEvents
+}
+
+// firstEventDefinition returns first event definition of element
+// Возвращает первое определение события элемента
+func firstEventDefinition(element map[string]interface{}) (map[string]interface{}, bool) {
+	switch definitions := element["event_definitions"].(type) {
+	case []interface{}:
+		for _, item := range definitions {
+			if definition, ok := item.(map[string]interface{}); ok {
+				return definition, true
+			}
+		}
+	case []map[string]interface{}:
+		if len(definitions) > 0 {
+			return definitions[0], true
+		}
+	}
+	return nil, false
+}
+
+// fillStartTrigger sets trigger details from event definition
+// Заполняет детали триггера из определения события
+func fillStartTrigger(info *StartEventInfo, definition map[string]interface{}, elements map[string]interface{}) {
+	reference, _ := definition["reference"].(string)
+
+	switch definition["type"] {
+	case "timerEventDefinition":
+		info.TriggerType = StartTriggerTimer
+		timer, _ := definition["timer"].(map[string]interface{})
+		for _, timerType := range []string{"cycle", "date", "duration"} {
+			if value, ok := timer[timerType].(string); ok && value != "" {
+				info.Timer = &StartEventTimer{Type: timerType, Value: value}
+				break
+			}
+		}
+	case "messageEventDefinition":
+		info.TriggerType = StartTriggerMessage
+		if reference == "" {
+			message, _ := definition["message"].(map[string]interface{})
+			reference, _ = message["message_ref"].(string)
+		}
+		info.MessageRef = reference
+		info.MessageName = referencedName(elements, reference, "message")
+	case "signalEventDefinition":
+		info.TriggerType = StartTriggerSignal
+		info.SignalRef = reference
+		info.SignalName = referencedName(elements, reference, "signal")
+	case "conditionalEventDefinition":
+		info.TriggerType = StartTriggerConditional
+		condition, _ := definition["condition"].(map[string]interface{})
+		info.Condition, _ = condition["expression"].(string)
+	default:
+		definitionType, _ := definition["type"].(string)
+		info.TriggerType = strings.TrimSuffix(definitionType, "EventDefinition")
+	}
+}
+
+// referencedName returns name of referenced message or signal, falling back to reference itself
+// Возвращает имя сообщения или сигнала по ссылке, при отсутствии возвращает саму ссылку
+func referencedName(elements map[string]interface{}, reference, elementType string) string {
+	if reference == "" {
+		return ""
+	}
+	if element, ok := elements[reference].(map[string]interface{}); ok && element["type"] == elementType {
+		if name, _ := element["name"].(string); name != "" {
+			return name
+		}
+	}
+	return reference
+}
@@ -149,9 +149,62 @@ func (p *TaskParser) Parse(element *XMLElement, context *ParseContext) (map[stri
 		result["io_specification"] = ioSpec
 	}
 
+	// Parse multi-instance loop characteristics, engine runs instances of element from them
+	// Парсинг характеристик multi-instance цикла, по ним движок запускает экземпляры элемента
+	for _, child := range element.Children {
+		if child.XMLName.Local == "multiInstanceLoopCharacteristics" {
+			result["multi_instance"] = p.parseMultiInstance(child)
+		}
+	}
+
 	return result, nil
 }
 
+// parseMultiInstance parses multi-instance loop characteristics with zeebe collection settings
+// Парсинг характеристик multi-instance цикла с настройками коллекций zeebe
+func (p *TaskParser) parseMultiInstance(element *XMLElement) map[string]interface{} {
+	multiInstance := map[string]interface{}{
+		"is_sequential": false,
+	}
+
+	for _, attr := range element.Attributes {
+		if attr.Name.Local == "isSequential" {
+			if sequential, err := strconv.ParseBool(attr.Value); err == nil {
+				multiInstance["is_sequential"] = sequential
+			}
+		}
+	}
+
+	for _, child := range element.Children {
+		switch child.XMLName.Local {
+		case "loopCardinality":
+			multiInstance["loop_cardinality"] = child.Text
+		case "completionCondition":
+			multiInstance["completion_condition"] = child.Text
+		case "extensionElements":
+			for _, ext := range child.Children {
+				if ext.XMLName.Local != "loopCharacteristics" {
+					continue
+				}
+				for _, attr := range ext.Attributes {
+					switch attr.Name.Local {
+					case "inputCollection":
+						multiInstance["input_collection"] = attr.Value
+					case "inputElement":
+						multiInstance["input_element"] = attr.Value
+					case "outputCollection":
+						multiInstance["output_collection"] = attr.Value
+					case "outputElement":
+						multiInstance["output_element"] = attr.Value
+					}
+				}
+			}
+		}
+	}
+
+	return multiInstance
+}
+
 // parseScriptTask parses script task specific elements
 // Парсинг специфичных элементов скриптовой задачи
 func (p *TaskParser) parseScriptTask(element *XMLElement) map[string]interface{} {
@@ -460,6 +513,8 @@ func (p *TaskParser) parseZeebeTaskDefinition(element *XMLElement) map[string]in
 			} else {
 				taskDef["retries"] = attr.Value
 			}
+		case "retryTimeCycle":
+			taskDef["retryTimeCycle"] = attr.Value
 		}
 	}
 
@@ -0,0 +1,253 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+)
+
+// batchStartRetention is how long finished background batches stay available for polling
+// Время, в течение которого завершенные фоновые пакеты доступны для опроса
+const batchStartRetention = time.Hour
+
+// businessKeyContextKey is context key for business key of instance being started
+// Ключ контекста для бизнес-ключа запускаемого экземпляра
+type businessKeyContextKey struct{}
+
+// withBusinessKey returns context carrying business key stored in metadata of started instance
+// Возвращает контекст с бизнес-ключом, сохраняемым в метаданных запущенного экземпляра
+func withBusinessKey(ctx context.Context, businessKey string) context.Context {
+	if businessKey == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, businessKeyContextKey{}, businessKey)
+}
+
+// businessKeyFromContext returns business key from context, empty when not set
+// Возвращает бизнес-ключ из контекста, пустой если не задан
+func businessKeyFromContext(ctx context.Context) string {
+	businessKey, _ := ctx.Value(businessKeyContextKey{}).(string)
+	return businessKey
+}
+
+// BatchStarter starts process instances in batches on bounded number of workers
+// Instances started before failure of other items are kept, batch is never rolled back
+// Запускает экземпляры процессов пакетами на ограниченном числе воркеров
+// Экземпляры, запущенные до ошибки других элементов, сохраняются, пакет не откатывается
+type BatchStarter struct {
+	component ComponentInterface
+	workers   int
+	maxItems  int
+	stopCh    <-chan struct{}
+
+	mu      sync.Mutex
+	batches map[string]*models.BatchStart
+}
+
+// NewBatchStarter creates batch starter, stopCh aborts pending items of background batches
+// Создает пакетный стартер, stopCh прерывает ожидающие элементы фоновых пакетов
+func NewBatchStarter(component ComponentInterface, workers, maxItems int, stopCh <-chan struct{}) *BatchStarter {
+	return &BatchStarter{
+		component: component,
+		workers:   workers,
+		maxItems:  maxItems,
+		stopCh:    stopCh,
+		batches:   make(map[string]*models.BatchStart),
+	}
+}
+
+// Start starts all items and returns finished batch
+// Запускает все элементы и возвращает завершенный пакет
+func (bs *BatchStarter) Start(ctx context.Context, items []models.BatchStartItem) (*models.BatchStart, error) {
+	batch, err := bs.newBatch(items)
+	if err != nil {
+		return nil, err
+	}
+
+	bs.run(ctx, batch, items)
+	return batch, nil
+}
+
+// StartAsync registers batch and starts its items in background
+// Progress is available from Get until retention period after batch completes
+// Регистрирует пакет и запускает его элементы в фоне
+// Прогресс доступен через Get до истечения срока хранения после завершения пакета
+func (bs *BatchStarter) StartAsync(ctx context.Context, items []models.BatchStartItem) (*models.BatchStart, error) {
+	batch, err := bs.newBatch(items)
+	if err != nil {
+		return nil, err
+	}
+
+	bs.mu.Lock()
+	bs.evictExpired()
+	bs.batches[batch.ID] = batch
+	snapshot := snapshotBatch(batch)
+	bs.mu.Unlock()
+
+	go bs.run(context.WithoutCancel(ctx), batch, items)
+
+	logger.Info("Background batch start accepted",
+		logger.String("batch_id", batch.ID),
+		logger.Int("items", batch.Total),
+		logger.String("request_id", models.RequestIDFromContext(ctx)))
+
+	return snapshot, nil
+}
+
+// Get returns snapshot of background batch
+// Возвращает снимок фонового пакета
+func (bs *BatchStarter) Get(batchID string) (*models.BatchStart, error) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	bs.evictExpired()
+	batch, ok := bs.batches[batchID]
+	if !ok {
+		return nil, fmt.Errorf("batch %w: %s", models.ErrNotFound, batchID)
+	}
+	return snapshotBatch(batch), nil
+}
+
+// newBatch validates items and creates batch with all items pending
+// Валидирует элементы и создает пакет со всеми элементами в ожидании
+func (bs *BatchStarter) newBatch(items []models.BatchStartItem) (*models.BatchStart, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: batch has no items", models.ErrInvalidArgument)
+	}
+	if len(items) > bs.maxItems {
+		return nil, fmt.Errorf("%w: batch has %d items, maximum is %d",
+			models.ErrInvalidArgument, len(items), bs.maxItems)
+	}
+
+	batch := &models.BatchStart{
+		ID:        models.GenerateID(),
+		State:     models.BatchStartStateRunning,
+		Total:     len(items),
+		CreatedAt: time.Now(),
+		Results:   make([]models.BatchStartItemResult, len(items)),
+	}
+	for i, item := range items {
+		if item.ProcessKey == "" {
+			return nil, fmt.Errorf("%w: items[%d].process_key is required", models.ErrInvalidArgument, i)
+		}
+		batch.Results[i] = models.BatchStartItemResult{
+			Index:       i,
+			ProcessKey:  item.ProcessKey,
+			BusinessKey: item.BusinessKey,
+			Status:      models.BatchItemStatusPending,
+		}
+	}
+	return batch, nil
+}
+
+// run starts batch items on workers and marks batch completed
+// Запускает элементы пакета на воркерах и отмечает пакет завершенным
+func (bs *BatchStarter) run(ctx context.Context, batch *models.BatchStart, items []models.BatchStartItem) {
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+
+	workers := min(bs.workers, len(items))
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				bs.startItem(ctx, batch, i, items[i])
+			}
+		}()
+	}
+
+	for i := range items {
+		select {
+		case indexes <- i:
+		case <-bs.stopCh:
+			bs.record(batch, i, nil, fmt.Errorf("batch aborted: process component stopped"))
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	bs.mu.Lock()
+	completedAt := time.Now()
+	batch.State = models.BatchStartStateCompleted
+	batch.CompletedAt = &completedAt
+	bs.mu.Unlock()
+
+	logger.Info("Batch start completed",
+		logger.String("batch_id", batch.ID),
+		logger.Int("total", batch.Total),
+		logger.Int("succeeded", batch.Succeeded),
+		logger.Int("failed", batch.Failed),
+		logger.String("duration", completedAt.Sub(batch.CreatedAt).String()))
+}
+
+// startItem starts instance of single batch item and records result
+// Запускает экземпляр отдельного элемента пакета и записывает результат
+func (bs *BatchStarter) startItem(
+	ctx context.Context,
+	batch *models.BatchStart,
+	index int,
+	item models.BatchStartItem,
+) {
+	instance, err := bs.component.StartProcessInstanceWithContext(
+		withBusinessKey(ctx, item.BusinessKey), item.ProcessKey, item.Variables, 0)
+	if err != nil {
+		logger.Warn("Failed to start batch item",
+			logger.String("batch_id", batch.ID),
+			logger.Int("index", index),
+			logger.String("process_key", item.ProcessKey),
+			logger.String("error", err.Error()))
+	}
+	bs.record(batch, index, instance, err)
+}
+
+// record stores item outcome, failed item keeps instance ID when instance was created before failure
+// Сохраняет результат элемента, упавший элемент хранит ID экземпляра, если тот был создан до ошибки
+func (bs *BatchStarter) record(batch *models.BatchStart, index int, instance *models.ProcessInstance, err error) {
+	bs.mu.Lock()
+	defer bs.mu.Unlock()
+
+	result := &batch.Results[index]
+	if instance != nil {
+		result.InstanceID = instance.InstanceID
+	}
+	batch.Processed++
+	if err != nil {
+		result.Status = models.BatchItemStatusFailed
+		result.Err = err
+		batch.Failed++
+		return
+	}
+	result.Status = models.BatchItemStatusStarted
+	batch.Succeeded++
+}
+
+// evictExpired drops background batches completed longer than retention period ago, caller holds mu
+// Удаляет фоновые пакеты, завершенные раньше срока хранения, вызывающий держит mu
+func (bs *BatchStarter) evictExpired() {
+	for id, batch := range bs.batches {
+		if batch.CompletedAt != nil && time.Since(*batch.CompletedAt) > batchStartRetention {
+			delete(bs.batches, id)
+		}
+	}
+}
+
+// snapshotBatch copies batch so caller reads it without lock, caller holds mu
+// Копирует пакет, чтобы вызывающий читал его без блокировки, вызывающий держит mu
+func snapshotBatch(batch *models.BatchStart) *models.BatchStart {
+	snapshot := *batch
+	snapshot.Results = append([]models.BatchStartItemResult(nil), batch.Results...)
+	return &snapshot
+}
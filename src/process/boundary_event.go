@@ -10,6 +10,7 @@ package process
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"atom-engine/src/core/logger"
@@ -90,6 +91,12 @@ func (bee *BoundaryEventExecutor) Execute(
 						return bee.executeRegularBoundaryEvent(token, element, cancelActivity)
 					}
 
+					// Conditional boundary events are fired by condition manager when condition becomes true
+					// Условные граничные события запускаются менеджером условий, когда условие становится истинным
+					if eventType == "conditionalEventDefinition" {
+						return bee.executeRegularBoundaryEvent(token, element, cancelActivity)
+					}
+
 					// Handle signal boundary events
 					if eventType == "signalEventDefinition" {
 						return bee.handleSignalBoundaryEvent(token, element, eventDefMap, cancelActivity)
@@ -152,34 +159,59 @@ func (bee *BoundaryEventExecutor) handleMessageBoundaryEvent(
 
 	// Extract message information from event definition
 	// Извлекаем информацию о сообщении из event definition
-	messageName := ""
-	correlationKey := ""
-
-	if messageRef, exists := eventDef["message_ref"]; exists {
-		if messageRefStr, ok := messageRef.(string); ok {
-			messageName = messageRefStr
-			logger.Info("Message boundary event message reference",
-				logger.String("message_ref", messageRefStr))
+	messageRef, _ := eventDef["reference"].(string)
+	if messageRef == "" {
+		messageRef, _ = eventDef["message_ref"].(string)
+	}
+	messageName := messageRef
+	correlationKeyExpression := ""
+
+	// Resolve message name and correlation key from message definition
+	// Получаем имя сообщения и correlation key из определения сообщения
+	if bee.processComponent != nil && messageRef != "" {
+		if bpmnProcess, err := bee.processComponent.GetBPMNProcessForToken(token); err == nil {
+			elements, _ := bpmnProcess["elements"].(map[string]interface{})
+			if definition, found := findMessageDefinition(elements, messageRef); found {
+				messageName = definition.Name
+				correlationKeyExpression = definition.CorrelationKey
+			}
 		}
 	}
 
-	// Extract and evaluate correlation key from token variables
-	// Извлекаем и вычисляем correlation key из переменных токена
-	correlationKey = bee.evaluateCorrelationKey(token)
+	// Legacy: correlation key passed through token variable
+	// Legacy: correlation key передан через переменную токена
+	if correlationKeyExpression == "" {
+		correlationKeyExpression, _ = token.Variables["correlationKey"].(string)
+	}
+
+	correlationKey, err := resolveCorrelationKey(bee.processComponent, correlationKeyExpression, token)
+	if err != nil {
+		logger.Error("Failed to resolve message boundary event correlation key, subscription not created",
+			logger.String("token_id", token.TokenID),
+			logger.String("message_name", messageName),
+			logger.String("correlation_key", correlationKeyExpression),
+			logger.String("error", err.Error()))
+	}
+	if !strings.HasPrefix(correlationKeyExpression, "=") {
+		correlationKeyExpression = ""
+	}
 
 	// Create message subscription for this boundary event
 	// Создаем подписку на сообщение для этого граничного события
-	if bee.processComponent != nil && messageName != "" {
+	if bee.processComponent != nil && messageName != "" && err == nil {
 		subscription := &models.ProcessMessageSubscription{
-			ID:                   models.GenerateID(),
-			TenantID:             "", // Default tenant
-			ProcessDefinitionKey: token.ProcessKey,
-			StartEventID:         token.CurrentElementID, // Use current element as reference
-			MessageName:          messageName,
-			CorrelationKey:       correlationKey,
-			IsActive:             true,
-			CreatedAt:            time.Now(),
-			UpdatedAt:            time.Now(),
+			ID:                       models.GenerateID(),
+			TenantID:                 "", // Default tenant
+			ProcessDefinitionKey:     token.ProcessKey,
+			StartEventID:             token.CurrentElementID, // Use current element as reference
+			MessageName:              messageName,
+			CorrelationKey:           correlationKey,
+			CorrelationKeyExpression: correlationKeyExpression,
+			ProcessInstanceID:        token.ProcessInstanceID,
+			ElementInstanceKey:       token.Key,
+			IsActive:                 true,
+			CreatedAt:                time.Now(),
+			UpdatedAt:                time.Now(),
 		}
 
 		if err := bee.processComponent.CreateMessageSubscription(subscription); err != nil {
@@ -378,89 +410,6 @@ func (bee *BoundaryEventExecutor) executeRegularBoundaryEvent(
 	}, nil
 }
 
-// evaluateCorrelationKey evaluates FEEL expressions in correlation key
-// Вычисляет FEEL expressions в correlation key
-func (bee *BoundaryEventExecutor) evaluateCorrelationKey(token *models.Token) string {
-	correlationKey := ""
-
-	// Extract correlation key from token variables
-	// Извлекаем correlation key из переменных токена
-	if corrKey, exists := token.Variables["correlationKey"]; exists {
-		if corrKeyStr, ok := corrKey.(string); ok {
-			// Check if this is a FEEL expression
-			// Проверяем является ли это FEEL expression
-			if len(corrKeyStr) > 0 && corrKeyStr[0] == '=' {
-				// Evaluate FEEL expression
-				// Вычисляем FEEL expression
-				if evaluatedKey := bee.evaluateFEELExpression(corrKeyStr, token); evaluatedKey != "" {
-					correlationKey = evaluatedKey
-				} else {
-					// Fallback to original value without "="
-					correlationKey = corrKeyStr[1:]
-				}
-			} else {
-				// Not a FEEL expression - use as is
-				correlationKey = corrKeyStr
-			}
-		}
-	}
-
-	return correlationKey
-}
-
-// evaluateFEELExpression evaluates FEEL expression using expression component
-// Вычисляет FEEL expression используя expression компонент
-func (bee *BoundaryEventExecutor) evaluateFEELExpression(expression string, token *models.Token) string {
-	// Get expression component through process component
-	// Получаем expression компонент через process компонент
-	if bee.processComponent == nil {
-		return ""
-	}
-
-	// Get core interface
-	core := bee.processComponent.GetCore()
-	if core == nil {
-		return ""
-	}
-
-	// Get expression component
-	expressionCompInterface := core.GetExpressionComponent()
-	if expressionCompInterface == nil {
-		return ""
-	}
-
-	// Cast to expression evaluator interface
-	type ExpressionEvaluator interface {
-		EvaluateExpressionEngine(expression interface{}, variables map[string]interface{}) (interface{}, error)
-	}
-
-	expressionComp, ok := expressionCompInterface.(ExpressionEvaluator)
-	if !ok {
-		return ""
-	}
-
-	// Evaluate FEEL expression
-	result, err := expressionComp.EvaluateExpressionEngine(expression, token.Variables)
-	if err != nil {
-		logger.Error("Failed to evaluate FEEL expression in correlation key",
-			logger.String("token_id", token.TokenID),
-			logger.String("expression", expression),
-			logger.String("error", err.Error()))
-		return ""
-	}
-
-	// Convert result to string
-	if resultStr := fmt.Sprintf("%v", result); resultStr != "" {
-		logger.Debug("Correlation key FEEL expression evaluated",
-			logger.String("token_id", token.TokenID),
-			logger.String("original", expression),
-			logger.String("evaluated", resultStr))
-		return resultStr
-	}
-
-	return ""
-}
-
 // GetElementType returns element type
 // Возвращает тип элемента
 func (bee *BoundaryEventExecutor) GetElementType() string {
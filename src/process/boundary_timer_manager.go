@@ -12,6 +12,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync/atomic"
 
 	"atom-engine/src/core/logger"
 	"atom-engine/src/core/models"
@@ -26,6 +27,10 @@ type BoundaryTimerManager struct {
 	component  ComponentInterface
 	core       CoreInterface
 	bpmnHelper *BPMNHelper
+
+	// Set once existing boundary timers are linked to their tokens
+	// Устанавливается, когда существующие boundary таймеры связаны со своими токенами
+	linksMigrated atomic.Bool
 }
 
 // NewBoundaryTimerManager creates new boundary timer manager
@@ -54,13 +59,91 @@ func (btm *BoundaryTimerManager) Init() error {
 // CreateBoundaryTimer creates boundary timer with BOUNDARY timer type
 // Создает boundary таймер с типом BOUNDARY
 func (btm *BoundaryTimerManager) CreateBoundaryTimer(timerRequest *TimerRequest) error {
-	if btm.core == nil {
-		return fmt.Errorf("core interface not set")
+	twRequest, err := btm.buildTimewheelRequest(timerRequest)
+	if err != nil {
+		return err
 	}
 
-	timewheelComp := btm.core.GetTimewheelComponentInterface()
-	if timewheelComp == nil {
-		return fmt.Errorf("timewheel component not available")
+	if err := btm.scheduleTimer(twRequest); err != nil {
+		return err
+	}
+
+	logger.Info("Boundary timer created successfully",
+		logger.String("element_id", timerRequest.ElementID),
+		logger.String("parent_token_id", timerRequest.TokenID),
+		logger.String("timer_type", "BOUNDARY"))
+
+	return nil
+}
+
+// RegisterBoundaryTimer creates boundary timer of token entering activity and returns its ID.
+// Timer ID is written onto token and token is saved with timer record in one transaction before timer
+// is scheduled, so executing token keeps ID and timer restored after crash is always linked to token
+// Создает boundary таймер токена, входящего в активность, и возвращает его ID
+// ID таймера записывается в токен, токен сохраняется вместе с записью таймера в одной транзакции до
+// планирования таймера, поэтому выполняемый токен сохраняет ID, а восстановленный после сбоя таймер связан с токеном
+func (btm *BoundaryTimerManager) RegisterBoundaryTimer(
+	token *models.Token,
+	timerRequest *TimerRequest,
+) (string, error) {
+	twRequest, err := btm.buildTimewheelRequest(timerRequest)
+	if err != nil {
+		return "", err
+	}
+
+	recordBuilder, ok := btm.core.GetTimewheelComponentInterface().(interface {
+		NewTimerRecord(req *timewheel.TimerRequest, timerID string) *storage.TimerRecord
+	})
+	if !ok {
+		return "", fmt.Errorf("timewheel component does not support timer registration")
+	}
+
+	timerID := models.GenerateID()
+	timerRecord := recordBuilder.NewTimerRecord(&twRequest, timerID)
+
+	// Due date is calculated from persisted base time, so restored timer fires at same moment
+	// Срок рассчитывается от сохраненного базового времени, поэтому восстановленный таймер сработает в тот же момент
+	baseTime := timerRecord.ScheduledAt
+	twRequest.BaseTime = &baseTime
+
+	token.AddBoundaryTimer(timerID)
+	if err := btm.storage.RegisterBoundaryTimer(token, timerRecord); err != nil {
+		token.RemoveBoundaryTimer(timerID)
+		return "", err
+	}
+
+	twRequest.RegisteredTimerID = &timerID
+	if err := btm.scheduleTimer(twRequest); err != nil {
+		// Roll registration back, timer never reached timewheel
+		// Откатываем регистрацию, таймер не попал в timewheel
+		token.RemoveBoundaryTimer(timerID)
+		if delErr := btm.storage.DeleteTimer(timerID); delErr != nil {
+			logger.Warn("Failed to delete record of unscheduled boundary timer",
+				logger.String("timer_id", timerID),
+				logger.String("error", delErr.Error()))
+		}
+		if updErr := btm.storage.UpdateToken(token); updErr != nil {
+			logger.Warn("Failed to unlink unscheduled boundary timer from token",
+				logger.String("token_id", token.TokenID),
+				logger.String("timer_id", timerID),
+				logger.String("error", updErr.Error()))
+		}
+		return "", err
+	}
+
+	logger.Info("Boundary timer registered",
+		logger.String("element_id", timerRequest.ElementID),
+		logger.String("parent_token_id", token.TokenID),
+		logger.String("timer_id", timerID))
+
+	return timerID, nil
+}
+
+// buildTimewheelRequest converts boundary timer request to timewheel request
+// Конвертирует запрос boundary таймера в запрос timewheel
+func (btm *BoundaryTimerManager) buildTimewheelRequest(timerRequest *TimerRequest) (timewheel.TimerRequest, error) {
+	if btm.core == nil {
+		return timewheel.TimerRequest{}, fmt.Errorf("core interface not set")
 	}
 
 	// Get process version from ProcessInstanceID
@@ -93,7 +176,7 @@ func (btm *BoundaryTimerManager) CreateBoundaryTimer(timerRequest *TimerRequest)
 	} else if timerRequest.TimeCycle != nil {
 		twRequest.TimeCycle = timerRequest.TimeCycle
 	} else {
-		return fmt.Errorf("no timer definition provided")
+		return timewheel.TimerRequest{}, fmt.Errorf("no timer definition provided")
 	}
 
 	// Set boundary timer metadata for proper scope tracking
@@ -105,6 +188,17 @@ func (btm *BoundaryTimerManager) CreateBoundaryTimer(timerRequest *TimerRequest)
 		twRequest.CancelActivity = timerRequest.CancelActivity
 	}
 
+	return twRequest, nil
+}
+
+// scheduleTimer sends schedule message for timer request to timewheel
+// Отправляет в timewheel сообщение планирования для запроса таймера
+func (btm *BoundaryTimerManager) scheduleTimer(twRequest timewheel.TimerRequest) error {
+	timewheelComp := btm.core.GetTimewheelComponentInterface()
+	if timewheelComp == nil {
+		return fmt.Errorf("timewheel component not available")
+	}
+
 	// Create schedule timer message
 	messageJSON, err := timewheel.CreateScheduleTimerMessage(twRequest)
 	if err != nil {
@@ -112,115 +206,69 @@ func (btm *BoundaryTimerManager) CreateBoundaryTimer(timerRequest *TimerRequest)
 	}
 
 	// Process timer message via timewheel component
-	if processMsgMethod, ok := timewheelComp.(interface {
+	processMsgMethod, ok := timewheelComp.(interface {
 		ProcessMessage(context.Context, string) error
-	}); ok {
-		ctx := context.Background()
-		if err := processMsgMethod.ProcessMessage(ctx, messageJSON); err != nil {
-			return fmt.Errorf("failed to process boundary timer message: %w", err)
-		}
-	} else {
+	})
+	if !ok {
 		return fmt.Errorf("timewheel component does not support ProcessMessage")
 	}
-
-	logger.Info("Boundary timer created successfully",
-		logger.String("element_id", timerRequest.ElementID),
-		logger.String("parent_token_id", timerRequest.TokenID),
-		logger.String("timer_type", "BOUNDARY"))
-
+	if err := processMsgMethod.ProcessMessage(context.Background(), messageJSON); err != nil {
+		return fmt.Errorf("failed to process boundary timer message: %w", err)
+	}
 	return nil
 }
 
-// CreateBoundaryTimerWithID creates boundary timer and returns timer ID
-// Создает boundary таймер и возвращает ID таймера
-func (btm *BoundaryTimerManager) CreateBoundaryTimerWithID(timerRequest *TimerRequest) (string, error) {
-	if err := btm.CreateBoundaryTimer(timerRequest); err != nil {
-		return "", err
-	}
-
-	// Find the real timer ID from storage that was just created
-	// Находим реальный timer ID из storage который только что был создан
-	allTimers, err := btm.storage.LoadAllTimers()
+// MigrateTimerLinks links boundary timers scheduled before registration stored them on tokens.
+// Until migration succeeds cancellation also looks for unlinked timers in storage
+// Связывает boundary таймеры, запланированные до того, как регистрация стала сохранять их в токенах
+// Пока миграция не выполнена, отмена также ищет несвязанные таймеры в storage
+func (btm *BoundaryTimerManager) MigrateTimerLinks() {
+	linked, err := btm.storage.MigrateBoundaryTimerLinks()
 	if err != nil {
-		logger.Error("Failed to load timers to find boundary timer ID",
-			logger.String("element_id", timerRequest.ElementID),
-			logger.String("parent_token_id", timerRequest.TokenID),
+		logger.Warn("Failed to migrate boundary timer links, unlinked timers are reconciled on cancellation",
 			logger.String("error", err.Error()))
-		return "", fmt.Errorf("failed to load timers: %w", err)
+		return
 	}
-
-	// Find the timer we just created for this token and element
-	// Находим таймер который мы только что создали для данного токена и элемента
-	for _, timerRecord := range allTimers {
-		if timerRecord.TimerType == "BOUNDARY" &&
-			timerRecord.TokenID == timerRequest.TokenID &&
-			timerRecord.ElementID == timerRequest.ElementID &&
-			timerRecord.State == "SCHEDULED" {
-
-			logger.Info("Real boundary timer ID found in storage",
-				logger.String("element_id", timerRequest.ElementID),
-				logger.String("parent_token_id", timerRequest.TokenID),
-				logger.String("timer_id", timerRecord.ID))
-
-			return timerRecord.ID, nil
-		}
+	btm.linksMigrated.Store(true)
+	if linked > 0 {
+		logger.Info("Legacy boundary timers linked to tokens", logger.Int("timers", linked))
 	}
-
-	// Fallback: timer not found in storage, generate ID but log warning
-	// Fallback: таймер не найден в storage, генерируем ID но логируем предупреждение
-	timerID := models.GenerateID()
-	logger.Warn("Boundary timer not found in storage, using generated ID",
-		logger.String("element_id", timerRequest.ElementID),
-		logger.String("parent_token_id", timerRequest.TokenID),
-		logger.String("timer_id", timerID))
-
-	return timerID, nil
 }
 
-// LinkBoundaryTimerToToken links boundary timer to parent token
-// Связывает boundary таймер с родительским токеном
-func (btm *BoundaryTimerManager) LinkBoundaryTimerToToken(tokenID, timerID string) error {
-	// Load parent token
-	// Загружаем родительский токен
+// CancelBoundaryTimersForToken loads token, cancels its boundary timers and saves it
+// Загружает токен, отменяет его boundary таймеры и сохраняет его
+func (btm *BoundaryTimerManager) CancelBoundaryTimersForToken(tokenID string) error {
 	token, err := btm.storage.LoadToken(tokenID)
 	if err != nil {
 		return fmt.Errorf("failed to load token: %w", err)
 	}
 
-	// Add boundary timer ID to token
-	// Добавляем ID boundary таймера к токену
-	token.AddBoundaryTimer(timerID)
-
-	// Save updated token
-	// Сохраняем обновленный токен
-	if err := btm.storage.UpdateToken(token); err != nil {
-		return fmt.Errorf("failed to update token: %w", err)
+	if !token.HasBoundaryTimers() && btm.linksMigrated.Load() {
+		return nil
 	}
 
-	logger.Info("Boundary timer linked to token",
-		logger.String("token_id", tokenID),
-		logger.String("timer_id", timerID))
+	if err := btm.CancelBoundaryTimers(token); err != nil {
+		return err
+	}
 
+	if err := btm.storage.UpdateToken(token); err != nil {
+		return fmt.Errorf("failed to update token after canceling boundary timers: %w", err)
+	}
 	return nil
 }
 
-// CancelBoundaryTimersForToken cancels all boundary timers for token
-// Отменяет все boundary таймеры для токена
-func (btm *BoundaryTimerManager) CancelBoundaryTimersForToken(tokenID string) error {
-	// Load all timers and find boundary timers for this token
-	// Загружаем все таймеры и находим boundary таймеры для данного токена
-	allTimers, err := btm.storage.LoadAllTimers()
-	if err != nil {
-		return fmt.Errorf("failed to load timers: %w", err)
-	}
-
-	// Find boundary timers for this token
-	// Находим boundary таймеры для данного токена
-	var boundaryTimers []string
-	for _, timerRecord := range allTimers {
-		if timerRecord.TimerType == "BOUNDARY" && timerRecord.TokenID == tokenID && timerRecord.State == "SCHEDULED" {
-			boundaryTimers = append(boundaryTimers, timerRecord.ID)
+// CancelBoundaryTimers cancels boundary timers registered on token and clears them on token.
+// Caller saves token. Before links migration completes timers of token missing from it are canceled too
+// Отменяет boundary таймеры, зарегистрированные в токене, и очищает их в токене
+// Токен сохраняет вызывающий. До завершения миграции связей отменяются и отсутствующие в токене таймеры
+func (btm *BoundaryTimerManager) CancelBoundaryTimers(token *models.Token) error {
+	boundaryTimers := token.GetBoundaryTimers()
+	if !btm.linksMigrated.Load() {
+		unlinked, err := btm.unlinkedBoundaryTimers(token)
+		if err != nil {
+			return err
 		}
+		boundaryTimers = append(boundaryTimers, unlinked...)
 	}
 
 	if len(boundaryTimers) == 0 {
@@ -228,7 +276,7 @@ func (btm *BoundaryTimerManager) CancelBoundaryTimersForToken(tokenID string) er
 	}
 
 	logger.Info("Canceling boundary timers for token",
-		logger.String("token_id", tokenID),
+		logger.String("token_id", token.TokenID),
 		logger.Int("timer_count", len(boundaryTimers)),
 		logger.String("boundary_timer_ids", fmt.Sprintf("%v", boundaryTimers)))
 
@@ -239,7 +287,7 @@ func (btm *BoundaryTimerManager) CancelBoundaryTimersForToken(tokenID string) er
 			defer func() {
 				if r := recover(); r != nil {
 					logger.Error("Panic occurred while canceling boundary timer",
-						logger.String("token_id", tokenID),
+						logger.String("token_id", token.TokenID),
 						logger.String("timer_id", timerID),
 						logger.String("panic", fmt.Sprintf("%v", r)))
 				}
@@ -247,31 +295,40 @@ func (btm *BoundaryTimerManager) CancelBoundaryTimersForToken(tokenID string) er
 
 			if err := btm.cancelTimer(timerID); err != nil {
 				logger.Error("Failed to cancel boundary timer",
-					logger.String("token_id", tokenID),
+					logger.String("token_id", token.TokenID),
 					logger.String("timer_id", timerID),
 					logger.String("error", err.Error()))
 				// Continue with other timers
 			} else {
 				logger.Info("Boundary timer canceled",
-					logger.String("token_id", tokenID),
+					logger.String("token_id", token.TokenID),
 					logger.String("timer_id", timerID))
 			}
 		}()
 	}
 
-	// Update token to clear boundary timer IDs (if needed)
-	// Обновляем токен чтобы очистить ID boundary таймеров (если нужно)
-	token, err := btm.storage.LoadToken(tokenID)
-	if err == nil && token.HasBoundaryTimers() {
-		token.BoundaryTimerIDs = make([]string, 0)
-		if err := btm.storage.UpdateToken(token); err != nil {
-			logger.Error("Failed to update token after canceling boundary timers",
-				logger.String("token_id", tokenID),
-				logger.String("error", err.Error()))
-		}
+	token.BoundaryTimerIDs = make([]string, 0)
+	return nil
+}
+
+// unlinkedBoundaryTimers returns scheduled boundary timers of token that token does not reference
+// Возвращает запланированные boundary таймеры токена, на которые токен не ссылается
+func (btm *BoundaryTimerManager) unlinkedBoundaryTimers(token *models.Token) ([]string, error) {
+	allTimers, err := btm.storage.LoadAllTimers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timers: %w", err)
 	}
 
-	return nil
+	var unlinked []string
+	for _, timerRecord := range allTimers {
+		if timerRecord.TimerType == string(models.TimerTypeBoundary) &&
+			timerRecord.TokenID == token.TokenID &&
+			timerRecord.State == "SCHEDULED" &&
+			!token.HasBoundaryTimer(timerRecord.ID) {
+			unlinked = append(unlinked, timerRecord.ID)
+		}
+	}
+	return unlinked, nil
 }
 
 // HandleBoundaryTimerCallback handles boundary timer callbacks
@@ -395,6 +452,16 @@ func (btm *BoundaryTimerManager) HandleBoundaryTimerCallback(
 			logger.String("boundary_event_id", elementID))
 	}
 
+	// Cancel remaining boundary timers of interrupted activity, fired timer is already done
+	// Отменяем оставшиеся boundary таймеры прерванной активности, сработавший таймер уже завершен
+	parentToken.RemoveBoundaryTimer(timerID)
+	if err := btm.CancelBoundaryTimers(parentToken); err != nil {
+		logger.Error("Failed to cancel boundary timers for interrupted token",
+			logger.String("token_id", parentToken.TokenID),
+			logger.String("boundary_event_id", elementID),
+			logger.String("error", err.Error()))
+	}
+
 	// Move parent token to boundary event
 	parentToken.MoveTo(elementID)
 		if err := btm.storage.UpdateToken(parentToken); err != nil {
@@ -418,6 +485,7 @@ func (btm *BoundaryTimerManager) HandleBoundaryTimerCallback(
 		// Создаем новый токен для boundary события
 		boundaryToken := models.NewToken(parentToken.ProcessInstanceID, parentToken.ProcessKey, elementID)
 		boundaryToken.SetVariables(parentToken.Variables) // Copy parent variables
+		boundaryToken.TraceParent = parentToken.TraceParent
 
 		// Save boundary token
 		if err := btm.storage.SaveToken(boundaryToken); err != nil {
@@ -441,6 +509,14 @@ func (btm *BoundaryTimerManager) cancelTimer(timerID string) error {
 		return fmt.Errorf("core interface not set")
 	}
 
+	// Timer IDs come from token state, skip timers that already fired or were canceled
+	// ID таймеров берутся из состояния токена, пропускаем уже сработавшие или отмененные таймеры
+	if timerRecord, err := btm.storage.LoadTimer(timerID); err != nil || timerRecord.State != "SCHEDULED" {
+		logger.Debug("Boundary timer is not scheduled, nothing to cancel",
+			logger.String("timer_id", timerID))
+		return nil
+	}
+
 	timewheelComp := btm.core.GetTimewheelComponentInterface()
 	if timewheelComp == nil {
 		return fmt.Errorf("timewheel component not available")
@@ -0,0 +1,162 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"atom-engine/src/core/models"
+	"atom-engine/src/storage"
+)
+
+// errCrashed is returned by storage writes after simulated crash
+// Возвращается записями storage после симулированного сбоя
+var errCrashed = errors.New("simulated crash")
+
+// crashAfterRegisterStorage fails every token and job write after first boundary timer registration,
+// as if process died between registering timer and next token update
+// Отклоняет все записи токенов и job'ов после первой регистрации boundary таймера,
+// как если бы процесс упал между регистрацией таймера и следующим обновлением токена
+type crashAfterRegisterStorage struct {
+	storage.Storage
+	crashed atomic.Bool
+}
+
+func (s *crashAfterRegisterStorage) RegisterBoundaryTimer(token *models.Token, timer *storage.TimerRecord) error {
+	if s.crashed.Load() {
+		return errCrashed
+	}
+	err := s.Storage.RegisterBoundaryTimer(token, timer)
+	s.crashed.Store(true)
+	return err
+}
+
+func (s *crashAfterRegisterStorage) SaveToken(token *models.Token) error {
+	if s.crashed.Load() {
+		return errCrashed
+	}
+	return s.Storage.SaveToken(token)
+}
+
+func (s *crashAfterRegisterStorage) UpdateToken(token *models.Token) error {
+	if s.crashed.Load() {
+		return errCrashed
+	}
+	return s.Storage.UpdateToken(token)
+}
+
+func (s *crashAfterRegisterStorage) SaveJob(ctx context.Context, job *models.Job) error {
+	if s.crashed.Load() {
+		return errCrashed
+	}
+	return s.Storage.SaveJob(ctx, job)
+}
+
+// timedTaskProcess has service task with interrupting one hour boundary timer leading to separate end
+// Сервисная задача с прерывающим часовым boundary таймером, ведущим к отдельному завершению
+const timedTaskProcess = `
+    <bpmn:startEvent id="start"><bpmn:outgoing>f1</bpmn:outgoing></bpmn:startEvent>
+    <bpmn:sequenceFlow id="f1" sourceRef="start" targetRef="task" />
+    <bpmn:serviceTask id="task">
+      <bpmn:extensionElements><zeebe:taskDefinition type="timed-work" /></bpmn:extensionElements>
+      <bpmn:incoming>f1</bpmn:incoming><bpmn:outgoing>f2</bpmn:outgoing>
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="f2" sourceRef="task" targetRef="done" />
+    <bpmn:endEvent id="done"><bpmn:incoming>f2</bpmn:incoming></bpmn:endEvent>
+    <bpmn:boundaryEvent id="timeout" attachedToRef="task">
+      <bpmn:outgoing>f3</bpmn:outgoing>
+      <bpmn:timerEventDefinition id="timeoutDefinition">
+        <bpmn:timeDuration>PT1H</bpmn:timeDuration>
+      </bpmn:timerEventDefinition>
+    </bpmn:boundaryEvent>
+    <bpmn:sequenceFlow id="f3" sourceRef="timeout" targetRef="timedOut" />
+    <bpmn:endEvent id="timedOut"><bpmn:incoming>f3</bpmn:incoming></bpmn:endEvent>`
+
+func TestBoundaryTimerRegisteredBeforeCrashFiresAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	crashing := &crashAfterRegisterStorage{}
+	e := startTestEngine(t, dir, withStorageWrapper(func(st storage.Storage) storage.Storage {
+		crashing.Storage = st
+		return crashing
+	}))
+
+	processID := e.deploy(bpmnDefinitions("timer-crash", timedTaskProcess))
+	instance, err := e.process.StartProcessInstance(processID, map[string]interface{}{})
+	if instance == nil {
+		t.Fatalf("instance not created: %v", err)
+	}
+	e.waitFor("boundary timer registration", crashing.crashed.Load)
+
+	tokens := e.tokens(instance.InstanceID)
+	if len(tokens) != 1 || !tokens[0].HasBoundaryTimers() {
+		t.Fatalf("token at task must carry registered timer before crash, got %+v", tokens)
+	}
+	timerID := tokens[0].BoundaryTimerIDs[0]
+	e.Stop()
+
+	e = startTestEngine(t, dir)
+	if _, err := e.storage.LoadTimer(timerID); err != nil {
+		t.Fatalf("timer record lost in crash: %v", err)
+	}
+
+	e.advance(time.Hour + time.Minute)
+	e.waitState(instance.InstanceID, models.ProcessInstanceStateCompleted)
+
+	for _, token := range e.tokens(instance.InstanceID) {
+		if token.CurrentElementID == "done" {
+			t.Fatalf("token completed task although only boundary timer fired")
+		}
+	}
+}
+
+func TestAdvancingClockPastBoundaryTimerInterruptsTask(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("timer-advance", timedTaskProcess))
+	instance := e.start(processID, nil)
+	job := e.waitJob(instance.InstanceID, "task")
+
+	// Clock short of timer due date fires nothing
+	// Часы, не дошедшие до срока таймера, ничего не запускают
+	fired, err := e.timewheel.AdvanceClock(59*time.Minute, e.handleTimerResponse)
+	if err != nil {
+		t.Fatalf("advance clock: %v", err)
+	}
+	if len(fired) != 0 {
+		t.Fatalf("%d timers fired before boundary timer was due", len(fired))
+	}
+	if state := e.instance(instance.InstanceID).State; state != models.ProcessInstanceStateActive {
+		t.Fatalf("instance in state %s before boundary timer was due", state)
+	}
+
+	fired, err = e.timewheel.AdvanceClock(2*time.Minute, e.handleTimerResponse)
+	if err != nil {
+		t.Fatalf("advance clock: %v", err)
+	}
+	if len(fired) != 1 || fired[0].ElementID != "timeout" {
+		t.Fatalf("fired timers %+v, want boundary timer of task", fired)
+	}
+	e.waitState(instance.InstanceID, models.ProcessInstanceStateCompleted)
+
+	if e.tokenAt(instance.InstanceID, "timedOut") == nil {
+		t.Errorf("instance did not take boundary timer path")
+	}
+	if e.tokenAt(instance.InstanceID, "done") != nil {
+		t.Errorf("interrupted task continued to normal path")
+	}
+	for _, current := range e.jobsOf(instance.InstanceID, "task") {
+		if current.Key == job.Key && isOpenJob(current) {
+			t.Errorf("job %s of interrupted task is still open: %s", current.Key, current.Status)
+		}
+	}
+}
@@ -83,12 +83,18 @@ func (bmp *BufferedMessageProcessor) findMatchingBufferedMessage(
 			continue
 		}
 
+		// Engine subscriptions belong to default tenant, other tenants correlate only with explicit broadcast
+		// Подписки движка принадлежат тенанту по умолчанию, другие тенанты коррелируют только при явной рассылке
+		if !models.SameTenant(message.TenantID, models.DefaultTenantID) && !message.BroadcastAcrossTenants {
+			continue
+		}
+
 		// Check correlation key match (empty correlation key matches any)
 		if correlationKey != "" {
 			// Note: FEEL expressions in correlation keys are now evaluated BEFORE calling this method
-			// in intermediate_catch_message_handler.go:evaluateCorrelationKeyExpression()
+			// in message_correlation_key.go:resolveCorrelationKey()
 			// Примечание: FEEL expressions в correlation keys теперь вычисляются ДО вызова этого метода
-			// в intermediate_catch_message_handler.go:evaluateCorrelationKeyExpression()
+			// в message_correlation_key.go:resolveCorrelationKey()
 			expectedKey := correlationKey
 			if strings.HasPrefix(correlationKey, "=") {
 				// This should not happen anymore, but keep fallback for safety
@@ -157,6 +163,17 @@ func (bmp *BufferedMessageProcessor) ProcessBufferedMessage(
 		logger.String("message_id", message.ID),
 		logger.String("token_id", token.TokenID))
 
+	if !models.SameTenant(message.TenantID, models.DefaultTenantID) {
+		logger.Warn("Audit: cross-tenant message correlation",
+			logger.String("message_id", message.ID),
+			logger.String("message_name", message.Name),
+			logger.String("correlation_key", message.CorrelationKey),
+			logger.String("publisher_tenant_id", message.TenantID),
+			logger.String("subscription_tenant_id", models.DefaultTenantID),
+			logger.String("process_instance_id", token.ProcessInstanceID),
+			logger.String("token_id", token.TokenID))
+	}
+
 	// Add message variables to token variables
 	if message.Variables != nil {
 		if token.Variables == nil {
@@ -9,10 +9,12 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package process
 
 import (
+	"context"
 	"fmt"
 
 	"atom-engine/src/core/logger"
 	"atom-engine/src/core/models"
+	"atom-engine/src/core/tracing"
 )
 
 // CallActivityExecutor executes call activities
@@ -37,14 +39,19 @@ func (cae *CallActivityExecutor) Execute(
 		activityName = token.CurrentElementID
 	}
 
-	// Check if call activity was already executed for this token
-	callActivityKey := fmt.Sprintf("call_activity_executed:%s", token.CurrentElementID)
+	// Check if child process of this activation already completed, revisit in loop is new activation
+	// Проверяем, завершился ли дочерний процесс этой активации, повторный заход в цикле - новая активация
+	callActivityKey := callActivityExecutedKey(token.CurrentElementID, token.Key)
 	if executed, exists := token.GetExecutionContext(callActivityKey); exists && executed == true {
 		logger.Info("Call activity already executed, continuing to next elements",
 			logger.String("token_id", token.TokenID),
 			logger.String("activity_name", activityName),
 			logger.String("element_id", token.CurrentElementID))
 
+		// Token leaves element, marker must not match later activation
+		// Токен покидает элемент, метка не должна совпасть с более поздней активацией
+		token.DeleteExecutionContext(callActivityKey)
+
 		// Child process completed, continue to next elements
 		outgoing, exists := element["outgoing"]
 		if !exists {
@@ -69,12 +76,16 @@ func (cae *CallActivityExecutor) Execute(
 
 		return &ExecutionResult{
 			Success:      true,
-			TokenUpdated: false,
+			TokenUpdated: true,
 			NextElements: nextElements,
 			Completed:    false,
 		}, nil
 	}
 
+	// Marker left by element ID scoped guard of earlier versions is stale here
+	// Метка, оставленная прежней защитой по ID элемента, здесь устарела
+	token.DeleteExecutionContext(legacyCallActivityExecutedKey(token.CurrentElementID))
+
 	// Extract called process ID from extension elements
 	calledProcessID, err := cae.extractCalledProcessID(element)
 	if err != nil {
@@ -107,8 +118,18 @@ func (cae *CallActivityExecutor) Execute(
 		}, nil
 	}
 
-	// Start child process instance with evaluated variables
-	childInstance, err := cae.component.StartProcessInstance(calledProcessID, evaluatedVariables)
+	// Child instance inherits priority of parent instance
+	// Дочерний экземпляр наследует приоритет родительского
+	priority := 0
+	if parentInstance, err := cae.component.GetProcessInstanceStatus(token.ProcessInstanceID); err == nil {
+		priority = parentInstance.Priority
+	}
+
+	// Start child process instance with evaluated variables, child is traced in trace of parent
+	// Запускаем дочерний экземпляр с вычисленными переменными, он трассируется в трассировке родителя
+	traceCtx := tracing.ContextWithTraceParent(context.Background(), token.TraceParent)
+	childInstance, err := cae.component.StartProcessInstanceWithContext(
+		traceCtx, calledProcessID, evaluatedVariables, priority)
 	if err != nil {
 		logger.Error("Failed to start child process",
 			logger.String("token_id", token.TokenID),
@@ -125,7 +146,7 @@ func (cae *CallActivityExecutor) Execute(
 		logger.String("child_instance_id", childInstance.InstanceID),
 		logger.String("called_process_id", calledProcessID))
 
-	// Mark call activity as executed for this element
+	// Mark call activity as executed for this activation
 	token.SetExecutionContext(callActivityKey, true)
 
 	// Set token to wait for child process completion
@@ -139,6 +160,32 @@ func (cae *CallActivityExecutor) Execute(
 	}, nil
 }
 
+// callActivityExecutedKey returns execution context key marking started child of element activation
+// Возвращает ключ контекста выполнения, отмечающий запущенный дочерний процесс активации элемента
+func callActivityExecutedKey(elementID string, elementInstanceKey int64) string {
+	return fmt.Sprintf("call_activity_executed:%s:%d", elementID, elementInstanceKey)
+}
+
+// legacyCallActivityExecutedKey returns marker key of earlier versions scoped to element ID only
+// Возвращает ключ метки прежних версий, привязанный только к ID элемента
+func legacyCallActivityExecutedKey(elementID string) string {
+	return fmt.Sprintf("call_activity_executed:%s", elementID)
+}
+
+// migrateCallActivityMarker moves marker of token that waited for child before upgrade to activation scoped key
+// Only token resumed by child completion is migrated,
+// stale markers of passed elements are ignored
+// Переносит метку токена, ждавшего дочерний процесс до обновления, на ключ активации
+// Переносится только токен, возобновляемый завершением дочернего процесса,
+// устаревшие метки пройденных элементов игнорируются
+func migrateCallActivityMarker(token *models.Token) {
+	legacyKey := legacyCallActivityExecutedKey(token.CurrentElementID)
+	if executed, exists := token.GetExecutionContext(legacyKey); exists && executed == true {
+		token.DeleteExecutionContext(legacyKey)
+		token.SetExecutionContext(callActivityExecutedKey(token.CurrentElementID, token.Key), true)
+	}
+}
+
 // NewCallActivityExecutor creates new call activity executor
 // Создает новый исполнитель call activity
 func NewCallActivityExecutor(component ComponentInterface) *CallActivityExecutor {
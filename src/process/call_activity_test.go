@@ -0,0 +1,133 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"testing"
+
+	"atom-engine/src/core/models"
+)
+
+// childProcess is called process with single service task, so each child instance leaves one job
+// Вызываемый процесс с одной сервисной задачей, поэтому каждый дочерний экземпляр оставляет один job
+const childProcess = `
+    <bpmn:startEvent id="childStart"><bpmn:outgoing>c1</bpmn:outgoing></bpmn:startEvent>
+    <bpmn:sequenceFlow id="c1" sourceRef="childStart" targetRef="childTask" />
+    <bpmn:serviceTask id="childTask">
+      <bpmn:extensionElements><zeebe:taskDefinition type="child-work" /></bpmn:extensionElements>
+      <bpmn:incoming>c1</bpmn:incoming><bpmn:outgoing>c2</bpmn:outgoing>
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="c2" sourceRef="childTask" targetRef="childEnd" />
+    <bpmn:endEvent id="childEnd"><bpmn:incoming>c2</bpmn:incoming></bpmn:endEvent>`
+
+// loopingCallProcess calls child process, then decides whether to call it again
+// Вызывает дочерний процесс, затем решает, вызывать ли его снова
+const loopingCallProcess = `
+    <bpmn:startEvent id="start"><bpmn:outgoing>f1</bpmn:outgoing></bpmn:startEvent>
+    <bpmn:sequenceFlow id="f1" sourceRef="start" targetRef="join" />
+    <bpmn:exclusiveGateway id="join"><bpmn:incoming>f1</bpmn:incoming><bpmn:incoming>again</bpmn:incoming>
+      <bpmn:outgoing>f2</bpmn:outgoing></bpmn:exclusiveGateway>
+    <bpmn:sequenceFlow id="f2" sourceRef="join" targetRef="call" />
+    <bpmn:callActivity id="call">
+      <bpmn:extensionElements><zeebe:calledElement processId="loop-child" /></bpmn:extensionElements>
+      <bpmn:incoming>f2</bpmn:incoming><bpmn:outgoing>f3</bpmn:outgoing>
+    </bpmn:callActivity>
+    <bpmn:sequenceFlow id="f3" sourceRef="call" targetRef="decide" />
+    <bpmn:serviceTask id="decide">
+      <bpmn:extensionElements><zeebe:taskDefinition type="decide" /></bpmn:extensionElements>
+      <bpmn:incoming>f3</bpmn:incoming><bpmn:outgoing>f4</bpmn:outgoing>
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="f4" sourceRef="decide" targetRef="split" />
+    <bpmn:exclusiveGateway id="split" default="done">
+      <bpmn:incoming>f4</bpmn:incoming><bpmn:outgoing>again</bpmn:outgoing><bpmn:outgoing>done</bpmn:outgoing>
+    </bpmn:exclusiveGateway>
+    <bpmn:sequenceFlow id="again" sourceRef="split" targetRef="join">
+      <bpmn:conditionExpression xsi:type="bpmn:tFormalExpression">=repeat</bpmn:conditionExpression>
+    </bpmn:sequenceFlow>
+    <bpmn:sequenceFlow id="done" sourceRef="split" targetRef="end" />
+    <bpmn:endEvent id="end"><bpmn:incoming>done</bpmn:incoming></bpmn:endEvent>`
+
+// childJobInstances returns instance IDs of child service task jobs across all instances
+// Возвращает ID экземпляров job'ов сервисной задачи дочернего процесса по всем экземплярам
+func (e *testEngine) childJobInstances() []string {
+	e.t.Helper()
+
+	all, _, err := e.jobs.ListJobs("child-work", "", "", "", 1000, 0)
+	if err != nil {
+		e.t.Fatalf("list child jobs: %v", err)
+	}
+	instances := make([]string, 0, len(all))
+	for _, job := range all {
+		instances = append(instances, job.ProcessInstanceID)
+	}
+	return instances
+}
+
+func TestCallActivityRevisitedInLoopStartsNewChild(t *testing.T) {
+	e := newTestEngine(t)
+
+	e.deploy(bpmnDefinitions("loop-child", childProcess))
+	processID := e.deploy(bpmnDefinitions("loop-parent", loopingCallProcess))
+	instance := e.start(processID, map[string]interface{}{})
+
+	var childInstances []string
+	for visit := 1; visit <= 2; visit++ {
+		e.waitFor("child job of visit", func() bool { return len(e.childJobInstances()) == visit })
+
+		var child string
+		for _, childID := range e.childJobInstances() {
+			if len(childInstances) == 0 || childInstances[0] != childID {
+				child = childID
+			}
+		}
+		childInstances = append(childInstances, child)
+
+		childJob := e.waitJob(child, "childTask")
+		e.completeJob(childJob, nil)
+
+		decide := e.waitJob(instance.InstanceID, "decide")
+		e.completeJob(decide, map[string]interface{}{"repeat": visit < 2})
+	}
+
+	e.waitState(instance.InstanceID, models.ProcessInstanceStateCompleted)
+
+	if len(childInstances) != 2 || childInstances[0] == childInstances[1] {
+		t.Fatalf("second visit must start new child instance, got %v", childInstances)
+	}
+	if got := len(e.childJobInstances()); got != 2 {
+		t.Fatalf("started %d child instances, want 2", got)
+	}
+	for _, childID := range childInstances {
+		if state := e.instance(childID).State; state != models.ProcessInstanceStateCompleted {
+			t.Errorf("child %s in state %s, want completed", childID, state)
+		}
+	}
+}
+
+func TestCallActivityExecutedKeyScopedToActivation(t *testing.T) {
+	first := callActivityExecutedKey("call", 1)
+	second := callActivityExecutedKey("call", 2)
+	if first == second {
+		t.Fatalf("activations share marker key %s", first)
+	}
+	if first == callActivityExecutedKey("other", 1) {
+		t.Fatalf("elements share marker key %s", first)
+	}
+
+	token := models.NewToken("instance-1", "process-1", "call")
+	token.SetExecutionContext(legacyCallActivityExecutedKey("call"), true)
+	migrateCallActivityMarker(token)
+
+	if _, exists := token.GetExecutionContext(legacyCallActivityExecutedKey("call")); exists {
+		t.Error("legacy marker kept after migration")
+	}
+	if executed, _ := token.GetExecutionContext(callActivityExecutedKey("call", token.Key)); executed != true {
+		t.Error("legacy marker not moved to activation key")
+	}
+}
@@ -9,6 +9,7 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package process
 
 import (
+	"errors"
 	"fmt"
 
 	"atom-engine/src/core/logger"
@@ -16,6 +17,10 @@ import (
 	"atom-engine/src/storage"
 )
 
+// ErrTokenNotWaiting is returned when callback targets token that has already moved on
+// Возвращается, когда callback адресован токену, который уже продвинулся дальше
+var ErrTokenNotWaiting = errors.New("token is not waiting for callback")
+
 // CallbackHelper provides common callback processing functionality
 // Предоставляет общую функциональность обработки callbacks
 type CallbackHelper struct {
@@ -48,12 +53,14 @@ func (ch *CallbackHelper) LoadAndValidateToken(tokenID, expectedWaitingFor strin
 
 	// Check if token is waiting for expected condition
 	if !token.IsWaiting() || token.WaitingFor != expectedWaitingFor {
-		logger.Warn("Token is not waiting for expected condition",
+		// Late or duplicate callback, caller decides whether it is an error
+		// Запоздавший или повторный callback, вызывающий решает, ошибка ли это
+		logger.Info("Token is not waiting for expected condition",
 			logger.String("token_id", tokenID),
 			logger.String("token_state", string(token.State)),
 			logger.String("token_waiting_for", token.WaitingFor),
 			logger.String("expected_waiting_for", expectedWaitingFor))
-		return nil, fmt.Errorf("token %s is not waiting for %s", tokenID, expectedWaitingFor)
+		return nil, fmt.Errorf("token %s is not waiting for %s: %w", tokenID, expectedWaitingFor, ErrTokenNotWaiting)
 	}
 
 	logger.Info("Token confirmed waiting for condition",
@@ -70,6 +77,8 @@ func (ch *CallbackHelper) ProcessCallbackAndContinue(
 	elementID string,
 	variables map[string]interface{},
 ) error {
+	log := ch.component.TokenLogger(token)
+
 	// Clear waiting state and merge variables if provided
 	token.ClearWaitingFor()
 	if variables != nil {
@@ -77,26 +86,16 @@ func (ch *CallbackHelper) ProcessCallbackAndContinue(
 	}
 
 	// Cancel boundary timers when token leaves activity (Service Task, etc.)
+	// Timers registered on token are authoritative, token is saved below
 	// Отменяем boundary таймеры когда токен покидает activity (Service Task, и т.д.)
-	// Always try to cancel boundary timers regardless of token's boundary timer IDs
-	// since there might be synchronization issues between token and storage
-	// Всегда пытаемся отменить boundary таймеры независимо от boundary timer IDs токена
-	// поскольку может быть рассинхронизация между токеном и storage
-	logger.Info("Attempting to cancel boundary timers for token leaving activity",
-		logger.String("token_id", token.TokenID),
-		logger.String("element_id", elementID),
-		logger.Bool("token_has_boundary_timers", token.HasBoundaryTimers()),
-		logger.String("token_boundary_timer_ids", fmt.Sprintf("%v", token.GetBoundaryTimers())))
-
-	if err := ch.component.CancelBoundaryTimersForToken(token.TokenID); err != nil {
-		logger.Error("Failed to cancel boundary timers for token leaving activity",
-			logger.String("token_id", token.TokenID),
+	// Таймеры, зарегистрированные в токене, являются источником истины, токен сохраняется ниже
+	if err := ch.component.CancelBoundaryTimers(token); err != nil {
+		log.Error("Failed to cancel boundary timers for token leaving activity",
 			logger.String("element_id", elementID),
 			logger.String("error", err.Error()))
 		// Continue execution - boundary timer cancellation is not critical
 	} else {
-		logger.Info("Boundary timer cancellation completed for token leaving activity",
-			logger.String("token_id", token.TokenID),
+		log.Debug("Boundary timer cancellation completed for token leaving activity",
 			logger.String("element_id", elementID))
 	}
 
@@ -113,27 +112,19 @@ func (ch *CallbackHelper) ProcessCallbackAndContinue(
 		currentElementID = elementID
 	}
 
-	logger.Info("DEBUG: About to move token to next elements",
-		logger.String("token_id", token.TokenID),
-		logger.String("token_current_element_id", token.CurrentElementID),
+	log.Debug("Moving token to next elements",
 		logger.String("element_id_param", elementID),
-		logger.String("using_element_id", currentElementID))
+		logger.String("current_element_id", currentElementID))
 
 	if err := ch.tokenMovement.MoveTokenToNextElements(token, currentElementID); err != nil {
-		logger.Error("DEBUG: Failed to move token to next elements",
-			logger.String("token_id", token.TokenID),
+		log.Error("Failed to move token to next elements",
 			logger.String("current_element_id", currentElementID),
 			logger.String("error", err.Error()))
 		return fmt.Errorf("failed to move token to next elements: %w", err)
 	}
 
-	logger.Info("DEBUG: Successfully moved token to next elements",
-		logger.String("token_id", token.TokenID),
-		logger.String("current_element_id", currentElementID))
-
-	logger.Info("Callback processed successfully - token execution continued",
-		logger.String("element_id", elementID),
-		logger.String("token_id", token.TokenID))
+	log.Debug("Callback processed - token execution continued",
+		logger.String("element_id", elementID))
 
 	return nil
 }
@@ -152,7 +143,7 @@ func (ch *CallbackHelper) ProcessCallbackAndContinueWithFlows(
 	}
 
 	// Cancel boundary timers
-	if err := ch.component.CancelBoundaryTimersForToken(token.TokenID); err != nil {
+	if err := ch.component.CancelBoundaryTimers(token); err != nil {
 		logger.Error("Failed to cancel boundary timers",
 			logger.String("token_id", token.TokenID),
 			logger.String("error", err.Error()))
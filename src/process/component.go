@@ -17,7 +17,9 @@ import (
 	"strings"
 	"time"
 
+	"atom-engine/src/core/config"
 	"atom-engine/src/core/logger"
+	"atom-engine/src/core/metrics"
 	"atom-engine/src/core/models"
 	"atom-engine/src/storage"
 )
@@ -32,6 +34,7 @@ type CoreInterface interface {
 	GetIncidentsComponent() interface{}          // Returns IncidentsComponentInterface
 	GetAuthComponent() interface{}               // Returns AuthComponentInterface
 	SendMessage(componentName, messageJSON string) error
+	PublishEngineEvent(event models.EngineEvent)
 }
 
 // ComponentInterface defines process component interface (legacy compatibility)
@@ -45,15 +48,40 @@ type ComponentInterface interface {
 
 	// Process management
 	StartProcessInstance(processKey string, variables map[string]interface{}) (*models.ProcessInstance, error)
+	StartProcessInstanceWithPriority(
+		processKey string,
+		variables map[string]interface{},
+		priority int,
+	) (*models.ProcessInstance, error)
+	StartProcessInstanceWithContext(
+		ctx context.Context,
+		processKey string,
+		variables map[string]interface{},
+		priority int,
+	) (*models.ProcessInstance, error)
+	StartProcessInstanceAsync(
+		ctx context.Context,
+		processKey string,
+		variables map[string]interface{},
+		priority int,
+	) (*models.ProcessInstance, error)
+	StartProcessInstanceAtStartEvent(
+		processKey string,
+		startEventID string,
+		variables map[string]interface{},
+	) (*models.ProcessInstance, error)
 	GetProcessInstanceStatus(instanceID string) (*models.ProcessInstance, error)
 	CancelProcessInstance(instanceID string, reason string) error
+	TerminateProcessInstance(instanceID, tokenID, elementID string) error
 	ListProcessInstances(statusFilter string, processKeyFilter string, limit int) ([]*models.ProcessInstance, error)
+	QueryProcessInstances(query models.ProcessInstanceQuery) ([]*models.ProcessInstance, int, error)
 
 	// Token management
 	GetActiveTokens(instanceID string) ([]*models.Token, error)
 	GetTokensByProcessInstance(instanceID string) ([]*models.Token, error)
 	GetAllTokens() ([]*models.Token, error)
 	ExecuteToken(token *models.Token) error
+	SubmitToken(token *models.Token)
 	ContinueExecution(instanceID string) error
 	UpdateToken(token *models.Token) error
 
@@ -61,14 +89,19 @@ type ComponentInterface interface {
 	CreateTimer(timerRequest *TimerRequest) error
 	HandleTimerCallback(timerID, elementID, tokenID string) error
 	CreateBoundaryTimer(timerRequest *TimerRequest) error
-	CreateBoundaryTimerWithID(timerRequest *TimerRequest) (string, error)
-	LinkBoundaryTimerToToken(tokenID, timerID string) error
+	RegisterBoundaryTimer(token *models.Token, timerRequest *TimerRequest) (string, error)
 	CancelBoundaryTimersForToken(tokenID string) error
+	CancelBoundaryTimers(token *models.Token) error
 	CancelEventTimersForToken(tokenID string) error
 	CancelAllTimersForProcessInstance(instanceID string) error
+	ScheduleInstanceSLA(instance *models.ProcessInstance, bpmnProcess *models.BPMNProcess, sla time.Duration) error
+	CancelInstanceSLA(instance *models.ProcessInstance)
 
 	// Job management
-	HandleJobCallback(jobID, elementID, tokenID, status, errorMessage string, variables map[string]interface{}) error
+	HandleJobCallback(
+		callbackID, jobID, elementID, tokenID, status, errorMessage string,
+		variables map[string]interface{},
+	) error
 	CancelJobForToken(tokenID string) error
 	CancelJobByID(jobID string) error
 	CancelAllJobsForProcessInstance(instanceID string, reason string) error
@@ -106,6 +139,7 @@ type ComponentInterface interface {
 
 	// Helper methods
 	GetBPMNProcessForToken(token *models.Token) (map[string]interface{}, error)
+	TokenLogger(token *models.Token) *logger.ContextLogger
 
 	// Gateway synchronization methods
 	SaveGatewaySyncState(state *models.GatewaySyncState) error
@@ -123,11 +157,21 @@ type ComponentInterface interface {
 	BroadcastSignal(signalName string, variables map[string]interface{}) error
 	UnsubscribeSignalsByToken(tokenID string) error
 
+	// Conditional event management
+	SubscribeToCondition(subscription *ConditionSubscription)
+	UnsubscribeConditionsByToken(tokenID string)
+	EvaluateConditionalEvents(instanceID string, variables map[string]interface{})
+
+	// Multi-instance activity management
+	StartMultiInstance(token *models.Token, multiInstance map[string]interface{}) error
+	CompleteMultiInstanceInstance(token *models.Token) error
+
 	// Legacy compatibility (will be removed in future)
 	GetJobsComponent() interface{}
 	GetMessagesComponent() interface{}
 	GetCore() CoreInterface
 	GetStorage() storage.Storage
+	GetInstanceStatistics() *InstanceStatistics
 }
 
 // TimerRequest represents timer creation request from flow element
@@ -198,6 +242,27 @@ type Component struct {
 	// Signal management
 	signalManager *SignalManager
 
+	// Conditional event management
+	conditionManager *ConditionManager
+
+	// Multi-instance activity management
+	multiInstanceManager *MultiInstanceManager
+
+	// Bounded execution of asynchronously spawned tokens
+	tokenPool *TokenExecutionPool
+
+	// Batch start of process instances
+	batchStarter *BatchStarter
+
+	// Per-version instance statistics
+	statistics *InstanceStatistics
+
+	// Side-effect free walk of process definitions
+	dryRunner *DryRunner
+
+	// Per-version elevation of execution logs
+	executionLogging *ExecutionLogging
+
 	// Component state
 	ready  bool
 	ctx    context.Context
@@ -206,8 +271,7 @@ type Component struct {
 
 // NewComponent creates new process component with SRP architecture
 // Создает новый компонент процессов с SRP архитектурой
-func NewComponent(storage storage.Storage) *Component {
-	logger.Info("DEBUG: NewComponent called")
+func NewComponent(cfg *config.Config, storage storage.Storage) *Component {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	comp := &Component{
@@ -215,6 +279,11 @@ func NewComponent(storage storage.Storage) *Component {
 		ctx:     ctx,
 		cancel:  cancel,
 	}
+	comp.tokenPool = NewTokenExecutionPool(cfg.Engine.TokenWorkers, cfg.Engine.TokenQueueSize, comp.ExecuteToken)
+	comp.batchStarter = NewBatchStarter(comp, cfg.Engine.BatchStartWorkers, cfg.Engine.BatchStartMaxItems, ctx.Done())
+	comp.statistics = NewInstanceStatistics()
+	comp.dryRunner = NewDryRunner(storage, comp)
+	comp.executionLogging = NewExecutionLogging()
 
 	// Initialize specialized managers
 	comp.processManager = NewProcessInstanceManager(storage, comp)
@@ -229,12 +298,15 @@ func NewComponent(storage storage.Storage) *Component {
 	// Initialize signal management
 	comp.signalManager = NewSignalManager(comp)
 
+	// Initialize conditional event management
+	comp.conditionManager = NewConditionManager(storage, comp)
+
+	// Initialize multi-instance activity management
+	comp.multiInstanceManager = NewMultiInstanceManager(storage, comp)
+
 	// Initialize core components
-	logger.Info("DEBUG: About to create BPMNHelper")
 	comp.bpmnHelper = NewBPMNHelper(storage)
-	logger.Info("DEBUG: About to create Engine")
 	comp.engine = NewEngine(storage, comp)
-	logger.Info("DEBUG: Engine created successfully")
 
 	return comp
 }
@@ -334,8 +406,33 @@ func (c *Component) Start() error {
 		}
 	}
 
+	c.tokenPool.Start()
+
+	// Statistics are restored before component accepts instances, so no start or finish is missed
+	// Статистика восстанавливается до приема экземпляров компонентом, чтобы не пропустить запуски и завершения
+	c.restoreInstanceStatistics()
+
 	c.ready = true
 	logger.Info("Process component started")
+	return nil
+}
+
+// Recover restores in-flight process instances and their subscriptions from storage
+// Called by core once all components are started, as restored tokens create jobs and timers
+// Восстанавливает выполняющиеся экземпляры процессов и их подписки из storage
+// Вызывается core после запуска всех компонентов, так как восстановленные токены создают job'ы и таймеры
+func (c *Component) Recover() {
+	c.restoreActiveInstanceMetrics()
+
+	// Timers are linked before restored tokens leave activities and cancel them
+	// Таймеры связываются до того, как восстановленные токены покинут активности и отменят их
+	if timerMgr, ok := c.timerManager.(*UnifiedTimerManager); ok {
+		timerMgr.MigrateBoundaryTimerLinks()
+	}
+
+	if err := c.conditionManager.Restore(); err != nil {
+		logger.Error("Failed to restore condition subscriptions", logger.String("error", err.Error()))
+	}
 
 	// Restore active process instances and tokens AFTER component is ready
 	if processMgr, ok := c.processManager.(*ProcessInstanceManager); ok {
@@ -344,7 +441,6 @@ func (c *Component) Start() error {
 			// Don't fail startup, just log the error
 		}
 	}
-	return nil
 }
 
 // Stop stops process component
@@ -355,6 +451,10 @@ func (c *Component) Stop() error {
 	c.ready = false
 	c.cancel()
 
+	// Stop token pool, queued tokens are restored as active tokens on next start
+	// Останавливаем пул токенов, токены из очереди восстанавливаются при следующем запуске
+	c.tokenPool.Stop()
+
 	// Stop token manager
 	if tokenMgr, ok := c.tokenManager.(*TokenManager); ok {
 		if err := tokenMgr.Stop(); err != nil {
@@ -387,14 +487,98 @@ func (c *Component) StartProcessInstance(
 	return c.processManager.StartProcessInstance(processKey, variables)
 }
 
+func (c *Component) StartProcessInstanceWithPriority(
+	processKey string,
+	variables map[string]interface{},
+	priority int,
+) (*models.ProcessInstance, error) {
+	return c.processManager.StartProcessInstanceWithPriority(processKey, variables, priority)
+}
+
+func (c *Component) StartProcessInstanceWithContext(
+	ctx context.Context,
+	processKey string,
+	variables map[string]interface{},
+	priority int,
+) (*models.ProcessInstance, error) {
+	return c.processManager.StartProcessInstanceWithContext(ctx, processKey, variables, priority)
+}
+
+func (c *Component) StartProcessInstanceAsync(
+	ctx context.Context,
+	processKey string,
+	variables map[string]interface{},
+	priority int,
+) (*models.ProcessInstance, error) {
+	return c.processManager.StartProcessInstanceAsync(ctx, processKey, variables, priority)
+}
+
+// StartProcessInstanceBatch starts instances of all items and returns finished batch
+// Запускает экземпляры всех элементов и возвращает завершенный пакет
+func (c *Component) StartProcessInstanceBatch(
+	ctx context.Context,
+	items []models.BatchStartItem,
+) (*models.BatchStart, error) {
+	return c.batchStarter.Start(ctx, items)
+}
+
+// StartProcessInstanceBatchAsync accepts batch and starts its instances in background
+// Принимает пакет и запускает его экземпляры в фоне
+func (c *Component) StartProcessInstanceBatchAsync(
+	ctx context.Context,
+	items []models.BatchStartItem,
+) (*models.BatchStart, error) {
+	return c.batchStarter.StartAsync(ctx, items)
+}
+
+// GetProcessInstanceBatch returns progress of background batch start
+// Возвращает прогресс фонового пакетного запуска
+func (c *Component) GetProcessInstanceBatch(batchID string) (*models.BatchStart, error) {
+	return c.batchStarter.Get(batchID)
+}
+
+// GetInstanceStatistics returns per-version instance statistics of component
+// Возвращает статистику экземпляров компонента по версиям
+func (c *Component) GetInstanceStatistics() *InstanceStatistics {
+	return c.statistics
+}
+
+// GetProcessStatistics returns instance statistics of process definition with daily counts of last days
+// Возвращает статистику экземпляров определения процесса с дневными счетчиками за последние дни
+func (c *Component) GetProcessStatistics(processID string, days int) *models.ProcessStatistics {
+	return c.statistics.Snapshot(processID, days, time.Now())
+}
+
+// DryRunProcess walks process definition with given variables and stubbed outcomes without side effects
+// Проходит определение процесса с заданными переменными и заглушками исходов без побочных эффектов
+func (c *Component) DryRunProcess(processKey string, request *models.DryRunRequest) (*models.DryRunResult, error) {
+	return c.dryRunner.Run(processKey, request)
+}
+
+func (c *Component) StartProcessInstanceAtStartEvent(
+	processKey string,
+	startEventID string,
+	variables map[string]interface{},
+) (*models.ProcessInstance, error) {
+	return c.processManager.StartProcessInstanceAtStartEvent(processKey, startEventID, variables)
+}
+
 func (c *Component) GetProcessInstanceStatus(instanceID string) (*models.ProcessInstance, error) {
+	instanceID = c.resolveInstanceID(instanceID)
 	return c.processManager.GetProcessInstanceStatus(instanceID)
 }
 
 func (c *Component) CancelProcessInstance(instanceID string, reason string) error {
+	instanceID = c.resolveInstanceID(instanceID)
 	return c.processManager.CancelProcessInstance(instanceID, reason)
 }
 
+// TerminateProcessInstance cancels all other tokens of instance for terminate end event
+// Отменяет все остальные токены экземпляра для terminate end event
+func (c *Component) TerminateProcessInstance(instanceID, tokenID, elementID string) error {
+	return c.processManager.TerminateProcessInstance(instanceID, tokenID, elementID)
+}
+
 func (c *Component) ListProcessInstances(
 	statusFilter string,
 	processKeyFilter string,
@@ -403,14 +587,59 @@ func (c *Component) ListProcessInstances(
 	return c.processManager.ListProcessInstances(statusFilter, processKeyFilter, limit)
 }
 
+func (c *Component) QueryProcessInstances(
+	query models.ProcessInstanceQuery,
+) ([]*models.ProcessInstance, int, error) {
+	return c.processManager.QueryProcessInstances(query)
+}
+
+func (c *Component) RestartProcessInstance(instanceID string, elementID string) (*models.ProcessInstance, error) {
+	instanceID = c.resolveInstanceID(instanceID)
+	return c.processManager.RestartProcessInstance(instanceID, elementID)
+}
+
+func (c *Component) ModifyProcessInstance(
+	instanceID string,
+	instructions []models.ModificationInstruction,
+) (*models.ProcessInstanceModification, error) {
+	instanceID = c.resolveInstanceID(instanceID)
+	return c.processManager.ModifyProcessInstance(instanceID, instructions)
+}
+
+func (c *Component) MigrateProcessInstances(
+	plan models.MigrationPlan,
+	filter models.MigrationFilter,
+	dryRun bool,
+) (*models.ProcessInstanceMigration, error) {
+	return c.processManager.MigrateProcessInstances(plan, filter, dryRun)
+}
+
+func (c *Component) PatchProcessInstanceVariables(
+	instanceID string,
+	patch map[string]interface{},
+) (map[string]interface{}, error) {
+	instanceID = c.resolveInstanceID(instanceID)
+	variables, err := c.processManager.PatchProcessInstanceVariables(instanceID, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	// Patched variables are already applied to tokens, conditions see them without overlay
+	// Патч уже применен к токенам, условия видят его без наложения
+	c.EvaluateConditionalEvents(instanceID, nil)
+	return variables, nil
+}
+
 // TokenManagerInterface delegation
 // Делегирование TokenManagerInterface
 
 func (c *Component) GetActiveTokens(instanceID string) ([]*models.Token, error) {
+	instanceID = c.resolveInstanceID(instanceID)
 	return c.tokenManager.GetActiveTokens(instanceID)
 }
 
 func (c *Component) GetTokensByProcessInstance(instanceID string) ([]*models.Token, error) {
+	instanceID = c.resolveInstanceID(instanceID)
 	return c.tokenManager.GetTokensByProcessInstance(instanceID)
 }
 
@@ -425,6 +654,79 @@ func (c *Component) ExecuteToken(token *models.Token) error {
 	return c.engine.ExecuteToken(token)
 }
 
+// resolveInstanceID converts numeric instance key to string instance ID
+// Конвертирует числовой ключ экземпляра в строковый ID экземпляра
+func (c *Component) resolveInstanceID(instanceIDOrKey string) string {
+	instanceID, err := c.storage.ResolveProcessInstanceID(instanceIDOrKey)
+	if err != nil {
+		logger.Warn("Failed to resolve process instance key",
+			logger.String("instance_id", instanceIDOrKey),
+			logger.String("error", err.Error()))
+		return instanceIDOrKey
+	}
+	return instanceID
+}
+
+// SubmitToken schedules asynchronous token execution on bounded worker pool
+// Планирует асинхронное выполнение токена в ограниченном пуле воркеров
+func (c *Component) SubmitToken(token *models.Token) {
+	c.tokenPool.Submit(token)
+}
+
+// restoreActiveInstanceMetrics sets active instance gauges from storage after restart
+// Устанавливает gauge активных экземпляров из storage после перезапуска
+func (c *Component) restoreActiveInstanceMetrics() {
+	instances, err := c.storage.LoadAllProcessInstances()
+	if err != nil {
+		logger.Warn("Failed to restore active instance metrics", logger.String("error", err.Error()))
+		return
+	}
+
+	active := make(map[string]int64)
+	for _, instance := range instances {
+		if !instance.IsCompleted() {
+			active[instance.ProcessID]++
+		}
+	}
+	for processID, count := range active {
+		metrics.Processes.SetActiveInstances(processID, "", count)
+	}
+}
+
+// restoreInstanceStatistics rebuilds per-version instance statistics from stored instances and open incidents
+// Восстанавливает статистику экземпляров по версиям из сохраненных экземпляров и открытых инцидентов
+func (c *Component) restoreInstanceStatistics() {
+	instances, err := c.storage.LoadAllProcessInstances()
+	if err != nil {
+		logger.Warn("Failed to restore instance statistics", logger.String("error", err.Error()))
+		return
+	}
+
+	openIncidents := make(map[string]int)
+	incidents, openTotal, err := c.storage.ListIncidents(map[string]interface{}{"status": []interface{}{"OPEN"}})
+	if err != nil {
+		logger.Warn("Failed to restore open incident statistics", logger.String("error", err.Error()))
+	}
+	if records, ok := incidents.([]map[string]interface{}); ok {
+		for _, record := range records {
+			if instanceID, _ := record["process_instance_id"].(string); instanceID != "" {
+				openIncidents[instanceID]++
+			}
+		}
+	}
+
+	c.statistics.Restore(instances, openIncidents)
+	logger.Info("Instance statistics restored",
+		logger.Int("instances", len(instances)),
+		logger.Int("open_incidents", openTotal))
+}
+
+// GetTokenExecutionStats returns token execution pool state
+// Возвращает состояние пула выполнения токенов
+func (c *Component) GetTokenExecutionStats() TokenExecutionStats {
+	return c.tokenPool.Stats()
+}
+
 func (c *Component) ContinueExecution(instanceID string) error {
 	if !c.IsReady() {
 		return fmt.Errorf("process component not ready")
@@ -463,18 +765,28 @@ func (c *Component) CreateBoundaryTimer(timerRequest *TimerRequest) error {
 	return c.timerManager.CreateBoundaryTimer(timerRequest)
 }
 
-func (c *Component) CreateBoundaryTimerWithID(timerRequest *TimerRequest) (string, error) {
-	return c.timerManager.CreateBoundaryTimerWithID(timerRequest)
-}
-
-func (c *Component) LinkBoundaryTimerToToken(tokenID, timerID string) error {
-	return c.timerManager.LinkBoundaryTimerToToken(tokenID, timerID)
+func (c *Component) RegisterBoundaryTimer(token *models.Token, timerRequest *TimerRequest) (string, error) {
+	return c.timerManager.RegisterBoundaryTimer(token, timerRequest)
 }
 
+// CancelBoundaryTimersForToken cancels boundary timers of token leaving activity
+// Conditional boundary events of activity are disarmed together with timers
+// Отменяет boundary таймеры токена, покидающего активность
+// Условные граничные события активности снимаются вместе с таймерами
 func (c *Component) CancelBoundaryTimersForToken(tokenID string) error {
+	c.conditionManager.UnsubscribeByToken(tokenID)
 	return c.timerManager.CancelBoundaryTimersForToken(tokenID)
 }
 
+// CancelBoundaryTimers cancels boundary timers of token leaving activity and clears them on token
+// Caller saves token, conditional boundary events of activity are disarmed together with timers
+// Отменяет boundary таймеры токена, покидающего активность, и очищает их в токене
+// Токен сохраняет вызывающий, условные граничные события активности снимаются вместе с таймерами
+func (c *Component) CancelBoundaryTimers(token *models.Token) error {
+	c.conditionManager.UnsubscribeByToken(token.TokenID)
+	return c.timerManager.CancelBoundaryTimers(token)
+}
+
 // CancelEventTimersForToken cancels all EVENT timers for token
 func (c *Component) CancelEventTimersForToken(tokenID string) error {
 	return c.timerManager.CancelEventTimersForToken(tokenID)
@@ -484,6 +796,30 @@ func (c *Component) CancelAllTimersForProcessInstance(instanceID string) error {
 	return c.timerManager.CancelAllTimersForProcessInstance(instanceID)
 }
 
+// ScheduleInstanceSLA registers SLA timer of saved instance, positive sla overrides SLA of definition
+func (c *Component) ScheduleInstanceSLA(
+	instance *models.ProcessInstance,
+	bpmnProcess *models.BPMNProcess,
+	sla time.Duration,
+) error {
+	return c.timerManager.ScheduleInstanceSLA(instance, bpmnProcess, sla)
+}
+
+// CancelInstanceSLA cancels SLA timer of completed or failed instance
+func (c *Component) CancelInstanceSLA(instance *models.ProcessInstance) {
+	c.timerManager.CancelInstanceSLA(instance)
+}
+
+// SyncTimerStartEvents schedules timer start events of latest process definition version
+func (c *Component) SyncTimerStartEvents(processID string) error {
+	return c.timerManager.SyncTimerStartEvents(processID)
+}
+
+// SyncAllTimerStartEvents schedules timer start events of all deployed process definitions
+func (c *Component) SyncAllTimerStartEvents() error {
+	return c.timerManager.SyncAllTimerStartEvents()
+}
+
 func (c *Component) GetBPMNProcessForToken(token *models.Token) (map[string]interface{}, error) {
 	return c.timerManager.GetBPMNProcessForToken(token)
 }
@@ -506,10 +842,10 @@ func (c *Component) DeleteGatewaySyncState(gatewayID, processInstanceID string)
 // Делегирование JobCallbackManagerInterface
 
 func (c *Component) HandleJobCallback(
-	jobID, elementID, tokenID, status, errorMessage string,
+	callbackID, jobID, elementID, tokenID, status, errorMessage string,
 	variables map[string]interface{},
 ) error {
-	return c.jobManager.HandleJobCallback(jobID, elementID, tokenID, status, errorMessage, variables)
+	return c.jobManager.HandleJobCallback(callbackID, jobID, elementID, tokenID, status, errorMessage, variables)
 }
 
 func (c *Component) CancelJobForToken(tokenID string) error {
@@ -877,6 +1213,36 @@ func (c *Component) UnsubscribeSignalsByToken(tokenID string) error {
 	return c.signalManager.UnsubscribeByToken(tokenID)
 }
 
+// SubscribeToCondition registers conditional event waiting for its condition
+// Регистрирует условное событие, ожидающее своего условия
+func (c *Component) SubscribeToCondition(subscription *ConditionSubscription) {
+	c.conditionManager.Subscribe(subscription)
+}
+
+// UnsubscribeConditionsByToken removes all condition subscriptions of token
+// Удаляет все подписки на условия токена
+func (c *Component) UnsubscribeConditionsByToken(tokenID string) {
+	c.conditionManager.UnsubscribeByToken(tokenID)
+}
+
+// EvaluateConditionalEvents re-evaluates pending conditional events of instance after variable change
+// Перевычисляет ожидающие условные события экземпляра после изменения переменных
+func (c *Component) EvaluateConditionalEvents(instanceID string, variables map[string]interface{}) {
+	c.conditionManager.EvaluateInstance(instanceID, variables)
+}
+
+// StartMultiInstance makes token body of multi-instance activity and starts its instances
+// Делает токен телом multi-instance активности и запускает ее экземпляры
+func (c *Component) StartMultiInstance(token *models.Token, multiInstance map[string]interface{}) error {
+	return c.multiInstanceManager.Start(token, multiInstance)
+}
+
+// CompleteMultiInstanceInstance records completed instance of multi-instance activity
+// Фиксирует завершенный экземпляр multi-instance активности
+func (c *Component) CompleteMultiInstanceInstance(token *models.Token) error {
+	return c.multiInstanceManager.CompleteInstance(token)
+}
+
 // UpdateToken updates token in storage
 // Обновляет токен в storage
 func (c *Component) UpdateToken(token *models.Token) error {
@@ -0,0 +1,501 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+	"atom-engine/src/storage"
+)
+
+// conditionWaitingPrefix prefixes waiting state of tokens on conditional catch events
+// Префикс состояния ожидания токенов на условных catch событиях
+const conditionWaitingPrefix = "condition:"
+
+// ConditionSubscription is conditional event waiting for its condition to become true
+// Условное событие, ожидающее, пока его условие станет истинным
+type ConditionSubscription struct {
+	ProcessInstanceID string `json:"process_instance_id"`
+	TokenID           string `json:"token_id"`
+	ElementID         string `json:"element_id"`
+	Condition         string `json:"condition"`
+	// AttachedToRef is activity of boundary event, empty for intermediate catch events
+	// Активность граничного события, пусто для промежуточных catch событий
+	AttachedToRef  string    `json:"attached_to_ref,omitempty"`
+	CancelActivity bool      `json:"cancel_activity"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	// satisfied holds last result of non-interrupting boundary condition,
+	// event fires again only after condition was false
+	// Последний результат условия non-interrupting граничного события,
+	// событие срабатывает снова только после ложного условия
+	satisfied bool
+}
+
+// IsBoundary reports whether subscription belongs to boundary event
+// Сообщает, принадлежит ли подписка граничному событию
+func (s *ConditionSubscription) IsBoundary() bool {
+	return s.AttachedToRef != ""
+}
+
+// ConditionManager keeps conditional event subscriptions per process instance
+// and re-evaluates them when variables of instance change
+// Хранит подписки условных событий по экземплярам процессов
+// и перевычисляет их при изменении переменных экземпляра
+type ConditionManager struct {
+	subscriptions map[string][]*ConditionSubscription // map[processInstanceID]subscriptions
+	mutex         sync.Mutex
+	storage       storage.Storage
+	component     ComponentInterface
+}
+
+// NewConditionManager creates new condition manager
+// Создает новый менеджер условий
+func NewConditionManager(storage storage.Storage, component ComponentInterface) *ConditionManager {
+	return &ConditionManager{
+		subscriptions: make(map[string][]*ConditionSubscription),
+		storage:       storage,
+		component:     component,
+	}
+}
+
+// Subscribe adds subscription, existing subscription of same token and element is replaced
+// Добавляет подписку, существующая подписка того же токена и элемента заменяется
+func (cm *ConditionManager) Subscribe(subscription *ConditionSubscription) {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	if subscription.CreatedAt.IsZero() {
+		subscription.CreatedAt = time.Now()
+	}
+
+	subscriptions := cm.subscriptions[subscription.ProcessInstanceID]
+	filtered := make([]*ConditionSubscription, 0, len(subscriptions)+1)
+	for _, existing := range subscriptions {
+		if existing.TokenID != subscription.TokenID || existing.ElementID != subscription.ElementID {
+			filtered = append(filtered, existing)
+		}
+	}
+	cm.subscriptions[subscription.ProcessInstanceID] = append(filtered, subscription)
+
+	logger.Info("Condition subscription added",
+		logger.String("instance_id", subscription.ProcessInstanceID),
+		logger.String("token_id", subscription.TokenID),
+		logger.String("element_id", subscription.ElementID),
+		logger.String("attached_to", subscription.AttachedToRef),
+		logger.String("condition", subscription.Condition))
+}
+
+// UnsubscribeByToken removes all subscriptions of token
+// Удаляет все подписки токена
+func (cm *ConditionManager) UnsubscribeByToken(tokenID string) {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	count := 0
+	for instanceID, subscriptions := range cm.subscriptions {
+		filtered := make([]*ConditionSubscription, 0, len(subscriptions))
+		for _, subscription := range subscriptions {
+			if subscription.TokenID == tokenID {
+				count++
+				continue
+			}
+			filtered = append(filtered, subscription)
+		}
+		cm.setInstanceSubscriptions(instanceID, filtered)
+	}
+
+	if count > 0 {
+		logger.Info("Condition subscriptions removed for token",
+			logger.String("token_id", tokenID),
+			logger.Int("removed_count", count))
+	}
+}
+
+// GetSubscriptions returns subscriptions of process instance
+// Возвращает подписки экземпляра процесса
+func (cm *ConditionManager) GetSubscriptions(instanceID string) []*ConditionSubscription {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	result := make([]*ConditionSubscription, len(cm.subscriptions[instanceID]))
+	copy(result, cm.subscriptions[instanceID])
+	return result
+}
+
+// EvaluateInstance re-evaluates pending conditions of process instance and triggers
+// events whose condition became true. Variables are changes not yet visible in tokens,
+// such as job results, they take precedence over token variables and are passed
+// to triggered tokens
+// Перевычисляет ожидающие условия экземпляра процесса и запускает события,
+// условие которых стало истинным. Variables - изменения, еще не видимые в токенах,
+// например результаты job'ов, они имеют приоритет над переменными токена
+// и передаются запущенным токенам
+func (cm *ConditionManager) EvaluateInstance(instanceID string, variables map[string]interface{}) {
+	for _, subscription := range cm.GetSubscriptions(instanceID) {
+		token, err := cm.storage.LoadToken(subscription.TokenID)
+		if err != nil || !cm.isArmed(subscription, token) {
+			cm.remove(subscription)
+			continue
+		}
+
+		evaluationVariables := make(map[string]interface{}, len(token.Variables)+len(variables))
+		for key, value := range token.Variables {
+			evaluationVariables[key] = value
+		}
+		for key, value := range variables {
+			evaluationVariables[key] = value
+		}
+
+		satisfied, err := evaluateEventCondition(cm.component, subscription.Condition, evaluationVariables)
+		if err != nil {
+			logger.Warn("Failed to evaluate conditional event condition",
+				logger.String("instance_id", instanceID),
+				logger.String("element_id", subscription.ElementID),
+				logger.String("condition", subscription.Condition),
+				logger.String("error", err.Error()))
+			continue
+		}
+		if !cm.claim(subscription, satisfied) {
+			continue
+		}
+
+		if err := cm.trigger(subscription, variables); err != nil {
+			logger.Error("Failed to trigger conditional event",
+				logger.String("instance_id", instanceID),
+				logger.String("token_id", subscription.TokenID),
+				logger.String("element_id", subscription.ElementID),
+				logger.String("error", err.Error()))
+		}
+	}
+}
+
+// Restore re-creates subscriptions of waiting tokens after restart
+// Active tokens re-enter their elements on restore and subscribe again
+// Восстанавливает подписки ожидающих токенов после перезапуска
+// Активные токены заново входят в свои элементы при восстановлении и подписываются снова
+func (cm *ConditionManager) Restore() error {
+	tokens, err := cm.storage.LoadAllTokens()
+	if err != nil {
+		return fmt.Errorf("failed to load tokens: %w", err)
+	}
+
+	restored := 0
+	for _, token := range tokens {
+		if !token.IsWaiting() {
+			continue
+		}
+
+		bpmnProcess, err := cm.component.GetBPMNProcessForToken(token)
+		if err != nil {
+			continue
+		}
+		elements, _ := bpmnProcess["elements"].(map[string]interface{})
+
+		if strings.HasPrefix(token.WaitingFor, conditionWaitingPrefix) {
+			element, _ := elements[token.CurrentElementID].(map[string]interface{})
+			if condition, ok := conditionalEventCondition(element); ok {
+				cm.Subscribe(&ConditionSubscription{
+					ProcessInstanceID: token.ProcessInstanceID,
+					TokenID:           token.TokenID,
+					ElementID:         token.CurrentElementID,
+					Condition:         condition,
+				})
+				restored++
+			}
+			continue
+		}
+
+		restored += subscribeConditionalBoundaryEvents(cm.component, token, elements)
+	}
+
+	if restored > 0 {
+		logger.Info("Condition subscriptions restored", logger.Int("count", restored))
+	}
+	return nil
+}
+
+// isArmed reports whether token is still in place where event can occur
+// Сообщает, находится ли токен еще там, где событие может произойти
+func (cm *ConditionManager) isArmed(subscription *ConditionSubscription, token *models.Token) bool {
+	if subscription.IsBoundary() {
+		return !token.IsCompleted() && token.CurrentElementID == subscription.AttachedToRef
+	}
+	return token.IsWaiting() && token.WaitingFor == conditionWaitingPrefix+subscription.ElementID
+}
+
+// claim decides under lock whether evaluation result fires event
+// Interrupting and catch subscriptions are removed so concurrent evaluations fire them once,
+// non-interrupting subscriptions fire on transition from false to true
+// Решает под блокировкой, запускает ли результат вычисления событие
+// Прерывающие и catch подписки удаляются, чтобы параллельные вычисления запускали их один раз,
+// non-interrupting подписки срабатывают при переходе условия из ложного в истинное
+func (cm *ConditionManager) claim(subscription *ConditionSubscription, satisfied bool) bool {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+
+	if !cm.contains(subscription) {
+		return false
+	}
+
+	if subscription.IsBoundary() && !subscription.CancelActivity {
+		fire := satisfied && !subscription.satisfied
+		subscription.satisfied = satisfied
+		return fire
+	}
+
+	if !satisfied {
+		return false
+	}
+	cm.removeLocked(subscription)
+	return true
+}
+
+// trigger continues token of catch event or fires boundary event
+// Продолжает токен catch события или запускает граничное событие
+func (cm *ConditionManager) trigger(subscription *ConditionSubscription, variables map[string]interface{}) error {
+	logger.Info("Conditional event condition satisfied",
+		logger.String("instance_id", subscription.ProcessInstanceID),
+		logger.String("token_id", subscription.TokenID),
+		logger.String("element_id", subscription.ElementID),
+		logger.String("attached_to", subscription.AttachedToRef),
+		logger.Bool("cancel_activity", subscription.CancelActivity))
+
+	if !subscription.IsBoundary() {
+		token, err := cm.storage.LoadToken(subscription.TokenID)
+		if err != nil {
+			return fmt.Errorf("failed to load token: %w", err)
+		}
+
+		// Catch event re-evaluates condition on execution and proceeds since it holds now
+		// Catch событие перевычисляет условие при выполнении и продолжает, так как оно выполнено
+		token.ClearWaitingFor()
+		token.MergeVariables(variables)
+		if err := cm.storage.UpdateToken(token); err != nil {
+			return fmt.Errorf("failed to update token: %w", err)
+		}
+		cm.component.SubmitToken(token)
+		return nil
+	}
+
+	if !subscription.CancelActivity {
+		parentToken, err := cm.storage.LoadToken(subscription.TokenID)
+		if err != nil {
+			return fmt.Errorf("failed to load parent token: %w", err)
+		}
+
+		boundaryToken := models.NewToken(parentToken.ProcessInstanceID, parentToken.ProcessKey, subscription.ElementID)
+		boundaryToken.SetVariables(parentToken.Variables)
+		boundaryToken.MergeVariables(variables)
+		boundaryToken.TraceParent = parentToken.TraceParent
+		if err := cm.storage.SaveToken(boundaryToken); err != nil {
+			return fmt.Errorf("failed to save boundary token: %w", err)
+		}
+
+		logger.Info("Non-interrupting conditional boundary token created",
+			logger.String("boundary_token_id", boundaryToken.TokenID),
+			logger.String("boundary_event_id", subscription.ElementID),
+			logger.String("parent_token_id", parentToken.TokenID))
+
+		cm.component.SubmitToken(boundaryToken)
+		return nil
+	}
+
+	// Interrupting boundary event leaves activity, so its other boundary events are disarmed
+	// Прерывающее граничное событие покидает активность, поэтому остальные ее граничные события снимаются
+	if err := cm.component.CancelBoundaryTimersForToken(subscription.TokenID); err != nil {
+		logger.Error("Failed to cancel boundary timers for interrupted token",
+			logger.String("token_id", subscription.TokenID),
+			logger.String("error", err.Error()))
+	}
+	if err := cm.component.CancelEventTimersForToken(subscription.TokenID); err != nil {
+		logger.Error("Failed to cancel EVENT timers for interrupted token",
+			logger.String("token_id", subscription.TokenID),
+			logger.String("error", err.Error()))
+	}
+	cm.component.RemoveErrorBoundariesForToken(subscription.TokenID)
+
+	// Reload token since timer cancellation updates it
+	// Перезагружаем токен, так как отмена таймеров его обновляет
+	parentToken, err := cm.storage.LoadToken(subscription.TokenID)
+	if err != nil {
+		return fmt.Errorf("failed to load parent token: %w", err)
+	}
+
+	if parentToken.IsWaiting() && strings.HasPrefix(parentToken.WaitingFor, "job:") {
+		jobID := strings.TrimPrefix(parentToken.WaitingFor, "job:")
+		if err := cm.component.CancelJobByID(jobID); err != nil {
+			logger.Error("Failed to cancel job for interrupted token",
+				logger.String("token_id", parentToken.TokenID),
+				logger.String("job_id", jobID),
+				logger.String("error", err.Error()))
+		}
+	}
+
+	parentToken.ClearWaitingFor()
+	parentToken.MergeVariables(variables)
+	parentToken.MoveTo(subscription.ElementID)
+	if err := cm.storage.UpdateToken(parentToken); err != nil {
+		return fmt.Errorf("failed to update parent token: %w", err)
+	}
+
+	logger.Info("Parent token interrupted and moved to conditional boundary event",
+		logger.String("token_id", parentToken.TokenID),
+		logger.String("boundary_event_id", subscription.ElementID))
+
+	cm.component.SubmitToken(parentToken)
+	return nil
+}
+
+// contains reports whether subscription is registered, caller holds lock
+// Сообщает, зарегистрирована ли подписка, вызывающий держит блокировку
+func (cm *ConditionManager) contains(subscription *ConditionSubscription) bool {
+	for _, existing := range cm.subscriptions[subscription.ProcessInstanceID] {
+		if existing == subscription {
+			return true
+		}
+	}
+	return false
+}
+
+// remove removes subscription
+// Удаляет подписку
+func (cm *ConditionManager) remove(subscription *ConditionSubscription) {
+	cm.mutex.Lock()
+	defer cm.mutex.Unlock()
+	cm.removeLocked(subscription)
+}
+
+// removeLocked removes subscription, caller holds lock
+// Удаляет подписку, вызывающий держит блокировку
+func (cm *ConditionManager) removeLocked(subscription *ConditionSubscription) {
+	subscriptions := cm.subscriptions[subscription.ProcessInstanceID]
+	filtered := make([]*ConditionSubscription, 0, len(subscriptions))
+	for _, existing := range subscriptions {
+		if existing != subscription {
+			filtered = append(filtered, existing)
+		}
+	}
+	cm.setInstanceSubscriptions(subscription.ProcessInstanceID, filtered)
+}
+
+// setInstanceSubscriptions stores subscriptions of instance, dropping empty entries
+// Сохраняет подписки экземпляра, удаляя пустые записи
+func (cm *ConditionManager) setInstanceSubscriptions(instanceID string, subscriptions []*ConditionSubscription) {
+	if len(subscriptions) == 0 {
+		delete(cm.subscriptions, instanceID)
+		return
+	}
+	cm.subscriptions[instanceID] = subscriptions
+}
+
+// conditionalEventCondition returns condition of conditional event definition of element
+// Возвращает условие определения условного события элемента
+func conditionalEventCondition(element map[string]interface{}) (string, bool) {
+	eventDefList, _ := element["event_definitions"].([]interface{})
+	for _, eventDef := range eventDefList {
+		eventDefMap, ok := eventDef.(map[string]interface{})
+		if !ok || eventDefMap["type"] != "conditionalEventDefinition" {
+			continue
+		}
+		conditionMap, _ := eventDefMap["condition"].(map[string]interface{})
+		condition, _ := conditionMap["expression"].(string)
+		return strings.TrimSpace(condition), true
+	}
+	return "", false
+}
+
+// subscribeConditionalBoundaryEvents subscribes conditional boundary events attached to activity of token
+// Returns number of created subscriptions
+// Подписывает условные граничные события, прикрепленные к активности токена
+// Возвращает количество созданных подписок
+func subscribeConditionalBoundaryEvents(
+	component ComponentInterface,
+	token *models.Token,
+	elements map[string]interface{},
+) int {
+	count := 0
+	for elementID, element := range elements {
+		elementMap, ok := element.(map[string]interface{})
+		if !ok || elementMap["type"] != "boundaryEvent" || elementMap["attached_to_ref"] != token.CurrentElementID {
+			continue
+		}
+		condition, ok := conditionalEventCondition(elementMap)
+		if !ok {
+			continue
+		}
+
+		cancelActivity := true
+		if cancelBool, ok := elementMap["cancel_activity"].(bool); ok {
+			cancelActivity = cancelBool
+		} else if cancelStr, ok := elementMap["cancel_activity"].(string); ok {
+			cancelActivity = cancelStr != "false"
+		}
+
+		component.SubscribeToCondition(&ConditionSubscription{
+			ProcessInstanceID: token.ProcessInstanceID,
+			TokenID:           token.TokenID,
+			ElementID:         elementID,
+			Condition:         condition,
+			AttachedToRef:     token.CurrentElementID,
+			CancelActivity:    cancelActivity,
+		})
+		count++
+	}
+	return count
+}
+
+// createConditionalBoundaries subscribes conditional boundary events when token enters activity
+// Подписывает условные граничные события при входе токена в активность
+func createConditionalBoundaries(component ComponentInterface, token *models.Token) error {
+	if component == nil {
+		return nil
+	}
+
+	bpmnProcess, err := component.GetBPMNProcessForToken(token)
+	if err != nil {
+		return fmt.Errorf("failed to get BPMN process: %w", err)
+	}
+	elements, _ := bpmnProcess["elements"].(map[string]interface{})
+
+	subscribeConditionalBoundaryEvents(component, token, elements)
+	return nil
+}
+
+// evaluateEventCondition evaluates condition of conditional event against variables
+// Вычисляет условие условного события на переменных
+func evaluateEventCondition(
+	component ComponentInterface,
+	condition string,
+	variables map[string]interface{},
+) (bool, error) {
+	if condition == "" {
+		return false, fmt.Errorf("conditional event has no condition")
+	}
+
+	type ConditionEvaluator interface {
+		EvaluateCondition(variables map[string]interface{}, condition string) (bool, error)
+	}
+
+	core := component.GetCore()
+	if core == nil {
+		return false, fmt.Errorf("core interface not available for condition evaluation")
+	}
+	evaluator, ok := core.GetExpressionComponent().(ConditionEvaluator)
+	if !ok {
+		return false, fmt.Errorf("expression component not available for condition evaluation")
+	}
+
+	return evaluator.EvaluateCondition(variables, condition)
+}
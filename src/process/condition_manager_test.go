@@ -0,0 +1,182 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"testing"
+
+	"atom-engine/src/core/models"
+)
+
+// waitApprovedProcess waits on conditional catch event until order is approved
+// Ожидает на условном catch событии, пока заказ не будет одобрен
+const waitApprovedProcess = `
+    <bpmn:startEvent id="start"><bpmn:outgoing>f1</bpmn:outgoing></bpmn:startEvent>
+    <bpmn:sequenceFlow id="f1" sourceRef="start" targetRef="waitApproved" />
+    <bpmn:intermediateCatchEvent id="waitApproved">
+      <bpmn:incoming>f1</bpmn:incoming>
+      <bpmn:outgoing>f2</bpmn:outgoing>
+      <bpmn:conditionalEventDefinition id="waitApprovedDefinition">
+        <bpmn:condition xsi:type="bpmn:tFormalExpression">= approved and amount &gt; 0</bpmn:condition>
+      </bpmn:conditionalEventDefinition>
+    </bpmn:intermediateCatchEvent>
+    <bpmn:sequenceFlow id="f2" sourceRef="waitApproved" targetRef="end" />
+    <bpmn:endEvent id="end"><bpmn:incoming>f2</bpmn:incoming></bpmn:endEvent>`
+
+// cancellableTaskProcess has service task interrupted by conditional boundary event
+// Сервисная задача, прерываемая условным граничным событием
+const cancellableTaskProcess = `
+    <bpmn:startEvent id="start"><bpmn:outgoing>f1</bpmn:outgoing></bpmn:startEvent>
+    <bpmn:sequenceFlow id="f1" sourceRef="start" targetRef="ship" />
+    <bpmn:serviceTask id="ship">
+      <bpmn:extensionElements><zeebe:taskDefinition type="ship" /></bpmn:extensionElements>
+      <bpmn:incoming>f1</bpmn:incoming><bpmn:outgoing>f2</bpmn:outgoing>
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="f2" sourceRef="ship" targetRef="shipped" />
+    <bpmn:endEvent id="shipped"><bpmn:incoming>f2</bpmn:incoming></bpmn:endEvent>
+    <bpmn:boundaryEvent id="cancelled" attachedToRef="ship">
+      <bpmn:outgoing>f3</bpmn:outgoing>
+      <bpmn:conditionalEventDefinition id="cancelledDefinition">
+        <bpmn:condition xsi:type="bpmn:tFormalExpression">= cancelRequested</bpmn:condition>
+      </bpmn:conditionalEventDefinition>
+    </bpmn:boundaryEvent>
+    <bpmn:sequenceFlow id="f3" sourceRef="cancelled" targetRef="refunded" />
+    <bpmn:endEvent id="refunded"><bpmn:incoming>f3</bpmn:incoming></bpmn:endEvent>`
+
+// tokenAt returns token of instance at element or nil
+// Возвращает токен экземпляра на элементе или nil
+func (e *testEngine) tokenAt(instanceID, elementID string) *models.Token {
+	e.t.Helper()
+
+	for _, token := range e.tokens(instanceID) {
+		if token.CurrentElementID == elementID {
+			return token
+		}
+	}
+	return nil
+}
+
+// patchVariables patches instance variables the way variable-update endpoint does
+// Изменяет переменные экземпляра так же, как endpoint обновления переменных
+func (e *testEngine) patchVariables(instanceID string, patch map[string]interface{}) {
+	e.t.Helper()
+
+	if _, err := e.process.PatchProcessInstanceVariables(instanceID, patch); err != nil {
+		e.t.Fatalf("patch variables of %s: %v", instanceID, err)
+	}
+}
+
+func TestConditionalCatchEventReleasedBySettingVariable(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("conditional-catch", waitApprovedProcess))
+	instance := e.start(processID, map[string]interface{}{"approved": false, "amount": 10})
+
+	e.waitFor("token waiting for condition", func() bool {
+		token := e.tokenAt(instance.InstanceID, "waitApproved")
+		return token != nil && token.WaitingFor == conditionWaitingPrefix+"waitApproved"
+	})
+	if subscriptions := e.process.conditionManager.GetSubscriptions(instance.InstanceID); len(subscriptions) != 1 {
+		t.Fatalf("%d condition subscriptions, want 1", len(subscriptions))
+	}
+
+	// Variable that keeps condition false does not release event
+	// Переменная, при которой условие ложно, не освобождает событие
+	e.patchVariables(instance.InstanceID, map[string]interface{}{"amount": 0, "approved": true})
+	if state := e.instance(instance.InstanceID).State; state != models.ProcessInstanceStateActive {
+		t.Fatalf("instance in state %s while condition is false", state)
+	}
+
+	e.patchVariables(instance.InstanceID, map[string]interface{}{"amount": 5})
+	e.waitState(instance.InstanceID, models.ProcessInstanceStateCompleted)
+	if subscriptions := e.process.conditionManager.GetSubscriptions(instance.InstanceID); len(subscriptions) != 0 {
+		t.Errorf("%d condition subscriptions left after event fired", len(subscriptions))
+	}
+}
+
+func TestConditionalCatchEventPassesWhenConditionAlreadyTrue(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("conditional-catch-true", waitApprovedProcess))
+	instance := e.start(processID, map[string]interface{}{"approved": true, "amount": 10})
+
+	e.waitState(instance.InstanceID, models.ProcessInstanceStateCompleted)
+	if subscriptions := e.process.conditionManager.GetSubscriptions(instance.InstanceID); len(subscriptions) != 0 {
+		t.Errorf("%d condition subscriptions for passed event", len(subscriptions))
+	}
+}
+
+func TestConditionalBoundaryEventInterruptsTaskWhenVariableIsSet(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("conditional-boundary", cancellableTaskProcess))
+	instance := e.start(processID, map[string]interface{}{"cancelRequested": false})
+	job := e.waitJob(instance.InstanceID, "ship")
+
+	e.patchVariables(instance.InstanceID, map[string]interface{}{"cancelRequested": true})
+	e.waitState(instance.InstanceID, models.ProcessInstanceStateCompleted)
+
+	if e.tokenAt(instance.InstanceID, "refunded") == nil {
+		t.Errorf("instance did not take boundary event path")
+	}
+	if e.tokenAt(instance.InstanceID, "shipped") != nil {
+		t.Errorf("interrupted task continued to normal path")
+	}
+	for _, current := range e.jobsOf(instance.InstanceID, "ship") {
+		if current.Key == job.Key && isOpenJob(current) {
+			t.Errorf("job %s of interrupted task is still open: %s", current.Key, current.Status)
+		}
+	}
+}
+
+func TestConditionalCatchEventReleasedByJobCompletion(t *testing.T) {
+	e := newTestEngine(t)
+
+	// Job of one branch sets variable awaited by conditional event of other branch
+	// Job одной ветки устанавливает переменную, ожидаемую условным событием другой ветки
+	processID := e.deploy(bpmnDefinitions("conditional-by-job", `
+    <bpmn:startEvent id="start"><bpmn:outgoing>f1</bpmn:outgoing></bpmn:startEvent>
+    <bpmn:sequenceFlow id="f1" sourceRef="start" targetRef="fork" />
+    <bpmn:parallelGateway id="fork">
+      <bpmn:incoming>f1</bpmn:incoming><bpmn:outgoing>f2</bpmn:outgoing><bpmn:outgoing>f3</bpmn:outgoing>
+    </bpmn:parallelGateway>
+    <bpmn:sequenceFlow id="f2" sourceRef="fork" targetRef="review" />
+    <bpmn:serviceTask id="review">
+      <bpmn:extensionElements><zeebe:taskDefinition type="review" /></bpmn:extensionElements>
+      <bpmn:incoming>f2</bpmn:incoming><bpmn:outgoing>f4</bpmn:outgoing>
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="f4" sourceRef="review" targetRef="reviewed" />
+    <bpmn:endEvent id="reviewed"><bpmn:incoming>f4</bpmn:incoming></bpmn:endEvent>
+    <bpmn:sequenceFlow id="f3" sourceRef="fork" targetRef="waitApproved" />
+    <bpmn:intermediateCatchEvent id="waitApproved">
+      <bpmn:incoming>f3</bpmn:incoming>
+      <bpmn:outgoing>f5</bpmn:outgoing>
+      <bpmn:conditionalEventDefinition id="waitApprovedDefinition">
+        <bpmn:condition xsi:type="bpmn:tFormalExpression">= approved and amount &gt; 0</bpmn:condition>
+      </bpmn:conditionalEventDefinition>
+    </bpmn:intermediateCatchEvent>
+    <bpmn:sequenceFlow id="f5" sourceRef="waitApproved" targetRef="end" />
+    <bpmn:endEvent id="end"><bpmn:incoming>f5</bpmn:incoming></bpmn:endEvent>`))
+	instance := e.start(processID, map[string]interface{}{"approved": false, "amount": 10})
+
+	job := e.waitJob(instance.InstanceID, "review")
+	e.waitFor("token waiting for condition", func() bool {
+		token := e.tokenAt(instance.InstanceID, "waitApproved")
+		return token != nil && token.IsWaiting()
+	})
+
+	e.completeJob(job, map[string]interface{}{"approved": true})
+	e.waitState(instance.InstanceID, models.ProcessInstanceStateCompleted)
+
+	// Released token carries job variables that satisfied condition
+	// Освобожденный токен несет переменные job'а, выполнившие условие
+	if token := e.tokenAt(instance.InstanceID, "end"); token == nil || token.Variables["approved"] != true {
+		t.Errorf("token after conditional event does not see job variables: %+v", token)
+	}
+}
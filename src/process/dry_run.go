@@ -0,0 +1,775 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+	"atom-engine/src/storage"
+)
+
+// DryRunner walks process definition with given variables without executing it
+// Conditions are evaluated by expression component, jobs and events take stubbed outcomes.
+// Walk only reads definition, no instances, tokens, jobs, timers or subscriptions are created
+// Проходит определение процесса с заданными переменными, не выполняя его
+// Условия вычисляются expression компонентом, job'ы и события получают заглушки исходов.
+// Проход только читает определение, экземпляры, токены, job'ы, таймеры и подписки не создаются
+type DryRunner struct {
+	storage   storage.Storage
+	component ComponentInterface
+}
+
+// NewDryRunner creates new dry runner
+// Создает новый исполнитель пробных прогонов
+func NewDryRunner(storage storage.Storage, component ComponentInterface) *DryRunner {
+	return &DryRunner{
+		storage:   storage,
+		component: component,
+	}
+}
+
+// Run walks process definition from start event until all paths stop
+// Process key is storage key of version or process ID with optional ":version", latest version by default
+// Проходит определение процесса от стартового события, пока все пути не остановятся
+// Ключ процесса - ключ версии в storage или ID процесса с необязательной ":версией", по умолчанию последняя
+func (dr *DryRunner) Run(processKey string, request *models.DryRunRequest) (*models.DryRunResult, error) {
+	if request == nil {
+		request = &models.DryRunRequest{}
+	}
+
+	maxSteps := request.MaxSteps
+	if maxSteps == 0 {
+		maxSteps = models.DryRunDefaultMaxSteps
+	}
+	if maxSteps < 0 || maxSteps > models.DryRunMaxSteps {
+		return nil, fmt.Errorf("%w: max_steps must be between 1 and %d",
+			models.ErrInvalidArgument, models.DryRunMaxSteps)
+	}
+	for name, outcome := range request.Events {
+		if outcome != models.DryRunEventFire && outcome != models.DryRunEventNever {
+			return nil, fmt.Errorf("%w: outcome of event %s must be %q or %q",
+				models.ErrInvalidArgument, name, models.DryRunEventFire, models.DryRunEventNever)
+		}
+	}
+
+	bpmnProcess, storageKey, err := loadProcessDefinition(dr.storage, processKey)
+	if err != nil {
+		return nil, err
+	}
+
+	startEventID := request.StartEventID
+	if startEventID == "" {
+		startEventID = findDryRunStartEvent(bpmnProcess)
+		if startEventID == "" {
+			return nil, fmt.Errorf("%w: process %s has no top-level none start event",
+				models.ErrInvalidArgument, bpmnProcess.ProcessID)
+		}
+	} else if element, ok := bpmnProcess.Elements[startEventID].(map[string]interface{}); !ok ||
+		element["type"] != "startEvent" {
+		return nil, fmt.Errorf("%w: start event %s not found in process %s",
+			models.ErrInvalidArgument, startEventID, bpmnProcess.ProcessID)
+	}
+
+	walk := &dryRunWalk{
+		component: dr.component,
+		elements:  bpmnProcess.Elements,
+		request:   request,
+		maxSteps:  maxSteps,
+		variables: maps.Clone(request.Variables),
+		joins:     make(map[string][]*dryRunPath),
+		result: &models.DryRunResult{
+			ProcessKey:     storageKey,
+			ProcessID:      bpmnProcess.ProcessID,
+			ProcessVersion: bpmnProcess.ProcessVersion,
+			Steps:          make([]models.DryRunStep, 0),
+			Conditions:     make([]models.DryRunCondition, 0),
+			Stops:          make([]models.DryRunStop, 0),
+		},
+	}
+	if walk.variables == nil {
+		walk.variables = make(map[string]interface{})
+	}
+	walk.run(startEventID)
+
+	logger.Info("Process dry run finished",
+		logger.String("process_key", storageKey),
+		logger.String("outcome", string(walk.result.Outcome)),
+		logger.Int("steps", len(walk.result.Steps)),
+		logger.Int("paths", walk.pathCount))
+
+	return walk.result, nil
+}
+
+// loadProcessDefinition loads process definition by storage key or by process ID with optional ":version"
+// Загружает определение процесса по ключу в storage или по ID процесса с необязательной ":версией"
+func loadProcessDefinition(store storage.Storage, processKey string) (*models.BPMNProcess, string, error) {
+	storageKey := processKey
+	processData, err := store.LoadBPMNProcess(processKey)
+	if err != nil {
+		processID, version := processKey, -1
+		if id, versionStr, found := strings.Cut(processKey, ":"); found {
+			if _, scanErr := fmt.Sscanf(versionStr, "%d", &version); scanErr == nil {
+				processID = id
+			}
+		}
+		processData, storageKey, err = store.LoadBPMNProcessByProcessID(processID, version)
+		if err != nil {
+			return nil, "", fmt.Errorf("%w: process %s: %v", models.ErrNotFound, processKey, err)
+		}
+	}
+
+	var bpmnProcess models.BPMNProcess
+	if err := json.Unmarshal(processData, &bpmnProcess); err != nil {
+		return nil, "", fmt.Errorf("failed to parse process definition: %w", err)
+	}
+	return &bpmnProcess, storageKey, nil
+}
+
+// findDryRunStartEvent returns first top-level start event without event definitions
+// Возвращает первое стартовое событие верхнего уровня без определений событий
+func findDryRunStartEvent(bpmnProcess *models.BPMNProcess) string {
+	ids := make([]string, 0)
+	for elementID, element := range bpmnProcess.Elements {
+		elementMap, ok := element.(map[string]interface{})
+		if !ok || elementMap["type"] != "startEvent" {
+			continue
+		}
+		if parentScope, _ := elementMap["parent_scope"].(string); parentScope != "" &&
+			parentScope != bpmnProcess.ProcessID {
+			continue
+		}
+		if definitions, _ := elementMap["event_definitions"].([]interface{}); len(definitions) > 0 {
+			continue
+		}
+		ids = append(ids, elementID)
+	}
+	if len(ids) == 0 {
+		return ""
+	}
+	sort.Strings(ids)
+	return ids[0]
+}
+
+// dryRunPath is token of dry run moving through elements
+// Токен пробного прогона, движущийся по элементам
+type dryRunPath struct {
+	id        int
+	elementID string
+	flowID    string // Sequence flow path came by
+}
+
+// dryRunWalk is state of single dry run
+// Paths are walked breadth first, paths arriving at joins wait there until join is released
+// Состояние одного пробного прогона
+// Пути проходятся в ширину, пути, пришедшие на слияние, ждут там его освобождения
+type dryRunWalk struct {
+	component ComponentInterface
+	elements  map[string]interface{}
+	request   *models.DryRunRequest
+	maxSteps  int
+
+	variables  map[string]interface{} // Shared by all paths
+	queue      []*dryRunPath
+	joins      map[string][]*dryRunPath // Paths waiting at join gateway
+	pathCount  int
+	terminated bool
+
+	result *models.DryRunResult
+}
+
+// run walks all paths from start event and sets outcome of result
+// Проходит все пути от стартового события и задает исход результата
+func (w *dryRunWalk) run(startEventID string) {
+	w.queue = append(w.queue, w.newPath(startEventID, ""))
+
+	for {
+		for len(w.queue) > 0 {
+			path := w.queue[0]
+			w.queue = w.queue[1:]
+
+			switch {
+			case w.terminated:
+				w.stop(path, models.DryRunStopTerminated, "", "")
+			case len(w.result.Steps) >= w.maxSteps:
+				w.stop(path, models.DryRunStopStepLimit, "", fmt.Sprintf("max steps %d reached", w.maxSteps))
+			default:
+				w.visit(path)
+			}
+		}
+		// Inclusive join waits until no other path can arrive
+		// Включающее слияние ждет, пока другие пути не смогут прийти
+		if w.terminated || !w.releaseInclusiveJoin() {
+			break
+		}
+	}
+
+	for _, gatewayID := range sortedKeys(w.joins) {
+		arrived := len(w.joins[gatewayID])
+		incoming := len(stringList(w.elementMap(gatewayID)["incoming"]))
+		for _, path := range w.joins[gatewayID] {
+			if w.terminated {
+				w.stop(path, models.DryRunStopTerminated, "", "")
+				continue
+			}
+			w.stop(path, models.DryRunStopWaitingJoin, "",
+				fmt.Sprintf("%d of %d incoming paths arrived", arrived, incoming))
+		}
+	}
+
+	w.result.Outcome = w.outcome()
+	w.result.Variables = w.variables
+}
+
+// visit records step at element of path and moves path further
+// Записывает шаг на элементе пути и продвигает путь дальше
+func (w *dryRunWalk) visit(path *dryRunPath) {
+	element := w.elementMap(path.elementID)
+	if element == nil {
+		w.stop(path, models.DryRunStopInvalidElement, "", "element not found in process definition")
+		return
+	}
+	elementType, _ := element["type"].(string)
+	step := w.addStep(path, elementType)
+
+	switch elementType {
+	case "startEvent", "task", "manualTask", "scriptTask", "boundaryEvent":
+		w.leave(path, element)
+	case "sendTask", "intermediateThrowEvent":
+		w.note(step, "throw is not performed in dry run")
+		w.leave(path, element)
+	case "endEvent":
+		w.visitEndEvent(path, element)
+	case "serviceTask", "userTask", "receiveTask":
+		w.visitActivity(path, element, step)
+	case "intermediateCatchEvent":
+		w.visitCatchEvent(path, element, step)
+	case "exclusiveGateway":
+		w.visitExclusiveGateway(path, element, step)
+	case "inclusiveGateway", "parallelGateway":
+		if len(stringList(element["incoming"])) > 1 {
+			w.arriveAtJoin(path, element, elementType, step)
+			return
+		}
+		w.fork(path, element, elementType, step)
+	case "eventBasedGateway":
+		w.visitEventBasedGateway(path, element)
+	default:
+		w.stop(path, models.DryRunStopUnsupported, "", elementType+" is not walked by dry run")
+	}
+}
+
+// visitEndEvent stops path, terminate end event stops all other paths
+// Останавливает путь, завершающее событие останавливает все остальные пути
+func (w *dryRunWalk) visitEndEvent(path *dryRunPath, element map[string]interface{}) {
+	kind, _ := w.eventTrigger(element)
+	if kind == "terminate" {
+		w.terminated = true
+		w.stop(path, models.DryRunStopEndEvent, "", "terminate end event")
+		return
+	}
+	w.stop(path, models.DryRunStopEndEvent, "", "")
+}
+
+// visitActivity fires stubbed boundary events of activity, then completes activity with stubbed outcome
+// Запускает граничные события активности с заглушками, затем завершает активность по заглушке
+func (w *dryRunWalk) visitActivity(path *dryRunPath, element map[string]interface{}, step int) {
+	if w.fireBoundaryEvents(path, step) {
+		return
+	}
+
+	elementType, _ := element["type"].(string)
+	switch elementType {
+	case "serviceTask":
+		jobType := serviceTaskJobType(element)
+		variables, stubbed := w.request.Jobs[jobType]
+		if !stubbed {
+			w.stop(path, models.DryRunStopWaitingJob, "job:"+jobType, "")
+			return
+		}
+		if err := w.completeJob(element, variables); err != nil {
+			w.stop(path, models.DryRunStopIncident, "", err.Error())
+			return
+		}
+		w.leave(path, element)
+	case "receiveTask":
+		receiveTask, _ := element["receive_task"].(map[string]interface{})
+		messageRef, _ := receiveTask["message_ref"].(string)
+		w.waitForEvent(path, element, "message", w.messageName(messageRef))
+	default:
+		w.waitForEvent(path, element, "user_task", "")
+	}
+}
+
+// visitCatchEvent passes event when it fires, conditional events evaluate their condition
+// Пропускает событие, когда оно срабатывает, условные события вычисляют свое условие
+func (w *dryRunWalk) visitCatchEvent(path *dryRunPath, element map[string]interface{}, step int) {
+	kind, name := w.eventTrigger(element)
+	switch kind {
+	case "":
+		w.leave(path, element)
+	case "conditional":
+		if w.evaluateEventCondition(path.elementID, element, step) {
+			w.leave(path, element)
+			return
+		}
+		w.stop(path, models.DryRunStopWaitingEvent, conditionWaitingPrefix+path.elementID, "condition is false")
+	default:
+		w.waitForEvent(path, element, kind, name)
+	}
+}
+
+// waitForEvent passes element when its event is stubbed to fire, otherwise path waits there
+// Пропускает элемент, если его событие должно сработать по заглушке, иначе путь ждет на нем
+func (w *dryRunWalk) waitForEvent(path *dryRunPath, element map[string]interface{}, kind, name string) {
+	switch w.eventOutcome(path.elementID, name) {
+	case models.DryRunEventFire:
+		w.leave(path, element)
+	case models.DryRunEventNever:
+		w.stop(path, models.DryRunStopWaitingEvent, waitingForEvent(kind, path.elementID, name), "event never fires")
+	default:
+		w.stop(path, models.DryRunStopWaitingEvent, waitingForEvent(kind, path.elementID, name), "event is not stubbed")
+	}
+}
+
+// fireBoundaryEvents fires boundary events of activity stubbed to fire or with true condition
+// Returns true when interrupting boundary event took path away from activity
+// Запускает граничные события активности с заглушкой fire или истинным условием
+// Возвращает true, если прерывающее граничное событие увело путь с активности
+func (w *dryRunWalk) fireBoundaryEvents(path *dryRunPath, step int) bool {
+	for _, boundaryID := range sortedKeys(w.elements) {
+		boundary := w.elementMap(boundaryID)
+		if boundary["type"] != "boundaryEvent" || boundary["attached_to_ref"] != path.elementID {
+			continue
+		}
+
+		kind, name := w.eventTrigger(boundary)
+		fired := false
+		switch kind {
+		case "conditional":
+			fired = w.evaluateEventCondition(boundaryID, boundary, step)
+		case "timer", "message", "signal":
+			fired = w.eventOutcome(boundaryID, name) == models.DryRunEventFire
+		}
+		if !fired {
+			continue
+		}
+
+		if cancel, ok := boundary["cancel_activity"].(bool); ok && !cancel {
+			w.note(step, "non-interrupting boundary event "+boundaryID+" fired")
+			w.queue = append(w.queue, w.newPath(boundaryID, ""))
+			continue
+		}
+		w.note(step, "interrupted by boundary event "+boundaryID)
+		path.elementID, path.flowID = boundaryID, ""
+		w.queue = append(w.queue, path)
+		return true
+	}
+	return false
+}
+
+// visitExclusiveGateway takes first flow with true condition like exclusive gateway executor does,
+// otherwise first flow without condition, otherwise first flow
+// Берет первый поток с истинным условием, как исполнитель эксклюзивного шлюза,
+// иначе первый поток без условия, иначе первый поток
+func (w *dryRunWalk) visitExclusiveGateway(path *dryRunPath, element map[string]interface{}, step int) {
+	outgoing := extractOutgoingFlows(element)
+	if len(outgoing) == 0 {
+		w.stop(path, models.DryRunStopInvalidElement, "", "exclusive gateway has no outgoing sequence flows")
+		return
+	}
+
+	defaultFlow := ""
+	for _, flowID := range outgoing {
+		flow := w.elementMap(flowID)
+		if flow == nil {
+			continue
+		}
+		expression, hasCondition := flowConditionExpression(flow)
+		if !hasCondition {
+			if defaultFlow == "" {
+				defaultFlow = flowID
+			}
+			continue
+		}
+		if expression != "" && w.evaluateFlowCondition(path.elementID, flowID, expression, step) {
+			w.move(path, flowID)
+			return
+		}
+	}
+
+	if defaultFlow == "" {
+		defaultFlow = outgoing[0]
+		w.note(step, "no condition matched and no flow without condition, first flow taken")
+	}
+	w.move(path, defaultFlow)
+}
+
+// fork moves path through outgoing flows of inclusive or parallel gateway
+// Inclusive gateway takes flows with true condition, otherwise first flow without condition, otherwise all flows
+// Проводит путь через исходящие потоки включающего или параллельного шлюза
+// Включающий шлюз берет потоки с истинным условием, иначе первый поток без условия, иначе все потоки
+func (w *dryRunWalk) fork(path *dryRunPath, element map[string]interface{}, elementType string, step int) {
+	outgoing := extractOutgoingFlows(element)
+	if elementType != "inclusiveGateway" {
+		w.follow(path, outgoing)
+		return
+	}
+
+	selected := make([]string, 0, len(outgoing))
+	defaultFlow := ""
+	for _, flowID := range outgoing {
+		flow := w.elementMap(flowID)
+		if flow == nil {
+			continue
+		}
+		expression, hasCondition := flowConditionExpression(flow)
+		if !hasCondition {
+			if defaultFlow == "" {
+				defaultFlow = flowID
+			}
+			continue
+		}
+		if expression != "" && w.evaluateFlowCondition(path.elementID, flowID, expression, step) {
+			selected = append(selected, flowID)
+		}
+	}
+	if len(selected) == 0 && defaultFlow != "" {
+		selected = append(selected, defaultFlow)
+	}
+	if len(selected) == 0 {
+		selected = outgoing
+		w.note(step, "no condition matched and no flow without condition, all flows taken")
+	}
+	w.follow(path, selected)
+}
+
+// arriveAtJoin parks path at join gateway, parallel join is released when all incoming paths arrived
+// Ставит путь на ожидание в слиянии, параллельное слияние освобождается, когда пришли все входящие пути
+func (w *dryRunWalk) arriveAtJoin(path *dryRunPath, element map[string]interface{}, elementType string, step int) {
+	gatewayID := path.elementID
+	w.joins[gatewayID] = append(w.joins[gatewayID], path)
+
+	arrived := len(w.joins[gatewayID])
+	incoming := len(stringList(element["incoming"]))
+	if elementType != "parallelGateway" || arrived < incoming {
+		w.note(step, fmt.Sprintf("waiting at join, %d of %d incoming paths arrived", arrived, incoming))
+		return
+	}
+
+	merged := w.joins[gatewayID][0]
+	delete(w.joins, gatewayID)
+	w.note(step, fmt.Sprintf("join released, paths merged into path %d", merged.id))
+	w.fork(merged, element, elementType, step)
+}
+
+// releaseInclusiveJoin releases first inclusive join with waiting paths, returns false when there is none
+// Освобождает первое включающее слияние с ожидающими путями, возвращает false, если таких нет
+func (w *dryRunWalk) releaseInclusiveJoin() bool {
+	for _, gatewayID := range sortedKeys(w.joins) {
+		element := w.elementMap(gatewayID)
+		if element["type"] != "inclusiveGateway" {
+			continue
+		}
+
+		merged := w.joins[gatewayID][0]
+		delete(w.joins, gatewayID)
+		step := w.addStep(merged, "inclusiveGateway")
+		w.note(step, fmt.Sprintf("join released, paths merged into path %d", merged.id))
+		w.fork(merged, element, "inclusiveGateway", step)
+		return true
+	}
+	return false
+}
+
+// visitEventBasedGateway takes flow to first event stubbed to fire
+// Берет поток к первому событию с заглушкой fire
+func (w *dryRunWalk) visitEventBasedGateway(path *dryRunPath, element map[string]interface{}) {
+	waitingFor := make([]string, 0)
+	for _, flowID := range extractOutgoingFlows(element) {
+		targetID, _ := w.elementMap(flowID)["target_ref"].(string)
+		target := w.elementMap(targetID)
+		if target == nil {
+			continue
+		}
+
+		kind, name := w.eventTrigger(target)
+		if target["type"] == "receiveTask" {
+			receiveTask, _ := target["receive_task"].(map[string]interface{})
+			messageRef, _ := receiveTask["message_ref"].(string)
+			kind, name = "message", w.messageName(messageRef)
+		}
+		if w.eventOutcome(targetID, name) == models.DryRunEventFire {
+			w.move(path, flowID)
+			return
+		}
+		waitingFor = append(waitingFor, waitingForEvent(kind, targetID, name))
+	}
+
+	w.stop(path, models.DryRunStopWaitingEvent, strings.Join(waitingFor, ","), "no event is stubbed to fire")
+}
+
+// leave moves path through all outgoing flows of element, element without them ends path
+// Проводит путь через все исходящие потоки элемента, элемент без них завершает путь
+func (w *dryRunWalk) leave(path *dryRunPath, element map[string]interface{}) {
+	outgoing := extractOutgoingFlows(element)
+	if len(outgoing) == 0 {
+		w.stop(path, models.DryRunStopEndEvent, "", "element has no outgoing flows")
+		return
+	}
+	w.follow(path, outgoing)
+}
+
+// follow moves path through first flow and spawns new paths for other flows
+// Проводит путь через первый поток и порождает новые пути для остальных потоков
+func (w *dryRunWalk) follow(path *dryRunPath, flows []string) {
+	sourceID := path.elementID
+	for i, flowID := range flows {
+		if i == 0 {
+			w.move(path, flowID)
+			continue
+		}
+		w.move(w.newPath(sourceID, ""), flowID)
+	}
+}
+
+// move queues path at target of sequence flow
+// Ставит путь в очередь на цели sequence flow
+func (w *dryRunWalk) move(path *dryRunPath, flowID string) {
+	targetID, _ := w.elementMap(flowID)["target_ref"].(string)
+	if targetID == "" {
+		w.stop(path, models.DryRunStopInvalidElement, "", "sequence flow "+flowID+" has no target")
+		return
+	}
+	path.elementID, path.flowID = targetID, flowID
+	w.queue = append(w.queue, path)
+}
+
+// completeJob merges stubbed job variables into variables, through output mappings when element has them
+// Объединяет переменные заглушки job'а с переменными, через output маппинги, если они есть у элемента
+func (w *dryRunWalk) completeJob(element map[string]interface{}, variables map[string]interface{}) error {
+	if mappings := extractIOMappings(element, "outputs"); len(mappings) > 0 {
+		mapped, err := applyOutputMappings(w.component, mappings, w.variables, variables)
+		if err != nil {
+			return err
+		}
+		variables = mapped
+	}
+	maps.Copy(w.variables, variables)
+	return nil
+}
+
+// evaluateFlowCondition evaluates sequence flow condition and records it
+// Вычисляет условие sequence flow и записывает его
+func (w *dryRunWalk) evaluateFlowCondition(elementID, flowID, expression string, step int) bool {
+	result, err := evaluateEventCondition(w.component, expression, w.variables)
+	condition := models.DryRunCondition{
+		Step:       step,
+		ElementID:  elementID,
+		FlowID:     flowID,
+		Expression: expression,
+		Result:     err == nil && result,
+	}
+	if err != nil {
+		condition.Error = err.Error()
+	}
+	w.result.Conditions = append(w.result.Conditions, condition)
+	return condition.Result
+}
+
+// evaluateEventCondition evaluates condition of conditional event and records it
+// Вычисляет условие условного события и записывает его
+func (w *dryRunWalk) evaluateEventCondition(elementID string, element map[string]interface{}, step int) bool {
+	expression, _ := conditionalEventCondition(element)
+	result, err := evaluateEventCondition(w.component, expression, w.variables)
+	condition := models.DryRunCondition{
+		Step:       step,
+		ElementID:  elementID,
+		Expression: expression,
+		Result:     err == nil && result,
+	}
+	if err != nil {
+		condition.Error = err.Error()
+	}
+	w.result.Conditions = append(w.result.Conditions, condition)
+	return condition.Result
+}
+
+// eventTrigger returns kind of first event definition of element and name of message or signal
+// Kind is empty for element without event definitions
+// Возвращает вид первого определения события элемента и имя сообщения или сигнала
+// Вид пустой для элемента без определений событий
+func (w *dryRunWalk) eventTrigger(element map[string]interface{}) (string, string) {
+	definitions, _ := element["event_definitions"].([]interface{})
+	for _, definition := range definitions {
+		definitionMap, ok := definition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		definitionType, _ := definitionMap["type"].(string)
+		switch definitionType {
+		case "messageEventDefinition":
+			reference, _ := definitionMap["reference"].(string)
+			return "message", w.messageName(reference)
+		case "signalEventDefinition":
+			signalRef, _ := definitionMap["signal_ref"].(string)
+			return "signal", signalRef
+		default:
+			return strings.TrimSuffix(definitionType, "EventDefinition"), ""
+		}
+	}
+	return "", ""
+}
+
+// messageName resolves message reference to message name, reference itself when message has no name
+// Разрешает ссылку на сообщение в имя сообщения, сама ссылка, если у сообщения нет имени
+func (w *dryRunWalk) messageName(messageRef string) string {
+	message := w.elementMap(messageRef)
+	if message == nil || message["type"] != "message" {
+		return messageRef
+	}
+	if name, _ := message["name"].(string); name != "" {
+		return name
+	}
+	return messageRef
+}
+
+// eventOutcome returns stubbed outcome of event by element ID, then by message or signal name
+// Возвращает заглушку исхода события по ID элемента, затем по имени сообщения или сигнала
+func (w *dryRunWalk) eventOutcome(elementID, name string) models.DryRunEventOutcome {
+	if outcome, ok := w.request.Events[elementID]; ok {
+		return outcome
+	}
+	if name != "" {
+		return w.request.Events[name]
+	}
+	return ""
+}
+
+// newPath creates path at element
+// Создает путь на элементе
+func (w *dryRunWalk) newPath(elementID, flowID string) *dryRunPath {
+	w.pathCount++
+	return &dryRunPath{id: w.pathCount, elementID: elementID, flowID: flowID}
+}
+
+// addStep records visit of element by path and returns step number
+// Записывает посещение элемента путем и возвращает номер шага
+func (w *dryRunWalk) addStep(path *dryRunPath, elementType string) int {
+	step := len(w.result.Steps) + 1
+	w.result.Steps = append(w.result.Steps, models.DryRunStep{
+		Step:        step,
+		Path:        path.id,
+		ElementID:   path.elementID,
+		ElementType: elementType,
+		FlowID:      path.flowID,
+	})
+	return step
+}
+
+// note appends note to recorded step
+// Добавляет заметку к записанному шагу
+func (w *dryRunWalk) note(step int, note string) {
+	recorded := &w.result.Steps[step-1]
+	if recorded.Note != "" {
+		recorded.Note += "; "
+	}
+	recorded.Note += note
+}
+
+// stop records element where path stopped
+// Записывает элемент, на котором остановился путь
+func (w *dryRunWalk) stop(path *dryRunPath, reason models.DryRunStopReason, waitingFor, detail string) {
+	w.result.Stops = append(w.result.Stops, models.DryRunStop{
+		Path:       path.id,
+		ElementID:  path.elementID,
+		Reason:     reason,
+		WaitingFor: waitingFor,
+		Detail:     detail,
+	})
+}
+
+// outcome summarizes stops of paths, step limit wins over failures, failures win over waits
+// Обобщает остановки путей, лимит шагов важнее сбоев, сбои важнее ожиданий
+func (w *dryRunWalk) outcome() models.DryRunOutcome {
+	outcome := models.DryRunOutcomeCompleted
+	for _, stop := range w.result.Stops {
+		switch stop.Reason {
+		case models.DryRunStopStepLimit:
+			return models.DryRunOutcomeStepLimit
+		case models.DryRunStopUnsupported, models.DryRunStopInvalidElement, models.DryRunStopIncident:
+			outcome = models.DryRunOutcomeFailed
+		case models.DryRunStopWaitingJob, models.DryRunStopWaitingEvent, models.DryRunStopWaitingJoin:
+			if outcome == models.DryRunOutcomeCompleted {
+				outcome = models.DryRunOutcomeWaiting
+			}
+		}
+	}
+	return outcome
+}
+
+// elementMap returns element of definition, nil when it is missing
+// Возвращает элемент определения, nil если его нет
+func (w *dryRunWalk) elementMap(elementID string) map[string]interface{} {
+	element, _ := w.elements[elementID].(map[string]interface{})
+	return element
+}
+
+// serviceTaskJobType returns job type of service task task definition
+// Возвращает тип job'а из определения задачи сервисной задачи
+func serviceTaskJobType(element map[string]interface{}) string {
+	taskDefinition, err := (&ServiceTaskExecutor{}).extractTaskDefinition(element)
+	if err != nil {
+		return ""
+	}
+	return taskDefinition.Type
+}
+
+// waitingForEvent formats event path waits on, like waiting_for of tokens
+// Форматирует событие, которое ждет путь, как waiting_for токенов
+func waitingForEvent(kind, elementID, name string) string {
+	if name != "" {
+		return kind + ":" + name
+	}
+	return kind + ":" + elementID
+}
+
+// stringList converts list of element references to strings
+// Конвертирует список ссылок элемента в строки
+func stringList(value interface{}) []string {
+	switch list := value.(type) {
+	case []interface{}:
+		result := make([]string, 0, len(list))
+		for _, item := range list {
+			if str, ok := item.(string); ok {
+				result = append(result, str)
+			}
+		}
+		return result
+	case string:
+		return []string{list}
+	}
+	return nil
+}
+
+// sortedKeys returns keys of map in sorted order for deterministic walk
+// Возвращает ключи карты в отсортированном порядке для детерминированного прохода
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,298 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+)
+
+// ListElementInstances returns filtered page of element activations of process instance in activation order
+// with their jobs, timers, message subscriptions and incidents, and total count of filtered activations
+// Возвращает отфильтрованную страницу активаций элементов экземпляра процесса в порядке активаций
+// с их job'ами, таймерами, подписками на сообщения и инцидентами, и общее число отфильтрованных активаций
+func (c *Component) ListElementInstances(query models.ElementInstanceQuery) ([]*models.ElementInstance, int, error) {
+	instanceID := c.resolveInstanceID(query.InstanceID)
+	instance, err := c.storage.LoadProcessInstance(instanceID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load process instance: %w", err)
+	}
+
+	records, err := c.storage.ListElementInstances(instance.InstanceID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	elements := make(map[string]interface{})
+	if bpmnProcess, err := c.bpmnHelper.LoadBPMNProcess(instance.ProcessKey); err == nil {
+		elements = bpmnProcess.Elements
+	} else {
+		logger.Warn("Failed to load process definition for element types",
+			logger.String("instance_id", instance.InstanceID),
+			logger.String("process_key", instance.ProcessKey),
+			logger.String("error", err.Error()))
+	}
+
+	for _, record := range records {
+		record.ElementType = elementType(elements, record.ElementID)
+	}
+	markInterruptedElementInstances(records, elements)
+
+	if err := c.joinElementInstanceWaits(instance, records, elements); err != nil {
+		return nil, 0, err
+	}
+
+	filtered := make([]*models.ElementInstance, 0, len(records))
+	for _, record := range records {
+		if query.ElementID != "" && record.ElementID != query.ElementID {
+			continue
+		}
+		if query.State != "" && record.State != query.State {
+			continue
+		}
+		filtered = append(filtered, record)
+	}
+
+	total := len(filtered)
+	if query.Offset >= total {
+		return []*models.ElementInstance{}, total, nil
+	}
+	filtered = filtered[query.Offset:]
+	if query.Limit > 0 && query.Limit < len(filtered) {
+		filtered = filtered[:query.Limit]
+	}
+	return filtered, total, nil
+}
+
+// joinElementInstanceWaits attaches jobs, timers, subscriptions and incidents to activations they belong to
+// Active activation with open incident is reported in incident state
+// Присоединяет job'ы, таймеры, подписки и инциденты к активациям, которым они принадлежат
+// Активная активация с открытым инцидентом отображается в состоянии инцидента
+func (c *Component) joinElementInstanceWaits(
+	instance *models.ProcessInstance,
+	records []*models.ElementInstance,
+	elements map[string]interface{},
+) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	byKey := make(map[int64]*models.ElementInstance, len(records))
+	for _, record := range records {
+		byKey[record.Key] = record
+	}
+
+	// Jobs carry element instance key of activation that created them
+	// Job'ы содержат ключ экземпляра элемента активации, создавшей их
+	jobs, err := c.storage.ListJobsByType(context.Background(), "", "", 0)
+	if err != nil {
+		return fmt.Errorf("failed to list jobs: %w", err)
+	}
+	for _, job := range jobs {
+		if job.ProcessInstanceID != instance.InstanceID {
+			continue
+		}
+		if record, ok := byKey[job.ElementInstanceKey]; ok {
+			record.Jobs = append(record.Jobs, models.ElementInstanceJob{
+				ID:     job.ID,
+				Key:    job.Key,
+				Type:   job.Type,
+				Status: job.Status,
+			})
+		}
+	}
+
+	// Timers carry token and element, boundary timers are matched by activity they are attached to
+	// Таймеры содержат токен и элемент, boundary таймеры сопоставляются по activity, к которой прикреплены
+	timers, err := c.storage.LoadAllTimers()
+	if err != nil {
+		return fmt.Errorf("failed to load timers: %w", err)
+	}
+	for _, timer := range timers {
+		if timer.ProcessInstanceID != instance.InstanceID {
+			continue
+		}
+		matches := func(record *models.ElementInstance) bool {
+			return timer.ElementID == record.ElementID || attachedTo(elements, timer.ElementID) == record.ElementID
+		}
+		record := findElementInstance(records, timer.TokenID, timer.CreatedAt, matches)
+		if record != nil {
+			record.Timers = append(record.Timers, models.ElementInstanceTimer{
+				ID:        timer.ID,
+				ElementID: timer.ElementID,
+				State:     timer.State,
+				DueDate:   timer.ScheduledAt,
+			})
+		}
+	}
+
+	c.joinElementInstanceSubscriptions(instance, records, byKey)
+	c.joinElementInstanceIncidents(instance, records)
+	return nil
+}
+
+// joinElementInstanceSubscriptions attaches active message subscriptions to activations waiting for them
+// Subscription shared by instances of same definition is matched by element and message name
+// Присоединяет активные подписки на сообщения к ожидающим их активациям
+// Подписка, общая для экземпляров одного определения, сопоставляется по элементу и имени сообщения
+func (c *Component) joinElementInstanceSubscriptions(
+	instance *models.ProcessInstance,
+	records []*models.ElementInstance,
+	byKey map[int64]*models.ElementInstance,
+) {
+	subscriptions, err := c.storage.ListProcessMessageSubscriptions(context.Background(), "", 0, 0)
+	if err != nil {
+		logger.Warn("Failed to list message subscriptions for element instances",
+			logger.String("instance_id", instance.InstanceID),
+			logger.String("error", err.Error()))
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		if !subscription.IsActive || subscription.ProcessDefinitionKey != instance.ProcessKey {
+			continue
+		}
+		reference := models.ElementInstanceSubscription{
+			ID:             subscription.ID,
+			MessageName:    subscription.MessageName,
+			CorrelationKey: subscription.CorrelationKey,
+		}
+
+		if record, ok := byKey[subscription.ElementInstanceKey]; ok && !record.IsFinished() {
+			record.Subscriptions = append(record.Subscriptions, reference)
+			continue
+		}
+		for _, record := range records {
+			if !record.IsFinished() && record.ElementID == subscription.StartEventID &&
+				record.WaitingFor == "message:"+subscription.MessageName {
+				record.Subscriptions = append(record.Subscriptions, reference)
+			}
+		}
+	}
+}
+
+// joinElementInstanceIncidents attaches incidents raised on element during activation
+// Присоединяет инциденты, возникшие на элементе во время активации
+func (c *Component) joinElementInstanceIncidents(instance *models.ProcessInstance, records []*models.ElementInstance) {
+	result, _, err := c.storage.ListIncidents(map[string]interface{}{"process_instance_id": instance.InstanceID})
+	if err != nil {
+		logger.Warn("Failed to list incidents for element instances",
+			logger.String("instance_id", instance.InstanceID),
+			logger.String("error", err.Error()))
+		return
+	}
+	incidents, _ := result.([]map[string]interface{})
+
+	for _, incident := range incidents {
+		elementID, _ := incident["element_id"].(string)
+		jobKey, _ := incident["job_key"].(string)
+		createdAt, _ := time.Parse(time.RFC3339Nano, fmt.Sprint(incident["created_at"]))
+
+		for _, record := range records {
+			if record.ElementID != elementID || !withinElementInstance(record, createdAt) {
+				continue
+			}
+			if jobKey != "" && len(record.Jobs) > 0 && !hasJob(record, jobKey) {
+				continue
+			}
+
+			reference := models.ElementInstanceIncident{}
+			reference.ID, _ = incident["id"].(string)
+			reference.Type, _ = incident["type"].(string)
+			reference.Status, _ = incident["status"].(string)
+			reference.Message, _ = incident["message"].(string)
+			record.Incidents = append(record.Incidents, reference)
+
+			if record.State == models.ElementInstanceStateActive && strings.EqualFold(reference.Status, "OPEN") {
+				record.State = models.ElementInstanceStateIncident
+			}
+			break
+		}
+	}
+}
+
+// markInterruptedElementInstances reports activity left through interrupting boundary event as terminated
+// Отмечает activity, покинутую через прерывающее граничное событие, как прерванную
+func markInterruptedElementInstances(records []*models.ElementInstance, elements map[string]interface{}) {
+	last := make(map[string]*models.ElementInstance)
+	for _, record := range records {
+		previous := last[record.TokenID]
+		last[record.TokenID] = record
+		if previous == nil || previous.State != models.ElementInstanceStateCompleted {
+			continue
+		}
+
+		element, _ := elements[record.ElementID].(map[string]interface{})
+		if element == nil || attachedTo(elements, record.ElementID) != previous.ElementID {
+			continue
+		}
+		if cancel, ok := element["cancel_activity"]; ok && (cancel == false || cancel == "false") {
+			continue
+		}
+		previous.State = models.ElementInstanceStateTerminated
+	}
+}
+
+// findElementInstance returns activation of token that matches and was on element at given time
+// Возвращает подходящую активацию токена, находившуюся на элементе в заданное время
+func findElementInstance(
+	records []*models.ElementInstance,
+	tokenID string,
+	at time.Time,
+	matches func(record *models.ElementInstance) bool,
+) *models.ElementInstance {
+	for _, record := range records {
+		if record.TokenID == tokenID && withinElementInstance(record, at) && matches(record) {
+			return record
+		}
+	}
+	return nil
+}
+
+// withinElementInstance reports whether time falls between activation start and end
+// Сообщает, попадает ли время между началом и концом активации
+func withinElementInstance(record *models.ElementInstance, at time.Time) bool {
+	if at.Before(record.StartedAt) {
+		return false
+	}
+	return record.EndedAt == nil || !at.After(*record.EndedAt)
+}
+
+// hasJob reports whether activation created job with given ID or numeric key
+// Сообщает, создала ли активация job с заданным ID или числовым ключом
+func hasJob(record *models.ElementInstance, jobKey string) bool {
+	for _, job := range record.Jobs {
+		if job.ID == jobKey || strconv.FormatInt(job.Key, 10) == jobKey {
+			return true
+		}
+	}
+	return false
+}
+
+// elementType returns BPMN type of element from process definition
+// Возвращает BPMN тип элемента из определения процесса
+func elementType(elements map[string]interface{}, elementID string) string {
+	element, _ := elements[elementID].(map[string]interface{})
+	elementType, _ := element["type"].(string)
+	return elementType
+}
+
+// attachedTo returns activity boundary event is attached to, empty for other elements
+// Возвращает activity, к которой прикреплено граничное событие, пустую строку для других элементов
+func attachedTo(elements map[string]interface{}, elementID string) string {
+	element, _ := elements[elementID].(map[string]interface{})
+	activityID, _ := element["attached_to_ref"].(string)
+	return activityID
+}
@@ -69,6 +69,11 @@ func (ee *EndEventExecutor) Execute(token *models.Token, element map[string]inte
 					if eventType == "errorEventDefinition" {
 						return ee.handleErrorEndEvent(token, element, eventDefMap)
 					}
+
+					// Handle terminate end events
+					if eventType == "terminateEventDefinition" {
+						return ee.handleTerminateEndEvent(token)
+					}
 				}
 			}
 		}
@@ -291,6 +296,39 @@ func (ee *EndEventExecutor) handleSignalEndEvent(
 	}, nil
 }
 
+// handleTerminateEndEvent handles terminate end events
+// Cancels all other tokens of instance, instance completes together with this token
+// Обрабатывает terminate конечные события
+// Отменяет все остальные токены экземпляра, экземпляр завершается вместе с этим токеном
+func (ee *EndEventExecutor) handleTerminateEndEvent(token *models.Token) (*ExecutionResult, error) {
+	logger.Info("Handling terminate end event",
+		logger.String("token_id", token.TokenID),
+		logger.String("element_id", token.CurrentElementID),
+		logger.String("instance_id", token.ProcessInstanceID))
+
+	if err := ee.processComponent.TerminateProcessInstance(
+		token.ProcessInstanceID,
+		token.TokenID,
+		token.CurrentElementID,
+	); err != nil {
+		logger.Error("Failed to terminate process instance",
+			logger.String("token_id", token.TokenID),
+			logger.String("instance_id", token.ProcessInstanceID),
+			logger.String("error", err.Error()))
+		return &ExecutionResult{
+			Success: false,
+			Error:   fmt.Sprintf("failed to terminate process instance: %v", err),
+		}, nil
+	}
+
+	return &ExecutionResult{
+		Success:      true,
+		TokenUpdated: true,
+		NextElements: []string{},
+		Completed:    true,
+	}, nil
+}
+
 // handleErrorEndEvent handles error end events
 // Обрабатывает конечные события ошибок
 func (ee *EndEventExecutor) handleErrorEndEvent(
@@ -501,7 +539,7 @@ func (ee *EndEventExecutor) handleSubProcessEndEvent(
 	parentToken.ClearWaitingFor()
 
 	// Cancel boundary timers/events for subprocess
-	if err := ee.processComponent.CancelBoundaryTimersForToken(parentToken.TokenID); err != nil {
+	if err := ee.processComponent.CancelBoundaryTimers(parentToken); err != nil {
 		logger.Error("Failed to cancel boundary timers",
 			logger.String("parent_token_id", parentToken.TokenID),
 			logger.String("error", err.Error()))
@@ -15,8 +15,13 @@ import (
 	"strconv"
 	"strings"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"atom-engine/src/core/logger"
+	"atom-engine/src/core/metrics"
 	"atom-engine/src/core/models"
+	"atom-engine/src/core/tracing"
 	"atom-engine/src/storage"
 )
 
@@ -42,9 +47,7 @@ func NewEngine(storage storage.Storage, component ComponentInterface) *Engine {
 	engine.executionProcessor = NewExecutionProcessor(storage, component)
 
 	// Register built-in element executors
-	logger.Info("DEBUG: About to register executors")
 	engine.executorRegistry.registerExecutors()
-	logger.Info("DEBUG: Executors registration completed")
 
 	return engine
 }
@@ -78,39 +81,42 @@ func (e *Engine) Stop() error {
 	return nil
 }
 
-// ExecuteToken executes token at current element
-// Выполняет токен на текущем элементе
+// ExecuteToken executes token at current element in span, child of token trace parent
+// Выполняет токен на текущем элементе в span'е, дочернем trace parent токена
 func (e *Engine) ExecuteToken(token *models.Token) error {
-	logger.Info("🚀 [DEBUG] === EXECUTING TOKEN START ===",
-		logger.String("token_id", token.TokenID),
+	ctx := tracing.ContextWithTraceParent(context.Background(), token.TraceParent)
+	_, span := tracing.Tracer().Start(ctx, "token "+token.CurrentElementID,
+		trace.WithAttributes(
+			attribute.String("atom.token_id", token.TokenID),
+			attribute.String("atom.element_id", token.CurrentElementID),
+			attribute.String("atom.process_instance_id", token.ProcessInstanceID),
+			attribute.String("atom.process_key", token.ProcessKey)))
+
+	err := e.executeToken(token)
+	tracing.EndSpan(span, err)
+	return err
+}
+
+// executeToken executes token at current element
+// Выполняет токен на текущем элементе
+func (e *Engine) executeToken(token *models.Token) error {
+	log := e.component.TokenLogger(token)
+
+	log.Debug("Executing token",
 		logger.String("element_id", token.CurrentElementID),
 		logger.String("token_state", string(token.State)),
-		logger.String("process_instance_id", token.ProcessInstanceID),
 		logger.String("waiting_for", token.WaitingFor),
-		logger.Any("variables", token.Variables))
-
-	logger.Info("🔍 [DEBUG] ExecuteToken entry point - critical checkpoint",
-		logger.String("token_id", token.TokenID),
-		logger.String("element_id", token.CurrentElementID),
-		logger.String("process_key", token.ProcessKey))
+		logger.Variables("variables", token.Variables))
 
 	// Load process definition
-	logger.Info("🔍 [DEBUG] Loading process definition from storage",
-		logger.String("token_id", token.TokenID),
-		logger.String("process_key", token.ProcessKey))
-
 	processData, err := e.storage.LoadBPMNProcess(token.ProcessKey)
 	if err != nil {
-		logger.Error("🔴 [DEBUG] Failed to load process definition - CRITICAL ERROR",
-			logger.String("process_key", token.ProcessKey),
-			logger.String("token_id", token.TokenID),
+		log.Error("Failed to load process definition",
 			logger.String("error", err.Error()))
 		return fmt.Errorf("failed to load process definition: %w", err)
 	}
 
-	logger.Info("✅ [DEBUG] Process definition loaded successfully",
-		logger.String("process_key", token.ProcessKey),
-		logger.String("token_id", token.TokenID),
+	log.Debug("Process definition loaded",
 		logger.Int("data_length", len(processData)))
 
 	// DEBUG: Output raw JSON data from database
@@ -118,23 +124,15 @@ func (e *Engine) ExecuteToken(token *models.Token) error {
 		logger.String("process_key", token.ProcessKey),
 		logger.String("json_data", string(processData)))
 
-	logger.Info("🔍 [DEBUG] Parsing process definition JSON",
-		logger.String("token_id", token.TokenID),
-		logger.String("process_key", token.ProcessKey))
-
 	var bpmnProcess models.BPMNProcess
 	if err := json.Unmarshal(processData, &bpmnProcess); err != nil {
-		logger.Error("🔴 [DEBUG] Failed to parse process definition - JSON UNMARSHAL ERROR",
-			logger.String("process_key", token.ProcessKey),
-			logger.String("token_id", token.TokenID),
+		log.Error("Failed to parse process definition",
 			logger.String("parse_error", err.Error()),
 			logger.String("raw_json_preview", string(processData[:min(200, len(processData))])))
 		return fmt.Errorf("failed to parse process definition: %w", err)
 	}
 
-	logger.Info("✅ [DEBUG] Process definition parsed successfully",
-		logger.String("process_key", token.ProcessKey),
-		logger.String("token_id", token.TokenID),
+	log.Debug("Process definition parsed",
 		logger.String("process_id", bpmnProcess.ProcessID),
 		logger.String("process_name", bpmnProcess.ProcessName),
 		logger.Int("elements_count", len(bpmnProcess.Elements)))
@@ -149,42 +147,25 @@ func (e *Engine) ExecuteToken(token *models.Token) error {
 	}
 
 	// Get current element
-	logger.Info("🔍 [DEBUG] Looking for element in process",
-		logger.String("token_id", token.TokenID),
-		logger.String("element_id", token.CurrentElementID),
-		logger.String("process_key", token.ProcessKey))
-
 	element, exists := bpmnProcess.Elements[token.CurrentElementID]
 	if !exists {
-		// Debug: show all available elements
 		availableElements := make([]string, 0, len(bpmnProcess.Elements))
 		for elementID := range bpmnProcess.Elements {
 			availableElements = append(availableElements, elementID)
 		}
-		logger.Error("🔴 [DEBUG] Element not found in process - CRITICAL ERROR",
+		log.Error("Element not found in process",
 			logger.String("element_id", token.CurrentElementID),
-			logger.String("token_id", token.TokenID),
-			logger.String("process_key", token.ProcessKey),
 			logger.Int("total_elements", len(bpmnProcess.Elements)),
 			logger.String("available_elements", fmt.Sprintf("%v", availableElements)))
 		return fmt.Errorf("element not found: %s", token.CurrentElementID)
 	}
 
-	logger.Info("✅ [DEBUG] Element found in process",
-		logger.String("token_id", token.TokenID),
-		logger.String("element_id", token.CurrentElementID))
-
 	// Check if this is a sequence flow - handle it directly
 	// Проверяем является ли это sequence flow - обрабатываем напрямую
 	elementMap, ok := element.(map[string]interface{})
 	if ok {
 		elementType, typeExists := elementMap["type"].(string)
 
-		logger.Info("DEBUG: Element type determined",
-			logger.String("element_id", token.CurrentElementID),
-			logger.String("element_type", elementType),
-			logger.Bool("type_exists", typeExists))
-
 		if typeExists && elementType == "sequenceFlow" {
 			// Handle sequence flow directly by getting target_ref
 			// Обрабатываем sequence flow напрямую получая target_ref
@@ -197,8 +178,7 @@ func (e *Engine) ExecuteToken(token *models.Token) error {
 			if !ok {
 				return fmt.Errorf("invalid target_ref format in sequence flow: %s", token.CurrentElementID)
 			}
-			logger.Info("Token moving via sequence flow to target element",
-				logger.String("token_id", token.TokenID),
+			log.Debug("Token moving via sequence flow to target element",
 				logger.String("flow_id", token.CurrentElementID),
 				logger.String("target_element", targetElementID))
 
@@ -229,83 +209,71 @@ func (e *Engine) ExecuteToken(token *models.Token) error {
 	if elementType == "serviceTask" {
 		executor, executorExists = e.executorRegistry.GetServiceTaskExecutor(elementMap)
 	} else {
-		logger.Info("DEBUG: Looking for executor",
-			logger.String("element_id", token.CurrentElementID),
-			logger.String("element_type", elementType))
 		executor, executorExists = e.executorRegistry.GetExecutor(elementType)
-		logger.Info("DEBUG: Executor lookup result",
-			logger.String("element_type", elementType),
-			logger.Bool("executor_exists", executorExists))
 	}
 
 	if !executorExists {
-		logger.Error("DEBUG: No executor found",
+		log.Error("No executor found",
 			logger.String("element_id", token.CurrentElementID),
 			logger.String("element_type", elementType))
 		return fmt.Errorf("no executor found for element type: %s", elementType)
 	}
 
+	// Multi-instance activity waits as body token, its instance tokens execute element
+	// Multi-instance активность ожидает как токен тела, элемент выполняют токены ее экземпляров
+	if multiInstance, isMulti := elementMap["multi_instance"].(map[string]interface{}); isMulti &&
+		!isMultiInstanceInstance(token) {
+		return e.component.StartMultiInstance(token, multiInstance)
+	}
+
 	// Execute element
-	logger.Info("🚀 [DEBUG] About to execute element - CRITICAL EXECUTION POINT",
-		logger.String("token_id", token.TokenID),
+	log.Debug("Executing element",
 		logger.String("element_id", token.CurrentElementID),
 		logger.String("element_type", elementType))
 
 	result, err := executor.Execute(token, elementMap)
 	if err != nil {
-		logger.Error("🔴 [DEBUG] Element execution failed - CRITICAL ERROR",
-			logger.String("token_id", token.TokenID),
+		log.Error("Element execution failed",
 			logger.String("element_id", token.CurrentElementID),
 			logger.String("element_type", elementType),
 			logger.String("error", err.Error()))
 
 		// Cancel boundary timers before marking token as failed
 		// Отменяем boundary таймеры перед отметкой токена как провалившегося
-		if err := e.component.CancelBoundaryTimersForToken(token.TokenID); err != nil {
-			logger.Error("Failed to cancel boundary timers for failed token",
-				logger.String("token_id", token.TokenID),
+		if err := e.component.CancelBoundaryTimers(token); err != nil {
+			log.Error("Failed to cancel boundary timers for failed token",
 				logger.String("error", err.Error()))
 		}
 
 		// Mark token as failed
 		token.SetState(models.TokenStateFailed)
 		if updateErr := e.storage.UpdateToken(token); updateErr != nil {
-			logger.Error("Failed to update failed token", logger.String("error", updateErr.Error()))
+			log.Error("Failed to update failed token", logger.String("error", updateErr.Error()))
 		}
 
 		return fmt.Errorf("element execution failed: %w", err)
 	}
 
-	logger.Info("✅ [DEBUG] Element execution successful",
-		logger.String("token_id", token.TokenID),
+	log.Debug("Element executed",
 		logger.String("element_id", token.CurrentElementID),
 		logger.String("element_type", elementType),
 		logger.Bool("success", result.Success),
 		logger.Bool("completed", result.Completed),
+		logger.Int("next_elements_count", len(result.NextElements)),
 		logger.String("waiting_for", result.WaitingFor))
 
-	// Process execution result
-	logger.Info("🔍 [DEBUG] Processing execution result",
-		logger.String("token_id", token.TokenID),
-		logger.String("element_id", token.CurrentElementID))
+	// Decision is recorded while token is still on gateway, so it lands on gateway element instance
+	// Решение записывается, пока токен еще на шлюзе, поэтому попадает в экземпляр элемента шлюза
+	e.recordGatewayDecision(token, elementType, result)
 
+	// Process execution result
 	if err := e.executionProcessor.processExecutionResult(token, result, &bpmnProcess); err != nil {
-		logger.Error("🔴 [DEBUG] Failed to process execution result - CRITICAL ERROR",
-			logger.String("token_id", token.TokenID),
+		log.Error("Failed to process execution result",
 			logger.String("element_id", token.CurrentElementID),
 			logger.String("error", err.Error()))
 		return fmt.Errorf("failed to process execution result: %w", err)
 	}
 
-	logger.Info("🎉 [DEBUG] === TOKEN EXECUTION COMPLETED SUCCESSFULLY ===",
-		logger.String("token_id", token.TokenID),
-		logger.String("element_id", token.CurrentElementID),
-		logger.String("element_type", elementType),
-		logger.Bool("success", result.Success),
-		logger.Bool("completed", result.Completed),
-		logger.Int("next_elements_count", len(result.NextElements)),
-		logger.String("waiting_for", result.WaitingFor))
-
 	return nil
 }
 
@@ -327,20 +295,18 @@ func (e *Engine) HandleMessageCallback(
 	messageID, messageName, correlationKey, tokenID string,
 	variables map[string]interface{},
 ) error {
-	logger.Info("🔍 [DEBUG] Engine HandleMessageCallback START",
+	logger.Debug("Handling message callback",
 		logger.String("message_id", messageID),
 		logger.String("message_name", messageName),
 		logger.String("correlation_key", correlationKey),
 		logger.String("token_id", tokenID),
-		logger.Any("variables", variables))
+		logger.Variables("variables", variables))
 
 	if e.storage == nil {
-		logger.Error("🔴 [DEBUG] Storage not available in HandleMessageCallback")
+		logger.Error("Storage not available in HandleMessageCallback")
 		return fmt.Errorf("storage not available")
 	}
 
-	logger.Info("✅ [DEBUG] Storage available, proceeding with message callback")
-
 	// Check if this is Message Start Event callback (empty token_id)
 	// Проверяем является ли это callback для Message Start Event (пустой token_id)
 	if tokenID == "" {
@@ -352,109 +318,58 @@ func (e *Engine) HandleMessageCallback(
 
 	// Load the specific token that is waiting for this message (for intermediate catch events)
 	// Загружаем конкретный токен который ожидает это сообщение (для intermediate catch events)
-	logger.Info("🔍 [DEBUG] Loading token from storage",
-		logger.String("token_id", tokenID))
-
 	token, err := e.storage.LoadToken(tokenID)
 	if err != nil {
-		logger.Error("🔴 [DEBUG] Failed to load token for message callback",
+		logger.Error("Failed to load token for message callback",
 			logger.String("message_id", messageID),
 			logger.String("token_id", tokenID),
 			logger.String("error", err.Error()))
 		return fmt.Errorf("failed to load token %s: %w", tokenID, err)
 	}
 
-	logger.Info("✅ [DEBUG] Token loaded successfully",
-		logger.String("token_id", tokenID),
-		logger.String("token_state", string(token.State)),
-		logger.String("token_waiting_for", token.WaitingFor),
-		logger.String("current_element_id", token.CurrentElementID),
-		logger.String("process_instance_id", token.ProcessInstanceID))
+	log := e.component.TokenLogger(token).With(
+		logger.String("message_id", messageID),
+		logger.String("message_name", messageName))
 
 	// Check if token is waiting for this message
 	expectedWaitingFor := fmt.Sprintf("message:%s", messageName)
-	logger.Info("🔍 [DEBUG] Validating token waiting state",
-		logger.String("token_id", tokenID),
-		logger.String("expected_waiting_for", expectedWaitingFor),
-		logger.String("actual_waiting_for", token.WaitingFor),
-		logger.Bool("is_waiting", token.IsWaiting()))
-
 	if !token.IsWaiting() || token.WaitingFor != expectedWaitingFor {
-		logger.Error("🔴 [DEBUG] Token validation failed - not waiting for this message",
-			logger.String("message_id", messageID),
-			logger.String("token_id", tokenID),
-			logger.String("message_name", messageName),
+		log.Error("Token is not waiting for this message",
 			logger.String("token_state", string(token.State)),
 			logger.String("token_waiting_for", token.WaitingFor),
 			logger.String("expected_waiting_for", expectedWaitingFor))
 		return fmt.Errorf("token %s is not waiting for message %s", tokenID, messageName)
 	}
 
-	logger.Info("✅ [DEBUG] Token validation passed - confirmed waiting for message",
-		logger.String("token_id", tokenID),
-		logger.String("message_name", messageName))
-
-	logger.Info("DEBUG: Token ProcessKey before message callback",
-		logger.String("token_id", tokenID),
-		logger.String("token_process_key", token.ProcessKey),
-		logger.String("token_process_instance_id", token.ProcessInstanceID))
-
 	// Clear waiting state and merge message variables
 	// Очищаем состояние ожидания и объединяем переменные сообщения
-	logger.Info("🔍 [DEBUG] Clearing token waiting state and merging variables",
-		logger.String("token_id", tokenID))
-
 	token.ClearWaitingFor()
-	logger.Info("✅ [DEBUG] Token waiting state cleared",
-		logger.String("token_id", tokenID),
-		logger.String("new_waiting_for", token.WaitingFor))
-
 	if variables != nil {
-		logger.Info("🔍 [DEBUG] Merging message variables to token",
-			logger.String("token_id", tokenID),
-			logger.Any("incoming_variables", variables))
-
 		token.MergeVariables(variables)
-		logger.Info("✅ [DEBUG] Message variables merged successfully",
-			logger.String("token_id", tokenID),
-			logger.Any("merged_variables", token.Variables))
-	} else {
-		logger.Info("ℹ️ [DEBUG] No variables to merge", logger.String("token_id", tokenID))
+		log.Debug("Message variables merged to token",
+			logger.Variables("merged_variables", token.Variables))
 	}
 
 	// Mark token as message correlated for future intermediate catch event detection
 	// Отмечаем токен как активированный через message correlation для обнаружения в intermediate catch events
-	logger.Info("🔍 [DEBUG] Marking token as message correlated",
-		logger.String("token_id", tokenID))
-
 	if token.Variables == nil {
 		token.Variables = make(map[string]interface{})
-		logger.Info("✅ [DEBUG] Initialized empty variables map", logger.String("token_id", tokenID))
 	}
 	token.Variables["_correlatedBy"] = "message"
-	logger.Info("✅ [DEBUG] Token marked as message correlated", logger.String("token_id", tokenID))
 
 	// Continue token execution from current element
 	// Продолжаем выполнение токена с текущего элемента
-	logger.Info("🚀 [DEBUG] About to call ExecuteToken - CRITICAL POINT",
-		logger.String("token_id", tokenID),
-		logger.String("element_id", token.CurrentElementID),
-		logger.String("token_state", string(token.State)),
-		logger.String("process_instance_id", token.ProcessInstanceID))
+	log.Debug("Continuing token after message correlation",
+		logger.String("element_id", token.CurrentElementID))
 
 	err = e.ExecuteToken(token)
 	if err != nil {
-		logger.Error("🔴 [DEBUG] ExecuteToken failed in HandleMessageCallback",
-			logger.String("token_id", tokenID),
+		log.Error("Failed to continue token after message correlation",
 			logger.String("element_id", token.CurrentElementID),
 			logger.String("error", err.Error()))
 		return err
 	}
 
-	logger.Info("🎉 [DEBUG] HandleMessageCallback completed successfully",
-		logger.String("token_id", tokenID),
-		logger.String("element_id", token.CurrentElementID))
-
 	return nil
 }
 
@@ -541,6 +456,14 @@ func (e *Engine) handleMessageStartEventCallback(
 		return fmt.Errorf("failed to save token: %w", err)
 	}
 
+	metrics.Processes.InstanceStarted(processInstance.ProcessID, "")
+	e.component.GetInstanceStatistics().InstanceStarted(processInstance)
+	if err := e.component.ScheduleInstanceSLA(processInstance, nil, 0); err != nil {
+		logger.Error("Failed to schedule process instance SLA",
+			logger.String("instance_id", processInstance.InstanceID),
+			logger.String("error", err.Error()))
+	}
+
 	// Execute token to start the process
 	// Выполняем токен чтобы запустить процесс
 	logger.Info("Starting Message Start Event process execution",
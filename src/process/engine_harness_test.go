@@ -0,0 +1,462 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"atom-engine/src/core/config"
+	"atom-engine/src/core/models"
+	"atom-engine/src/expression"
+	"atom-engine/src/incidents"
+	"atom-engine/src/jobs"
+	"atom-engine/src/messages"
+	"atom-engine/src/parser"
+	"atom-engine/src/storage"
+	"atom-engine/src/timewheel"
+)
+
+// testEngine runs process component against real storage and components wired the way core wires them
+// Timewheel runs on simulated clock, timers fire only when test advances it
+// Запускает компонент процессов с реальным storage и компонентами, связанными так же, как в core
+// Timewheel работает на симулированных часах, таймеры срабатывают только при продвижении времени тестом
+type testEngine struct {
+	t *testing.T
+
+	config     *config.Config
+	storage    storage.Storage
+	process    *Component
+	parser     *parser.Component
+	jobs       *jobs.Component
+	messages   *messages.Component
+	expression *expression.Component
+	incidents  *incidents.Component
+	timewheel  *timewheel.Component
+
+	mu     sync.Mutex
+	events []models.EngineEvent
+
+	stop chan struct{}
+	done sync.WaitGroup
+}
+
+// testEngineOption adjusts engine config or storage before components are created
+// Настраивает конфигурацию или storage движка до создания компонентов
+type testEngineOption func(cfg *config.Config, st *storage.Storage)
+
+// withStorageWrapper wraps storage passed to components, e.g. to count calls
+// Оборачивает storage, передаваемый компонентам, например для подсчета вызовов
+func withStorageWrapper(wrap func(storage.Storage) storage.Storage) testEngineOption {
+	return func(_ *config.Config, st *storage.Storage) {
+		*st = wrap(*st)
+	}
+}
+
+// newTestEngine starts engine in temporary directory stopped at test cleanup
+// Запускает движок во временной директории, останавливаемый по завершении теста
+func newTestEngine(t *testing.T, options ...testEngineOption) *testEngine {
+	t.Helper()
+	return startTestEngine(t, t.TempDir(), options...)
+}
+
+// startTestEngine starts engine on existing directory, so restart sees data of previous run
+// Запускает движок на существующей директории, чтобы перезапуск видел данные прошлого запуска
+func startTestEngine(t *testing.T, dir string, options ...testEngineOption) *testEngine {
+	t.Helper()
+
+	configPath := filepath.Join(dir, "config.yaml")
+	configYAML := fmt.Sprintf("base_path: %q\ndatabase:\n  path: %q\nbpmn:\n  path: %q\n",
+		dir, filepath.Join(dir, "db"), filepath.Join(dir, "bpmn"))
+	if err := os.WriteFile(configPath, []byte(configYAML), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+
+	badger := storage.NewStorage(&storage.Config{Path: cfg.Database.Path})
+	if err := badger.Init(); err != nil {
+		t.Fatalf("init storage: %v", err)
+	}
+	if err := badger.Start(); err != nil {
+		t.Fatalf("start storage: %v", err)
+	}
+
+	st := badger
+	for _, option := range options {
+		option(cfg, &st)
+	}
+
+	e := &testEngine{
+		t:          t,
+		config:     cfg,
+		storage:    st,
+		process:    NewComponent(cfg, st),
+		parser:     parser.NewComponent(cfg, st),
+		jobs:       jobs.NewComponent(cfg, st),
+		messages:   messages.NewComponent(cfg, st),
+		expression: expression.NewComponent(cfg),
+		incidents:  incidents.NewComponent(cfg, st),
+		timewheel:  timewheel.NewComponentWithStorage(st),
+		stop:       make(chan struct{}),
+	}
+
+	e.must(e.timewheel.EnableSimulation(time.Now()))
+	e.must(e.timewheel.Initialize(""))
+	e.must(e.timewheel.Start())
+	e.must(e.expression.Init())
+	e.must(e.expression.Start())
+
+	e.process.SetCore(e)
+	e.must(e.process.Init())
+	e.must(e.process.Start())
+
+	e.must(e.parser.Init())
+	e.must(e.parser.Start())
+
+	e.jobs.SetCore(e)
+	e.must(e.jobs.Start())
+	e.must(e.messages.Start())
+
+	e.incidents.SetCore(e)
+	e.must(e.incidents.Init())
+	e.must(e.incidents.Start())
+
+	e.route(e.jobs.GetResponseChannel(), e.handleJobsResponse)
+	e.route(e.messages.GetResponseChannel(), e.handleMessagesResponse)
+	e.route(e.timewheel.GetResponseChannel(), e.handleTimerResponse)
+
+	e.process.Recover()
+
+	t.Cleanup(e.Stop)
+	return e
+}
+
+// Stop stops components and storage, safe to call more than once
+// Останавливает компоненты и storage, можно вызывать повторно
+func (e *testEngine) Stop() {
+	select {
+	case <-e.stop:
+		return
+	default:
+	}
+	close(e.stop)
+	e.done.Wait()
+
+	e.process.Stop()
+	e.jobs.Stop()
+	e.messages.Stop()
+	e.timewheel.Stop()
+	e.storage.Stop()
+}
+
+func (e *testEngine) must(err error) {
+	e.t.Helper()
+	if err != nil {
+		e.t.Fatalf("start test engine: %v", err)
+	}
+}
+
+// route forwards component responses to handler until engine stops
+// Передает ответы компонента обработчику до остановки движка
+func (e *testEngine) route(responses <-chan string, handle func(string)) {
+	e.done.Add(1)
+	go func() {
+		defer e.done.Done()
+		for {
+			select {
+			case <-e.stop:
+				return
+			case response := <-responses:
+				handle(response)
+			}
+		}
+	}()
+}
+
+// handleJobsResponse forwards job callbacks to process component the way core does
+// Передает callbacks job'ов в компонент процессов так же, как core
+func (e *testEngine) handleJobsResponse(response string) {
+	var callback struct {
+		JobID        string             `json:"job_id"`
+		ElementID    string             `json:"element_id"`
+		TokenID      string             `json:"token_id"`
+		Status       string             `json:"status"`
+		Variables    models.VariableMap `json:"variables"`
+		ErrorMessage string             `json:"error_message"`
+		CallbackID   string             `json:"callback_id"`
+	}
+	if err := json.Unmarshal([]byte(response), &callback); err != nil || callback.TokenID == "" {
+		return
+	}
+	if err := e.process.HandleJobCallback(callback.CallbackID, callback.JobID, callback.ElementID,
+		callback.TokenID, callback.Status, callback.ErrorMessage, callback.Variables); err != nil {
+		e.t.Logf("job callback %s: %v", callback.JobID, err)
+	}
+}
+
+// handleMessagesResponse forwards message correlations to process component the way core does
+// Передает корреляции сообщений в компонент процессов так же, как core
+func (e *testEngine) handleMessagesResponse(response string) {
+	var callback struct {
+		MessageID      string                 `json:"message_id"`
+		MessageName    string                 `json:"message_name"`
+		CorrelationKey string                 `json:"correlation_key"`
+		TokenID        string                 `json:"token_id"`
+		Variables      map[string]interface{} `json:"variables"`
+		EventType      string                 `json:"event_type"`
+	}
+	if err := json.Unmarshal([]byte(response), &callback); err != nil || callback.EventType != "correlation" {
+		return
+	}
+	if err := e.process.HandleMessageCallback(callback.MessageID, callback.MessageName,
+		callback.CorrelationKey, callback.TokenID, callback.Variables); err != nil {
+		e.t.Logf("message callback %s: %v", callback.MessageID, err)
+	}
+}
+
+// handleTimerResponse forwards fired timers to jobs or process component the way core does
+// Передает сработавшие таймеры в компонент jobs или процессов так же, как core
+func (e *testEngine) handleTimerResponse(response string) {
+	var fired struct {
+		TimerID   string `json:"timer_id"`
+		ElementID string `json:"element_id"`
+		TokenID   string `json:"token_id"`
+		TimerType string `json:"timer_type"`
+	}
+	if err := json.Unmarshal([]byte(response), &fired); err != nil {
+		return
+	}
+	if fired.TimerType == string(models.TimerTypeJobRetry) {
+		if err := e.jobs.HandleRetryTimer(fired.ElementID); err != nil {
+			e.t.Logf("job retry timer %s: %v", fired.TimerID, err)
+		}
+		return
+	}
+	if err := e.process.HandleTimerCallback(fired.TimerID, fired.ElementID, fired.TokenID); err != nil {
+		e.t.Logf("timer callback %s: %v", fired.TimerID, err)
+	}
+}
+
+// CoreInterface implementation
+// Реализация CoreInterface
+
+func (e *testEngine) GetTimewheelComponentInterface() interface{} { return e.timewheel }
+func (e *testEngine) GetJobsComponent() interface{}               { return e.jobs }
+func (e *testEngine) GetMessagesComponent() interface{}           { return e.messages }
+func (e *testEngine) GetExpressionComponent() interface{}         { return e.expression }
+func (e *testEngine) GetIncidentsComponent() interface{}          { return e.incidents }
+func (e *testEngine) GetAuthComponent() interface{}               { return nil }
+
+// SendMessage delivers JSON message to component synchronously
+// Синхронно доставляет JSON сообщение компоненту
+func (e *testEngine) SendMessage(componentName, messageJSON string) error {
+	var processor models.JSONMessageProcessor
+	switch componentName {
+	case "incidents":
+		processor = e.incidents
+	case "timewheel":
+		processor = e.timewheel
+	case "jobs":
+		processor = e.jobs
+	case "messages":
+		processor = e.messages
+	default:
+		return fmt.Errorf("component not found: %s", componentName)
+	}
+	return processor.ProcessMessage(context.Background(), messageJSON)
+}
+
+// PublishEngineEvent records engine event for assertions
+// Сохраняет событие движка для проверок
+func (e *testEngine) PublishEngineEvent(event models.EngineEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.events = append(e.events, event)
+}
+
+// deploy parses BPMN content and returns process ID to start instances with
+// Парсит содержимое BPMN и возвращает ID процесса для запуска экземпляров
+func (e *testEngine) deploy(bpmnXML string) string {
+	e.t.Helper()
+
+	result, err := e.parser.ParseBPMNContent(bpmnXML, "", true, nil, nil)
+	if err != nil {
+		e.t.Fatalf("deploy BPMN: %v", err)
+	}
+	return result.ProcessID
+}
+
+// start starts process instance and fails test on error
+// Запускает экземпляр процесса, при ошибке тест завершается
+func (e *testEngine) start(processID string, variables map[string]interface{}) *models.ProcessInstance {
+	e.t.Helper()
+
+	if variables == nil {
+		variables = map[string]interface{}{}
+	}
+	instance, err := e.process.StartProcessInstance(processID, variables)
+	if err != nil {
+		e.t.Fatalf("start process %s: %v", processID, err)
+	}
+	return instance
+}
+
+// instance loads current state of process instance
+// Загружает текущее состояние экземпляра процесса
+func (e *testEngine) instance(instanceID string) *models.ProcessInstance {
+	e.t.Helper()
+
+	instance, err := e.storage.LoadProcessInstance(instanceID)
+	if err != nil {
+		e.t.Fatalf("load instance %s: %v", instanceID, err)
+	}
+	return instance
+}
+
+// tokens loads all tokens of process instance
+// Загружает все токены экземпляра процесса
+func (e *testEngine) tokens(instanceID string) []*models.Token {
+	e.t.Helper()
+
+	tokens, err := e.storage.LoadTokensByProcessInstance(instanceID)
+	if err != nil {
+		e.t.Fatalf("load tokens of %s: %v", instanceID, err)
+	}
+	return tokens
+}
+
+// jobsOf returns jobs of process instance, optionally of one element
+// Возвращает job'ы экземпляра процесса, при необходимости одного элемента
+func (e *testEngine) jobsOf(instanceID, elementID string) []jobs.JobInfo {
+	e.t.Helper()
+
+	all, _, err := e.jobs.ListJobs("", "", instanceID, "", 10000, 0)
+	if err != nil {
+		e.t.Fatalf("list jobs of %s: %v", instanceID, err)
+	}
+	var found []jobs.JobInfo
+	for _, job := range all {
+		if elementID == "" || job.ElementID == elementID {
+			found = append(found, job)
+		}
+	}
+	return found
+}
+
+// waitJob waits for open job of element and returns it
+// Ожидает открытый job элемента и возвращает его
+func (e *testEngine) waitJob(instanceID, elementID string) jobs.JobInfo {
+	e.t.Helper()
+
+	var open jobs.JobInfo
+	e.waitFor(fmt.Sprintf("job at %s", elementID), func() bool {
+		for _, job := range e.jobsOf(instanceID, elementID) {
+			if isOpenJob(job) {
+				open = job
+				return true
+			}
+		}
+		return false
+	})
+	return open
+}
+
+// isOpenJob reports whether job still waits for worker
+// Проверяет, ожидает ли job еще worker'а
+func isOpenJob(job jobs.JobInfo) bool {
+	return job.Status == string(models.JobStatusPending) || job.Status == string(models.JobStatusRunning)
+}
+
+// activate activates pending jobs of type as test worker
+// Активирует ожидающие job'ы типа как тестовый worker
+func (e *testEngine) activate(jobType string) []jobs.JobInfo {
+	e.t.Helper()
+
+	activated, err := e.jobs.ActivateJobs("test-worker", jobType, 1000)
+	if err != nil {
+		e.t.Fatalf("activate jobs of type %s: %v", jobType, err)
+	}
+	return activated
+}
+
+// completeJob activates and completes job through jobs component, as worker would
+// Активирует и завершает job через компонент jobs, как это сделал бы worker
+func (e *testEngine) completeJob(job jobs.JobInfo, variables map[string]interface{}) {
+	e.t.Helper()
+
+	if variables == nil {
+		variables = map[string]interface{}{}
+	}
+	if job.Status == string(models.JobStatusPending) {
+		e.activate(job.Type)
+	}
+	if err := e.jobs.CompleteJob(job.Key, variables); err != nil {
+		e.t.Fatalf("complete job %s: %v", job.Key, err)
+	}
+}
+
+// waitState waits until process instance reaches state
+// Ожидает перехода экземпляра процесса в состояние
+func (e *testEngine) waitState(instanceID string, state models.ProcessInstanceState) *models.ProcessInstance {
+	e.t.Helper()
+
+	var instance *models.ProcessInstance
+	e.waitFor(fmt.Sprintf("instance %s in state %s", instanceID, state), func() bool {
+		instance = e.instance(instanceID)
+		return instance.State == state
+	})
+	return instance
+}
+
+// advance moves simulated clock forward and delivers fired timers
+// Продвигает симулированные часы и доставляет сработавшие таймеры
+func (e *testEngine) advance(duration time.Duration) {
+	e.t.Helper()
+
+	if _, err := e.timewheel.AdvanceClock(duration, e.handleTimerResponse); err != nil {
+		e.t.Fatalf("advance clock: %v", err)
+	}
+}
+
+// waitFor polls condition until it holds or timeout expires
+// Опрашивает условие, пока оно не выполнится или не истечет таймаут
+func (e *testEngine) waitFor(what string, condition func() bool) {
+	e.t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			e.t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// bpmnDefinitions wraps process body into BPMN definitions with zeebe namespace
+// Оборачивает тело процесса в BPMN definitions с пространством имен zeebe
+func bpmnDefinitions(processID, body string) string {
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL"
+  xmlns:zeebe="http://camunda.org/schema/zeebe/1.0"
+  xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"
+  id="Definitions_` + processID + `" targetNamespace="http://bpmn.io/schema/bpmn">
+  <bpmn:process id="` + processID + `" isExecutable="true">
+` + body + `
+  </bpmn:process>
+</bpmn:definitions>`
+}
@@ -140,7 +140,7 @@ func (ege *ExclusiveGatewayExecutor) evaluateGatewayConditions(
 	logger.Info("Evaluating gateway conditions",
 		logger.String("token_id", token.TokenID),
 		logger.Int("outgoing_flows_count", len(outgoingFlows)),
-		logger.Any("token_variables", token.Variables))
+		logger.Variables("token_variables", token.Variables))
 
 	var defaultFlow string
 
@@ -161,51 +161,12 @@ func (ege *ExclusiveGatewayExecutor) evaluateGatewayConditions(
 			continue
 		}
 
-		// Debug: Check sequence_flow structure
-		// Отладка: Проверяем структуру sequence_flow
-		if sequenceFlow, hasSeqFlow := flowMap["sequence_flow"]; hasSeqFlow {
-			logger.Info("Flow sequence_flow structure",
-				logger.String("flow_id", flowID),
-				logger.Any("sequence_flow", sequenceFlow))
-		}
-
 		// Check if flow has condition
 		// Проверяем есть ли у потока условие
-		var conditionData interface{}
-		hasCondition := false
-
-		// First check direct condition field
-		if cond, exists := flowMap["condition"]; exists {
-			conditionData = cond
-			hasCondition = true
-			logger.Info("Found direct condition",
-				logger.String("flow_id", flowID),
-				logger.Any("condition", cond))
-		}
-
-		// Also check sequence_flow.condition
-		if seqFlow, exists := flowMap["sequence_flow"]; exists {
-			if seqFlowMap, ok := seqFlow.(map[string]interface{}); ok {
-				if cond, exists := seqFlowMap["condition"]; exists {
-					conditionData = cond
-					hasCondition = true
-					logger.Info("Found sequence_flow condition",
-						logger.String("flow_id", flowID),
-						logger.Any("condition", cond))
-				}
-			}
-		}
+		expression, hasCondition := flowConditionExpression(flowMap)
 
 		if hasCondition {
-			conditionMap, ok := conditionData.(map[string]interface{})
-			if !ok {
-				logger.Warn("Invalid condition structure",
-					logger.String("flow_id", flowID))
-				continue
-			}
-
-			expression, ok := conditionMap["expression"].(string)
-			if !ok || expression == "" {
+			if expression == "" {
 				logger.Warn("Empty or invalid condition expression",
 					logger.String("flow_id", flowID))
 				continue
@@ -257,6 +218,30 @@ func (ege *ExclusiveGatewayExecutor) evaluateGatewayConditions(
 	return "", fmt.Errorf("no valid outgoing flows found")
 }
 
+// flowConditionExpression returns condition expression of sequence flow element
+// Condition of sequence_flow wins over direct condition field, expression is empty when condition is malformed
+// Возвращает выражение условия элемента sequence flow
+// Условие sequence_flow важнее прямого поля condition, выражение пустое при некорректном условии
+func flowConditionExpression(flowMap map[string]interface{}) (string, bool) {
+	conditionData, hasCondition := flowMap["condition"]
+	if seqFlowMap, ok := flowMap["sequence_flow"].(map[string]interface{}); ok {
+		if cond, exists := seqFlowMap["condition"]; exists {
+			conditionData = cond
+			hasCondition = true
+		}
+	}
+	if !hasCondition {
+		return "", false
+	}
+
+	conditionMap, ok := conditionData.(map[string]interface{})
+	if !ok {
+		return "", true
+	}
+	expression, _ := conditionMap["expression"].(string)
+	return expression, true
+}
+
 // evaluateConditionWithExpressionEngine evaluates condition using full expression engine
 // Оценивает условие используя полноценный expression engine
 func (ege *ExclusiveGatewayExecutor) evaluateConditionWithExpressionEngine(
@@ -304,7 +289,7 @@ func (ege *ExclusiveGatewayExecutor) evaluateConditionWithExpressionEngine(
 	logger.Debug("Condition evaluated with expression engine",
 		logger.String("condition", condition),
 		logger.Bool("result", result),
-		logger.Any("variables", variables))
+		logger.Variables("variables", variables))
 
 	return result
 }
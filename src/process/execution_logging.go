@@ -0,0 +1,138 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+)
+
+const (
+	// DefaultDebugLoggingDuration is how long debug logging of definition lasts when duration is not given
+	// Длительность debug логирования определения, если длительность не задана
+	DefaultDebugLoggingDuration = time.Hour
+
+	// MaxDebugLoggingDuration limits debug logging so forgotten flag does not flood logs
+	// Ограничивает debug логирование, чтобы забытый флаг не переполнял логи
+	MaxDebugLoggingDuration = 24 * time.Hour
+)
+
+// ExecutionLogging holds definition versions whose execution logs are temporarily elevated to info.
+// State is kept in memory only, restart of daemon turns debug logging off
+// Хранит версии определений, логи выполнения которых временно подняты до info
+// Состояние хранится только в памяти, перезапуск демона выключает debug логирование
+type ExecutionLogging struct {
+	mu         sync.RWMutex
+	debugUntil map[string]time.Time // Storage key of definition version -> end of debug logging
+}
+
+// NewExecutionLogging creates execution logging state with debug logging off
+// Создает состояние логирования выполнения с выключенным debug логированием
+func NewExecutionLogging() *ExecutionLogging {
+	return &ExecutionLogging{debugUntil: make(map[string]time.Time)}
+}
+
+// SetDebug turns debug logging of definition version on until given time or off when until is zero
+// Включает debug логирование версии определения до указанного времени или выключает при нулевом времени
+func (el *ExecutionLogging) SetDebug(processKey string, until time.Time) {
+	el.mu.Lock()
+	defer el.mu.Unlock()
+
+	if until.IsZero() {
+		delete(el.debugUntil, processKey)
+		return
+	}
+	el.debugUntil[processKey] = until
+}
+
+// DebugUntil returns end of debug logging of definition version, false when debug logging is off or expired
+// Возвращает окончание debug логирования версии определения, false если оно выключено или истекло
+func (el *ExecutionLogging) DebugUntil(processKey string, now time.Time) (time.Time, bool) {
+	el.mu.RLock()
+	until, ok := el.debugUntil[processKey]
+	el.mu.RUnlock()
+
+	if !ok {
+		return time.Time{}, false
+	}
+	if !now.Before(until) {
+		el.mu.Lock()
+		if current, exists := el.debugUntil[processKey]; exists && current.Equal(until) {
+			delete(el.debugUntil, processKey)
+		}
+		el.mu.Unlock()
+		return time.Time{}, false
+	}
+	return until, true
+}
+
+// TokenLogger returns logger carrying instance, definition and token of token in every entry.
+// Debug entries are written at info level while debug logging of token definition is on
+// Возвращает логгер, добавляющий в каждую запись экземпляр, определение и токен
+// Debug записи пишутся на уровне info, пока включено debug логирование определения токена
+func (c *Component) TokenLogger(token *models.Token) *logger.ContextLogger {
+	_, debug := c.executionLogging.DebugUntil(token.ProcessKey, time.Now())
+	return logger.With(
+		logger.String("process_instance_id", token.ProcessInstanceID),
+		logger.String("process_key", token.ProcessKey),
+		logger.String("token_id", token.TokenID),
+	).Elevated(debug)
+}
+
+// SetProcessDebugLogging turns debug logging of process definition version on for duration or off.
+// Process key is storage key of version or process ID with optional ":version", latest version by default.
+// Zero duration means DefaultDebugLoggingDuration
+// Включает debug логирование версии определения процесса на длительность или выключает его
+// Ключ процесса - ключ версии в storage или ID процесса с необязательной ":версией", по умолчанию последняя.
+// Нулевая длительность означает DefaultDebugLoggingDuration
+func (c *Component) SetProcessDebugLogging(
+	processKey string,
+	debug bool,
+	duration time.Duration,
+) (*models.ProcessLogging, error) {
+	if duration < 0 || duration > MaxDebugLoggingDuration {
+		return nil, fmt.Errorf("%w: debug logging duration must be between 0 and %s",
+			models.ErrInvalidArgument, MaxDebugLoggingDuration)
+	}
+
+	bpmnProcess, storageKey, err := loadProcessDefinition(c.storage, processKey)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &models.ProcessLogging{
+		ProcessKey:     storageKey,
+		ProcessID:      bpmnProcess.ProcessID,
+		ProcessVersion: bpmnProcess.ProcessVersion,
+		Debug:          debug,
+	}
+
+	if !debug {
+		c.executionLogging.SetDebug(storageKey, time.Time{})
+		logger.Info("Debug logging of process definition turned off",
+			logger.String("process_key", storageKey))
+		return state, nil
+	}
+
+	if duration == 0 {
+		duration = DefaultDebugLoggingDuration
+	}
+	until := time.Now().Add(duration)
+	c.executionLogging.SetDebug(storageKey, until)
+	state.DebugUntil = &until
+
+	logger.Info("Debug logging of process definition turned on",
+		logger.String("process_key", storageKey),
+		logger.String("debug_until", until.Format(time.RFC3339)))
+	return state, nil
+}
@@ -10,8 +10,11 @@ package process
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"atom-engine/src/core/logger"
+	"atom-engine/src/core/metrics"
 	"atom-engine/src/core/models"
 	"atom-engine/src/storage"
 )
@@ -46,8 +49,7 @@ func (ep *ExecutionProcessor) processExecutionResult(
 
 	// Handle timer request from intermediate catch events
 	if result.TimerRequest != nil {
-		logger.Info("Processing timer request",
-			logger.String("token_id", token.TokenID),
+		ep.component.TokenLogger(token).Debug("Processing timer request",
 			logger.String("element_id", result.TimerRequest.ElementID))
 
 		if err := ep.component.CreateTimer(result.TimerRequest); err != nil {
@@ -67,20 +69,20 @@ func (ep *ExecutionProcessor) processExecutionResult(
 
 	// Handle completion
 	if result.Completed {
-		token.SetState(models.TokenStateCompleted)
-		if err := ep.storage.UpdateToken(token); err != nil {
-			return fmt.Errorf("failed to update completed token: %w", err)
-		}
-
 		// Cancel boundary timers for completed token
 		// Отменяем boundary таймеры для завершенного токена
-		if err := ep.component.CancelBoundaryTimersForToken(token.TokenID); err != nil {
+		if err := ep.component.CancelBoundaryTimers(token); err != nil {
 			logger.Error("Failed to cancel boundary timers for completed token",
 				logger.String("token_id", token.TokenID),
 				logger.String("error", err.Error()))
 			// Continue execution - boundary timer cancellation is not critical
 		}
 
+		token.SetState(models.TokenStateCompleted)
+		if err := ep.storage.UpdateToken(token); err != nil {
+			return fmt.Errorf("failed to update completed token: %w", err)
+		}
+
 		// Check if process instance should be completed
 		return ep.checkProcessCompletion(token.ProcessInstanceID)
 	}
@@ -98,19 +100,8 @@ func (ep *ExecutionProcessor) processExecutionResult(
 				continue
 			}
 
-			// Execute new token asynchronously
-			go func(t *models.Token) {
-				defer func() {
-					if r := recover(); r != nil {
-						logger.Error("Panic in token execution goroutine",
-							logger.String("token_id", t.TokenID),
-							logger.Any("panic", r))
-					}
-				}()
-				if err := ep.component.ExecuteToken(t); err != nil {
-					logger.Error("Failed to execute new token", logger.String("error", err.Error()))
-				}
-			}(newToken)
+			// Execute new token asynchronously on token pool
+			ep.component.SubmitToken(newToken)
 		}
 	}
 
@@ -133,25 +124,26 @@ func (ep *ExecutionProcessor) moveTokenToNextElements(
 		return nil
 	}
 
+	// Instance of multi-instance activity does not leave it, body token does
+	// Экземпляр multi-instance активности не покидает ее, это делает токен тела
+	if isMultiInstanceInstance(token) {
+		return ep.component.CompleteMultiInstanceInstance(token)
+	}
+
 	// Cancel boundary timers if token is leaving an activity
 	// Отменяем boundary таймеры если токен покидает activity
 	// Boundary timers are bound to specific activity and must be cancelled when token leaves that activity
 	// Boundary таймеры привязаны к конкретной activity и должны отменяться когда токен покидает эту activity
+	log := ep.component.TokenLogger(token)
 	if ep.isActivityElement(token.CurrentElementID, bpmnProcess) {
-		logger.Info("Token leaving activity - canceling boundary timers",
-			logger.String("token_id", token.TokenID),
+		log.Debug("Token leaving activity - canceling boundary timers",
 			logger.String("current_element_id", token.CurrentElementID))
 
-		if err := ep.component.CancelBoundaryTimersForToken(token.TokenID); err != nil {
-			logger.Error("Failed to cancel boundary timers when leaving activity",
-				logger.String("token_id", token.TokenID),
+		if err := ep.component.CancelBoundaryTimers(token); err != nil {
+			log.Error("Failed to cancel boundary timers when leaving activity",
 				logger.String("element_id", token.CurrentElementID),
 				logger.String("error", err.Error()))
 			// Continue execution - boundary timer cancellation is not critical
-		} else {
-			logger.Info("Boundary timers canceled when leaving activity",
-				logger.String("token_id", token.TokenID),
-				logger.String("element_id", token.CurrentElementID))
 		}
 	}
 
@@ -162,9 +154,8 @@ func (ep *ExecutionProcessor) moveTokenToNextElements(
 		if targetElementID != "" {
 			targetElements = append(targetElements, targetElementID)
 		} else {
-			logger.Error("Target element not found for flow",
-				logger.String("flow_id", flowID),
-				logger.String("token_id", token.TokenID))
+			log.Error("Target element not found for flow",
+				logger.String("flow_id", flowID))
 		}
 	}
 
@@ -172,6 +163,10 @@ func (ep *ExecutionProcessor) moveTokenToNextElements(
 		return fmt.Errorf("no target elements found for flows: %v", nextElements)
 	}
 
+	log.Debug("Moving token to next elements",
+		logger.String("from_element_id", token.CurrentElementID),
+		logger.String("target_elements", strings.Join(targetElements, ",")))
+
 	if len(targetElements) == 1 {
 		// Simple case: move token to single target element
 		token.MoveTo(targetElements[0])
@@ -199,19 +194,8 @@ func (ep *ExecutionProcessor) moveTokenToNextElements(
 			continue
 		}
 
-		// Execute new token asynchronously
-		go func(t *models.Token) {
-			defer func() {
-				if r := recover(); r != nil {
-					logger.Error("Panic in parallel token execution goroutine",
-						logger.String("token_id", t.TokenID),
-						logger.Any("panic", r))
-				}
-			}()
-			if err := ep.component.ExecuteToken(t); err != nil {
-				logger.Error("Failed to execute parallel token", logger.String("error", err.Error()))
-			}
-		}(newToken)
+		// Execute new token asynchronously on token pool
+		ep.component.SubmitToken(newToken)
 	}
 
 	return nil
@@ -325,6 +309,13 @@ func (ep *ExecutionProcessor) checkProcessCompletion(instanceID string) error {
 		}
 
 		logger.Info("Process instance completed", logger.String("instance_id", instanceID))
+		metrics.Processes.InstanceCompleted(instance.ProcessID, "", time.Since(instance.StartedAt))
+		ep.component.GetInstanceStatistics().InstanceFinished(instance)
+		ep.component.CancelInstanceSLA(instance)
+
+		event := models.NewProcessInstanceEvent(models.EngineEventProcessInstanceCompleted, instance)
+		event.Data = map[string]interface{}{"variables": instance.Variables}
+		ep.publishEngineEvent(event)
 
 		// Check for call activity parent tokens waiting for this process
 		if err := ep.handleCallActivityCompletion(instanceID); err != nil {
@@ -338,6 +329,17 @@ func (ep *ExecutionProcessor) checkProcessCompletion(instanceID string) error {
 	return nil
 }
 
+// publishEngineEvent forwards engine event to core listeners
+// Передает событие движка слушателям core
+func (ep *ExecutionProcessor) publishEngineEvent(event models.EngineEvent) {
+	if ep.component == nil {
+		return
+	}
+	if core := ep.component.GetCore(); core != nil {
+		core.PublishEngineEvent(event)
+	}
+}
+
 // handleCallActivityCompletion handles completion of child process for call activity
 // Обрабатывает завершение дочернего процесса для call activity
 func (ep *ExecutionProcessor) handleCallActivityCompletion(childInstanceID string) error {
@@ -388,6 +390,7 @@ func (ep *ExecutionProcessor) handleCallActivityCompletion(childInstanceID strin
 		}
 
 		// Clear waiting state
+		migrateCallActivityMarker(parentToken)
 		parentToken.ClearWaitingFor()
 
 		// Update token in storage
@@ -0,0 +1,112 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+)
+
+// isConditionalGateway reports whether gateway chooses outgoing flows by conditions
+// Parallel gateway takes all flows and event-based gateway waits on events, so they are not counted
+// Сообщает, выбирает ли шлюз исходящие потоки по условиям
+// Параллельный шлюз берет все потоки, а шлюз по событиям ждет события, поэтому они не считаются
+func isConditionalGateway(elementType string) bool {
+	return elementType == "exclusiveGateway" || elementType == "inclusiveGateway"
+}
+
+// recordGatewayDecision stores flows taken by token on conditional gateway
+// Failure is logged only, metrics must not stop token
+// Сохраняет потоки, выбранные токеном на условном шлюзе
+// Ошибка только логируется, метрики не должны останавливать токен
+func (e *Engine) recordGatewayDecision(token *models.Token, elementType string, result *ExecutionResult) {
+	if !isConditionalGateway(elementType) || result == nil || !result.Success || len(result.NextElements) == 0 {
+		return
+	}
+
+	decision := &models.GatewayDecision{
+		ProcessKey:        token.ProcessKey,
+		ProcessInstanceID: token.ProcessInstanceID,
+		TokenKey:          token.Key,
+		GatewayID:         token.CurrentElementID,
+		Flows:             result.NextElements,
+		DecidedAt:         time.Now(),
+	}
+	if err := e.storage.RecordGatewayDecision(decision); err != nil {
+		logger.Warn("Failed to record gateway decision",
+			logger.String("token_id", token.TokenID),
+			logger.String("gateway_id", decision.GatewayID),
+			logger.String("error", err.Error()))
+	}
+}
+
+// GetGatewayMetrics returns how often each outgoing flow of conditional gateways was taken
+// Process key is storage key of version or process ID with optional ":version", latest version by default.
+// Flows never taken are listed with zero count
+// Возвращает, как часто выбирался каждый исходящий поток условных шлюзов
+// Ключ процесса - ключ версии в storage или ID процесса с необязательной ":версией", по умолчанию последняя.
+// Ни разу не выбранные потоки перечисляются с нулевым счетчиком
+func (c *Component) GetGatewayMetrics(processKey string) (*models.GatewayMetrics, error) {
+	bpmnProcess, storageKey, err := loadProcessDefinition(c.storage, processKey)
+	if err != nil {
+		return nil, err
+	}
+
+	counters, err := c.storage.GetGatewayMetrics(storageKey)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := &models.GatewayMetrics{
+		ProcessKey:     storageKey,
+		ProcessID:      bpmnProcess.ProcessID,
+		ProcessVersion: bpmnProcess.ProcessVersion,
+		Gateways:       make([]models.GatewayFlowMetrics, 0),
+	}
+	for _, gatewayID := range sortedKeys(bpmnProcess.Elements) {
+		element, _ := bpmnProcess.Elements[gatewayID].(map[string]interface{})
+		elementType, _ := element["type"].(string)
+		if !isConditionalGateway(elementType) {
+			continue
+		}
+
+		gatewayCounters := counters[gatewayID]
+		name, _ := element["name"].(string)
+		gateway := models.GatewayFlowMetrics{
+			GatewayID:   gatewayID,
+			GatewayType: elementType,
+			Name:        name,
+			Total:       gatewayCounters[""],
+			Flows:       make([]models.FlowTakenCount, 0),
+		}
+		for _, flowID := range extractOutgoingFlows(element) {
+			flow, _ := bpmnProcess.Elements[flowID].(map[string]interface{})
+			targetID, _ := flow["target_ref"].(string)
+			flowName, _ := flow["name"].(string)
+			count := gatewayCounters[flowID]
+
+			var ratio float64
+			if gateway.Total > 0 {
+				ratio = float64(count) / float64(gateway.Total)
+			}
+			gateway.Flows = append(gateway.Flows, models.FlowTakenCount{
+				FlowID:   flowID,
+				TargetID: targetID,
+				Name:     flowName,
+				Count:    count,
+				Ratio:    ratio,
+			})
+		}
+		metrics.Gateways = append(metrics.Gateways, gateway)
+	}
+
+	return metrics, nil
+}
@@ -98,6 +98,15 @@ func (hce *HttpConnectorExecutor) Execute(
 			logger.String("error", err.Error()))
 	}
 
+	// Subscribe conditional boundary events when token enters activity
+	// Подписываем условные граничные события когда токен входит в активность
+	if err := createConditionalBoundaries(hce.processComponent, token); err != nil {
+		logger.Error("Failed to create conditional boundary subscriptions",
+			logger.String("token_id", token.TokenID),
+			logger.String("element_id", token.CurrentElementID),
+			logger.String("error", err.Error()))
+	}
+
 	// Create error boundary subscriptions when token enters activity
 	logger.Info("About to create error boundary subscriptions",
 		logger.String("token_id", token.TokenID),
@@ -1184,7 +1193,7 @@ func (hce *HttpConnectorExecutor) applyOutputMapping(
 		logger.Info("Output mapping applied",
 			logger.String("source", source),
 			logger.String("target", target),
-			logger.Any("value", value))
+			logger.VariableValue("value", target, value))
 	}
 
 	return nil
@@ -1409,7 +1418,7 @@ func (hce *HttpConnectorExecutor) processInputs(
 		logger.Debug("Evaluated input value",
 			logger.String("source", source),
 			logger.String("target", target),
-			logger.Any("value", value))
+			logger.VariableValue("value", target, value))
 
 		// Map to config fields
 		switch target {
@@ -1677,9 +1686,9 @@ func (hce *HttpConnectorExecutor) createBoundaryTimerForEvent(
 			}
 		}
 
-		timerID, err := hce.processComponent.CreateBoundaryTimerWithID(timerRequest)
+		timerID, err := hce.processComponent.RegisterBoundaryTimer(token, timerRequest)
 		if err != nil {
-			return fmt.Errorf("failed to create boundary timer: %w", err)
+			return fmt.Errorf("failed to register boundary timer: %w", err)
 		}
 
 		logger.Info("Boundary timer created",
@@ -1687,13 +1696,6 @@ func (hce *HttpConnectorExecutor) createBoundaryTimerForEvent(
 			logger.String("timer_id", timerID),
 			logger.String("event_id", eventID),
 			logger.String("activity_id", token.CurrentElementID))
-
-		if err := hce.processComponent.LinkBoundaryTimerToToken(token.TokenID, timerID); err != nil {
-			logger.Error("Failed to link boundary timer to token",
-				logger.String("parent_token_id", token.TokenID),
-				logger.String("timer_id", timerID),
-				logger.String("error", err.Error()))
-		}
 	}
 
 	return nil
@@ -1916,7 +1918,7 @@ func (hce *HttpConnectorExecutor) evaluateTimerExpression(expression string, tok
 		logger.String("token_id", token.TokenID),
 		logger.String("original_expression", expression),
 		logger.Any("evaluated_result", result),
-		logger.Any("token_variables", token.Variables))
+		logger.Variables("token_variables", token.Variables))
 
 	return result, nil
 }
@@ -114,7 +114,7 @@ func (ige *InclusiveGatewayExecutor) evaluateInclusiveGatewayConditions(
 	logger.Debug("Evaluating inclusive gateway conditions",
 		logger.String("token_id", token.TokenID),
 		logger.Int("outgoing_flows", len(outgoingFlows)),
-		logger.Any("variables", evaluationContext))
+		logger.Variables("variables", evaluationContext))
 
 	// Evaluate each outgoing flow
 	// Оцениваем каждый исходящий поток
@@ -273,7 +273,7 @@ func (ige *InclusiveGatewayExecutor) evaluateConditionWithExpressionEngine(
 	logger.Debug("Condition evaluated with expression engine",
 		logger.String("condition", condition),
 		logger.Bool("result", result),
-		logger.Any("variables", variables))
+		logger.Variables("variables", variables))
 
 	return result
 }
@@ -0,0 +1,500 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+)
+
+// errMigrationFilteredOut marks instance not selected by migration filter
+// Отмечает экземпляр, не выбранный фильтром миграции
+var errMigrationFilteredOut = errors.New("instance does not match migration filter")
+
+// rearmableWaits are waits released and created again when element definition changes
+// Waits of subprocesses, call activities and gateways hold state of their own and are kept only unchanged
+// Ожидания, которые снимаются и создаются заново при изменении определения элемента
+// Ожидания подпроцессов, call activity и шлюзов хранят собственное состояние и сохраняются только без изменений
+var rearmableWaits = []string{"job:", "timer:", "message:", "signal:", conditionWaitingPrefix}
+
+// definitionLayoutKeys are element fields that do not change behavior of waiting token
+// Поля элемента, которые не меняют поведение ожидающего токена
+var definitionLayoutKeys = []string{"id", "name", "incoming", "outgoing", "parent_scope", "attached_to_ref"}
+
+// instanceMigration holds validated plan with both loaded definitions
+// Хранит проверенный план с обоими загруженными определениями
+type instanceMigration struct {
+	sourceKey string
+	targetKey string
+	source    *models.BPMNProcess
+	target    *models.BPMNProcess
+	mappings  map[string]string
+}
+
+// migrationPlanResult holds tokens changed by migration of one instance before they are saved
+// Хранит токены, измененные миграцией одного экземпляра, до их сохранения
+type migrationPlanResult struct {
+	migrated []*models.Token
+
+	// rearmed keeps token state before migration, it tells which waits to release
+	// rearmed хранит состояние токена до миграции, по нему определяется, какие ожидания снять
+	rearmed []*models.Token
+}
+
+// MigrateProcessInstances moves running instances of source definition version to target version
+// Plan is validated against both definitions first. Every instance is checked and migrated on its own:
+// instance failing validation is skipped and reported, other instances are still migrated.
+// Waiting tokens whose element changed are released and executed again on target definition
+// Переносит выполняющиеся экземпляры исходной версии определения на целевую версию
+// Сначала план проверяется по обоим определениям. Каждый экземпляр проверяется и мигрируется отдельно:
+// экземпляр, не прошедший проверку, пропускается и попадает в отчет, остальные мигрируются.
+// Ожидающие токены, элемент которых изменился, освобождаются и выполняются заново по целевому определению
+func (pim *ProcessInstanceManager) MigrateProcessInstances(
+	plan models.MigrationPlan,
+	filter models.MigrationFilter,
+	dryRun bool,
+) (*models.ProcessInstanceMigration, error) {
+	if !pim.component.IsReady() {
+		return nil, fmt.Errorf("process component not ready")
+	}
+
+	migration, err := pim.loadMigration(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	instanceIDs, explicit, err := pim.migrationCandidates(migration, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &models.ProcessInstanceMigration{
+		SourceProcessKey: migration.sourceKey,
+		TargetProcessKey: migration.targetKey,
+		DryRun:           dryRun,
+		Instances:        make([]models.InstanceMigrationResult, 0, len(instanceIDs)),
+	}
+
+	for _, instanceID := range instanceIDs {
+		result, err := pim.migrateInstance(migration, instanceID, filter, dryRun)
+		if errors.Is(err, errMigrationFilteredOut) && !explicit {
+			continue
+		}
+		if err != nil {
+			logger.Warn("Process instance skipped by migration",
+				logger.String("instance_id", instanceID),
+				logger.String("source_process_key", migration.sourceKey),
+				logger.String("target_process_key", migration.targetKey),
+				logger.String("error", err.Error()))
+			report.Skipped++
+			report.Instances = append(report.Instances, models.InstanceMigrationResult{
+				InstanceID: instanceID,
+				Status:     models.MigrationStatusSkipped,
+				Error:      err.Error(),
+			})
+			continue
+		}
+		report.Migrated++
+		report.Instances = append(report.Instances, *result)
+	}
+
+	logger.Info("Process instances migrated",
+		logger.String("source_process_key", migration.sourceKey),
+		logger.String("target_process_key", migration.targetKey),
+		logger.Bool("dry_run", dryRun),
+		logger.Int("migrated", report.Migrated),
+		logger.Int("skipped", report.Skipped))
+
+	return report, nil
+}
+
+// loadMigration loads source and target definitions and validates element mappings against them
+// Загружает исходное и целевое определения и проверяет по ним сопоставления элементов
+func (pim *ProcessInstanceManager) loadMigration(plan models.MigrationPlan) (*instanceMigration, error) {
+	if plan.ProcessID == "" {
+		return nil, fmt.Errorf("%w: process_id is required", models.ErrInvalidMigration)
+	}
+	if plan.SourceVersion <= 0 || plan.TargetVersion <= 0 {
+		return nil, fmt.Errorf("%w: source_version and target_version must be positive", models.ErrInvalidMigration)
+	}
+	if plan.SourceVersion == plan.TargetVersion {
+		return nil, fmt.Errorf("%w: source and target versions are equal", models.ErrInvalidMigration)
+	}
+
+	source, sourceKey, err := pim.loadDefinitionVersion(plan.ProcessID, plan.SourceVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load source definition: %w", err)
+	}
+	target, targetKey, err := pim.loadDefinitionVersion(plan.ProcessID, plan.TargetVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load target definition: %w", err)
+	}
+
+	migration := &instanceMigration{
+		sourceKey: sourceKey,
+		targetKey: targetKey,
+		source:    source,
+		target:    target,
+		mappings:  plan.ElementMappings,
+	}
+	for sourceID, targetID := range plan.ElementMappings {
+		if _, exists := source.Elements[sourceID]; !exists {
+			return nil, fmt.Errorf("%w: mapped element %s not found in %s",
+				models.ErrInvalidMigration, sourceID, sourceKey)
+		}
+		if err := migration.checkElementPair(sourceID, targetID); err != nil {
+			return nil, fmt.Errorf("%w: %s", models.ErrInvalidMigration, err.Error())
+		}
+	}
+	return migration, nil
+}
+
+// loadDefinitionVersion loads definition of exact version with its storage key
+// Загружает определение конкретной версии вместе с его ключом в storage
+func (pim *ProcessInstanceManager) loadDefinitionVersion(
+	processID string,
+	version int,
+) (*models.BPMNProcess, string, error) {
+	data, storageKey, err := pim.storage.LoadBPMNProcessByProcessID(processID, version)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var bpmnProcess models.BPMNProcess
+	if err := json.Unmarshal(data, &bpmnProcess); err != nil {
+		return nil, "", fmt.Errorf("failed to parse process definition %s: %w", storageKey, err)
+	}
+	return &bpmnProcess, storageKey, nil
+}
+
+// migrationCandidates returns IDs of instances to check, explicit is true when IDs are given by filter
+// Возвращает ID экземпляров для проверки, explicit истинно, если ID заданы фильтром
+func (pim *ProcessInstanceManager) migrationCandidates(
+	migration *instanceMigration,
+	filter models.MigrationFilter,
+) ([]string, bool, error) {
+	if len(filter.InstanceIDs) > 0 {
+		instanceIDs := make([]string, 0, len(filter.InstanceIDs))
+		for _, instanceID := range filter.InstanceIDs {
+			if resolvedID, err := pim.storage.ResolveProcessInstanceID(instanceID); err == nil {
+				instanceID = resolvedID
+			}
+			instanceIDs = append(instanceIDs, instanceID)
+		}
+		return instanceIDs, true, nil
+	}
+
+	instances, err := pim.storage.LoadProcessInstancesByProcessKey(migration.sourceKey)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load process instances: %w", err)
+	}
+
+	instanceIDs := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		if !instance.IsCompleted() {
+			instanceIDs = append(instanceIDs, instance.InstanceID)
+		}
+	}
+	return instanceIDs, false, nil
+}
+
+// migrateInstance validates and migrates one instance, nothing is changed when validation fails
+// Проверяет и мигрирует один экземпляр, при ошибке проверки ничего не изменяется
+func (pim *ProcessInstanceManager) migrateInstance(
+	migration *instanceMigration,
+	instanceID string,
+	filter models.MigrationFilter,
+	dryRun bool,
+) (*models.InstanceMigrationResult, error) {
+	planResult, err := pim.applyMigration(migration, instanceID, filter, dryRun)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.InstanceMigrationResult{
+		InstanceID: instanceID,
+		Status:     models.MigrationStatusMigrated,
+	}
+	if dryRun {
+		result.Status = models.MigrationStatusValid
+	}
+	for _, token := range planResult.migrated {
+		result.MigratedTokenIDs = append(result.MigratedTokenIDs, token.TokenID)
+	}
+	for _, token := range planResult.rearmed {
+		result.RearmedTokenIDs = append(result.RearmedTokenIDs, token.TokenID)
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	for _, previous := range planResult.rearmed {
+		pim.releaseCanceledToken(previous)
+
+		// Releasing boundary timers updates stored token, so token is executed as stored
+		// Снятие граничных таймеров обновляет сохраненный токен, поэтому выполняется сохраненный токен
+		token, err := pim.storage.LoadToken(previous.TokenID)
+		if err == nil {
+			err = pim.component.ExecuteToken(token)
+		}
+		if err != nil {
+			logger.Error("Failed to execute token re-armed by migration",
+				logger.String("instance_id", instanceID),
+				logger.String("token_id", previous.TokenID),
+				logger.String("error", err.Error()))
+		}
+	}
+
+	logger.Info("Process instance migrated",
+		logger.String("instance_id", instanceID),
+		logger.String("source_process_key", migration.sourceKey),
+		logger.String("target_process_key", migration.targetKey),
+		logger.Int("migrated_tokens", len(result.MigratedTokenIDs)),
+		logger.Int("rearmed_tokens", len(result.RearmedTokenIDs)))
+
+	return result, nil
+}
+
+// applyMigration checks instance against plan and saves migrated instance and tokens in one transaction
+// Проверяет экземпляр по плану и сохраняет мигрированные экземпляр и токены в одной транзакции
+func (pim *ProcessInstanceManager) applyMigration(
+	migration *instanceMigration,
+	instanceID string,
+	filter models.MigrationFilter,
+	dryRun bool,
+) (*migrationPlanResult, error) {
+	pim.variablesMu.Lock()
+	defer pim.variablesMu.Unlock()
+
+	instance, err := pim.storage.LoadProcessInstance(instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load process instance: %w", err)
+	}
+	if instance.ProcessKey != migration.sourceKey {
+		return nil, fmt.Errorf("instance runs %s, not %s", instance.ProcessKey, migration.sourceKey)
+	}
+	if instance.IsCompleted() {
+		return nil, fmt.Errorf("%w: %s is %s", models.ErrProcessInstanceFinished, instanceID, instance.State)
+	}
+	if filter.BusinessKey != "" {
+		if businessKey, _ := instance.GetMetadata(models.BusinessKeyMetadataKey); businessKey != filter.BusinessKey {
+			return nil, fmt.Errorf("%w: business key differs", errMigrationFilteredOut)
+		}
+	}
+
+	tokens, err := pim.storage.LoadTokensByProcessInstance(instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokens: %w", err)
+	}
+
+	planResult := &migrationPlanResult{}
+	atFilterElement := filter.ElementID == ""
+	for _, token := range tokens {
+		if token.ProcessKey != migration.sourceKey || !isModifiableToken(token) {
+			continue
+		}
+		if token.CurrentElementID == filter.ElementID {
+			atFilterElement = true
+		}
+
+		rearm, err := migration.checkToken(token)
+		if err != nil {
+			return nil, err
+		}
+		planResult.migrated = append(planResult.migrated, token)
+		if rearm {
+			previous := *token
+			planResult.rearmed = append(planResult.rearmed, &previous)
+		}
+	}
+	if !atFilterElement {
+		return nil, fmt.Errorf("%w: no token at element %s", errMigrationFilteredOut, filter.ElementID)
+	}
+	if err := pim.checkGatewaySyncStates(migration, instanceID); err != nil {
+		return nil, err
+	}
+	if dryRun {
+		return planResult, nil
+	}
+
+	now := time.Now()
+	rearmed := make(map[string]bool, len(planResult.rearmed))
+	for _, previous := range planResult.rearmed {
+		rearmed[previous.TokenID] = true
+	}
+	for _, token := range planResult.migrated {
+		token.ProcessKey = migration.targetKey
+		token.CurrentElementID = migration.targetElementID(token.CurrentElementID)
+		if token.PreviousElementID != "" {
+			token.PreviousElementID = migration.targetElementID(token.PreviousElementID)
+		}
+		if token.SubProcessID != "" {
+			token.SubProcessID = migration.targetElementID(token.SubProcessID)
+		}
+		if rearmed[token.TokenID] {
+			token.State = models.TokenStateActive
+			token.WaitingFor = ""
+		}
+		token.UpdatedAt = now
+	}
+
+	instance.ProcessKey = migration.targetKey
+	instance.ProcessVersion = migration.target.ProcessVersion
+	instance.ProcessName = migration.target.ProcessName
+	if instance.CurrentActivity != "" {
+		instance.CurrentActivity = migration.targetElementID(instance.CurrentActivity)
+	}
+	instance.AddMetadata(models.MigratedFromMetadataKey, migration.sourceKey)
+
+	if err := pim.storage.SaveProcessInstanceWithTokens(instance, planResult.migrated); err != nil {
+		return nil, fmt.Errorf("failed to save migrated process instance: %w", err)
+	}
+	return planResult, nil
+}
+
+// checkGatewaySyncStates rejects renaming of joining gateway that already collected tokens of instance
+// Отклоняет переименование объединяющего шлюза, который уже собрал токены экземпляра
+func (pim *ProcessInstanceManager) checkGatewaySyncStates(migration *instanceMigration, instanceID string) error {
+	for sourceID, targetID := range migration.mappings {
+		if sourceID == targetID {
+			continue
+		}
+		element, _ := migration.source.Elements[sourceID].(map[string]interface{})
+		elementType, _ := element["type"].(string)
+		if elementType != "parallelGateway" && elementType != "inclusiveGateway" {
+			continue
+		}
+		if state, err := pim.storage.LoadGatewaySyncState(sourceID, instanceID); err == nil && state != nil {
+			return fmt.Errorf("gateway %s is synchronizing tokens and cannot be renamed", sourceID)
+		}
+	}
+	return nil
+}
+
+// checkToken checks token position maps to target definition, returns whether its wait must be re-armed
+// Проверяет, что позиция токена переносится в целевое определение, возвращает, нужно ли пересоздать ожидание
+func (m *instanceMigration) checkToken(token *models.Token) (bool, error) {
+	sourceID := token.CurrentElementID
+	targetID := m.targetElementID(sourceID)
+	if err := m.checkElementPair(sourceID, targetID); err != nil {
+		return false, fmt.Errorf("token %s: %s", token.TokenID, err.Error())
+	}
+
+	if !token.IsWaiting() || (sourceID == targetID && m.sameDefinition(sourceID, targetID)) {
+		return false, nil
+	}
+	for _, prefix := range rearmableWaits {
+		if strings.HasPrefix(token.WaitingFor, prefix) {
+			return true, nil
+		}
+	}
+	return false, fmt.Errorf("token %s waits for %s at changed element %s, such wait cannot be re-armed",
+		token.TokenID, token.WaitingFor, sourceID)
+}
+
+// checkElementPair checks target element exists, has same type and lies in mapped scope of source element
+// Проверяет, что целевой элемент существует, имеет тот же тип и лежит в сопоставленной области исходного
+func (m *instanceMigration) checkElementPair(sourceID, targetID string) error {
+	source, _ := m.source.Elements[sourceID].(map[string]interface{})
+	target, exists := m.target.Elements[targetID].(map[string]interface{})
+	if !exists {
+		return fmt.Errorf("element %s not found in %s, add element mapping", targetID, m.targetKey)
+	}
+
+	sourceType, _ := source["type"].(string)
+	targetType, _ := target["type"].(string)
+	if sourceType != targetType {
+		return fmt.Errorf("element %s is %s in %s, %s expected", targetID, targetType, m.targetKey, sourceType)
+	}
+
+	sourceScope := elementScope(m.source, source)
+	if sourceScope != "" {
+		sourceScope = m.targetElementID(sourceScope)
+	}
+	if targetScope := elementScope(m.target, target); targetScope != sourceScope {
+		return fmt.Errorf("element %s moved to other scope in %s", targetID, m.targetKey)
+	}
+	return nil
+}
+
+// sameDefinition reports whether element and its boundary events behave same in both definitions
+// Сообщает, ведут ли себя элемент и его граничные события одинаково в обоих определениях
+func (m *instanceMigration) sameDefinition(sourceID, targetID string) bool {
+	source, _ := m.source.Elements[sourceID].(map[string]interface{})
+	target, _ := m.target.Elements[targetID].(map[string]interface{})
+	if !reflect.DeepEqual(behaviorFields(source), behaviorFields(target)) {
+		return false
+	}
+
+	// Source boundary events are keyed by their target IDs, so renamed boundary event counts as change
+	// Исходные граничные события индексируются целевыми ID, поэтому переименование считается изменением
+	sourceBoundaries := make(map[string]map[string]interface{})
+	for id, boundary := range attachedBoundaryEvents(m.source, sourceID) {
+		sourceBoundaries[m.targetElementID(id)] = boundary
+	}
+	return reflect.DeepEqual(sourceBoundaries, attachedBoundaryEvents(m.target, targetID))
+}
+
+// targetElementID returns target ID of source element, unmapped elements keep their IDs
+// Возвращает целевой ID исходного элемента, несопоставленные элементы сохраняют свои ID
+func (m *instanceMigration) targetElementID(sourceID string) string {
+	if targetID, exists := m.mappings[sourceID]; exists {
+		return targetID
+	}
+	return sourceID
+}
+
+// elementScope returns ID of subprocess element lies in, empty for process level
+// Возвращает ID подпроцесса, в котором лежит элемент, пустой для уровня процесса
+func elementScope(bpmnProcess *models.BPMNProcess, element map[string]interface{}) string {
+	scope, _ := element["parent_scope"].(string)
+	if scope == bpmnProcess.ProcessID {
+		return ""
+	}
+	return scope
+}
+
+// attachedBoundaryEvents returns behavior fields of boundary events attached to element by their IDs
+// Возвращает поля поведения граничных событий, прикрепленных к элементу, по их ID
+func attachedBoundaryEvents(
+	bpmnProcess *models.BPMNProcess,
+	elementID string,
+) map[string]map[string]interface{} {
+	boundaries := make(map[string]map[string]interface{})
+	for id, raw := range bpmnProcess.Elements {
+		element, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if attachedTo, _ := element["attached_to_ref"].(string); attachedTo == elementID {
+			boundaries[id] = behaviorFields(element)
+		}
+	}
+	return boundaries
+}
+
+// behaviorFields returns copy of element without identity and layout fields
+// Возвращает копию элемента без полей идентичности и расположения
+func behaviorFields(element map[string]interface{}) map[string]interface{} {
+	fields := make(map[string]interface{}, len(element))
+	for key, value := range element {
+		fields[key] = value
+	}
+	for _, key := range definitionLayoutKeys {
+		delete(fields, key)
+	}
+	return fields
+}
@@ -0,0 +1,332 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+)
+
+// modificationPlan holds tokens changed by modification before they are saved
+// Хранит токены, измененные модификацией, до их сохранения
+type modificationPlan struct {
+	// canceled keeps token state before cancellation, it tells which waits to release
+	// canceled хранит состояние токена до отмены, по нему определяется, какие ожидания снять
+	canceled      []*models.Token
+	canceledSaved []*models.Token
+	started       []*models.Token
+	patched       []*models.Token
+	variablesSet  bool
+}
+
+// ModifyProcessInstance applies batch of modification instructions to running instance
+// All instructions are validated against instance tokens and definition before any change.
+// Variables are set first, so started tokens see them. Instance and tokens are saved in one
+// transaction, then timers, jobs and subscriptions of canceled tokens are released and
+// started tokens are executed
+// Применяет пакет инструкций модификации к выполняющемуся экземпляру
+// Все инструкции проверяются по токенам экземпляра и определению до любых изменений.
+// Переменные устанавливаются первыми, поэтому запущенные токены их видят. Экземпляр и токены
+// сохраняются в одной транзакции, затем снимаются таймеры, job'ы и подписки отмененных
+// токенов и выполняются запущенные токены
+func (pim *ProcessInstanceManager) ModifyProcessInstance(
+	instanceID string,
+	instructions []models.ModificationInstruction,
+) (*models.ProcessInstanceModification, error) {
+	if !pim.component.IsReady() {
+		return nil, fmt.Errorf("process component not ready")
+	}
+	if len(instructions) == 0 {
+		return nil, fmt.Errorf("%w: no instructions given", models.ErrInvalidModification)
+	}
+
+	instance, plan, err := pim.applyModification(instanceID, instructions)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, token := range plan.canceled {
+		pim.releaseCanceledToken(token)
+	}
+
+	for _, token := range plan.started {
+		if err := pim.component.ExecuteToken(token); err != nil {
+			logger.Error("Failed to execute token started by modification",
+				logger.String("instance_id", instanceID),
+				logger.String("token_id", token.TokenID),
+				logger.String("element_id", token.CurrentElementID),
+				logger.String("error", err.Error()))
+		}
+	}
+
+	if plan.variablesSet {
+		// Variables are already applied to tokens, conditions see them without overlay
+		// Переменные уже применены к токенам, условия видят их без наложения
+		pim.component.EvaluateConditionalEvents(instanceID, nil)
+	}
+
+	result := &models.ProcessInstanceModification{
+		InstanceID:       instanceID,
+		CanceledTokenIDs: make([]string, 0, len(plan.canceled)),
+		StartedTokenIDs:  make([]string, 0, len(plan.started)),
+		Variables:        instance.Variables,
+	}
+	for _, token := range plan.canceled {
+		result.CanceledTokenIDs = append(result.CanceledTokenIDs, token.TokenID)
+	}
+	for _, token := range plan.started {
+		result.StartedTokenIDs = append(result.StartedTokenIDs, token.TokenID)
+	}
+
+	logger.Info("Process instance modified",
+		logger.String("instance_id", instanceID),
+		logger.Int("instructions", len(instructions)),
+		logger.Int("canceled_tokens", len(result.CanceledTokenIDs)),
+		logger.Int("started_tokens", len(result.StartedTokenIDs)),
+		logger.Bool("variables_set", plan.variablesSet))
+
+	return result, nil
+}
+
+// applyModification validates instructions and saves modified instance and tokens in one transaction
+// Nothing is saved when any instruction is invalid
+// Проверяет инструкции и сохраняет измененные экземпляр и токены в одной транзакции
+// При любой некорректной инструкции ничего не сохраняется
+func (pim *ProcessInstanceManager) applyModification(
+	instanceID string,
+	instructions []models.ModificationInstruction,
+) (*models.ProcessInstance, *modificationPlan, error) {
+	pim.variablesMu.Lock()
+	defer pim.variablesMu.Unlock()
+
+	instance, err := pim.storage.LoadProcessInstance(instanceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load process instance: %w", err)
+	}
+	if instance.IsCompleted() {
+		return nil, nil, fmt.Errorf("%w: %s is %s", models.ErrProcessInstanceFinished, instanceID, instance.State)
+	}
+
+	bpmnProcess, err := pim.processStarter.bpmnHelper.LoadBPMNProcess(instance.ProcessKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load process definition: %w", err)
+	}
+
+	tokens, err := pim.storage.LoadTokensByProcessInstance(instanceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load tokens: %w", err)
+	}
+
+	plan := &modificationPlan{}
+	canceled := make(map[string]bool)
+	var patches []map[string]interface{}
+	var startInstructions []models.ModificationInstruction
+
+	for i, instruction := range instructions {
+		switch instruction.Type {
+		case models.ModificationSetVariables:
+			if len(instruction.Variables) == 0 {
+				return nil, nil, fmt.Errorf("%w: instruction %d: variables are required",
+					models.ErrInvalidModification, i)
+			}
+			patches = append(patches, instruction.Variables)
+
+		case models.ModificationCancelToken:
+			matched, err := tokensToCancel(bpmnProcess, tokens, instruction)
+			if err != nil {
+				return nil, nil, fmt.Errorf("%w: instruction %d: %s", models.ErrInvalidModification, i, err.Error())
+			}
+			for _, token := range matched {
+				if canceled[token.TokenID] {
+					continue
+				}
+				canceled[token.TokenID] = true
+				previous := *token
+				plan.canceled = append(plan.canceled, &previous)
+				plan.canceledSaved = append(plan.canceledSaved, token)
+			}
+
+		case models.ModificationStartToken:
+			if err := checkTokenElement(bpmnProcess, instruction.ElementID); err != nil {
+				return nil, nil, fmt.Errorf("%w: instruction %d: %s", models.ErrInvalidModification, i, err.Error())
+			}
+			startInstructions = append(startInstructions, instruction)
+
+		default:
+			return nil, nil, fmt.Errorf("%w: instruction %d: unknown type %q",
+				models.ErrInvalidModification, i, instruction.Type)
+		}
+	}
+
+	remaining := 0
+	for _, token := range tokens {
+		if canceled[token.TokenID] || !isModifiableToken(token) {
+			continue
+		}
+		remaining++
+		if len(patches) > 0 && (token.IsActive() || token.IsWaiting()) {
+			plan.patched = append(plan.patched, token)
+		}
+	}
+	if remaining+len(startInstructions) == 0 {
+		return nil, nil, fmt.Errorf("%w: instance would be left without tokens, cancel instance instead",
+			models.ErrInvalidModification)
+	}
+
+	now := time.Now()
+	for _, patch := range patches {
+		instance.Variables = models.ApplyMergePatch(instance.Variables, patch)
+		for _, token := range plan.patched {
+			token.Variables = models.ApplyMergePatch(token.Variables, patch)
+			token.UpdatedAt = now
+		}
+	}
+	plan.variablesSet = len(patches) > 0
+	instance.UpdatedAt = now
+
+	for _, token := range plan.canceledSaved {
+		token.SetState(models.TokenStateCanceled)
+	}
+
+	for _, instruction := range startInstructions {
+		token := models.NewToken(instance.InstanceID, instance.ProcessKey, instruction.ElementID)
+		token.SetVariables(instance.Variables)
+		token.SetVariables(instruction.Variables)
+		token.TraceParent = instance.TraceParent
+		plan.started = append(plan.started, token)
+	}
+
+	changed := make([]*models.Token, 0, len(plan.patched)+len(plan.canceledSaved)+len(plan.started))
+	changed = append(changed, plan.patched...)
+	changed = append(changed, plan.canceledSaved...)
+	changed = append(changed, plan.started...)
+
+	if err := pim.storage.SaveProcessInstanceWithTokens(instance, changed); err != nil {
+		return nil, nil, fmt.Errorf("failed to save modified process instance: %w", err)
+	}
+	return instance, plan, nil
+}
+
+// tokensToCancel returns tokens matched by cancel instruction by token ID or element ID
+// Возвращает токены, выбранные инструкцией отмены по ID токена или ID элемента
+func tokensToCancel(
+	bpmnProcess *models.BPMNProcess,
+	tokens []*models.Token,
+	instruction models.ModificationInstruction,
+) ([]*models.Token, error) {
+	if (instruction.TokenID == "") == (instruction.ElementID == "") {
+		return nil, fmt.Errorf("exactly one of token_id and element_id is required")
+	}
+
+	var matched []*models.Token
+	for _, token := range tokens {
+		if instruction.TokenID != "" && token.TokenID != instruction.TokenID {
+			continue
+		}
+		if instruction.ElementID != "" && token.CurrentElementID != instruction.ElementID {
+			continue
+		}
+		if !isModifiableToken(token) {
+			if instruction.TokenID != "" {
+				return nil, fmt.Errorf("token %s is %s", token.TokenID, token.State)
+			}
+			continue
+		}
+		if err := checkCancelableElement(bpmnProcess, token.CurrentElementID); err != nil {
+			return nil, err
+		}
+		matched = append(matched, token)
+	}
+
+	if len(matched) == 0 {
+		if instruction.TokenID != "" {
+			return nil, fmt.Errorf("token %s not found in instance", instruction.TokenID)
+		}
+		return nil, fmt.Errorf("no active, waiting or failed token at element %s", instruction.ElementID)
+	}
+	return matched, nil
+}
+
+// checkCancelableElement checks token at element can be canceled without leaving scope waiting for it
+// Tokens of subprocesses and call activities are owned by their scope, so they are not canceled directly
+// Проверяет, что токен на элементе можно отменить, не оставив ожидающую его область
+// Токены подпроцессов и call activity принадлежат своей области, поэтому напрямую не отменяются
+func checkCancelableElement(bpmnProcess *models.BPMNProcess, elementID string) error {
+	element, exists := bpmnProcess.Elements[elementID].(map[string]interface{})
+	if !exists {
+		return fmt.Errorf("element %s not found in process %s", elementID, bpmnProcess.ProcessID)
+	}
+
+	elementType, _ := element["type"].(string)
+	if elementType == "callActivity" || elementType == "subProcess" {
+		return fmt.Errorf("token at %s %s cannot be canceled by modification", elementType, elementID)
+	}
+	if parentScope, _ := element["parent_scope"].(string); parentScope != "" && parentScope != bpmnProcess.ProcessID {
+		return fmt.Errorf("element %s is inside subprocess %s", elementID, parentScope)
+	}
+	return nil
+}
+
+// isModifiableToken reports whether token still holds position in instance
+// Failed token stays on element until its incident is resolved
+// Сообщает, занимает ли токен еще позицию в экземпляре
+// Упавший токен остается на элементе до разрешения инцидента
+func isModifiableToken(token *models.Token) bool {
+	return token.IsActive() || token.IsWaiting() || token.State == models.TokenStateFailed
+}
+
+// releaseCanceledToken cancels timers, job and subscriptions token held before cancellation
+// Errors are logged, token is already canceled in storage
+// Отменяет таймеры, job и подписки, которые токен держал до отмены
+// Ошибки логируются, токен уже отменен в storage
+func (pim *ProcessInstanceManager) releaseCanceledToken(previous *models.Token) {
+	if previous.HasBoundaryTimers() {
+		token, err := pim.storage.LoadToken(previous.TokenID)
+		if err == nil {
+			err = pim.component.CancelBoundaryTimers(token)
+		}
+		if err == nil {
+			err = pim.storage.UpdateToken(token)
+		}
+		if err != nil {
+			logger.Error("Failed to cancel boundary timers of canceled token",
+				logger.String("token_id", previous.TokenID),
+				logger.String("error", err.Error()))
+		}
+	}
+
+	if err := pim.component.CancelEventTimersForToken(previous.TokenID); err != nil {
+		logger.Error("Failed to cancel event timers of canceled token",
+			logger.String("token_id", previous.TokenID),
+			logger.String("error", err.Error()))
+	}
+
+	if strings.HasPrefix(previous.WaitingFor, "job:") {
+		jobID := strings.TrimPrefix(previous.WaitingFor, "job:")
+		if err := pim.component.CancelJobByID(jobID); err != nil {
+			logger.Error("Failed to cancel job of canceled token",
+				logger.String("token_id", previous.TokenID),
+				logger.String("job_id", jobID),
+				logger.String("error", err.Error()))
+		}
+	}
+
+	pim.component.RemoveErrorBoundariesForToken(previous.TokenID)
+	pim.component.UnsubscribeConditionsByToken(previous.TokenID)
+	if err := pim.component.UnsubscribeSignalsByToken(previous.TokenID); err != nil {
+		logger.Warn("Failed to remove signal subscriptions of canceled token",
+			logger.String("token_id", previous.TokenID),
+			logger.String("error", err.Error()))
+	}
+	pim.deleteMessageSubscriptionForToken(previous)
+}
@@ -0,0 +1,156 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"errors"
+	"testing"
+
+	"atom-engine/src/core/models"
+)
+
+// reviewApproveProcess runs review task, then approve task
+// Выполняет задачу проверки, затем задачу утверждения
+const reviewApproveProcess = `
+    <bpmn:startEvent id="start"><bpmn:outgoing>f1</bpmn:outgoing></bpmn:startEvent>
+    <bpmn:sequenceFlow id="f1" sourceRef="start" targetRef="review" />
+    <bpmn:serviceTask id="review">
+      <bpmn:extensionElements><zeebe:taskDefinition type="review-work" /></bpmn:extensionElements>
+      <bpmn:incoming>f1</bpmn:incoming><bpmn:outgoing>f2</bpmn:outgoing>
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="f2" sourceRef="review" targetRef="approve" />
+    <bpmn:serviceTask id="approve">
+      <bpmn:extensionElements><zeebe:taskDefinition type="approve-work" /></bpmn:extensionElements>
+      <bpmn:incoming>f2</bpmn:incoming><bpmn:outgoing>f3</bpmn:outgoing>
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="f3" sourceRef="approve" targetRef="end" />
+    <bpmn:endEvent id="end"><bpmn:incoming>f3</bpmn:incoming></bpmn:endEvent>`
+
+func TestModifyProcessInstanceMovesTokenWithVariables(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("modify-move", reviewApproveProcess))
+	instance := e.start(processID, map[string]interface{}{"amount": 100, "status": "new"})
+	review := e.waitJob(instance.InstanceID, "review")
+
+	var reviewToken string
+	for _, token := range e.tokens(instance.InstanceID) {
+		if token.CurrentElementID == "review" && token.IsWaiting() {
+			reviewToken = token.TokenID
+		}
+	}
+	if reviewToken == "" {
+		t.Fatal("no token waiting at review")
+	}
+
+	result, err := e.process.ModifyProcessInstance(instance.InstanceID, []models.ModificationInstruction{
+		{Type: models.ModificationCancelToken, ElementID: "review"},
+		{Type: models.ModificationStartToken, ElementID: "approve", Variables: map[string]interface{}{"note": "moved"}},
+		{Type: models.ModificationSetVariables,
+			Variables: map[string]interface{}{"status": "skipped-review", "amount": nil}},
+	})
+	if err != nil {
+		t.Fatalf("modify instance: %v", err)
+	}
+	if len(result.CanceledTokenIDs) != 1 || result.CanceledTokenIDs[0] != reviewToken {
+		t.Errorf("canceled tokens %v, want [%s]", result.CanceledTokenIDs, reviewToken)
+	}
+	if len(result.StartedTokenIDs) != 1 {
+		t.Fatalf("started tokens %v, want one", result.StartedTokenIDs)
+	}
+
+	// Merge patch sets status and removes amount
+	// Merge patch устанавливает status и удаляет amount
+	variables := e.instance(instance.InstanceID).Variables
+	if variables["status"] != "skipped-review" {
+		t.Errorf("instance status = %v, want skipped-review", variables["status"])
+	}
+	if _, exists := variables["amount"]; exists {
+		t.Errorf("amount not removed by merge patch: %v", variables)
+	}
+
+	for _, token := range e.tokens(instance.InstanceID) {
+		if token.TokenID == reviewToken && token.State != models.TokenStateCanceled {
+			t.Errorf("review token in state %s, want canceled", token.State)
+		}
+	}
+	e.waitFor("review job canceled", func() bool {
+		for _, job := range e.jobsOf(instance.InstanceID, "review") {
+			if job.Key == review.Key && isOpenJob(job) {
+				return false
+			}
+		}
+		return true
+	})
+	if activated := e.activate("review-work"); len(activated) != 0 {
+		t.Errorf("job of canceled token activated: %+v", activated)
+	}
+
+	approve := e.waitJob(instance.InstanceID, "approve")
+	jobVariables := workerVariables(approve.Variables)
+	if jobVariables["status"] != "skipped-review" || jobVariables["note"] != "moved" {
+		t.Errorf("approve job variables %v, want status skipped-review and note moved", jobVariables)
+	}
+	if _, exists := jobVariables["amount"]; exists {
+		t.Errorf("approve job sees removed amount: %v", jobVariables)
+	}
+
+	e.completeJob(approve, nil)
+	e.waitState(instance.InstanceID, models.ProcessInstanceStateCompleted)
+}
+
+func TestModifyProcessInstanceRejectsInvalidInstructionsWithoutChanges(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("modify-invalid", reviewApproveProcess))
+	instance := e.start(processID, map[string]interface{}{"status": "new"})
+	review := e.waitJob(instance.InstanceID, "review")
+
+	tests := []struct {
+		name         string
+		instructions []models.ModificationInstruction
+	}{
+		{"unknown target element", []models.ModificationInstruction{
+			{Type: models.ModificationSetVariables, Variables: map[string]interface{}{"status": "changed"}},
+			{Type: models.ModificationCancelToken, ElementID: "review"},
+			{Type: models.ModificationStartToken, ElementID: "missing"},
+		}},
+		{"no token at element", []models.ModificationInstruction{
+			{Type: models.ModificationSetVariables, Variables: map[string]interface{}{"status": "changed"}},
+			{Type: models.ModificationCancelToken, ElementID: "approve"},
+		}},
+		{"instance left without tokens", []models.ModificationInstruction{
+			{Type: models.ModificationCancelToken, ElementID: "review"},
+		}},
+		{"no instructions", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := e.process.ModifyProcessInstance(instance.InstanceID, tt.instructions)
+			if !errors.Is(err, models.ErrInvalidModification) {
+				t.Fatalf("error %v, want ErrInvalidModification", err)
+			}
+		})
+	}
+
+	if status := e.instance(instance.InstanceID).Variables["status"]; status != "new" {
+		t.Errorf("rejected modification changed status to %v", status)
+	}
+	for _, token := range e.tokens(instance.InstanceID) {
+		if token.CurrentElementID == "review" && !token.IsWaiting() {
+			t.Errorf("rejected modification changed review token to %s", token.State)
+		}
+	}
+	for _, job := range e.jobsOf(instance.InstanceID, "review") {
+		if job.Key == review.Key && !isOpenJob(job) {
+			t.Errorf("rejected modification canceled review job: %s", job.Status)
+		}
+	}
+}
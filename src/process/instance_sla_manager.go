@@ -0,0 +1,314 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/metrics"
+	"atom-engine/src/core/models"
+	"atom-engine/src/incidents"
+	"atom-engine/src/storage"
+	"atom-engine/src/timewheel"
+)
+
+// InstanceSLAManager watches deadlines process instances must finish by
+// SLA timer is registered at instance start and canceled when instance completes, fails or is canceled
+// Следит за сроками, до которых должны завершиться экземпляры процессов
+// Таймер SLA регистрируется при запуске экземпляра и отменяется при его завершении, ошибке или отмене
+type InstanceSLAManager struct {
+	storage    storage.Storage
+	component  ComponentInterface
+	core       CoreInterface
+	bpmnHelper *BPMNHelper
+	parser     *timewheel.ISO8601DurationParser
+}
+
+// NewInstanceSLAManager creates new instance SLA manager
+// Создает новый менеджер SLA экземпляров
+func NewInstanceSLAManager(storage storage.Storage, component ComponentInterface) *InstanceSLAManager {
+	return &InstanceSLAManager{
+		storage:    storage,
+		component:  component,
+		bpmnHelper: NewBPMNHelper(storage),
+		parser:     timewheel.NewISO8601DurationParser(),
+	}
+}
+
+// SetCore sets core interface for timer and incident management
+// Устанавливает интерфейс core для управления таймерами и инцидентами
+func (ism *InstanceSLAManager) SetCore(core CoreInterface) {
+	ism.core = core
+}
+
+// ScheduleInstanceSLA registers SLA timer of saved instance, positive sla overrides SLA of definition.
+// Nil bpmnProcess is loaded by process key of instance, instance without SLA is left unchanged
+// Регистрирует таймер SLA сохраненного экземпляра, положительный sla заменяет SLA определения
+// Nil bpmnProcess загружается по ключу процесса экземпляра, экземпляр без SLA остается без изменений
+func (ism *InstanceSLAManager) ScheduleInstanceSLA(
+	instance *models.ProcessInstance,
+	bpmnProcess *models.BPMNProcess,
+	sla time.Duration,
+) error {
+	if sla <= 0 {
+		var err error
+		if bpmnProcess == nil {
+			if bpmnProcess, err = ism.bpmnHelper.LoadBPMNProcess(instance.ProcessKey); err != nil {
+				return fmt.Errorf("failed to load process definition: %w", err)
+			}
+		}
+		if sla, err = ism.definitionSLA(bpmnProcess); err != nil {
+			return err
+		}
+		if sla <= 0 {
+			return nil
+		}
+	}
+	if ism.core == nil {
+		return fmt.Errorf("core interface not set")
+	}
+
+	recordBuilder, ok := ism.core.GetTimewheelComponentInterface().(interface {
+		NewTimerRecord(req *timewheel.TimerRequest, timerID string) *storage.TimerRecord
+	})
+	if !ok {
+		return fmt.Errorf("timewheel component does not support timer registration")
+	}
+
+	deadline := instance.StartedAt.Add(sla)
+	timeDate := deadline.UTC().Format(time.RFC3339Nano)
+	twRequest := timewheel.TimerRequest{
+		ElementID:         instance.ProcessID,
+		ProcessInstanceID: instance.InstanceID,
+		TimerType:         models.TimerTypeSLA,
+		TimeDate:          &timeDate,
+		ProcessContext: &models.TimerProcessContext{
+			ProcessKey:      instance.ProcessKey,
+			ProcessVersion:  instance.ProcessVersion,
+			ProcessName:     instance.ProcessName,
+			ComponentSource: "process",
+		},
+	}
+
+	// Instance references timer before timer exists, so fired timer always finds its instance
+	// Экземпляр ссылается на таймер до его появления, поэтому сработавший таймер всегда находит экземпляр
+	timerID := models.GenerateID()
+	instance.SLADeadline = &deadline
+	instance.SLATimerID = timerID
+	if err := ism.storage.UpdateProcessInstance(instance); err != nil {
+		instance.SLADeadline = nil
+		instance.SLATimerID = ""
+		return fmt.Errorf("failed to save SLA of process instance: %w", err)
+	}
+
+	if err := ism.storage.SaveTimer(recordBuilder.NewTimerRecord(&twRequest, timerID)); err != nil {
+		return fmt.Errorf("failed to save SLA timer: %w", err)
+	}
+
+	twRequest.RegisteredTimerID = &timerID
+	messageJSON, err := timewheel.CreateScheduleTimerMessage(twRequest)
+	if err != nil {
+		return fmt.Errorf("failed to create SLA timer message: %w", err)
+	}
+	if err := ism.processTimewheelMessage(messageJSON); err != nil {
+		if delErr := ism.storage.DeleteTimer(timerID); delErr != nil {
+			logger.Warn("Failed to delete record of unscheduled SLA timer",
+				logger.String("timer_id", timerID),
+				logger.String("error", delErr.Error()))
+		}
+		return fmt.Errorf("failed to schedule SLA timer: %w", err)
+	}
+
+	logger.Info("Process instance SLA scheduled",
+		logger.String("instance_id", instance.InstanceID),
+		logger.String("timer_id", timerID),
+		logger.String("sla", sla.String()),
+		logger.String("deadline", timeDate))
+	return nil
+}
+
+// CancelInstanceSLA cancels SLA timer of finished instance, fired and canceled timers are skipped
+// Отменяет таймер SLA завершенного экземпляра, сработавшие и отмененные таймеры пропускаются
+func (ism *InstanceSLAManager) CancelInstanceSLA(instance *models.ProcessInstance) {
+	if instance.SLATimerID == "" || instance.SLABreachedAt != nil {
+		return
+	}
+
+	timerRecord, err := ism.storage.LoadTimer(instance.SLATimerID)
+	if err != nil || timerRecord.State != "SCHEDULED" {
+		return
+	}
+
+	cancelMessage, err := timewheel.CreateCancelTimerMessage(instance.SLATimerID)
+	if err == nil {
+		err = ism.processTimewheelMessage(cancelMessage)
+	}
+	if err != nil {
+		logger.Warn("Failed to cancel SLA timer of finished process instance",
+			logger.String("instance_id", instance.InstanceID),
+			logger.String("timer_id", instance.SLATimerID),
+			logger.String("error", err.Error()))
+		return
+	}
+
+	logger.Debug("Process instance SLA timer canceled",
+		logger.String("instance_id", instance.InstanceID),
+		logger.String("timer_id", instance.SLATimerID))
+}
+
+// HandleSLATimerCallback records breach when instance is still running at SLA deadline.
+// Breach is marked on instance, raised as SLA_BREACH incident, counted in metrics and published to history
+// Фиксирует нарушение, если экземпляр все еще выполняется в срок SLA
+// Нарушение отмечается в экземпляре, создается инцидент SLA_BREACH, учитывается в метриках и публикуется в историю
+func (ism *InstanceSLAManager) HandleSLATimerCallback(timerID string, timerRecord *storage.TimerRecord) error {
+	instance, err := ism.storage.LoadProcessInstance(timerRecord.ProcessInstanceID)
+	if err != nil {
+		return fmt.Errorf("failed to load process instance %s: %w", timerRecord.ProcessInstanceID, err)
+	}
+
+	// Instances registered for message start never run, so only running instances breach SLA
+	// Экземпляры, зарегистрированные для стартового сообщения, не выполняются,
+	// поэтому SLA нарушают только выполняющиеся
+	running := instance.IsActive() || instance.State == models.ProcessInstanceStateSuspended
+	if !running || instance.SLATimerID != timerID || instance.SLABreachedAt != nil {
+		logger.Debug("SLA timer fired for process instance that is not running, ignored",
+			logger.String("instance_id", instance.InstanceID),
+			logger.String("timer_id", timerID),
+			logger.String("state", string(instance.State)))
+		return nil
+	}
+
+	now := time.Now()
+	instance.SLABreachedAt = &now
+	if err := ism.storage.UpdateProcessInstance(instance); err != nil {
+		return fmt.Errorf("failed to mark SLA breach of process instance: %w", err)
+	}
+
+	deadline := now
+	if instance.SLADeadline != nil {
+		deadline = *instance.SLADeadline
+	}
+	logger.Warn("Process instance SLA breached",
+		logger.String("instance_id", instance.InstanceID),
+		logger.String("process_id", instance.ProcessID),
+		logger.String("sla_deadline", deadline.UTC().Format(time.RFC3339)),
+		logger.String("running_for", now.Sub(instance.StartedAt).Round(time.Second).String()))
+
+	metrics.Processes.InstanceSLABreached(instance.ProcessID, "")
+
+	if err := ism.createSLAIncident(instance, deadline); err != nil {
+		logger.Error("Failed to create SLA breach incident",
+			logger.String("instance_id", instance.InstanceID),
+			logger.String("error", err.Error()))
+	}
+
+	if ism.core != nil {
+		event := models.NewProcessInstanceEvent(models.EngineEventProcessInstanceSLABreached, instance)
+		event.Data = map[string]interface{}{
+			"sla_deadline":    deadline.UTC().Format(time.RFC3339Nano),
+			"process_version": instance.ProcessVersion,
+		}
+		ism.core.PublishEngineEvent(event)
+	}
+	return nil
+}
+
+// createSLAIncident raises SLA_BREACH incident of instance
+// Создает инцидент SLA_BREACH экземпляра
+func (ism *InstanceSLAManager) createSLAIncident(instance *models.ProcessInstance, deadline time.Time) error {
+	if ism.core == nil {
+		return fmt.Errorf("core interface not set")
+	}
+
+	message := fmt.Sprintf("process instance did not finish before SLA deadline %s",
+		deadline.UTC().Format(time.RFC3339))
+	rootCause := &incidents.RootCause{
+		ElementID:    instance.ProcessID,
+		ElementType:  "process",
+		ErrorType:    incidents.RootCauseSLABreach,
+		ErrorMessage: message,
+	}
+	rootCause.AddEvent("instance_started", instance.StartedAt)
+	rootCause.AddEvent("sla_deadline", deadline)
+
+	incidentMessage, err := incidents.CreateIncidentMessage(incidents.CreateIncidentPayload{
+		Type:              string(incidents.IncidentTypeSLABreach),
+		Message:           message,
+		ProcessInstanceID: instance.InstanceID,
+		ProcessKey:        instance.ProcessKey,
+		ElementID:         instance.ProcessID,
+		ElementType:       "process",
+		RootCause:         rootCause,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create incident message: %w", err)
+	}
+	return ism.core.SendMessage("incidents", incidentMessage)
+}
+
+// definitionSLA returns SLA declared by "sla" extension property of process element, zero when absent
+// Возвращает SLA, объявленный свойством расширения "sla" элемента процесса, ноль при его отсутствии
+func (ism *InstanceSLAManager) definitionSLA(bpmnProcess *models.BPMNProcess) (time.Duration, error) {
+	processElement, ok := bpmnProcess.Elements[bpmnProcess.ProcessID].(map[string]interface{})
+	if !ok {
+		return 0, nil
+	}
+
+	value := strings.TrimSpace(extensionProperty(processElement, models.InstanceSLAProperty))
+	if value == "" {
+		return 0, nil
+	}
+	sla, err := ism.parser.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid SLA %q of process %s: %w", value, bpmnProcess.ProcessID, err)
+	}
+	return sla, nil
+}
+
+// processTimewheelMessage sends message to timewheel component
+// Отправляет сообщение в timewheel компонент
+func (ism *InstanceSLAManager) processTimewheelMessage(messageJSON string) error {
+	if ism.core == nil {
+		return fmt.Errorf("core interface not set")
+	}
+
+	processMsgMethod, ok := ism.core.GetTimewheelComponentInterface().(interface {
+		ProcessMessage(context.Context, string) error
+	})
+	if !ok {
+		return fmt.Errorf("timewheel component does not support ProcessMessage")
+	}
+	return processMsgMethod.ProcessMessage(context.Background(), messageJSON)
+}
+
+// extensionProperty returns value of zeebe:property with given name from element extensions
+// Возвращает значение zeebe:property с заданным именем из расширений элемента
+func extensionProperty(element map[string]interface{}, name string) string {
+	extensionElements, _ := element["extension_elements"].([]interface{})
+	for _, extElement := range extensionElements {
+		extElementMap, _ := extElement.(map[string]interface{})
+		extensions, _ := extElementMap["extensions"].([]interface{})
+		for _, ext := range extensions {
+			extMap, _ := ext.(map[string]interface{})
+			properties, _ := extMap["properties"].([]interface{})
+			for _, property := range properties {
+				propertyMap, _ := property.(map[string]interface{})
+				if propertyName, _ := propertyMap["name"].(string); propertyName == name {
+					value, _ := propertyMap["value"].(string)
+					return value
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,330 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"atom-engine/src/core/models"
+)
+
+// instanceDurationSamples is number of most recent completion durations p95 is computed over
+// Число последних длительностей завершения, по которым вычисляется p95
+const instanceDurationSamples = 1000
+
+// statisticsDayLayout formats UTC day of daily instance counts
+// Формат UTC дня дневных счетчиков экземпляров
+const statisticsDayLayout = "2006-01-02"
+
+// statisticsVersionKey identifies process definition version
+// Идентифицирует версию определения процесса
+type statisticsVersionKey struct {
+	processID string
+	version   int
+}
+
+// versionCounters holds instance statistics of one process definition version
+// Хранит статистику экземпляров одной версии определения процесса
+type versionCounters struct {
+	active        int64
+	completed     int64
+	canceled      int64
+	failed        int64
+	openIncidents int64
+
+	durationTotal time.Duration
+	durationCount int64
+	durations     []time.Duration // Ring of most recent completion durations
+	nextDuration  int
+
+	daily map[string]*models.DailyInstanceCounts
+}
+
+// InstanceStatistics counts instances per process definition version as they start and finish
+// Counters are rebuilt from storage once on start, requests never scan instances
+// Считает экземпляры по версиям определений процессов по мере их запуска и завершения
+// Счетчики восстанавливаются из storage один раз при старте, запросы не сканируют экземпляры
+type InstanceStatistics struct {
+	mu       sync.Mutex
+	versions map[statisticsVersionKey]*versionCounters
+}
+
+// NewInstanceStatistics creates empty instance statistics
+// Создает пустую статистику экземпляров
+func NewInstanceStatistics() *InstanceStatistics {
+	return &InstanceStatistics{
+		versions: make(map[statisticsVersionKey]*versionCounters),
+	}
+}
+
+// InstanceStarted counts started instance
+// Учитывает запущенный экземпляр
+func (s *InstanceStatistics) InstanceStarted(instance *models.ProcessInstance) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recordStarted(instance)
+}
+
+// InstanceFinished counts instance moved to completed, canceled or failed state
+// Учитывает экземпляр, перешедший в состояние completed, canceled или failed
+func (s *InstanceStatistics) InstanceFinished(instance *models.ProcessInstance) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counters := s.counters(instance)
+	if counters.active > 0 {
+		counters.active--
+	}
+	s.recordFinished(counters, instance)
+}
+
+// HandleEngineEvent counts opened and resolved incidents, events must carry process ID and version
+// Учитывает открытые и разрешенные инциденты, события должны содержать ID и версию процесса
+func (s *InstanceStatistics) HandleEngineEvent(event models.EngineEvent) {
+	var delta int64
+	switch event.Type {
+	case models.EngineEventIncidentCreated:
+		delta = 1
+	case models.EngineEventIncidentResolved:
+		delta = -1
+	default:
+		return
+	}
+	if event.ProcessID == "" {
+		return
+	}
+	version, _ := event.Data["process_version"].(int)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	counters := s.version(statisticsVersionKey{processID: event.ProcessID, version: version})
+	counters.openIncidents = max(counters.openIncidents+delta, 0)
+}
+
+// Restore replaces counters with ones computed from stored instances and open incident counts by instance ID
+// Заменяет счетчики вычисленными по сохраненным экземплярам и числу открытых инцидентов по ID экземпляра
+func (s *InstanceStatistics) Restore(instances []*models.ProcessInstance, openIncidents map[string]int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.versions = make(map[statisticsVersionKey]*versionCounters)
+
+	var completed []*models.ProcessInstance
+	for _, instance := range instances {
+		counters := s.recordStarted(instance)
+		counters.openIncidents += int64(openIncidents[instance.InstanceID])
+		if !instance.IsCompleted() {
+			continue
+		}
+		counters.active--
+		if instance.State == models.ProcessInstanceStateCompleted {
+			completed = append(completed, instance)
+			continue
+		}
+		s.recordFinished(counters, instance)
+	}
+
+	// Completed instances are replayed in completion order so duration ring keeps most recent ones
+	// Завершенные экземпляры воспроизводятся в порядке завершения, чтобы кольцо длительностей хранило последние
+	sort.Slice(completed, func(i, j int) bool {
+		return finishTime(completed[i]).Before(finishTime(completed[j]))
+	})
+	for _, instance := range completed {
+		s.recordFinished(s.counters(instance), instance)
+	}
+}
+
+// Snapshot returns statistics of process definition with daily counts of last days
+// Возвращает статистику определения процесса с дневными счетчиками за последние дни
+func (s *InstanceStatistics) Snapshot(processID string, days int, now time.Time) *models.ProcessStatistics {
+	days = min(max(days, 1), models.ProcessStatisticsMaxDays)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statistics := &models.ProcessStatistics{
+		ProcessID: processID,
+		Days:      days,
+		Versions:  []models.ProcessVersionStatistics{},
+	}
+
+	total := &versionCounters{daily: make(map[string]*models.DailyInstanceCounts)}
+	for key, counters := range s.versions {
+		if key.processID != processID {
+			continue
+		}
+		statistics.Versions = append(statistics.Versions, counters.snapshot(key.version, days, now))
+		total.merge(counters)
+	}
+	sort.Slice(statistics.Versions, func(i, j int) bool {
+		return statistics.Versions[i].Version < statistics.Versions[j].Version
+	})
+	statistics.Total = total.snapshot(0, days, now)
+	return statistics
+}
+
+// recordStarted counts instance as active and started on its start day, caller holds mu
+// Учитывает экземпляр как активный и запущенный в день старта, вызывающий держит mu
+func (s *InstanceStatistics) recordStarted(instance *models.ProcessInstance) *versionCounters {
+	counters := s.counters(instance)
+	counters.active++
+	counters.day(instance.StartedAt).Started++
+	return counters
+}
+
+// recordFinished counts final state of instance on its finish day, caller holds mu
+// Учитывает конечное состояние экземпляра в день завершения, вызывающий держит mu
+func (s *InstanceStatistics) recordFinished(counters *versionCounters, instance *models.ProcessInstance) {
+	finishedAt := finishTime(instance)
+	day := counters.day(finishedAt)
+
+	switch instance.State {
+	case models.ProcessInstanceStateCompleted:
+		counters.completed++
+		day.Completed++
+		counters.observeDuration(finishedAt.Sub(instance.StartedAt))
+	case models.ProcessInstanceStateCanceled:
+		counters.canceled++
+		day.Canceled++
+	case models.ProcessInstanceStateFailed:
+		counters.failed++
+		day.Failed++
+	}
+}
+
+// counters returns counters of instance version, caller holds mu
+// Возвращает счетчики версии экземпляра, вызывающий держит mu
+func (s *InstanceStatistics) counters(instance *models.ProcessInstance) *versionCounters {
+	return s.version(statisticsVersionKey{processID: instance.ProcessID, version: instance.ProcessVersion})
+}
+
+// version returns counters of version, creating them on first use, caller holds mu
+// Возвращает счетчики версии, создавая их при первом обращении, вызывающий держит mu
+func (s *InstanceStatistics) version(key statisticsVersionKey) *versionCounters {
+	counters, ok := s.versions[key]
+	if !ok {
+		counters = &versionCounters{daily: make(map[string]*models.DailyInstanceCounts)}
+		s.versions[key] = counters
+	}
+	return counters
+}
+
+// finishTime returns completion time of finished instance, update time when it is not recorded
+// Возвращает время завершения экземпляра, время обновления если оно не записано
+func finishTime(instance *models.ProcessInstance) time.Time {
+	if instance.CompletedAt != nil {
+		return *instance.CompletedAt
+	}
+	return instance.UpdatedAt
+}
+
+// day returns daily counts of UTC day of time, days beyond retention are dropped on new day
+// Возвращает дневные счетчики UTC дня времени, дни за пределами хранения удаляются при новом дне
+func (vc *versionCounters) day(t time.Time) *models.DailyInstanceCounts {
+	date := t.UTC().Format(statisticsDayLayout)
+	counts, ok := vc.daily[date]
+	if ok {
+		return counts
+	}
+
+	counts = &models.DailyInstanceCounts{Date: date}
+	vc.daily[date] = counts
+
+	cutoff := time.Now().UTC().AddDate(0, 0, -models.ProcessStatisticsMaxDays).Format(statisticsDayLayout)
+	for existing := range vc.daily {
+		if existing < cutoff {
+			delete(vc.daily, existing)
+		}
+	}
+	return counts
+}
+
+// observeDuration adds completion duration to average and p95 samples
+// Добавляет длительность завершения к среднему и выборке p95
+func (vc *versionCounters) observeDuration(duration time.Duration) {
+	vc.durationTotal += duration
+	vc.durationCount++
+
+	if len(vc.durations) < instanceDurationSamples {
+		vc.durations = append(vc.durations, duration)
+		return
+	}
+	vc.durations[vc.nextDuration] = duration
+	vc.nextDuration = (vc.nextDuration + 1) % instanceDurationSamples
+}
+
+// merge adds counters of version to aggregate
+// Добавляет счетчики версии к агрегату
+func (vc *versionCounters) merge(other *versionCounters) {
+	vc.active += other.active
+	vc.completed += other.completed
+	vc.canceled += other.canceled
+	vc.failed += other.failed
+	vc.openIncidents += other.openIncidents
+	vc.durationTotal += other.durationTotal
+	vc.durationCount += other.durationCount
+	vc.durations = append(vc.durations, other.durations...)
+
+	for date, counts := range other.daily {
+		merged, ok := vc.daily[date]
+		if !ok {
+			merged = &models.DailyInstanceCounts{Date: date}
+			vc.daily[date] = merged
+		}
+		merged.Started += counts.Started
+		merged.Completed += counts.Completed
+		merged.Canceled += counts.Canceled
+		merged.Failed += counts.Failed
+	}
+}
+
+// snapshot returns statistics of counters with daily counts of last days up to now, oldest first
+// Возвращает статистику счетчиков с дневными счетчиками последних дней до now, от старых к новым
+func (vc *versionCounters) snapshot(version, days int, now time.Time) models.ProcessVersionStatistics {
+	statistics := models.ProcessVersionStatistics{
+		Version:       version,
+		Active:        vc.active,
+		Completed:     vc.completed,
+		Canceled:      vc.canceled,
+		Failed:        vc.failed,
+		OpenIncidents: vc.openIncidents,
+		Daily:         make([]models.DailyInstanceCounts, 0, days),
+	}
+
+	if vc.durationCount > 0 {
+		statistics.AvgDuration = vc.durationTotal / time.Duration(vc.durationCount)
+	}
+	if len(vc.durations) > 0 {
+		sorted := append([]time.Duration(nil), vc.durations...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+		statistics.P95Duration = sorted[int(math.Ceil(0.95*float64(len(sorted))))-1]
+	}
+
+	var completed, finished int64
+	today := now.UTC()
+	for i := days - 1; i >= 0; i-- {
+		date := today.AddDate(0, 0, -i).Format(statisticsDayLayout)
+		counts := models.DailyInstanceCounts{Date: date}
+		if stored, ok := vc.daily[date]; ok {
+			counts = *stored
+		}
+		statistics.Daily = append(statistics.Daily, counts)
+
+		completed += counts.Completed
+		finished += counts.Completed + counts.Canceled + counts.Failed
+	}
+	if finished > 0 {
+		statistics.CompletionRate = float64(completed) / float64(finished)
+	}
+	return statistics
+}
@@ -51,32 +51,24 @@ func (icee *IntermediateCatchEventExecutor) Execute(
 
 	// Check for event definitions to determine event type
 	eventDefinitions, hasEventDefs := element["event_definitions"]
-	logger.Info("DEBUG: Checking event definitions",
-		logger.String("token_id", token.TokenID),
-		logger.String("element_id", token.CurrentElementID),
-		logger.Bool("has_event_defs", hasEventDefs))
 	if hasEventDefs {
 		if eventDefList, ok := eventDefinitions.([]interface{}); ok {
-			logger.Info("DEBUG: Found event definitions list",
-				logger.String("token_id", token.TokenID),
-				logger.Int("count", len(eventDefList)))
+			log := icee.processComponent.TokenLogger(token)
 			for i, eventDef := range eventDefList {
 				if eventDefMap, ok := eventDef.(map[string]interface{}); ok {
 					eventType, _ := eventDefMap["type"].(string)
-					logger.Info("DEBUG: Processing event definition",
-						logger.String("token_id", token.TokenID),
+					log.Debug("Processing event definition",
+						logger.String("element_id", token.CurrentElementID),
 						logger.Int("index", i),
 						logger.String("event_type", eventType))
 
 					// Handle timer events
 					if eventType == "timerEventDefinition" {
-						logger.Info("DEBUG: Handling timer event", logger.String("token_id", token.TokenID))
 						return icee.timerHandler.HandleTimerEvent(token, element, eventDefMap)
 					}
 
 					// Handle message events
 					if eventType == "messageEventDefinition" {
-						logger.Info("DEBUG: Handling message event", logger.String("token_id", token.TokenID))
 						return icee.messageHandler.HandleMessageEvent(token, element, eventDefMap)
 					}
 
@@ -84,6 +76,11 @@ func (icee *IntermediateCatchEventExecutor) Execute(
 					if eventType == "signalEventDefinition" {
 						return icee.handleSignalEvent(token, element, eventDefMap)
 					}
+
+					// Handle conditional events
+					if eventType == "conditionalEventDefinition" {
+						return icee.handleConditionalEvent(token, element)
+					}
 				}
 			}
 		}
@@ -163,6 +160,49 @@ func (icee *IntermediateCatchEventExecutor) handleSignalEvent(
 	return icee.handleDefaultEvent(token, element)
 }
 
+// handleConditionalEvent proceeds when condition holds, otherwise waits until
+// variable change of instance makes it true
+// Продолжает выполнение, если условие выполнено, иначе ожидает, пока
+// изменение переменных экземпляра не сделает его истинным
+func (icee *IntermediateCatchEventExecutor) handleConditionalEvent(
+	token *models.Token,
+	element map[string]interface{},
+) (*ExecutionResult, error) {
+	condition, _ := conditionalEventCondition(element)
+
+	satisfied, err := evaluateEventCondition(icee.processComponent, condition, token.Variables)
+	if err != nil {
+		return &ExecutionResult{
+			Success:   false,
+			Error:     fmt.Sprintf("failed to evaluate condition: %v", err),
+			Completed: false,
+		}, fmt.Errorf("failed to evaluate condition of %s: %w", token.CurrentElementID, err)
+	}
+
+	if satisfied {
+		logger.Info("Conditional event condition satisfied, proceeding",
+			logger.String("token_id", token.TokenID),
+			logger.String("element_id", token.CurrentElementID),
+			logger.String("condition", condition))
+		return icee.handleDefaultEvent(token, element)
+	}
+
+	icee.processComponent.SubscribeToCondition(&ConditionSubscription{
+		ProcessInstanceID: token.ProcessInstanceID,
+		TokenID:           token.TokenID,
+		ElementID:         token.CurrentElementID,
+		Condition:         condition,
+	})
+
+	return &ExecutionResult{
+		Success:      true,
+		TokenUpdated: false,
+		NextElements: []string{},
+		WaitingFor:   conditionWaitingPrefix + token.CurrentElementID,
+		Completed:    false,
+	}, nil
+}
+
 // handleDefaultEvent handles default intermediate catch event (no specific event definition)
 // Обрабатывает default промежуточное catch событие (без specific event definition)
 func (icee *IntermediateCatchEventExecutor) handleDefaultEvent(
@@ -254,7 +254,7 @@ func (icth *IntermediateCatchTimerHandler) evaluateTimerExpression(
 		logger.String("token_id", token.TokenID),
 		logger.String("original_expression", expression),
 		logger.Any("evaluated_result", result),
-		logger.Any("token_variables", token.Variables))
+		logger.Variables("token_variables", token.Variables))
 
 	return result, nil
 }
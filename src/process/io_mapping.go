@@ -0,0 +1,217 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"fmt"
+	"strings"
+
+	"atom-engine/src/core/logger"
+)
+
+// IOMapping is single zeebe:input or zeebe:output mapping of element
+// Одиночный маппинг zeebe:input или zeebe:output элемента
+type IOMapping struct {
+	Source string
+	Target string
+}
+
+// MappingError is failure of single input or output mapping, keeps mapping for incident root cause
+// Ошибка отдельного input или output маппинга, хранит маппинг для первопричины инцидента
+type MappingError struct {
+	Direction string // "input" or "output"
+	Mapping   IOMapping
+	Err       error
+}
+
+// Error returns mapping error message
+// Возвращает сообщение ошибки маппинга
+func (e *MappingError) Error() string {
+	return fmt.Sprintf("%s mapping %s -> %s: %v", e.Direction, e.Mapping.Source, e.Mapping.Target, e.Err)
+}
+
+// Unwrap returns cause of mapping error
+// Возвращает причину ошибки маппинга
+func (e *MappingError) Unwrap() error {
+	return e.Err
+}
+
+// extractIOMappings returns mappings of direction "inputs" or "outputs" from element ioMapping extension
+// Возвращает маппинги направления "inputs" или "outputs" из расширения ioMapping элемента
+func extractIOMappings(element map[string]interface{}, direction string) []IOMapping {
+	extensionElements, ok := element["extension_elements"].([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var mappings []IOMapping
+	for _, extElement := range extensionElements {
+		extElementMap, ok := extElement.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		extensions, ok := extElementMap["extensions"].([]interface{})
+		if !ok {
+			continue
+		}
+
+		for _, ext := range extensions {
+			extMap, ok := ext.(map[string]interface{})
+			if !ok || extMap["type"] != "ioMapping" {
+				continue
+			}
+			ioMapping, ok := extMap["io_mapping"].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			items, ok := ioMapping[direction].([]interface{})
+			if !ok {
+				continue
+			}
+
+			for _, item := range items {
+				itemMap, ok := item.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				source, _ := itemMap["source"].(string)
+				target, _ := itemMap["target"].(string)
+				if source == "" || target == "" {
+					logger.Warn("IO mapping without source or target skipped",
+						logger.String("direction", direction),
+						logger.Any("mapping", itemMap))
+					continue
+				}
+				mappings = append(mappings, IOMapping{Source: source, Target: target})
+			}
+		}
+	}
+	return mappings
+}
+
+// applyInputMappings computes variables exposed to job from process variables using element input mappings
+// Вычисляет переменные, передаваемые job'у, из переменных процесса по input маппингам элемента
+func applyInputMappings(
+	component ComponentInterface,
+	mappings []IOMapping,
+	processVariables map[string]interface{},
+) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(mappings))
+	for _, mapping := range mappings {
+		value, err := evaluateMappingSource(component, mapping.Source, processVariables)
+		if err != nil {
+			return nil, &MappingError{Direction: "input", Mapping: mapping, Err: err}
+		}
+		if err := setVariablePath(result, nil, mapping.Target, value); err != nil {
+			return nil, &MappingError{Direction: "input", Mapping: mapping, Err: err}
+		}
+	}
+	return result, nil
+}
+
+// applyOutputMappings computes process variables from job result using element output mappings
+// Sources are evaluated against process variables overlaid with job variables,
+// only mapped targets are returned, job variables are not merged as is
+// Вычисляет переменные процесса из результата job'а по output маппингам элемента
+// Источники вычисляются по переменным процесса, перекрытым переменными job'а,
+// возвращаются только цели маппинга, переменные job'а как есть не объединяются
+func applyOutputMappings(
+	component ComponentInterface,
+	mappings []IOMapping,
+	processVariables, jobVariables map[string]interface{},
+) (map[string]interface{}, error) {
+	context := make(map[string]interface{}, len(processVariables)+len(jobVariables))
+	for key, value := range processVariables {
+		context[key] = value
+	}
+	for key, value := range jobVariables {
+		context[key] = value
+	}
+
+	result := make(map[string]interface{}, len(mappings))
+	for _, mapping := range mappings {
+		value, err := evaluateMappingSource(component, mapping.Source, context)
+		if err != nil {
+			return nil, &MappingError{Direction: "output", Mapping: mapping, Err: err}
+		}
+		if err := setVariablePath(result, processVariables, mapping.Target, value); err != nil {
+			return nil, &MappingError{Direction: "output", Mapping: mapping, Err: err}
+		}
+	}
+	return result, nil
+}
+
+// evaluateMappingSource evaluates FEEL source starting with "=", other sources are string literals
+// Вычисляет FEEL источник, начинающийся с "=", остальные источники - строковые литералы
+func evaluateMappingSource(
+	component ComponentInterface,
+	source string,
+	variables map[string]interface{},
+) (interface{}, error) {
+	if !strings.HasPrefix(source, "=") {
+		return source, nil
+	}
+	if component == nil || component.GetCore() == nil {
+		return nil, fmt.Errorf("core not available for mapping evaluation")
+	}
+
+	type ExpressionEvaluator interface {
+		EvaluateExpressionEngine(expression interface{}, variables map[string]interface{}) (interface{}, error)
+	}
+
+	evaluator, ok := component.GetCore().GetExpressionComponent().(ExpressionEvaluator)
+	if !ok {
+		return nil, fmt.Errorf("expression component not available for mapping evaluation")
+	}
+
+	result, err := evaluator.EvaluateExpressionEngine(source, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate %s: %w", source, err)
+	}
+	return result, nil
+}
+
+// setVariablePath sets value at dotted target path like "order.status"
+// Nested target is merged into copy of existing object variable, so sibling fields are kept
+// Устанавливает значение по пути цели через точку, например "order.status"
+// Вложенная цель объединяется с копией существующей переменной-объекта, соседние поля сохраняются
+func setVariablePath(result, existing map[string]interface{}, path string, value interface{}) error {
+	parts := strings.Split(path, ".")
+	for _, part := range parts {
+		if part == "" {
+			return fmt.Errorf("invalid target %q", path)
+		}
+	}
+
+	current := result
+	for i, part := range parts[:len(parts)-1] {
+		var source map[string]interface{}
+		if child, present := current[part]; present {
+			object, ok := child.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("target %q is not an object", strings.Join(parts[:i+1], "."))
+			}
+			source = object
+		} else if i == 0 {
+			source, _ = existing[part].(map[string]interface{})
+		}
+
+		// Objects are copied on the way down, existing variables are never modified in place
+		// Объекты копируются при спуске, существующие переменные не изменяются на месте
+		next := make(map[string]interface{}, len(source)+1)
+		for key, val := range source {
+			next[key] = val
+		}
+		current[part] = next
+		current = next
+	}
+
+	current[parts[len(parts)-1]] = value
+	return nil
+}
@@ -0,0 +1,271 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"atom-engine/src/core/models"
+)
+
+// mappedTaskProcess has service task with given ioMapping body
+// Сервисная задача с указанным телом ioMapping
+func mappedTaskProcess(ioMapping string) string {
+	return `
+    <bpmn:startEvent id="start"><bpmn:outgoing>f1</bpmn:outgoing></bpmn:startEvent>
+    <bpmn:sequenceFlow id="f1" sourceRef="start" targetRef="task" />
+    <bpmn:serviceTask id="task">
+      <bpmn:extensionElements>
+        <zeebe:taskDefinition type="mapped-work" />
+        ` + ioMapping + `
+      </bpmn:extensionElements>
+      <bpmn:incoming>f1</bpmn:incoming><bpmn:outgoing>f2</bpmn:outgoing>
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="f2" sourceRef="task" targetRef="end" />
+    <bpmn:endEvent id="end"><bpmn:incoming>f2</bpmn:incoming></bpmn:endEvent>`
+}
+
+// orderVariables are process variables of mapping tests
+// Переменные процесса тестов маппинга
+func orderVariables() map[string]interface{} {
+	return map[string]interface{}{
+		"order":  map[string]interface{}{"id": 7, "total": 10},
+		"secret": "x",
+	}
+}
+
+// workerVariables drops engine internal variables like _tokenID from job variables
+// Удаляет внутренние переменные движка, например _tokenID, из переменных job'а
+func workerVariables(variables map[string]interface{}) map[string]interface{} {
+	visible := make(map[string]interface{}, len(variables))
+	for name, value := range variables {
+		if !strings.HasPrefix(name, "_") {
+			visible[name] = value
+		}
+	}
+	return visible
+}
+
+// jsonOf normalizes value through JSON for comparison
+// Нормализует значение через JSON для сравнения
+func jsonOf(t *testing.T, value interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(value)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	return string(data)
+}
+
+func TestServiceTaskInputMappingsComputeJobVariables(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("input-mapping", mappedTaskProcess(`
+        <zeebe:ioMapping>
+          <zeebe:input source="=order.total" target="amount" />
+          <zeebe:input source="=order.total &gt; 5" target="large" />
+          <zeebe:input source="=order.id" target="ref.id" />
+          <zeebe:input source="express" target="mode" />
+        </zeebe:ioMapping>`)))
+	instance := e.start(processID, orderVariables())
+	e.waitJob(instance.InstanceID, "task")
+
+	activated := e.activate("mapped-work")
+	if len(activated) != 1 {
+		t.Fatalf("activated %d jobs, want 1", len(activated))
+	}
+
+	want := map[string]interface{}{
+		"amount": 10,
+		"large":  true,
+		"ref":    map[string]interface{}{"id": 7},
+		"mode":   "express",
+	}
+	if got := jsonOf(t, workerVariables(activated[0].Variables)); got != jsonOf(t, want) {
+		t.Fatalf("job variables = %s, want %s", got, jsonOf(t, want))
+	}
+
+	// Process variables are untouched by input mappings
+	// Input маппинги не изменяют переменные процесса
+	token := e.tokens(instance.InstanceID)[0]
+	if _, ok := token.Variables["amount"]; ok {
+		t.Errorf("input mapping leaked into process variables: %v", token.Variables)
+	}
+	if _, ok := token.Variables["secret"]; !ok {
+		t.Errorf("process variable dropped by input mapping: %v", token.Variables)
+	}
+}
+
+func TestServiceTaskInputMappingsEvaluatedAtJobCreation(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("input-mapping-creation", mappedTaskProcess(`
+        <zeebe:ioMapping>
+          <zeebe:input source="=order.total" target="amount" />
+        </zeebe:ioMapping>`)))
+	instance := e.start(processID, orderVariables())
+	e.waitJob(instance.InstanceID, "task")
+
+	// Variables changed after job creation are not seen by created job
+	// Переменные, измененные после создания job'а, не видны созданному job'у
+	token := e.tokens(instance.InstanceID)[0]
+	token.Variables["order"] = map[string]interface{}{"id": 7, "total": 99}
+	if err := e.storage.UpdateToken(token); err != nil {
+		t.Fatalf("update token: %v", err)
+	}
+
+	activated := e.activate("mapped-work")
+	if len(activated) != 1 {
+		t.Fatalf("activated %d jobs, want 1", len(activated))
+	}
+	if got := jsonOf(t, workerVariables(activated[0].Variables)); got != `{"amount":10}` {
+		t.Fatalf("job variables = %s, want values at job creation", got)
+	}
+}
+
+func TestServiceTaskWithoutInputMappingsGetsAllVariables(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("no-input-mapping", mappedTaskProcess("")))
+	instance := e.start(processID, orderVariables())
+	e.waitJob(instance.InstanceID, "task")
+
+	activated := e.activate("mapped-work")
+	if len(activated) != 1 {
+		t.Fatalf("activated %d jobs, want 1", len(activated))
+	}
+	for _, name := range []string{"order", "secret"} {
+		if _, ok := activated[0].Variables[name]; !ok {
+			t.Errorf("job without input mappings misses variable %s: %v", name, activated[0].Variables)
+		}
+	}
+}
+
+func TestApplyInputMappingsComputedParameters(t *testing.T) {
+	e := newTestEngine(t)
+
+	tests := []struct {
+		name     string
+		mappings []IOMapping
+		want     string
+	}{
+		{"path keeps number type", []IOMapping{{Source: "=order.total", Target: "amount"}}, `{"amount":10}`},
+		{"comparison", []IOMapping{{Source: "=order.total > 5", Target: "large"}}, `{"large":true}`},
+		{"whole object", []IOMapping{{Source: "=order", Target: "payload"}}, `{"payload":{"id":7,"total":10}}`},
+		{"constant", []IOMapping{{Source: "express", Target: "mode"}}, `{"mode":"express"}`},
+		{"nested target", []IOMapping{
+			{Source: "=order.id", Target: "ref.id"},
+			{Source: "=secret", Target: "ref.code"},
+		}, `{"ref":{"code":"x","id":7}}`},
+		{"no mappings", nil, `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyInputMappings(e.process, tt.mappings, orderVariables())
+			if err != nil {
+				t.Fatalf("apply input mappings: %v", err)
+			}
+			if gotJSON := jsonOf(t, got); gotJSON != tt.want {
+				t.Fatalf("got %s, want %s", gotJSON, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyInputMappingsInvalidTarget(t *testing.T) {
+	e := newTestEngine(t)
+
+	_, err := applyInputMappings(e.process, []IOMapping{
+		{Source: "=order.total", Target: "amount"},
+		{Source: "=order.total", Target: "bad..target"},
+	}, orderVariables())
+	if err == nil {
+		t.Fatal("expected error for invalid target")
+	}
+}
+
+func TestServiceTaskOutputMappingsMapWorkerResultIntoOrderStatus(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("output-mapping", mappedTaskProcess(`
+        <zeebe:ioMapping>
+          <zeebe:output source="=result" target="order.status" />
+          <zeebe:output source="=carrier" target="shipping" />
+        </zeebe:ioMapping>`)))
+	instance := e.start(processID, orderVariables())
+	job := e.waitJob(instance.InstanceID, "task")
+
+	e.completeJob(job, map[string]interface{}{"result": "shipped", "carrier": "DHL", "extra": 1})
+	e.waitState(instance.InstanceID, models.ProcessInstanceStateCompleted)
+
+	// Worker result lands in order.status, other order fields are kept, unmapped job variables are dropped
+	// Результат worker'а попадает в order.status, остальные поля заказа сохраняются,
+	// переменные job'а без маппинга отбрасываются
+	var variables map[string]interface{}
+	for _, token := range e.tokens(instance.InstanceID) {
+		if token.CurrentElementID == "end" {
+			variables = workerVariables(token.Variables)
+		}
+	}
+	want := map[string]interface{}{
+		"order":    map[string]interface{}{"id": 7, "total": 10, "status": "shipped"},
+		"secret":   "x",
+		"shipping": "DHL",
+	}
+	if got := jsonOf(t, variables); got != jsonOf(t, want) {
+		t.Fatalf("process variables = %s, want %s", got, jsonOf(t, want))
+	}
+}
+
+func TestApplyOutputMappingsJobResult(t *testing.T) {
+	e := newTestEngine(t)
+
+	tests := []struct {
+		name     string
+		mappings []IOMapping
+		result   map[string]interface{}
+		want     string
+	}{
+		{"result into nested status", []IOMapping{{Source: "=result", Target: "order.status"}},
+			map[string]interface{}{"result": "shipped"}, `{"order":{"id":7,"status":"shipped","total":10}}`},
+		{"job variable overrides process variable", []IOMapping{{Source: "=secret", Target: "code"}},
+			map[string]interface{}{"secret": "y"}, `{"code":"y"}`},
+		{"process variable when job omits it", []IOMapping{{Source: "=order.total", Target: "amount"}},
+			map[string]interface{}{}, `{"amount":10}`},
+		{"constant", []IOMapping{{Source: "done", Target: "order.status"}},
+			nil, `{"order":{"id":7,"status":"done","total":10}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applyOutputMappings(e.process, tt.mappings, orderVariables(), tt.result)
+			if err != nil {
+				t.Fatalf("apply output mappings: %v", err)
+			}
+			if gotJSON := jsonOf(t, got); gotJSON != tt.want {
+				t.Fatalf("got %s, want %s", gotJSON, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyOutputMappingsInvalidTarget(t *testing.T) {
+	e := newTestEngine(t)
+
+	_, err := applyOutputMappings(e.process, []IOMapping{{Source: "=result", Target: "order..status"}},
+		orderVariables(), map[string]interface{}{"result": "shipped"})
+	var mappingErr *MappingError
+	if !errors.As(err, &mappingErr) || mappingErr.Direction != "output" {
+		t.Fatalf("error %v, want output mapping error", err)
+	}
+}
@@ -9,10 +9,15 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package process
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 
 	"atom-engine/src/core/logger"
 	"atom-engine/src/core/models"
@@ -27,8 +32,15 @@ type JobCallbacks struct {
 	component      ComponentInterface
 	core           CoreInterface
 	callbackHelper *CallbackHelper
+
+	// Serialize callbacks of same token so concurrent duplicates cannot both move it
+	// Сериализуют callbacks одного токена, чтобы параллельные дубликаты не сдвинули его дважды
+	tokenLocks [tokenLockStripes]sync.Mutex
 }
 
+// tokenLockStripes is number of locks tokens are hashed to
+const tokenLockStripes = 64
+
 // NewJobCallbacks creates new job callbacks handler
 // Создает новый обработчик callbacks jobs
 func NewJobCallbacks(storage storage.Storage, component ComponentInterface) *JobCallbacks {
@@ -52,16 +64,66 @@ func (jc *JobCallbacks) Init() error {
 	return nil
 }
 
-// HandleJobCallback handles job completion callback
-// Обрабатывает callback завершения job
+// HandleJobCallback handles job completion callback exactly once per callback ID.
+// Duplicate callback and callback for token that already moved on are no-ops
+// Обрабатывает callback завершения job ровно один раз для каждого ID callback
+// Дубликат и callback для уже продвинувшегося токена ничего не делают
 func (jc *JobCallbacks) HandleJobCallback(
-	jobID, elementID, tokenID, status, errorMessage string,
+	callbackID, jobID, elementID, tokenID, status, errorMessage string,
 	variables map[string]interface{},
 ) error {
 	if !jc.component.IsReady() {
 		return fmt.Errorf("process component not ready")
 	}
 
+	unlock := jc.lockToken(tokenID)
+	defer unlock()
+
+	// Callbacks from intents written before callback IDs have no ID and are not deduplicated
+	// Callbacks из намерений, записанных до появления ID, не имеют ID и не дедуплицируются
+	if callbackID != "" {
+		processed, err := jc.storage.IsCallbackProcessed(callbackID)
+		if err != nil {
+			return fmt.Errorf("failed to check job callback %s: %w", callbackID, err)
+		}
+		if processed {
+			logger.Info("Duplicate job callback ignored",
+				logger.String("callback_id", callbackID),
+				logger.String("job_id", jobID),
+				logger.String("token_id", tokenID))
+			return nil
+		}
+	}
+
+	err := jc.handleJobCallback(jobID, elementID, tokenID, status, errorMessage, variables)
+	if errors.Is(err, ErrTokenNotWaiting) {
+		logger.Info("Job callback for token no longer waiting ignored",
+			logger.String("callback_id", callbackID),
+			logger.String("job_id", jobID),
+			logger.String("token_id", tokenID),
+			logger.String("status", status))
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if callbackID != "" {
+		if err := jc.storage.MarkCallbackProcessed(callbackID); err != nil {
+			logger.Warn("Failed to mark job callback processed",
+				logger.String("callback_id", callbackID),
+				logger.String("error", err.Error()))
+		}
+	}
+	return nil
+}
+
+// handleJobCallback applies job callback to token
+// Применяет callback job'а к токену
+func (jc *JobCallbacks) handleJobCallback(
+	jobID, elementID, tokenID, status, errorMessage string,
+	variables map[string]interface{},
+) error {
 	logger.Info("Handling job callback",
 		logger.String("job_id", jobID),
 		logger.String("element_id", elementID),
@@ -105,8 +167,104 @@ func (jc *JobCallbacks) HandleJobCallback(
 		}
 	}
 
+	// Output mappings of element decide which variables of job result reach process
+	// Output маппинги элемента определяют, какие переменные результата job'а попадают в процесс
+	variables, err = jc.mapJobOutputs(token, elementID, variables)
+	if err != nil {
+		return jc.handleOutputMappingFailure(token, jobID, elementID, err)
+	}
+
 	// Process successful completion callback and continue execution using helper
-	return jc.callbackHelper.ProcessCallbackAndContinue(token, elementID, variables)
+	if err := jc.callbackHelper.ProcessCallbackAndContinue(token, elementID, variables); err != nil {
+		return err
+	}
+
+	// Job result may satisfy conditional events waiting in other branches of instance
+	// Результат job'а может выполнить условные события, ожидающие в других ветках экземпляра
+	if len(variables) > 0 {
+		jc.component.EvaluateConditionalEvents(token.ProcessInstanceID, variables)
+	}
+	return nil
+}
+
+// lockToken locks stripe of token and returns unlock function
+// Блокирует полосу токена и возвращает функцию разблокировки
+func (jc *JobCallbacks) lockToken(tokenID string) func() {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(tokenID))
+	mu := &jc.tokenLocks[h.Sum32()%tokenLockStripes]
+	mu.Lock()
+	return mu.Unlock
+}
+
+// mapJobOutputs applies output mappings of job element to job variables
+// Variables are returned unchanged when element has no output mappings
+// Применяет output маппинги элемента job'а к переменным job'а
+// Если у элемента нет output маппингов, переменные возвращаются без изменений
+func (jc *JobCallbacks) mapJobOutputs(
+	token *models.Token,
+	elementID string,
+	variables map[string]interface{},
+) (map[string]interface{}, error) {
+	currentElementID := token.CurrentElementID
+	if currentElementID == "" {
+		currentElementID = elementID
+	}
+
+	elements, err := jc.callbackHelper.GetBPMNHelper().LoadProcessElements(token.ProcessKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load element for output mapping: %w", err)
+	}
+	element, ok := elements[currentElementID].(map[string]interface{})
+	if !ok {
+		return variables, nil
+	}
+
+	mappings := extractIOMappings(element, "outputs")
+	if len(mappings) == 0 {
+		return variables, nil
+	}
+
+	mapped, err := applyOutputMappings(jc.component, mappings, token.Variables, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Job output mappings applied",
+		logger.String("token_id", token.TokenID),
+		logger.String("element_id", currentElementID),
+		logger.Int("mappings", len(mappings)))
+	return mapped, nil
+}
+
+// handleOutputMappingFailure creates expression incident and fails token when output mapping fails
+// Создает инцидент выражения и переводит токен в failed при ошибке output маппинга
+func (jc *JobCallbacks) handleOutputMappingFailure(
+	token *models.Token,
+	jobID, elementID string,
+	mappingErr error,
+) error {
+	logger.Error("Job output mapping failed",
+		logger.String("token_id", token.TokenID),
+		logger.String("job_id", jobID),
+		logger.String("element_id", elementID),
+		logger.String("error", mappingErr.Error()))
+
+	if err := jc.createOutputMappingIncident(token, jobID, elementID, mappingErr); err != nil {
+		logger.Error("Failed to create output mapping incident",
+			logger.String("token_id", token.TokenID),
+			logger.String("job_id", jobID),
+			logger.String("error", err.Error()))
+	}
+
+	token.SetState(models.TokenStateFailed)
+	if err := jc.storage.UpdateToken(token); err != nil {
+		logger.Error("Failed to update token after output mapping failure",
+			logger.String("token_id", token.TokenID),
+			logger.String("error", err.Error()))
+	}
+
+	return fmt.Errorf("job output mapping failed: %w", mappingErr)
 }
 
 // handleJobFailure handles job failure and checks for error boundary events
@@ -301,6 +459,9 @@ func (jc *JobCallbacks) extractBPMNErrorCode(variables map[string]interface{}, e
 				if numVal, ok := value.(float64); ok {
 					return fmt.Sprintf("%.0f", numVal)
 				}
+				if intVal, ok := value.(int64); ok {
+					return fmt.Sprintf("%d", intVal)
+				}
 			}
 		}
 	}
@@ -325,10 +486,11 @@ func (jc *JobCallbacks) activateErrorBoundaryFlow(
 	// Continue execution with outgoing flows from error boundary event
 	if len(errorBoundary.OutgoingFlows) > 0 {
 		// Use execution processor to continue with next elements
+		// Token still stands on failed activity, so boundary flows are passed explicitly
+		// Токен все еще стоит на упавшей activity, поэтому потоки граничного события передаются явно
 		if jc.component != nil {
-			// Get the engine from component to access execution processor
-			// Use the callback helper to continue execution
-			return jc.callbackHelper.ProcessCallbackAndContinue(originalToken, errorBoundary.ElementID, variables)
+			return jc.callbackHelper.ProcessCallbackAndContinueWithFlows(originalToken, errorBoundary.OutgoingFlows,
+				variables)
 		}
 	}
 
@@ -442,7 +604,10 @@ func (jc *JobCallbacks) handleJobBPMNError(
 		errorVariables["errorCode"] = errorCode
 		errorVariables["errorMessage"] = errorMessage
 
-		return jc.callbackHelper.ProcessCallbackAndContinue(originalToken, errorBoundary.ElementID, errorVariables)
+		// Token still stands on failed task, continuing from it would take task's normal flow
+		// Токен все еще стоит на упавшей задаче, продолжение с нее пошло бы по обычному потоку задачи
+		return jc.callbackHelper.ProcessCallbackAndContinueWithFlows(originalToken, errorBoundary.OutgoingFlows,
+			errorVariables)
 	}
 
 	logger.Info("Error boundary event has no outgoing flows, process ends",
@@ -580,6 +745,8 @@ func (jc *JobCallbacks) createJobFailureIncident(token *models.Token, jobID, ele
 		return fmt.Errorf("incidents component not available")
 	}
 
+	rootCause := jc.jobRootCause(jobID, elementID, incidents.RootCauseJobFailure, errorMessage)
+
 	payload := incidents.CreateIncidentPayload{
 		Type:              "job_failure",
 		Message:           errorMessage,
@@ -587,7 +754,10 @@ func (jc *JobCallbacks) createJobFailureIncident(token *models.Token, jobID, ele
 		ElementID:         elementID,
 		ElementType:       "serviceTask", // Service task element type
 		JobKey:            jobID,
+		JobType:           rootCause.JobType,
+		WorkerID:          rootCause.WorkerID,
 		OriginalRetries:   0,
+		RootCause:         rootCause,
 	}
 
 	message, err := incidents.CreateIncidentMessage(payload)
@@ -610,6 +780,84 @@ func (jc *JobCallbacks) createJobFailureIncident(token *models.Token, jobID, ele
 	return nil
 }
 
+// createOutputMappingIncident creates expression incident for failed output mapping
+func (jc *JobCallbacks) createOutputMappingIncident(
+	token *models.Token,
+	jobID, elementID string,
+	mappingErr error,
+) error {
+	if jc.core == nil {
+		return fmt.Errorf("core interface not available")
+	}
+
+	rootCause := jc.jobRootCause(jobID, elementID, incidents.RootCauseOutputMapping, mappingErr.Error())
+	var mappingError *MappingError
+	if errors.As(mappingErr, &mappingError) {
+		rootCause.Expression = mappingError.Mapping.Source
+		rootCause.Target = mappingError.Mapping.Target
+		rootCause.ErrorMessage = mappingError.Err.Error()
+	}
+	rootCause.AddEvent("output_mapping_failed", time.Now())
+
+	payload := incidents.CreateIncidentPayload{
+		Type:              "expression_error",
+		Message:           mappingErr.Error(),
+		ProcessInstanceID: token.ProcessInstanceID,
+		ProcessKey:        token.ProcessKey,
+		ElementID:         elementID,
+		ElementType:       "serviceTask",
+		JobKey:            jobID,
+		JobType:           rootCause.JobType,
+		WorkerID:          rootCause.WorkerID,
+		RootCause:         rootCause,
+	}
+
+	message, err := incidents.CreateIncidentMessage(payload)
+	if err != nil {
+		return fmt.Errorf("failed to create incident message: %w", err)
+	}
+
+	if err := jc.core.SendMessage("incidents", message); err != nil {
+		return fmt.Errorf("failed to create output mapping incident: %w", err)
+	}
+	return nil
+}
+
+// jobRootCause builds root cause of incident raised by job, job context is best effort
+// Строит первопричину инцидента, вызванного job'ом, контекст job'а заполняется по возможности
+func (jc *JobCallbacks) jobRootCause(jobID, elementID, errorType, errorMessage string) *incidents.RootCause {
+	rootCause := &incidents.RootCause{
+		ElementID:    elementID,
+		ElementType:  "serviceTask",
+		ErrorType:    errorType,
+		ErrorMessage: errorMessage,
+		JobKey:       jobID,
+	}
+
+	job, err := jc.storage.GetJob(context.Background(), jobID)
+	if err != nil {
+		logger.Warn("Failed to load job for incident root cause",
+			logger.String("job_id", jobID),
+			logger.String("error", err.Error()))
+		return rootCause
+	}
+
+	rootCause.JobType = job.Type
+	rootCause.WorkerID = job.WorkerID
+	rootCause.Retries = job.MaxRetries
+	rootCause.AddEvent("job_created", job.CreatedAt)
+	if job.StartedAt != nil {
+		rootCause.AddEvent("job_activated", *job.StartedAt)
+	}
+	switch {
+	case job.Status == models.JobStatusFailed:
+		rootCause.AddEvent("job_failed", job.UpdatedAt)
+	case job.CompletedAt != nil:
+		rootCause.AddEvent("job_completed", *job.CompletedAt)
+	}
+	return rootCause
+}
+
 // createBPMNErrorIncident creates incident for unhandled BPMN error
 func (jc *JobCallbacks) createBPMNErrorIncident(token *models.Token, elementID, errorCode, errorMessage string) error {
 	if jc.component == nil {
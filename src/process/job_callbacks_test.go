@@ -0,0 +1,311 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"atom-engine/src/core/models"
+	"atom-engine/src/incidents"
+	"atom-engine/src/jobs"
+	"atom-engine/src/storage"
+)
+
+// callbackCountingStorage counts callback IDs marked processed
+// Считает ID callback'ов, отмеченные как обработанные
+type callbackCountingStorage struct {
+	storage.Storage
+	marked atomic.Int32
+}
+
+func (s *callbackCountingStorage) MarkCallbackProcessed(callbackID string) error {
+	s.marked.Add(1)
+	return s.Storage.MarkCallbackProcessed(callbackID)
+}
+
+// twoTaskProcess has two service tasks in sequence, so double move of token creates extra job
+// Две сервисные задачи подряд, поэтому двойное перемещение токена создает лишний job
+const twoTaskProcess = `
+    <bpmn:startEvent id="start"><bpmn:outgoing>f1</bpmn:outgoing></bpmn:startEvent>
+    <bpmn:sequenceFlow id="f1" sourceRef="start" targetRef="first" />
+    <bpmn:serviceTask id="first">
+      <bpmn:extensionElements><zeebe:taskDefinition type="first-work" /></bpmn:extensionElements>
+      <bpmn:incoming>f1</bpmn:incoming><bpmn:outgoing>f2</bpmn:outgoing>
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="f2" sourceRef="first" targetRef="second" />
+    <bpmn:serviceTask id="second">
+      <bpmn:extensionElements><zeebe:taskDefinition type="second-work" /></bpmn:extensionElements>
+      <bpmn:incoming>f2</bpmn:incoming><bpmn:outgoing>f3</bpmn:outgoing>
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="f3" sourceRef="second" targetRef="end" />
+    <bpmn:endEvent id="end"><bpmn:incoming>f3</bpmn:incoming></bpmn:endEvent>`
+
+func TestHandleJobCallbackConcurrentDuplicateMovesTokenOnce(t *testing.T) {
+	counting := &callbackCountingStorage{}
+	e := newTestEngine(t, withStorageWrapper(func(st storage.Storage) storage.Storage {
+		counting.Storage = st
+		return counting
+	}))
+
+	processID := e.deploy(bpmnDefinitions("duplicate-callback", twoTaskProcess))
+	instance := e.start(processID, nil)
+	job := e.waitJob(instance.InstanceID, "first")
+
+	tokens := e.tokens(instance.InstanceID)
+	if len(tokens) != 1 {
+		t.Fatalf("expected one token, got %d", len(tokens))
+	}
+	tokenID := tokens[0].TokenID
+	callbackID := fmt.Sprintf("%d:1", job.NumericKey)
+
+	const duplicates = 2
+	var wg sync.WaitGroup
+	errs := make([]error, duplicates)
+	start := make(chan struct{})
+	for i := 0; i < duplicates; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs[i] = e.process.HandleJobCallback(callbackID, job.Key, "first", tokenID, "COMPLETED", "",
+				map[string]interface{}{"result": "ok"})
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("callback %d returned error: %v", i, err)
+		}
+	}
+
+	e.waitJob(instance.InstanceID, "second")
+	if secondJobs := e.jobsOf(instance.InstanceID, "second"); len(secondJobs) != 1 {
+		t.Fatalf("token moved %d times past first task, want exactly once", len(secondJobs))
+	}
+	if marked := counting.marked.Load(); marked != 1 {
+		t.Errorf("callback marked processed %d times, want 1", marked)
+	}
+
+	processed, err := e.storage.IsCallbackProcessed(callbackID)
+	if err != nil || !processed {
+		t.Errorf("callback %s not recorded as processed: %v", callbackID, err)
+	}
+
+	// Late duplicate after token moved on is still a no-op
+	// Поздний дубликат после продвижения токена также ничего не делает
+	if err := e.process.HandleJobCallback(callbackID, job.Key, "first", tokenID, "COMPLETED", "", nil); err != nil {
+		t.Errorf("late duplicate returned error: %v", err)
+	}
+	if secondJobs := e.jobsOf(instance.InstanceID, "second"); len(secondJobs) != 1 {
+		t.Fatalf("late duplicate moved token again, %d jobs at second task", len(secondJobs))
+	}
+	if marked := counting.marked.Load(); marked != 1 {
+		t.Errorf("late duplicate marked callback again, %d marks", marked)
+	}
+}
+
+func TestHandleJobCallbackForTokenNoLongerWaitingIsNoOp(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("stale-callback", twoTaskProcess))
+	instance := e.start(processID, nil)
+	first := e.waitJob(instance.InstanceID, "first")
+	e.completeJob(first, nil)
+	e.waitJob(instance.InstanceID, "second")
+
+	tokenID := e.tokens(instance.InstanceID)[0].TokenID
+
+	// Different callback ID of already completed job, e.g. worker retry after engine restart
+	// Другой ID callback'а уже завершенного job'а, например повтор worker'а после перезапуска
+	err := e.process.HandleJobCallback(fmt.Sprintf("%d:99", first.NumericKey), first.Key, "first", tokenID,
+		"COMPLETED", "", nil)
+	if err != nil {
+		t.Fatalf("stale callback returned error: %v", err)
+	}
+	if secondJobs := e.jobsOf(instance.InstanceID, "second"); len(secondJobs) != 1 {
+		t.Fatalf("stale callback moved token, %d jobs at second task", len(secondJobs))
+	}
+}
+
+// chargeProcess catches PAYMENT_FAILED error of charge task with error boundary event
+// Перехватывает ошибку PAYMENT_FAILED задачи charge граничным событием ошибки
+const chargeProcess = `<?xml version="1.0" encoding="UTF-8"?>
+<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL"
+  xmlns:zeebe="http://camunda.org/schema/zeebe/1.0"
+  id="Definitions_charge" targetNamespace="http://bpmn.io/schema/bpmn">
+  <bpmn:process id="charge-error-boundary" isExecutable="true">
+    <bpmn:startEvent id="start"><bpmn:outgoing>f1</bpmn:outgoing></bpmn:startEvent>
+    <bpmn:sequenceFlow id="f1" sourceRef="start" targetRef="charge" />
+    <bpmn:serviceTask id="charge">
+      <bpmn:extensionElements><zeebe:taskDefinition type="charge" retries="3" /></bpmn:extensionElements>
+      <bpmn:incoming>f1</bpmn:incoming><bpmn:outgoing>f2</bpmn:outgoing>
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="f2" sourceRef="charge" targetRef="charged" />
+    <bpmn:endEvent id="charged"><bpmn:incoming>f2</bpmn:incoming></bpmn:endEvent>
+    <bpmn:boundaryEvent id="paymentFailed" attachedToRef="charge">
+      <bpmn:outgoing>f3</bpmn:outgoing>
+      <bpmn:errorEventDefinition id="paymentFailedDefinition" errorRef="Error_payment" />
+    </bpmn:boundaryEvent>
+    <bpmn:sequenceFlow id="f3" sourceRef="paymentFailed" targetRef="declined" />
+    <bpmn:endEvent id="declined"><bpmn:incoming>f3</bpmn:incoming></bpmn:endEvent>
+  </bpmn:process>
+  <bpmn:error id="Error_payment" name="Payment failed" errorCode="PAYMENT_FAILED" />
+</bpmn:definitions>`
+
+// incidentsOf returns incidents of process instance
+// Возвращает инциденты экземпляра процесса
+func (e *testEngine) incidentsOf(instanceID string) []*incidents.Incident {
+	e.t.Helper()
+
+	found, _, err := e.incidents.ListIncidents(context.Background(),
+		&incidents.IncidentFilter{ProcessInstanceID: instanceID})
+	if err != nil {
+		e.t.Fatalf("list incidents of %s: %v", instanceID, err)
+	}
+	return found
+}
+
+// failJob activates job and fails it with error code as worker would
+// Активирует job и проваливает его с кодом ошибки, как это сделал бы worker
+func (e *testEngine) failJob(job jobs.JobInfo, retries int, errorCode string) {
+	e.t.Helper()
+
+	e.activate(job.Type)
+	if err := e.jobs.FailJobWithErrorCode(job.Key, retries, "card declined", errorCode, 0); err != nil {
+		e.t.Fatalf("fail job %s: %v", job.Key, err)
+	}
+}
+
+func TestFailJobWithErrorCodeCaughtByErrorBoundaryWhenRetriesExhausted(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(chargeProcess)
+	instance := e.start(processID, nil)
+	job := e.waitJob(instance.InstanceID, "charge")
+
+	// Error code is ignored while retries are left, job is retried
+	// Код ошибки игнорируется, пока остаются повторы, job повторяется
+	e.failJob(job, 1, "PAYMENT_FAILED")
+	e.waitFor("job scheduled for retry", func() bool {
+		for _, current := range e.jobsOf(instance.InstanceID, "charge") {
+			if current.Key == job.Key && current.Retries == 1 {
+				return current.Status == string(models.JobStatusPending) ||
+					current.Status == string(models.JobStatusDeferred)
+			}
+		}
+		return false
+	})
+	if e.tokenAt(instance.InstanceID, "declined") != nil {
+		t.Fatalf("error boundary taken while job had retries left")
+	}
+
+	e.failJob(job, 0, "PAYMENT_FAILED")
+	e.waitState(instance.InstanceID, models.ProcessInstanceStateCompleted)
+
+	if e.tokenAt(instance.InstanceID, "declined") == nil {
+		t.Errorf("instance did not take error boundary path")
+	}
+	if e.tokenAt(instance.InstanceID, "charged") != nil {
+		t.Errorf("failed task continued to normal path")
+	}
+	if found := e.incidentsOf(instance.InstanceID); len(found) != 0 {
+		t.Errorf("%d incidents for caught error, first %+v", len(found), found[0])
+	}
+}
+
+func TestFailJobWithUnmatchedErrorCodeCreatesUnhandledErrorIncident(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(chargeProcess)
+	instance := e.start(processID, nil)
+	job := e.waitJob(instance.InstanceID, "charge")
+
+	e.failJob(job, 0, "FRAUD_SUSPECTED")
+
+	var found []*incidents.Incident
+	e.waitFor("unhandled error incident", func() bool {
+		found = e.incidentsOf(instance.InstanceID)
+		return len(found) == 1
+	})
+	if found[0].ErrorCode != "FRAUD_SUSPECTED" || !strings.HasPrefix(found[0].Message, "UNHANDLED_BPMN_ERROR") {
+		t.Errorf("incident error code %q, message %q, want unhandled FRAUD_SUSPECTED error",
+			found[0].ErrorCode, found[0].Message)
+	}
+	if e.tokenAt(instance.InstanceID, "declined") != nil {
+		t.Errorf("error boundary taken for unmatched error code")
+	}
+}
+
+func TestJobFailureIncidentCapturesRootCause(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("failing-job", twoTaskProcess))
+	instance := e.start(processID, nil)
+	job := e.waitJob(instance.InstanceID, "first")
+
+	e.activate(job.Type)
+	if err := e.jobs.FailJob(job.Key, 0, "payment gateway unreachable"); err != nil {
+		t.Fatalf("fail job: %v", err)
+	}
+
+	var found []*incidents.Incident
+	e.waitFor("job failure incident", func() bool {
+		found = e.incidentsOf(instance.InstanceID)
+		return len(found) == 1
+	})
+
+	// Root cause survives storage round trip used by GET /incidents/:id
+	// Первопричина сохраняется при чтении из storage, используемом GET /incidents/:id
+	incident, err := e.incidents.GetIncident(context.Background(), found[0].ID)
+	if err != nil {
+		t.Fatalf("get incident: %v", err)
+	}
+	rootCause := incident.RootCause
+	if rootCause == nil {
+		t.Fatalf("incident %s has no root cause", incident.ID)
+	}
+
+	want := incidents.RootCause{
+		ElementID:    "first",
+		ElementType:  "serviceTask",
+		ErrorType:    incidents.RootCauseJobFailure,
+		ErrorMessage: "payment gateway unreachable",
+		JobKey:       job.Key,
+		JobType:      "first-work",
+		WorkerID:     "test-worker",
+		Retries:      job.Retries,
+	}
+	got := *rootCause
+	got.Timeline = nil
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("root cause %+v, want %+v", got, want)
+	}
+	if incident.JobType != "first-work" || incident.WorkerID != "test-worker" || incident.ElementID != "first" {
+		t.Errorf("incident job type %q, worker %q, element %q", incident.JobType, incident.WorkerID, incident.ElementID)
+	}
+
+	events := make([]string, 0, len(rootCause.Timeline))
+	for i, event := range rootCause.Timeline {
+		events = append(events, event.Event)
+		if i > 0 && event.Timestamp.Before(rootCause.Timeline[i-1].Timestamp) {
+			t.Errorf("timeline event %s precedes %s", event.Event, rootCause.Timeline[i-1].Event)
+		}
+	}
+	if strings.Join(events, ",") != "job_created,job_activated,job_failed,incident_created" {
+		t.Errorf("timeline %v, want job created, activated, failed and incident created", events)
+	}
+}
@@ -0,0 +1,192 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+)
+
+// variablePathPattern matches plain variable reference like orderId or order.customer.id
+// Соответствует простой ссылке на переменную, например orderId или order.customer.id
+var variablePathPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`)
+
+// messageDefinition holds BPMN message referenced by catch element
+// Содержит BPMN сообщение, на которое ссылается catch элемент
+type messageDefinition struct {
+	Name           string
+	CorrelationKey string // literal key or FEEL expression starting with "="
+}
+
+// findMessageDefinition looks up message by ID, then by name, in process elements
+// Ищет сообщение по ID, затем по имени, в элементах процесса
+func findMessageDefinition(elements map[string]interface{}, messageRef string) (*messageDefinition, bool) {
+	if messageRef == "" {
+		return nil, false
+	}
+
+	if element, ok := elements[messageRef].(map[string]interface{}); ok && element["type"] == "message" {
+		return newMessageDefinition(element, messageRef), true
+	}
+
+	for _, item := range elements {
+		element, ok := item.(map[string]interface{})
+		if !ok || element["type"] != "message" {
+			continue
+		}
+		if name, _ := element["name"].(string); name == messageRef {
+			return newMessageDefinition(element, messageRef), true
+		}
+	}
+	return nil, false
+}
+
+func newMessageDefinition(element map[string]interface{}, messageRef string) *messageDefinition {
+	name, _ := element["name"].(string)
+	if name == "" {
+		name = messageRef
+	}
+	return &messageDefinition{
+		Name:           name,
+		CorrelationKey: subscriptionCorrelationKey(element),
+	}
+}
+
+// subscriptionCorrelationKey extracts zeebe:subscription correlationKey from message element
+// Извлекает correlationKey из zeebe:subscription элемента сообщения
+func subscriptionCorrelationKey(element map[string]interface{}) string {
+	extensionElements, _ := element["extension_elements"].([]interface{})
+	for _, item := range extensionElements {
+		extensionElement, _ := item.(map[string]interface{})
+		extensions, _ := extensionElement["extensions"].([]interface{})
+		for _, ext := range extensions {
+			extension, _ := ext.(map[string]interface{})
+			if extension["type"] != "subscription" {
+				continue
+			}
+			attributes, _ := extension["attributes"].(map[string]interface{})
+			if key, ok := attributes["correlationKey"].(string); ok {
+				return key
+			}
+		}
+	}
+	return ""
+}
+
+// resolveCorrelationKey derives subscription correlation key from token variables
+// Literal keys are returned unchanged, "=" expressions are evaluated once at subscription time
+// Вычисляет correlation key подписки из переменных токена
+// Литеральные ключи возвращаются без изменений, выражения "=" вычисляются один раз при создании подписки
+func resolveCorrelationKey(component ComponentInterface, correlationKey string, token *models.Token) (string, error) {
+	if !strings.HasPrefix(correlationKey, "=") {
+		return correlationKey, nil
+	}
+
+	expression := strings.TrimSpace(correlationKey[1:])
+	if expression == "" {
+		return "", fmt.Errorf("correlation key expression is empty")
+	}
+
+	// Quoted string literal, e.g. ="order-1"
+	// Строковый литерал в кавычках, например ="order-1"
+	if len(expression) >= 2 && strings.HasPrefix(expression, `"`) && strings.HasSuffix(expression, `"`) {
+		return expression[1 : len(expression)-1], nil
+	}
+
+	// Plain variable path is resolved directly so missing variables are reported instead of used as literal
+	// Простой путь к переменной разрешается напрямую, чтобы отсутствующая переменная была ошибкой, а не литералом
+	if variablePathPattern.MatchString(expression) {
+		value, found := lookupVariablePath(token.Variables, expression)
+		if !found {
+			return "", fmt.Errorf("correlation key variable %s not found", expression)
+		}
+		return formatCorrelationKey(expression, value)
+	}
+
+	value, err := evaluateCorrelationExpression(component, correlationKey, token.Variables)
+	if err != nil {
+		return "", err
+	}
+	if value == "null" {
+		value = nil
+	}
+	return formatCorrelationKey(expression, value)
+}
+
+// lookupVariablePath resolves dotted path through nested variable maps
+// Разрешает путь через точку по вложенным картам переменных
+func lookupVariablePath(variables map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = variables
+	for _, segment := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = object[segment]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// formatCorrelationKey converts evaluated value into string key, only strings and numbers are allowed
+// Преобразует вычисленное значение в строковый ключ, допустимы только строки и числа
+func formatCorrelationKey(expression string, value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32), nil
+	case int, int32, int64, uint, uint32, uint64:
+		return fmt.Sprintf("%d", v), nil
+	case nil:
+		return "", fmt.Errorf("correlation key expression %s evaluated to null", expression)
+	default:
+		return "", fmt.Errorf("correlation key expression %s evaluated to %T, expected string or number",
+			expression, value)
+	}
+}
+
+// evaluateCorrelationExpression evaluates FEEL expression using expression component
+// Вычисляет FEEL выражение через expression компонент
+func evaluateCorrelationExpression(
+	component ComponentInterface,
+	expression string,
+	variables map[string]interface{},
+) (interface{}, error) {
+	if component == nil || component.GetCore() == nil {
+		return nil, fmt.Errorf("core not available for correlation key evaluation")
+	}
+
+	type ExpressionEvaluator interface {
+		EvaluateExpressionEngine(expression interface{}, variables map[string]interface{}) (interface{}, error)
+	}
+
+	evaluator, ok := component.GetCore().GetExpressionComponent().(ExpressionEvaluator)
+	if !ok {
+		return nil, fmt.Errorf("expression component not available for correlation key evaluation")
+	}
+
+	result, err := evaluator.EvaluateExpressionEngine(expression, variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate correlation key expression %s: %w", expression, err)
+	}
+
+	logger.Debug("Correlation key expression evaluated",
+		logger.String("expression", expression),
+		logger.Any("result", result))
+	return result, nil
+}
@@ -0,0 +1,135 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"context"
+	"testing"
+
+	"atom-engine/src/core/models"
+)
+
+// paymentProcess waits for payment message correlated by nested customer ID of order
+// Ожидает сообщение об оплате, коррелируемое по вложенному ID клиента заказа
+const paymentProcess = `<?xml version="1.0" encoding="UTF-8"?>
+<bpmn:definitions xmlns:bpmn="http://www.omg.org/spec/BPMN/20100524/MODEL"
+  xmlns:zeebe="http://camunda.org/schema/zeebe/1.0"
+  id="Definitions_nested_correlation" targetNamespace="http://bpmn.io/schema/bpmn">
+  <bpmn:process id="nested-correlation" isExecutable="true">
+    <bpmn:startEvent id="start"><bpmn:outgoing>f1</bpmn:outgoing></bpmn:startEvent>
+    <bpmn:sequenceFlow id="f1" sourceRef="start" targetRef="payment" />
+    <bpmn:intermediateCatchEvent id="payment">
+      <bpmn:incoming>f1</bpmn:incoming>
+      <bpmn:outgoing>f2</bpmn:outgoing>
+      <bpmn:messageEventDefinition id="payment_def" messageRef="Message_payment" />
+    </bpmn:intermediateCatchEvent>
+    <bpmn:sequenceFlow id="f2" sourceRef="payment" targetRef="end" />
+    <bpmn:endEvent id="end"><bpmn:incoming>f2</bpmn:incoming></bpmn:endEvent>
+  </bpmn:process>
+  <bpmn:message id="Message_payment" name="payment-received">
+    <bpmn:extensionElements>
+      <zeebe:subscription correlationKey="=order.customer.id" />
+    </bpmn:extensionElements>
+  </bpmn:message>
+</bpmn:definitions>`
+
+// waitSubscription waits for message subscription of instance and returns it
+// Ожидает подписку на сообщение экземпляра и возвращает ее
+func (e *testEngine) waitSubscription(instanceID string) *models.ProcessMessageSubscription {
+	e.t.Helper()
+
+	var found *models.ProcessMessageSubscription
+	e.waitFor("message subscription of "+instanceID, func() bool {
+		subscriptions, err := e.messages.ListMessageSubscriptions(context.Background(), models.DefaultTenantID, 100, 0)
+		if err != nil {
+			e.t.Fatalf("list subscriptions: %v", err)
+		}
+		for _, subscription := range subscriptions {
+			if subscription.ProcessInstanceID == instanceID {
+				found = subscription
+				return true
+			}
+		}
+		return false
+	})
+	return found
+}
+
+func TestMessageCorrelationKeyFromNestedVariable(t *testing.T) {
+	e := newTestEngine(t)
+	processID := e.deploy(paymentProcess)
+
+	tests := []struct {
+		name     string
+		customer interface{}
+		wantKey  string
+	}{
+		{"string customer id", "C-42", "C-42"},
+		{"numeric customer id", float64(1042), "1042"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := e.start(processID, map[string]interface{}{
+				"order": map[string]interface{}{
+					"customer": map[string]interface{}{"id": tt.customer},
+				},
+			})
+
+			// Subscription stores key resolved from variables, not expression
+			// Подписка хранит ключ, вычисленный из переменных, а не выражение
+			subscription := e.waitSubscription(instance.InstanceID)
+			if subscription.CorrelationKey != tt.wantKey {
+				t.Fatalf("subscription correlation key %q, want %q", subscription.CorrelationKey, tt.wantKey)
+			}
+			if subscription.CorrelationKeyExpression != "=order.customer.id" {
+				t.Errorf("subscription expression %q", subscription.CorrelationKeyExpression)
+			}
+
+			result, err := e.messages.PublishMessage(context.Background(), models.DefaultTenantID,
+				"payment-received", "other-customer", "", nil, nil)
+			if err != nil {
+				t.Fatalf("publish message with other key: %v", err)
+			}
+			if result.IsCorrelated() {
+				t.Fatalf("message with other key correlated with instance %s", result.ProcessInstanceID)
+			}
+			if state := e.instance(instance.InstanceID).State; state != models.ProcessInstanceStateActive {
+				t.Fatalf("instance in state %s after message with other key", state)
+			}
+
+			if _, err := e.messages.PublishMessage(context.Background(), models.DefaultTenantID,
+				"payment-received", tt.wantKey, "", map[string]interface{}{"paid": true}, nil); err != nil {
+				t.Fatalf("publish message: %v", err)
+			}
+			e.waitState(instance.InstanceID, models.ProcessInstanceStateCompleted)
+			for _, token := range e.tokens(instance.InstanceID) {
+				if token.CurrentElementID == "end" && token.Variables["paid"] != true {
+					t.Errorf("message variables not passed to token: %v", token.Variables)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveCorrelationKeyReportsMissingNestedVariable(t *testing.T) {
+	token := &models.Token{Variables: map[string]interface{}{
+		"order": map[string]interface{}{"customer": map[string]interface{}{"name": "Ann"}},
+	}}
+
+	if _, err := resolveCorrelationKey(nil, "=order.customer.id", token); err == nil {
+		t.Error("missing nested variable resolved to key")
+	}
+	if _, err := resolveCorrelationKey(nil, "=order.customer", token); err == nil {
+		t.Error("object variable resolved to key")
+	}
+	if key, err := resolveCorrelationKey(nil, "order.customer.id", token); err != nil || key != "order.customer.id" {
+		t.Errorf("literal key resolved to %q, %v", key, err)
+	}
+}
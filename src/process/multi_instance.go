@@ -0,0 +1,503 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+	"atom-engine/src/storage"
+)
+
+const (
+	// multiInstanceWaitingPrefix prefixes waiting state of body token while its instances run
+	// Префикс состояния ожидания токена тела, пока выполняются его экземпляры
+	multiInstanceWaitingPrefix = "multi_instance:"
+
+	// multiInstanceOfKey marks instance token with element ID of its multi-instance activity
+	// Отмечает токен экземпляра ID элемента его multi-instance активности
+	multiInstanceOfKey = "multi_instance_of"
+
+	// multiInstanceItemsKey keeps input items of body token, sequential instances are created from them one by one
+	// Хранит входные элементы токена тела, последовательные экземпляры создаются из них по одному
+	multiInstanceItemsKey = "multi_instance_items"
+)
+
+// MultiInstanceManager runs multi-instance activities: body token waits while one token per item
+// executes activity, completion condition is evaluated after each instance completes
+// Выполняет multi-instance активности: токен тела ожидает, пока по одному токену на элемент
+// выполняют активность, условие завершения вычисляется после завершения каждого экземпляра
+type MultiInstanceManager struct {
+	mutex         sync.Mutex
+	storage       storage.Storage
+	component     ComponentInterface
+	tokenMovement *TokenMovement
+}
+
+// NewMultiInstanceManager creates new multi-instance manager
+// Создает новый менеджер multi-instance активностей
+func NewMultiInstanceManager(storage storage.Storage, component ComponentInterface) *MultiInstanceManager {
+	return &MultiInstanceManager{
+		storage:       storage,
+		component:     component,
+		tokenMovement: NewTokenMovement(storage, component),
+	}
+}
+
+// isMultiInstanceInstance reports whether token executes one instance of multi-instance activity it stands on
+// Сообщает, выполняет ли токен один экземпляр multi-instance активности, на которой стоит
+func isMultiInstanceInstance(token *models.Token) bool {
+	elementID, ok := token.GetExecutionContext(multiInstanceOfKey)
+	return ok && elementID == token.CurrentElementID
+}
+
+// Start makes token body of multi-instance activity and creates its instances.
+// Parallel activity creates all instances at once, sequential one creates next instance when previous completes
+// Делает токен телом multi-instance активности и создает ее экземпляры.
+// Параллельная активность создает все экземпляры сразу, последовательная - следующий после завершения предыдущего
+func (mim *MultiInstanceManager) Start(body *models.Token, multiInstance map[string]interface{}) error {
+	items, err := mim.inputItems(body, multiInstance)
+	if err != nil {
+		return fmt.Errorf("failed to resolve instances of multi-instance activity %s: %w", body.CurrentElementID, err)
+	}
+	sequential, _ := multiInstance["is_sequential"].(bool)
+
+	logger.Info("Starting multi-instance activity",
+		logger.String("token_id", body.TokenID),
+		logger.String("element_id", body.CurrentElementID),
+		logger.Int("instances", len(items)),
+		logger.Bool("sequential", sequential))
+
+	body.SetExecutionContext(multiInstanceItemsKey, items)
+	if len(items) == 0 {
+		return mim.leave(body, multiInstance, nil)
+	}
+
+	body.SetWaitingFor(multiInstanceWaitingPrefix + body.CurrentElementID)
+	if err := mim.storage.UpdateToken(body); err != nil {
+		return fmt.Errorf("failed to update multi-instance body token: %w", err)
+	}
+
+	count := len(items)
+	if sequential {
+		count = 1
+	}
+
+	// All instances are saved before any runs, so early completed instance sees its siblings active
+	// Все экземпляры сохраняются до запуска любого, поэтому рано завершенный экземпляр видит соседей активными
+	instances := make([]*models.Token, 0, count)
+	for i := 0; i < count; i++ {
+		instance, err := mim.createInstance(body, multiInstance, items, i)
+		if err != nil {
+			return err
+		}
+		instances = append(instances, instance)
+	}
+	for _, instance := range instances {
+		mim.component.SubmitToken(instance)
+	}
+	return nil
+}
+
+// CompleteInstance records completed instance token instead of moving it to outgoing flows.
+// When completion condition holds or no instances are left, remaining instances are canceled
+// and body token leaves activity with output collection
+// Фиксирует завершенный токен экземпляра вместо перемещения его по исходящим потокам.
+// Когда условие завершения выполнено или экземпляров не осталось, оставшиеся экземпляры отменяются,
+// а токен тела покидает активность с выходной коллекцией
+func (mim *MultiInstanceManager) CompleteInstance(instance *models.Token) error {
+	mim.mutex.Lock()
+	body, multiInstance, done, err := mim.completeInstanceLocked(instance)
+	mim.mutex.Unlock()
+	if err != nil || !done {
+		return err
+	}
+
+	instances, err := mim.instancesOf(body)
+	if err != nil {
+		return err
+	}
+	return mim.leave(body, multiInstance, instances)
+}
+
+// completeInstanceLocked completes instance token and decides whether body token leaves activity, caller holds lock
+// Завершает токен экземпляра и решает, покидает ли токен тела активность, вызывающий держит блокировку
+func (mim *MultiInstanceManager) completeInstanceLocked(
+	instance *models.Token,
+) (*models.Token, map[string]interface{}, bool, error) {
+	if err := mim.tokenMovement.CompleteToken(instance); err != nil {
+		return nil, nil, false, err
+	}
+
+	body, err := mim.storage.LoadToken(instance.ParentTokenID)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to load multi-instance body token: %w", err)
+	}
+	if body.WaitingFor != multiInstanceWaitingPrefix+instance.CurrentElementID {
+		// Instance completed after activity was already left, e.g. worker finished canceled job
+		// Экземпляр завершился после выхода из активности, например worker завершил отмененный job
+		logger.Info("Multi-instance activity already completed, instance result ignored",
+			logger.String("token_id", instance.TokenID),
+			logger.String("body_token_id", body.TokenID))
+		return nil, nil, false, nil
+	}
+
+	multiInstance, err := mim.definition(body)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	instances, err := mim.instancesOf(body)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	items := toInterfaceSlice(body.ExecutionContext[multiInstanceItemsKey])
+	completed, active := 0, 0
+	for _, current := range instances {
+		if current.State == models.TokenStateCompleted {
+			completed++
+		} else if !current.IsCompleted() {
+			active++
+		}
+	}
+
+	variables := mim.conditionVariables(instance, multiInstance, instances, len(items), completed, active)
+	conditionMet := mim.completionConditionMet(body, multiInstance, variables)
+
+	sequential, _ := multiInstance["is_sequential"].(bool)
+	if !conditionMet && sequential && len(instances) < len(items) {
+		next, err := mim.createInstance(body, multiInstance, items, len(instances))
+		if err != nil {
+			return nil, nil, false, err
+		}
+		mim.component.SubmitToken(next)
+		return nil, nil, false, nil
+	}
+	if !conditionMet && active > 0 {
+		return nil, nil, false, nil
+	}
+
+	if conditionMet && active > 0 {
+		logger.Info("Multi-instance completion condition satisfied, canceling remaining instances",
+			logger.String("body_token_id", body.TokenID),
+			logger.String("element_id", body.CurrentElementID),
+			logger.Int("completed", completed),
+			logger.Int("active", active))
+		for _, current := range instances {
+			if !current.IsCompleted() {
+				mim.cancelInstance(current)
+			}
+		}
+	}
+
+	// Body token stops waiting under lock, so concurrent instances see activity left
+	// Токен тела перестает ожидать под блокировкой, поэтому параллельные экземпляры видят выход из активности
+	body.ClearWaitingFor()
+	if err := mim.storage.UpdateToken(body); err != nil {
+		return nil, nil, false, fmt.Errorf("failed to update multi-instance body token: %w", err)
+	}
+	return body, multiInstance, true, nil
+}
+
+// createInstance creates and saves instance token for item at index
+// Создает и сохраняет токен экземпляра для элемента с индексом
+func (mim *MultiInstanceManager) createInstance(
+	body *models.Token,
+	multiInstance map[string]interface{},
+	items []interface{},
+	index int,
+) (*models.Token, error) {
+	instance := models.NewToken(body.ProcessInstanceID, body.ProcessKey, body.CurrentElementID)
+	instance.SetVariables(body.Variables)
+	if inputElement, _ := multiInstance["input_element"].(string); inputElement != "" {
+		instance.SetVariable(inputElement, items[index])
+	}
+	instance.SetVariable("loopCounter", index+1)
+	instance.SetExecutionContext(multiInstanceOfKey, body.CurrentElementID)
+	instance.ParentTokenID = body.TokenID
+	instance.TraceParent = body.TraceParent
+
+	if err := mim.storage.SaveToken(instance); err != nil {
+		return nil, fmt.Errorf("failed to save multi-instance token: %w", err)
+	}
+
+	logger.Debug("Multi-instance instance created",
+		logger.String("body_token_id", body.TokenID),
+		logger.String("token_id", instance.TokenID),
+		logger.Int("loop_counter", index+1))
+	return instance, nil
+}
+
+// cancelInstance cancels instance token with its job and boundary events
+// Отменяет токен экземпляра вместе с его job'ом и граничными событиями
+func (mim *MultiInstanceManager) cancelInstance(instance *models.Token) {
+	if err := mim.component.CancelBoundaryTimersForToken(instance.TokenID); err != nil {
+		logger.Error("Failed to cancel boundary timers of multi-instance token",
+			logger.String("token_id", instance.TokenID),
+			logger.String("error", err.Error()))
+	}
+	if err := mim.component.CancelEventTimersForToken(instance.TokenID); err != nil {
+		logger.Error("Failed to cancel EVENT timers of multi-instance token",
+			logger.String("token_id", instance.TokenID),
+			logger.String("error", err.Error()))
+	}
+	mim.component.RemoveErrorBoundariesForToken(instance.TokenID)
+
+	// Reload token since timer cancellation updates it
+	// Перезагружаем токен, так как отмена таймеров его обновляет
+	current, err := mim.storage.LoadToken(instance.TokenID)
+	if err != nil {
+		logger.Error("Failed to load multi-instance token for cancellation",
+			logger.String("token_id", instance.TokenID),
+			logger.String("error", err.Error()))
+		return
+	}
+
+	if current.IsWaiting() && strings.HasPrefix(current.WaitingFor, "job:") {
+		jobID := strings.TrimPrefix(current.WaitingFor, "job:")
+		if err := mim.component.CancelJobByID(jobID); err != nil {
+			logger.Error("Failed to cancel job of multi-instance token",
+				logger.String("token_id", current.TokenID),
+				logger.String("job_id", jobID),
+				logger.String("error", err.Error()))
+		}
+	}
+
+	current.ClearWaitingFor()
+	current.SetState(models.TokenStateCanceled)
+	if err := mim.storage.UpdateToken(current); err != nil {
+		logger.Error("Failed to cancel multi-instance token",
+			logger.String("token_id", current.TokenID),
+			logger.String("error", err.Error()))
+	}
+}
+
+// leave sets output collection on body token and moves it to outgoing flows of activity
+// Устанавливает выходную коллекцию в токен тела и перемещает его по исходящим потокам активности
+func (mim *MultiInstanceManager) leave(
+	body *models.Token,
+	multiInstance map[string]interface{},
+	instances []*models.Token,
+) error {
+	if outputCollection, _ := multiInstance["output_collection"].(string); outputCollection != "" {
+		items := toInterfaceSlice(body.ExecutionContext[multiInstanceItemsKey])
+		body.SetVariable(outputCollection, mim.outputCollection(multiInstance, instances, len(items)))
+	}
+	body.DeleteExecutionContext(multiInstanceItemsKey)
+
+	logger.Info("Multi-instance activity completed",
+		logger.String("token_id", body.TokenID),
+		logger.String("element_id", body.CurrentElementID))
+
+	return mim.tokenMovement.MoveTokenToNextElements(body, body.CurrentElementID)
+}
+
+// outputCollection collects output element of completed instances by loop counter, others stay null
+// Собирает выходной элемент завершенных экземпляров по счетчику цикла, остальные остаются null
+func (mim *MultiInstanceManager) outputCollection(
+	multiInstance map[string]interface{},
+	instances []*models.Token,
+	total int,
+) []interface{} {
+	outputElement, _ := multiInstance["output_element"].(string)
+	outputs := make([]interface{}, total)
+	for _, instance := range instances {
+		index := loopCounterOf(instance) - 1
+		if instance.State != models.TokenStateCompleted || index < 0 || index >= total || outputElement == "" {
+			continue
+		}
+		value, err := evaluateMappingSource(mim.component, outputElement, instance.Variables)
+		if err != nil {
+			logger.Error("Failed to evaluate multi-instance output element",
+				logger.String("token_id", instance.TokenID),
+				logger.String("output_element", outputElement),
+				logger.String("error", err.Error()))
+			continue
+		}
+		outputs[index] = value
+	}
+	return outputs
+}
+
+// conditionVariables returns variables of completion condition: instance scope with multi-instance counters
+// Возвращает переменные условия завершения: область экземпляра со счетчиками multi-instance
+func (mim *MultiInstanceManager) conditionVariables(
+	instance *models.Token,
+	multiInstance map[string]interface{},
+	instances []*models.Token,
+	total, completed, active int,
+) map[string]interface{} {
+	variables := make(map[string]interface{}, len(instance.Variables)+4)
+	for name, value := range instance.Variables {
+		variables[name] = value
+	}
+	if outputCollection, _ := multiInstance["output_collection"].(string); outputCollection != "" {
+		variables[outputCollection] = mim.outputCollection(multiInstance, instances, total)
+	}
+	variables["nrOfInstances"] = total
+	variables["nrOfCompletedInstances"] = completed
+	variables["nrOfActiveInstances"] = active
+	return variables
+}
+
+// completionConditionMet evaluates completion condition, failed evaluation keeps instances running
+// Вычисляет условие завершения, ошибка вычисления оставляет экземпляры выполняться
+func (mim *MultiInstanceManager) completionConditionMet(
+	body *models.Token,
+	multiInstance map[string]interface{},
+	variables map[string]interface{},
+) bool {
+	condition, _ := multiInstance["completion_condition"].(string)
+	if strings.TrimSpace(condition) == "" {
+		return false
+	}
+
+	met, err := evaluateEventCondition(mim.component, condition, variables)
+	if err != nil {
+		logger.Error("Failed to evaluate multi-instance completion condition",
+			logger.String("body_token_id", body.TokenID),
+			logger.String("element_id", body.CurrentElementID),
+			logger.String("condition", condition),
+			logger.String("error", err.Error()))
+		return false
+	}
+	return met
+}
+
+// inputItems resolves items of instances from input collection or loop cardinality
+// Определяет элементы экземпляров по входной коллекции или количеству итераций
+func (mim *MultiInstanceManager) inputItems(
+	body *models.Token,
+	multiInstance map[string]interface{},
+) ([]interface{}, error) {
+	if inputCollection, _ := multiInstance["input_collection"].(string); inputCollection != "" {
+		value, err := evaluateMappingSource(mim.component, inputCollection, body.Variables)
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			return nil, fmt.Errorf("input collection %s is null", inputCollection)
+		}
+		if reflect.TypeOf(value).Kind() != reflect.Slice {
+			return nil, fmt.Errorf("input collection %s is %T, not a list", inputCollection, value)
+		}
+		return toInterfaceSlice(value), nil
+	}
+
+	cardinality, _ := multiInstance["loop_cardinality"].(string)
+	cardinality = strings.TrimSpace(cardinality)
+	if cardinality == "" {
+		return nil, fmt.Errorf("neither input collection nor loop cardinality is defined")
+	}
+
+	var count int
+	if strings.HasPrefix(cardinality, "=") {
+		value, err := evaluateMappingSource(mim.component, cardinality, body.Variables)
+		if err != nil {
+			return nil, err
+		}
+		number, ok := toInt(value)
+		if !ok {
+			return nil, fmt.Errorf("loop cardinality %s is %T, not a number", cardinality, value)
+		}
+		count = number
+	} else {
+		number, err := strconv.Atoi(cardinality)
+		if err != nil {
+			return nil, fmt.Errorf("invalid loop cardinality %q", cardinality)
+		}
+		count = number
+	}
+	if count < 0 {
+		return nil, fmt.Errorf("loop cardinality %d is negative", count)
+	}
+	return make([]interface{}, count), nil
+}
+
+// definition returns multi-instance characteristics of activity body token stands on
+// Возвращает характеристики multi-instance активности, на которой стоит токен тела
+func (mim *MultiInstanceManager) definition(body *models.Token) (map[string]interface{}, error) {
+	elements, err := NewBPMNHelper(mim.storage).LoadProcessElements(body.ProcessKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load process elements: %w", err)
+	}
+	element, _ := elements[body.CurrentElementID].(map[string]interface{})
+	multiInstance, ok := element["multi_instance"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("element %s is not multi-instance activity", body.CurrentElementID)
+	}
+	return multiInstance, nil
+}
+
+// instancesOf returns instance tokens of body token ordered by loop counter
+// Возвращает токены экземпляров токена тела, упорядоченные по счетчику цикла
+func (mim *MultiInstanceManager) instancesOf(body *models.Token) ([]*models.Token, error) {
+	tokens, err := mim.storage.LoadTokensByProcessInstance(body.ProcessInstanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokens: %w", err)
+	}
+
+	instances := make([]*models.Token, 0)
+	for _, token := range tokens {
+		if token.ParentTokenID == body.TokenID && isMultiInstanceInstance(token) {
+			instances = append(instances, token)
+		}
+	}
+	sort.Slice(instances, func(i, j int) bool {
+		return loopCounterOf(instances[i]) < loopCounterOf(instances[j])
+	})
+	return instances, nil
+}
+
+// loopCounterOf returns one-based loop counter of instance token
+// Возвращает счетчик цикла токена экземпляра, начиная с единицы
+func loopCounterOf(token *models.Token) int {
+	counter, _ := toInt(token.Variables["loopCounter"])
+	return counter
+}
+
+// toInt converts numeric value, including numbers decoded from JSON, to int
+// Преобразует числовое значение, включая числа из JSON, в int
+func toInt(value interface{}) (int, bool) {
+	switch number := value.(type) {
+	case int:
+		return number, true
+	case int64:
+		return int(number), true
+	case float64:
+		if number != float64(int(number)) {
+			return 0, false
+		}
+		return int(number), true
+	}
+	return 0, false
+}
+
+// toInterfaceSlice converts any list value to []interface{}
+// Преобразует любое значение-список в []interface{}
+func toInterfaceSlice(value interface{}) []interface{} {
+	if items, ok := value.([]interface{}); ok {
+		return items
+	}
+	if value == nil || reflect.TypeOf(value).Kind() != reflect.Slice {
+		return nil
+	}
+	list := reflect.ValueOf(value)
+	items := make([]interface{}, list.Len())
+	for i := range items {
+		items[i] = list.Index(i).Interface()
+	}
+	return items
+}
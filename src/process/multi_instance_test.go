@@ -0,0 +1,175 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"fmt"
+	"testing"
+
+	"atom-engine/src/core/models"
+	"atom-engine/src/jobs"
+)
+
+// approvalProcess asks every approver of collection in multi-instance service task
+// Запрашивает каждого согласующего из коллекции в multi-instance сервисной задаче
+func approvalProcess(loop string) string {
+	return `
+    <bpmn:startEvent id="start"><bpmn:outgoing>f1</bpmn:outgoing></bpmn:startEvent>
+    <bpmn:sequenceFlow id="f1" sourceRef="start" targetRef="approve" />
+    <bpmn:serviceTask id="approve">
+      <bpmn:extensionElements><zeebe:taskDefinition type="approve" /></bpmn:extensionElements>
+      <bpmn:incoming>f1</bpmn:incoming><bpmn:outgoing>f2</bpmn:outgoing>` + loop + `
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="f2" sourceRef="approve" targetRef="approved" />
+    <bpmn:endEvent id="approved"><bpmn:incoming>f2</bpmn:incoming></bpmn:endEvent>`
+}
+
+// approversLoop iterates approvers collecting decisions, ends early by completion condition
+// Перебирает согласующих, собирая решения, досрочно завершается по условию завершения
+func approversLoop(sequential bool, completionCondition string) string {
+	return fmt.Sprintf(`
+      <bpmn:multiInstanceLoopCharacteristics isSequential="%t">
+        <bpmn:extensionElements>
+          <zeebe:loopCharacteristics inputCollection="=approvers" inputElement="approver"
+            outputCollection="decisions" outputElement="=decision" />
+        </bpmn:extensionElements>
+        <bpmn:completionCondition xsi:type="bpmn:tFormalExpression">%s</bpmn:completionCondition>
+      </bpmn:multiInstanceLoopCharacteristics>`, sequential, completionCondition)
+}
+
+// openJobsOf returns open jobs of element by approver
+// Возвращает открытые job'ы элемента по согласующему
+func (e *testEngine) openJobsOf(instanceID, elementID string) map[string]jobs.JobInfo {
+	e.t.Helper()
+
+	open := make(map[string]jobs.JobInfo)
+	for _, job := range e.jobsOf(instanceID, elementID) {
+		if isOpenJob(job) {
+			open[fmt.Sprint(job.Variables["approver"])] = job
+		}
+	}
+	return open
+}
+
+// waitOpenJobs waits until element has count open jobs and returns them by approver
+// Ожидает, пока у элемента будет count открытых job'ов, и возвращает их по согласующему
+func (e *testEngine) waitOpenJobs(instanceID, elementID string, count int) map[string]jobs.JobInfo {
+	e.t.Helper()
+
+	var open map[string]jobs.JobInfo
+	e.waitFor(fmt.Sprintf("%d open jobs of %s", count, elementID), func() bool {
+		open = e.openJobsOf(instanceID, elementID)
+		return len(open) == count
+	})
+	return open
+}
+
+func TestParallelMultiInstanceCompletesWhenThreeOfFiveInstancesComplete(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("three-of-five", approvalProcess(
+		approversLoop(false, "= nrOfCompletedInstances &gt;= 3"))))
+	instance := e.start(processID, map[string]interface{}{
+		"approvers": []interface{}{"ann", "bob", "cid", "dan", "eve"},
+	})
+
+	open := e.waitOpenJobs(instance.InstanceID, "approve", 5)
+	for i, approver := range []string{"ann", "bob", "cid", "dan", "eve"} {
+		if counter, _ := toInt(open[approver].Variables["loopCounter"]); counter != i+1 {
+			t.Errorf("job of %s has loop counter %v, want %d", approver, open[approver].Variables["loopCounter"], i+1)
+		}
+	}
+
+	e.completeJob(open["ann"], map[string]interface{}{"decision": "yes"})
+	e.completeJob(open["cid"], map[string]interface{}{"decision": "no"})
+	e.waitFor("two instances completed", func() bool {
+		return len(e.openJobsOf(instance.InstanceID, "approve")) == 3
+	})
+	if state := e.instance(instance.InstanceID).State; state != models.ProcessInstanceStateActive {
+		t.Fatalf("instance in state %s before completion condition holds", state)
+	}
+
+	// Third completed instance satisfies condition, two remaining instances are canceled
+	// Третий завершенный экземпляр выполняет условие, два оставшихся экземпляра отменяются
+	e.completeJob(open["eve"], map[string]interface{}{"decision": "yes"})
+	e.waitState(instance.InstanceID, models.ProcessInstanceStateCompleted)
+
+	for approver, job := range e.openJobsOf(instance.InstanceID, "approve") {
+		t.Errorf("job %s of %s is still open after completion condition held", job.Key, approver)
+	}
+	end := e.tokenAt(instance.InstanceID, "approved")
+	if end == nil {
+		t.Fatalf("body token did not leave multi-instance activity")
+	}
+	if got := jsonOf(t, end.Variables["decisions"]); got != `["yes",null,"no",null,"yes"]` {
+		t.Errorf("output collection %s, want decisions of completed instances by loop counter", got)
+	}
+	if _, leaked := end.Variables["approver"]; leaked {
+		t.Errorf("input element of instance leaked into process scope")
+	}
+}
+
+func TestParallelMultiInstanceWithoutConditionWaitsForAllInstances(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("all-of-three", approvalProcess(`
+      <bpmn:multiInstanceLoopCharacteristics>
+        <bpmn:loopCardinality>3</bpmn:loopCardinality>
+      </bpmn:multiInstanceLoopCharacteristics>`)))
+	instance := e.start(processID, nil)
+
+	e.waitFor("three open jobs", func() bool {
+		count := 0
+		for _, job := range e.jobsOf(instance.InstanceID, "approve") {
+			if isOpenJob(job) {
+				count++
+			}
+		}
+		return count == 3
+	})
+
+	// Instances without input collection get only loop counter
+	// Экземпляры без входной коллекции получают только счетчик цикла
+	for i, job := range e.jobsOf(instance.InstanceID, "approve") {
+		if state := e.instance(instance.InstanceID).State; state != models.ProcessInstanceStateActive {
+			t.Fatalf("instance in state %s after %d of 3 instances completed", state, i)
+		}
+		if _, ok := toInt(job.Variables["loopCounter"]); !ok {
+			t.Errorf("job %s has no loop counter: %v", job.Key, job.Variables)
+		}
+		e.completeJob(job, nil)
+	}
+	e.waitState(instance.InstanceID, models.ProcessInstanceStateCompleted)
+}
+
+func TestSequentialMultiInstanceStopsWhenConditionHolds(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("sequential-until-stop", approvalProcess(
+		approversLoop(true, `= decision == "stop"`))))
+	instance := e.start(processID, map[string]interface{}{
+		"approvers": []interface{}{"ann", "bob", "cid"},
+	})
+
+	// Next instance is created only after previous one completes
+	// Следующий экземпляр создается только после завершения предыдущего
+	first := e.waitOpenJobs(instance.InstanceID, "approve", 1)["ann"]
+	e.completeJob(first, map[string]interface{}{"decision": "go"})
+	second := e.waitOpenJobs(instance.InstanceID, "approve", 1)["bob"]
+	e.completeJob(second, map[string]interface{}{"decision": "stop"})
+	e.waitState(instance.InstanceID, models.ProcessInstanceStateCompleted)
+
+	if created := len(e.jobsOf(instance.InstanceID, "approve")); created != 2 {
+		t.Errorf("%d instances created, want none after condition held", created)
+	}
+	end := e.tokenAt(instance.InstanceID, "approved")
+	if end == nil || jsonOf(t, end.Variables["decisions"]) != `["go","stop",null]` {
+		t.Errorf("body token after sequential instances: %+v", end)
+	}
+}
@@ -9,9 +9,15 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package process
 
 import (
+	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"atom-engine/src/core/logger"
+	"atom-engine/src/core/metrics"
 	"atom-engine/src/core/models"
 	"atom-engine/src/storage"
 )
@@ -22,6 +28,10 @@ type ProcessInstanceManager struct {
 	storage        storage.Storage
 	component      ComponentInterface
 	processStarter *ProcessStarter
+
+	// variablesMu serializes variable patches so concurrent patches do not lose updates
+	// variablesMu сериализует патчи переменных, чтобы параллельные патчи не терялись
+	variablesMu sync.Mutex
 }
 
 // NewProcessInstanceManager creates new process instance manager
@@ -47,7 +57,49 @@ func (pim *ProcessInstanceManager) StartProcessInstance(
 	processKey string,
 	variables map[string]interface{},
 ) (*models.ProcessInstance, error) {
-	return pim.processStarter.StartProcessInstance(processKey, variables)
+	return pim.processStarter.StartProcessInstance(context.Background(), processKey, variables, 0)
+}
+
+// StartProcessInstanceWithPriority starts new process instance with given priority
+// Запускает новый экземпляр процесса с заданным приоритетом
+func (pim *ProcessInstanceManager) StartProcessInstanceWithPriority(
+	processKey string,
+	variables map[string]interface{},
+	priority int,
+) (*models.ProcessInstance, error) {
+	return pim.processStarter.StartProcessInstance(context.Background(), processKey, variables, priority)
+}
+
+// StartProcessInstanceWithContext starts new process instance traced under span in ctx
+// Запускает новый экземпляр процесса, трассируемый под span'ом из ctx
+func (pim *ProcessInstanceManager) StartProcessInstanceWithContext(
+	ctx context.Context,
+	processKey string,
+	variables map[string]interface{},
+	priority int,
+) (*models.ProcessInstance, error) {
+	return pim.processStarter.StartProcessInstance(ctx, processKey, variables, priority)
+}
+
+// StartProcessInstanceAsync starts new process instance and executes it in background
+// Запускает новый экземпляр процесса и выполняет его в фоне
+func (pim *ProcessInstanceManager) StartProcessInstanceAsync(
+	ctx context.Context,
+	processKey string,
+	variables map[string]interface{},
+	priority int,
+) (*models.ProcessInstance, error) {
+	return pim.processStarter.StartProcessInstanceAsync(ctx, processKey, variables, priority)
+}
+
+// StartProcessInstanceAtStartEvent starts new process instance from given start event
+// Запускает новый экземпляр процесса с указанного стартового события
+func (pim *ProcessInstanceManager) StartProcessInstanceAtStartEvent(
+	processKey string,
+	startEventID string,
+	variables map[string]interface{},
+) (*models.ProcessInstance, error) {
+	return pim.processStarter.StartProcessInstanceAtStartEvent(processKey, startEventID, variables)
 }
 
 // GetProcessInstanceStatus gets process instance status
@@ -88,6 +140,7 @@ func (pim *ProcessInstanceManager) CancelProcessInstance(instanceID string, reas
 	}
 
 	// Set state to canceled
+	wasFinished := instance.IsCompleted()
 	instance.SetState(models.ProcessInstanceStateCanceled)
 	instance.AddMetadata("cancel_reason", reason)
 
@@ -105,7 +158,7 @@ func (pim *ProcessInstanceManager) CancelProcessInstance(instanceID string, reas
 	for _, token := range tokens {
 		if token.IsActive() || token.IsWaiting() {
 			// Cancel boundary timers before setting token state
-			if err := pim.component.CancelBoundaryTimersForToken(token.TokenID); err != nil {
+			if err := pim.component.CancelBoundaryTimers(token); err != nil {
 				logger.Error("Failed to cancel boundary timers for token",
 					logger.String("token_id", token.TokenID),
 					logger.String("error", err.Error()))
@@ -141,9 +194,197 @@ func (pim *ProcessInstanceManager) CancelProcessInstance(instanceID string, reas
 	}
 
 	logger.Info("Process instance canceled", logger.String("instance_id", instanceID))
+	if !wasFinished {
+		metrics.Processes.InstanceCanceled(instance.ProcessID, "")
+		pim.component.GetInstanceStatistics().InstanceFinished(instance)
+	}
+
+	if core := pim.component.GetCore(); core != nil {
+		event := models.NewProcessInstanceEvent(models.EngineEventProcessInstanceCanceled, instance)
+		event.Data = map[string]interface{}{"reason": reason}
+		core.PublishEngineEvent(event)
+	}
+	return nil
+}
+
+// TerminateProcessInstance ends process instance from terminate end event
+// All tokens except terminating one are canceled together with their timers, jobs and
+// message subscriptions. Instance itself is completed when terminating token completes
+// Завершает экземпляр процесса из terminate end event
+// Все токены, кроме завершающего, отменяются вместе с их таймерами, job'ами и
+// подписками на сообщения. Сам экземпляр завершается при завершении завершающего токена
+func (pim *ProcessInstanceManager) TerminateProcessInstance(instanceID, tokenID, elementID string) error {
+	logger.Info("Terminating process instance",
+		logger.String("instance_id", instanceID),
+		logger.String("token_id", tokenID),
+		logger.String("element_id", elementID))
+
+	instance, err := pim.storage.LoadProcessInstance(instanceID)
+	if err != nil {
+		return fmt.Errorf("failed to load process instance: %w", err)
+	}
+
+	instance.AddMetadata("terminated_by", elementID)
+	if err := pim.storage.UpdateProcessInstance(instance); err != nil {
+		return fmt.Errorf("failed to update process instance: %w", err)
+	}
+
+	tokens, err := pim.storage.LoadTokensByProcessInstance(instanceID)
+	if err != nil {
+		return fmt.Errorf("failed to load tokens: %w", err)
+	}
+
+	canceled := 0
+	for _, token := range tokens {
+		if token.TokenID == tokenID || !(token.IsActive() || token.IsWaiting()) {
+			continue
+		}
+
+		if err := pim.component.CancelBoundaryTimers(token); err != nil {
+			logger.Error("Failed to cancel boundary timers for token",
+				logger.String("token_id", token.TokenID),
+				logger.String("error", err.Error()))
+		}
+		pim.component.RemoveErrorBoundariesForToken(token.TokenID)
+		pim.deleteMessageSubscriptionForToken(token)
+
+		token.SetState(models.TokenStateCanceled)
+		if err := pim.storage.UpdateToken(token); err != nil {
+			logger.Error("Failed to cancel token",
+				logger.String("token_id", token.TokenID),
+				logger.String("error", err.Error()))
+			continue
+		}
+		canceled++
+	}
+
+	// Cancel remaining timers and jobs of instance
+	// Отменяем оставшиеся таймеры и job'ы экземпляра
+	if err := pim.component.CancelAllTimersForProcessInstance(instanceID); err != nil {
+		logger.Error("Failed to cancel all process timers",
+			logger.String("instance_id", instanceID),
+			logger.String("error", err.Error()))
+	}
+
+	reason := fmt.Sprintf("terminated by end event %s", elementID)
+	if err := pim.component.CancelAllJobsForProcessInstance(instanceID, reason); err != nil {
+		logger.Error("Failed to cancel all process jobs",
+			logger.String("instance_id", instanceID),
+			logger.String("error", err.Error()))
+	}
+
+	logger.Info("Process instance terminated",
+		logger.String("instance_id", instanceID),
+		logger.String("element_id", elementID),
+		logger.Int("canceled_tokens", canceled))
 	return nil
 }
 
+// deleteMessageSubscriptionForToken removes message subscription created by waiting catch event
+// Subscriptions are not linked to tokens, so one subscription matching process, element and
+// message name is removed per waiting token
+// Удаляет подписку на сообщение, созданную ожидающим catch event
+// Подписки не связаны с токенами, поэтому на каждый ожидающий токен удаляется одна
+// подписка, совпадающая по процессу, элементу и имени сообщения
+func (pim *ProcessInstanceManager) deleteMessageSubscriptionForToken(token *models.Token) {
+	if !token.IsWaiting() || !strings.HasPrefix(token.WaitingFor, "message:") {
+		return
+	}
+	messageName := strings.TrimPrefix(token.WaitingFor, "message:")
+
+	subscriptions, err := pim.storage.ListProcessMessageSubscriptions(context.Background(), "", 0, 0)
+	if err != nil {
+		logger.Error("Failed to list message subscriptions",
+			logger.String("token_id", token.TokenID),
+			logger.String("error", err.Error()))
+		return
+	}
+
+	for _, subscription := range subscriptions {
+		if subscription.ProcessDefinitionKey != token.ProcessKey ||
+			subscription.StartEventID != token.CurrentElementID ||
+			subscription.MessageName != messageName {
+			continue
+		}
+
+		if err := pim.component.DeleteMessageSubscription(subscription.ID); err != nil {
+			logger.Error("Failed to delete message subscription",
+				logger.String("token_id", token.TokenID),
+				logger.String("subscription_id", subscription.ID),
+				logger.String("error", err.Error()))
+		}
+		return
+	}
+}
+
+// RestartProcessInstance starts new instance from element of finished instance
+// Запускает новый экземпляр с элемента завершенного экземпляра
+func (pim *ProcessInstanceManager) RestartProcessInstance(
+	instanceID string,
+	elementID string,
+) (*models.ProcessInstance, error) {
+	original, err := pim.storage.LoadProcessInstance(instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load process instance: %w", err)
+	}
+	return pim.processStarter.RestartProcessInstance(original, elementID)
+}
+
+// PatchProcessInstanceVariables applies JSON merge patch to instance variables
+// Patch is also applied to variables of active and waiting tokens so running
+// branches observe the change. Instance and tokens are saved in one transaction
+// Применяет JSON merge patch к переменным экземпляра
+// Патч также применяется к переменным активных и ожидающих токенов, чтобы
+// выполняющиеся ветки видели изменения. Экземпляр и токены сохраняются в одной транзакции
+func (pim *ProcessInstanceManager) PatchProcessInstanceVariables(
+	instanceID string,
+	patch map[string]interface{},
+) (map[string]interface{}, error) {
+	if !pim.component.IsReady() {
+		return nil, fmt.Errorf("process component not ready")
+	}
+
+	pim.variablesMu.Lock()
+	defer pim.variablesMu.Unlock()
+
+	instance, err := pim.storage.LoadProcessInstance(instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load process instance: %w", err)
+	}
+	if instance.IsCompleted() {
+		return nil, fmt.Errorf("%w: %s is %s", models.ErrProcessInstanceFinished, instanceID, instance.State)
+	}
+
+	tokens, err := pim.storage.LoadTokensByProcessInstance(instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load tokens: %w", err)
+	}
+
+	var patchedTokens []*models.Token
+	for _, token := range tokens {
+		if !token.IsActive() && !token.IsWaiting() {
+			continue
+		}
+		token.Variables = models.ApplyMergePatch(token.Variables, patch)
+		token.UpdatedAt = time.Now()
+		patchedTokens = append(patchedTokens, token)
+	}
+
+	instance.Variables = models.ApplyMergePatch(instance.Variables, patch)
+	instance.UpdatedAt = time.Now()
+
+	if err := pim.storage.SaveProcessInstanceWithTokens(instance, patchedTokens); err != nil {
+		return nil, fmt.Errorf("failed to save patched variables: %w", err)
+	}
+
+	logger.Info("Process instance variables patched",
+		logger.String("instance_id", instanceID),
+		logger.Int("patched_keys", len(patch)),
+		logger.Int("patched_tokens", len(patchedTokens)))
+
+	return instance.Variables, nil
+}
+
 // ListProcessInstances lists process instances with optional filters
 // Получает список экземпляров процессов с опциональными фильтрами
 func (pim *ProcessInstanceManager) ListProcessInstances(
@@ -188,6 +429,71 @@ func (pim *ProcessInstanceManager) ListProcessInstances(
 	return instances, nil
 }
 
+// QueryProcessInstances returns sorted page of filtered instances and total count of filtered instances
+// Sorting is done before paging, so pages of same query do not overlap
+// Возвращает отсортированную страницу отфильтрованных экземпляров и общее количество отфильтрованных
+// Сортировка выполняется до разбиения на страницы, поэтому страницы одного запроса не пересекаются
+func (pim *ProcessInstanceManager) QueryProcessInstances(
+	query models.ProcessInstanceQuery,
+) ([]*models.ProcessInstance, int, error) {
+	instances, err := pim.ListProcessInstances(query.Status, query.ProcessKey, 0)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	less, err := processInstanceLess(query.SortBy)
+	if err != nil {
+		return nil, 0, err
+	}
+	descending := !strings.EqualFold(query.SortOrder, "asc")
+	sort.SliceStable(instances, func(i, j int) bool {
+		if descending {
+			return less(instances[j], instances[i])
+		}
+		return less(instances[i], instances[j])
+	})
+
+	total := len(instances)
+	start := min(max(query.Offset, 0), total)
+	end := total
+	if query.Limit > 0 {
+		end = min(start+query.Limit, total)
+	}
+
+	page := instances[start:end]
+	if query.ExcludeVariables {
+		for _, instance := range page {
+			instance.Variables = nil
+		}
+	}
+	return page, total, nil
+}
+
+// processInstanceLess returns ascending order of sort field, ties are ordered by instance ID
+// Возвращает порядок по возрастанию для поля сортировки, равные упорядочиваются по ID экземпляра
+func processInstanceLess(sortBy string) (func(a, b *models.ProcessInstance) bool, error) {
+	var compare func(a, b *models.ProcessInstance) int
+	switch sortBy {
+	case "", "created_at":
+		compare = func(a, b *models.ProcessInstance) int { return a.StartedAt.Compare(b.StartedAt) }
+	case "updated_at":
+		compare = func(a, b *models.ProcessInstance) int { return a.UpdatedAt.Compare(b.UpdatedAt) }
+	case "state":
+		compare = func(a, b *models.ProcessInstance) int { return strings.Compare(string(a.State), string(b.State)) }
+	case "process_key":
+		compare = func(a, b *models.ProcessInstance) int { return strings.Compare(a.ProcessKey, b.ProcessKey) }
+	default:
+		return nil, fmt.Errorf("unsupported process instance sort field: %s", sortBy)
+	}
+
+	return func(a, b *models.ProcessInstance) bool {
+		if c := compare(a, b); c != 0 {
+			return c < 0
+		}
+		return a.InstanceID < b.InstanceID
+	}, nil
+}
+
 // RestoreActiveProcesses restores active processes after restart
 // Восстанавливает активные процессы после перезапуска
 func (pim *ProcessInstanceManager) RestoreActiveProcesses() error {
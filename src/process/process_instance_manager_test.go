@@ -0,0 +1,69 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"atom-engine/src/core/models"
+)
+
+func TestQueryProcessInstancesExcludeVariablesShrinksPage(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("query-exclude-variables", reviewApproveProcess))
+	document := strings.Repeat("x", 10000)
+	var instanceIDs []string
+	for i := 0; i < 5; i++ {
+		instance := e.start(processID, map[string]interface{}{"document": document, "index": i})
+		instanceIDs = append(instanceIDs, instance.InstanceID)
+	}
+
+	query := models.ProcessInstanceQuery{SortOrder: "asc"}
+	full, total, err := e.process.QueryProcessInstances(query)
+	if err != nil {
+		t.Fatalf("query instances: %v", err)
+	}
+	query.ExcludeVariables = true
+	lean, leanTotal, err := e.process.QueryProcessInstances(query)
+	if err != nil {
+		t.Fatalf("query instances without variables: %v", err)
+	}
+
+	if total != 5 || leanTotal != total || len(lean) != len(full) {
+		t.Fatalf("pages of %d and %d instances, totals %d and %d, want 5", len(full), len(lean), total, leanTotal)
+	}
+	for i, instance := range lean {
+		if instance.Variables != nil {
+			t.Errorf("instance %s returned with variables", instance.InstanceID)
+		}
+		if instance.InstanceID != full[i].InstanceID || instance.State != full[i].State {
+			t.Errorf("instance %d differs from full page: %+v", i, instance)
+		}
+	}
+
+	// Page without variables does not carry documents
+	// Страница без переменных не несет документов
+	fullJSON, _ := json.Marshal(full)
+	leanJSON, _ := json.Marshal(lean)
+	if len(fullJSON) < 5*len(document) || len(leanJSON)*10 > len(fullJSON) {
+		t.Errorf("page is %d bytes with variables and %d bytes without, want at least tenfold reduction",
+			len(fullJSON), len(leanJSON))
+	}
+
+	// Stored instances keep their variables
+	// Сохраненные экземпляры сохраняют свои переменные
+	for _, instanceID := range instanceIDs {
+		if e.instance(instanceID).Variables["document"] != document {
+			t.Errorf("instance %s lost variables after query without variables", instanceID)
+		}
+	}
+}
@@ -9,6 +9,8 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package process
 
 import (
+	"context"
+
 	"atom-engine/src/core/models"
 )
 
@@ -17,7 +19,44 @@ import (
 type ProcessManagerInterface interface {
 	// Process instance lifecycle
 	StartProcessInstance(processKey string, variables map[string]interface{}) (*models.ProcessInstance, error)
+	StartProcessInstanceWithPriority(
+		processKey string,
+		variables map[string]interface{},
+		priority int,
+	) (*models.ProcessInstance, error)
+	StartProcessInstanceWithContext(
+		ctx context.Context,
+		processKey string,
+		variables map[string]interface{},
+		priority int,
+	) (*models.ProcessInstance, error)
+	StartProcessInstanceAsync(
+		ctx context.Context,
+		processKey string,
+		variables map[string]interface{},
+		priority int,
+	) (*models.ProcessInstance, error)
+	StartProcessInstanceAtStartEvent(
+		processKey string,
+		startEventID string,
+		variables map[string]interface{},
+	) (*models.ProcessInstance, error)
 	GetProcessInstanceStatus(instanceID string) (*models.ProcessInstance, error)
 	CancelProcessInstance(instanceID string, reason string) error
+	TerminateProcessInstance(instanceID, tokenID, elementID string) error
 	ListProcessInstances(statusFilter string, processKeyFilter string, limit int) ([]*models.ProcessInstance, error)
+	QueryProcessInstances(query models.ProcessInstanceQuery) ([]*models.ProcessInstance, int, error)
+
+	RestartProcessInstance(instanceID string, elementID string) (*models.ProcessInstance, error)
+	ModifyProcessInstance(
+		instanceID string,
+		instructions []models.ModificationInstruction,
+	) (*models.ProcessInstanceModification, error)
+	MigrateProcessInstances(
+		plan models.MigrationPlan,
+		filter models.MigrationFilter,
+		dryRun bool,
+	) (*models.ProcessInstanceMigration, error)
+	// Process instance variables
+	PatchProcessInstanceVariables(instanceID string, patch map[string]interface{}) (map[string]interface{}, error)
 }
@@ -9,13 +9,21 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package process
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"strconv"
 	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"atom-engine/src/core/logger"
+	"atom-engine/src/core/metrics"
 	"atom-engine/src/core/models"
+	"atom-engine/src/core/tracing"
 	"atom-engine/src/storage"
 )
 
@@ -39,17 +47,121 @@ func NewProcessStarter(storage storage.Storage, component ComponentInterface) *P
 	}
 }
 
-// StartProcessInstance starts new process instance
-// Запускает новый экземпляр процесса
+// StartProcessInstance starts new process instance, span in ctx becomes parent of token spans
+// Запускает новый экземпляр процесса, span из ctx становится родителем span'ов токенов
 func (ps *ProcessStarter) StartProcessInstance(
+	ctx context.Context,
+	processKey string,
+	variables map[string]interface{},
+	priority int,
+) (*models.ProcessInstance, error) {
+	return ps.startProcessInstance(ctx, processKey, "", variables, priority)
+}
+
+// StartProcessInstanceAsync creates and saves process instance and executes it in background
+// Instance ID is allocated before return, execution failure moves instance to FAILED state
+// Создает и сохраняет экземпляр процесса и выполняет его в фоне
+// ID экземпляра выделяется до возврата, ошибка выполнения переводит экземпляр в состояние FAILED
+func (ps *ProcessStarter) StartProcessInstanceAsync(
+	ctx context.Context,
+	processKey string,
+	variables map[string]interface{},
+	priority int,
+) (*models.ProcessInstance, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "start process "+processKey,
+		trace.WithAttributes(
+			attribute.String("atom.process_key", processKey),
+			attribute.Bool("atom.async", true)))
+
+	instance, bpmnProcess, err := ps.createProcessInstanceFromDefinition(ctx, processKey, priority, variables)
+	if err != nil {
+		tracing.EndSpan(span, err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("atom.process_instance_id", instance.InstanceID))
+
+	// Caller gets snapshot, execution mutates instance concurrently with response encoding
+	// Вызывающий получает снимок, выполнение изменяет экземпляр одновременно с кодированием ответа
+	created := *instance
+	created.Variables = maps.Clone(instance.Variables)
+	created.Metadata = maps.Clone(instance.Metadata)
+
+	go func() {
+		err := ps.executeProcessInstance(instance, bpmnProcess, processKey, "", variables)
+		if err != nil {
+			ps.failProcessInstance(instance.InstanceID, err)
+		}
+		tracing.EndSpan(span, err)
+	}()
+
+	return &created, nil
+}
+
+// StartProcessInstanceAtStartEvent starts new process instance from given start event
+// Запускает новый экземпляр процесса с указанного стартового события
+func (ps *ProcessStarter) StartProcessInstanceAtStartEvent(
+	processKey string,
+	startEventID string,
+	variables map[string]interface{},
+) (*models.ProcessInstance, error) {
+	return ps.startProcessInstance(context.Background(), processKey, startEventID, variables, 0)
+}
+
+// startProcessInstance starts process instance in span, tokens of instance are traced under it
+// Запускает экземпляр процесса в span'е, токены экземпляра трассируются под ним
+func (ps *ProcessStarter) startProcessInstance(
+	ctx context.Context,
+	processKey string,
+	startEventID string,
+	variables map[string]interface{},
+	priority int,
+) (*models.ProcessInstance, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "start process "+processKey,
+		trace.WithAttributes(attribute.String("atom.process_key", processKey)))
+
+	instance, err := ps.createAndStartProcessInstance(ctx, processKey, startEventID, variables, priority)
+	if instance != nil {
+		span.SetAttributes(attribute.String("atom.process_instance_id", instance.InstanceID))
+	}
+	tracing.EndSpan(span, err)
+	return instance, err
+}
+
+// createAndStartProcessInstance creates and starts process instance, empty startEventID selects first start event
+// Создает и запускает экземпляр процесса, пустой startEventID выбирает первое стартовое событие
+func (ps *ProcessStarter) createAndStartProcessInstance(
+	ctx context.Context,
 	processKey string,
+	startEventID string,
 	variables map[string]interface{},
+	priority int,
 ) (*models.ProcessInstance, error) {
 	logger.Info("Starting process instance",
-		logger.String("process_key", processKey))
+		logger.String("process_key", processKey),
+		logger.String("start_event_id", startEventID),
+		logger.Int("priority", priority))
+
+	instance, bpmnProcess, err := ps.createProcessInstanceFromDefinition(ctx, processKey, priority, variables)
+	if err != nil {
+		return nil, err
+	}
 
+	if err := ps.executeProcessInstance(instance, bpmnProcess, processKey, startEventID, variables); err != nil {
+		return instance, err
+	}
+	return instance, nil
+}
+
+// createProcessInstanceFromDefinition loads definition and saves new instance of it without executing
+// Загружает определение и сохраняет его новый экземпляр без выполнения
+func (ps *ProcessStarter) createProcessInstanceFromDefinition(
+	ctx context.Context,
+	processKey string,
+	priority int,
+	variables map[string]interface{},
+) (*models.ProcessInstance, *models.BPMNProcess, error) {
 	if !ps.component.IsReady() {
-		return nil, fmt.Errorf("process component not ready")
+		return nil, nil, fmt.Errorf("process component not ready")
 	}
 
 	// Parse process key to get process ID and version
@@ -58,44 +170,213 @@ func (ps *ProcessStarter) StartProcessInstance(
 	// Load process definition from storage
 	processData, actualStorageKey, err := ps.loadProcessDefinition(processID, version)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load process definition: %w", err)
+		return nil, nil, fmt.Errorf("failed to load process definition: %w", err)
 	}
 
 	// Parse process definition
 	bpmnProcess, err := ps.parseProcessDefinition(processData, actualStorageKey)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse process definition: %w", err)
+		return nil, nil, fmt.Errorf("failed to parse process definition: %w", err)
 	}
 
 	// Create process instance
 	instance := ps.createProcessInstance(bpmnProcess, actualStorageKey, variables)
+	instance.Priority = priority
+	instance.TraceParent = tracing.TraceParentFromContext(ctx)
+	if businessKey := businessKeyFromContext(ctx); businessKey != "" {
+		instance.AddMetadata(models.BusinessKeyMetadataKey, businessKey)
+	}
 
 	// Save to storage first (sets InstanceID)
 	if err := ps.storage.SaveProcessInstance(instance); err != nil {
-		return nil, fmt.Errorf("failed to save process instance: %w", err)
+		return nil, nil, fmt.Errorf("failed to save process instance: %w", err)
 	}
 
 	logger.Info("Process instance created",
 		logger.String("instance_id", instance.InstanceID),
 		logger.String("process_id", instance.ProcessID),
 		logger.String("process_key", processKey),
-		logger.String("state", string(instance.State)))
+		logger.String("state", string(instance.State)),
+		logger.String("request_id", models.RequestIDFromContext(ctx)),
+		logger.String("trace_id", tracing.TraceIDFromContext(ctx)))
+
+	// Counted before execution, instance may complete synchronously
+	// Учитывается до выполнения, экземпляр может завершиться синхронно
+	metrics.Processes.InstanceStarted(instance.ProcessID, "")
+	ps.component.GetInstanceStatistics().InstanceStarted(instance)
+	ps.scheduleInstanceSLA(instance, bpmnProcess, models.InstanceSLAFromContext(ctx))
 
-	// Start execution
-	if err := ps.startExecution(instance, bpmnProcess, actualStorageKey, variables); err != nil {
+	return instance, bpmnProcess, nil
+}
+
+// executeProcessInstance places initial token of saved instance and runs it until first wait state
+// Ставит начальный токен сохраненного экземпляра и выполняет его до первого состояния ожидания
+func (ps *ProcessStarter) executeProcessInstance(
+	instance *models.ProcessInstance,
+	bpmnProcess *models.BPMNProcess,
+	processKey string,
+	startEventID string,
+	variables map[string]interface{},
+) error {
+	if err := ps.startExecution(instance, bpmnProcess, instance.ProcessKey, startEventID, variables); err != nil {
 		logger.Error("Failed to start process execution",
 			logger.String("instance_id", instance.InstanceID),
 			logger.String("error", err.Error()))
-		return instance, fmt.Errorf("failed to start process execution: %w", err)
+		return fmt.Errorf("failed to start process execution: %w", err)
 	}
 
 	logger.Info("Process instance started successfully",
 		logger.String("instance_id", instance.InstanceID),
 		logger.String("process_key", processKey))
+	return nil
+}
+
+// failProcessInstance moves instance whose background execution failed to FAILED state
+// Nobody waits for background start, so error is kept in instance metadata for polling clients
+// Переводит экземпляр, фоновое выполнение которого завершилось ошибкой, в состояние FAILED
+// Фоновый запуск никто не ожидает, поэтому ошибка сохраняется в метаданных для опрашивающих клиентов
+func (ps *ProcessStarter) failProcessInstance(instanceID string, cause error) {
+	instance, err := ps.storage.LoadProcessInstance(instanceID)
+	if err != nil {
+		logger.Error("Failed to load process instance after async start failure",
+			logger.String("instance_id", instanceID),
+			logger.String("error", err.Error()))
+		return
+	}
+	if instance.IsCompleted() {
+		return
+	}
 
+	instance.SetState(models.ProcessInstanceStateFailed)
+	instance.AddMetadata("start_error", cause.Error())
+	if err := ps.storage.UpdateProcessInstance(instance); err != nil {
+		logger.Error("Failed to mark process instance failed",
+			logger.String("instance_id", instanceID),
+			logger.String("error", err.Error()))
+		return
+	}
+	ps.component.GetInstanceStatistics().InstanceFinished(instance)
+	ps.component.CancelInstanceSLA(instance)
+}
+
+// scheduleInstanceSLA registers SLA timer of created instance, failure is logged and does not stop start
+// Регистрирует таймер SLA созданного экземпляра, ошибка логируется и не прерывает запуск
+func (ps *ProcessStarter) scheduleInstanceSLA(
+	instance *models.ProcessInstance,
+	bpmnProcess *models.BPMNProcess,
+	sla time.Duration,
+) {
+	if err := ps.component.ScheduleInstanceSLA(instance, bpmnProcess, sla); err != nil {
+		logger.Error("Failed to schedule process instance SLA",
+			logger.String("instance_id", instance.InstanceID),
+			logger.String("process_id", instance.ProcessID),
+			logger.String("error", err.Error()))
+	}
+}
+
+// restartableElementTypes are flow node types initial token of restarted instance or token
+// started by modification can be placed at
+// Boundary events are excluded, they exist only while attached activity runs
+// Типы flow node, на которые можно поставить начальный токен перезапущенного экземпляра
+// или токен, запущенный модификацией
+// Граничные события исключены, они существуют только пока выполняется активность
+var restartableElementTypes = map[string]bool{
+	"startEvent":             true,
+	"endEvent":               true,
+	"intermediateCatchEvent": true,
+	"intermediateThrowEvent": true,
+	"task":                   true,
+	"serviceTask":            true,
+	"userTask":               true,
+	"scriptTask":             true,
+	"sendTask":               true,
+	"receiveTask":            true,
+	"callActivity":           true,
+	"subProcess":             true,
+	"exclusiveGateway":       true,
+	"parallelGateway":        true,
+	"inclusiveGateway":       true,
+	"eventBasedGateway":      true,
+}
+
+// RestartProcessInstance creates new instance of same definition version as finished original
+// Variables of original are copied and initial token is placed at given element instead of start event
+// Создает новый экземпляр той же версии определения, что и завершенный исходный
+// Переменные исходного копируются, начальный токен ставится на заданный элемент вместо стартового события
+func (ps *ProcessStarter) RestartProcessInstance(
+	original *models.ProcessInstance,
+	elementID string,
+) (*models.ProcessInstance, error) {
+	if !ps.component.IsReady() {
+		return nil, fmt.Errorf("process component not ready")
+	}
+	if !original.IsCompleted() {
+		return nil, fmt.Errorf("%w: %s is %s",
+			models.ErrProcessInstanceNotFinished, original.InstanceID, original.State)
+	}
+
+	bpmnProcess, err := ps.bpmnHelper.LoadBPMNProcess(original.ProcessKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load process definition: %w", err)
+	}
+	if err := ps.validateRestartElement(bpmnProcess, elementID); err != nil {
+		return nil, err
+	}
+
+	instance := ps.createProcessInstance(bpmnProcess, original.ProcessKey, original.Variables)
+	instance.Priority = original.Priority
+	instance.ParentInstanceID = original.InstanceID
+
+	if err := ps.storage.SaveProcessInstance(instance); err != nil {
+		return nil, fmt.Errorf("failed to save process instance: %w", err)
+	}
+
+	logger.Info("Process instance restarted",
+		logger.String("instance_id", instance.InstanceID),
+		logger.String("parent_instance_id", original.InstanceID),
+		logger.String("process_key", instance.ProcessKey),
+		logger.String("element_id", elementID))
+
+	metrics.Processes.InstanceStarted(instance.ProcessID, "")
+	ps.component.GetInstanceStatistics().InstanceStarted(instance)
+	ps.scheduleInstanceSLA(instance, bpmnProcess, 0)
+
+	if err := ps.handleRegularStartEvent(instance, instance.ProcessKey, elementID); err != nil {
+		return instance, fmt.Errorf("failed to start process execution: %w", err)
+	}
 	return instance, nil
 }
 
+// validateRestartElement checks element is top-level flow node of process
+// Проверяет, что элемент является flow node верхнего уровня процесса
+func (ps *ProcessStarter) validateRestartElement(bpmnProcess *models.BPMNProcess, elementID string) error {
+	if err := checkTokenElement(bpmnProcess, elementID); err != nil {
+		return fmt.Errorf("%w: %s", models.ErrInvalidRestartElement, err.Error())
+	}
+	return nil
+}
+
+// checkTokenElement checks new token can be placed at element: it is top-level flow node of restartable type
+// Проверяет, что новый токен можно поставить на элемент: это flow node верхнего уровня допустимого типа
+func checkTokenElement(bpmnProcess *models.BPMNProcess, elementID string) error {
+	element, exists := bpmnProcess.Elements[elementID].(map[string]interface{})
+	if !exists {
+		return fmt.Errorf("element %s not found in process %s", elementID, bpmnProcess.ProcessID)
+	}
+
+	elementType, _ := element["type"].(string)
+	if !restartableElementTypes[elementType] {
+		return fmt.Errorf("element %s of type %s cannot hold token", elementID, elementType)
+	}
+
+	// Token of embedded subprocess element needs subprocess scope created by subprocess itself
+	// Токену элемента встроенного подпроцесса нужна область, создаваемая самим подпроцессом
+	if parentScope, _ := element["parent_scope"].(string); parentScope != "" && parentScope != bpmnProcess.ProcessID {
+		return fmt.Errorf("element %s is inside subprocess %s", elementID, parentScope)
+	}
+	return nil
+}
+
 // parseProcessKey parses process key to extract process ID and version
 // Парсит ключ процесса для извлечения ID процесса и версии
 func (ps *ProcessStarter) parseProcessKey(processKey string) (string, int) {
@@ -179,12 +460,18 @@ func (ps *ProcessStarter) startExecution(
 	instance *models.ProcessInstance,
 	bpmnProcess *models.BPMNProcess,
 	processKey string,
+	startEventID string,
 	variables map[string]interface{},
 ) error {
-	// Find start event
-	startEventID, err := ps.findStartEvent(bpmnProcess)
-	if err != nil {
-		return fmt.Errorf("failed to find start event: %w", err)
+	// Find start event unless it is given explicitly
+	if startEventID == "" {
+		var err error
+		startEventID, err = ps.findStartEvent(bpmnProcess)
+		if err != nil {
+			return fmt.Errorf("failed to find start event: %w", err)
+		}
+	} else if _, exists := bpmnProcess.Elements[startEventID]; !exists {
+		return fmt.Errorf("start event %s not found in process %s", startEventID, bpmnProcess.ProcessID)
 	}
 
 	// Check if start event is Message Start Event
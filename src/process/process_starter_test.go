@@ -0,0 +1,82 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"errors"
+	"testing"
+
+	"atom-engine/src/core/models"
+)
+
+func TestRestartProcessInstanceFromMidProcessTask(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("restart-mid-task", reviewApproveProcess))
+	original := e.start(processID, map[string]interface{}{"amount": 100, "status": "new"})
+	e.waitJob(original.InstanceID, "review")
+	if err := e.process.CancelProcessInstance(original.InstanceID, "approve failed"); err != nil {
+		t.Fatalf("cancel instance: %v", err)
+	}
+	e.waitState(original.InstanceID, models.ProcessInstanceStateCanceled)
+
+	// Data is fixed in restarted instance, original keeps its variables
+	// Данные исправляются в перезапущенном экземпляре, исходный сохраняет свои переменные
+	restarted, err := e.process.RestartProcessInstance(original.InstanceID, "approve")
+	if err != nil {
+		t.Fatalf("restart instance: %v", err)
+	}
+	if restarted.InstanceID == original.InstanceID || restarted.ParentInstanceID != original.InstanceID {
+		t.Fatalf("restarted instance %s has parent %q, want new instance linked to %s",
+			restarted.InstanceID, restarted.ParentInstanceID, original.InstanceID)
+	}
+	if restarted.ProcessKey != original.ProcessKey {
+		t.Errorf("restarted instance of %s, want same definition %s", restarted.ProcessKey, original.ProcessKey)
+	}
+
+	// Initial token is placed at approve task, review task and start event are skipped
+	// Начальный токен ставится на задачу approve, задача review и стартовое событие пропускаются
+	approve := e.waitJob(restarted.InstanceID, "approve")
+	if jobs := e.jobsOf(restarted.InstanceID, "review"); len(jobs) != 0 {
+		t.Errorf("restarted instance created %d review jobs", len(jobs))
+	}
+	if got := jsonOf(t, workerVariables(approve.Variables)); got != `{"amount":100,"status":"new"}` {
+		t.Errorf("variables of original not copied to job: %s", got)
+	}
+
+	e.completeJob(approve, map[string]interface{}{"status": "approved"})
+	e.waitState(restarted.InstanceID, models.ProcessInstanceStateCompleted)
+	if state := e.instance(original.InstanceID).State; state != models.ProcessInstanceStateCanceled {
+		t.Errorf("original instance in state %s after restart", state)
+	}
+}
+
+func TestRestartProcessInstanceRejectsRunningInstanceAndUnknownElement(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("restart-invalid", reviewApproveProcess))
+	instance := e.start(processID, nil)
+	review := e.waitJob(instance.InstanceID, "review")
+
+	if _, err := e.process.RestartProcessInstance(instance.InstanceID, "approve"); !errors.Is(err,
+		models.ErrProcessInstanceNotFinished) {
+		t.Errorf("restart of running instance: %v, want ErrProcessInstanceNotFinished", err)
+	}
+
+	e.completeJob(review, nil)
+	e.completeJob(e.waitJob(instance.InstanceID, "approve"), nil)
+	e.waitState(instance.InstanceID, models.ProcessInstanceStateCompleted)
+
+	for _, elementID := range []string{"missing", "f2"} {
+		if _, err := e.process.RestartProcessInstance(instance.InstanceID, elementID); !errors.Is(err,
+			models.ErrInvalidRestartElement) {
+			t.Errorf("restart from %s: %v, want ErrInvalidRestartElement", elementID, err)
+		}
+	}
+}
@@ -73,6 +73,7 @@ func (ps *ProcessStarter) handleRegularStartEvent(
 
 	token := models.NewToken(instance.InstanceID, processKey, startEventID)
 	token.SetVariables(instance.Variables) // Copy process variables to token
+	token.TraceParent = instance.TraceParent
 
 	logger.Info("Initial token created",
 		logger.String("token_id", token.TokenID),
@@ -10,6 +10,7 @@ package process
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"atom-engine/src/core/logger"
@@ -56,6 +57,15 @@ func (rte *ReceiveTaskExecutor) Execute(token *models.Token, element map[string]
 		// Продолжаем выполнение - создание boundary таймеров не критично
 	}
 
+	// Subscribe conditional boundary events when token enters activity
+	// Подписываем условные граничные события когда токен входит в активность
+	if err := createConditionalBoundaries(rte.processComponent, token); err != nil {
+		logger.Error("Failed to create conditional boundary subscriptions",
+			logger.String("token_id", token.TokenID),
+			logger.String("element_id", token.CurrentElementID),
+			logger.String("error", err.Error()))
+	}
+
 	// Create error boundary subscriptions when token enters activity
 	// Создаем подписки на граничные события ошибок когда токен входит в активность
 	if err := rte.createErrorBoundaries(token, element); err != nil {
@@ -110,7 +120,22 @@ func (rte *ReceiveTaskExecutor) Execute(token *models.Token, element map[string]
 
 	// Extract correlation key from message definition
 	// Извлекаем correlation key из определения сообщения
-	correlationKey := rte.extractCorrelationKeyFromMessage(token, messageName)
+	correlationKeyExpression := rte.extractCorrelationKeyFromMessage(token, messageName)
+	correlationKey, err := resolveCorrelationKey(rte.processComponent, correlationKeyExpression, token)
+	if err != nil {
+		logger.Error("Failed to resolve receive task correlation key",
+			logger.String("token_id", token.TokenID),
+			logger.String("correlation_key", correlationKeyExpression),
+			logger.String("error", err.Error()))
+		return &ExecutionResult{
+			Success:   false,
+			Error:     fmt.Sprintf("receive task: %v", err),
+			Completed: false,
+		}, nil
+	}
+	if !strings.HasPrefix(correlationKeyExpression, "=") {
+		correlationKeyExpression = ""
+	}
 
 	// Get outgoing flows for later use
 	// Получаем исходящие потоки для последующего использования
@@ -169,16 +194,19 @@ func (rte *ReceiveTaskExecutor) Execute(token *models.Token, element map[string]
 		// Create message subscription
 		// Создаем подписку на сообщение
 		subscription := &models.ProcessMessageSubscription{
-			ID:                   models.GenerateID(),
-			TenantID:             "DEFAULT_TENANT",
-			ProcessDefinitionKey: token.ProcessKey,
-			ProcessVersion:       int32(processVersion),  // Use actual version from ProcessKey
-			StartEventID:         token.CurrentElementID, // This is the receive task ID
-			MessageName:          messageName,
-			CorrelationKey:       correlationKey,
-			IsActive:             true,
-			CreatedAt:            time.Now(),
-			UpdatedAt:            time.Now(),
+			ID:                       models.GenerateID(),
+			TenantID:                 models.DefaultTenantID,
+			ProcessDefinitionKey:     token.ProcessKey,
+			ProcessVersion:           int32(processVersion),  // Use actual version from ProcessKey
+			StartEventID:             token.CurrentElementID, // This is the receive task ID
+			MessageName:              messageName,
+			CorrelationKey:           correlationKey,
+			CorrelationKeyExpression: correlationKeyExpression,
+			ProcessInstanceID:        token.ProcessInstanceID,
+			ElementInstanceKey:       token.Key,
+			IsActive:                 true,
+			CreatedAt:                time.Now(),
+			UpdatedAt:                time.Now(),
 		}
 
 		if err := rte.processComponent.CreateMessageSubscription(subscription); err != nil {
@@ -525,11 +553,11 @@ func (rte *ReceiveTaskExecutor) createBoundaryTimerForEvent(
 			}
 		}
 
-		// Create boundary timer via process component
-		// Создаем boundary таймер через process компонент
-		timerID, err := rte.processComponent.CreateBoundaryTimerWithID(timerRequest)
+		// Register boundary timer on the token and persist both atomically
+		// Регистрируем boundary таймер на токене и сохраняем оба атомарно
+		timerID, err := rte.processComponent.RegisterBoundaryTimer(token, timerRequest)
 		if err != nil {
-			return fmt.Errorf("failed to create boundary timer: %w", err)
+			return fmt.Errorf("failed to register boundary timer: %w", err)
 		}
 
 		logger.Info("Boundary timer created for receive task",
@@ -537,16 +565,6 @@ func (rte *ReceiveTaskExecutor) createBoundaryTimerForEvent(
 			logger.String("timer_id", timerID),
 			logger.String("event_id", eventID),
 			logger.String("activity_id", token.CurrentElementID))
-
-		// Associate boundary timer with parent token
-		// Связываем boundary таймер с родительским токеном
-		if err := rte.processComponent.LinkBoundaryTimerToToken(token.TokenID, timerID); err != nil {
-			logger.Error("Failed to link boundary timer to token",
-				logger.String("parent_token_id", token.TokenID),
-				logger.String("timer_id", timerID),
-				logger.String("error", err.Error()))
-			// Continue execution - linking is not critical
-		}
 	}
 
 	return nil
@@ -53,6 +53,15 @@ func (ste *SendTaskExecutor) Execute(token *models.Token, element map[string]int
 		// Продолжаем выполнение - создание boundary таймеров не критично
 	}
 
+	// Subscribe conditional boundary events when token enters activity
+	// Подписываем условные граничные события когда токен входит в активность
+	if err := createConditionalBoundaries(ste.processComponent, token); err != nil {
+		logger.Error("Failed to create conditional boundary subscriptions",
+			logger.String("token_id", token.TokenID),
+			logger.String("element_id", token.CurrentElementID),
+			logger.String("error", err.Error()))
+	}
+
 	// Create error boundary subscriptions when token enters activity
 	// Создаем подписки на граничные события ошибок когда токен входит в активность
 	if err := ste.createErrorBoundaries(token, element); err != nil {
@@ -67,11 +76,9 @@ func (ste *SendTaskExecutor) Execute(token *models.Token, element map[string]int
 	// Extract message information from send_task section
 	// Извлекаем информацию о сообщении из секции send_task
 	messageName := ""
-	logger.Info("DEBUG: Send task element data",
-		logger.Any("element", element))
-
+	log := ste.processComponent.TokenLogger(token)
 	if sendTaskData, exists := element["send_task"]; exists {
-		logger.Info("DEBUG: Found send_task data",
+		log.Debug("Send task data found",
 			logger.Any("send_task_data", sendTaskData))
 
 		if sendTaskMap, ok := sendTaskData.(map[string]interface{}); ok {
@@ -81,17 +88,17 @@ func (ste *SendTaskExecutor) Execute(token *models.Token, element map[string]int
 					logger.Info("Send task message name extracted from task_type",
 						logger.String("message_name", messageName))
 				} else {
-					logger.Warn("DEBUG: task_type is not string",
+					log.Warn("Send task task_type is not string",
 						logger.Any("task_type", taskType))
 				}
 			} else {
-				logger.Warn("DEBUG: task_type not found in send_task")
+				log.Debug("Send task has no task_type")
 			}
 		} else {
-			logger.Warn("DEBUG: send_task_data is not map[string]interface{}")
+			log.Warn("Send task data is not map[string]interface{}")
 		}
 	} else {
-		logger.Warn("DEBUG: send_task not found in element")
+		log.Warn("Send task data not found in element")
 	}
 
 	// Fallback: try to extract from messageRef if present
@@ -127,7 +134,7 @@ func (ste *SendTaskExecutor) Execute(token *models.Token, element map[string]int
 
 	// Publish message instantly through process component
 	// Мгновенно публикуем сообщение через process component
-	logger.Info("DEBUG: About to publish message",
+	log.Debug("Publishing message",
 		logger.String("message_name", messageName),
 		logger.String("correlation_key", correlationKey),
 		logger.Bool("has_process_component", ste.processComponent != nil))
@@ -447,11 +454,11 @@ func (ste *SendTaskExecutor) createBoundaryTimerForEvent(
 			}
 		}
 
-		// Create boundary timer via process component
-		// Создаем boundary таймер через process компонент
-		timerID, err := ste.processComponent.CreateBoundaryTimerWithID(timerRequest)
+		// Register boundary timer on the token and persist both atomically
+		// Регистрируем boundary таймер на токене и сохраняем оба атомарно
+		timerID, err := ste.processComponent.RegisterBoundaryTimer(token, timerRequest)
 		if err != nil {
-			return fmt.Errorf("failed to create boundary timer: %w", err)
+			return fmt.Errorf("failed to register boundary timer: %w", err)
 		}
 
 		logger.Info("Boundary timer created for send task",
@@ -459,16 +466,6 @@ func (ste *SendTaskExecutor) createBoundaryTimerForEvent(
 			logger.String("timer_id", timerID),
 			logger.String("event_id", eventID),
 			logger.String("activity_id", token.CurrentElementID))
-
-		// Associate boundary timer with parent token
-		// Связываем boundary таймер с родительским токеном
-		if err := ste.processComponent.LinkBoundaryTimerToToken(token.TokenID, timerID); err != nil {
-			logger.Error("Failed to link boundary timer to token",
-				logger.String("parent_token_id", token.TokenID),
-				logger.String("timer_id", timerID),
-				logger.String("error", err.Error()))
-			// Continue execution - linking is not critical
-		}
 	}
 
 	return nil
@@ -735,7 +732,7 @@ func (ste *SendTaskExecutor) evaluateTimerExpression(expression string, token *m
 		logger.String("token_id", token.TokenID),
 		logger.String("original_expression", expression),
 		logger.Any("evaluated_result", result),
-		logger.Any("token_variables", token.Variables))
+		logger.Variables("token_variables", token.Variables))
 
 	return result, nil
 }
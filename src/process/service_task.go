@@ -9,10 +9,15 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package process
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"atom-engine/src/core/logger"
 	"atom-engine/src/core/models"
+	"atom-engine/src/incidents"
 )
 
 // ServiceTaskExecutor executes service tasks
@@ -30,7 +35,11 @@ type JobComponentInterface interface {
 	CreateJobWithDetails(
 		jobType, processInstanceID, elementID string,
 		customHeaders map[string]string,
+		metadata map[string]string,
 		variables map[string]interface{},
+		priority int,
+		retries int,
+		retryTimeCycle string,
 	) (string, error)
 }
 
@@ -66,6 +75,15 @@ func (ste *ServiceTaskExecutor) Execute(token *models.Token, element map[string]
 		// Продолжаем выполнение - создание boundary таймеров не критично
 	}
 
+	// Subscribe conditional boundary events when token enters activity
+	// Подписываем условные граничные события когда токен входит в активность
+	if err := createConditionalBoundaries(ste.processComponent, token); err != nil {
+		logger.Error("Failed to create conditional boundary subscriptions",
+			logger.String("token_id", token.TokenID),
+			logger.String("element_id", token.CurrentElementID),
+			logger.String("error", err.Error()))
+	}
+
 	// Create error boundary subscriptions when token enters activity
 	// Создаем подписки на граничные события ошибок когда токен входит в активность
 	logger.Info("About to create error boundary subscriptions",
@@ -99,22 +117,72 @@ func (ste *ServiceTaskExecutor) Execute(token *models.Token, element map[string]
 		}, nil
 	}
 
+	// Job type starting with "=" is FEEL expression bound to instance variables when job is created
+	// Тип job'а, начинающийся с "=", - FEEL выражение, связываемое с переменными экземпляра при создании job'а
+	jobType, err := ste.resolveJobType(token, taskDefinition.Type)
+	if err != nil {
+		return nil, ste.handleJobTypeFailure(token, taskDefinition.Type, err)
+	}
+
+	// Job keeps expression it was routed by next to resolved type
+	// Job хранит выражение, по которому он был направлен, рядом с вычисленным типом
+	var jobMetadata map[string]string
+	if strings.HasPrefix(taskDefinition.Type, "=") {
+		jobMetadata = map[string]string{
+			models.JobMetadataTypeExpression: taskDefinition.Type,
+			models.JobMetadataResolvedType:   jobType,
+		}
+	}
+	taskDefinition.Type = jobType
+
 	logger.Info("Service task definition extracted",
 		logger.String("token_id", token.TokenID),
 		logger.String("task_name", taskName),
 		logger.String("job_type", taskDefinition.Type),
 		logger.Int("retries", taskDefinition.Retries))
 
-	// Extract custom headers from task definition
-	customHeaders := ste.extractCustomHeaders(element)
+	// Extract custom headers from task definition, header values starting with "=" are FEEL expressions
+	// Извлечение заголовков из определения задачи, значения, начинающиеся с "=", - FEEL выражения
+	customHeaders, err := ste.resolveCustomHeaders(token, ste.extractCustomHeaders(element))
+	if err != nil {
+		logger.Error("Failed to evaluate task headers",
+			logger.String("token_id", token.TokenID),
+			logger.String("element_id", token.CurrentElementID),
+			logger.String("error", err.Error()))
+		return &ExecutionResult{
+			Success:   false,
+			Error:     fmt.Sprintf("failed to evaluate task headers: %v", err),
+			Completed: false,
+		}, nil
+	}
 
-	// Add token ID to variables for job callback
-	jobVariables := make(map[string]interface{})
-	for k, v := range token.Variables {
-		jobVariables[k] = v
+	// Input mappings expose only computed variables to worker, otherwise worker gets all token variables
+	// Input маппинги передают worker'у только вычисленные переменные, иначе worker получает все переменные токена
+	jobVariables, err := ste.buildJobVariables(token, element)
+	if err != nil {
+		logger.Error("Failed to apply input mappings",
+			logger.String("token_id", token.TokenID),
+			logger.String("element_id", token.CurrentElementID),
+			logger.String("error", err.Error()))
+		return &ExecutionResult{
+			Success:   false,
+			Error:     fmt.Sprintf("failed to apply input mappings: %v", err),
+			Completed: false,
+		}, nil
 	}
+
+	// Add token ID to variables for job callback
 	jobVariables["_tokenID"] = token.TokenID
 
+	// Effective job priority is instance priority plus task priority
+	// Эффективный приоритет job равен приоритету экземпляра плюс приоритет задачи
+	jobPriority := taskDefinition.Priority
+	if ste.processComponent != nil {
+		if instance, err := ste.processComponent.GetProcessInstanceStatus(token.ProcessInstanceID); err == nil {
+			jobPriority += instance.Priority
+		}
+	}
+
 	// Get job component dynamically from process component
 	var jobComponent JobComponentInterface
 	if ste.processComponent != nil {
@@ -140,7 +208,11 @@ func (ste *ServiceTaskExecutor) Execute(token *models.Token, element map[string]
 			token.ProcessInstanceID,
 			token.CurrentElementID,
 			customHeaders,
+			jobMetadata,
 			jobVariables,
+			jobPriority,
+			taskDefinition.Retries,
+			taskDefinition.RetryTimeCycle,
 		)
 		if err != nil {
 			logger.Error("Failed to create job for service task",
@@ -159,7 +231,8 @@ func (ste *ServiceTaskExecutor) Execute(token *models.Token, element map[string]
 			logger.String("token_id", token.TokenID),
 			logger.String("task_name", taskName),
 			logger.String("job_id", jobID),
-			logger.String("job_type", taskDefinition.Type))
+			logger.String("job_type", taskDefinition.Type),
+			logger.Int("priority", jobPriority))
 
 		// Set token to wait for job completion
 		waitingFor := fmt.Sprintf("job:%s", jobID)
@@ -207,6 +280,117 @@ func (ste *ServiceTaskExecutor) Execute(token *models.Token, element map[string]
 	}, nil
 }
 
+// resolveJobType returns concrete job type, evaluating job type expression against token variables
+// Возвращает конкретный тип job'а, вычисляя выражение типа по переменным токена
+func (ste *ServiceTaskExecutor) resolveJobType(token *models.Token, source string) (string, error) {
+	if !strings.HasPrefix(source, "=") {
+		return source, nil
+	}
+
+	value, err := evaluateMappingSource(ste.processComponent, source, token.Variables)
+	if err != nil {
+		return "", err
+	}
+
+	jobType, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("job type expression %s returned %T, string expected", source, value)
+	}
+	jobType = strings.TrimSpace(jobType)
+	if jobType == "" {
+		return "", fmt.Errorf("job type expression %s returned empty string", source)
+	}
+
+	logger.Info("Job type resolved from expression",
+		logger.String("token_id", token.TokenID),
+		logger.String("element_id", token.CurrentElementID),
+		logger.String("expression", source),
+		logger.String("job_type", jobType))
+	return jobType, nil
+}
+
+// handleJobTypeFailure creates expression incident for job type that cannot be resolved, returns execution error
+// Создает инцидент выражения для типа job'а, который не удалось вычислить, возвращает ошибку выполнения
+func (ste *ServiceTaskExecutor) handleJobTypeFailure(token *models.Token, expression string, cause error) error {
+	logger.Error("Failed to resolve job type",
+		logger.String("token_id", token.TokenID),
+		logger.String("element_id", token.CurrentElementID),
+		logger.String("expression", expression),
+		logger.String("error", cause.Error()))
+
+	if err := ste.createJobTypeIncident(token, expression, cause); err != nil {
+		logger.Error("Failed to create job type incident",
+			logger.String("token_id", token.TokenID),
+			logger.String("element_id", token.CurrentElementID),
+			logger.String("error", err.Error()))
+	}
+
+	return fmt.Errorf("failed to resolve job type: %w", cause)
+}
+
+// createJobTypeIncident sends expression incident with root cause pointing at job type expression
+// Отправляет инцидент выражения с первопричиной, указывающей на выражение типа job'а
+func (ste *ServiceTaskExecutor) createJobTypeIncident(token *models.Token, expression string, cause error) error {
+	if ste.processComponent == nil || ste.processComponent.GetCore() == nil {
+		return fmt.Errorf("core interface not available")
+	}
+
+	rootCause := &incidents.RootCause{
+		ElementID:    token.CurrentElementID,
+		ElementType:  "serviceTask",
+		ErrorType:    incidents.RootCauseJobType,
+		ErrorMessage: cause.Error(),
+		Expression:   expression,
+		Target:       "type",
+	}
+	rootCause.AddEvent("job_type_failed", time.Now())
+
+	message, err := incidents.CreateIncidentMessage(incidents.CreateIncidentPayload{
+		Type:              "expression_error",
+		Message:           fmt.Sprintf("job type expression failed: %v", cause),
+		ProcessInstanceID: token.ProcessInstanceID,
+		ProcessKey:        token.ProcessKey,
+		ElementID:         token.CurrentElementID,
+		ElementType:       "serviceTask",
+		RootCause:         rootCause,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create incident message: %w", err)
+	}
+
+	if err := ste.processComponent.GetCore().SendMessage("incidents", message); err != nil {
+		return fmt.Errorf("failed to create job type incident: %w", err)
+	}
+	return nil
+}
+
+// buildJobVariables returns variables of job created for token
+// Возвращает переменные job'а, создаваемого для токена
+func (ste *ServiceTaskExecutor) buildJobVariables(
+	token *models.Token,
+	element map[string]interface{},
+) (map[string]interface{}, error) {
+	mappings := extractIOMappings(element, "inputs")
+	if len(mappings) == 0 {
+		jobVariables := make(map[string]interface{}, len(token.Variables)+1)
+		for k, v := range token.Variables {
+			jobVariables[k] = v
+		}
+		return jobVariables, nil
+	}
+
+	jobVariables, err := applyInputMappings(ste.processComponent, mappings, token.Variables)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Job input mappings applied",
+		logger.String("token_id", token.TokenID),
+		logger.String("element_id", token.CurrentElementID),
+		logger.Int("mappings", len(mappings)))
+	return jobVariables, nil
+}
+
 // GetElementType returns element type
 // Возвращает тип элемента
 func (ste *ServiceTaskExecutor) GetElementType() string {
@@ -216,8 +400,10 @@ func (ste *ServiceTaskExecutor) GetElementType() string {
 // TaskDefinition represents service task definition
 // Представляет определение сервисной задачи
 type TaskDefinition struct {
-	Type    string `json:"type"`
-	Retries int    `json:"retries"`
+	Type           string `json:"type"`
+	Retries        int    `json:"retries"`
+	Priority       int    `json:"priority"`
+	RetryTimeCycle string `json:"retry_time_cycle,omitempty"` // ISO 8601 repeating interval, e.g. "R3/PT10S"
 }
 
 // extractTaskDefinition extracts task definition from element
@@ -279,16 +465,17 @@ func (ste *ServiceTaskExecutor) extractTaskDefinition(element map[string]interfa
 				return nil, fmt.Errorf("task definition missing type")
 			}
 
-			retries := 3 // default retries
-			if retriesVal, exists := taskDefMap["retries"]; exists {
-				if retriesInt, ok := retriesVal.(int); ok {
-					retries = retriesInt
-				}
+			retries := parseTaskInt(taskDefMap["retries"])
+			if retries <= 0 {
+				retries = 3 // default retries
 			}
+			retryTimeCycle, _ := taskDefMap["retryTimeCycle"].(string)
 
 			return &TaskDefinition{
-				Type:    jobType,
-				Retries: retries,
+				Type:           jobType,
+				Retries:        retries,
+				Priority:       parseTaskInt(taskDefMap["priority"]),
+				RetryTimeCycle: retryTimeCycle,
 			}, nil
 		}
 	}
@@ -296,59 +483,110 @@ func (ste *ServiceTaskExecutor) extractTaskDefinition(element map[string]interfa
 	return nil, fmt.Errorf("taskDefinition not found in extension elements")
 }
 
-// extractCustomHeaders extracts custom headers from element
-// Извлекает пользовательские заголовки из элемента
+// parseTaskInt converts numeric attribute of task definition like priority or retries to int
+// Attribute may arrive as number or string depending on parser path and storage round trip
+// Конвертирует числовой атрибут определения задачи, например priority или retries, в int
+// Атрибут может прийти числом или строкой в зависимости от пути парсера и сохранения в хранилище
+func parseTaskInt(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	case string:
+		if number, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+			return number
+		}
+	}
+	return 0
+}
+
+// extractCustomHeaders extracts zeebe:taskHeaders of element as job custom headers
+// Извлекает zeebe:taskHeaders элемента как пользовательские заголовки job'а
 func (ste *ServiceTaskExecutor) extractCustomHeaders(element map[string]interface{}) map[string]string {
 	customHeaders := make(map[string]string)
 
-	// Look for extension elements with properties
-	extensionElements, exists := element["extension_elements"]
-	if !exists {
-		return customHeaders
-	}
-
-	extElementsList, ok := extensionElements.([]interface{})
+	extensionElements, ok := element["extension_elements"].([]interface{})
 	if !ok {
 		return customHeaders
 	}
 
-	for _, extElement := range extElementsList {
+	for _, extElement := range extensionElements {
 		extElementMap, ok := extElement.(map[string]interface{})
 		if !ok {
 			continue
 		}
-
-		extensions, exists := extElementMap["extensions"]
-		if !exists {
-			continue
-		}
-
-		extensionsList, ok := extensions.([]interface{})
+		extensions, ok := extElementMap["extensions"].([]interface{})
 		if !ok {
 			continue
 		}
 
-		for _, ext := range extensionsList {
+		for _, ext := range extensions {
 			extMap, ok := ext.(map[string]interface{})
+			if !ok || extMap["type"] != "taskHeaders" {
+				continue
+			}
+			taskHeaders, ok := extMap["task_headers"].(map[string]interface{})
 			if !ok {
 				continue
 			}
-
-			extType, exists := extMap["type"]
-			if !exists || extType != "properties" {
+			headers, ok := taskHeaders["headers"].([]interface{})
+			if !ok {
 				continue
 			}
 
-			// Extract properties as custom headers
-			// This would contain task headers if they existed in BPMN
-			logger.Debug("Found properties extension",
-				logger.String("extension_data", fmt.Sprintf("%v", extMap)))
+			for _, header := range headers {
+				headerMap, ok := header.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				key, _ := headerMap["key"].(string)
+				value, _ := headerMap["value"].(string)
+				if key == "" {
+					continue
+				}
+				customHeaders[key] = value
+			}
 		}
 	}
 
 	return customHeaders
 }
 
+// resolveCustomHeaders evaluates expression header values against token variables
+// Non-string results are passed to worker as JSON text
+// Вычисляет значения заголовков-выражений по переменным токена
+// Нестроковые результаты передаются worker'у как JSON текст
+func (ste *ServiceTaskExecutor) resolveCustomHeaders(
+	token *models.Token,
+	headers map[string]string,
+) (map[string]string, error) {
+	for key, source := range headers {
+		if !strings.HasPrefix(source, "=") {
+			continue
+		}
+
+		value, err := evaluateMappingSource(ste.processComponent, source, token.Variables)
+		if err != nil {
+			return nil, fmt.Errorf("header %s: %w", key, err)
+		}
+
+		switch v := value.(type) {
+		case nil:
+			headers[key] = ""
+		case string:
+			headers[key] = v
+		default:
+			data, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("header %s: failed to encode value: %w", key, err)
+			}
+			headers[key] = string(data)
+		}
+	}
+	return headers, nil
+}
+
 // createBoundaryTimers creates boundary timers for activity
 // Создает boundary таймеры для активности
 func (ste *ServiceTaskExecutor) createBoundaryTimers(token *models.Token, element map[string]interface{}) error {
@@ -550,11 +788,11 @@ func (ste *ServiceTaskExecutor) createBoundaryTimerForEvent(
 			}
 		}
 
-		// Create boundary timer via process component
-		// Создаем boundary таймер через process компонент
-		timerID, err := ste.processComponent.CreateBoundaryTimerWithID(timerRequest)
+		// Register boundary timer on the token and persist both atomically
+		// Регистрируем boundary таймер на токене и сохраняем оба атомарно
+		timerID, err := ste.processComponent.RegisterBoundaryTimer(token, timerRequest)
 		if err != nil {
-			return fmt.Errorf("failed to create boundary timer: %w", err)
+			return fmt.Errorf("failed to register boundary timer: %w", err)
 		}
 
 		logger.Info("Boundary timer created",
@@ -562,16 +800,6 @@ func (ste *ServiceTaskExecutor) createBoundaryTimerForEvent(
 			logger.String("timer_id", timerID),
 			logger.String("event_id", eventID),
 			logger.String("activity_id", token.CurrentElementID))
-
-		// Associate boundary timer with parent token
-		// Связываем boundary таймер с родительским токеном
-		if err := ste.processComponent.LinkBoundaryTimerToToken(token.TokenID, timerID); err != nil {
-			logger.Error("Failed to link boundary timer to token",
-				logger.String("parent_token_id", token.TokenID),
-				logger.String("timer_id", timerID),
-				logger.String("error", err.Error()))
-			// Continue execution - linking is not critical
-		}
 	}
 
 	return nil
@@ -835,7 +1063,7 @@ func (ste *ServiceTaskExecutor) evaluateTimerExpression(expression string, token
 		logger.String("token_id", token.TokenID),
 		logger.String("original_expression", expression),
 		logger.Any("evaluated_result", result),
-		logger.Any("token_variables", token.Variables))
+		logger.Variables("token_variables", token.Variables))
 
 	return result, nil
 }
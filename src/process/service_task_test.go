@@ -0,0 +1,94 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"testing"
+
+	"atom-engine/src/core/models"
+	"atom-engine/src/incidents"
+)
+
+// regionalProcess creates job of type computed from region variable
+// Создает job с типом, вычисленным из переменной region
+const regionalProcess = `
+    <bpmn:startEvent id="start"><bpmn:outgoing>f1</bpmn:outgoing></bpmn:startEvent>
+    <bpmn:sequenceFlow id="f1" sourceRef="start" targetRef="handle" />
+    <bpmn:serviceTask id="handle">
+      <bpmn:extensionElements><zeebe:taskDefinition type="= &quot;handler-&quot; + region" /></bpmn:extensionElements>
+      <bpmn:incoming>f1</bpmn:incoming><bpmn:outgoing>f2</bpmn:outgoing>
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="f2" sourceRef="handle" targetRef="end" />
+    <bpmn:endEvent id="end"><bpmn:incoming>f2</bpmn:incoming></bpmn:endEvent>`
+
+func TestServiceTaskJobTypeDerivedFromVariable(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("regional-handler", regionalProcess))
+	eu := e.start(processID, map[string]interface{}{"region": "eu"})
+	us := e.start(processID, map[string]interface{}{"region": "us"})
+
+	// Each instance binds job type to its own variables
+	// Каждый экземпляр связывает тип job'а со своими переменными
+	euJob := e.waitJob(eu.InstanceID, "handle")
+	usJob := e.waitJob(us.InstanceID, "handle")
+	if euJob.Type != "handler-eu" || usJob.Type != "handler-us" {
+		t.Fatalf("job types %q and %q, want handler-eu and handler-us", euJob.Type, usJob.Type)
+	}
+
+	activated := e.activate("handler-eu")
+	if len(activated) != 1 || activated[0].Key != euJob.Key {
+		t.Fatalf("worker of handler-eu activated %d jobs, want job of eu instance", len(activated))
+	}
+	if jobs := e.activate("= \"handler-\" + region"); len(jobs) != 0 {
+		t.Errorf("worker of unevaluated expression activated %d jobs", len(jobs))
+	}
+
+	e.completeJob(activated[0], nil)
+	e.waitState(eu.InstanceID, models.ProcessInstanceStateCompleted)
+	if state := e.instance(us.InstanceID).State; state != models.ProcessInstanceStateActive {
+		t.Errorf("us instance in state %s while its job is open", state)
+	}
+}
+
+func TestServiceTaskJobTypeExpressionWithoutStringRaisesIncident(t *testing.T) {
+	tests := []struct {
+		name      string
+		variables map[string]interface{}
+	}{
+		{"number", map[string]interface{}{"region": 7}},
+		{"missing variable", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestEngine(t)
+
+			processID := e.deploy(bpmnDefinitions("regional-invalid", regionalProcess))
+			instance, _ := e.process.StartProcessInstance(processID, tt.variables)
+			if instance == nil {
+				t.Fatal("instance not created")
+			}
+
+			var found []*incidents.Incident
+			e.waitFor("job type incident", func() bool {
+				found = e.incidentsOf(instance.InstanceID)
+				return len(found) == 1
+			})
+			rootCause := found[0].RootCause
+			if rootCause == nil || rootCause.ErrorType != incidents.RootCauseJobType ||
+				rootCause.ElementID != "handle" || rootCause.Expression != `= "handler-" + region` {
+				t.Errorf("root cause %+v, want job type expression of handle task", rootCause)
+			}
+			if jobs := e.jobsOf(instance.InstanceID, "handle"); len(jobs) != 0 {
+				t.Errorf("%d jobs created without resolved job type", len(jobs))
+			}
+		})
+	}
+}
@@ -342,6 +342,7 @@ func (spe *SubProcessExecutor) handleNoneStartEvent(
 	subprocessToken.Variables = subprocessVariables
 	subprocessToken.ParentTokenID = parentToken.TokenID
 	subprocessToken.SubProcessID = parentToken.CurrentElementID
+	subprocessToken.TraceParent = parentToken.TraceParent
 
 	logger.Info("Creating subprocess token for none start event",
 		logger.String("parent_token_id", parentToken.TokenID),
@@ -634,10 +635,10 @@ func (spe *SubProcessExecutor) createBoundaryTimerForEvent(
 			continue
 		}
 
-		// Create boundary timer via process component
-		timerID, err := spe.component.CreateBoundaryTimerWithID(timerRequest)
+		// Register boundary timer on the token and persist both atomically
+		timerID, err := spe.component.RegisterBoundaryTimer(token, timerRequest)
 		if err != nil {
-			return fmt.Errorf("failed to create boundary timer: %w", err)
+			return fmt.Errorf("failed to register boundary timer: %w", err)
 		}
 
 		logger.Info("Boundary timer created for subprocess",
@@ -645,14 +646,6 @@ func (spe *SubProcessExecutor) createBoundaryTimerForEvent(
 			logger.String("timer_id", timerID),
 			logger.String("event_id", eventID),
 			logger.String("subprocess_id", token.CurrentElementID))
-
-		// Associate boundary timer with parent token
-		if err := spe.component.LinkBoundaryTimerToToken(token.TokenID, timerID); err != nil {
-			logger.Error("Failed to link boundary timer to token",
-				logger.String("parent_token_id", token.TokenID),
-				logger.String("timer_id", timerID),
-				logger.String("error", err.Error()))
-		}
 	}
 
 	return nil
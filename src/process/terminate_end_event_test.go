@@ -0,0 +1,151 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"testing"
+	"time"
+
+	"atom-engine/src/core/models"
+)
+
+// terminatingParallelProcess forks into fast branch ending in terminate end event, slow task with
+// boundary timer and intermediate timer catch event
+// Разветвляется на быструю ветку с terminate end event, медленную задачу с boundary таймером
+// и промежуточное событие ожидания таймера
+const terminatingParallelProcess = `
+    <bpmn:startEvent id="start"><bpmn:outgoing>f1</bpmn:outgoing></bpmn:startEvent>
+    <bpmn:sequenceFlow id="f1" sourceRef="start" targetRef="fork" />
+    <bpmn:parallelGateway id="fork">
+      <bpmn:incoming>f1</bpmn:incoming>
+      <bpmn:outgoing>toFast</bpmn:outgoing><bpmn:outgoing>toSlow</bpmn:outgoing><bpmn:outgoing>toWait</bpmn:outgoing>
+    </bpmn:parallelGateway>
+    <bpmn:sequenceFlow id="toFast" sourceRef="fork" targetRef="fast" />
+    <bpmn:sequenceFlow id="toSlow" sourceRef="fork" targetRef="slow" />
+    <bpmn:sequenceFlow id="toWait" sourceRef="fork" targetRef="wait" />
+    <bpmn:serviceTask id="fast">
+      <bpmn:extensionElements><zeebe:taskDefinition type="fast-work" /></bpmn:extensionElements>
+      <bpmn:incoming>toFast</bpmn:incoming><bpmn:outgoing>f2</bpmn:outgoing>
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="f2" sourceRef="fast" targetRef="kill" />
+    <bpmn:endEvent id="kill">
+      <bpmn:incoming>f2</bpmn:incoming>
+      <bpmn:terminateEventDefinition id="killDefinition" />
+    </bpmn:endEvent>
+    <bpmn:serviceTask id="slow">
+      <bpmn:extensionElements><zeebe:taskDefinition type="slow-work" /></bpmn:extensionElements>
+      <bpmn:incoming>toSlow</bpmn:incoming><bpmn:outgoing>f3</bpmn:outgoing>
+    </bpmn:serviceTask>
+    <bpmn:sequenceFlow id="f3" sourceRef="slow" targetRef="slowDone" />
+    <bpmn:endEvent id="slowDone"><bpmn:incoming>f3</bpmn:incoming></bpmn:endEvent>
+    <bpmn:boundaryEvent id="slowTimeout" attachedToRef="slow">
+      <bpmn:outgoing>f4</bpmn:outgoing>
+      <bpmn:timerEventDefinition id="slowTimeoutDefinition">
+        <bpmn:timeDuration>PT1H</bpmn:timeDuration>
+      </bpmn:timerEventDefinition>
+    </bpmn:boundaryEvent>
+    <bpmn:sequenceFlow id="f4" sourceRef="slowTimeout" targetRef="slowTimedOut" />
+    <bpmn:endEvent id="slowTimedOut"><bpmn:incoming>f4</bpmn:incoming></bpmn:endEvent>
+    <bpmn:intermediateCatchEvent id="wait">
+      <bpmn:incoming>toWait</bpmn:incoming><bpmn:outgoing>f5</bpmn:outgoing>
+      <bpmn:timerEventDefinition id="waitDefinition">
+        <bpmn:timeDuration>PT2H</bpmn:timeDuration>
+      </bpmn:timerEventDefinition>
+    </bpmn:intermediateCatchEvent>
+    <bpmn:sequenceFlow id="f5" sourceRef="wait" targetRef="waited" />
+    <bpmn:endEvent id="waited"><bpmn:incoming>f5</bpmn:incoming></bpmn:endEvent>`
+
+func TestTerminateEndEventCancelsParallelBranches(t *testing.T) {
+	e := newTestEngine(t)
+
+	processID := e.deploy(bpmnDefinitions("terminate-parallel", terminatingParallelProcess))
+	instance := e.start(processID, nil)
+
+	fast := e.waitJob(instance.InstanceID, "fast")
+	e.waitJob(instance.InstanceID, "slow")
+
+	var timerIDs []string
+	e.waitFor("boundary and intermediate timers", func() bool {
+		timers, err := e.storage.LoadTimersByProcessInstance(instance.InstanceID)
+		if err != nil {
+			t.Fatalf("load timers: %v", err)
+		}
+		timerIDs = timerIDs[:0]
+		for _, timer := range timers {
+			timerIDs = append(timerIDs, timer.ID)
+		}
+		return len(timerIDs) == 2
+	})
+	for _, timerID := range timerIDs {
+		if _, _, found := e.timewheel.GetTimerInfo(timerID); !found {
+			t.Fatalf("timer %s not scheduled before terminate", timerID)
+		}
+	}
+
+	e.completeJob(fast, nil)
+	completed := e.waitState(instance.InstanceID, models.ProcessInstanceStateCompleted)
+	if terminatedBy, _ := completed.Metadata["terminated_by"].(string); terminatedBy != "kill" {
+		t.Errorf("terminated_by = %v, want kill", completed.Metadata["terminated_by"])
+	}
+
+	e.waitFor("sibling tokens canceled", func() bool {
+		for _, token := range e.tokens(instance.InstanceID) {
+			if token.IsActive() || token.IsWaiting() {
+				return false
+			}
+		}
+		return true
+	})
+	canceled := map[string]bool{}
+	for _, token := range e.tokens(instance.InstanceID) {
+		if token.State == models.TokenStateCanceled {
+			canceled[token.CurrentElementID] = true
+		}
+	}
+	for _, elementID := range []string{"slow", "wait"} {
+		if !canceled[elementID] {
+			t.Errorf("sibling token at %s not canceled, canceled: %v", elementID, canceled)
+		}
+	}
+
+	e.waitFor("slow job canceled", func() bool {
+		for _, job := range e.jobsOf(instance.InstanceID, "slow") {
+			if isOpenJob(job) {
+				return false
+			}
+		}
+		return true
+	})
+	if activated := e.activate("slow-work"); len(activated) != 0 {
+		t.Errorf("job of canceled branch activated: %+v", activated)
+	}
+
+	e.waitFor("timers removed from timewheel", func() bool {
+		for _, timerID := range timerIDs {
+			if _, _, found := e.timewheel.GetTimerInfo(timerID); found {
+				return false
+			}
+		}
+		return true
+	})
+
+	// Clock past both timers reaches no end event of canceled branches
+	// Часы после обоих таймеров не доводят отмененные ветки до конечных событий
+	e.advance(3 * time.Hour)
+	time.Sleep(100 * time.Millisecond)
+	for _, token := range e.tokens(instance.InstanceID) {
+		switch token.CurrentElementID {
+		case "slowDone", "slowTimedOut", "waited":
+			t.Errorf("canceled branch reached %s", token.CurrentElementID)
+		}
+	}
+	if state := e.instance(instance.InstanceID).State; state != models.ProcessInstanceStateCompleted {
+		t.Errorf("instance in state %s after timers, want completed", state)
+	}
+}
@@ -9,6 +9,8 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package process
 
 import (
+	"time"
+
 	"atom-engine/src/core/models"
 )
 
@@ -21,13 +23,19 @@ type TimerCallbackManagerInterface interface {
 
 	// Boundary timer operations
 	CreateBoundaryTimer(timerRequest *TimerRequest) error
-	CreateBoundaryTimerWithID(timerRequest *TimerRequest) (string, error)
-	LinkBoundaryTimerToToken(tokenID, timerID string) error
+	RegisterBoundaryTimer(token *models.Token, timerRequest *TimerRequest) (string, error)
 	CancelBoundaryTimersForToken(tokenID string) error
+	CancelBoundaryTimers(token *models.Token) error
 	CancelEventTimersForToken(tokenID string) error
 
 	// Process timer operations
 	CancelAllTimersForProcessInstance(instanceID string) error
+	ScheduleInstanceSLA(instance *models.ProcessInstance, bpmnProcess *models.BPMNProcess, sla time.Duration) error
+	CancelInstanceSLA(instance *models.ProcessInstance)
+
+	// Timer start event operations
+	SyncTimerStartEvents(processID string) error
+	SyncAllTimerStartEvents() error
 
 	// Helper operations
 	GetBPMNProcessForToken(token *models.Token) (map[string]interface{}, error)
@@ -0,0 +1,429 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+	"atom-engine/src/parser"
+	"atom-engine/src/storage"
+	"atom-engine/src/timewheel"
+)
+
+// TimerStartManager schedules timer start events of deployed process definitions
+// Only latest version of definition is scheduled, disabled and deleted definitions have no timers
+// Планирует стартовые события таймера развернутых определений процессов
+// Планируется только последняя версия определения, у выключенных и удаленных определений таймеров нет
+type TimerStartManager struct {
+	storage   storage.Storage
+	component ComponentInterface
+	core      CoreInterface
+	parser    *timewheel.ISO8601DurationParser
+	mu        sync.Mutex
+}
+
+// timerStartDefinition is timer start event that should be scheduled
+// Стартовое событие таймера, которое должно быть запланировано
+type timerStartDefinition struct {
+	storageKey string
+	process    *models.BPMNProcess
+	timers     map[string]*parser.StartEventTimer
+}
+
+// NewTimerStartManager creates new timer start manager
+// Создает новый менеджер стартовых событий таймера
+func NewTimerStartManager(storage storage.Storage, component ComponentInterface) *TimerStartManager {
+	return &TimerStartManager{
+		storage:   storage,
+		component: component,
+		parser:    timewheel.NewISO8601DurationParser(),
+	}
+}
+
+// SetCore sets core interface for timer management
+// Устанавливает интерфейс core для управления таймерами
+func (tsm *TimerStartManager) SetCore(core CoreInterface) {
+	tsm.core = core
+}
+
+// SyncTimerStartEvents aligns scheduled start timers with current state of process definition
+// Приводит запланированные стартовые таймеры в соответствие с текущим состоянием определения процесса
+func (tsm *TimerStartManager) SyncTimerStartEvents(processID string) error {
+	tsm.mu.Lock()
+	defer tsm.mu.Unlock()
+
+	return tsm.syncTimerStartEvents(processID)
+}
+
+// SyncAllTimerStartEvents aligns start timers of all deployed process definitions
+// Приводит в соответствие стартовые таймеры всех развернутых определений процессов
+func (tsm *TimerStartManager) SyncAllTimerStartEvents() error {
+	processes, err := tsm.storage.LoadAllBPMNProcesses()
+	if err != nil {
+		return fmt.Errorf("failed to load BPMN processes: %w", err)
+	}
+	timers, err := tsm.storage.LoadAllTimers()
+	if err != nil {
+		return fmt.Errorf("failed to load timers: %w", err)
+	}
+
+	// Process IDs of definitions and of existing start timers, latter covers deleted definitions
+	// ID процессов определений и существующих стартовых таймеров, последние покрывают удаленные определения
+	processIDs := make(map[string]bool)
+	for storageKey := range processes {
+		processIDs[processIDFromStorageKey(storageKey)] = true
+	}
+	for _, timer := range timers {
+		if timer.TimerType == string(models.TimerTypeStart) {
+			processIDs[processIDFromStorageKey(timerProcessKey(timer))] = true
+		}
+	}
+
+	tsm.mu.Lock()
+	defer tsm.mu.Unlock()
+
+	for processID := range processIDs {
+		if err := tsm.syncTimerStartEvents(processID); err != nil {
+			logger.Error("Failed to sync timer start events",
+				logger.String("process_id", processID),
+				logger.String("error", err.Error()))
+		}
+	}
+	return nil
+}
+
+// HandleStartTimerCallback starts process instance when start timer fires and schedules next cycle
+// Запускает экземпляр процесса при срабатывании стартового таймера и планирует следующий цикл
+func (tsm *TimerStartManager) HandleStartTimerCallback(
+	timerID, elementID string,
+	timerRecord *storage.TimerRecord,
+) error {
+	tsm.mu.Lock()
+	defer tsm.mu.Unlock()
+
+	storageKey := timerProcessKey(timerRecord)
+	processID := processIDFromStorageKey(storageKey)
+
+	definition, err := tsm.loadTimerStartDefinition(processID)
+	if err != nil {
+		return err
+	}
+
+	// Definition was redeployed, disabled or deleted after timer was scheduled
+	// Определение было переразвернуто, выключено или удалено после планирования таймера
+	if definition == nil || definition.storageKey != storageKey || definition.timers[elementID] == nil {
+		logger.Info("Skipping stale timer start event",
+			logger.String("timer_id", timerID),
+			logger.String("process_key", storageKey),
+			logger.String("element_id", elementID))
+		return tsm.syncTimerStartEvents(processID)
+	}
+
+	processKey := fmt.Sprintf("%s:%d", definition.process.ProcessID, definition.process.ProcessVersion)
+	instance, startErr := tsm.component.StartProcessInstanceAtStartEvent(processKey, elementID, nil)
+	if startErr != nil {
+		logger.Error("Failed to start process instance by timer start event",
+			logger.String("timer_id", timerID),
+			logger.String("process_key", processKey),
+			logger.String("element_id", elementID),
+			logger.String("error", startErr.Error()))
+	} else {
+		logger.Info("Process instance started by timer start event",
+			logger.String("timer_id", timerID),
+			logger.String("instance_id", instance.InstanceID),
+			logger.String("element_id", elementID))
+	}
+
+	// Next cycle is scheduled even if start failed so that schedule keeps running
+	// Следующий цикл планируется даже при ошибке запуска, чтобы расписание не прерывалось
+	if timerRecord.TimeCycle != nil {
+		if err := tsm.scheduleNextCycle(timerID, elementID, definition, *timerRecord.TimeCycle); err != nil {
+			return err
+		}
+	}
+
+	if startErr != nil {
+		return fmt.Errorf("failed to start process instance: %w", startErr)
+	}
+	return nil
+}
+
+// syncTimerStartEvents cancels stale start timers and schedules missing ones, caller holds mutex
+// Отменяет устаревшие стартовые таймеры и планирует недостающие, мьютекс удерживает вызывающий
+func (tsm *TimerStartManager) syncTimerStartEvents(processID string) error {
+	definition, err := tsm.loadTimerStartDefinition(processID)
+	if err != nil {
+		return err
+	}
+
+	timers, err := tsm.startTimersForProcess(processID)
+	if err != nil {
+		return err
+	}
+
+	for _, timer := range timers {
+		if timer.State != "SCHEDULED" {
+			continue
+		}
+		if definition != nil && timerProcessKey(timer) == definition.storageKey &&
+			definition.timers[timer.ElementID] != nil {
+			continue
+		}
+		if err := tsm.cancelTimer(timer.ID); err != nil {
+			logger.Error("Failed to cancel stale start timer",
+				logger.String("timer_id", timer.ID),
+				logger.String("error", err.Error()))
+			continue
+		}
+		logger.Info("Start timer cancelled",
+			logger.String("timer_id", timer.ID),
+			logger.String("process_key", timerProcessKey(timer)),
+			logger.String("element_id", timer.ElementID))
+	}
+
+	if definition == nil {
+		return nil
+	}
+
+	for elementID, timerDefinition := range definition.timers {
+		if tsm.isStartTimerCovered(timers, definition.storageKey, elementID) {
+			continue
+		}
+		if err := tsm.scheduleStartTimer(definition, elementID, timerDefinition); err != nil {
+			logger.Error("Failed to schedule timer start event",
+				logger.String("process_key", definition.storageKey),
+				logger.String("element_id", elementID),
+				logger.String("error", err.Error()))
+		}
+	}
+	return nil
+}
+
+// loadTimerStartDefinition loads latest definition and its timer start events
+// Returns nil when definition is deleted or its timer start events are disabled
+// Загружает последнюю версию определения и его стартовые события таймера
+// Возвращает nil, если определение удалено или его стартовые события таймера выключены
+func (tsm *TimerStartManager) loadTimerStartDefinition(processID string) (*timerStartDefinition, error) {
+	data, storageKey, err := tsm.storage.LoadBPMNProcessByProcessID(processID, -1)
+	if err != nil {
+		return nil, nil
+	}
+
+	var bpmnProcess models.BPMNProcess
+	if err := json.Unmarshal(data, &bpmnProcess); err != nil {
+		return nil, fmt.Errorf("failed to parse process definition %s: %w", storageKey, err)
+	}
+	if bpmnProcess.TimerStartDisabled {
+		return nil, nil
+	}
+
+	definition := &timerStartDefinition{
+		storageKey: storageKey,
+		process:    &bpmnProcess,
+		timers:     make(map[string]*parser.StartEventTimer),
+	}
+	for _, startEvent := range parser.ExtractStartEvents(bpmnProcess.Elements) {
+		if startEvent.TriggerType == parser.StartTriggerTimer && startEvent.Timer != nil {
+			definition.timers[startEvent.ElementID] = startEvent.Timer
+		}
+	}
+	return definition, nil
+}
+
+// startTimersForProcess returns start timers of all versions of process
+// Возвращает стартовые таймеры всех версий процесса
+func (tsm *TimerStartManager) startTimersForProcess(processID string) ([]*storage.TimerRecord, error) {
+	allTimers, err := tsm.storage.LoadAllTimers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timers: %w", err)
+	}
+
+	var timers []*storage.TimerRecord
+	for _, timer := range allTimers {
+		if timer.TimerType == string(models.TimerTypeStart) &&
+			processIDFromStorageKey(timerProcessKey(timer)) == processID {
+			timers = append(timers, timer)
+		}
+	}
+	return timers, nil
+}
+
+// isStartTimerCovered checks whether start event already has scheduled timer or finished its schedule
+// Cycle is finished when its last repetition fired, date and duration when timer fired once
+// Проверяет, есть ли у стартового события запланированный таймер или завершено ли его расписание
+// Цикл завершен после срабатывания последнего повторения, дата и длительность - после первого срабатывания
+func (tsm *TimerStartManager) isStartTimerCovered(
+	timers []*storage.TimerRecord,
+	storageKey, elementID string,
+) bool {
+	for _, timer := range timers {
+		if timerProcessKey(timer) != storageKey || timer.ElementID != elementID {
+			continue
+		}
+		if timer.State == "SCHEDULED" || timer.TimeCycle == nil {
+			return true
+		}
+		if cycle, err := tsm.parser.ParseTimeCycle(*timer.TimeCycle); err == nil &&
+			cycle.RepeatCount >= 0 && cycle.RepeatCount <= 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// scheduleNextCycle schedules next repetition of cycle unless it is exhausted or already scheduled
+// Планирует следующее повторение цикла, если оно не исчерпано и еще не запланировано
+func (tsm *TimerStartManager) scheduleNextCycle(
+	timerID, elementID string,
+	definition *timerStartDefinition,
+	cycle string,
+) error {
+	timeCycle, err := tsm.parser.ParseTimeCycle(cycle)
+	if err != nil {
+		return fmt.Errorf("failed to parse time cycle %s: %w", cycle, err)
+	}
+	repeatCount := timeCycle.RepeatCount
+	if repeatCount >= 0 && repeatCount <= 1 {
+		logger.Info("Timer start cycle finished",
+			logger.String("process_key", definition.storageKey),
+			logger.String("element_id", elementID))
+		return nil
+	}
+
+	timers, err := tsm.startTimersForProcess(definition.process.ProcessID)
+	if err != nil {
+		return err
+	}
+	for _, timer := range timers {
+		if timer.ID != timerID && timer.State == "SCHEDULED" &&
+			timerProcessKey(timer) == definition.storageKey && timer.ElementID == elementID {
+			return nil
+		}
+	}
+
+	// Remaining repetitions are kept in cycle itself so that they survive restart, cron never runs out
+	// Оставшиеся повторения хранятся в самом цикле, чтобы пережить перезапуск, cron не исчерпывается
+	nextCycle := cycle
+	if repeatCount > 1 {
+		nextCycle = fmt.Sprintf("R%d/%s", repeatCount-1, cycle[strings.Index(cycle, "/")+1:])
+	}
+
+	logger.Debug("Scheduling next timer start cycle",
+		logger.String("process_key", definition.storageKey),
+		logger.String("element_id", elementID),
+		logger.String("time_cycle", nextCycle))
+
+	return tsm.scheduleStartTimer(definition, elementID, &parser.StartEventTimer{Type: "cycle", Value: nextCycle})
+}
+
+// scheduleStartTimer schedules START timer for start event in timewheel
+// Планирует START таймер стартового события в timewheel
+func (tsm *TimerStartManager) scheduleStartTimer(
+	definition *timerStartDefinition,
+	elementID string,
+	timerDefinition *parser.StartEventTimer,
+) error {
+	value := strings.TrimSpace(timerDefinition.Value)
+	if strings.HasPrefix(value, "=") {
+		return fmt.Errorf("expression timer %s is not supported for start events", value)
+	}
+
+	twRequest := timewheel.TimerRequest{
+		ElementID: elementID,
+		TimerType: models.TimerTypeStart,
+		ProcessContext: &models.TimerProcessContext{
+			ProcessKey:      definition.storageKey,
+			ProcessVersion:  definition.process.ProcessVersion,
+			ProcessName:     definition.process.ProcessName,
+			ComponentSource: "process",
+		},
+	}
+
+	switch timerDefinition.Type {
+	case "cycle":
+		if _, err := tsm.parser.ParseTimeCycle(value); err != nil {
+			return fmt.Errorf("unsupported time cycle %s: %w", value, err)
+		}
+		twRequest.TimeCycle = &value
+	case "date":
+		twRequest.TimeDate = &value
+	case "duration":
+		twRequest.TimeDuration = &value
+	default:
+		return fmt.Errorf("unknown timer type %s", timerDefinition.Type)
+	}
+
+	messageJSON, err := timewheel.CreateScheduleTimerMessage(twRequest)
+	if err != nil {
+		return fmt.Errorf("failed to create start timer message: %w", err)
+	}
+	if err := tsm.processTimewheelMessage(messageJSON); err != nil {
+		return fmt.Errorf("failed to process start timer message: %w", err)
+	}
+
+	logger.Info("Timer start event scheduled",
+		logger.String("process_key", definition.storageKey),
+		logger.String("element_id", elementID),
+		logger.String("timer_type", timerDefinition.Type),
+		logger.String("timer_value", value))
+	return nil
+}
+
+// cancelTimer cancels timer in timewheel and removes it from storage
+// Отменяет таймер в timewheel и удаляет его из storage
+func (tsm *TimerStartManager) cancelTimer(timerID string) error {
+	cancelMessage, err := timewheel.CreateCancelTimerMessage(timerID)
+	if err != nil {
+		return fmt.Errorf("failed to create cancel timer message: %w", err)
+	}
+	return tsm.processTimewheelMessage(cancelMessage)
+}
+
+// processTimewheelMessage sends message to timewheel component
+// Отправляет сообщение в timewheel компонент
+func (tsm *TimerStartManager) processTimewheelMessage(messageJSON string) error {
+	if tsm.core == nil {
+		return fmt.Errorf("core interface not set")
+	}
+
+	timewheelComp := tsm.core.GetTimewheelComponentInterface()
+	if timewheelComp == nil {
+		return fmt.Errorf("timewheel component not available")
+	}
+
+	processMsgMethod, ok := timewheelComp.(interface {
+		ProcessMessage(context.Context, string) error
+	})
+	if !ok {
+		return fmt.Errorf("timewheel component does not support ProcessMessage")
+	}
+	return processMsgMethod.ProcessMessage(context.Background(), messageJSON)
+}
+
+// timerProcessKey returns process storage key saved in timer process context
+// Возвращает ключ хранения процесса, сохраненный в контексте процесса таймера
+func timerProcessKey(timer *storage.TimerRecord) string {
+	processKey, _ := timer.ProcessContext["process_key"].(string)
+	return processKey
+}
+
+// processIDFromStorageKey extracts BPMN process ID from storage key "ProcessID:vN"
+// Извлекает BPMN ID процесса из ключа хранения "ProcessID:vN"
+func processIDFromStorageKey(storageKey string) string {
+	if idx := strings.LastIndex(storageKey, ":v"); idx > 0 {
+		return storageKey[:idx]
+	}
+	return storageKey
+}
@@ -0,0 +1,143 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"testing"
+	"time"
+
+	"atom-engine/src/core/models"
+)
+
+// everyMinuteProcess is started three times by short-cycle timer start event
+// Запускается три раза стартовым событием таймера с коротким циклом
+const everyMinuteProcess = `
+    <bpmn:startEvent id="everyMinute">
+      <bpmn:outgoing>f1</bpmn:outgoing>
+      <bpmn:timerEventDefinition id="everyMinuteDefinition">
+        <bpmn:timeCycle>R3/PT1M</bpmn:timeCycle>
+      </bpmn:timerEventDefinition>
+    </bpmn:startEvent>
+    <bpmn:sequenceFlow id="f1" sourceRef="everyMinute" targetRef="done" />
+    <bpmn:endEvent id="done"><bpmn:incoming>f1</bpmn:incoming></bpmn:endEvent>`
+
+// followDefinitionEvents syncs timer start events on definition events the way core does
+// Синхронизирует стартовые события таймера по событиям определений так же, как core
+func (e *testEngine) followDefinitionEvents() {
+	e.parser.SetEventPublisher(func(event models.EngineEvent) {
+		if err := e.process.SyncTimerStartEvents(event.ProcessID); err != nil {
+			e.t.Errorf("sync timer start events of %s: %v", event.ProcessID, err)
+		}
+	})
+}
+
+// instancesOf returns number of instances of process definition
+// Возвращает количество экземпляров определения процесса
+func (e *testEngine) instancesOf(processID string) int {
+	e.t.Helper()
+
+	instances, err := e.process.ListProcessInstances("", "", 1000)
+	if err != nil {
+		e.t.Fatalf("list instances: %v", err)
+	}
+	count := 0
+	for _, instance := range instances {
+		if instance.ProcessID == processID {
+			count++
+		}
+	}
+	return count
+}
+
+// scheduledStartTimers returns number of scheduled start timers of process
+// Возвращает количество запланированных стартовых таймеров процесса
+func (e *testEngine) scheduledStartTimers(processID string) int {
+	e.t.Helper()
+
+	timers, err := e.storage.LoadAllTimers()
+	if err != nil {
+		e.t.Fatalf("load timers: %v", err)
+	}
+	count := 0
+	for _, timer := range timers {
+		if timer.TimerType == string(models.TimerTypeStart) && timer.State == "SCHEDULED" &&
+			processIDFromStorageKey(timerProcessKey(timer)) == processID {
+			count++
+		}
+	}
+	return count
+}
+
+func TestTimerStartEventStartsInstanceEachCycle(t *testing.T) {
+	e := newTestEngine(t)
+	e.followDefinitionEvents()
+
+	processID := e.deploy(bpmnDefinitions("every-minute", everyMinuteProcess))
+	if scheduled := e.scheduledStartTimers(processID); scheduled != 1 {
+		t.Fatalf("%d start timers scheduled on deployment, want 1", scheduled)
+	}
+
+	e.advance(30 * time.Second)
+	if count := e.instancesOf(processID); count != 0 {
+		t.Fatalf("%d instances started before first cycle", count)
+	}
+
+	for cycle := 1; cycle <= 3; cycle++ {
+		e.advance(time.Minute)
+		e.waitFor("instance of cycle", func() bool { return e.instancesOf(processID) == cycle })
+	}
+
+	// Cycle of three repetitions is exhausted, no further instances are started
+	// Цикл из трех повторений исчерпан, новые экземпляры не запускаются
+	if scheduled := e.scheduledStartTimers(processID); scheduled != 0 {
+		t.Errorf("%d start timers scheduled after last repetition", scheduled)
+	}
+	e.advance(5 * time.Minute)
+	if count := e.instancesOf(processID); count != 3 {
+		t.Errorf("%d instances started after cycle finished, want 3", count)
+	}
+}
+
+func TestTimerStartEventDisabledAndDeletedDefinitionStopsSchedule(t *testing.T) {
+	e := newTestEngine(t)
+	e.followDefinitionEvents()
+
+	processID := e.deploy(bpmnDefinitions("every-minute-toggle", everyMinuteProcess))
+	processKey := processID + ":v1"
+	e.advance(time.Minute)
+	e.waitFor("first instance", func() bool { return e.instancesOf(processID) == 1 })
+
+	if _, err := e.parser.SetTimerStartEnabled(processKey, false); err != nil {
+		t.Fatalf("disable timer start: %v", err)
+	}
+	if scheduled := e.scheduledStartTimers(processID); scheduled != 0 {
+		t.Fatalf("%d start timers scheduled for disabled definition", scheduled)
+	}
+	e.advance(2 * time.Minute)
+	if count := e.instancesOf(processID); count != 1 {
+		t.Fatalf("%d instances started while timer start was disabled, want 1", count)
+	}
+
+	if _, err := e.parser.SetTimerStartEnabled(processKey, true); err != nil {
+		t.Fatalf("enable timer start: %v", err)
+	}
+	e.advance(time.Minute)
+	e.waitFor("instance after enabling", func() bool { return e.instancesOf(processID) == 2 })
+
+	if err := e.parser.DeleteBPMNProcess(processKey); err != nil {
+		t.Fatalf("delete definition: %v", err)
+	}
+	if scheduled := e.scheduledStartTimers(processID); scheduled != 0 {
+		t.Fatalf("%d start timers scheduled for deleted definition", scheduled)
+	}
+	e.advance(5 * time.Minute)
+	if count := e.instancesOf(processID); count != 2 {
+		t.Errorf("%d instances started after definition was deleted, want 2", count)
+	}
+}
@@ -0,0 +1,182 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+)
+
+// TokenExecutionStats is snapshot of token execution pool state
+// Снимок состояния пула выполнения токенов
+type TokenExecutionStats struct {
+	Workers       int   `json:"workers"`
+	InFlight      int64 `json:"in_flight"`
+	QueueDepth    int   `json:"queue_depth"`
+	QueueCapacity int   `json:"queue_capacity"`
+	Executed      int64 `json:"executed"`
+	CallerRuns    int64 `json:"caller_runs"`
+}
+
+// TokenExecutionPool executes asynchronously spawned tokens on bounded number of workers
+// When queue is full token runs in submitting goroutine, which slows down fan-out producer
+// Выполняет асинхронно порожденные токены на ограниченном числе воркеров
+// При заполненной очереди токен выполняется в отправляющей горутине, замедляя источник
+type TokenExecutionPool struct {
+	execute func(token *models.Token) error
+	queue   chan *models.Token
+	workers int
+
+	inFlight   int64
+	executed   int64
+	callerRuns int64
+
+	mu      sync.RWMutex
+	running bool
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewTokenExecutionPool creates token execution pool
+// Создает пул выполнения токенов
+func NewTokenExecutionPool(workers, queueSize int, execute func(token *models.Token) error) *TokenExecutionPool {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 0 {
+		queueSize = 0
+	}
+
+	return &TokenExecutionPool{
+		execute: execute,
+		queue:   make(chan *models.Token, queueSize),
+		workers: workers,
+	}
+}
+
+// Start starts pool workers
+// Запускает воркеры пула
+func (p *TokenExecutionPool) Start() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.running {
+		return
+	}
+	p.running = true
+	p.stop = make(chan struct{})
+
+	for i := 0; i < p.workers; i++ {
+		p.wg.Add(1)
+		go p.worker(p.stop)
+	}
+
+	logger.Info("Token execution pool started",
+		logger.Int("workers", p.workers),
+		logger.Int("queue_size", cap(p.queue)))
+}
+
+// Stop stops pool workers and waits for running executions
+// Queued tokens stay persisted in storage and are not executed
+// Останавливает воркеры пула и ожидает текущие выполнения
+// Токены в очереди остаются сохраненными в storage и не выполняются
+func (p *TokenExecutionPool) Stop() {
+	p.mu.Lock()
+	if !p.running {
+		p.mu.Unlock()
+		return
+	}
+	p.running = false
+	close(p.stop)
+	p.mu.Unlock()
+
+	p.wg.Wait()
+
+	if pending := len(p.queue); pending > 0 {
+		logger.Warn("Token execution pool stopped with queued tokens",
+			logger.Int("queued", pending))
+	}
+	logger.Info("Token execution pool stopped")
+}
+
+// Submit schedules token execution
+// Runs token in caller goroutine when queue is full or pool is not running
+// Планирует выполнение токена
+// Выполняет токен в вызывающей горутине при заполненной очереди или остановленном пуле
+func (p *TokenExecutionPool) Submit(token *models.Token) {
+	p.mu.RLock()
+	if p.running {
+		select {
+		case p.queue <- token:
+			p.mu.RUnlock()
+			return
+		default:
+		}
+	}
+	p.mu.RUnlock()
+
+	atomic.AddInt64(&p.callerRuns, 1)
+	logger.Debug("Token execution queue full, executing in caller",
+		logger.String("token_id", token.TokenID),
+		logger.Int("queue_size", cap(p.queue)))
+	p.run(token)
+}
+
+// Stats returns current pool state
+// Возвращает текущее состояние пула
+func (p *TokenExecutionPool) Stats() TokenExecutionStats {
+	return TokenExecutionStats{
+		Workers:       p.workers,
+		InFlight:      atomic.LoadInt64(&p.inFlight),
+		QueueDepth:    len(p.queue),
+		QueueCapacity: cap(p.queue),
+		Executed:      atomic.LoadInt64(&p.executed),
+		CallerRuns:    atomic.LoadInt64(&p.callerRuns),
+	}
+}
+
+// worker executes queued tokens until pool is stopped
+// Выполняет токены из очереди до остановки пула
+func (p *TokenExecutionPool) worker(stop <-chan struct{}) {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case token := <-p.queue:
+			p.run(token)
+		}
+	}
+}
+
+// run executes single token with panic protection
+// Выполняет один токен с защитой от паники
+func (p *TokenExecutionPool) run(token *models.Token) {
+	atomic.AddInt64(&p.inFlight, 1)
+	defer func() {
+		atomic.AddInt64(&p.inFlight, -1)
+		atomic.AddInt64(&p.executed, 1)
+		if r := recover(); r != nil {
+			logger.Error("Panic in token execution",
+				logger.String("token_id", token.TokenID),
+				logger.Any("panic", r))
+		}
+	}()
+
+	if err := p.execute(token); err != nil {
+		logger.Error("Failed to execute token",
+			logger.String("token_id", token.TokenID),
+			logger.String("element_id", token.CurrentElementID),
+			logger.String("error", err.Error()))
+	}
+}
@@ -39,6 +39,12 @@ func NewTokenMovement(storage storage.Storage, component ComponentInterface) *To
 // MoveTokenToNextElements moves token to next elements using outgoing flows
 // Перемещает токен к следующим элементам используя outgoing flows
 func (tm *TokenMovement) MoveTokenToNextElements(token *models.Token, currentElementID string) error {
+	// Instance of multi-instance activity does not leave it, body token does
+	// Экземпляр multi-instance активности не покидает ее, это делает токен тела
+	if isMultiInstanceInstance(token) {
+		return tm.component.CompleteMultiInstanceInstance(token)
+	}
+
 	// Load process elements
 	elements, err := tm.bpmnHelper.LoadProcessElements(token.ProcessKey)
 	if err != nil {
@@ -73,7 +79,7 @@ func (tm *TokenMovement) CompleteToken(token *models.Token) error {
 		logger.String("token_id", token.TokenID))
 
 	// Cancel boundary timers for completing token
-	if err := tm.component.CancelBoundaryTimersForToken(token.TokenID); err != nil {
+	if err := tm.component.CancelBoundaryTimers(token); err != nil {
 		logger.Error("Failed to cancel boundary timers for completing token",
 			logger.String("token_id", token.TokenID),
 			logger.String("error", err.Error()))
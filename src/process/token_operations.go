@@ -92,6 +92,7 @@ func (to *TokenOperations) MergeTokens(tokenIDs []string, targetElementID string
 	// Create new merged token
 	mergedToken := models.NewToken(processInstanceID, processKey, targetElementID)
 	mergedToken.SetVariables(mergedVariables)
+	mergedToken.TraceParent = tokens[0].TraceParent
 
 	if err := to.storage.SaveToken(mergedToken); err != nil {
 		return nil, fmt.Errorf("failed to save merged token: %w", err)
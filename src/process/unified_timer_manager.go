@@ -11,6 +11,7 @@ package process
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"atom-engine/src/core/logger"
 	"atom-engine/src/core/models"
@@ -25,6 +26,8 @@ type UnifiedTimerManager struct {
 	component            ComponentInterface
 	timerCallbacks       *TimerCallbacks
 	boundaryTimerManager *BoundaryTimerManager
+	timerStartManager    *TimerStartManager
+	instanceSLAManager   *InstanceSLAManager
 	bpmnHelper           *BPMNHelper
 }
 
@@ -36,6 +39,8 @@ func NewUnifiedTimerManager(storage storage.Storage, component ComponentInterfac
 		component:            component,
 		timerCallbacks:       NewTimerCallbacks(storage, component),
 		boundaryTimerManager: NewBoundaryTimerManager(storage, component),
+		timerStartManager:    NewTimerStartManager(storage, component),
+		instanceSLAManager:   NewInstanceSLAManager(storage, component),
 		bpmnHelper:           NewBPMNHelper(storage),
 	}
 }
@@ -45,6 +50,8 @@ func NewUnifiedTimerManager(storage storage.Storage, component ComponentInterfac
 func (utm *UnifiedTimerManager) SetCore(core CoreInterface) {
 	utm.timerCallbacks.SetCore(core)
 	utm.boundaryTimerManager.SetCore(core)
+	utm.timerStartManager.SetCore(core)
+	utm.instanceSLAManager.SetCore(core)
 }
 
 // Init initializes unified timer manager
@@ -80,6 +87,10 @@ func (utm *UnifiedTimerManager) HandleTimerCallback(timerID, elementID, tokenID
 	switch timerRecord.TimerType {
 	case "BOUNDARY":
 		return utm.boundaryTimerManager.HandleBoundaryTimerCallback(timerID, elementID, tokenID, timerRecord)
+	case "START":
+		return utm.timerStartManager.HandleStartTimerCallback(timerID, elementID, timerRecord)
+	case "SLA":
+		return utm.instanceSLAManager.HandleSLATimerCallback(timerID, timerRecord)
 	case "EVENT":
 		return utm.timerCallbacks.HandleTimerCallback(timerID, elementID, tokenID)
 	default:
@@ -87,22 +98,44 @@ func (utm *UnifiedTimerManager) HandleTimerCallback(timerID, elementID, tokenID
 	}
 }
 
+// SyncTimerStartEvents schedules timer start events of process definition
+// Планирует стартовые события таймера определения процесса
+func (utm *UnifiedTimerManager) SyncTimerStartEvents(processID string) error {
+	return utm.timerStartManager.SyncTimerStartEvents(processID)
+}
+
+// SyncAllTimerStartEvents schedules timer start events of all process definitions
+// Планирует стартовые события таймера всех определений процессов
+func (utm *UnifiedTimerManager) SyncAllTimerStartEvents() error {
+	return utm.timerStartManager.SyncAllTimerStartEvents()
+}
+
+// ScheduleInstanceSLA registers SLA timer of process instance
+// Регистрирует таймер SLA экземпляра процесса
+func (utm *UnifiedTimerManager) ScheduleInstanceSLA(
+	instance *models.ProcessInstance,
+	bpmnProcess *models.BPMNProcess,
+	sla time.Duration,
+) error {
+	return utm.instanceSLAManager.ScheduleInstanceSLA(instance, bpmnProcess, sla)
+}
+
+// CancelInstanceSLA cancels SLA timer of finished process instance
+// Отменяет таймер SLA завершенного экземпляра процесса
+func (utm *UnifiedTimerManager) CancelInstanceSLA(instance *models.ProcessInstance) {
+	utm.instanceSLAManager.CancelInstanceSLA(instance)
+}
+
 // CreateBoundaryTimer creates boundary timer
 // Создает boundary таймер
 func (utm *UnifiedTimerManager) CreateBoundaryTimer(timerRequest *TimerRequest) error {
 	return utm.boundaryTimerManager.CreateBoundaryTimer(timerRequest)
 }
 
-// CreateBoundaryTimerWithID creates boundary timer with ID
-// Создает boundary таймер с ID
-func (utm *UnifiedTimerManager) CreateBoundaryTimerWithID(timerRequest *TimerRequest) (string, error) {
-	return utm.boundaryTimerManager.CreateBoundaryTimerWithID(timerRequest)
-}
-
-// LinkBoundaryTimerToToken links boundary timer to token
-// Связывает boundary таймер с токеном
-func (utm *UnifiedTimerManager) LinkBoundaryTimerToToken(tokenID, timerID string) error {
-	return utm.boundaryTimerManager.LinkBoundaryTimerToToken(tokenID, timerID)
+// RegisterBoundaryTimer creates boundary timer and registers its ID on token
+// Создает boundary таймер и регистрирует его ID в токене
+func (utm *UnifiedTimerManager) RegisterBoundaryTimer(token *models.Token, timerRequest *TimerRequest) (string, error) {
+	return utm.boundaryTimerManager.RegisterBoundaryTimer(token, timerRequest)
 }
 
 // CancelBoundaryTimersForToken cancels boundary timers for token
@@ -111,6 +144,18 @@ func (utm *UnifiedTimerManager) CancelBoundaryTimersForToken(tokenID string) err
 	return utm.boundaryTimerManager.CancelBoundaryTimersForToken(tokenID)
 }
 
+// CancelBoundaryTimers cancels boundary timers registered on token
+// Отменяет boundary таймеры, зарегистрированные в токене
+func (utm *UnifiedTimerManager) CancelBoundaryTimers(token *models.Token) error {
+	return utm.boundaryTimerManager.CancelBoundaryTimers(token)
+}
+
+// MigrateBoundaryTimerLinks links existing boundary timers to their tokens
+// Связывает существующие boundary таймеры с их токенами
+func (utm *UnifiedTimerManager) MigrateBoundaryTimerLinks() {
+	utm.boundaryTimerManager.MigrateTimerLinks()
+}
+
 // CancelAllTimersForProcessInstance cancels all scheduled timers for process instance
 // Отменяет все запланированные таймеры для экземпляра процесса
 func (utm *UnifiedTimerManager) CancelAllTimersForProcessInstance(instanceID string) error {
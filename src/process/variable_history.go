@@ -0,0 +1,48 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package process
+
+import (
+	"fmt"
+
+	"atom-engine/src/core/models"
+)
+
+// ListVariableHistory returns page of values one variable of process instance held in order they were set,
+// and total count of values. Variable never set on instance is reported as not found
+// Возвращает страницу значений одной переменной экземпляра процесса в порядке их установки
+// и общее количество значений. Переменная, которая ни разу не задавалась, считается не найденной
+func (c *Component) ListVariableHistory(
+	query models.VariableHistoryQuery,
+) ([]*models.VariableHistoryEntry, int, error) {
+	instanceID := c.resolveInstanceID(query.InstanceID)
+	instance, err := c.storage.LoadProcessInstance(instanceID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to load process instance: %w", err)
+	}
+
+	entries, err := c.storage.ListVariableHistory(instance.InstanceID, query.Name)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(entries) == 0 {
+		return nil, 0, fmt.Errorf("variable %w: %s was never set on %s",
+			models.ErrNotFound, query.Name, instance.InstanceID)
+	}
+
+	total := len(entries)
+	if query.Offset >= total {
+		return []*models.VariableHistoryEntry{}, total, nil
+	}
+	entries = entries[query.Offset:]
+	if query.Limit > 0 && query.Limit < len(entries) {
+		entries = entries[:query.Limit]
+	}
+	return entries, total, nil
+}
@@ -10,6 +10,7 @@ package storage
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"atom-engine/src/core/models"
@@ -25,17 +26,24 @@ type Storage interface {
 	Stop() error
 	IsReady() bool
 	LogSystemEvent(eventType, status, message string) error
+	LogSystemEventWithFields(eventType, status, message string, fields SystemEventFields) error
 	LoadSystemEvents(limit int) ([]*SystemEventRecord, error)
+	QuerySystemEvents(filter *SystemEventFilter) ([]*SystemEventRecord, int, error)
+	DeleteSystemEventsBefore(cutoff time.Time) (int, error)
 	GetStatus() (*StorageStatus, error)
 	GetInfo() (*StorageInfo, error)
+	Backup(w io.Writer) (uint64, error)
 
 	// Timer persistence methods
 	// Методы персистентности таймеров
 	SaveTimer(timer *TimerRecord) error
 	LoadTimer(timerID string) (*TimerRecord, error)
 	LoadAllTimers() ([]*TimerRecord, error)
+	LoadTimersByProcessInstance(instanceID string) ([]*TimerRecord, error)
 	DeleteTimer(timerID string) error
 	UpdateTimer(timer *TimerRecord) error
+	RegisterBoundaryTimer(token *models.Token, timer *TimerRecord) error
+	MigrateBoundaryTimerLinks() (int, error)
 
 	// BPMN persistence methods
 	// Методы персистентности BPMN
@@ -46,8 +54,8 @@ type Storage interface {
 	LoadAllBPMNProcesses() (map[string][]byte, error)
 	GetMaxProcessVersionByProcessID(processID string) (int, error)
 	DeleteBPMNProcess(processID string) error
-	// Note: SaveBPMNFile and LoadBPMNFile removed - XML files saved to filesystem only
-	// Примечание: SaveBPMNFile и LoadBPMNFile удалены - XML файлы сохраняются только в файловую систему
+	SaveBPMNFile(processID, filename string, content []byte) error
+	LoadBPMNFile(processID string) ([]byte, error)
 
 	// Process Instance persistence methods
 	// Методы персистентности экземпляров процессов
@@ -57,6 +65,10 @@ type Storage interface {
 	LoadAllProcessInstances() ([]*models.ProcessInstance, error)
 	UpdateProcessInstance(instance *models.ProcessInstance) error
 	DeleteProcessInstance(instanceID string) error
+	ResolveProcessInstanceID(instanceIDOrKey string) (string, error)
+	CountInstanceActivity(instanceIDs []string) (map[string]*InstanceActivityCounts, error)
+	SaveArchivedInstance(pointer *models.ArchivedInstance) error
+	LoadArchivedInstance(instanceID string) (*models.ArchivedInstance, error)
 
 	// Token persistence methods
 	// Методы персистентности токенов
@@ -68,12 +80,18 @@ type Storage interface {
 	LoadAllTokens() ([]*models.Token, error)
 	UpdateToken(token *models.Token) error
 	DeleteToken(tokenID string) error
+	ListElementInstances(instanceID string) ([]*models.ElementInstance, error)
+	ListVariableHistory(instanceID, name string) ([]*models.VariableHistoryEntry, error)
 
 	// Job persistence methods
 	// Методы персистентности заданий
 	SaveJob(ctx context.Context, job *models.Job) error
 	GetJob(ctx context.Context, jobID string) (*models.Job, error)
 	ListJobsByType(ctx context.Context, jobType string, status models.JobStatus, limit int) ([]*models.Job, error)
+	ListActivatableJobs(ctx context.Context, jobType string, limit int) ([]*models.Job, error)
+	ListJobsByProcessInstance(ctx context.Context, instanceID string) ([]*models.Job, error)
+	DeleteJob(ctx context.Context, jobID string) error
+	ResolveJobID(jobIDOrKey string) (string, error)
 
 	// Message persistence methods
 	// Методы персистентности сообщений
@@ -94,6 +112,7 @@ type Storage interface {
 	SaveBufferedMessage(ctx context.Context, message *models.BufferedMessage) error
 	GetBufferedMessage(ctx context.Context, messageID string) (*models.BufferedMessage, error)
 	ListBufferedMessages(ctx context.Context, tenantID string, limit, offset int) ([]*models.BufferedMessage, error)
+	ListBufferedMessagesByName(ctx context.Context, messageName string) ([]*models.BufferedMessage, error)
 	DeleteBufferedMessage(ctx context.Context, messageID string) error
 	SaveMessageCorrelationResult(ctx context.Context, result *models.MessageCorrelationResult) error
 	ListMessageCorrelationResults(
@@ -101,6 +120,10 @@ type Storage interface {
 		tenantID, messageName, processKey string,
 		limit, offset int,
 	) ([]*models.MessageCorrelationResult, error)
+	ListMessageCorrelationResultsByInstance(
+		ctx context.Context,
+		instanceID string,
+	) ([]*models.MessageCorrelationResult, error)
 	DeleteMessageCorrelationResult(ctx context.Context, resultID string) error
 
 	// Gateway synchronization persistence methods
@@ -108,6 +131,40 @@ type Storage interface {
 	SaveGatewaySyncState(state *models.GatewaySyncState) error
 	LoadGatewaySyncState(gatewayID, processInstanceID string) (*models.GatewaySyncState, error)
 	DeleteGatewaySyncState(gatewayID, processInstanceID string) error
+	RecordGatewayDecision(decision *models.GatewayDecision) error
+	GetGatewayMetrics(processKey string) (models.GatewayFlowCounters, error)
+
+	// Variable blob persistence methods
+	// Методы персистентности blob'ов переменных
+	SaveVariableBlob(blobID string, data []byte) error
+	LoadVariableBlob(blobID string) ([]byte, error)
+	DeleteVariableBlob(blobID string) error
+	GetVariableBlobStats() (*VariableBlobStats, error)
+
+	// Callback intent persistence methods
+	// Методы персистентности намерений callback
+	SaveCallbackIntent(intent *models.CallbackIntent) error
+	LoadCallbackIntent(intentID string) (*models.CallbackIntent, error)
+	DeleteCallbackIntent(intentID string) error
+	ListCallbackIntents() ([]*models.CallbackIntent, error)
+	DeadLetterCallbackIntent(intent *models.CallbackIntent, reason string) error
+	ListDeadLetterCallbackIntents() ([]*models.CallbackIntent, error)
+	IsCallbackProcessed(callbackID string) (bool, error)
+	MarkCallbackProcessed(callbackID string) error
+
+	// Webhook trigger persistence methods
+	// Методы персистентности webhook триггеров
+	SaveWebhookTrigger(trigger *models.WebhookTrigger) error
+	LoadWebhookTrigger(triggerID string) (*models.WebhookTrigger, error)
+	DeleteWebhookTrigger(triggerID string) error
+	ListWebhookTriggers() ([]*models.WebhookTrigger, error)
+
+	// History export backlog methods
+	// Методы очереди экспорта истории
+	SaveHistoryRecords(records []*models.HistoryRecord) error
+	ListHistoryRecords(limit int) ([]*models.HistoryRecord, error)
+	DeleteHistoryRecords(recordIDs []string) error
+	CountHistoryRecords() (int, error)
 
 	// Incident persistence methods
 	// Методы персистентности инцидентов
@@ -145,6 +202,7 @@ type Storage interface {
 	// Методы пакетных операций
 	SaveBufferedMessagesBatch(ctx context.Context, messages []*models.BufferedMessage) error
 	SaveTokensBatch(tokens []*models.Token) error
+	SaveProcessInstanceWithTokens(instance *models.ProcessInstance, tokens []*models.Token) error
 	DeleteMessagesBatch(ctx context.Context, messageIDs []string) error
 	CleanupExpiredMessagesBatch(ctx context.Context, batchSize int) (int, error)
 	GetBatchConfig() (maxBatchCount int, maxBatchSize int64)
@@ -157,13 +215,18 @@ type BadgerStorage struct {
 	config    *Config
 	ready     bool
 	startTime time.Time
+
+	// variableCipher encrypts sensitive variables, nil when no key is configured
+	// variableCipher шифрует чувствительные переменные, nil если ключ не настроен
+	variableCipher *variableCipher
 }
 
 // Config holds database configuration
 // Конфигурация базы данных
 type Config struct {
-	Path    string
-	Options *StorageOptionsConfig
+	Path               string
+	Options            *StorageOptionsConfig
+	VariableEncryption *VariableEncryptionConfig
 }
 
 // StorageOptionsConfig holds storage options
@@ -234,11 +297,37 @@ type StorageInfo struct {
 // SystemEventRecord represents system event record
 // Представляет запись системного события
 type SystemEventRecord struct {
-	ID        string    `json:"id"`
-	EventType string    `json:"event_type"`
-	Status    string    `json:"status"`
-	Message   string    `json:"message"`
-	CreatedAt time.Time `json:"created_at"`
+	ID         string    `json:"id"`
+	EventType  string    `json:"event_type"`
+	Status     string    `json:"status"`
+	Message    string    `json:"message"`
+	Component  string    `json:"component,omitempty"`
+	InstanceID string    `json:"instance_id,omitempty"`
+	JobID      string    `json:"job_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// SystemEventFields holds structured fields of system event
+// Содержит структурированные поля системного события
+type SystemEventFields struct {
+	Component  string
+	InstanceID string
+	JobID      string
+}
+
+// SystemEventFilter defines system events query filters
+// Определяет фильтры запроса системных событий
+type SystemEventFilter struct {
+	EventType  string
+	Status     string
+	Component  string
+	InstanceID string
+	JobID      string
+	Since      time.Time // Zero value means no lower bound
+	Until      time.Time // Zero value means no upper bound
+	Text       string    // Case-insensitive substring of message
+	Offset     int
+	Limit      int
 }
 
 // TimerRecord represents timer in database
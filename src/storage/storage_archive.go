@@ -0,0 +1,40 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package storage
+
+import (
+	"fmt"
+
+	"atom-engine/src/core/models"
+)
+
+// Archived instance storage key prefixes
+// Префиксы ключей для хранилища указателей на архивы
+const (
+	ArchivedInstancePrefix = "archived_instance:"
+)
+
+// SaveArchivedInstance persists archive pointer of removed process instance
+// Сохраняет указатель на архив удаленного экземпляра процесса
+func (bs *BadgerStorage) SaveArchivedInstance(pointer *models.ArchivedInstance) error {
+	if err := bs.saveJSON(ArchivedInstancePrefix+pointer.InstanceID, pointer); err != nil {
+		return fmt.Errorf("failed to save archived instance: %w", err)
+	}
+	return nil
+}
+
+// LoadArchivedInstance loads archive pointer by process instance ID
+// Загружает указатель на архив по ID экземпляра процесса
+func (bs *BadgerStorage) LoadArchivedInstance(instanceID string) (*models.ArchivedInstance, error) {
+	var pointer models.ArchivedInstance
+	if err := bs.loadJSON(ArchivedInstancePrefix+instanceID, &pointer); err != nil {
+		return nil, fmt.Errorf("archived instance not found: %s: %w", instanceID, err)
+	}
+	return &pointer, nil
+}
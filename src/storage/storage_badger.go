@@ -10,6 +10,7 @@ package storage
 
 import (
 	"fmt"
+	"io"
 	"time"
 
 	"atom-engine/src/core/logger"
@@ -31,6 +32,16 @@ func NewStorage(config *Config) Storage {
 func (s *BadgerStorage) Init() error {
 	logger.Info("Initializing BadgerDB with performance optimizations", logger.String("path", s.config.Path))
 
+	if s.config.VariableEncryption != nil {
+		variableCipher, err := newVariableCipher(s.config.VariableEncryption)
+		if err != nil {
+			return fmt.Errorf("failed to configure variable encryption: %w", err)
+		}
+		s.variableCipher = variableCipher
+		logger.Info("Variable encryption at rest configured",
+			logger.Int("key_patterns", len(s.config.VariableEncryption.KeyPatterns)))
+	}
+
 	opts := badger.DefaultOptions(s.config.Path)
 	opts.Logger = nil // Disable badger logs
 
@@ -182,6 +193,15 @@ func (s *BadgerStorage) Start() error {
 	logger.Info("Starting BadgerDB storage...")
 	s.ready = true
 	s.startTime = time.Now()
+
+	if err := s.ensureJobQueueIndex(); err != nil {
+		s.ready = false
+		return fmt.Errorf("failed to build job activation queue index: %w", err)
+	}
+	if err := s.ensureInstanceRecordIndex(); err != nil {
+		s.ready = false
+		return fmt.Errorf("failed to build instance record index: %w", err)
+	}
 	logger.Info("BadgerDB storage is ready")
 	return nil
 }
@@ -196,6 +216,22 @@ func (s *BadgerStorage) Stop() error {
 	return nil
 }
 
+// Backup streams full database backup in BadgerDB backup format to writer
+// Returns version of newest backed up entry
+// Передает полную резервную копию базы в формате BadgerDB в writer
+// Возвращает версию самой новой записи в копии
+func (s *BadgerStorage) Backup(w io.Writer) (uint64, error) {
+	if !s.ready {
+		return 0, fmt.Errorf("storage not ready")
+	}
+
+	version, err := s.db.Backup(w, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to backup database: %w", err)
+	}
+	return version, nil
+}
+
 // IsReady returns storage ready status
 // Возвращает статус готовности storage
 func (s *BadgerStorage) IsReady() bool {
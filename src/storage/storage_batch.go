@@ -113,6 +113,10 @@ func (s *BadgerStorage) SaveTokensBatch(tokens []*models.Token) error {
 		if err != nil {
 			return fmt.Errorf("failed to serialize token %s: %w", token.TokenID, err)
 		}
+		data, err = s.variableCipher.seal(data)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt variables of token %s: %w", token.TokenID, err)
+		}
 
 		key := TokenPrefix + token.TokenID
 		operations = append(operations, BatchOperation{
@@ -130,6 +134,59 @@ func (s *BadgerStorage) SaveTokensBatch(tokens []*models.Token) error {
 	return nil
 }
 
+// SaveProcessInstanceWithTokens saves process instance and its tokens in a single transaction
+// Token positions are recorded in element instance history within same transaction
+// Сохраняет экземпляр процесса и его токены в одной транзакции
+// Позиции токенов записываются в историю экземпляров элементов в той же транзакции
+func (s *BadgerStorage) SaveProcessInstanceWithTokens(instance *models.ProcessInstance, tokens []*models.Token) error {
+	if !s.ready {
+		return fmt.Errorf("storage not ready")
+	}
+
+	instanceData, err := instance.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to serialize process instance %s: %w", instance.InstanceID, err)
+	}
+	instanceData, err = s.variableCipher.seal(instanceData)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt variables of process instance %s: %w", instance.InstanceID, err)
+	}
+
+	tokensData := make([][]byte, len(tokens))
+	for i, token := range tokens {
+		data, err := s.encodeToken(token)
+		if err != nil {
+			return fmt.Errorf("failed to encode token %s: %w", token.TokenID, err)
+		}
+		tokensData[i] = data
+	}
+
+	err = s.db.Update(func(txn *badger.Txn) error {
+		if err := s.recordInstanceVariables(txn, instance); err != nil {
+			return err
+		}
+		if err := txn.Set([]byte(ProcessInstancePrefix+instance.InstanceID), instanceData); err != nil {
+			return err
+		}
+		for i, token := range tokens {
+			if err := s.recordTokenVariables(txn, token); err != nil {
+				return err
+			}
+			if err := txn.Set([]byte(TokenPrefix+token.TokenID), tokensData[i]); err != nil {
+				return err
+			}
+			if err := recordElementInstance(txn, token); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save process instance with tokens: %w", err)
+	}
+	return nil
+}
+
 // DeleteMessagesBatch deletes multiple messages in a single transaction
 // Удаляет множественные сообщения в одной транзакции
 func (s *BadgerStorage) DeleteMessagesBatch(ctx context.Context, messageIDs []string) error {
@@ -0,0 +1,171 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+
+	"github.com/dgraph-io/badger/v3"
+)
+
+// Variable blob storage key prefixes
+// Префиксы ключей для хранилища blob'ов переменных
+const (
+	VariableBlobPrefix = "variable_blob:"
+)
+
+// VariableBlobStats represents offloaded variable blob statistics
+// Представляет статистику вынесенных blob'ов переменных
+type VariableBlobStats struct {
+	Count     int64 `json:"count"`
+	TotalSize int64 `json:"total_size"`
+}
+
+// SaveVariableBlob stores offloaded variable value under blob ID
+// Сохраняет вынесенное значение переменной под ID blob'а
+func (bs *BadgerStorage) SaveVariableBlob(blobID string, data []byte) error {
+	if bs.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	key := VariableBlobPrefix + blobID
+
+	return bs.db.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte(key), data)
+	})
+}
+
+// LoadVariableBlob loads offloaded variable value by blob ID
+// Загружает вынесенное значение переменной по ID blob'а
+func (bs *BadgerStorage) LoadVariableBlob(blobID string) ([]byte, error) {
+	if bs.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	key := VariableBlobPrefix + blobID
+	var data []byte
+
+	err := bs.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(key))
+		if err != nil {
+			return err
+		}
+
+		return item.Value(func(val []byte) error {
+			data = append([]byte(nil), val...)
+			return nil
+		})
+	})
+
+	if err != nil {
+		if err == badger.ErrKeyNotFound {
+			return nil, fmt.Errorf("variable blob %w: %s", models.ErrNotFound, blobID)
+		}
+		return nil, fmt.Errorf("failed to load variable blob: %w", err)
+	}
+
+	return data, nil
+}
+
+// DeleteVariableBlob deletes offloaded variable value
+// Удаляет вынесенное значение переменной
+func (bs *BadgerStorage) DeleteVariableBlob(blobID string) error {
+	if bs.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	key := VariableBlobPrefix + blobID
+
+	return bs.db.Update(func(txn *badger.Txn) error {
+		return txn.Delete([]byte(key))
+	})
+}
+
+// GetVariableBlobStats returns count and total size of offloaded blobs
+// Возвращает количество и общий размер вынесенных blob'ов
+func (bs *BadgerStorage) GetVariableBlobStats() (*VariableBlobStats, error) {
+	if bs.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	stats := &VariableBlobStats{}
+	prefix := []byte(VariableBlobPrefix)
+
+	err := bs.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			stats.Count++
+			stats.TotalSize += it.Item().ValueSize()
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect variable blob stats: %w", err)
+	}
+
+	return stats, nil
+}
+
+// ResolveVariableBlobs replaces blob references in variables with stored values
+// Заменяет ссылки на blob'ы в переменных сохраненными значениями
+func ResolveVariableBlobs(s Storage, variables map[string]interface{}) map[string]interface{} {
+	if len(variables) == 0 {
+		return variables
+	}
+
+	var resolved map[string]interface{}
+	for name, value := range variables {
+		blobID, ok := models.ParseVariableBlobRef(value)
+		if !ok {
+			continue
+		}
+
+		// Copy lazily so variables without references are returned as is
+		// Копируем лениво, чтобы переменные без ссылок возвращались как есть
+		if resolved == nil {
+			resolved = make(map[string]interface{}, len(variables))
+			for k, v := range variables {
+				resolved[k] = v
+			}
+		}
+
+		data, err := s.LoadVariableBlob(blobID)
+		if err != nil {
+			logger.Warn("Failed to load variable blob, keeping reference",
+				logger.String("variable", name),
+				logger.String("blob_id", blobID),
+				logger.String("error", err.Error()))
+			continue
+		}
+
+		var decoded interface{}
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			logger.Warn("Failed to decode variable blob, keeping reference",
+				logger.String("variable", name),
+				logger.String("blob_id", blobID),
+				logger.String("error", err.Error()))
+			continue
+		}
+		resolved[name] = decoded
+	}
+
+	if resolved == nil {
+		return variables
+	}
+	return resolved
+}
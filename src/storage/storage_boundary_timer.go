@@ -0,0 +1,130 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+
+	"github.com/dgraph-io/badger/v3"
+)
+
+// boundaryTimerLinksMigrationKey marks that scheduled boundary timers were linked to their tokens
+// Отмечает, что запланированные boundary таймеры связаны со своими токенами
+const boundaryTimerLinksMigrationKey = "migration:boundary_timer_links"
+
+// RegisterBoundaryTimer saves token carrying boundary timer ID together with timer record in one transaction,
+// so token never references timer that was not saved and saved timer is never missing from token
+// Сохраняет токен с ID boundary таймера вместе с записью таймера в одной транзакции,
+// поэтому токен не ссылается на несохраненный таймер, а сохраненный таймер всегда есть в токене
+func (bs *BadgerStorage) RegisterBoundaryTimer(token *models.Token, timer *TimerRecord) error {
+	if err := bs.validateStorage(); err != nil {
+		return err
+	}
+
+	tokenData, err := bs.encodeToken(token)
+	if err != nil {
+		return err
+	}
+
+	timer.UpdatedAt = time.Now()
+	if timer.CreatedAt.IsZero() {
+		timer.CreatedAt = timer.UpdatedAt
+	}
+	timerData, err := marshalForStorage(timer)
+	if err != nil {
+		return fmt.Errorf("failed to marshal timer: %w", err)
+	}
+
+	err = bs.db.Update(func(txn *badger.Txn) error {
+		if err := bs.recordTokenVariables(txn, token); err != nil {
+			return err
+		}
+		if err := txn.Set([]byte(TokenPrefix+token.TokenID), tokenData); err != nil {
+			return err
+		}
+		if err := recordElementInstance(txn, token); err != nil {
+			return err
+		}
+		timerKey := fmt.Sprintf("timer_%s", timer.ID)
+		if err := txn.Set([]byte(timerKey), timerData); err != nil {
+			return err
+		}
+		return setInstanceRecordIndex(txn, timer.ProcessInstanceID, instanceRecordTimer, timer.ID, timerKey)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register boundary timer %s: %w", timer.ID, err)
+	}
+	return nil
+}
+
+// MigrateBoundaryTimerLinks adds scheduled boundary timers missing from boundary timer IDs of their tokens.
+// Runs once, returns number of linked timers
+// Добавляет запланированные boundary таймеры, отсутствующие в ID boundary таймеров их токенов
+// Выполняется один раз, возвращает число связанных таймеров
+func (bs *BadgerStorage) MigrateBoundaryTimerLinks() (int, error) {
+	done, err := bs.keyExists(boundaryTimerLinksMigrationKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check boundary timer links migration: %w", err)
+	}
+	if done {
+		return 0, nil
+	}
+
+	timers, err := bs.LoadAllTimers()
+	if err != nil {
+		return 0, err
+	}
+
+	timersByToken := make(map[string][]string)
+	for _, timer := range timers {
+		if timer.TimerType == string(models.TimerTypeBoundary) && timer.State == "SCHEDULED" && timer.TokenID != "" {
+			timersByToken[timer.TokenID] = append(timersByToken[timer.TokenID], timer.ID)
+		}
+	}
+
+	linked := 0
+	for tokenID, timerIDs := range timersByToken {
+		token, err := bs.LoadToken(tokenID)
+		if errors.Is(err, models.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return linked, err
+		}
+
+		added := 0
+		for _, timerID := range timerIDs {
+			if !token.HasBoundaryTimer(timerID) {
+				token.AddBoundaryTimer(timerID)
+				added++
+			}
+		}
+		if added == 0 {
+			continue
+		}
+		if err := bs.SaveToken(token); err != nil {
+			return linked, fmt.Errorf("failed to link boundary timers to token %s: %w", tokenID, err)
+		}
+		linked += added
+	}
+
+	if err := bs.saveJSON(boundaryTimerLinksMigrationKey, time.Now()); err != nil {
+		return linked, fmt.Errorf("failed to mark boundary timer links migration done: %w", err)
+	}
+
+	logger.Info("Boundary timer links migrated",
+		logger.Int("tokens", len(timersByToken)),
+		logger.Int("linked_timers", linked))
+	return linked, nil
+}
@@ -0,0 +1,192 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package storage
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"atom-engine/src/core/models"
+)
+
+// boundaryTimer returns scheduled boundary timer record of token
+// Возвращает запланированную запись boundary таймера токена
+func boundaryTimer(id string, token *models.Token) *TimerRecord {
+	return &TimerRecord{
+		ID:                id,
+		ElementID:         "timeout",
+		TokenID:           token.TokenID,
+		ProcessInstanceID: token.ProcessInstanceID,
+		TimerType:         string(models.TimerTypeBoundary),
+		ScheduledAt:       time.Now().Add(time.Hour),
+		State:             "SCHEDULED",
+	}
+}
+
+func TestRegisterBoundaryTimerSurvivesCrashBeforeTokenUpdate(t *testing.T) {
+	dir := t.TempDir()
+
+	bs := NewStorage(&Config{Path: dir}).(*BadgerStorage)
+	if err := bs.Init(); err != nil {
+		t.Fatalf("init storage: %v", err)
+	}
+	if err := bs.Start(); err != nil {
+		t.Fatalf("start storage: %v", err)
+	}
+
+	token := models.NewToken("instance-1", "process-1", "task")
+	if err := bs.SaveToken(token); err != nil {
+		t.Fatalf("save token: %v", err)
+	}
+
+	token.AddBoundaryTimer("timer-1")
+	if err := bs.RegisterBoundaryTimer(token, boundaryTimer("timer-1", token)); err != nil {
+		t.Fatalf("register boundary timer: %v", err)
+	}
+
+	// Process stops right after registration, before executor saves token again
+	// Процесс останавливается сразу после регистрации, до повторного сохранения токена исполнителем
+	if err := bs.Stop(); err != nil {
+		t.Fatalf("stop storage: %v", err)
+	}
+	bs = newTestStorage(t, &Config{Path: dir})
+
+	loaded, err := bs.LoadToken(token.TokenID)
+	if err != nil {
+		t.Fatalf("load token: %v", err)
+	}
+	if !loaded.HasBoundaryTimer("timer-1") {
+		t.Fatalf("restored token lost boundary timer, has %v", loaded.BoundaryTimerIDs)
+	}
+
+	timer, err := bs.LoadTimer("timer-1")
+	if err != nil {
+		t.Fatalf("load timer: %v", err)
+	}
+	if timer.TokenID != token.TokenID || timer.State != "SCHEDULED" {
+		t.Fatalf("unexpected restored timer: %+v", timer)
+	}
+	if timer.CreatedAt.IsZero() || timer.UpdatedAt.IsZero() {
+		t.Errorf("timer timestamps not set: %+v", timer)
+	}
+}
+
+func TestRegisterBoundaryTimerWritesNothingOnFailure(t *testing.T) {
+	bs := newTestStorage(t, nil)
+
+	token := models.NewToken("instance-1", "process-1", "task")
+	if err := bs.SaveToken(token); err != nil {
+		t.Fatalf("save token: %v", err)
+	}
+
+	// Timer key over Badger key size limit fails after token was written in same transaction
+	// Ключ таймера больше лимита Badger падает после записи токена в той же транзакции
+	timerID := strings.Repeat("t", 70000)
+	updated := token.Clone()
+	updated.AddBoundaryTimer(timerID)
+	if err := bs.RegisterBoundaryTimer(updated, boundaryTimer(timerID, token)); err == nil {
+		t.Fatal("expected error for timer that cannot be saved")
+	}
+
+	loaded, err := bs.LoadToken(token.TokenID)
+	if err != nil {
+		t.Fatalf("load token: %v", err)
+	}
+	if loaded.HasBoundaryTimers() {
+		t.Errorf("token linked to timer that was not registered: %v", loaded.BoundaryTimerIDs)
+	}
+}
+
+func TestRegisterBoundaryTimerRequiresReadyStorage(t *testing.T) {
+	bs := newTestStorage(t, nil)
+	bs.ready = false
+	defer func() { bs.ready = true }()
+
+	token := models.NewToken("instance-1", "process-1", "task")
+	if err := bs.RegisterBoundaryTimer(token, boundaryTimer("timer-1", token)); err == nil {
+		t.Fatal("expected error while storage is not ready")
+	}
+}
+
+func TestMigrateBoundaryTimerLinksLinksLegacyTokens(t *testing.T) {
+	bs := newTestStorage(t, nil)
+
+	// Legacy token saved before timer IDs were kept on token
+	// Устаревший токен, сохраненный до того, как ID таймеров хранились в токене
+	legacy := models.NewToken("instance-1", "process-1", "task")
+	if err := bs.SaveToken(legacy); err != nil {
+		t.Fatalf("save token: %v", err)
+	}
+	linked := models.NewToken("instance-1", "process-1", "other-task")
+	linked.AddBoundaryTimer("timer-linked")
+	if err := bs.SaveToken(linked); err != nil {
+		t.Fatalf("save token: %v", err)
+	}
+
+	fired := boundaryTimer("timer-fired", legacy)
+	fired.State = "FIRED"
+	nonBoundary := boundaryTimer("timer-intermediate", legacy)
+	nonBoundary.TimerType = string(models.TimerTypeEvent)
+	orphan := boundaryTimer("timer-orphan", models.NewToken("instance-1", "process-1", "gone"))
+
+	for _, timer := range []*TimerRecord{
+		boundaryTimer("timer-a", legacy),
+		boundaryTimer("timer-b", legacy),
+		boundaryTimer("timer-linked", linked),
+		fired,
+		nonBoundary,
+		orphan,
+	} {
+		if err := bs.SaveTimer(timer); err != nil {
+			t.Fatalf("save timer %s: %v", timer.ID, err)
+		}
+	}
+
+	count, err := bs.MigrateBoundaryTimerLinks()
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("linked %d timers, want 2", count)
+	}
+
+	loaded, err := bs.LoadToken(legacy.TokenID)
+	if err != nil {
+		t.Fatalf("load token: %v", err)
+	}
+	for _, timerID := range []string{"timer-a", "timer-b"} {
+		if !loaded.HasBoundaryTimer(timerID) {
+			t.Errorf("legacy token not linked to %s", timerID)
+		}
+	}
+	for _, timerID := range []string{"timer-fired", "timer-intermediate"} {
+		if loaded.HasBoundaryTimer(timerID) {
+			t.Errorf("legacy token linked to %s", timerID)
+		}
+	}
+
+	loadedLinked, err := bs.LoadToken(linked.TokenID)
+	if err != nil {
+		t.Fatalf("load token: %v", err)
+	}
+	if len(loadedLinked.BoundaryTimerIDs) != 1 {
+		t.Errorf("already linked timer duplicated: %v", loadedLinked.BoundaryTimerIDs)
+	}
+
+	// Migration runs once, later legacy timers are left to normal registration
+	// Миграция выполняется один раз, последующие таймеры остаются обычной регистрации
+	if err := bs.SaveTimer(boundaryTimer("timer-late", legacy)); err != nil {
+		t.Fatalf("save timer: %v", err)
+	}
+	count, err = bs.MigrateBoundaryTimerLinks()
+	if err != nil || count != 0 {
+		t.Fatalf("second migration linked %d timers, err %v", count, err)
+	}
+}
@@ -14,6 +14,8 @@ import (
 	"strings"
 	"time"
 
+	"atom-engine/src/core/models"
+
 	"github.com/dgraph-io/badger/v3"
 )
 
@@ -62,7 +64,7 @@ func (bs *BadgerStorage) LoadBPMNProcess(processID string) ([]byte, error) {
 
 	if err != nil {
 		if err == badger.ErrKeyNotFound {
-			return nil, fmt.Errorf("BPMN process not found: %s", processID)
+			return nil, fmt.Errorf("BPMN process %w: %s", models.ErrNotFound, processID)
 		}
 		return nil, fmt.Errorf("failed to load BPMN process: %w", err)
 	}
@@ -151,7 +153,7 @@ func (bs *BadgerStorage) LoadBPMNProcessByProcessID(processID string, version in
 		return nil, "", fmt.Errorf("failed to search BPMN processes: %w", err)
 	}
 
-	return nil, "", fmt.Errorf("BPMN process not found: process_id=%s, version=%d", processID, version)
+	return nil, "", fmt.Errorf("BPMN process %w: process_id=%s, version=%d", models.ErrNotFound, processID, version)
 }
 
 // LoadBPMNProcessByBPMNID loads BPMN process by BPMN ID
@@ -206,7 +208,7 @@ func (bs *BadgerStorage) LoadBPMNProcessByBPMNID(bpmnID string) ([]byte, error)
 		return nil, fmt.Errorf("failed to search BPMN processes: %w", err)
 	}
 
-	return nil, fmt.Errorf("BPMN process not found: %s", bpmnID)
+	return nil, fmt.Errorf("BPMN process %w: %s", models.ErrNotFound, bpmnID)
 }
 
 // LoadAllBPMNProcesses loads all BPMN processes from storage
@@ -258,13 +260,31 @@ func (bs *BadgerStorage) DeleteBPMNProcess(processID string) error {
 		return fmt.Errorf("database not initialized")
 	}
 
-	key := BPMNProcessPrefix + processID
-
+	// Original source and gateway metrics are deleted together with parsed process
+	// Оригинальный исходник и метрики шлюзов удаляются вместе с распарсенным процессом
 	return bs.db.Update(func(txn *badger.Txn) error {
-		return txn.Delete([]byte(key))
+		if err := txn.Delete([]byte(BPMNProcessPrefix + processID)); err != nil {
+			return err
+		}
+		if err := txn.Delete([]byte(BPMNFilePrefix + processID)); err != nil {
+			return err
+		}
+		return deleteGatewayMetrics(txn, processID)
 	})
 }
 
+// bpmnFileRecord is stored original BPMN source
+// Сохраненный оригинальный исходник BPMN
+type bpmnFileRecord struct {
+	ProcessID   string `json:"process_id"`
+	Filename    string `json:"filename"`
+	Content     []byte `json:"content"`
+	Size        int    `json:"size"`
+	SavedAt     string `json:"saved_at"`      // ISO 8601 timestamp
+	SavedAtUnix int64  `json:"saved_at_unix"` // Unix timestamp for easy querying
+	SavedAtNano int64  `json:"saved_at_nano"` // Nanosecond precision for uniqueness
+}
+
 // SaveBPMNFile saves original BPMN file content to storage
 // Сохраняет содержимое оригинального BPMN файла в storage
 func (bs *BadgerStorage) SaveBPMNFile(processID, filename string, content []byte) error {
@@ -275,14 +295,14 @@ func (bs *BadgerStorage) SaveBPMNFile(processID, filename string, content []byte
 	// Create file record with proper timestamp
 	// Создаем запись файла с правильным timestamp
 	now := time.Now()
-	fileRecord := map[string]interface{}{
-		"process_id":    processID,
-		"filename":      filename,
-		"content":       content,
-		"size":          len(content),
-		"saved_at":      now.Format(time.RFC3339), // ISO 8601 timestamp
-		"saved_at_unix": now.Unix(),               // Unix timestamp for easy querying
-		"saved_at_nano": now.UnixNano(),           // Nanosecond precision for uniqueness
+	fileRecord := bpmnFileRecord{
+		ProcessID:   processID,
+		Filename:    filename,
+		Content:     content,
+		Size:        len(content),
+		SavedAt:     now.Format(time.RFC3339),
+		SavedAtUnix: now.Unix(),
+		SavedAtNano: now.UnixNano(),
 	}
 
 	data, err := json.Marshal(fileRecord)
@@ -321,45 +341,19 @@ func (bs *BadgerStorage) LoadBPMNFile(processID string) ([]byte, error) {
 
 	if err != nil {
 		if err == badger.ErrKeyNotFound {
-			return nil, fmt.Errorf("BPMN file not found: %s", processID)
+			return nil, fmt.Errorf("BPMN file %w: %s", models.ErrNotFound, processID)
 		}
 		return nil, fmt.Errorf("failed to load BPMN file: %w", err)
 	}
 
-	// Unmarshal file record and extract content
-	// Демаршалим запись файла и извлекаем содержимое
-	var fileRecord map[string]interface{}
+	// Content is base64 encoded by JSON marshaling of []byte
+	// Содержимое закодировано в base64 при JSON сериализации []byte
+	var fileRecord bpmnFileRecord
 	if err := json.Unmarshal(data, &fileRecord); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal BPMN file record: %w", err)
 	}
 
-	contentInterface, exists := fileRecord["content"]
-	if !exists {
-		return nil, fmt.Errorf("no content found in BPMN file record")
-	}
-
-	// Handle different content formats
-	// Обрабатываем разные форматы содержимого
-	switch content := contentInterface.(type) {
-	case []byte:
-		return content, nil
-	case string:
-		return []byte(content), nil
-	case []interface{}:
-		// JSON array of bytes
-		// JSON массив байтов
-		bytes := make([]byte, len(content))
-		for i, b := range content {
-			if byteVal, ok := b.(float64); ok {
-				bytes[i] = byte(byteVal)
-			} else {
-				return nil, fmt.Errorf("invalid byte value in content array")
-			}
-		}
-		return bytes, nil
-	default:
-		return nil, fmt.Errorf("unsupported content type: %T", content)
-	}
+	return fileRecord.Content, nil
 }
 
 // GetBPMNProcessStats returns statistics about BPMN processes in storage
@@ -0,0 +1,209 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/dgraph-io/badger/v3"
+
+	"atom-engine/src/core/models"
+)
+
+// Element instance key prefixes
+// Records are keyed by instance and element instance key, so instance scan returns them in activation order
+// Префиксы ключей экземпляров элементов
+// Записи адресуются экземпляром и ключом экземпляра элемента, поэтому обход экземпляра идет в порядке активаций
+const (
+	ElementInstancePrefix       = "element_instance:"
+	ElementInstanceCursorPrefix = "element_instance_cursor:" // Record key of element token is currently on
+)
+
+// ListElementInstances returns element instances of process instance in activation order
+// Возвращает экземпляры элементов экземпляра процесса в порядке активаций
+func (bs *BadgerStorage) ListElementInstances(instanceID string) ([]*models.ElementInstance, error) {
+	if bs.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	var records []*models.ElementInstance
+	err := bs.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = elementInstanceInstancePrefix(instanceID)
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			var record models.ElementInstance
+			err := it.Item().Value(func(val []byte) error {
+				return json.Unmarshal(val, &record)
+			})
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal element instance %s: %w", string(it.Item().Key()), err)
+			}
+			records = append(records, &record)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list element instances: %w", err)
+	}
+	return records, nil
+}
+
+// recordElementInstance writes element activation of token inside token save transaction
+// Activation left by token since previous save is completed, finished token releases its cursor
+// Записывает активацию элемента токеном в транзакции сохранения токена
+// Активация, покинутая токеном после прошлого сохранения, завершается, завершенный токен освобождает курсор
+func recordElementInstance(txn *badger.Txn, token *models.Token) error {
+	if token.Key <= 0 || token.ProcessInstanceID == "" || token.CurrentElementID == "" {
+		return nil
+	}
+
+	cursorKey := []byte(ElementInstanceCursorPrefix + token.TokenID)
+	recordKey := elementInstanceRecordKey(token.ProcessInstanceID, token.Key)
+	now := token.UpdatedAt
+
+	previousKey, err := getValue(txn, cursorKey)
+	if err != nil {
+		return err
+	}
+	if previousKey != nil && !bytes.Equal(previousKey, recordKey) {
+		previous, err := loadElementInstance(txn, previousKey)
+		if err != nil {
+			return err
+		}
+		if previous != nil && !previous.IsFinished() {
+			previous.State = models.ElementInstanceStateCompleted
+			previous.WaitingFor = ""
+			previous.EndedAt = &now
+			if err := setElementInstance(txn, previousKey, previous); err != nil {
+				return err
+			}
+		}
+	}
+
+	record, err := loadElementInstance(txn, recordKey)
+	if err != nil {
+		return err
+	}
+	if record == nil {
+		record = &models.ElementInstance{
+			Key:               token.Key,
+			ProcessInstanceID: token.ProcessInstanceID,
+			TokenID:           token.TokenID,
+			StartedAt:         now,
+		}
+	}
+	record.ElementID = token.CurrentElementID
+	record.WaitingFor = token.WaitingFor
+
+	switch token.State {
+	case models.TokenStateCompleted:
+		record.State = models.ElementInstanceStateCompleted
+	case models.TokenStateCanceled:
+		record.State = models.ElementInstanceStateTerminated
+	case models.TokenStateFailed:
+		// Failed token stays on element until incident is resolved
+		// Упавший токен остается на элементе до разрешения инцидента
+		record.State = models.ElementInstanceStateIncident
+		record.EndedAt = nil
+	default:
+		record.State = models.ElementInstanceStateActive
+		record.EndedAt = nil
+	}
+	if record.IsFinished() {
+		endedAt := now
+		if token.CompletedAt != nil {
+			endedAt = *token.CompletedAt
+		}
+		record.EndedAt = &endedAt
+	}
+
+	if err := setElementInstance(txn, recordKey, record); err != nil {
+		return err
+	}
+	if record.IsFinished() {
+		return txn.Delete(cursorKey)
+	}
+	return txn.Set(cursorKey, recordKey)
+}
+
+// deleteElementInstances removes element instances of process instance inside transaction
+// Удаляет экземпляры элементов экземпляра процесса в транзакции
+func deleteElementInstances(txn *badger.Txn, instanceID string) error {
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = elementInstanceInstancePrefix(instanceID)
+	opts.PrefetchValues = false
+	it := txn.NewIterator(opts)
+
+	var keys [][]byte
+	for it.Rewind(); it.Valid(); it.Next() {
+		keys = append(keys, it.Item().KeyCopy(nil))
+	}
+	it.Close()
+
+	for _, key := range keys {
+		if err := txn.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadElementInstance reads element instance record, nil when it does not exist
+// Читает запись экземпляра элемента, nil если ее нет
+func loadElementInstance(txn *badger.Txn, key []byte) (*models.ElementInstance, error) {
+	data, err := getValue(txn, key)
+	if err != nil || data == nil {
+		return nil, err
+	}
+	var record models.ElementInstance
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal element instance %s: %w", string(key), err)
+	}
+	return &record, nil
+}
+
+// setElementInstance writes element instance record
+// Записывает запись экземпляра элемента
+func setElementInstance(txn *badger.Txn, key []byte, record *models.ElementInstance) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal element instance %d: %w", record.Key, err)
+	}
+	return txn.Set(key, data)
+}
+
+// getValue returns copy of value stored under key, nil when key does not exist
+// Возвращает копию значения по ключу, nil если ключа нет
+func getValue(txn *badger.Txn, key []byte) ([]byte, error) {
+	item, err := txn.Get(key)
+	if err == badger.ErrKeyNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return item.ValueCopy(nil)
+}
+
+// elementInstanceInstancePrefix returns key prefix of element instances of process instance
+// Возвращает префикс ключей экземпляров элементов экземпляра процесса
+func elementInstanceInstancePrefix(instanceID string) []byte {
+	return []byte(ElementInstancePrefix + instanceID + ":")
+}
+
+// elementInstanceRecordKey returns record key, zero padded element instance key keeps activation order
+// Возвращает ключ записи, дополненный нулями ключ экземпляра элемента сохраняет порядок активаций
+func elementInstanceRecordKey(instanceID string, key int64) []byte {
+	return []byte(fmt.Sprintf("%s%s:%020d", ElementInstancePrefix, instanceID, key))
+}
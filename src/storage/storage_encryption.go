@@ -0,0 +1,212 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	"atom-engine/src/core/models"
+)
+
+// EncryptedVariableKey marks stored variable value encrypted at rest
+// Помечает сохраненное значение переменной, зашифрованное в хранилище
+const EncryptedVariableKey = "$encrypted"
+
+// encryptedVariableMarker is searched in raw records to skip parsing of plain ones
+// Ищется в сырых записях, чтобы не разбирать записи без шифрования
+var encryptedVariableMarker = []byte(`"` + EncryptedVariableKey + `"`)
+
+// VariableEncryptionConfig holds encryption of sensitive variables at rest
+// Конфигурация шифрования чувствительных переменных в хранилище
+type VariableEncryptionConfig struct {
+	Key         []byte   // AES-256 key, 32 bytes
+	KeyPatterns []string // Case-insensitive regexps for variable keys
+}
+
+// variableCipher encrypts sensitive variables of token, instance and job records
+// Variables are matched by top-level name, whole value is encrypted with name as associated data
+// Шифрует чувствительные переменные записей токенов, экземпляров и заданий
+// Переменные сопоставляются по имени верхнего уровня, значение шифруется целиком с именем
+// в качестве связанных данных
+type variableCipher struct {
+	aead     cipher.AEAD
+	patterns []*regexp.Regexp
+}
+
+// newVariableCipher creates AES-GCM cipher for sensitive variables
+// Создает AES-GCM шифр для чувствительных переменных
+func newVariableCipher(config *VariableEncryptionConfig) (*variableCipher, error) {
+	if len(config.Key) != 32 {
+		return nil, fmt.Errorf("variable encryption key must be 32 bytes, got %d", len(config.Key))
+	}
+
+	block, err := aes.NewCipher(config.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create variable cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create variable cipher: %w", err)
+	}
+
+	patterns, err := models.CompileSensitiveVariablePatterns(config.KeyPatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	return &variableCipher{aead: aead, patterns: patterns}, nil
+}
+
+// isSensitive reports whether variable name matches configured patterns
+// Проверяет, соответствует ли имя переменной настроенным шаблонам
+func (vc *variableCipher) isSensitive(name string) bool {
+	for _, re := range vc.patterns {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// seal encrypts sensitive values in "variables" field of serialized record
+// Record is returned unchanged when encryption is disabled or nothing matched
+// Шифрует чувствительные значения в поле "variables" сериализованной записи
+// Запись возвращается без изменений, если шифрование выключено или совпадений нет
+func (vc *variableCipher) seal(data []byte) ([]byte, error) {
+	if vc == nil || len(vc.patterns) == 0 {
+		return data, nil
+	}
+
+	return rewriteVariables(data, func(name string, value json.RawMessage) (json.RawMessage, bool, error) {
+		if !vc.isSensitive(name) || isEncryptedValue(value) {
+			return nil, false, nil
+		}
+
+		nonce := make([]byte, vc.aead.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, false, fmt.Errorf("failed to generate nonce: %w", err)
+		}
+		sealed := vc.aead.Seal(nonce, nonce, value, []byte(name))
+
+		encrypted, err := json.Marshal(map[string]string{
+			EncryptedVariableKey: base64.StdEncoding.EncodeToString(sealed),
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		return encrypted, true, nil
+	})
+}
+
+// open decrypts encrypted values in "variables" field of serialized record
+// Values are decrypted regardless of current patterns so narrowing them keeps old data readable
+// Without configured key record is rejected, otherwise next save would lose encrypted values
+// Расшифровывает значения в поле "variables" сериализованной записи
+// Значения расшифровываются независимо от текущих шаблонов, чтобы старые данные оставались читаемыми
+// Без настроенного ключа запись отклоняется, иначе следующее сохранение потеряет зашифрованные значения
+func (vc *variableCipher) open(data []byte) ([]byte, error) {
+	if !bytes.Contains(data, encryptedVariableMarker) {
+		return data, nil
+	}
+
+	return rewriteVariables(data, func(name string, value json.RawMessage) (json.RawMessage, bool, error) {
+		if !isEncryptedValue(value) {
+			return nil, false, nil
+		}
+		if vc == nil {
+			return nil, false, fmt.Errorf("variable %s is encrypted but encryption key is not configured", name)
+		}
+
+		var encrypted map[string]string
+		if err := json.Unmarshal(value, &encrypted); err != nil {
+			return nil, false, fmt.Errorf("invalid encrypted variable %s: %w", name, err)
+		}
+		sealed, err := base64.StdEncoding.DecodeString(encrypted[EncryptedVariableKey])
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid encrypted variable %s: %w", name, err)
+		}
+
+		nonceSize := vc.aead.NonceSize()
+		if len(sealed) < nonceSize {
+			return nil, false, fmt.Errorf("invalid encrypted variable %s: ciphertext too short", name)
+		}
+		plain, err := vc.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(name))
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to decrypt variable %s: %w", name, err)
+		}
+		return plain, true, nil
+	})
+}
+
+// rewriteVariables replaces values of "variables" field of serialized record via rewrite callback
+// Заменяет значения поля "variables" сериализованной записи через функцию rewrite
+func rewriteVariables(
+	data []byte,
+	rewrite func(name string, value json.RawMessage) (json.RawMessage, bool, error),
+) ([]byte, error) {
+	var record map[string]json.RawMessage
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to parse record: %w", err)
+	}
+
+	rawVariables, ok := record["variables"]
+	if !ok {
+		return data, nil
+	}
+
+	var variables map[string]json.RawMessage
+	if err := json.Unmarshal(rawVariables, &variables); err != nil || variables == nil {
+		return data, nil
+	}
+
+	changed := false
+	for name, value := range variables {
+		rewritten, ok, err := rewrite(name, value)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			variables[name] = rewritten
+			changed = true
+		}
+	}
+	if !changed {
+		return data, nil
+	}
+
+	rawVariables, err := json.Marshal(variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize variables: %w", err)
+	}
+	record["variables"] = rawVariables
+
+	return json.Marshal(record)
+}
+
+// isEncryptedValue reports whether raw variable value is encrypted marker
+// Проверяет, является ли сырое значение переменной маркером шифрования
+func isEncryptedValue(value json.RawMessage) bool {
+	if !bytes.Contains(value, encryptedVariableMarker) {
+		return false
+	}
+
+	var encrypted map[string]json.RawMessage
+	if err := json.Unmarshal(value, &encrypted); err != nil || len(encrypted) != 1 {
+		return false
+	}
+	_, ok := encrypted[EncryptedVariableKey]
+	return ok
+}
@@ -0,0 +1,198 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"atom-engine/src/core/models"
+
+	"github.com/dgraph-io/badger/v3"
+)
+
+// newTestStorage opens started storage in temporary directory closed at test cleanup
+// Открывает запущенный storage во временной директории, закрываемый по завершении теста
+func newTestStorage(t *testing.T, config *Config) *BadgerStorage {
+	t.Helper()
+
+	if config == nil {
+		config = &Config{}
+	}
+	if config.Path == "" {
+		config.Path = t.TempDir()
+	}
+
+	bs := NewStorage(config).(*BadgerStorage)
+	if err := bs.Init(); err != nil {
+		t.Fatalf("init storage: %v", err)
+	}
+	if err := bs.Start(); err != nil {
+		t.Fatalf("start storage: %v", err)
+	}
+	t.Cleanup(func() { bs.Stop() })
+	return bs
+}
+
+// testEncryptionKey returns 32-byte key filled with given byte
+// Возвращает 32-байтовый ключ, заполненный указанным байтом
+func testEncryptionKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+func newTestCipher(t *testing.T, key []byte) *variableCipher {
+	t.Helper()
+
+	vc, err := newVariableCipher(&VariableEncryptionConfig{
+		Key:         key,
+		KeyPatterns: []string{"^card", "password"},
+	})
+	if err != nil {
+		t.Fatalf("create cipher: %v", err)
+	}
+	return vc
+}
+
+func TestVariableCipherSealOpen(t *testing.T) {
+	vc := newTestCipher(t, testEncryptionKey(1))
+
+	plain := []byte(`{"id":"t1","variables":{"cardNumber":"4111111111111111","userPassword":{"v":42},"amount":100}}`)
+
+	sealed, err := vc.seal(plain)
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+	if bytes.Contains(sealed, []byte("4111111111111111")) {
+		t.Fatalf("sealed record contains plaintext card number: %s", sealed)
+	}
+	if !bytes.Contains(sealed, []byte(`"amount":100`)) {
+		t.Fatalf("non-sensitive variable must stay in plaintext: %s", sealed)
+	}
+
+	var record struct {
+		Variables map[string]json.RawMessage `json:"variables"`
+	}
+	if err := json.Unmarshal(sealed, &record); err != nil {
+		t.Fatalf("parse sealed record: %v", err)
+	}
+	for _, name := range []string{"cardNumber", "userPassword"} {
+		if !isEncryptedValue(record.Variables[name]) {
+			t.Errorf("variable %s is not encrypted: %s", name, record.Variables[name])
+		}
+	}
+
+	resealed, err := vc.seal(sealed)
+	if err != nil {
+		t.Fatalf("reseal: %v", err)
+	}
+	if !bytes.Equal(resealed, sealed) {
+		t.Errorf("sealing already encrypted record must not change it")
+	}
+
+	opened, err := vc.open(sealed)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	assertSameJSON(t, opened, plain)
+}
+
+func TestVariableCipherOpenWrongKey(t *testing.T) {
+	sealed, err := newTestCipher(t, testEncryptionKey(1)).seal(
+		[]byte(`{"variables":{"cardNumber":"4111111111111111"}}`))
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+
+	_, err = newTestCipher(t, testEncryptionKey(2)).open(sealed)
+	if err == nil || !strings.Contains(err.Error(), "failed to decrypt variable cardNumber") {
+		t.Fatalf("open with wrong key: got %v, want decrypt error", err)
+	}
+
+	var missing *variableCipher
+	_, err = missing.open(sealed)
+	if err == nil || !strings.Contains(err.Error(), "encryption key is not configured") {
+		t.Fatalf("open without key: got %v, want key not configured error", err)
+	}
+}
+
+func TestNewVariableCipherRejectsShortKey(t *testing.T) {
+	_, err := newVariableCipher(&VariableEncryptionConfig{Key: []byte("short")})
+	if err == nil {
+		t.Fatal("expected error for short key")
+	}
+}
+
+func TestSaveTokenEncryptsSensitiveVariables(t *testing.T) {
+	bs := newTestStorage(t, &Config{
+		VariableEncryption: &VariableEncryptionConfig{
+			Key:         testEncryptionKey(7),
+			KeyPatterns: []string{"^card"},
+		},
+	})
+
+	token := models.NewToken("instance-1", "process-1", "task-1")
+	token.Variables["cardNumber"] = "4111111111111111"
+	token.Variables["amount"] = float64(100)
+
+	if err := bs.SaveToken(token); err != nil {
+		t.Fatalf("save token: %v", err)
+	}
+
+	var raw []byte
+	err := bs.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(TokenPrefix + token.TokenID))
+		if err != nil {
+			return err
+		}
+		raw, err = item.ValueCopy(nil)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("read raw token: %v", err)
+	}
+	if bytes.Contains(raw, []byte("4111111111111111")) {
+		t.Fatalf("stored token contains plaintext card number: %s", raw)
+	}
+	if !bytes.Contains(raw, encryptedVariableMarker) {
+		t.Fatalf("stored token has no encrypted variable: %s", raw)
+	}
+
+	loaded, err := bs.LoadToken(token.TokenID)
+	if err != nil {
+		t.Fatalf("load token: %v", err)
+	}
+	if got := loaded.Variables["cardNumber"]; got != "4111111111111111" {
+		t.Errorf("cardNumber = %v, want decrypted value", got)
+	}
+	if got := loaded.Variables["amount"]; got != float64(100) {
+		t.Errorf("amount = %v, want 100", got)
+	}
+}
+
+// assertSameJSON compares two JSON documents ignoring field order
+// Сравнивает два JSON документа без учета порядка полей
+func assertSameJSON(t *testing.T, got, want []byte) {
+	t.Helper()
+
+	var gotValue, wantValue interface{}
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("parse got: %v", err)
+	}
+	if err := json.Unmarshal(want, &wantValue); err != nil {
+		t.Fatalf("parse want: %v", err)
+	}
+
+	gotJSON, _ := json.Marshal(gotValue)
+	wantJSON, _ := json.Marshal(wantValue)
+	if !bytes.Equal(gotJSON, wantJSON) {
+		t.Errorf("JSON mismatch:\n got: %s\nwant: %s", gotJSON, wantJSON)
+	}
+}
@@ -0,0 +1,180 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dgraph-io/badger/v3"
+
+	"atom-engine/src/core/models"
+)
+
+// Gateway metrics key prefix
+// Counters are keyed by definition version, gateway and flow, activations counter has empty flow ID
+// Префикс ключей метрик шлюзов
+// Счетчики адресуются версией определения, шлюзом и потоком, счетчик активаций имеет пустой ID потока
+const (
+	GatewayMetricsPrefix = "gateway:metrics:"
+)
+
+// gatewayDecisionAttempts is number of attempts of counter update on concurrent decisions of same gateway
+// Число попыток обновления счетчиков при конкурентных решениях одного шлюза
+const gatewayDecisionAttempts = 5
+
+// RecordGatewayDecision increments counters of gateway and taken flows
+// and stores taken flows on element instance of token, so history shows decision
+// Увеличивает счетчики шлюза и выбранных потоков
+// и сохраняет выбранные потоки в экземпляре элемента токена, чтобы история показывала решение
+func (bs *BadgerStorage) RecordGatewayDecision(decision *models.GatewayDecision) error {
+	if bs.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	var err error
+	for range gatewayDecisionAttempts {
+		err = bs.db.Update(func(txn *badger.Txn) error {
+			activationsKey := gatewayMetricsKey(decision.ProcessKey, decision.GatewayID, "")
+			if err := incrementCounter(txn, activationsKey); err != nil {
+				return err
+			}
+			for _, flowID := range decision.Flows {
+				key := gatewayMetricsKey(decision.ProcessKey, decision.GatewayID, flowID)
+				if err := incrementCounter(txn, key); err != nil {
+					return err
+				}
+			}
+			return recordTakenFlows(txn, decision)
+		})
+		if !errors.Is(err, badger.ErrConflict) {
+			break
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record decision of gateway %s: %w", decision.GatewayID, err)
+	}
+	return nil
+}
+
+// GetGatewayMetrics returns counters of gateway decisions of definition version
+// Возвращает счетчики решений шлюзов версии определения
+func (bs *BadgerStorage) GetGatewayMetrics(processKey string) (models.GatewayFlowCounters, error) {
+	if bs.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	counters := make(models.GatewayFlowCounters)
+	prefix := gatewayMetricsProcessPrefix(processKey)
+	err := bs.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			// Gateway and flow IDs are XML IDs without colons
+			// ID шлюзов и потоков - XML ID без двоеточий
+			gatewayID, flowID, found := strings.Cut(strings.TrimPrefix(string(it.Item().Key()), string(prefix)), ":")
+			if !found || strings.Contains(flowID, ":") {
+				continue
+			}
+
+			var count int64
+			err := it.Item().Value(func(val []byte) error {
+				var parseErr error
+				count, parseErr = strconv.ParseInt(string(val), 10, 64)
+				return parseErr
+			})
+			if err != nil {
+				return fmt.Errorf("failed to parse gateway counter %s: %w", string(it.Item().Key()), err)
+			}
+
+			if counters[gatewayID] == nil {
+				counters[gatewayID] = make(map[string]int64)
+			}
+			counters[gatewayID][flowID] = count
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load gateway metrics: %w", err)
+	}
+	return counters, nil
+}
+
+// incrementCounter adds one to decimal counter stored under key
+// Увеличивает на единицу десятичный счетчик, хранящийся по ключу
+func incrementCounter(txn *badger.Txn, key []byte) error {
+	data, err := getValue(txn, key)
+	if err != nil {
+		return err
+	}
+	var count int64
+	if data != nil {
+		count, err = strconv.ParseInt(string(data), 10, 64)
+		if err != nil {
+			return fmt.Errorf("failed to parse counter %s: %w", string(key), err)
+		}
+	}
+	return txn.Set(key, []byte(strconv.FormatInt(count+1, 10)))
+}
+
+// recordTakenFlows sets taken flows on element instance of token on gateway, skipped when record is missing
+// Записывает выбранные потоки в экземпляр элемента токена на шлюзе, пропускается при отсутствии записи
+func recordTakenFlows(txn *badger.Txn, decision *models.GatewayDecision) error {
+	if decision.TokenKey <= 0 || decision.ProcessInstanceID == "" {
+		return nil
+	}
+
+	recordKey := elementInstanceRecordKey(decision.ProcessInstanceID, decision.TokenKey)
+	record, err := loadElementInstance(txn, recordKey)
+	if err != nil || record == nil || record.ElementID != decision.GatewayID {
+		return err
+	}
+	record.TakenFlows = slices.Clone(decision.Flows)
+	return setElementInstance(txn, recordKey, record)
+}
+
+// deleteGatewayMetrics removes counters of definition version inside transaction
+// Удаляет счетчики версии определения в транзакции
+func deleteGatewayMetrics(txn *badger.Txn, processKey string) error {
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = gatewayMetricsProcessPrefix(processKey)
+	opts.PrefetchValues = false
+	it := txn.NewIterator(opts)
+
+	var keys [][]byte
+	for it.Rewind(); it.Valid(); it.Next() {
+		keys = append(keys, it.Item().KeyCopy(nil))
+	}
+	it.Close()
+
+	for _, key := range keys {
+		if err := txn.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gatewayMetricsProcessPrefix returns key prefix of gateway counters of definition version
+// Возвращает префикс ключей счетчиков шлюзов версии определения
+func gatewayMetricsProcessPrefix(processKey string) []byte {
+	return []byte(GatewayMetricsPrefix + processKey + ":")
+}
+
+// gatewayMetricsKey returns key of flow counter, empty flow ID addresses activations counter
+// Возвращает ключ счетчика потока, пустой ID потока адресует счетчик активаций
+func gatewayMetricsKey(processKey, gatewayID, flowID string) []byte {
+	return []byte(GatewayMetricsPrefix + processKey + ":" + gatewayID + ":" + flowID)
+}
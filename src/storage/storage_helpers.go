@@ -12,12 +12,44 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"atom-engine/src/core/models"
+
 	"github.com/dgraph-io/badger/v3"
 )
 
 // Helper functions to reduce code duplication in storage operations
 // Вспомогательные функции для уменьшения дублирования кода в storage операциях
 
+// storageMarshaler is implemented by models with own storage serialization
+// Реализуется моделями с собственной сериализацией для storage
+type storageMarshaler interface {
+	ToJSON() ([]byte, error)
+}
+
+// storageUnmarshaler is implemented by models with own storage deserialization
+// Реализуется моделями с собственной десериализацией из storage
+type storageUnmarshaler interface {
+	FromJSON(data []byte) error
+}
+
+// marshalForStorage serializes data using model storage format if available
+// Сериализует данные, используя формат storage модели при наличии
+func marshalForStorage(data interface{}) ([]byte, error) {
+	if m, ok := data.(storageMarshaler); ok {
+		return m.ToJSON()
+	}
+	return json.Marshal(data)
+}
+
+// unmarshalFromStorage deserializes data using model storage format if available
+// Десериализует данные, используя формат storage модели при наличии
+func unmarshalFromStorage(data []byte, target interface{}) error {
+	if u, ok := target.(storageUnmarshaler); ok {
+		return u.FromJSON(data)
+	}
+	return json.Unmarshal(data, target)
+}
+
 // validateStorage checks if storage is ready and database is initialized
 // Проверяет готовность storage и инициализацию базы данных
 func (bs *BadgerStorage) validateStorage() error {
@@ -37,7 +69,7 @@ func (bs *BadgerStorage) saveJSON(key string, data interface{}) error {
 		return err
 	}
 
-	jsonData, err := json.Marshal(data)
+	jsonData, err := marshalForStorage(data)
 	if err != nil {
 		return fmt.Errorf("failed to marshal data: %w", err)
 	}
@@ -48,7 +80,9 @@ func (bs *BadgerStorage) saveJSON(key string, data interface{}) error {
 }
 
 // loadJSON loads JSON data from storage and unmarshals into target
+// Encrypted variables are decrypted before unmarshaling
 // Загружает JSON данные из storage и десериализует в target
+// Зашифрованные переменные расшифровываются перед десериализацией
 func (bs *BadgerStorage) loadJSON(key string, target interface{}) error {
 	if err := bs.validateStorage(); err != nil {
 		return err
@@ -58,13 +92,17 @@ func (bs *BadgerStorage) loadJSON(key string, target interface{}) error {
 		item, err := txn.Get([]byte(key))
 		if err != nil {
 			if err == badger.ErrKeyNotFound {
-				return fmt.Errorf("key not found: %s", key)
+				return fmt.Errorf("key %w: %s", models.ErrNotFound, key)
 			}
 			return fmt.Errorf("failed to get key %s: %w", key, err)
 		}
 
 		return item.Value(func(val []byte) error {
-			if err := json.Unmarshal(val, target); err != nil {
+			val, err := bs.variableCipher.open(val)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt variables for key %s: %w", key, err)
+			}
+			if err := unmarshalFromStorage(val, target); err != nil {
 				return fmt.Errorf("failed to unmarshal data for key %s: %w", key, err)
 			}
 			return nil
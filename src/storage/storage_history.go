@@ -0,0 +1,129 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package storage
+
+import (
+	"fmt"
+
+	"github.com/dgraph-io/badger/v3"
+
+	"atom-engine/src/core/models"
+)
+
+// History export backlog key prefixes
+// Префиксы ключей очереди экспорта истории
+const (
+	HistoryRecordPrefix = "history_record:"
+)
+
+// SaveHistoryRecords appends records to history export backlog in single transaction
+// Добавляет записи в очередь экспорта истории одной транзакцией
+func (bs *BadgerStorage) SaveHistoryRecords(records []*models.HistoryRecord) error {
+	if err := bs.validateStorage(); err != nil {
+		return err
+	}
+
+	err := bs.db.Update(func(txn *badger.Txn) error {
+		for _, record := range records {
+			data, err := marshalForStorage(record)
+			if err != nil {
+				return fmt.Errorf("failed to marshal history record %s: %w", record.ID, err)
+			}
+			if err := txn.Set([]byte(HistoryRecordPrefix+record.ID), data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save history records: %w", err)
+	}
+	return nil
+}
+
+// ListHistoryRecords returns oldest backlog records, at most limit
+// Возвращает самые старые записи очереди, не больше limit
+func (bs *BadgerStorage) ListHistoryRecords(limit int) ([]*models.HistoryRecord, error) {
+	if err := bs.validateStorage(); err != nil {
+		return nil, err
+	}
+
+	records := make([]*models.HistoryRecord, 0, limit)
+	err := bs.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+
+		prefix := []byte(HistoryRecordPrefix)
+		for it.Seek(prefix); it.ValidForPrefix(prefix) && len(records) < limit; it.Next() {
+			err := it.Item().Value(func(value []byte) error {
+				var record models.HistoryRecord
+				if err := unmarshalFromStorage(value, &record); err != nil {
+					return fmt.Errorf("failed to unmarshal history record %s: %w", string(it.Item().Key()), err)
+				}
+				records = append(records, &record)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list history records: %w", err)
+	}
+	return records, nil
+}
+
+// DeleteHistoryRecords removes exported records from backlog
+// Удаляет экспортированные записи из очереди
+func (bs *BadgerStorage) DeleteHistoryRecords(recordIDs []string) error {
+	if err := bs.validateStorage(); err != nil {
+		return err
+	}
+
+	err := bs.db.Update(func(txn *badger.Txn) error {
+		for _, recordID := range recordIDs {
+			if err := txn.Delete([]byte(HistoryRecordPrefix + recordID)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete history records: %w", err)
+	}
+	return nil
+}
+
+// CountHistoryRecords returns number of records in backlog
+// Возвращает количество записей в очереди
+func (bs *BadgerStorage) CountHistoryRecords() (int, error) {
+	if err := bs.validateStorage(); err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err := bs.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		prefix := []byte(HistoryRecordPrefix)
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			count++
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count history records: %w", err)
+	}
+	return count, nil
+}
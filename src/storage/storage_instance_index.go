@@ -0,0 +1,202 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
+
+	"github.com/dgraph-io/badger/v3"
+)
+
+// Instance record index keys
+// Index key points from process instance or message name to record key, so records of one
+// instance are read without scanning records of all instances
+// Ключи индекса записей экземпляра
+// Ключ индекса указывает от экземпляра процесса или имени сообщения на ключ записи, поэтому записи
+// одного экземпляра читаются без сканирования записей всех экземпляров
+const (
+	InstanceRecordIndexPrefix      = "instance_record:"
+	BufferedMessageNameIndexPrefix = "buf_msg_name:"
+	instanceRecordIndexMarker      = "index_version:instance_record"
+)
+
+// Record kinds of instance record index
+// Виды записей индекса записей экземпляра
+const (
+	instanceRecordJob         = "job"
+	instanceRecordTimer       = "timer"
+	instanceRecordCorrelation = "msg_corr"
+)
+
+// instanceRecordPrefix returns index prefix of records of kind owned by instance
+// Возвращает префикс индекса записей вида, принадлежащих экземпляру
+func instanceRecordPrefix(instanceID, kind string) string {
+	return fmt.Sprintf("%s%s:%s:", InstanceRecordIndexPrefix, instanceID, kind)
+}
+
+// setInstanceRecordIndex links record to its process instance, records without instance are not indexed
+// Связывает запись с ее экземпляром процесса, записи без экземпляра не индексируются
+func setInstanceRecordIndex(txn *badger.Txn, instanceID, kind, recordID, recordKey string) error {
+	if instanceID == "" {
+		return nil
+	}
+	return txn.Set([]byte(instanceRecordPrefix(instanceID, kind)+recordID), []byte(recordKey))
+}
+
+// deleteInstanceRecordIndex unlinks record from its process instance
+// Отвязывает запись от ее экземпляра процесса
+func deleteInstanceRecordIndex(txn *badger.Txn, instanceID, kind, recordID string) error {
+	if instanceID == "" {
+		return nil
+	}
+	return txn.Delete([]byte(instanceRecordPrefix(instanceID, kind) + recordID))
+}
+
+// setBufferedMessageNameIndex links buffered message to its name
+// Связывает буферизованное сообщение с его именем
+func setBufferedMessageNameIndex(txn *badger.Txn, message *models.BufferedMessage) error {
+	return txn.Set([]byte(BufferedMessageNameIndexPrefix+message.Name+":"+message.ID),
+		[]byte("buf_msg:"+message.ID))
+}
+
+// deleteBufferedMessageNameIndex unlinks buffered message from its name
+// Отвязывает буферизованное сообщение от его имени
+func deleteBufferedMessageNameIndex(txn *badger.Txn, message *models.BufferedMessage) error {
+	return txn.Delete([]byte(BufferedMessageNameIndexPrefix + message.Name + ":" + message.ID))
+}
+
+// loadIndexedRecords passes value of every record referenced by index keys with prefix to handler.
+// Index keys of records deleted by older code are skipped
+// Передает обработчику значение каждой записи, на которую ссылаются ключи индекса с префиксом
+// Ключи индекса записей, удаленных старым кодом, пропускаются
+func (bs *BadgerStorage) loadIndexedRecords(prefix string, handler func(value []byte) error) error {
+	if err := bs.validateStorage(); err != nil {
+		return err
+	}
+
+	return bs.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchSize = 10
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		prefixBytes := []byte(prefix)
+		for it.Seek(prefixBytes); it.ValidForPrefix(prefixBytes); it.Next() {
+			recordKey, err := it.Item().ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+
+			item, err := txn.Get(recordKey)
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			if err := item.Value(handler); err != nil {
+				return fmt.Errorf("failed to read %s: %w", recordKey, err)
+			}
+		}
+		return nil
+	})
+}
+
+// ensureInstanceRecordIndex builds instance record and message name indexes of records
+// stored before indexes were introduced
+// Строит индексы записей экземпляра и имен сообщений для записей, сохраненных до появления индексов
+func (bs *BadgerStorage) ensureInstanceRecordIndex() error {
+	built, err := bs.keyExists(instanceRecordIndexMarker)
+	if err != nil || built {
+		return err
+	}
+
+	// Links are collected first and written after scans, as job queue backfill does
+	// Связи сначала собираются и записываются после сканирования, как при заполнении очереди job'ов
+	var links []func(txn *badger.Txn) error
+	index := func(scanPrefix string, link func(txn *badger.Txn, key string, value []byte) error) error {
+		return bs.iterateWithPrefix(scanPrefix, func(key []byte, value []byte) error {
+			recordKey, recordValue := string(key), append([]byte(nil), value...)
+			links = append(links, func(txn *badger.Txn) error {
+				return link(txn, recordKey, recordValue)
+			})
+			return nil
+		})
+	}
+
+	err = index("job:", func(txn *badger.Txn, key string, value []byte) error {
+		data, err := bs.variableCipher.open(value)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt job %s: %w", key, err)
+		}
+		var job models.Job
+		if err := job.FromJSON(data); err != nil {
+			return fmt.Errorf("failed to parse job %s: %w", key, err)
+		}
+		return setInstanceRecordIndex(txn, job.ProcessInstanceID, instanceRecordJob, job.ID, key)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to index jobs: %w", err)
+	}
+
+	err = index("timer_", func(txn *badger.Txn, key string, value []byte) error {
+		var timer TimerRecord
+		if err := json.Unmarshal(value, &timer); err != nil {
+			// Unreadable timers are skipped by timer listing as well
+			// Нечитаемые таймеры пропускаются и при перечислении таймеров
+			return nil
+		}
+		return setInstanceRecordIndex(txn, timer.ProcessInstanceID, instanceRecordTimer,
+			strings.TrimPrefix(key, "timer_"), key)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to index timers: %w", err)
+	}
+
+	err = index("msg_corr:", func(txn *badger.Txn, key string, value []byte) error {
+		var result models.MessageCorrelationResult
+		if err := json.Unmarshal(value, &result); err != nil {
+			return fmt.Errorf("failed to parse correlation result %s: %w", key, err)
+		}
+		return setInstanceRecordIndex(txn, result.ProcessInstanceID, instanceRecordCorrelation, result.ID, key)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to index message correlation results: %w", err)
+	}
+
+	err = index("buf_msg:", func(txn *badger.Txn, key string, value []byte) error {
+		var message models.BufferedMessage
+		if err := json.Unmarshal(value, &message); err != nil {
+			// Corrupted buffered messages are skipped by message listing as well
+			// Поврежденные буферизованные сообщения пропускаются и при перечислении сообщений
+			return nil
+		}
+		return setBufferedMessageNameIndex(txn, &message)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to index buffered messages: %w", err)
+	}
+
+	for _, link := range links {
+		if err := bs.db.Update(link); err != nil {
+			return fmt.Errorf("failed to index instance record: %w", err)
+		}
+	}
+
+	logger.Info("Instance record index built", logger.Int("records", len(links)))
+	return bs.db.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte(instanceRecordIndexMarker), []byte("1"))
+	})
+}
@@ -0,0 +1,279 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package storage
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"atom-engine/src/core/models"
+)
+
+// instanceRecords is set of records saved for one process instance
+// Набор записей, сохраненных для одного экземпляра процесса
+type instanceRecords struct {
+	jobs         []string
+	timers       []string
+	correlations []string
+}
+
+// saveInstanceRecords saves job, timer and correlation result for every instance and returns their IDs
+// Сохраняет job, таймер и результат корреляции для каждого экземпляра и возвращает их ID
+func saveInstanceRecords(t *testing.T, bs *BadgerStorage, instanceIDs ...string) map[string]*instanceRecords {
+	t.Helper()
+	ctx := context.Background()
+
+	saved := make(map[string]*instanceRecords)
+	for _, instanceID := range instanceIDs {
+		records := &instanceRecords{}
+		for i := 0; i < 2; i++ {
+			job := models.NewJob("work", instanceID, "task")
+			if err := bs.SaveJob(ctx, job); err != nil {
+				t.Fatalf("save job: %v", err)
+			}
+			records.jobs = append(records.jobs, job.ID)
+
+			timer := &TimerRecord{ID: models.GenerateID(), ProcessInstanceID: instanceID,
+				TimerType: string(models.TimerTypeEvent), ScheduledAt: time.Now().Add(time.Hour), State: "SCHEDULED"}
+			if err := bs.SaveTimer(timer); err != nil {
+				t.Fatalf("save timer: %v", err)
+			}
+			records.timers = append(records.timers, timer.ID)
+
+			result := models.NewMessageCorrelationResult("message-1", "", "order-paid", "key-1")
+			result.ProcessInstanceID = instanceID
+			if err := bs.SaveMessageCorrelationResult(ctx, result); err != nil {
+				t.Fatalf("save correlation result: %v", err)
+			}
+			records.correlations = append(records.correlations, result.ID)
+		}
+		saved[instanceID] = records
+	}
+	return saved
+}
+
+// loadInstanceRecords loads IDs of instance records through instance-scoped queries
+// Загружает ID записей экземпляра через запросы в рамках экземпляра
+func loadInstanceRecords(t *testing.T, bs *BadgerStorage, instanceID string) *instanceRecords {
+	t.Helper()
+	ctx := context.Background()
+
+	records := &instanceRecords{}
+	jobs, err := bs.ListJobsByProcessInstance(ctx, instanceID)
+	if err != nil {
+		t.Fatalf("list jobs: %v", err)
+	}
+	for _, job := range jobs {
+		records.jobs = append(records.jobs, job.ID)
+	}
+	timers, err := bs.LoadTimersByProcessInstance(instanceID)
+	if err != nil {
+		t.Fatalf("load timers: %v", err)
+	}
+	for _, timer := range timers {
+		records.timers = append(records.timers, timer.ID)
+	}
+	results, err := bs.ListMessageCorrelationResultsByInstance(ctx, instanceID)
+	if err != nil {
+		t.Fatalf("list correlation results: %v", err)
+	}
+	for _, result := range results {
+		records.correlations = append(records.correlations, result.ID)
+	}
+	return records
+}
+
+// assertSameIDs fails test when ID lists differ ignoring order
+// Проваливает тест, если списки ID различаются без учета порядка
+func assertSameIDs(t *testing.T, what string, got, want []string) {
+	t.Helper()
+	got, want = append([]string(nil), got...), append([]string(nil), want...)
+	sort.Strings(got)
+	sort.Strings(want)
+	if len(got) != len(want) {
+		t.Fatalf("%s: got %v, want %v", what, got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("%s: got %v, want %v", what, got, want)
+		}
+	}
+}
+
+func TestInstanceScopedQueriesReturnOnlyInstanceRecords(t *testing.T) {
+	ctx := context.Background()
+	bs := newTestStorage(t, nil)
+
+	// Second instance ID continues first one with colon to check prefix matching
+	// ID второго экземпляра продолжает первый через двоеточие для проверки совпадения префиксов
+	saved := saveInstanceRecords(t, bs, "instance-1", "instance-1:job", "instance-2")
+
+	for instanceID, want := range saved {
+		got := loadInstanceRecords(t, bs, instanceID)
+		assertSameIDs(t, instanceID+" jobs", got.jobs, want.jobs)
+		assertSameIDs(t, instanceID+" timers", got.timers, want.timers)
+		assertSameIDs(t, instanceID+" correlations", got.correlations, want.correlations)
+	}
+
+	want := saved["instance-1"]
+	if err := bs.DeleteJob(ctx, want.jobs[0]); err != nil {
+		t.Fatalf("delete job: %v", err)
+	}
+	if err := bs.DeleteTimer(want.timers[0]); err != nil {
+		t.Fatalf("delete timer: %v", err)
+	}
+	if err := bs.DeleteMessageCorrelationResult(ctx, want.correlations[0]); err != nil {
+		t.Fatalf("delete correlation result: %v", err)
+	}
+
+	got := loadInstanceRecords(t, bs, "instance-1")
+	assertSameIDs(t, "jobs after delete", got.jobs, want.jobs[1:])
+	assertSameIDs(t, "timers after delete", got.timers, want.timers[1:])
+	assertSameIDs(t, "correlations after delete", got.correlations, want.correlations[1:])
+
+	for _, prefix := range []string{
+		instanceRecordPrefix("instance-1", instanceRecordJob) + want.jobs[0],
+		instanceRecordPrefix("instance-1", instanceRecordTimer) + want.timers[0],
+		instanceRecordPrefix("instance-1", instanceRecordCorrelation) + want.correlations[0],
+	} {
+		if exists, err := bs.keyExists(prefix); err != nil || exists {
+			t.Errorf("index key %s left after record deletion, err %v", prefix, err)
+		}
+	}
+
+	if records := loadInstanceRecords(t, bs, "unknown"); len(records.jobs)+len(records.timers)+
+		len(records.correlations) != 0 {
+		t.Errorf("unknown instance has records: %+v", records)
+	}
+}
+
+func TestInstanceTimerIndexFollowsTimerWrites(t *testing.T) {
+	bs := newTestStorage(t, nil)
+
+	token := models.NewToken("instance-1", "process-1", "task")
+	if err := bs.SaveToken(token); err != nil {
+		t.Fatalf("save token: %v", err)
+	}
+	token.AddBoundaryTimer("boundary-1")
+	if err := bs.RegisterBoundaryTimer(token, boundaryTimer("boundary-1", token)); err != nil {
+		t.Fatalf("register boundary timer: %v", err)
+	}
+
+	updated := &TimerRecord{ID: "updated-1", ProcessInstanceID: "instance-1", State: "SCHEDULED"}
+	if err := bs.UpdateTimer(updated); err != nil {
+		t.Fatalf("update timer: %v", err)
+	}
+	if err := bs.SaveTimer(&TimerRecord{ID: "start-1", State: "SCHEDULED"}); err != nil {
+		t.Fatalf("save timer without instance: %v", err)
+	}
+
+	timers, err := bs.LoadTimersByProcessInstance("instance-1")
+	if err != nil {
+		t.Fatalf("load timers: %v", err)
+	}
+	ids := make([]string, 0, len(timers))
+	for _, timer := range timers {
+		ids = append(ids, timer.ID)
+	}
+	assertSameIDs(t, "instance timers", ids, []string{"boundary-1", "updated-1"})
+
+	// Deleting missing timer keeps succeeding as before index was introduced
+	// Удаление отсутствующего таймера по-прежнему успешно, как и до появления индекса
+	if err := bs.DeleteTimer("missing"); err != nil {
+		t.Errorf("delete missing timer: %v", err)
+	}
+}
+
+func TestListBufferedMessagesByName(t *testing.T) {
+	ctx := context.Background()
+	bs := newTestStorage(t, nil)
+
+	var paid []string
+	for _, name := range []string{"order-paid", "order-paid", "order-paid:late", "order-shipped"} {
+		message := models.NewBufferedMessage("", name, "key-1", nil, "no subscription", "")
+		if err := bs.SaveBufferedMessage(ctx, message); err != nil {
+			t.Fatalf("save buffered message: %v", err)
+		}
+		if name == "order-paid" {
+			paid = append(paid, message.ID)
+		}
+	}
+
+	messages, err := bs.ListBufferedMessagesByName(ctx, "order-paid")
+	if err != nil {
+		t.Fatalf("list buffered messages: %v", err)
+	}
+	ids := make([]string, 0, len(messages))
+	for _, message := range messages {
+		ids = append(ids, message.ID)
+	}
+	assertSameIDs(t, "order-paid messages", ids, paid)
+
+	if err := bs.DeleteBufferedMessage(ctx, paid[0]); err != nil {
+		t.Fatalf("delete buffered message: %v", err)
+	}
+	messages, err = bs.ListBufferedMessagesByName(ctx, "order-paid")
+	if err != nil || len(messages) != 1 || messages[0].ID != paid[1] {
+		t.Fatalf("deleted message still listed: %+v, err %v", messages, err)
+	}
+}
+
+func TestEnsureInstanceRecordIndexBackfillsLegacyRecords(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	legacy := NewStorage(&Config{Path: dir}).(*BadgerStorage)
+	if err := legacy.Init(); err != nil {
+		t.Fatalf("init storage: %v", err)
+	}
+	if err := legacy.Start(); err != nil {
+		t.Fatalf("start storage: %v", err)
+	}
+
+	saved := saveInstanceRecords(t, legacy, "instance-1", "instance-2")
+	message := models.NewBufferedMessage("", "order-paid", "key-1", nil, "no subscription", "")
+	if err := legacy.SaveBufferedMessage(ctx, message); err != nil {
+		t.Fatalf("save buffered message: %v", err)
+	}
+
+	// Drop indexes and marker, as in database written before indexes were introduced
+	// Удаляем индексы и маркер, как в базе, записанной до появления индексов
+	dropped := []string{InstanceRecordIndexPrefix, BufferedMessageNameIndexPrefix, instanceRecordIndexMarker}
+	for _, prefix := range dropped {
+		if err := legacy.db.DropPrefix([]byte(prefix)); err != nil {
+			t.Fatalf("drop %s: %v", prefix, err)
+		}
+	}
+	if records := loadInstanceRecords(t, legacy, "instance-1"); len(records.jobs) != 0 {
+		t.Fatalf("legacy database must have empty index, got %+v", records)
+	}
+	if err := legacy.Stop(); err != nil {
+		t.Fatalf("stop storage: %v", err)
+	}
+
+	bs := newTestStorage(t, &Config{Path: dir})
+
+	built, err := bs.keyExists(instanceRecordIndexMarker)
+	if err != nil || !built {
+		t.Fatalf("index marker not written after backfill: %v", err)
+	}
+	for instanceID, want := range saved {
+		got := loadInstanceRecords(t, bs, instanceID)
+		assertSameIDs(t, instanceID+" jobs", got.jobs, want.jobs)
+		assertSameIDs(t, instanceID+" timers", got.timers, want.timers)
+		assertSameIDs(t, instanceID+" correlations", got.correlations, want.correlations)
+	}
+
+	messages, err := bs.ListBufferedMessagesByName(ctx, "order-paid")
+	if err != nil || len(messages) != 1 || messages[0].ID != message.ID {
+		t.Fatalf("buffered message not backfilled: %+v, err %v", messages, err)
+	}
+}
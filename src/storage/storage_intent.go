@@ -0,0 +1,147 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"atom-engine/src/core/models"
+
+	"github.com/dgraph-io/badger/v3"
+)
+
+// Callback intent storage key prefixes
+// Префиксы ключей для хранилища намерений callback
+const (
+	CallbackIntentPrefix     = "callback_intent:"
+	CallbackProcessedPrefix  = "callback_processed:"
+	CallbackDeadLetterPrefix = "callback_dead_letter:"
+)
+
+// ProcessedCallbackRetention is how long processed callback IDs are kept for duplicate detection
+// Время хранения ID обработанных callback для обнаружения дубликатов
+const ProcessedCallbackRetention = 7 * 24 * time.Hour
+
+// SaveCallbackIntent persists callback intent
+// Сохраняет намерение callback
+func (bs *BadgerStorage) SaveCallbackIntent(intent *models.CallbackIntent) error {
+	if err := bs.saveJSON(CallbackIntentPrefix+intent.ID, intent); err != nil {
+		return fmt.Errorf("failed to save callback intent: %w", err)
+	}
+	return nil
+}
+
+// LoadCallbackIntent loads callback intent by ID
+// Загружает намерение callback по ID
+func (bs *BadgerStorage) LoadCallbackIntent(intentID string) (*models.CallbackIntent, error) {
+	var intent models.CallbackIntent
+	if err := bs.loadJSON(CallbackIntentPrefix+intentID, &intent); err != nil {
+		return nil, fmt.Errorf("failed to load callback intent: %w", err)
+	}
+	return &intent, nil
+}
+
+// DeleteCallbackIntent marks callback intent done by removing it from log
+// Отмечает намерение callback выполненным, удаляя его из лога
+func (bs *BadgerStorage) DeleteCallbackIntent(intentID string) error {
+	return bs.deleteKey(CallbackIntentPrefix + intentID)
+}
+
+// ListCallbackIntents returns all callback intents not yet marked done
+// Возвращает все намерения callback, еще не отмеченные выполненными
+func (bs *BadgerStorage) ListCallbackIntents() ([]*models.CallbackIntent, error) {
+	intents := make([]*models.CallbackIntent, 0)
+
+	err := bs.iterateWithPrefix(CallbackIntentPrefix, func(key []byte, value []byte) error {
+		var intent models.CallbackIntent
+		if err := unmarshalFromStorage(value, &intent); err != nil {
+			return fmt.Errorf("failed to unmarshal callback intent %s: %w", string(key), err)
+		}
+		intents = append(intents, &intent)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list callback intents: %w", err)
+	}
+
+	return intents, nil
+}
+
+// DeadLetterCallbackIntent moves callback intent out of replay log into dead letter log
+// Переносит намерение callback из лога повтора в dead letter лог
+func (bs *BadgerStorage) DeadLetterCallbackIntent(intent *models.CallbackIntent, reason string) error {
+	if err := bs.validateStorage(); err != nil {
+		return err
+	}
+
+	intent.DeadLetterReason = reason
+	intent.UpdatedAt = time.Now()
+	data, err := marshalForStorage(intent)
+	if err != nil {
+		return fmt.Errorf("failed to marshal callback intent: %w", err)
+	}
+
+	if err := bs.db.Update(func(txn *badger.Txn) error {
+		if err := txn.Set([]byte(CallbackDeadLetterPrefix+intent.ID), data); err != nil {
+			return err
+		}
+		return txn.Delete([]byte(CallbackIntentPrefix + intent.ID))
+	}); err != nil {
+		return fmt.Errorf("failed to dead-letter callback intent: %w", err)
+	}
+	return nil
+}
+
+// ListDeadLetterCallbackIntents returns callback intents given up on replay
+// Возвращает намерения callback, от повтора которых отказались
+func (bs *BadgerStorage) ListDeadLetterCallbackIntents() ([]*models.CallbackIntent, error) {
+	intents := make([]*models.CallbackIntent, 0)
+
+	err := bs.iterateWithPrefix(CallbackDeadLetterPrefix, func(key []byte, value []byte) error {
+		var intent models.CallbackIntent
+		if err := unmarshalFromStorage(value, &intent); err != nil {
+			return fmt.Errorf("failed to unmarshal callback intent %s: %w", string(key), err)
+		}
+		intents = append(intents, &intent)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letter callback intents: %w", err)
+	}
+
+	return intents, nil
+}
+
+// IsCallbackProcessed checks whether callback with given ID was already applied
+// Проверяет, был ли callback с указанным ID уже применен
+func (bs *BadgerStorage) IsCallbackProcessed(callbackID string) (bool, error) {
+	exists, err := bs.keyExists(CallbackProcessedPrefix + callbackID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check processed callback: %w", err)
+	}
+	return exists, nil
+}
+
+// MarkCallbackProcessed records callback ID as applied, record expires after retention period
+// Отмечает ID callback как примененный, запись истекает после периода хранения
+func (bs *BadgerStorage) MarkCallbackProcessed(callbackID string) error {
+	if err := bs.validateStorage(); err != nil {
+		return err
+	}
+
+	entry := badger.NewEntry([]byte(CallbackProcessedPrefix+callbackID), []byte(time.Now().Format(time.RFC3339))).
+		WithTTL(ProcessedCallbackRetention)
+	if err := bs.db.Update(func(txn *badger.Txn) error {
+		return txn.SetEntry(entry)
+	}); err != nil {
+		return fmt.Errorf("failed to mark callback processed: %w", err)
+	}
+	return nil
+}
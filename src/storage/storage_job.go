@@ -10,30 +10,71 @@ package storage
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 
+	"atom-engine/src/core/logger"
 	"atom-engine/src/core/models"
 
 	"github.com/dgraph-io/badger/v3"
 )
 
+// Activation queue index keys
+// Queue key orders pending jobs of type by priority descending, then by creation time and numeric key
+// Ключи индекса очереди активации
+// Ключ очереди упорядочивает ожидающие job'ы типа по убыванию приоритета, затем по времени создания и ключу
+const (
+	JobQueueIndexPrefix = "job_queue:"
+	jobQueueRefPrefix   = "job_queue_ref:"
+	jobQueueIndexMarker = "index_version:job_queue"
+)
+
 // Job storage methods
 
 // SaveJob saves job to storage
+// Numeric job key is indexed so job can be loaded by either form
 func (bs *BadgerStorage) SaveJob(ctx context.Context, job *models.Job) error {
-	key := fmt.Sprintf("job:%s", job.ID)
-	return bs.saveJSON(key, job)
+	if err := bs.validateStorage(); err != nil {
+		return err
+	}
+
+	data, err := marshalForStorage(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal data: %w", err)
+	}
+	data, err = bs.variableCipher.seal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt job variables: %w", err)
+	}
+
+	return bs.db.Update(func(txn *badger.Txn) error {
+		if err := txn.Set([]byte(fmt.Sprintf("job:%s", job.ID)), data); err != nil {
+			return err
+		}
+		if err := setJobQueueIndex(txn, job); err != nil {
+			return err
+		}
+		if err := setInstanceRecordIndex(txn, job.ProcessInstanceID, instanceRecordJob, job.ID,
+			fmt.Sprintf("job:%s", job.ID)); err != nil {
+			return err
+		}
+		return setKeyIndex(txn, JobKeyIndexPrefix, job.Key, job.ID)
+	})
 }
 
-// GetJob gets job from storage
+// GetJob gets job from storage by string ID or numeric key
 func (bs *BadgerStorage) GetJob(ctx context.Context, jobID string) (*models.Job, error) {
+	jobID, err := bs.ResolveJobID(jobID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job: %w", err)
+	}
+
 	key := fmt.Sprintf("job:%s", jobID)
 	var job models.Job
 
-	err := bs.loadJSON(key, &job)
+	err = bs.loadJSON(key, &job)
 	if err != nil {
-		if err.Error() == fmt.Sprintf("key not found: %s", key) {
+		if errors.Is(err, models.ErrNotFound) {
 			return nil, nil // Job not found
 		}
 		return nil, fmt.Errorf("failed to get job: %w", err)
@@ -42,6 +83,30 @@ func (bs *BadgerStorage) GetJob(ctx context.Context, jobID string) (*models.Job,
 	return &job, nil
 }
 
+// DeleteJob deletes job from storage
+func (bs *BadgerStorage) DeleteJob(ctx context.Context, jobID string) error {
+	job, err := bs.GetJob(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		return bs.deleteKey(fmt.Sprintf("job:%s", jobID))
+	}
+
+	return bs.db.Update(func(txn *badger.Txn) error {
+		if err := txn.Delete([]byte(fmt.Sprintf("job:%s", job.ID))); err != nil {
+			return err
+		}
+		if err := deleteJobQueueIndex(txn, job.ID); err != nil {
+			return err
+		}
+		if err := deleteInstanceRecordIndex(txn, job.ProcessInstanceID, instanceRecordJob, job.ID); err != nil {
+			return err
+		}
+		return deleteKeyIndex(txn, JobKeyIndexPrefix, job.Key)
+	})
+}
+
 // ListJobsByType lists jobs by type and status
 func (bs *BadgerStorage) ListJobsByType(
 	ctx context.Context,
@@ -66,8 +131,13 @@ func (bs *BadgerStorage) ListJobsByType(
 		for it.Seek(prefix); it.ValidForPrefix(prefix) && (limit <= 0 || count < limit); it.Next() {
 			item := it.Item()
 			err := item.Value(func(val []byte) error {
+				data, err := bs.variableCipher.open(val)
+				if err != nil {
+					return fmt.Errorf("failed to decrypt job variables: %w", err)
+				}
+
 				var job models.Job
-				if err := json.Unmarshal(val, &job); err != nil {
+				if err := job.FromJSON(data); err != nil {
 					return err
 				}
 
@@ -98,3 +168,199 @@ func (bs *BadgerStorage) ListJobsByType(
 
 	return jobs, nil
 }
+
+// ListActivatableJobs lists pending jobs of type in activation order, limit <= 0 lists all
+// Higher priority jobs come first, jobs of same priority are listed oldest first
+// Возвращает ожидающие job'ы типа в порядке активации, limit <= 0 возвращает все
+// Сначала идут job'ы с большим приоритетом, job'ы одного приоритета - от старых к новым
+func (bs *BadgerStorage) ListActivatableJobs(ctx context.Context, jobType string, limit int) ([]*models.Job, error) {
+	if bs.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	var jobs []*models.Job
+	prefix := []byte(JobQueueIndexPrefix + jobType + ":")
+
+	err := bs.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix) && (limit <= 0 || len(jobs) < limit); it.Next() {
+			jobID, err := it.Item().ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+
+			item, err := txn.Get([]byte("job:" + string(jobID)))
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			data, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			data, err = bs.variableCipher.open(data)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt job variables: %w", err)
+			}
+
+			var job models.Job
+			if err := job.FromJSON(data); err != nil {
+				return err
+			}
+
+			// Prefix of type may also match types continuing with colon
+			// Префикс типа может совпасть и с типами, продолжающимися двоеточием
+			if job.Type != jobType || job.Status != models.JobStatusPending {
+				continue
+			}
+			jobs = append(jobs, &job)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list activatable jobs: %w", err)
+	}
+
+	return jobs, nil
+}
+
+// ListJobsByProcessInstance lists all jobs created by process instance
+// Возвращает все job'ы, созданные экземпляром процесса
+func (bs *BadgerStorage) ListJobsByProcessInstance(ctx context.Context, instanceID string) ([]*models.Job, error) {
+	jobs := make([]*models.Job, 0)
+	err := bs.loadIndexedRecords(instanceRecordPrefix(instanceID, instanceRecordJob), func(value []byte) error {
+		data, err := bs.variableCipher.open(value)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt job variables: %w", err)
+		}
+		var job models.Job
+		if err := job.FromJSON(data); err != nil {
+			return err
+		}
+		// Prefix of instance may also match instances continuing with colon
+		// Префикс экземпляра может совпасть и с экземплярами, продолжающимися двоеточием
+		if job.ProcessInstanceID == instanceID {
+			jobs = append(jobs, &job)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs of instance %s: %w", instanceID, err)
+	}
+	return jobs, nil
+}
+
+// ensureJobQueueIndex builds activation queue index of jobs stored before index was introduced
+// Строит индекс очереди активации для job'ов, сохраненных до появления индекса
+func (bs *BadgerStorage) ensureJobQueueIndex() error {
+	built, err := bs.keyExists(jobQueueIndexMarker)
+	if err != nil || built {
+		return err
+	}
+
+	var pending []*models.Job
+	err = bs.iterateWithPrefix("job:", func(key []byte, value []byte) error {
+		data, err := bs.variableCipher.open(value)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt job %s: %w", key, err)
+		}
+		var job models.Job
+		if err := job.FromJSON(data); err != nil {
+			return fmt.Errorf("failed to parse job %s: %w", key, err)
+		}
+		if job.Status == models.JobStatusPending {
+			pending = append(pending, &job)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan jobs: %w", err)
+	}
+
+	for _, job := range pending {
+		if err := bs.db.Update(func(txn *badger.Txn) error {
+			return setJobQueueIndex(txn, job)
+		}); err != nil {
+			return fmt.Errorf("failed to index job %s: %w", job.ID, err)
+		}
+	}
+
+	logger.Info("Job activation queue index built", logger.Int("pending_jobs", len(pending)))
+	return bs.db.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte(jobQueueIndexMarker), []byte("1"))
+	})
+}
+
+// jobQueueKey returns activation queue key of job
+// Priority is mapped to unsigned rank so that higher priority sorts first as text
+// Возвращает ключ очереди активации job'а
+// Приоритет отображается в беззнаковый ранг, чтобы больший приоритет шел первым при сортировке как текст
+func jobQueueKey(job *models.Job) string {
+	rank := ^(uint64(int64(job.Priority)) ^ (1 << 63))
+	return fmt.Sprintf("%s%s:%020d:%020d:%020d:%s",
+		JobQueueIndexPrefix, job.Type, rank, job.CreatedAt.UnixNano(), uint64(job.Key), job.ID)
+}
+
+// setJobQueueIndex keeps job in activation queue while it is pending and removes it otherwise
+// Держит job в очереди активации, пока он ожидает, и удаляет его из очереди в остальных случаях
+func setJobQueueIndex(txn *badger.Txn, job *models.Job) error {
+	queueKey := ""
+	if job.Status == models.JobStatusPending {
+		queueKey = jobQueueKey(job)
+	}
+
+	refKey := []byte(jobQueueRefPrefix + job.ID)
+	item, err := txn.Get(refKey)
+	switch {
+	case errors.Is(err, badger.ErrKeyNotFound):
+	case err != nil:
+		return err
+	default:
+		previous, err := item.ValueCopy(nil)
+		if err != nil {
+			return err
+		}
+		if string(previous) == queueKey {
+			return nil
+		}
+		if err := txn.Delete(previous); err != nil {
+			return err
+		}
+		if queueKey == "" {
+			return txn.Delete(refKey)
+		}
+	}
+
+	if queueKey == "" {
+		return nil
+	}
+	if err := txn.Set([]byte(queueKey), []byte(job.ID)); err != nil {
+		return err
+	}
+	return txn.Set(refKey, []byte(queueKey))
+}
+
+// deleteJobQueueIndex removes job from activation queue
+// Удаляет job из очереди активации
+func deleteJobQueueIndex(txn *badger.Txn, jobID string) error {
+	refKey := []byte(jobQueueRefPrefix + jobID)
+	item, err := txn.Get(refKey)
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	queueKey, err := item.ValueCopy(nil)
+	if err != nil {
+		return err
+	}
+	if err := txn.Delete(queueKey); err != nil {
+		return err
+	}
+	return txn.Delete(refKey)
+}
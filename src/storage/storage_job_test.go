@@ -0,0 +1,117 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"atom-engine/src/core/models"
+)
+
+func TestJobQueueKeyOrdersByPriorityThenCreation(t *testing.T) {
+	created := time.Unix(1700000000, 0)
+	job := func(priority int, offset time.Duration, key int64) *models.Job {
+		return &models.Job{ID: fmt.Sprintf("job-%d", key), Key: key, Type: "work", Priority: priority,
+			CreatedAt: created.Add(offset)}
+	}
+
+	// Expected activation order
+	// Ожидаемый порядок активации
+	ordered := []*models.Job{
+		job(100, 2*time.Second, 1),
+		job(1, 0, 2),
+		job(0, 0, 3),
+		job(0, 0, 4),
+		job(0, time.Second, 5),
+		job(-1, 0, 6),
+		job(-100, 0, 7),
+	}
+	for i := 1; i < len(ordered); i++ {
+		prev, next := jobQueueKey(ordered[i-1]), jobQueueKey(ordered[i])
+		if prev >= next {
+			t.Errorf("queue key %d must sort before %d:\n%s\n%s", i-1, i, prev, next)
+		}
+	}
+}
+
+func TestEnsureJobQueueIndexBackfillsLegacyJobs(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	legacy := NewStorage(&Config{Path: dir}).(*BadgerStorage)
+	if err := legacy.Init(); err != nil {
+		t.Fatalf("init storage: %v", err)
+	}
+	if err := legacy.Start(); err != nil {
+		t.Fatalf("start storage: %v", err)
+	}
+
+	const jobCount = 1000
+	base := time.Now().Add(-time.Hour)
+	var expected []string
+	byPriority := map[int][]string{}
+	for i := 0; i < jobCount; i++ {
+		job := models.NewJob("backfill", "instance-1", "task")
+		job.Priority = i % 3
+		job.CreatedAt = base.Add(time.Duration(i) * time.Millisecond)
+		if i%10 == 9 {
+			job.Status = models.JobStatusCompleted
+		} else {
+			byPriority[job.Priority] = append(byPriority[job.Priority], job.ID)
+		}
+		if err := legacy.SaveJob(ctx, job); err != nil {
+			t.Fatalf("save job: %v", err)
+		}
+	}
+	for priority := 2; priority >= 0; priority-- {
+		expected = append(expected, byPriority[priority]...)
+	}
+
+	// Drop index and its marker, as in database written before index was introduced
+	// Удаляем индекс и его маркер, как в базе, записанной до появления индекса
+	for _, prefix := range []string{JobQueueIndexPrefix, jobQueueRefPrefix, jobQueueIndexMarker} {
+		if err := legacy.db.DropPrefix([]byte(prefix)); err != nil {
+			t.Fatalf("drop %s: %v", prefix, err)
+		}
+	}
+	if jobs, err := legacy.ListActivatableJobs(ctx, "backfill", 0); err != nil || len(jobs) != 0 {
+		t.Fatalf("legacy database must have empty queue, got %d jobs, err %v", len(jobs), err)
+	}
+	if err := legacy.Stop(); err != nil {
+		t.Fatalf("stop storage: %v", err)
+	}
+
+	bs := newTestStorage(t, &Config{Path: dir})
+
+	built, err := bs.keyExists(jobQueueIndexMarker)
+	if err != nil || !built {
+		t.Fatalf("index marker not written after backfill: %v", err)
+	}
+
+	jobs, err := bs.ListActivatableJobs(ctx, "backfill", 0)
+	if err != nil {
+		t.Fatalf("list activatable jobs: %v", err)
+	}
+	if len(jobs) != len(expected) {
+		t.Fatalf("backfilled queue has %d jobs, want %d", len(jobs), len(expected))
+	}
+	for i, job := range jobs {
+		if job.ID != expected[i] {
+			t.Fatalf("queue position %d: got job %s, want %s", i, job.ID, expected[i])
+		}
+	}
+
+	limited, err := bs.ListActivatableJobs(ctx, "backfill", 5)
+	if err != nil || len(limited) != 5 || limited[0].ID != expected[0] {
+		t.Fatalf("limited listing must return queue head, got %d jobs, err %v", len(limited), err)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"strconv"
+
+	"atom-engine/src/core/models"
+
+	"github.com/dgraph-io/badger/v3"
+)
+
+// Numeric key index prefixes mapping int64 keys to string IDs
+// Префиксы индекса числовых ключей, отображающих ключи int64 в строковые ID
+const (
+	JobKeyIndexPrefix             = "key:job:"
+	ProcessInstanceKeyIndexPrefix = "key:instance:"
+)
+
+// ResolveProcessInstanceID returns instance ID for numeric key or string ID
+// Unknown numeric keys and string IDs are returned unchanged
+// Возвращает ID экземпляра по числовому ключу или строковому ID
+// Неизвестные числовые ключи и строковые ID возвращаются без изменений
+func (bs *BadgerStorage) ResolveProcessInstanceID(instanceIDOrKey string) (string, error) {
+	return bs.resolveKey(ProcessInstanceKeyIndexPrefix, instanceIDOrKey)
+}
+
+// ResolveJobID returns job ID for numeric key or string ID
+// Unknown numeric keys and string IDs are returned unchanged
+// Возвращает ID job'а по числовому ключу или строковому ID
+// Неизвестные числовые ключи и строковые ID возвращаются без изменений
+func (bs *BadgerStorage) ResolveJobID(jobIDOrKey string) (string, error) {
+	return bs.resolveKey(JobKeyIndexPrefix, jobIDOrKey)
+}
+
+// resolveKey looks up string ID in key index when value is numeric key
+// Ищет строковый ID в индексе ключей, если значение является числовым ключом
+func (bs *BadgerStorage) resolveKey(prefix, value string) (string, error) {
+	key, ok := models.ParseKey(value)
+	if !ok {
+		return value, nil
+	}
+	if bs.db == nil {
+		return "", fmt.Errorf("database not initialized")
+	}
+
+	resolved := value
+	err := bs.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(keyIndexEntry(prefix, key))
+		if err == badger.ErrKeyNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		return item.Value(func(val []byte) error {
+			resolved = string(val)
+			return nil
+		})
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve key %d: %w", key, err)
+	}
+
+	return resolved, nil
+}
+
+// setKeyIndex writes key index entry inside transaction, skipping entities without key
+// Записывает запись индекса ключей в транзакции, пропуская сущности без ключа
+func setKeyIndex(txn *badger.Txn, prefix string, key int64, id string) error {
+	if key <= 0 {
+		return nil
+	}
+	return txn.Set(keyIndexEntry(prefix, key), []byte(id))
+}
+
+// deleteKeyIndex removes key index entry inside transaction
+// Удаляет запись индекса ключей в транзакции
+func deleteKeyIndex(txn *badger.Txn, prefix string, key int64) error {
+	if key <= 0 {
+		return nil
+	}
+	return txn.Delete(keyIndexEntry(prefix, key))
+}
+
+// keyIndexEntry builds storage key of index entry
+// Формирует ключ storage для записи индекса
+func keyIndexEntry(prefix string, key int64) []byte {
+	return []byte(prefix + strconv.FormatInt(key, 10))
+}
@@ -11,6 +11,7 @@ package storage
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"atom-engine/src/core/models"
@@ -171,7 +172,10 @@ func (bs *BadgerStorage) SaveBufferedMessage(ctx context.Context, message *model
 
 	key := fmt.Sprintf("buf_msg:%s", message.ID)
 	return bs.db.Update(func(txn *badger.Txn) error {
-		return txn.Set([]byte(key), data)
+		if err := txn.Set([]byte(key), data); err != nil {
+			return err
+		}
+		return setBufferedMessageNameIndex(txn, message)
 	})
 }
 
@@ -284,6 +288,31 @@ func (bs *BadgerStorage) ListBufferedMessages(
 	return messages, nil
 }
 
+// ListBufferedMessagesByName lists buffered messages with given name
+// Возвращает буферизованные сообщения с указанным именем
+func (bs *BadgerStorage) ListBufferedMessagesByName(
+	ctx context.Context,
+	messageName string,
+) ([]*models.BufferedMessage, error) {
+	messages := make([]*models.BufferedMessage, 0)
+	err := bs.loadIndexedRecords(BufferedMessageNameIndexPrefix+messageName+":", func(value []byte) error {
+		var msg models.BufferedMessage
+		if err := json.Unmarshal(value, &msg); err != nil {
+			return nil // Skip corrupted entries
+		}
+		// Prefix of name may also match names continuing with colon
+		// Префикс имени может совпасть и с именами, продолжающимися двоеточием
+		if msg.Name == messageName {
+			messages = append(messages, &msg)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list messages %s: %w", messageName, err)
+	}
+	return messages, nil
+}
+
 // DeleteBufferedMessage deletes buffered message
 func (bs *BadgerStorage) DeleteBufferedMessage(ctx context.Context, messageID string) error {
 	if bs.db == nil {
@@ -292,6 +321,21 @@ func (bs *BadgerStorage) DeleteBufferedMessage(ctx context.Context, messageID st
 
 	key := fmt.Sprintf("buf_msg:%s", messageID)
 	return bs.db.Update(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(key))
+		switch {
+		case errors.Is(err, badger.ErrKeyNotFound):
+		case err != nil:
+			return err
+		default:
+			var message models.BufferedMessage
+			if err := item.Value(func(val []byte) error {
+				return json.Unmarshal(val, &message)
+			}); err == nil {
+				if err := deleteBufferedMessageNameIndex(txn, &message); err != nil {
+					return err
+				}
+			}
+		}
 		return txn.Delete([]byte(key))
 	})
 }
@@ -312,7 +356,10 @@ func (bs *BadgerStorage) SaveMessageCorrelationResult(
 
 	key := fmt.Sprintf("msg_corr:%s", result.ID)
 	return bs.db.Update(func(txn *badger.Txn) error {
-		return txn.Set([]byte(key), data)
+		if err := txn.Set([]byte(key), data); err != nil {
+			return err
+		}
+		return setInstanceRecordIndex(txn, result.ProcessInstanceID, instanceRecordCorrelation, result.ID, key)
 	})
 }
 
@@ -378,6 +425,30 @@ func (bs *BadgerStorage) ListMessageCorrelationResults(
 	return results, nil
 }
 
+// ListMessageCorrelationResultsByInstance lists message correlation results of process instance
+// Возвращает результаты корреляции сообщений экземпляра процесса
+func (bs *BadgerStorage) ListMessageCorrelationResultsByInstance(
+	ctx context.Context,
+	instanceID string,
+) ([]*models.MessageCorrelationResult, error) {
+	results := make([]*models.MessageCorrelationResult, 0)
+	prefix := instanceRecordPrefix(instanceID, instanceRecordCorrelation)
+	err := bs.loadIndexedRecords(prefix, func(value []byte) error {
+		var result models.MessageCorrelationResult
+		if err := json.Unmarshal(value, &result); err != nil {
+			return err
+		}
+		if result.ProcessInstanceID == instanceID {
+			results = append(results, &result)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list results of instance %s: %w", instanceID, err)
+	}
+	return results, nil
+}
+
 // DeleteMessageCorrelationResult deletes message correlation result
 func (bs *BadgerStorage) DeleteMessageCorrelationResult(ctx context.Context, resultID string) error {
 	if bs.db == nil {
@@ -386,6 +457,22 @@ func (bs *BadgerStorage) DeleteMessageCorrelationResult(ctx context.Context, res
 
 	key := fmt.Sprintf("msg_corr:%s", resultID)
 	return bs.db.Update(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(key))
+		switch {
+		case errors.Is(err, badger.ErrKeyNotFound):
+		case err != nil:
+			return err
+		default:
+			var result models.MessageCorrelationResult
+			if err := item.Value(func(val []byte) error {
+				return json.Unmarshal(val, &result)
+			}); err == nil {
+				if err := deleteInstanceRecordIndex(txn, result.ProcessInstanceID, instanceRecordCorrelation,
+					resultID); err != nil {
+					return err
+				}
+			}
+		}
 		return txn.Delete([]byte(key))
 	})
 }
@@ -9,6 +9,7 @@ This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
 package storage
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"atom-engine/src/core/models"
@@ -25,22 +26,49 @@ const (
 
 // SaveProcessInstance saves process instance to storage
 // Сохраняет экземпляр процесса в storage
+// Numeric instance key is indexed so instance can be loaded by either form
+// Числовой ключ экземпляра индексируется для загрузки по любой форме
 func (bs *BadgerStorage) SaveProcessInstance(instance *models.ProcessInstance) error {
-	key := ProcessInstancePrefix + instance.InstanceID
-	return bs.saveJSON(key, instance)
+	if err := bs.validateStorage(); err != nil {
+		return err
+	}
+
+	data, err := marshalForStorage(instance)
+	if err != nil {
+		return fmt.Errorf("failed to marshal data: %w", err)
+	}
+	data, err = bs.variableCipher.seal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt process instance variables: %w", err)
+	}
+
+	return bs.db.Update(func(txn *badger.Txn) error {
+		if err := bs.recordInstanceVariables(txn, instance); err != nil {
+			return err
+		}
+		if err := txn.Set([]byte(ProcessInstancePrefix+instance.InstanceID), data); err != nil {
+			return err
+		}
+		return setKeyIndex(txn, ProcessInstanceKeyIndexPrefix, instance.Key, instance.InstanceID)
+	})
 }
 
-// LoadProcessInstance loads process instance from storage
-// Загружает экземпляр процесса из storage
+// LoadProcessInstance loads process instance from storage by string ID or numeric key
+// Загружает экземпляр процесса из storage по строковому ID или числовому ключу
 func (bs *BadgerStorage) LoadProcessInstance(instanceID string) (*models.ProcessInstance, error) {
 	if bs.db == nil {
 		return nil, fmt.Errorf("database not initialized")
 	}
 
-	key := ProcessInstancePrefix + instanceID
+	resolvedID, err := bs.ResolveProcessInstanceID(instanceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load process instance: %w", err)
+	}
+
+	key := ProcessInstancePrefix + resolvedID
 	var data []byte
 
-	err := bs.db.View(func(txn *badger.Txn) error {
+	err = bs.db.View(func(txn *badger.Txn) error {
 		item, err := txn.Get([]byte(key))
 		if err != nil {
 			return err
@@ -54,11 +82,16 @@ func (bs *BadgerStorage) LoadProcessInstance(instanceID string) (*models.Process
 
 	if err != nil {
 		if err == badger.ErrKeyNotFound {
-			return nil, fmt.Errorf("process instance not found: %s", instanceID)
+			return nil, fmt.Errorf("process instance %w: %s", models.ErrNotFound, instanceID)
 		}
 		return nil, fmt.Errorf("failed to load process instance: %w", err)
 	}
 
+	data, err = bs.variableCipher.open(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt process instance variables: %w", err)
+	}
+
 	var instance models.ProcessInstance
 	if err := instance.FromJSON(data); err != nil {
 		return nil, fmt.Errorf("failed to deserialize process instance: %w", err)
@@ -95,6 +128,11 @@ func (bs *BadgerStorage) LoadProcessInstancesByProcessKey(processKey string) ([]
 				return fmt.Errorf("failed to read process instance data: %w", err)
 			}
 
+			data, err = bs.variableCipher.open(data)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt process instance variables: %w", err)
+			}
+
 			var instance models.ProcessInstance
 			if err := instance.FromJSON(data); err != nil {
 				continue // Skip invalid entries
@@ -143,6 +181,11 @@ func (bs *BadgerStorage) LoadAllProcessInstances() ([]*models.ProcessInstance, e
 				return fmt.Errorf("failed to read process instance data: %w", err)
 			}
 
+			data, err = bs.variableCipher.open(data)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt process instance variables: %w", err)
+			}
+
 			var instance models.ProcessInstance
 			if err := instance.FromJSON(data); err != nil {
 				continue // Skip invalid entries
@@ -174,10 +217,27 @@ func (bs *BadgerStorage) DeleteProcessInstance(instanceID string) error {
 		return fmt.Errorf("database not initialized")
 	}
 
+	// Key index entry, element instances and variable history are removed together with instance
+	// Запись индекса ключей, экземпляры элементов и история переменных удаляются вместе с экземпляром
+	var instanceKey int64
+	if instance, err := bs.LoadProcessInstance(instanceID); err == nil {
+		instanceID = instance.InstanceID
+		instanceKey = instance.Key
+	}
+
 	key := ProcessInstancePrefix + instanceID
 
 	return bs.db.Update(func(txn *badger.Txn) error {
-		return txn.Delete([]byte(key))
+		if err := txn.Delete([]byte(key)); err != nil {
+			return err
+		}
+		if err := deleteKeyIndex(txn, ProcessInstanceKeyIndexPrefix, instanceKey); err != nil {
+			return err
+		}
+		if err := deleteElementInstances(txn, instanceID); err != nil {
+			return err
+		}
+		return deleteVariableHistory(txn, instanceID)
 	})
 }
 
@@ -188,18 +248,40 @@ func (bs *BadgerStorage) SaveToken(token *models.Token) error {
 		return fmt.Errorf("database not initialized")
 	}
 
-	data, err := token.ToJSON()
+	data, err := bs.encodeToken(token)
 	if err != nil {
-		return fmt.Errorf("failed to serialize token: %w", err)
+		return err
 	}
 
 	key := TokenPrefix + token.TokenID
 
+	// Element activation and changed variables are recorded with token, so history never disagrees with token
+	// Активация элемента и измененные переменные записываются вместе с токеном, поэтому история не расходится с токеном
 	return bs.db.Update(func(txn *badger.Txn) error {
-		return txn.Set([]byte(key), data)
+		if err := bs.recordTokenVariables(txn, token); err != nil {
+			return err
+		}
+		if err := txn.Set([]byte(key), data); err != nil {
+			return err
+		}
+		return recordElementInstance(txn, token)
 	})
 }
 
+// encodeToken serializes token and encrypts its variables for storage
+// Сериализует токен и шифрует его переменные для storage
+func (bs *BadgerStorage) encodeToken(token *models.Token) ([]byte, error) {
+	data, err := token.ToJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize token: %w", err)
+	}
+	data, err = bs.variableCipher.seal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt token variables: %w", err)
+	}
+	return data, nil
+}
+
 // LoadToken loads token from storage
 // Загружает токен из storage
 func (bs *BadgerStorage) LoadToken(tokenID string) (*models.Token, error) {
@@ -224,11 +306,16 @@ func (bs *BadgerStorage) LoadToken(tokenID string) (*models.Token, error) {
 
 	if err != nil {
 		if err == badger.ErrKeyNotFound {
-			return nil, fmt.Errorf("token not found: %s", tokenID)
+			return nil, fmt.Errorf("token %w: %s", models.ErrNotFound, tokenID)
 		}
 		return nil, fmt.Errorf("failed to load token: %w", err)
 	}
 
+	data, err = bs.variableCipher.open(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt token variables: %w", err)
+	}
+
 	var token models.Token
 	if err := token.FromJSON(data); err != nil {
 		return nil, fmt.Errorf("failed to deserialize token: %w", err)
@@ -265,6 +352,11 @@ func (bs *BadgerStorage) LoadTokensByProcessInstance(processInstanceID string) (
 				return fmt.Errorf("failed to read token data: %w", err)
 			}
 
+			data, err = bs.variableCipher.open(data)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt token variables: %w", err)
+			}
+
 			var token models.Token
 			if err := token.FromJSON(data); err != nil {
 				continue // Skip invalid entries
@@ -319,6 +411,11 @@ func (bs *BadgerStorage) LoadAllTokens() ([]*models.Token, error) {
 				return fmt.Errorf("failed to read token data: %w", err)
 			}
 
+			data, err = bs.variableCipher.open(data)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt token variables: %w", err)
+			}
+
 			var token models.Token
 			if err := token.FromJSON(data); err != nil {
 				continue // Skip invalid entries
@@ -364,6 +461,11 @@ func (bs *BadgerStorage) LoadTokensByState(state models.TokenState) ([]*models.T
 				return fmt.Errorf("failed to read token data: %w", err)
 			}
 
+			data, err = bs.variableCipher.open(data)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt token variables: %w", err)
+			}
+
 			var token models.Token
 			if err := token.FromJSON(data); err != nil {
 				continue // Skip invalid entries
@@ -401,6 +503,94 @@ func (bs *BadgerStorage) DeleteToken(tokenID string) error {
 	key := TokenPrefix + tokenID
 
 	return bs.db.Update(func(txn *badger.Txn) error {
-		return txn.Delete([]byte(key))
+		if err := txn.Delete([]byte(key)); err != nil {
+			return err
+		}
+		return txn.Delete([]byte(ElementInstanceCursorPrefix + tokenID))
 	})
 }
+
+// InstanceActivityCounts holds counts of unfinished work of process instance
+// Количество незавершенной работы экземпляра процесса
+type InstanceActivityCounts struct {
+	ActiveTimers  int `json:"active_timers"`  // Scheduled timers
+	ActiveJobs    int `json:"active_jobs"`    // Pending, running and deferred jobs
+	OpenIncidents int `json:"open_incidents"` // Incidents not resolved or dismissed
+}
+
+// instanceActivityRecord holds fields of timer, job and incident records needed for counting
+// Поля записей таймеров, job'ов и инцидентов, нужные для подсчета
+type instanceActivityRecord struct {
+	ProcessInstanceID string `json:"process_instance_id"`
+	Status            string `json:"status"`
+	State             string `json:"state"`
+}
+
+// CountInstanceActivity counts active timers, jobs and open incidents of instances
+// Records are scanned once in single read transaction, not per instance
+// Подсчитывает активные таймеры, job'ы и открытые инциденты экземпляров
+// Записи просматриваются один раз в одной транзакции чтения, а не по каждому экземпляру
+func (bs *BadgerStorage) CountInstanceActivity(instanceIDs []string) (map[string]*InstanceActivityCounts, error) {
+	if bs.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	counts := make(map[string]*InstanceActivityCounts, len(instanceIDs))
+	for _, instanceID := range instanceIDs {
+		counts[instanceID] = &InstanceActivityCounts{}
+	}
+	if len(counts) == 0 {
+		return counts, nil
+	}
+
+	err := bs.db.View(func(txn *badger.Txn) error {
+		scans := []struct {
+			prefix string
+			count  func(record *instanceActivityRecord, c *InstanceActivityCounts)
+		}{
+			{"timer_", func(record *instanceActivityRecord, c *InstanceActivityCounts) {
+				if record.State == "SCHEDULED" {
+					c.ActiveTimers++
+				}
+			}},
+			{"job:", func(record *instanceActivityRecord, c *InstanceActivityCounts) {
+				job := models.Job{Status: models.JobStatus(record.Status)}
+				if !job.IsCompleted() {
+					c.ActiveJobs++
+				}
+			}},
+			{"incident:", func(record *instanceActivityRecord, c *InstanceActivityCounts) {
+				if record.Status == "OPEN" {
+					c.OpenIncidents++
+				}
+			}},
+		}
+
+		for _, scan := range scans {
+			opts := badger.DefaultIteratorOptions
+			opts.Prefix = []byte(scan.prefix)
+			it := txn.NewIterator(opts)
+
+			for it.Rewind(); it.Valid(); it.Next() {
+				var record instanceActivityRecord
+				err := it.Item().Value(func(val []byte) error {
+					return json.Unmarshal(val, &record)
+				})
+				if err != nil {
+					continue // Skip invalid entries
+				}
+				if c, ok := counts[record.ProcessInstanceID]; ok {
+					scan.count(&record, c)
+				}
+			}
+			it.Close()
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to count instance activity: %w", err)
+	}
+
+	return counts, nil
+}
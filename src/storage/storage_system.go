@@ -13,6 +13,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"atom-engine/src/core/logger"
@@ -20,19 +22,39 @@ import (
 	"github.com/dgraph-io/badger/v3"
 )
 
+// System event storage key prefixes
+// Префиксы ключей хранилища системных событий
+const (
+	SystemEventPrefix = "system_events:"
+)
+
+// systemEventsDeleteBatch limits keys deleted in single transaction
+// Ограничивает количество ключей, удаляемых в одной транзакции
+const systemEventsDeleteBatch = 1000
+
 // LogSystemEvent logs system events to database
 // Логирует системные события в базу данных
 func (s *BadgerStorage) LogSystemEvent(eventType, status, message string) error {
+	return s.LogSystemEventWithFields(eventType, status, message, SystemEventFields{})
+}
+
+// LogSystemEventWithFields logs system event with structured fields
+// Логирует системное событие со структурированными полями
+func (s *BadgerStorage) LogSystemEventWithFields(eventType, status, message string, fields SystemEventFields) error {
 	if !s.ready {
 		return fmt.Errorf("storage not ready")
 	}
 
+	now := time.Now()
 	event := SystemEventRecord{
-		ID:        fmt.Sprintf("event_%d", time.Now().UnixNano()),
-		EventType: eventType,
-		Status:    status,
-		Message:   message,
-		CreatedAt: time.Now(),
+		ID:         fmt.Sprintf("event_%d", now.UnixNano()),
+		EventType:  eventType,
+		Status:     status,
+		Message:    message,
+		Component:  fields.Component,
+		InstanceID: fields.InstanceID,
+		JobID:      fields.JobID,
+		CreatedAt:  now,
 	}
 
 	data, err := json.Marshal(event)
@@ -40,7 +62,7 @@ func (s *BadgerStorage) LogSystemEvent(eventType, status, message string) error
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
-	key := fmt.Sprintf("system_events:%s", event.ID)
+	key := SystemEventPrefix + event.ID
 	err = s.db.Update(func(txn *badger.Txn) error {
 		return txn.Set([]byte(key), data)
 	})
@@ -55,6 +77,7 @@ func (s *BadgerStorage) LogSystemEvent(eventType, status, message string) error
 	logger.Debug("System event logged to DB",
 		logger.String("event_type", eventType),
 		logger.String("status", status),
+		logger.String("component", fields.Component),
 		logger.String("message", message))
 	return nil
 }
@@ -62,12 +85,25 @@ func (s *BadgerStorage) LogSystemEvent(eventType, status, message string) error
 // LoadSystemEvents loads recent system events from database
 // Загружает последние системные события из базы данных
 func (s *BadgerStorage) LoadSystemEvents(limit int) ([]*SystemEventRecord, error) {
+	events, _, err := s.QuerySystemEvents(&SystemEventFilter{Limit: limit})
+	return events, err
+}
+
+// QuerySystemEvents returns filtered system events newest first and total match count
+// Возвращает отфильтрованные системные события от новых к старым и общее число совпадений
+func (s *BadgerStorage) QuerySystemEvents(filter *SystemEventFilter) ([]*SystemEventRecord, int, error) {
 	if !s.ready {
-		return nil, fmt.Errorf("storage not ready")
+		return nil, 0, fmt.Errorf("storage not ready")
 	}
 
-	var events []*SystemEventRecord
-	prefix := []byte("system_events:")
+	if filter == nil {
+		filter = &SystemEventFilter{}
+	}
+	text := strings.ToLower(filter.Text)
+
+	events := make([]*SystemEventRecord, 0)
+	total := 0
+	prefix := []byte(SystemEventPrefix)
 
 	err := s.db.View(func(txn *badger.Txn) error {
 		opts := badger.DefaultIteratorOptions
@@ -76,39 +112,150 @@ func (s *BadgerStorage) LoadSystemEvents(limit int) ([]*SystemEventRecord, error
 		it := txn.NewIterator(opts)
 		defer it.Close()
 
-		count := 0
-		for it.Seek(prefix); it.ValidForPrefix(prefix) && count < limit; it.Next() {
+		// Reverse iteration must start after the last key with prefix
+		// Обратная итерация должна начинаться после последнего ключа с префиксом
+		seekKey := append(append([]byte{}, prefix...), 0xFF)
+
+		for it.Seek(seekKey); it.ValidForPrefix(prefix); it.Next() {
 			item := it.Item()
 
+			var event SystemEventRecord
 			err := item.Value(func(val []byte) error {
-				var event SystemEventRecord
-				if err := json.Unmarshal(val, &event); err != nil {
-					logger.Warn("Failed to unmarshal system event",
-						logger.String("key", string(item.Key())),
-						logger.String("error", err.Error()))
-					return nil // Continue processing other events
-				}
-				events = append(events, &event)
-				return nil
+				return json.Unmarshal(val, &event)
 			})
-
 			if err != nil {
-				return err
+				logger.Warn("Failed to unmarshal system event",
+					logger.String("key", string(item.Key())),
+					logger.String("error", err.Error()))
+				continue // Continue processing other events
+			}
+
+			// Events are ordered by time so older ones can't match
+			// События упорядочены по времени, более старые не подойдут
+			if !filter.Since.IsZero() && event.CreatedAt.Before(filter.Since) {
+				break
+			}
+
+			if !matchSystemEvent(&event, filter, text) {
+				continue
+			}
+
+			total++
+			if total <= filter.Offset {
+				continue
+			}
+			if filter.Limit <= 0 || len(events) < filter.Limit {
+				events = append(events, &event)
 			}
-			count++
 		}
 		return nil
 	})
 
 	if err != nil {
 		logger.Error("Failed to load system events", logger.String("error", err.Error()))
-		return nil, fmt.Errorf("failed to load system events from database: %w", err)
+		return nil, 0, fmt.Errorf("failed to load system events from database: %w", err)
 	}
 
 	logger.Debug("Loaded system events from storage",
 		logger.Int("count", len(events)),
-		logger.Int("limit", limit))
-	return events, nil
+		logger.Int("total", total),
+		logger.Int("limit", filter.Limit))
+	return events, total, nil
+}
+
+// matchSystemEvent checks event against filter
+// Проверяет соответствие события фильтру
+func matchSystemEvent(event *SystemEventRecord, filter *SystemEventFilter, text string) bool {
+	if filter.EventType != "" && event.EventType != filter.EventType {
+		return false
+	}
+	if filter.Status != "" && event.Status != filter.Status {
+		return false
+	}
+	if filter.Component != "" && event.Component != filter.Component {
+		return false
+	}
+	if filter.InstanceID != "" && event.InstanceID != filter.InstanceID {
+		return false
+	}
+	if filter.JobID != "" && event.JobID != filter.JobID {
+		return false
+	}
+	if !filter.Until.IsZero() && event.CreatedAt.After(filter.Until) {
+		return false
+	}
+	if text != "" && !strings.Contains(strings.ToLower(event.Message), text) {
+		return false
+	}
+	return true
+}
+
+// DeleteSystemEventsBefore deletes system events created before cutoff
+// Удаляет системные события, созданные до указанного времени
+func (s *BadgerStorage) DeleteSystemEventsBefore(cutoff time.Time) (int, error) {
+	if !s.ready {
+		return 0, fmt.Errorf("storage not ready")
+	}
+
+	// First, collect expired keys. Event ID contains creation time in nanoseconds
+	// Сначала собираем устаревшие ключи. ID события содержит время создания в наносекундах
+	var keysToDelete [][]byte
+	prefix := []byte(SystemEventPrefix)
+	cutoffNanos := cutoff.UnixNano()
+
+	err := s.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			key := it.Item().KeyCopy(nil)
+			nanosStr := strings.TrimPrefix(string(key), SystemEventPrefix+"event_")
+			nanos, err := strconv.ParseInt(nanosStr, 10, 64)
+			if err != nil {
+				continue
+			}
+			if nanos >= cutoffNanos {
+				break
+			}
+			keysToDelete = append(keysToDelete, key)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to scan system events for cleanup: %w", err)
+	}
+
+	// Then, delete in batches to keep transactions small
+	// Затем удаляем пачками, чтобы транзакции оставались небольшими
+	deleted := 0
+	for start := 0; start < len(keysToDelete); start += systemEventsDeleteBatch {
+		end := start + systemEventsDeleteBatch
+		if end > len(keysToDelete) {
+			end = len(keysToDelete)
+		}
+
+		err = s.db.Update(func(txn *badger.Txn) error {
+			for _, key := range keysToDelete[start:end] {
+				if err := txn.Delete(key); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return deleted, fmt.Errorf("failed to delete expired system events: %w", err)
+		}
+		deleted += end - start
+	}
+
+	if deleted > 0 {
+		logger.Debug("System events cleanup completed", logger.Int("deleted_events", deleted))
+	}
+
+	return deleted, nil
 }
 
 // GetStatus returns current storage status
@@ -175,6 +322,12 @@ func (s *BadgerStorage) GetInfo() (*StorageInfo, error) {
 	info.Statistics["key_count"] = fmt.Sprintf("%d", keyCount)
 	info.Statistics["db_path"] = s.config.Path
 
+	// Offloaded variable blobs
+	if blobStats, err := s.GetVariableBlobStats(); err == nil {
+		info.Statistics["variable_blobs_count"] = fmt.Sprintf("%d", blobStats.Count)
+		info.Statistics["variable_blobs_size_bytes"] = fmt.Sprintf("%d", blobStats.TotalSize)
+	}
+
 	return info, nil
 }
 
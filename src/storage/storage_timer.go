@@ -10,10 +10,12 @@ package storage
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
 
 	"github.com/dgraph-io/badger/v3"
 )
@@ -26,8 +28,21 @@ func (s *BadgerStorage) SaveTimer(timer *TimerRecord) error {
 		timer.CreatedAt = time.Now()
 	}
 
+	if err := s.validateStorage(); err != nil {
+		return err
+	}
+	data, err := marshalForStorage(timer)
+	if err != nil {
+		return fmt.Errorf("failed to marshal timer: %w", err)
+	}
+
 	key := fmt.Sprintf("timer_%s", timer.ID)
-	err := s.saveJSON(key, timer)
+	err = s.db.Update(func(txn *badger.Txn) error {
+		if err := txn.Set([]byte(key), data); err != nil {
+			return err
+		}
+		return setInstanceRecordIndex(txn, timer.ProcessInstanceID, instanceRecordTimer, timer.ID, key)
+	})
 	if err != nil {
 		logger.Error("Failed to save timer",
 			logger.String("timer_id", timer.ID),
@@ -65,7 +80,7 @@ func (s *BadgerStorage) LoadTimer(timerID string) (*TimerRecord, error) {
 	})
 
 	if err == badger.ErrKeyNotFound {
-		return nil, fmt.Errorf("timer not found: %s", timerID)
+		return nil, fmt.Errorf("timer %w: %s", models.ErrNotFound, timerID)
 	}
 
 	if err != nil {
@@ -130,6 +145,26 @@ func (s *BadgerStorage) LoadAllTimers() ([]*TimerRecord, error) {
 	return timers, nil
 }
 
+// LoadTimersByProcessInstance loads all timers of process instance
+// Загружает все таймеры экземпляра процесса
+func (s *BadgerStorage) LoadTimersByProcessInstance(instanceID string) ([]*TimerRecord, error) {
+	timers := make([]*TimerRecord, 0)
+	err := s.loadIndexedRecords(instanceRecordPrefix(instanceID, instanceRecordTimer), func(value []byte) error {
+		var timer TimerRecord
+		if err := json.Unmarshal(value, &timer); err != nil {
+			return err
+		}
+		if timer.ProcessInstanceID == instanceID {
+			timers = append(timers, &timer)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load timers of instance %s: %w", instanceID, err)
+	}
+	return timers, nil
+}
+
 // DeleteTimer deletes timer from database
 // Удаляет таймер из базы данных
 func (s *BadgerStorage) DeleteTimer(timerID string) error {
@@ -139,12 +174,28 @@ func (s *BadgerStorage) DeleteTimer(timerID string) error {
 
 	key := fmt.Sprintf("timer_%s", timerID)
 	err := s.db.Update(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(key))
+		switch {
+		case errors.Is(err, badger.ErrKeyNotFound):
+		case err != nil:
+			return err
+		default:
+			var timer TimerRecord
+			if err := item.Value(func(val []byte) error {
+				return json.Unmarshal(val, &timer)
+			}); err == nil {
+				if err := deleteInstanceRecordIndex(txn, timer.ProcessInstanceID, instanceRecordTimer,
+					timerID); err != nil {
+					return err
+				}
+			}
+		}
 		return txn.Delete([]byte(key))
 	})
 
 	if err == badger.ErrKeyNotFound {
 		logger.Warn("Timer not found for deletion", logger.String("timer_id", timerID))
-		return fmt.Errorf("timer not found: %s", timerID)
+		return fmt.Errorf("timer %w: %s", models.ErrNotFound, timerID)
 	}
 
 	if err != nil {
@@ -174,7 +225,10 @@ func (s *BadgerStorage) UpdateTimer(timer *TimerRecord) error {
 
 	key := fmt.Sprintf("timer_%s", timer.ID)
 	err = s.db.Update(func(txn *badger.Txn) error {
-		return txn.Set([]byte(key), data)
+		if err := txn.Set([]byte(key), data); err != nil {
+			return err
+		}
+		return setInstanceRecordIndex(txn, timer.ProcessInstanceID, instanceRecordTimer, timer.ID, key)
 	})
 
 	if err != nil {
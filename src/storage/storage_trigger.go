@@ -0,0 +1,66 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package storage
+
+import (
+	"fmt"
+
+	"atom-engine/src/core/models"
+)
+
+// Webhook trigger storage key prefixes
+// Префиксы ключей для хранилища webhook триггеров
+const (
+	WebhookTriggerPrefix = "webhook_trigger:"
+)
+
+// SaveWebhookTrigger persists webhook trigger
+// Сохраняет webhook триггер
+func (bs *BadgerStorage) SaveWebhookTrigger(trigger *models.WebhookTrigger) error {
+	if err := bs.saveJSON(WebhookTriggerPrefix+trigger.ID, trigger); err != nil {
+		return fmt.Errorf("failed to save webhook trigger: %w", err)
+	}
+	return nil
+}
+
+// LoadWebhookTrigger loads webhook trigger by ID
+// Загружает webhook триггер по ID
+func (bs *BadgerStorage) LoadWebhookTrigger(triggerID string) (*models.WebhookTrigger, error) {
+	var trigger models.WebhookTrigger
+	if err := bs.loadJSON(WebhookTriggerPrefix+triggerID, &trigger); err != nil {
+		return nil, fmt.Errorf("failed to load webhook trigger: %w", err)
+	}
+	return &trigger, nil
+}
+
+// DeleteWebhookTrigger removes webhook trigger
+// Удаляет webhook триггер
+func (bs *BadgerStorage) DeleteWebhookTrigger(triggerID string) error {
+	return bs.deleteKey(WebhookTriggerPrefix + triggerID)
+}
+
+// ListWebhookTriggers returns all webhook triggers
+// Возвращает все webhook триггеры
+func (bs *BadgerStorage) ListWebhookTriggers() ([]*models.WebhookTrigger, error) {
+	triggers := make([]*models.WebhookTrigger, 0)
+
+	err := bs.iterateWithPrefix(WebhookTriggerPrefix, func(key []byte, value []byte) error {
+		var trigger models.WebhookTrigger
+		if err := unmarshalFromStorage(value, &trigger); err != nil {
+			return fmt.Errorf("failed to unmarshal webhook trigger %s: %w", string(key), err)
+		}
+		triggers = append(triggers, &trigger)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook triggers: %w", err)
+	}
+
+	return triggers, nil
+}
@@ -0,0 +1,276 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+
+	"atom-engine/src/core/models"
+)
+
+// Variable history key prefix
+// Entries are keyed by instance, escaped variable name and time, so variable scan returns values in order they were set
+// Префикс ключей истории переменных
+// Записи адресуются экземпляром, экранированным именем переменной и временем, поэтому обход переменной
+// возвращает значения в порядке их установки
+const (
+	VariableHistoryPrefix = "variable_history:"
+)
+
+// ListVariableHistory returns values variable of process instance held in order they were set
+// Возвращает значения переменной экземпляра процесса в порядке их установки
+func (bs *BadgerStorage) ListVariableHistory(instanceID, name string) ([]*models.VariableHistoryEntry, error) {
+	if bs.db == nil {
+		return nil, fmt.Errorf("database not initialized")
+	}
+
+	var entries []*models.VariableHistoryEntry
+	err := bs.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = variableHistoryVariablePrefix(instanceID, name)
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			entry, err := bs.decodeVariableHistoryEntry(it.Item())
+			if err != nil {
+				return err
+			}
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list variable history: %w", err)
+	}
+	return entries, nil
+}
+
+// recordInstanceVariables writes variables changed by instance save, must be called before instance is set
+// Only instance saves record removal, tokens drop variables of their scope without deleting them from instance
+// Записывает переменные, измененные сохранением экземпляра, вызывается до записи экземпляра
+// Удаление записывают только сохранения экземпляра, токены отбрасывают переменные своей области,
+// не удаляя их из экземпляра
+func (bs *BadgerStorage) recordInstanceVariables(txn *badger.Txn, instance *models.ProcessInstance) error {
+	data, err := getValue(txn, []byte(ProcessInstancePrefix+instance.InstanceID))
+	if err != nil {
+		return err
+	}
+
+	var previous map[string]interface{}
+	if data != nil {
+		if data, err = bs.variableCipher.open(data); err != nil {
+			return fmt.Errorf("failed to decrypt process instance variables: %w", err)
+		}
+		var stored models.ProcessInstance
+		if err := stored.FromJSON(data); err != nil {
+			return fmt.Errorf("failed to deserialize process instance: %w", err)
+		}
+		previous = stored.Variables
+	}
+
+	source := models.VariableHistoryEntry{ProcessInstanceID: instance.InstanceID}
+	if err := bs.recordVariableChanges(txn, source, previous, instance.Variables); err != nil {
+		return err
+	}
+
+	for name := range previous {
+		if _, exists := instance.Variables[name]; exists {
+			continue
+		}
+		removed := source
+		removed.Name = name
+		removed.Removed = true
+		if err := bs.appendVariableHistory(txn, &removed); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordTokenVariables writes variables changed by token save, must be called before token is set
+// Job is taken from stored token, completion callback clears wait before saving token
+// Записывает переменные, измененные сохранением токена, вызывается до записи токена
+// Job берется из сохраненного токена, callback завершения снимает ожидание до сохранения токена
+func (bs *BadgerStorage) recordTokenVariables(txn *badger.Txn, token *models.Token) error {
+	if token.ProcessInstanceID == "" {
+		return nil
+	}
+
+	data, err := getValue(txn, []byte(TokenPrefix+token.TokenID))
+	if err != nil {
+		return err
+	}
+
+	source := models.VariableHistoryEntry{
+		ProcessInstanceID: token.ProcessInstanceID,
+		ElementID:         token.CurrentElementID,
+		TokenID:           token.TokenID,
+	}
+
+	var previous map[string]interface{}
+	if data != nil {
+		if data, err = bs.variableCipher.open(data); err != nil {
+			return fmt.Errorf("failed to decrypt token variables: %w", err)
+		}
+		var stored models.Token
+		if err := stored.FromJSON(data); err != nil {
+			return fmt.Errorf("failed to deserialize token: %w", err)
+		}
+		previous = stored.Variables
+		if strings.HasPrefix(stored.WaitingFor, "job:") {
+			source.JobID = strings.TrimPrefix(stored.WaitingFor, "job:")
+		}
+	}
+
+	return bs.recordVariableChanges(txn, source, previous, token.Variables)
+}
+
+// recordVariableChanges appends entries for variables whose value differs from previous record state
+// New record (previous is nil) is compared with last recorded values only, stale copies of variables
+// in tokens that did not change them are never recorded
+// Добавляет записи для переменных, значение которых отличается от прежнего состояния записи
+// Новая запись (previous равен nil) сравнивается только с последними записанными значениями, устаревшие копии
+// переменных в токенах, которые их не меняли, никогда не записываются
+func (bs *BadgerStorage) recordVariableChanges(
+	txn *badger.Txn,
+	source models.VariableHistoryEntry,
+	previous, current map[string]interface{},
+) error {
+	for name, value := range current {
+		encoded := encodeVariableValue(value)
+		if previous != nil {
+			previousValue, existed := previous[name]
+			if existed && bytes.Equal(encodeVariableValue(previousValue), encoded) {
+				continue
+			}
+		}
+
+		last, err := bs.lastVariableHistoryEntry(txn, source.ProcessInstanceID, name)
+		if err != nil {
+			return err
+		}
+		if last != nil && !last.Removed && bytes.Equal(encodeVariableValue(last.Value), encoded) {
+			continue
+		}
+
+		entry := source
+		entry.Name = name
+		entry.Value = value
+		if err := bs.appendVariableHistory(txn, &entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lastVariableHistoryEntry returns most recent entry of variable, nil when variable was never recorded
+// Возвращает последнюю запись переменной, nil если переменная не записывалась
+func (bs *BadgerStorage) lastVariableHistoryEntry(
+	txn *badger.Txn,
+	instanceID, name string,
+) (*models.VariableHistoryEntry, error) {
+	prefix := variableHistoryVariablePrefix(instanceID, name)
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = prefix
+	opts.Reverse = true
+	it := txn.NewIterator(opts)
+	defer it.Close()
+
+	it.Seek(append(append([]byte(nil), prefix...), 0xff))
+	if !it.Valid() {
+		return nil, nil
+	}
+	return bs.decodeVariableHistoryEntry(it.Item())
+}
+
+// appendVariableHistory writes entry stamped with current time
+// Записывает запись с текущим временем
+func (bs *BadgerStorage) appendVariableHistory(txn *badger.Txn, entry *models.VariableHistoryEntry) error {
+	entry.SetAt = time.Now()
+
+	data, err := entry.ToJSON()
+	if err != nil {
+		return fmt.Errorf("failed to marshal variable history of %s: %w", entry.Name, err)
+	}
+	data, err = bs.variableCipher.seal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt variable history of %s: %w", entry.Name, err)
+	}
+
+	writer := entry.TokenID
+	if writer == "" {
+		writer = "instance"
+	}
+	key := fmt.Sprintf("%s%020d:%s",
+		variableHistoryVariablePrefix(entry.ProcessInstanceID, entry.Name), entry.SetAt.UnixNano(), writer)
+	return txn.Set([]byte(key), data)
+}
+
+// decodeVariableHistoryEntry decrypts and deserializes stored entry
+// Расшифровывает и десериализует сохраненную запись
+func (bs *BadgerStorage) decodeVariableHistoryEntry(item *badger.Item) (*models.VariableHistoryEntry, error) {
+	data, err := item.ValueCopy(nil)
+	if err != nil {
+		return nil, err
+	}
+	data, err = bs.variableCipher.open(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt variable history %s: %w", string(item.Key()), err)
+	}
+
+	var entry models.VariableHistoryEntry
+	if err := entry.FromJSON(data); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal variable history %s: %w", string(item.Key()), err)
+	}
+	return &entry, nil
+}
+
+// deleteVariableHistory removes variable history of process instance inside transaction
+// Удаляет историю переменных экземпляра процесса в транзакции
+func deleteVariableHistory(txn *badger.Txn, instanceID string) error {
+	opts := badger.DefaultIteratorOptions
+	opts.Prefix = []byte(VariableHistoryPrefix + instanceID + ":")
+	opts.PrefetchValues = false
+	it := txn.NewIterator(opts)
+
+	var keys [][]byte
+	for it.Rewind(); it.Valid(); it.Next() {
+		keys = append(keys, it.Item().KeyCopy(nil))
+	}
+	it.Close()
+
+	for _, key := range keys {
+		if err := txn.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// encodeVariableValue returns typed JSON form of value, equal values of different Go types encode equally
+// Возвращает типизированную JSON форму значения, равные значения разных Go типов кодируются одинаково
+func encodeVariableValue(value interface{}) []byte {
+	data, _ := json.Marshal(models.NewVariable(value))
+	return data
+}
+
+// variableHistoryVariablePrefix returns key prefix of values of one variable,
+// name is escaped so it never contains separator
+// Возвращает префикс ключей значений одной переменной, имя экранируется и не содержит разделителя
+func variableHistoryVariablePrefix(instanceID, name string) []byte {
+	return []byte(VariableHistoryPrefix + instanceID + ":" + url.QueryEscape(name) + ":")
+}
@@ -0,0 +1,61 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package timewheel
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides current time for due date calculation and timer firing
+// Предоставляет текущее время для расчета сроков и срабатывания таймеров
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is wall clock used outside simulation mode
+// Системные часы, используемые вне режима симуляции
+type systemClock struct{}
+
+// Now returns wall clock time
+// Возвращает время системных часов
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SimulatedClock is clock of simulation mode, time stands still until it is advanced
+// Часы режима симуляции, время стоит на месте, пока его не продвинут
+type SimulatedClock struct {
+	now time.Time
+	mu  sync.RWMutex
+}
+
+// NewSimulatedClock creates simulated clock starting at given time
+// Создает симулированные часы, начинающиеся с заданного времени
+func NewSimulatedClock(start time.Time) *SimulatedClock {
+	return &SimulatedClock{now: start}
+}
+
+// Now returns simulated time
+// Возвращает симулированное время
+func (sc *SimulatedClock) Now() time.Time {
+	sc.mu.RLock()
+	defer sc.mu.RUnlock()
+	return sc.now
+}
+
+// moveTo moves clock forward to given time, clock never goes back
+// Переводит часы вперед на заданное время, назад часы не идут
+func (sc *SimulatedClock) moveTo(t time.Time) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	if t.After(sc.now) {
+		sc.now = t
+	}
+}
@@ -11,6 +11,7 @@ package timewheel
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"atom-engine/src/core/models"
 	"atom-engine/src/storage"
@@ -41,6 +42,10 @@ type Component struct {
 	requestChannel  chan string
 	responseChannel chan string
 	ready           bool
+
+	// Simulated clock, nil when wheel runs on wall clock
+	// Симулированные часы, nil если колесо работает по системным часам
+	simulatedClock *SimulatedClock
 }
 
 // NewComponent creates new timewheel component
@@ -87,11 +92,53 @@ func (c *Component) Initialize(configJSON string) error {
 		return fmt.Errorf("failed to create timewheel manager: %w", err)
 	}
 
+	if c.simulatedClock != nil {
+		manager.wheel.setClock(c.simulatedClock)
+	}
+
 	c.manager = manager
 	c.ready = true
 	return nil
 }
 
+// EnableSimulation switches component to simulated clock starting at given time
+// Must be called before Initialize, simulated time moves only by AdvanceClock
+// Переключает компонент на симулированные часы, начинающиеся с заданного времени
+// Должен вызываться до Initialize, симулированное время идет только через AdvanceClock
+func (c *Component) EnableSimulation(start time.Time) error {
+	if c.ready {
+		return fmt.Errorf("simulation must be enabled before component is initialized")
+	}
+	c.simulatedClock = NewSimulatedClock(start)
+	return nil
+}
+
+// IsSimulated reports whether component runs on simulated clock
+// Сообщает, работает ли компонент на симулированных часах
+func (c *Component) IsSimulated() bool {
+	return c.simulatedClock != nil
+}
+
+// Now returns current time of timewheel clock
+// Возвращает текущее время часов timewheel
+func (c *Component) Now() time.Time {
+	if c.manager == nil {
+		return time.Now()
+	}
+	return c.manager.Now()
+}
+
+// AdvanceClock moves simulated clock forward and fires timers due within advanced period
+// Responses of fired timers are passed to deliver instead of response channel
+// Продвигает симулированные часы и запускает таймеры, срок которых наступил в этом периоде
+// Ответы сработавших таймеров передаются в deliver вместо канала ответов
+func (c *Component) AdvanceClock(duration time.Duration, deliver func(response string)) ([]*models.Timer, error) {
+	if !c.ready {
+		return nil, fmt.Errorf("component not initialized")
+	}
+	return c.manager.Advance(duration, deliver)
+}
+
 // Start starts the timewheel component
 // Запускает timewheel компонент
 func (c *Component) Start() error {
@@ -67,9 +67,10 @@ func (c *Component) handleScheduleTimer(messageJSON string) error {
 
 	// Save timer to storage with the actual timer ID from manager
 	// Сохраняем таймер в storage с реальным ID от manager
-	// Skip saving for restored timers to preserve original ScheduledAt
-	// Пропускаем сохранение для восстановленных таймеров чтобы сохранить оригинальный ScheduledAt
-	if c.storage != nil && message.Request.RestoreTimerID == nil {
+	// Skip saving for restored timers to preserve original ScheduledAt, registered timers are saved by caller
+	// Пропускаем сохранение для восстановленных таймеров чтобы сохранить оригинальный ScheduledAt,
+	// зарегистрированные таймеры сохраняет вызывающий
+	if c.storage != nil && message.Request.RestoreTimerID == nil && message.Request.RegisteredTimerID == nil {
 		timerRecord := c.timerRequestToRecord(&message.Request, timerID)
 		if err := c.storage.SaveTimer(timerRecord); err != nil {
 			return fmt.Errorf("failed to save timer to storage: %w", err)
@@ -131,6 +132,12 @@ func (c *Component) handleGetStats() error {
 	}
 }
 
+// NewTimerRecord builds storage record for timer that caller persists before scheduling it
+// Строит запись storage для таймера, который вызывающий сохраняет до его планирования
+func (c *Component) NewTimerRecord(req *TimerRequest, timerID string) *storage.TimerRecord {
+	return c.timerRequestToRecord(req, timerID)
+}
+
 // timerRequestToRecord converts TimerRequest to storage.TimerRecord
 // Конвертирует TimerRequest в storage.TimerRecord
 func (c *Component) timerRequestToRecord(req *TimerRequest, timerID string) *storage.TimerRecord {
@@ -151,7 +158,7 @@ func (c *Component) timerRequestToRecord(req *TimerRequest, timerID string) *sto
 		TokenID:           req.TokenID,
 		ProcessInstanceID: req.ProcessInstanceID,
 		TimerType:         string(req.TimerType),
-		ScheduledAt:       now, // Will be updated when actually scheduled
+		ScheduledAt:       c.manager.Now(), // Base of due date, simulated in simulation mode
 		TimeDate:          req.TimeDate,
 		TimeDuration:      req.TimeDuration,
 		TimeCycle:         req.TimeCycle,
@@ -47,7 +47,7 @@ func (c *Component) RestoreTimers() error {
 
 		// Check if timer is overdue
 		// Проверяем просрочен ли таймер
-		now := time.Now()
+		now := c.manager.Now()
 		if dueDate.Before(now) || dueDate.Equal(now) {
 			// Timer is overdue - fire it immediately
 			// Таймер просрочен - запускаем немедленно
@@ -159,11 +159,11 @@ func (c *Component) calculateOriginalDueDate(record *storage.TimerRecord) (time.
 	} else if record.TimeCycle != nil {
 		// Cycle-based timer - get first execution time
 		// Циклический таймер - получаем время первого выполнения
-		_, interval, err := parser.ParseRepeatingInterval(*record.TimeCycle)
+		cycle, err := parser.ParseTimeCycle(*record.TimeCycle)
 		if err != nil {
 			return time.Time{}, err
 		}
-		return baseTime.Add(interval), nil
+		return cycle.Next(baseTime), nil
 	}
 
 	return time.Time{}, fmt.Errorf("no timer definition found")
@@ -215,7 +215,7 @@ func (c *Component) fireOverdueTimer(record *storage.TimerRecord, originalDueDat
 		ProcessInstanceID: timer.ProcessInstanceID,
 		TimerType:         timer.Type,
 		ProcessContext:    timer.ProcessContext,
-		FiredAt:           time.Now(),
+		FiredAt:           c.manager.Now(),
 		Variables:         timer.Variables,
 	}
 
@@ -0,0 +1,332 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package timewheel
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronSearchYears limits how far ahead next fire time is searched for
+// Ограничивает, насколько далеко вперед ищется следующее время срабатывания
+const cronSearchYears = 5
+
+// cronMacros maps descriptors to equivalent expressions with seconds field
+// Отображает дескрипторы на эквивалентные выражения с полем секунд
+var cronMacros = map[string]string{
+	"@yearly":   "0 0 0 1 1 *",
+	"@annually": "0 0 0 1 1 *",
+	"@monthly":  "0 0 0 1 * *",
+	"@weekly":   "0 0 0 * * 0",
+	"@daily":    "0 0 0 * * *",
+	"@midnight": "0 0 0 * * *",
+	"@hourly":   "0 0 * * * *",
+}
+
+// cronField describes bounds and value names of cron field
+// Описывает границы и имена значений поля cron
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronSecond = cronField{name: "second", min: 0, max: 59}
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDom    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	// Day of week 7 is Sunday as well as 0
+	// День недели 7 - воскресенье, как и 0
+	cronDow = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+)
+
+// CronSchedule is parsed cron expression evaluated in its time zone
+// Разобранное cron выражение, вычисляемое в его часовом поясе
+type CronSchedule struct {
+	expression string
+	location   *time.Location
+
+	second, minute, hour, dom, month, dow uint64
+
+	// Day fields given as * or ?, when both are restricted day matches either, as in classic cron
+	// Поля дней, заданные как * или ?, если ограничены оба, день совпадает с любым, как в классическом cron
+	domAny, dowAny bool
+}
+
+// IsCronExpression reports whether timer cycle is cron expression rather than ISO 8601 repeating interval
+// Cron has descriptor, time zone prefix or five to six space separated fields, ISO interval has no spaces
+// Сообщает, является ли цикл таймера cron выражением, а не повторяющимся интервалом ISO 8601
+// Cron имеет дескриптор, префикс часового пояса или пять-шесть полей через пробел, в ISO интервале пробелов нет
+func IsCronExpression(value string) bool {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "=") || strings.HasPrefix(value, "${") {
+		return false
+	}
+	if strings.HasPrefix(value, "@") || strings.HasPrefix(value, "CRON_TZ=") || strings.HasPrefix(value, "TZ=") {
+		return true
+	}
+	fields := len(strings.Fields(value))
+	return fields == 5 || fields == 6
+}
+
+// ParseCron parses cron expression with optional seconds field and CRON_TZ= or TZ= prefix
+// Expression without time zone is evaluated in UTC
+// Парсит cron выражение с необязательным полем секунд и префиксом CRON_TZ= или TZ=
+// Выражение без часового пояса вычисляется в UTC
+func ParseCron(expression string) (*CronSchedule, error) {
+	spec := strings.TrimSpace(expression)
+	if spec == "" {
+		return nil, fmt.Errorf("empty cron expression")
+	}
+
+	location := time.UTC
+	if strings.HasPrefix(spec, "CRON_TZ=") || strings.HasPrefix(spec, "TZ=") {
+		zone, rest, found := strings.Cut(spec[strings.Index(spec, "=")+1:], " ")
+		if !found {
+			return nil, fmt.Errorf("cron expression %q has time zone but no schedule", expression)
+		}
+		loaded, err := time.LoadLocation(zone)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time zone in cron expression %q: %w", expression, err)
+		}
+		location = loaded
+		spec = strings.TrimSpace(rest)
+	}
+
+	if strings.HasPrefix(spec, "@") {
+		macro, ok := cronMacros[strings.ToLower(spec)]
+		if !ok {
+			return nil, fmt.Errorf("unknown cron descriptor %s", spec)
+		}
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("cron expression %q must have 5 or 6 fields, got %d", expression, len(fields))
+	}
+
+	schedule := &CronSchedule{expression: strings.TrimSpace(expression), location: location}
+	var err error
+	if schedule.second, _, err = cronSecond.parse(fields[0]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expression, err)
+	}
+	if schedule.minute, _, err = cronMinute.parse(fields[1]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expression, err)
+	}
+	if schedule.hour, _, err = cronHour.parse(fields[2]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expression, err)
+	}
+	if schedule.dom, schedule.domAny, err = cronDom.parse(fields[3]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expression, err)
+	}
+	if schedule.month, _, err = cronMonth.parse(fields[4]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expression, err)
+	}
+	if schedule.dow, schedule.dowAny, err = cronDow.parse(fields[5]); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expression, err)
+	}
+	if schedule.dow&(1<<7) != 0 {
+		schedule.dow = schedule.dow&^(1<<7) | 1
+	}
+
+	// Dates like February 30 never come, such schedule is rejected instead of never firing
+	// Даты вроде 30 февраля никогда не наступают, такое расписание отклоняется, а не молчит
+	if schedule.Next(time.Now()).IsZero() {
+		return nil, fmt.Errorf("cron expression %q never fires", expression)
+	}
+	return schedule, nil
+}
+
+// String returns source expression
+// Возвращает исходное выражение
+func (s *CronSchedule) String() string {
+	return s.expression
+}
+
+// Next returns first fire time strictly after given time, zero time when there is none within search limit
+// Wall clock is matched in schedule time zone: times skipped by DST change do not fire, repeated ones fire twice
+// Возвращает первое время срабатывания строго после заданного, нулевое время если его нет в пределах поиска
+// Время сверяется в часовом поясе расписания: пропущенное при смене времени не срабатывает,
+// повторившееся срабатывает дважды
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	t := after.In(s.location).Truncate(time.Second).Add(time.Second)
+	yearLimit := t.Year() + cronSearchYears
+
+	// Each field is advanced until it matches, overflow into higher field restarts search from month
+	// Каждое поле продвигается до совпадения, переполнение в старшее поле перезапускает поиск с месяца
+	reset := false
+search:
+	for t.Year() <= yearLimit {
+		for s.month&(1<<uint(t.Month())) == 0 {
+			if !reset {
+				reset = true
+				t = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, s.location)
+			}
+			t = t.AddDate(0, 1, 0)
+			if t.Month() == time.January {
+				continue search
+			}
+		}
+
+		for !s.dayMatches(t) {
+			if !reset {
+				reset = true
+				t = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, s.location)
+			}
+			t = startOfNextDay(t)
+			if t.Day() == 1 {
+				continue search
+			}
+		}
+
+		for s.hour&(1<<uint(t.Hour())) == 0 {
+			if !reset {
+				reset = true
+				t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, s.location)
+			}
+			t = t.Add(time.Hour)
+			if t.Hour() == 0 {
+				continue search
+			}
+		}
+
+		for s.minute&(1<<uint(t.Minute())) == 0 {
+			if !reset {
+				reset = true
+				t = t.Truncate(time.Minute)
+			}
+			t = t.Add(time.Minute)
+			if t.Minute() == 0 {
+				continue search
+			}
+		}
+
+		for s.second&(1<<uint(t.Second())) == 0 {
+			t = t.Add(time.Second)
+			if t.Second() == 0 {
+				continue search
+			}
+		}
+
+		return t.In(after.Location())
+	}
+	return time.Time{}
+}
+
+// dayMatches reports whether day of t matches day of month and day of week fields
+// Сообщает, совпадает ли день t с полями дня месяца и дня недели
+func (s *CronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// startOfNextDay returns midnight following midnight t, shifted to nearest existing hour when DST change skips it
+// Возвращает полночь, следующую за полночью t, сдвинутую к ближайшему существующему часу, если ее пропускает
+// смена времени
+func startOfNextDay(t time.Time) time.Time {
+	t = t.AddDate(0, 0, 1)
+	if hour := t.Hour(); hour > 12 {
+		t = t.Add(time.Duration(24-hour) * time.Hour)
+	} else if hour > 0 {
+		t = t.Add(-time.Duration(hour) * time.Hour)
+	}
+	return t
+}
+
+// parse parses field into bit set of allowed values, unrestricted reports * or ? field
+// Парсит поле в битовое множество допустимых значений, unrestricted сообщает о поле * или ?
+func (f cronField) parse(value string) (bits uint64, unrestricted bool, err error) {
+	if value == "*" || value == "?" {
+		return f.span(f.min, f.max, 1), true, nil
+	}
+
+	for _, part := range strings.Split(value, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, false, fmt.Errorf("invalid step %q in %s field", stepPart, f.name)
+			}
+		}
+
+		var low, high int
+		switch {
+		case rangePart == "*" || rangePart == "?":
+			low, high = f.min, f.max
+		case strings.Contains(rangePart, "-"):
+			lowPart, highPart, _ := strings.Cut(rangePart, "-")
+			if low, err = f.value(lowPart); err != nil {
+				return 0, false, err
+			}
+			if high, err = f.value(highPart); err != nil {
+				return 0, false, err
+			}
+			if low > high {
+				return 0, false, fmt.Errorf("invalid range %q in %s field", rangePart, f.name)
+			}
+		default:
+			if low, err = f.value(rangePart); err != nil {
+				return 0, false, err
+			}
+			// Single value with step runs to end of field, like 5/15
+			// Одиночное значение с шагом продолжается до конца поля, как 5/15
+			high = low
+			if hasStep {
+				high = f.max
+			}
+		}
+		bits |= f.span(low, high, step)
+	}
+	return bits, false, nil
+}
+
+// value parses number or name of field value and checks bounds
+// Парсит число или имя значения поля и проверяет границы
+func (f cronField) value(value string) (int, error) {
+	if number, ok := f.names[strings.ToUpper(value)]; ok {
+		return number, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q in %s field", value, f.name)
+	}
+	if number < f.min || number > f.max {
+		return 0, fmt.Errorf("%s value %d is out of range %d-%d", f.name, number, f.min, f.max)
+	}
+	return number, nil
+}
+
+// span returns bit set of values from low to high with step
+// Возвращает битовое множество значений от low до high с шагом
+func (f cronField) span(low, high, step int) uint64 {
+	var bits uint64
+	for i := low; i <= high; i += step {
+		bits |= 1 << uint(i)
+	}
+	return bits
+}
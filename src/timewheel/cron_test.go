@@ -0,0 +1,129 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package timewheel
+
+import (
+	"testing"
+	"time"
+)
+
+// utc builds UTC time of minute precision
+// Создает время UTC с точностью до минуты
+func utc(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// Wednesday
+	// Среда
+	after := utc(2025, time.January, 15, 10, 30)
+
+	tests := []struct {
+		name       string
+		expression string
+		after      time.Time
+		want       []time.Time
+	}{
+		{"minute step", "*/15 * * * *", after,
+			[]time.Time{utc(2025, 1, 15, 10, 45), utc(2025, 1, 15, 11, 0), utc(2025, 1, 15, 11, 15)}},
+		{"seconds field", "30 */10 * * * *", after, []time.Time{
+			utc(2025, 1, 15, 10, 30).Add(30 * time.Second),
+			utc(2025, 1, 15, 10, 40).Add(30 * time.Second),
+			utc(2025, 1, 15, 10, 50).Add(30 * time.Second),
+		}},
+		{"value with step runs to end of field", "5/20 * * * *", after,
+			[]time.Time{utc(2025, 1, 15, 10, 45), utc(2025, 1, 15, 11, 5), utc(2025, 1, 15, 11, 25)}},
+		{"range with step", "0 0-6/3 * * *", after,
+			[]time.Time{utc(2025, 1, 16, 0, 0), utc(2025, 1, 16, 3, 0), utc(2025, 1, 16, 6, 0)}},
+		{"weekday names", "0 9 * * MON-FRI", after,
+			[]time.Time{utc(2025, 1, 16, 9, 0), utc(2025, 1, 17, 9, 0), utc(2025, 1, 20, 9, 0)}},
+		{"sunday as 7", "0 12 * * 7", after,
+			[]time.Time{utc(2025, 1, 19, 12, 0), utc(2025, 1, 26, 12, 0), utc(2025, 2, 2, 12, 0)}},
+		{"day of month list", "0 0 1,15 * *", after,
+			[]time.Time{utc(2025, 2, 1, 0, 0), utc(2025, 2, 15, 0, 0), utc(2025, 3, 1, 0, 0)}},
+		{"day of month or day of week", "0 0 13 * FRI", utc(2025, 2, 8, 0, 0),
+			[]time.Time{utc(2025, 2, 13, 0, 0), utc(2025, 2, 14, 0, 0), utc(2025, 2, 21, 0, 0)}},
+		{"question mark day and month names", "0 30 8 ? JAN,JUL *", utc(2025, 1, 30, 12, 0),
+			[]time.Time{utc(2025, 1, 31, 8, 30), utc(2025, 7, 1, 8, 30), utc(2025, 7, 2, 8, 30)}},
+		{"leap day", "0 0 29 2 *", after,
+			[]time.Time{utc(2028, 2, 29, 0, 0), utc(2032, 2, 29, 0, 0)}},
+		{"hourly descriptor", "@hourly", after,
+			[]time.Time{utc(2025, 1, 15, 11, 0), utc(2025, 1, 15, 12, 0), utc(2025, 1, 15, 13, 0)}},
+		{"weekly descriptor", "@weekly", after,
+			[]time.Time{utc(2025, 1, 19, 0, 0), utc(2025, 1, 26, 0, 0), utc(2025, 2, 2, 0, 0)}},
+		{"yearly descriptor", "@YEARLY", after, []time.Time{utc(2026, 1, 1, 0, 0), utc(2027, 1, 1, 0, 0)}},
+		{"time zone", "CRON_TZ=America/New_York 0 9 * * *", after,
+			[]time.Time{utc(2025, 1, 15, 14, 0), utc(2025, 1, 16, 14, 0), utc(2025, 1, 17, 14, 0)}},
+		{"time skipped by daylight saving does not fire", "TZ=Europe/Berlin 30 2 * * *", utc(2025, 3, 29, 12, 0),
+			[]time.Time{utc(2025, 3, 31, 0, 30), utc(2025, 4, 1, 0, 30)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCron(tt.expression)
+			if err != nil {
+				t.Fatalf("parse %q: %v", tt.expression, err)
+			}
+			next := tt.after
+			for i, want := range tt.want {
+				next = schedule.Next(next)
+				if !next.Equal(want) {
+					t.Fatalf("fire %d of %q = %v, want %v", i+1, tt.expression, next.UTC(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestParseCronRejectsInvalidExpressions(t *testing.T) {
+	for _, expression := range []string{
+		"",
+		"* * * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"* * * * FUNDAY",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"0 0 30 2 *",
+		"@every 5m",
+		"CRON_TZ=Nowhere/City 0 9 * * *",
+		"CRON_TZ=UTC",
+	} {
+		if schedule, err := ParseCron(expression); err == nil {
+			t.Errorf("ParseCron(%q) accepted invalid expression: %s", expression, schedule)
+		}
+	}
+}
+
+func TestIsCronExpression(t *testing.T) {
+	tests := map[string]bool{
+		"0 9 * * MON-FRI":              true,
+		"0 0 9 * * *":                  true,
+		"@daily":                       true,
+		"TZ=UTC 0 9 * * *":             true,
+		"CRON_TZ=UTC 0 9 * * *":        true,
+		"R3/PT10S":                     false,
+		"R/PT1H":                       false,
+		"PT1H":                         false,
+		"= cronExpression":             false,
+		"${cycle}":                     false,
+		"0 9 * *":                      false,
+		"  0 9 * * MON-FRI  ":          true,
+		"R3/2025-01-01T00:00:00Z/PT1H": false,
+	}
+	for value, want := range tests {
+		if got := IsCronExpression(value); got != want {
+			t.Errorf("IsCronExpression(%q) = %v, want %v", value, got, want)
+		}
+	}
+}
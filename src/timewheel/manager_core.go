@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"atom-engine/src/core/logger"
+	"atom-engine/src/core/models"
 )
 
 // Manager manages timing wheel and handles JSON communication
@@ -106,6 +107,18 @@ func (m *Manager) GetRemainingTime(timerID string) (time.Duration, error) {
 	return m.wheel.GetRemainingTime(timerID)
 }
 
+// Now returns current time of timing wheel clock
+// Возвращает текущее время часов timing wheel
+func (m *Manager) Now() time.Time {
+	return m.wheel.Now()
+}
+
+// Advance moves simulated clock forward and fires due timers, see HierarchicalTimingWheel.Advance
+// Продвигает симулированные часы и запускает наступившие таймеры, см. HierarchicalTimingWheel.Advance
+func (m *Manager) Advance(duration time.Duration, deliver func(response string)) ([]*models.Timer, error) {
+	return m.wheel.Advance(duration, deliver)
+}
+
 // CancelTimer cancels timer by ID
 // Отменяет таймер по ID
 func (m *Manager) CancelTimer(timerID string) error {
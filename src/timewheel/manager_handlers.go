@@ -28,7 +28,7 @@ func (m *Manager) handleTimerFired(ctx context.Context, timer *models.Timer) err
 	if timer.Type == models.TimerTypeBoundary {
 		logger.Debug("Boundary timer fired",
 			logger.String("timer_id", timer.ID),
-			logger.Any("variables", timer.Variables))
+			logger.Variables("variables", timer.Variables))
 	}
 
 	// Update timer status in storage to FIRED
@@ -59,6 +59,12 @@ func (m *Manager) handleTimerFired(ctx context.Context, timer *models.Timer) err
 		}
 	}
 
+	// Start event timers are rescheduled by process component after instance is started
+	// Таймеры стартовых событий перепланирует process компонент после запуска экземпляра
+	if timer.Type == models.TimerTypeStart {
+		return nil
+	}
+
 	// Handle cycle timers for rescheduling
 	// Обрабатываем циклические таймеры для переplanирования
 	if cycleStr, ok := timer.Variables["time_cycle"].(string); ok {
@@ -103,18 +109,29 @@ func (m *Manager) handleCycleTimer(timer *models.Timer, cycleStr string) error {
 			}
 		}
 
-		// Parse interval
-		// Парсим интервал
-		_, interval, err := m.parser.ParseRepeatingInterval(cycleStr)
+		// Parse cycle, cron counts from due date so that early firing does not repeat same time
+		// Парсим цикл, cron отсчитывается от срока, чтобы раннее срабатывание не повторило то же время
+		cycle, err := m.parser.ParseTimeCycle(cycleStr)
 		if err != nil {
 			return err
 		}
+		now := m.wheel.Now()
+		if cycle.Cron != nil && timer.DueDate.After(now) {
+			now = timer.DueDate
+		}
+		dueDate := cycle.Next(now)
+		if dueDate.IsZero() {
+			logger.Info("Cron schedule has no more fire times - stopping cycle",
+				logger.String("timer_id", timer.ID),
+				logger.String("time_cycle", cycleStr))
+			return nil
+		}
 
 		// Create new timer for next iteration
 		// Создаем новый таймер для следующей итерации
 		nextTimer := *timer
 		nextTimer.ID = models.GenerateID()
-		nextTimer.DueDate = time.Now().Add(interval)
+		nextTimer.DueDate = dueDate
 		nextTimer.State = models.TimerStateScheduled
 		nextTimer.CreatedAt = time.Now()
 		nextTimer.UpdatedAt = time.Now()
@@ -146,7 +163,7 @@ func (m *Manager) handleCycleTimer(timer *models.Timer, cycleStr string) error {
 				TokenID:           nextTimer.ExecutionTokenID,
 				TimerType:         string(nextTimer.Type),
 				State:             string(nextTimer.State),
-				ScheduledAt:       nextTimer.CreatedAt,
+				ScheduledAt:       now,
 				CreatedAt:         nextTimer.CreatedAt,
 				UpdatedAt:         nextTimer.UpdatedAt,
 				Variables:         nextTimer.Variables,
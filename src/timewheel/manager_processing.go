@@ -44,6 +44,10 @@ func (m *Manager) ScheduleTimerRequest(ctx context.Context, req TimerRequest) (s
 		// Use existing ID for restoration
 		// Используем существующий ID для восстановления
 		timerID = *req.RestoreTimerID
+	} else if req.RegisteredTimerID != nil && *req.RegisteredTimerID != "" {
+		// Use ID of record persisted by caller
+		// Используем ID записи, сохраненной вызывающим
+		timerID = *req.RegisteredTimerID
 	} else {
 		// Generate new ID for new timer
 		// Генерируем новый ID для нового таймера
@@ -104,11 +108,19 @@ func (m *Manager) validateRequest(req TimerRequest) error {
 	if req.ElementID == "" {
 		return ErrInvalidTimerRequest("element_id is required")
 	}
-	if req.TokenID == "" {
-		return ErrInvalidTimerRequest("token_id is required")
-	}
-	if req.ProcessInstanceID == "" {
-		return ErrInvalidTimerRequest("process_instance_id is required")
+
+	// Start event timers belong to process definition and have no token or instance
+	// Job retry timers belong to job which may have neither, SLA timers belong to instance without token
+	// Таймеры стартовых событий принадлежат определению процесса и не имеют токена и экземпляра
+	// Таймеры повтора job'а принадлежат job'у, у которого их может не быть,
+	// таймеры SLA принадлежат экземпляру без токена
+	if req.TimerType != models.TimerTypeStart && req.TimerType != models.TimerTypeJobRetry {
+		if req.TokenID == "" && req.TimerType != models.TimerTypeSLA {
+			return ErrInvalidTimerRequest("token_id is required")
+		}
+		if req.ProcessInstanceID == "" {
+			return ErrInvalidTimerRequest("process_instance_id is required")
+		}
 	}
 
 	// Check that exactly one timer definition is provided
@@ -167,7 +179,7 @@ func (m *Manager) processTimeDuration(timer *models.Timer, durationStr string, b
 	if baseTime != nil {
 		startTime = *baseTime
 	} else {
-		startTime = time.Now()
+		startTime = m.wheel.Now()
 	}
 
 	timer.DueDate = startTime.Add(duration)
@@ -184,7 +196,7 @@ func (m *Manager) processTimeDuration(timer *models.Timer, durationStr string, b
 // processTimeCycle processes cycle-based timer
 // Обрабатывает циклический таймер
 func (m *Manager) processTimeCycle(timer *models.Timer, cycleStr string, baseTime *time.Time) error {
-	repeatCount, interval, err := m.parser.ParseRepeatingInterval(cycleStr)
+	cycle, err := m.parser.ParseTimeCycle(cycleStr)
 	if err != nil {
 		return err
 	}
@@ -195,12 +207,12 @@ func (m *Manager) processTimeCycle(timer *models.Timer, cycleStr string, baseTim
 	if baseTime != nil {
 		startTime = *baseTime
 	} else {
-		startTime = time.Now()
+		startTime = m.wheel.Now()
 	}
 
 	// For first execution
 	// Для первого выполнения
-	timer.DueDate = startTime.Add(interval)
+	timer.DueDate = cycle.Next(startTime)
 
 	// Ensure Variables is initialized before assignment
 	// Убеждаемся что Variables инициализирован перед присваиванием
@@ -208,8 +220,10 @@ func (m *Manager) processTimeCycle(timer *models.Timer, cycleStr string, baseTim
 		timer.Variables = make(map[string]interface{})
 	}
 	timer.Variables["time_cycle"] = cycleStr
-	timer.Variables["repeat_count"] = repeatCount
-	timer.Variables["interval"] = interval.String()
+	timer.Variables["repeat_count"] = cycle.RepeatCount
+	if cycle.Cron == nil {
+		timer.Variables["interval"] = cycle.Interval.String()
+	}
 	timer.Variables["current_iteration"] = 1
 
 	return nil
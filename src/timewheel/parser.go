@@ -149,6 +149,41 @@ func (p *ISO8601DurationParser) ParseRepeatingInterval(
 	return repeatCount, interval, nil
 }
 
+// TimeCycle is parsed timer cycle, ISO 8601 repeating interval or cron expression
+// Разобранный цикл таймера, повторяющийся интервал ISO 8601 или cron выражение
+type TimeCycle struct {
+	RepeatCount int           // -1 repeats without limit, always for cron
+	Interval    time.Duration // Zero for cron
+	Cron        *CronSchedule // Nil for ISO 8601 interval
+}
+
+// Next returns fire time following given time, zero time when cron schedule has no more fire times
+// Возвращает время срабатывания после заданного, нулевое время если у cron расписания их больше нет
+func (c *TimeCycle) Next(after time.Time) time.Time {
+	if c.Cron != nil {
+		return c.Cron.Next(after)
+	}
+	return after.Add(c.Interval)
+}
+
+// ParseTimeCycle parses timeCycle given either as repeating interval like "R5/PT30S" or cron expression
+// Парсит timeCycle, заданный повторяющимся интервалом типа "R5/PT30S" или cron выражением
+func (p *ISO8601DurationParser) ParseTimeCycle(cycleStr string) (*TimeCycle, error) {
+	if IsCronExpression(cycleStr) {
+		schedule, err := ParseCron(cycleStr)
+		if err != nil {
+			return nil, err
+		}
+		return &TimeCycle{RepeatCount: -1, Cron: schedule}, nil
+	}
+
+	repeatCount, interval, err := p.ParseRepeatingInterval(cycleStr)
+	if err != nil {
+		return nil, err
+	}
+	return &TimeCycle{RepeatCount: repeatCount, Interval: interval}, nil
+}
+
 // ParseDate parses ISO8601 date string like "2025-12-31T23:59:59Z"
 // Парсит ISO8601 строку даты типа "2025-12-31T23:59:59Z"
 func (p *ISO8601DurationParser) ParseDate(dateStr string) (time.Time, error) {
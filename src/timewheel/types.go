@@ -50,6 +50,10 @@ type TimerRequest struct {
 	// Для восстановления - если установлен, используем этот DueDate вместо расчета из определений времени
 	RestoreDueDate *time.Time `json:"restore_due_date,omitempty"`
 
+	// Registration specific - timer record already persisted by caller under this ID, timewheel only schedules it
+	// Для регистрации - запись таймера уже сохранена вызывающим под этим ID, timewheel только планирует его
+	RegisteredTimerID *string `json:"registered_timer_id,omitempty"`
+
 	// Base time for consistent calculation - if set, use this instead of time.Now()
 	// Базовое время для консистентного расчета - если установлен, используем его вместо time.Now()
 	BaseTime *time.Time `json:"base_time,omitempty"`
@@ -122,6 +126,11 @@ type HierarchicalTimingWheel struct {
 	mu         sync.RWMutex
 	wg         sync.WaitGroup
 
+	// Source of current time, simulated clock replaces ticker with explicit advances
+	// Источник текущего времени, симулированные часы заменяют тикер явным продвижением
+	clock     Clock
+	advanceMu sync.Mutex
+
 	// JSON communication channel with core
 	// Канал JSON связи с core
 	responseChannel chan<- string
@@ -26,6 +26,7 @@ func NewHierarchicalTimingWheel(config Config, responseChannel chan<- string) (*
 		running:         false,
 		stopChan:        make(chan struct{}),
 		responseChannel: responseChannel,
+		clock:           systemClock{},
 	}
 
 	// Create levels
@@ -54,7 +55,13 @@ func (htw *HierarchicalTimingWheel) Start() error {
 	}
 
 	htw.running = true
-	htw.startTime = time.Now()
+	htw.startTime = htw.clock.Now()
+
+	// Simulated time moves only by Advance, wheel does not tick on its own
+	// Симулированное время идет только через Advance, колесо не тикает само
+	if htw.isSimulated() {
+		return nil
+	}
 
 	// Start with smallest tick interval
 	// Запускаем с наименьшим интервалом тика
@@ -88,6 +95,27 @@ func (htw *HierarchicalTimingWheel) Stop() error {
 	return nil
 }
 
+// setClock replaces source of current time, must be called before wheel is started
+// Заменяет источник текущего времени, должен вызываться до запуска колеса
+func (htw *HierarchicalTimingWheel) setClock(clock Clock) {
+	htw.mu.Lock()
+	defer htw.mu.Unlock()
+	htw.clock = clock
+}
+
+// Now returns current time of wheel clock
+// Возвращает текущее время часов колеса
+func (htw *HierarchicalTimingWheel) Now() time.Time {
+	return htw.clock.Now()
+}
+
+// isSimulated reports whether wheel runs on simulated clock
+// Сообщает, работает ли колесо на симулированных часах
+func (htw *HierarchicalTimingWheel) isSimulated() bool {
+	_, ok := htw.clock.(*SimulatedClock)
+	return ok
+}
+
 // findLevelForDelay finds appropriate level for given delay
 // Находит подходящий уровень для заданной задержки
 func (htw *HierarchicalTimingWheel) findLevelForDelay(delay time.Duration) *TimingWheelLevel {
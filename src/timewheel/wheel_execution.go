@@ -38,7 +38,7 @@ func (htw *HierarchicalTimingWheel) processTick() {
 		return
 	}
 
-	now := time.Now()
+	now := htw.clock.Now()
 
 	// Process L0 (most frequent level)
 	// Обрабатываем L0 (самый частый уровень)
@@ -69,6 +69,16 @@ func (htw *HierarchicalTimingWheel) processTick() {
 // fireTimer fires a timer by sending JSON response
 // Запускает таймер отправкой JSON ответа
 func (htw *HierarchicalTimingWheel) fireTimer(timer *models.Timer, handler TimerHandler) {
+	htw.fireTimerWith(timer, handler, nil)
+}
+
+// fireTimerWith fires timer, response is passed to deliver when set instead of response channel
+// Запускает таймер, ответ передается в deliver, если он задан, вместо канала ответов
+func (htw *HierarchicalTimingWheel) fireTimerWith(
+	timer *models.Timer,
+	handler TimerHandler,
+	deliver func(response string),
+) {
 	// Update timer state
 	// Обновляем состояние таймера
 	timer.State = models.TimerStateFired
@@ -83,13 +93,17 @@ func (htw *HierarchicalTimingWheel) fireTimer(timer *models.Timer, handler Timer
 		ProcessInstanceID: timer.ProcessInstanceID,
 		TimerType:         timer.Type,
 		ProcessContext:    timer.ProcessContext,
-		FiredAt:           time.Now(),
+		FiredAt:           htw.clock.Now(),
 		Variables:         timer.Variables,
 	}
 
 	// Send JSON response to core engine
 	// Отправляем JSON ответ в core engine
-	if htw.responseChannel != nil {
+	if deliver != nil {
+		if jsonData, err := json.Marshal(response); err == nil {
+			deliver(string(jsonData))
+		}
+	} else if htw.responseChannel != nil {
 		if jsonData, err := json.Marshal(response); err == nil {
 			select {
 			case htw.responseChannel <- string(jsonData):
@@ -29,7 +29,7 @@ func (htw *HierarchicalTimingWheel) AddTimer(timer *models.Timer, handler TimerH
 
 	// Calculate delay
 	// Вычисляем задержку
-	delay := time.Until(timer.DueDate)
+	delay := timer.DueDate.Sub(htw.clock.Now())
 	if delay <= 0 {
 		// Timer should fire immediately
 		// Таймер должен сработать немедленно
@@ -169,7 +169,7 @@ func (htw *HierarchicalTimingWheel) GetRemainingTime(timerID string) (time.Durat
 			if entry, ok := e.Value.(*TimerEntry); ok && entry.Timer.ID == timerID {
 				// Use precise calculation based on DueDate
 				// Используем точный расчет на основе DueDate
-				remainingTime := time.Until(entry.Timer.DueDate)
+				remainingTime := entry.Timer.DueDate.Sub(htw.clock.Now())
 				if remainingTime < 0 {
 					return 0, nil // Timer should fire now
 				}
@@ -238,7 +238,7 @@ func (htw *HierarchicalTimingWheel) removeFromIndex(timerID string) {
 // rescheduleTimer reschedules timer to higher level
 // Перепланирует таймер на более высокий уровень
 func (htw *HierarchicalTimingWheel) rescheduleTimer(entry *TimerEntry) {
-	delay := time.Until(entry.Timer.DueDate)
+	delay := entry.Timer.DueDate.Sub(htw.clock.Now())
 	level := htw.findLevelForDelay(delay)
 	if level != nil {
 		level.AddTimer(entry.Timer, entry.Handler, delay)
@@ -0,0 +1,94 @@
+/*
+This file is part of the AtomBPMN (R) project.
+Copyright (c) 2025 Matreska Market LLC (ООО «Matreska Market»).
+Authors: Matreska Team.
+
+This project is dual-licensed under AGPL-3.0 and AtomBPMN Commercial License.
+*/
+
+package timewheel
+
+import (
+	"time"
+
+	"atom-engine/src/core/models"
+)
+
+// Advance moves simulated clock forward and fires timers due within advanced period
+// Timers fire one by one in due date order with clock set to their due date, response of each timer
+// is passed to deliver before next one fires, so timers scheduled while handling it join same advance
+// Продвигает симулированные часы и запускает таймеры, срок которых наступил в этом периоде
+// Таймеры срабатывают по одному в порядке сроков с часами, выставленными на их срок, ответ каждого
+// передается в deliver до срабатывания следующего, поэтому таймеры, запланированные при его обработке,
+// попадают в то же продвижение
+func (htw *HierarchicalTimingWheel) Advance(
+	duration time.Duration,
+	deliver func(response string),
+) ([]*models.Timer, error) {
+	clock, ok := htw.clock.(*SimulatedClock)
+	if !ok {
+		return nil, ErrNotSimulated
+	}
+	if duration < 0 {
+		return nil, ErrInvalidTimerRequest("advance duration must not be negative")
+	}
+
+	htw.advanceMu.Lock()
+	defer htw.advanceMu.Unlock()
+
+	htw.mu.RLock()
+	running := htw.running
+	htw.mu.RUnlock()
+	if !running {
+		return nil, ErrWheelNotRunning
+	}
+
+	target := clock.Now().Add(duration)
+	fired := make([]*models.Timer, 0)
+	for {
+		entry := htw.takeDueTimer(target)
+		if entry == nil {
+			break
+		}
+		clock.moveTo(entry.Timer.DueDate)
+		htw.fireTimerWith(entry.Timer, entry.Handler, deliver)
+		fired = append(fired, entry.Timer)
+	}
+	clock.moveTo(target)
+
+	return fired, nil
+}
+
+// takeDueTimer removes and returns earliest timer due not later than given time, nil when there is none
+// Timers with same due date are taken in creation order
+// Удаляет и возвращает самый ранний таймер со сроком не позже заданного времени, nil если таких нет
+// Таймеры с одинаковым сроком берутся в порядке создания
+func (htw *HierarchicalTimingWheel) takeDueTimer(until time.Time) *TimerEntry {
+	htw.mu.Lock()
+	defer htw.mu.Unlock()
+
+	var next *TimerEntry
+	for _, level := range htw.levels {
+		for _, entry := range level.GetAllTimers() {
+			if entry.Timer.DueDate.After(until) {
+				continue
+			}
+			if next == nil || entry.Timer.DueDate.Before(next.Timer.DueDate) ||
+				(entry.Timer.DueDate.Equal(next.Timer.DueDate) && entry.Timer.CreatedAt.Before(next.Timer.CreatedAt)) {
+				next = entry
+			}
+		}
+	}
+	if next == nil {
+		return nil
+	}
+
+	if anchor, ok := next.Timer.Variables["_anchor"].(*TimerAnchor); ok {
+		_ = htw.levels[anchor.Level].RemoveTimer(anchor)
+	} else if location, exists := htw.timerIndex[next.Timer.ID]; exists {
+		_ = htw.levels[location.Level].RemoveTimerBySlotAndID(location.Slot, next.Timer.ID)
+	}
+	delete(htw.timerIndex, next.Timer.ID)
+
+	return next
+}
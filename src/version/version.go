@@ -25,15 +25,22 @@ var (
 	Platform  = runtime.GOOS + "/" + runtime.GOARCH
 )
 
+// FeatureSetVersion is version of supported BPMN elements and FEEL expression features.
+// Incremented whenever engine supports new element, attribute or expression syntax
+// Версия поддерживаемых элементов BPMN и возможностей FEEL выражений
+// Увеличивается, когда движок начинает поддерживать новый элемент, атрибут или синтаксис выражений
+const FeatureSetVersion = "1"
+
 // GetBuildInfo returns build information
 // Возвращает информацию о сборке
 func GetBuildInfo() map[string]string {
 	return map[string]string{
-		"version":    Version,
-		"git_commit": GitCommit,
-		"build_time": BuildTime,
-		"go_version": GoVersion,
-		"platform":   Platform,
+		"version":             Version,
+		"git_commit":          GitCommit,
+		"build_time":          BuildTime,
+		"go_version":          GoVersion,
+		"platform":            Platform,
+		"feature_set_version": FeatureSetVersion,
 	}
 }
 